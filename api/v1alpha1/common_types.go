@@ -27,6 +27,33 @@ type TopicState string
 // UserState defines the state of a KafkaUser
 type UserState string
 
+// UserAuthenticationType defines how a KafkaUser authenticates to the Kafka cluster
+type UserAuthenticationType string
+
+// ACLState defines the state of a KafkaACL
+type ACLState string
+
+// ACLResourceType is the type of the Kafka resource an ACL is bound to
+type ACLResourceType string
+
+// ACLOperation is the Kafka operation an ACL allows or denies
+type ACLOperation string
+
+// ACLPermissionType defines whether an ACL allows or denies the operation
+type ACLPermissionType string
+
+// TopicReassignmentState defines the state of a KafkaTopic replica reassignment
+type TopicReassignmentState string
+
+// TopicReassignmentStrategy defines how the replica reassignment of a KafkaTopic is executed
+type TopicReassignmentStrategy string
+
+// ReassignmentState defines the state of a KafkaReassignment
+type ReassignmentState string
+
+// FailoverState defines the state of a KafkaFailover
+type FailoverState string
+
 // ClusterReference states a reference to a cluster for topic/user
 // provisioning
 type ClusterReference struct {
@@ -36,6 +63,7 @@ type ClusterReference struct {
 
 // CruiseControlTaskOperation defines distinct operation types used while running Cruise Control on the Kafka cluster.
 // More details: https://github.com/linkedin/cruise-control/wiki/REST-APIs#post-requests
+// +kubebuilder:validation:Enum=stop_proposal_execution;add_broker;remove_broker;remove_disks;rebalance;topic_configuration;demote_broker;fix_offline_replicas;status
 type CruiseControlTaskOperation string
 
 const (
@@ -49,6 +77,12 @@ const (
 	OperationRemoveDisks CruiseControlTaskOperation = "remove_disks"
 	// OperationRebalance means a Cruise Control rebalance operation
 	OperationRebalance CruiseControlTaskOperation = "rebalance"
+	// OperationTopicConfiguration means a Cruise Control topic_configuration operation
+	OperationTopicConfiguration CruiseControlTaskOperation = "topic_configuration"
+	// OperationDemoteBroker means a Cruise Control demote_broker operation
+	OperationDemoteBroker CruiseControlTaskOperation = "demote_broker"
+	// OperationFixOfflineReplicas means a Cruise Control fix_offline_replicas operation
+	OperationFixOfflineReplicas CruiseControlTaskOperation = "fix_offline_replicas"
 	// OperationStatus means a Cruise Control status operation
 	OperationStatus CruiseControlTaskOperation = "status"
 	// KafkaAccessTypeRead states that a user wants consume access to a topic
@@ -63,8 +97,57 @@ const (
 	KafkaPatternTypeDefault  KafkaPatternType = "literal"
 	// TopicStateCreated describes the status of a KafkaTopic as created
 	TopicStateCreated TopicState = "created"
+	// TopicReassignmentStatePending means the reassignment is waiting for Cruise Control to execute it
+	TopicReassignmentStatePending TopicReassignmentState = "pending"
+	// TopicReassignmentStateInProgress means the replicas of the topic partitions are being moved
+	TopicReassignmentStateInProgress TopicReassignmentState = "inProgress"
+	// TopicReassignmentStateCompleted means the reassignment finished successfully
+	TopicReassignmentStateCompleted TopicReassignmentState = "completed"
+	// TopicReassignmentStateFailed means the reassignment could not be finished
+	TopicReassignmentStateFailed TopicReassignmentState = "failed"
+	// TopicReassignmentStrategyCruiseControl executes the reassignment with a Cruise Control topic_configuration operation
+	TopicReassignmentStrategyCruiseControl TopicReassignmentStrategy = "cruisecontrol"
+	// TopicReassignmentStrategyAdminClient executes the reassignment through the Kafka admin API
+	TopicReassignmentStrategyAdminClient TopicReassignmentStrategy = "adminclient"
+	// ReassignmentStatePending means the reassignment plan has not been submitted to the Kafka cluster yet
+	ReassignmentStatePending ReassignmentState = "pending"
+	// ReassignmentStateInProgress means the replicas of the partitions in the plan are being moved
+	ReassignmentStateInProgress ReassignmentState = "inProgress"
+	// ReassignmentStateCompleted means every partition in the plan reached its desired replicas
+	ReassignmentStateCompleted ReassignmentState = "completed"
+	// ReassignmentStateFailed means the reassignment plan could not be executed
+	ReassignmentStateFailed ReassignmentState = "failed"
+	// FailoverStateStandby means the primary cluster is serving the clients and the secondary one is standing by
+	FailoverStateStandby FailoverState = "standby"
+	// FailoverStatePromoting means the primary cluster is marked failed and the secondary cluster is being promoted
+	FailoverStatePromoting FailoverState = "promoting"
+	// FailoverStatePromoted means the secondary cluster has been promoted and is serving the clients
+	FailoverStatePromoted FailoverState = "promoted"
+	// FailoverStateFailed means the clusters of the failover can not be paired
+	FailoverStateFailed FailoverState = "failed"
+	// ReassignmentLogDirAny leaves the log directory of a reassigned replica to the broker
+	ReassignmentLogDirAny string = "any"
+	// TopicConditionReplicationFactorReconciled is the KafkaTopic condition reporting whether the replication factor
+	// of the topic matches the desired one
+	TopicConditionReplicationFactorReconciled = "ReplicationFactorReconciled"
 	// UserStateCreated describes the status of a KafkaUser as created
 	UserStateCreated UserState = "created"
+	// UserAuthenticationTypeTLS means the user authenticates with a TLS client certificate
+	UserAuthenticationTypeTLS UserAuthenticationType = "tls"
+	// UserAuthenticationTypeSCRAMSHA256 means the user authenticates with SASL SCRAM-SHA-256
+	UserAuthenticationTypeSCRAMSHA256 UserAuthenticationType = "scram-sha-256"
+	// UserAuthenticationTypeSCRAMSHA512 means the user authenticates with SASL SCRAM-SHA-512
+	UserAuthenticationTypeSCRAMSHA512 UserAuthenticationType = "scram-sha-512"
+	// UserAuthenticationTypeOAuth means the user authenticates with an OAuth/OIDC bearer token issued by an external identity provider
+	UserAuthenticationTypeOAuth UserAuthenticationType = "oauth"
+	// ACLStateCreated describes the status of a KafkaACL as created
+	ACLStateCreated ACLState = "created"
+	// ACLPermissionTypeAllow allows the operation on the resource
+	ACLPermissionTypeAllow ACLPermissionType = "allow"
+	// ACLPermissionTypeDeny denies the operation on the resource
+	ACLPermissionTypeDeny ACLPermissionType = "deny"
+	// ACLHostAny matches every host
+	ACLHostAny string = "*"
 	// TLSJKSKeyStore is where a JKS keystore is stored in a user secret when requested
 	TLSJKSKeyStore string = "keystore.jks"
 	// TLSJKSTrustStore is where a JKS truststore is stored in a user secret when requested
@@ -87,4 +170,23 @@ const (
 	PeerPrivateKeyKey string = "peerKey"
 	// PasswordKey stores the JKS password
 	PasswordKey string = "password"
+	// SCRAMUsernameKey stores the SCRAM username in a user secret
+	SCRAMUsernameKey string = "username"
+	// SCRAMPasswordKey stores the SCRAM password in a user secret
+	SCRAMPasswordKey string = "password"
+	// SASLMechanismKey stores the SASL mechanism to be used by the clients in a user secret
+	SASLMechanismKey string = "sasl.mechanism"
+	// SASLJAASConfigKey stores the client JAAS configuration in a user secret
+	SASLJAASConfigKey string = "sasl.jaas.config"
+	// TeamLabelKey is the label of the KafkaTopic, KafkaUser and KafkaACL resources holding the team owning them
+	TeamLabelKey string = "kafka.banzaicloud.io/team"
+	// NamespaceTeamAnnotationKey is the annotation of a namespace holding the team owning it. The ownership of the
+	// KafkaTopic, KafkaUser and KafkaACL resources is only enforced in the annotated namespaces.
+	NamespaceTeamAnnotationKey string = "kafka.banzaicloud.io/team"
+	// NamespaceTopicPrefixesAnnotationKey is the annotation of a namespace holding the comma separated list of the
+	// topic name prefixes owned by the team of the namespace
+	NamespaceTopicPrefixesAnnotationKey string = "kafka.banzaicloud.io/topic-prefixes"
+	// ImportedLabelKey is the label of the KafkaTopics and KafkaUsers generated by the discovery of the topics and the
+	// users existing on the Kafka cluster
+	ImportedLabelKey string = "kafka.banzaicloud.io/imported"
 )
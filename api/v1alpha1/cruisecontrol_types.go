@@ -0,0 +1,67 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+// CruiseControlSpec defines the desired state of CruiseControl
+// +k8s:openapi-gen=true
+type CruiseControlSpec struct {
+	// Template is the deployment of Cruise Control, it is deployed for every KafkaCluster referencing the CruiseControl
+	// in its cruiseControlConfig.cruiseControlRef since a Cruise Control instance monitors a single Kafka cluster.
+	// The fields configuring how the operator uses Cruise Control (cruiseControlTaskSpec, cruiseControlOperationSpec,
+	// cruiseControlEndpoint, topicConfig, selfHealing and cruiseControlRef) are taken from the KafkaClusters instead.
+	Template v1beta1.CruiseControlConfig `json:"template"`
+}
+
+// CruiseControlStatus defines the observed state of CruiseControl
+// +k8s:openapi-gen=true
+type CruiseControlStatus struct {
+	// Clusters are the names of the KafkaClusters Cruise Control is deployed for
+	Clusters []string `json:"clusters,omitempty"`
+}
+
+// CruiseControl is the Schema for the Cruise Control deployments API
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Image",type="string",JSONPath=".spec.template.image"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type CruiseControl struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CruiseControlSpec   `json:"spec,omitempty"`
+	Status CruiseControlStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CruiseControlList contains a list of CruiseControl
+type CruiseControlList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CruiseControl `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CruiseControl{}, &CruiseControlList{})
+}
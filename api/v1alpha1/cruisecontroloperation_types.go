@@ -35,6 +35,8 @@ const (
 	True       = "true"
 )
 
+// +kubebuilder:webhook:verbs=create;update,path=/validate-kafka-banzaicloud-io-v1alpha1-cruisecontroloperation,mutating=false,failurePolicy=fail,groups=kafka.banzaicloud.io,resources=cruisecontroloperations,versions=v1alpha1,name=cruisecontroloperations.kafka.banzaicloud.io,sideEffects=None,admissionReviewVersions=v1
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 
@@ -71,8 +73,41 @@ type CruiseControlOperationSpec struct {
 	// Value can be only zero and positive integers
 	// +kubebuilder:validation:Minimum=0
 	TTLSecondsAfterFinished *int `json:"ttlSecondsAfterFinished,omitempty"`
+	// Rebalance defines the scope and the pace of the rebalance operation.
+	// When set, its fields take precedence over the corresponding parameters of the current task.
+	// +optional
+	Rebalance *RebalanceOptions `json:"rebalance,omitempty"`
 }
 
+// RebalanceOptions defines the Cruise Control rebalance request parameters which are validated by the webhook.
+type RebalanceOptions struct {
+	// ExcludedTopics is a regular expression matching the topics whose replicas are not moved by the rebalance.
+	// +optional
+	ExcludedTopics string `json:"excludedTopics,omitempty"`
+	// ReplicaMovementStrategies defines the order of the replica movements, the strategies are applied in the given order.
+	// +optional
+	ReplicaMovementStrategies []ReplicaMovementStrategy `json:"replicaMovementStrategies,omitempty"`
+	// ConcurrentPartitionMovementsPerBroker is the upper bound of the ongoing replica movements into or out of a broker.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ConcurrentPartitionMovementsPerBroker *int32 `json:"concurrentPartitionMovementsPerBroker,omitempty"`
+	// ConcurrentLeaderMovements is the upper bound of the ongoing leadership movements.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ConcurrentLeaderMovements *int32 `json:"concurrentLeaderMovements,omitempty"`
+}
+
+// ReplicaMovementStrategy is a Cruise Control replica movement strategy.
+// +kubebuilder:validation:Enum=PrioritizeLargeReplicaMovementStrategy;PrioritizeSmallReplicaMovementStrategy;PostponeUrpReplicaMovementStrategy;PrioritizeMinIsrWithOfflineReplicasStrategy
+type ReplicaMovementStrategy string
+
+const (
+	ReplicaMovementStrategyPrioritizeLarge             ReplicaMovementStrategy = "PrioritizeLargeReplicaMovementStrategy"
+	ReplicaMovementStrategyPrioritizeSmall             ReplicaMovementStrategy = "PrioritizeSmallReplicaMovementStrategy"
+	ReplicaMovementStrategyPostponeURP                 ReplicaMovementStrategy = "PostponeUrpReplicaMovementStrategy"
+	ReplicaMovementStrategyPrioritizeMinIsrWithOffline ReplicaMovementStrategy = "PrioritizeMinIsrWithOfflineReplicasStrategy"
+)
+
 // ErrorPolicyType defines methods of handling Cruise Control user task errors.
 type ErrorPolicyType string
 
@@ -82,6 +117,15 @@ type CruiseControlOperationStatus struct {
 	ErrorPolicy ErrorPolicyType     `json:"errorPolicy"`
 	RetryCount  int                 `json:"retryCount"`
 	FailedTasks []CruiseControlTask `json:"failedTasks,omitempty"`
+	// PreemptedBy is the name of the CruiseControlOperation of higher priority the execution of the current task was
+	// stopped for. The current task is executed again from the start once no task of higher priority is waiting.
+	// +optional
+	PreemptedBy string `json:"preemptedBy,omitempty"`
+	// Conditions represent the latest available observations of the operation
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // CruiseControlTask defines the observed state of the Cruise Control user task.
@@ -98,11 +142,23 @@ type CruiseControlTask struct {
 	HTTPResponseCode *int   `json:"httpResponseCode,omitempty"`
 	// Summary of the Cruise Control user task execution proposal.
 	Summary map[string]string `json:"summary,omitempty"`
+	// GoalSummaries is the outcome of the optimization of each goal of the proposal.
+	GoalSummaries []CruiseControlGoalSummary `json:"goalSummaries,omitempty"`
 	// State is the current state of the Cruise Control user task.
 	State        v1beta1.CruiseControlUserTaskState `json:"state,omitempty"`
 	ErrorMessage string                             `json:"errorMessage,omitempty"`
 }
 
+// CruiseControlGoalSummary is the outcome of the optimization of a Cruise Control goal.
+type CruiseControlGoalSummary struct {
+	// Goal is the name of the Cruise Control goal.
+	Goal string `json:"goal"`
+	// Status is the optimization status of the goal, e.g. NO-ACTION, FIXED or VIOLATED.
+	Status string `json:"status"`
+	// OptimizationTimeMs is the time the optimization of the goal took.
+	OptimizationTimeMs int64 `json:"optimizationTimeMs,omitempty"`
+}
+
 func init() {
 	SchemeBuilder.Register(&CruiseControlOperation{}, &CruiseControlOperationList{})
 }
@@ -121,6 +177,7 @@ func (task *CruiseControlTask) SetDefaults() {
 	task.HTTPResponseCode = nil
 	task.ID = ""
 	task.Summary = nil
+	task.GoalSummaries = nil
 }
 
 func (o *CruiseControlOperation) CurrentTask() *CruiseControlTask {
@@ -176,6 +233,12 @@ func (o *CruiseControlOperation) IsWaitingForFirstExecution() bool {
 	return false
 }
 
+// IsPreempted returns true if the execution of the current task was stopped for an operation of higher priority and
+// the task is waiting to be executed again
+func (o *CruiseControlOperation) IsPreempted() bool {
+	return o.Status.PreemptedBy != "" && o.CurrentTaskID() == "" && o.CurrentTaskState() == ""
+}
+
 func (o *CruiseControlOperation) IsInProgress() bool {
 	if o.CurrentTaskID() != "" && (o.CurrentTaskState() == v1beta1.CruiseControlTaskActive || o.CurrentTaskState() == v1beta1.CruiseControlTaskInExecution) {
 		return true
@@ -228,5 +291,8 @@ func (o *CruiseControlOperation) IsCurrentTaskOperationValid() bool {
 		o.CurrentTaskOperation() == OperationRebalance ||
 		o.CurrentTaskOperation() == OperationRemoveBroker ||
 		o.CurrentTaskOperation() == OperationStopExecution ||
-		o.CurrentTaskOperation() == OperationRemoveDisks
+		o.CurrentTaskOperation() == OperationRemoveDisks ||
+		o.CurrentTaskOperation() == OperationTopicConfiguration ||
+		o.CurrentTaskOperation() == OperationDemoteBroker ||
+		o.CurrentTaskOperation() == OperationFixOfflineReplicas
 }
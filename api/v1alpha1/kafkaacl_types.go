@@ -0,0 +1,119 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KafkaACLSpec defines the desired ACL bindings of a Kafka principal
+// +k8s:openapi-gen=true
+type KafkaACLSpec struct {
+	ClusterRef ClusterReference `json:"clusterRef"`
+	// Principal is the Kafka principal the ACLs are bound to in the `<type>:<name>` form, e.g. `User:CN=alice`
+	// +kubebuilder:validation:Pattern=`^[A-Za-z]+:.+$`
+	Principal string `json:"principal"`
+	// ACLs is the desired set of ACL bindings of the principal
+	// +kubebuilder:validation:MinItems=1
+	ACLs []ACLRule `json:"acls"`
+	// PruneUnmanaged removes every ACL of the principal which is not listed in acls, including the ones created
+	// outside of this resource. ACLs removed from acls are deleted from the cluster regardless of this setting.
+	// +optional
+	PruneUnmanaged bool `json:"pruneUnmanaged,omitempty"`
+}
+
+// ACLRule allows or denies an operation on a resource pattern from a host
+type ACLRule struct {
+	// +kubebuilder:validation:Enum={"topic","group","cluster","transactionalId","delegationToken"}
+	ResourceType ACLResourceType `json:"resourceType"`
+	// ResourceName is the name of the resource, `*` matches every resource of the type.
+	// The cluster resource is named `kafka-cluster`.
+	// +kubebuilder:validation:MinLength=1
+	ResourceName string `json:"resourceName"`
+	// PatternType defines how the resource name is matched, defaults to literal
+	// +kubebuilder:validation:Enum={"literal","prefixed"}
+	// +optional
+	PatternType KafkaPatternType `json:"patternType,omitempty"`
+	// +kubebuilder:validation:Enum={"all","read","write","create","delete","alter","describe","clusterAction","describeConfigs","alterConfigs","idempotentWrite"}
+	Operation ACLOperation `json:"operation"`
+	// Host the operation is allowed or denied from, defaults to any host
+	// +optional
+	Host string `json:"host,omitempty"`
+	// Permission defines whether the operation is allowed or denied, defaults to allow
+	// +kubebuilder:validation:Enum={"allow","deny"}
+	// +optional
+	Permission ACLPermissionType `json:"permission,omitempty"`
+}
+
+// KafkaACLStatus defines the observed state of KafkaACL
+// +k8s:openapi-gen=true
+type KafkaACLStatus struct {
+	State ACLState `json:"state"`
+	// ACLs are the ACL bindings applied on the Kafka cluster
+	ACLs []string `json:"acls,omitempty"`
+}
+
+// KafkaACL is the Schema for the kafka ACLs API
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Principal",type="string",JSONPath=".spec.principal"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+type KafkaACL struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KafkaACLSpec   `json:"spec,omitempty"`
+	Status KafkaACLStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// KafkaACLList contains a list of KafkaACL
+type KafkaACLList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KafkaACL `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KafkaACL{}, &KafkaACLList{})
+}
+
+// GetPatternType returns the pattern type of the resource name, literal if not specified otherwise
+func (r *ACLRule) GetPatternType() KafkaPatternType {
+	if r.PatternType == "" {
+		return KafkaPatternTypeDefault
+	}
+	return r.PatternType
+}
+
+// GetHost returns the host the rule applies to, any host if not specified otherwise
+func (r *ACLRule) GetHost() string {
+	if r.Host == "" {
+		return ACLHostAny
+	}
+	return r.Host
+}
+
+// GetPermission returns whether the rule allows or denies the operation, allow if not specified otherwise
+func (r *ACLRule) GetPermission() ACLPermissionType {
+	if r.Permission == "" {
+		return ACLPermissionTypeAllow
+	}
+	return r.Permission
+}
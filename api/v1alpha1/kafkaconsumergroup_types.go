@@ -0,0 +1,139 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultConsumerGroupRefreshInterval is the interval the status of a KafkaConsumerGroup is refreshed at by default
+const DefaultConsumerGroupRefreshInterval = 30 * time.Second
+
+// KafkaConsumerGroupSpec selects the consumer group reported by the KafkaConsumerGroup. The resource is read-only, the
+// consumer group itself is never changed by the operator.
+// +k8s:openapi-gen=true
+type KafkaConsumerGroupSpec struct {
+	ClusterRef ClusterReference `json:"clusterRef"`
+	// GroupID is the id of the consumer group, the name of the KafkaConsumerGroup is used when not set
+	// +optional
+	GroupID string `json:"groupID,omitempty"`
+	// RefreshInterval is the interval the status is refreshed at, 30s when not set
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// ConsumerGroupMemberStatus describes a member of a consumer group
+type ConsumerGroupMemberStatus struct {
+	MemberID string `json:"memberID"`
+	ClientID string `json:"clientID,omitempty"`
+	Host     string `json:"host,omitempty"`
+	// Assignments are the partitions assigned to the member by topic
+	Assignments []ConsumerGroupTopicPartitions `json:"assignments,omitempty"`
+}
+
+// ConsumerGroupTopicPartitions lists partitions of a topic
+type ConsumerGroupTopicPartitions struct {
+	Topic      string  `json:"topic"`
+	Partitions []int32 `json:"partitions"`
+}
+
+// ConsumerGroupTopicLag is the lag of a consumer group on a topic
+type ConsumerGroupTopicLag struct {
+	Topic string `json:"topic"`
+	// Lag is the sum of the lag of the partitions of the topic
+	Lag        int64                       `json:"lag"`
+	Partitions []ConsumerGroupPartitionLag `json:"partitions,omitempty"`
+}
+
+// ConsumerGroupPartitionLag is the lag of a consumer group on a partition, the number of messages between the offset
+// committed by the group and the log end offset of the partition
+type ConsumerGroupPartitionLag struct {
+	Partition       int32 `json:"partition"`
+	CommittedOffset int64 `json:"committedOffset"`
+	LogEndOffset    int64 `json:"logEndOffset"`
+	Lag             int64 `json:"lag"`
+}
+
+// KafkaConsumerGroupStatus defines the observed state of KafkaConsumerGroup
+// +k8s:openapi-gen=true
+type KafkaConsumerGroupStatus struct {
+	// State is the state of the group reported by Kafka, e.g. Stable, PreparingRebalance, Empty or Dead
+	State string `json:"state,omitempty"`
+	// MemberCount is the number of the members of the group
+	MemberCount int32                       `json:"memberCount,omitempty"`
+	Members     []ConsumerGroupMemberStatus `json:"members,omitempty"`
+	// Lag is the lag of the group on the topics it committed offsets for
+	Lag []ConsumerGroupTopicLag `json:"lag,omitempty"`
+	// TotalLag is the sum of the lag of the group on all the topics
+	TotalLag int64 `json:"totalLag,omitempty"`
+	// LastUpdateTime is the time the status was last refreshed
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+	// Conditions represent the latest available observations of the consumer group
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// KafkaConsumerGroup is the Schema for the read-only consumer group API reporting the members, the partition
+// assignments and the lag of a consumer group
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Group",type="string",JSONPath=".spec.groupID"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Members",type="integer",JSONPath=".status.memberCount"
+// +kubebuilder:printcolumn:name="Lag",type="integer",JSONPath=".status.totalLag"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type KafkaConsumerGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KafkaConsumerGroupSpec   `json:"spec,omitempty"`
+	Status KafkaConsumerGroupStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// KafkaConsumerGroupList contains a list of KafkaConsumerGroup
+type KafkaConsumerGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KafkaConsumerGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KafkaConsumerGroup{}, &KafkaConsumerGroupList{})
+}
+
+// GetGroupID returns the id of the consumer group, the name of the KafkaConsumerGroup when not set
+func (g *KafkaConsumerGroup) GetGroupID() string {
+	if g.Spec.GroupID != "" {
+		return g.Spec.GroupID
+	}
+	return g.Name
+}
+
+// GetRefreshInterval returns the interval the status is refreshed at
+func (s *KafkaConsumerGroupSpec) GetRefreshInterval() time.Duration {
+	if s.RefreshInterval != nil && s.RefreshInterval.Duration > 0 {
+		return s.RefreshInterval.Duration
+	}
+	return DefaultConsumerGroupRefreshInterval
+}
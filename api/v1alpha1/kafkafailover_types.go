@@ -0,0 +1,95 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KafkaFailoverSpec pairs a primary KafkaCluster with a secondary one its topics are replicated to by MirrorMaker 2.
+// The clients bootstrap through the service of the failover pointing at the active cluster, the secondary cluster is
+// promoted once the primary one is marked failed. The failover only covers the clients in the Kubernetes cluster: the
+// bootstrap service is an ExternalName service resolved by the cluster DNS, the clients outside of the Kubernetes
+// cluster keep bootstrapping through the external listeners of the primary cluster and must be pointed at the
+// external listeners of the secondary cluster by other means, e.g. their own DNS record.
+// +k8s:openapi-gen=true
+type KafkaFailoverSpec struct {
+	// Primary is the cluster serving the clients until it is marked failed
+	Primary ClusterReference `json:"primary"`
+	// Secondary is the cluster the topics of the primary cluster are replicated to under their own name, MirrorMaker 2
+	// must replicate them with the org.apache.kafka.connect.mirror.IdentityReplicationPolicy
+	Secondary ClusterReference `json:"secondary"`
+	// PrimaryFailed marks the primary cluster failed and starts the promotion of the secondary cluster. A promotion is
+	// never reverted, failing back is done by a new KafkaFailover with the clusters swapped.
+	// +optional
+	PrimaryFailed bool `json:"primaryFailed,omitempty"`
+	// TopicConfig is merged into the config of the KafkaTopics re-pointed to the secondary cluster on the promotion,
+	// e.g. to restore the settings relaxed for the replication
+	// +optional
+	TopicConfig map[string]string `json:"topicConfig,omitempty"`
+}
+
+// KafkaFailoverStatus defines the observed state of KafkaFailover
+// +k8s:openapi-gen=true
+type KafkaFailoverStatus struct {
+	State FailoverState `json:"state,omitempty"`
+	// Message describes why the failover failed or the promotion is waiting
+	Message string `json:"message,omitempty"`
+	// ActiveCluster is the cluster the bootstrap service points at
+	ActiveCluster *ClusterReference `json:"activeCluster,omitempty"`
+	// BootstrapService is the name of the service in the namespace of the failover the clients in the Kubernetes
+	// cluster bootstrap through
+	BootstrapService string `json:"bootstrapService,omitempty"`
+	// PromotedTopics and PromotedUsers are the KafkaTopics and KafkaUsers re-pointed to the secondary cluster in the
+	// namespace/name form
+	PromotedTopics []string `json:"promotedTopics,omitempty"`
+	PromotedUsers  []string `json:"promotedUsers,omitempty"`
+	// MissingTopics are the topics of the promoted KafkaTopics which had not been replicated to the secondary cluster,
+	// they are created empty
+	MissingTopics []string     `json:"missingTopics,omitempty"`
+	PromotedAt    *metav1.Time `json:"promotedAt,omitempty"`
+}
+
+// KafkaFailover is the Schema for the kafka cluster failovers API
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Primary",type="string",JSONPath=".spec.primary.name"
+// +kubebuilder:printcolumn:name="Secondary",type="string",JSONPath=".spec.secondary.name"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Active",type="string",JSONPath=".status.activeCluster.name"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type KafkaFailover struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KafkaFailoverSpec   `json:"spec,omitempty"`
+	Status KafkaFailoverStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// KafkaFailoverList contains a list of KafkaFailover
+type KafkaFailoverList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KafkaFailover `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KafkaFailover{}, &KafkaFailoverList{})
+}
@@ -0,0 +1,120 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KafkaReassignmentSpec defines an ad-hoc partition reassignment plan. The plan is executed once, changes made to
+// the spec after the reassignment has been submitted are ignored.
+// +k8s:openapi-gen=true
+type KafkaReassignmentSpec struct {
+	ClusterRef ClusterReference `json:"clusterRef"`
+	// Partitions lists the desired replica assignment of the partitions to move
+	// +optional
+	Partitions []PartitionReassignment `json:"partitions,omitempty"`
+	// ReassignmentJSON is a reassignment plan in the JSON format of the kafka-reassign-partitions tool,
+	// its partitions are executed together with the ones listed in partitions
+	// +optional
+	ReassignmentJSON string `json:"reassignmentJSON,omitempty"`
+	// ThrottleBytesPerSecond limits the replication traffic of the moved replicas on the involved brokers while the
+	// reassignment is in progress, the throttle is removed once the reassignment finished. No throttle is applied if not set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ThrottleBytesPerSecond *int64 `json:"throttleBytesPerSecond,omitempty"`
+}
+
+// PartitionReassignment defines the desired replicas of a topic partition
+type PartitionReassignment struct {
+	// +kubebuilder:validation:MinLength=1
+	Topic string `json:"topic"`
+	// +kubebuilder:validation:Minimum=0
+	Partition int32 `json:"partition"`
+	// Replicas are the ids of the brokers hosting the partition, the first one is the preferred leader
+	// +kubebuilder:validation:MinItems=1
+	Replicas []int32 `json:"replicas"`
+	// LogDirs are the log directories of the replicas in the order of replicas. Moving replicas between the log
+	// directories of a broker is not supported yet, every entry must be `any` which leaves the placement to the broker.
+	// +kubebuilder:validation:items:Enum=any
+	// +optional
+	LogDirs []string `json:"logDirs,omitempty"`
+}
+
+// ReassignmentPartitionStatus is the progress of a partition of a reassignment plan
+type ReassignmentPartitionStatus struct {
+	Topic     string `json:"topic"`
+	Partition int32  `json:"partition"`
+	// Replicas are the desired replicas of the partition
+	Replicas []int32 `json:"replicas"`
+	// AddingReplicas are the replicas still being added to the partition
+	AddingReplicas []int32 `json:"addingReplicas,omitempty"`
+	// RemovingReplicas are the replicas still being removed from the partition
+	RemovingReplicas []int32 `json:"removingReplicas,omitempty"`
+	// CompletedAt is the time the partition reached its desired replicas
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+}
+
+// KafkaReassignmentStatus defines the observed state of KafkaReassignment
+// +k8s:openapi-gen=true
+type KafkaReassignmentStatus struct {
+	State ReassignmentState `json:"state"`
+	// Message describes why the reassignment is waiting or failed
+	Message string `json:"message,omitempty"`
+	// Progress is the number of completed partitions out of the partitions in the plan
+	Progress   string                        `json:"progress,omitempty"`
+	Partitions []ReassignmentPartitionStatus `json:"partitions,omitempty"`
+	// ThrottledTopics are the topics the replication throttle has been applied on
+	ThrottledTopics []string `json:"throttledTopics,omitempty"`
+	// ThrottledBrokers are the brokers the replication throttle rate has been applied on
+	ThrottledBrokers []int32      `json:"throttledBrokers,omitempty"`
+	StartedAt        *metav1.Time `json:"startedAt,omitempty"`
+	CompletedAt      *metav1.Time `json:"completedAt,omitempty"`
+}
+
+// KafkaReassignment is the Schema for the kafka partition reassignments API
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Progress",type="string",JSONPath=".status.progress"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type KafkaReassignment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KafkaReassignmentSpec   `json:"spec,omitempty"`
+	Status KafkaReassignmentStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// KafkaReassignmentList contains a list of KafkaReassignment
+type KafkaReassignmentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KafkaReassignment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KafkaReassignment{}, &KafkaReassignmentList{})
+}
+
+// IsFinished returns true if the reassignment completed or failed
+func (s *KafkaReassignmentStatus) IsFinished() bool {
+	return s.State == ReassignmentStateCompleted || s.State == ReassignmentStateFailed
+}
@@ -15,6 +15,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -35,6 +36,17 @@ type KafkaTopicSpec struct {
 	ReplicationFactor int32             `json:"replicationFactor"`
 	Config            map[string]string `json:"config,omitempty"`
 	ClusterRef        ClusterReference  `json:"clusterRef"`
+	// ReassignmentStrategy defines how the replication factor of the existing topic is changed.
+	// With "cruisecontrol" a Cruise Control topic_configuration operation computes and executes the reassignment,
+	// with "adminclient" the operator computes the new replica assignment and applies it through the Kafka admin API.
+	// +kubebuilder:validation:Enum=cruisecontrol;adminclient
+	// +kubebuilder:default=cruisecontrol
+	// +optional
+	ReassignmentStrategy TopicReassignmentStrategy `json:"reassignmentStrategy,omitempty"`
+	// DeletionProtection leaves the topic on the Kafka cluster when the KafkaTopic is deleted, the topic is orphaned
+	// and an event is recorded instead. Defaults to the topicDeletionProtection of the KafkaCluster.
+	// +optional
+	DeletionProtection *bool `json:"deletionProtection,omitempty"`
 }
 
 // KafkaTopicStatus defines the observed state of KafkaTopic
@@ -45,6 +57,85 @@ type KafkaTopicStatus struct {
 	// Manager of the Kafka topic can be changed by adding the "managedBy: <manager>" annotation to the KafkaTopic CR.
 	ManagedBy string     `json:"managedBy"`
 	State     TopicState `json:"state"`
+	// Reassignment reports the progress of the replica reassignment of the topic partitions
+	// +optional
+	Reassignment *TopicReassignmentStatus `json:"reassignment,omitempty"`
+	// Adoption describes the topic as it existed on the Kafka cluster when it was adopted by the KafkaTopic
+	// +optional
+	Adoption *TopicAdoptionStatus `json:"adoption,omitempty"`
+	// Conditions represent the latest available observations of the topic
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// TopicAdoptionStatus describes the live state of an existing topic imported when the KafkaTopic adopted it
+type TopicAdoptionStatus struct {
+	// AdoptedAt is the time the topic was adopted
+	AdoptedAt metav1.Time `json:"adoptedAt"`
+	// Partitions is the partition count of the topic when it was adopted
+	Partitions int32 `json:"partitions"`
+	// ReplicationFactor is the replication factor of the topic when it was adopted
+	ReplicationFactor int32 `json:"replicationFactor"`
+	// Config holds the configuration overrides of the topic when it was adopted
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+}
+
+// TopicReassignmentStatus describes the replica reassignment driven by a change of the KafkaTopic spec
+type TopicReassignmentStatus struct {
+	// State is the state of the reassignment
+	State TopicReassignmentState `json:"state"`
+	// Strategy is the strategy used to execute the reassignment
+	Strategy TopicReassignmentStrategy `json:"strategy,omitempty"`
+	// TargetReplicationFactor is the replication factor the topic is reassigned to
+	TargetReplicationFactor int32 `json:"targetReplicationFactor"`
+	// CruiseControlOperationReference refers to the CruiseControlOperation executing the reassignment
+	CruiseControlOperationReference *corev1.LocalObjectReference `json:"cruiseControlOperationReference,omitempty"`
+	// StartedAt is the time the reassignment was requested
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+	// CompletedAt is the time the reassignment finished
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+	// Partitions holds the progress of the partitions that have been reassigned
+	Partitions []PartitionReassignmentStatus `json:"partitions,omitempty"`
+	// ErrorMessage holds the last error of the reassignment
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+// PartitionReassignmentStatus describes the reassignment progress of a single topic partition
+type PartitionReassignmentStatus struct {
+	Partition int32 `json:"partition"`
+	// Replicas is the current replica set of the partition
+	Replicas []int32 `json:"replicas,omitempty"`
+	// AddingReplicas are the replicas which are being added to the partition
+	AddingReplicas []int32 `json:"addingReplicas,omitempty"`
+	// RemovingReplicas are the replicas which are being removed from the partition
+	RemovingReplicas []int32 `json:"removingReplicas,omitempty"`
+	// CompletedAt is the time the reassignment of the partition finished
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+}
+
+// GetReassignmentStrategy returns the strategy used to change the replication factor of the topic
+func (s *KafkaTopicSpec) GetReassignmentStrategy() TopicReassignmentStrategy {
+	if s.ReassignmentStrategy == "" {
+		return TopicReassignmentStrategyCruiseControl
+	}
+	return s.ReassignmentStrategy
+}
+
+// IsDeletionProtected returns true if the topic must be left on the Kafka cluster when the KafkaTopic is deleted,
+// the default of the cluster applies when not set on the topic
+func (s *KafkaTopicSpec) IsDeletionProtected(clusterDefault bool) bool {
+	if s.DeletionProtection != nil {
+		return *s.DeletionProtection
+	}
+	return clusterDefault
+}
+
+// IsInProgress returns true when the reassignment is requested but not yet finished
+func (s *TopicReassignmentStatus) IsInProgress() bool {
+	return s != nil && (s.State == TopicReassignmentStatePending || s.State == TopicReassignmentStateInProgress)
 }
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-kafka-banzaicloud-io-v1alpha1-kafkatopic,mutating=false,failurePolicy=fail,groups=kafka.banzaicloud.io,resources=kafkatopics,versions=v1alpha1,name=kafkatopics.kafka.banzaicloud.io,sideEffects=None,admissionReviewVersions=v1
@@ -21,6 +21,7 @@ import (
 	"github.com/banzaicloud/koperator/api/util"
 
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -34,7 +35,7 @@ const (
 // KafkaUserSpec defines the desired state of KafkaUser
 // +k8s:openapi-gen=true
 type KafkaUserSpec struct {
-	// secretName is used as the name of the K8S secret that contains the certificate of the KafkaUser. SecretName should be unique inside the namespace where KafkaUser is located.
+	// secretName is used as the name of the K8S secret that contains the certificate or the SCRAM credentials of the KafkaUser. SecretName should be unique inside the namespace where KafkaUser is located.
 	SecretName string           `json:"secretName"`
 	ClusterRef ClusterReference `json:"clusterRef"`
 	// Annotations defines the annotations placed on the certificate or certificate signing request object
@@ -50,11 +51,31 @@ type KafkaUserSpec struct {
 	// +optional
 	// +kubebuilder:validation:Minimum=3600
 	ExpirationSeconds *int32 `json:"expirationSeconds,omitempty"`
+	// Authentication defines how the user authenticates to the Kafka cluster.
+	// When not specified the user authenticates with a TLS client certificate.
+	// +optional
+	Authentication *UserAuthentication `json:"authentication,omitempty"`
+}
+
+// UserAuthentication defines the authentication mechanism of a KafkaUser
+type UserAuthentication struct {
+	// +kubebuilder:validation:Enum={"tls","scram-sha-256","scram-sha-512","oauth"}
+	Type UserAuthenticationType `json:"type"`
+	// Principal is the name of the principal the brokers resolve for the OAuth bearer token of the user
+	// (e.g. the value of the sub claim). When not set the name of the KafkaUser is used.
+	// Only used with the oauth authentication type.
+	// +optional
+	Principal string `json:"principal,omitempty"`
+	// PasswordSecretRef references the key of a secret in the namespace of the KafkaUser which holds the SCRAM password.
+	// If not set, a random password is generated and stored in the secret referenced by spec.secretName.
+	// Only used with the SCRAM authentication types.
+	// +optional
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
 }
 
 type PKIBackendSpec struct {
 	IssuerRef *cmmeta.ObjectReference `json:"issuerRef,omitempty"`
-	// +kubebuilder:validation:Enum={"cert-manager","k8s-csr"}
+	// +kubebuilder:validation:Enum={"cert-manager","k8s-csr","vault"}
 	PKIBackend string `json:"pkiBackend"`
 	// SignerName indicates requested signer, and is a qualified name.
 	SignerName string `json:"signerName,omitempty"`
@@ -74,6 +95,22 @@ type UserTopicGrant struct {
 type KafkaUserStatus struct {
 	State UserState `json:"state"`
 	ACLs  []string  `json:"acls,omitempty"`
+	// SCRAMCredential holds information about the SCRAM credential created for the user on the Kafka cluster
+	SCRAMCredential *UserSCRAMCredentialStatus `json:"scramCredential,omitempty"`
+	// Conditions represent the latest available observations of the user
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// UserSCRAMCredentialStatus describes the SCRAM credential of a KafkaUser
+type UserSCRAMCredentialStatus struct {
+	// Mechanism is the SCRAM mechanism the credential was created for
+	Mechanism UserAuthenticationType `json:"mechanism"`
+	// PasswordSecretVersion is the resource version of the secret the password was taken from
+	// when the credential was last applied
+	PasswordSecretVersion string `json:"passwordSecretVersion,omitempty"`
 }
 
 // KafkaUser is the Schema for the kafka users API
@@ -133,3 +170,48 @@ func (spec *KafkaUserSpec) GetExpirationSeconds() int32 {
 	}
 	return *spec.ExpirationSeconds
 }
+
+// GetAuthenticationType returns the authentication type of the user, TLS if not specified otherwise
+func (spec *KafkaUserSpec) GetAuthenticationType() UserAuthenticationType {
+	if spec.Authentication == nil || spec.Authentication.Type == "" {
+		return UserAuthenticationTypeTLS
+	}
+	return spec.Authentication.Type
+}
+
+// IsSCRAMAuthentication returns true if the user authenticates with one of the SASL SCRAM mechanisms
+func (spec *KafkaUserSpec) IsSCRAMAuthentication() bool {
+	switch spec.GetAuthenticationType() {
+	case UserAuthenticationTypeSCRAMSHA256, UserAuthenticationTypeSCRAMSHA512:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsOAuthAuthentication returns true if the user authenticates with an OAuth bearer token
+func (spec *KafkaUserSpec) IsOAuthAuthentication() bool {
+	return spec.GetAuthenticationType() == UserAuthenticationTypeOAuth
+}
+
+// IsCertificateAuthentication returns true if the user authenticates with a TLS client certificate
+func (spec *KafkaUserSpec) IsCertificateAuthentication() bool {
+	return spec.GetAuthenticationType() == UserAuthenticationTypeTLS
+}
+
+// GetPasswordSecretRef returns the reference to the user provided SCRAM password, nil if it should be generated
+func (spec *KafkaUserSpec) GetPasswordSecretRef() *corev1.SecretKeySelector {
+	if spec.Authentication == nil {
+		return nil
+	}
+	return spec.Authentication.PasswordSecretRef
+}
+
+// GetPrincipalName returns the principal name of a user not authenticated with a certificate:
+// the configured OAuth principal if set, otherwise the name of the KafkaUser
+func (u *KafkaUser) GetPrincipalName() string {
+	if u.Spec.IsOAuthAuthentication() && u.Spec.Authentication.Principal != "" {
+		return u.Spec.Authentication.Principal
+	}
+	return u.Name
+}
@@ -101,3 +101,96 @@ func TestKafkaUserSpecValidateAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func TestKafkaUserSpecGetAuthenticationType(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		authentication *UserAuthentication
+		wantedType     UserAuthenticationType
+		wantedSCRAM    bool
+	}{
+		{
+			name:           "authentication not specified",
+			authentication: nil,
+			wantedType:     UserAuthenticationTypeTLS,
+			wantedSCRAM:    false,
+		},
+		{
+			name:           "tls authentication",
+			authentication: &UserAuthentication{Type: UserAuthenticationTypeTLS},
+			wantedType:     UserAuthenticationTypeTLS,
+			wantedSCRAM:    false,
+		},
+		{
+			name:           "scram-sha-256 authentication",
+			authentication: &UserAuthentication{Type: UserAuthenticationTypeSCRAMSHA256},
+			wantedType:     UserAuthenticationTypeSCRAMSHA256,
+			wantedSCRAM:    true,
+		},
+		{
+			name:           "scram-sha-512 authentication",
+			authentication: &UserAuthentication{Type: UserAuthenticationTypeSCRAMSHA512},
+			wantedType:     UserAuthenticationTypeSCRAMSHA512,
+			wantedSCRAM:    true,
+		},
+		{
+			name:           "oauth authentication",
+			authentication: &UserAuthentication{Type: UserAuthenticationTypeOAuth},
+			wantedType:     UserAuthenticationTypeOAuth,
+			wantedSCRAM:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			spec := KafkaUserSpec{Authentication: tt.authentication}
+			assert.Equal(t, tt.wantedType, spec.GetAuthenticationType())
+			assert.Equal(t, tt.wantedSCRAM, spec.IsSCRAMAuthentication())
+		})
+	}
+}
+
+func TestKafkaUserGetPrincipalName(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		authentication *UserAuthentication
+		wanted         string
+	}{
+		{
+			name:           "scram user",
+			authentication: &UserAuthentication{Type: UserAuthenticationTypeSCRAMSHA512},
+			wanted:         "test-user",
+		},
+		{
+			name:           "oauth user without principal",
+			authentication: &UserAuthentication{Type: UserAuthenticationTypeOAuth},
+			wanted:         "test-user",
+		},
+		{
+			name:           "oauth user with principal",
+			authentication: &UserAuthentication{Type: UserAuthenticationTypeOAuth, Principal: "service-account-test"},
+			wanted:         "service-account-test",
+		},
+		{
+			name:           "principal ignored for non oauth user",
+			authentication: &UserAuthentication{Type: UserAuthenticationTypeSCRAMSHA256, Principal: "service-account-test"},
+			wanted:         "test-user",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			user := KafkaUser{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-user"},
+				Spec:       KafkaUserSpec{Authentication: tt.authentication},
+			}
+			assert.Equal(t, tt.wanted, user.GetPrincipalName())
+		})
+	}
+}
@@ -22,27 +22,738 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	metav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACLRule) DeepCopyInto(out *ACLRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACLRule.
+func (in *ACLRule) DeepCopy() *ACLRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ACLRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterReference) DeepCopyInto(out *ClusterReference) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReference.
-func (in *ClusterReference) DeepCopy() *ClusterReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReference.
+func (in *ClusterReference) DeepCopy() *ClusterReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupMemberStatus) DeepCopyInto(out *ConsumerGroupMemberStatus) {
+	*out = *in
+	if in.Assignments != nil {
+		in, out := &in.Assignments, &out.Assignments
+		*out = make([]ConsumerGroupTopicPartitions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroupMemberStatus.
+func (in *ConsumerGroupMemberStatus) DeepCopy() *ConsumerGroupMemberStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupMemberStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupPartitionLag) DeepCopyInto(out *ConsumerGroupPartitionLag) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroupPartitionLag.
+func (in *ConsumerGroupPartitionLag) DeepCopy() *ConsumerGroupPartitionLag {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupPartitionLag)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupTopicLag) DeepCopyInto(out *ConsumerGroupTopicLag) {
+	*out = *in
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make([]ConsumerGroupPartitionLag, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroupTopicLag.
+func (in *ConsumerGroupTopicLag) DeepCopy() *ConsumerGroupTopicLag {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupTopicLag)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupTopicPartitions) DeepCopyInto(out *ConsumerGroupTopicPartitions) {
+	*out = *in
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroupTopicPartitions.
+func (in *ConsumerGroupTopicPartitions) DeepCopy() *ConsumerGroupTopicPartitions {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupTopicPartitions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CruiseControl) DeepCopyInto(out *CruiseControl) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CruiseControl.
+func (in *CruiseControl) DeepCopy() *CruiseControl {
+	if in == nil {
+		return nil
+	}
+	out := new(CruiseControl)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CruiseControl) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CruiseControlGoalSummary) DeepCopyInto(out *CruiseControlGoalSummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CruiseControlGoalSummary.
+func (in *CruiseControlGoalSummary) DeepCopy() *CruiseControlGoalSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(CruiseControlGoalSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CruiseControlList) DeepCopyInto(out *CruiseControlList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CruiseControl, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CruiseControlList.
+func (in *CruiseControlList) DeepCopy() *CruiseControlList {
+	if in == nil {
+		return nil
+	}
+	out := new(CruiseControlList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CruiseControlList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CruiseControlOperation) DeepCopyInto(out *CruiseControlOperation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CruiseControlOperation.
+func (in *CruiseControlOperation) DeepCopy() *CruiseControlOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(CruiseControlOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CruiseControlOperation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CruiseControlOperationList) DeepCopyInto(out *CruiseControlOperationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CruiseControlOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CruiseControlOperationList.
+func (in *CruiseControlOperationList) DeepCopy() *CruiseControlOperationList {
+	if in == nil {
+		return nil
+	}
+	out := new(CruiseControlOperationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CruiseControlOperationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CruiseControlOperationSpec) DeepCopyInto(out *CruiseControlOperationSpec) {
+	*out = *in
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int)
+		**out = **in
+	}
+	if in.Rebalance != nil {
+		in, out := &in.Rebalance, &out.Rebalance
+		*out = new(RebalanceOptions)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CruiseControlOperationSpec.
+func (in *CruiseControlOperationSpec) DeepCopy() *CruiseControlOperationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CruiseControlOperationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CruiseControlOperationStatus) DeepCopyInto(out *CruiseControlOperationStatus) {
+	*out = *in
+	if in.CurrentTask != nil {
+		in, out := &in.CurrentTask, &out.CurrentTask
+		*out = new(CruiseControlTask)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailedTasks != nil {
+		in, out := &in.FailedTasks, &out.FailedTasks
+		*out = make([]CruiseControlTask, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CruiseControlOperationStatus.
+func (in *CruiseControlOperationStatus) DeepCopy() *CruiseControlOperationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CruiseControlOperationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CruiseControlSpec) DeepCopyInto(out *CruiseControlSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CruiseControlSpec.
+func (in *CruiseControlSpec) DeepCopy() *CruiseControlSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CruiseControlSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CruiseControlStatus) DeepCopyInto(out *CruiseControlStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CruiseControlStatus.
+func (in *CruiseControlStatus) DeepCopy() *CruiseControlStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CruiseControlStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CruiseControlTask) DeepCopyInto(out *CruiseControlTask) {
+	*out = *in
+	if in.Started != nil {
+		in, out := &in.Started, &out.Started
+		*out = (*in).DeepCopy()
+	}
+	if in.Finished != nil {
+		in, out := &in.Finished, &out.Finished
+		*out = (*in).DeepCopy()
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.HTTPResponseCode != nil {
+		in, out := &in.HTTPResponseCode, &out.HTTPResponseCode
+		*out = new(int)
+		**out = **in
+	}
+	if in.Summary != nil {
+		in, out := &in.Summary, &out.Summary
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.GoalSummaries != nil {
+		in, out := &in.GoalSummaries, &out.GoalSummaries
+		*out = make([]CruiseControlGoalSummary, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CruiseControlTask.
+func (in *CruiseControlTask) DeepCopy() *CruiseControlTask {
+	if in == nil {
+		return nil
+	}
+	out := new(CruiseControlTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaACL) DeepCopyInto(out *KafkaACL) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaACL.
+func (in *KafkaACL) DeepCopy() *KafkaACL {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaACL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KafkaACL) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaACLList) DeepCopyInto(out *KafkaACLList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KafkaACL, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaACLList.
+func (in *KafkaACLList) DeepCopy() *KafkaACLList {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaACLList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KafkaACLList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaACLSpec) DeepCopyInto(out *KafkaACLSpec) {
+	*out = *in
+	out.ClusterRef = in.ClusterRef
+	if in.ACLs != nil {
+		in, out := &in.ACLs, &out.ACLs
+		*out = make([]ACLRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaACLSpec.
+func (in *KafkaACLSpec) DeepCopy() *KafkaACLSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaACLSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaACLStatus) DeepCopyInto(out *KafkaACLStatus) {
+	*out = *in
+	if in.ACLs != nil {
+		in, out := &in.ACLs, &out.ACLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaACLStatus.
+func (in *KafkaACLStatus) DeepCopy() *KafkaACLStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaACLStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaConsumerGroup) DeepCopyInto(out *KafkaConsumerGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaConsumerGroup.
+func (in *KafkaConsumerGroup) DeepCopy() *KafkaConsumerGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaConsumerGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KafkaConsumerGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaConsumerGroupList) DeepCopyInto(out *KafkaConsumerGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KafkaConsumerGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaConsumerGroupList.
+func (in *KafkaConsumerGroupList) DeepCopy() *KafkaConsumerGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaConsumerGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KafkaConsumerGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaConsumerGroupSpec) DeepCopyInto(out *KafkaConsumerGroupSpec) {
+	*out = *in
+	out.ClusterRef = in.ClusterRef
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaConsumerGroupSpec.
+func (in *KafkaConsumerGroupSpec) DeepCopy() *KafkaConsumerGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaConsumerGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaConsumerGroupStatus) DeepCopyInto(out *KafkaConsumerGroupStatus) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]ConsumerGroupMemberStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Lag != nil {
+		in, out := &in.Lag, &out.Lag
+		*out = make([]ConsumerGroupTopicLag, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaConsumerGroupStatus.
+func (in *KafkaConsumerGroupStatus) DeepCopy() *KafkaConsumerGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaConsumerGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaFailover) DeepCopyInto(out *KafkaFailover) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaFailover.
+func (in *KafkaFailover) DeepCopy() *KafkaFailover {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaFailover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KafkaFailover) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaFailoverList) DeepCopyInto(out *KafkaFailoverList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KafkaFailover, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaFailoverList.
+func (in *KafkaFailoverList) DeepCopy() *KafkaFailoverList {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaFailoverList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KafkaFailoverList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaFailoverSpec) DeepCopyInto(out *KafkaFailoverSpec) {
+	*out = *in
+	out.Primary = in.Primary
+	out.Secondary = in.Secondary
+	if in.TopicConfig != nil {
+		in, out := &in.TopicConfig, &out.TopicConfig
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaFailoverSpec.
+func (in *KafkaFailoverSpec) DeepCopy() *KafkaFailoverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaFailoverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaFailoverStatus) DeepCopyInto(out *KafkaFailoverStatus) {
+	*out = *in
+	if in.ActiveCluster != nil {
+		in, out := &in.ActiveCluster, &out.ActiveCluster
+		*out = new(ClusterReference)
+		**out = **in
+	}
+	if in.PromotedTopics != nil {
+		in, out := &in.PromotedTopics, &out.PromotedTopics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PromotedUsers != nil {
+		in, out := &in.PromotedUsers, &out.PromotedUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MissingTopics != nil {
+		in, out := &in.MissingTopics, &out.MissingTopics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PromotedAt != nil {
+		in, out := &in.PromotedAt, &out.PromotedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaFailoverStatus.
+func (in *KafkaFailoverStatus) DeepCopy() *KafkaFailoverStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterReference)
+	out := new(KafkaFailoverStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CruiseControlOperation) DeepCopyInto(out *CruiseControlOperation) {
+func (in *KafkaReassignment) DeepCopyInto(out *KafkaReassignment) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -50,18 +761,18 @@ func (in *CruiseControlOperation) DeepCopyInto(out *CruiseControlOperation) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CruiseControlOperation.
-func (in *CruiseControlOperation) DeepCopy() *CruiseControlOperation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaReassignment.
+func (in *KafkaReassignment) DeepCopy() *KafkaReassignment {
 	if in == nil {
 		return nil
 	}
-	out := new(CruiseControlOperation)
+	out := new(KafkaReassignment)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CruiseControlOperation) DeepCopyObject() runtime.Object {
+func (in *KafkaReassignment) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -69,31 +780,31 @@ func (in *CruiseControlOperation) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CruiseControlOperationList) DeepCopyInto(out *CruiseControlOperationList) {
+func (in *KafkaReassignmentList) DeepCopyInto(out *KafkaReassignmentList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CruiseControlOperation, len(*in))
+		*out = make([]KafkaReassignment, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CruiseControlOperationList.
-func (in *CruiseControlOperationList) DeepCopy() *CruiseControlOperationList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaReassignmentList.
+func (in *KafkaReassignmentList) DeepCopy() *KafkaReassignmentList {
 	if in == nil {
 		return nil
 	}
-	out := new(CruiseControlOperationList)
+	out := new(KafkaReassignmentList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CruiseControlOperationList) DeepCopyObject() runtime.Object {
+func (in *KafkaReassignmentList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -101,90 +812,69 @@ func (in *CruiseControlOperationList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CruiseControlOperationSpec) DeepCopyInto(out *CruiseControlOperationSpec) {
+func (in *KafkaReassignmentSpec) DeepCopyInto(out *KafkaReassignmentSpec) {
 	*out = *in
-	if in.TTLSecondsAfterFinished != nil {
-		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
-		*out = new(int)
+	out.ClusterRef = in.ClusterRef
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make([]PartitionReassignment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ThrottleBytesPerSecond != nil {
+		in, out := &in.ThrottleBytesPerSecond, &out.ThrottleBytesPerSecond
+		*out = new(int64)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CruiseControlOperationSpec.
-func (in *CruiseControlOperationSpec) DeepCopy() *CruiseControlOperationSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaReassignmentSpec.
+func (in *KafkaReassignmentSpec) DeepCopy() *KafkaReassignmentSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CruiseControlOperationSpec)
+	out := new(KafkaReassignmentSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CruiseControlOperationStatus) DeepCopyInto(out *CruiseControlOperationStatus) {
+func (in *KafkaReassignmentStatus) DeepCopyInto(out *KafkaReassignmentStatus) {
 	*out = *in
-	if in.CurrentTask != nil {
-		in, out := &in.CurrentTask, &out.CurrentTask
-		*out = new(CruiseControlTask)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.FailedTasks != nil {
-		in, out := &in.FailedTasks, &out.FailedTasks
-		*out = make([]CruiseControlTask, len(*in))
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make([]ReassignmentPartitionStatus, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CruiseControlOperationStatus.
-func (in *CruiseControlOperationStatus) DeepCopy() *CruiseControlOperationStatus {
-	if in == nil {
-		return nil
+	if in.ThrottledTopics != nil {
+		in, out := &in.ThrottledTopics, &out.ThrottledTopics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	out := new(CruiseControlOperationStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CruiseControlTask) DeepCopyInto(out *CruiseControlTask) {
-	*out = *in
-	if in.Started != nil {
-		in, out := &in.Started, &out.Started
-		*out = (*in).DeepCopy()
+	if in.ThrottledBrokers != nil {
+		in, out := &in.ThrottledBrokers, &out.ThrottledBrokers
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
 	}
-	if in.Finished != nil {
-		in, out := &in.Finished, &out.Finished
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
 		*out = (*in).DeepCopy()
 	}
-	if in.Parameters != nil {
-		in, out := &in.Parameters, &out.Parameters
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.HTTPResponseCode != nil {
-		in, out := &in.HTTPResponseCode, &out.HTTPResponseCode
-		*out = new(int)
-		**out = **in
-	}
-	if in.Summary != nil {
-		in, out := &in.Summary, &out.Summary
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CruiseControlTask.
-func (in *CruiseControlTask) DeepCopy() *CruiseControlTask {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaReassignmentStatus.
+func (in *KafkaReassignmentStatus) DeepCopy() *KafkaReassignmentStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CruiseControlTask)
+	out := new(KafkaReassignmentStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -195,7 +885,7 @@ func (in *KafkaTopic) DeepCopyInto(out *KafkaTopic) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTopic.
@@ -259,6 +949,11 @@ func (in *KafkaTopicSpec) DeepCopyInto(out *KafkaTopicSpec) {
 		}
 	}
 	out.ClusterRef = in.ClusterRef
+	if in.DeletionProtection != nil {
+		in, out := &in.DeletionProtection, &out.DeletionProtection
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTopicSpec.
@@ -274,6 +969,23 @@ func (in *KafkaTopicSpec) DeepCopy() *KafkaTopicSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaTopicStatus) DeepCopyInto(out *KafkaTopicStatus) {
 	*out = *in
+	if in.Reassignment != nil {
+		in, out := &in.Reassignment, &out.Reassignment
+		*out = new(TopicReassignmentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(TopicAdoptionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaTopicStatus.
@@ -381,6 +1093,11 @@ func (in *KafkaUserSpec) DeepCopyInto(out *KafkaUserSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(UserAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaUserSpec.
@@ -401,6 +1118,18 @@ func (in *KafkaUserStatus) DeepCopyInto(out *KafkaUserStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SCRAMCredential != nil {
+		in, out := &in.SCRAMCredential, &out.SCRAMCredential
+		*out = new(UserSCRAMCredentialStatus)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaUserStatus.
@@ -418,7 +1147,7 @@ func (in *PKIBackendSpec) DeepCopyInto(out *PKIBackendSpec) {
 	*out = *in
 	if in.IssuerRef != nil {
 		in, out := &in.IssuerRef, &out.IssuerRef
-		*out = new(v1.ObjectReference)
+		*out = new(metav1.ObjectReference)
 		**out = **in
 	}
 }
@@ -433,6 +1162,222 @@ func (in *PKIBackendSpec) DeepCopy() *PKIBackendSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionReassignment) DeepCopyInto(out *PartitionReassignment) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.LogDirs != nil {
+		in, out := &in.LogDirs, &out.LogDirs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionReassignment.
+func (in *PartitionReassignment) DeepCopy() *PartitionReassignment {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionReassignment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionReassignmentStatus) DeepCopyInto(out *PartitionReassignmentStatus) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.AddingReplicas != nil {
+		in, out := &in.AddingReplicas, &out.AddingReplicas
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemovingReplicas != nil {
+		in, out := &in.RemovingReplicas, &out.RemovingReplicas
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionReassignmentStatus.
+func (in *PartitionReassignmentStatus) DeepCopy() *PartitionReassignmentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionReassignmentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReassignmentPartitionStatus) DeepCopyInto(out *ReassignmentPartitionStatus) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.AddingReplicas != nil {
+		in, out := &in.AddingReplicas, &out.AddingReplicas
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemovingReplicas != nil {
+		in, out := &in.RemovingReplicas, &out.RemovingReplicas
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReassignmentPartitionStatus.
+func (in *ReassignmentPartitionStatus) DeepCopy() *ReassignmentPartitionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReassignmentPartitionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RebalanceOptions) DeepCopyInto(out *RebalanceOptions) {
+	*out = *in
+	if in.ReplicaMovementStrategies != nil {
+		in, out := &in.ReplicaMovementStrategies, &out.ReplicaMovementStrategies
+		*out = make([]ReplicaMovementStrategy, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConcurrentPartitionMovementsPerBroker != nil {
+		in, out := &in.ConcurrentPartitionMovementsPerBroker, &out.ConcurrentPartitionMovementsPerBroker
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConcurrentLeaderMovements != nil {
+		in, out := &in.ConcurrentLeaderMovements, &out.ConcurrentLeaderMovements
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RebalanceOptions.
+func (in *RebalanceOptions) DeepCopy() *RebalanceOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(RebalanceOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopicAdoptionStatus) DeepCopyInto(out *TopicAdoptionStatus) {
+	*out = *in
+	in.AdoptedAt.DeepCopyInto(&out.AdoptedAt)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopicAdoptionStatus.
+func (in *TopicAdoptionStatus) DeepCopy() *TopicAdoptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TopicAdoptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopicReassignmentStatus) DeepCopyInto(out *TopicReassignmentStatus) {
+	*out = *in
+	if in.CruiseControlOperationReference != nil {
+		in, out := &in.CruiseControlOperationReference, &out.CruiseControlOperationReference
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make([]PartitionReassignmentStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopicReassignmentStatus.
+func (in *TopicReassignmentStatus) DeepCopy() *TopicReassignmentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TopicReassignmentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserAuthentication) DeepCopyInto(out *UserAuthentication) {
+	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserAuthentication.
+func (in *UserAuthentication) DeepCopy() *UserAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(UserAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserSCRAMCredentialStatus) DeepCopyInto(out *UserSCRAMCredentialStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserSCRAMCredentialStatus.
+func (in *UserSCRAMCredentialStatus) DeepCopy() *UserSCRAMCredentialStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UserSCRAMCredentialStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserTopicGrant) DeepCopyInto(out *UserTopicGrant) {
 	*out = *in
@@ -19,6 +19,7 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // RackAwarenessState stores info about rack awareness status
@@ -39,6 +40,29 @@ type ClusterState string
 // ConfigurationState holds info about the configuration state
 type ConfigurationState string
 
+// KRaftMigrationPhase holds info about the phase of the ZooKeeper to KRaft migration
+type KRaftMigrationPhase string
+
+// KafkaVersionUpgradePhase holds info about the phase of the upgrade of the Kafka version of a cluster
+type KafkaVersionUpgradePhase string
+
+// ControllerQuorumState holds info about the health of the KRaft controller quorum
+type ControllerQuorumState string
+
+// ConformanceAuditSeverity holds info about the severity of a best-practice rule of the conformance audit
+type ConformanceAuditSeverity string
+
+// PlannedAction holds info about the change of a resource the reconciliation of a KafkaCluster would make
+// +kubebuilder:validation:Enum=Create;Update;Delete
+type PlannedAction string
+
+// CruiseControlAnomalyType holds info about the type of an anomaly detected by the Cruise Control anomaly detector
+// +kubebuilder:validation:Enum=GoalViolation;BrokerFailure;DiskFailure
+type CruiseControlAnomalyType string
+
+// ExternalListenerAccessTransitionPhase holds info about the phase of an external listener access method change
+type ExternalListenerAccessTransitionPhase string
+
 // SecurityProtocol is the protocol used to communicate with brokers.
 // Valid values are: plaintext, ssl, sasl_plaintext, sasl_ssl.
 type SecurityProtocol string
@@ -53,6 +77,10 @@ type PerBrokerConfigurationState string
 // ExternalListenerConfigNames type describes a collection of external listener names
 type ExternalListenerConfigNames []string
 
+// DynamicConfigKeys type describes the keys of the broker configuration applied to a running broker through its
+// dynamic broker configuration instead of a rolling restart
+type DynamicConfigKeys []string
+
 // KafkaVersion type describes the kafka version and docker version
 type KafkaVersion struct {
 	// Version holds the current version of the broker in semver format
@@ -161,7 +189,7 @@ func (r CruiseControlVolumeState) IsDiskRebalanceSucceeded() bool {
 
 // IsDiskRemovalSucceeded returns true if CruiseControlVolumeState is disk removal succeeded
 func (r CruiseControlVolumeState) IsDiskRemovalSucceeded() bool {
-	return r == GracefulDiskRemovalSucceeded
+	return r == GracefulDiskRemovalSucceeded || r == GracefulDiskRemovalRestartPending
 }
 
 // IsSSL determines if the receiver is using SSL
@@ -197,6 +225,8 @@ const (
 	PKIBackendProvided PKIBackend = "pki-backend-provided"
 	// PKIBackendK8sCSR invokes kubernetes csr API for user certificate management
 	PKIBackendK8sCSR PKIBackend = "k8s-csr"
+	// PKIBackendVault issues the certificates from the PKI secrets engine of HashiCorp Vault
+	PKIBackendVault PKIBackend = "vault"
 )
 
 // IstioControlPlaneReference is a reference to the IstioControlPlane resource.
@@ -232,6 +262,9 @@ type BrokerState struct {
 	ConfigurationState ConfigurationState `json:"configurationState"`
 	// PerBrokerConfigurationState holds info about the per-broker (dynamically updatable) config
 	PerBrokerConfigurationState PerBrokerConfigurationState `json:"perBrokerConfigurationState"`
+	// DynamicConfigKeys holds the keys of the broker configuration applied to the running broker through its dynamic
+	// broker configuration instead of a rolling restart
+	DynamicConfigKeys DynamicConfigKeys `json:"dynamicConfigKeys,omitempty"`
 	// ExternalListenerConfigNames holds info about what listener config is in use with the broker
 	ExternalListenerConfigNames ExternalListenerConfigNames `json:"externalListenerConfigNames,omitempty"`
 	// Version holds the current version of the broker in semver format
@@ -240,6 +273,176 @@ type BrokerState struct {
 	Image string `json:"image,omitempty"`
 	// Compressed data from broker configuration to restore broker pod in specific cases
 	ConfigurationBackup string `json:"configurationBackup,omitempty"`
+	// DrainState holds the progress of moving the partition replicas away from a broker removed from the spec
+	DrainState *BrokerDrainState `json:"drainState,omitempty"`
+	// ReplacementState holds the progress of the last replacement of the broker
+	ReplacementState *BrokerReplacementState `json:"replacementState,omitempty"`
+	// RestartHooksState holds whether the post-restart hooks of the broker passed since its last rolling restart
+	RestartHooksState RestartHooksState `json:"restartHooksState,omitempty"`
+	// StorageMigrations holds the progress of moving the volumes of the broker to a new StorageClass by mount path
+	StorageMigrations StorageMigrationStates `json:"storageMigrations,omitempty"`
+	// NodeInterruption holds the interruption of the node of the broker, e.g. the reclaim of a spot instance, while
+	// the broker is running on it
+	NodeInterruption *NodeInterruptionState `json:"nodeInterruption,omitempty"`
+	// LogDirFailures holds the offline log directories of the broker by the mount path of the affected volume
+	LogDirFailures LogDirFailureStates `json:"logDirFailures,omitempty"`
+}
+
+// LogDirFailureState holds an offline log directory of a broker and its remediation
+type LogDirFailureState struct {
+	// LogDir is the offline log directory as reported by Kafka
+	LogDir string `json:"logDir"`
+	// CruiseControlOperationReference refers to the CruiseControlOperation remediating the failure, empty when the
+	// failure is only reported
+	// +optional
+	CruiseControlOperationReference *corev1.LocalObjectReference `json:"cruiseControlOperationReference,omitempty"`
+	// DetectedTime is the time the log directory was detected offline
+	DetectedTime metav1.Time `json:"detectedTime"`
+}
+
+// LogDirFailureStates holds the offline log directories of a broker by the mount path of the affected volume
+type LogDirFailureStates map[string]LogDirFailureState
+
+// NodeInterruptionState holds the interruption signaled on the node of a broker
+type NodeInterruptionState struct {
+	// Node is the name of the interrupted node
+	Node string `json:"node"`
+	// Signal is the key of the taint or the type of the condition signaling the interruption of the node
+	Signal string `json:"signal"`
+	// DemoteOperationReference refers to the demote_broker CruiseControlOperation moving the leadership of the
+	// partitions away from the broker, empty until the operation is created
+	// +optional
+	DemoteOperationReference *corev1.LocalObjectReference `json:"demoteOperationReference,omitempty"`
+	// DetectedTime is the time the interruption of the node was detected
+	DetectedTime metav1.Time `json:"detectedTime"`
+}
+
+// RestartHooksState is the state of the post-restart hooks of a broker restarted by a rolling upgrade
+type RestartHooksState string
+
+const (
+	// PostRestartHooksPending states that the post-restart hooks of the broker have not passed since its restart
+	PostRestartHooksPending RestartHooksState = "PostRestartHooksPending"
+	// PostRestartHooksPassed states that the post-restart hooks of the broker passed since its restart
+	PostRestartHooksPassed RestartHooksState = "PostRestartHooksPassed"
+)
+
+// BrokerReplacementPhase is the phase of the replacement of a broker
+type BrokerReplacementPhase string
+
+const (
+	// BrokerReplacementDrainingLeadership states that the leadership of the partitions is moved away from the broker
+	BrokerReplacementDrainingLeadership BrokerReplacementPhase = "DrainingLeadership"
+	// BrokerReplacementDeleting states that the pod of the broker and its volumes, unless they are kept, are deleted
+	BrokerReplacementDeleting BrokerReplacementPhase = "Deleting"
+	// BrokerReplacementResyncing states that the recreated broker re-syncs its partition replicas
+	BrokerReplacementResyncing BrokerReplacementPhase = "Resyncing"
+	// BrokerReplacementSucceeded states that the recreated broker caught up with the partition leaders
+	BrokerReplacementSucceeded BrokerReplacementPhase = "Succeeded"
+)
+
+// BrokerReplacementState holds the progress of the replacement of a broker
+type BrokerReplacementState struct {
+	// ID is the id of the replacement of the broker
+	ID string `json:"id"`
+	// Phase is the phase of the replacement
+	Phase BrokerReplacementPhase `json:"phase"`
+	// DemoteOperationReference refers to the demote_broker CruiseControlOperation moving the leadership away from the
+	// broker
+	// +optional
+	DemoteOperationReference *corev1.LocalObjectReference `json:"demoteOperationReference,omitempty"`
+	// RemainingLeaders is the number of partitions still led by the broker while its leadership is drained
+	// +optional
+	RemainingLeaders int32 `json:"remainingLeaders,omitempty"`
+	// OutOfSyncReplicas is the number of partition replicas of the recreated broker which are not in sync yet
+	// +optional
+	OutOfSyncReplicas int32 `json:"outOfSyncReplicas,omitempty"`
+	// UnderReplicatedPartitions is the number of under-replicated partitions with a replica on the recreated broker
+	// +optional
+	UnderReplicatedPartitions int32 `json:"underReplicatedPartitions,omitempty"`
+	// LastUpdateTime is the time the replacement last progressed
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+}
+
+// BrokerDecommissionPhase is the phase of the decommission of a broker
+type BrokerDecommissionPhase string
+
+const (
+	// BrokerDecommissionDraining states that Cruise Control moves the partition replicas away from the broker
+	BrokerDecommissionDraining BrokerDecommissionPhase = "Draining"
+	// BrokerDecommissionVerifying states that the broker is drained and the removal waits for the cluster to have no
+	// under-replicated partitions
+	BrokerDecommissionVerifying BrokerDecommissionPhase = "Verifying"
+	// BrokerDecommissionRemoving states that the broker has been removed from the brokers list and its pod and volumes
+	// are deleted
+	BrokerDecommissionRemoving BrokerDecommissionPhase = "Removing"
+	// BrokerDecommissionCompleted states that the pod and the volumes of the broker are gone
+	BrokerDecommissionCompleted BrokerDecommissionPhase = "Completed"
+)
+
+// BrokerDecommissionStatus holds the progress and the report of the decommission of a broker
+type BrokerDecommissionStatus struct {
+	// Phase is the phase of the decommission
+	Phase BrokerDecommissionPhase `json:"phase"`
+	// StartTime is the time the decommission started
+	StartTime metav1.Time `json:"startTime"`
+	// InitialReplicas is the number of partition replicas hosted by the broker when the decommission started
+	InitialReplicas int32 `json:"initialReplicas"`
+	// RemainingReplicas is the number of partition replicas still hosted by the broker according to Cruise Control
+	RemainingReplicas int32 `json:"remainingReplicas"`
+	// MovedReplicas is the number of partition replicas moved away from the broker
+	MovedReplicas int32 `json:"movedReplicas"`
+	// UnderReplicatedPartitions is the number of under-replicated partitions of the cluster at the last verification
+	// +optional
+	UnderReplicatedPartitions int32 `json:"underReplicatedPartitions,omitempty"`
+	// VerificationTime is the time the cluster was verified to have no under-replicated partitions without the broker,
+	// right before the broker was removed from the brokers list
+	// +optional
+	VerificationTime *metav1.Time `json:"verificationTime,omitempty"`
+	// CompletionTime is the time the pod and the volumes of the broker were found deleted
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Duration is the time the decommission took from its start until its completion
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// LastUpdateTime is the time the decommission last progressed
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+}
+
+// StorageMigrationPhase is the phase of moving a volume of a broker to a new StorageClass
+type StorageMigrationPhase string
+
+const (
+	// StorageMigrationAddingVolume states that a volume of the new StorageClass is added to the broker next to the old
+	// one and the replicas are rebalanced onto it
+	StorageMigrationAddingVolume StorageMigrationPhase = "AddingVolume"
+	// StorageMigrationDrainingVolume states that the replicas are moved off the old volume which is removed afterwards
+	StorageMigrationDrainingVolume StorageMigrationPhase = "DrainingVolume"
+	// StorageMigrationSwappingMounts states that the new volume is mounted at the mount path of the old one by a
+	// rolling restart of the broker
+	StorageMigrationSwappingMounts StorageMigrationPhase = "SwappingMounts"
+)
+
+// StorageMigrationState holds the progress of moving a volume of a broker to a new StorageClass
+type StorageMigrationState struct {
+	// Phase is the phase of the migration
+	Phase StorageMigrationPhase `json:"phase"`
+	// StorageClassName is the name of the StorageClass the volume is moved to
+	StorageClassName string `json:"storageClassName"`
+	// LastUpdateTime is the time the migration last progressed
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+}
+
+// StorageMigrationStates holds the storage migrations of a broker by the mount path of the migrated volume
+type StorageMigrationStates map[string]StorageMigrationState
+
+// BrokerDrainState holds information about the partition replicas left on a broker removed from the spec. The pod and
+// the volumes of the broker are deleted only once no replicas are left on it.
+type BrokerDrainState struct {
+	// RemainingReplicas is the number of partition replicas still hosted by the broker according to Cruise Control
+	RemainingReplicas int32 `json:"remainingReplicas"`
+	// LastUpdateTime is the time the number of remaining replicas last changed
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
 }
 
 const (
@@ -289,6 +492,9 @@ const (
 	GracefulDiskRemovalCompletedWithError CruiseControlVolumeState = "GracefulDiskRemovalCompletedWithError"
 	// GracefulDiskRemovalPaused states that the broker volume removal task is completed with an error and it will not be retried, it is paused
 	GracefulDiskRemovalPaused CruiseControlVolumeState = "GracefulDiskRemovalPaused"
+	// GracefulDiskRemovalRestartPending states that the replicas have been moved off the broker volume and the broker is
+	// waiting to be restarted without the volume, the PVC of the volume is deleted afterwards
+	GracefulDiskRemovalRestartPending CruiseControlVolumeState = "GracefulDiskRemovalRestartPending"
 
 	// Disk rebalance cruise control states
 	// GracefulDiskRebalanceRequired states that the broker volume needs a CC disk rebalance
@@ -323,6 +529,91 @@ const (
 	// KafkaClusterRunning states that the cluster is in running state
 	KafkaClusterRunning ClusterState = "ClusterRunning"
 
+	// ConditionReady is the standard condition of the koperator resources reporting that the resource reached its
+	// desired state
+	ConditionReady = "Ready"
+	// ConditionProgressing is the standard condition of the koperator resources reporting that the resource is being
+	// changed towards its desired state
+	ConditionProgressing = "Progressing"
+	// ConditionDegraded is the standard condition of the koperator resources reporting that the resource failed to
+	// reach its desired state
+	ConditionDegraded = "Degraded"
+	// ConditionWaiting is the condition of the koperator resources reporting that disruptive changes of the resource
+	// wait for a maintenance window of the cluster
+	ConditionWaiting = "Waiting"
+
+	// KafkaClusterConditionRollbackRequired is the KafkaCluster condition reporting that some brokers failed to serve
+	// traffic after a rolling upgrade
+	KafkaClusterConditionRollbackRequired = "RollbackRequired"
+
+	// KRaftMigrationPhaseControllersProvisioning states that the KRaft controllers are being provisioned with the
+	// ZooKeeper migration enabled while the brokers still run in ZooKeeper mode
+	KRaftMigrationPhaseControllersProvisioning KRaftMigrationPhase = "ControllersProvisioning"
+	// KRaftMigrationPhaseMetadataMigrating states that the brokers are rolled with the migration flags and the controller
+	// quorum configuration and the active KRaft controller migrates the metadata from ZooKeeper
+	KRaftMigrationPhaseMetadataMigrating KRaftMigrationPhase = "MetadataMigrating"
+	// KRaftMigrationPhaseBrokersMigrating states that the metadata has been migrated and the brokers are flipped to
+	// KRaft mode one by one while the controllers keep writing the metadata to ZooKeeper
+	KRaftMigrationPhaseBrokersMigrating KRaftMigrationPhase = "BrokersMigrating"
+	// KRaftMigrationPhaseFinalizing states that all the brokers run in KRaft mode and the controllers are rolled
+	// without the ZooKeeper migration
+	KRaftMigrationPhaseFinalizing KRaftMigrationPhase = "Finalizing"
+	// KRaftMigrationPhaseCompleted states that the cluster runs in KRaft mode and no longer uses ZooKeeper
+	KRaftMigrationPhaseCompleted KRaftMigrationPhase = "Completed"
+
+	// KafkaVersionUpgradePhaseBinariesUpgrading states that the brokers are rolled with the images of the target
+	// version while they keep running the protocol of the previous version
+	KafkaVersionUpgradePhaseBinariesUpgrading KafkaVersionUpgradePhase = "BinariesUpgrading"
+	// KafkaVersionUpgradePhaseProtocolPending states that every broker runs the target version and the protocol is
+	// held at the previous version, the upgrade can still be rolled back by reverting the images
+	KafkaVersionUpgradePhaseProtocolPending KafkaVersionUpgradePhase = "ProtocolPending"
+	// KafkaVersionUpgradePhaseProtocolUpgrading states that the inter broker protocol version is rolled out to the
+	// brokers in ZooKeeper mode, or the metadata version of the cluster is upgraded in KRaft mode
+	KafkaVersionUpgradePhaseProtocolUpgrading KafkaVersionUpgradePhase = "ProtocolUpgrading"
+	// KafkaVersionUpgradePhaseCompleted states that the cluster runs the target version and its protocol
+	KafkaVersionUpgradePhaseCompleted KafkaVersionUpgradePhase = "Completed"
+
+	// ControllerQuorumHealthy states that every voter of the KRaft controller quorum is ready
+	ControllerQuorumHealthy ControllerQuorumState = "Healthy"
+	// ControllerQuorumDegraded states that some voters of the KRaft controller quorum are not ready but the
+	// remaining ones still form a majority
+	ControllerQuorumDegraded ControllerQuorumState = "Degraded"
+	// ControllerQuorumUnavailable states that the ready voters of the KRaft controller quorum do not form a majority
+	ControllerQuorumUnavailable ControllerQuorumState = "Unavailable"
+
+	// ConformanceAuditSeverityCritical states that the violation of the rule risks the availability or the security
+	// of the data of the cluster
+	ConformanceAuditSeverityCritical ConformanceAuditSeverity = "Critical"
+	// ConformanceAuditSeverityWarning states that the violation of the rule makes the operation of the cluster harder
+	ConformanceAuditSeverityWarning ConformanceAuditSeverity = "Warning"
+
+	// PlannedActionCreate states that the resource would be created
+	PlannedActionCreate PlannedAction = "Create"
+	// PlannedActionUpdate states that the resource would be updated or patched
+	PlannedActionUpdate PlannedAction = "Update"
+	// PlannedActionDelete states that the resource would be deleted
+	PlannedActionDelete PlannedAction = "Delete"
+
+	// CruiseControlAnomalyGoalViolation states that the distribution of the replicas violates Cruise Control goals,
+	// it is remediated by a rebalance
+	CruiseControlAnomalyGoalViolation CruiseControlAnomalyType = "GoalViolation"
+	// CruiseControlAnomalyBrokerFailure states that brokers of the cluster are offline, it is remediated by moving the
+	// offline replicas to healthy brokers
+	CruiseControlAnomalyBrokerFailure CruiseControlAnomalyType = "BrokerFailure"
+	// CruiseControlAnomalyDiskFailure states that log directories of brokers are offline, it is remediated by moving
+	// the offline replicas to healthy brokers
+	CruiseControlAnomalyDiskFailure CruiseControlAnomalyType = "DiskFailure"
+
+	// ExternalListenerAccessProvisioning states that the resources of the new access method of the external listener
+	// are being provisioned while the brokers still advertise the addresses of the previous one
+	ExternalListenerAccessProvisioning ExternalListenerAccessTransitionPhase = "Provisioning"
+	// ExternalListenerAccessAdvertising states that the new access method is reachable and the brokers are rolled
+	// to advertise its addresses
+	ExternalListenerAccessAdvertising ExternalListenerAccessTransitionPhase = "Advertising"
+	// ExternalListenerAccessCleaningUp states that every broker advertises the addresses of the new access method and
+	// the resources of the previous one are removed
+	ExternalListenerAccessCleaningUp ExternalListenerAccessTransitionPhase = "CleaningUp"
+
 	// ConfigInSync states that the generated brokerConfig is in sync with the Broker
 	ConfigInSync ConfigurationState = "ConfigInSync"
 	// ConfigOutOfSync states that the generated brokerConfig is out of sync with the Broker
@@ -345,4 +636,8 @@ const (
 
 	// SSLClientAuthRequired states that the client authentication is required when SSL is enabled
 	SSLClientAuthRequired SSLClientAuthentication = "required"
+	// SSLClientAuthRequested states that the client certificate is optional, the clients without one are anonymous
+	SSLClientAuthRequested SSLClientAuthentication = "requested"
+	// SSLClientAuthNone states that the listener only authenticates the server, the client certificates are ignored
+	SSLClientAuthNone SSLClientAuthentication = "none"
 )
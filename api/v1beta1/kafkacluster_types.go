@@ -17,8 +17,11 @@ package v1beta1
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"emperror.dev/errors"
 
@@ -62,6 +65,32 @@ const (
 	ControllerNodeProcessRole = "controller"
 	// BrokerNodeProcessRole represents the node is a broker node
 	BrokerNodeProcessRole = "broker"
+
+	// ReconcileTraceAnnotationKey enables the reconcile trace of the KafkaCluster, its value is the number of the
+	// latest reconciles whose steps are recorded in the <cluster>-reconcile-trace ConfigMap
+	ReconcileTraceAnnotationKey = "kafka.banzaicloud.io/reconcile-trace"
+
+	// DefaultClusterSourceHashAnnotationKey holds the hash of the ConfigMap manifest the default KafkaCluster was last
+	// created or updated from by the operator
+	DefaultClusterSourceHashAnnotationKey = "kafka.banzaicloud.io/default-cluster-source-hash"
+
+	// ResumeRollingUpgradeAnnotationKey resumes the rolling upgrade of the KafkaCluster paused after the restart of the
+	// canary broker, the operator removes the annotation once the rolling upgrade is resumed
+	ResumeRollingUpgradeAnnotationKey = "kafka.banzaicloud.io/resume-rolling-upgrade"
+
+	// PlanOnlyAnnotationKey puts the KafkaCluster into plan-only mode when set to "true": the operator records the
+	// changes it would make in the status of the cluster without applying any of them
+	PlanOnlyAnnotationKey = "kafka.banzaicloud.io/plan-only"
+
+	// BrokerReadyConditionType is the pod readiness gate set on the broker pods when spec.brokerReadiness is configured
+	BrokerReadyConditionType = "kafka.banzaicloud.io/broker-ready"
+
+	// ServiceTypePerBrokerLoadBalancer is the external listener access method which exposes every broker through its
+	// own LoadBalancer Service instead of a load balancer shared by the brokers
+	ServiceTypePerBrokerLoadBalancer corev1.ServiceType = "PerBrokerLoadBalancer"
+	// ExternalDNSHostnameAnnotationKey is the annotation external-dns creates the DNS records of a Service from
+	ExternalDNSHostnameAnnotationKey = "external-dns.alpha.kubernetes.io/hostname"
+
 	// These are default values for API keys
 
 	/* General Config */
@@ -83,6 +112,8 @@ const (
 	defaultEnvoyHealthCheckPort = 8080
 	// KafkaClusterDeployment.spec.template.spec.container["envoy"].args
 	defaultEnvoyConcurrency = 0
+	// idle timeout of the tcp_proxy filters of the envoy listeners
+	defaultEnvoyListenerIdleTimeout = 560 * time.Second
 
 	// KafkaClusterDeployment.spec.template.spec.container["envoy"].resource
 	defaultEnvoyRequestResourceCpu    = "100m"
@@ -98,12 +129,34 @@ const (
 	// KafkaBrokerPod.spec.terminationGracePeriodSeconds
 	defaultBrokerTerminationGracePeriod = 120
 
+	// KafkaCluster.spec.brokerReadiness.periodSeconds and timeoutSeconds
+	defaultBrokerReadinessPeriodSeconds  = 15
+	defaultBrokerReadinessTimeoutSeconds = 5
+
+	// KafkaCluster.spec.vaultConfig.certificateTTL
+	defaultVaultCertificateTTL = 24 * time.Hour
+	// KafkaCluster.spec.vaultConfig.auth.tokenPath
+	defaultServiceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+	// KafkaCluster.spec.listenersConfig.*.kerberos.serviceName
+	defaultKerberosServiceName = "kafka"
+
+	// KafkaBrokerPod.spec.topologySpreadConstraints[].maxSkew
+	defaultBrokerPlacementMaxSkew = 1
+
+	// KafkaBrokerPod.spec.container["kafka"].lifecycle page cache checkpoint size
+	defaultMaxSegmentsPerStorage = 50
+
 	// KafkaBrokerPod.spec.container["kafka"].resource
 	defaultBrokerRequestResourceCpu    = "1000m"
 	defaultBrokerRequestResourceMemory = "2Gi"
 	defaultBrokerLimitResourceCpu      = "1500m"
 	defaultBrokerLimitResourceMemory   = "3Gi"
 
+	// KafkaBrokerPod.spec.initContainers["cruise-control-reporter", "jmx-exporter"].resource
+	defaultBrokerInitContainerResourceCpu    = "100m"
+	defaultBrokerInitContainerResourceMemory = "100Mi"
+
 	defaultBrokerHeapOpts    = "-Xmx2G -Xms2G"
 	defaultBrokerPerfJvmOpts = "-server -XX:+UseG1GC -XX:MaxGCPauseMillis=20 -XX:InitiatingHeapOccupancyPercent=35 -XX:+ExplicitGCInvokesConcurrent -Djava.awt.headless=true -Dsun.net.inetaddr.ttl=60"
 
@@ -121,10 +174,43 @@ const (
 	// Cruise Control Task
 	defaultCruiseControlTaskDurationMin = 5
 
+	// KafkaCluster.spec.cruiseControlConfig.selfHealing.pollIntervalSeconds
+	defaultSelfHealingPollInterval = 60 * time.Second
+
+	// KafkaCluster.spec.cruiseControlConfig.rebalanceSchedule.startingDeadlineSeconds
+	defaultRebalanceScheduleStartingDeadline = 15 * time.Minute
+
+	// Rolling upgrade smoke test
+	defaultSmokeTestTopic          = "koperator-smoke-test"
+	defaultSmokeTestTimeoutSeconds = 30
+
+	// Rolling upgrade restart hooks
+	defaultRestartHookTimeoutSeconds    = 10
+	defaultJobRestartHookTimeoutSeconds = 300
+
+	// KafkaCluster.spec.brokerRescheduling.pendingTimeoutSeconds
+	defaultBrokerReschedulingPendingTimeoutSeconds = 300
+
+	// KafkaCluster.spec.logDirFailure.pollIntervalSeconds
+	defaultLogDirFailurePollInterval = 60 * time.Second
+
+	// KafkaCluster.spec.leaderBalance.imbalanceThresholdPercentage, the default of leader.imbalance.per.broker.percentage
+	defaultLeaderImbalanceThresholdPercentage = 10
+
+	// Rolling upgrade Prometheus replication check
+	defaultPrometheusReplicationCheckTimeoutSeconds = 10
+	defaultPrometheusReplicationCheckQuery          = `(kafka_server_replicamanager_underreplicatedpartitions{kafka_cr="$(CLUSTER_NAME)",namespace="$(NAMESPACE)"} > 0)` +
+		` or (kafka_controller_kafkacontroller_offlinepartitionscount{kafka_cr="$(CLUSTER_NAME)",namespace="$(NAMESPACE)"} > 0)`
+
 	// Kafka Cluster Spec
 	defaultKafkaClusterIngressController = "envoy"
 	defaultKafkaClusterK8sClusterDomain  = "cluster.local"
 
+	/* Nginx Ingress Config */
+	defaultNginxControllerNamespace      = "ingress-nginx"
+	defaultNginxControllerServiceName    = "ingress-nginx-controller"
+	defaultNginxTCPServicesConfigMapName = "tcp-services"
+
 	// KafkaBroker.spec.container["kafka"].image
 	defaultKafkaImage = "ghcr.io/adobe/koperator/kafka:2.13-3.9.1"
 
@@ -150,8 +236,25 @@ const (
 	// KafkaBrokerPod.spec.initContainer["jmx-exporter"].command
 	// kafkaClusterDeployment.spec.template.spec.initContainer["jmx-exporter"].command
 	defaultMonitorPathToJar = "/jmx_prometheus_javaagent.jar"
+
+	// KafkaBrokerPod.spec.containers["kafka"].ports["metrics"].containerPort
+	defaultJmxExporterPort = 9020
+
+	// defaultDiskUsageThresholdPercent is the usage of a broker volume the generated disk capacity alert fires above
+	defaultDiskUsageThresholdPercent = 80
+	// defaultGrafanaDashboardLabelKey is the label the dashboard sidecar of the Grafana Helm chart looks for by default
+	defaultGrafanaDashboardLabelKey = "grafana_dashboard"
 )
 
+// KafkaCluster.spec.nodeInterruption.taints
+var defaultNodeInterruptionTaints = []string{
+	"aws-node-termination-handler/spot-itn",
+	"aws-node-termination-handler/asg-lifecycle-termination",
+	"karpenter.sh/disrupted",
+	"cloud.google.com/impending-node-termination",
+	"ToBeDeletedByClusterAutoscaler",
+}
+
 // KafkaClusterSpec defines the desired state of KafkaCluster
 type KafkaClusterSpec struct {
 	// kRaft is used to decide where the Kafka cluster is under KRaft mode or ZooKeeper mode.
@@ -173,20 +276,26 @@ type KafkaClusterSpec struct {
 	// of its ZooKeeper connection string which puts its data under some path in the global ZooKeeper namespace.
 	// If set under KRaft mode, Koperator ignores this configuration.
 	// +optional
-	ZKPath                      string                  `json:"zkPath,omitempty"`
-	RackAwareness               *RackAwareness          `json:"rackAwareness,omitempty"`
-	ClusterImage                string                  `json:"clusterImage,omitempty"`
-	ClusterMetricsReporterImage string                  `json:"clusterMetricsReporterImage,omitempty"`
-	ReadOnlyConfig              string                  `json:"readOnlyConfig,omitempty"`
-	ClusterWideConfig           string                  `json:"clusterWideConfig,omitempty"`
-	BrokerConfigGroups          map[string]BrokerConfig `json:"brokerConfigGroups,omitempty"`
-	Brokers                     []Broker                `json:"brokers"`
-	DisruptionBudget            DisruptionBudget        `json:"disruptionBudget,omitempty"`
-	RollingUpgradeConfig        RollingUpgradeConfig    `json:"rollingUpgradeConfig"`
+	ZKPath                      string         `json:"zkPath,omitempty"`
+	RackAwareness               *RackAwareness `json:"rackAwareness,omitempty"`
+	ClusterImage                string         `json:"clusterImage,omitempty"`
+	ClusterMetricsReporterImage string         `json:"clusterMetricsReporterImage,omitempty"`
+	// ReadOnlyConfig is the read-only configuration of all brokers. Credentials can be referenced with the
+	// ${secret:<secret name>:<key>} placeholder of a secret in the namespace of the cluster, or with the
+	// ${env:<variable>} placeholder of an environment variable of the brokers, instead of being rendered into the
+	// broker ConfigMaps. The placeholders are resolved by config providers when the brokers start.
+	ReadOnlyConfig       string                  `json:"readOnlyConfig,omitempty"`
+	ClusterWideConfig    string                  `json:"clusterWideConfig,omitempty"`
+	BrokerConfigGroups   map[string]BrokerConfig `json:"brokerConfigGroups,omitempty"`
+	Brokers              []Broker                `json:"brokers"`
+	DisruptionBudget     DisruptionBudget        `json:"disruptionBudget,omitempty"`
+	RollingUpgradeConfig RollingUpgradeConfig    `json:"rollingUpgradeConfig"`
 	// Selector for broker pods that need to be recycled/reconciled
 	TaintedBrokersSelector *metav1.LabelSelector `json:"taintedBrokersSelector,omitempty"`
-	// +kubebuilder:validation:Enum=envoy;contour;istioingress
+	// +kubebuilder:validation:Enum=envoy;contour;istioingress;gatewayapi;nginx
 	// IngressController specifies the type of the ingress controller to be used for external listeners. The `istioingress` ingress controller type requires the `spec.istioControlPlane` field to be populated as well.
+	// The `gatewayapi` ingress controller type exposes the external listeners through a Kubernetes Gateway API implementation configured in `spec.gatewayAPIConfig`.
+	// The `nginx` ingress controller type exposes the external listeners through an already running ingress-nginx controller configured in `spec.nginxIngressConfig`.
 	IngressController string `json:"ingressController,omitempty"`
 	// IstioControlPlane is a reference to the IstioControlPlane resource for envoy configuration. It must be specified if istio ingress is used.
 	IstioControlPlane *IstioControlPlaneReference `json:"istioControlPlane,omitempty"`
@@ -195,6 +304,8 @@ type KafkaClusterSpec struct {
 	OneBrokerPerNode bool `json:"oneBrokerPerNode"`
 	// RemoveUnusedIngressResources when true, the unnecessary resources from the previous ingress state will be removed.
 	// when false, they will be kept so the Kafka cluster remains available for those Kafka clients which are still using the previous ingress setting.
+	// When the access method of an external listener is changed, the resources of the previous one are removed regardless of this
+	// setting once the brokers advertise the addresses of the new one.
 	// +kubebuilder:default=false
 	// +optional
 	RemoveUnusedIngressResources bool                 `json:"removeUnusedIngressResources,omitempty"`
@@ -205,6 +316,8 @@ type KafkaClusterSpec struct {
 	MonitoringConfig             MonitoringConfig     `json:"monitoringConfig,omitempty"`
 	AlertManagerConfig           *AlertManagerConfig  `json:"alertManagerConfig,omitempty"`
 	IstioIngressConfig           IstioIngressConfig   `json:"istioIngressConfig,omitempty"`
+	GatewayAPIConfig             GatewayAPIConfig     `json:"gatewayAPIConfig,omitempty"`
+	NginxIngressConfig           NginxIngressConfig   `json:"nginxIngressConfig,omitempty"`
 	// Envs defines environment variables for Kafka broker Pods.
 	// Adding the "+" prefix to the name prepends the value to that environment variable instead of overwriting it.
 	// Add the "+" suffix to append.
@@ -217,6 +330,294 @@ type KafkaClusterSpec struct {
 	// The secret must contain the keystore, truststore jks files and the password for them in base64 encoded format
 	// under the keystore.jks, truststore.jks, password data fields.
 	ClientSSLCertSecret *corev1.LocalObjectReference `json:"clientSSLCertSecret,omitempty"`
+	// PrincipalConfig defines how the Kafka brokers build the principal of authenticated clients.
+	// Koperator applies the same SSL principal mapping rules when it generates the principals used in the
+	// KafkaUser ACLs and in the super.users broker configuration so that they match what the brokers resolve.
+	// +optional
+	PrincipalConfig *PrincipalConfig `json:"principalConfig,omitempty"`
+	// AuthorizationConfig defines the authorizer of the Kafka brokers and the principals allowed to bypass it.
+	// The fields are rendered into the broker configuration and take precedence over the same properties in readOnlyConfig.
+	// +optional
+	AuthorizationConfig *AuthorizationConfig `json:"authorizationConfig,omitempty"`
+	// InternalTopicsConfig defines how Koperator manages the replication settings of the Kafka internal topics
+	// (__consumer_offsets and __transaction_state) as the number of brokers changes.
+	// +optional
+	InternalTopicsConfig *InternalTopicsConfig `json:"internalTopicsConfig,omitempty"`
+	// KRaftMigration drives the migration of a ZooKeeper based Kafka cluster to KRaft mode.
+	// It requires kRaft to be enabled together with the controller nodes of the KRaft quorum while zkAddresses still
+	// points to the ZooKeeper ensemble of the cluster. The progress of the migration is reported in status.kRaftMigration.
+	// +optional
+	KRaftMigration *KRaftMigrationConfig `json:"kRaftMigration,omitempty"`
+	// VersionUpgrade sequences the upgrade of the Kafka version of the cluster: the brokers are rolled with the images
+	// of the target version first while they keep the protocol of the previous version, the inter broker protocol
+	// version (ZooKeeper mode) or the metadata version (KRaft mode) is bumped once every broker runs the target
+	// version. The progress of the upgrade is reported in status.versionUpgrade.
+	// +optional
+	VersionUpgrade *KafkaVersionUpgradeConfig `json:"versionUpgrade,omitempty"`
+	// BrokerReadiness gates the readiness of the broker pods on an expression over the metrics they expose.
+	// The broker pods get the kafka.banzaicloud.io/broker-ready readiness gate whose condition is set by Koperator,
+	// changing it from or to empty restarts the brokers.
+	// +optional
+	BrokerReadiness *BrokerReadinessConfig `json:"brokerReadiness,omitempty"`
+	// DiskPlacementHints are advisory hints placing the replicas of the topics matching a pattern on the broker disks
+	// of a disk class in heterogeneous JBOD deployments. Cruise Control disk rebalances leave the hinted topics in place
+	// and Koperator reports the misplaced replicas together with the reassignment plan moving them, see
+	// status.diskPlacement. Koperator does not move the replicas itself, the plan is executed with the
+	// kafka-reassign-partitions tool.
+	// +optional
+	DiskPlacementHints []DiskPlacementHint `json:"diskPlacementHints,omitempty"`
+	// VaultConfig configures the HashiCorp Vault issuing the certificates when the vault PKI backend is selected in
+	// listenersConfig.sslSecrets.pkiBackend or in the pkiBackendSpec of a KafkaUser. The vault PKI backend of the
+	// listeners requires listenersConfig.hotReloadCertificates, its certificates are renewed well before they expire.
+	// +optional
+	VaultConfig *VaultConfig `json:"vaultConfig,omitempty"`
+	// DeletionPolicy defines what happens to the persistent volume claims of the brokers when the KafkaCluster is
+	// deleted. Koperator tears the cluster down in order: Cruise Control, the KafkaTopics and KafkaUsers of the cluster,
+	// the ingress resources, the brokers and finally, unless they are retained, their persistent volume claims.
+	// The retained artifacts are reported in an event of the KafkaCluster once the teardown completes.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+	// TopicDeletionProtection is the default of the deletionProtection of the KafkaTopics of the cluster. The topics
+	// of the protected KafkaTopics are left on the Kafka cluster when the KafkaTopics are deleted, e.g. pruned by a
+	// GitOps tool, instead of losing their data.
+	// +optional
+	TopicDeletionProtection bool `json:"topicDeletionProtection,omitempty"`
+	// AdoptExistingTopics lets the KafkaTopics of the cluster adopt the topics which already exist on the Kafka cluster
+	// with a different partition count, replication factor or configuration. The live state of the adopted topic is
+	// imported into the status of the KafkaTopic and only the explicitly set spec fields are reconciled: the partition
+	// count is only increased, the replication factor is changed when it is not -1 and only the configuration
+	// overrides listed in the spec are set, the other overrides of the topic are left untouched.
+	// +optional
+	AdoptExistingTopics bool `json:"adoptExistingTopics,omitempty"`
+	// TieredStorage configures the tiered storage of the brokers (Kafka 3.6+), which offloads the log segments of the
+	// topics with the remote.storage.enable topic configuration to a remote storage through a RemoteStorageManager
+	// plugin. The settings are rendered into the configuration of the broker nodes and take precedence over the same
+	// properties in readOnlyConfig.
+	// +optional
+	TieredStorage *TieredStorageConfig `json:"tieredStorage,omitempty"`
+	// BrokerRescheduling defines how the brokers whose pod can not be scheduled anymore are rescheduled on another
+	// node, e.g. after the drain or the failure of the node holding their local persistent volumes. By default the
+	// pods stay pending until the node returns or their volumes are deleted by hand.
+	// +optional
+	BrokerRescheduling *BrokerReschedulingConfig `json:"brokerRescheduling,omitempty"`
+	// NodeInterruption prepares the brokers for the interruption of their node, e.g. the reclaim of a spot or
+	// preemptible instance. Once the node is tainted by an interruption handler or reports an interruption condition,
+	// the leadership of the partitions of the brokers running on it is moved to other brokers through Cruise Control
+	// and the interruption is reported in status.brokersState[].nodeInterruption.
+	// +optional
+	NodeInterruption *NodeInterruptionConfig `json:"nodeInterruption,omitempty"`
+	// LogDirFailure watches the log directories of the brokers through Cruise Control, reports the offline ones, e.g.
+	// after the failure of a disk of a JBOD broker, in status.brokersState[].logDirFailures and remediates them
+	// according to the remediation policy.
+	// +optional
+	LogDirFailure *LogDirFailureConfig `json:"logDirFailure,omitempty"`
+	// LeaderBalance elects the preferred leaders of the partitions once their leadership is skewed across the brokers,
+	// e.g. after a rolling restart or the recovery of the cluster, and reports the leadership imbalance in
+	// status.leaderBalance.
+	// +optional
+	LeaderBalance *LeaderBalanceConfig `json:"leaderBalance,omitempty"`
+	// MaintenanceWindows are the recurring periods in which the disruptive actions are performed: the rolling restarts
+	// of the brokers, the removal of brokers and the Cruise Control rebalances only start in one of the windows, and
+	// outside of them they wait reported in the Waiting condition. The actions are not restricted when empty.
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+}
+
+// MaintenanceWindow defines a recurring period in which the disruptive actions are performed
+type MaintenanceWindow struct {
+	// Schedule is the cron expression of the start of the window, with the minute, hour, day of month, month and day
+	// of week fields, e.g. "0 22 * * mon-fri" for 10pm on weekdays
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+	// Duration is the length of the window, e.g. "4h"
+	Duration metav1.Duration `json:"duration"`
+	// TimeZone is the name of the time zone of the schedule in the IANA time zone database, e.g. "Europe/Berlin",
+	// defaults to UTC
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// LeaderBalanceConfig defines when the preferred leaders of the partitions are elected
+type LeaderBalanceConfig struct {
+	// ImbalanceThresholdPercentage is the percentage of the partitions not led by their preferred leader above which
+	// the preferred leaders are elected. Defaults to 10, the default of leader.imbalance.per.broker.percentage.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	ImbalanceThresholdPercentage *int32 `json:"imbalanceThresholdPercentage,omitempty"`
+}
+
+// NodeInterruptionConfig defines the signals of the upcoming interruption of a node
+type NodeInterruptionConfig struct {
+	// Taints are the keys of the node taints signaling the interruption of the node. Defaults to the taints of the
+	// AWS Node Termination Handler (aws-node-termination-handler/spot-itn,
+	// aws-node-termination-handler/asg-lifecycle-termination), Karpenter (karpenter.sh/disrupted), GKE
+	// (cloud.google.com/impending-node-termination) and the cluster autoscaler (ToBeDeletedByClusterAutoscaler).
+	// +optional
+	Taints []string `json:"taints,omitempty"`
+	// Conditions are the types of the node conditions signaling the interruption of the node when true, e.g. the
+	// PreemptScheduled condition reported for the Azure spot instances by the node problem detector
+	// +optional
+	Conditions []corev1.NodeConditionType `json:"conditions,omitempty"`
+}
+
+// LogDirRemediationPolicy defines how the offline log directories of the brokers are remediated
+type LogDirRemediationPolicy string
+
+const (
+	// LogDirRemediationNone only reports the offline log directories
+	LogDirRemediationNone LogDirRemediationPolicy = "None"
+	// LogDirRemediationFixOfflineReplicas moves the offline replicas to the healthy brokers with a Cruise Control
+	// fix_offline_replicas operation
+	LogDirRemediationFixOfflineReplicas LogDirRemediationPolicy = "FixOfflineReplicas"
+	// LogDirRemediationRemoveDisks moves the replicas off the offline log directories to the other log directories of
+	// the same broker with a Cruise Control remove_disks operation
+	LogDirRemediationRemoveDisks LogDirRemediationPolicy = "RemoveDisks"
+)
+
+// LogDirFailureConfig defines how the offline log directories of the brokers are detected and remediated
+type LogDirFailureConfig struct {
+	// Remediation is the action taken once a log directory of a broker is offline, None only reports it
+	// +kubebuilder:validation:Enum=None;FixOfflineReplicas;RemoveDisks
+	// +kubebuilder:default=None
+	// +optional
+	Remediation LogDirRemediationPolicy `json:"remediation,omitempty"`
+	// PollIntervalSeconds is the time between the polls of the log directories from Cruise Control, defaults to 60
+	// +kubebuilder:validation:Minimum=10
+	// +optional
+	PollIntervalSeconds *int32 `json:"pollIntervalSeconds,omitempty"`
+}
+
+// BrokerReschedulingPolicy defines how the unschedulable pods of the brokers are rescheduled
+type BrokerReschedulingPolicy string
+
+const (
+	// BrokerReschedulingPolicyManual leaves the unschedulable pods of the brokers pending
+	BrokerReschedulingPolicyManual BrokerReschedulingPolicy = "Manual"
+	// BrokerReschedulingPolicyRecreateVolumes deletes the pod of the broker together with its persistent volume claims
+	// bound to the local volumes of an unavailable node, the broker is recreated on another node with new volumes and
+	// replicates its partitions from the other brokers
+	BrokerReschedulingPolicyRecreateVolumes BrokerReschedulingPolicy = "RecreateVolumes"
+)
+
+// BrokerReschedulingConfig defines the rescheduling of the brokers whose pod can not be scheduled. The volumes of a
+// broker are only recreated when they are node-local persistent volumes (with node affinity) of a node which was
+// removed, cordoned or is not ready, and their storage class binds the volumes with the WaitForFirstConsumer mode,
+// so that the new volumes are provisioned on the node the broker is scheduled to.
+type BrokerReschedulingConfig struct {
+	// +kubebuilder:validation:Enum=Manual;RecreateVolumes
+	// +kubebuilder:default=Manual
+	// +optional
+	Policy BrokerReschedulingPolicy `json:"policy,omitempty"`
+	// PendingTimeoutSeconds is the time the pod of a broker has to be unschedulable before it is rescheduled.
+	// Defaults to 300.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PendingTimeoutSeconds *int32 `json:"pendingTimeoutSeconds,omitempty"`
+	// ForceDeleteLostNodePods force deletes the pods of the brokers whose node was removed or tainted with
+	// node.kubernetes.io/out-of-service, so that they are recreated on another node without waiting for the kubelet of
+	// the lost node. The taint declares that the node has been fenced, the pods of unreachable nodes are not deleted
+	// otherwise as their broker may still be running and serving with the same broker id.
+	// +optional
+	ForceDeleteLostNodePods bool `json:"forceDeleteLostNodePods,omitempty"`
+}
+
+// DeletionPolicy defines what happens to the persistent volume claims of the brokers when the KafkaCluster is deleted
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the persistent volume claims of the brokers together with the KafkaCluster
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyRetain keeps the persistent volume claims of the brokers, they are no longer owned by the KafkaCluster
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+)
+
+// TieredStorageConfig defines the remote storage the brokers offload the log segments to
+type TieredStorageConfig struct {
+	// RemoteStorageManagerClassName is the fully qualified name of the RemoteStorageManager implementation rendered
+	// into the "remote.log.storage.manager.class.name" broker configuration,
+	// e.g. io.aiven.kafka.tieredstorage.RemoteStorageManager
+	// +kubebuilder:validation:Pattern=`^([a-zA-Z_$][a-zA-Z0-9_$]*\.)*[a-zA-Z_$][a-zA-Z0-9_$]*$`
+	RemoteStorageManagerClassName string `json:"remoteStorageManagerClassName"`
+	// RemoteStorageManagerClassPath is rendered into the "remote.log.storage.manager.class.path" broker configuration.
+	// It defaults to the jars copied from the plugin image when pluginImage is set.
+	// +optional
+	RemoteStorageManagerClassPath string `json:"remoteStorageManagerClassPath,omitempty"`
+	// PluginImage is the image of the init container copying the jars of the RemoteStorageManager plugin into the
+	// broker pods. The jars are copied from the pluginPath directory of the image.
+	// +optional
+	PluginImage string `json:"pluginImage,omitempty"`
+	// PluginPath is the directory holding the jars of the plugin in the plugin image
+	// +kubebuilder:default=/plugins
+	// +optional
+	PluginPath string `json:"pluginPath,omitempty"`
+	// PluginInitContainerResources are the resources of the init container copying the jars of the plugin
+	// +optional
+	PluginInitContainerResources *corev1.ResourceRequirements `json:"pluginInitContainerResources,omitempty"`
+	// S3 configures an S3 bucket as the remote storage of the Aiven tiered storage plugin
+	// (io.aiven.kafka.tieredstorage.RemoteStorageManager), it is mutually exclusive with gcs
+	// +optional
+	S3 *TieredStorageS3Config `json:"s3,omitempty"`
+	// GCS configures a Google Cloud Storage bucket as the remote storage of the Aiven tiered storage plugin
+	// (io.aiven.kafka.tieredstorage.RemoteStorageManager), it is mutually exclusive with s3
+	// +optional
+	GCS *TieredStorageGCSConfig `json:"gcs,omitempty"`
+	// RemoteStorageManagerConfig is the configuration of the plugin, the keys are rendered with the "rsm.config."
+	// prefix and take precedence over the ones generated for s3 or gcs. Credentials can be referenced with the
+	// ${secret:<secret name>:<key>} placeholder of a secret in the namespace of the cluster.
+	// +optional
+	RemoteStorageManagerConfig map[string]string `json:"remoteStorageManagerConfig,omitempty"`
+	// RemoteLogMetadataManagerConfig is the configuration of the RemoteLogMetadataManager, the keys are rendered with
+	// the "rlmm.config." prefix, e.g. remote.log.metadata.topic.replication.factor
+	// +optional
+	RemoteLogMetadataManagerConfig map[string]string `json:"remoteLogMetadataManagerConfig,omitempty"`
+}
+
+// TieredStorageS3Config defines the S3 bucket the plugin offloads the log segments to
+type TieredStorageS3Config struct {
+	// Bucket is the name of the bucket
+	Bucket string `json:"bucket"`
+	// Region is the region of the bucket
+	Region string `json:"region"`
+	// Endpoint is the URL of the S3 compatible service, the AWS endpoint of the region is used when it is omitted
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// AccessKeyIDSecretRef references the key of a secret in the namespace of the cluster holding the access key id.
+	// The default credentials provider chain of AWS is used when the access keys are omitted.
+	// +optional
+	AccessKeyIDSecretRef *corev1.SecretKeySelector `json:"accessKeyIdSecretRef,omitempty"`
+	// SecretAccessKeySecretRef references the key of a secret in the namespace of the cluster holding the secret
+	// access key
+	// +optional
+	SecretAccessKeySecretRef *corev1.SecretKeySelector `json:"secretAccessKeySecretRef,omitempty"`
+}
+
+// TieredStorageGCSConfig defines the Google Cloud Storage bucket the plugin offloads the log segments to
+type TieredStorageGCSConfig struct {
+	// Bucket is the name of the bucket
+	Bucket string `json:"bucket"`
+	// CredentialsSecretRef references the key of a secret in the namespace of the cluster holding the JSON
+	// credentials of the service account. The application default credentials are used when it is omitted.
+	// +optional
+	CredentialsSecretRef *corev1.SecretKeySelector `json:"credentialsSecretRef,omitempty"`
+}
+
+// GetPluginPath returns the directory of the jars of the plugin in the plugin image
+func (t *TieredStorageConfig) GetPluginPath() string {
+	if t.PluginPath == "" {
+		return "/plugins"
+	}
+	return t.PluginPath
+}
+
+// GetPluginInitContainerResources returns the resources of the init container copying the jars of the plugin
+func (t *TieredStorageConfig) GetPluginInitContainerResources() *corev1.ResourceRequirements {
+	if t.PluginInitContainerResources != nil {
+		return t.PluginInitContainerResources
+	}
+	return defaultBrokerInitContainerResources()
 }
 
 // KafkaClusterStatus defines the observed state of KafkaCluster
@@ -227,8 +628,65 @@ type KafkaClusterStatus struct {
 	RollingUpgrade           RollingUpgradeStatus     `json:"rollingUpgradeStatus,omitempty"`
 	AlertCount               int                      `json:"alertCount"`
 	ListenerStatuses         ListenerStatuses         `json:"listenerStatuses,omitempty"`
+	// ListenerEndpoints holds the ready-to-use bootstrap endpoints of the internal and external listeners for the
+	// clients, derived from the listener statuses
+	// +optional
+	ListenerEndpoints *ListenerEndpoints `json:"listenerEndpoints,omitempty"`
 	// ClusterID is a base64-encoded random UUID generated by Koperator to run the Kafka cluster in KRaft mode
 	ClusterID string `json:"clusterID,omitempty"`
+	// Conditions represent the latest available observations of the KafkaCluster
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// KRaftMigration holds the progress of the ZooKeeper to KRaft migration
+	// +optional
+	KRaftMigration *KRaftMigrationStatus `json:"kRaftMigration,omitempty"`
+	// VersionUpgrade holds the progress of the upgrade of the Kafka version
+	// +optional
+	VersionUpgrade *KafkaVersionUpgradeStatus `json:"versionUpgrade,omitempty"`
+	// ControllerQuorum holds the health of the KRaft controller quorum
+	// +optional
+	ControllerQuorum *ControllerQuorumStatus `json:"controllerQuorum,omitempty"`
+	// ExternalListenersAccess holds the access method of the external listeners by their name and the progress of
+	// their change
+	// +optional
+	ExternalListenersAccess map[string]ExternalListenerAccessStatus `json:"externalListenersAccess,omitempty"`
+	// DetectedVersions holds the effective versions of Kafka and Cruise Control and their incompatibilities
+	// +optional
+	DetectedVersions *DetectedVersionsStatus `json:"detectedVersions,omitempty"`
+	// DiskPlacement holds the replicas violating the disk placement hints
+	// +optional
+	DiskPlacement *DiskPlacementStatus `json:"diskPlacement,omitempty"`
+	// ConformanceAudit holds the outcome of the periodic audit of the cluster against the best-practice rules, it is
+	// only set when the audit is enabled in the operator
+	// +optional
+	ConformanceAudit *ConformanceAuditStatus `json:"conformanceAudit,omitempty"`
+	// CruiseControlAnomalies holds the anomalies recently detected by the Cruise Control anomaly detector, it is only
+	// set when cruiseControlConfig.selfHealing is configured
+	// +optional
+	CruiseControlAnomalies *CruiseControlAnomaliesStatus `json:"cruiseControlAnomalies,omitempty"`
+	// Plan holds the changes the reconciliation of the cluster would make, it is only set while the cluster is
+	// annotated with kafka.banzaicloud.io/plan-only
+	// +optional
+	Plan *ReconcilePlanStatus `json:"plan,omitempty"`
+	// LeaderBalance holds the leadership imbalance of the partitions and the last election of their preferred leaders,
+	// it is only set when leaderBalance is configured
+	// +optional
+	LeaderBalance *LeaderBalanceStatus `json:"leaderBalance,omitempty"`
+	// RebalanceSchedule holds the last and next runs of the scheduled rebalances, it is only set when
+	// cruiseControlConfig.rebalanceSchedule is configured
+	// +optional
+	RebalanceSchedule *RebalanceScheduleStatus `json:"rebalanceSchedule,omitempty"`
+	// BrokerDecommissions holds the progress and the report of the decommissions of the brokers by broker id, the
+	// report is kept after the broker has been removed
+	// +optional
+	BrokerDecommissions map[string]BrokerDecommissionStatus `json:"brokerDecommissions,omitempty"`
+	// OrphanedTopics are the topics left on the Kafka cluster by the deletion of their deletion-protected KafkaTopics,
+	// the topic discovery does not import them. A topic is removed from the list once it is deleted from the Kafka
+	// cluster or managed by a KafkaTopic again.
+	// +optional
+	OrphanedTopics []string `json:"orphanedTopics,omitempty"`
 }
 
 // RollingUpgradeStatus defines status of rolling upgrade
@@ -237,6 +695,23 @@ type RollingUpgradeStatus struct {
 	// ErrorCount keeps track the number of errors reported by alerts labeled with 'rollingupgrade'.
 	// It's reset once these alerts stop firing.
 	ErrorCount int `json:"errorCount"`
+	// Progress holds the brokers restarted by the rolling upgrade in progress when rollingUpgradeConfig.pauseAfterCanary
+	// is set, it is cleared once the rolling upgrade completes
+	// +optional
+	Progress *RollingUpgradeProgress `json:"progress,omitempty"`
+}
+
+// RollingUpgradeProgress defines the progress of the rolling upgrade in progress
+type RollingUpgradeProgress struct {
+	// CanaryBrokerID is the broker restarted first by the rolling upgrade
+	// +optional
+	CanaryBrokerID string `json:"canaryBrokerId,omitempty"`
+	// Paused is set while the rolling upgrade waits to be resumed after the restart of the canary broker
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+	// RestartedBrokers are the brokers restarted by the rolling upgrade, in the order of their restart
+	// +optional
+	RestartedBrokers []string `json:"restartedBrokers,omitempty"`
 }
 
 // RollingUpgradeConfig defines the desired config of the RollingUpgrade
@@ -258,6 +733,139 @@ type RollingUpgradeConfig struct {
 	// +kubebuilder:default=1
 	// +optional
 	ConcurrentBrokerRestartCountPerRack int `json:"concurrentBrokerRestartCountPerRack,omitempty"`
+
+	// VerifyNoSharedReplicas makes the operator check the cluster metadata before restarting a broker concurrently with
+	// others in the same rack: the broker is only restarted if it does not host a replica of any partition together with
+	// the brokers being restarted. This guarantees that no topic-partition loses more than one replica at once
+	// independently of how the replicas are spread across racks, thus Cruise Control's RackAwareDistributionGoal is not
+	// required and ConcurrentBrokerRestartCountPerRack can be safely set to higher values.
+	// +optional
+	VerifyNoSharedReplicas bool `json:"verifyNoSharedReplicas,omitempty"`
+
+	// SmokeTest configures the produce/consume verification run against every broker once a rolling restart or upgrade
+	// completed. The rolling upgrade is only marked successful when all brokers serve traffic, failures are reported
+	// through the RollbackRequired condition of the KafkaCluster.
+	// +optional
+	SmokeTest *RollingUpgradeSmokeTest `json:"smokeTest,omitempty"`
+
+	// Hooks configures the checks gating the restart of each broker during rolling upgrades, e.g. on consumer lag or
+	// custom SLOs. A broker is only restarted once its pre-restart hooks passed, the next broker is only restarted (and
+	// the rolling upgrade only completes) once the post-restart hooks of the previously restarted brokers passed.
+	// +optional
+	Hooks *RollingUpgradeHooks `json:"hooks,omitempty"`
+
+	// PauseAfterCanary pauses rolling upgrades once the first (canary) broker is restarted, so that it can be verified
+	// before the other brokers are restarted. The rolling upgrade is resumed by annotating the KafkaCluster with
+	// kafka.banzaicloud.io/resume-rolling-upgrade. The progress of the rolling upgrade is kept in the status of the
+	// KafkaCluster, thus the pause survives restarts of the operator.
+	// +optional
+	PauseAfterCanary bool `json:"pauseAfterCanary,omitempty"`
+
+	// PrometheusReplicationCheck makes the operator consult Prometheus for under-replicated or offline partitions before
+	// restarting the next broker of a concurrent restart batch. The brokers reported by Prometheus are counted as
+	// failures against FailureThreshold, thus the next broker is only restarted once the replicas of the brokers being
+	// restarted are back in sync. The check fails closed: the rolling upgrade waits while Prometheus is unreachable.
+	// +optional
+	PrometheusReplicationCheck *PrometheusReplicationCheck `json:"prometheusReplicationCheck,omitempty"`
+}
+
+// PrometheusReplicationCheck defines the Prometheus query or alerts reporting under-replicated or offline partitions,
+// at most one of query and alerts can be set. The $(CLUSTER_NAME) and $(NAMESPACE) placeholders of the query are
+// replaced with the values of the cluster.
+type PrometheusReplicationCheck struct {
+	// PrometheusURL is the base URL of the Prometheus API, e.g. http://prometheus.monitoring:9090
+	PrometheusURL string `json:"prometheusURL"`
+	// Query is the PromQL instant query returning a series for each broker with under-replicated or offline
+	// partitions. Defaults to the under-replicated and offline partition count metrics of the brokers of the cluster.
+	// +optional
+	Query string `json:"query,omitempty"`
+	// Alerts are the names of the Prometheus alerts reporting under-replicated or offline partitions, each firing
+	// alert is counted as a failure
+	// +optional
+	Alerts []string `json:"alerts,omitempty"`
+	// TimeoutSeconds is the time the Prometheus query has to complete. Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// RollingUpgradeHooks defines the checks run before and after each broker restart during rolling upgrades
+type RollingUpgradeHooks struct {
+	// PreRestart hooks must all pass before a broker is restarted
+	// +optional
+	PreRestart []RollingUpgradeHook `json:"preRestart,omitempty"`
+	// PostRestart hooks must all pass after a broker was restarted
+	// +optional
+	PostRestart []RollingUpgradeHook `json:"postRestart,omitempty"`
+}
+
+// RollingUpgradeHook defines a check gating the restart of a broker, exactly one of http, promQL and job must be set.
+// The $(CLUSTER_NAME), $(NAMESPACE) and $(BROKER_ID) placeholders of the URLs, the query and the job command and
+// arguments are replaced with the values of the cluster and the restarted broker.
+type RollingUpgradeHook struct {
+	// Name identifies the hook in the status and the events of the cluster
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +kubebuilder:validation:MaxLength=20
+	Name string `json:"name"`
+	// HTTP passes when a GET request to the URL returns a 2xx status code
+	// +optional
+	HTTP *HTTPRestartHook `json:"http,omitempty"`
+	// PromQL passes when the instant query returns a non-empty vector or a non-zero scalar, e.g.
+	// `sum(kafka_consumergroup_lag{topic="orders"}) < 1000`
+	// +optional
+	PromQL *PromQLRestartHook `json:"promQL,omitempty"`
+	// Job passes when the Job created from the container completes successfully
+	// +optional
+	Job *JobRestartHook `json:"job,omitempty"`
+	// TimeoutSeconds is the time the request of an HTTP or PromQL hook, or the Job of a job hook, has to complete.
+	// Defaults to 10 for HTTP and PromQL hooks and 300 for job hooks.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// HTTPRestartHook defines the HTTP probe of a restart hook
+type HTTPRestartHook struct {
+	// URL is the URL the GET request is sent to
+	URL string `json:"url"`
+}
+
+// PromQLRestartHook defines the Prometheus query of a restart hook
+type PromQLRestartHook struct {
+	// PrometheusURL is the base URL of the Prometheus API, e.g. http://prometheus.monitoring:9090
+	PrometheusURL string `json:"prometheusURL"`
+	// Query is the PromQL instant query
+	Query string `json:"query"`
+}
+
+// JobRestartHook defines the Job of a restart hook
+type JobRestartHook struct {
+	// Image is the container image of the Job
+	Image string `json:"image"`
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// ServiceAccountName is the service account the Job runs with
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+// RollingUpgradeSmokeTest defines the produce/consume verification run after rolling upgrades
+type RollingUpgradeSmokeTest struct {
+	// Enabled turns on the smoke test after rolling upgrades
+	Enabled bool `json:"enabled"`
+	// Topic is the name of the topic used by the smoke test. The topic is created by the operator with a single
+	// replica partition on each broker so that every broker is addressed directly as partition leader.
+	// Defaults to koperator-smoke-test.
+	// +optional
+	Topic string `json:"topic,omitempty"`
+	// TimeoutSeconds is the time each broker has to acknowledge the produced message and serve it back. Defaults to 30.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
 }
 
 // DisruptionBudget defines the configuration for PodDisruptionBudget where the workload is managed by the kafka-operator
@@ -285,10 +893,36 @@ type Broker struct {
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=65535
 	// +kubebuilder:validation:ExclusiveMaximum=true
-	Id                int32         `json:"id"`
-	BrokerConfigGroup string        `json:"brokerConfigGroup,omitempty"`
-	ReadOnlyConfig    string        `json:"readOnlyConfig,omitempty"`
-	BrokerConfig      *BrokerConfig `json:"brokerConfig,omitempty"`
+	Id                int32  `json:"id"`
+	BrokerConfigGroup string `json:"brokerConfigGroup,omitempty"`
+	// ReadOnlyConfig is the read-only configuration of the broker merged over the one of the cluster, it supports the
+	// same secret and environment variable placeholders
+	ReadOnlyConfig string        `json:"readOnlyConfig,omitempty"`
+	BrokerConfig   *BrokerConfig `json:"brokerConfig,omitempty"`
+	// Replacement requests the replacement of the broker with a new one using the same id, e.g. to move it to a
+	// re-provisioned node or to another zone according to the updated scheduling constraints. The leadership of its
+	// partitions is moved away, its pod is deleted together with its volumes unless they are kept, then the broker is
+	// recreated and re-syncs its partition replicas. Unlike removing and adding a broker, the partition replicas are
+	// not moved between the brokers by Cruise Control.
+	// +optional
+	Replacement *BrokerReplacement `json:"replacement,omitempty"`
+	// Decommission requests the guarded removal of the broker. Cruise Control moves its partition replicas away, then
+	// the removal waits until the cluster has no under-replicated partitions, records the report of the removal in the
+	// brokerDecommissions of the status and removes the broker from the brokers list, which deletes its pod and volumes.
+	// +optional
+	Decommission bool `json:"decommission,omitempty"`
+}
+
+// BrokerReplacement defines a replacement of a broker preserving its id
+type BrokerReplacement struct {
+	// ID identifies the replacement, the broker is replaced once for every new id
+	// +kubebuilder:validation:MinLength=1
+	ID string `json:"id"`
+	// KeepVolumes keeps the persistent volume claims of the broker, so the recreated broker reattaches its data when
+	// the volumes can be attached on its new node. Otherwise the broker is recreated on new volumes and re-syncs all of
+	// its partition replicas from the other brokers.
+	// +optional
+	KeepVolumes bool `json:"keepVolumes,omitempty"`
 }
 
 // BrokerConfig defines the broker configuration
@@ -313,6 +947,12 @@ type BrokerConfig struct {
 	KafkaJVMPerfOpts     string                        `json:"kafkaJvmPerfOpts,omitempty"`
 	// Override for the default log4j configuration
 	Log4jConfig string `json:"log4jConfig,omitempty"`
+	// ConfigMapRef references a key of a ConfigMap in the namespace of the KafkaCluster holding broker configuration in
+	// properties format which is maintained outside of the KafkaCluster resource. It is merged beneath the readOnlyConfig
+	// and the operator managed settings, those take precedence on conflicting properties. A change of the referenced
+	// content is detected by its hash and rolled out like any other broker configuration change.
+	// +optional
+	ConfigMapRef *corev1.ConfigMapKeySelector `json:"configMapRef,omitempty"`
 	// Custom annotations for the broker pods - e.g.: Prometheus scraping annotations:
 	// prometheus.io/scrape: "true"
 	// prometheus.io/port: "9020"
@@ -351,6 +991,18 @@ type BrokerConfig struct {
 	BrokerIngressMapping []string `json:"brokerIngressMapping,omitempty"`
 	// InitContainers add extra initContainers to the Kafka broker pod
 	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+	// MetricsReporterInitContainerResources defines the resources of the init container copying the Cruise Control
+	// metrics reporter into the Kafka broker pod
+	// +optional
+	MetricsReporterInitContainerResources *corev1.ResourceRequirements `json:"metricsReporterInitContainerResources,omitempty"`
+	// JmxExporterInitContainerResources defines the resources of the init container copying the JMX exporter java
+	// agent into the Kafka broker pod, the agent itself runs in the kafka container
+	// +optional
+	JmxExporterInitContainerResources *corev1.ResourceRequirements `json:"jmxExporterInitContainerResources,omitempty"`
+	// JmxExporter overrides the Prometheus JMX exporter of the monitoringConfig for the brokers of the group, e.g. to
+	// run a different exporter version or rule set for a subset of the brokers
+	// +optional
+	JmxExporter *BrokerJmxExporterConfig `json:"jmxExporter,omitempty"`
 	// Containers add extra Containers to the Kafka broker pod
 	Containers []corev1.Container `json:"containers,omitempty"`
 	// Volumes define some extra Kubernetes Volumes for the Kafka broker Pods.
@@ -370,6 +1022,90 @@ type BrokerConfig struct {
 	// If not specified, the broker pods' priority is default to zero.
 	// +optional
 	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// Lifecycle configures additional lifecycle hooks of the kafka container. It is intended for latency-sensitive
+	// clusters where cold broker restarts with an empty page cache cause consumer timeouts.
+	// +optional
+	Lifecycle *BrokerLifecycle `json:"lifecycle,omitempty"`
+	// PlacementPolicy generates topology spread constraints and zone-aware node affinity for the broker pods, so the
+	// brokers of multi-AZ clusters are distributed evenly across the zones without raw affinity definitions
+	// +optional
+	PlacementPolicy *BrokerPlacementPolicy `json:"placementPolicy,omitempty"`
+}
+
+// BrokerPlacementPolicy defines how the broker pods are spread across the failure domains of the Kubernetes cluster
+type BrokerPlacementPolicy struct {
+	// TopologyKey is the node label key identifying the failure domain the broker pods are spread across
+	// +kubebuilder:default="topology.kubernetes.io/zone"
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
+	// MaxSkew is the maximum permitted difference between the number of broker pods of any two failure domains
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	MaxSkew *int32 `json:"maxSkew,omitempty"`
+	// WhenUnsatisfiable tells the scheduler what to do with a broker pod that does not satisfy the spread constraint
+	// +kubebuilder:validation:Enum=DoNotSchedule;ScheduleAnyway
+	// +kubebuilder:default=DoNotSchedule
+	// +optional
+	WhenUnsatisfiable corev1.UnsatisfiableConstraintAction `json:"whenUnsatisfiable,omitempty"`
+	// Zones restricts the broker pods to the nodes of the listed failure domains through a required node affinity.
+	// It is ignored when the node affinity of the broker is defined through the affinity field.
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+}
+
+// BrokerJmxExporterConfig defines the broker specific overrides of the Prometheus JMX exporter java agent
+type BrokerJmxExporterConfig struct {
+	// Image is the image the JMX exporter java agent is copied from, it overrides monitoringConfig.jmxImage
+	// +optional
+	Image string `json:"image,omitempty"`
+	// PathToJar is the path of the JMX exporter java agent in the image, it overrides monitoringConfig.pathToJar
+	// +optional
+	PathToJar string `json:"pathToJar,omitempty"`
+	// Port is the container port the JMX exporter serves the metrics of the broker on
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default=9020
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+	// Resources defines the resources of the init container copying the JMX exporter java agent into the broker pod,
+	// it takes precedence over jmxExporterInitContainerResources
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// RulesConfigMapRef references a key of a ConfigMap in the namespace of the KafkaCluster holding the JMX exporter
+	// configuration used instead of monitoringConfig.kafkaJMXExporterConfig. A change of the referenced content is
+	// detected by its hash and rolled out to the brokers one by one.
+	// +optional
+	RulesConfigMapRef *corev1.ConfigMapKeySelector `json:"rulesConfigMapRef,omitempty"`
+}
+
+// BrokerLifecycle defines the optional lifecycle hooks of the kafka container
+type BrokerLifecycle struct {
+	// PostStart is a custom handler executed right after the kafka container is created, e.g. to pre-touch files,
+	// pin pages into memory or restore a previously taken checkpoint.
+	// It takes precedence over the postStart hook generated for PageCacheWarmup.
+	// +optional
+	PostStart *corev1.LifecycleHandler `json:"postStart,omitempty"`
+	// PageCacheWarmup enables a built-in postStart hook that reads the log data of the broker into the page cache
+	// +optional
+	PageCacheWarmup *PageCacheWarmup `json:"pageCacheWarmup,omitempty"`
+}
+
+// PageCacheWarmup defines how the page cache of the broker is warmed up after the kafka container is (re)started
+type PageCacheWarmup struct {
+	// IndexFiles controls whether the offset and time index files of the log dirs are read into the page cache
+	// +kubebuilder:default=true
+	// +optional
+	IndexFiles *bool `json:"indexFiles,omitempty"`
+	// PreserveActiveSegments when true the preStop hook checkpoints the most recently modified log segments of every
+	// storage into a file and the postStart hook reads those segments back into the page cache after the restart
+	// +optional
+	PreserveActiveSegments bool `json:"preserveActiveSegments,omitempty"`
+	// MaxSegmentsPerStorage limits the number of log segments checkpointed per storage
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=50
+	// +optional
+	MaxSegmentsPerStorage *int32 `json:"maxSegmentsPerStorage,omitempty"`
 }
 
 type NetworkConfig struct {
@@ -416,6 +1152,85 @@ type CruiseControlConfig struct {
 	// If not specified, the CruiseControl pod's priority is default to zero.
 	// +optional
 	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// SelfHealing surfaces the anomalies detected by the Cruise Control anomaly detector in the status of the cluster
+	// and optionally remediates them with CruiseControlOperations
+	// +optional
+	SelfHealing *CruiseControlSelfHealing `json:"selfHealing,omitempty"`
+	// CruiseControlRef references a CruiseControl in the namespace of the cluster whose template deploys Cruise Control
+	// for the cluster instead of the deployment related fields above, so Cruise Control can be upgraded and restarted
+	// independently of the cluster and its template shared by multiple clusters
+	// +optional
+	CruiseControlRef *corev1.LocalObjectReference `json:"cruiseControlRef,omitempty"`
+	// RebalanceSchedule makes the operator rebalance the cluster periodically with CruiseControlOperations, e.g. in
+	// the maintenance windows
+	// +optional
+	RebalanceSchedule *RebalanceSchedule `json:"rebalanceSchedule,omitempty"`
+}
+
+// WithDeploymentOf returns the configuration with the deployment related fields taken from the given template, the
+// fields configuring how the operator uses Cruise Control and its metrics topic are kept
+func (cConfig CruiseControlConfig) WithDeploymentOf(template CruiseControlConfig) CruiseControlConfig {
+	config := *template.DeepCopy()
+	config.CruiseControlTaskSpec = cConfig.CruiseControlTaskSpec
+	config.CruiseControlOperationSpec = cConfig.CruiseControlOperationSpec
+	config.CruiseControlEndpoint = cConfig.CruiseControlEndpoint
+	config.TopicConfig = cConfig.TopicConfig
+	config.SelfHealing = cConfig.SelfHealing
+	config.CruiseControlRef = cConfig.CruiseControlRef
+	config.RebalanceSchedule = cConfig.RebalanceSchedule
+	return config
+}
+
+// RebalanceSchedule defines when the cluster is rebalanced periodically
+type RebalanceSchedule struct {
+	// Schedule is the cron expression of the rebalance runs in UTC, with the minute, hour, day of month, month and
+	// day of week fields, e.g. "0 2 * * sat,sun" for 2am on weekends
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+	// Goals are the Cruise Control goals the scheduled rebalances optimize for, the ready default goals of Cruise
+	// Control are used when empty
+	// +optional
+	Goals []string `json:"goals,omitempty"`
+	// StartingDeadlineSeconds is the time after a scheduled run within which the run is still started when missed,
+	// e.g. while the operator was down, so the rebalances are not started outside the maintenance window. Defaults
+	// to 900.
+	// +kubebuilder:validation:Minimum=60
+	// +optional
+	StartingDeadlineSeconds *int32 `json:"startingDeadlineSeconds,omitempty"`
+}
+
+// GetStartingDeadline returns the time after a scheduled run within which the run is still started
+func (s *RebalanceSchedule) GetStartingDeadline() time.Duration {
+	if s == nil || s.StartingDeadlineSeconds == nil {
+		return defaultRebalanceScheduleStartingDeadline
+	}
+	return time.Duration(*s.StartingDeadlineSeconds) * time.Second
+}
+
+// CruiseControlSelfHealing defines how the anomalies detected by Cruise Control are reported and remediated
+type CruiseControlSelfHealing struct {
+	// PollIntervalSeconds is the time between the polls of the Cruise Control anomaly detector state, defaults to 60
+	// +kubebuilder:validation:Minimum=10
+	// +optional
+	PollIntervalSeconds *int32 `json:"pollIntervalSeconds,omitempty"`
+	// Remediations are the types of the anomalies remediated by CruiseControlOperations created by the operator,
+	// goal violations are remediated by a rebalance, broker and disk failures by moving the offline replicas.
+	// The self-healing of Cruise Control itself must be disabled for these anomaly types.
+	// +optional
+	Remediations []CruiseControlAnomalyType `json:"remediations,omitempty"`
+}
+
+// GetPollInterval returns the time between the polls of the anomaly detector state
+func (s *CruiseControlSelfHealing) GetPollInterval() time.Duration {
+	if s == nil || s.PollIntervalSeconds == nil {
+		return defaultSelfHealingPollInterval
+	}
+	return time.Duration(*s.PollIntervalSeconds) * time.Second
+}
+
+// IsRemediated returns true when the anomalies of the given type are remediated by the operator
+func (s *CruiseControlSelfHealing) IsRemediated(anomalyType CruiseControlAnomalyType) bool {
+	return s != nil && slices.Contains(s.Remediations, anomalyType)
 }
 
 // CruiseControlOperationSpec specifies the configuration of the CruiseControlOperation handling
@@ -499,6 +1314,41 @@ type EnvoyConfig struct {
 	// PodSecurityContext holds pod-level security attributes and common container
 	// settings for the Envoy pods.
 	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// ListenerConfig holds the settings of the Envoy listeners serving the Kafka traffic
+	// +optional
+	ListenerConfig *EnvoyListenerConfig `json:"listenerConfig,omitempty"`
+}
+
+// EnvoyListenerConfig defines the settings of the Envoy listeners serving the Kafka traffic. The limits apply to
+// each broker and to the any cast filter chain of every Envoy replica separately.
+type EnvoyListenerConfig struct {
+	// MaxConnections is the maximum number of concurrent client connections, new connections above it are closed
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxConnections *int64 `json:"maxConnections,omitempty"`
+	// ConnectionRateLimit limits the rate at which new client connections are accepted
+	// +optional
+	ConnectionRateLimit *EnvoyConnectionRateLimit `json:"connectionRateLimit,omitempty"`
+	// ProxyProtocol enables the PROXY protocol on the listeners to preserve the client address when Envoy is behind
+	// a load balancer sending it, connections without the PROXY protocol header are rejected
+	// +optional
+	ProxyProtocol bool `json:"proxyProtocol,omitempty"`
+	// IdleTimeout is the time after which connections without traffic are closed, defaults to 560s
+	// +optional
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty"`
+}
+
+// EnvoyConnectionRateLimit defines a token bucket limiting the new client connections, each connection consumes a token
+type EnvoyConnectionRateLimit struct {
+	// MaxTokens is the size of the token bucket, the number of connections which can be accepted in a burst
+	// +kubebuilder:validation:Minimum=1
+	MaxTokens int32 `json:"maxTokens"`
+	// TokensPerFill is the number of tokens added to the bucket in every fill interval, defaults to 1
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TokensPerFill *int32 `json:"tokensPerFill,omitempty"`
+	// FillInterval is the interval the bucket is refilled with, it must be at least 50ms
+	FillInterval metav1.Duration `json:"fillInterval"`
 }
 
 // EnvoyCommandLineArgs defines envoy command line arguments
@@ -530,6 +1380,32 @@ type IstioIngressConfig struct {
 	// More info: https://kubernetes.io/docs/tasks/access-application-cluster/configure-cloud-provider-firewall/
 	// +optional
 	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+	// MeshMode is the Istio data plane mode of the brokers. In sidecar mode the Envoy sidecar injected into the broker
+	// pods handles their traffic, in ambient mode the broker pods are enrolled into the ambient mesh without sidecars,
+	// their traffic is handled by ztunnel and a waypoint proxy created for the cluster.
+	// The mesh mode of the brokers is set by the cluster wide `spec.istioIngressConfig`, the mesh mode of the
+	// external listeners must match it.
+	// +kubebuilder:validation:Enum=sidecar;ambient
+	// +optional
+	MeshMode IstioMeshMode `json:"meshMode,omitempty"`
+}
+
+// IstioMeshMode is the data plane mode of the Istio mesh the brokers are part of
+type IstioMeshMode string
+
+const (
+	// IstioMeshModeSidecar injects an Envoy sidecar into the broker pods
+	IstioMeshModeSidecar IstioMeshMode = "sidecar"
+	// IstioMeshModeAmbient enrolls the broker pods into the ambient mesh, handled by ztunnel and a waypoint proxy
+	IstioMeshModeAmbient IstioMeshMode = "ambient"
+)
+
+// GetMeshMode returns the Istio data plane mode of the brokers, defaults to sidecar
+func (iIConfig *IstioIngressConfig) GetMeshMode() IstioMeshMode {
+	if iIConfig.MeshMode == "" {
+		return IstioMeshModeSidecar
+	}
+	return iIConfig.MeshMode
 }
 
 func (iIConfig *IstioIngressConfig) GetAnnotations() map[string]string {
@@ -552,6 +1428,37 @@ type MonitoringConfig struct {
 	PathToJar              string `json:"pathToJar,omitempty"`
 	KafkaJMXExporterConfig string `json:"kafkaJMXExporterConfig,omitempty"`
 	CCJMXExporterConfig    string `json:"cCJMXExporterConfig,omitempty"`
+	// PrometheusRules configures the PrometheusRule with the alerts of the cluster generated by the operator
+	// +optional
+	PrometheusRules *PrometheusRulesConfig `json:"prometheusRules,omitempty"`
+	// GrafanaDashboard configures the ConfigMap with the Grafana dashboard of the cluster generated by the operator
+	// +optional
+	GrafanaDashboard *GrafanaDashboardConfig `json:"grafanaDashboard,omitempty"`
+}
+
+// PrometheusRulesConfig defines the PrometheusRule (Prometheus Operator resource) generated with the alerts of the
+// under-replicated and offline partitions, the broker volumes nearing capacity and the Cruise Control anomalies
+type PrometheusRulesConfig struct {
+	// Enabled generates the PrometheusRule of the cluster, it is removed when disabled
+	Enabled bool `json:"enabled"`
+	// Labels are added to the PrometheusRule, e.g. to match the ruleSelector of Prometheus
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// DiskUsageThresholdPercent is the usage of a broker volume the disk capacity alert fires above, 80 by default
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	// +optional
+	DiskUsageThresholdPercent *int32 `json:"diskUsageThresholdPercent,omitempty"`
+}
+
+// GrafanaDashboardConfig defines the ConfigMap generated with the Grafana dashboard of the brokers of the cluster
+type GrafanaDashboardConfig struct {
+	// Enabled generates the ConfigMap of the Grafana dashboard of the cluster, it is removed when disabled
+	Enabled bool `json:"enabled"`
+	// Labels are added to the ConfigMap, `grafana_dashboard: "1"` by default for the dashboard sidecar of Grafana to
+	// pick it up
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 // StorageConfig defines the broker storage configuration
@@ -572,90 +1479,831 @@ type StorageConfig struct {
 	// When both `pvcSpec` and `emptyDir` fields are set
 	// the `pvcSpec` is used by default.
 	// +optional
-	EmptyDir *corev1.EmptyDirVolumeSource `json:"emptyDir,omitempty"`
+	EmptyDir *corev1.EmptyDirVolumeSource `json:"emptyDir,omitempty"`
+
+	// DiskClass is the class of the disk mounted on mountPath, e.g. nvme or hdd, the disk placement hints refer to it
+	// +optional
+	DiskClass string `json:"diskClass,omitempty"`
+}
+
+// ListenersConfig defines the Kafka listener types
+type ListenersConfig struct {
+	ExternalListeners  []ExternalListenerConfig `json:"externalListeners,omitempty"`
+	InternalListeners  []InternalListenerConfig `json:"internalListeners"`
+	SSLSecrets         *SSLSecrets              `json:"sslSecrets,omitempty"`
+	ServiceAnnotations map[string]string        `json:"serviceAnnotations,omitempty"`
+	// HotReloadCertificates makes the running brokers reload the keystores and truststores of their SSL listeners
+	// through the dynamic broker configuration when the certificates are renewed, instead of serving the old
+	// certificates until they are restarted
+	// +optional
+	HotReloadCertificates bool `json:"hotReloadCertificates,omitempty"`
+	// CABundleDistribution publishes the CA chains of the SSL external listeners into other namespaces, so client
+	// workloads can mount the truststore without copying it manually
+	// +optional
+	CABundleDistribution *CABundleDistribution `json:"caBundleDistribution,omitempty"`
+	// Krb5ConfigMapRef selects the Kerberos configuration (krb5.conf) of the realms of the brokers with Kerberos
+	// listeners in a ConfigMap in the namespace of the KafkaCluster. It is mounted to /etc/krb5.conf of the brokers.
+	// +optional
+	Krb5ConfigMapRef *corev1.ConfigMapKeySelector `json:"krb5ConfigMapRef,omitempty"`
+}
+
+// CABundleDistribution defines the namespaces the CA chains of the SSL external listeners are published into. The
+// published ConfigMaps hold the PEM encoded CA certificates under the ca.crt key, they are kept up to date when the
+// CA is rotated and can be used as the source of a trust-manager Bundle as well.
+type CABundleDistribution struct {
+	// ConfigMapName is the name of the published ConfigMaps, defaults to <cluster name>-<cluster namespace>-ca-bundle
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+	// Namespaces lists the namespaces the CA bundle is published into
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// NamespaceSelector selects further namespaces the CA bundle is published into
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// GetConfigMapName returns the name of the ConfigMaps the CA bundle of the given cluster is published into
+func (c *CABundleDistribution) GetConfigMapName(clusterName, clusterNamespace string) string {
+	if c.ConfigMapName != "" {
+		return c.ConfigMapName
+	}
+	return fmt.Sprintf("%s-%s-ca-bundle", clusterName, clusterNamespace)
+}
+
+// GetServiceAnnotations returns a copy of the ServiceAnnotations field.
+func (c ListenersConfig) GetServiceAnnotations() map[string]string {
+	return util.CloneMap(c.ServiceAnnotations)
+}
+
+func (c ExternalListenerConfig) GetAccessMethod() corev1.ServiceType {
+	if c.AccessMethod == "" {
+		return corev1.ServiceTypeLoadBalancer
+	}
+	return c.AccessMethod
+}
+
+func (c ExternalListenerConfig) GetAnyCastPort() int32 {
+	if c.AnyCastPort == nil {
+		return defaultAnyCastPort
+	}
+	return *c.AnyCastPort
+}
+
+// GetIngressControllerTargetPort returns the IngressControllerTargetPort if it is defined,
+// otherwise it returns the DefaultIngressControllerTargetPort value
+func (c ExternalListenerConfig) GetIngressControllerTargetPort() int32 {
+	if c.IngressControllerTargetPort == nil {
+		return defaultIngressControllerTargetPort
+	}
+	return *c.IngressControllerTargetPort
+}
+
+// GetBrokerPort - When TLS is enabled AnyCastPort is enough since hostname based multiplexing
+// is used and not port based one
+func (c ExternalListenerConfig) GetBrokerPort(brokerId int32) int32 {
+	if c.TLSEnabled() {
+		return c.GetAnyCastPort()
+	} else {
+		return c.ExternalStartingPort + brokerId
+	}
+}
+
+// GetBrokerHostname replaces %id in brokerHostnameTemplate with the actual broker id
+func (c *PerBrokerLoadBalancerConfig) GetBrokerHostname(brokerId int32) string {
+	if c == nil {
+		return ""
+	}
+	return strings.Replace(c.BrokerHostnameTemplate, "%id", strconv.Itoa(int(brokerId)), 1)
+}
+
+// GetServiceAnnotations returns a copy of the ServiceAnnotations field.
+func (c IngressServiceSettings) GetServiceAnnotations() map[string]string {
+	return util.CloneMap(c.ServiceAnnotations)
+}
+
+// GetServiceType returns the field value of ServiceType defaults to LoadBalancer.
+func (c IngressServiceSettings) GetServiceType() corev1.ServiceType {
+	if c.ServiceType == "" {
+		return corev1.ServiceTypeLoadBalancer
+	}
+	return c.ServiceType
+}
+
+func (c ContourIngressConfig) GetBrokerFqdn(brokerId int32) string {
+	return strings.Replace(c.BrokerFQDNTemplate, "%id", strconv.Itoa(int(brokerId)), 1)
+}
+
+// GetControllerNamespace returns the namespace of the ingress-nginx controller, defaults to ingress-nginx
+func (c NginxIngressConfig) GetControllerNamespace() string {
+	if c.ControllerNamespace == "" {
+		return defaultNginxControllerNamespace
+	}
+	return c.ControllerNamespace
+}
+
+// GetControllerServiceName returns the name of the Service of the ingress-nginx controller, defaults to ingress-nginx-controller
+func (c NginxIngressConfig) GetControllerServiceName() string {
+	if c.ControllerServiceName == "" {
+		return defaultNginxControllerServiceName
+	}
+	return c.ControllerServiceName
+}
+
+// GetTCPServicesConfigMapName returns the name of the TCP services ConfigMap of the ingress-nginx controller, defaults to tcp-services
+func (c NginxIngressConfig) GetTCPServicesConfigMapName() string {
+	if c.TCPServicesConfigMapName == "" {
+		return defaultNginxTCPServicesConfigMapName
+	}
+	return c.TCPServicesConfigMapName
+}
+
+// GetRouteType returns the type of the Gateway API routes, defaults to TCPRoute
+func (c GatewayAPIConfig) GetRouteType() GatewayAPIRouteType {
+	if c.RouteType == "" {
+		return GatewayAPIRouteTypeTCP
+	}
+	return c.RouteType
+}
+
+// GetAnnotations returns a copy of the Annotations field
+func (c GatewayAPIConfig) GetAnnotations() map[string]string {
+	return util.CloneMap(c.Annotations)
+}
+
+// GetBrokerHostname replaces %id in brokerHostnameTemplate with the actual broker id
+func (c GatewayAPIConfig) GetBrokerHostname(brokerId int32) string {
+	return strings.Replace(c.BrokerHostnameTemplate, "%id", strconv.Itoa(int(brokerId)), 1)
+}
+
+// Replace %id in brokerHostnameTemplate with actual broker id
+func (c EnvoyConfig) GetBrokerHostname(brokerId int32) string {
+	return strings.Replace(c.BrokerHostnameTemplate, "%id", strconv.Itoa(int(brokerId)), 1)
+}
+
+// We use -1 for ExternalStartingPort value to enable TLS on envoy
+func (c ExternalListenerConfig) TLSEnabled() bool {
+	return c.ExternalStartingPort == -1
+}
+
+// SSLSecrets defines the Kafka SSL secrets
+type SSLSecrets struct {
+	TLSSecretName   string                  `json:"tlsSecretName"`
+	JKSPasswordName string                  `json:"jksPasswordName,omitempty"`
+	Create          bool                    `json:"create,omitempty"`
+	IssuerRef       *cmmeta.ObjectReference `json:"issuerRef,omitempty"`
+	// PKIBackend is the backend issuing the certificates of the brokers, the operator and the KafkaUsers. The vault
+	// backend requires spec.vaultConfig.
+	// +kubebuilder:validation:Enum={"cert-manager","vault"}
+	PKIBackend PKIBackend `json:"pkiBackend,omitempty"`
+	// PasswordPolicy defines how the passwords of the keystores and truststores of the brokers and the operator are
+	// generated and rotated. When it is set, the brokers read the passwords from the mounted keystore secrets through
+	// a config provider so they are not rendered into the broker configuration ConfigMaps.
+	// +optional
+	PasswordPolicy *KeystorePasswordPolicy `json:"passwordPolicy,omitempty"`
+}
+
+// KeystorePasswordGenerator is the source of the keystore passwords
+type KeystorePasswordGenerator string
+
+const (
+	// KeystorePasswordGeneratorRandom generates the passwords with a cryptographically secure random generator
+	KeystorePasswordGeneratorRandom KeystorePasswordGenerator = "random"
+	// KeystorePasswordGeneratorKMSWrapped generates the passwords as data keys of a KMS, the data key wrapped by the
+	// KMS key is kept beside the password so it can be recovered through the KMS
+	KeystorePasswordGeneratorKMSWrapped KeystorePasswordGenerator = "kmsWrapped"
+	// KeystorePasswordGeneratorExternal takes the passwords from a Secret maintained by an external secret provider
+	KeystorePasswordGeneratorExternal KeystorePasswordGenerator = "external"
+)
+
+// KeystorePasswordPolicy defines the generation and the rotation of the keystore passwords
+type KeystorePasswordPolicy struct {
+	// Generator is the source of the keystore passwords
+	// +kubebuilder:validation:Enum=random;kmsWrapped;external
+	// +kubebuilder:default=random
+	// +optional
+	Generator KeystorePasswordGenerator `json:"generator,omitempty"`
+	// KMS configures the KMS generating the passwords, required by the kmsWrapped generator
+	// +optional
+	KMS *KeystorePasswordKMSConfig `json:"kms,omitempty"`
+	// ExternalSecretRef references the key of a Secret in the namespace of the KafkaCluster holding the password,
+	// required by the external generator. The keystores are re-encrypted whenever the referenced password changes.
+	// +optional
+	ExternalSecretRef *corev1.SecretKeySelector `json:"externalSecretRef,omitempty"`
+	// RotationPeriod is the period the passwords are rotated with, the keystores are re-encrypted with the new
+	// password. The passwords are not rotated periodically when it is omitted.
+	// +optional
+	RotationPeriod *metav1.Duration `json:"rotationPeriod,omitempty"`
+}
+
+// KeystorePasswordKMSConfig defines the KMS generating the keystore passwords through a Vault transit compatible API
+type KeystorePasswordKMSConfig struct {
+	// Address of the KMS, e.g. https://vault.vault.svc:8200
+	// +kubebuilder:validation:MinLength=1
+	Address string `json:"address"`
+	// MountPath of the transit secrets engine
+	// +kubebuilder:default=transit
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+	// KeyName is the name of the KMS key wrapping the generated passwords
+	// +kubebuilder:validation:MinLength=1
+	KeyName string `json:"keyName"`
+	// TokenSecretRef references the key of a Secret in the namespace of the KafkaCluster holding the KMS token
+	TokenSecretRef corev1.SecretKeySelector `json:"tokenSecretRef"`
+}
+
+// VaultAuthMethod is the Vault auth method Koperator logs in with
+type VaultAuthMethod string
+
+const (
+	// VaultAuthMethodKubernetes logs in with the Kubernetes auth method of Vault
+	VaultAuthMethodKubernetes VaultAuthMethod = "kubernetes"
+	// VaultAuthMethodJWT logs in with the JWT auth method of Vault configured to trust the service account issuer of
+	// the Kubernetes cluster
+	VaultAuthMethodJWT VaultAuthMethod = "jwt"
+)
+
+// VaultConfig defines the PKI secrets engine of HashiCorp Vault issuing the certificates of the vault PKI backend
+type VaultConfig struct {
+	// Address of Vault, e.g. https://vault.vault.svc:8200
+	// +kubebuilder:validation:MinLength=1
+	Address string `json:"address"`
+	// Namespace is the Vault Enterprise namespace holding the auth method and the PKI secrets engine
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// CABundleSecretRef references the key of a Secret in the namespace of the KafkaCluster holding the PEM encoded CA
+	// certificates the certificate of Vault is verified with, the system CA certificates are used when it is omitted
+	// +optional
+	CABundleSecretRef *corev1.SecretKeySelector `json:"caBundleSecretRef,omitempty"`
+	// Auth configures how Koperator logs in to Vault
+	Auth VaultAuthConfig `json:"auth"`
+	// PKIPath is the mount path of the PKI secrets engine
+	// +kubebuilder:default=pki
+	// +optional
+	PKIPath string `json:"pkiPath,omitempty"`
+	// Role is the role of the PKI secrets engine the certificates are issued with. It has to allow the KafkaUser names
+	// as common names and the broker host names as DNS names.
+	// +kubebuilder:validation:MinLength=1
+	Role string `json:"role"`
+	// CertificateTTL is the validity of the issued certificates, unless the expirationSeconds of the KafkaUser is set.
+	// Koperator renews the certificates when two thirds of their validity elapsed, they are not revoked once they are
+	// not used anymore so they should be short-lived. Defaults to 24h.
+	// +optional
+	CertificateTTL *metav1.Duration `json:"certificateTTL,omitempty"`
+}
+
+// VaultAuthConfig defines the auth method Koperator logs in to Vault with using the token of its service account
+type VaultAuthConfig struct {
+	// Method is the type of the auth method
+	// +kubebuilder:validation:Enum=kubernetes;jwt
+	// +kubebuilder:default=kubernetes
+	// +optional
+	Method VaultAuthMethod `json:"method,omitempty"`
+	// Path is the mount path of the auth method, defaults to the type of the auth method
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Role is the role of the auth method Koperator logs in with
+	// +kubebuilder:validation:MinLength=1
+	Role string `json:"role"`
+	// TokenPath is the path of the service account token Koperator logs in with, e.g. the path of a projected token
+	// with the audience expected by Vault. Defaults to the token of the service account of the operator pod.
+	// +optional
+	TokenPath string `json:"tokenPath,omitempty"`
+}
+
+// PrincipalConfig defines the principal builder related configuration of the Kafka brokers
+type PrincipalConfig struct {
+	// PrincipalBuilderClass is the fully qualified name of a class that implements the KafkaPrincipalBuilder interface.
+	// It is rendered into the "principal.builder.class" broker configuration. When it is omitted the Kafka default is used.
+	// Note that the SSL principal mapping rules are only honored by principal builders that support them (e.g. the default one).
+	// +kubebuilder:validation:Pattern=`^([a-zA-Z_$][a-zA-Z0-9_$]*\.)*[a-zA-Z_$][a-zA-Z0-9_$]*$`
+	// +optional
+	PrincipalBuilderClass string `json:"principalBuilderClass,omitempty"`
+	// SSLPrincipalMappingRules is the ordered list of rules used to map the distinguished name of SSL client certificates
+	// to short principal names. Each rule is either "DEFAULT" or has the "RULE:pattern/replacement/[LU]" format.
+	// The rules are rendered into the "ssl.principal.mapping.rules" broker configuration.
+	// More info: https://kafka.apache.org/documentation/#security_authz_ssl
+	// +optional
+	SSLPrincipalMappingRules []string `json:"sslPrincipalMappingRules,omitempty"`
+}
+
+// GetSSLPrincipalMappingRules returns the SSL principal mapping rules, it returns nil if they are not specified
+func (p *PrincipalConfig) GetSSLPrincipalMappingRules() []string {
+	if p == nil {
+		return nil
+	}
+	return p.SSLPrincipalMappingRules
+}
+
+// GetPrincipalBuilderClass returns the principal builder class, it returns empty string if it is not specified
+func (p *PrincipalConfig) GetPrincipalBuilderClass() string {
+	if p == nil {
+		return ""
+	}
+	return p.PrincipalBuilderClass
+}
+
+// AuthorizationConfig defines the authorizer configuration of the Kafka brokers
+type AuthorizationConfig struct {
+	// AuthorizerClassName is the fully qualified name of the authorizer class rendered into the "authorizer.class.name"
+	// broker configuration, e.g. org.apache.kafka.metadata.authorizer.StandardAuthorizer in KRaft mode or
+	// kafka.security.authorizer.AclAuthorizer in ZooKeeper mode. When it is omitted no authorizer is configured.
+	// +kubebuilder:validation:Pattern=`^([a-zA-Z_$][a-zA-Z0-9_$]*\.)*[a-zA-Z_$][a-zA-Z0-9_$]*$`
+	// +optional
+	AuthorizerClassName string `json:"authorizerClassName,omitempty"`
+	// SuperUsers is the list of principals in the "<type>:<name>" format (e.g. User:CN=admin) which bypass the authorizer.
+	// They are rendered into the "super.users" broker configuration next to the principals Koperator generates for itself.
+	// +kubebuilder:validation:items:Pattern=`^[A-Za-z]+:[^;]+$`
+	// +optional
+	SuperUsers []string `json:"superUsers,omitempty"`
+	// AllowEveryoneIfNoACLFound is rendered into the "allow.everyone.if.no.acl.found" broker configuration.
+	// When it is omitted the Kafka default (false) is used.
+	// +optional
+	AllowEveryoneIfNoACLFound *bool `json:"allowEveryoneIfNoACLFound,omitempty"`
+}
+
+// GetAuthorizerClassName returns the authorizer class, it returns empty string if it is not specified
+func (a *AuthorizationConfig) GetAuthorizerClassName() string {
+	if a == nil {
+		return ""
+	}
+	return a.AuthorizerClassName
+}
+
+// GetSuperUsers returns the configured super users, it returns nil if they are not specified
+func (a *AuthorizationConfig) GetSuperUsers() []string {
+	if a == nil {
+		return nil
+	}
+	return a.SuperUsers
+}
+
+// GetAllowEveryoneIfNoACLFound returns the value of allow.everyone.if.no.acl.found and whether it is specified
+func (a *AuthorizationConfig) GetAllowEveryoneIfNoACLFound() (bool, bool) {
+	if a == nil || a.AllowEveryoneIfNoACLFound == nil {
+		return false, false
+	}
+	return *a.AllowEveryoneIfNoACLFound, true
+}
+
+// InternalTopicsConfig defines the management of the replication settings of the Kafka internal topics
+type InternalTopicsConfig struct {
+	// AutoReplication derives the "offsets.topic.replication.factor", "transaction.state.log.replication.factor"
+	// and "transaction.state.log.min.isr" broker configurations from the number of brokers when they are not set in
+	// readOnlyConfig, and raises the replication factor of the existing internal topics when the cluster grows.
+	// The replication factor is capped at 3 and the min ISR is 2 from 3 brokers on.
+	// +optional
+	AutoReplication bool `json:"autoReplication,omitempty"`
+}
+
+// IsAutoReplicationEnabled returns true if the replication settings of the internal topics follow the broker count
+func (c *InternalTopicsConfig) IsAutoReplicationEnabled() bool {
+	return c != nil && c.AutoReplication
+}
+
+// DiskPlacementHint hints the disk class of the disks hosting the replicas of the topics matching the pattern
+type DiskPlacementHint struct {
+	// TopicPattern is a regular expression matching the whole name of the topics to place
+	// +kubebuilder:validation:MinLength=1
+	TopicPattern string `json:"topicPattern"`
+	// DiskClass is the class of the broker disks hosting the replicas of the matching topics
+	// +kubebuilder:validation:MinLength=1
+	DiskClass string `json:"diskClass"`
+}
+
+// BrokerReadinessConfig defines when a broker is considered ready based on the metrics it exposes
+type BrokerReadinessConfig struct {
+	// Expression is a list of comparisons of the metrics exposed by the Prometheus JMX exporter of the broker, joined
+	// with &&. A comparison has the form `metric_name{label="value"} <op> <number>` where the label selector is optional
+	// and op is one of ==, !=, <, <=, >, >=. The comparison must hold for every series of the metric matching the selector,
+	// a metric without matching series fails it.
+	// E.g. `kafka_server_replicamanager_underreplicatedpartitions == 0 && kafka_server_kafkaserver_brokerstate == 3`
+	// +kubebuilder:validation:MinLength=1
+	Expression string `json:"expression"`
+	// PeriodSeconds is how often the expression is evaluated, defaults to 15 seconds
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+	// TimeoutSeconds is the timeout of fetching the metrics of the broker, defaults to 5 seconds
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// GetPeriod returns how often the broker readiness expression is evaluated
+func (c *BrokerReadinessConfig) GetPeriod() time.Duration {
+	if c.PeriodSeconds == nil {
+		return defaultBrokerReadinessPeriodSeconds * time.Second
+	}
+	return time.Duration(*c.PeriodSeconds) * time.Second
+}
+
+// GetTimeout returns the timeout of fetching the metrics of a broker
+func (c *BrokerReadinessConfig) GetTimeout() time.Duration {
+	if c.TimeoutSeconds == nil {
+		return defaultBrokerReadinessTimeoutSeconds * time.Second
+	}
+	return time.Duration(*c.TimeoutSeconds) * time.Second
+}
+
+// KRaftMigrationConfig defines the migration of a ZooKeeper based Kafka cluster to KRaft mode
+type KRaftMigrationConfig struct {
+	// Enabled starts the migration: the controller quorum is provisioned with the ZooKeeper migration enabled, the
+	// brokers are rolled with the migration flags and, once the active controller has migrated the metadata, they are
+	// flipped to KRaft mode one by one before the controllers are detached from ZooKeeper.
+	// A migration in progress can not be disabled.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// IsEnabled returns true if the ZooKeeper to KRaft migration is enabled
+func (c *KRaftMigrationConfig) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+// KRaftMigrationStatus holds the progress of the ZooKeeper to KRaft migration
+type KRaftMigrationStatus struct {
+	// Phase is the current phase of the migration
+	Phase KRaftMigrationPhase `json:"phase"`
+	// MigratedBrokers are the IDs of the brokers already running in KRaft mode during the BrokersMigrating phase
+	// +optional
+	MigratedBrokers []int32 `json:"migratedBrokers,omitempty"`
+	// LastTransitionTime is the time the migration entered the current phase
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// GetPhase returns the current phase of the ZooKeeper to KRaft migration
+func (s *KRaftMigrationStatus) GetPhase() KRaftMigrationPhase {
+	if s == nil {
+		return ""
+	}
+	return s.Phase
+}
+
+// IsInProgress returns true if the ZooKeeper to KRaft migration has been started but not completed yet
+func (s *KRaftMigrationStatus) IsInProgress() bool {
+	phase := s.GetPhase()
+	return phase != "" && phase != KRaftMigrationPhaseCompleted
+}
+
+// IsBrokerMigrated returns true if the given broker has been flipped to KRaft mode during the BrokersMigrating phase
+func (s *KRaftMigrationStatus) IsBrokerMigrated(brokerID int32) bool {
+	return s != nil && slices.Contains(s.MigratedBrokers, brokerID)
+}
+
+// KafkaVersionUpgradeConfig defines the upgrade of the Kafka version of a cluster
+type KafkaVersionUpgradeConfig struct {
+	// TargetVersion is the Kafka version the cluster is upgraded to, e.g. 3.9.1. The images of the brokers have to run
+	// it, the protocol is bumped once every broker reports it.
+	// +kubebuilder:validation:Pattern=`^[0-9]+\.[0-9]+\.[0-9]+$`
+	TargetVersion string `json:"targetVersion"`
+	// InterBrokerProtocolVersion is the inter.broker.protocol.version of the brokers in ZooKeeper mode once the
+	// protocol is bumped, it defaults to the major and minor version of the target version, e.g. 3.9.
+	// It overrides the inter.broker.protocol.version of the read-only configs.
+	// +optional
+	InterBrokerProtocolVersion string `json:"interBrokerProtocolVersion,omitempty"`
+	// MetadataVersion is the metadata.version of the cluster in KRaft mode once the protocol is bumped, e.g. 3.9-IV0,
+	// it defaults to the major and minor version of the target version, e.g. 3.9.
+	// +optional
+	MetadataVersion string `json:"metadataVersion,omitempty"`
+	// HoldProtocolVersion keeps the protocol of the previous version once every broker runs the target version, the
+	// upgrade can be rolled back by reverting the images of the brokers until it is unset and the protocol is bumped.
+	// +optional
+	HoldProtocolVersion bool `json:"holdProtocolVersion,omitempty"`
+}
+
+// GetInterBrokerProtocolVersion returns the inter broker protocol version the brokers are upgraded to
+func (c *KafkaVersionUpgradeConfig) GetInterBrokerProtocolVersion() string {
+	if c.InterBrokerProtocolVersion != "" {
+		return c.InterBrokerProtocolVersion
+	}
+	return ProtocolVersionOf(c.TargetVersion)
+}
+
+// GetMetadataVersion returns the metadata version the KRaft cluster is upgraded to
+func (c *KafkaVersionUpgradeConfig) GetMetadataVersion() string {
+	if c.MetadataVersion != "" {
+		return c.MetadataVersion
+	}
+	return ProtocolVersionOf(c.TargetVersion)
+}
+
+// ProtocolVersionOf returns the major and minor version of the given Kafka version, e.g. 3.9 for 3.9.1, which is the
+// release form of the inter broker protocol and metadata versions
+func ProtocolVersionOf(kafkaVersion string) string {
+	parts := strings.SplitN(kafkaVersion, ".", 3)
+	if len(parts) < 2 {
+		return kafkaVersion
+	}
+	return parts[0] + "." + parts[1]
+}
+
+// KafkaVersionUpgradeStatus holds the progress of the upgrade of the Kafka version
+type KafkaVersionUpgradeStatus struct {
+	// Phase is the current phase of the upgrade
+	Phase KafkaVersionUpgradePhase `json:"phase"`
+	// TargetVersion is the Kafka version the cluster is upgraded to
+	TargetVersion string `json:"targetVersion"`
+	// PreviousProtocolVersion is the protocol version of the lowest Kafka version the brokers ran when the upgrade
+	// started, the brokers in ZooKeeper mode keep it as their inter broker protocol version until the protocol is bumped
+	// +optional
+	PreviousProtocolVersion string `json:"previousProtocolVersion,omitempty"`
+	// LastTransitionTime is the time the upgrade entered the current phase
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// GetPhase returns the current phase of the upgrade of the Kafka version
+func (s *KafkaVersionUpgradeStatus) GetPhase() KafkaVersionUpgradePhase {
+	if s == nil {
+		return ""
+	}
+	return s.Phase
+}
+
+// IsProtocolBumped returns true if the protocol of the cluster is being or has been bumped to the target version,
+// the brokers can not be rolled back to the previous version anymore
+func (s *KafkaVersionUpgradeStatus) IsProtocolBumped() bool {
+	phase := s.GetPhase()
+	return phase == KafkaVersionUpgradePhaseProtocolUpgrading || phase == KafkaVersionUpgradePhaseCompleted
+}
+
+// DetectedVersionsStatus holds the versions of the components detected from the running brokers and the images, checked
+// against the compatibility matrix of the operator
+type DetectedVersionsStatus struct {
+	// Kafka holds the distinct Kafka versions run by the brokers
+	// +optional
+	Kafka []string `json:"kafka,omitempty"`
+	// CruiseControl holds the Cruise Control version detected from the tag of its image
+	// +optional
+	CruiseControl string `json:"cruiseControl,omitempty"`
+	// Incompatibilities lists the detected versions which are not compatible with each other or with the enabled features
+	// +optional
+	Incompatibilities []string `json:"incompatibilities,omitempty"`
+}
+
+// DiskPlacementStatus holds the replicas which are not on a disk of the class hinted for their topic
+type DiskPlacementStatus struct {
+	// MisplacedReplicas is the number of replicas which are not on a disk of the hinted class
+	MisplacedReplicas int32 `json:"misplacedReplicas"`
+	// PlanConfigMap is the ConfigMap holding the reassignment plan which moves the misplaced replicas in the
+	// reassignment.json key, it can be executed with the kafka-reassign-partitions tool
+	// +optional
+	PlanConfigMap string `json:"planConfigMap,omitempty"`
+}
+
+// LeaderBalanceStatus holds the leadership imbalance of the partitions of the cluster
+type LeaderBalanceStatus struct {
+	// Partitions is the number of partitions with a leader
+	Partitions int32 `json:"partitions"`
+	// NonPreferredLeaderPartitions is the number of partitions led by another broker than their preferred leader
+	NonPreferredLeaderPartitions int32 `json:"nonPreferredLeaderPartitions"`
+	// ImbalancePercentage is the percentage of the partitions not led by their preferred leader
+	ImbalancePercentage int32 `json:"imbalancePercentage"`
+	// LeaderCounts holds the number of partitions led by each broker by broker id
+	// +optional
+	LeaderCounts map[string]int32 `json:"leaderCounts,omitempty"`
+	// LastElectionTime is the time the preferred leaders were last elected by Koperator
+	// +optional
+	LastElectionTime *metav1.Time `json:"lastElectionTime,omitempty"`
+	// LastElectedPartitions is the number of partitions whose preferred leader was elected by the last election
+	// +optional
+	LastElectedPartitions int32 `json:"lastElectedPartitions,omitempty"`
+}
+
+// ConformanceAuditStatus holds the outcome of the best-practice audit of the cluster
+type ConformanceAuditStatus struct {
+	// Score is the weighted percentage of the best-practice rules the cluster conforms to, 100 when all rules pass
+	Score int32 `json:"score"`
+	// Findings are the violations of the best-practice rules
+	// +optional
+	Findings []ConformanceAuditFinding `json:"findings,omitempty"`
+	// LastAuditTime is the time the cluster was last audited
+	// +optional
+	LastAuditTime metav1.Time `json:"lastAuditTime,omitempty"`
+}
+
+// ConformanceAuditFinding is a violation of a best-practice rule
+type ConformanceAuditFinding struct {
+	// Rule is the name of the violated rule
+	Rule string `json:"rule"`
+	// Severity is the severity of the violated rule
+	Severity ConformanceAuditSeverity `json:"severity"`
+	// Message describes the violation
+	Message string `json:"message"`
+}
+
+// ReconcilePlanStatus holds the changes the reconciliation of the cluster would make without applying them
+type ReconcilePlanStatus struct {
+	// Changes are the resources the operator would create, update or delete
+	// +optional
+	Changes []PlannedResourceChange `json:"changes,omitempty"`
+	// RestartingBrokers are the ids of the brokers whose pods would be restarted
+	// +optional
+	RestartingBrokers []string `json:"restartingBrokers,omitempty"`
+	// Incomplete lists the components whose changes could only be planned partially with the reason, e.g. because
+	// they wait for the resources the plan would create
+	// +optional
+	Incomplete []string `json:"incomplete,omitempty"`
+	// ObservedGeneration is the generation of the cluster the plan was computed for
+	ObservedGeneration int64 `json:"observedGeneration"`
+	// PlanTime is the time the plan was computed
+	// +optional
+	PlanTime metav1.Time `json:"planTime,omitempty"`
+}
+
+// PlannedResourceChange is a change of a resource the reconciliation of the cluster would make
+type PlannedResourceChange struct {
+	// Action is the change of the resource
+	Action PlannedAction `json:"action"`
+	// Kind is the kind of the resource
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the resource, empty for cluster-scoped resources
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the resource, empty when the resources matching a selector would be deleted
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// CruiseControlAnomaliesStatus holds the state of the Cruise Control anomaly detector
+type CruiseControlAnomaliesStatus struct {
+	// OngoingSelfHealingAnomaly is the type of the anomaly Cruise Control itself is self-healing, if any
+	// +optional
+	OngoingSelfHealingAnomaly string `json:"ongoingSelfHealingAnomaly,omitempty"`
+	// Anomalies are the goal violations, broker and disk failures recently detected by Cruise Control
+	// +optional
+	Anomalies []CruiseControlAnomaly `json:"anomalies,omitempty"`
+	// LastUpdateTime is the time the reported state of the anomaly detector last changed
+	// +optional
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// RebalanceScheduleStatus holds the state of the scheduled rebalances
+type RebalanceScheduleStatus struct {
+	// LastScheduleTime is the time of the last scheduled run handled by the operator
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// LastOperation is the name of the CruiseControlOperation created by the last scheduled run, empty when the run
+	// was skipped as a rebalance was still in progress
+	// +optional
+	LastOperation string `json:"lastOperation,omitempty"`
+	// NextScheduleTime is the time of the next scheduled run
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+}
+
+// CruiseControlAnomaly is an anomaly detected by the Cruise Control anomaly detector
+type CruiseControlAnomaly struct {
+	// ID is the identifier of the anomaly in Cruise Control
+	ID string `json:"id"`
+	// Type is the type of the anomaly
+	Type CruiseControlAnomalyType `json:"type"`
+	// Status is the status of the anomaly reported by Cruise Control, e.g. DETECTED, IGNORED or FIX_STARTED
+	Status string `json:"status"`
+	// DetectionTime is the time Cruise Control detected the anomaly
+	// +optional
+	DetectionTime metav1.Time `json:"detectionTime,omitempty"`
+	// Description describes the anomaly, e.g. the failed brokers or the violated goals
+	// +optional
+	Description string `json:"description,omitempty"`
+	// RemediationOperation is the name of the CruiseControlOperation created by the operator to remediate the anomaly
+	// +optional
+	RemediationOperation string `json:"remediationOperation,omitempty"`
+}
+
+// ControllerQuorumStatus holds the health of the KRaft controller quorum
+type ControllerQuorumStatus struct {
+	// State is the health of the quorum derived from the number of ready voters
+	State ControllerQuorumState `json:"state"`
+	// Voters are the IDs of the controller nodes forming the quorum
+	// +optional
+	Voters []int32 `json:"voters,omitempty"`
+	// ReadyVoters are the IDs of the voters whose pod is ready
+	// +optional
+	ReadyVoters []int32 `json:"readyVoters,omitempty"`
+	// LastTransitionTime is the time the quorum entered the current state
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// QuorumMajority returns the number of voters required to form a majority in a quorum of the given size
+func QuorumMajority(voters int) int {
+	return voters/2 + 1
+}
+
+// ExternalListenerAccess identifies the path external clients reach the brokers through
+type ExternalListenerAccess struct {
+	IngressController string             `json:"ingressController"`
+	AccessMethod      corev1.ServiceType `json:"accessMethod"`
+}
+
+// ExternalListenerAccessStatus holds the access method of an external listener
+type ExternalListenerAccessStatus struct {
+	ExternalListenerAccess `json:",inline"`
+	// Transition holds the progress of the change from the previous access method, it is removed once the resources
+	// of the previous access method have been torn down
+	// +optional
+	Transition *ExternalListenerAccessTransition `json:"transition,omitempty"`
 }
 
-// ListenersConfig defines the Kafka listener types
-type ListenersConfig struct {
-	ExternalListeners  []ExternalListenerConfig `json:"externalListeners,omitempty"`
-	InternalListeners  []InternalListenerConfig `json:"internalListeners"`
-	SSLSecrets         *SSLSecrets              `json:"sslSecrets,omitempty"`
-	ServiceAnnotations map[string]string        `json:"serviceAnnotations,omitempty"`
+// ExternalListenerAccessTransition holds the progress of an external listener access method change
+type ExternalListenerAccessTransition struct {
+	Phase ExternalListenerAccessTransitionPhase `json:"phase"`
+	// Previous is the access method the external listener is switched from
+	Previous ExternalListenerAccess `json:"previous"`
+	// LastTransitionTime is the time the transition entered the current phase
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 }
 
-// GetServiceAnnotations returns a copy of the ServiceAnnotations field.
-func (c ListenersConfig) GetServiceAnnotations() map[string]string {
-	return util.CloneMap(c.ServiceAnnotations)
+// GetPhase returns the phase of the external listener access method change
+func (t *ExternalListenerAccessTransition) GetPhase() ExternalListenerAccessTransitionPhase {
+	if t == nil {
+		return ""
+	}
+	return t.Phase
 }
 
-func (c ExternalListenerConfig) GetAccessMethod() corev1.ServiceType {
-	if c.AccessMethod == "" {
-		return corev1.ServiceTypeLoadBalancer
+// GetAccess returns the access method of the given external listener set in the spec
+func (kSpec *KafkaClusterSpec) GetAccess(eListener ExternalListenerConfig) ExternalListenerAccess {
+	return ExternalListenerAccess{
+		IngressController: kSpec.GetIngressControllerForListener(eListener),
+		AccessMethod:      eListener.GetAccessMethod(),
 	}
-	return c.AccessMethod
 }
 
-func (c ExternalListenerConfig) GetAnyCastPort() int32 {
-	if c.AnyCastPort == nil {
-		return defaultAnyCastPort
+// GetAdvertisedAccess returns the access method whose addresses the brokers advertise for the given external listener,
+// it is the previous access method until the new one has been provisioned
+func (s *KafkaClusterStatus) GetAdvertisedAccess(kSpec *KafkaClusterSpec, eListener ExternalListenerConfig) ExternalListenerAccess {
+	if status, ok := s.ExternalListenersAccess[eListener.Name]; ok && status.Transition.GetPhase() == ExternalListenerAccessProvisioning {
+		return status.Transition.Previous
 	}
-	return *c.AnyCastPort
+	return kSpec.GetAccess(eListener)
 }
 
-// GetIngressControllerTargetPort returns the IngressControllerTargetPort if it is defined,
-// otherwise it returns the DefaultIngressControllerTargetPort value
-func (c ExternalListenerConfig) GetIngressControllerTargetPort() int32 {
-	if c.IngressControllerTargetPort == nil {
-		return defaultIngressControllerTargetPort
+// GetPasswordPolicy returns the keystore password policy, it returns nil if SSL secrets are not configured
+func (s *SSLSecrets) GetPasswordPolicy() *KeystorePasswordPolicy {
+	if s == nil {
+		return nil
 	}
-	return *c.IngressControllerTargetPort
+	return s.PasswordPolicy
 }
 
-// GetBrokerPort - When TLS is enabled AnyCastPort is enough since hostname based multiplexing
-// is used and not port based one
-func (c ExternalListenerConfig) GetBrokerPort(brokerId int32) int32 {
-	if c.TLSEnabled() {
-		return c.GetAnyCastPort()
-	} else {
-		return c.ExternalStartingPort + brokerId
+// GetGenerator returns the source of the keystore passwords
+func (p *KeystorePasswordPolicy) GetGenerator() KeystorePasswordGenerator {
+	if p.Generator == "" {
+		return KeystorePasswordGeneratorRandom
 	}
+	return p.Generator
 }
 
-// GetServiceAnnotations returns a copy of the ServiceAnnotations field.
-func (c IngressServiceSettings) GetServiceAnnotations() map[string]string {
-	return util.CloneMap(c.ServiceAnnotations)
+// GetPKIPath returns the mount path of the PKI secrets engine of Vault
+func (v *VaultConfig) GetPKIPath() string {
+	if v.PKIPath == "" {
+		return "pki"
+	}
+	return v.PKIPath
 }
 
-// GetServiceType returns the field value of ServiceType defaults to LoadBalancer.
-func (c IngressServiceSettings) GetServiceType() corev1.ServiceType {
-	if c.ServiceType == "" {
-		return corev1.ServiceTypeLoadBalancer
+// GetCertificateTTL returns the validity of the certificates issued by Vault
+func (v *VaultConfig) GetCertificateTTL() time.Duration {
+	if v.CertificateTTL == nil {
+		return defaultVaultCertificateTTL
 	}
-	return c.ServiceType
+	return v.CertificateTTL.Duration
 }
 
-func (c ContourIngressConfig) GetBrokerFqdn(brokerId int32) string {
-	return strings.Replace(c.BrokerFQDNTemplate, "%id", strconv.Itoa(int(brokerId)), 1)
+// GetMethod returns the type of the Vault auth method
+func (a VaultAuthConfig) GetMethod() VaultAuthMethod {
+	if a.Method == "" {
+		return VaultAuthMethodKubernetes
+	}
+	return a.Method
 }
 
-// Replace %id in brokerHostnameTemplate with actual broker id
-func (c EnvoyConfig) GetBrokerHostname(brokerId int32) string {
-	return strings.Replace(c.BrokerHostnameTemplate, "%id", strconv.Itoa(int(brokerId)), 1)
+// GetPath returns the mount path of the Vault auth method
+func (a VaultAuthConfig) GetPath() string {
+	if a.Path == "" {
+		return string(a.GetMethod())
+	}
+	return a.Path
 }
 
-// We use -1 for ExternalStartingPort value to enable TLS on envoy
-func (c ExternalListenerConfig) TLSEnabled() bool {
-	return c.ExternalStartingPort == -1
+// GetTokenPath returns the path of the service account token Koperator logs in to Vault with
+func (a VaultAuthConfig) GetTokenPath() string {
+	if a.TokenPath == "" {
+		return defaultServiceAccountTokenPath
+	}
+	return a.TokenPath
 }
 
-// SSLSecrets defines the Kafka SSL secrets
-type SSLSecrets struct {
-	TLSSecretName   string                  `json:"tlsSecretName"`
-	JKSPasswordName string                  `json:"jksPasswordName,omitempty"`
-	Create          bool                    `json:"create,omitempty"`
-	IssuerRef       *cmmeta.ObjectReference `json:"issuerRef,omitempty"`
-	// +kubebuilder:validation:Enum={"cert-manager"}
-	PKIBackend PKIBackend `json:"pkiBackend,omitempty"`
+// GetMountPath returns the mount path of the transit secrets engine of the KMS
+func (k *KeystorePasswordKMSConfig) GetMountPath() string {
+	if k.MountPath == "" {
+		return "transit"
+	}
+	return k.MountPath
 }
 
 // TODO (tinyzimmer): The above are all optional now in one way or another.
@@ -718,13 +2366,24 @@ type ExternalListenerConfig struct {
 	// IngressControllerTargetPort defines the container port that the ingress controller uses for handling external traffic.
 	// If not defined, 29092 will be used as the default IngressControllerTargetPort value.
 	IngressControllerTargetPort *int32 `json:"ingressControllerTargetPort,omitempty"`
-	// +kubebuilder:validation:Enum=LoadBalancer;NodePort;ClusterIP
+	// +kubebuilder:validation:Enum=LoadBalancer;NodePort;ClusterIP;PerBrokerLoadBalancer
 	// accessMethod defines the method which the external listener is exposed through.
 	// Two types are supported LoadBalancer and NodePort.
 	// The recommended and default is the LoadBalancer.
 	// NodePort should be used in Kubernetes environments with no support for provisioning Load Balancers.
+	// PerBrokerLoadBalancer provisions a LoadBalancer Service for every broker, without an ingress controller, for
+	// environments where a single load balancer shared by the brokers is a bottleneck.
 	// +optional
 	AccessMethod corev1.ServiceType `json:"accessMethod,omitempty"`
+	// PerBrokerLoadBalancerConfig configures the LoadBalancer Services of the brokers when accessMethod is PerBrokerLoadBalancer
+	// +optional
+	PerBrokerLoadBalancerConfig *PerBrokerLoadBalancerConfig `json:"perBrokerLoadBalancerConfig,omitempty"`
+	// +kubebuilder:validation:Enum=envoy;contour;istioingress;gatewayapi;nginx
+	// IngressController specifies the type of the ingress controller to be used for this external listener.
+	// If not set, the cluster wide `spec.ingressController` is used. This allows different external listeners
+	// of the same cluster to be exposed through different ingress implementations at the same time.
+	// +optional
+	IngressController string `json:"ingressController,omitempty"`
 	// Config allows to specify ingress controller configuration per external listener
 	// if set, it overrides the default `KafkaClusterSpec.IstioIngressConfig` or `KafkaClusterSpec.EnvoyConfig` for this external listener.
 	// +optional
@@ -733,6 +2392,15 @@ type ExternalListenerConfig struct {
 	TLSSecretName string `json:"tlsSecretName,omitempty"`
 }
 
+// PerBrokerLoadBalancerConfig defines the LoadBalancer Services provisioned for the brokers of an external listener
+type PerBrokerLoadBalancerConfig struct {
+	// BrokerHostnameTemplate is used to generate the hostnames the brokers are advertised on, e.g. broker-%id.kafka.example.com.
+	// The hostname is set in the external-dns annotation of the Service of the broker so external-dns creates its DNS record.
+	// The address of the load balancer is advertised when not set.
+	// +optional
+	BrokerHostnameTemplate string `json:"brokerHostnameTemplate,omitempty"`
+}
+
 // Config defines the external access ingress controller configuration
 type Config struct {
 	DefaultIngressConfig string                   `json:"defaultIngressConfig"`
@@ -744,6 +2412,8 @@ type IngressConfig struct {
 	IstioIngressConfig     *IstioIngressConfig   `json:"istioIngressConfig,omitempty"`
 	EnvoyConfig            *EnvoyConfig          `json:"envoyConfig,omitempty"`
 	ContourIngressConfig   *ContourIngressConfig `json:"contourIngressConfig,omitempty"`
+	GatewayAPIConfig       *GatewayAPIConfig     `json:"gatewayAPIConfig,omitempty"`
+	NginxIngressConfig     *NginxIngressConfig   `json:"nginxIngressConfig,omitempty"`
 }
 
 type ContourIngressConfig struct {
@@ -753,6 +2423,52 @@ type ContourIngressConfig struct {
 	BrokerFQDNTemplate string `json:"brokerFQDNTemplate"`
 }
 
+// NginxIngressConfig defines the ingress-nginx controller the external listeners are exposed through. The brokers are
+// exposed as TCP services of the controller, every broker on its own port (externalStartingPort + broker id).
+type NginxIngressConfig struct {
+	// ControllerNamespace is the namespace of the ingress-nginx controller, defaults to ingress-nginx
+	// +optional
+	ControllerNamespace string `json:"controllerNamespace,omitempty"`
+	// ControllerServiceName is the name of the LoadBalancer Service of the ingress-nginx controller, the ports of the
+	// brokers are added to it. Defaults to ingress-nginx-controller
+	// +optional
+	ControllerServiceName string `json:"controllerServiceName,omitempty"`
+	// TCPServicesConfigMapName is the name of the ConfigMap passed to the ingress-nginx controller with the
+	// --tcp-services-configmap flag, defaults to tcp-services
+	// +optional
+	TCPServicesConfigMapName string `json:"tcpServicesConfigMapName,omitempty"`
+}
+
+// GatewayAPIRouteType is the type of the Gateway API routes the external listener is exposed through
+type GatewayAPIRouteType string
+
+const (
+	// GatewayAPIRouteTypeTCP exposes every broker on its own port of the Gateway (externalStartingPort + broker id)
+	GatewayAPIRouteTypeTCP GatewayAPIRouteType = "TCPRoute"
+	// GatewayAPIRouteTypeTLS exposes every broker on the anyCastPort of the Gateway using TLS passthrough with SNI
+	// based routing, the broker hostnames are generated from the brokerHostnameTemplate
+	GatewayAPIRouteTypeTLS GatewayAPIRouteType = "TLSRoute"
+)
+
+// GatewayAPIConfig defines the Kubernetes Gateway API resources the external listeners are exposed through
+type GatewayAPIConfig struct {
+	// GatewayClassName is the name of the GatewayClass of the Gateway API implementation used for the Gateway
+	GatewayClassName string `json:"gatewayClassName,omitempty"`
+	// RouteType specifies the type of the routes attached to the Gateway. TCPRoute routes each broker by a dedicated
+	// port, while TLSRoute routes each broker by the SNI hostname on the anyCastPort.
+	// +kubebuilder:validation:Enum=TCPRoute;TLSRoute
+	// +kubebuilder:default=TCPRoute
+	// +optional
+	RouteType GatewayAPIRouteType `json:"routeType,omitempty"`
+	// BrokerHostnameTemplate is used to generate the SNI hostnames of the brokers when TLSRoute is used,
+	// %id is replaced with the broker id e.g. broker-%id.kafka.example.com
+	// +optional
+	BrokerHostnameTemplate string `json:"brokerHostnameTemplate,omitempty"`
+	// Annotations defines the annotations placed on the Gateway
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
 // InternalListenerConfig defines the internal listener config for Kafka
 type InternalListenerConfig struct {
 	CommonListenerSpec             `json:",inline"`
@@ -775,8 +2491,22 @@ type CommonListenerSpec struct {
 	// The secret must contain the keystore, truststore jks files and the password for them in base64 encoded format under the keystore.jks, truststore.jks, password data fields.
 	// If this field is omitted koperator will auto-create a self-signed server certificate using the configuration provided in 'sslSecrets' field.
 	ServerSSLCertSecret *corev1.LocalObjectReference `json:"serverSSLCertSecret,omitempty"`
+	// ServerCertificate provisions the server certificate of the listener from its own PKI instead of the cluster-wide
+	// one configured in 'sslSecrets'. It is ignored when serverSSLCertSecret is set.
+	// +optional
+	ServerCertificate *ListenerServerCertificate `json:"serverCertificate,omitempty"`
+	// OAuthBearer enables the SASL/OAUTHBEARER authentication of the clients on the listener, the tokens are validated
+	// against the signing keys of the OpenID Connect provider. It can only be set for sasl_ssl and sasl_plaintext listeners.
+	// +optional
+	OAuthBearer *OAuthBearerConfig `json:"oauthBearer,omitempty"`
+	// Kerberos enables the SASL/GSSAPI authentication of the clients on the listener with the Kerberos principals of
+	// the brokers. It can only be set for sasl_ssl and sasl_plaintext listeners.
+	// +optional
+	Kerberos *KerberosConfig `json:"kerberos,omitempty"`
 	// SSLClientAuth specifies whether client authentication is required, requested, or not required.
-	// This field defaults to "required" if it is omitted
+	// This field defaults to "required" if it is omitted. With "none" the listener is a server-only TLS listener, the
+	// clients do not need a certificate and the provided server certificate of the listener does not need a CA.
+	// It can only be set for ssl listeners.
 	// +kubebuilder:validation:Enum=required;requested;none
 	SSLClientAuth SSLClientAuthentication `json:"sslClientAuth,omitempty"`
 	// +kubebuilder:validation:Pattern=^[a-z0-9\-]+
@@ -800,6 +2530,91 @@ func (c *CommonListenerSpec) GetServerSSLCertSecretName() string {
 	return c.ServerSSLCertSecret.Name
 }
 
+// GetSSLClientAuth returns the client authentication mode of the listener, client certificates are required by default
+func (c *CommonListenerSpec) GetSSLClientAuth() SSLClientAuthentication {
+	if c.SSLClientAuth == "" {
+		return SSLClientAuthRequired
+	}
+	return c.SSLClientAuth
+}
+
+// HasOwnServerCertificate returns true if the listener does not use the server certificate provisioned by the
+// cluster-wide PKI
+func (c *CommonListenerSpec) HasOwnServerCertificate() bool {
+	return c.GetServerSSLCertSecretName() != "" || c.ServerCertificate != nil
+}
+
+// ListenerServerCertificate defines the source of the server certificate of a listener, exactly one of the fields
+// must be set
+type ListenerServerCertificate struct {
+	// IssuerRef is the cert-manager Issuer or ClusterIssuer signing the server certificate of the listener.
+	// An Issuer has to be in the namespace of the KafkaCluster.
+	// +optional
+	IssuerRef *cmmeta.ObjectReference `json:"issuerRef,omitempty"`
+	// TLSSecretName is the name of a pre-provisioned secret holding the server certificate chain under tls.crt, its
+	// private key under tls.key and the CA certificates under ca.crt. The chain is validated and the keystore and
+	// truststore of the listener are generated from it.
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+}
+
+// OAuthBearerConfig defines the OpenID Connect provider issuing the tokens of the clients authenticating with
+// SASL/OAUTHBEARER on a listener. The endpoints which are not set are discovered from the OpenID Connect discovery
+// document of the issuer.
+type OAuthBearerConfig struct {
+	// IssuerURL is the URL of the OpenID Connect provider, the tokens must be issued by it. The token and JWKS
+	// endpoints are discovered from its /.well-known/openid-configuration document unless they are set explicitly.
+	// +optional
+	IssuerURL string `json:"issuerURL,omitempty"`
+	// TokenEndpointURL is the URL of the token endpoint of the OpenID Connect provider
+	// +optional
+	TokenEndpointURL string `json:"tokenEndpointURL,omitempty"`
+	// JWKSEndpointURL is the URL of the JSON Web Key Set of the OpenID Connect provider, the signature of the tokens is
+	// verified with its keys
+	// +optional
+	JWKSEndpointURL string `json:"jwksEndpointURL,omitempty"`
+	// ExpectedAudience lists the audiences of which at least one has to be present in the tokens
+	// +optional
+	ExpectedAudience []string `json:"expectedAudience,omitempty"`
+	// Scope is the scope requested from the token endpoint
+	// +optional
+	Scope string `json:"scope,omitempty"`
+	// TrustedCASecretRef selects the PEM encoded CA certificates of the OpenID Connect provider in a secret in the
+	// namespace of the KafkaCluster. It is required when the provider endpoints are served with certificates which are
+	// not trusted by the default truststore of the brokers.
+	// +optional
+	TrustedCASecretRef *corev1.SecretKeySelector `json:"trustedCASecretRef,omitempty"`
+}
+
+// KerberosConfig defines the Kerberos principals of the brokers authenticating the clients with SASL/GSSAPI on a
+// listener, the keys of the principals are read from a keytab
+type KerberosConfig struct {
+	// ServiceName is the primary of the Kerberos principals of the brokers, the clients use it to request their
+	// service tickets. Defaults to kafka.
+	// +optional
+	ServiceName string `json:"serviceName,omitempty"`
+	// PrincipalTemplate is the Kerberos principal of the brokers on the listener, %id is replaced with the broker id
+	// e.g. kafka/kafka-%id.kafka-headless.kafka.svc.cluster.local@EXAMPLE.COM. The host of the principal has to match
+	// the address the clients connect to.
+	PrincipalTemplate string `json:"principalTemplate"`
+	// KeytabSecretRef selects the keytab holding the keys of the principals of all brokers in a secret in the
+	// namespace of the KafkaCluster
+	KeytabSecretRef corev1.SecretKeySelector `json:"keytabSecretRef"`
+}
+
+// GetServiceName returns the Kerberos service name of the brokers
+func (k *KerberosConfig) GetServiceName() string {
+	if k.ServiceName == "" {
+		return defaultKerberosServiceName
+	}
+	return k.ServiceName
+}
+
+// GetPrincipal returns the Kerberos principal of the broker with the given id
+func (k *KerberosConfig) GetPrincipal(brokerID int32) string {
+	return strings.ReplaceAll(k.PrincipalTemplate, "%id", strconv.Itoa(int(brokerID)))
+}
+
 // ListenerStatuses holds information about the statuses of the configured listeners.
 // The internal and external listeners are stored in separate maps, and each listener can be looked up by name.
 type ListenerStatuses struct {
@@ -828,6 +2643,25 @@ type ListenerStatus struct {
 	Address string `json:"address"`
 }
 
+// ListenerEndpoints holds the client endpoints of the internal and external listeners by the name of the listener.
+// The listeners used for the controller communication are not listed as the clients can not use them.
+type ListenerEndpoints struct {
+	InternalListeners map[string]ListenerEndpoint `json:"internalListeners,omitempty"`
+	ExternalListeners map[string]ListenerEndpoint `json:"externalListeners,omitempty"`
+}
+
+// ListenerEndpoint holds the connection info of a listener for the clients
+type ListenerEndpoint struct {
+	// SecurityProtocol is the security protocol of the listener
+	SecurityProtocol SecurityProtocol `json:"securityProtocol"`
+	// BootstrapServers is the comma separated host:port list the clients can bootstrap from, the address reaching any
+	// broker when there is one, the addresses of every broker otherwise
+	BootstrapServers string `json:"bootstrapServers"`
+	// AdvertisedAddresses are the host:port addresses advertised by the brokers on the listener
+	// +optional
+	AdvertisedAddresses []string `json:"advertisedAddresses,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:JSONPath=".status.state",name="Cluster state",type="string"
@@ -835,6 +2669,7 @@ type ListenerStatus struct {
 // +kubebuilder:printcolumn:JSONPath=".status.rollingUpgradeStatus.lastSuccess",name="Last successful upgrade",type="string"
 // +kubebuilder:printcolumn:JSONPath=".status.rollingUpgradeStatus.errorCount",name="Upgrade error count",type="string"
 // +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",name="Age",type="date"
+// +kubebuilder:webhook:verbs=create,path=/mutate-kafka-banzaicloud-io-v1beta1-kafkacluster,mutating=true,failurePolicy=fail,groups=kafka.banzaicloud.io,resources=kafkaclusters,versions=v1beta1,name=mkafkaclusters.kafka.banzaicloud.io,sideEffects=None,admissionReviewVersions=v1
 // +kubebuilder:webhook:verbs=create;update,path=/validate-kafka-banzaicloud-io-v1beta1-kafkacluster,mutating=false,failurePolicy=fail,groups=kafka.banzaicloud.io,resources=kafkaclusters,versions=v1beta1,name=kafkaclusters.kafka.banzaicloud.io,sideEffects=None,admissionReviewVersions=v1
 
 // KafkaCluster is the Schema for the kafkaclusters API
@@ -913,6 +2748,130 @@ func (kSpec *KafkaClusterSpec) GetIngressController() string {
 	return kSpec.IngressController
 }
 
+// GetIngressControllerForListener returns the ingress controller of the given external listener
+// and falls back to the cluster wide ingress controller if the listener does not specify one
+func (kSpec *KafkaClusterSpec) GetIngressControllerForListener(eListener ExternalListenerConfig) string {
+	if eListener.IngressController != "" {
+		return eListener.IngressController
+	}
+	return kSpec.GetIngressController()
+}
+
+// IsSmokeTestEnabled returns true if the produce/consume smoke test has to be run after rolling upgrades
+func (r RollingUpgradeConfig) IsSmokeTestEnabled() bool {
+	return r.SmokeTest != nil && r.SmokeTest.Enabled
+}
+
+// GetSmokeTestTopic returns the name of the smoke test topic, the default one if not specified otherwise
+func (r RollingUpgradeConfig) GetSmokeTestTopic() string {
+	if r.SmokeTest == nil || r.SmokeTest.Topic == "" {
+		return defaultSmokeTestTopic
+	}
+	return r.SmokeTest.Topic
+}
+
+// GetSmokeTestTimeout returns the per broker smoke test timeout, the default one if not specified otherwise
+func (r RollingUpgradeConfig) GetSmokeTestTimeout() time.Duration {
+	if r.SmokeTest == nil || r.SmokeTest.TimeoutSeconds == nil {
+		return defaultSmokeTestTimeoutSeconds * time.Second
+	}
+	return time.Duration(*r.SmokeTest.TimeoutSeconds) * time.Second
+}
+
+// GetPreRestartHooks returns the hooks to pass before each broker restart
+func (r RollingUpgradeConfig) GetPreRestartHooks() []RollingUpgradeHook {
+	if r.Hooks == nil {
+		return nil
+	}
+	return r.Hooks.PreRestart
+}
+
+// GetPostRestartHooks returns the hooks to pass after each broker restart
+func (r RollingUpgradeConfig) GetPostRestartHooks() []RollingUpgradeHook {
+	if r.Hooks == nil {
+		return nil
+	}
+	return r.Hooks.PostRestart
+}
+
+// GetTimeout returns the timeout of the hook, the default one of its kind if not specified otherwise
+func (h RollingUpgradeHook) GetTimeout() time.Duration {
+	switch {
+	case h.TimeoutSeconds != nil:
+		return time.Duration(*h.TimeoutSeconds) * time.Second
+	case h.Job != nil:
+		return defaultJobRestartHookTimeoutSeconds * time.Second
+	default:
+		return defaultRestartHookTimeoutSeconds * time.Second
+	}
+}
+
+// GetQuery returns the query of the check: the one matching the firing alerts if alerts are specified, the default
+// under-replicated and offline partition query if neither the query nor the alerts are specified
+func (c PrometheusReplicationCheck) GetQuery() string {
+	switch {
+	case c.Query != "":
+		return c.Query
+	case len(c.Alerts) > 0:
+		alerts := make([]string, 0, len(c.Alerts))
+		for _, alert := range c.Alerts {
+			alerts = append(alerts, regexp.QuoteMeta(alert))
+		}
+		return fmt.Sprintf(`ALERTS{alertstate="firing",alertname=~"%s"}`, strings.Join(alerts, "|"))
+	default:
+		return defaultPrometheusReplicationCheckQuery
+	}
+}
+
+// GetTimeout returns the timeout of the Prometheus query, the default one if not specified otherwise
+func (c PrometheusReplicationCheck) GetTimeout() time.Duration {
+	if c.TimeoutSeconds == nil {
+		return defaultPrometheusReplicationCheckTimeoutSeconds * time.Second
+	}
+	return time.Duration(*c.TimeoutSeconds) * time.Second
+}
+
+// GetPendingTimeout returns the time the pod of a broker has to be unschedulable before it is rescheduled
+func (c BrokerReschedulingConfig) GetPendingTimeout() time.Duration {
+	if c.PendingTimeoutSeconds == nil {
+		return defaultBrokerReschedulingPendingTimeoutSeconds * time.Second
+	}
+	return time.Duration(*c.PendingTimeoutSeconds) * time.Second
+}
+
+// GetRemediation returns the remediation policy of the offline log directories
+func (c LogDirFailureConfig) GetRemediation() LogDirRemediationPolicy {
+	if c.Remediation == "" {
+		return LogDirRemediationNone
+	}
+	return c.Remediation
+}
+
+// GetPollInterval returns the time between the polls of the log directories
+func (c LogDirFailureConfig) GetPollInterval() time.Duration {
+	if c.PollIntervalSeconds == nil {
+		return defaultLogDirFailurePollInterval
+	}
+	return time.Duration(*c.PollIntervalSeconds) * time.Second
+}
+
+// GetTaints returns the keys of the node taints signaling the interruption of the node
+func (c NodeInterruptionConfig) GetTaints() []string {
+	if len(c.Taints) == 0 {
+		return defaultNodeInterruptionTaints
+	}
+	return c.Taints
+}
+
+// GetImbalanceThresholdPercentage returns the percentage of the partitions not led by their preferred leader above
+// which the preferred leaders are elected
+func (c LeaderBalanceConfig) GetImbalanceThresholdPercentage() int32 {
+	if c.ImbalanceThresholdPercentage == nil {
+		return defaultLeaderImbalanceThresholdPercentage
+	}
+	return *c.ImbalanceThresholdPercentage
+}
+
 // GetKubernetesClusterDomain returns the default domain if not specified otherwise
 func (kSpec *KafkaClusterSpec) GetKubernetesClusterDomain() string {
 	if kSpec.KubernetesClusterDomain == "" {
@@ -935,6 +2894,32 @@ func (kSpec *KafkaClusterSpec) GetZkPath() string {
 	return kSpec.ZKPath
 }
 
+// GetDeletionPolicy returns the deletion policy of the cluster, Delete if not specified otherwise
+func (kSpec *KafkaClusterSpec) GetDeletionPolicy() DeletionPolicy {
+	if kSpec.DeletionPolicy == "" {
+		return DeletionPolicyDelete
+	}
+	return kSpec.DeletionPolicy
+}
+
+// IsIstioAmbientMode returns true if the brokers are enrolled into the Istio ambient mesh
+func (kSpec *KafkaClusterSpec) IsIstioAmbientMode() bool {
+	return kSpec.IstioIngressConfig.GetMeshMode() == IstioMeshModeAmbient
+}
+
+// GetDiskPlacementTopicsPattern returns the regular expression matching the whole name of the topics hinted by the
+// disk placement hints, empty if there are no hints
+func (kSpec *KafkaClusterSpec) GetDiskPlacementTopicsPattern() string {
+	if len(kSpec.DiskPlacementHints) == 0 {
+		return ""
+	}
+	patterns := make([]string, 0, len(kSpec.DiskPlacementHints))
+	for _, hint := range kSpec.DiskPlacementHints {
+		patterns = append(patterns, "(?:"+hint.TopicPattern+")")
+	}
+	return "^(?:" + strings.Join(patterns, "|") + ")$"
+}
+
 // GetClusterImage returns the default container image for Kafka Cluster
 func (kSpec *KafkaClusterSpec) GetClusterImage() string {
 	if kSpec.ClusterImage != "" {
@@ -1083,6 +3068,62 @@ func (eConfig *EnvoyConfig) GetPriorityClassName() string {
 	return eConfig.PriorityClassName
 }
 
+// GetPostStartHook returns the custom postStart handler of the kafka container
+func (bConfig *BrokerConfig) GetPostStartHook() *corev1.LifecycleHandler {
+	if bConfig.Lifecycle == nil {
+		return nil
+	}
+	return bConfig.Lifecycle.PostStart
+}
+
+// GetPageCacheWarmup returns the page cache warmup config of the broker
+func (bConfig *BrokerConfig) GetPageCacheWarmup() *PageCacheWarmup {
+	if bConfig.Lifecycle == nil {
+		return nil
+	}
+	return bConfig.Lifecycle.PageCacheWarmup
+}
+
+// GetIndexFiles returns whether the index files are read into the page cache
+func (w *PageCacheWarmup) GetIndexFiles() bool {
+	if w.IndexFiles == nil {
+		return true
+	}
+	return *w.IndexFiles
+}
+
+// GetMaxSegmentsPerStorage returns the maximum number of log segments checkpointed per storage
+func (w *PageCacheWarmup) GetMaxSegmentsPerStorage() int32 {
+	if w.MaxSegmentsPerStorage == nil {
+		return defaultMaxSegmentsPerStorage
+	}
+	return *w.MaxSegmentsPerStorage
+}
+
+// GetTopologyKey returns the node label key of the failure domains the broker pods are spread across
+func (p *BrokerPlacementPolicy) GetTopologyKey() string {
+	if p.TopologyKey == "" {
+		return corev1.LabelTopologyZone
+	}
+	return p.TopologyKey
+}
+
+// GetMaxSkew returns the maximum permitted skew of the broker pods between the failure domains
+func (p *BrokerPlacementPolicy) GetMaxSkew() int32 {
+	if p.MaxSkew == nil {
+		return defaultBrokerPlacementMaxSkew
+	}
+	return *p.MaxSkew
+}
+
+// GetWhenUnsatisfiable returns how the scheduler deals with a broker pod that does not satisfy the spread constraint
+func (p *BrokerPlacementPolicy) GetWhenUnsatisfiable() corev1.UnsatisfiableConstraintAction {
+	if p.WhenUnsatisfiable == "" {
+		return corev1.DoNotSchedule
+	}
+	return p.WhenUnsatisfiable
+}
+
 // GetNodeSelector returns the node selector for the given broker
 func (bConfig *BrokerConfig) GetNodeSelector() map[string]string {
 	return bConfig.NodeSelector
@@ -1232,6 +3273,73 @@ func (bConfig *BrokerConfig) GetResources() *corev1.ResourceRequirements {
 	}
 }
 
+// GetMetricsReporterInitContainerResources returns the resources of the Cruise Control metrics reporter init container
+func (bConfig *BrokerConfig) GetMetricsReporterInitContainerResources() *corev1.ResourceRequirements {
+	if bConfig.MetricsReporterInitContainerResources != nil {
+		return bConfig.MetricsReporterInitContainerResources
+	}
+	return defaultBrokerInitContainerResources()
+}
+
+// GetJmxExporterInitContainerResources returns the resources of the JMX exporter init container
+func (bConfig *BrokerConfig) GetJmxExporterInitContainerResources() *corev1.ResourceRequirements {
+	if bConfig.JmxExporter != nil && bConfig.JmxExporter.Resources != nil {
+		return bConfig.JmxExporter.Resources
+	}
+	if bConfig.JmxExporterInitContainerResources != nil {
+		return bConfig.JmxExporterInitContainerResources
+	}
+	return defaultBrokerInitContainerResources()
+}
+
+// GetJmxExporterImage returns the image of the JMX exporter init container, the one of the monitoring config if not
+// overridden for the broker
+func (bConfig *BrokerConfig) GetJmxExporterImage(mConfig *MonitoringConfig) string {
+	if bConfig.JmxExporter != nil && bConfig.JmxExporter.Image != "" {
+		return bConfig.JmxExporter.Image
+	}
+	return mConfig.GetImage()
+}
+
+// GetJmxExporterPathToJar returns the path of the JMX exporter java agent in its image, the one of the monitoring
+// config if not overridden for the broker
+func (bConfig *BrokerConfig) GetJmxExporterPathToJar(mConfig *MonitoringConfig) string {
+	if bConfig.JmxExporter != nil && bConfig.JmxExporter.PathToJar != "" {
+		return bConfig.JmxExporter.PathToJar
+	}
+	return mConfig.GetPathToJar()
+}
+
+// GetJmxExporterPort returns the container port the JMX exporter of the broker serves the metrics on
+func (bConfig *BrokerConfig) GetJmxExporterPort() int32 {
+	if bConfig.JmxExporter != nil && bConfig.JmxExporter.Port != nil {
+		return *bConfig.JmxExporter.Port
+	}
+	return defaultJmxExporterPort
+}
+
+// GetJmxExporterRulesConfigMapRef returns the reference of the JMX exporter configuration maintained outside of the
+// KafkaCluster, nil if the generated one is used
+func (bConfig *BrokerConfig) GetJmxExporterRulesConfigMapRef() *corev1.ConfigMapKeySelector {
+	if bConfig.JmxExporter == nil {
+		return nil
+	}
+	return bConfig.JmxExporter.RulesConfigMapRef
+}
+
+func defaultBrokerInitContainerResources() *corev1.ResourceRequirements {
+	return &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			"cpu":    resource.MustParse(defaultBrokerInitContainerResourceCpu),
+			"memory": resource.MustParse(defaultBrokerInitContainerResourceMemory),
+		},
+		Limits: corev1.ResourceList{
+			"cpu":    resource.MustParse(defaultBrokerInitContainerResourceCpu),
+			"memory": resource.MustParse(defaultBrokerInitContainerResourceMemory),
+		},
+	}
+}
+
 // GetKafkaHeapOpts returns the broker specific Heap settings
 func (bConfig *BrokerConfig) GetKafkaHeapOpts() string {
 	if bConfig.KafkaHeapOpts != "" {
@@ -1275,6 +3383,22 @@ func (eConfig *EnvoyConfig) GetEnvoyHealthCheckPort() int32 {
 	return defaultEnvoyHealthCheckPort
 }
 
+// GetListenerIdleTimeout returns the idle timeout of the connections of the Envoy listeners
+func (eConfig *EnvoyConfig) GetListenerIdleTimeout() time.Duration {
+	if eConfig.ListenerConfig != nil && eConfig.ListenerConfig.IdleTimeout != nil {
+		return eConfig.ListenerConfig.IdleTimeout.Duration
+	}
+	return defaultEnvoyListenerIdleTimeout
+}
+
+// GetTokensPerFill returns the number of tokens added to the bucket in every fill interval
+func (rConfig *EnvoyConnectionRateLimit) GetTokensPerFill() int32 {
+	if rConfig.TokensPerFill != nil {
+		return *rConfig.TokensPerFill
+	}
+	return 1
+}
+
 // GetCCImage returns the used Cruise Control image
 func (cConfig *CruiseControlConfig) GetCCImage() string {
 	if cConfig.Image != "" {
@@ -1316,6 +3440,32 @@ func (mConfig *MonitoringConfig) GetKafkaJMXExporterConfig() string {
 	return assets.KafkaJmxExporterYaml
 }
 
+// IsPrometheusRulesEnabled returns true if the PrometheusRule of the cluster is generated
+func (mConfig *MonitoringConfig) IsPrometheusRulesEnabled() bool {
+	return mConfig.PrometheusRules != nil && mConfig.PrometheusRules.Enabled
+}
+
+// GetDiskUsageThresholdPercent returns the usage of a broker volume the disk capacity alert fires above
+func (pConfig *PrometheusRulesConfig) GetDiskUsageThresholdPercent() int32 {
+	if pConfig.DiskUsageThresholdPercent != nil {
+		return *pConfig.DiskUsageThresholdPercent
+	}
+	return defaultDiskUsageThresholdPercent
+}
+
+// IsGrafanaDashboardEnabled returns true if the ConfigMap of the Grafana dashboard of the cluster is generated
+func (mConfig *MonitoringConfig) IsGrafanaDashboardEnabled() bool {
+	return mConfig.GrafanaDashboard != nil && mConfig.GrafanaDashboard.Enabled
+}
+
+// GetLabels returns the labels of the ConfigMap of the Grafana dashboard
+func (gConfig *GrafanaDashboardConfig) GetLabels() map[string]string {
+	if len(gConfig.Labels) > 0 {
+		return gConfig.Labels
+	}
+	return map[string]string{defaultGrafanaDashboardLabelKey: "1"}
+}
+
 // GetCCJMXExporterConfig returns the config for CC Prometheus JMX exporter
 func (mConfig *MonitoringConfig) GetCCJMXExporterConfig() string {
 	if mConfig.CCJMXExporterConfig != "" {
@@ -1360,6 +3510,48 @@ func (b *Broker) GetBrokerConfig(kafkaClusterSpec KafkaClusterSpec) (*BrokerConf
 	return bConfig, nil
 }
 
+// IsBrokerConfigMapReferenced returns true if the given ConfigMap is referenced as external broker configuration
+// by any of the broker config groups or brokers
+func (kSpec *KafkaClusterSpec) IsBrokerConfigMapReferenced(name string) bool {
+	for _, groupConfig := range kSpec.BrokerConfigGroups {
+		if groupConfig.ConfigMapRef != nil && groupConfig.ConfigMapRef.Name == name {
+			return true
+		}
+	}
+	for _, broker := range kSpec.Brokers {
+		if broker.BrokerConfig != nil && broker.BrokerConfig.ConfigMapRef != nil && broker.BrokerConfig.ConfigMapRef.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// GetControllerNodeIDs returns the IDs of the brokers with the controller process role, these are the voters of the
+// KRaft controller quorum
+func (kSpec *KafkaClusterSpec) GetControllerNodeIDs() ([]int32, error) {
+	var ids []int32
+	for _, broker := range kSpec.Brokers {
+		brokerConfig, err := broker.GetBrokerConfig(*kSpec)
+		if err != nil {
+			return nil, err
+		}
+		if brokerConfig != nil && brokerConfig.IsControllerNode() {
+			ids = append(ids, broker.Id)
+		}
+	}
+	return ids, nil
+}
+
+// IsSingleNodeCombinedMode returns true when the cluster runs in KRaft mode on a single node acting both as broker and
+// controller, typically a development cluster: the replicas and the quorum can not survive the loss of the node
+func (kSpec *KafkaClusterSpec) IsSingleNodeCombinedMode() bool {
+	if !kSpec.KRaftMode || len(kSpec.Brokers) != 1 {
+		return false
+	}
+	brokerConfig, err := kSpec.Brokers[0].GetBrokerConfig(*kSpec)
+	return err == nil && brokerConfig != nil && brokerConfig.IsCombinedNode()
+}
+
 func mergeEnvs(kafkaClusterSpec KafkaClusterSpec, groupConfig, bConfig *BrokerConfig) []corev1.EnvVar {
 	var envs []corev1.EnvVar
 	envs = append(envs, kafkaClusterSpec.Envs...)
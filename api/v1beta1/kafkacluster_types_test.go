@@ -17,8 +17,10 @@ package v1beta1
 
 import (
 	"reflect"
+	"slices"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"gotest.tools/assert"
@@ -792,3 +794,235 @@ func TestIsCombinedNode(t *testing.T) {
 		})
 	}
 }
+
+func TestIsSingleNodeCombinedMode(t *testing.T) {
+	combined := &BrokerConfig{Roles: []string{"broker", "controller"}}
+	testCases := []struct {
+		testName     string
+		spec         KafkaClusterSpec
+		isSingleNode bool
+	}{
+		{
+			testName:     "single combined node in KRaft mode",
+			spec:         KafkaClusterSpec{KRaftMode: true, Brokers: []Broker{{Id: 0, BrokerConfig: combined}}},
+			isSingleNode: true,
+		},
+		{
+			testName: "single combined node from a broker config group",
+			spec: KafkaClusterSpec{
+				KRaftMode:          true,
+				BrokerConfigGroups: map[string]BrokerConfig{"default": *combined},
+				Brokers:            []Broker{{Id: 0, BrokerConfigGroup: "default"}},
+			},
+			isSingleNode: true,
+		},
+		{
+			testName: "single node in ZooKeeper mode",
+			spec:     KafkaClusterSpec{Brokers: []Broker{{Id: 0, BrokerConfig: combined}}},
+		},
+		{
+			testName: "single broker-only node",
+			spec:     KafkaClusterSpec{KRaftMode: true, Brokers: []Broker{{Id: 0, BrokerConfig: &BrokerConfig{Roles: []string{"broker"}}}}},
+		},
+		{
+			testName: "multiple combined nodes",
+			spec:     KafkaClusterSpec{KRaftMode: true, Brokers: []Broker{{Id: 0, BrokerConfig: combined}, {Id: 1, BrokerConfig: combined}}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			require.Equal(t, test.isSingleNode, test.spec.IsSingleNodeCombinedMode())
+		})
+	}
+}
+
+func TestRollingUpgradeSmokeTestConfig(t *testing.T) {
+	timeout := int32(5)
+	testCases := []struct {
+		testName        string
+		config          RollingUpgradeConfig
+		expectedEnabled bool
+		expectedTopic   string
+		expectedTimeout time.Duration
+	}{
+		{
+			testName:        "smoke test not configured",
+			config:          RollingUpgradeConfig{},
+			expectedEnabled: false,
+			expectedTopic:   defaultSmokeTestTopic,
+			expectedTimeout: defaultSmokeTestTimeoutSeconds * time.Second,
+		},
+		{
+			testName:        "smoke test enabled with defaults",
+			config:          RollingUpgradeConfig{SmokeTest: &RollingUpgradeSmokeTest{Enabled: true}},
+			expectedEnabled: true,
+			expectedTopic:   defaultSmokeTestTopic,
+			expectedTimeout: defaultSmokeTestTimeoutSeconds * time.Second,
+		},
+		{
+			testName: "smoke test enabled with custom topic and timeout",
+			config: RollingUpgradeConfig{SmokeTest: &RollingUpgradeSmokeTest{
+				Enabled:        true,
+				Topic:          "custom-smoke-test",
+				TimeoutSeconds: &timeout,
+			}},
+			expectedEnabled: true,
+			expectedTopic:   "custom-smoke-test",
+			expectedTimeout: 5 * time.Second,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			require.Equal(t, test.expectedEnabled, test.config.IsSmokeTestEnabled())
+			require.Equal(t, test.expectedTopic, test.config.GetSmokeTestTopic())
+			require.Equal(t, test.expectedTimeout, test.config.GetSmokeTestTimeout())
+		})
+	}
+}
+
+func TestIsBrokerConfigMapReferenced(t *testing.T) {
+	ref := &corev1.ConfigMapKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "external-config"},
+		Key:                  "broker.properties",
+	}
+	testCases := []struct {
+		testName   string
+		spec       KafkaClusterSpec
+		referenced bool
+	}{
+		{
+			testName: "not referenced",
+			spec: KafkaClusterSpec{
+				BrokerConfigGroups: map[string]BrokerConfig{"default": {}},
+				Brokers:            []Broker{{Id: 0, BrokerConfigGroup: "default"}},
+			},
+			referenced: false,
+		},
+		{
+			testName: "referenced by broker config group",
+			spec: KafkaClusterSpec{
+				BrokerConfigGroups: map[string]BrokerConfig{"default": {ConfigMapRef: ref}},
+			},
+			referenced: true,
+		},
+		{
+			testName: "referenced by broker",
+			spec: KafkaClusterSpec{
+				Brokers: []Broker{{Id: 0, BrokerConfig: &BrokerConfig{ConfigMapRef: ref}}},
+			},
+			referenced: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			require.Equal(t, test.referenced, test.spec.IsBrokerConfigMapReferenced("external-config"))
+		})
+	}
+}
+
+func TestPrometheusReplicationCheckGetQuery(t *testing.T) {
+	testCases := []struct {
+		testName      string
+		check         PrometheusReplicationCheck
+		expectedQuery string
+	}{
+		{
+			testName:      "default query",
+			check:         PrometheusReplicationCheck{},
+			expectedQuery: defaultPrometheusReplicationCheckQuery,
+		},
+		{
+			testName:      "custom query",
+			check:         PrometheusReplicationCheck{Query: "kafka_under_replicated > 0"},
+			expectedQuery: "kafka_under_replicated > 0",
+		},
+		{
+			testName:      "alerts",
+			check:         PrometheusReplicationCheck{Alerts: []string{"KafkaUnderReplicatedPartitions", "Kafka.Offline"}},
+			expectedQuery: `ALERTS{alertstate="firing",alertname=~"KafkaUnderReplicatedPartitions|Kafka\.Offline"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			require.Equal(t, test.expectedQuery, test.check.GetQuery())
+		})
+	}
+}
+
+func TestCruiseControlSelfHealing(t *testing.T) {
+	pollIntervalSeconds := int32(30)
+	testCases := []struct {
+		testName             string
+		selfHealing          *CruiseControlSelfHealing
+		expectedPollInterval time.Duration
+		expectedRemediated   []CruiseControlAnomalyType
+	}{
+		{
+			testName:             "self-healing not configured",
+			expectedPollInterval: time.Minute,
+		},
+		{
+			testName:             "defaults",
+			selfHealing:          &CruiseControlSelfHealing{},
+			expectedPollInterval: time.Minute,
+		},
+		{
+			testName: "custom",
+			selfHealing: &CruiseControlSelfHealing{
+				PollIntervalSeconds: &pollIntervalSeconds,
+				Remediations:        []CruiseControlAnomalyType{CruiseControlAnomalyBrokerFailure},
+			},
+			expectedPollInterval: 30 * time.Second,
+			expectedRemediated:   []CruiseControlAnomalyType{CruiseControlAnomalyBrokerFailure},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			require.Equal(t, test.expectedPollInterval, test.selfHealing.GetPollInterval())
+			for _, anomalyType := range []CruiseControlAnomalyType{
+				CruiseControlAnomalyGoalViolation, CruiseControlAnomalyBrokerFailure, CruiseControlAnomalyDiskFailure,
+			} {
+				require.Equal(t, slices.Contains(test.expectedRemediated, anomalyType), test.selfHealing.IsRemediated(anomalyType))
+			}
+		})
+	}
+}
+
+func TestCruiseControlConfigWithDeploymentOf(t *testing.T) {
+	ref := &corev1.LocalObjectReference{Name: "cruisecontrol"}
+	selfHealing := &CruiseControlSelfHealing{Remediations: []CruiseControlAnomalyType{CruiseControlAnomalyBrokerFailure}}
+	cluster := CruiseControlConfig{
+		CruiseControlTaskSpec: CruiseControlTaskSpec{RetryDurationMinutes: 5},
+		Image:                 "cruisecontrol:cluster",
+		Config:                "num.metric.fetchers=1",
+		TopicConfig:           &TopicConfig{Partitions: 12, ReplicationFactor: 3},
+		SelfHealing:           selfHealing,
+		CruiseControlRef:      ref,
+	}
+	template := CruiseControlConfig{
+		CruiseControlTaskSpec: CruiseControlTaskSpec{RetryDurationMinutes: 10},
+		Image:                 "cruisecontrol:template",
+		Config:                "num.metric.fetchers=2",
+		NodeSelector:          map[string]string{"pool": "cruisecontrol"},
+	}
+
+	config := cluster.WithDeploymentOf(template)
+
+	require.Equal(t, CruiseControlConfig{
+		CruiseControlTaskSpec: CruiseControlTaskSpec{RetryDurationMinutes: 5},
+		Image:                 "cruisecontrol:template",
+		Config:                "num.metric.fetchers=2",
+		NodeSelector:          map[string]string{"pool": "cruisecontrol"},
+		TopicConfig:           &TopicConfig{Partitions: 12, ReplicationFactor: 3},
+		SelfHealing:           selfHealing,
+		CruiseControlRef:      ref,
+	}, config)
+	// the template is not shared with the returned configuration
+	config.NodeSelector["pool"] = "kafka"
+	require.Equal(t, "cruisecontrol", template.NodeSelector["pool"])
+}
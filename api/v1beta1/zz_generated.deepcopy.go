@@ -44,6 +44,31 @@ func (in *AlertManagerConfig) DeepCopy() *AlertManagerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthorizationConfig) DeepCopyInto(out *AuthorizationConfig) {
+	*out = *in
+	if in.SuperUsers != nil {
+		in, out := &in.SuperUsers, &out.SuperUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowEveryoneIfNoACLFound != nil {
+		in, out := &in.AllowEveryoneIfNoACLFound, &out.AllowEveryoneIfNoACLFound
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationConfig.
+func (in *AuthorizationConfig) DeepCopy() *AuthorizationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthorizationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Broker) DeepCopyInto(out *Broker) {
 	*out = *in
@@ -52,6 +77,11 @@ func (in *Broker) DeepCopyInto(out *Broker) {
 		*out = new(BrokerConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Replacement != nil {
+		in, out := &in.Replacement, &out.Replacement
+		*out = new(BrokerReplacement)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Broker.
@@ -103,6 +133,11 @@ func (in *BrokerConfig) DeepCopyInto(out *BrokerConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.BrokerAnnotations != nil {
 		in, out := &in.BrokerAnnotations, &out.BrokerAnnotations
 		*out = make(map[string]string, len(*in))
@@ -156,6 +191,21 @@ func (in *BrokerConfig) DeepCopyInto(out *BrokerConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MetricsReporterInitContainerResources != nil {
+		in, out := &in.MetricsReporterInitContainerResources, &out.MetricsReporterInitContainerResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JmxExporterInitContainerResources != nil {
+		in, out := &in.JmxExporterInitContainerResources, &out.JmxExporterInitContainerResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JmxExporter != nil {
+		in, out := &in.JmxExporter, &out.JmxExporter
+		*out = new(BrokerJmxExporterConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Containers != nil {
 		in, out := &in.Containers, &out.Containers
 		*out = make([]v1.Container, len(*in))
@@ -189,6 +239,16 @@ func (in *BrokerConfig) DeepCopyInto(out *BrokerConfig) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.Lifecycle != nil {
+		in, out := &in.Lifecycle, &out.Lifecycle
+		*out = new(BrokerLifecycle)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PlacementPolicy != nil {
+		in, out := &in.PlacementPolicy, &out.PlacementPolicy
+		*out = new(BrokerPlacementPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerConfig.
@@ -202,282 +262,484 @@ func (in *BrokerConfig) DeepCopy() *BrokerConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BrokerState) DeepCopyInto(out *BrokerState) {
+func (in *BrokerDecommissionStatus) DeepCopyInto(out *BrokerDecommissionStatus) {
 	*out = *in
-	in.GracefulActionState.DeepCopyInto(&out.GracefulActionState)
-	if in.ExternalListenerConfigNames != nil {
-		in, out := &in.ExternalListenerConfigNames, &out.ExternalListenerConfigNames
-		*out = make(ExternalListenerConfigNames, len(*in))
-		copy(*out, *in)
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.VerificationTime != nil {
+		in, out := &in.VerificationTime, &out.VerificationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
 	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerState.
-func (in *BrokerState) DeepCopy() *BrokerState {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerDecommissionStatus.
+func (in *BrokerDecommissionStatus) DeepCopy() *BrokerDecommissionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(BrokerState)
+	out := new(BrokerDecommissionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CommonListenerSpec) DeepCopyInto(out *CommonListenerSpec) {
+func (in *BrokerDrainState) DeepCopyInto(out *BrokerDrainState) {
 	*out = *in
-	if in.ServerSSLCertSecret != nil {
-		in, out := &in.ServerSSLCertSecret, &out.ServerSSLCertSecret
-		*out = new(v1.LocalObjectReference)
-		**out = **in
-	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonListenerSpec.
-func (in *CommonListenerSpec) DeepCopy() *CommonListenerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerDrainState.
+func (in *BrokerDrainState) DeepCopy() *BrokerDrainState {
 	if in == nil {
 		return nil
 	}
-	out := new(CommonListenerSpec)
+	out := new(BrokerDrainState)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Config) DeepCopyInto(out *Config) {
+func (in *BrokerJmxExporterConfig) DeepCopyInto(out *BrokerJmxExporterConfig) {
 	*out = *in
-	if in.IngressConfig != nil {
-		in, out := &in.IngressConfig, &out.IngressConfig
-		*out = make(map[string]IngressConfig, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RulesConfigMapRef != nil {
+		in, out := &in.RulesConfigMapRef, &out.RulesConfigMapRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Config.
-func (in *Config) DeepCopy() *Config {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerJmxExporterConfig.
+func (in *BrokerJmxExporterConfig) DeepCopy() *BrokerJmxExporterConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(Config)
+	out := new(BrokerJmxExporterConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ContourIngressConfig) DeepCopyInto(out *ContourIngressConfig) {
+func (in *BrokerLifecycle) DeepCopyInto(out *BrokerLifecycle) {
 	*out = *in
+	if in.PostStart != nil {
+		in, out := &in.PostStart, &out.PostStart
+		*out = new(v1.LifecycleHandler)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PageCacheWarmup != nil {
+		in, out := &in.PageCacheWarmup, &out.PageCacheWarmup
+		*out = new(PageCacheWarmup)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContourIngressConfig.
-func (in *ContourIngressConfig) DeepCopy() *ContourIngressConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerLifecycle.
+func (in *BrokerLifecycle) DeepCopy() *BrokerLifecycle {
 	if in == nil {
 		return nil
 	}
-	out := new(ContourIngressConfig)
+	out := new(BrokerLifecycle)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CruiseControlConfig) DeepCopyInto(out *CruiseControlConfig) {
+func (in *BrokerPlacementPolicy) DeepCopyInto(out *BrokerPlacementPolicy) {
 	*out = *in
-	out.CruiseControlTaskSpec = in.CruiseControlTaskSpec
-	if in.CruiseControlOperationSpec != nil {
-		in, out := &in.CruiseControlOperationSpec, &out.CruiseControlOperationSpec
-		*out = new(CruiseControlOperationSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	if in.MaxSkew != nil {
+		in, out := &in.MaxSkew, &out.MaxSkew
+		*out = new(int32)
+		**out = **in
 	}
-	if in.ImagePullSecrets != nil {
-		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]v1.LocalObjectReference, len(*in))
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerPlacementPolicy.
+func (in *BrokerPlacementPolicy) DeepCopy() *BrokerPlacementPolicy {
+	if in == nil {
+		return nil
 	}
-	if in.TopicConfig != nil {
-		in, out := &in.TopicConfig, &out.TopicConfig
-		*out = new(TopicConfig)
+	out := new(BrokerPlacementPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerReadinessConfig) DeepCopyInto(out *BrokerReadinessConfig) {
+	*out = *in
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(int32)
 		**out = **in
 	}
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.CruiseControlAnnotations != nil {
-		in, out := &in.CruiseControlAnnotations, &out.CruiseControlAnnotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.InitContainers != nil {
-		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]v1.Container, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Volumes != nil {
-		in, out := &in.Volumes, &out.Volumes
-		*out = make([]v1.Volume, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.VolumeMounts != nil {
-		in, out := &in.VolumeMounts, &out.VolumeMounts
-		*out = make([]v1.VolumeMount, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.PodSecurityContext != nil {
-		in, out := &in.PodSecurityContext, &out.PodSecurityContext
-		*out = new(v1.PodSecurityContext)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.SecurityContext != nil {
-		in, out := &in.SecurityContext, &out.SecurityContext
-		*out = new(v1.SecurityContext)
-		(*in).DeepCopyInto(*out)
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CruiseControlConfig.
-func (in *CruiseControlConfig) DeepCopy() *CruiseControlConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerReadinessConfig.
+func (in *BrokerReadinessConfig) DeepCopy() *BrokerReadinessConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(CruiseControlConfig)
+	out := new(BrokerReadinessConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CruiseControlOperationSpec) DeepCopyInto(out *CruiseControlOperationSpec) {
+func (in *BrokerReplacement) DeepCopyInto(out *BrokerReplacement) {
 	*out = *in
-	if in.TTLSecondsAfterFinished != nil {
-		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
-		*out = new(int)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CruiseControlOperationSpec.
-func (in *CruiseControlOperationSpec) DeepCopy() *CruiseControlOperationSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerReplacement.
+func (in *BrokerReplacement) DeepCopy() *BrokerReplacement {
 	if in == nil {
 		return nil
 	}
-	out := new(CruiseControlOperationSpec)
+	out := new(BrokerReplacement)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CruiseControlTaskSpec) DeepCopyInto(out *CruiseControlTaskSpec) {
+func (in *BrokerReplacementState) DeepCopyInto(out *BrokerReplacementState) {
 	*out = *in
+	if in.DemoteOperationReference != nil {
+		in, out := &in.DemoteOperationReference, &out.DemoteOperationReference
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CruiseControlTaskSpec.
-func (in *CruiseControlTaskSpec) DeepCopy() *CruiseControlTaskSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerReplacementState.
+func (in *BrokerReplacementState) DeepCopy() *BrokerReplacementState {
 	if in == nil {
 		return nil
 	}
-	out := new(CruiseControlTaskSpec)
+	out := new(BrokerReplacementState)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DisruptionBudget) DeepCopyInto(out *DisruptionBudget) {
+func (in *BrokerReschedulingConfig) DeepCopyInto(out *BrokerReschedulingConfig) {
 	*out = *in
+	if in.PendingTimeoutSeconds != nil {
+		in, out := &in.PendingTimeoutSeconds, &out.PendingTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionBudget.
-func (in *DisruptionBudget) DeepCopy() *DisruptionBudget {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerReschedulingConfig.
+func (in *BrokerReschedulingConfig) DeepCopy() *BrokerReschedulingConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(DisruptionBudget)
+	out := new(BrokerReschedulingConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DisruptionBudgetWithStrategy) DeepCopyInto(out *DisruptionBudgetWithStrategy) {
+func (in *BrokerState) DeepCopyInto(out *BrokerState) {
 	*out = *in
-	out.DisruptionBudget = in.DisruptionBudget
+	in.GracefulActionState.DeepCopyInto(&out.GracefulActionState)
+	if in.DynamicConfigKeys != nil {
+		in, out := &in.DynamicConfigKeys, &out.DynamicConfigKeys
+		*out = make(DynamicConfigKeys, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExternalListenerConfigNames != nil {
+		in, out := &in.ExternalListenerConfigNames, &out.ExternalListenerConfigNames
+		*out = make(ExternalListenerConfigNames, len(*in))
+		copy(*out, *in)
+	}
+	if in.DrainState != nil {
+		in, out := &in.DrainState, &out.DrainState
+		*out = new(BrokerDrainState)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReplacementState != nil {
+		in, out := &in.ReplacementState, &out.ReplacementState
+		*out = new(BrokerReplacementState)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageMigrations != nil {
+		in, out := &in.StorageMigrations, &out.StorageMigrations
+		*out = make(StorageMigrationStates, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.NodeInterruption != nil {
+		in, out := &in.NodeInterruption, &out.NodeInterruption
+		*out = new(NodeInterruptionState)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogDirFailures != nil {
+		in, out := &in.LogDirFailures, &out.LogDirFailures
+		*out = make(LogDirFailureStates, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionBudgetWithStrategy.
-func (in *DisruptionBudgetWithStrategy) DeepCopy() *DisruptionBudgetWithStrategy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerState.
+func (in *BrokerState) DeepCopy() *BrokerState {
 	if in == nil {
 		return nil
 	}
-	out := new(DisruptionBudgetWithStrategy)
+	out := new(BrokerState)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EnvoyCommandLineArgs) DeepCopyInto(out *EnvoyCommandLineArgs) {
+func (in *CABundleDistribution) DeepCopyInto(out *CABundleDistribution) {
 	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyCommandLineArgs.
-func (in *EnvoyCommandLineArgs) DeepCopy() *EnvoyCommandLineArgs {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CABundleDistribution.
+func (in *CABundleDistribution) DeepCopy() *CABundleDistribution {
 	if in == nil {
 		return nil
 	}
-	out := new(EnvoyCommandLineArgs)
+	out := new(CABundleDistribution)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EnvoyConfig) DeepCopyInto(out *EnvoyConfig) {
+func (in *CommonListenerSpec) DeepCopyInto(out *CommonListenerSpec) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+	if in.ServerSSLCertSecret != nil {
+		in, out := &in.ServerSSLCertSecret, &out.ServerSSLCertSecret
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ServerCertificate != nil {
+		in, out := &in.ServerCertificate, &out.ServerCertificate
+		*out = new(ListenerServerCertificate)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ImagePullSecrets != nil {
-		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]v1.LocalObjectReference, len(*in))
-		copy(*out, *in)
+	if in.OAuthBearer != nil {
+		in, out := &in.OAuthBearer, &out.OAuthBearer
+		*out = new(OAuthBearerConfig)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
+	if in.Kerberos != nil {
+		in, out := &in.Kerberos, &out.Kerberos
+		*out = new(KerberosConfig)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.TopologySpreadConstraints != nil {
-		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
-		*out = make([]v1.TopologySpreadConstraint, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonListenerSpec.
+func (in *CommonListenerSpec) DeepCopy() *CommonListenerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CommonListenerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Config) DeepCopyInto(out *Config) {
+	*out = *in
+	if in.IngressConfig != nil {
+		in, out := &in.IngressConfig, &out.IngressConfig
+		*out = make(map[string]IngressConfig, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Config.
+func (in *Config) DeepCopy() *Config {
+	if in == nil {
+		return nil
+	}
+	out := new(Config)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConformanceAuditFinding) DeepCopyInto(out *ConformanceAuditFinding) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConformanceAuditFinding.
+func (in *ConformanceAuditFinding) DeepCopy() *ConformanceAuditFinding {
+	if in == nil {
+		return nil
+	}
+	out := new(ConformanceAuditFinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConformanceAuditStatus) DeepCopyInto(out *ConformanceAuditStatus) {
+	*out = *in
+	if in.Findings != nil {
+		in, out := &in.Findings, &out.Findings
+		*out = make([]ConformanceAuditFinding, len(*in))
+		copy(*out, *in)
+	}
+	in.LastAuditTime.DeepCopyInto(&out.LastAuditTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConformanceAuditStatus.
+func (in *ConformanceAuditStatus) DeepCopy() *ConformanceAuditStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConformanceAuditStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContourIngressConfig) DeepCopyInto(out *ContourIngressConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContourIngressConfig.
+func (in *ContourIngressConfig) DeepCopy() *ContourIngressConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ContourIngressConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerQuorumStatus) DeepCopyInto(out *ControllerQuorumStatus) {
+	*out = *in
+	if in.Voters != nil {
+		in, out := &in.Voters, &out.Voters
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReadyVoters != nil {
+		in, out := &in.ReadyVoters, &out.ReadyVoters
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerQuorumStatus.
+func (in *ControllerQuorumStatus) DeepCopy() *ControllerQuorumStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerQuorumStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CruiseControlAnomaliesStatus) DeepCopyInto(out *CruiseControlAnomaliesStatus) {
+	*out = *in
+	if in.Anomalies != nil {
+		in, out := &in.Anomalies, &out.Anomalies
+		*out = make([]CruiseControlAnomaly, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CruiseControlAnomaliesStatus.
+func (in *CruiseControlAnomaliesStatus) DeepCopy() *CruiseControlAnomaliesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CruiseControlAnomaliesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CruiseControlAnomaly) DeepCopyInto(out *CruiseControlAnomaly) {
+	*out = *in
+	in.DetectionTime.DeepCopyInto(&out.DetectionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CruiseControlAnomaly.
+func (in *CruiseControlAnomaly) DeepCopy() *CruiseControlAnomaly {
+	if in == nil {
+		return nil
+	}
+	out := new(CruiseControlAnomaly)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CruiseControlConfig) DeepCopyInto(out *CruiseControlConfig) {
+	*out = *in
+	out.CruiseControlTaskSpec = in.CruiseControlTaskSpec
+	if in.CruiseControlOperationSpec != nil {
+		in, out := &in.CruiseControlOperationSpec, &out.CruiseControlOperationSpec
+		*out = new(CruiseControlOperationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
 	if in.NodeSelector != nil {
 		in, out := &in.NodeSelector, &out.NodeSelector
 		*out = make(map[string]string, len(*in))
@@ -492,650 +754,2095 @@ func (in *EnvoyConfig) DeepCopyInto(out *EnvoyConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
+	if in.TopicConfig != nil {
+		in, out := &in.TopicConfig, &out.TopicConfig
+		*out = new(TopicConfig)
+		**out = **in
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CruiseControlAnnotations != nil {
+		in, out := &in.CruiseControlAnnotations, &out.CruiseControlAnnotations
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.LoadBalancerSourceRanges != nil {
-		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.AdminPort != nil {
-		in, out := &in.AdminPort, &out.AdminPort
-		*out = new(int32)
-		**out = **in
-	}
-	if in.HealthCheckPort != nil {
-		in, out := &in.HealthCheckPort, &out.HealthCheckPort
-		*out = new(int32)
-		**out = **in
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.DisruptionBudget != nil {
-		in, out := &in.DisruptionBudget, &out.DisruptionBudget
-		*out = new(DisruptionBudgetWithStrategy)
-		**out = **in
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.CommandLineArgs != nil {
-		in, out := &in.CommandLineArgs, &out.CommandLineArgs
-		*out = new(EnvoyCommandLineArgs)
-		**out = **in
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.PodSecurityContext != nil {
 		in, out := &in.PodSecurityContext, &out.PodSecurityContext
 		*out = new(v1.PodSecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SelfHealing != nil {
+		in, out := &in.SelfHealing, &out.SelfHealing
+		*out = new(CruiseControlSelfHealing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CruiseControlRef != nil {
+		in, out := &in.CruiseControlRef, &out.CruiseControlRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.RebalanceSchedule != nil {
+		in, out := &in.RebalanceSchedule, &out.RebalanceSchedule
+		*out = new(RebalanceSchedule)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyConfig.
-func (in *EnvoyConfig) DeepCopy() *EnvoyConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CruiseControlConfig.
+func (in *CruiseControlConfig) DeepCopy() *CruiseControlConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(EnvoyConfig)
+	out := new(CruiseControlConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExternalListenerConfig) DeepCopyInto(out *ExternalListenerConfig) {
+func (in *CruiseControlOperationSpec) DeepCopyInto(out *CruiseControlOperationSpec) {
 	*out = *in
-	in.CommonListenerSpec.DeepCopyInto(&out.CommonListenerSpec)
-	in.IngressServiceSettings.DeepCopyInto(&out.IngressServiceSettings)
-	if in.AnyCastPort != nil {
-		in, out := &in.AnyCastPort, &out.AnyCastPort
-		*out = new(int32)
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int)
 		**out = **in
 	}
-	if in.IngressControllerTargetPort != nil {
-		in, out := &in.IngressControllerTargetPort, &out.IngressControllerTargetPort
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CruiseControlOperationSpec.
+func (in *CruiseControlOperationSpec) DeepCopy() *CruiseControlOperationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CruiseControlOperationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CruiseControlSelfHealing) DeepCopyInto(out *CruiseControlSelfHealing) {
+	*out = *in
+	if in.PollIntervalSeconds != nil {
+		in, out := &in.PollIntervalSeconds, &out.PollIntervalSeconds
 		*out = new(int32)
 		**out = **in
 	}
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = new(Config)
-		(*in).DeepCopyInto(*out)
+	if in.Remediations != nil {
+		in, out := &in.Remediations, &out.Remediations
+		*out = make([]CruiseControlAnomalyType, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalListenerConfig.
-func (in *ExternalListenerConfig) DeepCopy() *ExternalListenerConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CruiseControlSelfHealing.
+func (in *CruiseControlSelfHealing) DeepCopy() *CruiseControlSelfHealing {
+	if in == nil {
+		return nil
+	}
+	out := new(CruiseControlSelfHealing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CruiseControlTaskSpec) DeepCopyInto(out *CruiseControlTaskSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CruiseControlTaskSpec.
+func (in *CruiseControlTaskSpec) DeepCopy() *CruiseControlTaskSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CruiseControlTaskSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DetectedVersionsStatus) DeepCopyInto(out *DetectedVersionsStatus) {
+	*out = *in
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Incompatibilities != nil {
+		in, out := &in.Incompatibilities, &out.Incompatibilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DetectedVersionsStatus.
+func (in *DetectedVersionsStatus) DeepCopy() *DetectedVersionsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DetectedVersionsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskPlacementHint) DeepCopyInto(out *DiskPlacementHint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskPlacementHint.
+func (in *DiskPlacementHint) DeepCopy() *DiskPlacementHint {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskPlacementHint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskPlacementStatus) DeepCopyInto(out *DiskPlacementStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskPlacementStatus.
+func (in *DiskPlacementStatus) DeepCopy() *DiskPlacementStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskPlacementStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionBudget) DeepCopyInto(out *DisruptionBudget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionBudget.
+func (in *DisruptionBudget) DeepCopy() *DisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionBudgetWithStrategy) DeepCopyInto(out *DisruptionBudgetWithStrategy) {
+	*out = *in
+	out.DisruptionBudget = in.DisruptionBudget
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionBudgetWithStrategy.
+func (in *DisruptionBudgetWithStrategy) DeepCopy() *DisruptionBudgetWithStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionBudgetWithStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in DynamicConfigKeys) DeepCopyInto(out *DynamicConfigKeys) {
+	{
+		in := &in
+		*out = make(DynamicConfigKeys, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicConfigKeys.
+func (in DynamicConfigKeys) DeepCopy() DynamicConfigKeys {
+	if in == nil {
+		return nil
+	}
+	out := new(DynamicConfigKeys)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyCommandLineArgs) DeepCopyInto(out *EnvoyCommandLineArgs) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyCommandLineArgs.
+func (in *EnvoyCommandLineArgs) DeepCopy() *EnvoyCommandLineArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyCommandLineArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyConfig) DeepCopyInto(out *EnvoyConfig) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LoadBalancerSourceRanges != nil {
+		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdminPort != nil {
+		in, out := &in.AdminPort, &out.AdminPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HealthCheckPort != nil {
+		in, out := &in.HealthCheckPort, &out.HealthCheckPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DisruptionBudget != nil {
+		in, out := &in.DisruptionBudget, &out.DisruptionBudget
+		*out = new(DisruptionBudgetWithStrategy)
+		**out = **in
+	}
+	if in.CommandLineArgs != nil {
+		in, out := &in.CommandLineArgs, &out.CommandLineArgs
+		*out = new(EnvoyCommandLineArgs)
+		**out = **in
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ListenerConfig != nil {
+		in, out := &in.ListenerConfig, &out.ListenerConfig
+		*out = new(EnvoyListenerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyConfig.
+func (in *EnvoyConfig) DeepCopy() *EnvoyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyConnectionRateLimit) DeepCopyInto(out *EnvoyConnectionRateLimit) {
+	*out = *in
+	if in.TokensPerFill != nil {
+		in, out := &in.TokensPerFill, &out.TokensPerFill
+		*out = new(int32)
+		**out = **in
+	}
+	out.FillInterval = in.FillInterval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyConnectionRateLimit.
+func (in *EnvoyConnectionRateLimit) DeepCopy() *EnvoyConnectionRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyConnectionRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyListenerConfig) DeepCopyInto(out *EnvoyListenerConfig) {
+	*out = *in
+	if in.MaxConnections != nil {
+		in, out := &in.MaxConnections, &out.MaxConnections
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ConnectionRateLimit != nil {
+		in, out := &in.ConnectionRateLimit, &out.ConnectionRateLimit
+		*out = new(EnvoyConnectionRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyListenerConfig.
+func (in *EnvoyListenerConfig) DeepCopy() *EnvoyListenerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyListenerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalListenerAccess) DeepCopyInto(out *ExternalListenerAccess) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalListenerAccess.
+func (in *ExternalListenerAccess) DeepCopy() *ExternalListenerAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalListenerAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalListenerAccessStatus) DeepCopyInto(out *ExternalListenerAccessStatus) {
+	*out = *in
+	out.ExternalListenerAccess = in.ExternalListenerAccess
+	if in.Transition != nil {
+		in, out := &in.Transition, &out.Transition
+		*out = new(ExternalListenerAccessTransition)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalListenerAccessStatus.
+func (in *ExternalListenerAccessStatus) DeepCopy() *ExternalListenerAccessStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalListenerAccessStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalListenerAccessTransition) DeepCopyInto(out *ExternalListenerAccessTransition) {
+	*out = *in
+	out.Previous = in.Previous
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalListenerAccessTransition.
+func (in *ExternalListenerAccessTransition) DeepCopy() *ExternalListenerAccessTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalListenerAccessTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalListenerConfig) DeepCopyInto(out *ExternalListenerConfig) {
+	*out = *in
+	in.CommonListenerSpec.DeepCopyInto(&out.CommonListenerSpec)
+	in.IngressServiceSettings.DeepCopyInto(&out.IngressServiceSettings)
+	if in.AnyCastPort != nil {
+		in, out := &in.AnyCastPort, &out.AnyCastPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IngressControllerTargetPort != nil {
+		in, out := &in.IngressControllerTargetPort, &out.IngressControllerTargetPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PerBrokerLoadBalancerConfig != nil {
+		in, out := &in.PerBrokerLoadBalancerConfig, &out.PerBrokerLoadBalancerConfig
+		*out = new(PerBrokerLoadBalancerConfig)
+		**out = **in
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(Config)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalListenerConfig.
+func (in *ExternalListenerConfig) DeepCopy() *ExternalListenerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalListenerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ExternalListenerConfigNames) DeepCopyInto(out *ExternalListenerConfigNames) {
+	{
+		in := &in
+		*out = make(ExternalListenerConfigNames, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalListenerConfigNames.
+func (in ExternalListenerConfigNames) DeepCopy() ExternalListenerConfigNames {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalListenerConfigNames)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayAPIConfig) DeepCopyInto(out *GatewayAPIConfig) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayAPIConfig.
+func (in *GatewayAPIConfig) DeepCopy() *GatewayAPIConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayAPIConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GracefulActionState) DeepCopyInto(out *GracefulActionState) {
+	*out = *in
+	if in.CruiseControlOperationReference != nil {
+		in, out := &in.CruiseControlOperationReference, &out.CruiseControlOperationReference
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.VolumeStates != nil {
+		in, out := &in.VolumeStates, &out.VolumeStates
+		*out = make(map[string]VolumeState, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GracefulActionState.
+func (in *GracefulActionState) DeepCopy() *GracefulActionState {
+	if in == nil {
+		return nil
+	}
+	out := new(GracefulActionState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaDashboardConfig) DeepCopyInto(out *GrafanaDashboardConfig) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaDashboardConfig.
+func (in *GrafanaDashboardConfig) DeepCopy() *GrafanaDashboardConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaDashboardConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRestartHook) DeepCopyInto(out *HTTPRestartHook) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRestartHook.
+func (in *HTTPRestartHook) DeepCopy() *HTTPRestartHook {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRestartHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressConfig) DeepCopyInto(out *IngressConfig) {
+	*out = *in
+	in.IngressServiceSettings.DeepCopyInto(&out.IngressServiceSettings)
+	if in.IstioIngressConfig != nil {
+		in, out := &in.IstioIngressConfig, &out.IstioIngressConfig
+		*out = new(IstioIngressConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnvoyConfig != nil {
+		in, out := &in.EnvoyConfig, &out.EnvoyConfig
+		*out = new(EnvoyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContourIngressConfig != nil {
+		in, out := &in.ContourIngressConfig, &out.ContourIngressConfig
+		*out = new(ContourIngressConfig)
+		**out = **in
+	}
+	if in.GatewayAPIConfig != nil {
+		in, out := &in.GatewayAPIConfig, &out.GatewayAPIConfig
+		*out = new(GatewayAPIConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NginxIngressConfig != nil {
+		in, out := &in.NginxIngressConfig, &out.NginxIngressConfig
+		*out = new(NginxIngressConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressConfig.
+func (in *IngressConfig) DeepCopy() *IngressConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressServiceSettings) DeepCopyInto(out *IngressServiceSettings) {
+	*out = *in
+	if in.ServiceAnnotations != nil {
+		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressServiceSettings.
+func (in *IngressServiceSettings) DeepCopy() *IngressServiceSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressServiceSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalListenerConfig) DeepCopyInto(out *InternalListenerConfig) {
+	*out = *in
+	in.CommonListenerSpec.DeepCopyInto(&out.CommonListenerSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalListenerConfig.
+func (in *InternalListenerConfig) DeepCopy() *InternalListenerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalListenerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalTopicsConfig) DeepCopyInto(out *InternalTopicsConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalTopicsConfig.
+func (in *InternalTopicsConfig) DeepCopy() *InternalTopicsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalTopicsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IstioControlPlaneReference) DeepCopyInto(out *IstioControlPlaneReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IstioControlPlaneReference.
+func (in *IstioControlPlaneReference) DeepCopy() *IstioControlPlaneReference {
+	if in == nil {
+		return nil
+	}
+	out := new(IstioControlPlaneReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IstioIngressConfig) DeepCopyInto(out *IstioIngressConfig) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]*v1.Toleration, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(v1.Toleration)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TLSOptions != nil {
+		in, out := &in.TLSOptions, &out.TLSOptions
+		*out = new(networkingv1beta1.TLSOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VirtualServiceAnnotations != nil {
+		in, out := &in.VirtualServiceAnnotations, &out.VirtualServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Envs != nil {
+		in, out := &in.Envs, &out.Envs
+		*out = make([]*v1.EnvVar, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(v1.EnvVar)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.LoadBalancerSourceRanges != nil {
+		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IstioIngressConfig.
+func (in *IstioIngressConfig) DeepCopy() *IstioIngressConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IstioIngressConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobRestartHook) DeepCopyInto(out *JobRestartHook) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobRestartHook.
+func (in *JobRestartHook) DeepCopy() *JobRestartHook {
+	if in == nil {
+		return nil
+	}
+	out := new(JobRestartHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KRaftMigrationConfig) DeepCopyInto(out *KRaftMigrationConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KRaftMigrationConfig.
+func (in *KRaftMigrationConfig) DeepCopy() *KRaftMigrationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KRaftMigrationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KRaftMigrationStatus) DeepCopyInto(out *KRaftMigrationStatus) {
+	*out = *in
+	if in.MigratedBrokers != nil {
+		in, out := &in.MigratedBrokers, &out.MigratedBrokers
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KRaftMigrationStatus.
+func (in *KRaftMigrationStatus) DeepCopy() *KRaftMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KRaftMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaCluster) DeepCopyInto(out *KafkaCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaCluster.
+func (in *KafkaCluster) DeepCopy() *KafkaCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KafkaCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaClusterList) DeepCopyInto(out *KafkaClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KafkaCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaClusterList.
+func (in *KafkaClusterList) DeepCopy() *KafkaClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KafkaClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaClusterSpec) DeepCopyInto(out *KafkaClusterSpec) {
+	*out = *in
+	in.ListenersConfig.DeepCopyInto(&out.ListenersConfig)
+	if in.AdditionalPorts != nil {
+		in, out := &in.AdditionalPorts, &out.AdditionalPorts
+		*out = make([]v1.ContainerPort, len(*in))
+		copy(*out, *in)
+	}
+	if in.ZKAddresses != nil {
+		in, out := &in.ZKAddresses, &out.ZKAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RackAwareness != nil {
+		in, out := &in.RackAwareness, &out.RackAwareness
+		*out = new(RackAwareness)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BrokerConfigGroups != nil {
+		in, out := &in.BrokerConfigGroups, &out.BrokerConfigGroups
+		*out = make(map[string]BrokerConfig, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Brokers != nil {
+		in, out := &in.Brokers, &out.Brokers
+		*out = make([]Broker, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.DisruptionBudget = in.DisruptionBudget
+	in.RollingUpgradeConfig.DeepCopyInto(&out.RollingUpgradeConfig)
+	if in.TaintedBrokersSelector != nil {
+		in, out := &in.TaintedBrokersSelector, &out.TaintedBrokersSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IstioControlPlane != nil {
+		in, out := &in.IstioControlPlane, &out.IstioControlPlane
+		*out = new(IstioControlPlaneReference)
+		**out = **in
+	}
+	in.CruiseControlConfig.DeepCopyInto(&out.CruiseControlConfig)
+	in.EnvoyConfig.DeepCopyInto(&out.EnvoyConfig)
+	out.ContourIngressConfig = in.ContourIngressConfig
+	in.MonitoringConfig.DeepCopyInto(&out.MonitoringConfig)
+	if in.AlertManagerConfig != nil {
+		in, out := &in.AlertManagerConfig, &out.AlertManagerConfig
+		*out = new(AlertManagerConfig)
+		**out = **in
+	}
+	in.IstioIngressConfig.DeepCopyInto(&out.IstioIngressConfig)
+	in.GatewayAPIConfig.DeepCopyInto(&out.GatewayAPIConfig)
+	out.NginxIngressConfig = in.NginxIngressConfig
+	if in.Envs != nil {
+		in, out := &in.Envs, &out.Envs
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClientSSLCertSecret != nil {
+		in, out := &in.ClientSSLCertSecret, &out.ClientSSLCertSecret
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.PrincipalConfig != nil {
+		in, out := &in.PrincipalConfig, &out.PrincipalConfig
+		*out = new(PrincipalConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AuthorizationConfig != nil {
+		in, out := &in.AuthorizationConfig, &out.AuthorizationConfig
+		*out = new(AuthorizationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InternalTopicsConfig != nil {
+		in, out := &in.InternalTopicsConfig, &out.InternalTopicsConfig
+		*out = new(InternalTopicsConfig)
+		**out = **in
+	}
+	if in.KRaftMigration != nil {
+		in, out := &in.KRaftMigration, &out.KRaftMigration
+		*out = new(KRaftMigrationConfig)
+		**out = **in
+	}
+	if in.VersionUpgrade != nil {
+		in, out := &in.VersionUpgrade, &out.VersionUpgrade
+		*out = new(KafkaVersionUpgradeConfig)
+		**out = **in
+	}
+	if in.BrokerReadiness != nil {
+		in, out := &in.BrokerReadiness, &out.BrokerReadiness
+		*out = new(BrokerReadinessConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DiskPlacementHints != nil {
+		in, out := &in.DiskPlacementHints, &out.DiskPlacementHints
+		*out = make([]DiskPlacementHint, len(*in))
+		copy(*out, *in)
+	}
+	if in.VaultConfig != nil {
+		in, out := &in.VaultConfig, &out.VaultConfig
+		*out = new(VaultConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TieredStorage != nil {
+		in, out := &in.TieredStorage, &out.TieredStorage
+		*out = new(TieredStorageConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BrokerRescheduling != nil {
+		in, out := &in.BrokerRescheduling, &out.BrokerRescheduling
+		*out = new(BrokerReschedulingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeInterruption != nil {
+		in, out := &in.NodeInterruption, &out.NodeInterruption
+		*out = new(NodeInterruptionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogDirFailure != nil {
+		in, out := &in.LogDirFailure, &out.LogDirFailure
+		*out = new(LogDirFailureConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LeaderBalance != nil {
+		in, out := &in.LeaderBalance, &out.LeaderBalance
+		*out = new(LeaderBalanceConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaClusterSpec.
+func (in *KafkaClusterSpec) DeepCopy() *KafkaClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaClusterStatus) DeepCopyInto(out *KafkaClusterStatus) {
+	*out = *in
+	if in.BrokersState != nil {
+		in, out := &in.BrokersState, &out.BrokersState
+		*out = make(map[string]BrokerState, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	in.RollingUpgrade.DeepCopyInto(&out.RollingUpgrade)
+	in.ListenerStatuses.DeepCopyInto(&out.ListenerStatuses)
+	if in.ListenerEndpoints != nil {
+		in, out := &in.ListenerEndpoints, &out.ListenerEndpoints
+		*out = new(ListenerEndpoints)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.KRaftMigration != nil {
+		in, out := &in.KRaftMigration, &out.KRaftMigration
+		*out = new(KRaftMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VersionUpgrade != nil {
+		in, out := &in.VersionUpgrade, &out.VersionUpgrade
+		*out = new(KafkaVersionUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControllerQuorum != nil {
+		in, out := &in.ControllerQuorum, &out.ControllerQuorum
+		*out = new(ControllerQuorumStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalListenersAccess != nil {
+		in, out := &in.ExternalListenersAccess, &out.ExternalListenersAccess
+		*out = make(map[string]ExternalListenerAccessStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.DetectedVersions != nil {
+		in, out := &in.DetectedVersions, &out.DetectedVersions
+		*out = new(DetectedVersionsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DiskPlacement != nil {
+		in, out := &in.DiskPlacement, &out.DiskPlacement
+		*out = new(DiskPlacementStatus)
+		**out = **in
+	}
+	if in.ConformanceAudit != nil {
+		in, out := &in.ConformanceAudit, &out.ConformanceAudit
+		*out = new(ConformanceAuditStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CruiseControlAnomalies != nil {
+		in, out := &in.CruiseControlAnomalies, &out.CruiseControlAnomalies
+		*out = new(CruiseControlAnomaliesStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(ReconcilePlanStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LeaderBalance != nil {
+		in, out := &in.LeaderBalance, &out.LeaderBalance
+		*out = new(LeaderBalanceStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RebalanceSchedule != nil {
+		in, out := &in.RebalanceSchedule, &out.RebalanceSchedule
+		*out = new(RebalanceScheduleStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BrokerDecommissions != nil {
+		in, out := &in.BrokerDecommissions, &out.BrokerDecommissions
+		*out = make(map[string]BrokerDecommissionStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.OrphanedTopics != nil {
+		in, out := &in.OrphanedTopics, &out.OrphanedTopics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaClusterStatus.
+func (in *KafkaClusterStatus) DeepCopy() *KafkaClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaVersion) DeepCopyInto(out *KafkaVersion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaVersion.
+func (in *KafkaVersion) DeepCopy() *KafkaVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaVersionUpgradeConfig) DeepCopyInto(out *KafkaVersionUpgradeConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaVersionUpgradeConfig.
+func (in *KafkaVersionUpgradeConfig) DeepCopy() *KafkaVersionUpgradeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaVersionUpgradeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaVersionUpgradeStatus) DeepCopyInto(out *KafkaVersionUpgradeStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaVersionUpgradeStatus.
+func (in *KafkaVersionUpgradeStatus) DeepCopy() *KafkaVersionUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaVersionUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KerberosConfig) DeepCopyInto(out *KerberosConfig) {
+	*out = *in
+	in.KeytabSecretRef.DeepCopyInto(&out.KeytabSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KerberosConfig.
+func (in *KerberosConfig) DeepCopy() *KerberosConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KerberosConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeystorePasswordKMSConfig) DeepCopyInto(out *KeystorePasswordKMSConfig) {
+	*out = *in
+	in.TokenSecretRef.DeepCopyInto(&out.TokenSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeystorePasswordKMSConfig.
+func (in *KeystorePasswordKMSConfig) DeepCopy() *KeystorePasswordKMSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KeystorePasswordKMSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeystorePasswordPolicy) DeepCopyInto(out *KeystorePasswordPolicy) {
+	*out = *in
+	if in.KMS != nil {
+		in, out := &in.KMS, &out.KMS
+		*out = new(KeystorePasswordKMSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalSecretRef != nil {
+		in, out := &in.ExternalSecretRef, &out.ExternalSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RotationPeriod != nil {
+		in, out := &in.RotationPeriod, &out.RotationPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeystorePasswordPolicy.
+func (in *KeystorePasswordPolicy) DeepCopy() *KeystorePasswordPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(KeystorePasswordPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LeaderBalanceConfig) DeepCopyInto(out *LeaderBalanceConfig) {
+	*out = *in
+	if in.ImbalanceThresholdPercentage != nil {
+		in, out := &in.ImbalanceThresholdPercentage, &out.ImbalanceThresholdPercentage
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeaderBalanceConfig.
+func (in *LeaderBalanceConfig) DeepCopy() *LeaderBalanceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LeaderBalanceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LeaderBalanceStatus) DeepCopyInto(out *LeaderBalanceStatus) {
+	*out = *in
+	if in.LeaderCounts != nil {
+		in, out := &in.LeaderCounts, &out.LeaderCounts
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LastElectionTime != nil {
+		in, out := &in.LastElectionTime, &out.LastElectionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeaderBalanceStatus.
+func (in *LeaderBalanceStatus) DeepCopy() *LeaderBalanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LeaderBalanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListenerEndpoint) DeepCopyInto(out *ListenerEndpoint) {
+	*out = *in
+	if in.AdvertisedAddresses != nil {
+		in, out := &in.AdvertisedAddresses, &out.AdvertisedAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerEndpoint.
+func (in *ListenerEndpoint) DeepCopy() *ListenerEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(ListenerEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListenerEndpoints) DeepCopyInto(out *ListenerEndpoints) {
+	*out = *in
+	if in.InternalListeners != nil {
+		in, out := &in.InternalListeners, &out.InternalListeners
+		*out = make(map[string]ListenerEndpoint, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.ExternalListeners != nil {
+		in, out := &in.ExternalListeners, &out.ExternalListeners
+		*out = make(map[string]ListenerEndpoint, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerEndpoints.
+func (in *ListenerEndpoints) DeepCopy() *ListenerEndpoints {
+	if in == nil {
+		return nil
+	}
+	out := new(ListenerEndpoints)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListenerServerCertificate) DeepCopyInto(out *ListenerServerCertificate) {
+	*out = *in
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(apismetav1.ObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerServerCertificate.
+func (in *ListenerServerCertificate) DeepCopy() *ListenerServerCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(ListenerServerCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListenerStatus) DeepCopyInto(out *ListenerStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerStatus.
+func (in *ListenerStatus) DeepCopy() *ListenerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ListenerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ListenerStatusList) DeepCopyInto(out *ListenerStatusList) {
+	{
+		in := &in
+		*out = make(ListenerStatusList, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerStatusList.
+func (in ListenerStatusList) DeepCopy() ListenerStatusList {
+	if in == nil {
+		return nil
+	}
+	out := new(ListenerStatusList)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListenerStatuses) DeepCopyInto(out *ListenerStatuses) {
+	*out = *in
+	if in.InternalListeners != nil {
+		in, out := &in.InternalListeners, &out.InternalListeners
+		*out = make(map[string]ListenerStatusList, len(*in))
+		for key, val := range *in {
+			var outVal []ListenerStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(ListenerStatusList, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.ExternalListeners != nil {
+		in, out := &in.ExternalListeners, &out.ExternalListeners
+		*out = make(map[string]ListenerStatusList, len(*in))
+		for key, val := range *in {
+			var outVal []ListenerStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(ListenerStatusList, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerStatuses.
+func (in *ListenerStatuses) DeepCopy() *ListenerStatuses {
+	if in == nil {
+		return nil
+	}
+	out := new(ListenerStatuses)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListenersConfig) DeepCopyInto(out *ListenersConfig) {
+	*out = *in
+	if in.ExternalListeners != nil {
+		in, out := &in.ExternalListeners, &out.ExternalListeners
+		*out = make([]ExternalListenerConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InternalListeners != nil {
+		in, out := &in.InternalListeners, &out.InternalListeners
+		*out = make([]InternalListenerConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SSLSecrets != nil {
+		in, out := &in.SSLSecrets, &out.SSLSecrets
+		*out = new(SSLSecrets)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAnnotations != nil {
+		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CABundleDistribution != nil {
+		in, out := &in.CABundleDistribution, &out.CABundleDistribution
+		*out = new(CABundleDistribution)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Krb5ConfigMapRef != nil {
+		in, out := &in.Krb5ConfigMapRef, &out.Krb5ConfigMapRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenersConfig.
+func (in *ListenersConfig) DeepCopy() *ListenersConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ListenersConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogDirFailureConfig) DeepCopyInto(out *LogDirFailureConfig) {
+	*out = *in
+	if in.PollIntervalSeconds != nil {
+		in, out := &in.PollIntervalSeconds, &out.PollIntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogDirFailureConfig.
+func (in *LogDirFailureConfig) DeepCopy() *LogDirFailureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LogDirFailureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogDirFailureState) DeepCopyInto(out *LogDirFailureState) {
+	*out = *in
+	if in.CruiseControlOperationReference != nil {
+		in, out := &in.CruiseControlOperationReference, &out.CruiseControlOperationReference
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	in.DetectedTime.DeepCopyInto(&out.DetectedTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogDirFailureState.
+func (in *LogDirFailureState) DeepCopy() *LogDirFailureState {
+	if in == nil {
+		return nil
+	}
+	out := new(LogDirFailureState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in LogDirFailureStates) DeepCopyInto(out *LogDirFailureStates) {
+	{
+		in := &in
+		*out = make(LogDirFailureStates, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogDirFailureStates.
+func (in LogDirFailureStates) DeepCopy() LogDirFailureStates {
+	if in == nil {
+		return nil
+	}
+	out := new(LogDirFailureStates)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringConfig) DeepCopyInto(out *MonitoringConfig) {
+	*out = *in
+	if in.PrometheusRules != nil {
+		in, out := &in.PrometheusRules, &out.PrometheusRules
+		*out = new(PrometheusRulesConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GrafanaDashboard != nil {
+		in, out := &in.GrafanaDashboard, &out.GrafanaDashboard
+		*out = new(GrafanaDashboardConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringConfig.
+func (in *MonitoringConfig) DeepCopy() *MonitoringConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkConfig) DeepCopyInto(out *NetworkConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkConfig.
+func (in *NetworkConfig) DeepCopy() *NetworkConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NginxIngressConfig) DeepCopyInto(out *NginxIngressConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NginxIngressConfig.
+func (in *NginxIngressConfig) DeepCopy() *NginxIngressConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ExternalListenerConfig)
+	out := new(NginxIngressConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in ExternalListenerConfigNames) DeepCopyInto(out *ExternalListenerConfigNames) {
-	{
-		in := &in
-		*out = make(ExternalListenerConfigNames, len(*in))
+func (in *NodeInterruptionConfig) DeepCopyInto(out *NodeInterruptionConfig) {
+	*out = *in
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.NodeConditionType, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalListenerConfigNames.
-func (in ExternalListenerConfigNames) DeepCopy() ExternalListenerConfigNames {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeInterruptionConfig.
+func (in *NodeInterruptionConfig) DeepCopy() *NodeInterruptionConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ExternalListenerConfigNames)
+	out := new(NodeInterruptionConfig)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GracefulActionState) DeepCopyInto(out *GracefulActionState) {
+func (in *NodeInterruptionState) DeepCopyInto(out *NodeInterruptionState) {
 	*out = *in
-	if in.CruiseControlOperationReference != nil {
-		in, out := &in.CruiseControlOperationReference, &out.CruiseControlOperationReference
+	if in.DemoteOperationReference != nil {
+		in, out := &in.DemoteOperationReference, &out.DemoteOperationReference
 		*out = new(v1.LocalObjectReference)
 		**out = **in
 	}
-	if in.VolumeStates != nil {
-		in, out := &in.VolumeStates, &out.VolumeStates
-		*out = make(map[string]VolumeState, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
-	}
+	in.DetectedTime.DeepCopyInto(&out.DetectedTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GracefulActionState.
-func (in *GracefulActionState) DeepCopy() *GracefulActionState {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeInterruptionState.
+func (in *NodeInterruptionState) DeepCopy() *NodeInterruptionState {
 	if in == nil {
 		return nil
 	}
-	out := new(GracefulActionState)
+	out := new(NodeInterruptionState)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IngressConfig) DeepCopyInto(out *IngressConfig) {
+func (in *OAuthBearerConfig) DeepCopyInto(out *OAuthBearerConfig) {
 	*out = *in
-	in.IngressServiceSettings.DeepCopyInto(&out.IngressServiceSettings)
-	if in.IstioIngressConfig != nil {
-		in, out := &in.IstioIngressConfig, &out.IstioIngressConfig
-		*out = new(IstioIngressConfig)
-		(*in).DeepCopyInto(*out)
+	if in.ExpectedAudience != nil {
+		in, out := &in.ExpectedAudience, &out.ExpectedAudience
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.EnvoyConfig != nil {
-		in, out := &in.EnvoyConfig, &out.EnvoyConfig
-		*out = new(EnvoyConfig)
+	if in.TrustedCASecretRef != nil {
+		in, out := &in.TrustedCASecretRef, &out.TrustedCASecretRef
+		*out = new(v1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ContourIngressConfig != nil {
-		in, out := &in.ContourIngressConfig, &out.ContourIngressConfig
-		*out = new(ContourIngressConfig)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressConfig.
-func (in *IngressConfig) DeepCopy() *IngressConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuthBearerConfig.
+func (in *OAuthBearerConfig) DeepCopy() *OAuthBearerConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(IngressConfig)
+	out := new(OAuthBearerConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IngressServiceSettings) DeepCopyInto(out *IngressServiceSettings) {
+func (in *PageCacheWarmup) DeepCopyInto(out *PageCacheWarmup) {
 	*out = *in
-	if in.ServiceAnnotations != nil {
-		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.IndexFiles != nil {
+		in, out := &in.IndexFiles, &out.IndexFiles
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxSegmentsPerStorage != nil {
+		in, out := &in.MaxSegmentsPerStorage, &out.MaxSegmentsPerStorage
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressServiceSettings.
-func (in *IngressServiceSettings) DeepCopy() *IngressServiceSettings {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PageCacheWarmup.
+func (in *PageCacheWarmup) DeepCopy() *PageCacheWarmup {
 	if in == nil {
 		return nil
 	}
-	out := new(IngressServiceSettings)
+	out := new(PageCacheWarmup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InternalListenerConfig) DeepCopyInto(out *InternalListenerConfig) {
+func (in *PerBrokerLoadBalancerConfig) DeepCopyInto(out *PerBrokerLoadBalancerConfig) {
 	*out = *in
-	in.CommonListenerSpec.DeepCopyInto(&out.CommonListenerSpec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalListenerConfig.
-func (in *InternalListenerConfig) DeepCopy() *InternalListenerConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerBrokerLoadBalancerConfig.
+func (in *PerBrokerLoadBalancerConfig) DeepCopy() *PerBrokerLoadBalancerConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(InternalListenerConfig)
+	out := new(PerBrokerLoadBalancerConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IstioControlPlaneReference) DeepCopyInto(out *IstioControlPlaneReference) {
+func (in *PlannedResourceChange) DeepCopyInto(out *PlannedResourceChange) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IstioControlPlaneReference.
-func (in *IstioControlPlaneReference) DeepCopy() *IstioControlPlaneReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedResourceChange.
+func (in *PlannedResourceChange) DeepCopy() *PlannedResourceChange {
 	if in == nil {
 		return nil
 	}
-	out := new(IstioControlPlaneReference)
+	out := new(PlannedResourceChange)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IstioIngressConfig) DeepCopyInto(out *IstioIngressConfig) {
+func (in *PrincipalConfig) DeepCopyInto(out *PrincipalConfig) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]*v1.Toleration, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(v1.Toleration)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.TLSOptions != nil {
-		in, out := &in.TLSOptions, &out.TLSOptions
-		*out = new(networkingv1beta1.TLSOptions)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.VirtualServiceAnnotations != nil {
-		in, out := &in.VirtualServiceAnnotations, &out.VirtualServiceAnnotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Envs != nil {
-		in, out := &in.Envs, &out.Envs
-		*out = make([]*v1.EnvVar, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(v1.EnvVar)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
-	if in.LoadBalancerSourceRanges != nil {
-		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
+	if in.SSLPrincipalMappingRules != nil {
+		in, out := &in.SSLPrincipalMappingRules, &out.SSLPrincipalMappingRules
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IstioIngressConfig.
-func (in *IstioIngressConfig) DeepCopy() *IstioIngressConfig {
-	if in == nil {
-		return nil
-	}
-	out := new(IstioIngressConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KafkaCluster) DeepCopyInto(out *KafkaCluster) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaCluster.
-func (in *KafkaCluster) DeepCopy() *KafkaCluster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrincipalConfig.
+func (in *PrincipalConfig) DeepCopy() *PrincipalConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(KafkaCluster)
+	out := new(PrincipalConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KafkaCluster) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KafkaClusterList) DeepCopyInto(out *KafkaClusterList) {
+func (in *PromQLRestartHook) DeepCopyInto(out *PromQLRestartHook) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]KafkaCluster, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaClusterList.
-func (in *KafkaClusterList) DeepCopy() *KafkaClusterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromQLRestartHook.
+func (in *PromQLRestartHook) DeepCopy() *PromQLRestartHook {
 	if in == nil {
 		return nil
 	}
-	out := new(KafkaClusterList)
+	out := new(PromQLRestartHook)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KafkaClusterList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KafkaClusterSpec) DeepCopyInto(out *KafkaClusterSpec) {
+func (in *PrometheusReplicationCheck) DeepCopyInto(out *PrometheusReplicationCheck) {
 	*out = *in
-	in.ListenersConfig.DeepCopyInto(&out.ListenersConfig)
-	if in.AdditionalPorts != nil {
-		in, out := &in.AdditionalPorts, &out.AdditionalPorts
-		*out = make([]v1.ContainerPort, len(*in))
-		copy(*out, *in)
-	}
-	if in.ZKAddresses != nil {
-		in, out := &in.ZKAddresses, &out.ZKAddresses
+	if in.Alerts != nil {
+		in, out := &in.Alerts, &out.Alerts
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.RackAwareness != nil {
-		in, out := &in.RackAwareness, &out.RackAwareness
-		*out = new(RackAwareness)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.BrokerConfigGroups != nil {
-		in, out := &in.BrokerConfigGroups, &out.BrokerConfigGroups
-		*out = make(map[string]BrokerConfig, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
-	}
-	if in.Brokers != nil {
-		in, out := &in.Brokers, &out.Brokers
-		*out = make([]Broker, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	out.DisruptionBudget = in.DisruptionBudget
-	out.RollingUpgradeConfig = in.RollingUpgradeConfig
-	if in.TaintedBrokersSelector != nil {
-		in, out := &in.TaintedBrokersSelector, &out.TaintedBrokersSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.IstioControlPlane != nil {
-		in, out := &in.IstioControlPlane, &out.IstioControlPlane
-		*out = new(IstioControlPlaneReference)
-		**out = **in
-	}
-	in.CruiseControlConfig.DeepCopyInto(&out.CruiseControlConfig)
-	in.EnvoyConfig.DeepCopyInto(&out.EnvoyConfig)
-	out.ContourIngressConfig = in.ContourIngressConfig
-	out.MonitoringConfig = in.MonitoringConfig
-	if in.AlertManagerConfig != nil {
-		in, out := &in.AlertManagerConfig, &out.AlertManagerConfig
-		*out = new(AlertManagerConfig)
-		**out = **in
-	}
-	in.IstioIngressConfig.DeepCopyInto(&out.IstioIngressConfig)
-	if in.Envs != nil {
-		in, out := &in.Envs, &out.Envs
-		*out = make([]v1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.ClientSSLCertSecret != nil {
-		in, out := &in.ClientSSLCertSecret, &out.ClientSSLCertSecret
-		*out = new(v1.LocalObjectReference)
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaClusterSpec.
-func (in *KafkaClusterSpec) DeepCopy() *KafkaClusterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusReplicationCheck.
+func (in *PrometheusReplicationCheck) DeepCopy() *PrometheusReplicationCheck {
 	if in == nil {
 		return nil
 	}
-	out := new(KafkaClusterSpec)
+	out := new(PrometheusReplicationCheck)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KafkaClusterStatus) DeepCopyInto(out *KafkaClusterStatus) {
+func (in *PrometheusRulesConfig) DeepCopyInto(out *PrometheusRulesConfig) {
 	*out = *in
-	if in.BrokersState != nil {
-		in, out := &in.BrokersState, &out.BrokersState
-		*out = make(map[string]BrokerState, len(*in))
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
+			(*out)[key] = val
 		}
 	}
-	out.RollingUpgrade = in.RollingUpgrade
-	in.ListenerStatuses.DeepCopyInto(&out.ListenerStatuses)
+	if in.DiskUsageThresholdPercent != nil {
+		in, out := &in.DiskUsageThresholdPercent, &out.DiskUsageThresholdPercent
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaClusterStatus.
-func (in *KafkaClusterStatus) DeepCopy() *KafkaClusterStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusRulesConfig.
+func (in *PrometheusRulesConfig) DeepCopy() *PrometheusRulesConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(KafkaClusterStatus)
+	out := new(PrometheusRulesConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KafkaVersion) DeepCopyInto(out *KafkaVersion) {
+func (in *RackAwareness) DeepCopyInto(out *RackAwareness) {
 	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaVersion.
-func (in *KafkaVersion) DeepCopy() *KafkaVersion {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RackAwareness.
+func (in *RackAwareness) DeepCopy() *RackAwareness {
 	if in == nil {
 		return nil
 	}
-	out := new(KafkaVersion)
+	out := new(RackAwareness)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ListenerStatus) DeepCopyInto(out *ListenerStatus) {
+func (in *RebalanceSchedule) DeepCopyInto(out *RebalanceSchedule) {
 	*out = *in
+	if in.Goals != nil {
+		in, out := &in.Goals, &out.Goals
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartingDeadlineSeconds != nil {
+		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerStatus.
-func (in *ListenerStatus) DeepCopy() *ListenerStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RebalanceSchedule.
+func (in *RebalanceSchedule) DeepCopy() *RebalanceSchedule {
 	if in == nil {
 		return nil
 	}
-	out := new(ListenerStatus)
+	out := new(RebalanceSchedule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in ListenerStatusList) DeepCopyInto(out *ListenerStatusList) {
-	{
-		in := &in
-		*out = make(ListenerStatusList, len(*in))
-		copy(*out, *in)
+func (in *RebalanceScheduleStatus) DeepCopyInto(out *RebalanceScheduleStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerStatusList.
-func (in ListenerStatusList) DeepCopy() ListenerStatusList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RebalanceScheduleStatus.
+func (in *RebalanceScheduleStatus) DeepCopy() *RebalanceScheduleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ListenerStatusList)
+	out := new(RebalanceScheduleStatus)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ListenerStatuses) DeepCopyInto(out *ListenerStatuses) {
+func (in *ReconcilePlanStatus) DeepCopyInto(out *ReconcilePlanStatus) {
 	*out = *in
-	if in.InternalListeners != nil {
-		in, out := &in.InternalListeners, &out.InternalListeners
-		*out = make(map[string]ListenerStatusList, len(*in))
-		for key, val := range *in {
-			var outVal []ListenerStatus
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				inVal := (*in)[key]
-				in, out := &inVal, &outVal
-				*out = make(ListenerStatusList, len(*in))
-				copy(*out, *in)
-			}
-			(*out)[key] = outVal
-		}
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]PlannedResourceChange, len(*in))
+		copy(*out, *in)
 	}
-	if in.ExternalListeners != nil {
-		in, out := &in.ExternalListeners, &out.ExternalListeners
-		*out = make(map[string]ListenerStatusList, len(*in))
-		for key, val := range *in {
-			var outVal []ListenerStatus
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				inVal := (*in)[key]
-				in, out := &inVal, &outVal
-				*out = make(ListenerStatusList, len(*in))
-				copy(*out, *in)
-			}
-			(*out)[key] = outVal
-		}
+	if in.RestartingBrokers != nil {
+		in, out := &in.RestartingBrokers, &out.RestartingBrokers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Incomplete != nil {
+		in, out := &in.Incomplete, &out.Incomplete
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
+	in.PlanTime.DeepCopyInto(&out.PlanTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerStatuses.
-func (in *ListenerStatuses) DeepCopy() *ListenerStatuses {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcilePlanStatus.
+func (in *ReconcilePlanStatus) DeepCopy() *ReconcilePlanStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ListenerStatuses)
+	out := new(ReconcilePlanStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ListenersConfig) DeepCopyInto(out *ListenersConfig) {
+func (in *RollingUpgradeConfig) DeepCopyInto(out *RollingUpgradeConfig) {
 	*out = *in
-	if in.ExternalListeners != nil {
-		in, out := &in.ExternalListeners, &out.ExternalListeners
-		*out = make([]ExternalListenerConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.InternalListeners != nil {
-		in, out := &in.InternalListeners, &out.InternalListeners
-		*out = make([]InternalListenerConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.SmokeTest != nil {
+		in, out := &in.SmokeTest, &out.SmokeTest
+		*out = new(RollingUpgradeSmokeTest)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.SSLSecrets != nil {
-		in, out := &in.SSLSecrets, &out.SSLSecrets
-		*out = new(SSLSecrets)
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = new(RollingUpgradeHooks)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ServiceAnnotations != nil {
-		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.PrometheusReplicationCheck != nil {
+		in, out := &in.PrometheusReplicationCheck, &out.PrometheusReplicationCheck
+		*out = new(PrometheusReplicationCheck)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenersConfig.
-func (in *ListenersConfig) DeepCopy() *ListenersConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpgradeConfig.
+func (in *RollingUpgradeConfig) DeepCopy() *RollingUpgradeConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ListenersConfig)
+	out := new(RollingUpgradeConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MonitoringConfig) DeepCopyInto(out *MonitoringConfig) {
+func (in *RollingUpgradeHook) DeepCopyInto(out *RollingUpgradeHook) {
 	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPRestartHook)
+		**out = **in
+	}
+	if in.PromQL != nil {
+		in, out := &in.PromQL, &out.PromQL
+		*out = new(PromQLRestartHook)
+		**out = **in
+	}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(JobRestartHook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringConfig.
-func (in *MonitoringConfig) DeepCopy() *MonitoringConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpgradeHook.
+func (in *RollingUpgradeHook) DeepCopy() *RollingUpgradeHook {
 	if in == nil {
 		return nil
 	}
-	out := new(MonitoringConfig)
+	out := new(RollingUpgradeHook)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkConfig) DeepCopyInto(out *NetworkConfig) {
+func (in *RollingUpgradeHooks) DeepCopyInto(out *RollingUpgradeHooks) {
 	*out = *in
+	if in.PreRestart != nil {
+		in, out := &in.PreRestart, &out.PreRestart
+		*out = make([]RollingUpgradeHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostRestart != nil {
+		in, out := &in.PostRestart, &out.PostRestart
+		*out = make([]RollingUpgradeHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkConfig.
-func (in *NetworkConfig) DeepCopy() *NetworkConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpgradeHooks.
+func (in *RollingUpgradeHooks) DeepCopy() *RollingUpgradeHooks {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkConfig)
+	out := new(RollingUpgradeHooks)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RackAwareness) DeepCopyInto(out *RackAwareness) {
+func (in *RollingUpgradeProgress) DeepCopyInto(out *RollingUpgradeProgress) {
 	*out = *in
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
+	if in.RestartedBrokers != nil {
+		in, out := &in.RestartedBrokers, &out.RestartedBrokers
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RackAwareness.
-func (in *RackAwareness) DeepCopy() *RackAwareness {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpgradeProgress.
+func (in *RollingUpgradeProgress) DeepCopy() *RollingUpgradeProgress {
 	if in == nil {
 		return nil
 	}
-	out := new(RackAwareness)
+	out := new(RollingUpgradeProgress)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RollingUpgradeConfig) DeepCopyInto(out *RollingUpgradeConfig) {
+func (in *RollingUpgradeSmokeTest) DeepCopyInto(out *RollingUpgradeSmokeTest) {
 	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpgradeConfig.
-func (in *RollingUpgradeConfig) DeepCopy() *RollingUpgradeConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpgradeSmokeTest.
+func (in *RollingUpgradeSmokeTest) DeepCopy() *RollingUpgradeSmokeTest {
 	if in == nil {
 		return nil
 	}
-	out := new(RollingUpgradeConfig)
+	out := new(RollingUpgradeSmokeTest)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1143,6 +2850,11 @@ func (in *RollingUpgradeConfig) DeepCopy() *RollingUpgradeConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RollingUpgradeStatus) DeepCopyInto(out *RollingUpgradeStatus) {
 	*out = *in
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(RollingUpgradeProgress)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpgradeStatus.
@@ -1163,6 +2875,11 @@ func (in *SSLSecrets) DeepCopyInto(out *SSLSecrets) {
 		*out = new(apismetav1.ObjectReference)
 		**out = **in
 	}
+	if in.PasswordPolicy != nil {
+		in, out := &in.PasswordPolicy, &out.PasswordPolicy
+		*out = new(KeystorePasswordPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSLSecrets.
@@ -1200,6 +2917,132 @@ func (in *StorageConfig) DeepCopy() *StorageConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageMigrationState) DeepCopyInto(out *StorageMigrationState) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageMigrationState.
+func (in *StorageMigrationState) DeepCopy() *StorageMigrationState {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageMigrationState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in StorageMigrationStates) DeepCopyInto(out *StorageMigrationStates) {
+	{
+		in := &in
+		*out = make(StorageMigrationStates, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageMigrationStates.
+func (in StorageMigrationStates) DeepCopy() StorageMigrationStates {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageMigrationStates)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TieredStorageConfig) DeepCopyInto(out *TieredStorageConfig) {
+	*out = *in
+	if in.PluginInitContainerResources != nil {
+		in, out := &in.PluginInitContainerResources, &out.PluginInitContainerResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(TieredStorageS3Config)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(TieredStorageGCSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemoteStorageManagerConfig != nil {
+		in, out := &in.RemoteStorageManagerConfig, &out.RemoteStorageManagerConfig
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RemoteLogMetadataManagerConfig != nil {
+		in, out := &in.RemoteLogMetadataManagerConfig, &out.RemoteLogMetadataManagerConfig
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TieredStorageConfig.
+func (in *TieredStorageConfig) DeepCopy() *TieredStorageConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TieredStorageConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TieredStorageGCSConfig) DeepCopyInto(out *TieredStorageGCSConfig) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TieredStorageGCSConfig.
+func (in *TieredStorageGCSConfig) DeepCopy() *TieredStorageGCSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TieredStorageGCSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TieredStorageS3Config) DeepCopyInto(out *TieredStorageS3Config) {
+	*out = *in
+	if in.AccessKeyIDSecretRef != nil {
+		in, out := &in.AccessKeyIDSecretRef, &out.AccessKeyIDSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretAccessKeySecretRef != nil {
+		in, out := &in.SecretAccessKeySecretRef, &out.SecretAccessKeySecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TieredStorageS3Config.
+func (in *TieredStorageS3Config) DeepCopy() *TieredStorageS3Config {
+	if in == nil {
+		return nil
+	}
+	out := new(TieredStorageS3Config)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TopicConfig) DeepCopyInto(out *TopicConfig) {
 	*out = *in
@@ -1215,6 +3058,47 @@ func (in *TopicConfig) DeepCopy() *TopicConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultAuthConfig) DeepCopyInto(out *VaultAuthConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultAuthConfig.
+func (in *VaultAuthConfig) DeepCopy() *VaultAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultConfig) DeepCopyInto(out *VaultConfig) {
+	*out = *in
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Auth = in.Auth
+	if in.CertificateTTL != nil {
+		in, out := &in.CertificateTTL, &out.CertificateTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultConfig.
+func (in *VaultConfig) DeepCopy() *VaultConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeState) DeepCopyInto(out *VolumeState) {
 	*out = *in
@@ -66,3 +66,16 @@ Sidecar volume implementation details
 {{- define "chart.additionalVolumes"}}
 {{ toYaml .Values.additionalVolumes }}
 {{- end}}
+
+{{/*
+Manifest of the default KafkaCluster created by the operator
+*/}}
+{{- define "kafka-operator.defaultKafkaCluster" -}}
+apiVersion: kafka.banzaicloud.io/v1beta1
+kind: KafkaCluster
+metadata:
+  name: {{ .Values.defaultKafkaCluster.name }}
+  namespace: {{ .Values.defaultKafkaCluster.namespace | default .Release.Namespace }}
+spec:
+  {{- toYaml .Values.defaultKafkaCluster.spec | nindent 2 }}
+{{- end -}}
@@ -0,0 +1,123 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	certutil "github.com/banzaicloud/koperator/pkg/util/cert"
+	pkicommon "github.com/banzaicloud/koperator/pkg/util/pki"
+)
+
+func certInfoFlags(flags *pflag.FlagSet) func(context.Context, *session, []string) error {
+	var user string
+	flags.StringVar(&user, "user", "", "Name of the KafkaUser whose certificate is shown instead of the ones of a KafkaCluster")
+
+	return func(ctx context.Context, s *session, args []string) error {
+		if user != "" {
+			if len(args) != 0 {
+				return errors.New("expected either the name of a KafkaCluster or a KafkaUser")
+			}
+			return userCertInfo(ctx, s, user)
+		}
+		if len(args) != 1 {
+			return errors.New("expected the name of the KafkaCluster")
+		}
+		return clusterCertInfo(ctx, s, args[0])
+	}
+}
+
+// clusterCertInfo prints the server certificates of the SSL listeners of the KafkaCluster, read from the keystores
+// the brokers are configured with
+func clusterCertInfo(ctx context.Context, s *session, clusterName string) error {
+	cluster, err := s.getCluster(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	var listeners []v1beta1.CommonListenerSpec
+	for _, iListener := range cluster.Spec.ListenersConfig.InternalListeners {
+		listeners = append(listeners, iListener.CommonListenerSpec)
+	}
+	for _, eListener := range cluster.Spec.ListenersConfig.ExternalListeners {
+		listeners = append(listeners, eListener.CommonListenerSpec)
+	}
+
+	w := tabwriter.NewWriter(s.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LISTENER\tSECRET\tSUBJECT\tISSUER\tNOT AFTER\tEXPIRES IN")
+	for _, listener := range listeners {
+		if listener.Type != v1beta1.SecurityProtocolSSL {
+			continue
+		}
+		secretName := pkicommon.ListenerServerCertSecretName(cluster.Name, listener)
+		secret := &corev1.Secret{}
+		if err := s.client.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: secretName}, secret); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return errors.WrapIfWithDetails(err, "could not get the server certificate of the listener", "listener", listener.Name)
+			}
+			fmt.Fprintf(w, "%s\t%s\t<not issued>\t\t\t\n", listener.Name, secretName)
+			continue
+		}
+		tlsCert, err := certutil.ParseKeyStoreToTLSCertificate(secret.Data[v1alpha1.TLSJKSKeyStore], secret.Data[v1alpha1.PasswordKey])
+		if err != nil || tlsCert.Leaf == nil {
+			fmt.Fprintf(w, "%s\t%s\t<invalid keystore>\t\t\t\n", listener.Name, secretName)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", listener.Name, secretName, certificateColumns(tlsCert.Leaf, s.now()))
+	}
+	return w.Flush()
+}
+
+// userCertInfo prints the certificate of the KafkaUser stored in its secret
+func userCertInfo(ctx context.Context, s *session, userName string) error {
+	user := &v1alpha1.KafkaUser{}
+	if err := s.client.Get(ctx, client.ObjectKey{Namespace: s.namespace, Name: userName}, user); err != nil {
+		return errors.WrapIfWithDetails(err, "could not get the KafkaUser", "name", userName, "namespace", s.namespace)
+	}
+	secret := &corev1.Secret{}
+	if err := s.client.Get(ctx, client.ObjectKey{Namespace: user.Namespace, Name: user.Spec.SecretName}, secret); err != nil {
+		return errors.WrapIfWithDetails(err, "could not get the secret of the KafkaUser", "secret", user.Spec.SecretName)
+	}
+	certs, err := certutil.ParseCertificates(secret.Data[corev1.TLSCertKey])
+	if err != nil || len(certs) == 0 {
+		return errors.Errorf("secret %s/%s of KafkaUser %s holds no certificate", secret.Namespace, secret.Name, user.Name)
+	}
+
+	w := tabwriter.NewWriter(s.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "USER\tSECRET\tSUBJECT\tISSUER\tNOT AFTER\tEXPIRES IN")
+	fmt.Fprintf(w, "%s\t%s\t%s\n", user.Name, secret.Name, certificateColumns(certs[0].Certificate, s.now()))
+	return w.Flush()
+}
+
+func certificateColumns(cert *x509.Certificate, now time.Time) string {
+	expiresIn := "expired"
+	if remaining := cert.NotAfter.Sub(now); remaining > 0 {
+		expiresIn = fmt.Sprintf("%dd", int(remaining.Hours()/24))
+	}
+	return strings.Join([]string{cert.Subject.String(), cert.Issuer.String(), cert.NotAfter.UTC().Format(time.RFC3339), expiresIn}, "\t")
+}
@@ -0,0 +1,121 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	certutil "github.com/banzaicloud/koperator/pkg/util/cert"
+)
+
+// columnSeparator separates the columns aligned by the tabwriter
+var columnSeparator = regexp.MustCompile(`\s{2,}`)
+
+func TestCertInfo(t *testing.T) {
+	cert, key, expectedDN, err := certutil.GenerateTestCert()
+	require.NoError(t, err)
+	keyStore, password, err := certutil.GenerateJKSFromByte(cert, key, cert)
+	require.NoError(t, err)
+
+	cluster := testCluster(0)
+	cluster.Spec.ListenersConfig.InternalListeners = []v1beta1.InternalListenerConfig{
+		{CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolSSL}},
+		{CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "plaintext", Type: v1beta1.SecurityProtocolPlaintext}},
+	}
+	cluster.Spec.ListenersConfig.ExternalListeners = []v1beta1.ExternalListenerConfig{
+		{CommonListenerSpec: v1beta1.CommonListenerSpec{
+			Name:                "external",
+			Type:                v1beta1.SecurityProtocolSSL,
+			ServerSSLCertSecret: &corev1.LocalObjectReference{Name: "external-server-certificate"},
+			SSLClientAuth:       v1beta1.SSLClientAuthRequired,
+		}},
+	}
+	serverSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka-server-certificate", Namespace: "kafka"},
+		Data:       map[string][]byte{v1alpha1.TLSJKSKeyStore: keyStore, v1alpha1.PasswordKey: password},
+	}
+	user := &v1alpha1.KafkaUser{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "kafka"},
+		Spec:       v1alpha1.KafkaUserSpec{SecretName: "app-certificate"},
+	}
+	userSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-certificate", Namespace: "kafka"},
+		Data:       map[string][]byte{corev1.TLSCertKey: cert},
+	}
+
+	testCases := []struct {
+		testName     string
+		args         []string
+		expectedRows [][]string
+		expectedErr  bool
+	}{
+		{
+			testName: "certificates of the listeners",
+			args:     []string{"kafka"},
+			expectedRows: [][]string{
+				{"internal", "kafka-server-certificate", expectedDN, expectedDN},
+				{"external", "external-server-certificate", "<not issued>"},
+			},
+		},
+		{
+			testName:     "certificate of a user",
+			args:         []string{"--user", "app"},
+			expectedRows: [][]string{{"app", "app-certificate", expectedDN, expectedDN}},
+		},
+		{
+			testName:    "missing user",
+			args:        []string{"--user", "removed"},
+			expectedErr: true,
+		},
+		{
+			testName:    "cluster and user",
+			args:        []string{"kafka", "--user", "app"},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			s := newTestSession(t, cluster, serverSecret, user, userSecret)
+			flags := newTestFlagSet()
+			runCommand := certInfoFlags(flags)
+			require.NoError(t, flags.Parse(test.args))
+
+			err := runCommand(context.Background(), s, flags.Args())
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			var rows [][]string
+			for _, line := range strings.Split(strings.TrimSpace(s.out.(*bytes.Buffer).String()), "\n")[1:] {
+				fields := columnSeparator.Split(strings.TrimSpace(line), -1)
+				rows = append(rows, fields[:min(len(fields), 4)])
+			}
+			require.Equal(t, test.expectedRows, rows)
+		})
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/scale"
+)
+
+// restartLabelKey labels the broker pods to restart, the KafkaCluster selects them with its taintedBrokersSelector
+// so that the operator recreates them one by one. The recreated pods are not labeled anymore.
+const restartLabelKey = "kafka.banzaicloud.io/restart"
+
+func removeBrokerFlags(*pflag.FlagSet) func(context.Context, *session, []string) error {
+	return removeBrokers
+}
+
+// removeBrokers removes the brokers from the spec of the KafkaCluster, the operator downscales the cluster gracefully
+func removeBrokers(ctx context.Context, s *session, args []string) error {
+	if len(args) < 2 {
+		return errors.New("expected the name of the KafkaCluster and the IDs of the brokers to remove")
+	}
+	cluster, err := s.getCluster(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	brokerIDs, err := parseBrokerIDs(cluster, args[1:])
+	if err != nil {
+		return err
+	}
+
+	patch := client.MergeFromWithOptions(cluster.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	brokers := make([]v1beta1.Broker, 0, len(cluster.Spec.Brokers))
+	for _, broker := range cluster.Spec.Brokers {
+		if _, ok := brokerIDs[broker.Id]; !ok {
+			brokers = append(brokers, broker)
+		}
+	}
+	if len(brokers) == 0 {
+		return errors.Errorf("KafkaCluster %s/%s would have no brokers left", cluster.Namespace, cluster.Name)
+	}
+	cluster.Spec.Brokers = brokers
+	if err := s.client.Patch(ctx, cluster, patch); err != nil {
+		return errors.WrapIfWithDetails(err, "could not remove the brokers from the KafkaCluster", "name", cluster.Name)
+	}
+	fmt.Fprintf(s.out, "Brokers %s are being removed from KafkaCluster %s/%s, their partitions are moved to the other brokers first\n",
+		strings.Join(args[1:], ", "), cluster.Namespace, cluster.Name)
+	return nil
+}
+
+func replaceBrokerFlags(flags *pflag.FlagSet) func(context.Context, *session, []string) error {
+	var keepVolumes bool
+	flags.BoolVar(&keepVolumes, "keep-volumes", false, "Keep the persistent volumes of the broker, only its pod is recreated")
+
+	return func(ctx context.Context, s *session, args []string) error {
+		return replaceBroker(ctx, s, args, keepVolumes)
+	}
+}
+
+// replaceBroker requests a new replacement of the broker in the spec of the KafkaCluster, the operator recreates it
+// with empty storage once its leadership is moved away and it replicates its partitions from the other brokers
+func replaceBroker(ctx context.Context, s *session, args []string, keepVolumes bool) error {
+	if len(args) != 2 {
+		return errors.New("expected the name of the KafkaCluster and the ID of the broker to replace")
+	}
+	cluster, err := s.getCluster(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	brokerIDs, err := parseBrokerIDs(cluster, args[1:])
+	if err != nil {
+		return err
+	}
+
+	replacementID := s.now().UTC().Format("20060102T150405Z")
+	patch := client.MergeFromWithOptions(cluster.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	for i := range cluster.Spec.Brokers {
+		if _, ok := brokerIDs[cluster.Spec.Brokers[i].Id]; ok {
+			cluster.Spec.Brokers[i].Replacement = &v1beta1.BrokerReplacement{ID: replacementID, KeepVolumes: keepVolumes}
+		}
+	}
+	if err := s.client.Patch(ctx, cluster, patch); err != nil {
+		return errors.WrapIfWithDetails(err, "could not request the replacement of the broker", "name", cluster.Name)
+	}
+	fmt.Fprintf(s.out, "Broker %s of KafkaCluster %s/%s is being replaced, follow it with kubectl get kafkacluster %s -n %s -o jsonpath='{.status.brokersState.%s.replacementState}'\n",
+		args[1], cluster.Namespace, cluster.Name, cluster.Name, cluster.Namespace, args[1])
+	return nil
+}
+
+func rebalanceFlags(flags *pflag.FlagSet) func(context.Context, *session, []string) error {
+	var rebalanceDisk bool
+	var excludedTopics string
+	var destinationBrokerIDs []string
+	flags.BoolVar(&rebalanceDisk, "rebalance-disk", false, "Balance the partitions between the disks of each broker instead of between the brokers")
+	flags.StringVar(&excludedTopics, "excluded-topics", "", "Regular expression of the topics whose partitions are not moved")
+	flags.StringSliceVar(&destinationBrokerIDs, "destination-brokers", nil, "IDs of the brokers the partitions are moved to, all of them by default")
+
+	return func(ctx context.Context, s *session, args []string) error {
+		if len(args) != 1 {
+			return errors.New("expected the name of the KafkaCluster")
+		}
+		parameters := map[string]string{
+			scale.ParamExcludeDemoted: "true",
+			scale.ParamExcludeRemoved: "true",
+		}
+		if rebalanceDisk {
+			parameters[scale.ParamRebalanceDisk] = "true"
+		}
+		if excludedTopics != "" {
+			parameters[scale.ParamExcludedTopics] = excludedTopics
+		}
+		if len(destinationBrokerIDs) > 0 {
+			parameters[scale.ParamDestbrokerIDs] = strings.Join(destinationBrokerIDs, ",")
+		}
+		return rebalance(ctx, s, args[0], parameters)
+	}
+}
+
+// rebalance creates a CruiseControlOperation rebalancing the KafkaCluster, the same way as the operator creates the
+// operations it needs
+func rebalance(ctx context.Context, s *session, clusterName string, parameters map[string]string) error {
+	cluster, err := s.getCluster(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	operation := &v1alpha1.CruiseControlOperation{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-%s-", cluster.Name, v1alpha1.OperationRebalance),
+			Namespace:    cluster.Namespace,
+			Labels:       apiutil.LabelsForKafka(cluster.Name),
+		},
+		Spec: v1alpha1.CruiseControlOperationSpec{
+			ErrorPolicy:             v1alpha1.ErrorPolicyRetry,
+			TTLSecondsAfterFinished: cluster.Spec.CruiseControlConfig.CruiseControlOperationSpec.GetTTLSecondsAfterFinished(),
+		},
+	}
+	if err := controllerutil.SetControllerReference(cluster, operation, s.scheme); err != nil {
+		return errors.WrapIf(err, "could not set the owner of the CruiseControlOperation")
+	}
+	if err := s.client.Create(ctx, operation); err != nil {
+		return errors.WrapIfWithDetails(err, "could not create the CruiseControlOperation", "cluster", cluster.Name)
+	}
+	operation.Status.CurrentTask = &v1alpha1.CruiseControlTask{
+		Operation:  v1alpha1.OperationRebalance,
+		Parameters: parameters,
+	}
+	if err := s.client.Status().Update(ctx, operation); err != nil {
+		return errors.WrapIfWithDetails(err, "could not set the task of the CruiseControlOperation", "name", operation.Name)
+	}
+	fmt.Fprintf(s.out, "CruiseControlOperation %s/%s created, follow it with kubectl get kafkaclusters.kafka.banzaicloud.io,cruisecontroloperations -n %s\n",
+		operation.Namespace, operation.Name, operation.Namespace)
+	return nil
+}
+
+func restartFlags(*pflag.FlagSet) func(context.Context, *session, []string) error {
+	return restart
+}
+
+// restart labels the broker pods to restart and selects them with the taintedBrokersSelector of the KafkaCluster, the
+// operator recreates the selected pods with the checks of a rolling upgrade
+func restart(ctx context.Context, s *session, args []string) error {
+	if len(args) < 1 {
+		return errors.New("expected the name of the KafkaCluster")
+	}
+	cluster, err := s.getCluster(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	brokerIDs, err := parseBrokerIDs(cluster, args[1:])
+	if err != nil {
+		return err
+	}
+
+	if selector := cluster.Spec.TaintedBrokersSelector; selector != nil && (len(selector.MatchExpressions) > 0 ||
+		len(selector.MatchLabels) > 1 || (len(selector.MatchLabels) == 1 && selector.MatchLabels[restartLabelKey] == "")) {
+		return errors.Errorf("KafkaCluster %s/%s has its own taintedBrokersSelector, label the pods matching it to restart them",
+			cluster.Namespace, cluster.Name)
+	}
+
+	pods := &corev1.PodList{}
+	if err := s.client.List(ctx, pods, client.InNamespace(cluster.Namespace), client.MatchingLabels(apiutil.LabelsForKafka(cluster.Name))); err != nil {
+		return errors.WrapIfWithDetails(err, "could not list the broker pods", "cluster", cluster.Name)
+	}
+
+	var selected []*corev1.Pod
+	var restarted []string
+	for i := range pods.Items {
+		brokerID, err := strconv.ParseInt(pods.Items[i].Labels[v1beta1.BrokerIdLabelKey], 10, 32)
+		if err != nil {
+			continue
+		}
+		if _, ok := brokerIDs[int32(brokerID)]; len(brokerIDs) > 0 && !ok {
+			continue
+		}
+		selected = append(selected, &pods.Items[i])
+		restarted = append(restarted, pods.Items[i].Labels[v1beta1.BrokerIdLabelKey])
+	}
+	if len(selected) == 0 {
+		return errors.Errorf("no broker pod of KafkaCluster %s/%s to restart", cluster.Namespace, cluster.Name)
+	}
+
+	restartID := s.now().UTC().Format("20060102T150405Z")
+	patch := client.MergeFrom(cluster.DeepCopy())
+	cluster.Spec.TaintedBrokersSelector = &metav1.LabelSelector{MatchLabels: map[string]string{restartLabelKey: restartID}}
+	if err := s.client.Patch(ctx, cluster, patch); err != nil {
+		return errors.WrapIfWithDetails(err, "could not select the broker pods to restart", "cluster", cluster.Name)
+	}
+	for _, pod := range selected {
+		podPatch := client.MergeFrom(pod.DeepCopy())
+		pod.Labels[restartLabelKey] = restartID
+		if err := s.client.Patch(ctx, pod, podPatch); err != nil {
+			return errors.WrapIfWithDetails(err, "could not label the broker pod to restart", "pod", pod.Name)
+		}
+	}
+	fmt.Fprintf(s.out, "Brokers %s of KafkaCluster %s/%s are restarted one by one\n", strings.Join(restarted, ", "),
+		cluster.Namespace, cluster.Name)
+	return nil
+}
+
+func (s *session) getCluster(ctx context.Context, name string) (*v1beta1.KafkaCluster, error) {
+	cluster := &v1beta1.KafkaCluster{}
+	if err := s.client.Get(ctx, client.ObjectKey{Namespace: s.namespace, Name: name}, cluster); err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not get the KafkaCluster", "name", name, "namespace", s.namespace)
+	}
+	return cluster, nil
+}
+
+// parseBrokerIDs parses the IDs of brokers which must be in the spec of the KafkaCluster
+func parseBrokerIDs(cluster *v1beta1.KafkaCluster, args []string) (map[int32]struct{}, error) {
+	inSpec := make(map[int32]struct{}, len(cluster.Spec.Brokers))
+	for _, broker := range cluster.Spec.Brokers {
+		inSpec[broker.Id] = struct{}{}
+	}
+	brokerIDs := make(map[int32]struct{}, len(args))
+	for _, arg := range args {
+		brokerID, err := strconv.ParseInt(arg, 10, 32)
+		if err != nil {
+			return nil, errors.Errorf("invalid broker ID %q", arg)
+		}
+		if _, ok := inSpec[int32(brokerID)]; !ok {
+			return nil, errors.Errorf("broker %d is not in the spec of KafkaCluster %s/%s", brokerID, cluster.Namespace, cluster.Name)
+		}
+		brokerIDs[int32(brokerID)] = struct{}{}
+	}
+	return brokerIDs, nil
+}
@@ -0,0 +1,269 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func newTestSession(t *testing.T, objects ...client.Object) *session {
+	scheme, err := newScheme()
+	require.NoError(t, err)
+	return &session{
+		client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).
+			WithStatusSubresource(&v1alpha1.CruiseControlOperation{}).Build(),
+		scheme:    scheme,
+		namespace: "kafka",
+		out:       &bytes.Buffer{},
+		now:       func() time.Time { return time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC) },
+	}
+}
+
+func testCluster(brokerIDs ...int32) *v1beta1.KafkaCluster {
+	cluster := &v1beta1.KafkaCluster{ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"}}
+	for _, brokerID := range brokerIDs {
+		cluster.Spec.Brokers = append(cluster.Spec.Brokers, v1beta1.Broker{Id: brokerID, BrokerConfigGroup: "default"})
+	}
+	return cluster
+}
+
+func TestRemoveBrokers(t *testing.T) {
+	testCases := []struct {
+		testName          string
+		args              []string
+		expectedBrokerIDs []int32
+		expectedErr       bool
+	}{
+		{
+			testName:          "remove brokers",
+			args:              []string{"kafka", "1", "2"},
+			expectedBrokerIDs: []int32{0},
+		},
+		{
+			testName:    "missing broker IDs",
+			args:        []string{"kafka"},
+			expectedErr: true,
+		},
+		{
+			testName:    "broker not in the spec",
+			args:        []string{"kafka", "5"},
+			expectedErr: true,
+		},
+		{
+			testName:    "invalid broker ID",
+			args:        []string{"kafka", "first"},
+			expectedErr: true,
+		},
+		{
+			testName:    "every broker",
+			args:        []string{"kafka", "0", "1", "2"},
+			expectedErr: true,
+		},
+		{
+			testName:    "missing cluster",
+			args:        []string{"removed", "1"},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			s := newTestSession(t, testCluster(0, 1, 2))
+
+			err := removeBrokers(context.Background(), s, test.args)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			cluster, err := s.getCluster(context.Background(), "kafka")
+			require.NoError(t, err)
+			var brokerIDs []int32
+			for _, broker := range cluster.Spec.Brokers {
+				brokerIDs = append(brokerIDs, broker.Id)
+			}
+			require.Equal(t, test.expectedBrokerIDs, brokerIDs)
+		})
+	}
+}
+
+func TestReplaceBroker(t *testing.T) {
+	testCases := []struct {
+		testName            string
+		args                []string
+		keepVolumes         bool
+		expectedReplacement *v1beta1.BrokerReplacement
+		expectedErr         bool
+	}{
+		{
+			testName:            "replace broker",
+			args:                []string{"kafka", "1"},
+			expectedReplacement: &v1beta1.BrokerReplacement{ID: "20250301T100000Z"},
+		},
+		{
+			testName:            "replace broker keeping its volumes",
+			args:                []string{"kafka", "1"},
+			keepVolumes:         true,
+			expectedReplacement: &v1beta1.BrokerReplacement{ID: "20250301T100000Z", KeepVolumes: true},
+		},
+		{
+			testName:    "several brokers",
+			args:        []string{"kafka", "1", "2"},
+			expectedErr: true,
+		},
+		{
+			testName:    "broker not in the spec",
+			args:        []string{"kafka", "5"},
+			expectedErr: true,
+		},
+		{
+			testName:    "missing cluster",
+			args:        []string{"removed", "1"},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			s := newTestSession(t, testCluster(0, 1, 2))
+			err := replaceBroker(context.Background(), s, test.args, test.keepVolumes)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			cluster, err := s.getCluster(context.Background(), "kafka")
+			require.NoError(t, err)
+			for _, broker := range cluster.Spec.Brokers {
+				if broker.Id == 1 {
+					require.Equal(t, test.expectedReplacement, broker.Replacement)
+				} else {
+					require.Nil(t, broker.Replacement)
+				}
+			}
+		})
+	}
+}
+
+func TestRebalance(t *testing.T) {
+	s := newTestSession(t, testCluster(0, 1, 2))
+
+	require.NoError(t, rebalance(context.Background(), s, "kafka", map[string]string{"rebalance_disk": "true"}))
+
+	operations := &v1alpha1.CruiseControlOperationList{}
+	require.NoError(t, s.client.List(context.Background(), operations))
+	require.Len(t, operations.Items, 1)
+	operation := operations.Items[0]
+	require.Equal(t, "kafka", operation.GetClusterRef())
+	require.Equal(t, "KafkaCluster", operation.OwnerReferences[0].Kind)
+	require.Equal(t, v1alpha1.OperationRebalance, operation.CurrentTaskOperation())
+	require.Equal(t, map[string]string{"rebalance_disk": "true"}, operation.CurrentTaskParameters())
+
+	require.Error(t, rebalance(context.Background(), s, "removed", nil))
+}
+
+func TestRestart(t *testing.T) {
+	brokerPod := func(brokerID string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      "kafka-" + brokerID + "-abcde",
+			Namespace: "kafka",
+			Labels:    apiutil.MergeLabels(apiutil.LabelsForKafka("kafka"), map[string]string{v1beta1.BrokerIdLabelKey: brokerID}),
+		}}
+	}
+
+	testCases := []struct {
+		testName          string
+		selector          *metav1.LabelSelector
+		args              []string
+		expectedRestarted []string
+		expectedErr       bool
+	}{
+		{
+			testName:          "every broker",
+			args:              []string{"kafka"},
+			expectedRestarted: []string{"0", "1", "2"},
+		},
+		{
+			testName:          "selected brokers",
+			args:              []string{"kafka", "2"},
+			expectedRestarted: []string{"2"},
+		},
+		{
+			testName:          "previous restart",
+			selector:          &metav1.LabelSelector{MatchLabels: map[string]string{restartLabelKey: "20250201T100000Z"}},
+			args:              []string{"kafka", "1"},
+			expectedRestarted: []string{"1"},
+		},
+		{
+			testName:    "selector of the cluster",
+			selector:    &metav1.LabelSelector{MatchLabels: map[string]string{"tainted": "true"}},
+			args:        []string{"kafka"},
+			expectedErr: true,
+		},
+		{
+			testName:    "broker not in the spec",
+			args:        []string{"kafka", "5"},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			cluster := testCluster(0, 1, 2)
+			cluster.Spec.TaintedBrokersSelector = test.selector
+			s := newTestSession(t, cluster, brokerPod("0"), brokerPod("1"), brokerPod("2"))
+
+			err := restart(context.Background(), s, test.args)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			cluster, err = s.getCluster(context.Background(), "kafka")
+			require.NoError(t, err)
+			require.Equal(t, &metav1.LabelSelector{MatchLabels: map[string]string{restartLabelKey: "20250301T100000Z"}},
+				cluster.Spec.TaintedBrokersSelector)
+			pods := &corev1.PodList{}
+			require.NoError(t, s.client.List(context.Background(), pods, client.MatchingLabels{restartLabelKey: "20250301T100000Z"}))
+			var restarted []string
+			for _, pod := range pods.Items {
+				restarted = append(restarted, pod.Labels[v1beta1.BrokerIdLabelKey])
+			}
+			require.Equal(t, test.expectedRestarted, restarted)
+		})
+	}
+}
+
+func newTestFlagSet() *pflag.FlagSet {
+	return pflag.NewFlagSet("test", pflag.ContinueOnError)
+}
@@ -0,0 +1,135 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func eventsFlags(flags *pflag.FlagSet) func(context.Context, *session, []string) error {
+	var follow bool
+	flags.BoolVarP(&follow, "follow", "f", false, "Keep printing the new events until interrupted")
+
+	return func(ctx context.Context, s *session, args []string) error {
+		if len(args) != 1 {
+			return errors.New("expected the name of the KafkaCluster")
+		}
+		return clusterEvents(ctx, s, args[0], follow)
+	}
+}
+
+// clusterEvents prints the events of the KafkaCluster, its broker pods and its CruiseControlOperations, which report
+// the decisions of the operator such as rolling upgrades, graceful scaling and remediations, oldest first
+func clusterEvents(ctx context.Context, s *session, clusterName string, follow bool) error {
+	events := &corev1.EventList{}
+	if err := s.client.List(ctx, events, client.InNamespace(s.namespace)); err != nil {
+		return errors.WrapIfWithDetails(err, "could not list the events", "namespace", s.namespace)
+	}
+	var clusterEvents []*corev1.Event
+	for i := range events.Items {
+		if involvesCluster(&events.Items[i], clusterName) {
+			clusterEvents = append(clusterEvents, &events.Items[i])
+		}
+	}
+	sort.SliceStable(clusterEvents, func(i, j int) bool {
+		return eventTime(clusterEvents[i]).Before(eventTime(clusterEvents[j]))
+	})
+
+	w := tabwriter.NewWriter(s.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LAST SEEN\tTYPE\tREASON\tOBJECT\tMESSAGE")
+	for _, event := range clusterEvents {
+		printEvent(w, event)
+	}
+	if err := w.Flush(); err != nil || !follow {
+		return err
+	}
+
+	watcher, err := s.client.Watch(ctx, &corev1.EventList{}, client.InNamespace(s.namespace),
+		&client.ListOptions{Raw: &metav1.ListOptions{ResourceVersion: events.ResourceVersion}})
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not watch the events", "namespace", s.namespace)
+	}
+	defer watcher.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case watchEvent, ok := <-watcher.ResultChan():
+			if !ok {
+				return errors.New("the watch of the events was closed by the API server")
+			}
+			if watchEvent.Type != watch.Added && watchEvent.Type != watch.Modified {
+				continue
+			}
+			event, ok := watchEvent.Object.(*corev1.Event)
+			if !ok || !involvesCluster(event, clusterName) {
+				continue
+			}
+			printEvent(w, event)
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// involvesCluster returns whether the event is about the KafkaCluster, one of its broker pods or one of its
+// CruiseControlOperations, the names of the latter two start with the name of the cluster
+func involvesCluster(event *corev1.Event, clusterName string) bool {
+	object := event.InvolvedObject
+	switch object.Kind {
+	case "KafkaCluster":
+		return object.Name == clusterName && strings.HasPrefix(object.APIVersion, v1beta1.GroupVersion.Group+"/")
+	case "CruiseControlOperation":
+		return strings.HasPrefix(object.Name, clusterName+"-") && strings.HasPrefix(object.APIVersion, v1alpha1.GroupVersion.Group+"/")
+	case "Pod":
+		return strings.HasPrefix(object.Name, clusterName+"-")
+	default:
+		return false
+	}
+}
+
+func eventTime(event *corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+func printEvent(w io.Writer, event *corev1.Event) {
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s/%s\t%s\n", eventTime(event).Local().Format(time.DateTime), event.Type, event.Reason,
+		strings.ToLower(event.InvolvedObject.Kind), event.InvolvedObject.Name, event.Message)
+}
@@ -0,0 +1,57 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClusterEvents(t *testing.T) {
+	event := func(name, kind, apiVersion, objectName, reason string, lastSeen time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "kafka"},
+			InvolvedObject: corev1.ObjectReference{Kind: kind, APIVersion: apiVersion, Name: objectName, Namespace: "kafka"},
+			Reason:         reason,
+			Type:           corev1.EventTypeNormal,
+			LastTimestamp:  metav1.NewTime(lastSeen),
+		}
+	}
+	start := time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC)
+	s := newTestSession(t,
+		event("restart", "KafkaCluster", "kafka.banzaicloud.io/v1beta1", "kafka", "RollingUpgradeStarted", start.Add(2*time.Minute)),
+		event("rebalance", "CruiseControlOperation", "kafka.banzaicloud.io/v1alpha1", "kafka-rebalance-x7k2p", "Created", start.Add(time.Minute)),
+		event("pod", "Pod", "v1", "kafka-0-abcde", "Killing", start.Add(3*time.Minute)),
+		event("other-cluster", "KafkaCluster", "kafka.banzaicloud.io/v1beta1", "logs", "RollingUpgradeStarted", start),
+		event("other-pod", "Pod", "v1", "logs-0-abcde", "Killing", start),
+		event("topic", "KafkaTopic", "kafka.banzaicloud.io/v1alpha1", "kafka-events", "TopicCreated", start),
+	)
+
+	require.NoError(t, clusterEvents(context.Background(), s, "kafka", false))
+
+	var reasons []string
+	for _, line := range strings.Split(strings.TrimSpace(s.out.(*bytes.Buffer).String()), "\n")[1:] {
+		reasons = append(reasons, strings.Fields(line)[3])
+	}
+	require.Equal(t, []string{"Created", "RollingUpgradeStarted", "Killing"}, reasons)
+}
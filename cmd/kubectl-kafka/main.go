@@ -0,0 +1,184 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// kubectl-kafka is a kubectl plugin running the common day-2 operations of the Kafka clusters managed by the operator
+// through their custom resources, e.g. kubectl kafka rebalance kafka -n kafka
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+// session holds what the commands need to run against the Kubernetes cluster
+type session struct {
+	client    client.WithWatch
+	scheme    *runtime.Scheme
+	namespace string
+	out       io.Writer
+	now       func() time.Time
+}
+
+// command is a subcommand of the plugin, flags registers its flags and returns the function running it with its
+// positional arguments once the flags are parsed
+type command struct {
+	name        string
+	args        string
+	description string
+	flags       func(flags *pflag.FlagSet) func(ctx context.Context, s *session, args []string) error
+}
+
+var commands = []command{
+	{
+		name:        "remove-broker",
+		args:        "CLUSTER BROKER_ID...",
+		description: "Remove brokers from a KafkaCluster, their partitions are moved to the other brokers by Cruise Control before they are deleted",
+		flags:       removeBrokerFlags,
+	},
+	{
+		name:        "replace-broker",
+		args:        "CLUSTER BROKER_ID",
+		description: "Replace a broker of a KafkaCluster which lost its storage, it is recreated with empty volumes and declared healthy once its replicas are in sync",
+		flags:       replaceBrokerFlags,
+	},
+	{
+		name:        "rebalance",
+		args:        "CLUSTER",
+		description: "Rebalance the partitions of a KafkaCluster with a Cruise Control rebalance operation",
+		flags:       rebalanceFlags,
+	},
+	{
+		name:        "restart",
+		args:        "CLUSTER [BROKER_ID...]",
+		description: "Restart the brokers of a KafkaCluster one by one with the checks of a rolling upgrade, all of them by default",
+		flags:       restartFlags,
+	},
+	{
+		name:        "describe-topic",
+		args:        "KAFKATOPIC",
+		description: "Show the spec and the status of a KafkaTopic",
+		flags:       describeTopicFlags,
+	},
+	{
+		name:        "cert-info",
+		args:        "CLUSTER | --user KAFKAUSER",
+		description: "Show the server certificates of the SSL listeners of a KafkaCluster or the certificate of a KafkaUser",
+		flags:       certInfoFlags,
+	},
+	{
+		name:        "events",
+		args:        "CLUSTER",
+		description: "Show the events recorded by the operator for a KafkaCluster, its broker pods and its CruiseControlOperations",
+		flags:       eventsFlags,
+	},
+}
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if err := run(ctx, os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		cancel()
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, out io.Writer) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(out)
+		return nil
+	}
+	var cmd *command
+	for i := range commands {
+		if commands[i].name == args[0] {
+			cmd = &commands[i]
+		}
+	}
+	if cmd == nil {
+		return errors.Errorf("unknown command %q, see kubectl kafka help", args[0])
+	}
+
+	flags := pflag.NewFlagSet("kubectl kafka "+cmd.name, pflag.ContinueOnError)
+	flags.SetOutput(out)
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	overrides := &clientcmd.ConfigOverrides{}
+	flags.StringVar(&loadingRules.ExplicitPath, "kubeconfig", "", "Path to the kubeconfig file")
+	flags.StringVar(&overrides.CurrentContext, "context", "", "Name of the kubeconfig context to use")
+	flags.StringVarP(&overrides.Context.Namespace, "namespace", "n", "", "Namespace of the resources, the namespace of the kubeconfig context by default")
+	runCommand := cmd.flags(flags)
+	flags.Usage = func() {
+		fmt.Fprintf(out, "%s\n\nUsage:\n  kubectl kafka %s %s [flags]\n\nFlags:\n%s", cmd.description, cmd.name, cmd.args, flags.FlagUsages())
+	}
+	if err := flags.Parse(args[1:]); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides)
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return errors.WrapIf(err, "could not determine the namespace")
+	}
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return errors.WrapIf(err, "could not load the kubeconfig")
+	}
+	scheme, err := newScheme()
+	if err != nil {
+		return err
+	}
+	c, err := client.NewWithWatch(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return errors.WrapIf(err, "could not create the Kubernetes client")
+	}
+
+	return runCommand(ctx, &session{client: c, scheme: scheme, namespace: namespace, out: out, now: time.Now}, flags.Args())
+}
+
+func newScheme() (*runtime.Scheme, error) {
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{clientgoscheme.AddToScheme, v1alpha1.AddToScheme, v1beta1.AddToScheme} {
+		if err := addToScheme(scheme); err != nil {
+			return nil, errors.WrapIf(err, "could not register the API types")
+		}
+	}
+	return scheme, nil
+}
+
+func printUsage(out io.Writer) {
+	fmt.Fprint(out, "kubectl kafka runs the day-2 operations of the Kafka clusters managed by Koperator.\n\nCommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(out, "  %-16s %s\n", cmd.name, cmd.description)
+	}
+	fmt.Fprint(out, "\nUse \"kubectl kafka <command> --help\" for the flags and arguments of a command.\n")
+}
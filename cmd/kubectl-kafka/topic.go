@@ -0,0 +1,101 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+)
+
+func describeTopicFlags(*pflag.FlagSet) func(context.Context, *session, []string) error {
+	return describeTopic
+}
+
+// describeTopic prints the spec and the status of the KafkaTopic as reconciled by the operator
+func describeTopic(ctx context.Context, s *session, args []string) error {
+	if len(args) != 1 {
+		return errors.New("expected the name of the KafkaTopic")
+	}
+	topic := &v1alpha1.KafkaTopic{}
+	if err := s.client.Get(ctx, client.ObjectKey{Namespace: s.namespace, Name: args[0]}, topic); err != nil {
+		return errors.WrapIfWithDetails(err, "could not get the KafkaTopic", "name", args[0], "namespace", s.namespace)
+	}
+
+	w := tabwriter.NewWriter(s.out, 0, 0, 2, ' ', 0)
+	clusterNamespace := topic.Spec.ClusterRef.Namespace
+	if clusterNamespace == "" {
+		clusterNamespace = topic.Namespace
+	}
+	fmt.Fprintf(w, "Name:\t%s/%s\n", topic.Namespace, topic.Name)
+	fmt.Fprintf(w, "Topic:\t%s\n", topic.Spec.Name)
+	fmt.Fprintf(w, "Cluster:\t%s/%s\n", clusterNamespace, topic.Spec.ClusterRef.Name)
+	fmt.Fprintf(w, "Partitions:\t%d\n", topic.Spec.Partitions)
+	fmt.Fprintf(w, "Replication factor:\t%d\n", topic.Spec.ReplicationFactor)
+	fmt.Fprintf(w, "State:\t%s\n", topic.Status.State)
+	fmt.Fprintf(w, "Managed by:\t%s\n", topic.Status.ManagedBy)
+	if len(topic.Spec.Config) > 0 {
+		fmt.Fprintln(w, "Config:\t")
+		printConfig(w, "  ", topic.Spec.Config)
+	}
+	if len(topic.Status.Conditions) > 0 {
+		fmt.Fprintln(w, "Conditions:\t")
+		for _, condition := range topic.Status.Conditions {
+			fmt.Fprintf(w, "  %s=%s\t%s: %s\n", condition.Type, condition.Status, condition.Reason, condition.Message)
+		}
+	}
+	if reassignment := topic.Status.Reassignment; reassignment != nil {
+		fmt.Fprintln(w, "Reassignment:\t")
+		fmt.Fprintf(w, "  State:\t%s\n", reassignment.State)
+		fmt.Fprintf(w, "  Strategy:\t%s\n", reassignment.Strategy)
+		fmt.Fprintf(w, "  Target replication factor:\t%d\n", reassignment.TargetReplicationFactor)
+		if reassignment.CruiseControlOperationReference != nil {
+			fmt.Fprintf(w, "  CruiseControlOperation:\t%s\n", reassignment.CruiseControlOperationReference.Name)
+		}
+		if reassignment.ErrorMessage != "" {
+			fmt.Fprintf(w, "  Error:\t%s\n", reassignment.ErrorMessage)
+		}
+	}
+	if adoption := topic.Status.Adoption; adoption != nil {
+		fmt.Fprintf(w, "Adopted:\t%s\n", adoption.AdoptedAt.UTC().Format(time.RFC3339))
+		fmt.Fprintf(w, "  Partitions:\t%d\n", adoption.Partitions)
+		fmt.Fprintf(w, "  Replication factor:\t%d\n", adoption.ReplicationFactor)
+		if len(adoption.Config) > 0 {
+			fmt.Fprintln(w, "  Config:\t")
+			printConfig(w, "    ", adoption.Config)
+		}
+	}
+	return w.Flush()
+}
+
+func printConfig(w *tabwriter.Writer, indent string, config map[string]string) {
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s%s\t%s\n", indent, key, config[key])
+	}
+}
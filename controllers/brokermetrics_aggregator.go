@@ -0,0 +1,219 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/util"
+)
+
+const (
+	// BrokerMetricsAggregatorPath is the path of the metrics server of the operator the combined broker metrics of the
+	// clusters are served on, as <path><namespace>/<name>/metrics
+	BrokerMetricsAggregatorPath = "/kafkaclusters/"
+
+	// brokerMetricsUpMetricName is the metric reporting whether the metrics of each broker could be fetched
+	brokerMetricsUpMetricName = "koperator_broker_metrics_up"
+
+	brokerMetricsAggregatorTimeout = 10 * time.Second
+	// the labels identifying the brokers, the same as the ones set by the Prometheus scrape config of the samples
+	brokerMetricsBrokerIDLabel  = "brokerId"
+	brokerMetricsClusterLabel   = "kafka_cr"
+	brokerMetricsNamespaceLabel = "namespace"
+)
+
+// BrokerMetricsAggregator serves the metrics of the brokers of a cluster behind a single scrape target, each metric
+// labeled with the broker it comes from. It is meant for Prometheus setups with scrape target count limits or without
+// network reachability to the broker pods.
+type BrokerMetricsAggregator struct {
+	Client client.Reader
+	Log    logr.Logger
+}
+
+// SetupBrokerMetricsAggregatorWithManager serves the aggregated broker metrics on the metrics server of the Manager
+func SetupBrokerMetricsAggregatorWithManager(mgr manager.Manager) error {
+	return mgr.AddMetricsServerExtraHandler(BrokerMetricsAggregatorPath, &BrokerMetricsAggregator{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("broker-metrics-aggregator"),
+	})
+}
+
+// ServeHTTP implements http.Handler
+func (a *BrokerMetricsAggregator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, BrokerMetricsAggregatorPath), "/")
+	if r.Method != http.MethodGet || len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] != "metrics" {
+		http.NotFound(w, r)
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), brokerMetricsAggregatorTimeout)
+	defer cancel()
+
+	cluster := &v1beta1.KafkaCluster{}
+	if err := a.Client.Get(ctx, types.NamespacedName{Namespace: parts[0], Name: parts[1]}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			http.NotFound(w, r)
+			return
+		}
+		a.Log.Error(err, "could not get Kafka cluster", "clusterName", parts[1], "clusterNamespace", parts[0])
+		http.Error(w, "could not get Kafka cluster", http.StatusInternalServerError)
+		return
+	}
+
+	pods := &corev1.PodList{}
+	if err := a.Client.List(ctx, pods, client.InNamespace(cluster.Namespace), client.MatchingLabels(apiutil.LabelsForKafka(cluster.Name))); err != nil {
+		a.Log.Error(err, "could not list broker pods", "clusterName", cluster.Name, "clusterNamespace", cluster.Namespace)
+		http.Error(w, "could not list broker pods", http.StatusInternalServerError)
+		return
+	}
+
+	families := aggregateBrokerMetrics(cluster, fetchAllBrokerMetrics(ctx, pods.Items))
+
+	format := expfmt.NewFormat(expfmt.TypeTextPlain)
+	w.Header().Set("Content-Type", string(format))
+	encoder := expfmt.NewEncoder(w, format)
+	for _, family := range families {
+		if err := encoder.Encode(family); err != nil {
+			a.Log.Error(err, "could not write broker metrics", "clusterName", cluster.Name, "clusterNamespace", cluster.Namespace)
+			return
+		}
+	}
+}
+
+// fetchAllBrokerMetrics fetches the metrics of the running broker pods concurrently, the brokers whose metrics could
+// not be fetched are mapped to nil
+func fetchAllBrokerMetrics(ctx context.Context, pods []corev1.Pod) map[string]map[string]*dto.MetricFamily {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	metrics := make(map[string]map[string]*dto.MetricFamily, len(pods))
+	for i := range pods {
+		pod := &pods[i]
+		brokerID, ok := pod.Labels[v1beta1.BrokerIdLabelKey]
+		if !ok || pod.DeletionTimestamp != nil {
+			continue
+		}
+		if pod.Status.PodIP == "" {
+			metrics[brokerID] = nil
+			continue
+		}
+		wg.Add(1)
+		go func(brokerID, address string) {
+			defer wg.Done()
+			brokerMetrics, err := fetchBrokerMetrics(ctx, address, brokerMetricsAggregatorTimeout)
+			if err != nil {
+				brokerMetrics = nil
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			metrics[brokerID] = brokerMetrics
+		}(brokerID, brokerMetricsAddress(pod))
+	}
+	wg.Wait()
+	return metrics
+}
+
+// aggregateBrokerMetrics merges the metric families of the brokers, labeling their metrics with the broker, and adds
+// the metric reporting which brokers' metrics could be fetched. The families are sorted by name.
+func aggregateBrokerMetrics(cluster *v1beta1.KafkaCluster, brokerMetrics map[string]map[string]*dto.MetricFamily) []*dto.MetricFamily {
+	brokerIDs := make([]string, 0, len(brokerMetrics))
+	for brokerID := range brokerMetrics {
+		brokerIDs = append(brokerIDs, brokerID)
+	}
+	sort.Strings(brokerIDs)
+
+	up := &dto.MetricFamily{
+		Name: util.StringPointer(brokerMetricsUpMetricName),
+		Help: util.StringPointer("Whether the metrics of the broker could be fetched"),
+		Type: dto.MetricType_GAUGE.Enum(),
+	}
+	merged := map[string]*dto.MetricFamily{brokerMetricsUpMetricName: up}
+	for _, brokerID := range brokerIDs {
+		labels := brokerMetricsLabels(cluster, brokerID)
+		value := 0.0
+		if brokerMetrics[brokerID] != nil {
+			value = 1
+		}
+		up.Metric = append(up.Metric, &dto.Metric{Label: labels, Gauge: &dto.Gauge{Value: &value}})
+
+		for name, family := range brokerMetrics[brokerID] {
+			target, ok := merged[name]
+			if !ok {
+				target = &dto.MetricFamily{Name: family.Name, Help: family.Help, Type: family.Type, Unit: family.Unit}
+				merged[name] = target
+			} else if target.GetType() != family.GetType() {
+				// brokers of different versions may expose the same metric with different types
+				continue
+			}
+			for _, metric := range family.GetMetric() {
+				metric.Label = withBrokerMetricsLabels(metric.GetLabel(), labels)
+				target.Metric = append(target.Metric, metric)
+			}
+		}
+	}
+
+	families := make([]*dto.MetricFamily, 0, len(merged))
+	for _, family := range merged {
+		families = append(families, family)
+	}
+	sort.Slice(families, func(i, j int) bool { return families[i].GetName() < families[j].GetName() })
+	return families
+}
+
+func brokerMetricsLabels(cluster *v1beta1.KafkaCluster, brokerID string) []*dto.LabelPair {
+	return []*dto.LabelPair{
+		{Name: util.StringPointer(brokerMetricsBrokerIDLabel), Value: util.StringPointer(brokerID)},
+		{Name: util.StringPointer(brokerMetricsClusterLabel), Value: util.StringPointer(cluster.Name)},
+		{Name: util.StringPointer(brokerMetricsNamespaceLabel), Value: util.StringPointer(cluster.Namespace)},
+	}
+}
+
+// withBrokerMetricsLabels returns the labels of the metric with the broker labels, which override the labels of the
+// metric with the same name
+func withBrokerMetricsLabels(metricLabels, brokerLabels []*dto.LabelPair) []*dto.LabelPair {
+	labels := make([]*dto.LabelPair, 0, len(metricLabels)+len(brokerLabels))
+	for _, label := range metricLabels {
+		overridden := false
+		for _, brokerLabel := range brokerLabels {
+			if label.GetName() == brokerLabel.GetName() {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			labels = append(labels, label)
+		}
+	}
+	labels = append(labels, brokerLabels...)
+	sort.Slice(labels, func(i, j int) bool { return labels[i].GetName() < labels[j].GetName() })
+	return labels
+}
@@ -0,0 +1,119 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/util/readiness"
+)
+
+func TestBrokerMetricsAggregator(t *testing.T) {
+	cluster := &v1beta1.KafkaCluster{ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"}}
+	brokerPod := func(brokerID, podIP string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kafka-" + brokerID + "-abcde",
+				Namespace: "kafka",
+				Labels:    apiutil.MergeLabels(apiutil.LabelsForKafka("kafka"), map[string]string{v1beta1.BrokerIdLabelKey: brokerID}),
+			},
+			Status: corev1.PodStatus{PodIP: podIP},
+		}
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(cluster, brokerPod("0", "10.0.0.1"), brokerPod("1", "10.0.0.2"), brokerPod("2", "")).Build()
+	aggregator := &BrokerMetricsAggregator{Client: c, Log: logr.Discard()}
+
+	defer func(fetch func(context.Context, string, time.Duration) (map[string]*dto.MetricFamily, error)) {
+		fetchBrokerMetrics = fetch
+	}(fetchBrokerMetrics)
+	fetchBrokerMetrics = func(ctx context.Context, address string, timeout time.Duration) (map[string]*dto.MetricFamily, error) {
+		switch address {
+		case "10.0.0.1:9020":
+			return readiness.ParseMetrics(strings.NewReader("# TYPE kafka_server_replicamanager_leadercount gauge\n" +
+				"kafka_server_replicamanager_leadercount 12\n"))
+		case "10.0.0.2:9020":
+			return readiness.ParseMetrics(strings.NewReader("# TYPE kafka_server_replicamanager_leadercount gauge\n" +
+				"kafka_server_replicamanager_leadercount{brokerId=\"stale\"} 7\n"))
+		default:
+			return nil, errors.New("connection refused")
+		}
+	}
+
+	testCases := []struct {
+		testName           string
+		path               string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			testName:           "combined broker metrics",
+			path:               "/kafkaclusters/kafka/kafka/metrics",
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `# TYPE kafka_server_replicamanager_leadercount gauge
+kafka_server_replicamanager_leadercount{brokerId="0",kafka_cr="kafka",namespace="kafka"} 12
+kafka_server_replicamanager_leadercount{brokerId="1",kafka_cr="kafka",namespace="kafka"} 7
+# HELP koperator_broker_metrics_up Whether the metrics of the broker could be fetched
+# TYPE koperator_broker_metrics_up gauge
+koperator_broker_metrics_up{brokerId="0",kafka_cr="kafka",namespace="kafka"} 1
+koperator_broker_metrics_up{brokerId="1",kafka_cr="kafka",namespace="kafka"} 1
+koperator_broker_metrics_up{brokerId="2",kafka_cr="kafka",namespace="kafka"} 0
+`,
+		},
+		{
+			testName:           "unknown cluster",
+			path:               "/kafkaclusters/kafka/unknown/metrics",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			testName:           "invalid path",
+			path:               "/kafkaclusters/kafka",
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			aggregator.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, test.path, nil))
+
+			require.Equal(t, test.expectedStatusCode, rec.Code)
+			if test.expectedBody != "" {
+				require.Equal(t, test.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
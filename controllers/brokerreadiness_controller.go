@@ -0,0 +1,236 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	dto "github.com/prometheus/client_model/go"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/util/readiness"
+)
+
+const (
+	// brokerMetricsPort is the default port of the Prometheus JMX exporter of the broker pods
+	brokerMetricsPort = 9020
+	// brokerMetricsPortName is the name of the container port of the Prometheus JMX exporter of the broker pods
+	brokerMetricsPortName = "metrics"
+)
+
+// brokerMetricsAddress returns the address the Prometheus JMX exporter of the broker pod serves the metrics on, the
+// port can be overridden per broker config group
+func brokerMetricsAddress(pod *corev1.Pod) string {
+	port := int32(brokerMetricsPort)
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if containerPort.Name == brokerMetricsPortName {
+				port = containerPort.ContainerPort
+			}
+		}
+	}
+	return net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(port)))
+}
+
+// fetchBrokerMetrics returns the metrics exposed on the given address in the Prometheus text format
+var fetchBrokerMetrics = func(ctx context.Context, address string, timeout time.Duration) (map[string]*dto.MetricFamily, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s/metrics", address), nil)
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return nil, errors.NewWithDetails("unexpected response status", "status", rsp.Status)
+	}
+	return readiness.ParseMetrics(rsp.Body)
+}
+
+// SetupBrokerReadinessWithManager registers the broker readiness controller to the manager
+func SetupBrokerReadinessWithManager(mgr ctrl.Manager) *ctrl.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Pod{}, builder.WithPredicates(newOperatorScopePredicate(mgr), brokerReadinessPredicate())).
+		Named("BrokerReadiness")
+}
+
+// brokerReadinessPredicate selects the Kafka pods gated on the broker readiness expression. Updates are only
+// of interest when the pod gets its address, the expression is re-evaluated periodically afterwards.
+func brokerReadinessPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return hasBrokerReadinessGate(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPod, okOld := e.ObjectOld.(*corev1.Pod)
+			newPod, okNew := e.ObjectNew.(*corev1.Pod)
+			return okOld && okNew && hasBrokerReadinessGate(newPod) && oldPod.Status.PodIP != newPod.Status.PodIP
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+func hasBrokerReadinessGate(obj client.Object) bool {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok || pod.Labels[v1beta1.AppLabelKey] != "kafka" {
+		return false
+	}
+	for _, gate := range pod.Spec.ReadinessGates {
+		if gate.ConditionType == v1beta1.BrokerReadyConditionType {
+			return true
+		}
+	}
+	return false
+}
+
+// blank assignment to verify that BrokerReadinessReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &BrokerReadinessReconciler{}
+
+// BrokerReadinessReconciler sets the broker readiness gate condition of the Kafka pods by evaluating the broker
+// readiness expression of their KafkaCluster over the metrics the brokers expose
+type BrokerReadinessReconciler struct {
+	Client client.Client
+}
+
+// +kubebuilder:rbac:groups="",resources=pods/status,verbs=get;update;patch
+
+// Reconcile evaluates the broker readiness expression for a Kafka pod and records the outcome in its readiness
+// gate condition
+func (r *BrokerReadinessReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	pod := &corev1.Pod{}
+	if err := r.Client.Get(ctx, request.NamespacedName, pod); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconciled()
+		}
+		return requeueWithError(log, err.Error(), err)
+	}
+	if !hasBrokerReadinessGate(pod) || pod.DeletionTimestamp != nil {
+		return reconciled()
+	}
+
+	cluster := &v1beta1.KafkaCluster{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: pod.Labels[v1beta1.KafkaCRLabelKey], Namespace: pod.Namespace}, cluster)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconciled()
+		}
+		return requeueWithError(log, err.Error(), err)
+	}
+
+	config := cluster.Spec.BrokerReadiness
+	if config == nil {
+		// the gate is removed once the pod is recreated, it must not hold the broker back until then
+		return r.setBrokerReadyCondition(ctx, log, pod, true, "BrokerReadinessDisabled", "broker readiness expression is not configured", nil)
+	}
+	if pod.Status.PodIP == "" {
+		return requeueAfter(int(config.GetPeriod().Seconds()))
+	}
+
+	ready, reason, message := evaluateBrokerReadiness(ctx, config, brokerMetricsAddress(pod))
+	return r.setBrokerReadyCondition(ctx, log, pod, ready, reason, message, config)
+}
+
+// evaluateBrokerReadiness evaluates the broker readiness expression over the metrics of the broker on the given address
+func evaluateBrokerReadiness(ctx context.Context, config *v1beta1.BrokerReadinessConfig, address string) (bool, string, string) {
+	expression, err := readiness.Parse(config.Expression)
+	if err != nil {
+		return false, "InvalidExpression", err.Error()
+	}
+	metrics, err := fetchBrokerMetrics(ctx, address, config.GetTimeout())
+	if err != nil {
+		return false, "MetricsUnavailable", fmt.Sprintf("could not fetch the metrics of the broker: %s", err)
+	}
+	if ready, message := expression.Evaluate(metrics); !ready {
+		return false, "ExpressionNotSatisfied", message
+	}
+	return true, "ExpressionSatisfied", "broker readiness expression holds"
+}
+
+// setBrokerReadyCondition updates the broker readiness gate condition of the pod if it changed and requeues the
+// pod to evaluate the expression again
+func (r *BrokerReadinessReconciler) setBrokerReadyCondition(ctx context.Context, log logr.Logger, pod *corev1.Pod,
+	ready bool, reason, message string, config *v1beta1.BrokerReadinessConfig) (reconcile.Result, error) {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+
+	index := -1
+	for i, condition := range pod.Status.Conditions {
+		if condition.Type == v1beta1.BrokerReadyConditionType {
+			index = i
+			break
+		}
+	}
+
+	if index == -1 || pod.Status.Conditions[index].Status != status || pod.Status.Conditions[index].Reason != reason ||
+		pod.Status.Conditions[index].Message != message {
+		patch := client.MergeFrom(pod.DeepCopy())
+		condition := corev1.PodCondition{
+			Type:               v1beta1.BrokerReadyConditionType,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: metav1.Now(),
+		}
+		if index == -1 {
+			pod.Status.Conditions = append(pod.Status.Conditions, condition)
+		} else {
+			if pod.Status.Conditions[index].Status == status {
+				condition.LastTransitionTime = pod.Status.Conditions[index].LastTransitionTime
+			}
+			pod.Status.Conditions[index] = condition
+		}
+		if err := r.Client.Status().Patch(ctx, pod, patch); err != nil {
+			return requeueWithError(log, "could not update the broker readiness condition of the pod", err)
+		}
+		log.Info("broker readiness condition updated", "pod", pod.Name, "ready", ready, "reason", reason, "message", message)
+	}
+
+	if config == nil {
+		return reconciled()
+	}
+	return requeueAfter(int(config.GetPeriod().Seconds()))
+}
@@ -0,0 +1,124 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/util/readiness"
+)
+
+func TestBrokerReadinessReconcile(t *testing.T) {
+	const metrics = "kafka_server_replicamanager_underreplicatedpartitions 2\nkafka_server_kafkaserver_brokerstate 3\n"
+
+	testCases := []struct {
+		testName        string
+		brokerReadiness *v1beta1.BrokerReadinessConfig
+		fetchErr        error
+		expectedStatus  corev1.ConditionStatus
+		expectedReason  string
+	}{
+		{
+			testName:        "expression holds",
+			brokerReadiness: &v1beta1.BrokerReadinessConfig{Expression: "kafka_server_kafkaserver_brokerstate == 3"},
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  "ExpressionSatisfied",
+		},
+		{
+			testName:        "expression does not hold",
+			brokerReadiness: &v1beta1.BrokerReadinessConfig{Expression: "kafka_server_replicamanager_underreplicatedpartitions == 0"},
+			expectedStatus:  corev1.ConditionFalse,
+			expectedReason:  "ExpressionNotSatisfied",
+		},
+		{
+			testName:        "metrics unavailable",
+			brokerReadiness: &v1beta1.BrokerReadinessConfig{Expression: "kafka_server_kafkaserver_brokerstate == 3"},
+			fetchErr:        errors.New("connection refused"),
+			expectedStatus:  corev1.ConditionFalse,
+			expectedReason:  "MetricsUnavailable",
+		},
+		{
+			testName:       "broker readiness removed from the cluster",
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: "BrokerReadinessDisabled",
+		},
+	}
+
+	defer func(fetch func(context.Context, string, time.Duration) (map[string]*dto.MetricFamily, error)) {
+		fetchBrokerMetrics = fetch
+	}(fetchBrokerMetrics)
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			fetchBrokerMetrics = func(ctx context.Context, address string, timeout time.Duration) (map[string]*dto.MetricFamily, error) {
+				require.Equal(t, "10.0.0.1:9020", address)
+				if test.fetchErr != nil {
+					return nil, test.fetchErr
+				}
+				return readiness.ParseMetrics(strings.NewReader(metrics))
+			}
+
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Spec:       v1beta1.KafkaClusterSpec{BrokerReadiness: test.brokerReadiness},
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "kafka-0-abcde",
+					Namespace: "kafka",
+					Labels:    map[string]string{v1beta1.AppLabelKey: "kafka", v1beta1.KafkaCRLabelKey: "kafka"},
+				},
+				Spec: corev1.PodSpec{
+					ReadinessGates: []corev1.PodReadinessGate{{ConditionType: v1beta1.BrokerReadyConditionType}},
+				},
+				Status: corev1.PodStatus{PodIP: "10.0.0.1"},
+			}
+
+			scheme := runtime.NewScheme()
+			require.NoError(t, v1beta1.AddToScheme(scheme))
+			require.NoError(t, corev1.AddToScheme(scheme))
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, pod).WithStatusSubresource(pod).Build()
+
+			r := &BrokerReadinessReconciler{Client: c}
+			result, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}})
+			require.NoError(t, err)
+			if test.brokerReadiness != nil {
+				require.Equal(t, 15*time.Second, result.RequeueAfter)
+			}
+
+			updated := &corev1.Pod{}
+			require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}, updated))
+			require.Len(t, updated.Status.Conditions, 1)
+			require.Equal(t, corev1.PodConditionType(v1beta1.BrokerReadyConditionType), updated.Status.Conditions[0].Type)
+			require.Equal(t, test.expectedStatus, updated.Status.Conditions[0].Status)
+			require.Equal(t, test.expectedReason, updated.Status.Conditions[0].Reason)
+		})
+	}
+}
@@ -15,10 +15,15 @@
 package controllers
 
 import (
+	"context"
 	"fmt"
 	"time"
 
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/banzaicloud/koperator/pkg/util"
 
@@ -31,6 +36,7 @@ import (
 	"github.com/banzaicloud/koperator/api/v1beta1"
 	"github.com/banzaicloud/koperator/pkg/errorfactory"
 	"github.com/banzaicloud/koperator/pkg/kafkaclient"
+	"github.com/banzaicloud/koperator/pkg/util/tracing"
 )
 
 // clusterRefLabel is the label key used for referencing KafkaUsers/KafkaTopics
@@ -41,6 +47,43 @@ var clusterRefLabel = "kafkaCluster"
 // use as var so it can be overwritten from unit tests
 var newKafkaFromCluster = kafkaclient.NewFromCluster
 
+// connectKafka connects to the Kafka cluster through newKafkaFromCluster in a span of the reconcile
+func connectKafka(ctx context.Context, k8sclient client.Client, cluster *v1beta1.KafkaCluster) (kafkaclient.KafkaClient, func(), error) {
+	_, span := tracing.Start(ctx, "kafka.Connect", tracing.ClusterAttributes(cluster.Namespace, cluster.Name)...)
+	broker, close, err := newKafkaFromCluster(k8sclient, cluster)
+	tracing.End(span, err)
+	return broker, close, err
+}
+
+// RequeueBackoff bounds the exponential backoff of the requeues of the failed reconciles
+type RequeueBackoff struct {
+	// BaseDelay is the delay of the first requeue of a failed reconcile, doubled on every failure of the resource
+	BaseDelay time.Duration
+	// MaxDelay is the maximum delay of the requeues of a resource
+	MaxDelay time.Duration
+}
+
+// requeueBackoff is the backoff of the requeues of the failed reconciles, the one of controller-runtime by default
+var requeueBackoff = RequeueBackoff{BaseDelay: 5 * time.Millisecond, MaxDelay: 1000 * time.Second}
+
+// SetRequeueBackoff sets the backoff of the requeues of the failed reconciles of the controllers set up afterwards
+func SetRequeueBackoff(backoff RequeueBackoff) {
+	requeueBackoff = backoff
+}
+
+// controllerOptions returns the options of a controller reconciling at most the given number of resources at the
+// same time, with the requeue backoff of the operator
+func controllerOptions(maxConcurrentReconciles int) controller.Options {
+	return controller.Options{
+		MaxConcurrentReconciles: maxConcurrentReconciles,
+		RateLimiter: workqueue.NewTypedMaxOfRateLimiter(
+			workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](requeueBackoff.BaseDelay, requeueBackoff.MaxDelay),
+			// the overall retry rate of controller-runtime
+			&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+		),
+	}
+}
+
 func requeueAfter(sec int) (ctrl.Result, error) {
 	return ctrl.Result{
 		RequeueAfter: time.Duration(sec) * time.Second,
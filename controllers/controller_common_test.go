@@ -25,6 +25,7 @@ import (
 	//nolint:staticcheck
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/banzaicloud/koperator/api/v1alpha1"
 	"github.com/banzaicloud/koperator/api/v1beta1"
@@ -52,6 +53,26 @@ func TestRequeueAfter(t *testing.T) {
 	}
 }
 
+func TestControllerOptions(t *testing.T) {
+	defer SetRequeueBackoff(requeueBackoff)
+	SetRequeueBackoff(RequeueBackoff{BaseDelay: time.Second, MaxDelay: 3 * time.Second})
+
+	options := controllerOptions(5)
+	if options.MaxConcurrentReconciles != 5 {
+		t.Errorf("Expected 5 concurrent reconciles, got %d", options.MaxConcurrentReconciles)
+	}
+	request := reconcile.Request{}
+	for _, expected := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second} {
+		if delay := options.RateLimiter.When(request); delay != expected {
+			t.Errorf("Expected requeue delay %s, got %s", expected, delay)
+		}
+	}
+	options.RateLimiter.Forget(request)
+	if delay := options.RateLimiter.When(request); delay != time.Second {
+		t.Errorf("Expected requeue delay to be reset to %s, got %s", time.Second, delay)
+	}
+}
+
 func TestRequeueWithError(t *testing.T) {
 	_, err := requeueWithError(log, "test", errors.New("test error"))
 	if err == nil {
@@ -0,0 +1,293 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/banzaicloud/go-cruise-control/pkg/types"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	banzaiv1alpha1 "github.com/banzaicloud/koperator/api/v1alpha1"
+	banzaiv1beta1 "github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/scale"
+)
+
+// anomalyRemediations maps the anomaly types to the Cruise Control operations remediating them
+var anomalyRemediations = map[banzaiv1beta1.CruiseControlAnomalyType]banzaiv1alpha1.CruiseControlTaskOperation{
+	banzaiv1beta1.CruiseControlAnomalyGoalViolation: banzaiv1alpha1.OperationRebalance,
+	banzaiv1beta1.CruiseControlAnomalyBrokerFailure: banzaiv1alpha1.OperationFixOfflineReplicas,
+	banzaiv1beta1.CruiseControlAnomalyDiskFailure:   banzaiv1alpha1.OperationFixOfflineReplicas,
+}
+
+// remediableAnomalyStatuses are the statuses of the anomalies not being fixed by Cruise Control, the anomalies
+// remediated by the operator are reported as IGNORED as self-healing has to be disabled for them in Cruise Control
+var remediableAnomalyStatuses = map[string]bool{
+	types.AnomalyStatusDetected.String():       true,
+	types.AnomalyStatusIgnored.String():        true,
+	types.AnomalyStatusCheckWithDelay.String(): true,
+}
+
+// SetupCruiseControlAnomalyWithManager registers the Cruise Control anomaly controller to the manager
+func SetupCruiseControlAnomalyWithManager(mgr ctrl.Manager) *ctrl.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&banzaiv1beta1.KafkaCluster{}).
+		WithEventFilter(SkipClusterRegistryOwnedResourcePredicate{}).
+		WithEventFilter(newOperatorScopePredicate(mgr)).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Named("CruiseControlAnomaly")
+}
+
+// blank assignment to verify that CruiseControlAnomalyReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &CruiseControlAnomalyReconciler{}
+
+// CruiseControlAnomalyReconciler polls the Cruise Control anomaly detector of the KafkaClusters with self-healing
+// configured, reports the detected anomalies in the status of the cluster and remediates them with
+// CruiseControlOperations when the anomaly type is listed in the remediations
+type CruiseControlAnomalyReconciler struct {
+	client.Client
+	Scheme       *runtime.Scheme
+	ScaleFactory func(ctx context.Context, kafkaCluster *banzaiv1beta1.KafkaCluster) (scale.CruiseControlScaler, error)
+}
+
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkaclusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=cruisecontroloperations,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=cruisecontroloperations/status,verbs=get;update;patch
+
+// Reconcile reports and remediates the anomalies detected by Cruise Control
+func (r *CruiseControlAnomalyReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	cluster := &banzaiv1beta1.KafkaCluster{}
+	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconciled()
+		}
+		return requeueWithError(log, err.Error(), err)
+	}
+	if !cluster.DeletionTimestamp.IsZero() {
+		return reconciled()
+	}
+
+	selfHealing := cluster.Spec.CruiseControlConfig.SelfHealing
+	if selfHealing == nil {
+		if cluster.Status.CruiseControlAnomalies != nil {
+			if err := k8sutil.UpdateCRStatus(r.Client, cluster, (*banzaiv1beta1.CruiseControlAnomaliesStatus)(nil), log); err != nil {
+				return requeueWithError(log, "failed to remove the Cruise Control anomalies from the status", err)
+			}
+		}
+		return reconciled()
+	}
+	pollInterval := selfHealing.GetPollInterval()
+
+	scaler, err := r.ScaleFactory(ctx, cluster)
+	if err != nil {
+		return requeueWithError(log, "failed to create Cruise Control Scaler instance", err)
+	}
+	if !scaler.IsUp(ctx) {
+		log.Info("Cruise Control is not up, the anomaly detector state can not be polled")
+		return ctrl.Result{RequeueAfter: pollInterval}, nil
+	}
+	state, err := scaler.AnomalyDetectorState(ctx)
+	if err != nil {
+		log.Error(err, "could not get the Cruise Control anomaly detector state")
+		return ctrl.Result{RequeueAfter: pollInterval}, nil
+	}
+
+	previous := cluster.Status.CruiseControlAnomalies
+	anomalies := anomaliesFromState(state, previous)
+
+	if err := r.remediateAnomalies(ctx, log, cluster, anomalies); err != nil {
+		return requeueWithError(log, "failed to remediate the Cruise Control anomalies", err)
+	}
+
+	status := &banzaiv1beta1.CruiseControlAnomaliesStatus{
+		OngoingSelfHealingAnomaly: ongoingSelfHealingAnomaly(state),
+		Anomalies:                 anomalies,
+	}
+	if previous == nil || previous.OngoingSelfHealingAnomaly != status.OngoingSelfHealingAnomaly ||
+		!equality.Semantic.DeepEqual(previous.Anomalies, status.Anomalies) {
+		status.LastUpdateTime = metav1.Now()
+		if err := k8sutil.UpdateCRStatus(r.Client, cluster, status, log); err != nil {
+			return requeueWithError(log, "failed to update the Cruise Control anomalies in the status", err)
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: pollInterval}, nil
+}
+
+// remediateAnomalies creates a CruiseControlOperation for each newly detected or ignored anomaly whose type is remediated by the
+// operator, unless an operation of the same kind is already in progress
+func (r *CruiseControlAnomalyReconciler) remediateAnomalies(ctx context.Context, log logr.Logger, cluster *banzaiv1beta1.KafkaCluster, anomalies []banzaiv1beta1.CruiseControlAnomaly) error {
+	ccOperations := &banzaiv1alpha1.CruiseControlOperationList{}
+	if err := r.List(ctx, ccOperations, client.InNamespace(cluster.Namespace), client.MatchingLabels(apiutil.LabelsForKafka(cluster.Name))); err != nil {
+		return err
+	}
+	inProgress := make(map[banzaiv1alpha1.CruiseControlTaskOperation]bool)
+	for i := range ccOperations.Items {
+		if !ccOperations.Items[i].IsDone() {
+			inProgress[ccOperations.Items[i].CurrentTaskOperation()] = true
+		}
+	}
+
+	selfHealing := cluster.Spec.CruiseControlConfig.SelfHealing
+	for i := range anomalies {
+		anomaly := &anomalies[i]
+		operationType := anomalyRemediations[anomaly.Type]
+		if !selfHealing.IsRemediated(anomaly.Type) || !remediableAnomalyStatuses[anomaly.Status] ||
+			anomaly.RemediationOperation != "" || inProgress[operationType] {
+			continue
+		}
+		operation, err := r.createRemediation(ctx, cluster, operationType)
+		if err != nil {
+			return err
+		}
+		log.Info("remediating Cruise Control anomaly", "anomalyID", anomaly.ID, "type", anomaly.Type,
+			"operation", operationType, "cruiseControlOperation", operation.GetName())
+		anomaly.RemediationOperation = operation.GetName()
+		inProgress[operationType] = true
+	}
+	return nil
+}
+
+// createRemediation creates the CruiseControlOperation remediating an anomaly, failures are not retried as the
+// anomaly detector reports the anomaly again when it persists
+func (r *CruiseControlAnomalyReconciler) createRemediation(ctx context.Context, cluster *banzaiv1beta1.KafkaCluster, operationType banzaiv1alpha1.CruiseControlTaskOperation) (*banzaiv1alpha1.CruiseControlOperation, error) {
+	operation := &banzaiv1alpha1.CruiseControlOperation{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-%s-", cluster.Name, strings.ReplaceAll(string(operationType), "_", "")),
+			Namespace:    cluster.Namespace,
+			Labels:       apiutil.LabelsForKafka(cluster.Name),
+		},
+		Spec: banzaiv1alpha1.CruiseControlOperationSpec{
+			ErrorPolicy:             banzaiv1alpha1.ErrorPolicyIgnore,
+			TTLSecondsAfterFinished: cluster.Spec.CruiseControlConfig.CruiseControlOperationSpec.GetTTLSecondsAfterFinished(),
+		},
+	}
+	if err := controllerutil.SetControllerReference(cluster, operation, r.Scheme); err != nil {
+		return nil, err
+	}
+	if err := r.Create(ctx, operation); err != nil {
+		return nil, err
+	}
+
+	operation.Status.CurrentTask = &banzaiv1alpha1.CruiseControlTask{
+		Operation: operationType,
+		Parameters: map[string]string{
+			scale.ParamExcludeDemoted: True,
+			scale.ParamExcludeRemoved: True,
+		},
+	}
+	if err := r.Status().Update(ctx, operation); err != nil {
+		return nil, err
+	}
+	return operation, nil
+}
+
+// anomaliesFromState converts the goal violations, broker and disk failures reported by the anomaly detector, the
+// remediation operations are carried over from the previous status
+func anomaliesFromState(state *types.AnomalyDetectorState, previous *banzaiv1beta1.CruiseControlAnomaliesStatus) []banzaiv1beta1.CruiseControlAnomaly {
+	remediations := make(map[string]string)
+	if previous != nil {
+		for _, anomaly := range previous.Anomalies {
+			remediations[anomaly.ID] = anomaly.RemediationOperation
+		}
+	}
+
+	var anomalies []banzaiv1beta1.CruiseControlAnomaly
+	for anomalyType, details := range map[banzaiv1beta1.CruiseControlAnomalyType][]types.AnomalyDetails{
+		banzaiv1beta1.CruiseControlAnomalyGoalViolation: state.RecentGoalViolations,
+		banzaiv1beta1.CruiseControlAnomalyBrokerFailure: state.RecentBrokerFailures,
+		banzaiv1beta1.CruiseControlAnomalyDiskFailure:   state.RecentDiskFailures,
+	} {
+		for _, detail := range details {
+			anomalies = append(anomalies, banzaiv1beta1.CruiseControlAnomaly{
+				ID:                   detail.AnomalyID,
+				Type:                 anomalyType,
+				Status:               detail.Status.String(),
+				DetectionTime:        metav1.NewTime(time.UnixMilli(detail.DetectionMs).Truncate(time.Second)),
+				Description:          anomalyDescription(anomalyType, detail),
+				RemediationOperation: remediations[detail.AnomalyID],
+			})
+		}
+	}
+	// the most recent anomalies come first
+	sort.SliceStable(anomalies, func(i, j int) bool {
+		if !anomalies[i].DetectionTime.Equal(&anomalies[j].DetectionTime) {
+			return anomalies[j].DetectionTime.Before(&anomalies[i].DetectionTime)
+		}
+		return anomalies[i].ID < anomalies[j].ID
+	})
+	return anomalies
+}
+
+// anomalyDescription returns the description of the anomaly reported by Cruise Control, or one built from the
+// failed brokers, disks or violated goals when missing
+func anomalyDescription(anomalyType banzaiv1beta1.CruiseControlAnomalyType, detail types.AnomalyDetails) string {
+	if detail.Description != "" {
+		return detail.Description
+	}
+	switch anomalyType {
+	case banzaiv1beta1.CruiseControlAnomalyBrokerFailure:
+		return fmt.Sprintf("failed brokers: %s", strings.Join(sortedKeys(detail.FailedBrokersByTimeMs), ", "))
+	case banzaiv1beta1.CruiseControlAnomalyDiskFailure:
+		return fmt.Sprintf("failed disks: %s", strings.Join(sortedKeys(detail.FailedDisksByTimeMs), ", "))
+	case banzaiv1beta1.CruiseControlAnomalyGoalViolation:
+		fixable := make([]string, 0, len(detail.FixableViolatedGoals))
+		for _, goal := range detail.FixableViolatedGoals {
+			fixable = append(fixable, goal.String())
+		}
+		unfixable := make([]string, 0, len(detail.UnfixableViolatedGoals))
+		for _, goal := range detail.UnfixableViolatedGoals {
+			unfixable = append(unfixable, goal.String())
+		}
+		return fmt.Sprintf("fixable violated goals: [%s], unfixable violated goals: [%s]",
+			strings.Join(fixable, ", "), strings.Join(unfixable, ", "))
+	}
+	return ""
+}
+
+// ongoingSelfHealingAnomaly returns the type of the anomaly Cruise Control is self-healing, empty when none
+func ongoingSelfHealingAnomaly(state *types.AnomalyDetectorState) string {
+	if state.OngoingSelfHealingAnomaly == types.AnomalyTypeUndefined {
+		return ""
+	}
+	return state.OngoingSelfHealingAnomaly.String()
+}
+
+func sortedKeys(m map[string]int64) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
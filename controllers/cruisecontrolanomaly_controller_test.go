@@ -0,0 +1,196 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/banzaicloud/go-cruise-control/pkg/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/controllers/tests/mocks"
+	"github.com/banzaicloud/koperator/pkg/scale"
+)
+
+func TestCruiseControlAnomalyReconcile(t *testing.T) {
+	detected := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	state := &types.AnomalyDetectorState{
+		RecentGoalViolations: []types.AnomalyDetails{{
+			AnomalyID:            "goal-violation",
+			Status:               types.AnomalyStatusDetected,
+			DetectionMs:          detected.UnixMilli(),
+			FixableViolatedGoals: []types.Goal{types.RackAwareGoal},
+		}},
+		RecentBrokerFailures: []types.AnomalyDetails{
+			{
+				AnomalyID:             "broker-failure",
+				Status:                types.AnomalyStatusDetected,
+				DetectionMs:           detected.Add(time.Minute).UnixMilli(),
+				FailedBrokersByTimeMs: map[string]int64{"2": 0, "1": 0},
+			},
+			{
+				AnomalyID:   "ignored-broker-failure",
+				Status:      types.AnomalyStatusIgnored,
+				DetectionMs: detected.Add(-time.Hour).UnixMilli(),
+				Description: "broker 3 failed",
+			},
+		},
+		RecentDiskFailures: []types.AnomalyDetails{{
+			AnomalyID:   "ignored-disk-failure",
+			Status:      types.AnomalyStatusIgnored,
+			DetectionMs: detected.Add(-2 * time.Hour).UnixMilli(),
+			Description: "disk /kafka-logs failed on broker 0",
+		}},
+	}
+
+	testCases := []struct {
+		testName             string
+		remediations         []v1beta1.CruiseControlAnomalyType
+		runningOperation     v1alpha1.CruiseControlTaskOperation
+		expectedRemediations map[string]v1alpha1.CruiseControlTaskOperation
+	}{
+		{
+			testName: "anomalies are reported only",
+		},
+		{
+			testName:     "detected anomalies are remediated",
+			remediations: []v1beta1.CruiseControlAnomalyType{v1beta1.CruiseControlAnomalyGoalViolation, v1beta1.CruiseControlAnomalyBrokerFailure},
+			expectedRemediations: map[string]v1alpha1.CruiseControlTaskOperation{
+				"goal-violation": v1alpha1.OperationRebalance,
+				"broker-failure": v1alpha1.OperationFixOfflineReplicas,
+			},
+		},
+		{
+			testName:         "no remediation while an operation of the same kind is in progress",
+			remediations:     []v1beta1.CruiseControlAnomalyType{v1beta1.CruiseControlAnomalyGoalViolation, v1beta1.CruiseControlAnomalyBrokerFailure},
+			runningOperation: v1alpha1.OperationRebalance,
+			expectedRemediations: map[string]v1alpha1.CruiseControlTaskOperation{
+				"broker-failure": v1alpha1.OperationFixOfflineReplicas,
+			},
+		},
+		{
+			testName:     "ignored anomalies are remediated",
+			remediations: []v1beta1.CruiseControlAnomalyType{v1beta1.CruiseControlAnomalyDiskFailure},
+			expectedRemediations: map[string]v1alpha1.CruiseControlTaskOperation{
+				"ignored-disk-failure": v1alpha1.OperationFixOfflineReplicas,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, v1alpha1.AddToScheme(scheme))
+			require.NoError(t, v1beta1.AddToScheme(scheme))
+
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Spec: v1beta1.KafkaClusterSpec{
+					CruiseControlConfig: v1beta1.CruiseControlConfig{
+						SelfHealing: &v1beta1.CruiseControlSelfHealing{Remediations: test.remediations},
+					},
+				},
+			}
+			builder := fake.NewClientBuilder().WithScheme(scheme).
+				WithStatusSubresource(&v1beta1.KafkaCluster{}, &v1alpha1.CruiseControlOperation{}).
+				WithObjects(cluster)
+			if test.runningOperation != "" {
+				builder = builder.WithObjects(&v1alpha1.CruiseControlOperation{
+					ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "kafka", Labels: apiutil.LabelsForKafka("kafka")},
+					Status: v1alpha1.CruiseControlOperationStatus{
+						CurrentTask: &v1alpha1.CruiseControlTask{
+							ID:        "task",
+							Operation: test.runningOperation,
+							State:     v1beta1.CruiseControlTaskInExecution,
+						},
+					},
+				})
+			}
+			c := builder.Build()
+
+			mockCtrl := gomock.NewController(t)
+			scaleMock := mocks.NewMockCruiseControlScaler(mockCtrl)
+			scaleMock.EXPECT().IsUp(gomock.Any()).Return(true).Times(2)
+			scaleMock.EXPECT().AnomalyDetectorState(gomock.Any()).Return(state, nil).Times(2)
+
+			r := &CruiseControlAnomalyReconciler{
+				Client: c,
+				Scheme: scheme,
+				ScaleFactory: func(ctx context.Context, kafkaCluster *v1beta1.KafkaCluster) (scale.CruiseControlScaler, error) {
+					return scaleMock, nil
+				},
+			}
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cluster)}
+			// the anomalies are remediated only once
+			for range 2 {
+				result, err := r.Reconcile(context.Background(), request)
+				require.NoError(t, err)
+				require.Equal(t, time.Minute, result.RequeueAfter)
+			}
+
+			updated := &v1beta1.KafkaCluster{}
+			require.NoError(t, c.Get(context.Background(), request.NamespacedName, updated))
+			status := updated.Status.CruiseControlAnomalies
+			require.NotNil(t, status)
+			require.Len(t, status.Anomalies, 4)
+			// the most recent anomalies come first
+			require.Equal(t, "broker-failure", status.Anomalies[0].ID)
+			require.Equal(t, v1beta1.CruiseControlAnomalyBrokerFailure, status.Anomalies[0].Type)
+			require.Equal(t, "failed brokers: 1, 2", status.Anomalies[0].Description)
+			require.Equal(t, "goal-violation", status.Anomalies[1].ID)
+			require.Equal(t, "DETECTED", status.Anomalies[1].Status)
+			require.Equal(t, "ignored-broker-failure", status.Anomalies[2].ID)
+			require.Equal(t, "broker 3 failed", status.Anomalies[2].Description)
+			require.Equal(t, "ignored-disk-failure", status.Anomalies[3].ID)
+			require.Equal(t, v1beta1.CruiseControlAnomalyDiskFailure, status.Anomalies[3].Type)
+			require.Equal(t, "IGNORED", status.Anomalies[3].Status)
+
+			ccOperations := &v1alpha1.CruiseControlOperationList{}
+			require.NoError(t, c.List(context.Background(), ccOperations))
+			expectedOperations := len(test.expectedRemediations)
+			if test.runningOperation != "" {
+				expectedOperations++
+			}
+			require.Len(t, ccOperations.Items, expectedOperations)
+			operations := make(map[string]v1alpha1.CruiseControlTaskOperation)
+			for _, operation := range ccOperations.Items {
+				operations[operation.Name] = operation.CurrentTaskOperation()
+			}
+			remediations := make(map[string]v1alpha1.CruiseControlTaskOperation)
+			for _, anomaly := range status.Anomalies {
+				if anomaly.RemediationOperation != "" {
+					remediations[anomaly.ID] = operations[anomaly.RemediationOperation]
+				}
+			}
+			if test.expectedRemediations == nil {
+				require.Empty(t, remediations)
+			} else {
+				require.Equal(t, test.expectedRemediations, remediations)
+			}
+		})
+	}
+}
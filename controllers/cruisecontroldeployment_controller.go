@@ -0,0 +1,179 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"sort"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlBuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/kafkaclient"
+	"github.com/banzaicloud/koperator/pkg/resources/cruisecontrol"
+)
+
+// SetupCruiseControlDeploymentWithManager registers the CruiseControl controller to the manager
+func SetupCruiseControlDeploymentWithManager(mgr ctrl.Manager) *ctrl.Builder {
+	kafkaClusterPredicate := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldObj := e.ObjectOld.(*v1beta1.KafkaCluster)
+			newObj := e.ObjectNew.(*v1beta1.KafkaCluster)
+			return oldObj.GetGeneration() != newObj.GetGeneration() ||
+				oldObj.GetDeletionTimestamp() != newObj.GetDeletionTimestamp() ||
+				oldObj.Status.CruiseControlTopicStatus != newObj.Status.CruiseControlTopicStatus ||
+				!reflect.DeepEqual(oldObj.Status.BrokersState, newObj.Status.BrokersState)
+		},
+	}
+	mapper := cruiseControlMapper{client: mgr.GetClient(), log: mgr.GetLogger().WithName("CruiseControlDeployment")}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.CruiseControl{}).
+		Watches(&v1beta1.KafkaCluster{}, handler.EnqueueRequestsFromMapFunc(mapper.mapToCruiseControls),
+			ctrlBuilder.WithPredicates(SkipClusterRegistryOwnedResourcePredicate{}, newOperatorScopePredicate(mgr), kafkaClusterPredicate)).
+		Owns(&corev1.Service{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.ConfigMap{}, ctrlBuilder.OnlyMetadata).
+		Named("CruiseControlDeployment")
+}
+
+// cruiseControlMapper maps the KafkaClusters to the CruiseControls deploying Cruise Control for them
+type cruiseControlMapper struct {
+	client client.Reader
+	log    logr.Logger
+}
+
+// mapToCruiseControls returns the CruiseControl referenced by the cluster and the ones which deployed Cruise Control
+// for the cluster before, so the resources are released when the cluster stops referencing them
+func (m cruiseControlMapper) mapToCruiseControls(ctx context.Context, obj client.Object) []reconcile.Request {
+	cluster, ok := obj.(*v1beta1.KafkaCluster)
+	if !ok {
+		return nil
+	}
+	cruiseControls := &v1alpha1.CruiseControlList{}
+	if err := m.client.List(ctx, cruiseControls, client.InNamespace(cluster.Namespace)); err != nil {
+		m.log.Error(err, "failed to list CruiseControls", "namespace", cluster.Namespace)
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, cc := range cruiseControls.Items {
+		ref := cluster.Spec.CruiseControlConfig.CruiseControlRef
+		if (ref != nil && ref.Name == cc.Name) || slices.Contains(cc.Status.Clusters, cluster.Name) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cc)})
+		}
+	}
+	return requests
+}
+
+// blank assignment to verify that CruiseControlDeploymentReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &CruiseControlDeploymentReconciler{}
+
+// CruiseControlDeploymentReconciler deploys Cruise Control from the template of a CruiseControl for every KafkaCluster
+// in its namespace referencing it, the resources are owned by the CruiseControl so Cruise Control can be upgraded and
+// restarted independently of the clusters
+type CruiseControlDeploymentReconciler struct {
+	client.Client
+	KafkaClientProvider kafkaclient.Provider
+}
+
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=cruisecontrols,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=cruisecontrols/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile deploys Cruise Control for the clusters referencing the CruiseControl and removes it for the ones which
+// stopped referencing it
+func (r *CruiseControlDeploymentReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	cc := &v1alpha1.CruiseControl{}
+	if err := r.Get(ctx, request.NamespacedName, cc); err != nil {
+		if apierrors.IsNotFound(err) {
+			// the resources are garbage collected with the CruiseControl
+			return reconciled()
+		}
+		return requeueWithError(log, err.Error(), err)
+	}
+	if !cc.DeletionTimestamp.IsZero() {
+		return reconciled()
+	}
+
+	clusters := &v1beta1.KafkaClusterList{}
+	if err := r.List(ctx, clusters, client.InNamespace(cc.Namespace)); err != nil {
+		return requeueWithError(log, "failed to list KafkaClusters", err)
+	}
+
+	var reconcileErr error
+	var referencing []string
+	deployed := make(map[string]*v1beta1.KafkaCluster)
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		if !cluster.DeletionTimestamp.IsZero() || !operatorScope.includes(cluster) {
+			continue
+		}
+		if cluster.Spec.CruiseControlConfig.CruiseControlEndpoint == "" {
+			deployed[cluster.Name] = cluster
+		}
+		ref := cluster.Spec.CruiseControlConfig.CruiseControlRef
+		if ref == nil || ref.Name != cc.Name {
+			continue
+		}
+		referencing = append(referencing, cluster.Name)
+		err := cruisecontrol.NewForCruiseControl(r.Client, cluster, cc, r.KafkaClientProvider).
+			Reconcile(log.WithValues("kafkaCluster", cluster.Name))
+		reconcileErr = errors.Append(reconcileErr, err)
+	}
+
+	for _, name := range cc.Status.Clusters {
+		// the resources of a cluster still deploying Cruise Control are adopted by the cluster or its CruiseControl
+		if _, ok := deployed[name]; ok {
+			continue
+		}
+		err := cruisecontrol.DeleteOwnedResources(ctx, r.Client, types.NamespacedName{Name: name, Namespace: cc.Namespace}, cc)
+		if err != nil && !slices.Contains(referencing, name) {
+			// the cluster is kept in the status to retry the removal
+			referencing = append(referencing, name)
+		}
+		reconcileErr = errors.Append(reconcileErr, err)
+	}
+
+	sort.Strings(referencing)
+	if !slices.Equal(cc.Status.Clusters, referencing) {
+		cc.Status.Clusters = referencing
+		if err := r.Status().Update(ctx, cc); err != nil {
+			return requeueWithError(log, "failed to update the CruiseControl status", err)
+		}
+	}
+
+	if reconcileErr != nil {
+		return requeueWithError(log, "failed to reconcile Cruise Control", reconcileErr)
+	}
+	return reconciled()
+}
@@ -0,0 +1,94 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func TestCruiseControlDeploymentReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	cc := &v1alpha1.CruiseControl{
+		ObjectMeta: metav1.ObjectMeta{Name: "cruisecontrol", Namespace: "kafka", UID: "cruisecontrol-uid"},
+		Spec: v1alpha1.CruiseControlSpec{
+			Template: v1beta1.CruiseControlConfig{Image: "cruisecontrol:template"},
+		},
+		Status: v1alpha1.CruiseControlStatus{Clusters: []string{"deleted", "inline"}},
+	}
+	ownerRef := *metav1.NewControllerRef(cc, v1alpha1.GroupVersion.WithKind("CruiseControl"))
+	referencing := &v1beta1.KafkaCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "referencing", Namespace: "kafka"},
+		Spec: v1beta1.KafkaClusterSpec{
+			CruiseControlConfig: v1beta1.CruiseControlConfig{
+				Image:            "cruisecontrol:cluster",
+				CruiseControlRef: &corev1.LocalObjectReference{Name: "cruisecontrol"},
+			},
+		},
+		Status: v1beta1.KafkaClusterStatus{CruiseControlTopicStatus: v1beta1.CruiseControlTopicReady},
+	}
+	// the clusters which stopped referencing the CruiseControl, Cruise Control is still deployed for the inline one
+	inline := &v1beta1.KafkaCluster{ObjectMeta: metav1.ObjectMeta{Name: "inline", Namespace: "kafka"}}
+	ownedDeployment := func(name string) *appsv1.Deployment {
+		return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: "kafka", OwnerReferences: []metav1.OwnerReference{ownerRef},
+		}}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&v1beta1.KafkaCluster{}, &v1alpha1.CruiseControl{}).
+		WithObjects(cc, referencing, inline, ownedDeployment("deleted-cruisecontrol"), ownedDeployment("inline-cruisecontrol")).
+		Build()
+	r := CruiseControlDeploymentReconciler{Client: c}
+
+	_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cc)})
+	require.NoError(t, err)
+
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "referencing-cruisecontrol", Namespace: "kafka"}, deployment))
+	require.Equal(t, []metav1.OwnerReference{ownerRef}, deployment.OwnerReferences)
+	require.Equal(t, "cruisecontrol:template", deployment.Spec.Template.Spec.Containers[0].Image)
+	service := &corev1.Service{}
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "referencing-cruisecontrol-svc", Namespace: "kafka"}, service))
+	require.Equal(t, []metav1.OwnerReference{ownerRef}, service.OwnerReferences)
+
+	err = c.Get(context.Background(), types.NamespacedName{Name: "deleted-cruisecontrol", Namespace: "kafka"}, &appsv1.Deployment{})
+	require.True(t, apierrors.IsNotFound(err))
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "inline-cruisecontrol", Namespace: "kafka"}, &appsv1.Deployment{}))
+
+	actual := &v1alpha1.CruiseControl{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(cc), actual))
+	require.Equal(t, []string{"referencing"}, actual.Status.Clusters)
+}
@@ -25,9 +25,12 @@ import (
 
 	"emperror.dev/errors"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -39,8 +42,11 @@ import (
 	apiutil "github.com/banzaicloud/koperator/api/util"
 	banzaiv1alpha1 "github.com/banzaicloud/koperator/api/v1alpha1"
 	banzaiv1beta1 "github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
 	"github.com/banzaicloud/koperator/pkg/scale"
 	"github.com/banzaicloud/koperator/pkg/util"
+	"github.com/banzaicloud/koperator/pkg/util/metrics"
+	"github.com/banzaicloud/koperator/pkg/util/tracing"
 )
 
 const (
@@ -56,51 +62,58 @@ const (
 var (
 	defaultRequeueIntervalInSeconds = 10
 	executionPriorityMap            = map[banzaiv1alpha1.CruiseControlTaskOperation]int{
-		banzaiv1alpha1.OperationAddBroker:    3,
-		banzaiv1alpha1.OperationRemoveBroker: 2,
-		banzaiv1alpha1.OperationRemoveDisks:  1,
-		banzaiv1alpha1.OperationRebalance:    0,
+		// offline replicas leave partitions unavailable, they are fixed before anything else
+		banzaiv1alpha1.OperationFixOfflineReplicas: 5,
+		banzaiv1alpha1.OperationAddBroker:          4,
+		banzaiv1alpha1.OperationRemoveBroker:       3,
+		banzaiv1alpha1.OperationRemoveDisks:        2,
+		// the brokers of interrupted nodes and replaced brokers are demoted promptly, preempting the rebalances
+		banzaiv1alpha1.OperationDemoteBroker:       1,
+		banzaiv1alpha1.OperationRebalance:          0,
+		banzaiv1alpha1.OperationTopicConfiguration: 0,
 	}
 	missingCCResErr = errors.New("missing Cruise Control user task result")
 )
 
+// The reasons of the events emitted on the KafkaCluster about the Cruise Control tasks
+const (
+	cruiseControlTaskSubmittedEventReason = "CruiseControlTaskSubmitted"
+	cruiseControlTaskCompletedEventReason = "CruiseControlTaskCompleted"
+	cruiseControlTaskFailedEventReason    = "CruiseControlTaskFailed"
+)
+
 // CruiseControlOperationReconciler reconciles CruiseControlOperation custom resources
 type CruiseControlOperationReconciler struct {
 	client.Client
 	DirectClient client.Reader
 	Scheme       *runtime.Scheme
-	scaler       scale.CruiseControlScaler
 	ScaleFactory func(ctx context.Context, kafkaCluster *banzaiv1beta1.KafkaCluster) (scale.CruiseControlScaler, error)
+	// Recorder emits the events of the Cruise Control tasks on the KafkaClusters
+	Recorder record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=cruisecontroloperations,verbs=get;list;watch;create;update;patch;delete;deletecollection
 // +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=cruisecontroloperations/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=cruisecontroloperations/finalizers,verbs=create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 //nolint:gocyclo
 func (r *CruiseControlOperationReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.V(1).Info("reconciling CruiseControlOperation custom resources")
+	start := time.Now()
+	ctx, span := tracing.StartReconcile(ctx, "CruiseControlOperation", request)
+	defer span.End()
 
-	ccOperationListClusterWide := banzaiv1alpha1.CruiseControlOperationList{}
-	err := r.DirectClient.List(ctx, &ccOperationListClusterWide, client.ListOption(client.InNamespace(request.Namespace)))
-	if err != nil {
-		return requeueWithError(log, err.Error(), err)
-	}
-
-	// Selecting the reconciling CruiseControlOperation
-	var currentCCOperation *banzaiv1alpha1.CruiseControlOperation
-	for i := range ccOperationListClusterWide.Items {
-		ccOperation := &ccOperationListClusterWide.Items[i]
-		if ccOperation.GetName() == request.Name && ccOperation.GetNamespace() == request.Namespace {
-			currentCCOperation = ccOperation
-			break
+	currentCCOperation := &banzaiv1alpha1.CruiseControlOperation{}
+	if err := r.DirectClient.Get(ctx, request.NamespacedName, currentCCOperation); err != nil {
+		if apiErrors.IsNotFound(err) {
+			metrics.DeleteReconcileDuration("CruiseControlOperation", request)
+			return reconciled()
 		}
+		return requeueWithError(log, err.Error(), err)
 	}
-
-	if currentCCOperation == nil {
-		return reconciled()
-	}
+	defer metrics.ObserveReconcileDuration("CruiseControlOperation", request, start)
 
 	// Skip reconciliation for Cruise Control Status operation
 	if currentCCOperation.CurrentTaskOperation() == banzaiv1alpha1.OperationStatus {
@@ -149,13 +162,30 @@ func (r *CruiseControlOperationReconciler) Reconcile(ctx context.Context, reques
 		return requeueWithError(log, "failed to add finalizer to CruiseControlOperation", err)
 	}
 
-	r.scaler, err = r.ScaleFactory(ctx, kafkaCluster)
+	// The operations of the cluster may be reconciled concurrently, their tasks are selected and submitted to Cruise
+	// Control by one reconcile at a time so that a single task is executed
+	unlock := ccOperationClusterLocks.lock(kafkaClusterRef)
+	defer unlock()
+
+	ccOperationListClusterWide := banzaiv1alpha1.CruiseControlOperationList{}
+	err = r.DirectClient.List(ctx, &ccOperationListClusterWide, client.ListOption(client.InNamespace(request.Namespace)))
+	if err != nil {
+		return requeueWithError(log, err.Error(), err)
+	}
+	for i := range ccOperationListClusterWide.Items {
+		if ccOperationListClusterWide.Items[i].GetName() == request.Name {
+			currentCCOperation = &ccOperationListClusterWide.Items[i]
+			break
+		}
+	}
+
+	scaler, err := r.ScaleFactory(ctx, kafkaCluster)
 	if err != nil {
 		return requeueWithError(log, "failed to create Cruise Control Scaler instance", err)
 	}
 
 	// Checking Cruise Control health
-	status, err := r.getStatus(ctx, log, kafkaCluster, kafkaClusterRef, ccOperationListClusterWide)
+	status, err := r.getStatus(ctx, log, scaler, kafkaCluster, kafkaClusterRef, ccOperationListClusterWide)
 	if err != nil {
 		log.Error(err, "could not get Cruise Control status")
 		return requeueAfter(defaultRequeueIntervalInSeconds)
@@ -183,7 +213,7 @@ func (r *CruiseControlOperationReconciler) Reconcile(ctx context.Context, reques
 	}
 
 	// Update currentTask states from Cruise Control
-	err = r.updateCurrentTasks(ctx, ccOperationsKafkaClusterFiltered)
+	err = r.updateCurrentTasks(ctx, scaler, kafkaCluster, ccOperationsKafkaClusterFiltered)
 	if err != nil {
 		log.Error(err, "requeue event as updating state of currentTask(s) failed")
 		return requeueAfter(defaultRequeueIntervalInSeconds)
@@ -201,9 +231,22 @@ func (r *CruiseControlOperationReconciler) Reconcile(ctx context.Context, reques
 	// Sorting operations into categories which are sorted by priority
 	ccOperationQueueMap := sortOperations(ccOperationsKafkaClusterFiltered)
 
+	// The rebalances only start in the maintenance windows of the cluster
+	waiting, nextWindow, err := r.deferToMaintenanceWindow(ctx, log, kafkaCluster, ccOperationQueueMap)
+	if err != nil {
+		return requeueWithError(log, "could not defer the operations to the next maintenance window", err)
+	}
+
 	// When there is no more job present in the cluster we reconciled.
 	if len(ccOperationQueueMap[ccOperationForStopExecution]) == 0 && len(ccOperationQueueMap[ccOperationFirstExecution]) == 0 &&
 		len(ccOperationQueueMap[ccOperationRetryExecution]) == 0 && len(ccOperationQueueMap[ccOperationInProgress]) == 0 {
+		if waiting {
+			log.Info("operations wait for the next maintenance window", "nextWindow", nextWindow)
+			if nextWindow.IsZero() {
+				return requeueAfter(defaultRequeueIntervalInSeconds)
+			}
+			return ctrl.Result{RequeueAfter: time.Until(nextWindow)}, nil
+		}
 		log.Info("there is no more operation for execution")
 		return reconciled()
 	}
@@ -218,6 +261,14 @@ func (r *CruiseControlOperationReconciler) Reconcile(ctx context.Context, reques
 		return requeueAfter(defaultRequeueIntervalInSeconds)
 	}
 
+	// An operation of higher priority stops the execution of a preemptible operation, which is executed again afterwards
+	if inProgress := getFirstOperation(ccOperationQueueMap, ccOperationInProgress); inProgress != nil && preempts(ccOperationExecution, inProgress) {
+		if err := r.preempt(ctx, log, scaler, kafkaCluster, inProgress, ccOperationExecution); err != nil {
+			return requeueWithError(log, "could not preempt the Cruise Control operation in progress", err)
+		}
+		return requeueAfter(defaultRequeueIntervalInSeconds)
+	}
+
 	// Check if CruiseControl is ready as we cannot perform any operation until it is in ready state unless it is a stop execution operation
 	if (status.InExecution() || len(ccOperationQueueMap[ccOperationInProgress]) > 0) && ccOperationExecution.CurrentTaskOperation() != banzaiv1alpha1.OperationStopExecution {
 		// Requeue because we can't do more
@@ -226,7 +277,7 @@ func (r *CruiseControlOperationReconciler) Reconcile(ctx context.Context, reques
 
 	log.Info("executing Cruise Control task", "operation", ccOperationExecution.CurrentTaskOperation(), "parameters", ccOperationExecution.CurrentTaskParameters())
 	// Executing operation
-	cruseControlTaskResult, err := r.executeOperation(ctx, ccOperationExecution)
+	cruseControlTaskResult, err := r.executeOperation(ctx, scaler, ccOperationExecution)
 
 	if err != nil {
 		log.Error(err, "Cruise Control task execution got an error", "name", ccOperationExecution.GetName(), "namespace", ccOperationExecution.GetNamespace(), "operation", ccOperationExecution.CurrentTaskOperation(), "parameters", ccOperationExecution.CurrentTaskParameters())
@@ -250,6 +301,15 @@ func (r *CruiseControlOperationReconciler) Reconcile(ctx context.Context, reques
 	if err := util.RetryOnConflict(util.DefaultBackOffForConflict, conflictRetryFunction); err != nil {
 		return requeueWithError(log, "could not update the result of the Cruise Control user task execution to the CruiseControlOperation status", err)
 	}
+	if ccOperationExecution.CurrentTaskState() == banzaiv1beta1.CruiseControlTaskCompletedWithError {
+		k8sutil.RecordEvent(r.Recorder, kafkaCluster, corev1.EventTypeWarning, cruiseControlTaskFailedEventReason,
+			"the %s task of CruiseControlOperation %s failed: %s", ccOperationExecution.CurrentTaskOperation(),
+			ccOperationExecution.GetName(), ccOperationExecution.CurrentTask().ErrorMessage)
+	} else {
+		k8sutil.RecordEvent(r.Recorder, kafkaCluster, corev1.EventTypeNormal, cruiseControlTaskSubmittedEventReason,
+			"the %s task of CruiseControlOperation %s has been submitted to Cruise Control with ID %s",
+			ccOperationExecution.CurrentTaskOperation(), ccOperationExecution.GetName(), ccOperationExecution.CurrentTaskID())
+	}
 
 	return reconciled()
 }
@@ -270,20 +330,26 @@ func (r *CruiseControlOperationReconciler) addFinalizer(ctx context.Context, cur
 	return nil
 }
 
-func (r *CruiseControlOperationReconciler) executeOperation(ctx context.Context, ccOperationExecution *banzaiv1alpha1.CruiseControlOperation) (*scale.Result, error) {
+func (r *CruiseControlOperationReconciler) executeOperation(ctx context.Context, scaler scale.CruiseControlScaler, ccOperationExecution *banzaiv1alpha1.CruiseControlOperation) (*scale.Result, error) {
 	var cruseControlTaskResult *scale.Result
 	var err error
 	switch ccOperationExecution.CurrentTaskOperation() {
 	case banzaiv1alpha1.OperationAddBroker:
-		cruseControlTaskResult, err = r.scaler.AddBrokersWithParams(ctx, ccOperationExecution.CurrentTaskParameters())
+		cruseControlTaskResult, err = scaler.AddBrokersWithParams(ctx, ccOperationExecution.CurrentTaskParameters())
 	case banzaiv1alpha1.OperationRemoveBroker:
-		cruseControlTaskResult, err = r.scaler.RemoveBrokersWithParams(ctx, ccOperationExecution.CurrentTaskParameters())
+		cruseControlTaskResult, err = scaler.RemoveBrokersWithParams(ctx, ccOperationExecution.CurrentTaskParameters())
 	case banzaiv1alpha1.OperationRebalance:
-		cruseControlTaskResult, err = r.scaler.RebalanceWithParams(ctx, ccOperationExecution.CurrentTaskParameters())
+		cruseControlTaskResult, err = scaler.RebalanceWithParams(ctx, rebalanceParameters(ccOperationExecution))
 	case banzaiv1alpha1.OperationRemoveDisks:
-		cruseControlTaskResult, err = r.scaler.RemoveDisksWithParams(ctx, ccOperationExecution.CurrentTaskParameters())
+		cruseControlTaskResult, err = scaler.RemoveDisksWithParams(ctx, ccOperationExecution.CurrentTaskParameters())
+	case banzaiv1alpha1.OperationTopicConfiguration:
+		cruseControlTaskResult, err = scaler.TopicConfigurationWithParams(ctx, ccOperationExecution.CurrentTaskParameters())
+	case banzaiv1alpha1.OperationDemoteBroker:
+		cruseControlTaskResult, err = scaler.DemoteBrokersWithParams(ctx, ccOperationExecution.CurrentTaskParameters())
+	case banzaiv1alpha1.OperationFixOfflineReplicas:
+		cruseControlTaskResult, err = scaler.FixOfflineReplicasWithParams(ctx, ccOperationExecution.CurrentTaskParameters())
 	case banzaiv1alpha1.OperationStopExecution:
-		cruseControlTaskResult, err = r.scaler.StopExecution(ctx)
+		cruseControlTaskResult, err = scaler.StopExecution(ctx)
 	case banzaiv1alpha1.OperationStatus:
 		err = errors.NewWithDetails("Cruise Control operation not supported", "name", ccOperationExecution.GetName(), "namespace", ccOperationExecution.GetNamespace(), "operation", ccOperationExecution.CurrentTaskOperation(), "parameters", ccOperationExecution.CurrentTaskParameters())
 	default:
@@ -298,7 +364,7 @@ func sortOperations(ccOperations []*banzaiv1alpha1.CruiseControlOperation) map[s
 		switch {
 		case isWaitingForFinalization(ccOperation):
 			ccOperationQueueMap[ccOperationForStopExecution] = append(ccOperationQueueMap[ccOperationForStopExecution], ccOperation)
-		case ccOperation.IsWaitingForFirstExecution():
+		case ccOperation.IsPreempted(), ccOperation.IsWaitingForFirstExecution():
 			ccOperationQueueMap[ccOperationFirstExecution] = append(ccOperationQueueMap[ccOperationFirstExecution], ccOperation)
 		case ccOperation.IsWaitingForRetryExecution():
 			ccOperationQueueMap[ccOperationRetryExecution] = append(ccOperationQueueMap[ccOperationRetryExecution], ccOperation)
@@ -372,11 +438,13 @@ func getFirstOperation(ccOperationQueueMap map[string][]*banzaiv1alpha1.CruiseCo
 }
 
 // SetupCruiseControlWithManager registers cruise control controller to the manager
-func SetupCruiseControlOperationWithManager(mgr ctrl.Manager) *ctrl.Builder {
+func SetupCruiseControlOperationWithManager(mgr ctrl.Manager, maxConcurrentReconciles int) *ctrl.Builder {
 	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&banzaiv1alpha1.CruiseControlOperation{}).
 		WithEventFilter(SkipClusterRegistryOwnedResourcePredicate{}).
+		WithEventFilter(newOperatorScopePredicate(mgr)).
 		Named("CruiseControlOperation")
+	builder.WithOptions(controllerOptions(maxConcurrentReconciles))
 
 	builder.WithEventFilter(
 		predicate.Funcs{
@@ -450,6 +518,7 @@ func updateResult(log logr.Logger, res *scale.Result, operation *banzaiv1alpha1.
 	}
 
 	if isAfterExecution {
+		operation.Status.PreemptedBy = ""
 		if task.Started == nil {
 			startTime, err := time.Parse(time.RFC1123, res.StartedAt)
 			if err != nil {
@@ -459,6 +528,7 @@ func updateResult(log logr.Logger, res *scale.Result, operation *banzaiv1alpha1.
 		}
 		task.ID = res.TaskID
 		task.Summary = formatSummary(res.Result)
+		task.GoalSummaries = formatGoalSummaries(res.Result)
 		if res.Err != nil {
 			task.ErrorMessage = res.Err.Error()
 		}
@@ -467,13 +537,76 @@ func updateResult(log logr.Logger, res *scale.Result, operation *banzaiv1alpha1.
 	}
 
 	task.State = res.State
+	setOperationConditions(operation)
 
 	return nil
 }
 
+// recordTaskCompletion emits an event on the cluster when the current task of the operation completed since the
+// previous state of the operation
+func (r *CruiseControlOperationReconciler) recordTaskCompletion(kafkaCluster *banzaiv1beta1.KafkaCluster, previous,
+	operation *banzaiv1alpha1.CruiseControlOperation) {
+	if previous.CurrentTaskState() == operation.CurrentTaskState() {
+		return
+	}
+	switch operation.CurrentTaskState() {
+	case banzaiv1beta1.CruiseControlTaskCompleted:
+		k8sutil.RecordEvent(r.Recorder, kafkaCluster, corev1.EventTypeNormal, cruiseControlTaskCompletedEventReason,
+			"the %s task %s of CruiseControlOperation %s completed", operation.CurrentTaskOperation(),
+			operation.CurrentTaskID(), operation.GetName())
+	case banzaiv1beta1.CruiseControlTaskCompletedWithError:
+		k8sutil.RecordEvent(r.Recorder, kafkaCluster, corev1.EventTypeWarning, cruiseControlTaskFailedEventReason,
+			"the %s task %s of CruiseControlOperation %s failed: %s", operation.CurrentTaskOperation(),
+			operation.CurrentTaskID(), operation.GetName(), operation.CurrentTask().ErrorMessage)
+	}
+}
+
+// setOperationConditions sets the standard Ready, Progressing and Degraded conditions of the operation matching the
+// state of its current task
+func setOperationConditions(operation *banzaiv1alpha1.CruiseControlOperation) {
+	generation := operation.GetGeneration()
+	state := string(operation.CurrentTaskState())
+	if state == "" {
+		state = "Pending"
+	}
+	message := fmt.Sprintf("the Cruise Control user task %s is %s", operation.CurrentTaskID(), state)
+	if operation.IsPreempted() {
+		state = "Preempted"
+		message = fmt.Sprintf("the execution of the task was stopped for CruiseControlOperation %s, it is executed again afterwards",
+			operation.Status.PreemptedBy)
+	}
+
+	var progressing, degraded v1.Condition
+	switch {
+	case operation.IsCurrentTaskRunning():
+		progressing = k8sutil.NewCondition(banzaiv1beta1.ConditionProgressing, true, generation, state, message)
+		degraded = k8sutil.NewCondition(banzaiv1beta1.ConditionDegraded, false, generation, state, message)
+	case operation.CurrentTaskState() == banzaiv1beta1.CruiseControlTaskCompletedWithError:
+		if errorMessage := operation.CurrentTask().ErrorMessage; errorMessage != "" {
+			message = fmt.Sprintf("%s: %s", message, errorMessage)
+		}
+		// the failed task is executed again unless the operation is paused or its errors are ignored
+		progressing = k8sutil.NewCondition(banzaiv1beta1.ConditionProgressing, operation.IsWaitingForRetryExecution(), generation, state, message)
+		degraded = k8sutil.NewCondition(banzaiv1beta1.ConditionDegraded, true, generation, state, message)
+	default:
+		progressing = k8sutil.NewCondition(banzaiv1beta1.ConditionProgressing, false, generation, state, message)
+		degraded = k8sutil.NewCondition(banzaiv1beta1.ConditionDegraded, false, generation, state, message)
+	}
+	ready := k8sutil.NewCondition(banzaiv1beta1.ConditionReady, operation.IsFinished(), generation, state, message)
+
+	for _, condition := range []v1.Condition{ready, progressing, degraded} {
+		meta.SetStatusCondition(&operation.Status.Conditions, condition)
+	}
+	// the operation no longer waits for a maintenance window once it is executed
+	if meta.FindStatusCondition(operation.Status.Conditions, banzaiv1beta1.ConditionWaiting) != nil {
+		meta.SetStatusCondition(&operation.Status.Conditions, k8sutil.NewCondition(banzaiv1beta1.ConditionWaiting, false, generation, state, message))
+	}
+}
+
 // updateCurrentTasks the state of the CruiseControlOperation from the CruiseControlTasksAndStates instance by getting their
 // status from Cruise Control.
-func (r *CruiseControlOperationReconciler) updateCurrentTasks(ctx context.Context, ccOperations []*banzaiv1alpha1.CruiseControlOperation) error {
+func (r *CruiseControlOperationReconciler) updateCurrentTasks(ctx context.Context, scaler scale.CruiseControlScaler, kafkaCluster *banzaiv1beta1.KafkaCluster,
+	ccOperations []*banzaiv1alpha1.CruiseControlOperation) error {
 	log := logr.FromContextOrDiscard(ctx)
 
 	userTaskIDs := make([]string, 0, len(ccOperations))
@@ -486,7 +619,7 @@ func (r *CruiseControlOperationReconciler) updateCurrentTasks(ctx context.Contex
 		ccOperationsCopy = append(ccOperationsCopy, ccOperation.DeepCopy())
 	}
 
-	tasks, err := r.scaler.UserTasks(ctx, userTaskIDs...)
+	tasks, err := scaler.UserTasks(ctx, userTaskIDs...)
 	if err != nil {
 		return errors.WrapIff(err, "could not get user tasks from Cruise Control API")
 	}
@@ -509,6 +642,7 @@ func (r *CruiseControlOperationReconciler) updateCurrentTasks(ctx context.Contex
 			if err := r.Status().Update(ctx, ccOperations[i]); err != nil {
 				return errors.WrapIfWithDetails(err, "could not update CruiseControlOperation status", "name", ccOperations[i].GetName(), "namespace", ccOperations[i].GetNamespace())
 			}
+			r.recordTaskCompletion(kafkaCluster, ccOperationsCopy[i], ccOperations[i])
 		}
 	}
 	return nil
@@ -525,6 +659,7 @@ func (r *CruiseControlOperationReconciler) updateCurrentTasks(ctx context.Contex
 func (r *CruiseControlOperationReconciler) getStatus(
 	ctx context.Context,
 	log logr.Logger,
+	scaler scale.CruiseControlScaler,
 	kafkaCluster *banzaiv1beta1.KafkaCluster,
 	kafkaClusterRef client.ObjectKey,
 	ccOperationListClusterWide banzaiv1alpha1.CruiseControlOperationList,
@@ -543,7 +678,7 @@ func (r *CruiseControlOperationReconciler) getStatus(
 	}
 
 	if statusOperation != nil {
-		res, err := r.scaler.StatusTask(ctx, statusOperation.CurrentTaskID())
+		res, err := scaler.StatusTask(ctx, statusOperation.CurrentTaskID())
 		if err != nil {
 			return scale.CruiseControlStatus{}, errors.WrapIfWithDetails(err, "could not get the latest state of Status CruiseControlOperation", "name", statusOperation.GetName(), "namespace", statusOperation.GetNamespace())
 		}
@@ -568,7 +703,7 @@ func (r *CruiseControlOperationReconciler) getStatus(
 		return *res.Status, nil
 	}
 
-	res, err := r.scaler.Status(ctx)
+	res, err := scaler.Status(ctx)
 	if err != nil {
 		return scale.CruiseControlStatus{}, errors.WrapIfWithDetails(err, "could not get Cruise Control status")
 	}
@@ -633,6 +768,50 @@ func isWaitingForFinalization(ccOperation *banzaiv1alpha1.CruiseControlOperation
 	return ccOperation.IsCurrentTaskRunning() && !ccOperation.DeletionTimestamp.IsZero() && controllerutil.ContainsFinalizer(ccOperation, ccOperationFinalizerGroup)
 }
 
+// rebalanceParameters returns the parameters of the current rebalance task with the rebalance options of the spec applied
+func rebalanceParameters(ccOperation *banzaiv1alpha1.CruiseControlOperation) map[string]string {
+	params := make(map[string]string, len(ccOperation.CurrentTaskParameters()))
+	for param, value := range ccOperation.CurrentTaskParameters() {
+		params[param] = value
+	}
+	options := ccOperation.Spec.Rebalance
+	if options == nil {
+		return params
+	}
+	if options.ExcludedTopics != "" {
+		params[scale.ParamExcludedTopics] = options.ExcludedTopics
+	}
+	if len(options.ReplicaMovementStrategies) > 0 {
+		strategies := make([]string, 0, len(options.ReplicaMovementStrategies))
+		for _, strategy := range options.ReplicaMovementStrategies {
+			strategies = append(strategies, string(strategy))
+		}
+		params[scale.ParamReplicaMovementStrategies] = strings.Join(strategies, ",")
+	}
+	if options.ConcurrentPartitionMovementsPerBroker != nil {
+		params[scale.ParamConcurrentPartitionMovementsPerBroker] = fmt.Sprintf("%d", *options.ConcurrentPartitionMovementsPerBroker)
+	}
+	if options.ConcurrentLeaderMovements != nil {
+		params[scale.ParamConcurrentLeaderMovements] = fmt.Sprintf("%d", *options.ConcurrentLeaderMovements)
+	}
+	return params
+}
+
+func formatGoalSummaries(res *types.OptimizationResult) []banzaiv1alpha1.CruiseControlGoalSummary {
+	if res == nil || len(res.GoalSummary) == 0 {
+		return nil
+	}
+	goalSummaries := make([]banzaiv1alpha1.CruiseControlGoalSummary, 0, len(res.GoalSummary))
+	for _, goalSummary := range res.GoalSummary {
+		goalSummaries = append(goalSummaries, banzaiv1alpha1.CruiseControlGoalSummary{
+			Goal:               goalSummary.Goal.String(),
+			Status:             goalSummary.Status.String(),
+			OptimizationTimeMs: goalSummary.OptimizationTimeMs,
+		})
+	}
+	return goalSummaries
+}
+
 func formatSummary(res *types.OptimizationResult) map[string]string {
 	if res == nil {
 		return nil
@@ -21,9 +21,12 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 
 	"github.com/banzaicloud/koperator/api/v1alpha1"
 	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/scale"
+	"github.com/banzaicloud/koperator/pkg/util"
 )
 
 func createCCRetryExecutionOperation(createTime time.Time, id string, operation v1alpha1.CruiseControlTaskOperation) *v1alpha1.CruiseControlOperation {
@@ -100,6 +103,21 @@ func TestSortOperations(t *testing.T) {
 				createCCRetryExecutionOperation(timeNow, "4", v1alpha1.OperationRebalance),
 			},
 		},
+		{
+			testName: "mixed with demote broker and fix offline replicas",
+			ccOperations: []*v1alpha1.CruiseControlOperation{
+				createCCRetryExecutionOperation(timeNow, "1", v1alpha1.OperationDemoteBroker),
+				createCCRetryExecutionOperation(timeNow, "2", v1alpha1.OperationAddBroker),
+				createCCRetryExecutionOperation(timeNow.Add(time.Second), "3", v1alpha1.OperationFixOfflineReplicas),
+				createCCRetryExecutionOperation(timeNow, "4", v1alpha1.OperationRebalance),
+			},
+			expectedOutput: []*v1alpha1.CruiseControlOperation{
+				createCCRetryExecutionOperation(timeNow.Add(time.Second), "3", v1alpha1.OperationFixOfflineReplicas),
+				createCCRetryExecutionOperation(timeNow, "2", v1alpha1.OperationAddBroker),
+				createCCRetryExecutionOperation(timeNow, "1", v1alpha1.OperationDemoteBroker),
+				createCCRetryExecutionOperation(timeNow, "4", v1alpha1.OperationRebalance),
+			},
+		},
 	}
 	for _, testCase := range testCases {
 		sortedCCOperations := sortOperations(testCase.ccOperations)
@@ -107,3 +125,170 @@ func TestSortOperations(t *testing.T) {
 		assert.Equal(t, sortedRetryOutput, testCase.expectedOutput, "test", testCase.testName)
 	}
 }
+
+func TestRebalanceParameters(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		parameters     map[string]string
+		rebalance      *v1alpha1.RebalanceOptions
+		expectedParams map[string]string
+	}{
+		{
+			testName:       "parameters of the current task only",
+			parameters:     map[string]string{scale.ParamExcludedTopics: "__.*", scale.ParamRebalanceDisk: "true"},
+			expectedParams: map[string]string{scale.ParamExcludedTopics: "__.*", scale.ParamRebalanceDisk: "true"},
+		},
+		{
+			testName:   "rebalance options take precedence",
+			parameters: map[string]string{scale.ParamExcludedTopics: "__.*", scale.ParamRebalanceDisk: "true"},
+			rebalance: &v1alpha1.RebalanceOptions{
+				ExcludedTopics: "orders-.*",
+				ReplicaMovementStrategies: []v1alpha1.ReplicaMovementStrategy{
+					v1alpha1.ReplicaMovementStrategyPostponeURP, v1alpha1.ReplicaMovementStrategyPrioritizeSmall,
+				},
+				ConcurrentPartitionMovementsPerBroker: util.Int32Pointer(2),
+				ConcurrentLeaderMovements:             util.Int32Pointer(100),
+			},
+			expectedParams: map[string]string{
+				scale.ParamExcludedTopics:                        "orders-.*",
+				scale.ParamRebalanceDisk:                         "true",
+				scale.ParamReplicaMovementStrategies:             "PostponeUrpReplicaMovementStrategy,PrioritizeSmallReplicaMovementStrategy",
+				scale.ParamConcurrentPartitionMovementsPerBroker: "2",
+				scale.ParamConcurrentLeaderMovements:             "100",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			ccOperation := &v1alpha1.CruiseControlOperation{
+				Spec: v1alpha1.CruiseControlOperationSpec{Rebalance: tc.rebalance},
+				Status: v1alpha1.CruiseControlOperationStatus{
+					CurrentTask: &v1alpha1.CruiseControlTask{Operation: v1alpha1.OperationRebalance, Parameters: tc.parameters},
+				},
+			}
+			assert.Equal(t, tc.expectedParams, rebalanceParameters(ccOperation))
+			// the parameters of the current task are left intact
+			assert.NotEqual(t, "orders-.*", ccOperation.CurrentTaskParameters()[scale.ParamExcludedTopics])
+		})
+	}
+}
+
+func TestSetOperationConditions(t *testing.T) {
+	testCases := []struct {
+		testName            string
+		state               v1beta1.CruiseControlUserTaskState
+		errorPolicy         v1alpha1.ErrorPolicyType
+		expectedReady       v1.ConditionStatus
+		expectedProgressing v1.ConditionStatus
+		expectedDegraded    v1.ConditionStatus
+	}{
+		{
+			testName:            "task in execution",
+			state:               v1beta1.CruiseControlTaskInExecution,
+			errorPolicy:         v1alpha1.ErrorPolicyRetry,
+			expectedReady:       v1.ConditionFalse,
+			expectedProgressing: v1.ConditionTrue,
+			expectedDegraded:    v1.ConditionFalse,
+		},
+		{
+			testName:            "task completed",
+			state:               v1beta1.CruiseControlTaskCompleted,
+			errorPolicy:         v1alpha1.ErrorPolicyRetry,
+			expectedReady:       v1.ConditionTrue,
+			expectedProgressing: v1.ConditionFalse,
+			expectedDegraded:    v1.ConditionFalse,
+		},
+		{
+			testName:            "task failed and waiting for retry",
+			state:               v1beta1.CruiseControlTaskCompletedWithError,
+			errorPolicy:         v1alpha1.ErrorPolicyRetry,
+			expectedReady:       v1.ConditionFalse,
+			expectedProgressing: v1.ConditionTrue,
+			expectedDegraded:    v1.ConditionTrue,
+		},
+		{
+			testName:            "task failed with ignored errors",
+			state:               v1beta1.CruiseControlTaskCompletedWithError,
+			errorPolicy:         v1alpha1.ErrorPolicyIgnore,
+			expectedReady:       v1.ConditionTrue,
+			expectedProgressing: v1.ConditionFalse,
+			expectedDegraded:    v1.ConditionTrue,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			operation := createCCRetryExecutionOperation(time.Now(), "task-1", v1alpha1.OperationRebalance)
+			operation.Spec.ErrorPolicy = test.errorPolicy
+			operation.Status.CurrentTask.State = test.state
+
+			setOperationConditions(operation)
+
+			conditionStatuses := make(map[string]v1.ConditionStatus)
+			for _, condition := range operation.Status.Conditions {
+				conditionStatuses[condition.Type] = condition.Status
+			}
+			assert.Equal(t, map[string]v1.ConditionStatus{
+				v1beta1.ConditionReady:       test.expectedReady,
+				v1beta1.ConditionProgressing: test.expectedProgressing,
+				v1beta1.ConditionDegraded:    test.expectedDegraded,
+			}, conditionStatuses)
+		})
+	}
+}
+
+func TestRecordTaskCompletion(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		previousState  v1beta1.CruiseControlUserTaskState
+		state          v1beta1.CruiseControlUserTaskState
+		expectedEvents []string
+	}{
+		{
+			testName:       "task completed",
+			previousState:  v1beta1.CruiseControlTaskInExecution,
+			state:          v1beta1.CruiseControlTaskCompleted,
+			expectedEvents: []string{"Normal CruiseControlTaskCompleted the rebalance task task-1 of CruiseControlOperation op completed"},
+		},
+		{
+			testName:       "task failed",
+			previousState:  v1beta1.CruiseControlTaskInExecution,
+			state:          v1beta1.CruiseControlTaskCompletedWithError,
+			expectedEvents: []string{"Warning CruiseControlTaskFailed the rebalance task task-1 of CruiseControlOperation op failed: timeout"},
+		},
+		{
+			testName:      "task state unchanged",
+			previousState: v1beta1.CruiseControlTaskCompleted,
+			state:         v1beta1.CruiseControlTaskCompleted,
+		},
+		{
+			testName:      "task still in execution",
+			previousState: v1beta1.CruiseControlTaskActive,
+			state:         v1beta1.CruiseControlTaskInExecution,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			r := CruiseControlOperationReconciler{Recorder: recorder}
+
+			previous := createCCRetryExecutionOperation(time.Now(), "task-1", v1alpha1.OperationRebalance)
+			previous.Name = "op"
+			previous.Status.CurrentTask.State = test.previousState
+			operation := previous.DeepCopy()
+			operation.Status.CurrentTask.State = test.state
+			operation.Status.CurrentTask.ErrorMessage = "timeout"
+
+			r.recordTaskCompletion(&v1beta1.KafkaCluster{}, previous, operation)
+			close(recorder.Events)
+
+			var events []string
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+			assert.Equal(t, test.expectedEvents, events)
+		})
+	}
+}
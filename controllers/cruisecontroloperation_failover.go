@@ -0,0 +1,151 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	banzaiv1alpha1 "github.com/banzaicloud/koperator/api/v1alpha1"
+	banzaiv1beta1 "github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/scale"
+)
+
+// CruiseControlOperationFailoverCleaner implements Runnable. When the operator becomes the leader it settles the
+// CruiseControlOperations left in execution by the previous leader whose Cruise Control user tasks finished or
+// vanished in the meantime, and releases the graceful action states of the KafkaClusters referencing them, so the
+// operations are not stuck in execution after a failover.
+type CruiseControlOperationFailoverCleaner struct {
+	Client       client.Client
+	DirectClient client.Reader
+	ScaleFactory func(ctx context.Context, kafkaCluster *banzaiv1beta1.KafkaCluster) (scale.CruiseControlScaler, error)
+}
+
+// SetupCruiseControlOperationFailoverCleanerWithManager adds the cleaner to the Manager, the Manager starts it every
+// time the operator acquires the leadership
+func SetupCruiseControlOperationFailoverCleanerWithManager(mgr manager.Manager, cleaner *CruiseControlOperationFailoverCleaner) error {
+	return mgr.Add(cleaner)
+}
+
+// NeedLeaderElection implements LeaderElectionRunnable, the cleanup must only run on the leader
+func (c *CruiseControlOperationFailoverCleaner) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs the cleanup for every KafkaCluster once. Failures are only logged as the CruiseControlOperation and
+// CruiseControlTask controllers eventually settle the operations as well.
+func (c *CruiseControlOperationFailoverCleaner) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("cruisecontroloperation-failover")
+
+	clusters := &banzaiv1beta1.KafkaClusterList{}
+	if err := c.DirectClient.List(ctx, clusters); err != nil {
+		log.Error(err, "failed to list Kafka clusters, skipping the cleanup of orphaned Cruise Control operations")
+		return nil
+	}
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		if !operatorScope.includes(cluster) {
+			continue
+		}
+		if err := c.cleanup(ctx, log.WithValues("clusterName", cluster.GetName(), "clusterNamespace", cluster.GetNamespace()), cluster); err != nil {
+			log.Error(err, "failed to clean up orphaned Cruise Control operations", "clusterName", cluster.GetName(), "clusterNamespace", cluster.GetNamespace())
+		}
+	}
+	return nil
+}
+
+func (c *CruiseControlOperationFailoverCleaner) cleanup(ctx context.Context, log logr.Logger, cluster *banzaiv1beta1.KafkaCluster) error {
+	ccOperationList := banzaiv1alpha1.CruiseControlOperationList{}
+	if err := c.DirectClient.List(ctx, &ccOperationList, client.InNamespace(cluster.GetNamespace()),
+		client.MatchingLabels(apiutil.LabelsForKafka(cluster.GetName()))); err != nil {
+		return errors.WrapIf(err, "failed to list Cruise Control operations")
+	}
+	ccOperations := make([]*banzaiv1alpha1.CruiseControlOperation, 0, len(ccOperationList.Items))
+	for i := range ccOperationList.Items {
+		ccOperations = append(ccOperations, &ccOperationList.Items[i])
+	}
+
+	if err := c.settleRunningOperations(ctx, log, cluster, ccOperations); err != nil {
+		return err
+	}
+
+	// Release the graceful action states referencing operations which are finished or removed
+	tasksAndStates := getActiveTasksFromCluster(cluster)
+	if tasksAndStates.IsEmpty() {
+		return nil
+	}
+	updateActiveTasks(tasksAndStates, ccOperations)
+	taskReconciler := &CruiseControlTaskReconciler{Client: c.Client}
+	if err := taskReconciler.UpdateStatus(logr.NewContext(ctx, log), cluster, tasksAndStates); err != nil {
+		return errors.WrapIf(err, "failed to update the graceful action states of the Kafka cluster")
+	}
+	return nil
+}
+
+// settleRunningOperations updates the state of the operations in execution from the user tasks of Cruise Control,
+// the operations whose user task is unknown to Cruise Control are completed with error
+func (c *CruiseControlOperationFailoverCleaner) settleRunningOperations(ctx context.Context, log logr.Logger,
+	cluster *banzaiv1beta1.KafkaCluster, ccOperations []*banzaiv1alpha1.CruiseControlOperation) error {
+	var running []*banzaiv1alpha1.CruiseControlOperation
+	var userTaskIDs []string
+	for _, ccOperation := range ccOperations {
+		if ccOperation.IsCurrentTaskRunning() && ccOperation.CurrentTaskID() != "" {
+			running = append(running, ccOperation)
+			userTaskIDs = append(userTaskIDs, ccOperation.CurrentTaskID())
+		}
+	}
+	if len(running) == 0 {
+		return nil
+	}
+
+	scaler, err := c.ScaleFactory(ctx, cluster)
+	if err != nil {
+		return errors.WrapIf(err, "failed to create Cruise Control Scaler instance")
+	}
+	tasks, err := scaler.UserTasks(ctx, userTaskIDs...)
+	if err != nil {
+		return errors.WrapIf(err, "could not get user tasks from Cruise Control API")
+	}
+	taskResultsByID := make(map[string]*scale.Result, len(tasks))
+	for _, task := range tasks {
+		taskResultsByID[task.TaskID] = task
+	}
+
+	for _, ccOperation := range running {
+		currentStatus := ccOperation.Status.DeepCopy()
+		if err := updateResult(log, taskResultsByID[ccOperation.CurrentTaskID()], ccOperation, false); err != nil {
+			return errors.WrapIfWithDetails(err, "could not set Cruise Control user task result to CruiseControlOperation CurrentTask",
+				"name", ccOperation.GetName(), "namespace", ccOperation.GetNamespace())
+		}
+		if reflect.DeepEqual(*currentStatus, ccOperation.Status) {
+			continue
+		}
+		log.Info("settling Cruise Control operation left in execution by the previous leader", "name", ccOperation.GetName(),
+			"task ID", ccOperation.CurrentTaskID(), "state", ccOperation.CurrentTaskState())
+		if err := c.Client.Status().Update(ctx, ccOperation); err != nil {
+			return errors.WrapIfWithDetails(err, "could not update CruiseControlOperation status",
+				"name", ccOperation.GetName(), "namespace", ccOperation.GetNamespace())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/controllers/tests/mocks"
+	"github.com/banzaicloud/koperator/pkg/scale"
+)
+
+func TestCruiseControlOperationFailoverCleaner(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	runningOperation := func(name, taskID string, operation v1alpha1.CruiseControlTaskOperation) *v1alpha1.CruiseControlOperation {
+		return &v1alpha1.CruiseControlOperation{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kafka", Labels: apiutil.LabelsForKafka("kafka")},
+			Status: v1alpha1.CruiseControlOperationStatus{
+				CurrentTask: &v1alpha1.CruiseControlTask{
+					ID:        taskID,
+					Operation: operation,
+					State:     v1beta1.CruiseControlTaskInExecution,
+				},
+			},
+		}
+	}
+	cluster := &v1beta1.KafkaCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+		Status: v1beta1.KafkaClusterStatus{
+			BrokersState: map[string]v1beta1.BrokerState{
+				"0": {GracefulActionState: v1beta1.GracefulActionState{
+					CruiseControlState:              v1beta1.GracefulUpscaleRunning,
+					CruiseControlOperationReference: &corev1.LocalObjectReference{Name: "finished"},
+				}},
+				"1": {GracefulActionState: v1beta1.GracefulActionState{
+					CruiseControlState:              v1beta1.GracefulDownscaleRunning,
+					CruiseControlOperationReference: &corev1.LocalObjectReference{Name: "vanished"},
+				}},
+				"2": {GracefulActionState: v1beta1.GracefulActionState{
+					CruiseControlState:              v1beta1.GracefulDownscaleRunning,
+					CruiseControlOperationReference: &corev1.LocalObjectReference{Name: "running"},
+				}},
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&v1beta1.KafkaCluster{}, &v1alpha1.CruiseControlOperation{}).
+		WithObjects(
+			cluster,
+			runningOperation("finished", "task-finished", v1alpha1.OperationAddBroker),
+			runningOperation("vanished", "task-vanished", v1alpha1.OperationRemoveBroker),
+			runningOperation("running", "task-running", v1alpha1.OperationRemoveBroker),
+		).Build()
+
+	mockCtrl := gomock.NewController(t)
+	scaleMock := mocks.NewMockCruiseControlScaler(mockCtrl)
+	scaleMock.EXPECT().UserTasks(gomock.Any(), "task-finished", "task-running", "task-vanished").Return([]*scale.Result{
+		{TaskID: "task-finished", State: v1beta1.CruiseControlTaskCompleted},
+		{TaskID: "task-running", State: v1beta1.CruiseControlTaskInExecution},
+	}, nil)
+
+	cleaner := &CruiseControlOperationFailoverCleaner{
+		Client:       c,
+		DirectClient: c,
+		ScaleFactory: func(ctx context.Context, kafkaCluster *v1beta1.KafkaCluster) (scale.CruiseControlScaler, error) {
+			return scaleMock, nil
+		},
+	}
+	require.NoError(t, cleaner.Start(context.Background()))
+
+	for name, expectedState := range map[string]v1beta1.CruiseControlUserTaskState{
+		"finished": v1beta1.CruiseControlTaskCompleted,
+		"vanished": v1beta1.CruiseControlTaskCompletedWithError,
+		"running":  v1beta1.CruiseControlTaskInExecution,
+	} {
+		operation := &v1alpha1.CruiseControlOperation{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: name, Namespace: "kafka"}, operation))
+		require.Equal(t, expectedState, operation.CurrentTaskState(), name)
+	}
+
+	updatedCluster := &v1beta1.KafkaCluster{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(cluster), updatedCluster))
+	require.Equal(t, v1beta1.GracefulUpscaleSucceeded, updatedCluster.Status.BrokersState["0"].GracefulActionState.CruiseControlState)
+	require.Equal(t, v1beta1.GracefulDownscaleCompletedWithError, updatedCluster.Status.BrokersState["1"].GracefulActionState.CruiseControlState)
+	require.Equal(t, v1beta1.GracefulDownscaleRunning, updatedCluster.Status.BrokersState["2"].GracefulActionState.CruiseControlState)
+}
@@ -0,0 +1,151 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	banzaiv1alpha1 "github.com/banzaicloud/koperator/api/v1alpha1"
+	banzaiv1beta1 "github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/scale"
+	"github.com/banzaicloud/koperator/pkg/util/maintenance"
+)
+
+const cruiseControlTaskPreemptedEventReason = "CruiseControlTaskPreempted"
+
+var (
+	// ccOperationClusterLocks serializes the selection and the submission of the Cruise Control tasks per KafkaCluster
+	ccOperationClusterLocks = &clusterLocks{}
+
+	// preemptibleOperations are the operations whose execution is stopped for an operation of higher priority, they
+	// only move replicas to balance the cluster and can be executed again from the start
+	preemptibleOperations = map[banzaiv1alpha1.CruiseControlTaskOperation]bool{
+		banzaiv1alpha1.OperationRebalance:          true,
+		banzaiv1alpha1.OperationTopicConfiguration: true,
+	}
+
+	// maintenanceWindowOperations are the operations only started in the maintenance windows of the cluster
+	maintenanceWindowOperations = map[banzaiv1alpha1.CruiseControlTaskOperation]bool{
+		banzaiv1alpha1.OperationRebalance: true,
+	}
+)
+
+// clusterLocks holds a mutex per KafkaCluster
+type clusterLocks struct {
+	mu    sync.Mutex
+	locks map[client.ObjectKey]*sync.Mutex
+}
+
+// lock locks the mutex of the cluster and returns the function unlocking it
+func (l *clusterLocks) lock(cluster client.ObjectKey) func() {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[client.ObjectKey]*sync.Mutex)
+	}
+	lock, ok := l.locks[cluster]
+	if !ok {
+		lock = &sync.Mutex{}
+		l.locks[cluster] = lock
+	}
+	l.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+// preempts returns true if the execution of the operation in progress is stopped for the selected operation
+func preempts(selected, inProgress *banzaiv1alpha1.CruiseControlOperation) bool {
+	return selected.CurrentTaskOperation() != banzaiv1alpha1.OperationStopExecution &&
+		preemptibleOperations[inProgress.CurrentTaskOperation()] &&
+		executionPriorityMap[selected.CurrentTaskOperation()] > executionPriorityMap[inProgress.CurrentTaskOperation()]
+}
+
+// preempt stops the execution of the task in progress in Cruise Control and resets the task of its operation, which
+// is executed again once the operations of higher priority are done
+func (r *CruiseControlOperationReconciler) preempt(ctx context.Context, log logr.Logger, scaler scale.CruiseControlScaler,
+	kafkaCluster *banzaiv1beta1.KafkaCluster, inProgress, selected *banzaiv1alpha1.CruiseControlOperation) error {
+	log.Info("stopping the execution of Cruise Control task for an operation of higher priority", "name", inProgress.GetName(),
+		"operation", inProgress.CurrentTaskOperation(), "task ID", inProgress.CurrentTaskID(),
+		"preemptedBy", selected.GetName(), "preemptingOperation", selected.CurrentTaskOperation())
+	if _, err := scaler.StopExecution(ctx); err != nil {
+		return errors.WrapIf(err, "could not stop the execution of Cruise Control")
+	}
+
+	taskID := inProgress.CurrentTaskID()
+	inProgress.Status.PreemptedBy = selected.GetName()
+	inProgress.CurrentTask().SetDefaults()
+	setOperationConditions(inProgress)
+	if err := r.Status().Update(ctx, inProgress); err != nil {
+		return errors.WrapIfWithDetails(err, "could not update CruiseControlOperation status", "name", inProgress.GetName(),
+			"namespace", inProgress.GetNamespace())
+	}
+	k8sutil.RecordEvent(r.Recorder, kafkaCluster, corev1.EventTypeNormal, cruiseControlTaskPreemptedEventReason,
+		"the %s task %s of CruiseControlOperation %s has been stopped for the %s task of CruiseControlOperation %s, it is executed again afterwards",
+		inProgress.CurrentTaskOperation(), taskID, inProgress.GetName(), selected.CurrentTaskOperation(), selected.GetName())
+	return nil
+}
+
+// deferToMaintenanceWindow removes the operations only started in the maintenance windows of the cluster from the
+// execution queues outside of the windows and reports them in their Waiting condition. It returns whether operations
+// wait and the start of the next window.
+func (r *CruiseControlOperationReconciler) deferToMaintenanceWindow(ctx context.Context, log logr.Logger, kafkaCluster *banzaiv1beta1.KafkaCluster,
+	ccOperationQueueMap map[string][]*banzaiv1alpha1.CruiseControlOperation) (bool, time.Time, error) {
+	inWindow, next, err := maintenance.InWindow(kafkaCluster.Spec.MaintenanceWindows, time.Now())
+	if err != nil {
+		log.Error(err, "invalid maintenance windows, the rebalances wait until they are fixed")
+	}
+	if inWindow {
+		return false, time.Time{}, nil
+	}
+	message := "the operation waits for the next maintenance window of the cluster"
+	if !next.IsZero() {
+		message = fmt.Sprintf("%s starting at %s", message, next.UTC().Format(time.RFC3339))
+	}
+
+	waiting := false
+	for _, queue := range []string{ccOperationFirstExecution, ccOperationRetryExecution} {
+		var remaining []*banzaiv1alpha1.CruiseControlOperation
+		for _, operation := range ccOperationQueueMap[queue] {
+			if !maintenanceWindowOperations[operation.CurrentTaskOperation()] {
+				remaining = append(remaining, operation)
+				continue
+			}
+			waiting = true
+			condition := k8sutil.NewCondition(banzaiv1beta1.ConditionWaiting, true, operation.GetGeneration(), "MaintenanceWindow", message)
+			if !meta.SetStatusCondition(&operation.Status.Conditions, condition) {
+				continue
+			}
+			log.Info("Cruise Control operation waits for the next maintenance window", "name", operation.GetName(),
+				"operation", operation.CurrentTaskOperation(), "nextWindow", next)
+			if err := r.Status().Update(ctx, operation); err != nil {
+				return false, time.Time{}, errors.WrapIfWithDetails(err, "could not update CruiseControlOperation status",
+					"name", operation.GetName(), "namespace", operation.GetNamespace())
+			}
+		}
+		ccOperationQueueMap[queue] = remaining
+	}
+	return waiting, next, nil
+}
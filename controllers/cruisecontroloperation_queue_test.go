@@ -0,0 +1,290 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/controllers/tests/mocks"
+	"github.com/banzaicloud/koperator/pkg/scale"
+)
+
+func TestPreempts(t *testing.T) {
+	operation := func(operation v1alpha1.CruiseControlTaskOperation) *v1alpha1.CruiseControlOperation {
+		return &v1alpha1.CruiseControlOperation{Status: v1alpha1.CruiseControlOperationStatus{
+			CurrentTask: &v1alpha1.CruiseControlTask{Operation: operation},
+		}}
+	}
+
+	testCases := []struct {
+		testName   string
+		selected   v1alpha1.CruiseControlTaskOperation
+		inProgress v1alpha1.CruiseControlTaskOperation
+		expected   bool
+	}{
+		{
+			testName:   "remove broker during a rebalance",
+			selected:   v1alpha1.OperationRemoveBroker,
+			inProgress: v1alpha1.OperationRebalance,
+			expected:   true,
+		},
+		{
+			testName:   "fix offline replicas during a topic configuration",
+			selected:   v1alpha1.OperationFixOfflineReplicas,
+			inProgress: v1alpha1.OperationTopicConfiguration,
+			expected:   true,
+		},
+		{
+			testName:   "rebalance during a rebalance",
+			selected:   v1alpha1.OperationRebalance,
+			inProgress: v1alpha1.OperationRebalance,
+			expected:   false,
+		},
+		{
+			testName:   "demote broker during a rebalance",
+			selected:   v1alpha1.OperationDemoteBroker,
+			inProgress: v1alpha1.OperationRebalance,
+			expected:   true,
+		},
+		{
+			testName:   "demote broker during a topic configuration",
+			selected:   v1alpha1.OperationDemoteBroker,
+			inProgress: v1alpha1.OperationTopicConfiguration,
+			expected:   true,
+		},
+		{
+			testName:   "rebalance during a demote broker",
+			selected:   v1alpha1.OperationRebalance,
+			inProgress: v1alpha1.OperationDemoteBroker,
+			expected:   false,
+		},
+		{
+			testName:   "add broker during a remove broker",
+			selected:   v1alpha1.OperationAddBroker,
+			inProgress: v1alpha1.OperationRemoveBroker,
+			expected:   false,
+		},
+		{
+			testName:   "stop execution during a rebalance",
+			selected:   v1alpha1.OperationStopExecution,
+			inProgress: v1alpha1.OperationRebalance,
+			expected:   false,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			require.Equal(t, test.expected, preempts(operation(test.selected), operation(test.inProgress)))
+		})
+	}
+}
+
+func TestPreempt(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	cluster := &v1beta1.KafkaCluster{ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"}}
+	created := time.Now().Add(-time.Hour)
+	rebalance := &v1alpha1.CruiseControlOperation{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka-rebalance-abcde", Namespace: "kafka", Labels: apiutil.LabelsForKafka("kafka"),
+			CreationTimestamp: metav1.NewTime(created)},
+		Status: v1alpha1.CruiseControlOperationStatus{
+			RetryCount: 1,
+			CurrentTask: &v1alpha1.CruiseControlTask{
+				ID:         "task-rebalance",
+				Operation:  v1alpha1.OperationRebalance,
+				Parameters: map[string]string{scale.ParamRebalanceDisk: "true"},
+				State:      v1beta1.CruiseControlTaskInExecution,
+				Started:    &metav1.Time{Time: created},
+			},
+		},
+	}
+	removeBroker := &v1alpha1.CruiseControlOperation{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka-removebroker-fghij", Namespace: "kafka", Labels: apiutil.LabelsForKafka("kafka"),
+			CreationTimestamp: metav1.NewTime(created.Add(time.Minute))},
+		Status: v1alpha1.CruiseControlOperationStatus{
+			CurrentTask: &v1alpha1.CruiseControlTask{Operation: v1alpha1.OperationRemoveBroker},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&v1alpha1.CruiseControlOperation{}).
+		WithObjects(cluster, rebalance, removeBroker).Build()
+
+	mockCtrl := gomock.NewController(t)
+	scaleMock := mocks.NewMockCruiseControlScaler(mockCtrl)
+	scaleMock.EXPECT().StopExecution(gomock.Any()).Return(&scale.Result{State: v1beta1.CruiseControlTaskCompleted}, nil)
+	recorder := record.NewFakeRecorder(1)
+	r := CruiseControlOperationReconciler{Client: c, Recorder: recorder}
+
+	queues := sortOperations([]*v1alpha1.CruiseControlOperation{rebalance, removeBroker})
+	inProgress := getFirstOperation(queues, ccOperationInProgress)
+	selected := getFirstOperation(queues, ccOperationFirstExecution)
+	require.True(t, preempts(selected, inProgress))
+	require.NoError(t, r.preempt(context.Background(), logr.Discard(), scaleMock, cluster, inProgress, selected))
+	require.Contains(t, <-recorder.Events, cruiseControlTaskPreemptedEventReason)
+
+	preempted := &v1alpha1.CruiseControlOperation{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(rebalance), preempted))
+	require.True(t, preempted.IsPreempted())
+	require.Equal(t, "kafka-removebroker-fghij", preempted.Status.PreemptedBy)
+	require.Equal(t, v1alpha1.OperationRebalance, preempted.CurrentTaskOperation())
+	require.Equal(t, map[string]string{scale.ParamRebalanceDisk: "true"}, preempted.CurrentTaskParameters())
+	require.False(t, preempted.IsDone())
+
+	// the preempted rebalance waits for its execution behind the remove broker operation of higher priority
+	queues = sortOperations([]*v1alpha1.CruiseControlOperation{preempted, removeBroker})
+	require.Empty(t, queues[ccOperationInProgress])
+	require.Equal(t, []*v1alpha1.CruiseControlOperation{removeBroker, preempted}, queues[ccOperationFirstExecution])
+
+	// the preemption is cleared once the task is executed again
+	require.NoError(t, updateResult(logr.Discard(), &scale.Result{TaskID: "task-rebalance-2", State: v1beta1.CruiseControlTaskActive,
+		StartedAt: time.Now().Format(time.RFC1123)}, preempted, true))
+	require.False(t, preempted.IsPreempted())
+	require.Empty(t, preempted.Status.PreemptedBy)
+}
+
+func TestDeferToMaintenanceWindow(t *testing.T) {
+	testCases := []struct {
+		testName        string
+		windows         []v1beta1.MaintenanceWindow
+		expectedWaiting bool
+	}{
+		{
+			testName: "no maintenance window",
+		},
+		{
+			testName: "in a maintenance window",
+			windows:  []v1beta1.MaintenanceWindow{{Schedule: "* * * * *", Duration: metav1.Duration{Duration: time.Hour}}},
+		},
+		{
+			testName:        "the rebalance waits outside of the maintenance windows",
+			windows:         []v1beta1.MaintenanceWindow{{Schedule: "0 0 30 2 *", Duration: metav1.Duration{Duration: time.Hour}}},
+			expectedWaiting: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, v1alpha1.AddToScheme(scheme))
+			require.NoError(t, v1beta1.AddToScheme(scheme))
+
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Spec:       v1beta1.KafkaClusterSpec{MaintenanceWindows: test.windows},
+			}
+			operation := func(name string, operation v1alpha1.CruiseControlTaskOperation) *v1alpha1.CruiseControlOperation {
+				return &v1alpha1.CruiseControlOperation{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kafka", Labels: apiutil.LabelsForKafka("kafka")},
+					Status: v1alpha1.CruiseControlOperationStatus{
+						CurrentTask: &v1alpha1.CruiseControlTask{Operation: operation},
+					},
+				}
+			}
+			rebalance := operation("kafka-rebalance-abcde", v1alpha1.OperationRebalance)
+			addBroker := operation("kafka-addbroker-fghij", v1alpha1.OperationAddBroker)
+			c := fake.NewClientBuilder().WithScheme(scheme).
+				WithStatusSubresource(&v1alpha1.CruiseControlOperation{}).
+				WithObjects(cluster, rebalance, addBroker).Build()
+			r := CruiseControlOperationReconciler{Client: c}
+
+			queues := sortOperations([]*v1alpha1.CruiseControlOperation{rebalance, addBroker})
+			waiting, next, err := r.deferToMaintenanceWindow(context.Background(), logr.Discard(), cluster, queues)
+			require.NoError(t, err)
+			require.Equal(t, test.expectedWaiting, waiting)
+			require.True(t, next.IsZero())
+
+			stored := &v1alpha1.CruiseControlOperation{}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(rebalance), stored))
+			if !test.expectedWaiting {
+				require.Equal(t, []*v1alpha1.CruiseControlOperation{addBroker, rebalance}, queues[ccOperationFirstExecution])
+				require.Empty(t, stored.Status.Conditions)
+				return
+			}
+			// the operations not restricted to the maintenance windows are executed
+			require.Equal(t, []*v1alpha1.CruiseControlOperation{addBroker}, queues[ccOperationFirstExecution])
+			condition := meta.FindStatusCondition(stored.Status.Conditions, v1beta1.ConditionWaiting)
+			require.NotNil(t, condition)
+			require.Equal(t, metav1.ConditionTrue, condition.Status)
+			require.Equal(t, "MaintenanceWindow", condition.Reason)
+
+			// the operation no longer waits once it is executed
+			require.NoError(t, updateResult(logr.Discard(), &scale.Result{TaskID: "task-rebalance", State: v1beta1.CruiseControlTaskActive,
+				StartedAt: time.Now().Format(time.RFC1123)}, stored, true))
+			require.True(t, meta.IsStatusConditionFalse(stored.Status.Conditions, v1beta1.ConditionWaiting))
+		})
+	}
+}
+
+func TestClusterLocks(t *testing.T) {
+	locks := &clusterLocks{}
+	kafka := client.ObjectKey{Name: "kafka", Namespace: "kafka"}
+	logs := client.ObjectKey{Name: "logs", Namespace: "kafka"}
+
+	var mu sync.Mutex
+	inSection, maxInSection := 0, 0
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := locks.lock(kafka)
+			defer unlock()
+			mu.Lock()
+			inSection++
+			maxInSection = max(maxInSection, inSection)
+			mu.Unlock()
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			inSection--
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 1, maxInSection)
+
+	// the lock of another cluster is independent
+	unlock := locks.lock(kafka)
+	defer unlock()
+	locked := make(chan struct{})
+	go func() {
+		unlockLogs := locks.lock(logs)
+		unlockLogs()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("the lock of another cluster is held")
+	}
+}
@@ -98,6 +98,7 @@ func SetupCruiseControlOperationTTLWithManager(mgr ctrl.Manager) *ctrl.Builder {
 	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&banzaiv1alpha1.CruiseControlOperation{}).
 		WithEventFilter(SkipClusterRegistryOwnedResourcePredicate{}).
+		WithEventFilter(newOperatorScopePredicate(mgr)).
 		WithEventFilter(cruiseControlOperationTTLPredicate).
 		Named("CruiseControlOperationTTL")
 
@@ -30,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -40,6 +41,7 @@ import (
 	apiutil "github.com/banzaicloud/koperator/api/util"
 	banzaiv1alpha1 "github.com/banzaicloud/koperator/api/v1alpha1"
 	banzaiv1beta1 "github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
 	koperatorccconf "github.com/banzaicloud/koperator/pkg/resources/cruisecontrol"
 	"github.com/banzaicloud/koperator/pkg/scale"
 	"github.com/banzaicloud/koperator/pkg/util"
@@ -50,6 +52,8 @@ const (
 	BrokerCapacityDisk           = "DISK"
 	BrokerCapacity               = "capacity"
 	True                         = "true"
+
+	cruiseControlOperationCreatedEventReason = "CruiseControlOperationCreated"
 )
 
 // CruiseControlTaskReconciler reconciles a kafka cluster object
@@ -60,9 +64,12 @@ type CruiseControlTaskReconciler struct {
 	DirectClient client.Reader
 	Scheme       *runtime.Scheme
 	ScaleFactory func(ctx context.Context, kafkaCluster *banzaiv1beta1.KafkaCluster) (scale.CruiseControlScaler, error)
+	// Recorder emits the events of the CruiseControlOperations created for the KafkaClusters
+	Recorder record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkaclusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 //nolint:funlen,gocyclo
 func (r *CruiseControlTaskReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
@@ -81,6 +88,9 @@ func (r *CruiseControlTaskReconciler) Reconcile(ctx context.Context, request ctr
 		return requeueWithError(log, err.Error(), err)
 	}
 
+	// Status updates queued for coalescing are not yet visible through the API
+	k8sutil.ApplyPendingStatus(instance)
+
 	log.Info("reconciling Cruise Control tasks")
 
 	// Get all active tasks reported in status of Kafka Cluster CR
@@ -111,6 +121,8 @@ func (r *CruiseControlTaskReconciler) Reconcile(ctx context.Context, request ctr
 	// Update task states with information from Cruise Control
 	updateActiveTasks(tasksAndStates, ccOperations)
 
+	// the task states are graceful action states, they are written directly as a queued write replayed later could
+	// overwrite the newer states written by the KafkaCluster reconciler
 	if err = r.UpdateStatus(ctx, instance, tasksAndStates); err != nil {
 		return requeueWithError(log, "failed to update Kafka Cluster status", err)
 	}
@@ -427,6 +439,10 @@ func (r *CruiseControlTaskReconciler) createCCOperation(
 		operation.Status.CurrentTask.Parameters[scale.ParamDestbrokerIDs] = strings.Join(brokerIDs, ",")
 		if isJBOD {
 			operation.Status.CurrentTask.Parameters[scale.ParamRebalanceDisk] = True
+			// the disk rebalance must not move the replicas hinted to a disk class between the disks of the brokers
+			if pattern := kafkaCluster.Spec.GetDiskPlacementTopicsPattern(); pattern != "" {
+				operation.Status.CurrentTask.Parameters[scale.ParamExcludedTopics] = pattern
+			}
 		}
 	case banzaiv1alpha1.OperationRemoveDisks:
 		pairs := make([]string, 0, len(logDirsByBrokerID))
@@ -452,6 +468,8 @@ func (r *CruiseControlTaskReconciler) createCCOperation(
 	if err := r.Status().Update(ctx, operation); err != nil {
 		return corev1.LocalObjectReference{}, err
 	}
+	k8sutil.RecordEvent(r.Recorder, kafkaCluster, corev1.EventTypeNormal, cruiseControlOperationCreatedEventReason,
+		"CruiseControlOperation %s has been created to execute the %s task", operation.Name, operationType)
 	return corev1.LocalObjectReference{
 		Name: operation.Name,
 	}, nil
@@ -593,6 +611,7 @@ func SetupCruiseControlWithManager(mgr ctrl.Manager) *ctrl.Builder {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&banzaiv1beta1.KafkaCluster{}).
 		WithEventFilter(SkipClusterRegistryOwnedResourcePredicate{}).
+		WithEventFilter(newOperatorScopePredicate(mgr)).
 		WithEventFilter(kafkaClusterPredicate).
 		Owns(&banzaiv1alpha1.CruiseControlOperation{}, builder.WithPredicates(cruiseControlOperationPredicate)).
 		Named("CruiseControlTask")
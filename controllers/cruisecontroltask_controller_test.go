@@ -319,6 +319,7 @@ func TestCreateCCOperation(t *testing.T) {
 		brokerIDs          []string
 		isJBOD             bool
 		brokerIdsToLogDirs map[string][]string
+		diskPlacementHints []v1beta1.DiskPlacementHint
 		parameterCheck     func(t *testing.T, params map[string]string)
 	}{
 		{
@@ -368,6 +369,20 @@ func TestCreateCCOperation(t *testing.T) {
 				assert.Equal(t, "true", params[scale.ParamRebalanceDisk])
 				assert.Equal(t, "true", params[scale.ParamExcludeDemoted])
 				assert.Equal(t, "true", params[scale.ParamExcludeRemoved])
+				assert.NotContains(t, params, scale.ParamExcludedTopics)
+			},
+		},
+		{
+			operationType: banzaiv1alpha1.OperationRebalance,
+			brokerIDs:     []string{"1", "2", "3"},
+			isJBOD:        true,
+			diskPlacementHints: []v1beta1.DiskPlacementHint{
+				{TopicPattern: "orders-.*", DiskClass: "nvme"},
+				{TopicPattern: "archive", DiskClass: "hdd"},
+			},
+			parameterCheck: func(t *testing.T, params map[string]string) {
+				assert.Equal(t, "true", params[scale.ParamRebalanceDisk])
+				assert.Equal(t, "^(?:(?:orders-.*)|(?:archive))$", params[scale.ParamExcludedTopics])
 			},
 		},
 	}
@@ -389,7 +404,11 @@ func TestCreateCCOperation(t *testing.T) {
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "kafka",
 				Namespace: "kafka",
-			}}
+			},
+			Spec: v1beta1.KafkaClusterSpec{
+				DiskPlacementHints: testCase.diskPlacementHints,
+			},
+		}
 
 		// Mock the Create call and capture the operation
 		var createdOperation *banzaiv1alpha1.CruiseControlOperation
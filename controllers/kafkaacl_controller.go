@@ -0,0 +1,242 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/kafkaclient"
+	"github.com/banzaicloud/koperator/pkg/util"
+	"github.com/banzaicloud/koperator/pkg/util/ownership"
+)
+
+var aclFinalizer = "finalizer.kafkaacls.kafka.banzaicloud.io"
+
+// SetupKafkaACLWithManager registers KafkaACL controller to the manager
+func SetupKafkaACLWithManager(mgr ctrl.Manager) *ctrl.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.KafkaACL{}).
+		WithEventFilter(SkipClusterRegistryOwnedResourcePredicate{}).
+		WithEventFilter(newOperatorScopePredicate(mgr)).
+		Named("KafkaACL")
+}
+
+// blank assignment to verify that KafkaACLReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &KafkaACLReconciler{}
+
+// KafkaACLReconciler reconciles a KafkaACL object
+type KafkaACLReconciler struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	Client client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkaacls,verbs=get;list;watch;create;update;patch;delete;deletecollection
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkaacls/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkaacls/finalizers,verbs=create;update;patch;delete
+
+// Reconcile ensures the ACL bindings of the KafkaACL exist on the referenced Kafka cluster, removes the bindings
+// dropped from the spec and, when requested, every other binding of the principal
+func (r *KafkaACLReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := logr.FromContextOrDiscard(ctx)
+	reqLogger.Info("Reconciling KafkaACL")
+	var err error
+
+	// Fetch the KafkaACL instance
+	instance := &v1alpha1.KafkaACL{}
+	if err = r.Client.Get(ctx, request.NamespacedName, instance); err != nil {
+		if apierrors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			return reconciled()
+		}
+		// Error reading the object - requeue the request.
+		return requeueWithError(reqLogger, err.Error(), err)
+	}
+
+	// Get the referenced kafkacluster
+	clusterNamespace := getClusterRefNamespace(instance.Namespace, instance.Spec.ClusterRef)
+	var cluster *v1beta1.KafkaCluster
+	if cluster, err = k8sutil.LookupKafkaCluster(ctx, r.Client, instance.Spec.ClusterRef.Name, clusterNamespace); err != nil {
+		if k8sutil.IsMarkedForDeletion(instance.ObjectMeta) {
+			reqLogger.Info("Cluster is gone already, there is nothing we can do")
+			if err = r.removeFinalizer(ctx, instance); err != nil {
+				return requeueWithError(reqLogger, "failed to remove finalizer from kafkaacl", err)
+			}
+			return reconciled()
+		}
+		return requeueWithError(reqLogger, "failed to lookup referenced cluster", err)
+	}
+
+	desired, err := kafkaclient.ACLBindingsForSpec(instance.Spec)
+	if err != nil {
+		return requeueWithError(reqLogger, "failed to convert kafkaacl rules to ACL bindings", err)
+	}
+
+	// check if marked for deletion and remove kafka ACLs
+	if k8sutil.IsMarkedForDeletion(instance.ObjectMeta) {
+		return r.checkFinalizers(ctx, cluster, instance, desired)
+	}
+
+	// Only the namespaces of the team owning the topic prefixes may manage ACLs of the topics
+	if err := ownership.CheckKafkaACL(ctx, r.Client, instance); err != nil {
+		return requeueWithError(reqLogger, "kafkaacl is not owned by the team of its namespace", err)
+	}
+
+	// ensure a kafkaCluster label
+	labels := applyClusterRefLabel(cluster, instance.GetLabels())
+	if !reflect.DeepEqual(labels, instance.GetLabels()) {
+		instance.SetLabels(labels)
+		if err = r.Client.Update(ctx, instance); err != nil {
+			return requeueWithError(reqLogger, "failed to ensure kafkacluster label on kafkaacl", err)
+		}
+	}
+
+	broker, close, err := newKafkaFromCluster(r.Client, cluster)
+	if err != nil {
+		return checkBrokerConnectionError(reqLogger, err)
+	}
+	defer close()
+
+	current, err := broker.ListACLBindings(instance.Spec.Principal)
+	if err != nil {
+		return requeueWithError(reqLogger, "failed to list ACLs of the kafkaacl principal", err)
+	}
+
+	toCreate, toDelete := diffACLBindings(desired, current, instance.Status.ACLs, instance.Spec.PruneUnmanaged)
+	if len(toCreate) > 0 {
+		reqLogger.Info("Creating ACLs", "principal", instance.Spec.Principal, "acls", aclBindingStrings(toCreate))
+		if err = broker.CreateACLBindings(toCreate); err != nil {
+			return requeueWithError(reqLogger, "failed to create ACLs for kafkaacl", err)
+		}
+	}
+	if len(toDelete) > 0 {
+		reqLogger.Info("Deleting ACLs", "principal", instance.Spec.Principal, "acls", aclBindingStrings(toDelete))
+		if err = broker.DeleteACLBindings(toDelete); err != nil {
+			return requeueWithError(reqLogger, "failed to delete ACLs for kafkaacl", err)
+		}
+	}
+
+	// ensure a finalizer for cleanup on deletion
+	if !apiutil.StringSliceContains(instance.GetFinalizers(), aclFinalizer) {
+		reqLogger.Info("Adding Finalizer for the KafkaACL")
+		instance.SetFinalizers(append(instance.GetFinalizers(), aclFinalizer))
+		if err = r.Client.Update(ctx, instance); err != nil {
+			return requeueWithError(reqLogger, "failed to update kafkaacl with finalizer", err)
+		}
+	}
+
+	// set ACL status
+	status := v1alpha1.KafkaACLStatus{
+		State: v1alpha1.ACLStateCreated,
+		ACLs:  aclBindingStrings(desired),
+	}
+	if !reflect.DeepEqual(status, instance.Status) {
+		instance.Status = status
+		if err = r.Client.Status().Update(ctx, instance); err != nil {
+			return requeueWithError(reqLogger, "failed to update kafkaacl status", err)
+		}
+	}
+
+	return reconciled()
+}
+
+func (r *KafkaACLReconciler) checkFinalizers(ctx context.Context, cluster *v1beta1.KafkaCluster, instance *v1alpha1.KafkaACL, desired []kafkaclient.ACLBinding) (reconcile.Result, error) {
+	reqLogger := logr.FromContextOrDiscard(ctx)
+	if !apiutil.StringSliceContains(instance.GetFinalizers(), aclFinalizer) {
+		return reconciled()
+	}
+	if k8sutil.IsMarkedForDeletion(cluster.ObjectMeta) {
+		reqLogger.Info("Cluster is being deleted, skipping ACL deletion")
+	} else {
+		broker, close, err := newKafkaFromCluster(r.Client, cluster)
+		if err != nil {
+			return checkBrokerConnectionError(reqLogger, err)
+		}
+		defer close()
+		current, err := broker.ListACLBindings(instance.Spec.Principal)
+		if err != nil {
+			return requeueWithError(reqLogger, "failed to list ACLs of the kafkaacl principal", err)
+		}
+		// remove everything the resource manages: the desired bindings and the ones applied previously
+		_, toDelete := diffACLBindings(nil, current, append(instance.Status.ACLs, aclBindingStrings(desired)...), false)
+		reqLogger.Info("Deleting ACLs", "principal", instance.Spec.Principal, "acls", aclBindingStrings(toDelete))
+		if err = broker.DeleteACLBindings(toDelete); err != nil {
+			return requeueWithError(reqLogger, "failed to finalize kafkaacl", err)
+		}
+	}
+	if err := r.removeFinalizer(ctx, instance); err != nil {
+		return requeueWithError(reqLogger, "failed to remove finalizer from kafkaacl", err)
+	}
+	return reconciled()
+}
+
+func (r *KafkaACLReconciler) removeFinalizer(ctx context.Context, acl *v1alpha1.KafkaACL) error {
+	acl.SetFinalizers(util.StringSliceRemove(acl.GetFinalizers(), aclFinalizer))
+	return r.Client.Update(ctx, acl)
+}
+
+// diffACLBindings returns the desired bindings missing from the cluster and the current bindings to be removed:
+// the ones previously applied but no longer desired and, when pruning, every binding which is not desired
+func diffACLBindings(desired, current []kafkaclient.ACLBinding, applied []string, prune bool) ([]kafkaclient.ACLBinding, []kafkaclient.ACLBinding) {
+	desiredSet := make(map[string]struct{}, len(desired))
+	for _, binding := range desired {
+		desiredSet[binding.String()] = struct{}{}
+	}
+	currentSet := make(map[string]struct{}, len(current))
+	for _, binding := range current {
+		currentSet[binding.String()] = struct{}{}
+	}
+
+	toCreate := make([]kafkaclient.ACLBinding, 0)
+	for _, binding := range desired {
+		if _, ok := currentSet[binding.String()]; !ok {
+			toCreate = append(toCreate, binding)
+		}
+	}
+
+	toDelete := make([]kafkaclient.ACLBinding, 0)
+	for _, binding := range current {
+		key := binding.String()
+		if _, ok := desiredSet[key]; ok {
+			continue
+		}
+		if prune || apiutil.StringSliceContains(applied, key) {
+			toDelete = append(toDelete, binding)
+		}
+	}
+	return toCreate, toDelete
+}
+
+func aclBindingStrings(bindings []kafkaclient.ACLBinding) []string {
+	acls := make([]string, 0, len(bindings))
+	for _, binding := range bindings {
+		acls = append(acls, binding.String())
+	}
+	return acls
+}
@@ -0,0 +1,101 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/pkg/kafkaclient"
+)
+
+func TestDiffACLBindings(t *testing.T) {
+	t.Parallel()
+
+	bindings := func(rules ...v1alpha1.ACLRule) []kafkaclient.ACLBinding {
+		result, err := kafkaclient.ACLBindingsForSpec(v1alpha1.KafkaACLSpec{Principal: "User:alice", ACLs: rules})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+	readOrders := v1alpha1.ACLRule{ResourceType: "topic", ResourceName: "orders", Operation: "read"}
+	writeOrders := v1alpha1.ACLRule{ResourceType: "topic", ResourceName: "orders", Operation: "write"}
+	readGroups := v1alpha1.ACLRule{ResourceType: "group", ResourceName: "*", Operation: "read"}
+
+	testCases := []struct {
+		testName         string
+		desired          []kafkaclient.ACLBinding
+		current          []kafkaclient.ACLBinding
+		applied          []string
+		prune            bool
+		expectedToCreate []string
+		expectedToDelete []string
+	}{
+		{
+			testName:         "nothing applied yet",
+			desired:          bindings(readOrders, readGroups),
+			current:          nil,
+			expectedToCreate: aclBindingStrings(bindings(readOrders, readGroups)),
+			expectedToDelete: []string{},
+		},
+		{
+			testName:         "in sync",
+			desired:          bindings(readOrders),
+			current:          bindings(readOrders),
+			applied:          aclBindingStrings(bindings(readOrders)),
+			expectedToCreate: []string{},
+			expectedToDelete: []string{},
+		},
+		{
+			testName:         "binding removed from spec is deleted",
+			desired:          bindings(readOrders),
+			current:          bindings(readOrders, writeOrders),
+			applied:          aclBindingStrings(bindings(readOrders, writeOrders)),
+			expectedToCreate: []string{},
+			expectedToDelete: aclBindingStrings(bindings(writeOrders)),
+		},
+		{
+			testName:         "unmanaged binding is kept without pruning",
+			desired:          bindings(readOrders),
+			current:          bindings(readOrders, readGroups),
+			applied:          aclBindingStrings(bindings(readOrders)),
+			expectedToCreate: []string{},
+			expectedToDelete: []string{},
+		},
+		{
+			testName:         "unmanaged binding is pruned",
+			desired:          bindings(readOrders, writeOrders),
+			current:          bindings(readOrders, readGroups),
+			applied:          aclBindingStrings(bindings(readOrders)),
+			prune:            true,
+			expectedToCreate: aclBindingStrings(bindings(writeOrders)),
+			expectedToDelete: aclBindingStrings(bindings(readGroups)),
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.testName, func(t *testing.T) {
+			t.Parallel()
+			toCreate, toDelete := diffACLBindings(test.desired, test.current, test.applied, test.prune)
+			assert.Equal(t, test.expectedToCreate, aclBindingStrings(toCreate))
+			assert.Equal(t, test.expectedToDelete, aclBindingStrings(toDelete))
+		})
+	}
+}
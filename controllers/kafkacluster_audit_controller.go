@@ -0,0 +1,131 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/util/audit"
+)
+
+const (
+	// conformanceAuditEventReason is the reason of the events reporting the outcome of the conformance audit
+	conformanceAuditEventReason = "ConformanceAudit"
+)
+
+// SetupKafkaClusterAuditWithManager registers the conformance audit controller to the manager
+func SetupKafkaClusterAuditWithManager(mgr ctrl.Manager) *ctrl.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.KafkaCluster{}).
+		WithEventFilter(SkipClusterRegistryOwnedResourcePredicate{}).
+		WithEventFilter(newOperatorScopePredicate(mgr)).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Named("KafkaClusterAudit")
+}
+
+// blank assignment to verify that KafkaClusterAuditReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &KafkaClusterAuditReconciler{}
+
+// KafkaClusterAuditReconciler periodically audits the KafkaClusters against the built-in best-practice ruleset,
+// e.g. the replication factor against the number of brokers or the encryption of the listeners. The findings and the
+// score of each cluster are reported in its status and in events, the cluster itself is never changed.
+type KafkaClusterAuditReconciler struct {
+	Client   client.Client
+	Recorder record.EventRecorder
+	// Interval is the time between the audits of a cluster
+	Interval time.Duration
+}
+
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkatopics,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkaclusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile audits the cluster and records the outcome when it changed
+func (r *KafkaClusterAuditReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	cluster := &v1beta1.KafkaCluster{}
+	if err := r.Client.Get(ctx, request.NamespacedName, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconciled()
+		}
+		return requeueWithError(log, err.Error(), err)
+	}
+	if !cluster.DeletionTimestamp.IsZero() {
+		return reconciled()
+	}
+
+	topics := &v1alpha1.KafkaTopicList{}
+	if err := r.Client.List(ctx, topics); err != nil {
+		return requeueWithError(log, "failed to list KafkaTopics", err)
+	}
+	var clusterTopics []v1alpha1.KafkaTopic
+	for _, topic := range topics.Items {
+		if topic.Spec.ClusterRef.Name == cluster.Name && getClusterRefNamespace(topic.Namespace, topic.Spec.ClusterRef) == cluster.Namespace {
+			clusterTopics = append(clusterTopics, topic)
+		}
+	}
+
+	score, findings, err := audit.Evaluate(cluster, clusterTopics)
+	if err != nil {
+		return requeueWithError(log, "failed to audit the cluster", err)
+	}
+
+	previous := cluster.Status.ConformanceAudit
+	if previous == nil || previous.Score != score || !reflect.DeepEqual(previous.Findings, findings) {
+		r.recordConformanceAudit(cluster, score, findings)
+	}
+	status := &v1beta1.ConformanceAuditStatus{Score: score, Findings: findings, LastAuditTime: metav1.Now()}
+	if err := k8sutil.UpdateCRStatus(r.Client, cluster, status, log); err != nil {
+		return requeueWithError(log, "failed to update the conformance audit status", err)
+	}
+
+	return ctrl.Result{RequeueAfter: r.Interval}, nil
+}
+
+// recordConformanceAudit emits an event with the score of the cluster and one for each finding
+func (r *KafkaClusterAuditReconciler) recordConformanceAudit(cluster *v1beta1.KafkaCluster, score int32, findings []v1beta1.ConformanceAuditFinding) {
+	if r.Recorder == nil {
+		return
+	}
+	if len(findings) == 0 {
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, conformanceAuditEventReason,
+			"the cluster conforms to all the best-practice rules")
+		return
+	}
+	r.Recorder.Event(cluster, corev1.EventTypeWarning, conformanceAuditEventReason,
+		fmt.Sprintf("the cluster scored %d%% in the best-practice audit with %d findings", score, len(findings)))
+	for _, finding := range findings {
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, conformanceAuditEventReason,
+			fmt.Sprintf("%s (%s): %s", finding.Rule, finding.Severity, finding.Message))
+	}
+}
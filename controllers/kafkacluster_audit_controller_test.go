@@ -0,0 +1,97 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/util/audit"
+)
+
+func TestKafkaClusterAuditReconcile(t *testing.T) {
+	cluster := &v1beta1.KafkaCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+		Spec: v1beta1.KafkaClusterSpec{
+			ReadOnlyConfig:     "default.replication.factor=3\nmin.insync.replicas=2",
+			BrokerConfigGroups: map[string]v1beta1.BrokerConfig{"default": {}},
+			Brokers: []v1beta1.Broker{
+				{Id: 0, BrokerConfigGroup: "default"},
+				{Id: 1, BrokerConfigGroup: "default"},
+				{Id: 2, BrokerConfigGroup: "default"},
+			},
+			RackAwareness:    &v1beta1.RackAwareness{Labels: []string{"topology.kubernetes.io/zone"}},
+			DisruptionBudget: v1beta1.DisruptionBudget{Create: true, Budget: "1"},
+			ListenersConfig: v1beta1.ListenersConfig{
+				InternalListeners: []v1beta1.InternalListenerConfig{
+					{CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolSSL}},
+				},
+			},
+		},
+	}
+	topics := []runtime.Object{
+		&v1alpha1.KafkaTopic{
+			ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "kafka"},
+			Spec:       v1alpha1.KafkaTopicSpec{Name: "orders", ReplicationFactor: 1, ClusterRef: v1alpha1.ClusterReference{Name: "kafka"}},
+		},
+		// topics of other clusters are not audited
+		&v1alpha1.KafkaTopic{
+			ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "kafka"},
+			Spec:       v1alpha1.KafkaTopicSpec{Name: "payments", ReplicationFactor: 0, ClusterRef: v1alpha1.ClusterReference{Name: "other"}},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster).WithRuntimeObjects(topics...).
+		WithStatusSubresource(cluster).Build()
+	recorder := record.NewFakeRecorder(10)
+
+	r := &KafkaClusterAuditReconciler{Client: c, Recorder: recorder, Interval: time.Hour}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}}
+	result, err := r.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, result.RequeueAfter)
+
+	updated := &v1beta1.KafkaCluster{}
+	require.NoError(t, c.Get(context.Background(), request.NamespacedName, updated))
+	require.NotNil(t, updated.Status.ConformanceAudit)
+	require.Len(t, updated.Status.ConformanceAudit.Findings, 2)
+	require.Equal(t, audit.RuleReplicationFactor, updated.Status.ConformanceAudit.Findings[0].Rule)
+	require.Contains(t, updated.Status.ConformanceAudit.Findings[0].Message, "kafka/orders")
+	require.Equal(t, audit.RuleResources, updated.Status.ConformanceAudit.Findings[1].Rule)
+	require.Len(t, recorder.Events, 3)
+
+	// unchanged findings are not reported again
+	for len(recorder.Events) > 0 {
+		<-recorder.Events
+	}
+	_, err = r.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+	require.Empty(t, recorder.Events)
+}
@@ -18,7 +18,10 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"path"
 	"reflect"
+	"slices"
+	"strings"
 	"time"
 
 	"emperror.dev/errors"
@@ -27,10 +30,14 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlBuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	apiutil "github.com/banzaicloud/koperator/api/util"
@@ -44,15 +51,27 @@ import (
 	"github.com/banzaicloud/koperator/pkg/kafkaclient"
 	"github.com/banzaicloud/koperator/pkg/pki"
 	"github.com/banzaicloud/koperator/pkg/resources"
+	"github.com/banzaicloud/koperator/pkg/resources/cabundle"
 	"github.com/banzaicloud/koperator/pkg/resources/contouringress"
 	"github.com/banzaicloud/koperator/pkg/resources/cruisecontrol"
 	"github.com/banzaicloud/koperator/pkg/resources/cruisecontrolmonitoring"
+	"github.com/banzaicloud/koperator/pkg/resources/diskplacement"
 	"github.com/banzaicloud/koperator/pkg/resources/envoy"
+	"github.com/banzaicloud/koperator/pkg/resources/gatewayapi"
 	"github.com/banzaicloud/koperator/pkg/resources/istioingress"
 	"github.com/banzaicloud/koperator/pkg/resources/kafka"
 	"github.com/banzaicloud/koperator/pkg/resources/kafkamonitoring"
+	"github.com/banzaicloud/koperator/pkg/resources/leaderbalance"
+	"github.com/banzaicloud/koperator/pkg/resources/nginxingress"
 	"github.com/banzaicloud/koperator/pkg/resources/nodeportexternalaccess"
+	"github.com/banzaicloud/koperator/pkg/resources/perbrokerloadbalancer"
 	"github.com/banzaicloud/koperator/pkg/util"
+	kafkautil "github.com/banzaicloud/koperator/pkg/util/kafka"
+	"github.com/banzaicloud/koperator/pkg/util/maintenance"
+	"github.com/banzaicloud/koperator/pkg/util/metrics"
+	pkicommon "github.com/banzaicloud/koperator/pkg/util/pki"
+	"github.com/banzaicloud/koperator/pkg/util/restarthooks"
+	"github.com/banzaicloud/koperator/pkg/util/tracing"
 
 	contour "github.com/projectcontour/contour/apis/projectcontour/v1"
 )
@@ -67,6 +86,9 @@ type KafkaClusterReconciler struct {
 	DirectClient        client.Reader
 	Namespaces          []string
 	KafkaClientProvider kafkaclient.Provider
+	// Recorder emits the events of the KafkaClusters, e.g. the artifacts retained by their teardown or the brokers
+	// added and restarted
+	Recorder record.EventRecorder
 }
 
 // Reconcile reads that state of the cluster for a KafkaCluster object and makes changes based on the state read
@@ -75,12 +97,17 @@ type KafkaClusterReconciler struct {
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=persistentvolumes,verbs=get
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;create;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;delete;patch
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=limitranges,verbs=get;list;watch
 // +kubebuilder:rbac:groups="policy",resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkaclusters,verbs=get;list;watch;create;update;patch;delete
@@ -88,49 +115,77 @@ type KafkaClusterReconciler struct {
 // +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkaclusters/finalizers,verbs=create;update;patch;delete
 // +kubebuilder:rbac:groups=servicemesh.cisco.com,resources=istiomeshgateways,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.istio.io,resources=*,verbs=*
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways;tcproutes;tlsroutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules,verbs=get;list;watch;create;update;patch;delete
 
-func (r *KafkaClusterReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+func (r *KafkaClusterReconciler) Reconcile(ctx context.Context, request ctrl.Request) (result ctrl.Result, err error) {
 	log := logr.FromContextOrDiscard(ctx)
 
 	log.Info("Reconciling KafkaCluster")
+	start := time.Now()
+	ctx, span := tracing.StartReconcile(ctx, "KafkaCluster", request)
+	defer func() { tracing.End(span, err) }()
 
 	// Fetch the KafkaCluster instance
 	instance := &v1beta1.KafkaCluster{}
-	err := r.Get(ctx, request.NamespacedName, instance)
+	err = r.Get(ctx, request.NamespacedName, instance)
 	if err != nil {
 		if apiErrors.IsNotFound(err) {
 			// Object not found, return.  Created objects are automatically garbage collected.
 			// For additional cleanup logic use finalizers.
+			metrics.DeleteReconcileDuration("KafkaCluster", request)
 			return reconciled()
 		}
 		// Error reading the object - requeue the request.
 		return requeueWithError(log, err.Error(), err)
 	}
+	defer metrics.ObserveReconcileDuration("KafkaCluster", request, start)
+
+	// The cluster left the scope of the operator while its reconciliation was requeued
+	if !operatorScope.includes(instance) {
+		log.Info("KafkaCluster is out of the scope of the operator, skipping reconciliation")
+		return reconciled()
+	}
+
+	// Status updates queued for coalescing are not yet visible through the API
+	k8sutil.ApplyPendingStatus(instance)
 
 	// Check if marked for deletion and run finalizers
 	if k8sutil.IsMarkedForDeletion(instance.ObjectMeta) {
 		return r.checkFinalizers(ctx, instance)
 	}
 
+	if k8sutil.IsPlanOnly(instance) {
+		return r.reconcilePlan(log, instance)
+	}
+	if instance.Status.Plan != nil {
+		if err := k8sutil.UpdateCRStatus(r.Client, instance, (*v1beta1.ReconcilePlanStatus)(nil), log); err != nil {
+			return requeueWithError(log, "failed to clear the plan of the cluster", err)
+		}
+	}
+
+	trace := k8sutil.NewReconcileTrace(instance)
+	defer func() {
+		trace.Finish(result, err)
+		if err := k8sutil.StoreReconcileTrace(ctx, r.Client, instance, trace); err != nil {
+			log.Error(err, "could not store the reconcile trace")
+		}
+	}()
+
 	if instance.Status.State != v1beta1.KafkaClusterRollingUpgrading {
-		if err := k8sutil.UpdateCRStatus(r.Client, instance, v1beta1.KafkaClusterReconciling, log); err != nil {
+		if err := trace.Step("status", func() error {
+			return k8sutil.UpdateCRStatus(r.Client, instance, v1beta1.KafkaClusterReconciling, log)
+		}); err != nil {
 			return requeueWithError(log, err.Error(), err)
 		}
 	}
 
-	reconcilers := []resources.ComponentReconciler{
-		envoy.New(r.Client, instance),
-		istioingress.New(r.Client, instance),
-		nodeportexternalaccess.New(r.Client, instance),
-		contouringress.New(r.Client, instance),
-		kafkamonitoring.New(r.Client, instance),
-		cruisecontrolmonitoring.New(r.Client, instance),
-		kafka.New(r.Client, r.DirectClient, instance, r.KafkaClientProvider),
-		cruisecontrol.New(r.Client, instance, r.KafkaClientProvider),
-	}
+	reconcilers := componentReconcilers(r.Client, r.DirectClient, instance, r.KafkaClientProvider, r.Recorder)
 
 	for _, rec := range reconcilers {
-		err = rec.Reconcile(log)
+		err = trace.Step(componentReconcilerName(rec), func() error {
+			return tracing.Run(ctx, componentReconcilerName(rec), func(context.Context) error { return rec.Reconcile(log) })
+		})
 		if err != nil {
 			switch {
 			case errors.As(err, &errorfactory.BrokersUnreachable{}):
@@ -179,30 +234,232 @@ func (r *KafkaClusterReconciler) Reconcile(ctx context.Context, request ctrl.Req
 					RequeueAfter: time.Duration(30) * time.Second,
 				}, nil
 			default:
+				degraded := k8sutil.NewCondition(v1beta1.ConditionDegraded, true, instance.Generation, "ReconcileFailed",
+					fmt.Sprintf("%s: %s", componentReconcilerName(rec), err))
+				if statusErr := k8sutil.UpdateCRStatus(r.Client, instance, degraded, log); statusErr != nil {
+					log.Error(statusErr, "could not update the Degraded condition of the cluster")
+				}
+				k8sutil.RecordEvent(r.Recorder, instance, corev1.EventTypeWarning, degraded.Reason, degraded.Message)
 				return requeueWithError(log, err.Error(), err)
 			}
 		}
 	}
 
 	log.Info("ensuring finalizers on kafkacluster")
-	if instance, err = r.ensureFinalizers(ctx, instance); err != nil {
+	if err = trace.Step("finalizers", func() error {
+		updated, err := r.ensureFinalizers(ctx, instance)
+		if err != nil {
+			return err
+		}
+		instance = updated
+		return nil
+	}); err != nil {
 		return requeueWithError(log, "failed to ensure finalizers on kafkacluster instance", err)
 	}
 
 	// Update rolling upgrade last successful state
 	if instance.Status.State == v1beta1.KafkaClusterRollingUpgrading {
+		// the rolling upgrade only completes once the last restarted brokers passed their post-restart hooks
+		err = trace.Step("restartHooks", func() error {
+			return restarthooks.NewRunner(r.Client, r.DirectClient).RunPendingPostRestart(ctx, log, instance, nil)
+		})
+		if err != nil {
+			if errors.As(err, &errorfactory.ReconcileRollingUpgrade{}) {
+				log.Info("Post-restart hooks have not passed yet", "error", err.Error())
+				return ctrl.Result{
+					RequeueAfter: time.Duration(15) * time.Second,
+				}, nil
+			}
+			return requeueWithError(log, err.Error(), err)
+		}
+		// the rolling upgrade is only successful once every broker serves traffic again
+		if instance.Spec.RollingUpgradeConfig.IsSmokeTestEnabled() {
+			var passed bool
+			err = trace.Step("smokeTest", func() (err error) {
+				passed, err = r.runRollingUpgradeSmokeTest(log, instance)
+				return err
+			})
+			if err != nil {
+				return checkBrokerConnectionError(log, err)
+			}
+			if !passed {
+				log.Info("Brokers failed the smoke test after the rolling upgrade, rollback may be required")
+				return ctrl.Result{
+					RequeueAfter: time.Duration(30) * time.Second,
+				}, nil
+			}
+		}
 		if err := k8sutil.UpdateRollingUpgradeState(r.Client, instance, time.Now(), log); err != nil {
 			return requeueWithError(log, err.Error(), err)
 		}
 	}
 
-	if err := k8sutil.UpdateCRStatus(r.Client, instance, v1beta1.KafkaClusterRunning, log); err != nil {
+	if versions := kafkautil.DetectedVersions(instance); !reflect.DeepEqual(versions, instance.Status.DetectedVersions) {
+		if len(versions.Incompatibilities) > 0 {
+			log.Info("incompatible Kafka and Cruise Control versions detected", "incompatibilities", versions.Incompatibilities)
+		}
+		if err := k8sutil.UpdateCRStatus(r.Client, instance, versions, log); err != nil {
+			return requeueWithError(log, err.Error(), err)
+		}
+	}
+
+	if err := trace.Step("status", func() error {
+		return k8sutil.UpdateCRStatus(r.Client, instance, v1beta1.KafkaClusterRunning, log)
+	}); err != nil {
 		return requeueWithError(log, err.Error(), err)
 	}
 
+	if waiting := maintenanceWaiting(reconcilers); len(waiting) > 0 {
+		return r.waitForMaintenanceWindow(log, instance, waiting)
+	}
+	if meta.IsStatusConditionTrue(instance.Status.Conditions, v1beta1.ConditionWaiting) {
+		notWaiting := k8sutil.NewCondition(v1beta1.ConditionWaiting, false, instance.Generation, "Reconciled",
+			"no change waits for a maintenance window")
+		if err := k8sutil.UpdateCRStatus(r.Client, instance, notWaiting, log); err != nil {
+			return requeueWithError(log, "could not update the Waiting condition of the cluster", err)
+		}
+	}
+
 	return reconciled()
 }
 
+// maintenanceWaiting returns the disruptive actions the component reconcilers deferred to the next maintenance window
+func maintenanceWaiting(reconcilers []resources.ComponentReconciler) []string {
+	var waiting []string
+	for _, rec := range reconcilers {
+		if waiter, ok := rec.(resources.MaintenanceWindowWaiter); ok {
+			waiting = append(waiting, waiter.MaintenanceWaiting()...)
+		}
+	}
+	return waiting
+}
+
+// waitForMaintenanceWindow reports the disruptive actions waiting for the next maintenance window in the Waiting
+// condition of the cluster and requeues the cluster for the start of the window
+func (r *KafkaClusterReconciler) waitForMaintenanceWindow(log logr.Logger, instance *v1beta1.KafkaCluster, actions []string) (ctrl.Result, error) {
+	requeueAfter := 5 * time.Minute
+	message := strings.Join(actions, ", ")
+	if _, next, windowErr := maintenance.InWindow(instance.Spec.MaintenanceWindows, time.Now()); windowErr == nil && !next.IsZero() {
+		requeueAfter = time.Until(next)
+		message = fmt.Sprintf("%s, the next window starts at %s", message, next.UTC().Format(time.RFC3339))
+	}
+	log.Info("Disruptive actions wait for the next maintenance window", "actions", actions, "requeueAfter", requeueAfter)
+	waiting := k8sutil.NewCondition(v1beta1.ConditionWaiting, true, instance.Generation, "MaintenanceWindow", message)
+	if current := meta.FindStatusCondition(instance.Status.Conditions, v1beta1.ConditionWaiting); current == nil ||
+		current.Status != waiting.Status || current.Message != waiting.Message {
+		if statusErr := k8sutil.UpdateCRStatus(r.Client, instance, waiting, log); statusErr != nil {
+			return requeueWithError(log, "could not update the Waiting condition of the cluster", statusErr)
+		}
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// componentReconcilers returns the reconcilers of the components of the cluster in the order they are reconciled
+func componentReconcilers(c client.Client, directClient client.Reader, instance *v1beta1.KafkaCluster,
+	kafkaClientProvider kafkaclient.Provider, recorder record.EventRecorder) []resources.ComponentReconciler {
+	return []resources.ComponentReconciler{
+		envoy.New(c, instance),
+		istioingress.New(c, instance),
+		nodeportexternalaccess.New(c, instance),
+		perbrokerloadbalancer.New(c, instance),
+		contouringress.New(c, instance),
+		gatewayapi.New(c, instance),
+		nginxingress.New(c, directClient, instance),
+		kafkamonitoring.New(c, instance),
+		cruisecontrolmonitoring.New(c, instance),
+		kafka.New(c, directClient, instance, kafkaClientProvider, recorder),
+		cabundle.New(c, directClient, instance),
+		cruisecontrol.New(c, instance, kafkaClientProvider),
+		diskplacement.New(c, instance, kafkaClientProvider),
+		leaderbalance.New(c, instance, kafkaClientProvider, recorder),
+	}
+}
+
+// componentReconcilerName returns the name of the package of the component reconciler which names the reconciler
+// in the reconcile trace
+func componentReconcilerName(rec resources.ComponentReconciler) string {
+	recType := reflect.TypeOf(rec)
+	if recType.Kind() == reflect.Ptr {
+		recType = recType.Elem()
+	}
+	return path.Base(recType.PkgPath())
+}
+
+// runRollingUpgradeSmokeTest produces and consumes a message through every broker and records the outcome in the
+// RollbackRequired condition of the cluster. It returns true if all brokers served the traffic.
+func (r *KafkaClusterReconciler) runRollingUpgradeSmokeTest(log logr.Logger, cluster *v1beta1.KafkaCluster) (bool, error) {
+	brokerIDs, err := smokeTestBrokerIDs(cluster)
+	if err != nil {
+		return false, err
+	}
+
+	kClient, close, err := r.KafkaClientProvider.NewFromCluster(r.Client, cluster)
+	if err != nil {
+		return false, err
+	}
+	defer close()
+
+	log.Info("Running produce/consume smoke test after rolling upgrade", "brokers", brokerIDs)
+	failures, err := kClient.ProduceConsumeSmokeTest(cluster.Spec.RollingUpgradeConfig.GetSmokeTestTopic(), brokerIDs,
+		cluster.Spec.RollingUpgradeConfig.GetSmokeTestTimeout())
+	if err != nil {
+		return false, err
+	}
+
+	if err = k8sutil.UpdateCRStatus(r.Client, cluster, rollbackRequiredCondition(failures, cluster.Generation), log); err != nil {
+		return false, err
+	}
+	return len(failures) == 0, nil
+}
+
+// smokeTestBrokerIDs returns the ids of the brokers expected to serve client traffic, KRaft controller-only nodes are skipped
+func smokeTestBrokerIDs(cluster *v1beta1.KafkaCluster) ([]int32, error) {
+	brokerIDs := make([]int32, 0, len(cluster.Spec.Brokers))
+	for _, broker := range cluster.Spec.Brokers {
+		brokerConfig, err := broker.GetBrokerConfig(cluster.Spec)
+		if err != nil {
+			return nil, err
+		}
+		if brokerConfig.IsControllerOnlyNode() {
+			continue
+		}
+		brokerIDs = append(brokerIDs, broker.Id)
+	}
+	return brokerIDs, nil
+}
+
+// rollbackRequiredCondition returns the RollbackRequired condition matching the outcome of the smoke test
+func rollbackRequiredCondition(failures map[int32]error, generation int64) metav1.Condition {
+	if len(failures) == 0 {
+		return metav1.Condition{
+			Type:               v1beta1.KafkaClusterConditionRollbackRequired,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: generation,
+			Reason:             "SmokeTestSucceeded",
+			Message:            "all brokers served produce and consume requests after the rolling upgrade",
+		}
+	}
+
+	failedBrokerIDs := make([]int32, 0, len(failures))
+	for brokerID := range failures {
+		failedBrokerIDs = append(failedBrokerIDs, brokerID)
+	}
+	slices.Sort(failedBrokerIDs)
+	reasons := make([]string, 0, len(failedBrokerIDs))
+	for _, brokerID := range failedBrokerIDs {
+		reasons = append(reasons, fmt.Sprintf("broker %d: %s", brokerID, failures[brokerID]))
+	}
+
+	return metav1.Condition{
+		Type:               v1beta1.KafkaClusterConditionRollbackRequired,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             "SmokeTestFailed",
+		Message: fmt.Sprintf("brokers %v failed to serve produce and consume requests after the rolling upgrade: %s",
+			failedBrokerIDs, strings.Join(reasons, "; ")),
+	}
+}
+
 func (r *KafkaClusterReconciler) checkFinalizers(ctx context.Context, cluster *v1beta1.KafkaCluster) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.Info("KafkaCluster is marked for deletion, checking for children")
@@ -230,6 +487,15 @@ func (r *KafkaClusterReconciler) checkFinalizers(ctx context.Context, cluster *v
 		namespaces = r.Namespaces
 	}
 
+	// Stop Cruise Control first so that it does not act on the brokers while the cluster is torn down
+	log.Info("Stopping Cruise Control of the kafkacluster")
+	if stopped, err := r.stopCruiseControl(ctx, cluster); err != nil {
+		return requeueWithError(log, "failed to stop Cruise Control", err)
+	} else if !stopped {
+		log.Info("Still waiting for Cruise Control to stop")
+		return requeueAfter(3)
+	}
+
 	// If we haven't deleted all kafkatopics yet, iterate namespaces and delete all kafkatopics
 	// with the matching label.
 	if apiutil.StringSliceContains(cluster.GetFinalizers(), clusterTopicsFinalizer) {
@@ -295,6 +561,28 @@ func (r *KafkaClusterReconciler) checkFinalizers(ctx context.Context, cluster *v
 			return requeueWithError(log, "failed to remove users finalizer from kafkacluster", err)
 		}
 	}
+
+	log.Info("Deleting the ingress resources of the kafkacluster")
+	if deleted, err := r.deleteIngress(ctx, cluster); err != nil {
+		return requeueWithError(log, "failed to delete ingress resources", err)
+	} else if !deleted {
+		log.Info("Still waiting for the ingress resources to be deleted")
+		return requeueAfter(3)
+	}
+
+	log.Info("Removing the TCP services of the kafkacluster from the ingress-nginx controllers")
+	if err = nginxingress.New(r.Client, r.DirectClient, cluster).Finalize(ctx, log); err != nil {
+		return requeueWithError(log, "failed to remove nginx tcp services", err)
+	}
+
+	log.Info("Stopping the brokers of the kafkacluster")
+	if stopped, err := r.stopBrokers(ctx, cluster); err != nil {
+		return requeueWithError(log, "failed to stop brokers", err)
+	} else if !stopped {
+		log.Info("Still waiting for the brokers to stop")
+		return requeueAfter(3)
+	}
+
 	if cluster.Spec.ListenersConfig.SSLSecrets != nil {
 		// Do any necessary PKI cleanup - a PKI backend should make sure any
 		// user finalizations are done before it does its final cleanup
@@ -313,6 +601,18 @@ func (r *KafkaClusterReconciler) checkFinalizers(ctx context.Context, cluster *v
 		}
 	}
 
+	log.Info("Removing the published CA bundles of the kafkacluster")
+	if err = cabundle.New(r.Client, r.DirectClient, cluster).Finalize(ctx, log); err != nil {
+		return requeueWithError(log, "failed to remove CA bundles", err)
+	}
+
+	log.Info("Finalizing the persistent volume claims of the brokers", "deletionPolicy", cluster.Spec.GetDeletionPolicy())
+	retained, err := r.finalizeBrokerStorage(ctx, log, cluster)
+	if err != nil {
+		return requeueWithError(log, "failed to finalize persistent volume claims", err)
+	}
+	r.reportTeardown(cluster, retained)
+
 	log.Info("Finalizing deletion of kafkacluster instance")
 	if _, err = r.removeFinalizer(ctx, cluster, clusterFinalizer); err != nil {
 		if client.IgnoreNotFound(err) == nil {
@@ -361,14 +661,18 @@ func (r *KafkaClusterReconciler) updateAndFetchLatest(ctx context.Context, clust
 }
 
 // SetupKafkaClusterWithManager registers kafka cluster controller to the manager
-func SetupKafkaClusterWithManager(mgr ctrl.Manager) *ctrl.Builder {
+func SetupKafkaClusterWithManager(mgr ctrl.Manager, maxConcurrentReconciles int) *ctrl.Builder {
 	log := mgr.GetLogger()
 	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&v1beta1.KafkaCluster{}).
 		WithEventFilter(SkipClusterRegistryOwnedResourcePredicate{}).
+		WithEventFilter(newOperatorScopePredicate(mgr)).
 		Named("KafkaCluster")
+	builder.WithOptions(controllerOptions(maxConcurrentReconciles))
 
 	kafkaWatches(builder)
+	externalBrokerConfigWatches(builder, mgr.GetClient(), log)
+	listenerCertificateSecretWatches(builder, mgr.GetClient(), log)
 	envoyWatches(builder)
 	contourWatches(builder)
 	cruiseControlWatches(builder)
@@ -384,18 +688,29 @@ func SetupKafkaClusterWithManager(mgr ctrl.Manager) *ctrl.Builder {
 			UpdateFunc: func(e event.UpdateEvent) bool {
 				switch newObj := e.ObjectNew.(type) {
 				case *corev1.Pod, *corev1.ConfigMap, *corev1.PersistentVolumeClaim:
+					// the reconcile trace is written by every traced reconcile, it must not trigger a new one
+					if k8sutil.IsReconcileTraceConfigMap(newObj) {
+						return false
+					}
 					patchResult, err := patch.DefaultPatchMaker.Calculate(e.ObjectOld, e.ObjectNew)
 					if err != nil {
 						log.Error(err, "could not match objects", "kind", e.ObjectOld.GetObjectKind())
 					} else if patchResult.IsEmpty() {
 						return false
 					}
+				case *metav1.PartialObjectMetadata:
+					// Secrets and ConfigMaps are watched through their metadata only, the patch of the metadata does
+					// not show the changes of their content
+					return !k8sutil.IsReconcileTraceConfigMap(newObj)
 				case *v1beta1.KafkaCluster:
 					oldObj := e.ObjectOld.(*v1beta1.KafkaCluster)
 					if !reflect.DeepEqual(oldObj.Spec, newObj.Spec) ||
+						k8sutil.IsPlanOnly(oldObj) != k8sutil.IsPlanOnly(newObj) ||
 						oldObj.GetDeletionTimestamp() != newObj.GetDeletionTimestamp() ||
 						oldObj.GetGeneration() != newObj.GetGeneration() ||
-						!reflect.DeepEqual(oldObj.Status.BrokersState, newObj.Status.BrokersState) {
+						!reflect.DeepEqual(oldObj.Status.BrokersState, newObj.Status.BrokersState) ||
+						!reflect.DeepEqual(oldObj.Status.KRaftMigration, newObj.Status.KRaftMigration) ||
+						!reflect.DeepEqual(oldObj.Status.ExternalListenersAccess, newObj.Status.ExternalListenersAccess) {
 						return true
 					}
 					return false
@@ -410,17 +725,104 @@ func SetupKafkaClusterWithManager(mgr ctrl.Manager) *ctrl.Builder {
 func kafkaWatches(builder *ctrl.Builder) *ctrl.Builder {
 	return builder.
 		Owns(&corev1.Service{}).
-		Owns(&corev1.ConfigMap{}).
+		Owns(&corev1.ConfigMap{}, ctrlBuilder.OnlyMetadata).
 		Owns(&policyv1.PodDisruptionBudget{}).
 		Owns(&corev1.PersistentVolumeClaim{}).
 		Owns(&corev1.Pod{})
 }
 
+// externalBrokerConfigWatches triggers the reconciliation of the KafkaClusters referencing the changed ConfigMap
+// as external broker configuration
+func externalBrokerConfigWatches(builder *ctrl.Builder, c client.Reader, log logr.Logger) *ctrl.Builder {
+	mapper := externalBrokerConfigMapper{
+		client: c,
+		log:    log,
+	}
+	return builder.WatchesMetadata(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(mapper.mapToKafkaClusters))
+}
+
+type externalBrokerConfigMapper struct {
+	client client.Reader
+	log    logr.Logger
+}
+
+// mapToKafkaClusters maps ConfigMap events to reconcile events of the KafkaClusters referencing the ConfigMap
+func (m *externalBrokerConfigMapper) mapToKafkaClusters(ctx context.Context, obj client.Object) []ctrl.Request {
+	var clusters v1beta1.KafkaClusterList
+	if err := m.client.List(ctx, &clusters, client.InNamespace(obj.GetNamespace())); err != nil {
+		m.log.Error(err, "couldn't list KafkaClusters", "namespace", obj.GetNamespace())
+		return []ctrl.Request{}
+	}
+
+	requests := make([]ctrl.Request, 0)
+	for _, cluster := range clusters.Items {
+		if cluster.Spec.IsBrokerConfigMapReferenced(obj.GetName()) {
+			requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&cluster)})
+		}
+	}
+	return requests
+}
+
+// listenerCertificateSecretWatches triggers the reconciliation of the KafkaClusters using the changed Secret as the
+// pre-provisioned server certificate of a listener or distributing its CA bundle, so the generated keystores and the
+// published CA bundles are updated when the certificates are rotated
+func listenerCertificateSecretWatches(builder *ctrl.Builder, c client.Reader, log logr.Logger) *ctrl.Builder {
+	mapper := listenerCertificateSecretMapper{
+		client: c,
+		log:    log,
+	}
+	return builder.WatchesMetadata(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(mapper.mapToKafkaClusters))
+}
+
+type listenerCertificateSecretMapper struct {
+	client client.Reader
+	log    logr.Logger
+}
+
+// mapToKafkaClusters maps Secret events to reconcile events of the KafkaClusters with a listener using the Secret as
+// pre-provisioned server certificate, or distributing the CA bundle of an external listener using the Secret as
+// server certificate
+func (m *listenerCertificateSecretMapper) mapToKafkaClusters(ctx context.Context, obj client.Object) []ctrl.Request {
+	var clusters v1beta1.KafkaClusterList
+	if err := m.client.List(ctx, &clusters, client.InNamespace(obj.GetNamespace())); err != nil {
+		m.log.Error(err, "couldn't list KafkaClusters", "namespace", obj.GetNamespace())
+		return []ctrl.Request{}
+	}
+
+	requests := make([]ctrl.Request, 0)
+	for _, cluster := range clusters.Items {
+		if isListenerCertificateSecret(&cluster, obj.GetName()) {
+			requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&cluster)})
+		}
+	}
+	return requests
+}
+
+func isListenerCertificateSecret(cluster *v1beta1.KafkaCluster, secretName string) bool {
+	listeners := make([]v1beta1.CommonListenerSpec, 0, len(cluster.Spec.ListenersConfig.InternalListeners))
+	for _, iListener := range cluster.Spec.ListenersConfig.InternalListeners {
+		listeners = append(listeners, iListener.CommonListenerSpec)
+	}
+	for _, eListener := range cluster.Spec.ListenersConfig.ExternalListeners {
+		listeners = append(listeners, eListener.CommonListenerSpec)
+		if cluster.Spec.ListenersConfig.CABundleDistribution != nil && eListener.Type.IsSSL() &&
+			pkicommon.ListenerServerCertSecretName(cluster.Name, eListener.CommonListenerSpec) == secretName {
+			return true
+		}
+	}
+	for _, listener := range listeners {
+		if listener.ServerCertificate != nil && listener.ServerCertificate.TLSSecretName == secretName {
+			return true
+		}
+	}
+	return false
+}
+
 func envoyWatches(builder *ctrl.Builder) *ctrl.Builder {
 	return builder.
 		Owns(&corev1.Service{}).
 		Owns(&appsv1.Deployment{}).
-		Owns(&corev1.ConfigMap{})
+		Owns(&corev1.ConfigMap{}, ctrlBuilder.OnlyMetadata)
 }
 
 func contourWatches(builder *ctrl.Builder) *ctrl.Builder {
@@ -433,5 +835,5 @@ func cruiseControlWatches(builder *ctrl.Builder) *ctrl.Builder {
 	return builder.
 		Owns(&corev1.Service{}).
 		Owns(&appsv1.Deployment{}).
-		Owns(&corev1.ConfigMap{})
+		Owns(&corev1.ConfigMap{}, ctrlBuilder.OnlyMetadata)
 }
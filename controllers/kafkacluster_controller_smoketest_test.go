@@ -0,0 +1,90 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func TestSmokeTestBrokerIDs(t *testing.T) {
+	t.Parallel()
+
+	cluster := &v1beta1.KafkaCluster{
+		Spec: v1beta1.KafkaClusterSpec{
+			KRaftMode: true,
+			Brokers: []v1beta1.Broker{
+				{Id: 0, BrokerConfig: &v1beta1.BrokerConfig{Roles: []string{"controller"}}},
+				{Id: 1, BrokerConfig: &v1beta1.BrokerConfig{Roles: []string{"broker"}}},
+				{Id: 2, BrokerConfig: &v1beta1.BrokerConfig{Roles: []string{"controller", "broker"}}},
+			},
+		},
+	}
+
+	brokerIDs, err := smokeTestBrokerIDs(cluster)
+	assert.NoError(t, err)
+	assert.Equal(t, []int32{1, 2}, brokerIDs)
+}
+
+func TestRollbackRequiredCondition(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName string
+		failures map[int32]error
+		expected metav1.Condition
+	}{
+		{
+			testName: "all brokers passed",
+			failures: map[int32]error{},
+			expected: metav1.Condition{
+				Type:               v1beta1.KafkaClusterConditionRollbackRequired,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: 3,
+				Reason:             "SmokeTestSucceeded",
+				Message:            "all brokers served produce and consume requests after the rolling upgrade",
+			},
+		},
+		{
+			testName: "some brokers failed",
+			failures: map[int32]error{
+				2: errors.New("timed out"),
+				1: errors.New("not leader"),
+			},
+			expected: metav1.Condition{
+				Type:               v1beta1.KafkaClusterConditionRollbackRequired,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: 3,
+				Reason:             "SmokeTestFailed",
+				Message:            "brokers [1 2] failed to serve produce and consume requests after the rolling upgrade: broker 1: not leader; broker 2: timed out",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.testName, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, rollbackRequiredCondition(test.failures, 3))
+		})
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/yaml"
+
+	banzaiv1beta1 "github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+const (
+	// DefaultKafkaClusterConfigMapKey is the key of the ConfigMap holding the manifest of the default KafkaCluster
+	DefaultKafkaClusterConfigMapKey = "kafkacluster.yaml"
+
+	defaultKafkaClusterRetryInterval = 5 * time.Second
+	defaultKafkaClusterRetryTimeout  = 5 * time.Minute
+)
+
+// DefaultKafkaClusterBootstrapper implements Runnable. When the operator becomes the leader it creates the default
+// KafkaCluster from the manifest of a ConfigMap, so that the operator and the cluster are installed in one step.
+// The KafkaCluster can be edited afterwards: it is only updated from the manifest again when the manifest changes.
+type DefaultKafkaClusterBootstrapper struct {
+	Client       client.Client
+	DirectClient client.Reader
+	// ConfigMap is the ConfigMap holding the manifest of the default KafkaCluster under the kafkacluster.yaml key
+	ConfigMap types.NamespacedName
+}
+
+// SetupDefaultKafkaClusterBootstrapperWithManager adds the bootstrapper to the Manager, the Manager starts it every
+// time the operator acquires the leadership
+func SetupDefaultKafkaClusterBootstrapperWithManager(mgr manager.Manager, bootstrapper *DefaultKafkaClusterBootstrapper) error {
+	return mgr.Add(bootstrapper)
+}
+
+// NeedLeaderElection implements LeaderElectionRunnable, the default KafkaCluster must only be created by the leader
+func (b *DefaultKafkaClusterBootstrapper) NeedLeaderElection() bool {
+	return true
+}
+
+// Start creates or updates the default KafkaCluster, retrying while the API server or the admission webhooks of the
+// operator are not available yet. Failures are only logged so that the operator keeps managing the other clusters.
+func (b *DefaultKafkaClusterBootstrapper) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("default-kafkacluster").WithValues("configMap", b.ConfigMap)
+
+	err := wait.PollUntilContextTimeout(ctx, defaultKafkaClusterRetryInterval, defaultKafkaClusterRetryTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			if err := b.bootstrap(ctx, log); err != nil {
+				log.Info("could not create the default Kafka cluster, retrying", "error", err.Error())
+				return false, nil
+			}
+			return true, nil
+		})
+	if err != nil {
+		log.Error(err, "failed to create the default Kafka cluster")
+	}
+	return nil
+}
+
+func (b *DefaultKafkaClusterBootstrapper) bootstrap(ctx context.Context, log logr.Logger) error {
+	configMap := &corev1.ConfigMap{}
+	if err := b.DirectClient.Get(ctx, b.ConfigMap, configMap); err != nil {
+		return errors.WrapIf(err, "failed to get the ConfigMap of the default Kafka cluster")
+	}
+	desired, sourceHash, err := defaultKafkaClusterFromConfigMap(configMap)
+	if err != nil {
+		return err
+	}
+	log = log.WithValues("clusterName", desired.GetName(), "clusterNamespace", desired.GetNamespace())
+
+	current := &banzaiv1beta1.KafkaCluster{}
+	err = b.DirectClient.Get(ctx, client.ObjectKeyFromObject(desired), current)
+	switch {
+	case apierrors.IsNotFound(err):
+		if err := b.Client.Create(ctx, desired); err != nil {
+			return errors.WrapIf(err, "failed to create the default Kafka cluster")
+		}
+		log.Info("default Kafka cluster created")
+		return nil
+	case err != nil:
+		return errors.WrapIf(err, "failed to get the default Kafka cluster")
+	}
+
+	currentHash, managed := current.GetAnnotations()[banzaiv1beta1.DefaultClusterSourceHashAnnotationKey]
+	switch {
+	case !managed:
+		log.Info("Kafka cluster was not created from the ConfigMap, leaving it unchanged")
+		return nil
+	case currentHash == sourceHash:
+		// the manifest is unchanged, the edits of the cluster are kept
+		return nil
+	}
+
+	current.Spec = desired.Spec
+	current.SetLabels(desired.GetLabels())
+	annotations := current.GetAnnotations()
+	for key, value := range desired.GetAnnotations() {
+		annotations[key] = value
+	}
+	current.SetAnnotations(annotations)
+	if err := b.Client.Update(ctx, current); err != nil {
+		return errors.WrapIf(err, "failed to update the default Kafka cluster")
+	}
+	log.Info("default Kafka cluster updated from the changed ConfigMap")
+	return nil
+}
+
+// defaultKafkaClusterFromConfigMap returns the KafkaCluster of the manifest of the ConfigMap, annotated with the hash
+// of the manifest, and the hash. The KafkaCluster is created in the namespace of the ConfigMap unless specified.
+func defaultKafkaClusterFromConfigMap(configMap *corev1.ConfigMap) (*banzaiv1beta1.KafkaCluster, string, error) {
+	manifest, ok := configMap.Data[DefaultKafkaClusterConfigMapKey]
+	if !ok {
+		return nil, "", errors.NewWithDetails("the ConfigMap of the default Kafka cluster has no manifest", "key", DefaultKafkaClusterConfigMapKey)
+	}
+	cluster := &banzaiv1beta1.KafkaCluster{}
+	if err := yaml.UnmarshalStrict([]byte(manifest), cluster); err != nil {
+		return nil, "", errors.WrapIf(err, "failed to parse the manifest of the default Kafka cluster")
+	}
+	if cluster.GetName() == "" {
+		return nil, "", errors.New("the manifest of the default Kafka cluster has no name")
+	}
+	if cluster.GetNamespace() == "" {
+		cluster.SetNamespace(configMap.GetNamespace())
+	}
+
+	sourceHash := fmt.Sprintf("%x", sha256.Sum256([]byte(manifest)))
+	annotations := cluster.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[banzaiv1beta1.DefaultClusterSourceHashAnnotationKey] = sourceHash
+	cluster.SetAnnotations(annotations)
+	// the manifest may be exported from a running cluster
+	cluster.SetResourceVersion("")
+	cluster.SetUID("")
+	cluster.Status = banzaiv1beta1.KafkaClusterStatus{}
+	return cluster, sourceHash, nil
+}
@@ -0,0 +1,171 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+const defaultKafkaClusterManifest = `apiVersion: kafka.banzaicloud.io/v1beta1
+kind: KafkaCluster
+metadata:
+  name: kafka
+  labels:
+    app: default
+spec:
+  clusterImage: ghcr.io/adobe/koperator/kafka:2.13-3.9.1
+  brokers:
+    - id: 0
+    - id: 1
+`
+
+func TestDefaultKafkaClusterBootstrap(t *testing.T) {
+	t.Parallel()
+
+	_, sourceHash, err := defaultKafkaClusterFromConfigMap(&corev1.ConfigMap{
+		Data: map[string]string{DefaultKafkaClusterConfigMapKey: defaultKafkaClusterManifest},
+	})
+	require.NoError(t, err)
+
+	existing := func(annotations map[string]string) *v1beta1.KafkaCluster {
+		return &v1beta1.KafkaCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka", Annotations: annotations},
+			Spec:       v1beta1.KafkaClusterSpec{ClusterImage: "edited", Brokers: []v1beta1.Broker{{Id: 0}}},
+		}
+	}
+
+	testCases := []struct {
+		testName             string
+		existing             *v1beta1.KafkaCluster
+		expectedClusterImage string
+		expectedBrokers      int
+	}{
+		{
+			testName:             "the default cluster is created in the namespace of the ConfigMap",
+			expectedClusterImage: "ghcr.io/adobe/koperator/kafka:2.13-3.9.1",
+			expectedBrokers:      2,
+		},
+		{
+			testName:             "the edits of the cluster are kept while the manifest is unchanged",
+			existing:             existing(map[string]string{v1beta1.DefaultClusterSourceHashAnnotationKey: sourceHash}),
+			expectedClusterImage: "edited",
+			expectedBrokers:      1,
+		},
+		{
+			testName:             "the cluster is updated from the changed manifest",
+			existing:             existing(map[string]string{v1beta1.DefaultClusterSourceHashAnnotationKey: "previous"}),
+			expectedClusterImage: "ghcr.io/adobe/koperator/kafka:2.13-3.9.1",
+			expectedBrokers:      2,
+		},
+		{
+			testName:             "the cluster not created from the manifest is left unchanged",
+			existing:             existing(nil),
+			expectedClusterImage: "edited",
+			expectedBrokers:      1,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			t.Parallel()
+
+			scheme := runtime.NewScheme()
+			require.NoError(t, clientgoscheme.AddToScheme(scheme))
+			require.NoError(t, v1beta1.AddToScheme(scheme))
+			objects := []client.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "default-kafkacluster", Namespace: "kafka"},
+				Data:       map[string]string{DefaultKafkaClusterConfigMapKey: defaultKafkaClusterManifest},
+			}}
+			if test.existing != nil {
+				objects = append(objects, test.existing)
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+			b := &DefaultKafkaClusterBootstrapper{
+				Client:       c,
+				DirectClient: c,
+				ConfigMap:    types.NamespacedName{Name: "default-kafkacluster", Namespace: "kafka"},
+			}
+
+			require.NoError(t, b.bootstrap(context.Background(), logr.Discard()))
+
+			cluster := &v1beta1.KafkaCluster{}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "kafka", Namespace: "kafka"}, cluster))
+			require.Equal(t, test.expectedClusterImage, cluster.Spec.ClusterImage)
+			require.Len(t, cluster.Spec.Brokers, test.expectedBrokers)
+		})
+	}
+}
+
+func TestDefaultKafkaClusterFromConfigMap(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName    string
+		data        map[string]string
+		expectedErr bool
+	}{
+		{
+			testName: "valid manifest",
+			data:     map[string]string{DefaultKafkaClusterConfigMapKey: defaultKafkaClusterManifest},
+		},
+		{
+			testName:    "missing manifest",
+			data:        map[string]string{"cluster.yaml": defaultKafkaClusterManifest},
+			expectedErr: true,
+		},
+		{
+			testName:    "unknown field",
+			data:        map[string]string{DefaultKafkaClusterConfigMapKey: defaultKafkaClusterManifest + "  unknownField: true\n"},
+			expectedErr: true,
+		},
+		{
+			testName:    "missing name",
+			data:        map[string]string{DefaultKafkaClusterConfigMapKey: "spec:\n  brokers: []\n"},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cluster, _, err := defaultKafkaClusterFromConfigMap(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "default-kafkacluster", Namespace: "kafka"},
+				Data:       test.data,
+			})
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "kafka", cluster.GetNamespace())
+			require.NotEmpty(t, cluster.GetAnnotations()[v1beta1.DefaultClusterSourceHashAnnotationKey])
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/resources"
+)
+
+type fakeMaintenanceWindowWaiter []string
+
+func (f fakeMaintenanceWindowWaiter) Reconcile(logr.Logger) error { return nil }
+
+func (f fakeMaintenanceWindowWaiter) MaintenanceWaiting() []string { return f }
+
+type fakeComponentReconciler struct{}
+
+func (fakeComponentReconciler) Reconcile(logr.Logger) error { return nil }
+
+func TestWaitForMaintenanceWindow(t *testing.T) {
+	testCases := []struct {
+		testName             string
+		reconcilers          []resources.ComponentReconciler
+		windows              []v1beta1.MaintenanceWindow
+		expectedWaiting      bool
+		expectedMessage      string
+		expectedRequeueAfter time.Duration
+	}{
+		{
+			testName:    "nothing waits",
+			reconcilers: []resources.ComponentReconciler{fakeComponentReconciler{}, fakeMaintenanceWindowWaiter(nil)},
+		},
+		{
+			testName: "the deferred actions are reported",
+			reconcilers: []resources.ComponentReconciler{
+				fakeComponentReconciler{},
+				fakeMaintenanceWindowWaiter{"rolling restart of broker 0", "removal of brokers 2"},
+			},
+			expectedWaiting:      true,
+			expectedMessage:      "rolling restart of broker 0, removal of brokers 2",
+			expectedRequeueAfter: 5 * time.Minute,
+		},
+		{
+			testName:    "the cluster is requeued for the next window",
+			reconcilers: []resources.ComponentReconciler{fakeMaintenanceWindowWaiter{"rolling restart of broker 0"}},
+			windows: []v1beta1.MaintenanceWindow{{
+				Schedule: "0 0 1 1 *",
+				Duration: metav1.Duration{Duration: time.Hour},
+			}},
+			expectedWaiting: true,
+			expectedMessage: "rolling restart of broker 0, the next window starts at",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			waiting := maintenanceWaiting(test.reconcilers)
+			if !test.expectedWaiting {
+				require.Empty(t, waiting)
+				return
+			}
+
+			scheme := runtime.NewScheme()
+			require.NoError(t, v1beta1.AddToScheme(scheme))
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Spec:       v1beta1.KafkaClusterSpec{MaintenanceWindows: test.windows},
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster).WithStatusSubresource(cluster).Build()
+			r := &KafkaClusterReconciler{Client: c}
+
+			result, err := r.waitForMaintenanceWindow(logr.Discard(), cluster, waiting)
+			require.NoError(t, err)
+			if test.expectedRequeueAfter != 0 {
+				require.Equal(t, test.expectedRequeueAfter, result.RequeueAfter)
+			} else {
+				require.Positive(t, result.RequeueAfter)
+			}
+
+			updated := &v1beta1.KafkaCluster{}
+			require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "kafka", Namespace: "kafka"}, updated))
+			condition := meta.FindStatusCondition(updated.Status.Conditions, v1beta1.ConditionWaiting)
+			require.NotNil(t, condition)
+			require.Equal(t, metav1.ConditionTrue, condition.Status)
+			require.Contains(t, condition.Message, test.expectedMessage)
+		})
+	}
+}
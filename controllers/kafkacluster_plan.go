@@ -0,0 +1,76 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/kafkaclient"
+	"github.com/banzaicloud/koperator/pkg/resources/kafka"
+	"github.com/banzaicloud/koperator/pkg/scale"
+)
+
+const (
+	// reconcilePlannedEventReason is the reason of the events reporting the changed plan of a plan-only KafkaCluster
+	reconcilePlannedEventReason = "ReconcilePlanned"
+)
+
+// reconcilePlan computes the changes the reconciliation of a plan-only cluster would make and records them in its
+// status. The component reconcilers run against a client which records the changes of the resources instead of
+// applying them and against Kafka clients which only read the state of the Kafka cluster. A component reconciler
+// which fails, e.g. because it waits for a resource the plan would create, is reported as incomplete while the
+// other components are still planned.
+func (r *KafkaClusterReconciler) reconcilePlan(log logr.Logger, instance *v1beta1.KafkaCluster) (ctrl.Result, error) {
+	log.Info("planning the changes of the plan-only KafkaCluster")
+
+	planClient := k8sutil.NewPlanClient(r.Client)
+	// the component reconcilers update the status of the cluster in memory, it must not leak into the recorded plan
+	cluster := instance.DeepCopy()
+	var incomplete []string
+	for _, rec := range componentReconcilers(planClient, r.DirectClient, cluster, kafkaclient.NewReadOnlyProvider(r.KafkaClientProvider), nil) {
+		if kafkaReconciler, ok := rec.(*kafka.Reconciler); ok {
+			// the operations of Cruise Control move the data of the brokers, planning the removal or the replacement of
+			// brokers leaves the plan of the Kafka resources incomplete
+			kafkaReconciler.CruiseControlScalerFactory = func(context.Context, *v1beta1.KafkaCluster) (scale.CruiseControlScaler, error) {
+				return nil, errors.New("cruise control is not called while planning")
+			}
+		}
+		if err := rec.Reconcile(log); err != nil {
+			incomplete = append(incomplete, fmt.Sprintf("%s: %s", componentReconcilerName(rec), err))
+		}
+	}
+
+	plan := planClient.Plan(instance.Generation)
+	plan.Incomplete = incomplete
+	previous := instance.Status.Plan
+	if err := k8sutil.UpdateCRStatus(r.Client, instance, plan, log); err != nil {
+		return requeueWithError(log, "failed to update the plan of the cluster", err)
+	}
+	if previous == nil || !reflect.DeepEqual(previous.Changes, plan.Changes) || !reflect.DeepEqual(previous.RestartingBrokers, plan.RestartingBrokers) {
+		k8sutil.RecordEvent(r.Recorder, instance, corev1.EventTypeNormal, reconcilePlannedEventReason,
+			"the reconciliation would change %d resources and restart %d brokers", len(plan.Changes), len(plan.RestartingBrokers))
+	}
+	return reconciled()
+}
@@ -0,0 +1,104 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/kafkaclient"
+)
+
+func TestKafkaClusterReconcilePlan(t *testing.T) {
+	cluster := &v1beta1.KafkaCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "kafka",
+			Namespace:   "kafka",
+			Generation:  2,
+			Annotations: map[string]string{v1beta1.PlanOnlyAnnotationKey: "true"},
+		},
+		Spec: v1beta1.KafkaClusterSpec{
+			HeadlessServiceEnabled: true,
+			ZKAddresses:            []string{"zookeeper:2181"},
+			BrokerConfigGroups: map[string]v1beta1.BrokerConfig{"default": {
+				StorageConfigs: []v1beta1.StorageConfig{{MountPath: "/kafka-logs", PvcSpec: &corev1.PersistentVolumeClaimSpec{
+					Resources: corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")}},
+				}}},
+			}},
+			Brokers: []v1beta1.Broker{{Id: 0, BrokerConfigGroup: "default"}},
+			ListenersConfig: v1beta1.ListenersConfig{
+				InternalListeners: []v1beta1.InternalListenerConfig{{CommonListenerSpec: v1beta1.CommonListenerSpec{
+					Name: "internal", Type: v1beta1.SecurityProtocolPlaintext, ContainerPort: 29092, UsedForInnerBrokerCommunication: true,
+				}}},
+			},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, gatewayv1.Install(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster).WithStatusSubresource(cluster).Build()
+	recorder := record.NewFakeRecorder(10)
+
+	r := &KafkaClusterReconciler{Client: c, DirectClient: c, KafkaClientProvider: kafkaclient.NewMockProvider(), Recorder: recorder}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}}
+	_, err := r.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+
+	updated := &v1beta1.KafkaCluster{}
+	require.NoError(t, c.Get(context.Background(), request.NamespacedName, updated))
+	plan := updated.Status.Plan
+	require.NotNil(t, plan)
+	require.Equal(t, cluster.Generation, plan.ObservedGeneration)
+	require.Contains(t, plan.Changes, v1beta1.PlannedResourceChange{
+		Action: v1beta1.PlannedActionCreate, Kind: "Service", Namespace: "kafka", Name: "kafka-headless",
+	})
+	require.Contains(t, plan.Changes, v1beta1.PlannedResourceChange{
+		Action: v1beta1.PlannedActionCreate, Kind: "ConfigMap", Namespace: "kafka", Name: "kafka-config-0",
+	})
+	// the pods of the brokers can not be planned before their volumes exist
+	require.NotEmpty(t, plan.Incomplete)
+	require.Empty(t, updated.Status.State, "the cluster must not be reconciled in plan-only mode")
+	require.Len(t, recorder.Events, 1)
+
+	services := &corev1.ServiceList{}
+	require.NoError(t, c.List(context.Background(), services))
+	require.Empty(t, services.Items, "no resource must be created in plan-only mode")
+
+	// the plan is cleared once the cluster leaves plan-only mode
+	delete(updated.Annotations, v1beta1.PlanOnlyAnnotationKey)
+	require.NoError(t, c.Update(context.Background(), updated))
+	r.KafkaClientProvider = kafkaclient.NewMockProvider()
+	_, _ = r.Reconcile(context.Background(), request)
+	require.NoError(t, c.Get(context.Background(), request.NamespacedName, updated))
+	require.Nil(t, updated.Status.Plan)
+}
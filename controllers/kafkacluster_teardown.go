@@ -0,0 +1,173 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+const (
+	// teardownCompletedEventReason is the reason of the event reporting the artifacts retained by the teardown of a
+	// deleted KafkaCluster
+	teardownCompletedEventReason = "TeardownCompleted"
+
+	cruiseControlAppLabelValue = "cruisecontrol"
+)
+
+// ingressAppLabelValues are the app labels of the ingress resources Koperator creates for the external listeners
+var ingressAppLabelValues = []string{"envoyingress", "contouringress", "istioingress", "nginxingress", "gatewayapi"}
+
+// teardownDeleteAllOf deletes the objects of the given list type in the namespace of the cluster matching the labels
+// and returns the number of them still present. The objects are deleted one by one as not every resource supports
+// delete collection requests.
+func (r *KafkaClusterReconciler) teardownDeleteAllOf(ctx context.Context, cluster *v1beta1.KafkaCluster, list client.ObjectList, labels map[string]string) (int, error) {
+	if err := r.DirectClient.List(ctx, list, client.InNamespace(cluster.Namespace), client.MatchingLabels(labels)); err != nil {
+		return 0, errors.WrapIfWithDetails(err, "failed to list resources of the kafkacluster", "labels", labels)
+	}
+	remaining := 0
+	err := meta.EachListItem(list, func(item runtime.Object) error {
+		obj, ok := item.(client.Object)
+		if !ok {
+			return nil
+		}
+		remaining++
+		if obj.GetDeletionTimestamp() != nil {
+			return nil
+		}
+		if err := r.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return errors.WrapIfWithDetails(err, "failed to delete resource of the kafkacluster", "name", obj.GetName())
+		}
+		return nil
+	})
+	return remaining, err
+}
+
+// stopCruiseControl deletes the Cruise Control Deployment and Pods of the cluster and returns true once the Pods are gone,
+// so that Cruise Control does not act on the brokers while they are torn down
+func (r *KafkaClusterReconciler) stopCruiseControl(ctx context.Context, cluster *v1beta1.KafkaCluster) (bool, error) {
+	labels := map[string]string{v1beta1.AppLabelKey: cruiseControlAppLabelValue, v1beta1.KafkaCRLabelKey: cluster.Name}
+	if _, err := r.teardownDeleteAllOf(ctx, cluster, &appsv1.DeploymentList{}, labels); err != nil {
+		return false, err
+	}
+	pods, err := r.teardownDeleteAllOf(ctx, cluster, &corev1.PodList{}, labels)
+	return pods == 0, err
+}
+
+// deleteIngress deletes the Deployments and Services of the ingress resources of the cluster and returns true once the
+// Deployments are gone
+func (r *KafkaClusterReconciler) deleteIngress(ctx context.Context, cluster *v1beta1.KafkaCluster) (bool, error) {
+	done := true
+	for _, app := range ingressAppLabelValues {
+		labels := map[string]string{v1beta1.AppLabelKey: app, v1beta1.KafkaCRLabelKey: cluster.Name}
+		if _, err := r.teardownDeleteAllOf(ctx, cluster, &corev1.ServiceList{}, labels); err != nil {
+			return false, err
+		}
+		deployments, err := r.teardownDeleteAllOf(ctx, cluster, &appsv1.DeploymentList{}, labels)
+		if err != nil {
+			return false, err
+		}
+		done = done && deployments == 0
+	}
+	return done, nil
+}
+
+// stopBrokers deletes the broker Pods of the cluster and returns true once they are gone
+func (r *KafkaClusterReconciler) stopBrokers(ctx context.Context, cluster *v1beta1.KafkaCluster) (bool, error) {
+	pods, err := r.teardownDeleteAllOf(ctx, cluster, &corev1.PodList{}, apiutil.LabelsForKafka(cluster.Name))
+	return pods == 0, err
+}
+
+// finalizeBrokerStorage deletes or, when the deletion policy of the cluster is Retain, releases from the cluster the
+// persistent volume claims of the brokers. It returns the retained artifacts: the claims kept by the Retain policy or
+// the persistent volumes of the deleted claims which outlive them because of their Retain reclaim policy.
+func (r *KafkaClusterReconciler) finalizeBrokerStorage(ctx context.Context, log logr.Logger, cluster *v1beta1.KafkaCluster) ([]string, error) {
+	var pvcs corev1.PersistentVolumeClaimList
+	if err := r.DirectClient.List(ctx, &pvcs, client.InNamespace(cluster.Namespace), client.MatchingLabels(apiutil.LabelsForKafka(cluster.Name))); err != nil {
+		return nil, errors.WrapIf(err, "failed to list persistent volume claims of the kafkacluster")
+	}
+
+	var retained []string
+	for i := range pvcs.Items {
+		pvc := &pvcs.Items[i]
+		if cluster.Spec.GetDeletionPolicy() == v1beta1.DeletionPolicyRetain {
+			if ownerReferences := withoutOwner(pvc.GetOwnerReferences(), cluster); len(ownerReferences) != len(pvc.GetOwnerReferences()) {
+				pvc.SetOwnerReferences(ownerReferences)
+				if err := r.Update(ctx, pvc); err != nil {
+					return nil, errors.WrapIfWithDetails(err, "failed to release persistent volume claim from the kafkacluster", "name", pvc.Name)
+				}
+			}
+			retained = append(retained, fmt.Sprintf("persistentvolumeclaim/%s", pvc.Name))
+			continue
+		}
+
+		if pvc.Spec.VolumeName != "" {
+			pv := &corev1.PersistentVolume{}
+			if err := r.DirectClient.Get(ctx, client.ObjectKey{Name: pvc.Spec.VolumeName}, pv); client.IgnoreNotFound(err) != nil {
+				return nil, errors.WrapIfWithDetails(err, "failed to get persistent volume of the kafkacluster", "name", pvc.Spec.VolumeName)
+			} else if err == nil && pv.Spec.PersistentVolumeReclaimPolicy == corev1.PersistentVolumeReclaimRetain {
+				retained = append(retained, fmt.Sprintf("persistentvolume/%s", pv.Name))
+			}
+		}
+		if pvc.GetDeletionTimestamp() != nil {
+			continue
+		}
+		log.Info("deleting persistent volume claim of the kafkacluster", "name", pvc.Name)
+		if err := r.Delete(ctx, pvc); client.IgnoreNotFound(err) != nil {
+			return nil, errors.WrapIfWithDetails(err, "failed to delete persistent volume claim of the kafkacluster", "name", pvc.Name)
+		}
+	}
+	sort.Strings(retained)
+	return retained, nil
+}
+
+// reportTeardown emits the event of the cluster reporting the artifacts retained by its teardown
+func (r *KafkaClusterReconciler) reportTeardown(cluster *v1beta1.KafkaCluster, retained []string) {
+	if r.Recorder == nil {
+		return
+	}
+	message := fmt.Sprintf("Teardown completed with deletion policy %s, no data is retained", cluster.Spec.GetDeletionPolicy())
+	if len(retained) > 0 {
+		message = fmt.Sprintf("Teardown completed with deletion policy %s, retained: %s",
+			cluster.Spec.GetDeletionPolicy(), strings.Join(retained, ", "))
+	}
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, teardownCompletedEventReason, message)
+}
+
+// withoutOwner returns the owner references without the one of the given owner
+func withoutOwner(ownerReferences []metav1.OwnerReference, owner metav1.Object) []metav1.OwnerReference {
+	var filtered []metav1.OwnerReference
+	for _, ownerReference := range ownerReferences {
+		if ownerReference.UID != owner.GetUID() {
+			filtered = append(filtered, ownerReference)
+		}
+	}
+	return filtered
+}
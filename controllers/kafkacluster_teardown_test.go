@@ -0,0 +1,105 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func TestFinalizeBrokerStorage(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName         string
+		deletionPolicy   v1beta1.DeletionPolicy
+		expectedRetained []string
+		expectedPVCs     bool
+	}{
+		{
+			testName:         "persistent volume claims are deleted, persistent volumes with Retain reclaim policy are reported",
+			expectedRetained: []string{"persistentvolume/pv-0"},
+		},
+		{
+			testName:         "persistent volume claims are released from the cluster",
+			deletionPolicy:   v1beta1.DeletionPolicyRetain,
+			expectedRetained: []string{"persistentvolumeclaim/kafka-0-storage-0", "persistentvolumeclaim/kafka-1-storage-0"},
+			expectedPVCs:     true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka", UID: types.UID("kafka-uid")},
+				Spec:       v1beta1.KafkaClusterSpec{DeletionPolicy: test.deletionPolicy},
+			}
+			pvc := func(name, volumeName string) *corev1.PersistentVolumeClaim {
+				return &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            name,
+						Namespace:       "kafka",
+						Labels:          apiutil.LabelsForKafka("kafka"),
+						OwnerReferences: []metav1.OwnerReference{{Kind: "KafkaCluster", Name: "kafka", UID: cluster.UID}},
+					},
+					Spec: corev1.PersistentVolumeClaimSpec{VolumeName: volumeName},
+				}
+			}
+			c := fake.NewClientBuilder().WithObjects(
+				pvc("kafka-0-storage-0", "pv-0"),
+				pvc("kafka-1-storage-0", "pv-1"),
+				&corev1.PersistentVolume{
+					ObjectMeta: metav1.ObjectMeta{Name: "pv-0"},
+					Spec:       corev1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain},
+				},
+				&corev1.PersistentVolume{
+					ObjectMeta: metav1.ObjectMeta{Name: "pv-1"},
+					Spec:       corev1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimDelete},
+				},
+			).Build()
+			r := &KafkaClusterReconciler{Client: c, DirectClient: c}
+
+			retained, err := r.finalizeBrokerStorage(context.Background(), logr.Discard(), cluster)
+			require.NoError(t, err)
+			require.Equal(t, test.expectedRetained, retained)
+
+			for _, name := range []string{"kafka-0-storage-0", "kafka-1-storage-0"} {
+				claim := &corev1.PersistentVolumeClaim{}
+				err := c.Get(context.Background(), client.ObjectKey{Name: name, Namespace: "kafka"}, claim)
+				if !test.expectedPVCs {
+					require.True(t, apierrors.IsNotFound(err))
+					continue
+				}
+				require.NoError(t, err)
+				require.Empty(t, claim.GetOwnerReferences())
+			}
+		})
+	}
+}
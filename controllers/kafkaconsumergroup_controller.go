@@ -0,0 +1,169 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/kafkaclient"
+)
+
+// SetupKafkaConsumerGroupWithManager registers KafkaConsumerGroup controller to the manager
+func SetupKafkaConsumerGroupWithManager(mgr ctrl.Manager) *ctrl.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.KafkaConsumerGroup{}).
+		WithEventFilter(SkipClusterRegistryOwnedResourcePredicate{}).
+		WithEventFilter(newOperatorScopePredicate(mgr)).
+		// the status is refreshed periodically, its updates must not trigger a refresh
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Named("KafkaConsumerGroup")
+}
+
+// blank assignment to verify that KafkaConsumerGroupReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &KafkaConsumerGroupReconciler{}
+
+// KafkaConsumerGroupReconciler periodically reports the members, the partition assignments and the lag of the consumer
+// groups selected by the KafkaConsumerGroups in their status. The consumer groups are never changed.
+type KafkaConsumerGroupReconciler struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	Client client.Client
+}
+
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkaconsumergroups,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkaconsumergroups/status,verbs=get;update;patch
+
+// Reconcile refreshes the status of the KafkaConsumerGroup from the consumer group on the referenced Kafka cluster
+func (r *KafkaConsumerGroupReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := logr.FromContextOrDiscard(ctx)
+	var err error
+
+	instance := &v1alpha1.KafkaConsumerGroup{}
+	if err = r.Client.Get(ctx, request.NamespacedName, instance); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconciled()
+		}
+		return requeueWithError(reqLogger, err.Error(), err)
+	}
+	if k8sutil.IsMarkedForDeletion(instance.ObjectMeta) {
+		return reconciled()
+	}
+
+	clusterNamespace := getClusterRefNamespace(instance.Namespace, instance.Spec.ClusterRef)
+	var cluster *v1beta1.KafkaCluster
+	if cluster, err = k8sutil.LookupKafkaCluster(ctx, r.Client, instance.Spec.ClusterRef.Name, clusterNamespace); err != nil {
+		return requeueWithError(reqLogger, "failed to lookup referenced cluster", err)
+	}
+	if k8sutil.IsMarkedForDeletion(cluster.ObjectMeta) {
+		reqLogger.Info("Referenced cluster is being deleted, skipping reconciliation")
+		return reconciled()
+	}
+
+	// ensure a kafkaCluster label
+	labels := applyClusterRefLabel(cluster, instance.GetLabels())
+	if !reflect.DeepEqual(labels, instance.GetLabels()) {
+		instance.SetLabels(labels)
+		if err = r.Client.Update(ctx, instance); err != nil {
+			return requeueWithError(reqLogger, "failed to ensure kafkacluster label on kafkaconsumergroup", err)
+		}
+	}
+
+	broker, close, err := connectKafka(ctx, r.Client, cluster)
+	if err != nil {
+		return checkBrokerConnectionError(reqLogger, err)
+	}
+	defer close()
+
+	description, err := broker.DescribeConsumerGroup(instance.GetGroupID())
+	if err != nil {
+		meta.SetStatusCondition(&instance.Status.Conditions, k8sutil.NewCondition(v1beta1.ConditionReady, false,
+			instance.GetGeneration(), "DescribeFailed", err.Error()))
+		if updateErr := r.Client.Status().Update(ctx, instance); updateErr != nil {
+			reqLogger.Error(updateErr, "failed to update kafkaconsumergroup status")
+		}
+		return requeueWithError(reqLogger, "failed to describe consumer group", err)
+	}
+
+	status := consumerGroupStatus(description)
+	now := metav1.Now()
+	status.LastUpdateTime = &now
+	status.Conditions = instance.Status.Conditions
+	meta.SetStatusCondition(&status.Conditions, k8sutil.NewCondition(v1beta1.ConditionReady, true, instance.GetGeneration(),
+		"GroupDescribed", "the members and the lag of the consumer group are up to date"))
+	instance.Status = status
+	if err = r.Client.Status().Update(ctx, instance); err != nil {
+		return requeueWithError(reqLogger, "failed to update kafkaconsumergroup status", err)
+	}
+
+	return ctrl.Result{RequeueAfter: instance.Spec.GetRefreshInterval()}, nil
+}
+
+// consumerGroupStatus returns the status of the described consumer group, the members, the topics and the partitions
+// are sorted to keep the status stable between the refreshes
+func consumerGroupStatus(description *kafkaclient.ConsumerGroupDescription) v1alpha1.KafkaConsumerGroupStatus {
+	status := v1alpha1.KafkaConsumerGroupStatus{
+		State:       description.State,
+		MemberCount: int32(len(description.Members)),
+	}
+
+	for _, member := range description.Members {
+		memberStatus := v1alpha1.ConsumerGroupMemberStatus{MemberID: member.MemberID, ClientID: member.ClientID, Host: member.Host}
+		for _, topic := range slices.Sorted(maps.Keys(member.Assignments)) {
+			memberStatus.Assignments = append(memberStatus.Assignments, v1alpha1.ConsumerGroupTopicPartitions{
+				Topic:      topic,
+				Partitions: slices.Sorted(slices.Values(member.Assignments[topic])),
+			})
+		}
+		status.Members = append(status.Members, memberStatus)
+	}
+	sort.Slice(status.Members, func(i, j int) bool { return status.Members[i].MemberID < status.Members[j].MemberID })
+
+	for _, topic := range slices.Sorted(maps.Keys(description.Offsets)) {
+		topicLag := v1alpha1.ConsumerGroupTopicLag{Topic: topic}
+		for _, partition := range slices.Sorted(maps.Keys(description.Offsets[topic])) {
+			committed := description.Offsets[topic][partition]
+			logEnd := description.LogEndOffsets[topic][partition]
+			// the committed offset may exceed the log end offset after an unclean leader election
+			lag := max(logEnd-committed, 0)
+			topicLag.Partitions = append(topicLag.Partitions, v1alpha1.ConsumerGroupPartitionLag{
+				Partition:       partition,
+				CommittedOffset: committed,
+				LogEndOffset:    logEnd,
+				Lag:             lag,
+			})
+			topicLag.Lag += lag
+		}
+		status.Lag = append(status.Lag, topicLag)
+		status.TotalLag += topicLag.Lag
+	}
+	return status
+}
@@ -0,0 +1,135 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/kafkaclient"
+	"github.com/banzaicloud/koperator/pkg/resources/kafka/mocks"
+)
+
+func TestConsumerGroupStatus(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		description    *kafkaclient.ConsumerGroupDescription
+		expectedStatus v1alpha1.KafkaConsumerGroupStatus
+	}{
+		{
+			testName:       "group without members and offsets",
+			description:    &kafkaclient.ConsumerGroupDescription{State: "Dead"},
+			expectedStatus: v1alpha1.KafkaConsumerGroupStatus{State: "Dead"},
+		},
+		{
+			testName: "members and lag are sorted",
+			description: &kafkaclient.ConsumerGroupDescription{
+				State: "Stable",
+				Members: []kafkaclient.ConsumerGroupMember{
+					{MemberID: "consumer-2", ClientID: "orders-app-1", Assignments: map[string][]int32{"orders": {3, 2}}},
+					{MemberID: "consumer-1", ClientID: "orders-app-0", Assignments: map[string][]int32{"payments": {0}, "orders": {1, 0}}},
+				},
+				Offsets:       map[string]map[int32]int64{"orders": {1: 40, 0: 90}, "payments": {0: 12}},
+				LogEndOffsets: map[string]map[int32]int64{"orders": {1: 50, 0: 100}, "payments": {0: 10}},
+			},
+			expectedStatus: v1alpha1.KafkaConsumerGroupStatus{
+				State:       "Stable",
+				MemberCount: 2,
+				Members: []v1alpha1.ConsumerGroupMemberStatus{
+					{MemberID: "consumer-1", ClientID: "orders-app-0", Assignments: []v1alpha1.ConsumerGroupTopicPartitions{
+						{Topic: "orders", Partitions: []int32{0, 1}},
+						{Topic: "payments", Partitions: []int32{0}},
+					}},
+					{MemberID: "consumer-2", ClientID: "orders-app-1", Assignments: []v1alpha1.ConsumerGroupTopicPartitions{
+						{Topic: "orders", Partitions: []int32{2, 3}},
+					}},
+				},
+				Lag: []v1alpha1.ConsumerGroupTopicLag{
+					{Topic: "orders", Lag: 20, Partitions: []v1alpha1.ConsumerGroupPartitionLag{
+						{Partition: 0, CommittedOffset: 90, LogEndOffset: 100, Lag: 10},
+						{Partition: 1, CommittedOffset: 40, LogEndOffset: 50, Lag: 10},
+					}},
+					// the committed offset ahead of the log end offset does not lower the lag
+					{Topic: "payments", Lag: 0, Partitions: []v1alpha1.ConsumerGroupPartitionLag{
+						{Partition: 0, CommittedOffset: 12, LogEndOffset: 10, Lag: 0},
+					}},
+				},
+				TotalLag: 20,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			require.Equal(t, test.expectedStatus, consumerGroupStatus(test.description))
+		})
+	}
+}
+
+func TestKafkaConsumerGroupReconcile(t *testing.T) {
+	cluster := &v1beta1.KafkaCluster{ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"}}
+	group := &v1alpha1.KafkaConsumerGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "orders-app", Namespace: "kafka"},
+		Spec: v1alpha1.KafkaConsumerGroupSpec{
+			ClusterRef:      v1alpha1.ClusterReference{Name: "kafka"},
+			RefreshInterval: &metav1.Duration{Duration: time.Minute},
+		},
+	}
+
+	mockCtrl := gomock.NewController(t)
+	broker := mocks.NewMockKafkaClient(mockCtrl)
+	broker.EXPECT().DescribeConsumerGroup("orders-app").Return(&kafkaclient.ConsumerGroupDescription{
+		State:         "Empty",
+		Offsets:       map[string]map[int32]int64{"orders": {0: 90}},
+		LogEndOffsets: map[string]map[int32]int64{"orders": {0: 100}},
+	}, nil)
+	SetNewKafkaFromCluster(func(client.Client, *v1beta1.KafkaCluster) (kafkaclient.KafkaClient, func(), error) {
+		return broker, func() {}, nil
+	})
+	defer SetNewKafkaFromCluster(kafkaclient.NewFromCluster)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, group).WithStatusSubresource(group).Build()
+
+	r := &KafkaConsumerGroupReconciler{Client: c}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: group.Name, Namespace: group.Namespace}}
+	result, err := r.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, result.RequeueAfter)
+
+	updated := &v1alpha1.KafkaConsumerGroup{}
+	require.NoError(t, c.Get(context.Background(), request.NamespacedName, updated))
+	require.Equal(t, "kafka.kafka", updated.Labels[clusterRefLabel])
+	require.Equal(t, "Empty", updated.Status.State)
+	require.Equal(t, int64(10), updated.Status.TotalLag)
+	require.NotNil(t, updated.Status.LastUpdateTime)
+	require.True(t, meta.IsStatusConditionTrue(updated.Status.Conditions, v1beta1.ConditionReady))
+}
@@ -0,0 +1,316 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/kafkaclient"
+	kafkautils "github.com/banzaicloud/koperator/pkg/util/kafka"
+)
+
+const (
+	// failoverBootstrapServiceTemplate is the name template of the service the clients of a KafkaFailover bootstrap through
+	failoverBootstrapServiceTemplate = "%s-bootstrap"
+	// failoverPromotionStartedEventReason is the reason of the events reporting the start of a promotion
+	failoverPromotionStartedEventReason = "PromotionStarted"
+	// failoverPromotedEventReason is the reason of the events reporting the promotion of the secondary cluster
+	failoverPromotedEventReason = "Promoted"
+)
+
+// SetupKafkaFailoverWithManager registers KafkaFailover controller to the manager
+func SetupKafkaFailoverWithManager(mgr ctrl.Manager) *ctrl.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.KafkaFailover{}).
+		Owns(&corev1.Service{}).
+		WithEventFilter(SkipClusterRegistryOwnedResourcePredicate{}).
+		WithEventFilter(newOperatorScopePredicate(mgr)).
+		Named("KafkaFailover")
+}
+
+// blank assignment to verify that KafkaFailoverReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &KafkaFailoverReconciler{}
+
+// KafkaFailoverReconciler points the bootstrap service of the KafkaFailovers at their active cluster and promotes the
+// secondary cluster once the primary one is marked failed
+type KafkaFailoverReconciler struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	Client   client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkafailovers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkafailovers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkatopics,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkausers,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile points the bootstrap service of the KafkaFailover at the primary cluster while it is serving the clients,
+// and promotes the secondary cluster once the primary one is marked failed
+func (r *KafkaFailoverReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := logr.FromContextOrDiscard(ctx)
+	var err error
+
+	instance := &v1alpha1.KafkaFailover{}
+	if err = r.Client.Get(ctx, request.NamespacedName, instance); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconciled()
+		}
+		return requeueWithError(reqLogger, err.Error(), err)
+	}
+	if k8sutil.IsMarkedForDeletion(instance.ObjectMeta) {
+		return reconciled()
+	}
+
+	primary := types.NamespacedName{Name: instance.Spec.Primary.Name, Namespace: getClusterRefNamespace(instance.Namespace, instance.Spec.Primary)}
+	secondary := types.NamespacedName{Name: instance.Spec.Secondary.Name, Namespace: getClusterRefNamespace(instance.Namespace, instance.Spec.Secondary)}
+	if primary == secondary {
+		status := instance.Status.DeepCopy()
+		status.State = v1alpha1.FailoverStateFailed
+		status.Message = "the primary and the secondary clusters must differ"
+		if err = r.updateStatus(ctx, instance, status); err != nil {
+			return requeueWithError(reqLogger, "failed to update kafkafailover status", err)
+		}
+		return reconciled()
+	}
+
+	if instance.Status.State == v1alpha1.FailoverStatePromoted {
+		// the promotion is never reverted, the bootstrap service keeps pointing at the secondary cluster
+		secondaryCluster, err := k8sutil.LookupKafkaCluster(ctx, r.Client, secondary.Name, secondary.Namespace)
+		if err != nil {
+			return requeueWithError(reqLogger, "failed to lookup the secondary cluster", err)
+		}
+		if err = r.reconcileBootstrapService(ctx, instance, secondaryCluster); err != nil {
+			return requeueWithError(reqLogger, "failed to reconcile the bootstrap service", err)
+		}
+		return reconciled()
+	}
+
+	if instance.Spec.PrimaryFailed {
+		secondaryCluster, err := k8sutil.LookupKafkaCluster(ctx, r.Client, secondary.Name, secondary.Namespace)
+		if err != nil {
+			return requeueWithError(reqLogger, "failed to lookup the secondary cluster", err)
+		}
+		return r.promote(ctx, instance, primary, secondaryCluster)
+	}
+
+	primaryCluster, err := k8sutil.LookupKafkaCluster(ctx, r.Client, primary.Name, primary.Namespace)
+	if err != nil {
+		return requeueWithError(reqLogger, "failed to lookup the primary cluster", err)
+	}
+	if err = r.reconcileBootstrapService(ctx, instance, primaryCluster); err != nil {
+		return requeueWithError(reqLogger, "failed to reconcile the bootstrap service", err)
+	}
+	status := instance.Status.DeepCopy()
+	status.State = v1alpha1.FailoverStateStandby
+	status.Message = ""
+	status.ActiveCluster = &v1alpha1.ClusterReference{Name: primary.Name, Namespace: primary.Namespace}
+	status.BootstrapService = fmt.Sprintf(failoverBootstrapServiceTemplate, instance.Name)
+	if err = r.updateStatus(ctx, instance, status); err != nil {
+		return requeueWithError(reqLogger, "failed to update kafkafailover status", err)
+	}
+	return reconciled()
+}
+
+// promote re-points the KafkaTopics and the KafkaUsers of the primary cluster to the secondary cluster, then points
+// the bootstrap service at the secondary cluster. Every step is idempotent, a failed promotion is resumed on requeue.
+func (r *KafkaFailoverReconciler) promote(ctx context.Context, instance *v1alpha1.KafkaFailover, primary types.NamespacedName,
+	secondary *v1beta1.KafkaCluster) (reconcile.Result, error) {
+	reqLogger := logr.FromContextOrDiscard(ctx)
+	status := instance.Status.DeepCopy()
+
+	if status.State != v1alpha1.FailoverStatePromoting {
+		k8sutil.RecordEvent(r.Recorder, instance, corev1.EventTypeWarning, failoverPromotionStartedEventReason,
+			"the primary cluster %s/%s is marked failed, promoting the secondary cluster %s/%s",
+			primary.Namespace, primary.Name, secondary.Namespace, secondary.Name)
+		status.State = v1alpha1.FailoverStatePromoting
+		status.Message = ""
+		if err := r.updateStatus(ctx, instance, status); err != nil {
+			return requeueWithError(reqLogger, "failed to update kafkafailover status", err)
+		}
+		status = instance.Status.DeepCopy()
+	}
+
+	broker, close, err := connectKafka(ctx, r.Client, secondary)
+	if err != nil {
+		status.Message = fmt.Sprintf("could not connect to the secondary cluster: %s", err)
+		if err := r.updateStatus(ctx, instance, status); err != nil {
+			return requeueWithError(reqLogger, "failed to update kafkafailover status", err)
+		}
+		return checkBrokerConnectionError(reqLogger, err)
+	}
+	defer close()
+
+	promoteErr := r.promoteTopics(ctx, broker, instance, primary, secondary, status)
+	if promoteErr == nil {
+		promoteErr = r.promoteUsers(ctx, primary, secondary, status)
+	}
+	if promoteErr == nil {
+		promoteErr = r.reconcileBootstrapService(ctx, instance, secondary)
+	}
+	if promoteErr != nil {
+		status.Message = promoteErr.Error()
+		if err := r.updateStatus(ctx, instance, status); err != nil {
+			return requeueWithError(reqLogger, "failed to update kafkafailover status", err)
+		}
+		return requeueWithError(reqLogger, "failed to promote the secondary cluster", promoteErr)
+	}
+
+	status.State = v1alpha1.FailoverStatePromoted
+	status.Message = ""
+	status.ActiveCluster = &v1alpha1.ClusterReference{Name: secondary.Name, Namespace: secondary.Namespace}
+	status.BootstrapService = fmt.Sprintf(failoverBootstrapServiceTemplate, instance.Name)
+	status.PromotedAt = &metav1.Time{Time: time.Now()}
+	if err = r.updateStatus(ctx, instance, status); err != nil {
+		return requeueWithError(reqLogger, "failed to update kafkafailover status", err)
+	}
+	k8sutil.RecordEvent(r.Recorder, instance, corev1.EventTypeNormal, failoverPromotedEventReason,
+		"the secondary cluster %s/%s has been promoted, %d KafkaTopics and %d KafkaUsers re-pointed",
+		secondary.Namespace, secondary.Name, len(status.PromotedTopics), len(status.PromotedUsers))
+	return reconciled()
+}
+
+// promoteTopics re-points the KafkaTopics of the primary cluster to the secondary cluster and merges the topic config
+// of the failover into their config. The topics which had not been replicated are recorded in the status.
+func (r *KafkaFailoverReconciler) promoteTopics(ctx context.Context, broker kafkaclient.KafkaClient, instance *v1alpha1.KafkaFailover,
+	primary types.NamespacedName, secondary *v1beta1.KafkaCluster, status *v1alpha1.KafkaFailoverStatus) error {
+	topics := &v1alpha1.KafkaTopicList{}
+	if err := r.Client.List(ctx, topics); err != nil {
+		return errors.WrapIf(err, "could not list KafkaTopics")
+	}
+	for i := range topics.Items {
+		topic := &topics.Items[i]
+		if !referencesClusterRef(topic.Namespace, topic.Spec.ClusterRef, primary) {
+			continue
+		}
+		existing, err := broker.GetTopic(topic.Spec.Name)
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "could not get the topic from the secondary cluster", "topic", topic.Spec.Name)
+		}
+		topic.Spec.ClusterRef = v1alpha1.ClusterReference{Name: secondary.Name, Namespace: secondary.Namespace}
+		if len(instance.Spec.TopicConfig) > 0 {
+			if topic.Spec.Config == nil {
+				topic.Spec.Config = make(map[string]string, len(instance.Spec.TopicConfig))
+			}
+			maps.Copy(topic.Spec.Config, instance.Spec.TopicConfig)
+		}
+		if err = r.Client.Update(ctx, topic); err != nil {
+			return errors.WrapIfWithDetails(err, "could not re-point the KafkaTopic to the secondary cluster",
+				"kafkaTopic", client.ObjectKeyFromObject(topic).String())
+		}
+		status.PromotedTopics = append(status.PromotedTopics, client.ObjectKeyFromObject(topic).String())
+		if existing == nil {
+			status.MissingTopics = append(status.MissingTopics, topic.Spec.Name)
+		}
+	}
+	return nil
+}
+
+// promoteUsers re-points the KafkaUsers of the primary cluster to the secondary cluster, their credentials and ACLs are
+// reconciled on the secondary cluster by the KafkaUser controller
+func (r *KafkaFailoverReconciler) promoteUsers(ctx context.Context, primary types.NamespacedName, secondary *v1beta1.KafkaCluster, status *v1alpha1.KafkaFailoverStatus) error {
+	users := &v1alpha1.KafkaUserList{}
+	if err := r.Client.List(ctx, users); err != nil {
+		return errors.WrapIf(err, "could not list KafkaUsers")
+	}
+	for i := range users.Items {
+		user := &users.Items[i]
+		if !referencesClusterRef(user.Namespace, user.Spec.ClusterRef, primary) {
+			continue
+		}
+		user.Spec.ClusterRef = v1alpha1.ClusterReference{Name: secondary.Name, Namespace: secondary.Namespace}
+		if err := r.Client.Update(ctx, user); err != nil {
+			return errors.WrapIfWithDetails(err, "could not re-point the KafkaUser to the secondary cluster",
+				"kafkaUser", client.ObjectKeyFromObject(user).String())
+		}
+		status.PromotedUsers = append(status.PromotedUsers, client.ObjectKeyFromObject(user).String())
+	}
+	return nil
+}
+
+// reconcileBootstrapService points the ExternalName bootstrap service of the failover at the service of the active
+// cluster. The clients bootstrapping through it connect to the brokers advertised by the active cluster, it is only
+// resolved inside the Kubernetes cluster so the external clients are not failed over.
+func (r *KafkaFailoverReconciler) reconcileBootstrapService(ctx context.Context, instance *v1alpha1.KafkaFailover,
+	active *v1beta1.KafkaCluster) error {
+	desired := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf(failoverBootstrapServiceTemplate, instance.Name),
+			Namespace: instance.Namespace,
+			Labels:    map[string]string{"app": "kafka-failover", "kafkaFailover": instance.Name},
+		},
+		Spec: corev1.ServiceSpec{
+			Type:         corev1.ServiceTypeExternalName,
+			ExternalName: kafkautils.GetClusterServiceFqdn(active),
+		},
+	}
+	if err := controllerutil.SetControllerReference(instance, desired, r.Scheme); err != nil {
+		return errors.WrapIf(err, "could not set the owner of the bootstrap service")
+	}
+
+	current := &corev1.Service{}
+	err := r.Client.Get(ctx, client.ObjectKeyFromObject(desired), current)
+	switch {
+	case apierrors.IsNotFound(err):
+		return errors.WrapIf(r.Client.Create(ctx, desired), "could not create the bootstrap service")
+	case err != nil:
+		return errors.WrapIf(err, "could not get the bootstrap service")
+	}
+	if current.Spec.Type == desired.Spec.Type && current.Spec.ExternalName == desired.Spec.ExternalName {
+		return nil
+	}
+	current.Spec.Type = desired.Spec.Type
+	current.Spec.ExternalName = desired.Spec.ExternalName
+	return errors.WrapIf(r.Client.Update(ctx, current), "could not update the bootstrap service")
+}
+
+// updateStatus updates the status of the KafkaFailover when it changed
+func (r *KafkaFailoverReconciler) updateStatus(ctx context.Context, instance *v1alpha1.KafkaFailover,
+	status *v1alpha1.KafkaFailoverStatus) error {
+	if equality.Semantic.DeepEqual(instance.Status, *status) {
+		return nil
+	}
+	instance.Status = *status
+	return r.Client.Status().Update(ctx, instance)
+}
+
+// referencesClusterRef returns whether the cluster reference of a resource in the given namespace points to the cluster
+func referencesClusterRef(namespace string, ref v1alpha1.ClusterReference, cluster types.NamespacedName) bool {
+	return ref.Name == cluster.Name && getClusterRefNamespace(namespace, ref) == cluster.Namespace
+}
@@ -0,0 +1,165 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/kafkaclient"
+	"github.com/banzaicloud/koperator/pkg/resources/kafka/mocks"
+)
+
+func TestKafkaFailoverReconcile(t *testing.T) {
+	primary := &v1beta1.KafkaCluster{ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"}}
+	secondary := &v1beta1.KafkaCluster{ObjectMeta: metav1.ObjectMeta{Name: "kafka-dr", Namespace: "kafka-dr"}}
+	failover := &v1alpha1.KafkaFailover{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+		Spec: v1alpha1.KafkaFailoverSpec{
+			Primary:     v1alpha1.ClusterReference{Name: "kafka"},
+			Secondary:   v1alpha1.ClusterReference{Name: "kafka-dr", Namespace: "kafka-dr"},
+			TopicConfig: map[string]string{"min.insync.replicas": "2"},
+		},
+	}
+	orders := &v1alpha1.KafkaTopic{
+		ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "kafka"},
+		Spec: v1alpha1.KafkaTopicSpec{Name: "orders", Partitions: 3, ReplicationFactor: 3,
+			Config: map[string]string{"retention.ms": "3600000"}, ClusterRef: v1alpha1.ClusterReference{Name: "kafka"}},
+	}
+	payments := &v1alpha1.KafkaTopic{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "apps"},
+		Spec: v1alpha1.KafkaTopicSpec{Name: "payments", Partitions: 3, ReplicationFactor: 3,
+			ClusterRef: v1alpha1.ClusterReference{Name: "kafka", Namespace: "kafka"}},
+	}
+	unrelated := &v1alpha1.KafkaTopic{
+		ObjectMeta: metav1.ObjectMeta{Name: "audit", Namespace: "apps"},
+		Spec: v1alpha1.KafkaTopicSpec{Name: "audit", Partitions: 1, ReplicationFactor: 3,
+			ClusterRef: v1alpha1.ClusterReference{Name: "kafka"}},
+	}
+	user := &v1alpha1.KafkaUser{
+		ObjectMeta: metav1.ObjectMeta{Name: "orders-app", Namespace: "apps"},
+		Spec:       v1alpha1.KafkaUserSpec{SecretName: "orders-app", ClusterRef: v1alpha1.ClusterReference{Name: "kafka", Namespace: "kafka"}},
+	}
+
+	mockCtrl := gomock.NewController(t)
+	broker := mocks.NewMockKafkaClient(mockCtrl)
+	broker.EXPECT().GetTopic("orders").Return(&sarama.TopicDetail{NumPartitions: 3, ReplicationFactor: 3}, nil)
+	broker.EXPECT().GetTopic("payments").Return(nil, nil)
+	SetNewKafkaFromCluster(func(_ client.Client, cluster *v1beta1.KafkaCluster) (kafkaclient.KafkaClient, func(), error) {
+		require.Equal(t, "kafka-dr", cluster.Name)
+		return broker, func() {}, nil
+	})
+	defer SetNewKafkaFromCluster(kafkaclient.NewFromCluster)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(primary, secondary, failover, orders, payments, unrelated, user).
+		WithStatusSubresource(failover).Build()
+
+	ctx := context.Background()
+	r := &KafkaFailoverReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(10)}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: failover.Name, Namespace: failover.Namespace}}
+	bootstrapService := types.NamespacedName{Name: "kafka-bootstrap", Namespace: "kafka"}
+
+	// the primary cluster serves the clients
+	_, err := r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	updated := &v1alpha1.KafkaFailover{}
+	require.NoError(t, c.Get(ctx, request.NamespacedName, updated))
+	require.Equal(t, v1alpha1.FailoverStateStandby, updated.Status.State)
+	require.Equal(t, &v1alpha1.ClusterReference{Name: "kafka", Namespace: "kafka"}, updated.Status.ActiveCluster)
+	service := &corev1.Service{}
+	require.NoError(t, c.Get(ctx, bootstrapService, service))
+	require.Equal(t, corev1.ServiceTypeExternalName, service.Spec.Type)
+	require.Equal(t, "kafka-all-broker.kafka.svc.cluster.local", service.Spec.ExternalName)
+
+	// the primary cluster is marked failed
+	updated.Spec.PrimaryFailed = true
+	require.NoError(t, c.Update(ctx, updated))
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Get(ctx, request.NamespacedName, updated))
+	require.Equal(t, v1alpha1.FailoverStatePromoted, updated.Status.State)
+	require.Equal(t, &v1alpha1.ClusterReference{Name: "kafka-dr", Namespace: "kafka-dr"}, updated.Status.ActiveCluster)
+	require.ElementsMatch(t, []string{"kafka/orders", "apps/payments"}, updated.Status.PromotedTopics)
+	require.Equal(t, []string{"apps/orders-app"}, updated.Status.PromotedUsers)
+	require.Equal(t, []string{"payments"}, updated.Status.MissingTopics)
+	require.NotNil(t, updated.Status.PromotedAt)
+
+	topic := &v1alpha1.KafkaTopic{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(orders), topic))
+	require.Equal(t, v1alpha1.ClusterReference{Name: "kafka-dr", Namespace: "kafka-dr"}, topic.Spec.ClusterRef)
+	require.Equal(t, map[string]string{"retention.ms": "3600000", "min.insync.replicas": "2"}, topic.Spec.Config)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(unrelated), topic))
+	require.Equal(t, v1alpha1.ClusterReference{Name: "kafka"}, topic.Spec.ClusterRef)
+	promotedUser := &v1alpha1.KafkaUser{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(user), promotedUser))
+	require.Equal(t, v1alpha1.ClusterReference{Name: "kafka-dr", Namespace: "kafka-dr"}, promotedUser.Spec.ClusterRef)
+
+	require.NoError(t, c.Get(ctx, bootstrapService, service))
+	require.Equal(t, "kafka-dr-all-broker.kafka-dr.svc.cluster.local", service.Spec.ExternalName)
+
+	// the promotion is not reverted
+	updated.Spec.PrimaryFailed = false
+	require.NoError(t, c.Update(ctx, updated))
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	require.NoError(t, c.Get(ctx, request.NamespacedName, updated))
+	require.Equal(t, v1alpha1.FailoverStatePromoted, updated.Status.State)
+	require.NoError(t, c.Get(ctx, bootstrapService, service))
+	require.Equal(t, "kafka-dr-all-broker.kafka-dr.svc.cluster.local", service.Spec.ExternalName)
+}
+
+func TestKafkaFailoverSameClusters(t *testing.T) {
+	failover := &v1alpha1.KafkaFailover{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+		Spec: v1alpha1.KafkaFailoverSpec{
+			Primary:   v1alpha1.ClusterReference{Name: "kafka"},
+			Secondary: v1alpha1.ClusterReference{Name: "kafka", Namespace: "kafka"},
+		},
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(failover).WithStatusSubresource(failover).Build()
+
+	r := &KafkaFailoverReconciler{Client: c, Scheme: scheme}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: failover.Name, Namespace: failover.Namespace}}
+	_, err := r.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+
+	updated := &v1alpha1.KafkaFailover{}
+	require.NoError(t, c.Get(context.Background(), request.NamespacedName, updated))
+	require.Equal(t, v1alpha1.FailoverStateFailed, updated.Status.State)
+	require.NotEmpty(t, updated.Status.Message)
+}
@@ -0,0 +1,578 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/IBM/sarama"
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/kafkaclient"
+	"github.com/banzaicloud/koperator/pkg/util"
+	kafkautils "github.com/banzaicloud/koperator/pkg/util/kafka"
+)
+
+var reassignmentFinalizer = "finalizer.kafkareassignments.kafka.banzaicloud.io"
+
+// SetupKafkaReassignmentWithManager registers KafkaReassignment controller to the manager
+func SetupKafkaReassignmentWithManager(mgr ctrl.Manager) *ctrl.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.KafkaReassignment{}).
+		WithEventFilter(SkipClusterRegistryOwnedResourcePredicate{}).
+		WithEventFilter(newOperatorScopePredicate(mgr)).
+		Named("KafkaReassignment")
+}
+
+// blank assignment to verify that KafkaReassignmentReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &KafkaReassignmentReconciler{}
+
+// KafkaReassignmentReconciler reconciles a KafkaReassignment object
+type KafkaReassignmentReconciler struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	Client client.Client
+	Scheme *runtime.Scheme
+}
+
+// reassignmentPlan holds the desired replicas of the partitions per topic
+type reassignmentPlan map[string]map[int32][]int32
+
+// reassignmentThrottles holds the replication throttles of a reassignment plan
+type reassignmentThrottles struct {
+	// leaderReplicas and followerReplicas are the throttled replicas per topic in the partition:broker form
+	leaderReplicas   map[string][]string
+	followerReplicas map[string][]string
+	// brokers are the brokers sending or receiving the moved replicas
+	brokers []int32
+}
+
+// reassignmentJSON is the reassignment plan format of the kafka-reassign-partitions tool
+type reassignmentJSON struct {
+	Version    int `json:"version"`
+	Partitions []struct {
+		Topic     string   `json:"topic"`
+		Partition int32    `json:"partition"`
+		Replicas  []int32  `json:"replicas"`
+		LogDirs   []string `json:"log_dirs,omitempty"`
+	} `json:"partitions"`
+}
+
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkareassignments,verbs=get;list;watch;create;update;patch;delete;deletecollection
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkareassignments/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkareassignments/finalizers,verbs=create;update;patch;delete
+
+// Reconcile executes the partition reassignment plan of the KafkaReassignment on the referenced Kafka cluster. The
+// moved replicas are throttled while the reassignment is in progress, the throttles are removed once it finished.
+func (r *KafkaReassignmentReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := logr.FromContextOrDiscard(ctx)
+	reqLogger.Info("Reconciling KafkaReassignment")
+	var err error
+
+	// Fetch the KafkaReassignment instance
+	instance := &v1alpha1.KafkaReassignment{}
+	if err = r.Client.Get(ctx, request.NamespacedName, instance); err != nil {
+		if apierrors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			return reconciled()
+		}
+		// Error reading the object - requeue the request.
+		return requeueWithError(reqLogger, err.Error(), err)
+	}
+
+	// Get the referenced kafkacluster
+	clusterNamespace := getClusterRefNamespace(instance.Namespace, instance.Spec.ClusterRef)
+	var cluster *v1beta1.KafkaCluster
+	if cluster, err = k8sutil.LookupKafkaCluster(ctx, r.Client, instance.Spec.ClusterRef.Name, clusterNamespace); err != nil {
+		if k8sutil.IsMarkedForDeletion(instance.ObjectMeta) {
+			reqLogger.Info("Cluster is gone already, there is nothing we can do")
+			if err = r.removeFinalizer(ctx, instance); err != nil {
+				return requeueWithError(reqLogger, "failed to remove finalizer from kafkareassignment", err)
+			}
+			return reconciled()
+		}
+		return requeueWithError(reqLogger, "failed to lookup referenced cluster", err)
+	}
+
+	// check if marked for deletion and remove the replication throttles
+	if k8sutil.IsMarkedForDeletion(instance.ObjectMeta) {
+		return r.checkFinalizers(ctx, cluster, instance)
+	}
+
+	// ensure a kafkaCluster label
+	labels := applyClusterRefLabel(cluster, instance.GetLabels())
+	if !reflect.DeepEqual(labels, instance.GetLabels()) {
+		instance.SetLabels(labels)
+		if err = r.Client.Update(ctx, instance); err != nil {
+			return requeueWithError(reqLogger, "failed to ensure kafkacluster label on kafkareassignment", err)
+		}
+	}
+
+	if instance.Status.IsFinished() {
+		return reconciled()
+	}
+
+	// ensure a finalizer for removing the replication throttles on deletion
+	if !apiutil.StringSliceContains(instance.GetFinalizers(), reassignmentFinalizer) {
+		reqLogger.Info("Adding Finalizer for the KafkaReassignment")
+		instance.SetFinalizers(append(instance.GetFinalizers(), reassignmentFinalizer))
+		if err = r.Client.Update(ctx, instance); err != nil {
+			return requeueWithError(reqLogger, "failed to update kafkareassignment with finalizer", err)
+		}
+	}
+
+	broker, close, err := connectKafka(ctx, r.Client, cluster)
+	if err != nil {
+		return checkBrokerConnectionError(reqLogger, err)
+	}
+	defer close()
+
+	if instance.Status.State == v1alpha1.ReassignmentStateInProgress {
+		return r.checkReassignmentProgress(ctx, broker, instance)
+	}
+	return r.submitReassignment(ctx, broker, cluster, instance)
+}
+
+// submitReassignment applies the replication throttles and starts the reassignment once the partitions of the plan
+// are not being reassigned by anything else
+func (r *KafkaReassignmentReconciler) submitReassignment(ctx context.Context, broker kafkaclient.KafkaClient,
+	cluster *v1beta1.KafkaCluster, instance *v1alpha1.KafkaReassignment) (reconcile.Result, error) {
+	reqLogger := logr.FromContextOrDiscard(ctx)
+
+	brokerIDs := make([]int32, 0, len(cluster.Spec.Brokers))
+	for _, b := range cluster.Spec.Brokers {
+		brokerIDs = append(brokerIDs, b.Id)
+	}
+	plan, err := reassignmentPlanForSpec(instance.Spec, brokerIDs)
+	if err != nil {
+		return r.failReassignment(ctx, broker, instance, err.Error())
+	}
+
+	current := make(reassignmentPlan, len(plan))
+	for _, topic := range plan.topics() {
+		meta, err := broker.DescribeTopic(topic)
+		if err != nil {
+			return r.failReassignment(ctx, broker, instance, fmt.Sprintf("could not describe topic %s: %s", topic, err))
+		}
+		current[topic] = make(map[int32][]int32, len(meta.Partitions))
+		partitionIDs := make([]int32, 0, len(meta.Partitions))
+		for _, partition := range meta.Partitions {
+			current[topic][partition.ID] = partition.Replicas
+			partitionIDs = append(partitionIDs, partition.ID)
+		}
+		for id := range plan[topic] {
+			if _, ok := current[topic][id]; !ok {
+				return r.failReassignment(ctx, broker, instance, fmt.Sprintf("partition %d of topic %s does not exist", id, topic))
+			}
+		}
+
+		// the reassignment request of a topic holds every partition up to the last moved one, it must not
+		// interfere with the reassignments started by others
+		ongoing, err := broker.ListPartitionReassignments(topic, partitionIDs)
+		if err != nil {
+			return requeueWithError(reqLogger, "failed to list partition reassignments", err)
+		}
+		if len(ongoing) > 0 {
+			message := fmt.Sprintf("waiting for the ongoing reassignments of topic %s to finish", topic)
+			if err = r.updateStatus(ctx, instance, v1alpha1.ReassignmentStatePending, message); err != nil {
+				return requeueWithError(reqLogger, "failed to update kafkareassignment status", err)
+			}
+			return requeueAfter(defaultRequeueIntervalInSeconds)
+		}
+	}
+
+	if instance.Spec.ThrottleBytesPerSecond != nil {
+		throttles := throttlesForReassignment(plan, current)
+		// the throttled resources are recorded first so they are cleaned up even if the reassignment never starts
+		instance.Status.ThrottledTopics = slices.Sorted(maps.Keys(throttles.leaderReplicas))
+		instance.Status.ThrottledBrokers = throttles.brokers
+		if err = r.updateStatus(ctx, instance, v1alpha1.ReassignmentStatePending, ""); err != nil {
+			return requeueWithError(reqLogger, "failed to update kafkareassignment status", err)
+		}
+		reqLogger.Info("Applying replication throttles", "topics", instance.Status.ThrottledTopics,
+			"brokers", instance.Status.ThrottledBrokers, "bytesPerSecond", *instance.Spec.ThrottleBytesPerSecond)
+		if err = applyReassignmentThrottles(broker, throttles, *instance.Spec.ThrottleBytesPerSecond); err != nil {
+			return requeueWithError(reqLogger, "failed to apply replication throttles", err)
+		}
+	}
+
+	for _, topic := range plan.topics() {
+		reqLogger.Info("Reassigning partitions", "topic", topic, "replicas", plan[topic])
+		if err = broker.ReassignPartitions(topic, plan[topic]); err != nil {
+			return r.failReassignment(ctx, broker, instance, fmt.Sprintf("could not reassign the partitions of topic %s: %s", topic, err))
+		}
+	}
+
+	now := metav1.Now()
+	instance.Status.StartedAt = &now
+	instance.Status.Partitions = plan.partitionStatuses()
+	instance.Status.Progress = reassignmentProgress(instance.Status.Partitions)
+	if err = r.updateStatus(ctx, instance, v1alpha1.ReassignmentStateInProgress, ""); err != nil {
+		return requeueWithError(reqLogger, "failed to update kafkareassignment status", err)
+	}
+	return requeueAfter(defaultRequeueIntervalInSeconds)
+}
+
+// checkReassignmentProgress records the progress of the reassignment and removes the replication throttles once
+// every partition of the plan reached its desired replicas
+func (r *KafkaReassignmentReconciler) checkReassignmentProgress(ctx context.Context, broker kafkaclient.KafkaClient,
+	instance *v1alpha1.KafkaReassignment) (reconcile.Result, error) {
+	reqLogger := logr.FromContextOrDiscard(ctx)
+
+	partitionIDs := make(map[string][]int32)
+	for _, partition := range instance.Status.Partitions {
+		partitionIDs[partition.Topic] = append(partitionIDs[partition.Topic], partition.Partition)
+	}
+	ongoing := make(map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus, len(partitionIDs))
+	for topic, ids := range partitionIDs {
+		topicOngoing, err := broker.ListPartitionReassignments(topic, ids)
+		if err != nil {
+			return requeueWithError(reqLogger, "failed to list partition reassignments", err)
+		}
+		ongoing[topic] = topicOngoing
+	}
+
+	instance.Status.Partitions = mergeReassignmentProgress(instance.Status.Partitions, ongoing, metav1.Now())
+	instance.Status.Progress = reassignmentProgress(instance.Status.Partitions)
+	for _, partition := range instance.Status.Partitions {
+		if partition.CompletedAt == nil {
+			if err := r.updateStatus(ctx, instance, v1alpha1.ReassignmentStateInProgress, ""); err != nil {
+				return requeueWithError(reqLogger, "failed to update kafkareassignment status", err)
+			}
+			return requeueAfter(defaultRequeueIntervalInSeconds)
+		}
+	}
+
+	// a reassignment cancelled by someone else disappears from the ongoing ones as well
+	for topic := range partitionIDs {
+		meta, err := broker.DescribeTopic(topic)
+		if err != nil {
+			return requeueWithError(reqLogger, "failed to describe topic", err)
+		}
+		if message := checkReassignedReplicas(topic, meta.Partitions, instance.Status.Partitions); message != "" {
+			return r.failReassignment(ctx, broker, instance, message)
+		}
+	}
+
+	if err := removeReassignmentThrottles(broker, instance); err != nil {
+		return requeueWithError(reqLogger, "failed to remove replication throttles", err)
+	}
+	reqLogger.Info("Partition reassignment completed")
+	now := metav1.Now()
+	instance.Status.CompletedAt = &now
+	if err := r.updateStatus(ctx, instance, v1alpha1.ReassignmentStateCompleted, ""); err != nil {
+		return requeueWithError(reqLogger, "failed to update kafkareassignment status", err)
+	}
+	return reconciled()
+}
+
+func (r *KafkaReassignmentReconciler) failReassignment(ctx context.Context, broker kafkaclient.KafkaClient,
+	instance *v1alpha1.KafkaReassignment, message string) (reconcile.Result, error) {
+	reqLogger := logr.FromContextOrDiscard(ctx)
+	reqLogger.Info("Partition reassignment failed", "reason", message)
+	if err := removeReassignmentThrottles(broker, instance); err != nil {
+		return requeueWithError(reqLogger, "failed to remove replication throttles", err)
+	}
+	now := metav1.Now()
+	instance.Status.CompletedAt = &now
+	if err := r.updateStatus(ctx, instance, v1alpha1.ReassignmentStateFailed, message); err != nil {
+		return requeueWithError(reqLogger, "failed to update kafkareassignment status", err)
+	}
+	return reconciled()
+}
+
+func (r *KafkaReassignmentReconciler) updateStatus(ctx context.Context, instance *v1alpha1.KafkaReassignment,
+	state v1alpha1.ReassignmentState, message string) error {
+	status := instance.Status.DeepCopy()
+	status.State = state
+	status.Message = message
+	if reflect.DeepEqual(*status, instance.Status) {
+		return nil
+	}
+	instance.Status = *status
+	return r.Client.Status().Update(ctx, instance)
+}
+
+func (r *KafkaReassignmentReconciler) checkFinalizers(ctx context.Context, cluster *v1beta1.KafkaCluster, instance *v1alpha1.KafkaReassignment) (reconcile.Result, error) {
+	reqLogger := logr.FromContextOrDiscard(ctx)
+	if !apiutil.StringSliceContains(instance.GetFinalizers(), reassignmentFinalizer) {
+		return reconciled()
+	}
+	if k8sutil.IsMarkedForDeletion(cluster.ObjectMeta) {
+		reqLogger.Info("Cluster is being deleted, skipping replication throttle removal")
+	} else if len(instance.Status.ThrottledTopics) > 0 || len(instance.Status.ThrottledBrokers) > 0 {
+		// the ongoing reassignment is not cancelled, only its throttles are removed
+		broker, close, err := connectKafka(ctx, r.Client, cluster)
+		if err != nil {
+			return checkBrokerConnectionError(reqLogger, err)
+		}
+		defer close()
+		if err = removeReassignmentThrottles(broker, instance); err != nil {
+			return requeueWithError(reqLogger, "failed to finalize kafkareassignment", err)
+		}
+	}
+	if err := r.removeFinalizer(ctx, instance); err != nil {
+		return requeueWithError(reqLogger, "failed to remove finalizer from kafkareassignment", err)
+	}
+	return reconciled()
+}
+
+func (r *KafkaReassignmentReconciler) removeFinalizer(ctx context.Context, reassignment *v1alpha1.KafkaReassignment) error {
+	reassignment.SetFinalizers(util.StringSliceRemove(reassignment.GetFinalizers(), reassignmentFinalizer))
+	return r.Client.Update(ctx, reassignment)
+}
+
+// reassignmentPlanForSpec merges the partitions listed in the spec with the ones of the kafka-reassign-partitions
+// JSON plan and validates them against the brokers of the cluster
+func reassignmentPlanForSpec(spec v1alpha1.KafkaReassignmentSpec, brokerIDs []int32) (reassignmentPlan, error) {
+	partitions := slices.Clone(spec.Partitions)
+	if spec.ReassignmentJSON != "" {
+		var plan reassignmentJSON
+		if err := json.Unmarshal([]byte(spec.ReassignmentJSON), &plan); err != nil {
+			return nil, fmt.Errorf("could not parse reassignmentJSON: %w", err)
+		}
+		if plan.Version != 1 {
+			return nil, fmt.Errorf("unsupported reassignmentJSON version %d", plan.Version)
+		}
+		for _, partition := range plan.Partitions {
+			partitions = append(partitions, v1alpha1.PartitionReassignment{
+				Topic:     partition.Topic,
+				Partition: partition.Partition,
+				Replicas:  partition.Replicas,
+				LogDirs:   partition.LogDirs,
+			})
+		}
+	}
+	if len(partitions) == 0 {
+		return nil, fmt.Errorf("the reassignment plan holds no partitions")
+	}
+
+	plan := make(reassignmentPlan)
+	for _, partition := range partitions {
+		name := fmt.Sprintf("%s-%d", partition.Topic, partition.Partition)
+		if partition.Topic == "" || partition.Partition < 0 {
+			return nil, fmt.Errorf("invalid partition %s", name)
+		}
+		if _, ok := plan[partition.Topic][partition.Partition]; ok {
+			return nil, fmt.Errorf("partition %s is listed more than once", name)
+		}
+		if len(partition.Replicas) == 0 {
+			return nil, fmt.Errorf("partition %s has no replicas", name)
+		}
+		for i, replica := range partition.Replicas {
+			if !slices.Contains(brokerIDs, replica) {
+				return nil, fmt.Errorf("replica %d of partition %s is not a broker of the cluster", replica, name)
+			}
+			if slices.Contains(partition.Replicas[:i], replica) {
+				return nil, fmt.Errorf("replica %d of partition %s is listed more than once", replica, name)
+			}
+		}
+		if len(partition.LogDirs) > 0 && len(partition.LogDirs) != len(partition.Replicas) {
+			return nil, fmt.Errorf("partition %s has %d log dirs for %d replicas", name, len(partition.LogDirs), len(partition.Replicas))
+		}
+		for _, logDir := range partition.LogDirs {
+			if logDir != v1alpha1.ReassignmentLogDirAny {
+				return nil, fmt.Errorf("moving the replicas of partition %s to log dir %s is not supported", name, logDir)
+			}
+		}
+		if plan[partition.Topic] == nil {
+			plan[partition.Topic] = make(map[int32][]int32)
+		}
+		plan[partition.Topic][partition.Partition] = partition.Replicas
+	}
+	return plan, nil
+}
+
+func (p reassignmentPlan) topics() []string {
+	return slices.Sorted(maps.Keys(p))
+}
+
+func (p reassignmentPlan) partitionStatuses() []v1alpha1.ReassignmentPartitionStatus {
+	statuses := make([]v1alpha1.ReassignmentPartitionStatus, 0)
+	for _, topic := range p.topics() {
+		for id, replicas := range p[topic] {
+			statuses = append(statuses, v1alpha1.ReassignmentPartitionStatus{
+				Topic:     topic,
+				Partition: id,
+				Replicas:  replicas,
+			})
+		}
+	}
+	sortReassignmentPartitions(statuses)
+	return statuses
+}
+
+// throttlesForReassignment returns the replication throttles of the partitions whose replicas change: the current
+// replicas are throttled as leaders and the new replicas as followers
+func throttlesForReassignment(plan, current reassignmentPlan) reassignmentThrottles {
+	throttles := reassignmentThrottles{
+		leaderReplicas:   make(map[string][]string),
+		followerReplicas: make(map[string][]string),
+	}
+	brokers := make(map[int32]struct{})
+	for _, topic := range plan.topics() {
+		for id, replicas := range plan[topic] {
+			currentReplicas := current[topic][id]
+			if slices.Equal(replicas, currentReplicas) {
+				continue
+			}
+			for _, replica := range currentReplicas {
+				throttles.leaderReplicas[topic] = append(throttles.leaderReplicas[topic], fmt.Sprintf("%d:%d", id, replica))
+				brokers[replica] = struct{}{}
+			}
+			for _, replica := range replicas {
+				if !slices.Contains(currentReplicas, replica) {
+					throttles.followerReplicas[topic] = append(throttles.followerReplicas[topic], fmt.Sprintf("%d:%d", id, replica))
+					brokers[replica] = struct{}{}
+				}
+			}
+		}
+		sort.Strings(throttles.leaderReplicas[topic])
+		sort.Strings(throttles.followerReplicas[topic])
+	}
+	for id := range brokers {
+		throttles.brokers = append(throttles.brokers, id)
+	}
+	slices.Sort(throttles.brokers)
+	return throttles
+}
+
+func applyReassignmentThrottles(broker kafkaclient.KafkaClient, throttles reassignmentThrottles, bytesPerSecond int64) error {
+	for topic, leaderReplicas := range throttles.leaderReplicas {
+		leader := strings.Join(leaderReplicas, ",")
+		follower := strings.Join(throttles.followerReplicas[topic], ",")
+		err := broker.SetTopicConfig(topic, map[string]*string{
+			kafkautils.TopicConfigLeaderReplicationThrottledReplicas:   &leader,
+			kafkautils.TopicConfigFollowerReplicationThrottledReplicas: &follower,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	rate := strconv.FormatInt(bytesPerSecond, 10)
+	for _, id := range throttles.brokers {
+		err := broker.SetPerBrokerConfig(id, map[string]*string{
+			kafkautils.KafkaConfigLeaderReplicationThrottledRate:   &rate,
+			kafkautils.KafkaConfigFollowerReplicationThrottledRate: &rate,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeReassignmentThrottles removes the replication throttles recorded in the status of the reassignment
+func removeReassignmentThrottles(broker kafkaclient.KafkaClient, instance *v1alpha1.KafkaReassignment) error {
+	for _, topic := range instance.Status.ThrottledTopics {
+		err := broker.DeleteTopicConfig(topic, []string{
+			kafkautils.TopicConfigLeaderReplicationThrottledReplicas,
+			kafkautils.TopicConfigFollowerReplicationThrottledReplicas,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, id := range instance.Status.ThrottledBrokers {
+		err := broker.DeletePerBrokerConfig(id, []string{
+			kafkautils.KafkaConfigLeaderReplicationThrottledRate,
+			kafkautils.KafkaConfigFollowerReplicationThrottledRate,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	instance.Status.ThrottledTopics = nil
+	instance.Status.ThrottledBrokers = nil
+	return nil
+}
+
+// mergeReassignmentProgress records the ongoing partition reassignments and marks the partitions of the plan which
+// are not listed anymore as completed
+func mergeReassignmentProgress(partitions []v1alpha1.ReassignmentPartitionStatus,
+	ongoing map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus, now metav1.Time) []v1alpha1.ReassignmentPartitionStatus {
+	merged := make([]v1alpha1.ReassignmentPartitionStatus, 0, len(partitions))
+	for _, partition := range partitions {
+		if status, ok := ongoing[partition.Topic][partition.Partition]; ok {
+			partition.AddingReplicas = status.AddingReplicas
+			partition.RemovingReplicas = status.RemovingReplicas
+		} else if partition.CompletedAt == nil {
+			partition.AddingReplicas = nil
+			partition.RemovingReplicas = nil
+			partition.CompletedAt = &now
+		}
+		merged = append(merged, partition)
+	}
+	sortReassignmentPartitions(merged)
+	return merged
+}
+
+// checkReassignedReplicas returns a message describing the partitions of the topic which did not reach their desired replicas
+func checkReassignedReplicas(topic string, partitions []*sarama.PartitionMetadata, statuses []v1alpha1.ReassignmentPartitionStatus) string {
+	current := make(map[int32][]int32, len(partitions))
+	for _, partition := range partitions {
+		current[partition.ID] = partition.Replicas
+	}
+	mismatches := make([]string, 0)
+	for _, status := range statuses {
+		if status.Topic != topic || slices.Equal(status.Replicas, current[status.Partition]) {
+			continue
+		}
+		mismatches = append(mismatches, fmt.Sprintf("%s-%d has replicas %v instead of %v",
+			topic, status.Partition, current[status.Partition], status.Replicas))
+	}
+	if len(mismatches) == 0 {
+		return ""
+	}
+	return "the reassignment finished without reaching the desired replicas: " + strings.Join(mismatches, ", ")
+}
+
+func reassignmentProgress(partitions []v1alpha1.ReassignmentPartitionStatus) string {
+	completed := 0
+	for _, partition := range partitions {
+		if partition.CompletedAt != nil {
+			completed++
+		}
+	}
+	return fmt.Sprintf("%d/%d", completed, len(partitions))
+}
+
+func sortReassignmentPartitions(partitions []v1alpha1.ReassignmentPartitionStatus) {
+	sort.Slice(partitions, func(i, j int) bool {
+		if partitions[i].Topic != partitions[j].Topic {
+			return partitions[i].Topic < partitions[j].Topic
+		}
+		return partitions[i].Partition < partitions[j].Partition
+	})
+}
@@ -0,0 +1,198 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+)
+
+func TestReassignmentPlanForSpec(t *testing.T) {
+	t.Parallel()
+
+	brokerIDs := []int32{0, 1, 2}
+
+	testCases := []struct {
+		testName     string
+		spec         v1alpha1.KafkaReassignmentSpec
+		expectedPlan reassignmentPlan
+		expectedErr  bool
+	}{
+		{
+			testName: "partitions of the spec",
+			spec: v1alpha1.KafkaReassignmentSpec{
+				Partitions: []v1alpha1.PartitionReassignment{
+					{Topic: "orders", Partition: 0, Replicas: []int32{0, 1}},
+					{Topic: "orders", Partition: 1, Replicas: []int32{1, 2}, LogDirs: []string{"any", "any"}},
+				},
+			},
+			expectedPlan: reassignmentPlan{"orders": {0: {0, 1}, 1: {1, 2}}},
+		},
+		{
+			testName: "partitions of the spec merged with the JSON plan",
+			spec: v1alpha1.KafkaReassignmentSpec{
+				Partitions: []v1alpha1.PartitionReassignment{
+					{Topic: "orders", Partition: 0, Replicas: []int32{0, 1}},
+				},
+				ReassignmentJSON: `{"version":1,"partitions":[{"topic":"payments","partition":2,"replicas":[2,0],"log_dirs":["any","any"]}]}`,
+			},
+			expectedPlan: reassignmentPlan{"orders": {0: {0, 1}}, "payments": {2: {2, 0}}},
+		},
+		{
+			testName:    "empty plan",
+			expectedErr: true,
+		},
+		{
+			testName:    "invalid JSON plan",
+			spec:        v1alpha1.KafkaReassignmentSpec{ReassignmentJSON: `{"version":1,"partitions":[`},
+			expectedErr: true,
+		},
+		{
+			testName:    "unsupported JSON plan version",
+			spec:        v1alpha1.KafkaReassignmentSpec{ReassignmentJSON: `{"version":2,"partitions":[{"topic":"orders","partition":0,"replicas":[0]}]}`},
+			expectedErr: true,
+		},
+		{
+			testName: "partition listed twice",
+			spec: v1alpha1.KafkaReassignmentSpec{
+				Partitions: []v1alpha1.PartitionReassignment{
+					{Topic: "orders", Partition: 0, Replicas: []int32{0, 1}},
+				},
+				ReassignmentJSON: `{"version":1,"partitions":[{"topic":"orders","partition":0,"replicas":[1,2]}]}`,
+			},
+			expectedErr: true,
+		},
+		{
+			testName: "unknown broker",
+			spec: v1alpha1.KafkaReassignmentSpec{
+				Partitions: []v1alpha1.PartitionReassignment{{Topic: "orders", Partition: 0, Replicas: []int32{0, 3}}},
+			},
+			expectedErr: true,
+		},
+		{
+			testName: "replica listed twice",
+			spec: v1alpha1.KafkaReassignmentSpec{
+				Partitions: []v1alpha1.PartitionReassignment{{Topic: "orders", Partition: 0, Replicas: []int32{1, 1}}},
+			},
+			expectedErr: true,
+		},
+		{
+			testName: "log dirs do not match the replicas",
+			spec: v1alpha1.KafkaReassignmentSpec{
+				Partitions: []v1alpha1.PartitionReassignment{{Topic: "orders", Partition: 0, Replicas: []int32{0, 1}, LogDirs: []string{"any"}}},
+			},
+			expectedErr: true,
+		},
+		{
+			testName: "explicit log dir",
+			spec: v1alpha1.KafkaReassignmentSpec{
+				Partitions: []v1alpha1.PartitionReassignment{{Topic: "orders", Partition: 0, Replicas: []int32{0}, LogDirs: []string{"/kafka-logs"}}},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			plan, err := reassignmentPlanForSpec(test.spec, brokerIDs)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedPlan, plan)
+		})
+	}
+}
+
+func TestThrottlesForReassignment(t *testing.T) {
+	t.Parallel()
+
+	plan := reassignmentPlan{
+		"orders":   {0: {2, 1}, 1: {1, 2}},
+		"payments": {0: {0, 3}},
+	}
+	current := reassignmentPlan{
+		"orders":   {0: {0, 1}, 1: {1, 2}},
+		"payments": {0: {0, 1}},
+	}
+
+	throttles := throttlesForReassignment(plan, current)
+	assert.Equal(t, map[string][]string{"orders": {"0:0", "0:1"}, "payments": {"0:0", "0:1"}}, throttles.leaderReplicas)
+	assert.Equal(t, map[string][]string{"orders": {"0:2"}, "payments": {"0:3"}}, throttles.followerReplicas)
+	assert.Equal(t, []int32{0, 1, 2, 3}, throttles.brokers)
+}
+
+func TestMergeReassignmentProgress(t *testing.T) {
+	t.Parallel()
+
+	completedAt := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(time.Date(2025, 1, 1, 0, 1, 0, 0, time.UTC))
+	partitions := []v1alpha1.ReassignmentPartitionStatus{
+		{Topic: "orders", Partition: 1, Replicas: []int32{1, 2}, CompletedAt: &completedAt},
+		{Topic: "orders", Partition: 0, Replicas: []int32{2, 1}, AddingReplicas: []int32{2}},
+		{Topic: "payments", Partition: 0, Replicas: []int32{0, 3}},
+	}
+	ongoing := map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus{
+		"payments": {0: {Replicas: []int32{0, 3, 1}, AddingReplicas: []int32{3}, RemovingReplicas: []int32{1}}},
+	}
+
+	merged := mergeReassignmentProgress(partitions, ongoing, now)
+	assert.Equal(t, []v1alpha1.ReassignmentPartitionStatus{
+		{Topic: "orders", Partition: 0, Replicas: []int32{2, 1}, CompletedAt: &now},
+		{Topic: "orders", Partition: 1, Replicas: []int32{1, 2}, CompletedAt: &completedAt},
+		{Topic: "payments", Partition: 0, Replicas: []int32{0, 3}, AddingReplicas: []int32{3}, RemovingReplicas: []int32{1}},
+	}, merged)
+	assert.Equal(t, "2/3", reassignmentProgress(merged))
+}
+
+func TestCheckReassignedReplicas(t *testing.T) {
+	t.Parallel()
+
+	statuses := []v1alpha1.ReassignmentPartitionStatus{
+		{Topic: "orders", Partition: 0, Replicas: []int32{2, 1}},
+		{Topic: "payments", Partition: 0, Replicas: []int32{0, 3}},
+	}
+
+	testCases := []struct {
+		testName        string
+		partitions      []*sarama.PartitionMetadata
+		expectedMessage bool
+	}{
+		{
+			testName:   "desired replicas reached",
+			partitions: []*sarama.PartitionMetadata{{ID: 0, Replicas: []int32{2, 1}}},
+		},
+		{
+			testName:        "reassignment cancelled",
+			partitions:      []*sarama.PartitionMetadata{{ID: 0, Replicas: []int32{0, 1}}},
+			expectedMessage: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			message := checkReassignedReplicas("orders", test.partitions, statuses)
+			assert.Equal(t, test.expectedMessage, message != "")
+		})
+	}
+}
@@ -20,15 +20,23 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/IBM/sarama"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	apiutil "github.com/banzaicloud/koperator/api/util"
@@ -37,12 +45,24 @@ import (
 	"github.com/banzaicloud/koperator/api/v1beta1"
 	"github.com/banzaicloud/koperator/pkg/k8sutil"
 	"github.com/banzaicloud/koperator/pkg/kafkaclient"
+	"github.com/banzaicloud/koperator/pkg/scale"
 	"github.com/banzaicloud/koperator/pkg/util"
+	"github.com/banzaicloud/koperator/pkg/util/metrics"
+	"github.com/banzaicloud/koperator/pkg/util/ownership"
+	"github.com/banzaicloud/koperator/pkg/util/tracing"
 	"github.com/banzaicloud/koperator/pkg/webhooks"
 )
 
 var topicFinalizer = "finalizer.kafkatopics.kafka.banzaicloud.io"
 
+// The reasons of the events emitted on the KafkaTopics
+const (
+	topicCreatedEventReason        = "TopicCreated"
+	partitionsIncreasedEventReason = "PartitionsIncreased"
+	topicOrphanedEventReason       = "TopicOrphaned"
+	topicAdoptedEventReason        = "TopicAdopted"
+)
+
 func isTopicManagedByKoperator(topic metav1.Object) bool {
 	if managedByAnnotation, hasManagedByAnnotation := topic.GetAnnotations()[webhooks.TopicManagedByAnnotationKey]; hasManagedByAnnotation {
 		return strings.ToLower(managedByAnnotation) == webhooks.TopicManagedByKoperatorAnnotationValue
@@ -55,8 +75,9 @@ func SetupKafkaTopicWithManager(mgr ctrl.Manager, maxConcurrentReconciles int) *
 	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.KafkaTopic{}).
 		WithEventFilter(SkipClusterRegistryOwnedResourcePredicate{}).
+		WithEventFilter(newOperatorScopePredicate(mgr)).
 		Named("KafkaTopic")
-	builder.WithOptions(controller.Options{MaxConcurrentReconciles: maxConcurrentReconciles})
+	builder.WithOptions(controllerOptions(maxConcurrentReconciles))
 
 	return builder
 }
@@ -70,16 +91,27 @@ type KafkaTopicReconciler struct {
 	// that reads objects from the cache and writes to the apiserver
 	Client client.Client
 	Scheme *runtime.Scheme
+	// Recorder emits the events of the KafkaTopics, e.g. the creation of the topic on the Kafka cluster
+	Recorder record.EventRecorder
+	// KafkaClientProvider provides the Kafka clients of the reconciles, typically a pool sharing a client per cluster.
+	// A client is opened for each reconcile when nil.
+	KafkaClientProvider kafkaclient.Provider
 }
 
 // +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkatopics,verbs=get;list;watch;create;update;patch;delete;deletecollection
 // +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkatopics/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkatopics/finalizers,verbs=create;update;patch;delete
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=cruisecontroloperations,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=cruisecontroloperations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile reconciles the kafka topic
 func (r *KafkaTopicReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	reqLogger := logr.FromContextOrDiscard(ctx)
 	reqLogger.Info("Reconciling KafkaTopic")
+	start := time.Now()
+	ctx, span := tracing.StartReconcile(ctx, "KafkaTopic", request)
+	defer span.End()
 	var err error
 
 	// Fetch the KafkaTopic instance
@@ -87,11 +119,13 @@ func (r *KafkaTopicReconciler) Reconcile(ctx context.Context, request reconcile.
 	if err = r.Client.Get(ctx, request.NamespacedName, instance); err != nil {
 		if apierrors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
+			metrics.DeleteReconcileDuration("KafkaTopic", request)
 			return reconciled()
 		}
 		// Error reading the object - requeue the request.
 		return requeueWithError(reqLogger, err.Error(), err)
 	}
+	defer metrics.ObserveReconcileDuration("KafkaTopic", request, start)
 
 	// Get the referenced kafkacluster
 	clusterNamespace := getClusterRefNamespace(instance.Namespace, instance.Spec.ClusterRef)
@@ -110,6 +144,12 @@ func (r *KafkaTopicReconciler) Reconcile(ctx context.Context, request reconcile.
 		return requeueWithError(reqLogger, "failed to lookup referenced cluster", err)
 	}
 
+	// The teardown of the referenced cluster deletes its topics, they are no longer reconciled until then
+	if k8sutil.IsMarkedForDeletion(cluster.ObjectMeta) && !k8sutil.IsMarkedForDeletion(instance.ObjectMeta) {
+		reqLogger.Info("Referenced cluster is being deleted, skipping reconciliation")
+		return reconciled()
+	}
+
 	// Set managed status based on KafkaTopic managedBy annotation
 	managedByStatus := webhooks.TopicManagedByKoperatorAnnotationValue
 	if !isTopicManagedByKoperator(instance) {
@@ -124,7 +164,7 @@ func (r *KafkaTopicReconciler) Reconcile(ctx context.Context, request reconcile.
 	}
 
 	// Get a kafka connection
-	broker, close, err := newKafkaFromCluster(r.Client, cluster)
+	broker, close, err := r.connectKafka(ctx, cluster)
 	if err != nil {
 		return checkBrokerConnectionError(reqLogger, err)
 	}
@@ -132,7 +172,7 @@ func (r *KafkaTopicReconciler) Reconcile(ctx context.Context, request reconcile.
 
 	// Check if marked for deletion and if so run finalizers
 	if k8sutil.IsMarkedForDeletion(instance.ObjectMeta) {
-		return r.checkFinalizers(ctx, broker, instance)
+		return r.checkFinalizers(ctx, broker, cluster, instance)
 	}
 
 	// No need to do anything when the kafka topic is not managed by Koperator
@@ -141,6 +181,11 @@ func (r *KafkaTopicReconciler) Reconcile(ctx context.Context, request reconcile.
 		return reconciled()
 	}
 
+	// Only the namespaces of the team owning the topic prefix may manage the topic
+	if err := ownership.CheckKafkaTopic(ctx, r.Client, instance); err != nil {
+		return requeueWithError(reqLogger, "kafkatopic is not owned by the team of its namespace", err)
+	}
+
 	// Check if the topic already exists
 	existing, err := broker.GetTopic(instance.Spec.Name)
 	if err != nil {
@@ -156,25 +201,43 @@ func (r *KafkaTopicReconciler) Reconcile(ctx context.Context, request reconcile.
 	// we got a topic back
 	if existing != nil {
 		reqLogger.Info("Topic already exists, verifying configuration")
-		// Ensure partition count
-		if changed, err := broker.EnsurePartitionCount(instance.Spec.Name, instance.Spec.Partitions); err != nil {
-			return requeueWithError(reqLogger, "failed to ensure topic partition count", err)
-		} else if changed {
-			reqLogger.Info("Increased partition count for topic")
+		// Adopt the topic existing before the KafkaTopic when the cluster adopts the existing topics
+		if instance.Status.Adoption == nil && instance.Status.State != v1alpha1.TopicStateCreated && cluster.Spec.AdoptExistingTopics {
+			if err = r.adoptTopic(ctx, instance, existing); err != nil {
+				return requeueWithError(reqLogger, "failed to adopt existing topic", err)
+			}
 		}
-		// Ensure topic configurations
-		if err = broker.EnsureTopicConfig(instance.Spec.Name, util.MapStringStringPointer(instance.Spec.Config)); err != nil {
-			return requeueWithError(reqLogger, "failure to ensure topic config", err)
+		if instance.Status.Adoption != nil {
+			if err = r.reconcileAdoptedTopic(ctx, broker, instance, existing); err != nil {
+				return requeueWithError(reqLogger, "failed to reconcile adopted topic", err)
+			}
+		} else {
+			// Ensure partition count
+			if changed, err := broker.EnsurePartitionCount(instance.Spec.Name, instance.Spec.Partitions); err != nil {
+				return requeueWithError(reqLogger, "failed to ensure topic partition count", err)
+			} else if changed {
+				reqLogger.Info("Increased partition count for topic")
+				k8sutil.RecordEvent(r.Recorder, instance, corev1.EventTypeNormal, partitionsIncreasedEventReason,
+					"the partition count of the topic has been increased to %d", instance.Spec.Partitions)
+			}
+			// Ensure topic configurations
+			if err = broker.EnsureTopicConfig(instance.Spec.Name, util.MapStringStringPointer(instance.Spec.Config)); err != nil {
+				return requeueWithError(reqLogger, "failure to ensure topic config", err)
+			}
 		}
 		reqLogger.Info("Verified partitions and configuration for topic")
-	} else if err = broker.CreateTopic(&kafkaclient.CreateTopicOptions{
+	} else {
 		// Create the topic
-		Name:              instance.Spec.Name,
-		Partitions:        instance.Spec.Partitions,
-		ReplicationFactor: int16(instance.Spec.ReplicationFactor),
-		Config:            util.MapStringStringPointer(instance.Spec.Config),
-	}); err != nil {
-		return requeueWithError(reqLogger, "failed to create kafka topic", err)
+		if err = broker.CreateTopic(&kafkaclient.CreateTopicOptions{
+			Name:              instance.Spec.Name,
+			Partitions:        instance.Spec.Partitions,
+			ReplicationFactor: int16(instance.Spec.ReplicationFactor),
+			Config:            util.MapStringStringPointer(instance.Spec.Config),
+		}); err != nil {
+			return requeueWithError(reqLogger, "failed to create kafka topic", err)
+		}
+		k8sutil.RecordEvent(r.Recorder, instance, corev1.EventTypeNormal, topicCreatedEventReason,
+			"topic %s has been created on the Kafka cluster %s", instance.Spec.Name, cluster.Name)
 	}
 
 	// ensure kafkaCluster label
@@ -191,19 +254,87 @@ func (r *KafkaTopicReconciler) Reconcile(ctx context.Context, request reconcile.
 		}
 	}
 
-	// set topic status as created
-	if instance.Status.State != v1alpha1.TopicStateCreated {
-		instance.Status.State = v1alpha1.TopicStateCreated
+	// set topic status as created and ready
+	ready := k8sutil.NewCondition(v1beta1.ConditionReady, true, instance.GetGeneration(), "TopicCreated",
+		"the topic exists on the Kafka cluster with the desired partitions and configuration")
+	created := instance.Status.State != v1alpha1.TopicStateCreated
+	instance.Status.State = v1alpha1.TopicStateCreated
+	if meta.SetStatusCondition(&instance.Status.Conditions, ready) || created {
 		if err := r.Client.Status().Update(ctx, instance); err != nil {
 			return requeueWithError(reqLogger, "failed to update kafkatopic status", err)
 		}
 	}
 
+	// drive the replication factor change of an existing topic
+	if existing != nil {
+		if inProgress, err := r.ensureReplicationFactor(ctx, broker, cluster, instance, existing); err != nil {
+			return requeueWithError(reqLogger, "failed to ensure topic replication factor", err)
+		} else if inProgress {
+			reqLogger.Info("Replica reassignment of topic is in progress")
+			return requeueAfter(defaultRequeueIntervalInSeconds)
+		}
+	}
+
 	reqLogger.Info("Ensured topic")
 
 	return reconciled()
 }
 
+// connectKafka returns a client of the Kafka cluster from the provider of the reconciler
+func (r *KafkaTopicReconciler) connectKafka(ctx context.Context, cluster *v1beta1.KafkaCluster) (kafkaclient.KafkaClient, func(), error) {
+	if r.KafkaClientProvider == nil {
+		return connectKafka(ctx, r.Client, cluster)
+	}
+	_, span := tracing.Start(ctx, "kafka.Connect", tracing.ClusterAttributes(cluster.Namespace, cluster.Name)...)
+	broker, close, err := r.KafkaClientProvider.NewFromCluster(r.Client, cluster)
+	tracing.End(span, err)
+	return broker, close, err
+}
+
+// adoptTopic imports the live state of the existing topic into the status of the KafkaTopic, the status is persisted
+// right away so the topic is adopted only once
+func (r *KafkaTopicReconciler) adoptTopic(ctx context.Context, topic *v1alpha1.KafkaTopic, existing *sarama.TopicDetail) error {
+	config := make(map[string]string, len(existing.ConfigEntries))
+	for key, value := range existing.ConfigEntries {
+		if value != nil {
+			config[key] = *value
+		}
+	}
+	topic.Status.Adoption = &v1alpha1.TopicAdoptionStatus{
+		AdoptedAt:         metav1.Now(),
+		Partitions:        existing.NumPartitions,
+		ReplicationFactor: int32(existing.ReplicationFactor),
+		Config:            config,
+	}
+	if err := r.Client.Status().Update(ctx, topic); err != nil {
+		return err
+	}
+	logr.FromContextOrDiscard(ctx).Info("Adopted existing topic", "partitions", existing.NumPartitions,
+		"replicationFactor", existing.ReplicationFactor)
+	k8sutil.RecordEvent(r.Recorder, topic, corev1.EventTypeNormal, topicAdoptedEventReason,
+		"existing topic %s with %d partitions and replication factor %d has been adopted", topic.Spec.Name,
+		existing.NumPartitions, existing.ReplicationFactor)
+	return nil
+}
+
+// reconcileAdoptedTopic reconciles only the explicitly set spec fields of an adopted topic: the partition count is
+// only increased and only the configuration overrides listed in the spec are set, the others are left untouched
+func (r *KafkaTopicReconciler) reconcileAdoptedTopic(ctx context.Context, broker kafkaclient.KafkaClient, topic *v1alpha1.KafkaTopic,
+	existing *sarama.TopicDetail) error {
+	if topic.Spec.Partitions > existing.NumPartitions {
+		if _, err := broker.EnsurePartitionCount(topic.Spec.Name, topic.Spec.Partitions); err != nil {
+			return err
+		}
+		logr.FromContextOrDiscard(ctx).Info("Increased partition count for topic")
+		k8sutil.RecordEvent(r.Recorder, topic, corev1.EventTypeNormal, partitionsIncreasedEventReason,
+			"the partition count of the topic has been increased to %d", topic.Spec.Partitions)
+	}
+	if len(topic.Spec.Config) > 0 {
+		return broker.SetTopicConfig(topic.Spec.Name, util.MapStringStringPointer(topic.Spec.Config))
+	}
+	return nil
+}
+
 func (r *KafkaTopicReconciler) ensureClusterLabel(ctx context.Context, cluster *v1beta1.KafkaCluster, topic *v1alpha1.KafkaTopic) (*v1alpha1.KafkaTopic, error) {
 	labels := applyClusterRefLabel(cluster, topic.GetLabels())
 	if !reflect.DeepEqual(labels, topic.GetLabels()) {
@@ -223,14 +354,22 @@ func (r *KafkaTopicReconciler) updateAndFetchLatest(ctx context.Context, topic *
 	return topic, nil
 }
 
-func (r *KafkaTopicReconciler) checkFinalizers(ctx context.Context, broker kafkaclient.KafkaClient, topic *v1alpha1.KafkaTopic) (reconcile.Result, error) {
+func (r *KafkaTopicReconciler) checkFinalizers(ctx context.Context, broker kafkaclient.KafkaClient, cluster *v1beta1.KafkaCluster,
+	topic *v1alpha1.KafkaTopic) (reconcile.Result, error) {
 	reqLogger := logr.FromContextOrDiscard(ctx)
 	reqLogger.Info("Kafka topic is marked for deletion")
 	var err error
 	if apiutil.StringSliceContains(topic.GetFinalizers(), topicFinalizer) {
-		// Remove topic from Kafka cluster when it is managed by Koperator
+		// Remove topic from Kafka cluster when it is managed by Koperator, protected topics are orphaned instead
 		if isTopicManagedByKoperator(topic) {
-			if err = r.finalizeKafkaTopic(reqLogger, broker, topic); err != nil {
+			if topic.Spec.IsDeletionProtected(cluster.Spec.TopicDeletionProtection) {
+				reqLogger.Info("Topic is protected against deletion, leaving it on the Kafka cluster")
+				k8sutil.RecordEvent(r.Recorder, topic, corev1.EventTypeWarning, topicOrphanedEventReason,
+					"topic %s is protected against deletion, it is left on the Kafka cluster %s", topic.Spec.Name, cluster.Name)
+				if err = addOrphanedTopic(ctx, r.Client, cluster, topic.Spec.Name); err != nil {
+					return requeueWithError(reqLogger, "failed to record the orphaned topic", err)
+				}
+			} else if err = r.finalizeKafkaTopic(reqLogger, broker, topic); err != nil {
 				return requeueWithError(reqLogger, "failed to finalize kafkatopic", err)
 			}
 		}
@@ -266,3 +405,232 @@ func (r *KafkaTopicReconciler) finalizeKafkaTopic(reqLogger logr.Logger, broker
 	}
 	return nil
 }
+
+// ensureReplicationFactor changes the replication factor of an existing topic by reassigning its replicas, either with
+// a Cruise Control topic_configuration operation or through the Kafka admin API, and reports the progress of the
+// reassignment in the KafkaTopic status. It returns true while the reassignment is in progress.
+func (r *KafkaTopicReconciler) ensureReplicationFactor(ctx context.Context, broker kafkaclient.KafkaClient, cluster *v1beta1.KafkaCluster,
+	topic *v1alpha1.KafkaTopic, existing *sarama.TopicDetail) (bool, error) {
+	reassignment := topic.Status.Reassignment.DeepCopy()
+	mismatch := topic.Spec.ReplicationFactor > 0 && int16(topic.Spec.ReplicationFactor) != existing.ReplicationFactor
+
+	switch {
+	case reassignment.IsInProgress():
+		if err := r.updateReassignmentProgress(ctx, broker, cluster, topic.Spec.Name, existing, reassignment); err != nil {
+			return false, err
+		}
+	// a failed reassignment is not retried until the desired replication factor is changed
+	case mismatch && (reassignment == nil || reassignment.State != v1alpha1.TopicReassignmentStateFailed ||
+		reassignment.TargetReplicationFactor != topic.Spec.ReplicationFactor):
+		now := metav1.Now()
+		reassignment = &v1alpha1.TopicReassignmentStatus{
+			State:                   v1alpha1.TopicReassignmentStatePending,
+			Strategy:                topic.Spec.GetReassignmentStrategy(),
+			TargetReplicationFactor: topic.Spec.ReplicationFactor,
+			StartedAt:               &now,
+		}
+		switch reassignment.Strategy {
+		case v1alpha1.TopicReassignmentStrategyAdminClient:
+			if err := broker.ChangeReplicationFactor(topic.Spec.Name, topic.Spec.ReplicationFactor); err != nil {
+				return false, err
+			}
+			reassignment.State = v1alpha1.TopicReassignmentStateInProgress
+		default:
+			operationRef, err := r.createTopicConfigurationOperation(ctx, cluster, topic.Spec.Name, topic.Spec.ReplicationFactor)
+			if err != nil {
+				return false, err
+			}
+			reassignment.CruiseControlOperationReference = &operationRef
+		}
+	}
+
+	condition := replicationFactorCondition(reassignment, mismatch, existing.ReplicationFactor)
+	condition.ObservedGeneration = topic.GetGeneration()
+	changed := !reflect.DeepEqual(reassignment, topic.Status.Reassignment)
+	topic.Status.Reassignment = reassignment
+	for _, c := range []metav1.Condition{
+		condition,
+		k8sutil.NewCondition(v1beta1.ConditionProgressing, reassignment.IsInProgress(), topic.GetGeneration(), condition.Reason, condition.Message),
+		k8sutil.NewCondition(v1beta1.ConditionDegraded, mismatch && !reassignment.IsInProgress(), topic.GetGeneration(), condition.Reason, condition.Message),
+	} {
+		if meta.SetStatusCondition(&topic.Status.Conditions, c) {
+			changed = true
+		}
+	}
+	if changed {
+		if err := r.Client.Status().Update(ctx, topic); err != nil {
+			return false, err
+		}
+	}
+	return reassignment.IsInProgress(), nil
+}
+
+// replicationFactorCondition returns the ReplicationFactorReconciled condition of the topic
+func replicationFactorCondition(reassignment *v1alpha1.TopicReassignmentStatus, mismatch bool, currentReplicationFactor int16) metav1.Condition {
+	switch {
+	case reassignment.IsInProgress() && reassignment.State == v1alpha1.TopicReassignmentStatePending:
+		return metav1.Condition{
+			Type:    v1alpha1.TopicConditionReplicationFactorReconciled,
+			Status:  metav1.ConditionFalse,
+			Reason:  "ReassignmentPending",
+			Message: fmt.Sprintf("replication factor change from %d to %d is waiting for execution", currentReplicationFactor, reassignment.TargetReplicationFactor),
+		}
+	case reassignment.IsInProgress():
+		return metav1.Condition{
+			Type:    v1alpha1.TopicConditionReplicationFactorReconciled,
+			Status:  metav1.ConditionFalse,
+			Reason:  "ReassignmentInProgress",
+			Message: fmt.Sprintf("replicas are being reassigned to change the replication factor from %d to %d", currentReplicationFactor, reassignment.TargetReplicationFactor),
+		}
+	case mismatch:
+		message := "replica reassignment failed"
+		if reassignment != nil && reassignment.ErrorMessage != "" {
+			message = fmt.Sprintf("%s: %s", message, reassignment.ErrorMessage)
+		}
+		return metav1.Condition{
+			Type:    v1alpha1.TopicConditionReplicationFactorReconciled,
+			Status:  metav1.ConditionFalse,
+			Reason:  "ReassignmentFailed",
+			Message: message,
+		}
+	default:
+		return metav1.Condition{
+			Type:   v1alpha1.TopicConditionReplicationFactorReconciled,
+			Status: metav1.ConditionTrue,
+			Reason: "ReplicationFactorMatches",
+		}
+	}
+}
+
+// updateReassignmentProgress updates the given reassignment status from the ongoing partition reassignments of the
+// topic and the state of the CruiseControlOperation executing it
+func (r *KafkaTopicReconciler) updateReassignmentProgress(ctx context.Context, broker kafkaclient.KafkaClient, cluster *v1beta1.KafkaCluster,
+	topicName string, existing *sarama.TopicDetail, reassignment *v1alpha1.TopicReassignmentStatus) error {
+	partitions := make([]int32, 0, existing.NumPartitions)
+	for i := int32(0); i < existing.NumPartitions; i++ {
+		partitions = append(partitions, i)
+	}
+	ongoing, err := broker.ListPartitionReassignments(topicName, partitions)
+	if err != nil {
+		return err
+	}
+	now := metav1.Now()
+	reassignment.Partitions = mergePartitionReassignmentProgress(reassignment.Partitions, ongoing, now)
+
+	// the reassignment applied through the admin API is finished when none of the partitions is reassigned anymore
+	if reassignment.CruiseControlOperationReference == nil {
+		switch {
+		case len(ongoing) > 0:
+			reassignment.State = v1alpha1.TopicReassignmentStateInProgress
+		case int16(reassignment.TargetReplicationFactor) == existing.ReplicationFactor:
+			completeReassignment(reassignment, now)
+		default:
+			reassignment.State = v1alpha1.TopicReassignmentStateFailed
+			reassignment.ErrorMessage = fmt.Sprintf("replica reassignment finished with replication factor %d", existing.ReplicationFactor)
+		}
+		return nil
+	}
+
+	operation := &v1alpha1.CruiseControlOperation{}
+	err = r.Client.Get(ctx, client.ObjectKey{Name: reassignment.CruiseControlOperationReference.Name, Namespace: cluster.Namespace}, operation)
+	switch {
+	case apierrors.IsNotFound(err):
+		// the operation can be removed right after it finished when ttlSecondsAfterFinished is set
+		if int16(reassignment.TargetReplicationFactor) == existing.ReplicationFactor && len(ongoing) == 0 {
+			completeReassignment(reassignment, now)
+		} else {
+			reassignment.State = v1alpha1.TopicReassignmentStateFailed
+			reassignment.ErrorMessage = fmt.Sprintf("CruiseControlOperation %s/%s not found", cluster.Namespace, reassignment.CruiseControlOperationReference.Name)
+		}
+	case err != nil:
+		return err
+	case operation.IsFinished():
+		completeReassignment(reassignment, now)
+	case operation.IsDone():
+		reassignment.State = v1alpha1.TopicReassignmentStateFailed
+		reassignment.ErrorMessage = operation.CurrentTask().ErrorMessage
+	case operation.IsInProgress():
+		reassignment.State = v1alpha1.TopicReassignmentStateInProgress
+	default:
+		if operation.CurrentTask() != nil {
+			reassignment.ErrorMessage = operation.CurrentTask().ErrorMessage
+		}
+	}
+	return nil
+}
+
+func completeReassignment(reassignment *v1alpha1.TopicReassignmentStatus, now metav1.Time) {
+	reassignment.State = v1alpha1.TopicReassignmentStateCompleted
+	reassignment.CompletedAt = &now
+	reassignment.ErrorMessage = ""
+	reassignment.Partitions = mergePartitionReassignmentProgress(reassignment.Partitions, nil, now)
+}
+
+// mergePartitionReassignmentProgress records the ongoing partition reassignments and marks the previously
+// ongoing ones which are not listed anymore as completed
+func mergePartitionReassignmentProgress(partitions []v1alpha1.PartitionReassignmentStatus,
+	ongoing map[int32]*sarama.PartitionReplicaReassignmentsStatus, now metav1.Time) []v1alpha1.PartitionReassignmentStatus {
+	merged := make([]v1alpha1.PartitionReassignmentStatus, 0, len(partitions)+len(ongoing))
+	for _, partition := range partitions {
+		if _, ok := ongoing[partition.Partition]; ok {
+			continue
+		}
+		if partition.CompletedAt == nil {
+			partition.AddingReplicas = nil
+			partition.RemovingReplicas = nil
+			partition.CompletedAt = &now
+		}
+		merged = append(merged, partition)
+	}
+	for id, status := range ongoing {
+		merged = append(merged, v1alpha1.PartitionReassignmentStatus{
+			Partition:        id,
+			Replicas:         status.Replicas,
+			AddingReplicas:   status.AddingReplicas,
+			RemovingReplicas: status.RemovingReplicas,
+		})
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Partition < merged[j].Partition
+	})
+	return merged
+}
+
+func (r *KafkaTopicReconciler) createTopicConfigurationOperation(ctx context.Context, cluster *v1beta1.KafkaCluster,
+	topicName string, replicationFactor int32) (corev1.LocalObjectReference, error) {
+	operation := &v1alpha1.CruiseControlOperation{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-%s-", cluster.Name, strings.ReplaceAll(string(v1alpha1.OperationTopicConfiguration), "_", "")),
+			Namespace:    cluster.Namespace,
+			Labels:       apiutil.LabelsForKafka(cluster.Name),
+		},
+		Spec: v1alpha1.CruiseControlOperationSpec{
+			ErrorPolicy:             v1alpha1.ErrorPolicyRetry,
+			TTLSecondsAfterFinished: cluster.Spec.CruiseControlConfig.CruiseControlOperationSpec.GetTTLSecondsAfterFinished(),
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(cluster, operation, r.Scheme); err != nil {
+		return corev1.LocalObjectReference{}, err
+	}
+	if err := r.Client.Create(ctx, operation); err != nil {
+		return corev1.LocalObjectReference{}, err
+	}
+
+	operation.Status.CurrentTask = &v1alpha1.CruiseControlTask{
+		Operation: v1alpha1.OperationTopicConfiguration,
+		Parameters: map[string]string{
+			// the topic parameter is a regular expression
+			scale.ParamTopic:             regexp.QuoteMeta(topicName),
+			scale.ParamReplicationFactor: strconv.Itoa(int(replicationFactor)),
+			scale.ParamExcludeDemoted:    True,
+			scale.ParamExcludeRemoved:    True,
+		},
+	}
+	if err := r.Client.Status().Update(ctx, operation); err != nil {
+		return corev1.LocalObjectReference{}, err
+	}
+	return corev1.LocalObjectReference{
+		Name: operation.Name,
+	}, nil
+}
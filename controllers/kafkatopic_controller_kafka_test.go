@@ -15,13 +15,24 @@
 package controllers
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/IBM/sarama"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/resources/kafka/mocks"
+	"github.com/banzaicloud/koperator/pkg/util"
 	"github.com/banzaicloud/koperator/pkg/webhooks"
 )
 
@@ -68,3 +79,282 @@ func TestIsTopicManagedByKoperator(t *testing.T) {
 		})
 	}
 }
+
+func TestMergePartitionReassignmentProgress(t *testing.T) {
+	before := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(before.Add(time.Minute))
+
+	testCases := []struct {
+		testName   string
+		partitions []v1alpha1.PartitionReassignmentStatus
+		ongoing    map[int32]*sarama.PartitionReplicaReassignmentsStatus
+		expected   []v1alpha1.PartitionReassignmentStatus
+	}{
+		{
+			testName: "no reassignment",
+			expected: []v1alpha1.PartitionReassignmentStatus{},
+		},
+		{
+			testName: "ongoing reassignments are recorded in partition order",
+			ongoing: map[int32]*sarama.PartitionReplicaReassignmentsStatus{
+				1: {Replicas: []int32{1, 2, 0}, AddingReplicas: []int32{0}},
+				0: {Replicas: []int32{0, 1}, RemovingReplicas: []int32{1}},
+			},
+			expected: []v1alpha1.PartitionReassignmentStatus{
+				{Partition: 0, Replicas: []int32{0, 1}, RemovingReplicas: []int32{1}},
+				{Partition: 1, Replicas: []int32{1, 2, 0}, AddingReplicas: []int32{0}},
+			},
+		},
+		{
+			testName: "partitions which are not reassigned anymore are completed",
+			partitions: []v1alpha1.PartitionReassignmentStatus{
+				{Partition: 0, Replicas: []int32{0, 1}, CompletedAt: &before},
+				{Partition: 1, Replicas: []int32{1, 2, 0}, AddingReplicas: []int32{0}},
+				{Partition: 2, Replicas: []int32{2, 0, 1}, AddingReplicas: []int32{1}},
+			},
+			ongoing: map[int32]*sarama.PartitionReplicaReassignmentsStatus{
+				2: {Replicas: []int32{2, 0, 1}, AddingReplicas: []int32{1}},
+			},
+			expected: []v1alpha1.PartitionReassignmentStatus{
+				{Partition: 0, Replicas: []int32{0, 1}, CompletedAt: &before},
+				{Partition: 1, Replicas: []int32{1, 2, 0}, CompletedAt: &now},
+				{Partition: 2, Replicas: []int32{2, 0, 1}, AddingReplicas: []int32{1}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, mergePartitionReassignmentProgress(testCase.partitions, testCase.ongoing, now))
+		})
+	}
+}
+
+func TestReplicationFactorCondition(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		reassignment   *v1alpha1.TopicReassignmentStatus
+		mismatch       bool
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			testName:       "replication factor matches",
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: "ReplicationFactorMatches",
+		},
+		{
+			testName:       "reassignment completed",
+			reassignment:   &v1alpha1.TopicReassignmentStatus{State: v1alpha1.TopicReassignmentStateCompleted},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: "ReplicationFactorMatches",
+		},
+		{
+			testName:       "reassignment pending",
+			reassignment:   &v1alpha1.TopicReassignmentStatus{State: v1alpha1.TopicReassignmentStatePending},
+			mismatch:       true,
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: "ReassignmentPending",
+		},
+		{
+			testName:       "reassignment in progress",
+			reassignment:   &v1alpha1.TopicReassignmentStatus{State: v1alpha1.TopicReassignmentStateInProgress},
+			mismatch:       true,
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: "ReassignmentInProgress",
+		},
+		{
+			testName:       "reassignment failed",
+			reassignment:   &v1alpha1.TopicReassignmentStatus{State: v1alpha1.TopicReassignmentStateFailed},
+			mismatch:       true,
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: "ReassignmentFailed",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+			condition := replicationFactorCondition(testCase.reassignment, testCase.mismatch, 2)
+			assert.Equal(t, v1alpha1.TopicConditionReplicationFactorReconciled, condition.Type)
+			assert.Equal(t, testCase.expectedStatus, condition.Status)
+			assert.Equal(t, testCase.expectedReason, condition.Reason)
+		})
+	}
+}
+
+func TestCheckFinalizersDeletionProtection(t *testing.T) {
+	testCases := []struct {
+		testName          string
+		clusterProtection bool
+		topicProtection   *bool
+		expectedDelete    bool
+		expectedOrphaned  []string
+		expectedEvents    []string
+	}{
+		{
+			testName:       "topic is not protected",
+			expectedDelete: true,
+		},
+		{
+			testName:         "topic is protected",
+			topicProtection:  util.BoolPointer(true),
+			expectedOrphaned: []string{"test-topic"},
+			expectedEvents:   []string{"Warning TopicOrphaned topic test-topic is protected against deletion, it is left on the Kafka cluster kafka"},
+		},
+		{
+			testName:          "topics of the cluster are protected by default",
+			clusterProtection: true,
+			expectedOrphaned:  []string{"test-topic"},
+			expectedEvents:    []string{"Warning TopicOrphaned topic test-topic is protected against deletion, it is left on the Kafka cluster kafka"},
+		},
+		{
+			testName:          "topic opts out of the protection of the cluster",
+			clusterProtection: true,
+			topicProtection:   util.BoolPointer(false),
+			expectedDelete:    true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			broker := mocks.NewMockKafkaClient(mockCtrl)
+			if test.expectedDelete {
+				broker.EXPECT().GetTopic("test-topic").Return(&sarama.TopicDetail{}, nil)
+				broker.EXPECT().DeleteTopic("test-topic", true).Return(nil)
+			}
+
+			now := metav1.Now()
+			topic := &v1alpha1.KafkaTopic{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test-topic",
+					Namespace:         "kafka",
+					Finalizers:        []string{topicFinalizer},
+					DeletionTimestamp: &now,
+				},
+				Spec: v1alpha1.KafkaTopicSpec{
+					Name:               "test-topic",
+					ClusterRef:         v1alpha1.ClusterReference{Name: "kafka"},
+					DeletionProtection: test.topicProtection,
+				},
+			}
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Spec:       v1beta1.KafkaClusterSpec{TopicDeletionProtection: test.clusterProtection},
+			}
+
+			scheme := runtime.NewScheme()
+			assert.NoError(t, v1alpha1.AddToScheme(scheme))
+			assert.NoError(t, v1beta1.AddToScheme(scheme))
+			recorder := record.NewFakeRecorder(10)
+			r := KafkaTopicReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(topic, cluster).WithStatusSubresource(cluster).Build(),
+				Recorder: recorder,
+			}
+
+			_, err := r.checkFinalizers(context.Background(), broker, cluster, topic)
+			assert.NoError(t, err)
+			assert.NotContains(t, topic.GetFinalizers(), topicFinalizer)
+			assert.NoError(t, r.Client.Get(context.Background(), client.ObjectKeyFromObject(cluster), cluster))
+			assert.Equal(t, test.expectedOrphaned, cluster.Status.OrphanedTopics)
+
+			close(recorder.Events)
+			var events []string
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+			assert.Equal(t, test.expectedEvents, events)
+		})
+	}
+}
+
+func TestAdoptTopic(t *testing.T) {
+	testCases := []struct {
+		testName           string
+		partitions         int32
+		config             map[string]string
+		expectedPartitions bool
+		expectedConfig     bool
+		expectedEvents     []string
+	}{
+		{
+			testName:       "spec differing from the live topic is not enforced",
+			partitions:     2,
+			expectedEvents: []string{"Normal TopicAdopted existing topic test-topic with 6 partitions and replication factor 3 has been adopted"},
+		},
+		{
+			testName:           "explicitly increased partition count is reconciled",
+			partitions:         12,
+			expectedPartitions: true,
+			expectedEvents: []string{
+				"Normal TopicAdopted existing topic test-topic with 6 partitions and replication factor 3 has been adopted",
+				"Normal PartitionsIncreased the partition count of the topic has been increased to 12",
+			},
+		},
+		{
+			testName:       "only the configs of the spec are set",
+			partitions:     -1,
+			config:         map[string]string{"retention.ms": "3600000"},
+			expectedConfig: true,
+			expectedEvents: []string{"Normal TopicAdopted existing topic test-topic with 6 partitions and replication factor 3 has been adopted"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			broker := mocks.NewMockKafkaClient(mockCtrl)
+			if test.expectedPartitions {
+				broker.EXPECT().EnsurePartitionCount("test-topic", test.partitions).Return(true, nil)
+			}
+			if test.expectedConfig {
+				broker.EXPECT().SetTopicConfig("test-topic", util.MapStringStringPointer(test.config)).Return(nil)
+			}
+
+			topic := &v1alpha1.KafkaTopic{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-topic", Namespace: "kafka"},
+				Spec: v1alpha1.KafkaTopicSpec{
+					Name:       "test-topic",
+					Partitions: test.partitions,
+					Config:     test.config,
+					ClusterRef: v1alpha1.ClusterReference{Name: "kafka"},
+				},
+			}
+			existing := &sarama.TopicDetail{
+				NumPartitions:     6,
+				ReplicationFactor: 3,
+				ConfigEntries:     map[string]*string{"cleanup.policy": util.StringPointer("compact")},
+			}
+
+			scheme := runtime.NewScheme()
+			assert.NoError(t, v1alpha1.AddToScheme(scheme))
+			recorder := record.NewFakeRecorder(10)
+			r := KafkaTopicReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(topic).WithStatusSubresource(topic).Build(),
+				Recorder: recorder,
+			}
+
+			assert.NoError(t, r.adoptTopic(context.Background(), topic, existing))
+			assert.NoError(t, r.reconcileAdoptedTopic(context.Background(), broker, topic, existing))
+
+			persisted := &v1alpha1.KafkaTopic{}
+			assert.NoError(t, r.Client.Get(context.Background(), client.ObjectKeyFromObject(topic), persisted))
+			if assert.NotNil(t, persisted.Status.Adoption) {
+				assert.Equal(t, int32(6), persisted.Status.Adoption.Partitions)
+				assert.Equal(t, int32(3), persisted.Status.Adoption.ReplicationFactor)
+				assert.Equal(t, map[string]string{"cleanup.policy": "compact"}, persisted.Status.Adoption.Config)
+			}
+
+			close(recorder.Events)
+			var events []string
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+			assert.Equal(t, test.expectedEvents, events)
+		})
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/IBM/sarama"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/util"
+	"github.com/banzaicloud/koperator/pkg/webhooks"
+)
+
+const (
+	// topicsImportedEventReason is the reason of the events reporting the KafkaTopics generated by the topic discovery
+	topicsImportedEventReason = "TopicsImported"
+	// internalTopicPrefix is the prefix of the internal topics of Kafka and Cruise Control, they are never imported
+	internalTopicPrefix = "__"
+)
+
+// invalidResourceNameChars matches the characters not allowed in the name of a resource
+var invalidResourceNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// SetupKafkaTopicDiscoveryWithManager registers the topic discovery controller to the manager
+func SetupKafkaTopicDiscoveryWithManager(mgr ctrl.Manager) *ctrl.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.KafkaCluster{}).
+		WithEventFilter(SkipClusterRegistryOwnedResourcePredicate{}).
+		WithEventFilter(newOperatorScopePredicate(mgr)).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Named("KafkaTopicDiscovery")
+}
+
+// blank assignment to verify that KafkaTopicDiscoveryReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &KafkaTopicDiscoveryReconciler{}
+
+// KafkaTopicDiscoveryReconciler periodically lists the topics of the KafkaClusters and generates a KafkaTopic, labeled
+// as imported, in the namespace of the cluster for each topic without one. It brings the topics of the existing
+// clusters under the management of the operator in bulk, the internal topics are skipped.
+type KafkaTopicDiscoveryReconciler struct {
+	Client   client.Client
+	Recorder record.EventRecorder
+	// Interval is the time between the discoveries of the topics of a cluster
+	Interval time.Duration
+}
+
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkatopics,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkaclusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile imports the topics of the cluster without a KafkaTopic
+func (r *KafkaTopicDiscoveryReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	cluster := &v1beta1.KafkaCluster{}
+	if err := r.Client.Get(ctx, request.NamespacedName, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconciled()
+		}
+		return requeueWithError(log, err.Error(), err)
+	}
+	if !cluster.DeletionTimestamp.IsZero() {
+		return reconciled()
+	}
+
+	topics := &v1alpha1.KafkaTopicList{}
+	if err := r.Client.List(ctx, topics); err != nil {
+		return requeueWithError(log, "failed to list KafkaTopics", err)
+	}
+	managed := make(map[string]struct{})
+	for _, topic := range topics.Items {
+		if topic.Spec.ClusterRef.Name == cluster.Name && getClusterRefNamespace(topic.Namespace, topic.Spec.ClusterRef) == cluster.Namespace {
+			managed[topic.Spec.Name] = struct{}{}
+		}
+	}
+
+	broker, close, err := connectKafka(ctx, r.Client, cluster)
+	if err != nil {
+		return checkBrokerConnectionError(log, err)
+	}
+	defer close()
+
+	existing, err := broker.ListTopics()
+	if err != nil {
+		return requeueWithError(log, "failed to list the topics of the cluster", err)
+	}
+
+	// the orphaned topics are left alone until they are deleted from the cluster or managed again
+	orphaned := make(map[string]struct{}, len(cluster.Status.OrphanedTopics))
+	for _, name := range cluster.Status.OrphanedTopics {
+		_, isManaged := managed[name]
+		if _, exists := existing[name]; exists && !isManaged {
+			orphaned[name] = struct{}{}
+		}
+	}
+	if len(orphaned) != len(cluster.Status.OrphanedTopics) {
+		if err := updateOrphanedTopics(ctx, r.Client, cluster, func(topics []string) []string {
+			remaining := make([]string, 0, len(topics))
+			for _, name := range topics {
+				if _, ok := orphaned[name]; ok {
+					remaining = append(remaining, name)
+				}
+			}
+			return remaining
+		}); err != nil {
+			return requeueWithError(log, "failed to update the orphaned topics of the cluster", err)
+		}
+	}
+
+	names := make([]string, 0, len(existing))
+	for name := range existing {
+		_, isManaged := managed[name]
+		_, isOrphaned := orphaned[name]
+		if !isManaged && !isOrphaned && !strings.HasPrefix(name, internalTopicPrefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var imported []string
+	var combinedErr error
+	for _, name := range names {
+		topic := newImportedKafkaTopic(cluster, name, existing[name])
+		if err := r.Client.Create(ctx, topic); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				log.Info("KafkaTopic of the imported topic already exists for another topic, skipping it",
+					"topic", name, "kafkaTopic", topic.Name)
+				continue
+			}
+			combinedErr = errors.Combine(combinedErr, errors.WrapIfWithDetails(err, "failed to import topic", "topic", name))
+			continue
+		}
+		imported = append(imported, name)
+	}
+	if len(imported) > 0 {
+		log.Info("Imported the topics of the cluster", "topics", imported)
+		k8sutil.RecordEvent(r.Recorder, cluster, corev1.EventTypeNormal, topicsImportedEventReason,
+			"%d topics have been imported as KafkaTopics: %s", len(imported), strings.Join(imported, ", "))
+	}
+	if combinedErr != nil {
+		return requeueWithError(log, "failed to import topics of the cluster", combinedErr)
+	}
+
+	return ctrl.Result{RequeueAfter: r.Interval}, nil
+}
+
+// addOrphanedTopic records the topic left on the Kafka cluster by the deletion of its deletion-protected KafkaTopic
+func addOrphanedTopic(ctx context.Context, c client.Client, cluster *v1beta1.KafkaCluster, name string) error {
+	return updateOrphanedTopics(ctx, c, cluster, func(topics []string) []string {
+		for _, topic := range topics {
+			if topic == name {
+				return topics
+			}
+		}
+		topics = append(append(make([]string, 0, len(topics)+1), topics...), name)
+		sort.Strings(topics)
+		return topics
+	})
+}
+
+// updateOrphanedTopics updates the orphaned topics in the status of the cluster, the update is applied again to the
+// latest status on conflict since the topics are recorded by the KafkaTopic controller and pruned by the discovery
+func updateOrphanedTopics(ctx context.Context, c client.Client, cluster *v1beta1.KafkaCluster, update func([]string) []string) error {
+	return util.RetryOnConflict(util.DefaultBackOffForConflict, func() error {
+		topics := update(cluster.Status.OrphanedTopics)
+		if reflect.DeepEqual(topics, cluster.Status.OrphanedTopics) {
+			return nil
+		}
+		cluster.Status.OrphanedTopics = topics
+		err := c.Status().Update(ctx, cluster)
+		if apierrors.IsConflict(err) {
+			if err := c.Get(ctx, client.ObjectKeyFromObject(cluster), cluster); err != nil {
+				return errors.WrapIf(err, "failed to get the KafkaCluster before updating its orphaned topics")
+			}
+		}
+		return err
+	})
+}
+
+// newImportedKafkaTopic returns the KafkaTopic of the existing topic, its spec matches the live topic so it is managed
+// without changing the topic
+func newImportedKafkaTopic(cluster *v1beta1.KafkaCluster, name string, detail sarama.TopicDetail) *v1alpha1.KafkaTopic {
+	config := make(map[string]string, len(detail.ConfigEntries))
+	for key, value := range detail.ConfigEntries {
+		if value != nil {
+			config[key] = *value
+		}
+	}
+	labels := applyClusterRefLabel(cluster, map[string]string{v1alpha1.ImportedLabelKey: "true"})
+	return &v1alpha1.KafkaTopic{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        importedResourceName(name),
+			Namespace:   cluster.Namespace,
+			Labels:      labels,
+			Annotations: map[string]string{webhooks.TopicManagedByAnnotationKey: webhooks.TopicManagedByKoperatorAnnotationValue},
+		},
+		Spec: v1alpha1.KafkaTopicSpec{
+			Name:              name,
+			Partitions:        detail.NumPartitions,
+			ReplicationFactor: int32(detail.ReplicationFactor),
+			Config:            config,
+			ClusterRef:        v1alpha1.ClusterReference{Name: cluster.Name, Namespace: cluster.Namespace},
+		},
+	}
+}
+
+// importedResourceName returns the name of the resource imported for the topic or principal, the names which are not
+// valid resource names are sanitized and suffixed with the hash of the original name to keep them unique
+func importedResourceName(original string) string {
+	if len(validation.IsDNS1123Subdomain(original)) == 0 {
+		return original
+	}
+	suffix := "-" + util.GetMD5Hash(original)[:8]
+	name := strings.Trim(invalidResourceNameChars.ReplaceAllString(strings.ToLower(original), "-"), ".-")
+	if maxLength := validation.DNS1123SubdomainMaxLength - len(suffix); len(name) > maxLength {
+		name = strings.TrimRight(name[:maxLength], ".-")
+	}
+	if name == "" {
+		name = "imported"
+	}
+	return name + suffix
+}
@@ -0,0 +1,141 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/kafkaclient"
+	"github.com/banzaicloud/koperator/pkg/resources/kafka/mocks"
+	"github.com/banzaicloud/koperator/pkg/util"
+)
+
+func TestKafkaTopicDiscoveryReconcile(t *testing.T) {
+	cluster := &v1beta1.KafkaCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+		Status:     v1beta1.KafkaClusterStatus{OrphanedTopics: []string{"audit", "deleted", "orders"}},
+	}
+	managedTopic := &v1alpha1.KafkaTopic{
+		ObjectMeta: metav1.ObjectMeta{Name: "orders-topic", Namespace: "apps"},
+		Spec:       v1alpha1.KafkaTopicSpec{Name: "orders", ClusterRef: v1alpha1.ClusterReference{Name: "kafka", Namespace: "kafka"}},
+	}
+
+	mockCtrl := gomock.NewController(t)
+	broker := mocks.NewMockKafkaClient(mockCtrl)
+	broker.EXPECT().ListTopics().Return(map[string]sarama.TopicDetail{
+		"orders":             {NumPartitions: 3, ReplicationFactor: 3},
+		"audit":              {NumPartitions: 1, ReplicationFactor: 3},
+		"payments":           {NumPartitions: 6, ReplicationFactor: 2, ConfigEntries: map[string]*string{"retention.ms": util.StringPointer("3600000")}},
+		"__consumer_offsets": {NumPartitions: 50, ReplicationFactor: 3},
+	}, nil)
+	SetNewKafkaFromCluster(func(client.Client, *v1beta1.KafkaCluster) (kafkaclient.KafkaClient, func(), error) {
+		return broker, func() {}, nil
+	})
+	defer SetNewKafkaFromCluster(kafkaclient.NewFromCluster)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, managedTopic).WithStatusSubresource(cluster).Build()
+	recorder := record.NewFakeRecorder(10)
+
+	r := &KafkaTopicDiscoveryReconciler{Client: c, Recorder: recorder, Interval: time.Hour}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}}
+	result, err := r.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, result.RequeueAfter)
+
+	topics := &v1alpha1.KafkaTopicList{}
+	require.NoError(t, c.List(context.Background(), topics, client.MatchingLabels{v1alpha1.ImportedLabelKey: "true"}))
+	require.Len(t, topics.Items, 1)
+	imported := topics.Items[0]
+	require.Equal(t, "payments", imported.Name)
+	require.Equal(t, "kafka", imported.Namespace)
+	require.Equal(t, "kafka.kafka", imported.Labels[clusterRefLabel])
+	require.Equal(t, v1alpha1.KafkaTopicSpec{
+		Name:              "payments",
+		Partitions:        6,
+		ReplicationFactor: 2,
+		Config:            map[string]string{"retention.ms": "3600000"},
+		ClusterRef:        v1alpha1.ClusterReference{Name: "kafka", Namespace: "kafka"},
+	}, imported.Spec)
+
+	// the orphaned topics deleted from the cluster or managed again are pruned
+	require.NoError(t, c.Get(context.Background(), request.NamespacedName, cluster))
+	require.Equal(t, []string{"audit"}, cluster.Status.OrphanedTopics)
+
+	close(recorder.Events)
+	var events []string
+	for event := range recorder.Events {
+		events = append(events, event)
+	}
+	require.Equal(t, []string{"Normal TopicsImported 1 topics have been imported as KafkaTopics: payments"}, events)
+}
+
+func TestImportedResourceName(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		topic          string
+		expectedPrefix string
+		expectedHashed bool
+	}{
+		{
+			testName:       "valid resource name is kept",
+			topic:          "payments.orders-v1",
+			expectedPrefix: "payments.orders-v1",
+		},
+		{
+			testName:       "invalid characters are replaced",
+			topic:          "Payments_Orders",
+			expectedPrefix: "payments-orders",
+			expectedHashed: true,
+		},
+		{
+			testName:       "leading and trailing separators are trimmed",
+			topic:          "_orders_",
+			expectedPrefix: "orders",
+			expectedHashed: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			name := importedResourceName(test.topic)
+			if !test.expectedHashed {
+				require.Equal(t, test.expectedPrefix, name)
+				return
+			}
+			require.True(t, strings.HasPrefix(name, test.expectedPrefix+"-"), name)
+			require.Len(t, name, len(test.expectedPrefix)+9)
+		})
+	}
+}
@@ -16,6 +16,7 @@
 package controllers
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"reflect"
@@ -31,12 +32,17 @@ import (
 	certv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/go-logr/logr"
 	certsigningreqv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlBuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -47,21 +53,37 @@ import (
 	"github.com/banzaicloud/koperator/api/v1beta1"
 	"github.com/banzaicloud/koperator/pkg/errorfactory"
 	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/kafkaclient"
 	"github.com/banzaicloud/koperator/pkg/pki"
 	"github.com/banzaicloud/koperator/pkg/util"
+	certutil "github.com/banzaicloud/koperator/pkg/util/cert"
 	kafkautil "github.com/banzaicloud/koperator/pkg/util/kafka"
+	"github.com/banzaicloud/koperator/pkg/util/metrics"
+	"github.com/banzaicloud/koperator/pkg/util/ownership"
 	pkicommon "github.com/banzaicloud/koperator/pkg/util/pki"
+	"github.com/banzaicloud/koperator/pkg/util/tracing"
 )
 
 var userFinalizer = "finalizer.kafkausers.kafka.banzaicloud.io"
 
+// scramPasswordLength is the length of the generated SCRAM passwords
+const scramPasswordLength = 32
+
+// The reasons of the events emitted on the KafkaUsers
+const (
+	userCreatedEventReason           = "UserCreated"
+	userCertificateFailedEventReason = "CertificateReconcileFailed"
+)
+
 // SetupKafkaUserWithManager registers KafkaUser controller to the manager
-func SetupKafkaUserWithManager(mgr ctrl.Manager, certSigningEnabled bool, certManagerEnabled bool) *ctrl.Builder {
+func SetupKafkaUserWithManager(mgr ctrl.Manager, certSigningEnabled bool, certManagerEnabled bool, maxConcurrentReconciles int) *ctrl.Builder {
 	log := mgr.GetLogger()
 	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.KafkaUser{}).
 		WithEventFilter(SkipClusterRegistryOwnedResourcePredicate{}).
+		WithEventFilter(newOperatorScopePredicate(mgr)).
 		Named("KafkaUser")
+	builder.WithOptions(controllerOptions(maxConcurrentReconciles))
 	if certSigningEnabled {
 		csrMapper := csrMapper{
 			client: mgr.GetClient(),
@@ -150,6 +172,8 @@ type KafkaUserReconciler struct {
 	// that reads objects from the cache and writes to the apiserver
 	Client client.Client
 	Scheme *runtime.Scheme
+	// Recorder emits the events of the KafkaUsers, e.g. the creation of the user
+	Recorder record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkausers,verbs=get;list;watch;create;update;patch;delete;deletecollection
@@ -161,12 +185,16 @@ type KafkaUserReconciler struct {
 // +kubebuilder:rbac:groups=certificates.k8s.io,resources=certificatesigningrequests,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=certificates.k8s.io,resources=certificatesigningrequests/approval,verbs=update
 // +kubebuilder:rbac:groups=certificates.k8s.io,resources=signers,verbs=approve
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile reads that state of the cluster for a KafkaUser object and makes changes based on the state read
 // and what is in the KafkaUser.Spec
 func (r *KafkaUserReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	reqLogger := logr.FromContextOrDiscard(ctx)
 	reqLogger.Info("Reconciling KafkaUser")
+	start := time.Now()
+	ctx, span := tracing.StartReconcile(ctx, "KafkaUser", request)
+	defer span.End()
 	var err error
 
 	// Fetch the KafkaUser instance
@@ -174,11 +202,13 @@ func (r *KafkaUserReconciler) Reconcile(ctx context.Context, request reconcile.R
 	if err = r.Client.Get(ctx, request.NamespacedName, instance); err != nil {
 		if apierrors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
+			metrics.DeleteReconcileDuration("KafkaUser", request)
 			return reconciled()
 		}
 		// Error reading the object - requeue the request.
 		return requeueWithError(reqLogger, err.Error(), err)
 	}
+	defer metrics.ObserveReconcileDuration("KafkaUser", request, start)
 
 	// Get the referenced kafkacluster
 	clusterNamespace := getClusterRefNamespace(instance.Namespace, instance.Spec.ClusterRef)
@@ -195,9 +225,19 @@ func (r *KafkaUserReconciler) Reconcile(ctx context.Context, request reconcile.R
 		return requeueWithError(reqLogger, "failed to lookup referenced cluster", err)
 	}
 
+	// The teardown of the referenced cluster deletes its users, they are no longer reconciled until then
+	if k8sutil.IsMarkedForDeletion(cluster.ObjectMeta) && !k8sutil.IsMarkedForDeletion(instance.ObjectMeta) {
+		reqLogger.Info("Referenced cluster is being deleted, skipping reconciliation")
+		return reconciled()
+	}
+
 	var kafkaUser string
+	var certificateRenewalDelay time.Duration
 
-	if instance.Spec.GetIfCertShouldBeCreated() {
+	if !instance.Spec.IsCertificateAuthentication() {
+		// SCRAM and OAuth users are not authenticated with certificates, only their principal is needed for the ACLs
+		kafkaUser = instance.GetPrincipalName()
+	} else if instance.Spec.GetIfCertShouldBeCreated() {
 		// Validate the KafkaUser instance annotations before creating a certificate request
 		err := instance.Spec.ValidateAnnotations()
 		if err != nil {
@@ -232,6 +272,8 @@ func (r *KafkaUserReconciler) Reconcile(ctx context.Context, request reconcile.R
 				// But really we should catch these kinds of issues in a pre-admission hook in a future PR
 				// The user can fix while this is looping and it will pick it up next reconcile attempt
 				reqLogger.Error(err, "Fatal error attempting to reconcile the user certificate.")
+				k8sutil.RecordEvent(r.Recorder, instance, corev1.EventTypeWarning, userCertificateFailedEventReason,
+					"the certificate of the user could not be reconciled: %s", err)
 				return ctrl.Result{
 					Requeue:      true,
 					RequeueAfter: time.Duration(15) * time.Second,
@@ -247,6 +289,7 @@ func (r *KafkaUserReconciler) Reconcile(ctx context.Context, request reconcile.R
 				return requeueWithError(reqLogger, "failed to reconcile user secret", err)
 			}
 		}
+		certificateRenewalDelay = pki.UserCertificateRenewalDelay(cluster, backend, user)
 		kafkaUser, err = user.GetDistinguishedName()
 		if err != nil {
 			reqLogger.Error(err, "could not get Distinguished Name from the generated TLS certificate", "cert", string(user.Certificate))
@@ -265,19 +308,49 @@ func (r *KafkaUserReconciler) Reconcile(ctx context.Context, request reconcile.R
 		kafkaUser = fmt.Sprintf("CN=%s", instance.Name)
 	}
 
+	// use the same principal name in the ACLs that the brokers resolve for the user certificate
+	if instance.Spec.IsCertificateAuthentication() {
+		if kafkaUser, err = kafkautil.GetPrincipalNameForDistinguishedName(cluster, kafkaUser); err != nil {
+			return requeueWithError(reqLogger, "failed to map the user certificate to principal name using the SSL principal mapping rules", err)
+		}
+	}
+
 	// check if marked for deletion and remove kafka ACLs
 	if k8sutil.IsMarkedForDeletion(instance.ObjectMeta) {
 		return r.checkFinalizers(ctx, cluster, instance, kafkaUser)
 	}
 
+	// Only the namespaces of the team owning the topic prefixes may grant access to the topics
+	if err := ownership.CheckKafkaUser(ctx, r.Client, instance); err != nil {
+		return requeueWithError(reqLogger, "kafkauser is not owned by the team of its namespace", err)
+	}
+
 	// ensure a kafkaCluster label
 	if instance, err = r.ensureClusterLabel(ctx, cluster, instance); err != nil {
 		return requeueWithError(reqLogger, "failed to ensure kafkacluster label on user", err)
 	}
 
+	// Ensure the SCRAM credential of the user both in the user secret and on the Kafka cluster
+	var scramCredential *v1alpha1.UserSCRAMCredentialStatus
+	if instance.Spec.IsSCRAMAuthentication() {
+		password, passwordSecretVersion, err := r.ensureSCRAMUserSecret(ctx, instance)
+		if err != nil {
+			return requeueWithError(reqLogger, "failed to ensure SCRAM credentials secret for kafkauser", err)
+		}
+		broker, close, err := connectKafka(ctx, r.Client, cluster)
+		if err != nil {
+			return checkBrokerConnectionError(reqLogger, err)
+		}
+		scramCredential, err = ensureSCRAMCredential(reqLogger, broker, instance, password, passwordSecretVersion)
+		close()
+		if err != nil {
+			return requeueWithError(reqLogger, "failed to ensure SCRAM credential for kafkauser", err)
+		}
+	}
+
 	// If topic grants supplied, grab a broker connection and set ACLs
 	if len(instance.Spec.TopicGrants) > 0 {
-		broker, close, err := newKafkaFromCluster(r.Client, cluster)
+		broker, close, err := connectKafka(ctx, r.Client, cluster)
 		if err != nil {
 			return checkBrokerConnectionError(reqLogger, err)
 		}
@@ -302,9 +375,17 @@ func (r *KafkaUserReconciler) Reconcile(ctx context.Context, request reconcile.R
 	}
 
 	// set user status
+	if instance.Status.State != v1alpha1.UserStateCreated {
+		k8sutil.RecordEvent(r.Recorder, instance, corev1.EventTypeNormal, userCreatedEventReason,
+			"the credentials and the ACLs of user %s have been created on the Kafka cluster %s", kafkaUser, cluster.Name)
+	}
 	instance.Status = v1alpha1.KafkaUserStatus{
-		State: v1alpha1.UserStateCreated,
+		State:           v1alpha1.UserStateCreated,
+		SCRAMCredential: scramCredential,
+		Conditions:      instance.Status.Conditions,
 	}
+	meta.SetStatusCondition(&instance.Status.Conditions, k8sutil.NewCondition(v1beta1.ConditionReady, true, instance.GetGeneration(),
+		"UserCreated", "the credentials and the ACLs of the user are in place"))
 	if len(instance.Spec.TopicGrants) > 0 {
 		instance.Status.ACLs = kafkautil.GrantsToACLStrings(kafkaUser, instance.Spec.TopicGrants)
 	}
@@ -312,6 +393,11 @@ func (r *KafkaUserReconciler) Reconcile(ctx context.Context, request reconcile.R
 		return requeueWithError(reqLogger, "failed to update kafkauser status", err)
 	}
 
+	// the certificates of some PKI backends are renewed by the operator, the user is reconciled again when it is due
+	if certificateRenewalDelay > 0 {
+		return ctrl.Result{RequeueAfter: certificateRenewalDelay}, nil
+	}
+
 	return reconciled()
 }
 
@@ -341,11 +427,16 @@ func (r *KafkaUserReconciler) checkFinalizers(ctx context.Context, cluster *v1be
 	if apiutil.StringSliceContains(instance.GetFinalizers(), userFinalizer) {
 		if len(instance.Spec.TopicGrants) > 0 {
 			for _, topicGrant := range instance.Spec.TopicGrants {
-				if err = r.finalizeKafkaUserACLs(reqLogger, cluster, user, topicGrant.PatternType); err != nil {
+				if err = r.finalizeKafkaUserACLs(ctx, reqLogger, cluster, user, topicGrant.PatternType); err != nil {
 					return requeueWithError(reqLogger, "failed to finalize kafkauser", err)
 				}
 			}
 		}
+		if instance.Spec.IsSCRAMAuthentication() {
+			if err = r.finalizeKafkaUserSCRAMCredential(ctx, reqLogger, cluster, instance); err != nil {
+				return requeueWithError(reqLogger, "failed to finalize kafkauser SCRAM credential", err)
+			}
+		}
 		// remove finalizer
 		if err = r.removeFinalizer(ctx, instance); err != nil {
 			return requeueWithError(reqLogger, "failed to remove finalizer from kafkauser", err)
@@ -360,14 +451,14 @@ func (r *KafkaUserReconciler) removeFinalizer(ctx context.Context, user *v1alpha
 	return err
 }
 
-func (r *KafkaUserReconciler) finalizeKafkaUserACLs(reqLogger logr.Logger, cluster *v1beta1.KafkaCluster, user string, patternType v1alpha1.KafkaPatternType) error {
+func (r *KafkaUserReconciler) finalizeKafkaUserACLs(ctx context.Context, reqLogger logr.Logger, cluster *v1beta1.KafkaCluster, user string, patternType v1alpha1.KafkaPatternType) error {
 	if k8sutil.IsMarkedForDeletion(cluster.ObjectMeta) {
 		reqLogger.Info("Cluster is being deleted, skipping ACL deletion")
 		return nil
 	}
 	var err error
 	reqLogger.Info("Deleting user ACLs from kafka")
-	broker, close, err := newKafkaFromCluster(r.Client, cluster)
+	broker, close, err := connectKafka(ctx, r.Client, cluster)
 	if err != nil {
 		return err
 	}
@@ -378,6 +469,132 @@ func (r *KafkaUserReconciler) finalizeKafkaUserACLs(reqLogger logr.Logger, clust
 	return nil
 }
 
+func (r *KafkaUserReconciler) finalizeKafkaUserSCRAMCredential(ctx context.Context, reqLogger logr.Logger, cluster *v1beta1.KafkaCluster, user *v1alpha1.KafkaUser) error {
+	if k8sutil.IsMarkedForDeletion(cluster.ObjectMeta) {
+		reqLogger.Info("Cluster is being deleted, skipping SCRAM credential deletion")
+		return nil
+	}
+	reqLogger.Info("Deleting user SCRAM credential from kafka")
+	broker, close, err := connectKafka(ctx, r.Client, cluster)
+	if err != nil {
+		return err
+	}
+	defer close()
+	return broker.DeleteUserScramCredential(user.Name, user.Spec.GetAuthenticationType())
+}
+
+// ensureSCRAMUserSecret ensures the secret referenced by spec.secretName exposes the SCRAM credentials of the user and
+// returns the password together with the resource version of the secret it was read from
+func (r *KafkaUserReconciler) ensureSCRAMUserSecret(ctx context.Context, user *v1alpha1.KafkaUser) ([]byte, string, error) {
+	userSecret := &corev1.Secret{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: user.Spec.SecretName, Namespace: user.Namespace}, userSecret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, "", errorfactory.New(errorfactory.APIFailure{}, err, "failed to get user secret", "secretName", user.Spec.SecretName)
+	}
+	userSecretExists := err == nil
+
+	var password []byte
+	var passwordSecretVersion string
+	if ref := user.Spec.GetPasswordSecretRef(); ref != nil {
+		passwordSecret := &corev1.Secret{}
+		if err = r.Client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: user.Namespace}, passwordSecret); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, "", errorfactory.New(errorfactory.ResourceNotReady{}, err, "SCRAM password secret not found", "secretName", ref.Name)
+			}
+			return nil, "", errorfactory.New(errorfactory.APIFailure{}, err, "failed to get SCRAM password secret", "secretName", ref.Name)
+		}
+		if password = passwordSecret.Data[ref.Key]; len(password) == 0 {
+			return nil, "", errorfactory.New(errorfactory.ResourceNotReady{}, errors.New("key not found or empty"),
+				"SCRAM password secret does not contain the referenced key", "secretName", ref.Name, "key", ref.Key)
+		}
+		passwordSecretVersion = passwordSecret.GetResourceVersion()
+	} else if userSecretExists && len(userSecret.Data[v1alpha1.SCRAMPasswordKey]) > 0 {
+		password = userSecret.Data[v1alpha1.SCRAMPasswordKey]
+	} else {
+		password = certutil.GeneratePass(scramPasswordLength)
+	}
+
+	desiredData := kafkautil.SCRAMUserSecretData(user.Spec.GetAuthenticationType(), user.Name, password)
+	if !userSecretExists {
+		userSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      user.Spec.SecretName,
+				Namespace: user.Namespace,
+			},
+			Data: desiredData,
+		}
+		if err = controllerutil.SetControllerReference(user, userSecret, r.Scheme); err != nil {
+			return nil, "", errorfactory.New(errorfactory.InternalError{}, err, "failed to set controller reference on user secret")
+		}
+		if err = r.Client.Create(ctx, userSecret); err != nil {
+			return nil, "", errorfactory.New(errorfactory.APIFailure{}, err, "failed to create user secret", "secretName", user.Spec.SecretName)
+		}
+	} else if secretDataNeedsUpdate(userSecret.Data, desiredData) {
+		if userSecret.Data == nil {
+			userSecret.Data = make(map[string][]byte, len(desiredData))
+		}
+		for k, v := range desiredData {
+			userSecret.Data[k] = v
+		}
+		if err = r.Client.Update(ctx, userSecret); err != nil {
+			return nil, "", errorfactory.New(errorfactory.APIFailure{}, err, "failed to update user secret", "secretName", user.Spec.SecretName)
+		}
+	}
+
+	if passwordSecretVersion == "" {
+		passwordSecretVersion = userSecret.GetResourceVersion()
+	}
+	return password, passwordSecretVersion, nil
+}
+
+// secretDataNeedsUpdate returns true if any of the desired keys is missing from the secret data or has a different value
+func secretDataNeedsUpdate(current, desired map[string][]byte) bool {
+	for k, v := range desired {
+		if !bytes.Equal(current[k], v) {
+			return true
+		}
+	}
+	return false
+}
+
+// ensureSCRAMCredential creates or updates the SCRAM credential of the user on the Kafka cluster when it is missing,
+// the mechanism changed or the password secret changed since the credential was last applied. The existing credential
+// of a user imported by the user discovery is kept as its password is not known.
+func ensureSCRAMCredential(reqLogger logr.Logger, broker kafkaclient.KafkaClient, user *v1alpha1.KafkaUser,
+	password []byte, passwordSecretVersion string) (*v1alpha1.UserSCRAMCredentialStatus, error) {
+	authType := user.Spec.GetAuthenticationType()
+	current := user.Status.SCRAMCredential
+
+	// remove the credential of the previously used mechanism
+	if current != nil && current.Mechanism != authType {
+		reqLogger.Info("Removing SCRAM credential of the previous mechanism", "mechanism", current.Mechanism)
+		if err := broker.DeleteUserScramCredential(user.Name, current.Mechanism); err != nil {
+			return nil, err
+		}
+	}
+
+	exists, err := broker.UserScramCredentialExists(user.Name, authType)
+	if err != nil {
+		return nil, err
+	}
+	// the existing credential of an imported user is kept until its password secret changes
+	if exists && current == nil && user.GetLabels()[v1alpha1.ImportedLabelKey] == "true" {
+		reqLogger.Info("Keeping the existing SCRAM credential of the imported user", "user", user.Name, "mechanism", authType)
+		current = &v1alpha1.UserSCRAMCredentialStatus{Mechanism: authType, PasswordSecretVersion: passwordSecretVersion}
+	}
+	if !exists || current == nil || current.Mechanism != authType || current.PasswordSecretVersion != passwordSecretVersion {
+		reqLogger.Info("Ensuring SCRAM credential for user", "user", user.Name, "mechanism", authType)
+		if err = broker.UpsertUserScramCredential(user.Name, authType, password); err != nil {
+			return nil, err
+		}
+	}
+
+	return &v1alpha1.UserSCRAMCredentialStatus{
+		Mechanism:             authType,
+		PasswordSecretVersion: passwordSecretVersion,
+	}, nil
+}
+
 func (r *KafkaUserReconciler) addFinalizer(reqLogger logr.Logger, user *v1alpha1.KafkaUser) {
 	reqLogger.Info("Adding Finalizer for the KafkaUser")
 	user.SetFinalizers(append(user.GetFinalizers(), userFinalizer))
@@ -0,0 +1,169 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/pkg/resources/kafka/mocks"
+)
+
+func TestEnsureSCRAMCredential(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName       string
+		currentStatus  *v1alpha1.UserSCRAMCredentialStatus
+		imported       bool
+		exists         bool
+		secretVersion  string
+		expectDelete   bool
+		expectUpsert   bool
+		expectedStatus *v1alpha1.UserSCRAMCredentialStatus
+	}{
+		{
+			testName:      "credential not yet created",
+			currentStatus: nil,
+			exists:        false,
+			secretVersion: "1",
+			expectUpsert:  true,
+			expectedStatus: &v1alpha1.UserSCRAMCredentialStatus{
+				Mechanism:             v1alpha1.UserAuthenticationTypeSCRAMSHA512,
+				PasswordSecretVersion: "1",
+			},
+		},
+		{
+			testName:      "existing credential of an imported user is kept",
+			currentStatus: nil,
+			imported:      true,
+			exists:        true,
+			secretVersion: "1",
+			expectUpsert:  false,
+			expectedStatus: &v1alpha1.UserSCRAMCredentialStatus{
+				Mechanism:             v1alpha1.UserAuthenticationTypeSCRAMSHA512,
+				PasswordSecretVersion: "1",
+			},
+		},
+		{
+			testName: "credential up to date",
+			currentStatus: &v1alpha1.UserSCRAMCredentialStatus{
+				Mechanism:             v1alpha1.UserAuthenticationTypeSCRAMSHA512,
+				PasswordSecretVersion: "1",
+			},
+			exists:        true,
+			secretVersion: "1",
+			expectUpsert:  false,
+			expectedStatus: &v1alpha1.UserSCRAMCredentialStatus{
+				Mechanism:             v1alpha1.UserAuthenticationTypeSCRAMSHA512,
+				PasswordSecretVersion: "1",
+			},
+		},
+		{
+			testName: "password secret changed",
+			currentStatus: &v1alpha1.UserSCRAMCredentialStatus{
+				Mechanism:             v1alpha1.UserAuthenticationTypeSCRAMSHA512,
+				PasswordSecretVersion: "1",
+			},
+			exists:        true,
+			secretVersion: "2",
+			expectUpsert:  true,
+			expectedStatus: &v1alpha1.UserSCRAMCredentialStatus{
+				Mechanism:             v1alpha1.UserAuthenticationTypeSCRAMSHA512,
+				PasswordSecretVersion: "2",
+			},
+		},
+		{
+			testName: "credential removed from the cluster",
+			currentStatus: &v1alpha1.UserSCRAMCredentialStatus{
+				Mechanism:             v1alpha1.UserAuthenticationTypeSCRAMSHA512,
+				PasswordSecretVersion: "1",
+			},
+			exists:        false,
+			secretVersion: "1",
+			expectUpsert:  true,
+			expectedStatus: &v1alpha1.UserSCRAMCredentialStatus{
+				Mechanism:             v1alpha1.UserAuthenticationTypeSCRAMSHA512,
+				PasswordSecretVersion: "1",
+			},
+		},
+		{
+			testName: "mechanism changed",
+			currentStatus: &v1alpha1.UserSCRAMCredentialStatus{
+				Mechanism:             v1alpha1.UserAuthenticationTypeSCRAMSHA256,
+				PasswordSecretVersion: "1",
+			},
+			exists:        false,
+			secretVersion: "1",
+			expectDelete:  true,
+			expectUpsert:  true,
+			expectedStatus: &v1alpha1.UserSCRAMCredentialStatus{
+				Mechanism:             v1alpha1.UserAuthenticationTypeSCRAMSHA512,
+				PasswordSecretVersion: "1",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.testName, func(t *testing.T) {
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			broker := mocks.NewMockKafkaClient(mockCtrl)
+
+			user := &v1alpha1.KafkaUser{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-user"},
+				Spec: v1alpha1.KafkaUserSpec{
+					Authentication: &v1alpha1.UserAuthentication{Type: v1alpha1.UserAuthenticationTypeSCRAMSHA512},
+				},
+				Status: v1alpha1.KafkaUserStatus{SCRAMCredential: test.currentStatus},
+			}
+			if test.imported {
+				user.SetLabels(map[string]string{v1alpha1.ImportedLabelKey: "true"})
+			}
+			password := []byte("secret")
+
+			if test.expectDelete {
+				broker.EXPECT().DeleteUserScramCredential("test-user", test.currentStatus.Mechanism).Return(nil)
+			}
+			broker.EXPECT().UserScramCredentialExists("test-user", v1alpha1.UserAuthenticationTypeSCRAMSHA512).Return(test.exists, nil)
+			if test.expectUpsert {
+				broker.EXPECT().UpsertUserScramCredential("test-user", v1alpha1.UserAuthenticationTypeSCRAMSHA512, password).Return(nil)
+			}
+
+			status, err := ensureSCRAMCredential(logr.Discard(), broker, user, password, test.secretVersion)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedStatus, status)
+		})
+	}
+}
+
+func TestSecretDataNeedsUpdate(t *testing.T) {
+	t.Parallel()
+
+	desired := map[string][]byte{"username": []byte("test-user"), "password": []byte("secret")}
+
+	assert.True(t, secretDataNeedsUpdate(nil, desired))
+	assert.True(t, secretDataNeedsUpdate(map[string][]byte{"username": []byte("test-user")}, desired))
+	assert.True(t, secretDataNeedsUpdate(map[string][]byte{"username": []byte("test-user"), "password": []byte("other")}, desired))
+	assert.False(t, secretDataNeedsUpdate(map[string][]byte{"username": []byte("test-user"), "password": []byte("secret"), "extra": []byte("x")}, desired))
+}
@@ -0,0 +1,285 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/IBM/sarama"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+)
+
+const (
+	// usersImportedEventReason is the reason of the events reporting the KafkaUsers generated by the user discovery
+	usersImportedEventReason = "UsersImported"
+	// userPrincipalPrefix is the prefix of the principals of the users in the ACLs
+	userPrincipalPrefix = "User:"
+)
+
+// SetupKafkaUserDiscoveryWithManager registers the user discovery controller to the manager
+func SetupKafkaUserDiscoveryWithManager(mgr ctrl.Manager) *ctrl.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.KafkaCluster{}).
+		WithEventFilter(SkipClusterRegistryOwnedResourcePredicate{}).
+		WithEventFilter(newOperatorScopePredicate(mgr)).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Named("KafkaUserDiscovery")
+}
+
+// blank assignment to verify that KafkaUserDiscoveryReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &KafkaUserDiscoveryReconciler{}
+
+// KafkaUserDiscoveryReconciler periodically reads the SCRAM users and the ACLs of the KafkaClusters and generates a
+// KafkaUser, labeled as imported, in the namespace of the cluster for each principal without one. The topic ACLs of the
+// principals become the topic grants of their KafkaUsers. The SCRAM users keep their existing credential, the other
+// principals are imported as OAuth users when the cluster authenticates clients with SASL/OAUTHBEARER, as their
+// authentication can not be inferred otherwise.
+type KafkaUserDiscoveryReconciler struct {
+	Client   client.Client
+	Recorder record.EventRecorder
+	// Interval is the time between the discoveries of the users of a cluster
+	Interval time.Duration
+}
+
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkausers,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile imports the SCRAM users and the ACL principals of the cluster without a KafkaUser
+func (r *KafkaUserDiscoveryReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	cluster := &v1beta1.KafkaCluster{}
+	if err := r.Client.Get(ctx, request.NamespacedName, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconciled()
+		}
+		return requeueWithError(log, err.Error(), err)
+	}
+	if !cluster.DeletionTimestamp.IsZero() {
+		return reconciled()
+	}
+
+	users := &v1alpha1.KafkaUserList{}
+	if err := r.Client.List(ctx, users); err != nil {
+		return requeueWithError(log, "failed to list KafkaUsers", err)
+	}
+	managed := make(map[string]struct{})
+	for _, user := range users.Items {
+		if user.Spec.ClusterRef.Name == cluster.Name && getClusterRefNamespace(user.Namespace, user.Spec.ClusterRef) == cluster.Namespace {
+			managed[user.GetPrincipalName()] = struct{}{}
+		}
+	}
+
+	broker, close, err := connectKafka(ctx, r.Client, cluster)
+	if err != nil {
+		return checkBrokerConnectionError(log, err)
+	}
+	defer close()
+
+	scramUsers, err := broker.ListUserScramCredentials()
+	if err != nil {
+		return requeueWithError(log, "failed to list the SCRAM users of the cluster", err)
+	}
+	acls, err := broker.ListUserACLs()
+	if err != nil {
+		return requeueWithError(log, "failed to list the ACLs of the cluster", err)
+	}
+	grants := userTopicGrants(acls)
+
+	principals := make([]string, 0, len(scramUsers)+len(grants))
+	for principal := range scramUsers {
+		principals = append(principals, principal)
+	}
+	for principal := range grants {
+		if _, ok := scramUsers[principal]; !ok {
+			principals = append(principals, principal)
+		}
+	}
+	sort.Strings(principals)
+
+	oauth := hasOAuthBearerListener(cluster)
+	var imported []string
+	var combinedErr error
+	for _, principal := range principals {
+		if _, ok := managed[principal]; ok {
+			continue
+		}
+		user := newImportedKafkaUser(cluster, principal, scramUsers[principal], grants[principal], oauth)
+		if user == nil {
+			log.Info("The authentication of the principal can not be inferred, skipping it", "principal", principal)
+			continue
+		}
+		if err := r.Client.Create(ctx, user); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				log.Info("KafkaUser of the imported principal already exists for another principal, skipping it",
+					"principal", principal, "kafkaUser", user.Name)
+				continue
+			}
+			combinedErr = errors.Combine(combinedErr, errors.WrapIfWithDetails(err, "failed to import user", "principal", principal))
+			continue
+		}
+		imported = append(imported, principal)
+	}
+	if len(imported) > 0 {
+		log.Info("Imported the users of the cluster", "principals", imported)
+		k8sutil.RecordEvent(r.Recorder, cluster, corev1.EventTypeNormal, usersImportedEventReason,
+			"%d users have been imported as KafkaUsers: %s", len(imported), strings.Join(imported, ", "))
+	}
+	if combinedErr != nil {
+		return requeueWithError(log, "failed to import users of the cluster", combinedErr)
+	}
+
+	return ctrl.Result{RequeueAfter: r.Interval}, nil
+}
+
+// newImportedKafkaUser returns the KafkaUser of the principal with the given topic grants, nil when the authentication
+// of the principal can not be inferred: SCRAM users are named after the principal as the KafkaUser name is their SCRAM
+// user name, the other principals are only imported as OAuth users and never when they are distinguished names of
+// certificates
+func newImportedKafkaUser(cluster *v1beta1.KafkaCluster, principal string, mechanisms []v1alpha1.UserAuthenticationType,
+	grants []v1alpha1.UserTopicGrant, oauth bool) *v1alpha1.KafkaUser {
+	var name string
+	authentication := &v1alpha1.UserAuthentication{}
+	switch {
+	case len(mechanisms) > 0:
+		if len(validation.IsDNS1123Subdomain(principal)) > 0 {
+			return nil
+		}
+		name = principal
+		// SCRAM-SHA-512 is preferred when the user has credentials for both mechanisms
+		authentication.Type = mechanisms[0]
+		for _, mechanism := range mechanisms {
+			if mechanism == v1alpha1.UserAuthenticationTypeSCRAMSHA512 {
+				authentication.Type = mechanism
+			}
+		}
+	case oauth && !strings.Contains(principal, "="):
+		name = importedResourceName(principal)
+		authentication.Type = v1alpha1.UserAuthenticationTypeOAuth
+		authentication.Principal = principal
+	default:
+		return nil
+	}
+
+	labels := applyClusterRefLabel(cluster, map[string]string{v1alpha1.ImportedLabelKey: "true"})
+	return &v1alpha1.KafkaUser{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cluster.Namespace,
+			Labels:    labels,
+		},
+		Spec: v1alpha1.KafkaUserSpec{
+			SecretName:     name + "-secret",
+			ClusterRef:     v1alpha1.ClusterReference{Name: cluster.Name, Namespace: cluster.Namespace},
+			TopicGrants:    grants,
+			Authentication: authentication,
+		},
+	}
+}
+
+// userTopicGrants returns the topic grants of the user principals by the read and write topic ACLs allowed for them,
+// the wildcard principal and the ACLs of other resources are skipped
+func userTopicGrants(acls []sarama.ResourceAcls) map[string][]v1alpha1.UserTopicGrant {
+	grants := make(map[string]map[v1alpha1.UserTopicGrant]struct{})
+	for _, resourceAcls := range acls {
+		if resourceAcls.ResourceType != sarama.AclResourceTopic {
+			continue
+		}
+		var patternType v1alpha1.KafkaPatternType
+		switch resourceAcls.ResourcePatternType {
+		case sarama.AclPatternLiteral:
+			patternType = v1alpha1.KafkaPatternTypeLiteral
+		case sarama.AclPatternPrefixed:
+			patternType = v1alpha1.KafkaPatternTypePrefixed
+		default:
+			continue
+		}
+		for _, acl := range resourceAcls.Acls {
+			principal, ok := strings.CutPrefix(acl.Principal, userPrincipalPrefix)
+			if !ok || principal == "*" || acl.PermissionType != sarama.AclPermissionAllow {
+				continue
+			}
+			var accessType v1alpha1.KafkaAccessType
+			switch acl.Operation {
+			case sarama.AclOperationRead:
+				accessType = v1alpha1.KafkaAccessTypeRead
+			case sarama.AclOperationWrite:
+				accessType = v1alpha1.KafkaAccessTypeWrite
+			default:
+				continue
+			}
+			if grants[principal] == nil {
+				grants[principal] = make(map[v1alpha1.UserTopicGrant]struct{})
+			}
+			grants[principal][v1alpha1.UserTopicGrant{
+				TopicName:   resourceAcls.ResourceName,
+				AccessType:  accessType,
+				PatternType: patternType,
+			}] = struct{}{}
+		}
+	}
+
+	principalGrants := make(map[string][]v1alpha1.UserTopicGrant, len(grants))
+	for principal, set := range grants {
+		list := make([]v1alpha1.UserTopicGrant, 0, len(set))
+		for grant := range set {
+			list = append(list, grant)
+		}
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].TopicName != list[j].TopicName {
+				return list[i].TopicName < list[j].TopicName
+			}
+			if list[i].AccessType != list[j].AccessType {
+				return list[i].AccessType < list[j].AccessType
+			}
+			return list[i].PatternType < list[j].PatternType
+		})
+		principalGrants[principal] = list
+	}
+	return principalGrants
+}
+
+// hasOAuthBearerListener returns true when a listener of the cluster authenticates the clients with SASL/OAUTHBEARER
+func hasOAuthBearerListener(cluster *v1beta1.KafkaCluster) bool {
+	for _, listener := range cluster.Spec.ListenersConfig.InternalListeners {
+		if listener.OAuthBearer != nil && listener.Type.IsSasl() {
+			return true
+		}
+	}
+	for _, listener := range cluster.Spec.ListenersConfig.ExternalListeners {
+		if listener.OAuthBearer != nil && listener.Type.IsSasl() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,175 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/kafkaclient"
+	"github.com/banzaicloud/koperator/pkg/resources/kafka/mocks"
+)
+
+func TestKafkaUserDiscoveryReconcile(t *testing.T) {
+	testCases := []struct {
+		testName      string
+		oauthListener bool
+		expectedUsers map[string]v1alpha1.KafkaUserSpec
+		expectedEvent string
+	}{
+		{
+			testName: "only the SCRAM users are imported without OAuth listener",
+			expectedUsers: map[string]v1alpha1.KafkaUserSpec{
+				"orders-app": {
+					SecretName:     "orders-app-secret",
+					ClusterRef:     v1alpha1.ClusterReference{Name: "kafka", Namespace: "kafka"},
+					Authentication: &v1alpha1.UserAuthentication{Type: v1alpha1.UserAuthenticationTypeSCRAMSHA512},
+					TopicGrants: []v1alpha1.UserTopicGrant{
+						{TopicName: "orders", AccessType: v1alpha1.KafkaAccessTypeRead, PatternType: v1alpha1.KafkaPatternTypeLiteral},
+						{TopicName: "orders", AccessType: v1alpha1.KafkaAccessTypeWrite, PatternType: v1alpha1.KafkaPatternTypeLiteral},
+					},
+				},
+			},
+			expectedEvent: "Normal UsersImported 1 users have been imported as KafkaUsers: orders-app",
+		},
+		{
+			testName:      "ACL principals are imported as OAuth users with OAuth listener",
+			oauthListener: true,
+			expectedUsers: map[string]v1alpha1.KafkaUserSpec{
+				"orders-app": {
+					SecretName:     "orders-app-secret",
+					ClusterRef:     v1alpha1.ClusterReference{Name: "kafka", Namespace: "kafka"},
+					Authentication: &v1alpha1.UserAuthentication{Type: v1alpha1.UserAuthenticationTypeSCRAMSHA512},
+					TopicGrants: []v1alpha1.UserTopicGrant{
+						{TopicName: "orders", AccessType: v1alpha1.KafkaAccessTypeRead, PatternType: v1alpha1.KafkaPatternTypeLiteral},
+						{TopicName: "orders", AccessType: v1alpha1.KafkaAccessTypeWrite, PatternType: v1alpha1.KafkaPatternTypeLiteral},
+					},
+				},
+				"reporting": {
+					SecretName: "reporting-secret",
+					ClusterRef: v1alpha1.ClusterReference{Name: "kafka", Namespace: "kafka"},
+					Authentication: &v1alpha1.UserAuthentication{
+						Type:      v1alpha1.UserAuthenticationTypeOAuth,
+						Principal: "reporting",
+					},
+					TopicGrants: []v1alpha1.UserTopicGrant{
+						{TopicName: "orders.", AccessType: v1alpha1.KafkaAccessTypeRead, PatternType: v1alpha1.KafkaPatternTypePrefixed},
+					},
+				},
+			},
+			expectedEvent: "Normal UsersImported 2 users have been imported as KafkaUsers: orders-app, reporting",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			cluster := &v1beta1.KafkaCluster{ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"}}
+			if test.oauthListener {
+				cluster.Spec.ListenersConfig.InternalListeners = []v1beta1.InternalListenerConfig{{
+					CommonListenerSpec: v1beta1.CommonListenerSpec{
+						Name:        "oauth",
+						Type:        v1beta1.SecurityProtocolSaslSSL,
+						OAuthBearer: &v1beta1.OAuthBearerConfig{IssuerURL: "https://issuer.example.com"},
+					},
+				}}
+			}
+			// the principal of the existing KafkaUser is not imported
+			managedUser := &v1alpha1.KafkaUser{
+				ObjectMeta: metav1.ObjectMeta{Name: "payments-app", Namespace: "apps"},
+				Spec: v1alpha1.KafkaUserSpec{
+					ClusterRef:     v1alpha1.ClusterReference{Name: "kafka", Namespace: "kafka"},
+					Authentication: &v1alpha1.UserAuthentication{Type: v1alpha1.UserAuthenticationTypeSCRAMSHA256},
+				},
+			}
+
+			mockCtrl := gomock.NewController(t)
+			broker := mocks.NewMockKafkaClient(mockCtrl)
+			broker.EXPECT().ListUserScramCredentials().Return(map[string][]v1alpha1.UserAuthenticationType{
+				"orders-app":   {v1alpha1.UserAuthenticationTypeSCRAMSHA256, v1alpha1.UserAuthenticationTypeSCRAMSHA512},
+				"payments-app": {v1alpha1.UserAuthenticationTypeSCRAMSHA256},
+			}, nil)
+			broker.EXPECT().ListUserACLs().Return([]sarama.ResourceAcls{
+				{
+					Resource: sarama.Resource{ResourceType: sarama.AclResourceTopic, ResourceName: "orders", ResourcePatternType: sarama.AclPatternLiteral},
+					Acls: []*sarama.Acl{
+						{Principal: "User:orders-app", Host: "*", Operation: sarama.AclOperationRead, PermissionType: sarama.AclPermissionAllow},
+						{Principal: "User:orders-app", Host: "*", Operation: sarama.AclOperationWrite, PermissionType: sarama.AclPermissionAllow},
+						{Principal: "User:orders-app", Host: "*", Operation: sarama.AclOperationDescribe, PermissionType: sarama.AclPermissionAllow},
+						{Principal: "User:CN=legacy-app", Host: "*", Operation: sarama.AclOperationRead, PermissionType: sarama.AclPermissionAllow},
+					},
+				},
+				{
+					Resource: sarama.Resource{ResourceType: sarama.AclResourceTopic, ResourceName: "orders.", ResourcePatternType: sarama.AclPatternPrefixed},
+					Acls: []*sarama.Acl{
+						{Principal: "User:reporting", Host: "*", Operation: sarama.AclOperationRead, PermissionType: sarama.AclPermissionAllow},
+					},
+				},
+				{
+					Resource: sarama.Resource{ResourceType: sarama.AclResourceGroup, ResourceName: "*", ResourcePatternType: sarama.AclPatternLiteral},
+					Acls: []*sarama.Acl{
+						{Principal: "User:orders-app", Host: "*", Operation: sarama.AclOperationRead, PermissionType: sarama.AclPermissionAllow},
+					},
+				},
+			}, nil)
+			SetNewKafkaFromCluster(func(client.Client, *v1beta1.KafkaCluster) (kafkaclient.KafkaClient, func(), error) {
+				return broker, func() {}, nil
+			})
+			defer SetNewKafkaFromCluster(kafkaclient.NewFromCluster)
+
+			scheme := runtime.NewScheme()
+			require.NoError(t, v1beta1.AddToScheme(scheme))
+			require.NoError(t, v1alpha1.AddToScheme(scheme))
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, managedUser).Build()
+			recorder := record.NewFakeRecorder(10)
+
+			r := &KafkaUserDiscoveryReconciler{Client: c, Recorder: recorder, Interval: time.Hour}
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}}
+			result, err := r.Reconcile(context.Background(), request)
+			require.NoError(t, err)
+			require.Equal(t, time.Hour, result.RequeueAfter)
+
+			users := &v1alpha1.KafkaUserList{}
+			require.NoError(t, c.List(context.Background(), users, client.MatchingLabels{v1alpha1.ImportedLabelKey: "true"}))
+			specs := make(map[string]v1alpha1.KafkaUserSpec, len(users.Items))
+			for _, user := range users.Items {
+				require.Equal(t, "kafka", user.Namespace)
+				specs[user.Name] = user.Spec
+			}
+			require.Equal(t, test.expectedUsers, specs)
+
+			close(recorder.Events)
+			var events []string
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+			require.Equal(t, []string{test.expectedEvent}, events)
+		})
+	}
+}
@@ -0,0 +1,349 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlBuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/scale"
+)
+
+const (
+	// logDirOfflineEventReason is the reason of the events reporting an offline log directory of a broker
+	logDirOfflineEventReason = "LogDirOffline"
+	// logDirRecoveredEventReason is the reason of the events reporting that an offline log directory is online again
+	logDirRecoveredEventReason = "LogDirRecovered"
+	// logDirRemediationStartedEventReason is the reason of the events reporting the CruiseControlOperation created to
+	// remediate offline log directories
+	logDirRemediationStartedEventReason = "LogDirRemediationStarted"
+)
+
+// logDirRemediations maps the remediation policies to the Cruise Control operations performing them
+var logDirRemediations = map[v1beta1.LogDirRemediationPolicy]v1alpha1.CruiseControlTaskOperation{
+	v1beta1.LogDirRemediationFixOfflineReplicas: v1alpha1.OperationFixOfflineReplicas,
+	v1beta1.LogDirRemediationRemoveDisks:        v1alpha1.OperationRemoveDisks,
+}
+
+// SetupLogDirFailureWithManager registers the log directory failure controller to the manager
+func SetupLogDirFailureWithManager(mgr ctrl.Manager) *ctrl.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.KafkaCluster{}, ctrlBuilder.WithPredicates(SkipClusterRegistryOwnedResourcePredicate{},
+			newOperatorScopePredicate(mgr), predicate.GenerationChangedPredicate{})).
+		Named("LogDirFailure")
+}
+
+// blank assignment to verify that LogDirFailureReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &LogDirFailureReconciler{}
+
+// LogDirFailureReconciler polls the state of the log directories of the brokers of the KafkaClusters with
+// logDirFailure configured from Cruise Control, records the offline log directories in the status of the brokers and
+// remediates them with CruiseControlOperations according to the remediation policy
+type LogDirFailureReconciler struct {
+	client.Client
+	Scheme       *runtime.Scheme
+	Recorder     record.EventRecorder
+	ScaleFactory func(ctx context.Context, kafkaCluster *v1beta1.KafkaCluster) (scale.CruiseControlScaler, error)
+}
+
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkaclusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=cruisecontroloperations,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=cruisecontroloperations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile records and remediates the offline log directories of the brokers
+func (r *LogDirFailureReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	cluster := &v1beta1.KafkaCluster{}
+	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconciled()
+		}
+		return requeueWithError(log, err.Error(), err)
+	}
+	if !cluster.DeletionTimestamp.IsZero() || !operatorScope.includes(cluster) {
+		return reconciled()
+	}
+
+	config := cluster.Spec.LogDirFailure
+	if config == nil {
+		if err := r.clearLogDirFailures(cluster, log); err != nil {
+			return requeueWithError(log, "failed to remove the log directory failures from the status", err)
+		}
+		return reconciled()
+	}
+	pollInterval := config.GetPollInterval()
+
+	scaler, err := r.ScaleFactory(ctx, cluster)
+	if err != nil {
+		return requeueWithError(log, "failed to create Cruise Control Scaler instance", err)
+	}
+	if !scaler.IsUp(ctx) {
+		log.Info("Cruise Control is not up, the state of the log directories can not be polled")
+		return ctrl.Result{RequeueAfter: pollInterval}, nil
+	}
+	logDirsByBroker, err := scaler.LogDirsByBroker(ctx)
+	if err != nil {
+		log.Error(err, "could not get the state of the log directories from Cruise Control")
+		return ctrl.Result{RequeueAfter: pollInterval}, nil
+	}
+
+	failures := offlineLogDirs(cluster, logDirsByBroker)
+	if err := r.remediateLogDirFailures(ctx, log, cluster, config.GetRemediation(), failures); err != nil {
+		return requeueWithError(log, "failed to remediate the offline log directories", err)
+	}
+	if err := r.updateLogDirFailures(cluster, failures, log); err != nil {
+		return requeueWithError(log, "failed to record the offline log directories of the brokers", err)
+	}
+
+	return ctrl.Result{RequeueAfter: pollInterval}, nil
+}
+
+// offlineLogDirs returns the offline log directories reported by Cruise Control by broker id and mount path of the
+// affected volume, the failures already recorded for the same log directory are kept
+func offlineLogDirs(cluster *v1beta1.KafkaCluster, logDirsByBroker map[string]map[scale.LogDirState][]string) map[string]v1beta1.LogDirFailureStates {
+	failures := make(map[string]v1beta1.LogDirFailureStates)
+	for _, broker := range cluster.Spec.Brokers {
+		brokerID := strconv.Itoa(int(broker.Id))
+		offline := logDirsByBroker[brokerID][scale.LogDirStateOffline]
+		if len(offline) == 0 {
+			continue
+		}
+		var mountPaths []string
+		if brokerConfig, err := broker.GetBrokerConfig(cluster.Spec); err == nil {
+			for _, storageConfig := range brokerConfig.StorageConfigs {
+				mountPaths = append(mountPaths, storageConfig.MountPath)
+			}
+		}
+		brokerFailures := make(v1beta1.LogDirFailureStates, len(offline))
+		for _, logDir := range offline {
+			mountPath := logDirMountPath(logDir, mountPaths)
+			if recorded, ok := cluster.Status.BrokersState[brokerID].LogDirFailures[mountPath]; ok && recorded.LogDir == logDir {
+				brokerFailures[mountPath] = recorded
+				continue
+			}
+			brokerFailures[mountPath] = v1beta1.LogDirFailureState{LogDir: logDir, DetectedTime: metav1.Now()}
+		}
+		failures[brokerID] = brokerFailures
+	}
+	return failures
+}
+
+// logDirMountPath returns the longest mount path holding the log directory, the log directory itself when it is not
+// on any of the volumes of the broker
+func logDirMountPath(logDir string, mountPaths []string) string {
+	match := ""
+	for _, mountPath := range mountPaths {
+		mountPath = strings.TrimSuffix(mountPath, "/")
+		if (logDir == mountPath || strings.HasPrefix(logDir, mountPath+"/")) && len(mountPath) > len(match) {
+			match = mountPath
+		}
+	}
+	if match == "" {
+		return logDir
+	}
+	return match
+}
+
+// remediateLogDirFailures creates a CruiseControlOperation remediating the offline log directories not remediated yet,
+// unless the policy only reports them or an operation of the same kind is already in progress
+func (r *LogDirFailureReconciler) remediateLogDirFailures(ctx context.Context, log logr.Logger, cluster *v1beta1.KafkaCluster,
+	policy v1beta1.LogDirRemediationPolicy, failures map[string]v1beta1.LogDirFailureStates) error {
+	operationType, ok := logDirRemediations[policy]
+	if !ok {
+		return nil
+	}
+
+	var pairs []string
+	for _, brokerID := range sortedBrokerIDs(failures) {
+		for _, failure := range failures[brokerID] {
+			if failure.CruiseControlOperationReference == nil {
+				pairs = append(pairs, fmt.Sprintf("%s-%s", brokerID, failure.LogDir))
+			}
+		}
+	}
+	if len(pairs) == 0 {
+		return nil
+	}
+	sort.Strings(pairs)
+
+	ccOperations := &v1alpha1.CruiseControlOperationList{}
+	if err := r.List(ctx, ccOperations, client.InNamespace(cluster.Namespace), client.MatchingLabels(apiutil.LabelsForKafka(cluster.Name))); err != nil {
+		return err
+	}
+	for i := range ccOperations.Items {
+		if !ccOperations.Items[i].IsDone() && ccOperations.Items[i].CurrentTaskOperation() == operationType {
+			log.Info("waiting for the Cruise Control operation in progress to remediate the offline log directories",
+				"operation", operationType, "cruiseControlOperation", ccOperations.Items[i].Name)
+			return nil
+		}
+	}
+
+	parameters := map[string]string{}
+	if operationType == v1alpha1.OperationRemoveDisks {
+		parameters[scale.ParamBrokerIDAndLogDirs] = strings.Join(pairs, ",")
+	} else {
+		parameters[scale.ParamExcludeDemoted] = True
+		parameters[scale.ParamExcludeRemoved] = True
+	}
+	operation, err := r.createLogDirRemediation(ctx, cluster, operationType, parameters)
+	if err != nil {
+		return err
+	}
+	log.Info("remediating the offline log directories", "logDirs", pairs, "operation", operationType,
+		"cruiseControlOperation", operation.Name)
+	k8sutil.RecordEvent(r.Recorder, cluster, corev1.EventTypeNormal, logDirRemediationStartedEventReason,
+		"the offline log directories %s are remediated by the %s CruiseControlOperation %s", strings.Join(pairs, ", "),
+		operationType, operation.Name)
+
+	for _, brokerFailures := range failures {
+		for mountPath, failure := range brokerFailures {
+			if failure.CruiseControlOperationReference == nil {
+				failure.CruiseControlOperationReference = &corev1.LocalObjectReference{Name: operation.Name}
+				brokerFailures[mountPath] = failure
+			}
+		}
+	}
+	return nil
+}
+
+// createLogDirRemediation creates the CruiseControlOperation remediating offline log directories, it is retried
+// until it succeeds as the failures are remediated only once
+func (r *LogDirFailureReconciler) createLogDirRemediation(ctx context.Context, cluster *v1beta1.KafkaCluster,
+	operationType v1alpha1.CruiseControlTaskOperation, parameters map[string]string) (*v1alpha1.CruiseControlOperation, error) {
+	operation := &v1alpha1.CruiseControlOperation{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-%s-", cluster.Name, strings.ReplaceAll(string(operationType), "_", "")),
+			Namespace:    cluster.Namespace,
+			Labels:       apiutil.LabelsForKafka(cluster.Name),
+		},
+		Spec: v1alpha1.CruiseControlOperationSpec{
+			ErrorPolicy:             v1alpha1.ErrorPolicyRetry,
+			TTLSecondsAfterFinished: cluster.Spec.CruiseControlConfig.CruiseControlOperationSpec.GetTTLSecondsAfterFinished(),
+		},
+	}
+	if err := controllerutil.SetControllerReference(cluster, operation, r.Scheme); err != nil {
+		return nil, errors.WrapIf(err, "could not set the owner of the CruiseControlOperation")
+	}
+	if err := r.Create(ctx, operation); err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not create the CruiseControlOperation", "operation", operationType)
+	}
+
+	operation.Status.CurrentTask = &v1alpha1.CruiseControlTask{
+		Operation:  operationType,
+		Parameters: parameters,
+	}
+	if err := r.Status().Update(ctx, operation); err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not set the task of the CruiseControlOperation", "name", operation.Name)
+	}
+	return operation, nil
+}
+
+// updateLogDirFailures records the offline log directories in the status of the brokers, reporting the ones newly
+// detected and the ones online again in events
+func (r *LogDirFailureReconciler) updateLogDirFailures(cluster *v1beta1.KafkaCluster, failures map[string]v1beta1.LogDirFailureStates, log logr.Logger) error {
+	brokerIDs := sortedBrokerIDs(failures)
+	for brokerID, brokerState := range cluster.Status.BrokersState {
+		if _, ok := failures[brokerID]; !ok && len(brokerState.LogDirFailures) > 0 {
+			brokerIDs = append(brokerIDs, brokerID)
+		}
+	}
+	sort.Strings(brokerIDs)
+
+	for _, brokerID := range brokerIDs {
+		recorded := cluster.Status.BrokersState[brokerID].LogDirFailures
+		current := failures[brokerID]
+		if equality.Semantic.DeepEqual(recorded, current) {
+			continue
+		}
+		for _, mountPath := range sortedMountPaths(current) {
+			if _, ok := recorded[mountPath]; !ok {
+				log.Info("log directory of the broker is offline", v1beta1.BrokerIdLabelKey, brokerID,
+					"logDir", current[mountPath].LogDir, "mountPath", mountPath)
+				k8sutil.RecordEvent(r.Recorder, cluster, corev1.EventTypeWarning, logDirOfflineEventReason,
+					"log directory %s of broker %s is offline", current[mountPath].LogDir, brokerID)
+			}
+		}
+		for _, mountPath := range sortedMountPaths(recorded) {
+			if _, ok := current[mountPath]; !ok {
+				log.Info("log directory of the broker is online again", v1beta1.BrokerIdLabelKey, brokerID,
+					"logDir", recorded[mountPath].LogDir, "mountPath", mountPath)
+				k8sutil.RecordEvent(r.Recorder, cluster, corev1.EventTypeNormal, logDirRecoveredEventReason,
+					"log directory %s of broker %s is online again", recorded[mountPath].LogDir, brokerID)
+			}
+		}
+		if err := k8sutil.UpdateBrokerStatus(r.Client, []string{brokerID}, cluster, current, log); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clearLogDirFailures removes the offline log directories from the status of the brokers once logDirFailure is unset
+func (r *LogDirFailureReconciler) clearLogDirFailures(cluster *v1beta1.KafkaCluster, log logr.Logger) error {
+	var brokerIDs []string
+	for brokerID, brokerState := range cluster.Status.BrokersState {
+		if len(brokerState.LogDirFailures) > 0 {
+			brokerIDs = append(brokerIDs, brokerID)
+		}
+	}
+	if len(brokerIDs) == 0 {
+		return nil
+	}
+	sort.Strings(brokerIDs)
+	return k8sutil.UpdateBrokerStatus(r.Client, brokerIDs, cluster, v1beta1.LogDirFailureStates(nil), log)
+}
+
+func sortedBrokerIDs(failures map[string]v1beta1.LogDirFailureStates) []string {
+	brokerIDs := make([]string, 0, len(failures))
+	for brokerID := range failures {
+		brokerIDs = append(brokerIDs, brokerID)
+	}
+	sort.Strings(brokerIDs)
+	return brokerIDs
+}
+
+func sortedMountPaths(failures v1beta1.LogDirFailureStates) []string {
+	mountPaths := make([]string, 0, len(failures))
+	for mountPath := range failures {
+		mountPaths = append(mountPaths, mountPath)
+	}
+	sort.Strings(mountPaths)
+	return mountPaths
+}
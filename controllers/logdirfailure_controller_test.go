@@ -0,0 +1,203 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/controllers/tests/mocks"
+	"github.com/banzaicloud/koperator/pkg/scale"
+)
+
+func TestLogDirFailureReconcile(t *testing.T) {
+	logDirsByBroker := map[string]map[scale.LogDirState][]string{
+		"0": {scale.LogDirStateOnline: {"/kafka-logs/kafka", "/kafka-logs2/kafka"}},
+		"1": {scale.LogDirStateOnline: {"/kafka-logs/kafka"}, scale.LogDirStateOffline: {"/kafka-logs2/kafka"}},
+		"2": {scale.LogDirStateOnline: {"/kafka-logs/kafka", "/kafka-logs2/kafka"}},
+	}
+
+	testCases := []struct {
+		testName           string
+		config             *v1beta1.LogDirFailureConfig
+		runningOperation   v1alpha1.CruiseControlTaskOperation
+		expectedFailures   map[string]v1beta1.LogDirFailureStates
+		expectedOperation  v1alpha1.CruiseControlTaskOperation
+		expectedParameters map[string]string
+	}{
+		{
+			testName: "offline log directories are reported only",
+			config:   &v1beta1.LogDirFailureConfig{},
+			expectedFailures: map[string]v1beta1.LogDirFailureStates{
+				"1": {"/kafka-logs2": {LogDir: "/kafka-logs2/kafka"}},
+			},
+		},
+		{
+			testName: "offline replicas are fixed",
+			config:   &v1beta1.LogDirFailureConfig{Remediation: v1beta1.LogDirRemediationFixOfflineReplicas},
+			expectedFailures: map[string]v1beta1.LogDirFailureStates{
+				"1": {"/kafka-logs2": {LogDir: "/kafka-logs2/kafka"}},
+			},
+			expectedOperation:  v1alpha1.OperationFixOfflineReplicas,
+			expectedParameters: map[string]string{scale.ParamExcludeDemoted: "true", scale.ParamExcludeRemoved: "true"},
+		},
+		{
+			testName: "replicas are moved off the offline log directories",
+			config:   &v1beta1.LogDirFailureConfig{Remediation: v1beta1.LogDirRemediationRemoveDisks},
+			expectedFailures: map[string]v1beta1.LogDirFailureStates{
+				"1": {"/kafka-logs2": {LogDir: "/kafka-logs2/kafka"}},
+			},
+			expectedOperation:  v1alpha1.OperationRemoveDisks,
+			expectedParameters: map[string]string{scale.ParamBrokerIDAndLogDirs: "1-/kafka-logs2/kafka"},
+		},
+		{
+			testName:         "no remediation while an operation of the same kind is in progress",
+			config:           &v1beta1.LogDirFailureConfig{Remediation: v1beta1.LogDirRemediationFixOfflineReplicas},
+			runningOperation: v1alpha1.OperationFixOfflineReplicas,
+			expectedFailures: map[string]v1beta1.LogDirFailureStates{
+				"1": {"/kafka-logs2": {LogDir: "/kafka-logs2/kafka"}},
+			},
+		},
+		{
+			testName:         "failures are removed once logDirFailure is unset",
+			expectedFailures: map[string]v1beta1.LogDirFailureStates{},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, v1alpha1.AddToScheme(scheme))
+			require.NoError(t, v1beta1.AddToScheme(scheme))
+
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Spec: v1beta1.KafkaClusterSpec{
+					BrokerConfigGroups: map[string]v1beta1.BrokerConfig{
+						"default": {StorageConfigs: []v1beta1.StorageConfig{{MountPath: "/kafka-logs"}, {MountPath: "/kafka-logs2"}}},
+					},
+					Brokers: []v1beta1.Broker{
+						{Id: 0, BrokerConfigGroup: "default"},
+						{Id: 1, BrokerConfigGroup: "default"},
+						{Id: 2, BrokerConfigGroup: "default"},
+					},
+					LogDirFailure: test.config,
+				},
+				Status: v1beta1.KafkaClusterStatus{
+					BrokersState: map[string]v1beta1.BrokerState{
+						"0": {},
+						"1": {},
+						// the log directory of broker 2 is online again
+						"2": {LogDirFailures: v1beta1.LogDirFailureStates{"/kafka-logs": {LogDir: "/kafka-logs/kafka"}}},
+					},
+				},
+			}
+			builder := fake.NewClientBuilder().WithScheme(scheme).
+				WithStatusSubresource(&v1beta1.KafkaCluster{}, &v1alpha1.CruiseControlOperation{}).
+				WithObjects(cluster)
+			if test.runningOperation != "" {
+				builder = builder.WithObjects(&v1alpha1.CruiseControlOperation{
+					ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "kafka", Labels: apiutil.LabelsForKafka("kafka")},
+					Status: v1alpha1.CruiseControlOperationStatus{
+						CurrentTask: &v1alpha1.CruiseControlTask{
+							ID:        "task",
+							Operation: test.runningOperation,
+							State:     v1beta1.CruiseControlTaskInExecution,
+						},
+					},
+				})
+			}
+			c := builder.Build()
+
+			mockCtrl := gomock.NewController(t)
+			scaleMock := mocks.NewMockCruiseControlScaler(mockCtrl)
+			reconciles := 1
+			if test.config != nil {
+				reconciles = 2
+				scaleMock.EXPECT().IsUp(gomock.Any()).Return(true).Times(reconciles)
+				scaleMock.EXPECT().LogDirsByBroker(gomock.Any()).Return(logDirsByBroker, nil).Times(reconciles)
+			}
+
+			r := &LogDirFailureReconciler{
+				Client: c,
+				Scheme: scheme,
+				ScaleFactory: func(ctx context.Context, kafkaCluster *v1beta1.KafkaCluster) (scale.CruiseControlScaler, error) {
+					return scaleMock, nil
+				},
+			}
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cluster)}
+			// the offline log directories are remediated only once
+			for range reconciles {
+				result, err := r.Reconcile(context.Background(), request)
+				require.NoError(t, err)
+				if test.config != nil {
+					require.Equal(t, time.Minute, result.RequeueAfter)
+				}
+			}
+
+			updated := &v1beta1.KafkaCluster{}
+			require.NoError(t, c.Get(context.Background(), request.NamespacedName, updated))
+			ccOperations := &v1alpha1.CruiseControlOperationList{}
+			require.NoError(t, c.List(context.Background(), ccOperations))
+			var remediation *v1alpha1.CruiseControlOperation
+			for i := range ccOperations.Items {
+				if ccOperations.Items[i].Name != "running" {
+					require.Nil(t, remediation, "the offline log directories are remediated by a single operation")
+					remediation = &ccOperations.Items[i]
+				}
+			}
+
+			failures := make(map[string]v1beta1.LogDirFailureStates)
+			for brokerID, brokerState := range updated.Status.BrokersState {
+				for mountPath, failure := range brokerState.LogDirFailures {
+					require.False(t, failure.DetectedTime.IsZero())
+					failure.DetectedTime = metav1.Time{}
+					if test.expectedOperation != "" {
+						require.NotNil(t, remediation)
+						require.Equal(t, &corev1.LocalObjectReference{Name: remediation.Name}, failure.CruiseControlOperationReference)
+						failure.CruiseControlOperationReference = nil
+					}
+					if failures[brokerID] == nil {
+						failures[brokerID] = v1beta1.LogDirFailureStates{}
+					}
+					failures[brokerID][mountPath] = failure
+				}
+			}
+			require.Equal(t, test.expectedFailures, failures)
+
+			if test.expectedOperation == "" {
+				require.Nil(t, remediation)
+				return
+			}
+			require.NotNil(t, remediation)
+			require.Equal(t, test.expectedOperation, remediation.CurrentTaskOperation())
+			require.Equal(t, test.expectedParameters, remediation.CurrentTaskParameters())
+		})
+	}
+}
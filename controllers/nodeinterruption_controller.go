@@ -0,0 +1,370 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlBuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/scale"
+)
+
+const (
+	// brokerNodeInterruptedEventReason is the reason of the events reporting the interruption of the node of a broker
+	brokerNodeInterruptedEventReason = "BrokerNodeInterrupted"
+	// brokerNodeInterruptionEndedEventReason is the reason of the events reporting that a broker left its interrupted node
+	brokerNodeInterruptionEndedEventReason = "BrokerNodeInterruptionEnded"
+	// nodeInterruptionRequeueInterval is the time between the checks of the brokers on interrupted nodes, until they
+	// have left the node or their leadership is demoted
+	nodeInterruptionRequeueInterval = 30 * time.Second
+)
+
+// SetupNodeInterruptionWithManager registers the node interruption controller to the manager
+func SetupNodeInterruptionWithManager(mgr ctrl.Manager) *ctrl.Builder {
+	mapper := nodeInterruptionMapper{client: mgr.GetClient(), log: mgr.GetLogger().WithName("NodeInterruption")}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.KafkaCluster{}, ctrlBuilder.WithPredicates(SkipClusterRegistryOwnedResourcePredicate{},
+			newOperatorScopePredicate(mgr), predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(mapper.mapToKafkaClusters),
+			ctrlBuilder.WithPredicates(nodeSignalsChangedPredicate())).
+		Named("NodeInterruption")
+}
+
+// nodeSignalsChangedPredicate selects the updates of the nodes changing their taints or conditions
+func nodeSignalsChangedPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, okOld := e.ObjectOld.(*corev1.Node)
+			newNode, okNew := e.ObjectNew.(*corev1.Node)
+			return okOld && okNew && (!reflect.DeepEqual(oldNode.Spec.Taints, newNode.Spec.Taints) ||
+				!reflect.DeepEqual(nodeConditionStatuses(oldNode), nodeConditionStatuses(newNode)))
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+func nodeConditionStatuses(node *corev1.Node) map[corev1.NodeConditionType]corev1.ConditionStatus {
+	statuses := make(map[corev1.NodeConditionType]corev1.ConditionStatus, len(node.Status.Conditions))
+	for _, condition := range node.Status.Conditions {
+		statuses[condition.Type] = condition.Status
+	}
+	return statuses
+}
+
+// nodeInterruptionMapper maps the nodes to the KafkaClusters with brokers running on them
+type nodeInterruptionMapper struct {
+	client client.Reader
+	log    logr.Logger
+}
+
+func (m nodeInterruptionMapper) mapToKafkaClusters(ctx context.Context, obj client.Object) []reconcile.Request {
+	pods := &corev1.PodList{}
+	if err := m.client.List(ctx, pods, client.MatchingLabels{v1beta1.AppLabelKey: "kafka"}); err != nil {
+		m.log.Error(err, "failed to list the Kafka pods", "node", obj.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != obj.GetName() || pod.Labels[v1beta1.KafkaCRLabelKey] == "" {
+			continue
+		}
+		request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: pod.Namespace, Name: pod.Labels[v1beta1.KafkaCRLabelKey]}}
+		if !slices.Contains(requests, request) {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+// blank assignment to verify that NodeInterruptionReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &NodeInterruptionReconciler{}
+
+// NodeInterruptionReconciler prepares the brokers of the KafkaClusters with nodeInterruption configured for the
+// interruption of their node, e.g. the reclaim of a spot instance: the leadership of the partitions of the brokers
+// running on a node signaling its interruption is moved to other brokers through a CruiseControlOperation, and the
+// interruption is reported in the status of the brokers until they leave the node
+type NodeInterruptionReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkaclusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=cruisecontroloperations,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=cruisecontroloperations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile demotes the brokers running on interrupted nodes and records the interruptions in their status
+func (r *NodeInterruptionReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	cluster := &v1beta1.KafkaCluster{}
+	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconciled()
+		}
+		return requeueWithError(log, err.Error(), err)
+	}
+	if !cluster.DeletionTimestamp.IsZero() || !operatorScope.includes(cluster) {
+		return reconciled()
+	}
+
+	interruptions, err := r.brokerNodeInterruptions(ctx, cluster)
+	if err != nil {
+		return requeueWithError(log, "failed to check the nodes of the brokers", err)
+	}
+
+	if err := r.clearEndedInterruptions(cluster, interruptions, log); err != nil {
+		return requeueWithError(log, "failed to clear the node interruptions of the brokers", err)
+	}
+
+	if err := r.trackDemotions(ctx, cluster, interruptions, log); err != nil {
+		return requeueWithError(log, "failed to check the demotion of the brokers", err)
+	}
+
+	var toDemote []string
+	for brokerID, interruption := range interruptions {
+		if interruption.DemoteOperationReference == nil {
+			toDemote = append(toDemote, brokerID)
+		}
+	}
+	sort.Strings(toDemote)
+	if len(toDemote) > 0 {
+		for _, brokerID := range toDemote {
+			if cluster.Status.BrokersState[brokerID].NodeInterruption == nil {
+				interruption := interruptions[brokerID]
+				log.Info("the node of the broker is interrupted", v1beta1.BrokerIdLabelKey, brokerID,
+					"node", interruption.Node, "signal", interruption.Signal)
+				k8sutil.RecordEvent(r.Recorder, cluster, corev1.EventTypeWarning, brokerNodeInterruptedEventReason,
+					"the node %s of broker %s is interrupted (%s), the leadership of its partitions is moved to other brokers",
+					interruption.Node, brokerID, interruption.Signal)
+			}
+		}
+		if operationRef, err := r.demoteBrokers(ctx, cluster, toDemote); err != nil {
+			log.Error(err, "could not move the leadership of the partitions away from the brokers of the interrupted nodes",
+				"brokers", toDemote)
+		} else {
+			for _, brokerID := range toDemote {
+				interruption := interruptions[brokerID]
+				interruption.DemoteOperationReference = operationRef
+				interruptions[brokerID] = interruption
+			}
+		}
+	}
+
+	brokerIDs := make([]string, 0, len(interruptions))
+	for brokerID := range interruptions {
+		brokerIDs = append(brokerIDs, brokerID)
+	}
+	sort.Strings(brokerIDs)
+	for _, brokerID := range brokerIDs {
+		interruption := interruptions[brokerID]
+		if current := cluster.Status.BrokersState[brokerID].NodeInterruption; current != nil && reflect.DeepEqual(*current, interruption) {
+			continue
+		}
+		if err := k8sutil.UpdateBrokerStatus(r.Client, []string{brokerID}, cluster, &interruption, log); err != nil {
+			return requeueWithError(log, "failed to record the node interruption of the broker", err)
+		}
+	}
+
+	if len(interruptions) > 0 {
+		return ctrl.Result{RequeueAfter: nodeInterruptionRequeueInterval}, nil
+	}
+	return reconciled()
+}
+
+// brokerNodeInterruptions returns the interruptions of the nodes the brokers of the cluster are running on by broker
+// id, the interruptions already recorded for the same node are kept
+func (r *NodeInterruptionReconciler) brokerNodeInterruptions(ctx context.Context, cluster *v1beta1.KafkaCluster) (map[string]v1beta1.NodeInterruptionState, error) {
+	interruptions := make(map[string]v1beta1.NodeInterruptionState)
+	config := cluster.Spec.NodeInterruption
+	if config == nil {
+		return interruptions, nil
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(cluster.Namespace), client.MatchingLabels(apiutil.LabelsForKafka(cluster.Name))); err != nil {
+		return nil, err
+	}
+	for _, pod := range pods.Items {
+		brokerID, ok := pod.Labels[v1beta1.BrokerIdLabelKey]
+		if !ok || pod.Spec.NodeName == "" {
+			continue
+		}
+		node := &corev1.Node{}
+		if err := r.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		signal := nodeInterruptionSignal(node, *config)
+		if signal == "" {
+			continue
+		}
+		if recorded := cluster.Status.BrokersState[brokerID].NodeInterruption; recorded != nil && recorded.Node == node.Name {
+			interruptions[brokerID] = *recorded
+			continue
+		}
+		interruptions[brokerID] = v1beta1.NodeInterruptionState{Node: node.Name, Signal: signal, DetectedTime: metav1.Now()}
+	}
+	return interruptions, nil
+}
+
+// nodeInterruptionSignal returns the key of the taint or the type of the condition signaling the interruption of the
+// node, empty if the node is not interrupted
+func nodeInterruptionSignal(node *corev1.Node, config v1beta1.NodeInterruptionConfig) string {
+	taints := config.GetTaints()
+	for _, taint := range node.Spec.Taints {
+		if slices.Contains(taints, taint.Key) {
+			return taint.Key
+		}
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Status == corev1.ConditionTrue && slices.Contains(config.Conditions, condition.Type) {
+			return string(condition.Type)
+		}
+	}
+	return ""
+}
+
+// clearEndedInterruptions removes the node interruptions of the brokers which are no longer running on an interrupted
+// node from their status
+func (r *NodeInterruptionReconciler) clearEndedInterruptions(cluster *v1beta1.KafkaCluster,
+	interruptions map[string]v1beta1.NodeInterruptionState, log logr.Logger) error {
+	var ended []string
+	for brokerID, brokerState := range cluster.Status.BrokersState {
+		if _, interrupted := interruptions[brokerID]; brokerState.NodeInterruption != nil && !interrupted {
+			ended = append(ended, brokerID)
+		}
+	}
+	sort.Strings(ended)
+	for _, brokerID := range ended {
+		node := cluster.Status.BrokersState[brokerID].NodeInterruption.Node
+		if err := k8sutil.UpdateBrokerStatus(r.Client, []string{brokerID}, cluster, (*v1beta1.NodeInterruptionState)(nil), log); err != nil {
+			return err
+		}
+		log.Info("the broker is no longer running on the interrupted node", v1beta1.BrokerIdLabelKey, brokerID, "node", node)
+		k8sutil.RecordEvent(r.Recorder, cluster, corev1.EventTypeNormal, brokerNodeInterruptionEndedEventReason,
+			"broker %s is no longer running on the interrupted node %s", brokerID, node)
+	}
+	return nil
+}
+
+// trackDemotions reports the state of the demote_broker CruiseControlOperations of the interruptions and clears the
+// references to the operations which no longer exist, so the brokers are demoted again while they stay on the
+// interrupted node. The failed demotions are retried by the operations themselves.
+func (r *NodeInterruptionReconciler) trackDemotions(ctx context.Context, cluster *v1beta1.KafkaCluster,
+	interruptions map[string]v1beta1.NodeInterruptionState, log logr.Logger) error {
+	var names []string
+	for _, interruption := range interruptions {
+		if ref := interruption.DemoteOperationReference; ref != nil && !slices.Contains(names, ref.Name) {
+			names = append(names, ref.Name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		operation := &v1alpha1.CruiseControlOperation{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: name}, operation)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.WrapIfWithDetails(err, "could not get the demote_broker CruiseControlOperation", "name", name)
+		}
+		for brokerID, interruption := range interruptions {
+			if ref := interruption.DemoteOperationReference; ref == nil || ref.Name != name {
+				continue
+			}
+			if apierrors.IsNotFound(err) {
+				log.Info("the demote_broker CruiseControlOperation of the broker of the interrupted node is gone, it is demoted again",
+					v1beta1.BrokerIdLabelKey, brokerID, "node", interruption.Node, "cruiseControlOperation", name)
+				interruption.DemoteOperationReference = nil
+				interruptions[brokerID] = interruption
+				continue
+			}
+			log.V(1).Info("the broker of the interrupted node is being demoted", v1beta1.BrokerIdLabelKey, brokerID,
+				"node", interruption.Node, "cruiseControlOperation", name, "taskState", operation.CurrentTaskState())
+		}
+	}
+	return nil
+}
+
+// demoteBrokers creates the demote_broker CruiseControlOperation moving the leadership of the partitions away from the
+// brokers, which is executed through the queue of the Cruise Control operations of the cluster
+func (r *NodeInterruptionReconciler) demoteBrokers(ctx context.Context, cluster *v1beta1.KafkaCluster, brokerIDs []string) (*corev1.LocalObjectReference, error) {
+	operation := &v1alpha1.CruiseControlOperation{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-%s-", cluster.Name, strings.ReplaceAll(string(v1alpha1.OperationDemoteBroker), "_", "")),
+			Namespace:    cluster.Namespace,
+			Labels:       apiutil.LabelsForKafka(cluster.Name),
+		},
+		Spec: v1alpha1.CruiseControlOperationSpec{
+			ErrorPolicy:             v1alpha1.ErrorPolicyRetry,
+			TTLSecondsAfterFinished: cluster.Spec.CruiseControlConfig.CruiseControlOperationSpec.GetTTLSecondsAfterFinished(),
+		},
+	}
+	if err := controllerutil.SetControllerReference(cluster, operation, r.Scheme); err != nil {
+		return nil, errors.WrapIf(err, "could not set the owner of the CruiseControlOperation")
+	}
+	if err := r.Create(ctx, operation); err != nil {
+		return nil, errors.WrapIf(err, "could not create the demote_broker CruiseControlOperation")
+	}
+
+	operation.Status.CurrentTask = &v1alpha1.CruiseControlTask{
+		Operation:  v1alpha1.OperationDemoteBroker,
+		Parameters: map[string]string{scale.ParamBrokerID: strings.Join(brokerIDs, ",")},
+	}
+	if err := r.Status().Update(ctx, operation); err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not set the task of the CruiseControlOperation", "name", operation.Name)
+	}
+	return &corev1.LocalObjectReference{Name: operation.Name}, nil
+}
@@ -0,0 +1,222 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/scale"
+)
+
+func TestNodeInterruptionSignal(t *testing.T) {
+	testCases := []struct {
+		testName string
+		node     corev1.Node
+		config   v1beta1.NodeInterruptionConfig
+		expected string
+	}{
+		{
+			testName: "healthy node",
+			node:     corev1.Node{},
+			expected: "",
+		},
+		{
+			testName: "default spot interruption taint",
+			node: corev1.Node{Spec: corev1.NodeSpec{Taints: []corev1.Taint{
+				{Key: "aws-node-termination-handler/spot-itn", Effect: corev1.TaintEffectNoSchedule},
+			}}},
+			expected: "aws-node-termination-handler/spot-itn",
+		},
+		{
+			testName: "taint not configured",
+			node: corev1.Node{Spec: corev1.NodeSpec{Taints: []corev1.Taint{
+				{Key: "karpenter.sh/disrupted", Effect: corev1.TaintEffectNoSchedule},
+			}}},
+			config:   v1beta1.NodeInterruptionConfig{Taints: []string{"example.com/preempted"}},
+			expected: "",
+		},
+		{
+			testName: "configured condition",
+			node: corev1.Node{Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: "TerminationScheduled", Status: corev1.ConditionTrue},
+			}}},
+			config:   v1beta1.NodeInterruptionConfig{Conditions: []corev1.NodeConditionType{"TerminationScheduled"}},
+			expected: "TerminationScheduled",
+		},
+		{
+			testName: "configured condition not true",
+			node: corev1.Node{Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: "TerminationScheduled", Status: corev1.ConditionFalse},
+			}}},
+			config:   v1beta1.NodeInterruptionConfig{Conditions: []corev1.NodeConditionType{"TerminationScheduled"}},
+			expected: "",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			require.Equal(t, test.expected, nodeInterruptionSignal(&test.node, test.config))
+		})
+	}
+}
+
+func TestNodeInterruptionReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	brokerPod := func(brokerID, nodeName string) *corev1.Pod {
+		labels := apiutil.LabelsForKafka("kafka")
+		labels[v1beta1.BrokerIdLabelKey] = brokerID
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "kafka-" + brokerID, Namespace: "kafka", Labels: labels},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+		}
+	}
+	interruptedNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "spot"},
+		Spec: corev1.NodeSpec{Taints: []corev1.Taint{
+			{Key: "aws-node-termination-handler/spot-itn", Effect: corev1.TaintEffectNoSchedule},
+		}},
+	}
+	cluster := &v1beta1.KafkaCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+		Spec:       v1beta1.KafkaClusterSpec{NodeInterruption: &v1beta1.NodeInterruptionConfig{}},
+		Status: v1beta1.KafkaClusterStatus{
+			BrokersState: map[string]v1beta1.BrokerState{
+				"0": {},
+				"1": {},
+				"2": {NodeInterruption: &v1beta1.NodeInterruptionState{Node: "reclaimed", Signal: "karpenter.sh/disrupted"}},
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&v1beta1.KafkaCluster{}, &v1alpha1.CruiseControlOperation{}).
+		WithObjects(cluster, interruptedNode, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "stable"}},
+			brokerPod("0", "spot"), brokerPod("1", "stable"), brokerPod("2", "stable")).
+		Build()
+
+	recorder := record.NewFakeRecorder(10)
+	reconciler := &NodeInterruptionReconciler{
+		Client:   c,
+		Scheme:   scheme,
+		Recorder: recorder,
+	}
+	request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cluster)}
+
+	getBrokersState := func() map[string]v1beta1.BrokerState {
+		updatedCluster := &v1beta1.KafkaCluster{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(cluster), updatedCluster))
+		return updatedCluster.Status.BrokersState
+	}
+	getOperations := func() []v1alpha1.CruiseControlOperation {
+		operations := &v1alpha1.CruiseControlOperationList{}
+		require.NoError(t, c.List(context.Background(), operations, client.InNamespace("kafka")))
+		return operations.Items
+	}
+
+	// the broker is demoted through a demote_broker CruiseControlOperation
+	result, err := reconciler.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, nodeInterruptionRequeueInterval, result.RequeueAfter)
+	brokersState := getBrokersState()
+	require.NotNil(t, brokersState["0"].NodeInterruption)
+	require.Equal(t, "spot", brokersState["0"].NodeInterruption.Node)
+	require.Equal(t, "aws-node-termination-handler/spot-itn", brokersState["0"].NodeInterruption.Signal)
+	require.Nil(t, brokersState["1"].NodeInterruption)
+	require.Nil(t, brokersState["2"].NodeInterruption)
+	require.Len(t, recorder.Events, 2)
+	require.Contains(t, <-recorder.Events, brokerNodeInterruptionEndedEventReason)
+	require.Contains(t, <-recorder.Events, brokerNodeInterruptedEventReason)
+
+	operations := getOperations()
+	require.Len(t, operations, 1)
+	require.Equal(t, v1alpha1.OperationDemoteBroker, operations[0].CurrentTaskOperation())
+	require.Equal(t, map[string]string{scale.ParamBrokerID: "0"}, operations[0].CurrentTaskParameters())
+	require.Equal(t, apiutil.LabelsForKafka("kafka"), operations[0].Labels)
+	require.Equal(t, &corev1.LocalObjectReference{Name: operations[0].Name}, brokersState["0"].NodeInterruption.DemoteOperationReference)
+
+	// the broker is demoted only once while it stays on the interrupted node
+	result, err = reconciler.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, nodeInterruptionRequeueInterval, result.RequeueAfter)
+	require.Len(t, getOperations(), 1)
+	require.Empty(t, recorder.Events)
+
+	// the broker is demoted again once its operation is gone
+	require.NoError(t, c.Delete(context.Background(), &operations[0]))
+	result, err = reconciler.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, nodeInterruptionRequeueInterval, result.RequeueAfter)
+	operations = getOperations()
+	require.Len(t, operations, 1)
+	require.Equal(t, &corev1.LocalObjectReference{Name: operations[0].Name}, getBrokersState()["0"].NodeInterruption.DemoteOperationReference)
+	require.Empty(t, recorder.Events)
+
+	// the interruption is cleared once the broker left the node
+	pod := &corev1.Pod{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "kafka-0", Namespace: "kafka"}, pod))
+	pod.Spec.NodeName = "stable"
+	require.NoError(t, c.Update(context.Background(), pod))
+	result, err = reconciler.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+	require.Zero(t, result.RequeueAfter)
+	require.Nil(t, getBrokersState()["0"].NodeInterruption)
+	require.Contains(t, <-recorder.Events, brokerNodeInterruptionEndedEventReason)
+}
+
+func TestNodeInterruptionMapper(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	pod := func(name, namespace, cluster, nodeName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: apiutil.LabelsForKafka(cluster)},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+		}
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			pod("kafka-0", "kafka", "kafka", "spot"),
+			pod("kafka-1", "kafka", "kafka", "spot"),
+			pod("other-0", "other", "other", "spot"),
+			pod("kafka-2", "kafka", "kafka", "stable"),
+		).Build()
+
+	mapper := nodeInterruptionMapper{client: c}
+	requests := mapper.mapToKafkaClusters(context.Background(), &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "spot"}})
+	require.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: client.ObjectKey{Name: "kafka", Namespace: "kafka"}},
+		{NamespacedName: client.ObjectKey{Name: "other", Namespace: "other"}},
+	}, requests)
+}
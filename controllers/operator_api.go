@@ -0,0 +1,493 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+const (
+	// OperatorAPIPath is the path the KafkaClusters are served on by the operator API, as a list on the path itself
+	// and one by one on <path>/<namespace>/<name>
+	OperatorAPIPath = "/api/v1/kafkaclusters"
+
+	operatorAPITimeout = 10 * time.Second
+
+	// the TokenReviews and SubjectAccessReviews are cached for the same durations as by controller-runtime's
+	// filters.WithAuthenticationAndAuthorization, so portals polling the API do not review every request
+	operatorAPICacheSize          = 1024
+	operatorAPIAuthenticationTTL  = time.Minute
+	operatorAPIAllowedDecisionTTL = 5 * time.Minute
+	operatorAPIDeniedDecisionTTL  = 30 * time.Second
+)
+
+// ClusterSummary is the summary of a KafkaCluster served by the operator API
+type ClusterSummary struct {
+	Namespace   string               `json:"namespace"`
+	Name        string               `json:"name"`
+	State       v1beta1.ClusterState `json:"state"`
+	BrokerCount int                  `json:"brokerCount"`
+	AlertCount  int                  `json:"alertCount"`
+}
+
+// ClusterInfo is the state of a KafkaCluster served by the operator API
+type ClusterInfo struct {
+	ClusterSummary
+	Conditions        []metav1.Condition         `json:"conditions,omitempty"`
+	Brokers           []BrokerInfo               `json:"brokers"`
+	ListenerEndpoints *v1beta1.ListenerEndpoints `json:"listenerEndpoints,omitempty"`
+	// CruiseControlTasks are the CruiseControlOperations of the cluster which are not done yet, oldest first
+	CruiseControlTasks []CruiseControlTaskInfo `json:"cruiseControlTasks"`
+	TopicCount         int                     `json:"topicCount"`
+	UserCount          int                     `json:"userCount"`
+}
+
+// BrokerInfo is the state of a broker served by the operator API
+type BrokerInfo struct {
+	ID                 string                     `json:"id"`
+	Version            string                     `json:"version,omitempty"`
+	Image              string                     `json:"image,omitempty"`
+	ConfigurationState v1beta1.ConfigurationState `json:"configurationState"`
+	CruiseControlState v1beta1.CruiseControlState `json:"cruiseControlState"`
+	RackAwarenessState v1beta1.RackAwarenessState `json:"rackAwarenessState"`
+}
+
+// CruiseControlTaskInfo is the state of a CruiseControlOperation served by the operator API
+type CruiseControlTaskInfo struct {
+	Name      string                              `json:"name"`
+	Operation v1alpha1.CruiseControlTaskOperation `json:"operation"`
+	State     v1beta1.CruiseControlUserTaskState  `json:"state,omitempty"`
+	TaskID    string                              `json:"taskID,omitempty"`
+	Paused    bool                                `json:"paused,omitempty"`
+	Created   metav1.Time                         `json:"created"`
+}
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// OperatorAPI serves the state of the KafkaClusters in the scope of the operator as JSON for portals and other
+// integrations. The state is read from the cache of the operator, neither the Kafka clusters nor Cruise Control are
+// queried. Only the KafkaClusters the user of the request is allowed to get are served.
+type OperatorAPI struct {
+	Client client.Reader
+	// Authorizer creates the SubjectAccessReviews authorizing the user of the request to get the KafkaClusters
+	Authorizer client.Writer
+	Log        logr.Logger
+	// decisions caches the results of the SubjectAccessReviews, they are not cached when it is nil
+	decisions *cache.LRUExpireCache
+}
+
+// operatorAPIUserKey is the key of the user authenticated by the bearer token in the context of the request
+type operatorAPIUserKey struct{}
+
+// SetupOperatorAPIWithManager serves the operator API on the given address of the Manager, over TLS with the
+// tls.crt and tls.key of the certificate directory. The requests are authenticated with TokenReviews and authorized
+// with SubjectAccessReviews of the list verb on the namespace, or of the get verb on each KafkaCluster served.
+func SetupOperatorAPIWithManager(mgr manager.Manager, addr, certDir string) error {
+	if certDir == "" {
+		return errors.New("the operator API requires a TLS certificate directory, the bearer tokens of the requests are not accepted over plain HTTP")
+	}
+	certWatcher, err := certwatcher.New(filepath.Join(certDir, "tls.crt"), filepath.Join(certDir, "tls.key"))
+	if err != nil {
+		return errors.WrapIf(err, "could not load the certificate of the operator API")
+	}
+
+	log := ctrl.Log.WithName("operator-api")
+	mux := http.NewServeMux()
+	api := &OperatorAPI{
+		Client:     mgr.GetClient(),
+		Authorizer: mgr.GetClient(),
+		Log:        log,
+		decisions:  cache.NewLRUExpireCache(operatorAPICacheSize),
+	}
+	mux.Handle(OperatorAPIPath, api)
+	mux.Handle(OperatorAPIPath+"/", api)
+
+	return mgr.Add(&operatorAPIServer{
+		addr:        addr,
+		handler:     withTokenAuthentication(mgr.GetClient(), log, mux),
+		certWatcher: certWatcher,
+		log:         log,
+	})
+}
+
+// withTokenAuthentication only passes the requests whose bearer token is authenticated by a TokenReview to the
+// handler, with the user of the token in the context of the request. The TokenReviews are cached by the hash of the
+// token.
+func withTokenAuthentication(c client.Client, log logr.Logger, handler http.Handler) http.Handler {
+	tokenReviews := cache.NewLRUExpireCache(operatorAPICacheSize)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		tokenHash := sha256.Sum256([]byte(token))
+		status, ok := tokenReviews.Get(tokenHash)
+		if !ok {
+			ctx, cancel := context.WithTimeout(r.Context(), operatorAPITimeout)
+			defer cancel()
+
+			tokenReview := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+			if err := c.Create(ctx, tokenReview); err != nil {
+				log.Error(err, "could not review the token of the request")
+				http.Error(w, "could not authenticate the request", http.StatusInternalServerError)
+				return
+			}
+			status = tokenReview.Status
+			tokenReviews.Add(tokenHash, status, operatorAPIAuthenticationTTL)
+		}
+		if !status.(authenticationv1.TokenReviewStatus).Authenticated {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		user := status.(authenticationv1.TokenReviewStatus).User
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), operatorAPIUserKey{}, user)))
+	})
+}
+
+// canGetCluster returns whether the user of the request is allowed to get the KafkaCluster
+func (a *OperatorAPI) canGetCluster(ctx context.Context, cluster types.NamespacedName) (bool, error) {
+	return a.isAllowed(ctx, "get", cluster.Namespace, cluster.Name)
+}
+
+// canListClusters returns whether the user of the request is allowed to list the KafkaClusters of the namespace
+func (a *OperatorAPI) canListClusters(ctx context.Context, namespace string) (bool, error) {
+	return a.isAllowed(ctx, "list", namespace, "")
+}
+
+// isAllowed returns whether the user of the request is allowed the verb on the KafkaClusters by a SubjectAccessReview
+func (a *OperatorAPI) isAllowed(ctx context.Context, verb, namespace, name string) (bool, error) {
+	user, ok := ctx.Value(operatorAPIUserKey{}).(authenticationv1.UserInfo)
+	if !ok {
+		return false, nil
+	}
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	accessReview := &authorizationv1.SubjectAccessReview{Spec: authorizationv1.SubjectAccessReviewSpec{
+		User:   user.Username,
+		UID:    user.UID,
+		Groups: user.Groups,
+		Extra:  extra,
+		ResourceAttributes: &authorizationv1.ResourceAttributes{
+			Namespace: namespace,
+			Verb:      verb,
+			Group:     v1beta1.GroupVersion.Group,
+			Resource:  "kafkaclusters",
+			Name:      name,
+		},
+	}}
+
+	var decisionKey string
+	if a.decisions != nil {
+		spec, err := json.Marshal(accessReview.Spec)
+		if err != nil {
+			return false, errors.WrapIfWithDetails(err, "could not compute the cache key of the access review", "user", user.Username)
+		}
+		decisionKey = string(spec)
+		if allowed, ok := a.decisions.Get(decisionKey); ok {
+			return allowed.(bool), nil
+		}
+	}
+	if err := a.Authorizer.Create(ctx, accessReview); err != nil {
+		return false, errors.WrapIfWithDetails(err, "could not review the access of the request", "user", user.Username)
+	}
+	if a.decisions != nil {
+		ttl := operatorAPIDeniedDecisionTTL
+		if accessReview.Status.Allowed {
+			ttl = operatorAPIAllowedDecisionTTL
+		}
+		a.decisions.Add(decisionKey, accessReview.Status.Allowed, ttl)
+	}
+	return accessReview.Status.Allowed, nil
+}
+
+// operatorAPIServer serves the operator API on every replica of the operator, as they all have a cache
+type operatorAPIServer struct {
+	addr        string
+	handler     http.Handler
+	certWatcher *certwatcher.CertWatcher
+	log         logr.Logger
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable
+func (s *operatorAPIServer) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable
+func (s *operatorAPIServer) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              s.addr,
+		Handler:           s.handler,
+		ReadHeaderTimeout: operatorAPITimeout,
+	}
+	go func() {
+		if err := s.certWatcher.Start(ctx); err != nil {
+			s.log.Error(err, "could not watch the certificate of the operator API")
+		}
+	}()
+	server.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12, GetCertificate: s.certWatcher.GetCertificate}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), operatorAPITimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			s.log.Error(err, "could not shut down the operator API")
+		}
+	}()
+
+	s.log.Info("Serving the operator API", "addr", s.addr)
+	err := server.ListenAndServeTLS("", "")
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// ServeHTTP implements http.Handler
+func (a *OperatorAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), operatorAPITimeout)
+	defer cancel()
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, OperatorAPIPath), "/")
+	if path == "" {
+		a.serveClusters(ctx, w)
+		return
+	}
+	parts := strings.Split(path, "/")
+	if len(parts) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+
+	// the request is authorized before the lookup, so the callers not allowed to get the cluster cannot tell whether
+	// it exists
+	clusterName := types.NamespacedName{Namespace: parts[0], Name: parts[1]}
+	allowed, err := a.canGetCluster(ctx, clusterName)
+	if err != nil {
+		a.Log.Error(err, "could not authorize the request", "clusterName", clusterName.Name, "clusterNamespace", clusterName.Namespace)
+		http.Error(w, "could not authorize the request", http.StatusInternalServerError)
+		return
+	}
+	if !allowed {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	cluster := &v1beta1.KafkaCluster{}
+	if err := a.Client.Get(ctx, clusterName, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			http.NotFound(w, r)
+			return
+		}
+		a.Log.Error(err, "could not get Kafka cluster", "clusterName", clusterName.Name, "clusterNamespace", clusterName.Namespace)
+		http.Error(w, "could not get Kafka cluster", http.StatusInternalServerError)
+		return
+	}
+	if !operatorScope.includes(cluster) {
+		http.NotFound(w, r)
+		return
+	}
+
+	info, err := a.clusterInfo(ctx, cluster)
+	if err != nil {
+		a.Log.Error(err, "could not collect the state of Kafka cluster", "clusterName", cluster.Name, "clusterNamespace", cluster.Namespace)
+		http.Error(w, "could not collect the state of Kafka cluster", http.StatusInternalServerError)
+		return
+	}
+	a.writeJSON(w, info)
+}
+
+func (a *OperatorAPI) serveClusters(ctx context.Context, w http.ResponseWriter) {
+	clusters := &v1beta1.KafkaClusterList{}
+	if err := a.Client.List(ctx, clusters); err != nil {
+		a.Log.Error(err, "could not list Kafka clusters")
+		http.Error(w, "could not list Kafka clusters", http.StatusInternalServerError)
+		return
+	}
+	summaries := make([]ClusterSummary, 0, len(clusters.Items))
+	// the user is authorized to list the clusters once per namespace, the clusters of the namespaces it is not allowed
+	// to list are authorized one by one
+	namespacesListed := make(map[string]bool)
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		if !operatorScope.includes(cluster) {
+			continue
+		}
+		listed, ok := namespacesListed[cluster.Namespace]
+		if !ok {
+			var err error
+			listed, err = a.canListClusters(ctx, cluster.Namespace)
+			if err != nil {
+				a.Log.Error(err, "could not authorize the request", "clusterNamespace", cluster.Namespace)
+				http.Error(w, "could not authorize the request", http.StatusInternalServerError)
+				return
+			}
+			namespacesListed[cluster.Namespace] = listed
+		}
+		allowed := listed
+		if !allowed {
+			var err error
+			allowed, err = a.canGetCluster(ctx, client.ObjectKeyFromObject(cluster))
+			if err != nil {
+				a.Log.Error(err, "could not authorize the request", "clusterName", cluster.Name, "clusterNamespace", cluster.Namespace)
+				http.Error(w, "could not authorize the request", http.StatusInternalServerError)
+				return
+			}
+		}
+		if allowed {
+			summaries = append(summaries, clusterSummary(cluster))
+		}
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Namespace != summaries[j].Namespace {
+			return summaries[i].Namespace < summaries[j].Namespace
+		}
+		return summaries[i].Name < summaries[j].Name
+	})
+	a.writeJSON(w, summaries)
+}
+
+func (a *OperatorAPI) writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		a.Log.Error(err, "could not write the response of the operator API")
+	}
+}
+
+func clusterSummary(cluster *v1beta1.KafkaCluster) ClusterSummary {
+	return ClusterSummary{
+		Namespace:   cluster.Namespace,
+		Name:        cluster.Name,
+		State:       cluster.Status.State,
+		BrokerCount: len(cluster.Spec.Brokers),
+		AlertCount:  cluster.Status.AlertCount,
+	}
+}
+
+// clusterInfo collects the state of the cluster, its brokers, its pending Cruise Control operations and the number of
+// its topics and users
+func (a *OperatorAPI) clusterInfo(ctx context.Context, cluster *v1beta1.KafkaCluster) (*ClusterInfo, error) {
+	info := &ClusterInfo{
+		ClusterSummary:     clusterSummary(cluster),
+		Conditions:         cluster.Status.Conditions,
+		Brokers:            make([]BrokerInfo, 0, len(cluster.Status.BrokersState)),
+		ListenerEndpoints:  cluster.Status.ListenerEndpoints,
+		CruiseControlTasks: []CruiseControlTaskInfo{},
+	}
+
+	for id, state := range cluster.Status.BrokersState {
+		info.Brokers = append(info.Brokers, BrokerInfo{
+			ID:                 id,
+			Version:            state.Version,
+			Image:              state.Image,
+			ConfigurationState: state.ConfigurationState,
+			CruiseControlState: state.GracefulActionState.CruiseControlState,
+			RackAwarenessState: state.RackAwarenessState,
+		})
+	}
+	sort.Slice(info.Brokers, func(i, j int) bool {
+		idI, errI := strconv.Atoi(info.Brokers[i].ID)
+		idJ, errJ := strconv.Atoi(info.Brokers[j].ID)
+		if errI != nil || errJ != nil {
+			return info.Brokers[i].ID < info.Brokers[j].ID
+		}
+		return idI < idJ
+	})
+
+	operations := &v1alpha1.CruiseControlOperationList{}
+	if err := a.Client.List(ctx, operations, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{v1beta1.KafkaCRLabelKey: cluster.Name}); err != nil {
+		return nil, errors.WrapIf(err, "could not list CruiseControlOperations")
+	}
+	for i := range operations.Items {
+		operation := &operations.Items[i]
+		if operation.IsDone() {
+			continue
+		}
+		info.CruiseControlTasks = append(info.CruiseControlTasks, CruiseControlTaskInfo{
+			Name:      operation.Name,
+			Operation: operation.CurrentTaskOperation(),
+			State:     operation.CurrentTaskState(),
+			TaskID:    operation.CurrentTaskID(),
+			Paused:    operation.IsPaused(),
+			Created:   operation.CreationTimestamp,
+		})
+	}
+	sort.SliceStable(info.CruiseControlTasks, func(i, j int) bool {
+		return info.CruiseControlTasks[i].Created.Before(&info.CruiseControlTasks[j].Created)
+	})
+
+	topics := &v1alpha1.KafkaTopicList{}
+	if err := a.Client.List(ctx, topics); err != nil {
+		return nil, errors.WrapIf(err, "could not list KafkaTopics")
+	}
+	for i := range topics.Items {
+		if referencesCluster(topics.Items[i].Namespace, topics.Items[i].Spec.ClusterRef, cluster) {
+			info.TopicCount++
+		}
+	}
+
+	users := &v1alpha1.KafkaUserList{}
+	if err := a.Client.List(ctx, users); err != nil {
+		return nil, errors.WrapIf(err, "could not list KafkaUsers")
+	}
+	for i := range users.Items {
+		if referencesCluster(users.Items[i].Namespace, users.Items[i].Spec.ClusterRef, cluster) {
+			info.UserCount++
+		}
+	}
+	return info, nil
+}
+
+// referencesCluster returns whether the cluster reference of a resource in the given namespace points to the cluster
+func referencesCluster(namespace string, ref v1alpha1.ClusterReference, cluster *v1beta1.KafkaCluster) bool {
+	return ref.Name == cluster.Name && getClusterRefNamespace(namespace, ref) == cluster.Namespace
+}
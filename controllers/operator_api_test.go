@@ -0,0 +1,331 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func TestOperatorAPI(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	created := metav1.NewTime(time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC))
+	payments := &v1beta1.KafkaCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "kafka", Labels: map[string]string{"team": "payments"}},
+		Spec:       v1beta1.KafkaClusterSpec{Brokers: []v1beta1.Broker{{Id: 0}, {Id: 1}, {Id: 10}}},
+		Status: v1beta1.KafkaClusterStatus{
+			State: v1beta1.KafkaClusterRunning,
+			BrokersState: map[string]v1beta1.BrokerState{
+				"10": {ConfigurationState: v1beta1.ConfigInSync, RackAwarenessState: v1beta1.Configured},
+				"1":  {ConfigurationState: v1beta1.ConfigInSync, RackAwarenessState: v1beta1.Configured},
+				"0": {
+					ConfigurationState:  v1beta1.ConfigInSync,
+					RackAwarenessState:  v1beta1.Configured,
+					GracefulActionState: v1beta1.GracefulActionState{CruiseControlState: v1beta1.GracefulUpscaleSucceeded},
+				},
+			},
+		},
+	}
+	orders := &v1beta1.KafkaCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "kafka", Labels: map[string]string{"team": "orders"}},
+		Spec:       v1beta1.KafkaClusterSpec{Brokers: []v1beta1.Broker{{Id: 0}}},
+		Status:     v1beta1.KafkaClusterStatus{State: v1beta1.KafkaClusterReconciling},
+	}
+	operation := func(name string, state v1beta1.CruiseControlUserTaskState) *v1alpha1.CruiseControlOperation {
+		return &v1alpha1.CruiseControlOperation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "kafka",
+				Labels:            map[string]string{v1beta1.KafkaCRLabelKey: "payments"},
+				CreationTimestamp: created,
+			},
+			Status: v1alpha1.CruiseControlOperationStatus{
+				CurrentTask: &v1alpha1.CruiseControlTask{ID: name + "-task", Operation: v1alpha1.OperationRebalance, State: state},
+			},
+		}
+	}
+	topic := func(name, namespace, clusterNamespace string) *v1alpha1.KafkaTopic {
+		return &v1alpha1.KafkaTopic{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       v1alpha1.KafkaTopicSpec{ClusterRef: v1alpha1.ClusterReference{Name: "payments", Namespace: clusterNamespace}},
+		}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(payments, orders,
+			operation("rebalance", v1beta1.CruiseControlTaskActive), operation("done", v1beta1.CruiseControlTaskCompleted),
+			topic("events", "kafka", ""), topic("audit", "topics", "kafka"), topic("other", "topics", ""),
+			&v1alpha1.KafkaUser{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "kafka"},
+				Spec:       v1alpha1.KafkaUserSpec{ClusterRef: v1alpha1.ClusterReference{Name: "payments"}},
+			}).
+		Build()
+	var accessReviews int
+	authorizer := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			// the admin is allowed to list the clusters, the payments team is only allowed to get its own cluster
+			accessReviews++
+			review := obj.(*authorizationv1.SubjectAccessReview)
+			attributes := review.Spec.ResourceAttributes
+			review.Status.Allowed = attributes.Group == v1beta1.GroupVersion.Group && attributes.Resource == "kafkaclusters" &&
+				attributes.Namespace == "kafka" &&
+				(review.Spec.User == "admin" || attributes.Verb == "get" && attributes.Name == "payments")
+			return nil
+		},
+	}).Build()
+	api := &OperatorAPI{Client: c, Authorizer: authorizer, Log: logr.Discard()}
+
+	testCases := []struct {
+		testName           string
+		method             string
+		path               string
+		user               string
+		unauthenticated    bool
+		scope              OperatorScope
+		expectedStatusCode int
+		expectedBody       string
+		expectedReviews    int
+	}{
+		{
+			testName:           "list of the clusters",
+			path:               OperatorAPIPath,
+			expectedStatusCode: http.StatusOK,
+			expectedReviews:    1,
+			expectedBody: `[
+				{"namespace": "kafka", "name": "orders", "state": "ClusterReconciling", "brokerCount": 1, "alertCount": 0},
+				{"namespace": "kafka", "name": "payments", "state": "ClusterRunning", "brokerCount": 3, "alertCount": 0}
+			]`,
+		},
+		{
+			testName:           "list of the clusters the user is allowed to get",
+			path:               OperatorAPIPath,
+			user:               "payments",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `[{"namespace": "kafka", "name": "payments", "state": "ClusterRunning", "brokerCount": 3, "alertCount": 0}]`,
+			expectedReviews:    3,
+		},
+		{
+			testName:           "list of the clusters in the scope",
+			path:               OperatorAPIPath + "/",
+			scope:              OperatorScope{ClusterSelector: labels.SelectorFromSet(labels.Set{"team": "payments"})},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `[{"namespace": "kafka", "name": "payments", "state": "ClusterRunning", "brokerCount": 3, "alertCount": 0}]`,
+		},
+		{
+			testName:           "state of a cluster",
+			path:               OperatorAPIPath + "/kafka/payments",
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{
+				"namespace": "kafka", "name": "payments", "state": "ClusterRunning", "brokerCount": 3, "alertCount": 0,
+				"brokers": [
+					{"id": "0", "configurationState": "ConfigInSync", "cruiseControlState": "GracefulUpscaleSucceeded", "rackAwarenessState": "Configured"},
+					{"id": "1", "configurationState": "ConfigInSync", "cruiseControlState": "", "rackAwarenessState": "Configured"},
+					{"id": "10", "configurationState": "ConfigInSync", "cruiseControlState": "", "rackAwarenessState": "Configured"}
+				],
+				"cruiseControlTasks": [
+					{"name": "rebalance", "operation": "rebalance", "state": "Active", "taskID": "rebalance-task", "created": "2025-03-01T10:00:00Z"}
+				],
+				"topicCount": 2,
+				"userCount": 1
+			}`,
+		},
+		{
+			testName:           "cluster the user is not allowed to get",
+			path:               OperatorAPIPath + "/kafka/orders",
+			user:               "payments",
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			testName:           "unauthenticated request",
+			path:               OperatorAPIPath + "/kafka/payments",
+			unauthenticated:    true,
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			testName:           "cluster out of the scope",
+			path:               OperatorAPIPath + "/kafka/orders",
+			scope:              OperatorScope{ClusterSelector: labels.SelectorFromSet(labels.Set{"team": "payments"})},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			testName:           "missing cluster",
+			path:               OperatorAPIPath + "/kafka/removed",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			testName:           "missing cluster the user is not allowed to get",
+			path:               OperatorAPIPath + "/kafka/removed",
+			user:               "payments",
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			testName:           "unknown path",
+			path:               OperatorAPIPath + "/kafka/payments/brokers",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			testName:           "write request",
+			method:             http.MethodPost,
+			path:               OperatorAPIPath,
+			expectedStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			defer SetOperatorScope(OperatorScope{})
+			SetOperatorScope(test.scope)
+
+			method := test.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			request := httptest.NewRequest(method, test.path, nil)
+			if !test.unauthenticated {
+				user := authenticationv1.UserInfo{Username: "admin"}
+				if test.user != "" {
+					user.Username = test.user
+				}
+				request = request.WithContext(context.WithValue(request.Context(), operatorAPIUserKey{}, user))
+			}
+			accessReviews = 0
+			recorder := httptest.NewRecorder()
+			api.ServeHTTP(recorder, request)
+
+			require.Equal(t, test.expectedStatusCode, recorder.Code)
+			if test.expectedBody != "" {
+				require.JSONEq(t, test.expectedBody, recorder.Body.String())
+			}
+			if test.expectedReviews != 0 {
+				require.Equal(t, test.expectedReviews, accessReviews)
+			}
+		})
+	}
+
+	t.Run("cached access reviews", func(t *testing.T) {
+		cachingAPI := &OperatorAPI{Client: c, Authorizer: authorizer, Log: logr.Discard(), decisions: cache.NewLRUExpireCache(operatorAPICacheSize)}
+		request := httptest.NewRequest(http.MethodGet, OperatorAPIPath, nil)
+		request = request.WithContext(context.WithValue(request.Context(), operatorAPIUserKey{}, authenticationv1.UserInfo{Username: "payments"}))
+
+		accessReviews = 0
+		for range 3 {
+			recorder := httptest.NewRecorder()
+			cachingAPI.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusOK, recorder.Code)
+			require.JSONEq(t, `[{"namespace": "kafka", "name": "payments", "state": "ClusterRunning", "brokerCount": 3, "alertCount": 0}]`,
+				recorder.Body.String())
+		}
+		require.Equal(t, 3, accessReviews)
+	})
+}
+
+func TestOperatorAPIAuthentication(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, authenticationv1.AddToScheme(scheme))
+
+	var tokenReviews int
+	c := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			tokenReviews++
+			if review, ok := obj.(*authenticationv1.TokenReview); ok && review.Spec.Token == "reader" {
+				review.Status = authenticationv1.TokenReviewStatus{
+					Authenticated: true,
+					User:          authenticationv1.UserInfo{Username: "system:serviceaccount:portal:reader"},
+				}
+			}
+			return nil
+		},
+	}).Build()
+	var authenticatedUser string
+	handler := withTokenAuthentication(c, logr.Discard(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authenticatedUser = r.Context().Value(operatorAPIUserKey{}).(authenticationv1.UserInfo).Username
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	testCases := []struct {
+		testName           string
+		authorization      string
+		expectedStatusCode int
+		expectedUser       string
+	}{
+		{
+			testName:           "missing token",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			testName:           "invalid token",
+			authorization:      "Bearer invalid",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			testName:           "basic authentication",
+			authorization:      "Basic cmVhZGVyOnJlYWRlcg==",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			testName:           "authenticated user",
+			authorization:      "Bearer reader",
+			expectedStatusCode: http.StatusOK,
+			expectedUser:       "system:serviceaccount:portal:reader",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			authenticatedUser = ""
+			request := httptest.NewRequest(http.MethodGet, OperatorAPIPath+"/kafka/payments", nil)
+			if test.authorization != "" {
+				request.Header.Set("Authorization", test.authorization)
+			}
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			require.Equal(t, test.expectedStatusCode, recorder.Code)
+			require.Equal(t, test.expectedUser, authenticatedUser)
+		})
+	}
+
+	t.Run("cached token review", func(t *testing.T) {
+		reviews := tokenReviews
+		for _, token := range []string{"reader", "invalid"} {
+			request := httptest.NewRequest(http.MethodGet, OperatorAPIPath+"/kafka/payments", nil)
+			request.Header.Set("Authorization", "Bearer "+token)
+			handler.ServeHTTP(httptest.NewRecorder(), request)
+		}
+		require.Equal(t, reviews, tokenReviews)
+	})
+}
@@ -0,0 +1,156 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"hash/fnv"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+// OperatorScope restricts the KafkaClusters reconciled by the operator, so that several operator instances, e.g. one
+// per team or per environment, can run in the same Kubernetes cluster without reconciling each other's clusters
+type OperatorScope struct {
+	// ExcludedNamespaces are the namespaces whose KafkaClusters are not reconciled
+	ExcludedNamespaces []string
+	// ClusterSelector selects the reconciled KafkaClusters by their labels, every cluster is selected when nil
+	ClusterSelector labels.Selector
+	// ShardCount is the number of operator instances sharing the KafkaClusters, each cluster is reconciled by the
+	// instance whose ShardIndex is the hash of the namespace and the name of the cluster modulo ShardCount. The
+	// clusters are not sharded when it is 0 or 1.
+	ShardCount uint32
+	// ShardIndex is the shard of the KafkaClusters reconciled by the operator, from 0 to ShardCount-1
+	ShardIndex uint32
+}
+
+// operatorScope is the scope of the operator, every KafkaCluster is reconciled by default
+var operatorScope OperatorScope
+
+// SetOperatorScope sets the scope of the KafkaClusters reconciled by the controllers
+func SetOperatorScope(scope OperatorScope) {
+	operatorScope = scope
+}
+
+// isRestricted returns true if the scope leaves some KafkaClusters out
+func (s OperatorScope) isRestricted() bool {
+	return len(s.ExcludedNamespaces) > 0 || s.ClusterSelector != nil || s.ShardCount > 1
+}
+
+// includes returns true if the cluster is reconciled by the operator
+func (s OperatorScope) includes(cluster *v1beta1.KafkaCluster) bool {
+	return s.includesKey(client.ObjectKeyFromObject(cluster)) &&
+		(s.ClusterSelector == nil || s.ClusterSelector.Matches(labels.Set(cluster.GetLabels())))
+}
+
+// includesKey returns true if the namespace and the shard of the cluster with the given key are reconciled by the
+// operator
+func (s OperatorScope) includesKey(clusterKey types.NamespacedName) bool {
+	return !slices.Contains(s.ExcludedNamespaces, clusterKey.Namespace) &&
+		(s.ShardCount <= 1 || clusterShard(clusterKey, s.ShardCount) == s.ShardIndex)
+}
+
+// clusterShard returns the shard of the cluster with the given key
+func clusterShard(clusterKey types.NamespacedName, shardCount uint32) uint32 {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(clusterKey.String()))
+	return hash.Sum32() % shardCount
+}
+
+// OperatorScopePredicate returns a controller event filter that filters out the events of the KafkaClusters out of
+// the scope of the operator and of the resources belonging to them: the resources referencing the cluster in their
+// clusterRef and the ones labeled with the name of the cluster
+type OperatorScopePredicate struct {
+	client client.Reader
+}
+
+func newOperatorScopePredicate(mgr ctrl.Manager) OperatorScopePredicate {
+	return OperatorScopePredicate{client: mgr.GetClient()}
+}
+
+func (p OperatorScopePredicate) Create(e event.CreateEvent) bool {
+	return p.inScope(e.Object)
+}
+
+func (p OperatorScopePredicate) Delete(e event.DeleteEvent) bool {
+	return p.inScope(e.Object)
+}
+
+func (p OperatorScopePredicate) Update(e event.UpdateEvent) bool {
+	return p.inScope(e.ObjectNew)
+}
+
+func (p OperatorScopePredicate) Generic(e event.GenericEvent) bool {
+	return p.inScope(e.Object)
+}
+
+func (p OperatorScopePredicate) inScope(obj client.Object) bool {
+	if !operatorScope.isRestricted() {
+		return true
+	}
+	if cluster, ok := obj.(*v1beta1.KafkaCluster); ok {
+		return operatorScope.includes(cluster)
+	}
+	clusterKey, ok := owningClusterKey(obj)
+	if !ok {
+		return !slices.Contains(operatorScope.ExcludedNamespaces, obj.GetNamespace())
+	}
+	if !operatorScope.includesKey(clusterKey) {
+		return false
+	}
+	if operatorScope.ClusterSelector == nil {
+		return true
+	}
+	cluster := &v1beta1.KafkaCluster{}
+	if err := p.client.Get(context.Background(), clusterKey, cluster); err != nil {
+		// the resources of the clusters which do not exist (anymore) are left to every operator instance, e.g. to
+		// remove their finalizers
+		return true
+	}
+	return operatorScope.includes(cluster)
+}
+
+// owningClusterKey returns the key of the KafkaCluster the resource belongs to
+func owningClusterKey(obj client.Object) (types.NamespacedName, bool) {
+	var clusterRef *v1alpha1.ClusterReference
+	switch o := obj.(type) {
+	case *v1alpha1.KafkaTopic:
+		clusterRef = &o.Spec.ClusterRef
+	case *v1alpha1.KafkaUser:
+		clusterRef = &o.Spec.ClusterRef
+	case *v1alpha1.KafkaACL:
+		clusterRef = &o.Spec.ClusterRef
+	case *v1alpha1.KafkaConsumerGroup:
+		clusterRef = &o.Spec.ClusterRef
+	case *v1alpha1.KafkaReassignment:
+		clusterRef = &o.Spec.ClusterRef
+	}
+	if clusterRef != nil {
+		return types.NamespacedName{Name: clusterRef.Name, Namespace: getClusterRefNamespace(obj.GetNamespace(), *clusterRef)}, true
+	}
+	if clusterName, ok := obj.GetLabels()[v1beta1.KafkaCRLabelKey]; ok {
+		return types.NamespacedName{Name: clusterName, Namespace: obj.GetNamespace()}, true
+	}
+	return types.NamespacedName{}, false
+}
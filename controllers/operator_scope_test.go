@@ -0,0 +1,164 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func TestOperatorScopePredicate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	cluster := func(name, namespace, team string) *v1beta1.KafkaCluster {
+		return &v1beta1.KafkaCluster{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"team": team}}}
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(cluster("payments", "kafka", "payments"), cluster("orders", "kafka", "orders")).
+		Build()
+
+	topic := func(clusterName, clusterNamespace string) *v1alpha1.KafkaTopic {
+		return &v1alpha1.KafkaTopic{
+			ObjectMeta: metav1.ObjectMeta{Name: "topic", Namespace: "topics"},
+			Spec:       v1alpha1.KafkaTopicSpec{ClusterRef: v1alpha1.ClusterReference{Name: clusterName, Namespace: clusterNamespace}},
+		}
+	}
+	brokerPod := func(clusterName string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "kafka", Labels: apiutil.LabelsForKafka(clusterName)}}
+	}
+
+	testCases := []struct {
+		testName string
+		scope    OperatorScope
+		object   client.Object
+		expected bool
+	}{
+		{
+			testName: "unrestricted scope",
+			object:   cluster("orders", "kafka", "orders"),
+			expected: true,
+		},
+		{
+			testName: "selected cluster",
+			scope:    OperatorScope{ClusterSelector: labels.SelectorFromSet(labels.Set{"team": "payments"})},
+			object:   cluster("payments", "kafka", "payments"),
+			expected: true,
+		},
+		{
+			testName: "cluster not selected",
+			scope:    OperatorScope{ClusterSelector: labels.SelectorFromSet(labels.Set{"team": "payments"})},
+			object:   cluster("orders", "kafka", "orders"),
+			expected: false,
+		},
+		{
+			testName: "cluster of an excluded namespace",
+			scope:    OperatorScope{ExcludedNamespaces: []string{"kafka"}},
+			object:   cluster("payments", "kafka", "payments"),
+			expected: false,
+		},
+		{
+			testName: "topic of a selected cluster",
+			scope:    OperatorScope{ClusterSelector: labels.SelectorFromSet(labels.Set{"team": "payments"})},
+			object:   topic("payments", "kafka"),
+			expected: true,
+		},
+		{
+			testName: "topic of a cluster not selected",
+			scope:    OperatorScope{ClusterSelector: labels.SelectorFromSet(labels.Set{"team": "payments"})},
+			object:   topic("orders", "kafka"),
+			expected: false,
+		},
+		{
+			testName: "topic of a cluster in an excluded namespace",
+			scope:    OperatorScope{ExcludedNamespaces: []string{"kafka"}},
+			object:   topic("payments", "kafka"),
+			expected: false,
+		},
+		{
+			testName: "topic of a missing cluster",
+			scope:    OperatorScope{ClusterSelector: labels.SelectorFromSet(labels.Set{"team": "payments"})},
+			object:   topic("removed", "kafka"),
+			expected: true,
+		},
+		{
+			testName: "broker pod of a cluster not selected",
+			scope:    OperatorScope{ClusterSelector: labels.SelectorFromSet(labels.Set{"team": "payments"})},
+			object:   brokerPod("orders"),
+			expected: false,
+		},
+		{
+			testName: "cluster of the shard",
+			scope:    OperatorScope{ShardCount: 4, ShardIndex: clusterShard(client.ObjectKey{Name: "orders", Namespace: "kafka"}, 4)},
+			object:   cluster("orders", "kafka", "orders"),
+			expected: true,
+		},
+		{
+			testName: "cluster of another shard",
+			scope:    OperatorScope{ShardCount: 4, ShardIndex: (clusterShard(client.ObjectKey{Name: "orders", Namespace: "kafka"}, 4) + 1) % 4},
+			object:   cluster("orders", "kafka", "orders"),
+			expected: false,
+		},
+		{
+			testName: "topic of a missing cluster of another shard",
+			scope:    OperatorScope{ShardCount: 4, ShardIndex: (clusterShard(client.ObjectKey{Name: "removed", Namespace: "kafka"}, 4) + 1) % 4},
+			object:   topic("removed", "kafka"),
+			expected: false,
+		},
+		{
+			testName: "resource of no cluster",
+			scope:    OperatorScope{ClusterSelector: labels.SelectorFromSet(labels.Set{"team": "payments"})},
+			object:   &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}},
+			expected: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			SetOperatorScope(test.scope)
+			defer SetOperatorScope(OperatorScope{})
+
+			require.Equal(t, test.expected, OperatorScopePredicate{client: c}.inScope(test.object))
+		})
+	}
+}
+
+func TestClusterShard(t *testing.T) {
+	shardCounts := make(map[uint32]int)
+	for i := 0; i < 100; i++ {
+		clusterKey := client.ObjectKey{Name: fmt.Sprintf("kafka-%d", i), Namespace: "kafka"}
+		shard := clusterShard(clusterKey, 3)
+		require.Less(t, shard, uint32(3))
+		// the shard of a cluster is stable
+		require.Equal(t, shard, clusterShard(clusterKey, 3))
+		shardCounts[shard]++
+	}
+	// every shard gets clusters
+	require.Len(t, shardCounts, 3)
+}
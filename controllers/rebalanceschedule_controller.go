@@ -0,0 +1,219 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	banzaiv1alpha1 "github.com/banzaicloud/koperator/api/v1alpha1"
+	banzaiv1beta1 "github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/scale"
+	"github.com/banzaicloud/koperator/pkg/util/cron"
+)
+
+const (
+	// scheduledRebalanceStartedEventReason is the reason of the events reporting the start of a scheduled rebalance
+	scheduledRebalanceStartedEventReason = "ScheduledRebalanceStarted"
+	// scheduledRebalanceSkippedEventReason is the reason of the events reporting a scheduled rebalance skipped as
+	// another rebalance was still in progress
+	scheduledRebalanceSkippedEventReason = "ScheduledRebalanceSkipped"
+)
+
+// SetupRebalanceScheduleWithManager registers the rebalance schedule controller to the manager
+func SetupRebalanceScheduleWithManager(mgr ctrl.Manager) *ctrl.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&banzaiv1beta1.KafkaCluster{}).
+		WithEventFilter(SkipClusterRegistryOwnedResourcePredicate{}).
+		WithEventFilter(newOperatorScopePredicate(mgr)).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Named("RebalanceSchedule")
+}
+
+// blank assignment to verify that RebalanceScheduleReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &RebalanceScheduleReconciler{}
+
+// RebalanceScheduleReconciler creates the rebalance CruiseControlOperations of the KafkaClusters with a rebalance
+// schedule configured at the scheduled times, unless a rebalance of the cluster is still in progress
+type RebalanceScheduleReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=kafkaclusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=cruisecontroloperations,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=kafka.banzaicloud.io,resources=cruisecontroloperations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile starts the scheduled rebalances that are due and requeues the cluster for the next scheduled run
+func (r *RebalanceScheduleReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	cluster := &banzaiv1beta1.KafkaCluster{}
+	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconciled()
+		}
+		return requeueWithError(log, err.Error(), err)
+	}
+	if !cluster.DeletionTimestamp.IsZero() {
+		return reconciled()
+	}
+
+	rebalanceSchedule := cluster.Spec.CruiseControlConfig.RebalanceSchedule
+	if rebalanceSchedule == nil {
+		if cluster.Status.RebalanceSchedule != nil {
+			if err := k8sutil.UpdateCRStatus(r.Client, cluster, (*banzaiv1beta1.RebalanceScheduleStatus)(nil), log); err != nil {
+				return requeueWithError(log, "failed to remove the rebalance schedule from the status", err)
+			}
+		}
+		return reconciled()
+	}
+	schedule, err := cron.Parse(rebalanceSchedule.Schedule)
+	if err != nil {
+		// the schedule is validated by the webhook, it is not retried until the cluster is changed
+		log.Error(err, "invalid rebalance schedule")
+		return reconciled()
+	}
+
+	previous := cluster.Status.RebalanceSchedule
+	status := &banzaiv1beta1.RebalanceScheduleStatus{}
+	// the runs are counted from the creation of the cluster until the first one is handled
+	reference := cluster.CreationTimestamp.Time
+	if previous != nil {
+		status = previous.DeepCopy()
+		if previous.LastScheduleTime != nil {
+			reference = previous.LastScheduleTime.Time
+		}
+	}
+
+	now := time.Now().UTC()
+	due, next := scheduledRun(schedule, reference, now, rebalanceSchedule.GetStartingDeadline())
+	if !due.IsZero() {
+		operation, err := r.startScheduledRebalance(ctx, log, cluster, due)
+		if err != nil {
+			return requeueWithError(log, "failed to start the scheduled rebalance", err)
+		}
+		status.LastScheduleTime = &metav1.Time{Time: due}
+		status.LastOperation = operation
+	}
+	status.NextScheduleTime = nil
+	if !next.IsZero() {
+		status.NextScheduleTime = &metav1.Time{Time: next}
+	}
+
+	if previous == nil || !equality.Semantic.DeepEqual(previous, status) {
+		if err := k8sutil.UpdateCRStatus(r.Client, cluster, status, log); err != nil {
+			return requeueWithError(log, "failed to update the rebalance schedule in the status", err)
+		}
+	}
+
+	if next.IsZero() {
+		return reconciled()
+	}
+	return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+}
+
+// scheduledRun returns the latest run of the schedule after the reference time that is due at the given time and is
+// missed by less than the starting deadline, the zero time when none, and the next run after the given time
+func scheduledRun(schedule *cron.Schedule, reference, now time.Time, startingDeadline time.Duration) (due, next time.Time) {
+	reference = reference.UTC()
+	if earliest := now.Add(-startingDeadline); reference.Before(earliest) {
+		reference = earliest
+	}
+	for run := schedule.Next(reference); !run.IsZero() && !run.After(now); run = schedule.Next(run) {
+		due = run
+	}
+	return due, schedule.Next(now)
+}
+
+// startScheduledRebalance creates the rebalance CruiseControlOperation of the scheduled run and returns its name,
+// the run is skipped and an empty name is returned when a rebalance of the cluster is still in progress
+func (r *RebalanceScheduleReconciler) startScheduledRebalance(ctx context.Context, log logr.Logger, cluster *banzaiv1beta1.KafkaCluster, scheduleTime time.Time) (string, error) {
+	ccOperations := &banzaiv1alpha1.CruiseControlOperationList{}
+	if err := r.List(ctx, ccOperations, client.InNamespace(cluster.Namespace), client.MatchingLabels(apiutil.LabelsForKafka(cluster.Name))); err != nil {
+		return "", err
+	}
+	for i := range ccOperations.Items {
+		ccOperation := &ccOperations.Items[i]
+		if ccOperation.CurrentTaskOperation() == banzaiv1alpha1.OperationRebalance && !ccOperation.IsDone() {
+			log.Info("skipping the scheduled rebalance as a rebalance is in progress",
+				"scheduleTime", scheduleTime, "cruiseControlOperation", ccOperation.GetName())
+			k8sutil.RecordEvent(r.Recorder, cluster, corev1.EventTypeWarning, scheduledRebalanceSkippedEventReason,
+				"scheduled rebalance of %s skipped as the rebalance %s is in progress",
+				scheduleTime.Format(time.RFC3339), ccOperation.GetName())
+			return "", nil
+		}
+	}
+
+	operation := &banzaiv1alpha1.CruiseControlOperation{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-%s-", cluster.Name, strings.ReplaceAll(string(banzaiv1alpha1.OperationRebalance), "_", "")),
+			Namespace:    cluster.Namespace,
+			Labels:       apiutil.LabelsForKafka(cluster.Name),
+		},
+		Spec: banzaiv1alpha1.CruiseControlOperationSpec{
+			// a failed run is not retried, the next scheduled run rebalances the cluster again
+			ErrorPolicy:             banzaiv1alpha1.ErrorPolicyIgnore,
+			TTLSecondsAfterFinished: cluster.Spec.CruiseControlConfig.CruiseControlOperationSpec.GetTTLSecondsAfterFinished(),
+		},
+	}
+	if err := controllerutil.SetControllerReference(cluster, operation, r.Scheme); err != nil {
+		return "", err
+	}
+	if err := r.Create(ctx, operation); err != nil {
+		return "", err
+	}
+
+	parameters := map[string]string{
+		scale.ParamExcludeDemoted: True,
+		scale.ParamExcludeRemoved: True,
+	}
+	if goals := cluster.Spec.CruiseControlConfig.RebalanceSchedule.Goals; len(goals) > 0 {
+		parameters[scale.ParamGoals] = strings.Join(goals, ",")
+	}
+	operation.Status.CurrentTask = &banzaiv1alpha1.CruiseControlTask{
+		Operation:  banzaiv1alpha1.OperationRebalance,
+		Parameters: parameters,
+	}
+	if err := r.Status().Update(ctx, operation); err != nil {
+		return "", err
+	}
+
+	log.Info("started the scheduled rebalance", "scheduleTime", scheduleTime, "cruiseControlOperation", operation.GetName())
+	k8sutil.RecordEvent(r.Recorder, cluster, corev1.EventTypeNormal, scheduledRebalanceStartedEventReason,
+		"scheduled rebalance of %s started with the CruiseControlOperation %s",
+		scheduleTime.Format(time.RFC3339), operation.GetName())
+	return operation.GetName(), nil
+}
@@ -0,0 +1,169 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/scale"
+	"github.com/banzaicloud/koperator/pkg/util/cron"
+)
+
+func TestScheduledRun(t *testing.T) {
+	now := time.Date(2025, time.June, 7, 2, 5, 30, 0, time.UTC)
+	schedule, err := cron.Parse("0 2 * * *")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		testName    string
+		reference   time.Time
+		deadline    time.Duration
+		expectedDue time.Time
+	}{
+		{
+			testName:    "due run",
+			reference:   time.Date(2025, time.June, 6, 2, 0, 0, 0, time.UTC),
+			deadline:    15 * time.Minute,
+			expectedDue: time.Date(2025, time.June, 7, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			testName:  "run already handled",
+			reference: time.Date(2025, time.June, 7, 2, 0, 0, 0, time.UTC),
+			deadline:  15 * time.Minute,
+		},
+		{
+			testName:  "run missed by more than the starting deadline",
+			reference: time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC),
+			deadline:  5 * time.Minute,
+		},
+		{
+			testName:    "only the latest missed run is due",
+			reference:   time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC),
+			deadline:    72 * time.Hour,
+			expectedDue: time.Date(2025, time.June, 7, 2, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			due, next := scheduledRun(schedule, test.reference, now, test.deadline)
+			require.Equal(t, test.expectedDue, due)
+			require.Equal(t, time.Date(2025, time.June, 8, 2, 0, 0, 0, time.UTC), next)
+		})
+	}
+}
+
+func TestRebalanceScheduleReconcile(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		runningRebalance bool
+		expectStarted    bool
+	}{
+		{
+			testName:      "scheduled rebalance is started",
+			expectStarted: true,
+		},
+		{
+			testName:         "scheduled rebalance is skipped while a rebalance is in progress",
+			runningRebalance: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, v1alpha1.AddToScheme(scheme))
+			require.NoError(t, v1beta1.AddToScheme(scheme))
+
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "kafka",
+					Namespace:         "kafka",
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+				},
+				Spec: v1beta1.KafkaClusterSpec{
+					CruiseControlConfig: v1beta1.CruiseControlConfig{
+						RebalanceSchedule: &v1beta1.RebalanceSchedule{
+							Schedule: "* * * * *",
+							Goals:    []string{"RackAwareGoal", "ReplicaCapacityGoal"},
+						},
+					},
+				},
+			}
+			builder := fake.NewClientBuilder().WithScheme(scheme).
+				WithStatusSubresource(&v1beta1.KafkaCluster{}, &v1alpha1.CruiseControlOperation{}).
+				WithObjects(cluster)
+			if test.runningRebalance {
+				builder = builder.WithObjects(&v1alpha1.CruiseControlOperation{
+					ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "kafka", Labels: apiutil.LabelsForKafka("kafka")},
+					Status: v1alpha1.CruiseControlOperationStatus{
+						CurrentTask: &v1alpha1.CruiseControlTask{
+							ID:        "task",
+							Operation: v1alpha1.OperationRebalance,
+							State:     v1beta1.CruiseControlTaskInExecution,
+						},
+					},
+				})
+			}
+			c := builder.Build()
+			recorder := record.NewFakeRecorder(10)
+
+			r := &RebalanceScheduleReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cluster)}
+			result, err := r.Reconcile(context.Background(), request)
+			require.NoError(t, err)
+			require.Greater(t, result.RequeueAfter, time.Duration(0))
+			require.LessOrEqual(t, result.RequeueAfter, time.Minute)
+
+			updated := &v1beta1.KafkaCluster{}
+			require.NoError(t, c.Get(context.Background(), request.NamespacedName, updated))
+			status := updated.Status.RebalanceSchedule
+			require.NotNil(t, status)
+			require.NotNil(t, status.LastScheduleTime)
+			require.NotNil(t, status.NextScheduleTime)
+			require.Equal(t, time.Minute, status.NextScheduleTime.Sub(status.LastScheduleTime.Time))
+
+			ccOperations := &v1alpha1.CruiseControlOperationList{}
+			require.NoError(t, c.List(context.Background(), ccOperations))
+			if !test.expectStarted {
+				require.Empty(t, status.LastOperation)
+				require.Len(t, ccOperations.Items, 1)
+				require.Contains(t, <-recorder.Events, scheduledRebalanceSkippedEventReason)
+				return
+			}
+			require.Len(t, ccOperations.Items, 1)
+			operation := ccOperations.Items[0]
+			require.Equal(t, status.LastOperation, operation.Name)
+			require.Equal(t, v1alpha1.OperationRebalance, operation.CurrentTaskOperation())
+			require.Equal(t, "RackAwareGoal,ReplicaCapacityGoal", operation.Status.CurrentTask.Parameters[scale.ParamGoals])
+			require.Equal(t, v1alpha1.ErrorPolicyIgnore, operation.Spec.ErrorPolicy)
+			require.Contains(t, <-recorder.Events, scheduledRebalanceStartedEventReason)
+		})
+	}
+}
@@ -150,7 +150,7 @@ var _ = BeforeSuite(func() {
 	Expect(mgr).ToNot(BeNil())
 
 	kafkaClusterReconciler = NewTestReconciler()
-	err = controllers.SetupKafkaClusterWithManager(mgr).Named("KafkaCluster").Complete(kafkaClusterReconciler)
+	err = controllers.SetupKafkaClusterWithManager(mgr, 1).Named("KafkaCluster").Complete(kafkaClusterReconciler)
 	Expect(err).NotTo(HaveOccurred())
 
 	kafkaTopicReconciler = NewTestReconciler()
@@ -159,7 +159,7 @@ var _ = BeforeSuite(func() {
 
 	// Create a new  kafka user reconciler
 	kafkaUserReconciler = NewTestReconciler()
-	err = controllers.SetupKafkaUserWithManager(mgr, true, true).Named("KafkaUser").Complete(kafkaUserReconciler)
+	err = controllers.SetupKafkaUserWithManager(mgr, true, true, 1).Named("KafkaUser").Complete(kafkaUserReconciler)
 	Expect(err).NotTo(HaveOccurred())
 
 	cruiseControlTaskReconciler = NewTestReconciler()
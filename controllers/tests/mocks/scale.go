@@ -94,6 +94,21 @@ func (mr *MockCruiseControlScalerMockRecorder) AddBrokersWithParams(ctx, params
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBrokersWithParams", reflect.TypeOf((*MockCruiseControlScaler)(nil).AddBrokersWithParams), ctx, params)
 }
 
+// AnomalyDetectorState mocks base method.
+func (m *MockCruiseControlScaler) AnomalyDetectorState(ctx context.Context) (*types.AnomalyDetectorState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnomalyDetectorState", ctx)
+	ret0, _ := ret[0].(*types.AnomalyDetectorState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnomalyDetectorState indicates an expected call of AnomalyDetectorState.
+func (mr *MockCruiseControlScalerMockRecorder) AnomalyDetectorState(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnomalyDetectorState", reflect.TypeOf((*MockCruiseControlScaler)(nil).AnomalyDetectorState), ctx)
+}
+
 // BrokerWithLeastPartitionReplicas mocks base method.
 func (m *MockCruiseControlScaler) BrokerWithLeastPartitionReplicas(ctx context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -129,6 +144,56 @@ func (mr *MockCruiseControlScalerMockRecorder) BrokersWithState(ctx any, states
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BrokersWithState", reflect.TypeOf((*MockCruiseControlScaler)(nil).BrokersWithState), varargs...)
 }
 
+// DemoteBrokers mocks base method.
+func (m *MockCruiseControlScaler) DemoteBrokers(ctx context.Context, brokerIDs ...string) (*scale.Result, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range brokerIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DemoteBrokers", varargs...)
+	ret0, _ := ret[0].(*scale.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DemoteBrokers indicates an expected call of DemoteBrokers.
+func (mr *MockCruiseControlScalerMockRecorder) DemoteBrokers(ctx any, brokerIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, brokerIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DemoteBrokers", reflect.TypeOf((*MockCruiseControlScaler)(nil).DemoteBrokers), varargs...)
+}
+
+// DemoteBrokersWithParams mocks base method.
+func (m *MockCruiseControlScaler) DemoteBrokersWithParams(ctx context.Context, params map[string]string) (*scale.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DemoteBrokersWithParams", ctx, params)
+	ret0, _ := ret[0].(*scale.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DemoteBrokersWithParams indicates an expected call of DemoteBrokersWithParams.
+func (mr *MockCruiseControlScalerMockRecorder) DemoteBrokersWithParams(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DemoteBrokersWithParams", reflect.TypeOf((*MockCruiseControlScaler)(nil).DemoteBrokersWithParams), ctx, params)
+}
+
+// FixOfflineReplicasWithParams mocks base method.
+func (m *MockCruiseControlScaler) FixOfflineReplicasWithParams(ctx context.Context, params map[string]string) (*scale.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FixOfflineReplicasWithParams", ctx, params)
+	ret0, _ := ret[0].(*scale.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FixOfflineReplicasWithParams indicates an expected call of FixOfflineReplicasWithParams.
+func (mr *MockCruiseControlScalerMockRecorder) FixOfflineReplicasWithParams(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FixOfflineReplicasWithParams", reflect.TypeOf((*MockCruiseControlScaler)(nil).FixOfflineReplicasWithParams), ctx, params)
+}
+
 // IsReady mocks base method.
 func (m *MockCruiseControlScaler) IsReady(ctx context.Context) bool {
 	m.ctrl.T.Helper()
@@ -347,6 +412,21 @@ func (mr *MockCruiseControlScalerMockRecorder) StopExecution(ctx any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopExecution", reflect.TypeOf((*MockCruiseControlScaler)(nil).StopExecution), ctx)
 }
 
+// TopicConfigurationWithParams mocks base method.
+func (m *MockCruiseControlScaler) TopicConfigurationWithParams(ctx context.Context, params map[string]string) (*scale.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopicConfigurationWithParams", ctx, params)
+	ret0, _ := ret[0].(*scale.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopicConfigurationWithParams indicates an expected call of TopicConfigurationWithParams.
+func (mr *MockCruiseControlScalerMockRecorder) TopicConfigurationWithParams(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopicConfigurationWithParams", reflect.TypeOf((*MockCruiseControlScaler)(nil).TopicConfigurationWithParams), ctx, params)
+}
+
 // UserTasks mocks base method.
 func (m *MockCruiseControlScaler) UserTasks(ctx context.Context, taskIDs ...string) ([]*scale.Result, error) {
 	m.ctrl.T.Helper()
@@ -76,10 +76,26 @@ func (n *noopCruiseControlScaler) RemoveBrokers(ctx context.Context, brokerIDs .
 	return &scale.Result{State: v1beta1.CruiseControlTaskActive}, nil
 }
 
+func (n *noopCruiseControlScaler) DemoteBrokers(ctx context.Context, brokerIDs ...string) (*scale.Result, error) {
+	return &scale.Result{State: v1beta1.CruiseControlTaskActive}, nil
+}
+
+func (n *noopCruiseControlScaler) DemoteBrokersWithParams(ctx context.Context, params map[string]string) (*scale.Result, error) {
+	return &scale.Result{State: v1beta1.CruiseControlTaskActive}, nil
+}
+
+func (n *noopCruiseControlScaler) FixOfflineReplicasWithParams(ctx context.Context, params map[string]string) (*scale.Result, error) {
+	return &scale.Result{State: v1beta1.CruiseControlTaskActive}, nil
+}
+
 func (n *noopCruiseControlScaler) RemoveDisksWithParams(ctx context.Context, params map[string]string) (*scale.Result, error) {
 	return &scale.Result{State: v1beta1.CruiseControlTaskActive}, nil
 }
 
+func (n *noopCruiseControlScaler) TopicConfigurationWithParams(ctx context.Context, params map[string]string) (*scale.Result, error) {
+	return &scale.Result{State: v1beta1.CruiseControlTaskActive}, nil
+}
+
 func (n *noopCruiseControlScaler) RebalanceDisks(ctx context.Context, brokerIDs ...string) (*scale.Result, error) {
 	return &scale.Result{State: v1beta1.CruiseControlTaskActive}, nil
 }
@@ -92,6 +108,10 @@ func (n *noopCruiseControlScaler) KafkaClusterState(ctx context.Context) (*cctyp
 	return &cctypes.KafkaClusterState{}, nil
 }
 
+func (n *noopCruiseControlScaler) AnomalyDetectorState(ctx context.Context) (*cctypes.AnomalyDetectorState, error) {
+	return &cctypes.AnomalyDetectorState{}, nil
+}
+
 func (n *noopCruiseControlScaler) PartitionReplicasByBroker(ctx context.Context) (map[string]int32, error) {
 	return map[string]int32{}, nil
 }
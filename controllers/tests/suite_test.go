@@ -68,6 +68,7 @@ import (
 	"github.com/banzaicloud/koperator/controllers"
 	controllerMocks "github.com/banzaicloud/koperator/controllers/tests/mocks"
 	"github.com/banzaicloud/koperator/pkg/jmxextractor"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
 	"github.com/banzaicloud/koperator/pkg/kafkaclient"
 	// +kubebuilder:scaffold:imports
 )
@@ -150,6 +151,8 @@ var _ = BeforeSuite(func(ctx SpecContext) {
 			Port: 8443,
 		}),
 		LeaderElection: false,
+		Cache:          k8sutil.CacheOptions(nil),
+		Client:         k8sutil.ClientOptions(),
 	})
 	Expect(err).ToNot(HaveOccurred())
 	Expect(mgr).ToNot(BeNil())
@@ -171,7 +174,7 @@ var _ = BeforeSuite(func(ctx SpecContext) {
 		KafkaClientProvider: kafkaclient.NewMockProvider(),
 	}
 
-	err = controllers.SetupKafkaClusterWithManager(mgr).Complete(&kafkaClusterReconciler)
+	err = controllers.SetupKafkaClusterWithManager(mgr, 1).Complete(&kafkaClusterReconciler)
 	Expect(err).NotTo(HaveOccurred())
 
 	kafkaTopicReconciler := &controllers.KafkaTopicReconciler{
@@ -188,7 +191,7 @@ var _ = BeforeSuite(func(ctx SpecContext) {
 		Scheme: mgr.GetScheme(),
 	}
 
-	err = controllers.SetupKafkaUserWithManager(mgr, true, true).Complete(&kafkaUserReconciler)
+	err = controllers.SetupKafkaUserWithManager(mgr, true, true, 1).Complete(&kafkaUserReconciler)
 	Expect(err).NotTo(HaveOccurred())
 
 	kafkaClusterCCReconciler = controllers.CruiseControlTaskReconciler{
@@ -208,7 +211,7 @@ var _ = BeforeSuite(func(ctx SpecContext) {
 		ScaleFactory: controllerMocks.NewNoopScaleFactory(),
 	}
 
-	err = controllers.SetupCruiseControlOperationWithManager(mgr).Complete(&cruiseControlOperationReconciler)
+	err = controllers.SetupCruiseControlOperationWithManager(mgr, 1).Complete(&cruiseControlOperationReconciler)
 	Expect(err).NotTo(HaveOccurred())
 
 	cruiseControlOperationTTLReconciler := controllers.CruiseControlOperationTTLReconciler{
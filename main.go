@@ -34,9 +34,14 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
+	"time"
+	// the time zones of the maintenance windows are resolved in images without the time zone database
+	_ "time/tzdata"
 
+	"emperror.dev/errors"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -47,20 +52,27 @@ import (
 	banzaiistiov1alpha1 "github.com/banzaicloud/istio-operator/api/v2/v1alpha1"
 
 	certv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	contour "github.com/projectcontour/contour/apis/projectcontour/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	banzaicloudv1alpha1 "github.com/banzaicloud/koperator/api/v1alpha1"
 	banzaicloudv1beta1 "github.com/banzaicloud/koperator/api/v1beta1"
 	"github.com/banzaicloud/koperator/controllers"
+	"github.com/banzaicloud/koperator/pkg/export"
 	"github.com/banzaicloud/koperator/pkg/k8sutil"
 	"github.com/banzaicloud/koperator/pkg/kafkaclient"
 	"github.com/banzaicloud/koperator/pkg/scale"
 	"github.com/banzaicloud/koperator/pkg/util"
+	"github.com/banzaicloud/koperator/pkg/util/metrics"
+	"github.com/banzaicloud/koperator/pkg/util/tracing"
 	"github.com/banzaicloud/koperator/pkg/webhooks"
 	// +kubebuilder:scaffold:imports
 )
@@ -82,26 +94,74 @@ func init() {
 	_ = istioclientv1beta1.AddToScheme(scheme)
 
 	_ = contour.AddToScheme(scheme)
+
+	_ = gatewayv1.Install(scheme)
+
+	_ = gatewayv1alpha2.Install(scheme)
 	// +kubebuilder:scaffold:scheme
 }
 
 func main() {
+	// the export verb dumps the topics, users and ACLs of a cluster instead of running the operator
+	if len(os.Args) > 1 && os.Args[1] == export.CommandName {
+		if err := export.Run(context.Background(), os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	var (
-		namespaces                        string
-		metricsAddr                       string
-		enableLeaderElection              bool
-		webhookCertDir                    string
-		webhookDisabled                   bool
-		webhookServerPort                 int
-		developmentLogging                bool
-		verboseLogging                    bool
-		certSigningDisabled               bool
-		certManagerEnabled                bool
-		maxKafkaTopicConcurrentReconciles int
-		healthProbesAddr                  string
+		namespaces                          string
+		excludedNamespaces                  string
+		kafkaClusterSelector                string
+		shardCount                          uint
+		shardIndex                          uint
+		metricsAddr                         string
+		enableLeaderElection                bool
+		webhookCertDir                      string
+		webhookDisabled                     bool
+		webhookServerPort                   int
+		developmentLogging                  bool
+		verboseLogging                      bool
+		certSigningDisabled                 bool
+		certManagerEnabled                  bool
+		maxKafkaTopicConcurrentReconciles   int
+		kafkaTopicBatchWindow               time.Duration
+		kafkaClientMaxAge                   time.Duration
+		kafkaClientHealthCheckInterval      time.Duration
+		maxKafkaClusterConcurrentReconciles int
+		maxKafkaUserConcurrentReconciles    int
+		maxCCOperationConcurrentReconciles  int
+		reconcileBackoffBaseDelay           time.Duration
+		reconcileBackoffMaxDelay            time.Duration
+		kubeAPIQPS                          float64
+		kubeAPIBurst                        int
+		healthProbesAddr                    string
+		pprofAddr                           string
+		statusCoalescingWindow              time.Duration
+		ccRecordInteractions                bool
+		ccFixtureDir                        string
+		defaultKafkaClusterConfigMap        string
+		brokerMetricsAggregation            bool
+		operatorAPIAddr                     string
+		operatorAPICertDir                  string
+		clusterAuditInterval                time.Duration
+		topicDiscoveryInterval              time.Duration
+		userDiscoveryInterval               time.Duration
+		otlpEndpoint                        string
+		otlpInsecure                        bool
+		otlpSampleRatio                     float64
 	)
 
 	flag.StringVar(&namespaces, "namespaces", "", "Comma separated list of namespaces where operator listens for resources")
+	flag.StringVar(&excludedNamespaces, "exclude-namespaces", "",
+		"Comma separated list of namespaces whose KafkaClusters and their resources are not reconciled by the operator")
+	flag.StringVar(&kafkaClusterSelector, "kafkacluster-selector", "",
+		"Label selector of the KafkaClusters reconciled by the operator, the resources of the other clusters are not reconciled either")
+	flag.UintVar(&shardCount, "shard-count", 1,
+		"The number of operator instances sharing the KafkaClusters by the hash of their namespace and name, the clusters are not sharded when 1")
+	flag.UintVar(&shardIndex, "shard-index", 0, "The shard of the KafkaClusters reconciled by the operator, from 0 to shard-count - 1")
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
@@ -113,9 +173,50 @@ func main() {
 	flag.BoolVar(&certManagerEnabled, "cert-manager-enabled", false, "Enable cert-manager integration")
 	flag.BoolVar(&certSigningDisabled, "disable-cert-signing-support", false, "Disable native certificate signing integration")
 	flag.IntVar(&maxKafkaTopicConcurrentReconciles, "max-kafka-topic-concurrent-reconciles", 10, "Define max amount of concurrent KafkaTopic reconciles")
+	flag.DurationVar(&kafkaTopicBatchWindow, "kafka-topic-batch-window", 100*time.Millisecond,
+		"The window within which the topic creations and configuration changes of the KafkaTopics of a cluster are coalesced into a single request. Each change is sent on its own when 0")
+	flag.DurationVar(&kafkaClientMaxAge, "kafka-client-max-age", 10*time.Minute,
+		"The duration the Kafka client shared by the reconciles of a cluster is used for before it is reopened. A client is opened for each reconcile when 0")
+	flag.DurationVar(&kafkaClientHealthCheckInterval, "kafka-client-health-check-interval", 30*time.Second,
+		"The interval the brokers of the shared Kafka clients are checked at, a client is reopened when its brokers are unreachable or have changed")
+	flag.IntVar(&maxKafkaClusterConcurrentReconciles, "max-kafka-cluster-concurrent-reconciles", 1, "Define max amount of concurrent KafkaCluster reconciles")
+	flag.IntVar(&maxKafkaUserConcurrentReconciles, "max-kafka-user-concurrent-reconciles", 1, "Define max amount of concurrent KafkaUser reconciles")
+	flag.IntVar(&maxCCOperationConcurrentReconciles, "max-cruise-control-operation-concurrent-reconciles", 1,
+		"Define max amount of concurrent CruiseControlOperation reconciles. The operations of a KafkaCluster may be reconciled at the same time when higher than 1")
+	flag.DurationVar(&reconcileBackoffBaseDelay, "reconcile-backoff-base-delay", 5*time.Millisecond,
+		"The delay of the first requeue of a failed reconcile, doubled on every consecutive failure of the resource")
+	flag.DurationVar(&reconcileBackoffMaxDelay, "reconcile-backoff-max-delay", 1000*time.Second, "The maximum delay of the requeues of a failed reconcile")
+	flag.Float64Var(&kubeAPIQPS, "kube-api-qps", 0, "The queries per second the operator sends to the Kubernetes API server at most. The client-go default is used when 0")
+	flag.IntVar(&kubeAPIBurst, "kube-api-burst", 0, "The burst of queries the operator sends to the Kubernetes API server at most. The client-go default is used when 0")
 	flag.StringVar(&healthProbesAddr, "health-probes-addr", ":8081", "The address the probe endpoint binds to.")
+	flag.StringVar(&pprofAddr, "pprof-addr", "", "The address the pprof profiling endpoints bind to. Profiling is disabled when empty")
+	flag.DurationVar(&statusCoalescingWindow, "status-coalescing-window", 0,
+		"Window within which high-frequency KafkaCluster status updates are coalesced into a single write. Status is written synchronously when 0")
+	flag.BoolVar(&ccRecordInteractions, "cruise-control-record-interactions", false,
+		"Log the HTTP interactions with Cruise Control, credentials are redacted")
+	flag.StringVar(&ccFixtureDir, "cruise-control-fixture-dir", "",
+		"The directory the HTTP interactions with Cruise Control are recorded to as replayable fixture files. Interactions are not recorded to files when empty")
+	flag.StringVar(&defaultKafkaClusterConfigMap, "default-kafkacluster-configmap", "",
+		"The namespace/name of the ConfigMap holding the manifest of the default KafkaCluster created by the operator. No cluster is created when empty")
+	flag.BoolVar(&brokerMetricsAggregation, "broker-metrics-aggregation", false,
+		"Serve the combined metrics of the brokers of each KafkaCluster on the metrics endpoint at /kafkaclusters/<namespace>/<name>/metrics")
+	flag.StringVar(&operatorAPIAddr, "api-addr", "",
+		"The address the read-only operator API serving the state of the KafkaClusters at /api/v1/kafkaclusters binds to, the requests are authenticated with Kubernetes bearer tokens. The API is disabled when empty")
+	flag.StringVar(&operatorAPICertDir, "api-tls-cert-dir", "",
+		"The directory with a tls.crt and tls.key the operator API is served over HTTPS with, required by the operator API")
+	flag.DurationVar(&clusterAuditInterval, "cluster-audit-interval", 0,
+		"The interval the KafkaClusters are audited against the best-practice rules at, the scored findings are reported in their status and events. The audit is disabled when 0")
+	flag.DurationVar(&topicDiscoveryInterval, "topic-discovery-interval", 0,
+		"The interval the topics of the KafkaClusters are discovered at, a KafkaTopic labeled as imported is created for each topic without one. The discovery is disabled when 0")
+	flag.DurationVar(&userDiscoveryInterval, "user-discovery-interval", 0,
+		"The interval the SCRAM users and the ACLs of the KafkaClusters are discovered at, a KafkaUser labeled as imported is created for each principal without one. The discovery is disabled when 0")
+	flag.StringVar(&otlpEndpoint, "otlp-endpoint", "",
+		"The host:port of the OTLP/HTTP receiver the OpenTelemetry spans of the reconciles and the Cruise Control, Kafka and API server calls are exported to. Tracing is disabled when empty")
+	flag.BoolVar(&otlpInsecure, "otlp-insecure", false, "Export the OpenTelemetry spans over plain HTTP instead of HTTPS")
+	flag.Float64Var(&otlpSampleRatio, "otlp-sample-ratio", 1, "The ratio of the reconciles traced when tracing is enabled")
 	flag.Parse()
 	ctrl.SetLogger(util.CreateLogger(verboseLogging, developmentLogging))
+	scale.SetRecordOptions(scale.RecordOptions{Log: ccRecordInteractions, FixtureDir: ccFixtureDir})
 
 	// adding indexers to KafkaTopics so that the KafkaTopic admission webhooks could work
 	ctx := context.Background()
@@ -137,12 +238,62 @@ func main() {
 		watchedNamespaces[strings.TrimSpace(namespaceList[i])] = cache.Config{}
 	}
 
+	operatorScope := controllers.OperatorScope{}
+	if excludedNamespaces != "" {
+		for _, namespace := range strings.Split(excludedNamespaces, ",") {
+			operatorScope.ExcludedNamespaces = append(operatorScope.ExcludedNamespaces, strings.TrimSpace(namespace))
+		}
+	}
+	if kafkaClusterSelector != "" {
+		selector, err := labels.Parse(kafkaClusterSelector)
+		if err != nil {
+			setupLog.Error(err, "invalid KafkaCluster label selector", "selector", kafkaClusterSelector)
+			os.Exit(1)
+		}
+		operatorScope.ClusterSelector = selector
+	}
+	if shardCount > 1 {
+		if shardIndex >= shardCount {
+			setupLog.Error(errors.New("the shard index must be lower than the shard count"), "invalid shard",
+				"shardIndex", shardIndex, "shardCount", shardCount)
+			os.Exit(1)
+		}
+		operatorScope.ShardCount = uint32(shardCount)
+		operatorScope.ShardIndex = uint32(shardIndex)
+	}
+	controllers.SetOperatorScope(operatorScope)
+	controllers.SetRequeueBackoff(controllers.RequeueBackoff{BaseDelay: reconcileBackoffBaseDelay, MaxDelay: reconcileBackoffMaxDelay})
+
 	// hash the watched namespaces to allow for more than one operator deployment per namespace
 	// same watched namespaces will return the same hash so only one operator will be active
-	leaderElectionID := fmt.Sprintf("%s-%x", "controller-leader-election-helper", util.GetMD5Hash(namespaces))
-	setupLog.Info("Using leader electrion id", "LeaderElectionID", leaderElectionID, "watched namespaces", namespaceList)
+	// operators with a different scope of KafkaClusters get a different hash so that they can be active at the same time
+	leaderElectionScope := namespaces
+	if excludedNamespaces != "" || kafkaClusterSelector != "" || operatorScope.ShardCount > 1 {
+		leaderElectionScope = strings.Join([]string{namespaces, excludedNamespaces, kafkaClusterSelector,
+			fmt.Sprintf("%d/%d", operatorScope.ShardIndex, operatorScope.ShardCount)}, "/")
+	}
+	leaderElectionID := fmt.Sprintf("%s-%x", "controller-leader-election-helper", util.GetMD5Hash(leaderElectionScope))
+	setupLog.Info("Using leader electrion id", "LeaderElectionID", leaderElectionID, "watched namespaces", namespaceList,
+		"excluded namespaces", operatorScope.ExcludedNamespaces, "KafkaCluster selector", kafkaClusterSelector,
+		"shard index", operatorScope.ShardIndex, "shard count", operatorScope.ShardCount)
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Options{Endpoint: otlpEndpoint, Insecure: otlpInsecure, SampleRatio: otlpSampleRatio})
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+	restConfig := ctrl.GetConfigOrDie()
+	if kubeAPIQPS > 0 {
+		restConfig.QPS = float32(kubeAPIQPS)
+	}
+	if kubeAPIBurst > 0 {
+		restConfig.Burst = kubeAPIBurst
+	}
+	if otlpEndpoint != "" {
+		restConfig.Wrap(func(rt http.RoundTripper) http.RoundTripper { return tracing.WrapTransport("kube-apiserver", rt) })
+	}
+
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme:           scheme,
 		LeaderElection:   enableLeaderElection,
 		LeaderElectionID: leaderElectionID,
@@ -151,10 +302,12 @@ func main() {
 			CertDir: webhookCertDir,
 		}),
 		HealthProbeBindAddress: healthProbesAddr,
+		PprofBindAddress:       pprofAddr,
 		Metrics: server.Options{
 			BindAddress: metricsAddr,
 		},
-		Cache: cache.Options{DefaultNamespaces: watchedNamespaces},
+		Cache:  k8sutil.CacheOptions(watchedNamespaces),
+		Client: k8sutil.ClientOptions(),
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -176,26 +329,56 @@ func main() {
 		os.Exit(1)
 	}
 
+	if statusCoalescingWindow > 0 {
+		statusWriter := k8sutil.NewStatusWriter(mgr.GetClient(), statusCoalescingWindow, ctrl.Log.WithName("status-writer"))
+		if err = mgr.Add(statusWriter); err != nil {
+			setupLog.Error(err, "unable to add status writer")
+			os.Exit(1)
+		}
+		k8sutil.SetStatusWriter(statusWriter)
+	}
+
 	if err = controllers.SetAlertManagerWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AlertManagerForKafka")
 		os.Exit(1)
 	}
 
+	// the controllers and the webhooks share a Kafka client per cluster
+	kafkaClientPool := kafkaclient.NewClientPool(kafkaclient.ClientPoolOptions{
+		MaxAge:              kafkaClientMaxAge,
+		HealthCheckInterval: kafkaClientHealthCheckInterval,
+		BatchWindow:         kafkaTopicBatchWindow,
+	})
+	controllers.SetNewKafkaFromCluster(kafkaClientPool.NewFromCluster)
+
 	kafkaClusterReconciler := &controllers.KafkaClusterReconciler{
 		Client:              mgr.GetClient(),
 		DirectClient:        mgr.GetAPIReader(),
 		Namespaces:          namespaceList,
-		KafkaClientProvider: kafkaclient.NewDefaultProvider(),
+		KafkaClientProvider: kafkaClientPool,
+		Recorder:            mgr.GetEventRecorderFor("kafkacluster-controller"),
 	}
 
-	if err = controllers.SetupKafkaClusterWithManager(mgr).Complete(kafkaClusterReconciler); err != nil {
+	if err = controllers.SetupKafkaClusterWithManager(mgr, maxKafkaClusterConcurrentReconciles).Complete(kafkaClusterReconciler); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KafkaCluster")
 		os.Exit(1)
 	}
 
+	cruiseControlDeploymentReconciler := &controllers.CruiseControlDeploymentReconciler{
+		Client:              mgr.GetClient(),
+		KafkaClientProvider: kafkaClientPool,
+	}
+
+	if err = controllers.SetupCruiseControlDeploymentWithManager(mgr).Complete(cruiseControlDeploymentReconciler); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CruiseControlDeployment")
+		os.Exit(1)
+	}
+
 	kafkaTopicReconciler := &controllers.KafkaTopicReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:              mgr.GetClient(),
+		Scheme:              mgr.GetScheme(),
+		Recorder:            mgr.GetEventRecorderFor("kafkatopic-controller"),
+		KafkaClientProvider: kafkaClientPool,
 	}
 
 	if err = controllers.SetupKafkaTopicWithManager(mgr, maxKafkaTopicConcurrentReconciles).Complete(kafkaTopicReconciler); err != nil {
@@ -205,12 +388,62 @@ func main() {
 
 	// Create a new  kafka user reconciler
 	kafkaUserReconciler := &controllers.KafkaUserReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("kafkauser-controller"),
+	}
+
+	if err = controllers.SetupKafkaUserWithManager(mgr, !certSigningDisabled, certManagerEnabled, maxKafkaUserConcurrentReconciles).Complete(kafkaUserReconciler); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KafkaUser")
+		os.Exit(1)
+	}
+
+	kafkaACLReconciler := &controllers.KafkaACLReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 	}
 
-	if err = controllers.SetupKafkaUserWithManager(mgr, !certSigningDisabled, certManagerEnabled).Complete(kafkaUserReconciler); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "KafkaUser")
+	if err = controllers.SetupKafkaACLWithManager(mgr).Complete(kafkaACLReconciler); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KafkaACL")
+		os.Exit(1)
+	}
+
+	kafkaReassignmentReconciler := &controllers.KafkaReassignmentReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}
+
+	if err = controllers.SetupKafkaReassignmentWithManager(mgr).Complete(kafkaReassignmentReconciler); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KafkaReassignment")
+		os.Exit(1)
+	}
+
+	kafkaFailoverReconciler := &controllers.KafkaFailoverReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("kafkafailover-controller"),
+	}
+
+	if err = controllers.SetupKafkaFailoverWithManager(mgr).Complete(kafkaFailoverReconciler); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KafkaFailover")
+		os.Exit(1)
+	}
+
+	kafkaConsumerGroupReconciler := &controllers.KafkaConsumerGroupReconciler{
+		Client: mgr.GetClient(),
+	}
+
+	if err = controllers.SetupKafkaConsumerGroupWithManager(mgr).Complete(kafkaConsumerGroupReconciler); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KafkaConsumerGroup")
+		os.Exit(1)
+	}
+
+	brokerReadinessReconciler := &controllers.BrokerReadinessReconciler{
+		Client: mgr.GetClient(),
+	}
+
+	if err = controllers.SetupBrokerReadinessWithManager(mgr).Complete(brokerReadinessReconciler); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BrokerReadiness")
 		os.Exit(1)
 	}
 
@@ -219,6 +452,7 @@ func main() {
 		DirectClient: mgr.GetAPIReader(),
 		Scheme:       mgr.GetScheme(),
 		ScaleFactory: scale.ScaleFactoryFn(),
+		Recorder:     mgr.GetEventRecorderFor("cruisecontroltask-controller"),
 	}
 
 	if err = controllers.SetupCruiseControlWithManager(mgr).Complete(kafkaClusterCCReconciler); err != nil {
@@ -231,9 +465,10 @@ func main() {
 		DirectClient: mgr.GetAPIReader(),
 		Scheme:       mgr.GetScheme(),
 		ScaleFactory: scale.ScaleFactoryFn(),
+		Recorder:     mgr.GetEventRecorderFor("cruisecontroloperation-controller"),
 	}
 
-	if err = controllers.SetupCruiseControlOperationWithManager(mgr).Complete(&cruiseControlOperationReconciler); err != nil {
+	if err = controllers.SetupCruiseControlOperationWithManager(mgr, maxCCOperationConcurrentReconciles).Complete(&cruiseControlOperationReconciler); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "CruiseControlOperation")
 		os.Exit(1)
 	}
@@ -248,20 +483,156 @@ func main() {
 		os.Exit(1)
 	}
 
+	cruiseControlOperationFailoverCleaner := &controllers.CruiseControlOperationFailoverCleaner{
+		Client:       mgr.GetClient(),
+		DirectClient: mgr.GetAPIReader(),
+		ScaleFactory: scale.ScaleFactoryFn(),
+	}
+
+	if err = controllers.SetupCruiseControlOperationFailoverCleanerWithManager(mgr, cruiseControlOperationFailoverCleaner); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CruiseControlOperationFailoverCleaner")
+		os.Exit(1)
+	}
+
+	cruiseControlAnomalyReconciler := &controllers.CruiseControlAnomalyReconciler{
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		ScaleFactory: scale.ScaleFactoryFn(),
+	}
+
+	if err = controllers.SetupCruiseControlAnomalyWithManager(mgr).Complete(cruiseControlAnomalyReconciler); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CruiseControlAnomaly")
+		os.Exit(1)
+	}
+
+	rebalanceScheduleReconciler := &controllers.RebalanceScheduleReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("rebalanceschedule-controller"),
+	}
+
+	if err = controllers.SetupRebalanceScheduleWithManager(mgr).Complete(rebalanceScheduleReconciler); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RebalanceSchedule")
+		os.Exit(1)
+	}
+
+	nodeInterruptionReconciler := &controllers.NodeInterruptionReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("nodeinterruption-controller"),
+	}
+
+	if err = controllers.SetupNodeInterruptionWithManager(mgr).Complete(nodeInterruptionReconciler); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NodeInterruption")
+		os.Exit(1)
+	}
+
+	logDirFailureReconciler := &controllers.LogDirFailureReconciler{
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		Recorder:     mgr.GetEventRecorderFor("logdirfailure-controller"),
+		ScaleFactory: scale.ScaleFactoryFn(),
+	}
+
+	if err = controllers.SetupLogDirFailureWithManager(mgr).Complete(logDirFailureReconciler); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "LogDirFailure")
+		os.Exit(1)
+	}
+
+	if err = metrics.RegisterClusterCollector(mgr.GetClient(), ctrl.Log.WithName("cluster-metrics")); err != nil {
+		setupLog.Error(err, "unable to register the cluster metrics")
+		os.Exit(1)
+	}
+
+	if brokerMetricsAggregation {
+		if err = controllers.SetupBrokerMetricsAggregatorWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to serve aggregated broker metrics")
+			os.Exit(1)
+		}
+	}
+
+	if operatorAPIAddr != "" {
+		if err = controllers.SetupOperatorAPIWithManager(mgr, operatorAPIAddr, operatorAPICertDir); err != nil {
+			setupLog.Error(err, "unable to serve the operator API")
+			os.Exit(1)
+		}
+	}
+
+	if clusterAuditInterval > 0 {
+		kafkaClusterAuditReconciler := &controllers.KafkaClusterAuditReconciler{
+			Client:   mgr.GetClient(),
+			Recorder: mgr.GetEventRecorderFor("kafkacluster-audit"),
+			Interval: clusterAuditInterval,
+		}
+
+		if err = controllers.SetupKafkaClusterAuditWithManager(mgr).Complete(kafkaClusterAuditReconciler); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "KafkaClusterAudit")
+			os.Exit(1)
+		}
+	}
+
+	if topicDiscoveryInterval > 0 {
+		kafkaTopicDiscoveryReconciler := &controllers.KafkaTopicDiscoveryReconciler{
+			Client:   mgr.GetClient(),
+			Recorder: mgr.GetEventRecorderFor("kafkatopic-discovery"),
+			Interval: topicDiscoveryInterval,
+		}
+
+		if err = controllers.SetupKafkaTopicDiscoveryWithManager(mgr).Complete(kafkaTopicDiscoveryReconciler); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "KafkaTopicDiscovery")
+			os.Exit(1)
+		}
+	}
+
+	if userDiscoveryInterval > 0 {
+		kafkaUserDiscoveryReconciler := &controllers.KafkaUserDiscoveryReconciler{
+			Client:   mgr.GetClient(),
+			Recorder: mgr.GetEventRecorderFor("kafkauser-discovery"),
+			Interval: userDiscoveryInterval,
+		}
+
+		if err = controllers.SetupKafkaUserDiscoveryWithManager(mgr).Complete(kafkaUserDiscoveryReconciler); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "KafkaUserDiscovery")
+			os.Exit(1)
+		}
+	}
+
+	if defaultKafkaClusterConfigMap != "" {
+		configMapNamespace, configMapName, found := strings.Cut(defaultKafkaClusterConfigMap, "/")
+		if !found || configMapNamespace == "" || configMapName == "" {
+			setupLog.Error(errors.New("expected namespace/name"), "invalid default KafkaCluster ConfigMap", "configMap", defaultKafkaClusterConfigMap)
+			os.Exit(1)
+		}
+		defaultKafkaClusterBootstrapper := &controllers.DefaultKafkaClusterBootstrapper{
+			Client:       mgr.GetClient(),
+			DirectClient: mgr.GetAPIReader(),
+			ConfigMap:    types.NamespacedName{Namespace: configMapNamespace, Name: configMapName},
+		}
+
+		if err = controllers.SetupDefaultKafkaClusterBootstrapperWithManager(mgr, defaultKafkaClusterBootstrapper); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "DefaultKafkaClusterBootstrapper")
+			os.Exit(1)
+		}
+	}
+
 	if !webhookDisabled {
 		err = ctrl.NewWebhookManagedBy(mgr).For(&banzaicloudv1beta1.KafkaCluster{}).
 			WithValidator(webhooks.KafkaClusterValidator{
+				Client: mgr.GetClient(),
+				Log:    mgr.GetLogger().WithName("webhooks").WithName("KafkaCluster"),
+			}).
+			WithDefaulter(webhooks.KafkaClusterDefaulter{
 				Log: mgr.GetLogger().WithName("webhooks").WithName("KafkaCluster"),
 			}).
 			Complete()
 		if err != nil {
-			setupLog.Error(err, "unable to create validating webhook", "Kind", "KafkaCluster")
+			setupLog.Error(err, "unable to create webhooks", "Kind", "KafkaCluster")
 			os.Exit(1)
 		}
 		err = ctrl.NewWebhookManagedBy(mgr).For(&banzaicloudv1alpha1.KafkaTopic{}).
 			WithValidator(webhooks.KafkaTopicValidator{
 				Client:              mgr.GetClient(),
-				NewKafkaFromCluster: kafkaclient.NewFromCluster,
+				NewKafkaFromCluster: kafkaClientPool.NewFromCluster,
 				Log:                 mgr.GetLogger().WithName("webhooks").WithName("KafkaTopic"),
 			}).
 			Complete()
@@ -269,6 +640,15 @@ func main() {
 			setupLog.Error(err, "unable to create validating webhook", "Kind", "KafkaTopic")
 			os.Exit(1)
 		}
+		err = ctrl.NewWebhookManagedBy(mgr).For(&banzaicloudv1alpha1.CruiseControlOperation{}).
+			WithValidator(webhooks.CruiseControlOperationValidator{
+				Log: mgr.GetLogger().WithName("webhooks").WithName("CruiseControlOperation"),
+			}).
+			Complete()
+		if err != nil {
+			setupLog.Error(err, "unable to create validating webhook", "Kind", "CruiseControlOperation")
+			os.Exit(1)
+		}
 	}
 
 	// +kubebuilder:scaffold:builder
@@ -283,4 +663,8 @@ func main() {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+	kafkaClientPool.Close()
+	if err := shutdownTracing(ctx); err != nil {
+		setupLog.Error(err, "could not flush the pending spans")
+	}
 }
@@ -0,0 +1,189 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package export dumps the KafkaTopics, KafkaUsers and KafkaACLs of a Kafka cluster as manifests which can be
+// applied as is to rebuild the cluster metadata, e.g. for disaster recovery or to promote it to another environment.
+package export
+
+import (
+	"context"
+	"flag"
+	"io"
+	"os"
+	"sort"
+
+	"emperror.dev/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/banzaicloud/k8s-objectmatcher/patch"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+)
+
+const (
+	// CommandName is the name of the command line verb of the export
+	CommandName = "export"
+
+	// clusterRefLabel is set on the topics and users by the operator to track the cluster they belong to
+	clusterRefLabel = "kafkaCluster"
+	// kubectlLastAppliedConfig is set on the resources applied by kubectl
+	kubectlLastAppliedConfig = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// Kinds are the kinds of the resources exported in the order they are written
+var Kinds = []schema.GroupVersionKind{
+	v1alpha1.GroupVersion.WithKind("KafkaTopic"),
+	v1alpha1.GroupVersion.WithKind("KafkaUser"),
+	v1alpha1.GroupVersion.WithKind("KafkaACL"),
+}
+
+// ClusterMetadata returns the resources of the given kinds which reference the Kafka cluster, stripped of the fields
+// populated by the API server and by the operator (status, owner references, finalizers, etc.)
+func ClusterMetadata(ctx context.Context, c client.Reader, cluster types.NamespacedName, kinds []schema.GroupVersionKind) ([]unstructured.Unstructured, error) {
+	var objects []unstructured.Unstructured
+	for _, gvk := range kinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := c.List(ctx, list); err != nil {
+			return nil, errors.WrapIfWithDetails(err, "could not list resources", "kind", gvk.Kind)
+		}
+
+		var kindObjects []unstructured.Unstructured
+		for _, object := range list.Items {
+			if !referencesCluster(object, cluster) {
+				continue
+			}
+			object.SetAPIVersion(gvk.GroupVersion().String())
+			object.SetKind(gvk.Kind)
+			strip(&object)
+			kindObjects = append(kindObjects, object)
+		}
+		sort.Slice(kindObjects, func(i, j int) bool {
+			if kindObjects[i].GetNamespace() != kindObjects[j].GetNamespace() {
+				return kindObjects[i].GetNamespace() < kindObjects[j].GetNamespace()
+			}
+			return kindObjects[i].GetName() < kindObjects[j].GetName()
+		})
+		objects = append(objects, kindObjects...)
+	}
+	return objects, nil
+}
+
+// referencesCluster returns true if the clusterRef of the object points to the given cluster, the namespace of the
+// reference defaults to the namespace of the object
+func referencesCluster(object unstructured.Unstructured, cluster types.NamespacedName) bool {
+	name, _, _ := unstructured.NestedString(object.Object, "spec", "clusterRef", "name")
+	namespace, _, _ := unstructured.NestedString(object.Object, "spec", "clusterRef", "namespace")
+	if namespace == "" {
+		namespace = object.GetNamespace()
+	}
+	return name == cluster.Name && namespace == cluster.Namespace
+}
+
+// strip removes the fields of the object which must not be present in a manifest applied to a new cluster
+func strip(object *unstructured.Unstructured) {
+	unstructured.RemoveNestedField(object.Object, "status")
+	for _, field := range []string{"uid", "resourceVersion", "generation", "creationTimestamp", "deletionTimestamp",
+		"deletionGracePeriodSeconds", "selfLink", "managedFields", "ownerReferences", "finalizers"} {
+		unstructured.RemoveNestedField(object.Object, "metadata", field)
+	}
+
+	if labels := object.GetLabels(); labels != nil {
+		delete(labels, clusterRefLabel)
+		object.SetLabels(labels)
+		if len(labels) == 0 {
+			unstructured.RemoveNestedField(object.Object, "metadata", "labels")
+		}
+	}
+	if annotations := object.GetAnnotations(); annotations != nil {
+		delete(annotations, patch.LastAppliedConfig)
+		delete(annotations, kubectlLastAppliedConfig)
+		object.SetAnnotations(annotations)
+		if len(annotations) == 0 {
+			unstructured.RemoveNestedField(object.Object, "metadata", "annotations")
+		}
+	}
+}
+
+// WriteManifests writes the objects as a multi-document YAML stream
+func WriteManifests(w io.Writer, objects []unstructured.Unstructured) error {
+	for _, object := range objects {
+		manifest, err := yaml.Marshal(object.Object)
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "could not marshal resource", "kind", object.GetKind(), "name", object.GetName())
+		}
+		if _, err = io.WriteString(w, "---\n"); err != nil {
+			return errors.WrapIf(err, "could not write manifests")
+		}
+		if _, err = w.Write(manifest); err != nil {
+			return errors.WrapIf(err, "could not write manifests")
+		}
+	}
+	return nil
+}
+
+// Run implements the export command line verb, it writes the manifests of the cluster given by the arguments to out
+// or to the file given by the --output flag
+func Run(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet(CommandName, flag.ContinueOnError)
+	var clusterName, clusterNamespace, output, kubeconfig string
+	flags.StringVar(&clusterName, "cluster", "", "Name of the KafkaCluster whose topics, users and ACLs are exported")
+	flags.StringVar(&clusterNamespace, "namespace", "default", "Namespace of the KafkaCluster")
+	flags.StringVar(&output, "output", "", "Path of the file the manifests are written to. The manifests are written to the standard output when empty")
+	flags.StringVar(&kubeconfig, "kubeconfig", "", "Path to the kubeconfig file. The in-cluster or the default kubeconfig is used when empty")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if clusterName == "" {
+		return errors.New("the --cluster flag is required")
+	}
+
+	var config *rest.Config
+	var err error
+	if kubeconfig != "" {
+		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	} else {
+		config, err = ctrl.GetConfig()
+	}
+	if err != nil {
+		return errors.WrapIf(err, "could not load the Kubernetes client configuration")
+	}
+	c, err := client.New(config, client.Options{})
+	if err != nil {
+		return errors.WrapIf(err, "could not create Kubernetes client")
+	}
+
+	objects, err := ClusterMetadata(ctx, c, types.NamespacedName{Name: clusterName, Namespace: clusterNamespace}, Kinds)
+	if err != nil {
+		return err
+	}
+
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "could not create output file", "output", output)
+		}
+		defer f.Close()
+		out = f
+	}
+	return WriteManifests(out, objects)
+}
@@ -0,0 +1,124 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+)
+
+func TestClusterMetadata(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	objects := []client.Object{
+		&v1alpha1.KafkaTopic{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "orders",
+				Namespace:       "kafka",
+				Labels:          map[string]string{clusterRefLabel: "kafka.kafka", "team": "payments"},
+				Annotations:     map[string]string{kubectlLastAppliedConfig: "{}"},
+				Finalizers:      []string{"finalizer.kafkatopics.kafka.banzaicloud.io"},
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "kafka.banzaicloud.io/v1beta1", Kind: "KafkaCluster", Name: "kafka", UID: "uid"}},
+			},
+			Spec: v1alpha1.KafkaTopicSpec{
+				Name: "orders", Partitions: 3, ReplicationFactor: 2,
+				ClusterRef: v1alpha1.ClusterReference{Name: "kafka"},
+			},
+			Status: v1alpha1.KafkaTopicStatus{State: v1alpha1.TopicStateCreated},
+		},
+		&v1alpha1.KafkaTopic{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-cluster", Namespace: "kafka"},
+			Spec: v1alpha1.KafkaTopicSpec{
+				Name: "other", Partitions: 1, ReplicationFactor: 1,
+				ClusterRef: v1alpha1.ClusterReference{Name: "other"},
+			},
+		},
+		&v1alpha1.KafkaUser{
+			ObjectMeta: metav1.ObjectMeta{Name: "alice", Namespace: "apps"},
+			Spec: v1alpha1.KafkaUserSpec{
+				SecretName: "alice",
+				ClusterRef: v1alpha1.ClusterReference{Name: "kafka", Namespace: "kafka"},
+			},
+		},
+		&v1alpha1.KafkaACL{
+			ObjectMeta: metav1.ObjectMeta{Name: "alice", Namespace: "kafka"},
+			Spec: v1alpha1.KafkaACLSpec{
+				ClusterRef: v1alpha1.ClusterReference{Name: "kafka"},
+				Principal:  "User:CN=alice",
+				ACLs:       []v1alpha1.ACLRule{{ResourceType: "topic", ResourceName: "orders", Operation: "read"}},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	exported, err := ClusterMetadata(context.Background(), c, types.NamespacedName{Name: "kafka", Namespace: "kafka"}, Kinds)
+	require.NoError(t, err)
+	require.Len(t, exported, 3)
+
+	var manifests bytes.Buffer
+	require.NoError(t, WriteManifests(&manifests, exported))
+	require.Equal(t, `---
+apiVersion: kafka.banzaicloud.io/v1alpha1
+kind: KafkaTopic
+metadata:
+  labels:
+    team: payments
+  name: orders
+  namespace: kafka
+spec:
+  clusterRef:
+    name: kafka
+  name: orders
+  partitions: 3
+  replicationFactor: 2
+---
+apiVersion: kafka.banzaicloud.io/v1alpha1
+kind: KafkaUser
+metadata:
+  name: alice
+  namespace: apps
+spec:
+  clusterRef:
+    name: kafka
+    namespace: kafka
+  secretName: alice
+---
+apiVersion: kafka.banzaicloud.io/v1alpha1
+kind: KafkaACL
+metadata:
+  name: alice
+  namespace: kafka
+spec:
+  acls:
+  - operation: read
+    resourceName: orders
+    resourceType: topic
+  clusterRef:
+    name: kafka
+  principal: User:CN=alice
+`, manifests.String())
+}
@@ -18,12 +18,41 @@ import (
 	"context"
 
 	"emperror.dev/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
 )
 
+// CacheOptions returns the options of the manager cache watching the given namespaces, all namespaces are watched if
+// none is given. The managed fields are stripped from the cached objects and only the Kafka pods are cached, as the
+// operator never reads other pods.
+func CacheOptions(namespaces map[string]cache.Config) cache.Options {
+	return cache.Options{
+		DefaultNamespaces: namespaces,
+		DefaultTransform:  cache.TransformStripManagedFields(),
+		ByObject: map[client.Object]cache.ByObject{
+			&corev1.Pod{}: {
+				Label: labels.SelectorFromSet(labels.Set{v1beta1.AppLabelKey: "kafka"}),
+			},
+		},
+	}
+}
+
+// ClientOptions returns the options of the manager client. Secrets and ConfigMaps are read directly from the API
+// server so that their content is not cached for every Secret and ConfigMap of the watched namespaces, their events
+// are received through metadata-only watches.
+func ClientOptions() client.Options {
+	return client.Options{
+		Cache: &client.CacheOptions{
+			DisableFor: []client.Object{&corev1.Secret{}, &corev1.ConfigMap{}},
+		},
+	}
+}
+
 func AddKafkaTopicIndexers(ctx context.Context, cache cache.Cache) error {
 	nameIndexFunc := func(obj client.Object) []string {
 		return []string{obj.(*v1alpha1.KafkaTopic).Spec.Name}
@@ -0,0 +1,67 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sutil
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	banzaicloudv1beta1 "github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+// NewCondition returns the condition of the given type observed on the given generation of the resource
+func NewCondition(conditionType string, status bool, generation int64, reason, message string) metav1.Condition {
+	conditionStatus := metav1.ConditionFalse
+	if status {
+		conditionStatus = metav1.ConditionTrue
+	}
+	return metav1.Condition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	}
+}
+
+// setClusterStateConditions sets the standard Ready, Progressing and Degraded conditions of the cluster matching its
+// state. The cluster keeps its Ready condition while it is being reconciled, so that it only reports not ready during
+// the rolling upgrades. The Waiting condition is left to the reconciler, a running cluster may still defer disruptive
+// changes to its next maintenance window.
+func setClusterStateConditions(cluster *banzaicloudv1beta1.KafkaCluster, state banzaicloudv1beta1.ClusterState) {
+	generation := cluster.Generation
+	switch state {
+	case banzaicloudv1beta1.KafkaClusterReconciling:
+		meta.SetStatusCondition(&cluster.Status.Conditions, NewCondition(banzaicloudv1beta1.ConditionProgressing, true,
+			generation, "Reconciling", "the cluster is being reconciled"))
+		if meta.FindStatusCondition(cluster.Status.Conditions, banzaicloudv1beta1.ConditionReady) == nil {
+			meta.SetStatusCondition(&cluster.Status.Conditions, NewCondition(banzaicloudv1beta1.ConditionReady, false,
+				generation, "Reconciling", "the cluster is being reconciled"))
+		}
+	case banzaicloudv1beta1.KafkaClusterRollingUpgrading:
+		meta.SetStatusCondition(&cluster.Status.Conditions, NewCondition(banzaicloudv1beta1.ConditionProgressing, true,
+			generation, "RollingUpgrade", "the brokers are being restarted by a rolling upgrade"))
+		meta.SetStatusCondition(&cluster.Status.Conditions, NewCondition(banzaicloudv1beta1.ConditionReady, false,
+			generation, "RollingUpgrade", "the brokers are being restarted by a rolling upgrade"))
+	case banzaicloudv1beta1.KafkaClusterRunning:
+		meta.SetStatusCondition(&cluster.Status.Conditions, NewCondition(banzaicloudv1beta1.ConditionReady, true,
+			generation, "Running", "the cluster is running with the desired configuration"))
+		meta.SetStatusCondition(&cluster.Status.Conditions, NewCondition(banzaicloudv1beta1.ConditionProgressing, false,
+			generation, "Reconciled", "the cluster has been reconciled"))
+		meta.SetStatusCondition(&cluster.Status.Conditions, NewCondition(banzaicloudv1beta1.ConditionDegraded, false,
+			generation, "Reconciled", "the cluster has been reconciled"))
+	}
+}
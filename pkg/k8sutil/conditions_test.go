@@ -0,0 +1,98 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func TestSetClusterStateConditions(t *testing.T) {
+	testCases := []struct {
+		testName            string
+		conditions          []metav1.Condition
+		state               v1beta1.ClusterState
+		expectedReady       metav1.ConditionStatus
+		expectedProgressing metav1.ConditionStatus
+		expectedWaiting     metav1.ConditionStatus
+	}{
+		{
+			testName:            "new cluster being reconciled",
+			state:               v1beta1.KafkaClusterReconciling,
+			expectedReady:       metav1.ConditionFalse,
+			expectedProgressing: metav1.ConditionTrue,
+		},
+		{
+			testName:            "running cluster being reconciled stays ready",
+			conditions:          []metav1.Condition{NewCondition(v1beta1.ConditionReady, true, 1, "Running", "")},
+			state:               v1beta1.KafkaClusterReconciling,
+			expectedReady:       metav1.ConditionTrue,
+			expectedProgressing: metav1.ConditionTrue,
+		},
+		{
+			testName:            "rolling upgrade",
+			conditions:          []metav1.Condition{NewCondition(v1beta1.ConditionReady, true, 1, "Running", "")},
+			state:               v1beta1.KafkaClusterRollingUpgrading,
+			expectedReady:       metav1.ConditionFalse,
+			expectedProgressing: metav1.ConditionTrue,
+		},
+		{
+			testName:            "running cluster",
+			state:               v1beta1.KafkaClusterRunning,
+			expectedReady:       metav1.ConditionTrue,
+			expectedProgressing: metav1.ConditionFalse,
+		},
+		{
+			testName:            "running cluster keeps waiting for a maintenance window",
+			conditions:          []metav1.Condition{NewCondition(v1beta1.ConditionWaiting, true, 1, "MaintenanceWindow", "")},
+			state:               v1beta1.KafkaClusterRunning,
+			expectedReady:       metav1.ConditionTrue,
+			expectedProgressing: metav1.ConditionFalse,
+			expectedWaiting:     metav1.ConditionTrue,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status:     v1beta1.KafkaClusterStatus{Conditions: test.conditions},
+			}
+
+			setClusterStateConditions(cluster, test.state)
+
+			ready := meta.FindStatusCondition(cluster.Status.Conditions, v1beta1.ConditionReady)
+			require.NotNil(t, ready)
+			require.Equal(t, test.expectedReady, ready.Status)
+			progressing := meta.FindStatusCondition(cluster.Status.Conditions, v1beta1.ConditionProgressing)
+			require.NotNil(t, progressing)
+			require.Equal(t, test.expectedProgressing, progressing.Status)
+			require.Equal(t, int64(2), progressing.ObservedGeneration)
+			waiting := meta.FindStatusCondition(cluster.Status.Conditions, v1beta1.ConditionWaiting)
+			if test.expectedWaiting == "" {
+				require.Nil(t, waiting)
+			} else {
+				require.NotNil(t, waiting)
+				require.Equal(t, test.expectedWaiting, waiting.Status)
+			}
+		})
+	}
+}
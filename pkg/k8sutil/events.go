@@ -0,0 +1,29 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sutil
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// RecordEvent emits an event on the given object, it does nothing when no event recorder is set
+func RecordEvent(recorder record.EventRecorder, object runtime.Object, eventType, reason, messageFmt string, args ...interface{}) {
+	if recorder == nil {
+		return
+	}
+	recorder.Eventf(object, eventType, reason, messageFmt, args...)
+}
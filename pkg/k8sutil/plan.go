@@ -0,0 +1,191 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sutil
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+
+	"emperror.dev/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+// IsPlanOnly returns true when the cluster is in plan-only mode through the PlanOnlyAnnotationKey annotation
+func IsPlanOnly(cluster *v1beta1.KafkaCluster) bool {
+	return cluster.GetAnnotations()[v1beta1.PlanOnlyAnnotationKey] == "true"
+}
+
+// PlanClient is a client which records the changes of the resources instead of applying them, the reads are served by
+// the wrapped client. The status writes are dropped, the component reconcilers only keep their own state there.
+type PlanClient struct {
+	client.Client
+
+	mu                sync.Mutex
+	changes           []v1beta1.PlannedResourceChange
+	restartingBrokers []string
+}
+
+// NewPlanClient creates a PlanClient reading through the given client
+func NewPlanClient(c client.Client) *PlanClient {
+	return &PlanClient{Client: c}
+}
+
+// PlanFromClient returns the PlanClient the given client is, if any, so that the reconcilers can record the changes
+// which are not writes of a resource, e.g. the restart of a broker
+func PlanFromClient(c client.Client) (*PlanClient, bool) {
+	plan, ok := c.(*PlanClient)
+	return plan, ok
+}
+
+func (p *PlanClient) Create(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+	p.record(v1beta1.PlannedActionCreate, obj)
+	return nil
+}
+
+func (p *PlanClient) Update(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	p.record(v1beta1.PlannedActionUpdate, obj)
+	return nil
+}
+
+func (p *PlanClient) Patch(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+	p.record(v1beta1.PlannedActionUpdate, obj)
+	return nil
+}
+
+// Apply records the creation or the update of the applied resource depending on whether it exists
+func (p *PlanClient) Apply(ctx context.Context, obj runtime.ApplyConfiguration, _ ...client.ApplyOption) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return errors.WrapIf(err, "could not marshal the apply configuration")
+	}
+	applied := &unstructured.Unstructured{}
+	if err := applied.UnmarshalJSON(data); err != nil {
+		return errors.WrapIf(err, "could not unmarshal the apply configuration")
+	}
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(applied.GroupVersionKind())
+	err = p.Get(ctx, client.ObjectKeyFromObject(applied), current)
+	switch {
+	case apierrors.IsNotFound(err):
+		p.record(v1beta1.PlannedActionCreate, applied)
+	case err != nil:
+		return err
+	default:
+		p.record(v1beta1.PlannedActionUpdate, applied)
+	}
+	return nil
+}
+
+// Delete records the removal of the resource when it exists, otherwise it fails like the wrapped client would
+func (p *PlanClient) Delete(ctx context.Context, obj client.Object, _ ...client.DeleteOption) error {
+	if err := p.Get(ctx, client.ObjectKeyFromObject(obj), obj.DeepCopyObject().(client.Object)); err != nil {
+		return err
+	}
+	p.record(v1beta1.PlannedActionDelete, obj)
+	return nil
+}
+
+func (p *PlanClient) DeleteAllOf(_ context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	deleteOpts := &client.DeleteAllOfOptions{}
+	deleteOpts.ApplyOptions(opts)
+	obj = obj.DeepCopyObject().(client.Object)
+	obj.SetNamespace(deleteOpts.Namespace)
+	obj.SetName("")
+	p.record(v1beta1.PlannedActionDelete, obj)
+	return nil
+}
+
+func (p *PlanClient) Status() client.SubResourceWriter {
+	return planSubResourceWriter{}
+}
+
+func (p *PlanClient) SubResource(subResource string) client.SubResourceClient {
+	return planSubResourceClient{SubResourceReader: p.Client.SubResource(subResource)}
+}
+
+// RecordBrokerRestart records that the pod of the broker would be restarted
+func (p *PlanClient) RecordBrokerRestart(brokerID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !slices.Contains(p.restartingBrokers, brokerID) {
+		p.restartingBrokers = append(p.restartingBrokers, brokerID)
+	}
+}
+
+// Plan returns the changes recorded so far as the plan of the given generation of the cluster
+func (p *PlanClient) Plan(generation int64) *v1beta1.ReconcilePlanStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return &v1beta1.ReconcilePlanStatus{
+		Changes:            slices.Clone(p.changes),
+		RestartingBrokers:  slices.Clone(p.restartingBrokers),
+		ObservedGeneration: generation,
+		PlanTime:           metav1.Now(),
+	}
+}
+
+func (p *PlanClient) record(action v1beta1.PlannedAction, obj client.Object) {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if gvk, err := apiutil.GVKForObject(obj, p.Scheme()); err == nil {
+		kind = gvk.Kind
+	}
+	name := obj.GetName()
+	if name == "" {
+		name = obj.GetGenerateName()
+	}
+	change := v1beta1.PlannedResourceChange{Action: action, Kind: kind, Namespace: obj.GetNamespace(), Name: name}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// a resource updated several times within a reconcile is only changed once, a created one is not updated anymore
+	for _, recorded := range p.changes {
+		if recorded.Kind == change.Kind && recorded.Namespace == change.Namespace && recorded.Name == change.Name &&
+			(recorded.Action == change.Action || recorded.Action == v1beta1.PlannedActionCreate && change.Action == v1beta1.PlannedActionUpdate) {
+			return
+		}
+	}
+	p.changes = append(p.changes, change)
+}
+
+// planSubResourceWriter drops the writes of the subresources
+type planSubResourceWriter struct{}
+
+func (planSubResourceWriter) Create(context.Context, client.Object, client.Object, ...client.SubResourceCreateOption) error {
+	return nil
+}
+
+func (planSubResourceWriter) Update(context.Context, client.Object, ...client.SubResourceUpdateOption) error {
+	return nil
+}
+
+func (planSubResourceWriter) Patch(context.Context, client.Object, client.Patch, ...client.SubResourcePatchOption) error {
+	return nil
+}
+
+// planSubResourceClient reads the subresources through the wrapped client and drops their writes
+type planSubResourceClient struct {
+	client.SubResourceReader
+	planSubResourceWriter
+}
@@ -0,0 +1,175 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func TestIsPlanOnly(t *testing.T) {
+	testCases := []struct {
+		testName    string
+		annotations map[string]string
+		expected    bool
+	}{
+		{testName: "no annotations"},
+		{testName: "plan-only", annotations: map[string]string{v1beta1.PlanOnlyAnnotationKey: "true"}, expected: true},
+		{testName: "plan-only disabled", annotations: map[string]string{v1beta1.PlanOnlyAnnotationKey: "false"}},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			cluster := &v1beta1.KafkaCluster{ObjectMeta: metav1.ObjectMeta{Annotations: test.annotations}}
+			require.Equal(t, test.expected, IsPlanOnly(cluster))
+		})
+	}
+}
+
+func TestPlanClient(t *testing.T) {
+	existing := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "kafka"}}
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	testCases := []struct {
+		testName string
+		writes   func(ctx context.Context, c client.Client) error
+		expected []v1beta1.PlannedResourceChange
+	}{
+		{
+			testName: "create, update and delete",
+			writes: func(ctx context.Context, c client.Client) error {
+				if err := c.Create(ctx, &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "kafka"}}); err != nil {
+					return err
+				}
+				if err := c.Update(ctx, existing.DeepCopy()); err != nil {
+					return err
+				}
+				return c.Delete(ctx, existing.DeepCopy())
+			},
+			expected: []v1beta1.PlannedResourceChange{
+				{Action: v1beta1.PlannedActionCreate, Kind: "Service", Namespace: "kafka", Name: "new"},
+				{Action: v1beta1.PlannedActionUpdate, Kind: "ConfigMap", Namespace: "kafka", Name: "existing"},
+				{Action: v1beta1.PlannedActionDelete, Kind: "ConfigMap", Namespace: "kafka", Name: "existing"},
+			},
+		},
+		{
+			testName: "repeated changes are recorded once",
+			writes: func(ctx context.Context, c client.Client) error {
+				created := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "kafka"}}
+				if err := c.Create(ctx, created); err != nil {
+					return err
+				}
+				if err := c.Update(ctx, created); err != nil {
+					return err
+				}
+				if err := c.Update(ctx, existing.DeepCopy()); err != nil {
+					return err
+				}
+				return c.Patch(ctx, existing.DeepCopy(), client.MergeFrom(existing))
+			},
+			expected: []v1beta1.PlannedResourceChange{
+				{Action: v1beta1.PlannedActionCreate, Kind: "Service", Namespace: "kafka", Name: "new"},
+				{Action: v1beta1.PlannedActionUpdate, Kind: "ConfigMap", Namespace: "kafka", Name: "existing"},
+			},
+		},
+		{
+			testName: "generated name",
+			writes: func(ctx context.Context, c client.Client) error {
+				return c.Create(ctx, &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{GenerateName: "kafka-0-", Namespace: "kafka"}})
+			},
+			expected: []v1beta1.PlannedResourceChange{
+				{Action: v1beta1.PlannedActionCreate, Kind: "PersistentVolumeClaim", Namespace: "kafka", Name: "kafka-0-"},
+			},
+		},
+		{
+			testName: "status writes are dropped",
+			writes: func(ctx context.Context, c client.Client) error {
+				return c.Status().Update(ctx, existing.DeepCopy())
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			ctx := context.Background()
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing.DeepCopy()).Build()
+			plan := NewPlanClient(c)
+
+			require.NoError(t, test.writes(ctx, plan))
+			require.Equal(t, test.expected, plan.Plan(1).Changes)
+
+			// nothing is written to the wrapped client
+			configMaps := &corev1.ConfigMapList{}
+			require.NoError(t, c.List(ctx, configMaps))
+			require.Len(t, configMaps.Items, 1)
+			require.Equal(t, existing.Name, configMaps.Items[0].Name)
+			services := &corev1.ServiceList{}
+			require.NoError(t, c.List(ctx, services))
+			require.Empty(t, services.Items)
+		})
+	}
+}
+
+func TestPlanClientApply(t *testing.T) {
+	existing := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "kafka"}}
+	c := fake.NewClientBuilder().WithObjects(existing).Build()
+	plan := NewPlanClient(c)
+
+	require.NoError(t, plan.Apply(context.Background(), corev1ac.Pod("existing", "kafka").WithLabels(map[string]string{"team": "streaming"})))
+	require.NoError(t, plan.Apply(context.Background(), corev1ac.Pod("new", "kafka")))
+	require.Equal(t, []v1beta1.PlannedResourceChange{
+		{Action: v1beta1.PlannedActionUpdate, Kind: "Pod", Namespace: "kafka", Name: "existing"},
+		{Action: v1beta1.PlannedActionCreate, Kind: "Pod", Namespace: "kafka", Name: "new"},
+	}, plan.Plan(1).Changes)
+
+	stored := &corev1.Pod{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(existing), stored))
+	require.Empty(t, stored.Labels)
+}
+
+func TestPlanClientDeleteMissing(t *testing.T) {
+	plan := NewPlanClient(fake.NewClientBuilder().Build())
+	err := plan.Delete(context.Background(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "missing", Namespace: "kafka"}})
+	require.True(t, apierrors.IsNotFound(err))
+	require.Empty(t, plan.Plan(1).Changes)
+}
+
+func TestPlanClientRecordBrokerRestart(t *testing.T) {
+	plan := NewPlanClient(fake.NewClientBuilder().Build())
+	plan.RecordBrokerRestart("0")
+	plan.RecordBrokerRestart("1")
+	plan.RecordBrokerRestart("0")
+
+	require.Equal(t, []string{"0", "1"}, plan.Plan(1).RestartingBrokers)
+	planClient, planning := PlanFromClient(plan)
+	require.True(t, planning)
+	require.Same(t, plan, planClient)
+	_, planning = PlanFromClient(fake.NewClientBuilder().Build())
+	require.False(t, planning)
+}
@@ -0,0 +1,185 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"emperror.dev/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+const (
+	// ReconcileTraceConfigMapKey is the key of the recorded reconcile traces in the reconcile trace ConfigMap
+	ReconcileTraceConfigMapKey = "traces.json"
+	// maxReconcileTraces bounds the number of traces kept to stay well below the size limit of a ConfigMap
+	maxReconcileTraces = 50
+)
+
+// ReconcileTrace is the timeline of the steps of a single KafkaCluster reconcile
+type ReconcileTrace struct {
+	Start        metav1.Time          `json:"start"`
+	Duration     metav1.Duration      `json:"duration"`
+	RequeueAfter metav1.Duration      `json:"requeueAfter,omitempty"`
+	Error        string               `json:"error,omitempty"`
+	Steps        []ReconcileTraceStep `json:"steps"`
+
+	limit int
+}
+
+// ReconcileTraceStep is a step of a traced reconcile
+type ReconcileTraceStep struct {
+	Name     string          `json:"name"`
+	Start    metav1.Time     `json:"start"`
+	Duration metav1.Duration `json:"duration"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// ReconcileTraceConfigMapName returns the name of the ConfigMap holding the reconcile traces of the cluster
+func ReconcileTraceConfigMapName(clusterName string) string {
+	return fmt.Sprintf("%s-reconcile-trace", clusterName)
+}
+
+// IsReconcileTraceConfigMap returns true if the object is the reconcile trace ConfigMap of a cluster
+func IsReconcileTraceConfigMap(obj runtimeClient.Object) bool {
+	switch o := obj.(type) {
+	case *corev1.ConfigMap:
+	case *metav1.PartialObjectMetadata:
+		// ConfigMaps are watched through their metadata only
+		if o.Kind != "ConfigMap" {
+			return false
+		}
+	default:
+		return false
+	}
+	clusterName, ok := obj.GetLabels()[v1beta1.KafkaCRLabelKey]
+	return ok && obj.GetName() == ReconcileTraceConfigMapName(clusterName)
+}
+
+// NewReconcileTrace starts the trace of a reconcile if the reconcile trace is enabled on the cluster through the
+// ReconcileTraceAnnotationKey annotation, it returns nil otherwise. The methods of a nil trace only run the steps.
+func NewReconcileTrace(cluster *v1beta1.KafkaCluster) *ReconcileTrace {
+	value, ok := cluster.GetAnnotations()[v1beta1.ReconcileTraceAnnotationKey]
+	if !ok {
+		return nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 {
+		return nil
+	}
+	return &ReconcileTrace{
+		Start: metav1.Now(),
+		limit: min(limit, maxReconcileTraces),
+	}
+}
+
+// Step runs fn and records its duration and error as a step of the trace
+func (t *ReconcileTrace) Step(name string, fn func() error) error {
+	if t == nil {
+		return fn()
+	}
+	start := time.Now()
+	err := fn()
+	step := ReconcileTraceStep{
+		Name:     name,
+		Start:    metav1.NewTime(start),
+		Duration: metav1.Duration{Duration: time.Since(start)},
+	}
+	if err != nil {
+		step.Error = err.Error()
+	}
+	t.Steps = append(t.Steps, step)
+	return err
+}
+
+// Finish records the outcome of the traced reconcile
+func (t *ReconcileTrace) Finish(result ctrl.Result, err error) {
+	if t == nil {
+		return
+	}
+	t.Duration = metav1.Duration{Duration: time.Since(t.Start.Time)}
+	t.RequeueAfter = metav1.Duration{Duration: result.RequeueAfter}
+	if err != nil {
+		t.Error = err.Error()
+	}
+}
+
+// StoreReconcileTrace appends the trace to the reconcile trace ConfigMap of the cluster, only the latest traces
+// up to the limit set on the cluster are kept
+func StoreReconcileTrace(ctx context.Context, client runtimeClient.Client, cluster *v1beta1.KafkaCluster, trace *ReconcileTrace) error {
+	if trace == nil {
+		return nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err := client.Get(ctx, types.NamespacedName{Name: ReconcileTraceConfigMapName(cluster.Name), Namespace: cluster.Namespace}, configMap)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.WrapIf(err, "could not get the reconcile trace ConfigMap")
+	}
+	notFound := apierrors.IsNotFound(err)
+
+	var traces []ReconcileTrace
+	if data := configMap.Data[ReconcileTraceConfigMapKey]; data != "" {
+		// traces which can not be read are dropped, the ConfigMap is only a debugging aid
+		_ = json.Unmarshal([]byte(data), &traces)
+	}
+	data, err := json.Marshal(appendReconcileTrace(traces, *trace, trace.limit))
+	if err != nil {
+		return errors.WrapIf(err, "could not marshal the reconcile traces")
+	}
+
+	if notFound {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ReconcileTraceConfigMapName(cluster.Name),
+				Namespace: cluster.Namespace,
+				Labels:    apiutil.LabelsForKafka(cluster.Name),
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(cluster, v1beta1.GroupVersion.WithKind("KafkaCluster")),
+				},
+			},
+			Data: map[string]string{ReconcileTraceConfigMapKey: string(data)},
+		}
+		return errors.WrapIf(client.Create(ctx, configMap), "could not create the reconcile trace ConfigMap")
+	}
+
+	if configMap.Data == nil {
+		configMap.Data = make(map[string]string, 1)
+	}
+	configMap.Data[ReconcileTraceConfigMapKey] = string(data)
+	return errors.WrapIf(client.Update(ctx, configMap), "could not update the reconcile trace ConfigMap")
+}
+
+// appendReconcileTrace appends the trace to the traces and drops the oldest ones above the limit
+func appendReconcileTrace(traces []ReconcileTrace, trace ReconcileTrace, limit int) []ReconcileTrace {
+	traces = append(traces, trace)
+	if len(traces) > limit {
+		traces = traces[len(traces)-limit:]
+	}
+	return traces
+}
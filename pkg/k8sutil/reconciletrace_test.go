@@ -0,0 +1,167 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sutil
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func TestNewReconcileTrace(t *testing.T) {
+	testCases := []struct {
+		testName      string
+		annotations   map[string]string
+		expectedLimit int
+	}{
+		{
+			testName: "trace disabled",
+		},
+		{
+			testName:    "invalid number of traces",
+			annotations: map[string]string{v1beta1.ReconcileTraceAnnotationKey: "all"},
+		},
+		{
+			testName:    "zero traces",
+			annotations: map[string]string{v1beta1.ReconcileTraceAnnotationKey: "0"},
+		},
+		{
+			testName:      "trace enabled",
+			annotations:   map[string]string{v1beta1.ReconcileTraceAnnotationKey: "5"},
+			expectedLimit: 5,
+		},
+		{
+			testName:      "number of traces capped",
+			annotations:   map[string]string{v1beta1.ReconcileTraceAnnotationKey: "1000"},
+			expectedLimit: maxReconcileTraces,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			trace := NewReconcileTrace(&v1beta1.KafkaCluster{ObjectMeta: metav1.ObjectMeta{Annotations: test.annotations}})
+			if test.expectedLimit == 0 {
+				require.Nil(t, trace)
+				return
+			}
+			require.NotNil(t, trace)
+			require.Equal(t, test.expectedLimit, trace.limit)
+		})
+	}
+}
+
+func TestReconcileTraceSteps(t *testing.T) {
+	var disabled *ReconcileTrace
+	require.NoError(t, disabled.Step("kafka", func() error { return nil }))
+	disabled.Finish(ctrl.Result{}, nil)
+
+	trace := &ReconcileTrace{Start: metav1.Now(), limit: 1}
+	require.NoError(t, trace.Step("envoy", func() error { return nil }))
+	require.Error(t, trace.Step("kafka", func() error { return errors.New("brokers unreachable") }))
+	trace.Finish(ctrl.Result{RequeueAfter: 15 * time.Second}, nil)
+
+	require.Len(t, trace.Steps, 2)
+	require.Equal(t, "envoy", trace.Steps[0].Name)
+	require.Empty(t, trace.Steps[0].Error)
+	require.Equal(t, "kafka", trace.Steps[1].Name)
+	require.Equal(t, "brokers unreachable", trace.Steps[1].Error)
+	require.Equal(t, 15*time.Second, trace.RequeueAfter.Duration)
+	require.Empty(t, trace.Error)
+}
+
+func TestStoreReconcileTrace(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	cluster := &v1beta1.KafkaCluster{ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka", UID: "uid"}}
+	for _, name := range []string{"first", "second", "third"} {
+		trace := &ReconcileTrace{Start: metav1.Now(), limit: 2}
+		require.NoError(t, trace.Step(name, func() error { return nil }))
+		require.NoError(t, StoreReconcileTrace(context.Background(), c, cluster, trace))
+	}
+
+	configMap := &corev1.ConfigMap{}
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "kafka-reconcile-trace", Namespace: "kafka"}, configMap))
+	require.True(t, IsReconcileTraceConfigMap(configMap))
+	require.Len(t, configMap.OwnerReferences, 1)
+
+	var traces []ReconcileTrace
+	require.NoError(t, json.Unmarshal([]byte(configMap.Data[ReconcileTraceConfigMapKey]), &traces))
+	require.Len(t, traces, 2)
+	require.Equal(t, "second", traces[0].Steps[0].Name)
+	require.Equal(t, "third", traces[1].Steps[0].Name)
+}
+
+func TestIsReconcileTraceConfigMap(t *testing.T) {
+	objectMeta := metav1.ObjectMeta{
+		Name:   "kafka-reconcile-trace",
+		Labels: map[string]string{v1beta1.KafkaCRLabelKey: "kafka"},
+	}
+	testCases := []struct {
+		testName string
+		obj      client.Object
+		expected bool
+	}{
+		{
+			testName: "reconcile trace ConfigMap",
+			obj:      &corev1.ConfigMap{ObjectMeta: objectMeta},
+			expected: true,
+		},
+		{
+			testName: "metadata of the reconcile trace ConfigMap",
+			obj: &metav1.PartialObjectMetadata{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+				ObjectMeta: objectMeta,
+			},
+			expected: true,
+		},
+		{
+			testName: "metadata of a Secret",
+			obj: &metav1.PartialObjectMetadata{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+				ObjectMeta: objectMeta,
+			},
+		},
+		{
+			testName: "other ConfigMap of the cluster",
+			obj: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name:   "kafka-config-0",
+				Labels: map[string]string{v1beta1.KafkaCRLabelKey: "kafka"},
+			}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			require.Equal(t, test.expected, IsReconcileTraceConfigMap(test.obj))
+		})
+	}
+}
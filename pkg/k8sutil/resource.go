@@ -113,7 +113,8 @@ func Reconcile(log logr.Logger, client runtimeClient.Client, desired runtime.Obj
 
 			switch d := desired.(type) {
 			default:
-				d.(metav1.ObjectMetaAccessor).GetObjectMeta().SetResourceVersion(current.(metav1.ObjectMetaAccessor).GetObjectMeta().GetResourceVersion())
+				// the unstructured objects do not implement metav1.ObjectMetaAccessor
+				d.(runtimeClient.Object).SetResourceVersion(current.GetResourceVersion())
 			case *corev1.Service:
 				svc := desired.(*corev1.Service)
 				svc.ResourceVersion = current.(*corev1.Service).ResourceVersion
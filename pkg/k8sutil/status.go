@@ -25,6 +25,7 @@ import (
 	"github.com/go-logr/logr"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -95,6 +96,14 @@ func generateBrokerConfigurationBackups(cluster *banzaicloudv1beta1.KafkaCluster
 
 // UpdateBrokerStatus updates the broker status with rack and configuration infos
 func UpdateBrokerStatus(c client.Client, brokerIDs []string, cluster *banzaicloudv1beta1.KafkaCluster, state interface{}, logger logr.Logger) error {
+	// the status writes of a plan are dropped by the client, they must not be queued for the status writer either
+	if _, planning := PlanFromClient(c); !planning && isCoalescedBrokerState(state) && QueueStatusUpdate(cluster, func(cluster *banzaicloudv1beta1.KafkaCluster) {
+		generateBrokerState(brokerIDs, cluster, state)
+	}) {
+		logger.V(1).Info("Kafka cluster state update queued")
+		return nil
+	}
+
 	typeMeta := cluster.TypeMeta
 
 	generateBrokerState(brokerIDs, cluster, state)
@@ -131,6 +140,17 @@ func UpdateBrokerStatus(c client.Client, brokerIDs []string, cluster *banzaiclou
 	return nil
 }
 
+// isCoalescedBrokerState reports whether the broker state is only informational and can be written by the status
+// writer. The configuration states are written directly as a lost ConfigOutOfSync would leave the broker running its
+// previous configuration, and graceful action states coordinate with Cruise Control.
+func isCoalescedBrokerState(state interface{}) bool {
+	switch state.(type) {
+	case banzaicloudv1beta1.RackAwarenessState, banzaicloudv1beta1.ExternalListenerConfigNames, banzaicloudv1beta1.KafkaVersion:
+		return true
+	}
+	return false
+}
+
 func generateBrokerState(brokerIDs []string, cluster *banzaicloudv1beta1.KafkaCluster, state interface{}) {
 	brokersState := cluster.Status.BrokersState
 	if brokersState == nil {
@@ -156,6 +176,8 @@ func generateBrokerState(brokerIDs []string, cluster *banzaicloudv1beta1.KafkaCl
 			brokerState.ConfigurationState = s
 		case banzaicloudv1beta1.PerBrokerConfigurationState:
 			brokerState.PerBrokerConfigurationState = s
+		case banzaicloudv1beta1.DynamicConfigKeys:
+			brokerState.DynamicConfigKeys = s
 		case map[string]banzaicloudv1beta1.VolumeState:
 			if brokerState.GracefulActionState.VolumeStates == nil {
 				brokerState.GracefulActionState.VolumeStates = make(map[string]banzaicloudv1beta1.VolumeState)
@@ -174,6 +196,18 @@ func generateBrokerState(brokerIDs []string, cluster *banzaicloudv1beta1.KafkaCl
 		case banzaicloudv1beta1.KafkaVersion:
 			brokerState.Image = s.Image
 			brokerState.Version = s.Version
+		case banzaicloudv1beta1.BrokerDrainState:
+			brokerState.DrainState = &s
+		case banzaicloudv1beta1.BrokerReplacementState:
+			brokerState.ReplacementState = &s
+		case banzaicloudv1beta1.RestartHooksState:
+			brokerState.RestartHooksState = s
+		case banzaicloudv1beta1.StorageMigrationStates:
+			brokerState.StorageMigrations = s
+		case *banzaicloudv1beta1.NodeInterruptionState:
+			brokerState.NodeInterruption = s
+		case banzaicloudv1beta1.LogDirFailureStates:
+			brokerState.LogDirFailures = s
 		}
 		brokersState[brokerID] = brokerState
 	}
@@ -281,8 +315,37 @@ func UpdateCRStatus(c client.Client, cluster *banzaicloudv1beta1.KafkaCluster, s
 	switch s := state.(type) {
 	case banzaicloudv1beta1.ClusterState:
 		cluster.Status.State = s
+		setClusterStateConditions(cluster, s)
 	case banzaicloudv1beta1.CruiseControlTopicStatus:
 		cluster.Status.CruiseControlTopicStatus = s
+	case metav1.Condition:
+		meta.SetStatusCondition(&cluster.Status.Conditions, s)
+	case *banzaicloudv1beta1.DetectedVersionsStatus:
+		cluster.Status.DetectedVersions = s
+	case *banzaicloudv1beta1.DiskPlacementStatus:
+		cluster.Status.DiskPlacement = s
+	case *banzaicloudv1beta1.LeaderBalanceStatus:
+		cluster.Status.LeaderBalance = s
+	case *banzaicloudv1beta1.ConformanceAuditStatus:
+		cluster.Status.ConformanceAudit = s
+	case *banzaicloudv1beta1.CruiseControlAnomaliesStatus:
+		cluster.Status.CruiseControlAnomalies = s
+	case *banzaicloudv1beta1.RebalanceScheduleStatus:
+		cluster.Status.RebalanceSchedule = s
+	case *banzaicloudv1beta1.ReconcilePlanStatus:
+		cluster.Status.Plan = s
+	case map[string]banzaicloudv1beta1.BrokerDecommissionStatus:
+		cluster.Status.BrokerDecommissions = s
+	case *banzaicloudv1beta1.RollingUpgradeProgress:
+		cluster.Status.RollingUpgrade.Progress = s
+	case map[string]banzaicloudv1beta1.ExternalListenerAccessStatus:
+		cluster.Status.ExternalListenersAccess = s
+	case *banzaicloudv1beta1.KRaftMigrationStatus:
+		cluster.Status.KRaftMigration = s
+	case *banzaicloudv1beta1.ControllerQuorumStatus:
+		cluster.Status.ControllerQuorum = s
+	case *banzaicloudv1beta1.KafkaVersionUpgradeStatus:
+		cluster.Status.VersionUpgrade = s
 	}
 
 	err := c.Status().Update(context.Background(), cluster)
@@ -303,8 +366,37 @@ func UpdateCRStatus(c client.Client, cluster *banzaicloudv1beta1.KafkaCluster, s
 		switch s := state.(type) {
 		case banzaicloudv1beta1.ClusterState:
 			cluster.Status.State = s
+			setClusterStateConditions(cluster, s)
 		case banzaicloudv1beta1.CruiseControlTopicStatus:
 			cluster.Status.CruiseControlTopicStatus = s
+		case metav1.Condition:
+			meta.SetStatusCondition(&cluster.Status.Conditions, s)
+		case *banzaicloudv1beta1.DetectedVersionsStatus:
+			cluster.Status.DetectedVersions = s
+		case *banzaicloudv1beta1.DiskPlacementStatus:
+			cluster.Status.DiskPlacement = s
+		case *banzaicloudv1beta1.LeaderBalanceStatus:
+			cluster.Status.LeaderBalance = s
+		case *banzaicloudv1beta1.ConformanceAuditStatus:
+			cluster.Status.ConformanceAudit = s
+		case *banzaicloudv1beta1.CruiseControlAnomaliesStatus:
+			cluster.Status.CruiseControlAnomalies = s
+		case *banzaicloudv1beta1.RebalanceScheduleStatus:
+			cluster.Status.RebalanceSchedule = s
+		case *banzaicloudv1beta1.ReconcilePlanStatus:
+			cluster.Status.Plan = s
+		case map[string]banzaicloudv1beta1.BrokerDecommissionStatus:
+			cluster.Status.BrokerDecommissions = s
+		case *banzaicloudv1beta1.RollingUpgradeProgress:
+			cluster.Status.RollingUpgrade.Progress = s
+		case map[string]banzaicloudv1beta1.ExternalListenerAccessStatus:
+			cluster.Status.ExternalListenersAccess = s
+		case *banzaicloudv1beta1.KRaftMigrationStatus:
+			cluster.Status.KRaftMigration = s
+		case *banzaicloudv1beta1.ControllerQuorumStatus:
+			cluster.Status.ControllerQuorum = s
+		case *banzaicloudv1beta1.KafkaVersionUpgradeStatus:
+			cluster.Status.VersionUpgrade = s
 		}
 
 		err = c.Status().Update(context.Background(), cluster)
@@ -327,6 +419,7 @@ func UpdateRollingUpgradeState(c client.Client, cluster *banzaicloudv1beta1.Kafk
 
 	timeStamp := time.Format("2006-01-02 15:04:05")
 	cluster.Status.RollingUpgrade.LastSuccess = timeStamp
+	cluster.Status.RollingUpgrade.Progress = nil
 
 	err := c.Status().Update(context.Background(), cluster)
 	if apierrors.IsNotFound(err) {
@@ -345,6 +438,7 @@ func UpdateRollingUpgradeState(c client.Client, cluster *banzaicloudv1beta1.Kafk
 		}
 
 		cluster.Status.RollingUpgrade.LastSuccess = timeStamp
+		cluster.Status.RollingUpgrade.Progress = nil
 
 		err = c.Status().Update(context.Background(), cluster)
 		if apierrors.IsNotFound(err) {
@@ -369,6 +463,8 @@ func UpdateListenerStatuses(ctx context.Context, c client.Client, cluster *banza
 		InternalListeners: intListenerStatuses,
 		ExternalListeners: extListenerStatuses,
 	}
+	listenerEndpoints := CreateListenerEndpoints(cluster, intListenerStatuses, extListenerStatuses)
+	cluster.Status.ListenerEndpoints = listenerEndpoints
 
 	err := c.Status().Update(ctx, cluster)
 	if apierrors.IsNotFound(err) {
@@ -390,6 +486,7 @@ func UpdateListenerStatuses(ctx context.Context, c client.Client, cluster *banza
 			InternalListeners: intListenerStatuses,
 			ExternalListeners: extListenerStatuses,
 		}
+		cluster.Status.ListenerEndpoints = listenerEndpoints
 
 		err = c.Status().Update(ctx, cluster)
 		if apierrors.IsNotFound(err) {
@@ -495,6 +592,54 @@ func CreateInternalListenerStatuses(kafkaCluster *banzaicloudv1beta1.KafkaCluste
 	return intListenerStatuses, controllerIntListenerStatuses, nil
 }
 
+// CreateListenerEndpoints returns the bootstrap servers and the advertised addresses of the internal and external
+// listeners derived from their statuses
+func CreateListenerEndpoints(kafkaCluster *banzaicloudv1beta1.KafkaCluster, intListenerStatuses, extListenerStatuses map[string]banzaicloudv1beta1.ListenerStatusList) *banzaicloudv1beta1.ListenerEndpoints {
+	if len(intListenerStatuses) == 0 && len(extListenerStatuses) == 0 {
+		return nil
+	}
+	endpoints := &banzaicloudv1beta1.ListenerEndpoints{}
+	for _, iListener := range kafkaCluster.Spec.ListenersConfig.InternalListeners {
+		if statuses, ok := intListenerStatuses[iListener.Name]; ok {
+			if endpoints.InternalListeners == nil {
+				endpoints.InternalListeners = make(map[string]banzaicloudv1beta1.ListenerEndpoint)
+			}
+			endpoints.InternalListeners[iListener.Name] = listenerEndpoint(iListener.Type, statuses)
+		}
+	}
+	for _, eListener := range kafkaCluster.Spec.ListenersConfig.ExternalListeners {
+		if statuses, ok := extListenerStatuses[eListener.Name]; ok {
+			if endpoints.ExternalListeners == nil {
+				endpoints.ExternalListeners = make(map[string]banzaicloudv1beta1.ListenerEndpoint)
+			}
+			endpoints.ExternalListeners[eListener.Name] = listenerEndpoint(eListener.Type, statuses)
+		}
+	}
+	return endpoints
+}
+
+// listenerEndpoint returns the endpoint of the listener, the clients bootstrap from the addresses reaching any broker
+// when the listener has such, e.g. the headless or the all-broker service, from the addresses of the brokers otherwise
+func listenerEndpoint(securityProtocol banzaicloudv1beta1.SecurityProtocol, statuses banzaicloudv1beta1.ListenerStatusList) banzaicloudv1beta1.ListenerEndpoint {
+	var anyBrokerAddresses, brokerAddresses []string
+	for _, status := range statuses {
+		if strings.HasPrefix(status.Name, "broker-") {
+			brokerAddresses = append(brokerAddresses, status.Address)
+		} else {
+			anyBrokerAddresses = append(anyBrokerAddresses, status.Address)
+		}
+	}
+	bootstrapServers := anyBrokerAddresses
+	if len(bootstrapServers) == 0 {
+		bootstrapServers = brokerAddresses
+	}
+	return banzaicloudv1beta1.ListenerEndpoint{
+		SecurityProtocol:    securityProtocol,
+		BootstrapServers:    strings.Join(bootstrapServers, ","),
+		AdvertisedAddresses: brokerAddresses,
+	}
+}
+
 func getHostnameForBrokerId(eListenerStatusList banzaicloudv1beta1.ListenerStatusList, brokerId int32) string {
 	for _, eListenerStatus := range eListenerStatusList {
 		if eListenerStatus.Name == fmt.Sprintf("broker-%d", brokerId) {
@@ -0,0 +1,104 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func TestCreateListenerEndpoints(t *testing.T) {
+	cluster := &v1beta1.KafkaCluster{
+		Spec: v1beta1.KafkaClusterSpec{
+			ListenersConfig: v1beta1.ListenersConfig{
+				InternalListeners: []v1beta1.InternalListenerConfig{
+					{CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolPlaintext}},
+					{CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "controller", Type: v1beta1.SecurityProtocolPlaintext}},
+				},
+				ExternalListeners: []v1beta1.ExternalListenerConfig{
+					{CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "loadbalancer", Type: v1beta1.SecurityProtocolSSL}},
+					{CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "nodeport", Type: v1beta1.SecurityProtocolSaslSSL}},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName            string
+		intListenerStatuses map[string]v1beta1.ListenerStatusList
+		extListenerStatuses map[string]v1beta1.ListenerStatusList
+		expected            *v1beta1.ListenerEndpoints
+	}{
+		{
+			testName: "no listener statuses",
+		},
+		{
+			testName: "bootstrap from the any-broker addresses and the brokers",
+			intListenerStatuses: map[string]v1beta1.ListenerStatusList{
+				"internal": {
+					{Name: "headless", Address: "kafka-headless.kafka.svc.cluster.local:29092"},
+					{Name: "broker-0", Address: "kafka-0.kafka-headless.kafka.svc.cluster.local:29092"},
+					{Name: "broker-1", Address: "kafka-1.kafka-headless.kafka.svc.cluster.local:29092"},
+				},
+			},
+			extListenerStatuses: map[string]v1beta1.ListenerStatusList{
+				"loadbalancer": {
+					{Name: "any-broker-az1", Address: "10.0.0.1:29092"},
+					{Name: "any-broker-az2", Address: "10.0.0.2:29092"},
+					{Name: "broker-0", Address: "10.0.0.1:19090"},
+					{Name: "broker-1", Address: "10.0.0.2:19091"},
+				},
+				"nodeport": {
+					{Name: "broker-0", Address: "node-0:30090"},
+					{Name: "broker-1", Address: "node-1:30091"},
+				},
+			},
+			expected: &v1beta1.ListenerEndpoints{
+				InternalListeners: map[string]v1beta1.ListenerEndpoint{
+					"internal": {
+						SecurityProtocol: v1beta1.SecurityProtocolPlaintext,
+						BootstrapServers: "kafka-headless.kafka.svc.cluster.local:29092",
+						AdvertisedAddresses: []string{
+							"kafka-0.kafka-headless.kafka.svc.cluster.local:29092",
+							"kafka-1.kafka-headless.kafka.svc.cluster.local:29092",
+						},
+					},
+				},
+				ExternalListeners: map[string]v1beta1.ListenerEndpoint{
+					"loadbalancer": {
+						SecurityProtocol:    v1beta1.SecurityProtocolSSL,
+						BootstrapServers:    "10.0.0.1:29092,10.0.0.2:29092",
+						AdvertisedAddresses: []string{"10.0.0.1:19090", "10.0.0.2:19091"},
+					},
+					"nodeport": {
+						SecurityProtocol:    v1beta1.SecurityProtocolSaslSSL,
+						BootstrapServers:    "node-0:30090,node-1:30091",
+						AdvertisedAddresses: []string{"node-0:30090", "node-1:30091"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			require.Equal(t, test.expected, CreateListenerEndpoints(cluster, test.intListenerStatuses, test.extListenerStatuses))
+		})
+	}
+}
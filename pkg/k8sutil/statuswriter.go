@@ -0,0 +1,171 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sutil
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	banzaicloudv1beta1 "github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/util"
+)
+
+// StatusMutation applies a change to the status of the given KafkaCluster in place
+type StatusMutation func(cluster *banzaicloudv1beta1.KafkaCluster)
+
+// StatusWriter coalesces the KafkaCluster status mutations queued within a time window
+// into a single status update per cluster
+type StatusWriter struct {
+	client client.Client
+	window time.Duration
+	log    logr.Logger
+
+	mu      sync.Mutex
+	pending map[types.NamespacedName][]StatusMutation
+}
+
+// statusWriter is the writer used for high-frequency status transitions, nil means
+// the status is written synchronously
+var statusWriter *StatusWriter
+
+// SetStatusWriter sets the writer used for high-frequency status transitions
+func SetStatusWriter(w *StatusWriter) {
+	statusWriter = w
+}
+
+// NewStatusWriter creates a StatusWriter which flushes the queued mutations every window
+func NewStatusWriter(c client.Client, window time.Duration, log logr.Logger) *StatusWriter {
+	return &StatusWriter{
+		client:  c,
+		window:  window,
+		log:     log,
+		pending: make(map[types.NamespacedName][]StatusMutation),
+	}
+}
+
+// Enqueue queues the mutation to be written with the next flush of the status of the given cluster
+func (w *StatusWriter) Enqueue(key types.NamespacedName, mutation StatusMutation) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.pending[key] = append(w.pending[key], mutation)
+}
+
+// ApplyPending applies the mutations not yet written to the given cluster so that
+// readers observe the latest status
+func (w *StatusWriter) ApplyPending(cluster *banzaicloudv1beta1.KafkaCluster) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, mutation := range w.pending[client.ObjectKeyFromObject(cluster)] {
+		mutation(cluster)
+	}
+}
+
+// Start implements manager.Runnable, it flushes the queued mutations every window until the context is done
+func (w *StatusWriter) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// write what is left before shutting down
+			if err := w.Flush(context.Background()); err != nil {
+				w.log.Error(err, "could not flush queued Kafka cluster status updates")
+			}
+			return nil
+		case <-ticker.C:
+			if err := w.Flush(ctx); err != nil {
+				w.log.Error(err, "could not flush queued Kafka cluster status updates")
+			}
+		}
+	}
+}
+
+// Flush writes the queued mutations with a single status update per cluster, mutations
+// which could not be written are queued again for the next flush
+func (w *StatusWriter) Flush(ctx context.Context) error {
+	w.mu.Lock()
+	pending := w.pending
+	w.pending = make(map[types.NamespacedName][]StatusMutation)
+	w.mu.Unlock()
+
+	var combinedErr error
+	for key, mutations := range pending {
+		if err := w.flushCluster(ctx, key, mutations); err != nil {
+			combinedErr = errors.Combine(combinedErr, err)
+			w.mu.Lock()
+			w.pending[key] = append(mutations, w.pending[key]...)
+			w.mu.Unlock()
+		}
+	}
+	return combinedErr
+}
+
+func (w *StatusWriter) flushCluster(ctx context.Context, key types.NamespacedName, mutations []StatusMutation) error {
+	cluster := &banzaicloudv1beta1.KafkaCluster{}
+	updateFn := func() error {
+		if err := w.client.Get(ctx, key, cluster); err != nil {
+			return err
+		}
+		currentStatus := cluster.Status.DeepCopy()
+		for _, mutation := range mutations {
+			mutation(cluster)
+		}
+		if reflect.DeepEqual(*currentStatus, cluster.Status) {
+			return nil
+		}
+		return w.client.Status().Update(ctx, cluster)
+	}
+
+	err := util.RetryOnConflict(util.DefaultBackOffForConflict, updateFn)
+	if apierrors.IsNotFound(err) {
+		// the cluster is gone, nothing left to write
+		return nil
+	}
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not write queued status updates", "cluster", key.String(), "updates", len(mutations))
+	}
+	w.log.V(1).Info("Kafka cluster status updates written", "cluster", key.String(), "updates", len(mutations))
+	return nil
+}
+
+// QueueStatusUpdate applies the mutation to the given cluster and queues it to be written asynchronously,
+// returns false when status updates are not coalesced and the caller has to write the status itself
+func QueueStatusUpdate(cluster *banzaicloudv1beta1.KafkaCluster, mutation StatusMutation) bool {
+	if statusWriter == nil {
+		return false
+	}
+	mutation(cluster)
+	statusWriter.Enqueue(client.ObjectKeyFromObject(cluster), mutation)
+	return true
+}
+
+// ApplyPendingStatus applies the status mutations not yet written by the status writer to the given cluster
+func ApplyPendingStatus(cluster *banzaicloudv1beta1.KafkaCluster) {
+	if statusWriter == nil {
+		return
+	}
+	statusWriter.ApplyPending(cluster)
+}
@@ -0,0 +1,151 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func newStatusWriterTestClient(t *testing.T, statusUpdates *int, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := v1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&v1beta1.KafkaCluster{}).
+		WithObjects(objs...).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				*statusUpdates++
+				return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+}
+
+func TestStatusWriterCoalescesUpdates(t *testing.T) {
+	cluster := &v1beta1.KafkaCluster{ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"}}
+	statusUpdates := 0
+	c := newStatusWriterTestClient(t, &statusUpdates, cluster)
+
+	writer := NewStatusWriter(c, time.Second, logr.Discard())
+	SetStatusWriter(writer)
+	defer SetStatusWriter(nil)
+
+	states := []interface{}{
+		v1beta1.WaitingForRackAwareness,
+		v1beta1.KafkaVersion{Image: "kafka:3.9.0", Version: "3.9.0"},
+		v1beta1.Configured,
+		v1beta1.ExternalListenerConfigNames{"external"},
+	}
+	for _, state := range states {
+		if err := UpdateBrokerStatus(c, []string{"0", "1"}, cluster, state, logr.Discard()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if statusUpdates != 0 {
+		t.Fatalf("status should not be written before flush, got %d writes", statusUpdates)
+	}
+
+	// readers observe the queued updates before they are written
+	cached := &v1beta1.KafkaCluster{}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(cluster), cached); err != nil {
+		t.Fatal(err)
+	}
+	ApplyPendingStatus(cached)
+	if cached.Status.BrokersState["1"].RackAwarenessState != v1beta1.Configured {
+		t.Errorf("pending status not applied, got %v", cached.Status.BrokersState["1"])
+	}
+
+	if err := writer.Flush(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if statusUpdates != 1 {
+		t.Errorf("expected a single status write, got %d", statusUpdates)
+	}
+
+	written := &v1beta1.KafkaCluster{}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(cluster), written); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"0", "1"} {
+		state := written.Status.BrokersState[id]
+		if state.RackAwarenessState != v1beta1.Configured || state.Version != "3.9.0" || len(state.ExternalListenerConfigNames) != 1 {
+			t.Errorf("broker %s: unexpected state %v", id, state)
+		}
+	}
+
+	// nothing left to write
+	if err := writer.Flush(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if statusUpdates != 1 {
+		t.Errorf("expected no further status writes, got %d", statusUpdates)
+	}
+}
+
+func TestStatusWriterSynchronousStates(t *testing.T) {
+	cluster := &v1beta1.KafkaCluster{ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"}}
+	statusUpdates := 0
+	c := newStatusWriterTestClient(t, &statusUpdates, cluster)
+
+	SetStatusWriter(NewStatusWriter(c, time.Second, logr.Discard()))
+	defer SetStatusWriter(nil)
+
+	states := []interface{}{
+		v1beta1.GracefulActionState{CruiseControlState: v1beta1.GracefulUpscaleRequired},
+		v1beta1.ConfigOutOfSync,
+		v1beta1.PerBrokerConfigOutOfSync,
+	}
+	for i, state := range states {
+		if err := UpdateBrokerStatus(c, []string{"0"}, cluster, state, logr.Discard()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if statusUpdates != i+1 {
+			t.Errorf("%T should be written directly, got %d writes", state, statusUpdates)
+		}
+	}
+}
+
+func TestStatusWriterDropsDeletedCluster(t *testing.T) {
+	statusUpdates := 0
+	c := newStatusWriterTestClient(t, &statusUpdates)
+
+	writer := NewStatusWriter(c, time.Second, logr.Discard())
+	writer.Enqueue(client.ObjectKey{Name: "kafka", Namespace: "kafka"}, func(cluster *v1beta1.KafkaCluster) {
+		cluster.Status.State = v1beta1.KafkaClusterRunning
+	})
+
+	if err := writer.Flush(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(writer.pending) != 0 {
+		t.Errorf("updates of a deleted cluster should be dropped, got %d pending", len(writer.pending))
+	}
+}
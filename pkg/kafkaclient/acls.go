@@ -0,0 +1,140 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaclient
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/IBM/sarama"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+)
+
+// ACLBinding is a single Kafka ACL entry bound to a resource pattern
+type ACLBinding struct {
+	Resource sarama.Resource
+	Acl      sarama.Acl
+}
+
+// String returns the raw representation of the binding used in CR statuses,
+// e.g. User:alice,Topic,LITERAL,orders,Read,Allow,*
+func (b ACLBinding) String() string {
+	return fmt.Sprintf("%s,%s,%s,%s,%s,%s,%s",
+		b.Acl.Principal,
+		b.Resource.ResourceType.String(),
+		strings.ToUpper(b.Resource.ResourcePatternType.String()),
+		b.Resource.ResourceName,
+		b.Acl.Operation.String(),
+		b.Acl.PermissionType.String(),
+		b.Acl.Host)
+}
+
+// ACLBindingsForSpec converts the ACL rules of a KafkaACL to Kafka ACL bindings
+func ACLBindingsForSpec(spec v1alpha1.KafkaACLSpec) ([]ACLBinding, error) {
+	bindings := make([]ACLBinding, 0, len(spec.ACLs))
+	for _, rule := range spec.ACLs {
+		var resourceType sarama.AclResourceType
+		if err := resourceType.UnmarshalText([]byte(rule.ResourceType)); err != nil {
+			return nil, errorfactory.New(errorfactory.InternalError{}, err, "unrecognized resource type")
+		}
+		patternType := AclPatternTypeMapping(rule.GetPatternType())
+		if patternType == sarama.AclPatternUnknown {
+			return nil, errorfactory.New(errorfactory.InternalError{}, fmt.Errorf("unknown type: %s", rule.PatternType), "unrecognized pattern type")
+		}
+		var operation sarama.AclOperation
+		if err := operation.UnmarshalText([]byte(rule.Operation)); err != nil {
+			return nil, errorfactory.New(errorfactory.InternalError{}, err, "unrecognized operation")
+		}
+		var permission sarama.AclPermissionType
+		if err := permission.UnmarshalText([]byte(rule.GetPermission())); err != nil {
+			return nil, errorfactory.New(errorfactory.InternalError{}, err, "unrecognized permission type")
+		}
+		bindings = append(bindings, ACLBinding{
+			Resource: sarama.Resource{
+				ResourceType:        resourceType,
+				ResourceName:        rule.ResourceName,
+				ResourcePatternType: patternType,
+			},
+			Acl: sarama.Acl{
+				Principal:      spec.Principal,
+				Host:           rule.GetHost(),
+				Operation:      operation,
+				PermissionType: permission,
+			},
+		})
+	}
+	return bindings, nil
+}
+
+// ListACLBindings returns the ACL bindings of the given principal
+func (k *kafkaClient) ListACLBindings(principal string) ([]ACLBinding, error) {
+	resourceAcls, err := k.admin.ListAcls(sarama.AclFilter{
+		ResourceType:              sarama.AclResourceAny,
+		ResourcePatternTypeFilter: sarama.AclPatternAny,
+		Principal:                 &principal,
+		Operation:                 sarama.AclOperationAny,
+		PermissionType:            sarama.AclPermissionAny,
+	})
+	if err != nil {
+		return nil, err
+	}
+	bindings := make([]ACLBinding, 0)
+	for _, resource := range resourceAcls {
+		for _, acl := range resource.Acls {
+			if acl == nil || acl.Principal != principal {
+				continue
+			}
+			bindings = append(bindings, ACLBinding{Resource: resource.Resource, Acl: *acl})
+		}
+	}
+	return bindings, nil
+}
+
+// CreateACLBindings creates the given ACL bindings, existing bindings are left untouched
+func (k *kafkaClient) CreateACLBindings(bindings []ACLBinding) error {
+	for _, binding := range bindings {
+		if err := k.admin.CreateACL(binding.Resource, binding.Acl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteACLBindings removes exactly the given ACL bindings
+func (k *kafkaClient) DeleteACLBindings(bindings []ACLBinding) error {
+	for _, binding := range bindings {
+		matches, err := k.admin.DeleteACL(sarama.AclFilter{
+			ResourceType:              binding.Resource.ResourceType,
+			ResourceName:              &binding.Resource.ResourceName,
+			ResourcePatternTypeFilter: binding.Resource.ResourcePatternType,
+			Principal:                 &binding.Acl.Principal,
+			Host:                      &binding.Acl.Host,
+			Operation:                 binding.Acl.Operation,
+			PermissionType:            binding.Acl.PermissionType,
+		}, false)
+		if err != nil {
+			return err
+		}
+		for _, match := range matches {
+			if match.Err != sarama.ErrNoError {
+				return match.Err
+			}
+		}
+	}
+	return nil
+}
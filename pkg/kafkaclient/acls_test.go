@@ -0,0 +1,130 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaclient
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+)
+
+func TestACLBindingsForSpec(t *testing.T) {
+	testCases := []struct {
+		testName  string
+		rules     []v1alpha1.ACLRule
+		expected  []string
+		expectErr bool
+	}{
+		{
+			testName: "defaults",
+			rules: []v1alpha1.ACLRule{
+				{ResourceType: "topic", ResourceName: "orders", Operation: "read"},
+			},
+			expected: []string{"User:alice,Topic,LITERAL,orders,Read,Allow,*"},
+		},
+		{
+			testName: "all fields set",
+			rules: []v1alpha1.ACLRule{
+				{ResourceType: "group", ResourceName: "orders-", PatternType: "prefixed", Operation: "describeConfigs", Host: "10.0.0.1", Permission: "deny"},
+				{ResourceType: "transactionalId", ResourceName: "*", Operation: "idempotentWrite"},
+			},
+			expected: []string{
+				"User:alice,Group,PREFIXED,orders-,DescribeConfigs,Deny,10.0.0.1",
+				"User:alice,TransactionalID,LITERAL,*,IdempotentWrite,Allow,*",
+			},
+		},
+		{
+			testName: "unknown operation",
+			rules: []v1alpha1.ACLRule{
+				{ResourceType: "topic", ResourceName: "orders", Operation: "helloWorld"},
+			},
+			expectErr: true,
+		},
+		{
+			testName: "unknown resource type",
+			rules: []v1alpha1.ACLRule{
+				{ResourceType: "helloWorld", ResourceName: "orders", Operation: "read"},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			bindings, err := ACLBindingsForSpec(v1alpha1.KafkaACLSpec{Principal: "User:alice", ACLs: test.rules})
+			if test.expectErr {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("Expected no error, got:", err)
+			}
+			actual := make([]string, 0, len(bindings))
+			for _, binding := range bindings {
+				actual = append(actual, binding.String())
+			}
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Errorf("Expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCreateAndListACLBindings(t *testing.T) {
+	client := newOpenedMockClient()
+
+	alice, err := ACLBindingsForSpec(v1alpha1.KafkaACLSpec{Principal: "User:alice", ACLs: []v1alpha1.ACLRule{
+		{ResourceType: "topic", ResourceName: "orders", Operation: "read"},
+		{ResourceType: "group", ResourceName: "*", Operation: "read"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := ACLBindingsForSpec(v1alpha1.KafkaACLSpec{Principal: "User:bob", ACLs: []v1alpha1.ACLRule{
+		{ResourceType: "topic", ResourceName: "orders", Operation: "write"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.CreateACLBindings(append(alice, bob...)); err != nil {
+		t.Fatal("Expected no error, got:", err)
+	}
+	// creating existing bindings again is a no-op
+	if err := client.CreateACLBindings(alice); err != nil {
+		t.Fatal("Expected no error, got:", err)
+	}
+
+	listed, err := client.ListACLBindings("User:alice")
+	if err != nil {
+		t.Fatal("Expected no error, got:", err)
+	}
+	if len(listed) != len(alice) {
+		t.Fatalf("Expected %d bindings, got %d: %v", len(alice), len(listed), listed)
+	}
+	for _, binding := range listed {
+		if binding.Acl.Principal != "User:alice" {
+			t.Errorf("Expected only bindings of User:alice, got %s", binding)
+		}
+	}
+
+	if err := client.DeleteACLBindings(alice); err != nil {
+		t.Error("Expected no error, got:", err)
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"time"
 
+	"emperror.dev/errors"
 	"github.com/IBM/sarama"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -29,7 +30,9 @@ import (
 )
 
 var log = logf.Log.WithName("kafka_util")
-var apiVersion = sarama.V2_6_0_0
+
+// 2.7 is the lowest version supporting the SCRAM credential admin APIs
+var apiVersion = sarama.V2_7_0_0
 var clientId = "koperator"
 
 // KafkaClient is the exported interface for kafka operations
@@ -37,17 +40,34 @@ type KafkaClient interface {
 	NumBrokers() int
 	ListTopics() (map[string]sarama.TopicDetail, error)
 	CreateTopic(*CreateTopicOptions) error
+	CreateTopics([]*CreateTopicOptions) (map[string]error, error)
 	EnsurePartitionCount(string, int32) (bool, error)
 	EnsureTopicConfig(string, map[string]*string) error
+	EnsureTopicConfigs(map[string]map[string]*string) (map[string]error, error)
+	SetTopicConfig(string, map[string]*string) error
 	DeleteTopic(string, bool) error
 	GetTopic(string) (*sarama.TopicDetail, error)
 	DescribeTopic(string) (*sarama.TopicMetadata, error)
+	ListPartitionReassignments(string, []int32) (map[int32]*sarama.PartitionReplicaReassignmentsStatus, error)
+	ChangeReplicationFactor(string, int32) error
+	ReassignPartitions(string, map[int32][]int32) error
+	DescribeLogDirs([]int32) (map[int32][]sarama.DescribeLogDirsResponseDirMetadata, error)
+	DeleteTopicConfig(string, []string) error
 	CreateUserACLs(v1alpha1.KafkaAccessType, v1alpha1.KafkaPatternType, string, string) error
 	ListUserACLs() ([]sarama.ResourceAcls, error)
 	DeleteUserACLs(string, v1alpha1.KafkaPatternType) error
+	ListACLBindings(string) ([]ACLBinding, error)
+	CreateACLBindings([]ACLBinding) error
+	DeleteACLBindings([]ACLBinding) error
+	UpsertUserScramCredential(string, v1alpha1.UserAuthenticationType, []byte) error
+	UserScramCredentialExists(string, v1alpha1.UserAuthenticationType) (bool, error)
+	DeleteUserScramCredential(string, v1alpha1.UserAuthenticationType) error
+	ListUserScramCredentials() (map[string][]v1alpha1.UserAuthenticationType, error)
+	DescribeConsumerGroup(string) (*ConsumerGroupDescription, error)
 
 	Brokers() map[int32]string
 	DescribeCluster() ([]*sarama.Broker, int32, error)
+	ClusterID() (string, error)
 
 	// AllOfflineReplicas returns the list of unique offline replica (broker) ids
 	AllOfflineReplicas() ([]int32, error)
@@ -55,14 +75,31 @@ type KafkaClient interface {
 	// OutOfSyncReplicas returns the list of unique out of sync replica (broker) ids
 	OutOfSyncReplicas() ([]int32, error)
 
+	// ReplicaPeers returns the ids of the brokers hosting a replica of a partition together with the given broker
+	ReplicaPeers(int32) ([]int32, error)
+
+	// PartitionLeaderCounts returns the number of partitions led by each broker
+	PartitionLeaderCounts() (map[int32]int, error)
+
+	// LeadershipImbalance returns the partitions not led by their preferred leader and the leaders of the partitions
+	LeadershipImbalance() (LeadershipImbalance, error)
+
+	// ElectPreferredLeaders elects the preferred leader of the partitions not led by it and returns their number
+	ElectPreferredLeaders() (int, error)
+
 	AlterPerBrokerConfig(int32, map[string]*string, bool) error
 	DescribePerBrokerConfig(int32, []string) ([]*sarama.ConfigEntry, error)
+	SetPerBrokerConfig(int32, map[string]*string) error
+	DeletePerBrokerConfig(int32, []string) error
 
 	AlterClusterWideConfig(map[string]*string, bool) error
 	DescribeClusterWideConfig() ([]sarama.ConfigEntry, error)
 
 	TopicMetaToStatus(meta *sarama.TopicMetadata) *v1alpha1.KafkaTopicStatus
 
+	// ProduceConsumeSmokeTest verifies that each of the given brokers serves produce and consume requests
+	ProduceConsumeSmokeTest(string, []int32, time.Duration) (map[int32]error, error)
+
 	Open() error
 	Close() error
 }
@@ -78,6 +115,8 @@ type kafkaClient struct {
 	// client funcs for mocking
 	newClusterAdmin func([]string, *sarama.Config) (sarama.ClusterAdmin, error)
 	newClient       func([]string, *sarama.Config) (sarama.Client, error)
+	newSyncProducer func([]string, *sarama.Config) (sarama.SyncProducer, error)
+	newConsumer     func([]string, *sarama.Config) (sarama.Consumer, error)
 }
 
 func New(opts *KafkaConfig) KafkaClient {
@@ -87,6 +126,8 @@ func New(opts *KafkaConfig) KafkaClient {
 	}
 	kclient.newClusterAdmin = sarama.NewClusterAdmin
 	kclient.newClient = sarama.NewClient
+	kclient.newSyncProducer = sarama.NewSyncProducer
+	kclient.newConsumer = sarama.NewConsumer
 	return kclient
 }
 
@@ -162,6 +203,22 @@ func (k *kafkaClient) DescribeCluster() (brokers []*sarama.Broker, controllerID
 	return
 }
 
+// ClusterID returns the ID of the Kafka cluster as reported by the controller broker
+func (k *kafkaClient) ClusterID() (string, error) {
+	controller, err := k.client.Controller()
+	if err != nil {
+		return "", errors.WrapIf(err, "could not find controller broker")
+	}
+	metadata, err := controller.GetMetadata(sarama.NewMetadataRequest(apiVersion, nil))
+	if err != nil {
+		return "", errors.WrapIf(err, "could not get cluster metadata")
+	}
+	if metadata.ClusterID == nil || *metadata.ClusterID == "" {
+		return "", errors.New("cluster ID is not reported by the controller broker")
+	}
+	return *metadata.ClusterID, nil
+}
+
 func (k *kafkaClient) getSaramaConfig() (config *sarama.Config) {
 	config = sarama.NewConfig()
 	if k.opts.UseSSL {
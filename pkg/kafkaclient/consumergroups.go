@@ -0,0 +1,100 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaclient
+
+import (
+	"github.com/IBM/sarama"
+
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+)
+
+// ConsumerGroupDescription describes the members and the committed offsets of a consumer group
+type ConsumerGroupDescription struct {
+	// State is the state of the group, e.g. Stable, PreparingRebalance, Empty or Dead
+	State   string
+	Members []ConsumerGroupMember
+	// Offsets are the offsets committed by the group by topic and partition
+	Offsets map[string]map[int32]int64
+	// LogEndOffsets are the log end offsets of the partitions the group committed offsets for
+	LogEndOffsets map[string]map[int32]int64
+}
+
+// ConsumerGroupMember describes a member of a consumer group
+type ConsumerGroupMember struct {
+	MemberID string
+	ClientID string
+	Host     string
+	// Assignments are the partitions assigned to the member by topic, empty for the groups which are not consumer groups
+	Assignments map[string][]int32
+}
+
+// DescribeConsumerGroup returns the members, the committed offsets and the log end offsets of the partitions of the
+// given consumer group
+func (k *kafkaClient) DescribeConsumerGroup(group string) (*ConsumerGroupDescription, error) {
+	groups, err := k.admin.DescribeConsumerGroups([]string{group})
+	if err != nil {
+		return nil, errorfactory.New(errorfactory.BrokersRequestError{}, err, "error describing consumer group")
+	}
+	if len(groups) == 0 {
+		return nil, errorfactory.New(errorfactory.BrokersRequestError{}, sarama.ErrGroupIDNotFound, "empty describe consumer group response")
+	}
+	if groups[0].Err != sarama.ErrNoError {
+		return nil, errorfactory.New(errorfactory.BrokersRequestError{}, groups[0].Err, "error describing consumer group")
+	}
+
+	description := &ConsumerGroupDescription{
+		State:         groups[0].State,
+		Offsets:       make(map[string]map[int32]int64),
+		LogEndOffsets: make(map[string]map[int32]int64),
+	}
+	for memberID, member := range groups[0].Members {
+		groupMember := ConsumerGroupMember{MemberID: memberID, ClientID: member.ClientId, Host: member.ClientHost}
+		// the assignments of the members of other protocols, e.g. Kafka Connect workers, can not be decoded
+		if groups[0].ProtocolType == "consumer" {
+			if assignment, err := member.GetMemberAssignment(); err == nil && assignment != nil {
+				groupMember.Assignments = assignment.Topics
+			}
+		}
+		description.Members = append(description.Members, groupMember)
+	}
+
+	offsets, err := k.admin.ListConsumerGroupOffsets(group, nil)
+	if err != nil {
+		return nil, errorfactory.New(errorfactory.BrokersRequestError{}, err, "error listing consumer group offsets")
+	}
+	if offsets.Err != sarama.ErrNoError {
+		return nil, errorfactory.New(errorfactory.BrokersRequestError{}, offsets.Err, "error listing consumer group offsets")
+	}
+	for topic, partitions := range offsets.Blocks {
+		for partition, block := range partitions {
+			// the partitions without a committed offset have no lag to report
+			if block.Err != sarama.ErrNoError || block.Offset < 0 {
+				continue
+			}
+			logEndOffset, err := k.client.GetOffset(topic, partition, sarama.OffsetNewest)
+			if err != nil {
+				return nil, errorfactory.New(errorfactory.BrokersRequestError{}, err, "error getting log end offset", "topic", topic, "partition", partition)
+			}
+			if description.Offsets[topic] == nil {
+				description.Offsets[topic] = make(map[int32]int64)
+				description.LogEndOffsets[topic] = make(map[int32]int64)
+			}
+			description.Offsets[topic][partition] = block.Offset
+			description.LogEndOffsets[topic][partition] = logEndOffset
+		}
+	}
+	return description, nil
+}
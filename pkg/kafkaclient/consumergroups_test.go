@@ -0,0 +1,105 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaclient
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/require"
+)
+
+// consumerGroupAdmin serves the consumer group and the committed offset requests of DescribeConsumerGroup
+type consumerGroupAdmin struct {
+	sarama.ClusterAdmin
+	groups  []*sarama.GroupDescription
+	offsets *sarama.OffsetFetchResponse
+}
+
+// logEndOffsetClient serves the log end offset requests of DescribeConsumerGroup
+type logEndOffsetClient struct {
+	sarama.Client
+	logEndOffsets map[string]map[int32]int64
+}
+
+func (a *consumerGroupAdmin) DescribeConsumerGroups([]string) ([]*sarama.GroupDescription, error) {
+	return a.groups, nil
+}
+
+func (a *consumerGroupAdmin) ListConsumerGroupOffsets(string, map[string][]int32) (*sarama.OffsetFetchResponse, error) {
+	return a.offsets, nil
+}
+
+func (c *logEndOffsetClient) GetOffset(topic string, partition int32, _ int64) (int64, error) {
+	return c.logEndOffsets[topic][partition], nil
+}
+
+// encodeMemberAssignment encodes the assignment of a single topic in the wire format of the consumer protocol
+func encodeMemberAssignment(topic string, partitions ...int32) []byte {
+	b := binary.BigEndian.AppendUint16(nil, 0)
+	b = binary.BigEndian.AppendUint32(b, 1)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(topic)))
+	b = append(b, topic...)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(partitions)))
+	for _, partition := range partitions {
+		b = binary.BigEndian.AppendUint32(b, uint32(partition))
+	}
+	// no user data
+	return binary.BigEndian.AppendUint32(b, 0xFFFFFFFF)
+}
+
+func TestDescribeConsumerGroup(t *testing.T) {
+	offsets := &sarama.OffsetFetchResponse{}
+	offsets.AddBlock("orders", 0, &sarama.OffsetFetchResponseBlock{Offset: 90})
+	offsets.AddBlock("orders", 1, &sarama.OffsetFetchResponseBlock{Offset: 200})
+	// no committed offset
+	offsets.AddBlock("orders", 2, &sarama.OffsetFetchResponseBlock{Offset: -1})
+
+	admin := &consumerGroupAdmin{
+		groups: []*sarama.GroupDescription{{
+			GroupId:      "orders-app",
+			State:        "Stable",
+			ProtocolType: "consumer",
+			Members: map[string]*sarama.GroupMemberDescription{
+				"consumer-1": {ClientId: "orders-app-0", ClientHost: "/10.0.0.1", MemberAssignment: encodeMemberAssignment("orders", 0, 1)},
+			},
+		}},
+		offsets: offsets,
+	}
+	client := &kafkaClient{
+		admin:  admin,
+		client: &logEndOffsetClient{logEndOffsets: map[string]map[int32]int64{"orders": {0: 100, 1: 200, 2: 50}}},
+	}
+
+	description, err := client.DescribeConsumerGroup("orders-app")
+	require.NoError(t, err)
+	require.Equal(t, &ConsumerGroupDescription{
+		State: "Stable",
+		Members: []ConsumerGroupMember{{
+			MemberID:    "consumer-1",
+			ClientID:    "orders-app-0",
+			Host:        "/10.0.0.1",
+			Assignments: map[string][]int32{"orders": {0, 1}},
+		}},
+		Offsets:       map[string]map[int32]int64{"orders": {0: 90, 1: 200}},
+		LogEndOffsets: map[string]map[int32]int64{"orders": {0: 100, 1: 200}},
+	}, description)
+
+	admin.groups[0].Err = sarama.ErrGroupAuthorizationFailed
+	_, err = client.DescribeConsumerGroup("orders-app")
+	require.Error(t, err)
+}
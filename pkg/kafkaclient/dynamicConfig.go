@@ -76,3 +76,26 @@ func (k *kafkaClient) DescribePerBrokerConfig(brokerId int32, config []string) (
 	}
 	return currentConfig.Resources[0].Configs, nil
 }
+
+// SetPerBrokerConfig sets the given dynamic configs of a broker, the other dynamic configs of the broker are left untouched
+func (k *kafkaClient) SetPerBrokerConfig(brokerId int32, config map[string]*string) error {
+	entries := make(map[string]sarama.IncrementalAlterConfigsEntry, len(config))
+	for key, value := range config {
+		entries[key] = sarama.IncrementalAlterConfigsEntry{
+			Operation: sarama.IncrementalAlterConfigsOperationSet,
+			Value:     value,
+		}
+	}
+	return k.admin.IncrementalAlterConfig(sarama.BrokerResource, strconv.Itoa(int(brokerId)), entries, false)
+}
+
+// DeletePerBrokerConfig removes the given dynamic configs of a broker, the other dynamic configs of the broker are left untouched
+func (k *kafkaClient) DeletePerBrokerConfig(brokerId int32, keys []string) error {
+	entries := make(map[string]sarama.IncrementalAlterConfigsEntry, len(keys))
+	for _, key := range keys {
+		entries[key] = sarama.IncrementalAlterConfigsEntry{
+			Operation: sarama.IncrementalAlterConfigsOperationDelete,
+		}
+	}
+	return k.admin.IncrementalAlterConfig(sarama.BrokerResource, strconv.Itoa(int(brokerId)), entries, false)
+}
@@ -15,6 +15,8 @@
 package kafkaclient
 
 import (
+	"slices"
+
 	"emperror.dev/errors"
 )
 
@@ -91,3 +93,39 @@ func (k *kafkaClient) OutOfSyncReplicas() ([]int32, error) {
 	}
 	return brokerIDs, nil
 }
+
+func (k *kafkaClient) ReplicaPeers(brokerID int32) ([]int32, error) {
+	availableTopics, err := k.client.Topics()
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not fetch topics")
+	}
+	replicaPeers := make(map[int32]struct{})
+	for _, topic := range availableTopics {
+		partitions, err := k.client.Partitions(topic)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "could not fetch partition", "topic", topic)
+		}
+		for _, partition := range partitions {
+			replicas, err := k.client.Replicas(topic, partition)
+			if err != nil {
+				return nil, errors.WrapIfWithDetails(err, "could not fetch replicas", "topic", topic, "partition", partition)
+			}
+			if !slices.Contains(replicas, brokerID) {
+				continue
+			}
+			for _, replica := range replicas {
+				if replica != brokerID {
+					replicaPeers[replica] = struct{}{}
+				}
+			}
+		}
+	}
+
+	brokerIDs := make([]int32, 0, len(replicaPeers))
+	for peer := range replicaPeers {
+		brokerIDs = append(brokerIDs, peer)
+	}
+	slices.Sort(brokerIDs)
+
+	return brokerIDs, nil
+}
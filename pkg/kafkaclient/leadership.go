@@ -0,0 +1,148 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaclient
+
+import (
+	"slices"
+
+	"emperror.dev/errors"
+	"github.com/IBM/sarama"
+)
+
+// PartitionLeaderCounts returns the number of partitions led by each broker
+func (k *kafkaClient) PartitionLeaderCounts() (map[int32]int, error) {
+	metadata, err := k.describeAllTopics()
+	if err != nil {
+		return nil, err
+	}
+	return partitionLeaderCounts(metadata), nil
+}
+
+// LeadershipImbalance describes how far the leadership of the partitions is from their preferred leaders
+type LeadershipImbalance struct {
+	// Partitions is the number of partitions with a leader
+	Partitions int
+	// NonPreferredLeaderPartitions is the number of partitions led by another broker than their preferred leader
+	NonPreferredLeaderPartitions int
+	// LeaderCounts is the number of partitions led by each broker
+	LeaderCounts map[int32]int
+}
+
+// LeadershipImbalance returns the number of partitions which are not led by their preferred leader with the number of
+// partitions led by each broker
+func (k *kafkaClient) LeadershipImbalance() (LeadershipImbalance, error) {
+	metadata, err := k.describeAllTopics()
+	if err != nil {
+		return LeadershipImbalance{}, err
+	}
+	return leadershipImbalance(metadata), nil
+}
+
+// ElectPreferredLeaders elects the preferred leader, the first replica, of the partitions led by another broker while
+// their preferred leader is in sync, and returns the number of partitions whose preferred leader was elected
+func (k *kafkaClient) ElectPreferredLeaders() (int, error) {
+	metadata, err := k.describeAllTopics()
+	if err != nil {
+		return 0, err
+	}
+	partitions := preferredLeaderElectionPartitions(metadata)
+	if len(partitions) == 0 {
+		return 0, nil
+	}
+
+	results, err := k.admin.ElectLeaders(sarama.PreferredElection, partitions)
+	if err != nil {
+		return 0, errors.WrapIf(err, "could not elect preferred leaders")
+	}
+	count := 0
+	for topic, partitionResults := range results {
+		for partition, result := range partitionResults {
+			// the preferred leader may have been elected or gone out of sync since the partitions were described
+			if result.ErrorCode != sarama.ErrNoError && result.ErrorCode != sarama.ErrElectionNotNeeded &&
+				result.ErrorCode != sarama.ErrPreferredLeaderNotAvailable {
+				return count, errors.WrapIfWithDetails(result.ErrorCode, "could not elect preferred leader",
+					"topic", topic, "partition", partition)
+			}
+			if result.ErrorCode == sarama.ErrNoError {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+func (k *kafkaClient) describeAllTopics() ([]*sarama.TopicMetadata, error) {
+	topics, err := k.admin.ListTopics()
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not list topics")
+	}
+	if len(topics) == 0 {
+		return nil, nil
+	}
+	names := make([]string, 0, len(topics))
+	for name := range topics {
+		names = append(names, name)
+	}
+	metadata, err := k.admin.DescribeTopics(names)
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not describe topics")
+	}
+	return metadata, nil
+}
+
+func partitionLeaderCounts(metadata []*sarama.TopicMetadata) map[int32]int {
+	counts := make(map[int32]int)
+	for _, topic := range metadata {
+		for _, partition := range topic.Partitions {
+			if partition.Leader >= 0 {
+				counts[partition.Leader]++
+			}
+		}
+	}
+	return counts
+}
+
+func leadershipImbalance(metadata []*sarama.TopicMetadata) LeadershipImbalance {
+	imbalance := LeadershipImbalance{LeaderCounts: partitionLeaderCounts(metadata)}
+	for _, topic := range metadata {
+		for _, partition := range topic.Partitions {
+			if partition.Leader < 0 {
+				continue
+			}
+			imbalance.Partitions++
+			if len(partition.Replicas) > 0 && partition.Leader != partition.Replicas[0] {
+				imbalance.NonPreferredLeaderPartitions++
+			}
+		}
+	}
+	return imbalance
+}
+
+func preferredLeaderElectionPartitions(metadata []*sarama.TopicMetadata) map[string][]int32 {
+	partitions := make(map[string][]int32)
+	for _, topic := range metadata {
+		for _, partition := range topic.Partitions {
+			if len(partition.Replicas) == 0 {
+				continue
+			}
+			preferredLeader := partition.Replicas[0]
+			if partition.Leader != preferredLeader && slices.Contains(partition.Isr, preferredLeader) {
+				partitions[topic.Name] = append(partitions[topic.Name], partition.ID)
+			}
+		}
+	}
+	return partitions
+}
@@ -0,0 +1,55 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaclient
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartitionLeadership(t *testing.T) {
+	metadata := []*sarama.TopicMetadata{
+		{
+			Name: "orders",
+			Partitions: []*sarama.PartitionMetadata{
+				// led by its preferred leader
+				{ID: 0, Leader: 0, Replicas: []int32{0, 1}, Isr: []int32{0, 1}},
+				// preferred leader returned and caught up
+				{ID: 1, Leader: 0, Replicas: []int32{1, 0}, Isr: []int32{0, 1}},
+				// preferred leader is still out of sync
+				{ID: 2, Leader: 0, Replicas: []int32{2, 0}, Isr: []int32{0}},
+			},
+		},
+		{
+			Name: "payments",
+			Partitions: []*sarama.PartitionMetadata{
+				{ID: 0, Leader: 2, Replicas: []int32{1, 2}, Isr: []int32{2, 1}},
+				// offline partition
+				{ID: 1, Leader: -1, Replicas: []int32{1}, Isr: []int32{}},
+			},
+		},
+	}
+
+	require.Equal(t, map[int32]int{0: 3, 2: 1}, partitionLeaderCounts(metadata))
+	require.Equal(t, LeadershipImbalance{
+		Partitions:                   4,
+		NonPreferredLeaderPartitions: 3,
+		LeaderCounts:                 map[int32]int{0: 3, 2: 1},
+	}, leadershipImbalance(metadata))
+	require.Equal(t, map[string][]int32{"orders": {1}, "payments": {0}}, preferredLeaderElectionPartitions(metadata))
+}
@@ -17,6 +17,7 @@ package kafkaclient
 
 import (
 	"errors"
+	"slices"
 	"sync"
 	"time"
 
@@ -27,7 +28,8 @@ import (
 )
 
 const (
-	testTopicName = "test-topic"
+	testTopicName          = "test-topic"
+	testSmokeTestTopicName = "smoke-test-topic"
 )
 
 type mockClusterAdmin struct {
@@ -37,6 +39,7 @@ type mockClusterAdmin struct {
 	failOps    bool
 	mockTopics map[string]sarama.TopicDetail
 	mockACLs   map[sarama.Resource]*sarama.ResourceAcls
+	mockScram  map[string][]sarama.ScramMechanismType
 }
 
 // Coordinator resolves the ambiguity between sarama.ClusterAdmin.Coordinator and sarama.Client.Coordinator
@@ -54,6 +57,7 @@ func newEmptyMockClusterAdmin(failOps bool) *mockClusterAdmin {
 	return &mockClusterAdmin{
 		mockTopics: make(map[string]sarama.TopicDetail, 0),
 		mockACLs:   make(map[sarama.Resource]*sarama.ResourceAcls, 0),
+		mockScram:  make(map[string][]sarama.ScramMechanismType, 0),
 		failOps:    failOps,
 	}
 }
@@ -114,6 +118,13 @@ func (m *mockClusterAdmin) ListTopics() (map[string]sarama.TopicDetail, error) {
 	return shallowCopy(m.mockTopics), nil
 }
 
+func (m *mockClusterAdmin) DescribeLogDirs(brokerIDs []int32) (map[int32][]sarama.DescribeLogDirsResponseDirMetadata, error) {
+	if m.failOps {
+		return nil, errors.New("bad describe log dirs")
+	}
+	return map[int32][]sarama.DescribeLogDirsResponseDirMetadata{}, nil
+}
+
 func (m *mockClusterAdmin) Topics() ([]string, error) {
 	m.Lock()
 	defer m.Unlock()
@@ -141,6 +152,17 @@ func (m *mockClusterAdmin) DescribeTopics(topics []string) ([]*sarama.TopicMetad
 				Err:        sarama.ErrNoError,
 			},
 		}, nil
+	case testSmokeTestTopicName:
+		return []*sarama.TopicMetadata{
+			{
+				Name: topics[0],
+				Partitions: []*sarama.PartitionMetadata{
+					{ID: 0, Leader: 0, Replicas: []int32{0}},
+					{ID: 1, Leader: 1, Replicas: []int32{1}},
+				},
+				Err: sarama.ErrNoError,
+			},
+		}, nil
 	case "not-exists":
 		return []*sarama.TopicMetadata{}, nil
 	case "with-error":
@@ -156,6 +178,27 @@ func (m *mockClusterAdmin) DescribeTopics(topics []string) ([]*sarama.TopicMetad
 	}
 }
 
+func (m *mockClusterAdmin) ListPartitionReassignments(topic string, partitions []int32) (map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus, error) {
+	if m.failOps {
+		return nil, errors.New("bad list partition reassignments")
+	}
+	if topic != testTopicName {
+		return map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus{}, nil
+	}
+	return map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus{
+		topic: {
+			0: {Replicas: []int32{0, 1, 2}, AddingReplicas: []int32{2}},
+		},
+	}, nil
+}
+
+func (m *mockClusterAdmin) AlterPartitionReassignments(topic string, assignment [][]int32) error {
+	if m.failOps {
+		return errors.New("bad alter partition reassignments")
+	}
+	return nil
+}
+
 func (m *mockClusterAdmin) CreateTopic(name string, detail *sarama.TopicDetail, validateOnly bool) error {
 	m.Lock()
 	defer m.Unlock()
@@ -192,6 +235,13 @@ func (m *mockClusterAdmin) AlterConfig(resource sarama.ConfigResourceType, topic
 	return nil
 }
 
+func (m *mockClusterAdmin) IncrementalAlterConfig(resource sarama.ConfigResourceType, topic string, entries map[string]sarama.IncrementalAlterConfigsEntry, validateOnly bool) error {
+	if m.failOps {
+		return errors.New("bad incremental alter config")
+	}
+	return nil
+}
+
 func (m *mockClusterAdmin) CreatePartitions(topic string, count int32, assn [][]int32, validateOnly bool) error {
 	return nil
 }
@@ -251,6 +301,79 @@ func (m *mockClusterAdmin) DeleteACL(filter sarama.AclFilter, validateOnly bool)
 	}
 }
 
+func (m *mockClusterAdmin) UpsertUserScramCredentials(upsert []sarama.AlterUserScramCredentialsUpsert) ([]*sarama.AlterUserScramCredentialsResult, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.failOps {
+		return nil, errors.New("bad upsert user scram credentials")
+	}
+	results := make([]*sarama.AlterUserScramCredentialsResult, 0, len(upsert))
+	for _, u := range upsert {
+		if !slices.Contains(m.mockScram[u.Name], u.Mechanism) {
+			m.mockScram[u.Name] = append(m.mockScram[u.Name], u.Mechanism)
+		}
+		results = append(results, &sarama.AlterUserScramCredentialsResult{User: u.Name})
+	}
+	return results, nil
+}
+
+func (m *mockClusterAdmin) DescribeUserScramCredentials(users []string) ([]*sarama.DescribeUserScramCredentialsResult, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.failOps {
+		return nil, errors.New("bad describe user scram credentials")
+	}
+	// all the users are described when none is given
+	if len(users) == 0 {
+		for user := range m.mockScram {
+			users = append(users, user)
+		}
+	}
+	results := make([]*sarama.DescribeUserScramCredentialsResult, 0, len(users))
+	for _, user := range users {
+		mechanisms, ok := m.mockScram[user]
+		if !ok {
+			results = append(results, &sarama.DescribeUserScramCredentialsResult{User: user, ErrorCode: errResourceNotFound})
+			continue
+		}
+		result := &sarama.DescribeUserScramCredentialsResult{User: user}
+		for _, mechanism := range mechanisms {
+			result.CredentialInfos = append(result.CredentialInfos, &sarama.UserScramCredentialsResponseInfo{
+				Mechanism:  mechanism,
+				Iterations: scramIterations,
+			})
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (m *mockClusterAdmin) DeleteUserScramCredentials(del []sarama.AlterUserScramCredentialsDelete) ([]*sarama.AlterUserScramCredentialsResult, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.failOps {
+		return nil, errors.New("bad delete user scram credentials")
+	}
+	results := make([]*sarama.AlterUserScramCredentialsResult, 0, len(del))
+	for _, d := range del {
+		result := &sarama.AlterUserScramCredentialsResult{User: d.Name}
+		idx := slices.Index(m.mockScram[d.Name], d.Mechanism)
+		if idx < 0 {
+			result.ErrorCode = errResourceNotFound
+		} else {
+			m.mockScram[d.Name] = slices.Delete(m.mockScram[d.Name], idx, idx+1)
+			if len(m.mockScram[d.Name]) == 0 {
+				delete(m.mockScram, d.Name)
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
 func (m *mockClusterAdmin) DescribeConfig(resource sarama.ConfigResource) ([]sarama.ConfigEntry, error) {
 	return []sarama.ConfigEntry{}, nil
 }
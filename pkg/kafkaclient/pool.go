@@ -0,0 +1,336 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaclient
+
+import (
+	"bytes"
+	"crypto/tls"
+	"slices"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+// maxTopicBatchSize is the maximum number of topics sent in a single batched request
+const maxTopicBatchSize = 1000
+
+// ClientPoolOptions are the options of a ClientPool
+type ClientPoolOptions struct {
+	// MaxAge is the duration a shared client is used for before it is reopened
+	MaxAge time.Duration
+	// HealthCheckInterval is the interval the brokers of a shared client are checked at when it is in use, the
+	// client is reopened when they are unreachable or have changed
+	HealthCheckInterval time.Duration
+	// BatchWindow is the window within which the topic creations and configuration changes requested through a
+	// shared client are coalesced into a single request, they are sent on their own when 0
+	BatchWindow time.Duration
+}
+
+// ClientPool is a Provider sharing an open Kafka client per KafkaCluster between its callers instead of opening a
+// connection for each of them. A shared client is reopened as soon as the listener or the TLS configuration used to
+// connect to the cluster changes, when its health check fails and after its maximum age.
+type ClientPool struct {
+	options ClientPoolOptions
+
+	mu      sync.Mutex
+	clients map[types.NamespacedName]*pooledClient
+
+	// funcs for mocking
+	clusterConfig func(client.Client, *v1beta1.KafkaCluster) (*KafkaConfig, error)
+	newClient     func(*KafkaConfig) KafkaClient
+}
+
+// NewClientPool creates an empty pool of Kafka clients
+func NewClientPool(options ClientPoolOptions) *ClientPool {
+	return &ClientPool{
+		options:       options,
+		clients:       make(map[types.NamespacedName]*pooledClient),
+		clusterConfig: ClusterConfig,
+		newClient:     New,
+	}
+}
+
+// pooledClient is a shared client, it is closed once it is retired from the pool and released by all of its users
+type pooledClient struct {
+	KafkaClient
+	opts       *KafkaConfig
+	clusterUID types.UID
+	openedAt   time.Time
+	checkedAt  time.Time
+	refs       int
+	retired    bool
+
+	creates *requestBatcher[*CreateTopicOptions]
+	configs *requestBatcher[map[string]*string]
+}
+
+// NewFromCluster returns the shared client of the cluster, the returned function releases it
+func (p *ClientPool) NewFromCluster(k8sclient client.Client, cluster *v1beta1.KafkaCluster) (KafkaClient, func(), error) {
+	opts, err := p.clusterConfig(k8sclient, cluster)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}
+	pooled, check, unused := p.acquire(key, cluster.UID, opts)
+	closeClients(unused)
+	if pooled != nil && check && !healthy(pooled.KafkaClient) {
+		log.Info("Reopening the unhealthy Kafka client of the cluster", "namespace", cluster.Namespace, "name", cluster.Name)
+		p.mu.Lock()
+		if p.clients[key] == pooled {
+			unused = p.retire(key, nil)
+		}
+		p.mu.Unlock()
+		closeClients(unused)
+		p.releaseFunc(pooled)()
+		pooled = nil
+	}
+	if pooled != nil {
+		return pooled, p.releaseFunc(pooled), nil
+	}
+
+	kafkaClient := p.newClient(opts)
+	if err = kafkaClient.Open(); err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	pooled = &pooledClient{
+		KafkaClient: kafkaClient,
+		opts:        opts,
+		clusterUID:  cluster.UID,
+		openedAt:    now,
+		checkedAt:   now,
+		refs:        1,
+	}
+	if p.options.BatchWindow > 0 {
+		pooled.creates = newRequestBatcher(p.options.BatchWindow, func(requests map[string]*CreateTopicOptions) (map[string]error, error) {
+			opts := make([]*CreateTopicOptions, 0, len(requests))
+			for _, request := range requests {
+				opts = append(opts, request)
+			}
+			return kafkaClient.CreateTopics(opts)
+		})
+		pooled.configs = newRequestBatcher(p.options.BatchWindow, kafkaClient.EnsureTopicConfigs)
+	}
+
+	p.mu.Lock()
+	// another caller may have opened a client for the cluster in the meantime
+	if existing, ok := p.clients[key]; ok && existing.clusterUID == cluster.UID && sameConnection(existing.opts, opts) {
+		existing.refs++
+		p.mu.Unlock()
+		closeClients([]*pooledClient{pooled})
+		return existing, p.releaseFunc(existing), nil
+	}
+	unused = p.retire(key, nil)
+	p.clients[key] = pooled
+	p.mu.Unlock()
+	closeClients(unused)
+	return pooled, p.releaseFunc(pooled), nil
+}
+
+// acquire returns the shared client of the cluster if it is still usable and whether it is due for a health check,
+// together with the retired clients which are not in use. The expired clients are retired.
+func (p *ClientPool) acquire(key types.NamespacedName, clusterUID types.UID, opts *KafkaConfig) (*pooledClient, bool, []*pooledClient) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var unused []*pooledClient
+	now := time.Now()
+	for k, pooled := range p.clients {
+		if now.Sub(pooled.openedAt) >= p.options.MaxAge {
+			unused = p.retire(k, unused)
+		}
+	}
+	pooled, ok := p.clients[key]
+	if !ok {
+		return nil, false, unused
+	}
+	// the cluster has been recreated with the same name or the way to connect to it has changed
+	if pooled.clusterUID != clusterUID || !sameConnection(pooled.opts, opts) {
+		return nil, false, p.retire(key, unused)
+	}
+	pooled.refs++
+	// only one of the callers checks the health of the client
+	check := now.Sub(pooled.checkedAt) >= p.options.HealthCheckInterval
+	if check {
+		pooled.checkedAt = now
+	}
+	return pooled, check, unused
+}
+
+// retire removes the client of the cluster from the pool and appends it to the unused clients when it is not in use,
+// p.mu must be held
+func (p *ClientPool) retire(key types.NamespacedName, unused []*pooledClient) []*pooledClient {
+	pooled, ok := p.clients[key]
+	if !ok {
+		return unused
+	}
+	delete(p.clients, key)
+	pooled.retired = true
+	if pooled.refs == 0 {
+		unused = append(unused, pooled)
+	}
+	return unused
+}
+
+func closeClients(clients []*pooledClient) {
+	for _, pooled := range clients {
+		if err := pooled.KafkaClient.Close(); err != nil {
+			log.Error(err, "Error closing Kafka client")
+		}
+	}
+}
+
+// healthy checks that the brokers of the client are reachable and that they have not changed since it was opened
+func healthy(kafkaClient KafkaClient) bool {
+	brokers, _, err := kafkaClient.DescribeCluster()
+	if err != nil {
+		return false
+	}
+	known := kafkaClient.Brokers()
+	if len(brokers) != len(known) {
+		return false
+	}
+	for _, broker := range brokers {
+		if addr, ok := known[broker.ID()]; !ok || addr != broker.Addr() {
+			return false
+		}
+	}
+	return true
+}
+
+// sameConnection returns whether the clients of the given options connect the same way to the same cluster
+func sameConnection(a, b *KafkaConfig) bool {
+	if a.BrokerURI != b.BrokerURI || a.UseSSL != b.UseSSL {
+		return false
+	}
+	if a.TLSConfig == nil || b.TLSConfig == nil {
+		return a.TLSConfig == b.TLSConfig
+	}
+	if !a.TLSConfig.RootCAs.Equal(b.TLSConfig.RootCAs) {
+		return false
+	}
+	return slices.EqualFunc(a.TLSConfig.Certificates, b.TLSConfig.Certificates, func(x, y tls.Certificate) bool {
+		return slices.EqualFunc(x.Certificate, y.Certificate, bytes.Equal)
+	})
+}
+
+func (p *ClientPool) releaseFunc(pooled *pooledClient) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			p.mu.Lock()
+			pooled.refs--
+			closeClient := pooled.retired && pooled.refs == 0
+			p.mu.Unlock()
+			if closeClient {
+				closeClients([]*pooledClient{pooled})
+			}
+		})
+	}
+}
+
+// Close retires all the clients of the pool, the ones in use are closed when they are released
+func (p *ClientPool) Close() {
+	p.mu.Lock()
+	var unused []*pooledClient
+	for key := range p.clients {
+		unused = p.retire(key, unused)
+	}
+	p.mu.Unlock()
+	closeClients(unused)
+}
+
+// Close does nothing, the shared client is closed by the pool once it is released by all of its users
+func (c *pooledClient) Close() error { return nil }
+
+// CreateTopic creates the topic within the next batched request of the shared client
+func (c *pooledClient) CreateTopic(opts *CreateTopicOptions) error {
+	if c.creates == nil {
+		return c.KafkaClient.CreateTopic(opts)
+	}
+	return c.creates.submit(opts.Name, opts)
+}
+
+// EnsureTopicConfig ensures the configuration of the topic within the next batched request of the shared client
+func (c *pooledClient) EnsureTopicConfig(topic string, desiredConf map[string]*string) error {
+	if c.configs == nil {
+		return c.KafkaClient.EnsureTopicConfig(topic, desiredConf)
+	}
+	return c.configs.submit(topic, desiredConf)
+}
+
+// requestBatcher coalesces the requests of the topics submitted within a window into a single call of send, which
+// returns the errors of the topics by their name
+type requestBatcher[T any] struct {
+	window time.Duration
+	send   func(map[string]T) (map[string]error, error)
+
+	mu      sync.Mutex
+	pending *requestBatch[T]
+}
+
+type requestBatch[T any] struct {
+	requests map[string]T
+	done     chan struct{}
+	errs     map[string]error
+	err      error
+}
+
+func newRequestBatcher[T any](window time.Duration, send func(map[string]T) (map[string]error, error)) *requestBatcher[T] {
+	return &requestBatcher[T]{window: window, send: send}
+}
+
+// submit adds the request of the topic to the pending batch and waits for the batch to be sent. The batch is sent
+// when the window of its first request elapses or when it is full.
+func (b *requestBatcher[T]) submit(topic string, request T) error {
+	b.mu.Lock()
+	batch := b.pending
+	if batch == nil {
+		batch = &requestBatch[T]{requests: make(map[string]T), done: make(chan struct{})}
+		b.pending = batch
+		time.AfterFunc(b.window, func() { b.flush(batch) })
+	}
+	batch.requests[topic] = request
+	full := len(batch.requests) >= maxTopicBatchSize
+	b.mu.Unlock()
+
+	if full {
+		b.flush(batch)
+	}
+	<-batch.done
+	if batch.err != nil {
+		return batch.err
+	}
+	return batch.errs[topic]
+}
+
+// flush sends the batch unless it has already been sent
+func (b *requestBatcher[T]) flush(batch *requestBatch[T]) {
+	b.mu.Lock()
+	if b.pending != batch {
+		b.mu.Unlock()
+		return
+	}
+	b.pending = nil
+	b.mu.Unlock()
+
+	batch.errs, batch.err = b.send(batch.requests)
+	close(batch.done)
+}
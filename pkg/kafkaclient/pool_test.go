@@ -0,0 +1,380 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+// batchRecordingClient records the batched topic requests it receives and fails the topics named "invalid"
+type batchRecordingClient struct {
+	KafkaClient
+	mu          sync.Mutex
+	batches     [][]string
+	single      []string
+	closed      bool
+	unreachable bool
+}
+
+func (c *batchRecordingClient) Open() error { return nil }
+
+func (c *batchRecordingClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+func (c *batchRecordingClient) DescribeCluster() ([]*sarama.Broker, int32, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.unreachable {
+		return nil, 0, errors.New("brokers are unreachable")
+	}
+	return nil, 0, nil
+}
+
+func (c *batchRecordingClient) Brokers() map[int32]string { return map[int32]string{} }
+
+func (c *batchRecordingClient) record(topics []string) map[string]error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	sort.Strings(topics)
+	c.batches = append(c.batches, topics)
+	topicErrs := make(map[string]error)
+	for _, topic := range topics {
+		if topic == "invalid" {
+			topicErrs[topic] = errors.New("invalid topic")
+		}
+	}
+	return topicErrs
+}
+
+func (c *batchRecordingClient) CreateTopics(opts []*CreateTopicOptions) (map[string]error, error) {
+	topics := make([]string, 0, len(opts))
+	for _, topic := range opts {
+		topics = append(topics, topic.Name)
+	}
+	return c.record(topics), nil
+}
+
+func (c *batchRecordingClient) EnsureTopicConfigs(configs map[string]map[string]*string) (map[string]error, error) {
+	topics := make([]string, 0, len(configs))
+	for topic := range configs {
+		topics = append(topics, topic)
+	}
+	return c.record(topics), nil
+}
+
+func (c *batchRecordingClient) CreateTopic(opts *CreateTopicOptions) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.single = append(c.single, opts.Name)
+	return nil
+}
+
+// recordingOpener opens a new batchRecordingClient on every call, connecting to the clusters on the listener port
+type recordingOpener struct {
+	mu           sync.Mutex
+	clients      []*batchRecordingClient
+	listenerPort int
+}
+
+func (o *recordingOpener) clusterConfig(_ client.Client, cluster *v1beta1.KafkaCluster) (*KafkaConfig, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return &KafkaConfig{BrokerURI: fmt.Sprintf("%s:%d", cluster.Name, o.listenerPort)}, nil
+}
+
+func (o *recordingOpener) newClient(*KafkaConfig) KafkaClient {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	kafkaClient := &batchRecordingClient{}
+	o.clients = append(o.clients, kafkaClient)
+	return kafkaClient
+}
+
+func newRecordingClientPool(opener *recordingOpener, options ClientPoolOptions) *ClientPool {
+	pool := NewClientPool(options)
+	pool.clusterConfig = opener.clusterConfig
+	pool.newClient = opener.newClient
+	return pool
+}
+
+func (c *batchRecordingClient) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+func newPoolTestCluster(name string, uid types.UID) *v1beta1.KafkaCluster {
+	return &v1beta1.KafkaCluster{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kafka", UID: uid}}
+}
+
+func TestClientPoolSharesClients(t *testing.T) {
+	opener := &recordingOpener{}
+	pool := newRecordingClientPool(opener, ClientPoolOptions{MaxAge: time.Hour, HealthCheckInterval: time.Hour})
+
+	first, releaseFirst, err := pool.NewFromCluster(nil, newPoolTestCluster("kafka", "uid"))
+	if err != nil {
+		t.Fatal("Expected no error, got:", err)
+	}
+	second, releaseSecond, _ := pool.NewFromCluster(nil, newPoolTestCluster("kafka", "uid"))
+	other, releaseOther, _ := pool.NewFromCluster(nil, newPoolTestCluster("other", "other-uid"))
+	if first != second {
+		t.Error("Expected the clients of the same cluster to be shared")
+	}
+	if first == other {
+		t.Error("Expected the clusters to get different clients")
+	}
+	if len(opener.clients) != 2 {
+		t.Errorf("Expected 2 clients to be opened, got %d", len(opener.clients))
+	}
+
+	// the callers closing the shared client do not close it
+	_ = first.Close()
+	releaseFirst()
+	releaseFirst()
+	releaseSecond()
+	releaseOther()
+	if opener.clients[0].isClosed() || opener.clients[1].isClosed() {
+		t.Error("Expected the released clients to stay open in the pool")
+	}
+
+	// the client of a cluster recreated with the same name is reopened
+	recreated, releaseRecreated, _ := pool.NewFromCluster(nil, newPoolTestCluster("kafka", "new-uid"))
+	defer releaseRecreated()
+	if recreated == first || len(opener.clients) != 3 {
+		t.Error("Expected a new client for the recreated cluster")
+	}
+	if !opener.clients[0].isClosed() {
+		t.Error("Expected the client of the deleted cluster to be closed")
+	}
+
+	pool.Close()
+	if !opener.clients[1].isClosed() {
+		t.Error("Expected the unused client to be closed with the pool")
+	}
+	if opener.clients[2].isClosed() {
+		t.Error("Expected the client in use to stay open until it is released")
+	}
+}
+
+func TestClientPoolReopensExpiredClients(t *testing.T) {
+	opener := &recordingOpener{}
+	pool := newRecordingClientPool(opener, ClientPoolOptions{HealthCheckInterval: time.Hour})
+
+	first, releaseFirst, _ := pool.NewFromCluster(nil, newPoolTestCluster("kafka", "uid"))
+	second, releaseSecond, _ := pool.NewFromCluster(nil, newPoolTestCluster("kafka", "uid"))
+	if first == second {
+		t.Error("Expected the expired client to be reopened")
+	}
+	if opener.clients[0].isClosed() {
+		t.Error("Expected the expired client to stay open while it is in use")
+	}
+	releaseFirst()
+	if !opener.clients[0].isClosed() {
+		t.Error("Expected the expired client to be closed once released")
+	}
+	releaseSecond()
+}
+
+func TestClientPoolReopensClientsOnConnectionChanges(t *testing.T) {
+	opener := &recordingOpener{listenerPort: 29092}
+	pool := newRecordingClientPool(opener, ClientPoolOptions{MaxAge: time.Hour, HealthCheckInterval: time.Hour})
+	cluster := newPoolTestCluster("kafka", "uid")
+
+	first, releaseFirst, _ := pool.NewFromCluster(nil, cluster)
+	// the internal listener of the cluster is changed
+	opener.listenerPort = 29093
+	second, releaseSecond, _ := pool.NewFromCluster(nil, cluster)
+	defer releaseSecond()
+	if first == second || len(opener.clients) != 2 {
+		t.Error("Expected the client to be reopened on the new listener")
+	}
+	releaseFirst()
+	if !opener.clients[0].isClosed() {
+		t.Error("Expected the client of the former listener to be closed once released")
+	}
+}
+
+func TestClientPoolReopensUnhealthyClients(t *testing.T) {
+	opener := &recordingOpener{}
+	pool := newRecordingClientPool(opener, ClientPoolOptions{MaxAge: time.Hour})
+	cluster := newPoolTestCluster("kafka", "uid")
+
+	first, releaseFirst, _ := pool.NewFromCluster(nil, cluster)
+	releaseFirst()
+	second, releaseSecond, _ := pool.NewFromCluster(nil, cluster)
+	releaseSecond()
+	if first != second {
+		t.Error("Expected the healthy client to be shared")
+	}
+
+	opener.clients[0].mu.Lock()
+	opener.clients[0].unreachable = true
+	opener.clients[0].mu.Unlock()
+	third, releaseThird, err := pool.NewFromCluster(nil, cluster)
+	if err != nil {
+		t.Fatal("Expected no error, got:", err)
+	}
+	defer releaseThird()
+	if third == first || len(opener.clients) != 2 {
+		t.Error("Expected the unhealthy client to be reopened")
+	}
+	if !opener.clients[0].isClosed() {
+		t.Error("Expected the unhealthy client to be closed")
+	}
+}
+
+func TestSameConnection(t *testing.T) {
+	rootCAs := x509.NewCertPool()
+	otherRootCAs := x509.NewCertPool()
+	otherRootCAs.AddCert(&x509.Certificate{Raw: []byte("ca"), RawSubject: []byte("ca")})
+	certificate := tls.Certificate{Certificate: [][]byte{[]byte("cert")}}
+	renewedCertificate := tls.Certificate{Certificate: [][]byte{[]byte("renewed-cert")}}
+
+	tests := []struct {
+		testName string
+		a        *KafkaConfig
+		b        *KafkaConfig
+		expected bool
+	}{
+		{
+			testName: "same plaintext listener",
+			a:        &KafkaConfig{BrokerURI: "kafka:29092"},
+			b:        &KafkaConfig{BrokerURI: "kafka:29092", OperationTimeout: 10},
+			expected: true,
+		},
+		{
+			testName: "different listener",
+			a:        &KafkaConfig{BrokerURI: "kafka:29092"},
+			b:        &KafkaConfig{BrokerURI: "kafka:29093"},
+			expected: false,
+		},
+		{
+			testName: "TLS enabled",
+			a:        &KafkaConfig{BrokerURI: "kafka:29092"},
+			b: &KafkaConfig{BrokerURI: "kafka:29092", UseSSL: true,
+				TLSConfig: &tls.Config{Certificates: []tls.Certificate{certificate}, RootCAs: rootCAs}},
+			expected: false,
+		},
+		{
+			testName: "same client certificate",
+			a: &KafkaConfig{BrokerURI: "kafka:29092", UseSSL: true,
+				TLSConfig: &tls.Config{Certificates: []tls.Certificate{certificate}, RootCAs: rootCAs}},
+			b: &KafkaConfig{BrokerURI: "kafka:29092", UseSSL: true,
+				TLSConfig: &tls.Config{Certificates: []tls.Certificate{certificate}, RootCAs: x509.NewCertPool()}},
+			expected: true,
+		},
+		{
+			testName: "renewed client certificate",
+			a: &KafkaConfig{BrokerURI: "kafka:29092", UseSSL: true,
+				TLSConfig: &tls.Config{Certificates: []tls.Certificate{certificate}, RootCAs: rootCAs}},
+			b: &KafkaConfig{BrokerURI: "kafka:29092", UseSSL: true,
+				TLSConfig: &tls.Config{Certificates: []tls.Certificate{renewedCertificate}, RootCAs: rootCAs}},
+			expected: false,
+		},
+		{
+			testName: "different CA",
+			a: &KafkaConfig{BrokerURI: "kafka:29092", UseSSL: true,
+				TLSConfig: &tls.Config{Certificates: []tls.Certificate{certificate}, RootCAs: rootCAs}},
+			b: &KafkaConfig{BrokerURI: "kafka:29092", UseSSL: true,
+				TLSConfig: &tls.Config{Certificates: []tls.Certificate{certificate}, RootCAs: otherRootCAs}},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			if actual := sameConnection(test.a, test.b); actual != test.expected {
+				t.Errorf("Expected %t, got %t", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestClientPoolBatchesTopicRequests(t *testing.T) {
+	opener := &recordingOpener{}
+	pool := newRecordingClientPool(opener, ClientPoolOptions{MaxAge: time.Hour, HealthCheckInterval: time.Hour,
+		BatchWindow: 200 * time.Millisecond})
+	cluster := newPoolTestCluster("kafka", "uid")
+	// the client is opened upfront, so that the reconciles share it
+	_, releaseShared, _ := pool.NewFromCluster(nil, cluster)
+	defer releaseShared()
+
+	topics := []string{"invalid", "topic-1", "topic-2", "topic-3"}
+	createErrs := make(map[string]error, len(topics))
+	configErrs := make(map[string]error, len(topics))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, topic := range topics {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			kafkaClient, release, _ := pool.NewFromCluster(nil, cluster)
+			defer release()
+			createErr := kafkaClient.CreateTopic(&CreateTopicOptions{Name: topic})
+			configErr := kafkaClient.EnsureTopicConfig(topic, map[string]*string{})
+			mu.Lock()
+			defer mu.Unlock()
+			createErrs[topic] = createErr
+			configErrs[topic] = configErr
+		}()
+	}
+	wg.Wait()
+
+	if len(opener.clients) != 1 {
+		t.Fatalf("Expected a single client to be opened, got %d", len(opener.clients))
+	}
+	expected := fmt.Sprint([][]string{topics, topics})
+	if batches := fmt.Sprint(opener.clients[0].batches); batches != expected {
+		t.Errorf("Expected the batches %s, got %s", expected, batches)
+	}
+	for _, topic := range topics {
+		if failed := createErrs[topic] != nil; failed != (topic == "invalid") {
+			t.Errorf("Unexpected creation error of topic %s: %v", topic, createErrs[topic])
+		}
+		if failed := configErrs[topic] != nil; failed != (topic == "invalid") {
+			t.Errorf("Unexpected configuration error of topic %s: %v", topic, configErrs[topic])
+		}
+	}
+
+	// the requests are sent on their own without a batch window
+	pool = newRecordingClientPool(opener, ClientPoolOptions{MaxAge: time.Hour, HealthCheckInterval: time.Hour})
+	kafkaClient, release, _ := pool.NewFromCluster(nil, cluster)
+	defer release()
+	if err := kafkaClient.CreateTopic(&CreateTopicOptions{Name: "topic-1"}); err != nil {
+		t.Error("Expected no error, got:", err)
+	}
+	if single := opener.clients[1].single; len(single) != 1 || len(opener.clients[1].batches) != 0 {
+		t.Errorf("Expected the topic to be created on its own, got %v", single)
+	}
+}
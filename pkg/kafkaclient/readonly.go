@@ -0,0 +1,104 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaclient
+
+import (
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+type readOnlyProvider struct {
+	provider Provider
+}
+
+// NewReadOnlyProvider wraps the given provider so that the clients it creates only read the state of the Kafka
+// cluster, their writes do nothing. It is used while the changes of a KafkaCluster are planned.
+func NewReadOnlyProvider(provider Provider) Provider {
+	return &readOnlyProvider{provider: provider}
+}
+
+func (p *readOnlyProvider) NewFromCluster(client client.Client, cluster *v1beta1.KafkaCluster) (KafkaClient, func(), error) {
+	kafkaClient, closeFunc, err := p.provider.NewFromCluster(client, cluster)
+	if err != nil {
+		return nil, closeFunc, err
+	}
+	return &readOnlyKafkaClient{KafkaClient: kafkaClient}, closeFunc, nil
+}
+
+// readOnlyKafkaClient passes the reads through to the wrapped client and drops the writes
+type readOnlyKafkaClient struct {
+	KafkaClient
+}
+
+func (*readOnlyKafkaClient) CreateTopic(*CreateTopicOptions) error { return nil }
+
+func (*readOnlyKafkaClient) CreateTopics([]*CreateTopicOptions) (map[string]error, error) {
+	return nil, nil
+}
+
+func (*readOnlyKafkaClient) EnsurePartitionCount(string, int32) (bool, error) { return false, nil }
+
+func (*readOnlyKafkaClient) EnsureTopicConfig(string, map[string]*string) error { return nil }
+
+func (*readOnlyKafkaClient) EnsureTopicConfigs(map[string]map[string]*string) (map[string]error, error) {
+	return nil, nil
+}
+
+func (*readOnlyKafkaClient) SetTopicConfig(string, map[string]*string) error { return nil }
+
+func (*readOnlyKafkaClient) DeleteTopic(string, bool) error { return nil }
+
+func (*readOnlyKafkaClient) ChangeReplicationFactor(string, int32) error { return nil }
+
+func (*readOnlyKafkaClient) ReassignPartitions(string, map[int32][]int32) error { return nil }
+
+func (*readOnlyKafkaClient) DeleteTopicConfig(string, []string) error { return nil }
+
+func (*readOnlyKafkaClient) CreateUserACLs(v1alpha1.KafkaAccessType, v1alpha1.KafkaPatternType, string, string) error {
+	return nil
+}
+
+func (*readOnlyKafkaClient) DeleteUserACLs(string, v1alpha1.KafkaPatternType) error { return nil }
+
+func (*readOnlyKafkaClient) CreateACLBindings([]ACLBinding) error { return nil }
+
+func (*readOnlyKafkaClient) DeleteACLBindings([]ACLBinding) error { return nil }
+
+func (*readOnlyKafkaClient) UpsertUserScramCredential(string, v1alpha1.UserAuthenticationType, []byte) error {
+	return nil
+}
+
+func (*readOnlyKafkaClient) DeleteUserScramCredential(string, v1alpha1.UserAuthenticationType) error {
+	return nil
+}
+
+func (*readOnlyKafkaClient) ElectPreferredLeaders() (int, error) { return 0, nil }
+
+func (*readOnlyKafkaClient) AlterPerBrokerConfig(int32, map[string]*string, bool) error { return nil }
+
+func (*readOnlyKafkaClient) SetPerBrokerConfig(int32, map[string]*string) error { return nil }
+
+func (*readOnlyKafkaClient) DeletePerBrokerConfig(int32, []string) error { return nil }
+
+func (*readOnlyKafkaClient) AlterClusterWideConfig(map[string]*string, bool) error { return nil }
+
+func (*readOnlyKafkaClient) ProduceConsumeSmokeTest(string, []int32, time.Duration) (map[int32]error, error) {
+	return nil, nil
+}
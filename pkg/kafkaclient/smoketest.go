@@ -0,0 +1,169 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaclient
+
+import (
+	"fmt"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/IBM/sarama"
+
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+)
+
+const (
+	smokeTestMessageTemplate = "koperator smoke test message for broker %d"
+	// keep the smoke test messages only for a short time, they are never read after the verification
+	smokeTestTopicRetentionMs = "3600000"
+	// the partitions of the smoke test topic have a single replica, they must accept writes regardless of the
+	// min.insync.replicas of the cluster
+	smokeTestTopicMinInSyncReplicas = "1"
+)
+
+// ProduceConsumeSmokeTest produces a message to every given broker and consumes it back. The messages are sent to the
+// partitions of the smoke test topic each broker is the single replica of, hence every broker is addressed directly.
+// The returned map holds the error of the brokers which failed to serve the traffic.
+func (k *kafkaClient) ProduceConsumeSmokeTest(topic string, brokerIDs []int32, timeout time.Duration) (map[int32]error, error) {
+	partitions, err := k.ensureSmokeTestTopic(topic, brokerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	config := k.getSaramaConfig()
+	config.Producer.Return.Successes = true
+	// the leader is the single replica of the partition, its acknowledgement does not depend on the min.insync.replicas
+	// of the topics created by earlier versions
+	config.Producer.RequiredAcks = sarama.WaitForLocal
+	config.Producer.Partitioner = sarama.NewManualPartitioner
+	config.Producer.Timeout = timeout
+	config.Producer.Retry.Max = 0
+	config.Consumer.Return.Errors = true
+
+	producer, err := k.newSyncProducer([]string{k.opts.BrokerURI}, config)
+	if err != nil {
+		return nil, errorfactory.New(errorfactory.BrokersUnreachable{}, err, "could not create smoke test producer")
+	}
+	defer func() { _ = producer.Close() }()
+
+	consumer, err := k.newConsumer([]string{k.opts.BrokerURI}, config)
+	if err != nil {
+		return nil, errorfactory.New(errorfactory.BrokersUnreachable{}, err, "could not create smoke test consumer")
+	}
+	defer func() { _ = consumer.Close() }()
+
+	failures := make(map[int32]error)
+	for _, brokerID := range brokerIDs {
+		if err = produceConsume(producer, consumer, topic, partitions[brokerID], brokerID, timeout); err != nil {
+			failures[brokerID] = err
+		}
+	}
+	return failures, nil
+}
+
+func produceConsume(producer sarama.SyncProducer, consumer sarama.Consumer, topic string, partition, brokerID int32, timeout time.Duration) error {
+	payload := fmt.Sprintf(smokeTestMessageTemplate, brokerID)
+	_, offset, err := producer.SendMessage(&sarama.ProducerMessage{
+		Topic:     topic,
+		Partition: partition,
+		Value:     sarama.StringEncoder(payload),
+	})
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not produce smoke test message", "partition", partition)
+	}
+
+	partitionConsumer, err := consumer.ConsumePartition(topic, partition, offset)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not consume smoke test message", "partition", partition)
+	}
+	defer func() { _ = partitionConsumer.Close() }()
+
+	select {
+	case msg := <-partitionConsumer.Messages():
+		if string(msg.Value) != payload {
+			return errors.NewWithDetails("unexpected smoke test message consumed", "partition", partition, "offset", msg.Offset)
+		}
+		return nil
+	case consumerErr := <-partitionConsumer.Errors():
+		return errors.WrapIfWithDetails(consumerErr, "could not consume smoke test message", "partition", partition)
+	case <-time.After(timeout):
+		return errors.NewWithDetails("timed out waiting for the smoke test message", "partition", partition, "timeout", timeout)
+	}
+}
+
+// ensureSmokeTestTopic makes sure the smoke test topic has a single replica partition on each of the given brokers
+// and returns the partition hosted by each broker
+func (k *kafkaClient) ensureSmokeTestTopic(topic string, brokerIDs []int32) (map[int32]int32, error) {
+	partitions := make(map[int32]int32, len(brokerIDs))
+
+	metas, err := k.admin.DescribeTopics([]string{topic})
+	if err != nil {
+		return nil, errorfactory.New(errorfactory.BrokersRequestError{}, err, "could not describe smoke test topic", "topic", topic)
+	}
+	var meta *sarama.TopicMetadata
+	if len(metas) > 0 {
+		switch metas[0].Err {
+		case sarama.ErrNoError:
+			meta = metas[0]
+		case sarama.ErrUnknownTopicOrPartition:
+		default:
+			return nil, errorfactory.New(errorfactory.BrokersRequestError{}, metas[0].Err, "could not describe smoke test topic", "topic", topic)
+		}
+	}
+
+	if meta == nil || len(meta.Partitions) == 0 {
+		assignment := make(map[int32][]int32, len(brokerIDs))
+		for i, brokerID := range brokerIDs {
+			assignment[int32(i)] = []int32{brokerID}
+			partitions[brokerID] = int32(i)
+		}
+		retentionMs, minInSyncReplicas := smokeTestTopicRetentionMs, smokeTestTopicMinInSyncReplicas
+		if err = k.admin.CreateTopic(topic, &sarama.TopicDetail{
+			NumPartitions:     -1,
+			ReplicationFactor: -1,
+			ReplicaAssignment: assignment,
+			ConfigEntries: map[string]*string{
+				"retention.ms":        &retentionMs,
+				"min.insync.replicas": &minInSyncReplicas,
+			},
+		}, false); err != nil {
+			return nil, errorfactory.New(errorfactory.BrokersRequestError{}, err, "could not create smoke test topic", "topic", topic)
+		}
+		return partitions, nil
+	}
+
+	for _, partition := range meta.Partitions {
+		if len(partition.Replicas) == 1 {
+			partitions[partition.Replicas[0]] = partition.ID
+		}
+	}
+
+	// add a partition for the brokers which do not host any yet, e.g. after an upscale
+	var newAssignment [][]int32
+	partitionCount := int32(len(meta.Partitions))
+	for _, brokerID := range brokerIDs {
+		if _, ok := partitions[brokerID]; !ok {
+			newAssignment = append(newAssignment, []int32{brokerID})
+			partitions[brokerID] = partitionCount + int32(len(newAssignment)-1)
+		}
+	}
+	if len(newAssignment) > 0 {
+		if err = k.admin.CreatePartitions(topic, partitionCount+int32(len(newAssignment)), newAssignment, false); err != nil {
+			return nil, errorfactory.New(errorfactory.BrokersRequestError{}, err, "could not add partitions to smoke test topic", "topic", topic)
+		}
+	}
+	return partitions, nil
+}
@@ -0,0 +1,101 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaclient
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+)
+
+func TestEnsureSmokeTestTopic(t *testing.T) {
+	client := newOpenedMockClient()
+
+	// the topic is created with a partition on each broker
+	partitions, err := client.ensureSmokeTestTopic("new-smoke-test-topic", []int32{0, 1, 2})
+	if err != nil {
+		t.Error("Expected no error, got:", err)
+	}
+	if expected := map[int32]int32{0: 0, 1: 1, 2: 2}; !reflect.DeepEqual(partitions, expected) {
+		t.Errorf("Expected partitions %v, got: %v", expected, partitions)
+	}
+	detail, ok := client.admin.(*mockClusterAdmin).mockTopics["new-smoke-test-topic"]
+	if !ok {
+		t.Fatal("Expected smoke test topic to be created")
+	}
+	if expected := map[int32][]int32{0: {0}, 1: {1}, 2: {2}}; !reflect.DeepEqual(detail.ReplicaAssignment, expected) {
+		t.Errorf("Expected replica assignment %v, got: %v", expected, detail.ReplicaAssignment)
+	}
+	// the single replica partitions accept writes regardless of the min.insync.replicas of the cluster
+	if minInSyncReplicas := detail.ConfigEntries["min.insync.replicas"]; minInSyncReplicas == nil || *minInSyncReplicas != "1" {
+		t.Errorf("Expected min.insync.replicas 1, got: %v", minInSyncReplicas)
+	}
+
+	// a partition is added for the broker not hosting any yet
+	partitions, err = client.ensureSmokeTestTopic(testSmokeTestTopicName, []int32{1, 0, 4})
+	if err != nil {
+		t.Error("Expected no error, got:", err)
+	}
+	if expected := map[int32]int32{0: 0, 1: 1, 4: 2}; !reflect.DeepEqual(partitions, expected) {
+		t.Errorf("Expected partitions %v, got: %v", expected, partitions)
+	}
+
+	client.admin, _ = newMockClusterAdminFailOps([]string{}, sarama.NewConfig())
+	if _, err = client.ensureSmokeTestTopic(testSmokeTestTopicName, []int32{0}); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func TestProduceConsumeSmokeTest(t *testing.T) {
+	client := newOpenedMockClient()
+
+	config := mocks.NewTestConfig()
+	config.Producer.Partitioner = sarama.NewManualPartitioner
+	producer := mocks.NewSyncProducer(t, config)
+	consumer := mocks.NewConsumer(t, config)
+	client.newSyncProducer = func([]string, *sarama.Config) (sarama.SyncProducer, error) { return producer, nil }
+	client.newConsumer = func([]string, *sarama.Config) (sarama.Consumer, error) { return consumer, nil }
+
+	// broker 0 serves the message back
+	producer.ExpectSendMessageAndSucceed()
+	consumer.ExpectConsumePartition(testSmokeTestTopicName, 0, mocks.AnyOffset).
+		YieldMessage(&sarama.ConsumerMessage{Value: []byte(fmt.Sprintf(smokeTestMessageTemplate, 0))})
+	// broker 1 fails to consume
+	producer.ExpectSendMessageAndSucceed()
+	consumer.ExpectConsumePartition(testSmokeTestTopicName, 1, mocks.AnyOffset).
+		YieldError(sarama.ErrNotLeaderForPartition)
+	// broker 2 fails to produce
+	producer.ExpectSendMessageAndFail(sarama.ErrRequestTimedOut)
+
+	failures, err := client.ProduceConsumeSmokeTest(testSmokeTestTopicName, []int32{0, 1, 2}, time.Second)
+	if err != nil {
+		t.Fatal("Expected no error, got:", err)
+	}
+	if _, ok := failures[0]; ok {
+		t.Error("Expected broker 0 to pass the smoke test, got:", failures[0])
+	}
+	if !errors.Is(failures[1], sarama.ErrNotLeaderForPartition) {
+		t.Error("Expected broker 1 to fail consuming, got:", failures[1])
+	}
+	if !errors.Is(failures[2], sarama.ErrRequestTimedOut) {
+		t.Error("Expected broker 2 to fail producing, got:", failures[2])
+	}
+}
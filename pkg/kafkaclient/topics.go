@@ -19,6 +19,8 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
+	"sort"
 	"time"
 
 	"github.com/IBM/sarama"
@@ -71,6 +73,154 @@ func (k *kafkaClient) DescribeTopic(topic string) (meta *sarama.TopicMetadata, e
 	return
 }
 
+// ListPartitionReassignments returns the ongoing replica reassignments of the given partitions of a topic
+func (k *kafkaClient) ListPartitionReassignments(topic string, partitions []int32) (map[int32]*sarama.PartitionReplicaReassignmentsStatus, error) {
+	topicStatus, err := k.admin.ListPartitionReassignments(topic, partitions)
+	if err != nil {
+		return nil, errorfactory.New(errorfactory.BrokersRequestError{}, err, "error listing partition reassignments")
+	}
+	return topicStatus[topic], nil
+}
+
+// DescribeLogDirs returns the log directories of the given brokers together with the replicas they host
+func (k *kafkaClient) DescribeLogDirs(brokerIDs []int32) (map[int32][]sarama.DescribeLogDirsResponseDirMetadata, error) {
+	logDirs, err := k.admin.DescribeLogDirs(brokerIDs)
+	if err != nil {
+		return nil, errorfactory.New(errorfactory.BrokersRequestError{}, err, "error describing log dirs")
+	}
+	return logDirs, nil
+}
+
+// ChangeReplicationFactor starts the reassignment of the topic replicas to reach the desired replication factor
+func (k *kafkaClient) ChangeReplicationFactor(topic string, replicationFactor int32) error {
+	meta, err := k.DescribeTopic(topic)
+	if err != nil {
+		return errorfactory.New(errorfactory.BrokersRequestError{}, err, "error describing topic")
+	}
+
+	brokerIDs := make([]int32, 0, len(k.brokers))
+	for _, broker := range k.brokers {
+		brokerIDs = append(brokerIDs, broker.ID())
+	}
+
+	assignment, err := replicaAssignmentForReplicationFactor(meta.Partitions, brokerIDs, int(replicationFactor))
+	if err != nil {
+		return err
+	}
+
+	if err = k.admin.AlterPartitionReassignments(topic, assignment); err != nil {
+		return errorfactory.New(errorfactory.BrokersRequestError{}, err, "error altering partition reassignments")
+	}
+	return nil
+}
+
+// ReassignPartitions starts moving the replicas of the given partitions of a topic to the given brokers, the
+// other partitions of the topic are left untouched
+func (k *kafkaClient) ReassignPartitions(topic string, replicas map[int32][]int32) error {
+	meta, err := k.DescribeTopic(topic)
+	if err != nil {
+		return errorfactory.New(errorfactory.BrokersRequestError{}, err, "error describing topic")
+	}
+
+	assignment, err := replicaAssignmentForPartitions(meta.Partitions, replicas)
+	if err != nil {
+		return err
+	}
+
+	if err = k.admin.AlterPartitionReassignments(topic, assignment); err != nil {
+		return errorfactory.New(errorfactory.BrokersRequestError{}, err, "error altering partition reassignments")
+	}
+	return nil
+}
+
+// replicaAssignmentForPartitions computes the replica assignment holding the desired replicas of the given
+// partitions. The reassignment request is indexed by the partition id and a missing entry cancels the ongoing
+// reassignment of the partition, so the partitions which are not moved keep their current replicas.
+func replicaAssignmentForPartitions(partitions []*sarama.PartitionMetadata, replicas map[int32][]int32) ([][]int32, error) {
+	current := make(map[int32][]int32, len(partitions))
+	for _, partition := range partitions {
+		current[partition.ID] = partition.Replicas
+	}
+
+	size := 0
+	for id := range replicas {
+		if _, ok := current[id]; !ok {
+			return nil, fmt.Errorf("partition %d does not exist", id)
+		}
+		size = max(size, int(id)+1)
+	}
+
+	assignment := make([][]int32, size)
+	for id := range assignment {
+		if desired, ok := replicas[int32(id)]; ok {
+			assignment[id] = desired
+		} else {
+			assignment[id] = current[int32(id)]
+		}
+	}
+	return assignment, nil
+}
+
+// replicaAssignmentForReplicationFactor computes the replica assignment of the topic partitions for the desired
+// replication factor. The existing replicas keep their order so the preferred leaders do not change, surplus
+// replicas are removed from the end of the replica lists and new replicas are placed on the brokers hosting the
+// fewest replicas of the topic.
+func replicaAssignmentForReplicationFactor(partitions []*sarama.PartitionMetadata, brokerIDs []int32, replicationFactor int) ([][]int32, error) {
+	if replicationFactor < 1 || replicationFactor > len(brokerIDs) {
+		return nil, fmt.Errorf("replication factor %d must be between 1 and the number of brokers %d", replicationFactor, len(brokerIDs))
+	}
+
+	replicaCounts := make(map[int32]int, len(brokerIDs))
+	for _, id := range brokerIDs {
+		replicaCounts[id] = 0
+	}
+	for _, partition := range partitions {
+		for _, replica := range partition.Replicas {
+			replicaCounts[replica]++
+		}
+	}
+
+	sortedBrokerIDs := make([]int32, len(brokerIDs))
+	copy(sortedBrokerIDs, brokerIDs)
+	sort.Slice(sortedBrokerIDs, func(i, j int) bool { return sortedBrokerIDs[i] < sortedBrokerIDs[j] })
+
+	sortedPartitions := make([]*sarama.PartitionMetadata, len(partitions))
+	copy(sortedPartitions, partitions)
+	sort.Slice(sortedPartitions, func(i, j int) bool { return sortedPartitions[i].ID < sortedPartitions[j].ID })
+
+	assignment := make([][]int32, len(partitions))
+	for _, partition := range sortedPartitions {
+		if partition.ID < 0 || int(partition.ID) >= len(partitions) {
+			return nil, fmt.Errorf("unexpected partition id %d for a topic with %d partitions", partition.ID, len(partitions))
+		}
+
+		replicas := make([]int32, 0, replicationFactor)
+		for i, replica := range partition.Replicas {
+			if i < replicationFactor {
+				replicas = append(replicas, replica)
+			} else {
+				replicaCounts[replica]--
+			}
+		}
+
+		for len(replicas) < replicationFactor {
+			selected := int32(-1)
+			for _, id := range sortedBrokerIDs {
+				if slices.Contains(replicas, id) {
+					continue
+				}
+				if selected == -1 || replicaCounts[id] < replicaCounts[selected] {
+					selected = id
+				}
+			}
+			replicas = append(replicas, selected)
+			replicaCounts[selected]++
+		}
+		assignment[partition.ID] = replicas
+	}
+	return assignment, nil
+}
+
 // CreateTopic creates a topic with the given options
 func (k *kafkaClient) CreateTopic(opts *CreateTopicOptions) (err error) {
 	err = k.admin.CreateTopic(opts.Name, &sarama.TopicDetail{
@@ -84,6 +234,44 @@ func (k *kafkaClient) CreateTopic(opts *CreateTopicOptions) (err error) {
 	return
 }
 
+// CreateTopics creates the given topics with a single request to the controller broker, the errors of the topics which
+// could not be created are returned by their name
+func (k *kafkaClient) CreateTopics(opts []*CreateTopicOptions) (map[string]error, error) {
+	details := make(map[string]*sarama.TopicDetail, len(opts))
+	for _, topic := range opts {
+		details[topic.Name] = &sarama.TopicDetail{
+			NumPartitions:     topic.Partitions,
+			ReplicationFactor: topic.ReplicationFactor,
+			ConfigEntries:     topic.Config,
+		}
+	}
+
+	controller, err := k.client.Controller()
+	if err != nil {
+		return nil, errorfactory.New(errorfactory.BrokersRequestError{}, err, "could not find controller broker")
+	}
+	response, err := controller.CreateTopics(sarama.NewCreateTopicsRequest(apiVersion, details, k.timeout, false))
+	if err != nil {
+		return nil, errorfactory.New(errorfactory.BrokersRequestError{}, err, "error creating topics")
+	}
+
+	topicErrs := make(map[string]error)
+	for name := range details {
+		topicErr, ok := response.TopicErrors[name]
+		switch {
+		case !ok:
+			topicErrs[name] = errorfactory.New(errorfactory.CreateTopicError{}, sarama.ErrIncompleteResponse, "failed to create topic")
+		case !errors.Is(topicErr.Err, sarama.ErrNoError):
+			// the topics are created again by the next attempt, with the new controller
+			if errors.Is(topicErr.Err, sarama.ErrNotController) {
+				_, _ = k.client.RefreshController()
+			}
+			topicErrs[name] = errorfactory.New(errorfactory.CreateTopicError{}, topicErr, "failed to create topic")
+		}
+	}
+	return topicErrs, nil
+}
+
 // DeleteTopic deletes a topic - when wait is specified, the method will not
 // return until the topic doesn't appear in the cluster topic list.
 func (k *kafkaClient) DeleteTopic(topicName string, wait bool) error {
@@ -143,3 +331,65 @@ func (k *kafkaClient) EnsurePartitionCount(topic string, desired int32) (changed
 func (k *kafkaClient) EnsureTopicConfig(topic string, desiredConf map[string]*string) error {
 	return k.admin.AlterConfig(sarama.TopicResource, topic, desiredConf, false)
 }
+
+// EnsureTopicConfigs ensures the configuration overrides of the given topics with a single request, the errors of the
+// topics whose configuration could not be altered are returned by their name
+func (k *kafkaClient) EnsureTopicConfigs(configs map[string]map[string]*string) (map[string]error, error) {
+	request := &sarama.AlterConfigsRequest{Version: 1}
+	for topic, config := range configs {
+		request.Resources = append(request.Resources, &sarama.AlterConfigsResource{
+			Type:          sarama.TopicResource,
+			Name:          topic,
+			ConfigEntries: config,
+		})
+	}
+
+	broker := k.client.LeastLoadedBroker()
+	if broker == nil {
+		return nil, errorfactory.New(errorfactory.BrokersRequestError{}, errors.New("no available broker"), "could not find a broker")
+	}
+	_ = broker.Open(k.client.Config())
+	response, err := broker.AlterConfigs(request)
+	if err != nil {
+		return nil, errorfactory.New(errorfactory.BrokersRequestError{}, err, "error altering topic configs")
+	}
+
+	topicErrs := make(map[string]error)
+	for topic := range configs {
+		topicErrs[topic] = errorfactory.New(errorfactory.BrokersRequestError{}, sarama.ErrIncompleteResponse, "failed to alter topic config")
+	}
+	for _, resource := range response.Resources {
+		if _, ok := topicErrs[resource.Name]; !ok {
+			continue
+		}
+		if resource.ErrorCode != 0 {
+			topicErrs[resource.Name] = &sarama.AlterConfigError{Err: sarama.KError(resource.ErrorCode), ErrMsg: resource.ErrorMsg}
+		} else {
+			delete(topicErrs, resource.Name)
+		}
+	}
+	return topicErrs, nil
+}
+
+// SetTopicConfig sets the given topic configuration overrides, the other overrides of the topic are left untouched
+func (k *kafkaClient) SetTopicConfig(topic string, conf map[string]*string) error {
+	entries := make(map[string]sarama.IncrementalAlterConfigsEntry, len(conf))
+	for key, value := range conf {
+		entries[key] = sarama.IncrementalAlterConfigsEntry{
+			Operation: sarama.IncrementalAlterConfigsOperationSet,
+			Value:     value,
+		}
+	}
+	return k.admin.IncrementalAlterConfig(sarama.TopicResource, topic, entries, false)
+}
+
+// DeleteTopicConfig removes the given topic configuration overrides, the other overrides of the topic are left untouched
+func (k *kafkaClient) DeleteTopicConfig(topic string, keys []string) error {
+	entries := make(map[string]sarama.IncrementalAlterConfigsEntry, len(keys))
+	for _, key := range keys {
+		entries[key] = sarama.IncrementalAlterConfigsEntry{
+			Operation: sarama.IncrementalAlterConfigsOperationDelete,
+		}
+	}
+	return k.admin.IncrementalAlterConfig(sarama.TopicResource, topic, entries, false)
+}
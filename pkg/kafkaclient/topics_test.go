@@ -16,6 +16,7 @@
 package kafkaclient
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/IBM/sarama"
@@ -82,6 +83,170 @@ func TestDescribeTopic(t *testing.T) {
 	}
 }
 
+func TestListPartitionReassignments(t *testing.T) {
+	client := newOpenedMockClient()
+
+	if reassignments, err := client.ListPartitionReassignments("test-topic", []int32{0}); err != nil {
+		t.Error("Expected no error on ListPartitionReassignments, got:", err)
+	} else if len(reassignments) != 1 || len(reassignments[0].AddingReplicas) != 1 {
+		t.Error("Expected an ongoing reassignment of partition 0, got:", reassignments)
+	}
+
+	if reassignments, err := client.ListPartitionReassignments("other", []int32{0}); err != nil {
+		t.Error("Expected no error on ListPartitionReassignments, got:", err)
+	} else if len(reassignments) != 0 {
+		t.Error("Expected no ongoing reassignments, got:", reassignments)
+	}
+
+	client.admin, _ = newMockClusterAdminFailOps([]string{}, sarama.NewConfig())
+	if _, err := client.ListPartitionReassignments("test-topic", []int32{0}); err == nil {
+		t.Error("Expected error on ListPartitionReassignments, got nil")
+	}
+}
+
+func TestChangeReplicationFactor(t *testing.T) {
+	client := newOpenedMockClient()
+
+	if err := client.ChangeReplicationFactor("test-topic", 1); err != nil {
+		t.Error("Expected no error on ChangeReplicationFactor, got:", err)
+	}
+
+	if err := client.ChangeReplicationFactor("test-topic", 2); err == nil {
+		t.Error("Expected error for replication factor larger than the number of brokers, got nil")
+	}
+
+	client.admin, _ = newMockClusterAdminFailOps([]string{}, sarama.NewConfig())
+	if err := client.ChangeReplicationFactor("test-topic", 1); err == nil {
+		t.Error("Expected error on ChangeReplicationFactor, got nil")
+	}
+}
+
+func TestReplicaAssignmentForReplicationFactor(t *testing.T) {
+	partitions := []*sarama.PartitionMetadata{
+		{ID: 1, Replicas: []int32{1, 2}},
+		{ID: 0, Replicas: []int32{0, 1}},
+		{ID: 2, Replicas: []int32{2, 0}},
+	}
+	brokerIDs := []int32{3, 2, 1, 0}
+
+	testCases := []struct {
+		testName          string
+		replicationFactor int
+		expected          [][]int32
+		expectedErr       bool
+	}{
+		{
+			testName:          "increase replication factor",
+			replicationFactor: 3,
+			expected:          [][]int32{{0, 1, 3}, {1, 2, 3}, {2, 0, 1}},
+		},
+		{
+			testName:          "increase replication factor to the number of brokers",
+			replicationFactor: 4,
+			expected:          [][]int32{{0, 1, 3, 2}, {1, 2, 3, 0}, {2, 0, 1, 3}},
+		},
+		{
+			testName:          "decrease replication factor keeps the preferred leaders",
+			replicationFactor: 1,
+			expected:          [][]int32{{0}, {1}, {2}},
+		},
+		{
+			testName:          "unchanged replication factor",
+			replicationFactor: 2,
+			expected:          [][]int32{{0, 1}, {1, 2}, {2, 0}},
+		},
+		{
+			testName:          "replication factor larger than the number of brokers",
+			replicationFactor: 5,
+			expectedErr:       true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			assignment, err := replicaAssignmentForReplicationFactor(partitions, brokerIDs, testCase.replicationFactor)
+			if testCase.expectedErr {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Error("Expected no error, got:", err)
+			}
+			if !reflect.DeepEqual(assignment, testCase.expected) {
+				t.Error("Expected:", testCase.expected, "Got:", assignment)
+			}
+		})
+	}
+}
+
+func TestReassignPartitions(t *testing.T) {
+	client := newOpenedMockClient()
+
+	if err := client.ReassignPartitions("test-topic", map[int32][]int32{0: {0}}); err != nil {
+		t.Error("Expected no error on ReassignPartitions, got:", err)
+	}
+
+	if err := client.ReassignPartitions("test-topic", map[int32][]int32{5: {0}}); err == nil {
+		t.Error("Expected error for a partition which does not exist, got nil")
+	}
+
+	client.admin, _ = newMockClusterAdminFailOps([]string{}, sarama.NewConfig())
+	if err := client.ReassignPartitions("test-topic", map[int32][]int32{0: {0}}); err == nil {
+		t.Error("Expected error on ReassignPartitions, got nil")
+	}
+}
+
+func TestReplicaAssignmentForPartitions(t *testing.T) {
+	partitions := []*sarama.PartitionMetadata{
+		{ID: 1, Replicas: []int32{1, 2}},
+		{ID: 0, Replicas: []int32{0, 1}},
+		{ID: 2, Replicas: []int32{2, 0}},
+	}
+
+	testCases := []struct {
+		testName    string
+		replicas    map[int32][]int32
+		expected    [][]int32
+		expectedErr bool
+	}{
+		{
+			testName: "move the first partition",
+			replicas: map[int32][]int32{0: {3, 1}},
+			expected: [][]int32{{3, 1}},
+		},
+		{
+			testName: "partitions which are not moved keep their replicas",
+			replicas: map[int32][]int32{2: {3, 0}},
+			expected: [][]int32{{0, 1}, {1, 2}, {3, 0}},
+		},
+		{
+			testName:    "partition does not exist",
+			replicas:    map[int32][]int32{3: {0, 1}},
+			expectedErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			assignment, err := replicaAssignmentForPartitions(partitions, testCase.replicas)
+			if testCase.expectedErr {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Error("Expected no error, got:", err)
+			}
+			if !reflect.DeepEqual(assignment, testCase.expected) {
+				t.Error("Expected:", testCase.expected, "Got:", assignment)
+			}
+		})
+	}
+}
+
 func TestCreateTopic(t *testing.T) {
 	client := newOpenedMockClient()
 	if err := client.CreateTopic(&CreateTopicOptions{
@@ -102,6 +267,77 @@ func TestCreateTopic(t *testing.T) {
 	}
 }
 
+// newMockBrokerClient returns a client sending its requests to a sarama mock broker, which is also the controller
+func newMockBrokerClient(t *testing.T, handlers map[string]sarama.MockResponse) *kafkaClient {
+	broker := sarama.NewMockBroker(t, 1)
+	t.Cleanup(broker.Close)
+	handlers["ApiVersionsRequest"] = sarama.NewMockApiVersionsResponse(t)
+	handlers["MetadataRequest"] = sarama.NewMockMetadataResponse(t).
+		SetBroker(broker.Addr(), broker.BrokerID()).
+		SetController(broker.BrokerID())
+	broker.SetHandlerByMap(handlers)
+
+	config := sarama.NewConfig()
+	config.Version = apiVersion
+	saramaClient, err := sarama.NewClient([]string{broker.Addr()}, config)
+	if err != nil {
+		t.Fatal("Expected no error, got:", err)
+	}
+	t.Cleanup(func() { _ = saramaClient.Close() })
+
+	client := newMockClient()
+	client.client = saramaClient
+	return client
+}
+
+func TestCreateTopics(t *testing.T) {
+	client := newMockBrokerClient(t, map[string]sarama.MockResponse{
+		"CreateTopicsRequest": sarama.NewMockCreateTopicsResponse(t),
+	})
+
+	topicErrs, err := client.CreateTopics([]*CreateTopicOptions{
+		{Name: "new-topic", Partitions: 1, ReplicationFactor: 1},
+		{Name: "other-topic", Partitions: 3, ReplicationFactor: 1},
+		// the mock broker refuses the topics with a reserved prefix
+		{Name: "_reserved-topic", Partitions: 1, ReplicationFactor: 1},
+	})
+	if err != nil {
+		t.Fatal("Expected no error, got:", err)
+	}
+	if len(topicErrs) != 1 || topicErrs["_reserved-topic"] == nil {
+		t.Error("Expected an error for _reserved-topic only, got:", topicErrs)
+	}
+}
+
+func TestEnsureTopicConfigs(t *testing.T) {
+	retention := "1000"
+	client := newMockBrokerClient(t, map[string]sarama.MockResponse{
+		"AlterConfigsRequest": sarama.NewMockAlterConfigsResponse(t),
+	})
+
+	topicErrs, err := client.EnsureTopicConfigs(map[string]map[string]*string{
+		"test-topic":  {"retention.ms": &retention},
+		"other-topic": {},
+	})
+	if err != nil {
+		t.Fatal("Expected no error, got:", err)
+	}
+	if len(topicErrs) != 0 {
+		t.Error("Expected no topic errors, got:", topicErrs)
+	}
+
+	client = newMockBrokerClient(t, map[string]sarama.MockResponse{
+		"AlterConfigsRequest": sarama.NewMockAlterConfigsResponseWithErrorCode(t),
+	})
+	topicErrs, err = client.EnsureTopicConfigs(map[string]map[string]*string{"test-topic": {}})
+	if err != nil {
+		t.Fatal("Expected no error, got:", err)
+	}
+	if topicErrs["test-topic"] == nil {
+		t.Error("Expected an error for test-topic, got nil")
+	}
+}
+
 func TestDeleteTopic(t *testing.T) {
 	client := newOpenedMockClient()
 	if err := client.CreateTopic(&CreateTopicOptions{
@@ -133,6 +369,29 @@ func TestEnsureTopicConfig(t *testing.T) {
 	}
 }
 
+func TestSetTopicConfig(t *testing.T) {
+	minISR := "2"
+	client := newOpenedMockClient()
+	if err := client.SetTopicConfig("test-topic", map[string]*string{"min.insync.replicas": &minISR}); err != nil {
+		t.Error("Expected no error, got:", err)
+	}
+	client.admin, _ = newMockClusterAdminFailOps([]string{}, sarama.NewConfig())
+	if err := client.SetTopicConfig("test-topic", map[string]*string{"min.insync.replicas": &minISR}); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func TestDeleteTopicConfig(t *testing.T) {
+	client := newOpenedMockClient()
+	if err := client.DeleteTopicConfig("test-topic", []string{"min.insync.replicas"}); err != nil {
+		t.Error("Expected no error, got:", err)
+	}
+	client.admin, _ = newMockClusterAdminFailOps([]string{}, sarama.NewConfig())
+	if err := client.DeleteTopicConfig("test-topic", []string{"min.insync.replicas"}); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
 func TestEnsurePartitionCount(t *testing.T) {
 	client := newOpenedMockClient()
 	if changed, err := client.EnsurePartitionCount("test-topic", 1); err != nil {
@@ -16,6 +16,7 @@
 package kafkaclient
 
 import (
+	"crypto/rand"
 	"fmt"
 
 	"github.com/IBM/sarama"
@@ -40,6 +41,123 @@ func AclPatternTypeMapping(patternType v1alpha1.KafkaPatternType) sarama.AclReso
 	}
 }
 
+const (
+	// scramIterations is the iteration count used to salt SCRAM passwords, the minimum Kafka accepts for SCRAM-SHA-512
+	scramIterations = 4096
+	scramSaltLength = 32
+	// errResourceNotFound is the RESOURCE_NOT_FOUND Kafka error returned for users without SCRAM credentials,
+	// sarama does not define it
+	errResourceNotFound sarama.KError = 91
+)
+
+// ScramMechanismMapping maps v1alpha1.UserAuthenticationType to sarama.ScramMechanismType
+func ScramMechanismMapping(authType v1alpha1.UserAuthenticationType) sarama.ScramMechanismType {
+	switch authType {
+	case v1alpha1.UserAuthenticationTypeSCRAMSHA256:
+		return sarama.SCRAM_MECHANISM_SHA_256
+	case v1alpha1.UserAuthenticationTypeSCRAMSHA512:
+		return sarama.SCRAM_MECHANISM_SHA_512
+	default:
+		return sarama.SCRAM_MECHANISM_UNKNOWN
+	}
+}
+
+// UpsertUserScramCredential creates or updates the SCRAM credential of the given user with the given password
+func (k *kafkaClient) UpsertUserScramCredential(user string, authType v1alpha1.UserAuthenticationType, password []byte) error {
+	mechanism := ScramMechanismMapping(authType)
+	if mechanism == sarama.SCRAM_MECHANISM_UNKNOWN {
+		return errorfactory.New(errorfactory.InternalError{}, fmt.Errorf("unknown type: %s", authType), "unrecognized SCRAM mechanism")
+	}
+	salt := make([]byte, scramSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return errorfactory.New(errorfactory.InternalError{}, err, "could not generate SCRAM salt")
+	}
+	results, err := k.admin.UpsertUserScramCredentials([]sarama.AlterUserScramCredentialsUpsert{
+		{
+			Name:       user,
+			Mechanism:  mechanism,
+			Iterations: scramIterations,
+			Salt:       salt,
+			Password:   password,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if result.ErrorCode != sarama.ErrNoError {
+			return result.ErrorCode
+		}
+	}
+	return nil
+}
+
+// UserScramCredentialExists returns true if the given user has a SCRAM credential for the given mechanism
+func (k *kafkaClient) UserScramCredentialExists(user string, authType v1alpha1.UserAuthenticationType) (bool, error) {
+	mechanism := ScramMechanismMapping(authType)
+	results, err := k.admin.DescribeUserScramCredentials([]string{user})
+	if err != nil {
+		return false, err
+	}
+	for _, result := range results {
+		switch result.ErrorCode {
+		case sarama.ErrNoError:
+		case errResourceNotFound:
+			return false, nil
+		default:
+			return false, result.ErrorCode
+		}
+		for _, info := range result.CredentialInfos {
+			if info.Mechanism == mechanism {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// DeleteUserScramCredential removes the SCRAM credential of the given user, it is a no-op if the credential does not exist
+func (k *kafkaClient) DeleteUserScramCredential(user string, authType v1alpha1.UserAuthenticationType) error {
+	results, err := k.admin.DeleteUserScramCredentials([]sarama.AlterUserScramCredentialsDelete{
+		{
+			Name:      user,
+			Mechanism: ScramMechanismMapping(authType),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if result.ErrorCode != sarama.ErrNoError && result.ErrorCode != errResourceNotFound {
+			return result.ErrorCode
+		}
+	}
+	return nil
+}
+
+// ListUserScramCredentials returns the SCRAM mechanisms of all the users having a SCRAM credential on the cluster
+func (k *kafkaClient) ListUserScramCredentials() (map[string][]v1alpha1.UserAuthenticationType, error) {
+	results, err := k.admin.DescribeUserScramCredentials(nil)
+	if err != nil {
+		return nil, err
+	}
+	users := make(map[string][]v1alpha1.UserAuthenticationType, len(results))
+	for _, result := range results {
+		if result.ErrorCode != sarama.ErrNoError {
+			return nil, result.ErrorCode
+		}
+		for _, info := range result.CredentialInfos {
+			switch info.Mechanism {
+			case sarama.SCRAM_MECHANISM_SHA_256:
+				users[result.User] = append(users[result.User], v1alpha1.UserAuthenticationTypeSCRAMSHA256)
+			case sarama.SCRAM_MECHANISM_SHA_512:
+				users[result.User] = append(users[result.User], v1alpha1.UserAuthenticationTypeSCRAMSHA512)
+			}
+		}
+	}
+	return users, nil
+}
+
 // CreateUserACLs creates Kafka ACLs for the given access type and user
 // `literal` patternType will be used if patternType == ""
 func (k *kafkaClient) CreateUserACLs(accessType v1alpha1.KafkaAccessType, patternType v1alpha1.KafkaPatternType, dn string, topic string) (err error) {
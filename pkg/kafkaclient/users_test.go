@@ -16,6 +16,7 @@
 package kafkaclient
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/IBM/sarama"
@@ -107,3 +108,63 @@ func TestDeleteUserACLs(t *testing.T) {
 		t.Error("Expected error, got nil")
 	}
 }
+
+func TestUserScramCredentials(t *testing.T) {
+	client := newOpenedMockClient()
+
+	if err := client.UpsertUserScramCredential("test-user", v1alpha1.UserAuthenticationTypeTLS, []byte("secret")); err == nil {
+		t.Error("Expected error for non SCRAM authentication type, got nil")
+	}
+
+	exists, err := client.UserScramCredentialExists("test-user", v1alpha1.UserAuthenticationTypeSCRAMSHA512)
+	if err != nil {
+		t.Error("Expected no error, got:", err)
+	}
+	if exists {
+		t.Error("Expected SCRAM credential to not exist before upsert")
+	}
+
+	if err = client.UpsertUserScramCredential("test-user", v1alpha1.UserAuthenticationTypeSCRAMSHA512, []byte("secret")); err != nil {
+		t.Error("Expected no error, got:", err)
+	}
+
+	if exists, err = client.UserScramCredentialExists("test-user", v1alpha1.UserAuthenticationTypeSCRAMSHA512); err != nil || !exists {
+		t.Error("Expected SCRAM-SHA-512 credential to exist, got:", exists, err)
+	}
+	if exists, err = client.UserScramCredentialExists("test-user", v1alpha1.UserAuthenticationTypeSCRAMSHA256); err != nil || exists {
+		t.Error("Expected SCRAM-SHA-256 credential to not exist, got:", exists, err)
+	}
+
+	users, err := client.ListUserScramCredentials()
+	if err != nil {
+		t.Error("Expected no error, got:", err)
+	}
+	if !reflect.DeepEqual(users, map[string][]v1alpha1.UserAuthenticationType{"test-user": {v1alpha1.UserAuthenticationTypeSCRAMSHA512}}) {
+		t.Error("Expected the SCRAM-SHA-512 credential of test-user to be listed, got:", users)
+	}
+
+	if err = client.DeleteUserScramCredential("test-user", v1alpha1.UserAuthenticationTypeSCRAMSHA512); err != nil {
+		t.Error("Expected no error, got:", err)
+	}
+	// deleting a missing credential is a no-op
+	if err = client.DeleteUserScramCredential("test-user", v1alpha1.UserAuthenticationTypeSCRAMSHA512); err != nil {
+		t.Error("Expected no error, got:", err)
+	}
+	if exists, err = client.UserScramCredentialExists("test-user", v1alpha1.UserAuthenticationTypeSCRAMSHA512); err != nil || exists {
+		t.Error("Expected SCRAM credential to be deleted, got:", exists, err)
+	}
+
+	client.admin, _ = newMockClusterAdminFailOps([]string{}, sarama.NewConfig())
+	if err = client.UpsertUserScramCredential("test-user", v1alpha1.UserAuthenticationTypeSCRAMSHA512, []byte("secret")); err == nil {
+		t.Error("Expected error, got nil")
+	}
+	if _, err = client.UserScramCredentialExists("test-user", v1alpha1.UserAuthenticationTypeSCRAMSHA512); err == nil {
+		t.Error("Expected error, got nil")
+	}
+	if err = client.DeleteUserScramCredential("test-user", v1alpha1.UserAuthenticationTypeSCRAMSHA512); err == nil {
+		t.Error("Expected error, got nil")
+	}
+	if _, err = client.ListUserScramCredentials(); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
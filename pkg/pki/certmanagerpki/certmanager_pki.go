@@ -103,7 +103,7 @@ func (c *certManager) ReconcilePKI(ctx context.Context, extListenerStatuses map[
 		}
 	}
 
-	return nil
+	return c.rotateKeystorePasswords(ctx)
 }
 
 func (c *certManager) kafkapki(ctx context.Context, extListenerStatuses map[string]v1beta1.ListenerStatusList) ([]runtime.Object, error) {
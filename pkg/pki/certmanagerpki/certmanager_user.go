@@ -18,6 +18,7 @@ package certmanagerpki
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"emperror.dev/errors"
@@ -49,7 +50,7 @@ func (c *certManager) ReconcileUserCertificate(
 	var err error
 	var secret *corev1.Secret
 	// See if we have an existing certificate for this user already
-	_, err = c.getUserCertificate(ctx, user)
+	existing, err := c.getUserCertificate(ctx, user)
 
 	if err != nil && apierrors.IsNotFound(err) {
 		// the certificate does not exist, let's make one
@@ -66,6 +67,12 @@ func (c *certManager) ReconcileUserCertificate(
 	} else if err != nil {
 		// API failure, requeue
 		return nil, errorfactory.New(errorfactory.APIFailure{}, err, "failed looking up user certificate")
+	} else if len(user.Spec.DNSNames) > 0 && !slices.Equal(existing.Spec.DNSNames, user.Spec.DNSNames) {
+		// cert-manager reissues the certificate once its DNS names change, e.g. when brokers are added
+		existing.Spec.DNSNames = user.Spec.DNSNames
+		if err = c.client.Update(ctx, existing); err != nil {
+			return nil, errorfactory.New(errorfactory.APIFailure{}, err, "could not update the DNS names of user certificate")
+		}
 	}
 
 	// Get the secret created from the certificate
@@ -96,9 +103,16 @@ func (c *certManager) injectJKSPassword(ctx context.Context, user *v1alpha1.Kafk
 		},
 		Data: map[string][]byte{},
 	}
-	secret, err = certutil.EnsureSecretPassJKS(secret)
-	if err != nil {
-		return errorfactory.New(errorfactory.InternalError{}, err, "could not inject secret with jks password")
+	// The keystore passwords of the broker and the controller users are generated according to the password policy
+	if policy := c.keystorePasswordPolicy(); policy != nil && c.isClusterKeystoreSecret(secret.Name, secret.Namespace) {
+		if _, err = c.setKeystorePassword(ctx, secret, policy); err != nil {
+			return err
+		}
+	} else {
+		secret, err = certutil.EnsureSecretPassJKS(secret)
+		if err != nil {
+			return errorfactory.New(errorfactory.InternalError{}, err, "could not inject secret with jks password")
+		}
 	}
 	if err = c.client.Create(ctx, secret); err != nil {
 		return errorfactory.New(errorfactory.APIFailure{}, err, "could not create secret with jks password")
@@ -112,6 +112,19 @@ func TestReconcileUserCertificate(t *testing.T) {
 		t.Error("Expected no error, got:", err)
 	}
 
+	// Test DNS names update
+	user = newMockUser()
+	user.Spec.DNSNames = []string{"*.kafka.example.com", "broker-0.kafka.example.com"}
+
+	if _, err := manager.ReconcileUserCertificate(ctx, user, scheme.Scheme, clusterDomain); err != nil {
+		t.Error("Expected no error, got:", err)
+	}
+	if cert, err := manager.getUserCertificate(ctx, user); err != nil {
+		t.Error("Expected no error, got:", err)
+	} else if !reflect.DeepEqual(cert.Spec.DNSNames, user.Spec.DNSNames) {
+		t.Errorf("Expected DNS names %v, got: %v", user.Spec.DNSNames, cert.Spec.DNSNames)
+	}
+
 	// Test error conditions
 	manager, err = newMock(newMockCluster())
 	if err != nil {
@@ -0,0 +1,170 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certmanagerpki
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	certutil "github.com/banzaicloud/koperator/pkg/util/cert"
+	"github.com/banzaicloud/koperator/pkg/util/keystore"
+	pkicommon "github.com/banzaicloud/koperator/pkg/util/pki"
+)
+
+// keystorePasswordPolicy returns the password policy of the keystores managed for the cluster, nil if there is none
+func (c *certManager) keystorePasswordPolicy() *v1beta1.KeystorePasswordPolicy {
+	if c.cluster == nil {
+		return nil
+	}
+	return c.cluster.Spec.ListenersConfig.SSLSecrets.GetPasswordPolicy()
+}
+
+// isClusterKeystoreSecret returns true for the secrets of the broker and the controller users of the cluster
+func (c *certManager) isClusterKeystoreSecret(name, namespace string) bool {
+	if c.cluster == nil || namespace != c.cluster.Namespace {
+		return false
+	}
+	return name == fmt.Sprintf(pkicommon.BrokerServerCertTemplate, c.cluster.Name) ||
+		name == fmt.Sprintf(pkicommon.BrokerControllerTemplate, c.cluster.Name)
+}
+
+// setKeystorePassword generates a new keystore password with the generator of the policy and sets it in the secret
+func (c *certManager) setKeystorePassword(ctx context.Context, secret *corev1.Secret, policy *v1beta1.KeystorePasswordPolicy) ([]byte, error) {
+	generator, err := keystore.NewPasswordGenerator(c.client, c.cluster.Namespace, policy)
+	if err != nil {
+		return nil, errorfactory.New(errorfactory.InternalError{}, err, "invalid keystore password policy")
+	}
+	password, err := generator.Generate(ctx)
+	if err != nil {
+		return nil, errorfactory.New(errorfactory.ResourceNotReady{}, err, "could not generate keystore password")
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[v1alpha1.PasswordKey] = password.Value
+
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[pkicommon.KeystorePasswordRotatedAtAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	if password.Wrapped != "" {
+		secret.Annotations[pkicommon.KeystorePasswordWrappedAnnotation] = password.Wrapped
+	} else {
+		delete(secret.Annotations, pkicommon.KeystorePasswordWrappedAnnotation)
+	}
+	return password.Value, nil
+}
+
+// rotateKeystorePasswords rotates the passwords of the keystores of the broker and the controller users when it is
+// due according to the password policy of the cluster. The keystore and the truststore are re-encrypted with the new
+// password and stored together with it in a single update, so the secret is never left in an inconsistent state.
+func (c *certManager) rotateKeystorePasswords(ctx context.Context) error {
+	policy := c.keystorePasswordPolicy()
+	if policy == nil {
+		return nil
+	}
+	log := logr.FromContextOrDiscard(ctx)
+
+	for _, name := range []string{
+		fmt.Sprintf(pkicommon.BrokerServerCertTemplate, c.cluster.Name),
+		fmt.Sprintf(pkicommon.BrokerControllerTemplate, c.cluster.Name),
+	} {
+		secret := &corev1.Secret{}
+		if err := c.client.Get(ctx, types.NamespacedName{Name: name, Namespace: c.cluster.Namespace}, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return errorfactory.New(errorfactory.APIFailure{}, err, "could not get keystore secret", "secret", name)
+		}
+		// cert-manager has not populated the keystores yet
+		if certutil.CheckSSLCertSecret(secret) != nil {
+			continue
+		}
+
+		due, err := c.isKeystorePasswordRotationDue(ctx, secret, policy, time.Now())
+		if err != nil {
+			return err
+		}
+		if !due {
+			continue
+		}
+
+		if err = c.rotateKeystorePassword(ctx, secret, policy); err != nil {
+			return err
+		}
+		log.Info("keystore password rotated", "secret", name)
+	}
+	return nil
+}
+
+// isKeystorePasswordRotationDue returns true when the rotation period has elapsed since the last rotation of the
+// password, or when the password provided by the external secret has changed
+func (c *certManager) isKeystorePasswordRotationDue(ctx context.Context, secret *corev1.Secret,
+	policy *v1beta1.KeystorePasswordPolicy, now time.Time) (bool, error) {
+	if policy.GetGenerator() == v1beta1.KeystorePasswordGeneratorExternal {
+		generator, err := keystore.NewPasswordGenerator(c.client, c.cluster.Namespace, policy)
+		if err != nil {
+			return false, errorfactory.New(errorfactory.InternalError{}, err, "invalid keystore password policy")
+		}
+		password, err := generator.Generate(ctx)
+		if err != nil {
+			return false, errorfactory.New(errorfactory.ResourceNotReady{}, err, "could not get external keystore password")
+		}
+		return !bytes.Equal(password.Value, secret.Data[v1alpha1.PasswordKey]), nil
+	}
+
+	if policy.RotationPeriod == nil || policy.RotationPeriod.Duration <= 0 {
+		return false, nil
+	}
+	rotatedAt := secret.CreationTimestamp.Time
+	if value, ok := secret.Annotations[pkicommon.KeystorePasswordRotatedAtAnnotation]; ok {
+		if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+			rotatedAt = parsed
+		}
+	}
+	return !now.Before(rotatedAt.Add(policy.RotationPeriod.Duration)), nil
+}
+
+// rotateKeystorePassword sets a new password in the secret and re-encrypts its keystore and truststore with it
+func (c *certManager) rotateKeystorePassword(ctx context.Context, secret *corev1.Secret, policy *v1beta1.KeystorePasswordPolicy) error {
+	oldPassword := secret.Data[v1alpha1.PasswordKey]
+	newPassword, err := c.setKeystorePassword(ctx, secret, policy)
+	if err != nil {
+		return err
+	}
+	for _, key := range []string{v1alpha1.TLSJKSKeyStore, v1alpha1.TLSJKSTrustStore} {
+		store, err := certutil.ReencryptJKS(secret.Data[key], oldPassword, newPassword)
+		if err != nil {
+			return errorfactory.New(errorfactory.InternalError{}, err, "could not re-encrypt keystore", "secret", secret.Name, "key", key)
+		}
+		secret.Data[key] = store
+	}
+	if err = c.client.Update(ctx, secret); err != nil {
+		return errorfactory.New(errorfactory.APIFailure{}, err, "could not update keystore secret", "secret", secret.Name)
+	}
+	return nil
+}
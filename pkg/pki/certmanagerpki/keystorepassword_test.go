@@ -0,0 +1,127 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certmanagerpki
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	certutil "github.com/banzaicloud/koperator/pkg/util/cert"
+	pkicommon "github.com/banzaicloud/koperator/pkg/util/pki"
+)
+
+func TestRotateKeystorePasswords(t *testing.T) {
+	cert, key, _, err := certutil.GenerateTestCert()
+	require.NoError(t, err)
+	store, password, err := certutil.GenerateJKSFromByte(cert, key, cert)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		testName         string
+		policy           *v1beta1.KeystorePasswordPolicy
+		rotatedAt        time.Time
+		expectedRotation bool
+		expectedPassword string
+	}{
+		{
+			testName:  "no rotation period",
+			policy:    &v1beta1.KeystorePasswordPolicy{},
+			rotatedAt: time.Now().Add(-24 * time.Hour),
+		},
+		{
+			testName:  "rotation period has not elapsed",
+			policy:    &v1beta1.KeystorePasswordPolicy{RotationPeriod: &metav1.Duration{Duration: time.Hour}},
+			rotatedAt: time.Now().Add(-time.Minute),
+		},
+		{
+			testName:         "rotation period has elapsed",
+			policy:           &v1beta1.KeystorePasswordPolicy{RotationPeriod: &metav1.Duration{Duration: time.Hour}},
+			rotatedAt:        time.Now().Add(-2 * time.Hour),
+			expectedRotation: true,
+		},
+		{
+			testName: "external password has changed",
+			policy: &v1beta1.KeystorePasswordPolicy{
+				Generator: v1beta1.KeystorePasswordGeneratorExternal,
+				ExternalSecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "keystore-password"},
+					Key:                  "password",
+				},
+			},
+			rotatedAt:        time.Now().Add(-time.Minute),
+			expectedRotation: true,
+			expectedPassword: "external-password",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			cluster := newMockCluster()
+			cluster.Spec.ListenersConfig.SSLSecrets.PasswordPolicy = test.policy
+			manager, err := newMock(cluster)
+			require.NoError(t, err)
+
+			secretName := types.NamespacedName{Name: fmt.Sprintf(pkicommon.BrokerServerCertTemplate, cluster.Name), Namespace: cluster.Namespace}
+			rotatedAt := test.rotatedAt.UTC().Format(time.RFC3339)
+			require.NoError(t, manager.client.Create(context.Background(), &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        secretName.Name,
+					Namespace:   secretName.Namespace,
+					Annotations: map[string]string{pkicommon.KeystorePasswordRotatedAtAnnotation: rotatedAt},
+				},
+				Data: map[string][]byte{
+					v1alpha1.TLSJKSKeyStore:   store,
+					v1alpha1.TLSJKSTrustStore: store,
+					v1alpha1.PasswordKey:      password,
+				},
+			}))
+			require.NoError(t, manager.client.Create(context.Background(), &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "keystore-password", Namespace: cluster.Namespace},
+				Data:       map[string][]byte{"password": []byte("external-password")},
+			}))
+
+			require.NoError(t, manager.rotateKeystorePasswords(context.Background()))
+
+			secret := &corev1.Secret{}
+			require.NoError(t, manager.client.Get(context.Background(), secretName, secret))
+			newPassword := secret.Data[v1alpha1.PasswordKey]
+			if !test.expectedRotation {
+				require.Equal(t, password, newPassword)
+				require.Equal(t, rotatedAt, secret.Annotations[pkicommon.KeystorePasswordRotatedAtAnnotation])
+				return
+			}
+
+			require.NotEqual(t, password, newPassword)
+			if test.expectedPassword != "" {
+				require.Equal(t, test.expectedPassword, string(newPassword))
+			}
+			require.NotEqual(t, rotatedAt, secret.Annotations[pkicommon.KeystorePasswordRotatedAtAnnotation])
+			for _, key := range []string{v1alpha1.TLSJKSKeyStore, v1alpha1.TLSJKSTrustStore} {
+				_, err = certutil.ParseKeyStoreToTLSCertificate(secret.Data[key], newPassword)
+				require.NoError(t, err, key)
+			}
+		})
+	}
+}
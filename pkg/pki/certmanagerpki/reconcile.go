@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
 
 	certv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -84,7 +85,8 @@ func reconcileSecret(ctx context.Context, client client.Client, secret *corev1.S
 	return nil
 }
 
-// reconcileUser ensures a v1alpha1.KafkaUser
+// reconcileUser ensures a v1alpha1.KafkaUser, the DNS names of an existing user are updated so its certificate is
+// reissued whenever the brokers or the addresses of the external listeners change
 func reconcileUser(ctx context.Context, client client.Client, user *v1alpha1.KafkaUser) error {
 	obj := &v1alpha1.KafkaUser{}
 	var err error
@@ -94,5 +96,9 @@ func reconcileUser(ctx context.Context, client client.Client, user *v1alpha1.Kaf
 		}
 		return client.Create(ctx, user)
 	}
-	return nil
+	if slices.Equal(obj.Spec.DNSNames, user.Spec.DNSNames) {
+		return nil
+	}
+	obj.Spec.DNSNames = user.Spec.DNSNames
+	return client.Update(ctx, obj)
 }
@@ -0,0 +1,177 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pki
+
+import (
+	"context"
+	"reflect"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	"github.com/banzaicloud/koperator/pkg/util/pki"
+)
+
+// ReconcileListenerPKI provisions the server certificates of the SSL listeners with their own PKI independently of
+// the cluster-wide PKI backend: a KafkaUser is created for the listeners referencing a cert-manager issuer, the
+// keystore and truststore are generated from the validated chain for the listeners referencing a pre-provisioned
+// TLS secret. The server certificate resources of the listeners not using their own PKI anymore are removed.
+func ReconcileListenerPKI(ctx context.Context, c client.Client, cluster *v1beta1.KafkaCluster,
+	extListenerStatuses map[string]v1beta1.ListenerStatusList) error {
+	log := logr.FromContextOrDiscard(ctx)
+
+	var listeners []v1beta1.CommonListenerSpec
+	for _, iListener := range cluster.Spec.ListenersConfig.InternalListeners {
+		listeners = append(listeners, iListener.CommonListenerSpec)
+	}
+	for _, eListener := range cluster.Spec.ListenersConfig.ExternalListeners {
+		listeners = append(listeners, eListener.CommonListenerSpec)
+	}
+
+	desiredUsers, desiredSecrets := make(map[string]struct{}), make(map[string]struct{})
+	for _, listener := range listeners {
+		if listener.Type != v1beta1.SecurityProtocolSSL || listener.GetServerSSLCertSecretName() != "" ||
+			listener.ServerCertificate == nil {
+			continue
+		}
+		var err error
+		switch {
+		case listener.ServerCertificate.IssuerRef != nil:
+			desiredUsers[listener.Name] = struct{}{}
+			err = reconcileListenerUser(ctx, c, pki.ListenerUserForCluster(cluster, listener, extListenerStatuses))
+		case listener.ServerCertificate.TLSSecretName != "":
+			desiredSecrets[listener.Name] = struct{}{}
+			err = reconcileListenerKeystore(ctx, c, cluster, listener)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return removeUnusedListenerPKI(ctx, log, c, cluster, desiredUsers, desiredSecrets)
+}
+
+// reconcileListenerUser ensures the KafkaUser of the server certificate of a listener
+func reconcileListenerUser(ctx context.Context, c client.Client, user *v1alpha1.KafkaUser) error {
+	current := &v1alpha1.KafkaUser{}
+	if err := c.Get(ctx, types.NamespacedName{Name: user.Name, Namespace: user.Namespace}, current); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return errorfactory.New(errorfactory.APIFailure{}, err, "getting listener server certificate user failed", "user", user.Name)
+		}
+		if err = c.Create(ctx, user); err != nil {
+			return errorfactory.New(errorfactory.APIFailure{}, err, "creating listener server certificate user failed", "user", user.Name)
+		}
+		return nil
+	}
+	if reflect.DeepEqual(current.Spec, user.Spec) {
+		return nil
+	}
+	current.Spec = user.Spec
+	if err := c.Update(ctx, current); err != nil {
+		return errorfactory.New(errorfactory.APIFailure{}, err, "updating listener server certificate user failed", "user", user.Name)
+	}
+	return nil
+}
+
+// reconcileListenerKeystore ensures the keystore and truststore of a listener are generated from the current
+// pre-provisioned certificate chain, they are regenerated only when the chain changes
+func reconcileListenerKeystore(ctx context.Context, c client.Client, cluster *v1beta1.KafkaCluster, listener v1beta1.CommonListenerSpec) error {
+	tlsSecret := &corev1.Secret{}
+	tlsSecretName := types.NamespacedName{Name: listener.ServerCertificate.TLSSecretName, Namespace: cluster.Namespace}
+	if err := c.Get(ctx, tlsSecretName, tlsSecret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return errorfactory.New(errorfactory.ResourceNotReady{}, err, "could not find provided tls secret of the listener",
+				"listener", listener.Name, "secret", tlsSecretName.Name)
+		}
+		return errorfactory.New(errorfactory.APIFailure{}, err, "could not lookup provided tls secret of the listener",
+			"listener", listener.Name, "secret", tlsSecretName.Name)
+	}
+
+	current := &corev1.Secret{}
+	secretName := types.NamespacedName{Name: pki.ListenerServerCertSecretName(cluster.Name, listener), Namespace: cluster.Namespace}
+	err := c.Get(ctx, secretName, current)
+	switch {
+	case err == nil && current.Annotations[pki.ListenerServerCertSourceAnnotation] == pki.ListenerServerCertSource(tlsSecret):
+		return nil
+	case err != nil && !apierrors.IsNotFound(err):
+		return errorfactory.New(errorfactory.APIFailure{}, err, "getting listener keystore secret failed", "secret", secretName.Name)
+	}
+
+	desired, genErr := pki.ListenerKeystoreSecret(cluster, listener, tlsSecret)
+	if genErr != nil {
+		return genErr
+	}
+	if apierrors.IsNotFound(err) {
+		if err = c.Create(ctx, desired); err != nil {
+			return errorfactory.New(errorfactory.APIFailure{}, err, "creating listener keystore secret failed", "secret", secretName.Name)
+		}
+		return nil
+	}
+	current.Annotations = desired.Annotations
+	current.Data = desired.Data
+	if err = c.Update(ctx, current); err != nil {
+		return errorfactory.New(errorfactory.APIFailure{}, err, "updating listener keystore secret failed", "secret", secretName.Name)
+	}
+	return nil
+}
+
+// removeUnusedListenerPKI deletes the server certificate users and the generated keystore secrets of the listeners
+// which do not use them anymore
+func removeUnusedListenerPKI(ctx context.Context, log logr.Logger, c client.Client, cluster *v1beta1.KafkaCluster,
+	desiredUsers, desiredSecrets map[string]struct{}) error {
+	opts := []client.ListOption{
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(pki.LabelsForKafkaPKI(cluster.Name, cluster.Namespace)),
+		client.HasLabels{pki.ListenerLabelKey},
+	}
+	var users v1alpha1.KafkaUserList
+	if err := c.List(ctx, &users, opts...); err != nil {
+		return errorfactory.New(errorfactory.APIFailure{}, err, "listing listener server certificate users failed")
+	}
+	var secrets corev1.SecretList
+	if err := c.List(ctx, &secrets, opts...); err != nil {
+		return errorfactory.New(errorfactory.APIFailure{}, err, "listing listener keystore secrets failed")
+	}
+
+	var objects []client.Object
+	for i := range users.Items {
+		if _, ok := desiredUsers[users.Items[i].Labels[pki.ListenerLabelKey]]; !ok {
+			objects = append(objects, &users.Items[i])
+		}
+	}
+	for i := range secrets.Items {
+		if _, ok := desiredSecrets[secrets.Items[i].Labels[pki.ListenerLabelKey]]; !ok {
+			objects = append(objects, &secrets.Items[i])
+		}
+	}
+	for _, object := range objects {
+		if !object.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if err := c.Delete(ctx, object); client.IgnoreNotFound(err) != nil {
+			return errors.WrapIfWithDetails(err, "could not remove unused listener server certificate resource", "name", object.GetName())
+		}
+		log.Info("removed unused listener server certificate resource", "name", object.GetName())
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pki
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	certutil "github.com/banzaicloud/koperator/pkg/util/cert"
+	"github.com/banzaicloud/koperator/pkg/util/pki"
+)
+
+func TestReconcileListenerPKI(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	cert, key, _, err := certutil.GenerateTestCert()
+	require.NoError(t, err)
+	tlsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "external-tls", Namespace: "kafka"},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       cert,
+			corev1.TLSPrivateKeyKey: key,
+			v1alpha1.CoreCACertKey:  cert,
+		},
+	}
+	issuerRef := &cmmeta.ObjectReference{Name: "internal-issuer", Kind: "Issuer"}
+	cluster := &v1beta1.KafkaCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+		Spec: v1beta1.KafkaClusterSpec{
+			ListenersConfig: v1beta1.ListenersConfig{
+				InternalListeners: []v1beta1.InternalListenerConfig{
+					{CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolSSL,
+						ServerCertificate: &v1beta1.ListenerServerCertificate{IssuerRef: issuerRef}}},
+					{CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "controller", Type: v1beta1.SecurityProtocolSSL}},
+				},
+				ExternalListeners: []v1beta1.ExternalListenerConfig{
+					{CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "external", Type: v1beta1.SecurityProtocolSSL,
+						ServerCertificate: &v1beta1.ListenerServerCertificate{TLSSecretName: "external-tls"}}},
+				},
+			},
+		},
+	}
+	staleUser := &v1alpha1.KafkaUser{ObjectMeta: metav1.ObjectMeta{
+		Name:      "kafka-old-server-certificate",
+		Namespace: "kafka",
+		Labels:    pki.LabelsForListenerPKI("kafka", "kafka", "old"),
+	}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(staleUser).Build()
+	ctx := context.Background()
+
+	// the pre-provisioned TLS secret does not exist yet
+	err = ReconcileListenerPKI(ctx, c, cluster, nil)
+	require.True(t, errors.As(err, &errorfactory.ResourceNotReady{}))
+
+	require.NoError(t, c.Create(ctx, tlsSecret))
+	require.NoError(t, ReconcileListenerPKI(ctx, c, cluster, map[string]v1beta1.ListenerStatusList{
+		"internal": {{Address: "internal.example.com:9092"}},
+	}))
+
+	user := &v1alpha1.KafkaUser{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "kafka-internal-server-certificate", Namespace: "kafka"}, user))
+	require.Equal(t, "kafka-internal-server-certificate", user.Spec.SecretName)
+	require.Equal(t, &v1alpha1.PKIBackendSpec{IssuerRef: issuerRef, PKIBackend: string(v1beta1.PKIBackendCertManager)}, user.Spec.PKIBackendSpec)
+	require.Contains(t, user.Spec.DNSNames, "internal.example.com")
+	require.True(t, user.Spec.IncludeJKS)
+
+	var users v1alpha1.KafkaUserList
+	require.NoError(t, c.List(ctx, &users, client.InNamespace("kafka")))
+	require.Len(t, users.Items, 1)
+
+	keystore := &corev1.Secret{}
+	keystoreName := types.NamespacedName{Name: "kafka-external-server-certificate", Namespace: "kafka"}
+	require.NoError(t, c.Get(ctx, keystoreName, keystore))
+	require.NoError(t, certutil.CheckSSLCertSecret(keystore))
+	password := keystore.Data[v1alpha1.PasswordKey]
+
+	// the keystore is regenerated only when the pre-provisioned certificate changes
+	require.NoError(t, ReconcileListenerPKI(ctx, c, cluster, nil))
+	require.NoError(t, c.Get(ctx, keystoreName, keystore))
+	require.Equal(t, password, keystore.Data[v1alpha1.PasswordKey])
+
+	renewedCert, renewedKey, _, err := certutil.GenerateTestCert()
+	require.NoError(t, err)
+	tlsSecret.Data = map[string][]byte{
+		corev1.TLSCertKey:       renewedCert,
+		corev1.TLSPrivateKeyKey: renewedKey,
+		v1alpha1.CoreCACertKey:  renewedCert,
+	}
+	require.NoError(t, c.Update(ctx, tlsSecret))
+	require.NoError(t, ReconcileListenerPKI(ctx, c, cluster, nil))
+	require.NoError(t, c.Get(ctx, keystoreName, keystore))
+	require.NotEqual(t, password, keystore.Data[v1alpha1.PasswordKey])
+	require.Equal(t, pki.ListenerServerCertSource(tlsSecret), keystore.Annotations[pki.ListenerServerCertSourceAnnotation])
+
+	// the server certificate resources are removed when the listeners do not use their own PKI anymore
+	cluster.Spec.ListenersConfig.InternalListeners[0].ServerCertificate = nil
+	cluster.Spec.ListenersConfig.ExternalListeners[0].ServerCertificate = nil
+	require.NoError(t, ReconcileListenerPKI(ctx, c, cluster, nil))
+	require.NoError(t, c.List(ctx, &users, client.InNamespace("kafka")))
+	require.Empty(t, users.Items)
+	require.True(t, apierrors.IsNotFound(c.Get(ctx, keystoreName, &corev1.Secret{})))
+}
@@ -17,6 +17,7 @@ package pki
 import (
 	"context"
 	"crypto/tls"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -25,6 +26,7 @@ import (
 	"github.com/banzaicloud/koperator/api/v1beta1"
 	"github.com/banzaicloud/koperator/pkg/pki/certmanagerpki"
 	"github.com/banzaicloud/koperator/pkg/pki/k8scsrpki"
+	"github.com/banzaicloud/koperator/pkg/pki/vaultpki"
 	"github.com/banzaicloud/koperator/pkg/util/pki"
 )
 
@@ -33,20 +35,17 @@ var MockBackend = v1beta1.PKIBackend("mock")
 
 // GetPKIManager returns a PKI/User manager interface for a given cluster
 func GetPKIManager(client client.Client, cluster *v1beta1.KafkaCluster, pkiBackend v1beta1.PKIBackend) pki.Manager {
-	var backend v1beta1.PKIBackend
-	if pkiBackend == v1beta1.PKIBackendProvided {
-		backend = cluster.Spec.ListenersConfig.SSLSecrets.PKIBackend
-	} else {
-		backend = pkiBackend
-	}
 	//nolint:exhaustive
-	switch backend {
+	switch clusterPKIBackend(cluster, pkiBackend) {
 	// Use cert-manager for pki backend
 	case v1beta1.PKIBackendCertManager:
 		return certmanagerpki.New(client, cluster)
 	// Use k8s csr api for pki backend
 	case v1beta1.PKIBackendK8sCSR:
 		return k8scsrpki.New(client, cluster)
+	// Use the PKI secrets engine of Vault for pki backend
+	case v1beta1.PKIBackendVault:
+		return vaultpki.New(client, cluster)
 	// Return mock backend for testing - cannot be triggered by CR due to enum in api schema
 	case MockBackend:
 		return newMockPKIManager(client, cluster)
@@ -56,6 +55,27 @@ func GetPKIManager(client client.Client, cluster *v1beta1.KafkaCluster, pkiBacke
 	}
 }
 
+// UserCertificateRenewalDelay returns the time left until the user certificate issued by the given PKI backend has
+// to be renewed by the operator, it returns zero for the backends renewing the certificates on their own
+func UserCertificateRenewalDelay(cluster *v1beta1.KafkaCluster, pkiBackend v1beta1.PKIBackend, user *pki.UserCertificate) time.Duration {
+	if clusterPKIBackend(cluster, pkiBackend) != v1beta1.PKIBackendVault {
+		return 0
+	}
+	delay, err := vaultpki.RenewalDelay(user.Certificate)
+	if err != nil {
+		return 0
+	}
+	return delay
+}
+
+// clusterPKIBackend resolves the PKI backend set in the cluster CR for the provided backend
+func clusterPKIBackend(cluster *v1beta1.KafkaCluster, pkiBackend v1beta1.PKIBackend) v1beta1.PKIBackend {
+	if pkiBackend == v1beta1.PKIBackendProvided {
+		return cluster.Spec.ListenersConfig.SSLSecrets.PKIBackend
+	}
+	return pkiBackend
+}
+
 // Mock types and functions
 
 type mockPKIManager struct {
@@ -0,0 +1,196 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vaultpki
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+const vaultRequestTimeout = 10 * time.Second
+
+// vaultClient issues certificates from the PKI secrets engine of Vault through its HTTP API
+type vaultClient struct {
+	config     v1beta1.VaultConfig
+	httpClient *http.Client
+	token      string
+}
+
+type vaultResponse struct {
+	Auth *struct {
+		ClientToken string `json:"client_token"`
+	} `json:"auth"`
+	Data   json.RawMessage `json:"data"`
+	Errors []string        `json:"errors"`
+}
+
+// issuedCertificate is a certificate issued by the PKI secrets engine together with its private key
+type issuedCertificate struct {
+	Certificate  string   `json:"certificate"`
+	IssuingCA    string   `json:"issuing_ca"`
+	CAChain      []string `json:"ca_chain"`
+	PrivateKey   string   `json:"private_key"`
+	SerialNumber string   `json:"serial_number"`
+}
+
+// newVaultClient returns a client of Vault logged in with the auth method of the given config
+func newVaultClient(ctx context.Context, c client.Reader, namespace string, config v1beta1.VaultConfig) (*vaultClient, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if config.CABundleSecretRef != nil {
+		caBundle, err := secretKeyValue(ctx, c, namespace, *config.CABundleSecretRef)
+		if err != nil {
+			return nil, errors.WrapIf(err, "could not get the CA bundle of Vault")
+		}
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(caBundle) {
+			return nil, errors.NewWithDetails("CA bundle of Vault holds no PEM encoded certificate",
+				"secret", config.CABundleSecretRef.Name, "key", config.CABundleSecretRef.Key)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	v := &vaultClient{
+		config:     config,
+		httpClient: &http.Client{Timeout: vaultRequestTimeout, Transport: transport},
+	}
+	if err := v.login(ctx); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// login logs in to Vault with the service account token of the operator and keeps the returned client token
+func (v *vaultClient) login(ctx context.Context) error {
+	tokenPath := v.config.Auth.GetTokenPath()
+	jwt, err := os.ReadFile(tokenPath)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not read service account token", "path", tokenPath)
+	}
+	rsp, err := v.write(ctx, fmt.Sprintf("auth/%s/login", strings.Trim(v.config.Auth.GetPath(), "/")), map[string]interface{}{
+		"role": v.config.Auth.Role,
+		"jwt":  strings.TrimSpace(string(jwt)),
+	})
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not log in to Vault", "method", v.config.Auth.GetMethod(), "role", v.config.Auth.Role)
+	}
+	if rsp.Auth == nil || rsp.Auth.ClientToken == "" {
+		return errors.NewWithDetails("Vault login returned no client token", "method", v.config.Auth.GetMethod(), "role", v.config.Auth.Role)
+	}
+	v.token = rsp.Auth.ClientToken
+	return nil
+}
+
+// issue issues a certificate with a new private key for the given common name and DNS names
+func (v *vaultClient) issue(ctx context.Context, commonName string, dnsNames []string, ttl time.Duration) (*issuedCertificate, error) {
+	body := map[string]interface{}{
+		"common_name":          commonName,
+		"ttl":                  fmt.Sprintf("%ds", int64(ttl.Seconds())),
+		"exclude_cn_from_sans": true,
+		"private_key_format":   "pkcs8",
+	}
+	if len(dnsNames) > 0 {
+		body["alt_names"] = strings.Join(dnsNames, ",")
+	}
+	rsp, err := v.write(ctx, fmt.Sprintf("%s/issue/%s", strings.Trim(v.config.GetPKIPath(), "/"), v.config.Role), body)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not issue certificate", "commonName", commonName, "role", v.config.Role)
+	}
+	cert := &issuedCertificate{}
+	if err = json.Unmarshal(rsp.Data, cert); err != nil {
+		return nil, errors.WrapIf(err, "could not decode the certificate issued by Vault")
+	}
+	if cert.Certificate == "" || cert.PrivateKey == "" {
+		return nil, errors.NewWithDetails("Vault returned an invalid certificate", "commonName", commonName)
+	}
+	return cert, nil
+}
+
+// caCertificates returns the PEM encoded CA certificates of the issued certificate, the full chain if Vault returned it
+func (c *issuedCertificate) caCertificates() []byte {
+	caCerts := c.CAChain
+	if len(caCerts) == 0 {
+		caCerts = []string{c.IssuingCA}
+	}
+	var bundle bytes.Buffer
+	for _, caCert := range caCerts {
+		bundle.WriteString(strings.TrimSpace(caCert))
+		bundle.WriteByte('\n')
+	}
+	return bundle.Bytes()
+}
+
+func (v *vaultClient) write(ctx context.Context, path string, body map[string]interface{}) (*vaultResponse, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not encode Vault request")
+	}
+	url := fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(v.config.Address, "/"), path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not create Vault request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if v.token != "" {
+		req.Header.Set("X-Vault-Token", v.token)
+	}
+	if v.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.config.Namespace)
+	}
+
+	rsp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "Vault request failed", "path", path)
+	}
+	defer rsp.Body.Close()
+
+	var vaultRsp vaultResponse
+	if err = json.NewDecoder(rsp.Body).Decode(&vaultRsp); err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not decode Vault response", "path", path, "status", rsp.Status)
+	}
+	if rsp.StatusCode != http.StatusOK {
+		return nil, errors.NewWithDetails("Vault request failed", "path", path, "status", rsp.Status, "errors", strings.Join(vaultRsp.Errors, "; "))
+	}
+	return &vaultRsp, nil
+}
+
+func secretKeyValue(ctx context.Context, c client.Reader, namespace string, ref corev1.SecretKeySelector) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, secret); err != nil {
+		return nil, err
+	}
+	value := secret.Data[ref.Key]
+	if len(value) == 0 {
+		return nil, errors.NewWithDetails("key of the secret is empty", "secret", ref.Name, "key", ref.Key)
+	}
+	return value, nil
+}
@@ -0,0 +1,80 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vaultpki
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	"golang.org/x/exp/slices"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	pkicommon "github.com/banzaicloud/koperator/pkg/util/pki"
+)
+
+const missingVaultConfigErrMsg = "vault PKI backend requires spec.vaultConfig"
+
+// FinalizePKI for Vault backend auto returns because controller references handle the cleanup of the broker and
+// controller users, the certificates issued by Vault are left to expire
+func (v *vaultPKI) FinalizePKI(_ context.Context) error {
+	return nil
+}
+
+// ReconcilePKI ensures the users of the brokers and the operator, their certificates are issued from Vault like the
+// certificates of any other KafkaUser
+func (v *vaultPKI) ReconcilePKI(ctx context.Context, extListenerStatuses map[string]v1beta1.ListenerStatusList) error {
+	logger := logr.FromContextOrDiscard(ctx)
+	logger.Info("Reconciling Vault PKI")
+
+	if v.cluster.Spec.VaultConfig == nil {
+		return errorfactory.New(errorfactory.FatalReconcileError{}, errors.New("vault configuration is missing"), missingVaultConfigErrMsg)
+	}
+
+	for _, user := range []*v1alpha1.KafkaUser{
+		// Broker "user"
+		pkicommon.BrokerUserForCluster(v.cluster, extListenerStatuses),
+		// Operator user
+		pkicommon.ControllerUserForCluster(v.cluster),
+	} {
+		if err := reconcileUser(ctx, v.client, user); err != nil {
+			return errorfactory.New(errorfactory.APIFailure{}, err, "could not reconcile user", "user", user.GetName())
+		}
+	}
+	return nil
+}
+
+// reconcileUser ensures a v1alpha1.KafkaUser, the DNS names of an existing user are updated so its certificate is
+// reissued whenever the addresses of the external listeners change
+func reconcileUser(ctx context.Context, c client.Client, user *v1alpha1.KafkaUser) error {
+	current := &v1alpha1.KafkaUser{}
+	if err := c.Get(ctx, types.NamespacedName{Name: user.Name, Namespace: user.Namespace}, current); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		return c.Create(ctx, user)
+	}
+	if slices.Equal(current.Spec.DNSNames, user.Spec.DNSNames) {
+		return nil
+	}
+	current.Spec.DNSNames = user.Spec.DNSNames
+	return c.Update(ctx, current)
+}
@@ -0,0 +1,67 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vaultpki
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	pkicommon "github.com/banzaicloud/koperator/pkg/util/pki"
+)
+
+func TestReconcilePKI(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	cluster := newVaultCluster(t, "https://vault.vault.svc:8200")
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	ctx := context.Background()
+	v := New(c, cluster)
+	getUser := func(name string) *v1alpha1.KafkaUser {
+		user := &v1alpha1.KafkaUser{}
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Name: name, Namespace: "kafka"}, user))
+		return user
+	}
+	brokerUserName := pkicommon.BrokerUserForCluster(cluster, nil).Name
+
+	require.NoError(t, v.ReconcilePKI(ctx, nil))
+	brokerUser := getUser(brokerUserName)
+	require.Equal(t, fmt.Sprintf(pkicommon.BrokerServerCertTemplate, "kafka"), brokerUser.Spec.SecretName)
+	require.True(t, brokerUser.Spec.IncludeJKS)
+	controllerUser := getUser(pkicommon.ControllerUserForCluster(cluster).Name)
+	require.Equal(t, fmt.Sprintf(pkicommon.BrokerControllerTemplate, "kafka"), controllerUser.Spec.SecretName)
+
+	// the DNS names of the broker user follow the addresses of the external listeners
+	require.NoError(t, v.ReconcilePKI(ctx, map[string]v1beta1.ListenerStatusList{
+		"external": {{Name: "any-broker", Address: "kafka.example.com:9094"}},
+	}))
+	require.Contains(t, getUser(brokerUserName).Spec.DNSNames, "kafka.example.com")
+
+	require.NoError(t, v.FinalizePKI(ctx))
+
+	cluster.Spec.VaultConfig = nil
+	require.True(t, errors.As(v.ReconcilePKI(ctx, nil), &errorfactory.FatalReconcileError{}))
+}
@@ -0,0 +1,203 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vaultpki
+
+import (
+	"context"
+	"crypto/x509"
+	"time"
+
+	"emperror.dev/errors"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	certutil "github.com/banzaicloud/koperator/pkg/util/cert"
+	pkicommon "github.com/banzaicloud/koperator/pkg/util/pki"
+)
+
+// FinalizeUserCertificate for Vault backend auto returns because the certificates are short-lived, they are left to
+// expire instead of being revoked
+func (v *vaultPKI) FinalizeUserCertificate(_ context.Context, _ *v1alpha1.KafkaUser) error {
+	return nil
+}
+
+// ReconcileUserCertificate ensures a certificate issued by Vault in the secret of the user. The certificate is
+// reissued when two thirds of its validity elapsed or when the DNS names of the user changed.
+func (v *vaultPKI) ReconcileUserCertificate(
+	ctx context.Context, user *v1alpha1.KafkaUser, scheme *runtime.Scheme, _ string) (*pkicommon.UserCertificate, error) {
+	if v.cluster.Spec.VaultConfig == nil {
+		return nil, errorfactory.New(errorfactory.FatalReconcileError{}, errors.New("vault configuration is missing"), missingVaultConfigErrMsg)
+	}
+
+	secret := &corev1.Secret{}
+	err := v.client.Get(ctx, types.NamespacedName{Name: user.Spec.SecretName, Namespace: user.Namespace}, secret)
+	switch {
+	case apierrors.IsNotFound(err):
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      user.Spec.SecretName,
+				Namespace: user.Namespace,
+			},
+		}
+	case err != nil:
+		return nil, errorfactory.New(errorfactory.APIFailure{}, err, "failed to get user secret")
+	case !isCertificateDue(secret, user, time.Now()):
+		if err = pkicommon.EnsureControllerReference(ctx, user, secret, scheme, v.client); err != nil {
+			return nil, err
+		}
+		return userCertificate(secret), nil
+	}
+
+	vault, err := newVaultClient(ctx, v.client, v.cluster.Namespace, *v.cluster.Spec.VaultConfig)
+	if err != nil {
+		return nil, errorfactory.New(errorfactory.APIFailure{}, err, "could not connect to Vault")
+	}
+	cert, err := vault.issue(ctx, user.GetName(), user.Spec.DNSNames, v.certificateTTL(user))
+	if err != nil {
+		return nil, errorfactory.New(errorfactory.APIFailure{}, err, "could not issue user certificate")
+	}
+	if err = setCertificate(secret, cert, user.Spec.IncludeJKS); err != nil {
+		return nil, errorfactory.New(errorfactory.InternalError{}, err, "could not store the certificate issued by Vault")
+	}
+
+	if err = controllerutil.SetControllerReference(user, secret, scheme); err != nil && !k8sutil.IsAlreadyOwnedError(err) {
+		return nil, errorfactory.New(errorfactory.InternalError{}, err, "error checking controller reference on user secret")
+	}
+	if secret.GetResourceVersion() == "" {
+		err = v.client.Create(ctx, secret)
+	} else {
+		err = v.client.Update(ctx, secret)
+	}
+	if err != nil {
+		return nil, errorfactory.New(errorfactory.APIFailure{}, err, "could not store user secret")
+	}
+
+	return userCertificate(secret), nil
+}
+
+// RenewalDelay returns the time left until the given PEM encoded certificate issued by Vault is renewed
+func RenewalDelay(certPEM []byte) (time.Duration, error) {
+	cert, err := certutil.DecodeCertificate(certPEM)
+	if err != nil {
+		return 0, err
+	}
+	return time.Until(renewalTime(cert)), nil
+}
+
+// renewalTime returns the time two thirds of the validity of the certificate elapse at
+func renewalTime(cert *x509.Certificate) time.Time {
+	return cert.NotBefore.Add(cert.NotAfter.Sub(cert.NotBefore) * 2 / 3)
+}
+
+// certificateTTL returns the validity of the certificate of the user, the expiration of the user overrides the TTL of
+// the Vault config of the cluster
+func (v *vaultPKI) certificateTTL(user *v1alpha1.KafkaUser) time.Duration {
+	if user.Spec.ExpirationSeconds != nil {
+		return time.Duration(user.Spec.GetExpirationSeconds()) * time.Second
+	}
+	return v.cluster.Spec.VaultConfig.GetCertificateTTL()
+}
+
+// isCertificateDue returns true if the secret holds no complete certificate of the user or the certificate has to be
+// renewed
+func isCertificateDue(secret *corev1.Secret, user *v1alpha1.KafkaUser, now time.Time) bool {
+	requiredFields := []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey, v1alpha1.CoreCACertKey}
+	if user.Spec.IncludeJKS {
+		requiredFields = append(requiredFields, v1alpha1.TLSJKSKeyStore, v1alpha1.TLSJKSTrustStore, v1alpha1.PasswordKey)
+	}
+	for _, field := range requiredFields {
+		if len(secret.Data[field]) == 0 {
+			return true
+		}
+	}
+	cert, err := certutil.DecodeCertificate(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return true
+	}
+	if !now.Before(renewalTime(cert)) {
+		return true
+	}
+	return !sameNames(cert.DNSNames, user.Spec.DNSNames)
+}
+
+func sameNames(a, b []string) bool {
+	a = slices.Clone(a)
+	b = slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
+
+// setCertificate stores the issued certificate in the secret, the keystores are encrypted with the password already
+// in the secret so the password does not change when the certificate is renewed
+func setCertificate(secret *corev1.Secret, cert *issuedCertificate, includeJKS bool) error {
+	caPEM := cert.caCertificates()
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	secret.Data[corev1.TLSCertKey] = []byte(cert.Certificate)
+	secret.Data[corev1.TLSPrivateKeyKey] = []byte(cert.PrivateKey)
+	secret.Data[v1alpha1.CoreCACertKey] = caPEM
+	if !includeJKS {
+		return nil
+	}
+
+	leaf, err := certutil.DecodeCertificate([]byte(cert.Certificate))
+	if err != nil {
+		return errors.WrapIf(err, "could not decode certificate")
+	}
+	caCerts, err := certutil.ParseCertificates(caPEM)
+	if err != nil {
+		return errors.WrapIf(err, "could not decode CA certificates")
+	}
+	password := secret.Data[v1alpha1.PasswordKey]
+	if len(password) == 0 {
+		password = certutil.GeneratePass(16)
+	}
+	keyStore, keyStorePassword, err := certutil.GenerateJKS(append([]*x509.Certificate{leaf}, certutil.GetCertBundle(caCerts)...), []byte(cert.PrivateKey))
+	if err != nil {
+		return errors.WrapIf(err, "could not generate keystore")
+	}
+	if keyStore, err = certutil.ReencryptJKS(keyStore, keyStorePassword, password); err != nil {
+		return errors.WrapIf(err, "could not encrypt keystore")
+	}
+	trustStore, err := certutil.GenerateTrustStoreJKS(certutil.GetCertBundle(caCerts), password)
+	if err != nil {
+		return errors.WrapIf(err, "could not generate truststore")
+	}
+	secret.Data[v1alpha1.TLSJKSKeyStore] = keyStore
+	secret.Data[v1alpha1.TLSJKSTrustStore] = trustStore
+	secret.Data[v1alpha1.PasswordKey] = password
+	return nil
+}
+
+func userCertificate(secret *corev1.Secret) *pkicommon.UserCertificate {
+	return &pkicommon.UserCertificate{
+		CA:          secret.Data[v1alpha1.CoreCACertKey],
+		Certificate: secret.Data[corev1.TLSCertKey],
+		Key:         secret.Data[corev1.TLSPrivateKeyKey],
+		JKS:         secret.Data[v1alpha1.TLSJKSKeyStore],
+		Password:    secret.Data[v1alpha1.PasswordKey],
+	}
+}
@@ -0,0 +1,295 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vaultpki
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	"github.com/banzaicloud/koperator/pkg/util"
+	certutil "github.com/banzaicloud/koperator/pkg/util/cert"
+)
+
+// fakeVault serves the Kubernetes auth method and the PKI secrets engine of Vault backed by a test CA
+type fakeVault struct {
+	*httptest.Server
+	caCert *x509.Certificate
+	caKey  *ecdsa.PrivateKey
+	caPEM  []byte
+	issued int
+}
+
+func newFakeVault(t *testing.T) *fakeVault {
+	t.Helper()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "vault-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(48 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	v := &fakeVault{
+		caCert: caCert,
+		caKey:  caKey,
+		caPEM:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/auth/kubernetes/login", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["role"] != "koperator" || body["jwt"] != "service-account-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"auth":{"client_token":"vault-token"}}`))
+	})
+	mux.HandleFunc("/v1/pki/issue/kafka", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			CommonName string `json:"common_name"`
+			AltNames   string `json:"alt_names"`
+			TTL        string `json:"ttl"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		certPEM, keyPEM := v.issue(t, body.CommonName, body.AltNames, body.TTL)
+		rsp, _ := json.Marshal(map[string]interface{}{"data": map[string]interface{}{
+			"certificate": string(certPEM),
+			"issuing_ca":  string(v.caPEM),
+			"private_key": string(keyPEM),
+		}})
+		_, _ = w.Write(rsp)
+	})
+	v.Server = httptest.NewServer(mux)
+	t.Cleanup(v.Close)
+	return v
+}
+
+func (v *fakeVault) issue(t *testing.T, commonName, altNames, ttl string) (certPEM, keyPEM []byte) {
+	validity, err := time.ParseDuration(ttl)
+	require.NoError(t, err)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	v.issued++
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(int64(v.issued + 1)),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(validity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if altNames != "" {
+		template.DNSNames = strings.Split(altNames, ",")
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, v.caCert, &key.PublicKey, v.caKey)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
+
+func newVaultCluster(t *testing.T, address string) *v1beta1.KafkaCluster {
+	t.Helper()
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenPath, []byte("service-account-token\n"), 0o600))
+	return &v1beta1.KafkaCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+		Spec: v1beta1.KafkaClusterSpec{
+			VaultConfig: &v1beta1.VaultConfig{
+				Address: address,
+				Auth:    v1beta1.VaultAuthConfig{Role: "koperator", TokenPath: tokenPath},
+				Role:    "kafka",
+			},
+		},
+	}
+}
+
+func TestReconcileUserCertificate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	vault := newFakeVault(t)
+	cluster := newVaultCluster(t, vault.URL)
+	user := &v1alpha1.KafkaUser{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-user", Namespace: "kafka", UID: "test-user-uid"},
+		Spec: v1alpha1.KafkaUserSpec{
+			SecretName: "test-user-secret",
+			DNSNames:   []string{"test-user.kafka.svc"},
+			IncludeJKS: true,
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	ctx := context.Background()
+	v := New(c, cluster)
+	getSecret := func() *corev1.Secret {
+		secret := &corev1.Secret{}
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "test-user-secret", Namespace: "kafka"}, secret))
+		return secret
+	}
+
+	// the certificate of a new user is issued by Vault
+	userCert, err := v.ReconcileUserCertificate(ctx, user, scheme, "cluster.local")
+	require.NoError(t, err)
+	require.Equal(t, 1, vault.issued)
+	secret := getSecret()
+	require.Len(t, secret.Data, 6)
+	require.Equal(t, "test-user", secret.GetOwnerReferences()[0].Name)
+	require.Equal(t, secret.Data[corev1.TLSCertKey], userCert.Certificate)
+	require.Equal(t, vault.caPEM, userCert.CA)
+	dn, err := userCert.GetDistinguishedName()
+	require.NoError(t, err)
+	require.Equal(t, "CN=test-user", dn)
+	_, err = util.CreateTLSConfigFromSecret(secret)
+	require.NoError(t, err)
+	password := secret.Data[v1alpha1.PasswordKey]
+
+	// a valid certificate is not reissued
+	_, err = v.ReconcileUserCertificate(ctx, user, scheme, "cluster.local")
+	require.NoError(t, err)
+	require.Equal(t, 1, vault.issued)
+
+	// the certificate is reissued when the DNS names change, the keystores keep their password
+	user.Spec.DNSNames = append(user.Spec.DNSNames, "test-user.example.com")
+	userCert, err = v.ReconcileUserCertificate(ctx, user, scheme, "cluster.local")
+	require.NoError(t, err)
+	require.Equal(t, 2, vault.issued)
+	cert, err := certutil.DecodeCertificate(userCert.Certificate)
+	require.NoError(t, err)
+	require.Equal(t, user.Spec.DNSNames, cert.DNSNames)
+	secret = getSecret()
+	require.Equal(t, password, secret.Data[v1alpha1.PasswordKey])
+	_, err = util.CreateTLSConfigFromSecret(secret)
+	require.NoError(t, err)
+
+	// failing to log in to Vault keeps the current certificate
+	cluster.Spec.VaultConfig.Auth.Role = "unknown"
+	user.Spec.DNSNames = []string{"test-user.kafka.svc"}
+	_, err = v.ReconcileUserCertificate(ctx, user, scheme, "cluster.local")
+	require.True(t, errors.As(err, &errorfactory.APIFailure{}))
+	require.Equal(t, 2, vault.issued)
+	require.Equal(t, secret.Data, getSecret().Data)
+
+	// the certificates can not be issued without a Vault config
+	cluster.Spec.VaultConfig = nil
+	_, err = v.ReconcileUserCertificate(ctx, user, scheme, "cluster.local")
+	require.True(t, errors.As(err, &errorfactory.FatalReconcileError{}))
+}
+
+func TestIsCertificateDue(t *testing.T) {
+	testCertificate := func(notBefore, notAfter time.Time, dnsNames ...string) []byte {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "test-user"},
+			NotBefore:    notBefore,
+			NotAfter:     notAfter,
+			DNSNames:     dnsNames,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		require.NoError(t, err)
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	}
+	now := time.Now()
+
+	testCases := []struct {
+		testName    string
+		certificate []byte
+		includeJKS  bool
+		dnsNames    []string
+		expectedDue bool
+	}{
+		{
+			testName:    "valid certificate",
+			certificate: testCertificate(now.Add(-time.Hour), now.Add(23*time.Hour), "b.kafka.svc", "a.kafka.svc"),
+			dnsNames:    []string{"a.kafka.svc", "b.kafka.svc"},
+		},
+		{
+			testName:    "missing certificate",
+			expectedDue: true,
+		},
+		{
+			testName:    "missing keystore",
+			certificate: testCertificate(now.Add(-time.Hour), now.Add(23*time.Hour)),
+			includeJKS:  true,
+			expectedDue: true,
+		},
+		{
+			testName:    "two thirds of the validity elapsed",
+			certificate: testCertificate(now.Add(-17*time.Hour), now.Add(7*time.Hour)),
+			expectedDue: true,
+		},
+		{
+			testName:    "DNS names changed",
+			certificate: testCertificate(now.Add(-time.Hour), now.Add(23*time.Hour), "a.kafka.svc"),
+			dnsNames:    []string{"a.kafka.svc", "b.kafka.svc"},
+			expectedDue: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			secret := &corev1.Secret{Data: map[string][]byte{
+				corev1.TLSPrivateKeyKey: []byte("key"),
+				v1alpha1.CoreCACertKey:  []byte("ca"),
+			}}
+			if test.certificate != nil {
+				secret.Data[corev1.TLSCertKey] = test.certificate
+			}
+			user := &v1alpha1.KafkaUser{Spec: v1alpha1.KafkaUserSpec{DNSNames: test.dnsNames, IncludeJKS: test.includeJKS}}
+			require.Equal(t, test.expectedDue, isCertificateDue(secret, user, now))
+		})
+	}
+}
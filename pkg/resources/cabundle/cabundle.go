@@ -0,0 +1,278 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cabundle
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"reflect"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	"github.com/banzaicloud/koperator/pkg/resources"
+	certutil "github.com/banzaicloud/koperator/pkg/util/cert"
+	pkicommon "github.com/banzaicloud/koperator/pkg/util/pki"
+)
+
+const (
+	componentName = "caBundleDistribution"
+	appLabelValue = "kafka-ca-bundle"
+
+	// clusterNamespaceLabelKey tells apart the CA bundles of the clusters with the same name in different namespaces
+	clusterNamespaceLabelKey = "kafka_cr_namespace"
+)
+
+// Reconciler implements the Component Reconciler
+type Reconciler struct {
+	resources.Reconciler
+	// directClient reads the namespaces and the published ConfigMaps which may live in namespaces not watched by the operator
+	directClient client.Reader
+}
+
+// New creates a new reconciler for the CA bundle distribution
+func New(client client.Client, directClient client.Reader, cluster *v1beta1.KafkaCluster) *Reconciler {
+	return &Reconciler{
+		Reconciler: resources.Reconciler{
+			Client:       client,
+			KafkaCluster: cluster,
+		},
+		directClient: directClient,
+	}
+}
+
+// Reconcile implements the reconcile logic for the CA bundle distribution. The CA chains of the SSL external
+// listeners are published into a ConfigMap in every selected namespace, the ConfigMaps published earlier into
+// namespaces which are not selected anymore are removed.
+func (r *Reconciler) Reconcile(log logr.Logger) error {
+	log = log.WithValues("component", componentName)
+	log.V(1).Info("Reconciling")
+	ctx := context.Background()
+
+	desired := make(map[types.NamespacedName]struct{})
+	if distribution := r.KafkaCluster.Spec.ListenersConfig.CABundleDistribution; distribution != nil {
+		bundle, err := r.caBundle(ctx)
+		if err != nil {
+			return err
+		}
+		if len(bundle) > 0 {
+			namespaces, err := r.targetNamespaces(ctx, log, distribution)
+			if err != nil {
+				return err
+			}
+			name := distribution.GetConfigMapName(r.KafkaCluster.Name, r.KafkaCluster.Namespace)
+			for _, namespace := range namespaces {
+				configMapName := types.NamespacedName{Name: name, Namespace: namespace}
+				if err = r.publish(ctx, log, configMapName, bundle); err != nil {
+					return err
+				}
+				desired[configMapName] = struct{}{}
+			}
+		}
+	}
+
+	if err := r.removeStale(ctx, log, desired); err != nil {
+		return err
+	}
+
+	log.V(1).Info("Reconciled")
+
+	return nil
+}
+
+// Finalize removes the published CA bundles of the cluster, those are not garbage collected together with the
+// KafkaCluster since they live in other namespaces
+func (r *Reconciler) Finalize(ctx context.Context, log logr.Logger) error {
+	return r.removeStale(ctx, log.WithValues("component", componentName), nil)
+}
+
+// caBundle returns the PEM encoded CA certificates found in the truststores of the SSL external listeners
+func (r *Reconciler) caBundle(ctx context.Context) (string, error) {
+	var caCerts []*x509.Certificate
+	for _, eListener := range r.KafkaCluster.Spec.ListenersConfig.ExternalListeners {
+		if !eListener.Type.IsSSL() {
+			continue
+		}
+		secret, err := r.listenerSSLCertSecret(ctx, eListener.CommonListenerSpec)
+		if err != nil {
+			return "", err
+		}
+		chain, err := certutil.ParseTrustStoreToCaChain(secret.Data[v1alpha1.TLSJKSTrustStore], secret.Data[v1alpha1.PasswordKey])
+		if err != nil {
+			return "", errors.WrapIfWithDetails(err, "could not parse the truststore of the external listener",
+				"listener", eListener.Name, "secret", secret.Name)
+		}
+		for _, caCert := range chain {
+			if !containsCertificate(caCerts, caCert) {
+				caCerts = append(caCerts, caCert)
+			}
+		}
+	}
+
+	var bundle bytes.Buffer
+	for _, caCert := range caCerts {
+		if err := pem.Encode(&bundle, &pem.Block{Type: "CERTIFICATE", Bytes: caCert.Raw}); err != nil {
+			return "", errors.WrapIf(err, "could not encode CA certificate")
+		}
+	}
+	return bundle.String(), nil
+}
+
+// listenerSSLCertSecret returns the server certificate secret of the listener
+func (r *Reconciler) listenerSSLCertSecret(ctx context.Context, commonSpec v1beta1.CommonListenerSpec) (*corev1.Secret, error) {
+	secretName := types.NamespacedName{Name: pkicommon.ListenerServerCertSecretName(r.KafkaCluster.Name, commonSpec), Namespace: r.KafkaCluster.Namespace}
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, secretName, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errorfactory.New(errorfactory.ResourceNotReady{}, err, "server secret not ready", "secret", secretName)
+		}
+		return nil, errorfactory.New(errorfactory.APIFailure{}, err, "getting server secret failed", "secret", secretName)
+	}
+	if err := certutil.CheckSSLCertSecret(secret); err != nil {
+		return nil, errorfactory.New(errorfactory.ResourceNotReady{}, err, "server secret not ready", "secret", secretName)
+	}
+	return secret, nil
+}
+
+// targetNamespaces returns the existing namespaces listed or selected by the CA bundle distribution
+func (r *Reconciler) targetNamespaces(ctx context.Context, log logr.Logger, distribution *v1beta1.CABundleDistribution) ([]string, error) {
+	var namespaces []corev1.Namespace
+	for _, name := range distribution.Namespaces {
+		namespace := corev1.Namespace{}
+		if err := r.directClient.Get(ctx, types.NamespacedName{Name: name}, &namespace); err != nil {
+			if apierrors.IsNotFound(err) {
+				log.V(1).Info("namespace of the CA bundle distribution not found", "namespace", name)
+				continue
+			}
+			return nil, errorfactory.New(errorfactory.APIFailure{}, err, "getting namespace failed", "namespace", name)
+		}
+		namespaces = append(namespaces, namespace)
+	}
+	if distribution.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(distribution.NamespaceSelector)
+		if err != nil {
+			return nil, errors.WrapIf(err, "invalid namespace selector of the CA bundle distribution")
+		}
+		var namespaceList corev1.NamespaceList
+		if err = r.directClient.List(ctx, &namespaceList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, errorfactory.New(errorfactory.APIFailure{}, err, "listing namespaces failed")
+		}
+		namespaces = append(namespaces, namespaceList.Items...)
+	}
+
+	var names []string
+	seen := make(map[string]struct{})
+	for _, namespace := range namespaces {
+		if _, ok := seen[namespace.Name]; ok || !namespace.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		seen[namespace.Name] = struct{}{}
+		names = append(names, namespace.Name)
+	}
+	return names, nil
+}
+
+// publish creates or updates the CA bundle ConfigMap, ConfigMaps with the same name not published by the operator
+// are never modified
+func (r *Reconciler) publish(ctx context.Context, log logr.Logger, configMapName types.NamespacedName, bundle string) error {
+	data := map[string]string{v1alpha1.CoreCACertKey: bundle}
+	configMap := &corev1.ConfigMap{}
+	err := r.directClient.Get(ctx, configMapName, configMap)
+	switch {
+	case apierrors.IsNotFound(err):
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: configMapName.Name, Namespace: configMapName.Namespace, Labels: r.labels()},
+			Data:       data,
+		}
+		if err = r.Create(ctx, configMap); err != nil {
+			return errorfactory.New(errorfactory.APIFailure{}, err, "creating CA bundle configmap failed", "configMap", configMapName)
+		}
+		log.Info("CA bundle configmap created", "configMap", configMapName)
+		return nil
+	case err != nil:
+		return errorfactory.New(errorfactory.APIFailure{}, err, "getting CA bundle configmap failed", "configMap", configMapName)
+	}
+
+	if !r.isPublished(configMap) {
+		return errors.NewWithDetails("CA bundle configmap name is already used by a configmap not published by the operator",
+			"configMap", configMapName)
+	}
+	if !reflect.DeepEqual(data, configMap.Data) {
+		configMap.Data = data
+		if err = r.Update(ctx, configMap); err != nil {
+			return errorfactory.New(errorfactory.APIFailure{}, err, "updating CA bundle configmap failed", "configMap", configMapName)
+		}
+		log.Info("CA bundle configmap updated", "configMap", configMapName)
+	}
+	return nil
+}
+
+// removeStale deletes the published CA bundle ConfigMaps of the cluster which are not desired anymore
+func (r *Reconciler) removeStale(ctx context.Context, log logr.Logger, desired map[types.NamespacedName]struct{}) error {
+	var configMaps corev1.ConfigMapList
+	if err := r.directClient.List(ctx, &configMaps, client.MatchingLabels(r.labels())); err != nil {
+		return errorfactory.New(errorfactory.APIFailure{}, err, "listing CA bundle configmaps failed")
+	}
+	for i := range configMaps.Items {
+		configMap := &configMaps.Items[i]
+		if _, ok := desired[client.ObjectKeyFromObject(configMap)]; ok || !configMap.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if err := r.Delete(ctx, configMap); client.IgnoreNotFound(err) != nil {
+			return errors.WrapIfWithDetails(err, "error when removing CA bundle configmap",
+				"configMap", client.ObjectKeyFromObject(configMap))
+		}
+		log.Info("CA bundle configmap removed", "configMap", client.ObjectKeyFromObject(configMap))
+	}
+	return nil
+}
+
+// isPublished returns true if the ConfigMap holds the CA bundle of the cluster
+func (r *Reconciler) isPublished(configMap *corev1.ConfigMap) bool {
+	for key, value := range r.labels() {
+		if configMap.Labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Reconciler) labels() map[string]string {
+	return map[string]string{
+		v1beta1.AppLabelKey:      appLabelValue,
+		v1beta1.KafkaCRLabelKey:  r.KafkaCluster.Name,
+		clusterNamespaceLabelKey: r.KafkaCluster.Namespace,
+	}
+}
+
+func containsCertificate(certs []*x509.Certificate, cert *x509.Certificate) bool {
+	for _, c := range certs {
+		if c.Equal(cert) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cabundle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	certutil "github.com/banzaicloud/koperator/pkg/util/cert"
+)
+
+func TestReconcile(t *testing.T) {
+	caCert, caKey, _, err := certutil.GenerateTestCert()
+	require.NoError(t, err)
+	trustStore, password, err := certutil.GenerateJKSFromByte(caCert, caKey, caCert)
+	require.NoError(t, err)
+
+	publishedLabels := map[string]string{
+		v1beta1.AppLabelKey:      appLabelValue,
+		v1beta1.KafkaCRLabelKey:  "kafka",
+		clusterNamespaceLabelKey: "kafka",
+	}
+	namespace := func(name string, labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+
+	testCases := []struct {
+		testName           string
+		distribution       *v1beta1.CABundleDistribution
+		existing           []client.Object
+		expectedConfigMaps []string
+		expectedErr        bool
+	}{
+		{
+			testName: "CA bundle is published into the listed and selected namespaces",
+			distribution: &v1beta1.CABundleDistribution{
+				Namespaces:        []string{"app1", "missing"},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kafka-client": "true"}},
+			},
+			existing: []client.Object{
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "kafka-kafka-ca-bundle", Namespace: "old", Labels: publishedLabels}},
+			},
+			expectedConfigMaps: []string{"app1/kafka-kafka-ca-bundle", "app2/kafka-kafka-ca-bundle"},
+		},
+		{
+			testName:     "custom configmap name",
+			distribution: &v1beta1.CABundleDistribution{ConfigMapName: "kafka-ca", Namespaces: []string{"app1"}},
+			existing: []client.Object{
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "kafka-kafka-ca-bundle", Namespace: "app1", Labels: publishedLabels}},
+			},
+			expectedConfigMaps: []string{"app1/kafka-ca"},
+		},
+		{
+			testName:     "configmap not published by the operator is not overwritten",
+			distribution: &v1beta1.CABundleDistribution{Namespaces: []string{"app1"}},
+			existing: []client.Object{
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "kafka-kafka-ca-bundle", Namespace: "app1"}},
+			},
+			expectedErr: true,
+		},
+		{
+			testName: "published CA bundles are removed when the distribution is disabled",
+			existing: []client.Object{
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "kafka-kafka-ca-bundle", Namespace: "app1", Labels: publishedLabels}},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			objects := append([]client.Object{
+				namespace("app1", nil),
+				namespace("app2", map[string]string{"kafka-client": "true"}),
+				namespace("old", nil),
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "kafka-server-certificate", Namespace: "kafka"},
+					Data: map[string][]byte{
+						v1alpha1.TLSJKSKeyStore:   trustStore,
+						v1alpha1.TLSJKSTrustStore: trustStore,
+						v1alpha1.PasswordKey:      password,
+					},
+				},
+			}, test.existing...)
+			c := fake.NewClientBuilder().WithObjects(objects...).Build()
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Spec: v1beta1.KafkaClusterSpec{
+					ListenersConfig: v1beta1.ListenersConfig{
+						ExternalListeners: []v1beta1.ExternalListenerConfig{
+							{CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "external", Type: v1beta1.SecurityProtocolSSL}},
+							{CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "external2", Type: v1beta1.SecurityProtocolSaslSSL}},
+							{CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "plain", Type: v1beta1.SecurityProtocolPlaintext}},
+						},
+						CABundleDistribution: test.distribution,
+					},
+				},
+			}
+
+			err := New(c, c, cluster).Reconcile(logr.Discard())
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var configMaps corev1.ConfigMapList
+			require.NoError(t, c.List(context.Background(), &configMaps, client.MatchingLabels(publishedLabels)))
+			var published []string
+			for _, configMap := range configMaps.Items {
+				published = append(published, client.ObjectKeyFromObject(&configMap).String())
+				require.Equal(t, map[string]string{v1alpha1.CoreCACertKey: string(caCert)}, configMap.Data)
+			}
+			require.ElementsMatch(t, test.expectedConfigMaps, published)
+		})
+	}
+}
@@ -68,7 +68,7 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 	var reconcileObjects []runtime.Object
 	// create ClusterIP services for discovery service and brokers
 	for _, eListener := range r.KafkaCluster.Spec.ListenersConfig.ExternalListeners {
-		if r.KafkaCluster.Spec.GetIngressController() == contourutils.IngressControllerName && eListener.GetAccessMethod() == corev1.ServiceTypeClusterIP {
+		if r.KafkaCluster.Spec.GetIngressControllerForListener(eListener) == contourutils.IngressControllerName && eListener.GetAccessMethod() == corev1.ServiceTypeClusterIP {
 			// create per ingressConfig services ClusterIP
 			ingressConfigs, defaultControllerName, err := util.GetIngressConfigs(r.KafkaCluster.Spec, eListener)
 			if err != nil {
@@ -104,7 +104,7 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 					return err
 				}
 			}
-		} else if r.KafkaCluster.Spec.RemoveUnusedIngressResources {
+		} else if util.ShouldRemoveUnusedIngressResources(r.KafkaCluster, eListener.Name) {
 			// Cleaning up unused contour resources when ingress controller is not contour or externalListener access method is not ClusterIP
 			deletionCounter := 0
 			ctx := context.Background()
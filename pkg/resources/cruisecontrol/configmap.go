@@ -18,6 +18,8 @@ package cruisecontrol
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strconv"
 
@@ -48,8 +50,16 @@ const (
 	storageConfigNWINDefaultValue  = "125000"
 	storageConfigNWOUTDefaultValue = "125000"
 	defaultDoc                     = "Capacity unit used for disk is in MB, cpu is in percentage, network throughput is in KB."
+
+	// NetworkInCapacityAnnotation and NetworkOutCapacityAnnotation set the network throughput capacity of the brokers
+	// in KB/s through the brokerAnnotations of the broker config, the networkConfig takes precedence over them
+	NetworkInCapacityAnnotation  = "cruise-control.banzaicloud.com/network-in-capacity"
+	NetworkOutCapacityAnnotation = "cruise-control.banzaicloud.com/network-out-capacity"
 )
 
+// VolumeCapacities are the actual capacities of the broker volumes by broker id and mount path
+type VolumeCapacities map[string]map[string]resource.Quantity
+
 func (r *Reconciler) configMap(clientPass string, capacityConfig string, log logr.Logger) runtime.Object {
 	ccConfig := properties.NewProperties()
 
@@ -85,6 +95,13 @@ func (r *Reconciler) configMap(clientPass string, capacityConfig string, log log
 		}
 	}
 
+	// The sample store topics are created with two replicas by default which a single-node cluster can not host
+	if _, found := ccConfig.Get(kafkautils.CruiseControlConfigSampleStoreTopicReplicationFactor); !found && r.KafkaCluster.Spec.IsSingleNodeCombinedMode() {
+		if err = ccConfig.Set(kafkautils.CruiseControlConfigSampleStoreTopicReplicationFactor, 1); err != nil {
+			log.Error(err, fmt.Sprintf("setting '%s' in Cruise Control configuration failed", kafkautils.CruiseControlConfigSampleStoreTopicReplicationFactor), "config", 1)
+		}
+	}
+
 	// Add SSL configuration
 	sslConf := generateSSLConfig(r.KafkaCluster.Spec, clientPass, log)
 	if sslConf.Len() != 0 {
@@ -130,6 +147,10 @@ func generateSSLConfig(kafkaCluster v1beta1.KafkaClusterSpec, clientPass string,
 				log.Error(err, fmt.Sprintf("setting '%s' parameter in Cruise Control configuration resulted an error", k))
 			}
 		}
+		// The client keystore password managed by a password policy is resolved from the mounted secret
+		if kafkaCluster.ListenersConfig.SSLSecrets.GetPasswordPolicy() != nil {
+			kafkautils.ConfigureKeystoreConfigProvider(config, log)
+		}
 	}
 	return config
 }
@@ -153,8 +174,9 @@ type JBODInvariantCapacityConfig struct {
 	Capacities []interface{} `json:"brokerCapacities"`
 }
 
-// GenerateCapacityConfig generates a CC capacity config with default values or returns the manually overridden value if it exists
-func GenerateCapacityConfig(kafkaCluster *v1beta1.KafkaCluster, log logr.Logger, config *corev1.ConfigMap) (string, error) {
+// GenerateCapacityConfig generates a CC capacity config with default values or returns the manually overridden value if it exists.
+// The disk capacities are taken from the volume capacities if known, e.g. the bound PVCs, the requested sizes otherwise.
+func GenerateCapacityConfig(kafkaCluster *v1beta1.KafkaCluster, log logr.Logger, config *corev1.ConfigMap, volumeCapacities VolumeCapacities) (string, error) {
 	var err error
 
 	log.Info("generating capacity config")
@@ -201,7 +223,7 @@ func GenerateCapacityConfig(kafkaCluster *v1beta1.KafkaCluster, log logr.Logger,
 
 	// If there was no user provided config we shall generate all configuration or
 	// adding generated values to all Brokers not provided by the user.
-	brokerCapacities, err := appendGeneratedBrokerCapacities(kafkaCluster, log, userConfigBrokerIds)
+	brokerCapacities, err := appendGeneratedBrokerCapacities(kafkaCluster, log, userConfigBrokerIds, volumeCapacities)
 	if err != nil {
 		return "", err
 	}
@@ -215,7 +237,8 @@ func GenerateCapacityConfig(kafkaCluster *v1beta1.KafkaCluster, log logr.Logger,
 	return string(result), err
 }
 
-func appendGeneratedBrokerCapacities(kafkaCluster *v1beta1.KafkaCluster, log logr.Logger, userConfigBrokerIds []string) ([]interface{}, error) {
+func appendGeneratedBrokerCapacities(kafkaCluster *v1beta1.KafkaCluster, log logr.Logger, userConfigBrokerIds []string,
+	volumeCapacities VolumeCapacities) ([]interface{}, error) {
 	var brokerCapacities []interface{}
 
 	brokerIdFromStatus := make([]string, 0, len(kafkaCluster.Status.BrokersState))
@@ -239,7 +262,8 @@ func appendGeneratedBrokerCapacities(kafkaCluster *v1beta1.KafkaCluster, log log
 		for _, broker := range kafkaCluster.Spec.Brokers {
 			if brokerId == strconv.Itoa(int(broker.Id)) {
 				brokerFoundInSpec = true
-				brokerDisks, err := generateBrokerDisks(broker, kafkaCluster.Spec, log)
+				brokerDisks, err := generateBrokerDisks(broker, kafkaCluster.Spec, volumeCapacities[brokerId],
+					kafkaCluster.Status.BrokersState[brokerId].StorageMigrations, log)
 				if err != nil {
 					return nil, errors.WrapIfWithDetails(err, "could not generate broker disks config for broker", v1beta1.BrokerIdLabelKey, broker.Id)
 				}
@@ -295,6 +319,9 @@ func generateBrokerNetworkIn(broker v1beta1.Broker, kafkaClusterSpec v1beta1.Kaf
 	if brokerConfig.NetworkConfig != nil && brokerConfig.NetworkConfig.IncomingNetworkThroughPut != "" {
 		return brokerConfig.NetworkConfig.IncomingNetworkThroughPut
 	}
+	if value := brokerConfig.BrokerAnnotations[NetworkInCapacityAnnotation]; value != "" {
+		return value
+	}
 
 	log.Info("incoming network throughput is not set falling back to default value")
 	return storageConfigNWINDefaultValue
@@ -309,6 +336,9 @@ func generateBrokerNetworkOut(broker v1beta1.Broker, kafkaClusterSpec v1beta1.Ka
 	if brokerConfig.NetworkConfig != nil && brokerConfig.NetworkConfig.OutgoingNetworkThroughPut != "" {
 		return brokerConfig.NetworkConfig.OutgoingNetworkThroughPut
 	}
+	if value := brokerConfig.BrokerAnnotations[NetworkOutCapacityAnnotation]; value != "" {
+		return value
+	}
 
 	log.Info("outgoing network throughput is not set falling back to default value")
 	return storageConfigNWOUTDefaultValue
@@ -321,10 +351,20 @@ func generateBrokerCPU(broker v1beta1.Broker, kafkaClusterSpec v1beta1.KafkaClus
 		return storageConfigCPUDefaultValue
 	}
 
-	return strconv.Itoa(int(brokerConfig.GetResources().Limits.Cpu().ScaledValue(-2)))
+	// the CPU capacity is the limit of the broker, the request if no limit is set
+	resources := brokerConfig.GetResources()
+	cpu := resources.Limits.Cpu()
+	if cpu.IsZero() {
+		cpu = resources.Requests.Cpu()
+	}
+	if cpu.IsZero() {
+		return storageConfigCPUDefaultValue
+	}
+	return strconv.Itoa(int(cpu.ScaledValue(-2)))
 }
 
-func generateBrokerDisks(brokerState v1beta1.Broker, kafkaClusterSpec v1beta1.KafkaClusterSpec, log logr.Logger) (map[string]string, error) {
+func generateBrokerDisks(brokerState v1beta1.Broker, kafkaClusterSpec v1beta1.KafkaClusterSpec, volumeCapacities map[string]resource.Quantity,
+	migrations v1beta1.StorageMigrationStates, log logr.Logger) (map[string]string, error) {
 	storageConfigs := make(map[string]v1beta1.StorageConfig)
 
 	// Get disks from the BrokerConfigGroup if it's in use
@@ -343,10 +383,24 @@ func generateBrokerDisks(brokerState v1beta1.Broker, kafkaClusterSpec v1beta1.Ka
 		}
 	}
 
+	// the volumes of the storage migrations in progress are attached to the broker next to or instead of the old ones
+	if len(migrations) > 0 {
+		configs := slices.Collect(maps.Values(storageConfigs))
+		clear(storageConfigs)
+		for _, c := range kafkautils.StorageConfigsWithMigrations(configs, migrations) {
+			storageConfigs[c.MountPath] = c
+		}
+	}
+
 	// Generate log dir configuration
 	logDirs := make(map[string]string, len(storageConfigs))
 	for path, conf := range storageConfigs {
 		size := parseMountPathWithSize(conf)
+		// the actual capacity of the volume differs from the requested size when it was rounded up by the provisioner or
+		// expanded since
+		if capacity, ok := volumeCapacities[path]; ok && !capacity.IsZero() {
+			size = quantityInMB(&capacity)
+		}
 		log.V(1).Info(fmt.Sprintf("broker log.dir %s size in MB: %d", path, size), v1beta1.BrokerIdLabelKey, brokerState.Id)
 
 		if size < MinLogDirSizeInMB {
@@ -368,7 +422,11 @@ func parseMountPathWithSize(storage v1beta1.StorageConfig) int64 {
 	} else if storage.EmptyDir != nil {
 		q = storage.EmptyDir.SizeLimit
 	}
+	return quantityInMB(q)
+}
 
+// quantityInMB returns the quantity in megabytes rounded down
+func quantityInMB(q *resource.Quantity) int64 {
 	var tmpDec = inf.NewDec(0, 0)
 	tmpDec.Round(q.AsDec(), -1*inf.Scale(resource.Mega), inf.RoundDown)
 
@@ -21,10 +21,14 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/banzaicloud/koperator/api/v1beta1"
+	kafkautils "github.com/banzaicloud/koperator/pkg/util/kafka"
+	properties "github.com/banzaicloud/koperator/properties/pkg"
 )
 
 //nolint:funlen
@@ -561,7 +565,7 @@ func TestGenerateCapacityConfig_JBOD(t *testing.T) {
 
 		t.Run(test.testName, func(t *testing.T) {
 			var actual CapacityConfig
-			rawStringActual, _ := GenerateCapacityConfig(&test.kafkaCluster, logr.Discard(), nil)
+			rawStringActual, _ := GenerateCapacityConfig(&test.kafkaCluster, logr.Discard(), nil, nil)
 			err := json.Unmarshal([]byte(rawStringActual), &actual)
 			if err != nil {
 				t.Error(err, "could not unmarshal actual json")
@@ -617,7 +621,7 @@ func TestReturnErrorStorageConfigLessThan1MB(t *testing.T) {
 		},
 	}
 
-	_, err := GenerateCapacityConfig(&kafkaCluster, logr.Discard(), nil)
+	_, err := GenerateCapacityConfig(&kafkaCluster, logr.Discard(), nil, nil)
 
 	if err == nil {
 		t.Error("Expected error to be thrown when storage config < 1MB")
@@ -1000,7 +1004,7 @@ func TestGenerateCapacityConfigWithUserProvidedInput(t *testing.T) {
 				},
 			}
 			var actual JBODInvariantCapacityConfig
-			rawStringActual, _ := GenerateCapacityConfig(&kafkaCluster, logr.Discard(), nil)
+			rawStringActual, _ := GenerateCapacityConfig(&kafkaCluster, logr.Discard(), nil, nil)
 			err := json.Unmarshal([]byte(rawStringActual), &actual)
 			if err != nil {
 				t.Error(err, "could not unmarshal actual json")
@@ -1018,3 +1022,138 @@ func TestGenerateCapacityConfigWithUserProvidedInput(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigMapSampleStoreReplicationFactor(t *testing.T) {
+	combined := v1beta1.BrokerConfig{Roles: []string{"broker", "controller"}}
+	testCases := []struct {
+		testName                  string
+		brokers                   []v1beta1.Broker
+		config                    string
+		expectedReplicationFactor string
+	}{
+		{
+			testName:                  "single-node cluster",
+			brokers:                   []v1beta1.Broker{{Id: 0, BrokerConfig: &combined}},
+			expectedReplicationFactor: "1",
+		},
+		{
+			testName:                  "single-node cluster with the replication factor set in the config",
+			brokers:                   []v1beta1.Broker{{Id: 0, BrokerConfig: &combined}},
+			config:                    "sample.store.topic.replication.factor=2",
+			expectedReplicationFactor: "2",
+		},
+		{
+			testName: "multi-node cluster",
+			brokers:  []v1beta1.Broker{{Id: 0, BrokerConfig: &combined}, {Id: 1, BrokerConfig: &combined}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			r := New(nil, &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Spec: v1beta1.KafkaClusterSpec{
+					KRaftMode:           true,
+					Brokers:             test.brokers,
+					CruiseControlConfig: v1beta1.CruiseControlConfig{Config: test.config},
+				},
+			}, nil)
+
+			configMap := r.configMap("", "", logr.Discard()).(*v1.ConfigMap)
+			config, err := properties.NewFromString(configMap.Data["cruisecontrol.properties"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			replicationFactor, _ := config.Get(kafkautils.CruiseControlConfigSampleStoreTopicReplicationFactor)
+			if replicationFactor.Value() != test.expectedReplicationFactor {
+				t.Errorf("expected sample store replication factor %q, got %q", test.expectedReplicationFactor, replicationFactor.Value())
+			}
+		})
+	}
+}
+
+func TestGenerateCapacityConfigFromActualValues(t *testing.T) {
+	requested := resource.MustParse("10Gi")
+	testCases := []struct {
+		testName         string
+		resources        *v1.ResourceRequirements
+		networkConfig    *v1beta1.NetworkConfig
+		volumeCapacities VolumeCapacities
+		expectedCapacity Capacity
+	}{
+		{
+			testName: "requested sizes and default resources",
+			expectedCapacity: Capacity{
+				DISK:  map[string]string{"/kafka-logs/kafka": "10737"},
+				CPU:   "150",
+				NWIN:  "250000",
+				NWOUT: "500000",
+			},
+		},
+		{
+			testName: "capacity of the expanded volume and CPU request",
+			resources: &v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1500m")},
+			},
+			volumeCapacities: VolumeCapacities{"0": {"/kafka-logs": resource.MustParse("20Gi")}},
+			expectedCapacity: Capacity{
+				DISK:  map[string]string{"/kafka-logs/kafka": "21474"},
+				CPU:   "150",
+				NWIN:  "250000",
+				NWOUT: "500000",
+			},
+		},
+		{
+			testName: "CPU limit and network config",
+			resources: &v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+				Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")},
+			},
+			networkConfig:    &v1beta1.NetworkConfig{IncomingNetworkThroughPut: "100000"},
+			volumeCapacities: VolumeCapacities{"1": {"/kafka-logs": resource.MustParse("20Gi")}},
+			expectedCapacity: Capacity{
+				DISK:  map[string]string{"/kafka-logs/kafka": "10737"},
+				CPU:   "200",
+				NWIN:  "100000",
+				NWOUT: "500000",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			kafkaCluster := v1beta1.KafkaCluster{
+				Spec: v1beta1.KafkaClusterSpec{
+					BrokerConfigGroups: map[string]v1beta1.BrokerConfig{
+						"default": {
+							Resources:     test.resources,
+							NetworkConfig: test.networkConfig,
+							BrokerAnnotations: map[string]string{
+								NetworkInCapacityAnnotation:  "250000",
+								NetworkOutCapacityAnnotation: "500000",
+							},
+							StorageConfigs: []v1beta1.StorageConfig{{
+								MountPath: "/kafka-logs",
+								PvcSpec: &v1.PersistentVolumeClaimSpec{
+									Resources: v1.VolumeResourceRequirements{
+										Requests: v1.ResourceList{v1.ResourceStorage: requested},
+									},
+								},
+							}},
+						},
+					},
+					Brokers: []v1beta1.Broker{{Id: 0, BrokerConfigGroup: "default"}},
+				},
+				Status: v1beta1.KafkaClusterStatus{
+					BrokersState: map[string]v1beta1.BrokerState{"0": {}},
+				},
+			}
+
+			rawCapacityConfig, err := GenerateCapacityConfig(&kafkaCluster, logr.Discard(), nil, test.volumeCapacities)
+			require.NoError(t, err)
+			var actual CapacityConfig
+			require.NoError(t, json.Unmarshal([]byte(rawCapacityConfig), &actual))
+			require.Equal(t, []BrokerCapacity{{BrokerID: "0", Capacity: test.expectedCapacity, Doc: defaultDoc}}, actual.BrokerCapacities)
+		})
+	}
+}
@@ -21,11 +21,16 @@ import (
 
 	"emperror.dev/errors"
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	apiutil "github.com/banzaicloud/koperator/api/util"
 	"github.com/banzaicloud/koperator/api/v1alpha1"
 	"github.com/banzaicloud/koperator/api/v1beta1"
 	"github.com/banzaicloud/koperator/pkg/errorfactory"
@@ -33,6 +38,7 @@ import (
 	"github.com/banzaicloud/koperator/pkg/kafkaclient"
 	"github.com/banzaicloud/koperator/pkg/resources"
 	certutil "github.com/banzaicloud/koperator/pkg/util/cert"
+	kafkautils "github.com/banzaicloud/koperator/pkg/util/kafka"
 	pkicommon "github.com/banzaicloud/koperator/pkg/util/pki"
 )
 
@@ -57,6 +63,8 @@ type CapacityConfigAnnotation string
 type Reconciler struct {
 	resources.Reconciler
 	KafkaClientProvider kafkaclient.Provider
+	// cruiseControl is the CruiseControl deploying Cruise Control for the cluster, nil if it is deployed by the cluster
+	cruiseControl *v1alpha1.CruiseControl
 }
 
 func ccLabelSelector(kafkaCluster string) map[string]string {
@@ -77,35 +85,59 @@ func New(client client.Client, cluster *v1beta1.KafkaCluster, kafkaClientProvide
 	}
 }
 
+// NewForCruiseControl creates a new reconciler deploying CC for the cluster from the template of the CruiseControl,
+// the resources are owned by the CruiseControl instead of the cluster
+func NewForCruiseControl(client client.Client, cluster *v1beta1.KafkaCluster, cruiseControl *v1alpha1.CruiseControl,
+	kafkaClientProvider kafkaclient.Provider) *Reconciler {
+	cluster = cluster.DeepCopy()
+	cluster.Spec.CruiseControlConfig = cluster.Spec.CruiseControlConfig.WithDeploymentOf(cruiseControl.Spec.Template)
+	r := New(client, cluster, kafkaClientProvider)
+	r.cruiseControl = cruiseControl
+	return r
+}
+
 // Reconcile implements the reconcile logic for CC
 func (r *Reconciler) Reconcile(log logr.Logger) error {
 	log = log.WithValues("component", fmt.Sprintf(componentNameTemplate, r.KafkaCluster.Name))
 
 	log.V(1).Info("Reconciling")
 
-	var clientPass string
+	var clientPass, clientPassRevision string
 	var err error
 
 	// Get configuration data from client secret
 	if r.KafkaCluster.Spec.IsClientSSLSecretPresent() {
-		if clientPass, err = r.getClientPassword(); err != nil {
+		if r.KafkaCluster.Spec.ListenersConfig.SSLSecrets.GetPasswordPolicy() != nil {
+			if clientPassRevision, err = r.getClientPasswordRevision(); err != nil {
+				return err
+			}
+			clientPass = kafkautils.KeystorePasswordReference(keystoreVolumePath)
+		} else if clientPass, err = r.getClientPassword(); err != nil {
 			return err
 		}
 	}
 
 	if r.KafkaCluster.Spec.CruiseControlConfig.CruiseControlEndpoint == "" {
-		genErr := generateCCTopic(r.KafkaCluster, r.Client, r.KafkaClientProvider, log.WithName("generateCCTopic"))
-		if genErr != nil {
-			updateErr := k8sutil.UpdateCRStatus(r.Client, r.KafkaCluster, v1beta1.CruiseControlTopicNotReady, log)
-			return errors.Combine(genErr, updateErr)
-		}
-		statusErr := k8sutil.UpdateCRStatus(r.Client, r.KafkaCluster, v1beta1.CruiseControlTopicReady, log)
-		if statusErr != nil {
-			return errors.WrapIf(statusErr, "could not update CC topic status")
+		// the CC topic is managed by the cluster even if CC is deployed by a CruiseControl
+		if r.cruiseControl == nil {
+			genErr := generateCCTopic(r.KafkaCluster, r.Client, r.KafkaClientProvider, log.WithName("generateCCTopic"))
+			if genErr != nil {
+				updateErr := k8sutil.UpdateCRStatus(r.Client, r.KafkaCluster, v1beta1.CruiseControlTopicNotReady, log)
+				return errors.Combine(genErr, updateErr)
+			}
+			statusErr := k8sutil.UpdateCRStatus(r.Client, r.KafkaCluster, v1beta1.CruiseControlTopicReady, log)
+			if statusErr != nil {
+				return errors.WrapIf(statusErr, "could not update CC topic status")
+			}
+
+			if ref := r.KafkaCluster.Spec.CruiseControlConfig.CruiseControlRef; ref != nil {
+				log.V(1).Info("Cruise Control is deployed by a CruiseControl", "cruiseControl", ref.Name)
+				return nil
+			}
 		}
 
 		if r.KafkaCluster.Status.CruiseControlTopicStatus == v1beta1.CruiseControlTopicReady {
-			o := r.service()
+			o := r.withOwner(r.service())
 			err := k8sutil.Reconcile(log, r.Client, o, r.KafkaCluster)
 			if err != nil {
 				return errors.WrapIfWithDetails(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
@@ -128,12 +160,16 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 					)
 				}
 			}
-			capacityConfig, err := GenerateCapacityConfig(r.KafkaCluster, log, config)
+			volumeCapacities, err := r.volumeCapacities()
+			if err != nil {
+				return err
+			}
+			capacityConfig, err := GenerateCapacityConfig(r.KafkaCluster, log, config, volumeCapacities)
 			if err != nil {
 				return errors.WrapIf(err, "failed to generate capacity config")
 			}
 
-			o = r.configMap(clientPass, capacityConfig, log)
+			o = r.withOwner(r.configMap(clientPass, capacityConfig, log))
 			err = k8sutil.Reconcile(log, r.Client, o, r.KafkaCluster)
 			if err != nil {
 				return errors.WrapIfWithDetails(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
@@ -143,8 +179,11 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 				r.KafkaCluster.Spec.CruiseControlConfig.GetCruiseControlAnnotations(),
 				o.(*corev1.ConfigMap).Data,
 			)
+			if clientPassRevision != "" {
+				podAnnotations[pkicommon.KeystorePasswordRevisionAnnotation] = clientPassRevision
+			}
 
-			o = r.deployment(podAnnotations)
+			o = r.withOwner(r.deployment(podAnnotations))
 			err = k8sutil.Reconcile(log, r.Client, o, r.KafkaCluster)
 			if err != nil {
 				return errors.WrapIfWithDetails(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
@@ -157,6 +196,63 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 	return nil
 }
 
+// DeleteOwnedResources deletes the CC resources of the cluster which are controlled by the owner
+func DeleteOwnedResources(ctx context.Context, c client.Client, cluster types.NamespacedName, owner metav1.Object) error {
+	resources := map[string]client.Object{
+		fmt.Sprintf(serviceNameTemplate, cluster.Name):         &corev1.Service{},
+		fmt.Sprintf(configAndVolumeNameTemplate, cluster.Name): &corev1.ConfigMap{},
+		fmt.Sprintf(deploymentNameTemplate, cluster.Name):      &appsv1.Deployment{},
+	}
+	for name, o := range resources {
+		if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: cluster.Namespace}, o); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return errorfactory.New(errorfactory.APIFailure{}, err, "getting resource failed", "name", name)
+		}
+		if !metav1.IsControlledBy(o, owner) {
+			continue
+		}
+		if err := c.Delete(ctx, o); client.IgnoreNotFound(err) != nil {
+			return errorfactory.New(errorfactory.APIFailure{}, err, "deleting resource failed", "name", name)
+		}
+	}
+	return nil
+}
+
+// volumeCapacities returns the capacities of the bound PVCs of the brokers
+func (r *Reconciler) volumeCapacities() (VolumeCapacities, error) {
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	err := r.List(context.Background(), pvcs,
+		client.InNamespace(r.KafkaCluster.Namespace), client.MatchingLabels(apiutil.LabelsForKafka(r.KafkaCluster.Name)))
+	if err != nil {
+		return nil, errorfactory.New(errorfactory.APIFailure{}, err, "listing broker PVCs failed")
+	}
+	capacities := make(VolumeCapacities)
+	for _, pvc := range pvcs.Items {
+		brokerId, mountPath := pvc.Labels[v1beta1.BrokerIdLabelKey], pvc.Annotations["mountPath"]
+		capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]
+		if brokerId == "" || mountPath == "" || !ok {
+			continue
+		}
+		if capacities[brokerId] == nil {
+			capacities[brokerId] = make(map[string]resource.Quantity)
+		}
+		capacities[brokerId][mountPath] = capacity
+	}
+	return capacities, nil
+}
+
+// withOwner makes the CruiseControl the controller of the resource when CC is deployed by one instead of the cluster
+func (r *Reconciler) withOwner(o runtime.Object) runtime.Object {
+	if r.cruiseControl != nil {
+		o.(metav1.Object).SetOwnerReferences([]metav1.OwnerReference{
+			*metav1.NewControllerRef(r.cruiseControl, v1alpha1.GroupVersion.WithKind("CruiseControl")),
+		})
+	}
+	return o
+}
+
 func (r *Reconciler) getClientPassword() (string, error) {
 	clientSecret, err := r.getClientSecret()
 	if err != nil {
@@ -165,6 +261,15 @@ func (r *Reconciler) getClientPassword() (string, error) {
 	return string(clientSecret.Data[v1alpha1.PasswordKey]), nil
 }
 
+// getClientPasswordRevision returns the time of the last rotation of the client keystore password
+func (r *Reconciler) getClientPasswordRevision() (string, error) {
+	clientSecret, err := r.getClientSecret()
+	if err != nil {
+		return "", err
+	}
+	return clientSecret.Annotations[pkicommon.KeystorePasswordRotatedAtAnnotation], nil
+}
+
 func (r *Reconciler) getClientSecret() (*corev1.Secret, error) {
 	clientSecret := &corev1.Secret{}
 	// Use that secret as default which has autogenerated for clients by us
@@ -50,6 +50,9 @@ func newCruiseControlTopic(cluster *v1beta1.KafkaCluster) *v1alpha1.KafkaTopic {
 	} else {
 		topicPartitions = cruiseControlTopicPartitions
 		topicReplicationFactor = cruiseControlTopicReplicationFactor
+		if cluster.Spec.IsSingleNodeCombinedMode() {
+			topicReplicationFactor = 1
+		}
 	}
 	return &v1alpha1.KafkaTopic{
 		ObjectMeta: templates.ObjectMeta(
@@ -0,0 +1,143 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskplacement
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/kafkaclient"
+	"github.com/banzaicloud/koperator/pkg/resources"
+	"github.com/banzaicloud/koperator/pkg/resources/templates"
+	"github.com/banzaicloud/koperator/pkg/util/kafka"
+)
+
+const (
+	componentName = "diskPlacement"
+
+	// reassignmentPlanKey is the key of the reassignment plan in the plan ConfigMap
+	reassignmentPlanKey = "reassignment.json"
+)
+
+// Reconciler implements the Component Reconciler
+type Reconciler struct {
+	resources.Reconciler
+	kafkaClientProvider kafkaclient.Provider
+}
+
+// New creates a new reconciler for the disk placement of the replicas
+func New(client client.Client, cluster *v1beta1.KafkaCluster, kafkaClientProvider kafkaclient.Provider) *Reconciler {
+	return &Reconciler{
+		Reconciler: resources.Reconciler{
+			Client:       client,
+			KafkaCluster: cluster,
+		},
+		kafkaClientProvider: kafkaClientProvider,
+	}
+}
+
+// Reconcile generates the reassignment plan moving the replicas which violate the disk placement hints of the cluster
+// and reports the number of misplaced replicas in the status of the cluster. The hints are advisory, the plan is not
+// executed by the reconciler.
+func (r *Reconciler) Reconcile(log logr.Logger) error {
+	log = log.WithValues("component", componentName)
+
+	log.V(1).Info("Reconciling")
+	if len(r.KafkaCluster.Spec.DiskPlacementHints) == 0 {
+		return r.cleanup(log)
+	}
+
+	kClient, closeClient, err := r.kafkaClientProvider.NewFromCluster(r.Client, r.KafkaCluster)
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+
+	topics, err := kClient.ListTopics()
+	if err != nil {
+		return errors.WrapIf(err, "could not list topics")
+	}
+	logDirs, err := kClient.DescribeLogDirs(brokerIDs(r.KafkaCluster))
+	if err != nil {
+		return err
+	}
+
+	plan, misplaced, err := newPlan(r.KafkaCluster, topics, logDirs)
+	if err != nil {
+		return err
+	}
+	configMap, err := r.planConfigMap(plan)
+	if err != nil {
+		return err
+	}
+	if err := k8sutil.Reconcile(log, r.Client, configMap, r.KafkaCluster); err != nil {
+		return err
+	}
+
+	status := &v1beta1.DiskPlacementStatus{MisplacedReplicas: misplaced, PlanConfigMap: configMap.GetName()}
+	if !reflect.DeepEqual(status, r.KafkaCluster.Status.DiskPlacement) {
+		if misplaced > 0 {
+			log.Info("replicas violate the disk placement hints, the plan moving them can be executed with the kafka-reassign-partitions tool",
+				"misplacedReplicas", misplaced, "planConfigMap", configMap.GetName())
+		}
+		if err := k8sutil.UpdateCRStatus(r.Client, r.KafkaCluster, status, log); err != nil {
+			return err
+		}
+	}
+
+	log.V(1).Info("Reconciled")
+
+	return nil
+}
+
+// cleanup removes the plan ConfigMap and the disk placement status once the disk placement hints are removed
+func (r *Reconciler) cleanup(log logr.Logger) error {
+	configMap := &corev1.ConfigMap{}
+	configMap.SetName(fmt.Sprintf(kafka.DiskPlacementPlanConfigMapTemplate, r.KafkaCluster.GetName()))
+	configMap.SetNamespace(r.KafkaCluster.GetNamespace())
+	if err := r.Delete(context.Background(), configMap); client.IgnoreNotFound(err) != nil {
+		return errors.WrapIf(err, "error when removing the disk placement plan")
+	}
+	if r.KafkaCluster.Status.DiskPlacement != nil {
+		return k8sutil.UpdateCRStatus(r.Client, r.KafkaCluster, (*v1beta1.DiskPlacementStatus)(nil), log)
+	}
+	return nil
+}
+
+func (r *Reconciler) planConfigMap(plan reassignmentPlan) (*corev1.ConfigMap, error) {
+	planJSON, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not marshal the disk placement plan")
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: templates.ObjectMeta(
+			fmt.Sprintf(kafka.DiskPlacementPlanConfigMapTemplate, r.KafkaCluster.GetName()),
+			apiutil.LabelsForKafka(r.KafkaCluster.GetName()),
+			r.KafkaCluster,
+		),
+		Data: map[string]string{reassignmentPlanKey: string(planJSON)},
+	}, nil
+}
@@ -0,0 +1,218 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskplacement
+
+import (
+	"regexp"
+	"sort"
+
+	"emperror.dev/errors"
+	"github.com/IBM/sarama"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/util"
+)
+
+// anyLogDir leaves the placement of a replica to the broker in a reassignment plan
+const anyLogDir = "any"
+
+// reassignmentPlan is a reassignment plan in the JSON format of the kafka-reassign-partitions tool
+type reassignmentPlan struct {
+	Version    int                     `json:"version"`
+	Partitions []partitionReassignment `json:"partitions"`
+}
+
+type partitionReassignment struct {
+	Topic     string   `json:"topic"`
+	Partition int32    `json:"partition"`
+	Replicas  []int32  `json:"replicas"`
+	LogDirs   []string `json:"log_dirs"`
+}
+
+type diskPlacementHint struct {
+	topicPattern *regexp.Regexp
+	diskClass    string
+}
+
+// brokerLogDirs holds the log directories of a broker with their disk class and the replicas they host
+type brokerLogDirs struct {
+	diskClasses map[string]string
+	// replicaLogDirs holds the log directory of the replicas by topic and partition
+	replicaLogDirs map[string]map[int32]string
+	// numReplicas holds the number of replicas by log directory
+	numReplicas map[string]int
+}
+
+// newPlan returns the reassignment plan moving the replicas which are not on a disk of the class hinted for their
+// topic to the least loaded disk of the hinted class of their broker, together with the number of misplaced replicas.
+// The replicas of brokers which have no disk of the hinted class are counted as misplaced but left out of the plan.
+func newPlan(cluster *v1beta1.KafkaCluster, topics map[string]sarama.TopicDetail,
+	logDirs map[int32][]sarama.DescribeLogDirsResponseDirMetadata) (reassignmentPlan, int32, error) {
+	plan := reassignmentPlan{Version: 1, Partitions: []partitionReassignment{}}
+
+	hints := make([]diskPlacementHint, 0, len(cluster.Spec.DiskPlacementHints))
+	for _, hint := range cluster.Spec.DiskPlacementHints {
+		topicPattern, err := regexp.Compile("^(?:" + hint.TopicPattern + ")$")
+		if err != nil {
+			return plan, 0, errors.WrapIfWithDetails(err, "invalid disk placement hint topic pattern", "topicPattern", hint.TopicPattern)
+		}
+		hints = append(hints, diskPlacementHint{topicPattern: topicPattern, diskClass: hint.DiskClass})
+	}
+
+	brokers, err := newBrokerLogDirs(cluster, logDirs)
+	if err != nil {
+		return plan, 0, err
+	}
+
+	topicNames := make([]string, 0, len(topics))
+	for topic := range topics {
+		topicNames = append(topicNames, topic)
+	}
+	sort.Strings(topicNames)
+
+	var misplaced int32
+	for _, topic := range topicNames {
+		diskClass, found := hintedDiskClass(hints, topic)
+		if !found {
+			continue
+		}
+		replicaAssignment := topics[topic].ReplicaAssignment
+		partitions := make([]int32, 0, len(replicaAssignment))
+		for partition := range replicaAssignment {
+			partitions = append(partitions, partition)
+		}
+		sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+
+		for _, partition := range partitions {
+			replicas := replicaAssignment[partition]
+			reassignment := partitionReassignment{
+				Topic:     topic,
+				Partition: partition,
+				Replicas:  replicas,
+				LogDirs:   make([]string, len(replicas)),
+			}
+			moved := false
+			for i, brokerID := range replicas {
+				reassignment.LogDirs[i] = anyLogDir
+				broker, found := brokers[brokerID]
+				if !found {
+					continue
+				}
+				logDir, found := broker.replicaLogDirs[topic][partition]
+				if !found || broker.diskClasses[logDir] == diskClass {
+					continue
+				}
+				misplaced++
+				if target := broker.leastLoadedLogDir(diskClass); target != "" {
+					broker.numReplicas[logDir]--
+					broker.numReplicas[target]++
+					reassignment.LogDirs[i] = target
+					moved = true
+				}
+			}
+			if moved {
+				plan.Partitions = append(plan.Partitions, reassignment)
+			}
+		}
+	}
+	return plan, misplaced, nil
+}
+
+func newBrokerLogDirs(cluster *v1beta1.KafkaCluster,
+	logDirs map[int32][]sarama.DescribeLogDirsResponseDirMetadata) (map[int32]*brokerLogDirs, error) {
+	brokers := make(map[int32]*brokerLogDirs, len(cluster.Spec.Brokers))
+	for i := range cluster.Spec.Brokers {
+		broker := &cluster.Spec.Brokers[i]
+		brokerConfig, err := broker.GetBrokerConfig(cluster.Spec)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "failed to determine broker config", "brokerId", broker.Id)
+		}
+		dirs := &brokerLogDirs{
+			diskClasses:    make(map[string]string, len(brokerConfig.StorageConfigs)),
+			replicaLogDirs: make(map[string]map[int32]string),
+			numReplicas:    make(map[string]int, len(brokerConfig.StorageConfigs)),
+		}
+		for _, storage := range brokerConfig.StorageConfigs {
+			logDir := util.StorageConfigKafkaMountPath(storage.MountPath)
+			dirs.diskClasses[logDir] = storage.DiskClass
+			dirs.numReplicas[logDir] = 0
+		}
+		for _, logDir := range logDirs[broker.Id] {
+			if logDir.ErrorCode != sarama.ErrNoError {
+				// offline log directories can not be the target of a move
+				delete(dirs.numReplicas, logDir.Path)
+				continue
+			}
+			for _, topic := range logDir.Topics {
+				if dirs.replicaLogDirs[topic.Topic] == nil {
+					dirs.replicaLogDirs[topic.Topic] = make(map[int32]string)
+				}
+				for _, partition := range topic.Partitions {
+					// the future replicas of an ongoing move are reported with the current ones, the current one wins
+					if partition.IsTemporary {
+						continue
+					}
+					dirs.replicaLogDirs[topic.Topic][partition.PartitionID] = logDir.Path
+					if _, found := dirs.numReplicas[logDir.Path]; found {
+						dirs.numReplicas[logDir.Path]++
+					}
+				}
+			}
+		}
+		brokers[broker.Id] = dirs
+	}
+	return brokers, nil
+}
+
+// leastLoadedLogDir returns the online log directory of the disk class hosting the fewest replicas, empty if the
+// broker has no such log directory
+func (b *brokerLogDirs) leastLoadedLogDir(diskClass string) string {
+	var candidates []string
+	for logDir := range b.numReplicas {
+		if b.diskClasses[logDir] == diskClass {
+			candidates = append(candidates, logDir)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if b.numReplicas[candidates[i]] != b.numReplicas[candidates[j]] {
+			return b.numReplicas[candidates[i]] < b.numReplicas[candidates[j]]
+		}
+		return candidates[i] < candidates[j]
+	})
+	if len(candidates) == 0 {
+		return ""
+	}
+	return candidates[0]
+}
+
+// hintedDiskClass returns the disk class of the first hint matching the topic
+func hintedDiskClass(hints []diskPlacementHint, topic string) (string, bool) {
+	for _, hint := range hints {
+		if hint.topicPattern.MatchString(topic) {
+			return hint.diskClass, true
+		}
+	}
+	return "", false
+}
+
+// brokerIDs returns the ids of the brokers of the cluster
+func brokerIDs(cluster *v1beta1.KafkaCluster) []int32 {
+	ids := make([]int32, 0, len(cluster.Spec.Brokers))
+	for _, broker := range cluster.Spec.Brokers {
+		ids = append(ids, broker.Id)
+	}
+	return ids
+}
@@ -0,0 +1,83 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskplacement
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func TestNewPlan(t *testing.T) {
+	cluster := &v1beta1.KafkaCluster{
+		Spec: v1beta1.KafkaClusterSpec{
+			Brokers: []v1beta1.Broker{
+				{Id: 0, BrokerConfig: &v1beta1.BrokerConfig{StorageConfigs: []v1beta1.StorageConfig{
+					{MountPath: "/nvme-0", DiskClass: "nvme"},
+					{MountPath: "/hdd-0", DiskClass: "hdd"},
+					{MountPath: "/hdd-1", DiskClass: "hdd"},
+				}}},
+				{Id: 1, BrokerConfig: &v1beta1.BrokerConfig{StorageConfigs: []v1beta1.StorageConfig{
+					{MountPath: "/hdd-0", DiskClass: "hdd"},
+				}}},
+			},
+			DiskPlacementHints: []v1beta1.DiskPlacementHint{
+				{TopicPattern: "orders-.*", DiskClass: "nvme"},
+				{TopicPattern: "archive", DiskClass: "hdd"},
+			},
+		},
+	}
+	topics := map[string]sarama.TopicDetail{
+		"orders-eu": {ReplicaAssignment: map[int32][]int32{0: {0, 1}}},
+		"archive":   {ReplicaAssignment: map[int32][]int32{0: {0}}},
+		"other":     {ReplicaAssignment: map[int32][]int32{0: {0}}},
+	}
+	logDir := func(path string, topics ...string) sarama.DescribeLogDirsResponseDirMetadata {
+		dir := sarama.DescribeLogDirsResponseDirMetadata{Path: path}
+		for _, topic := range topics {
+			dir.Topics = append(dir.Topics, sarama.DescribeLogDirsResponseTopic{
+				Topic:      topic,
+				Partitions: []sarama.DescribeLogDirsResponsePartition{{PartitionID: 0}},
+			})
+		}
+		return dir
+	}
+	logDirs := map[int32][]sarama.DescribeLogDirsResponseDirMetadata{
+		0: {
+			logDir("/nvme-0/kafka", "archive"),
+			logDir("/hdd-0/kafka", "orders-eu", "other"),
+			logDir("/hdd-1/kafka"),
+		},
+		1: {
+			logDir("/hdd-0/kafka", "orders-eu"),
+		},
+	}
+
+	plan, misplaced, err := newPlan(cluster, topics, logDirs)
+	require.NoError(t, err)
+	// the replica of orders-eu on broker 1 is misplaced but the broker has no nvme disk to move it to
+	require.Equal(t, int32(3), misplaced)
+	require.Equal(t, reassignmentPlan{
+		Version: 1,
+		Partitions: []partitionReassignment{
+			{Topic: "archive", Partition: 0, Replicas: []int32{0}, LogDirs: []string{"/hdd-1/kafka"}},
+			{Topic: "orders-eu", Partition: 0, Replicas: []int32{0, 1}, LogDirs: []string{"/nvme-0/kafka", anyLogDir}},
+		},
+	}, plan)
+}
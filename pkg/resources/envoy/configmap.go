@@ -30,8 +30,11 @@ import (
 	envoystdoutaccesslog "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/stream/v3"
 	envoyhttphealthcheck "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/health_check/v3"
 	envoyhttprouter "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/router/v3"
+	proxy_protocolv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/listener/proxy_protocol/v3"
 	tls_inspectorv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/listener/tls_inspector/v3"
+	envoyconnectionlimit "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/connection_limit/v3"
 	envoyhcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	envoylocalratelimit "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/local_ratelimit/v3"
 	envoytcpproxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
 	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	envoytypesmatcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
@@ -218,7 +221,54 @@ func generateEnvoyHealthCheckListener(ingressConfig v1beta1.IngressConfig, log l
 	}
 }
 
-func GenerateEnvoyTLSFilterChain(tcpProxy *envoytcpproxy.TcpProxy, brokerFqdn string, log logr.Logger) (*envoylistener.FilterChain, error) {
+// generateEnvoyListenerFilters returns the network filters enforcing the connection limits of the listener config,
+// they have to precede the tcp_proxy filter of the filter chain
+func generateEnvoyListenerFilters(listenerConfig *v1beta1.EnvoyListenerConfig, statPrefix string, log logr.Logger) ([]*envoylistener.Filter, error) {
+	if listenerConfig == nil {
+		return nil, nil
+	}
+	var filters []*envoylistener.Filter
+	if listenerConfig.MaxConnections != nil {
+		pbConnectionLimit, err := anypb.New(&envoyconnectionlimit.ConnectionLimit{
+			StatPrefix:     statPrefix,
+			MaxConnections: wrapperspb.UInt64(uint64(*listenerConfig.MaxConnections)),
+		})
+		if err != nil {
+			log.Error(err, "could not marshall envoy connection_limit config")
+			return nil, err
+		}
+		filters = append(filters, &envoylistener.Filter{
+			Name: envoyutils.ConnectionLimitFilterName,
+			ConfigType: &envoylistener.Filter_TypedConfig{
+				TypedConfig: pbConnectionLimit,
+			},
+		})
+	}
+	if rateLimit := listenerConfig.ConnectionRateLimit; rateLimit != nil {
+		pbLocalRateLimit, err := anypb.New(&envoylocalratelimit.LocalRateLimit{
+			StatPrefix: statPrefix,
+			TokenBucket: &envoytypes.TokenBucket{
+				MaxTokens:     uint32(rateLimit.MaxTokens),
+				TokensPerFill: wrapperspb.UInt32(uint32(rateLimit.GetTokensPerFill())),
+				FillInterval:  durationpb.New(rateLimit.FillInterval.Duration),
+			},
+		})
+		if err != nil {
+			log.Error(err, "could not marshall envoy local_ratelimit config")
+			return nil, err
+		}
+		filters = append(filters, &envoylistener.Filter{
+			Name: envoyutils.LocalRateLimitFilterName,
+			ConfigType: &envoylistener.Filter_TypedConfig{
+				TypedConfig: pbLocalRateLimit,
+			},
+		})
+	}
+	return filters, nil
+}
+
+func GenerateEnvoyTLSFilterChain(tcpProxy *envoytcpproxy.TcpProxy, listenerFilters []*envoylistener.Filter, brokerFqdn string,
+	log logr.Logger) (*envoylistener.FilterChain, error) {
 	tlsContext := &tlsv3.DownstreamTlsContext{
 		CommonTlsContext: &tlsv3.CommonTlsContext{
 			TlsParams: &tlsv3.TlsParameters{
@@ -271,14 +321,13 @@ func GenerateEnvoyTLSFilterChain(tcpProxy *envoytcpproxy.TcpProxy, brokerFqdn st
 				TypedConfig: pbTlsContext,
 			},
 		},
-		Filters: []*envoylistener.Filter{
-			brokerTcpProxyFilter,
-		},
+		Filters: append(listenerFilters, brokerTcpProxyFilter),
 	}
 	return filterChain, nil
 }
 
-func GenerateEnvoyFilterChain(tcpProxy *envoytcpproxy.TcpProxy, log logr.Logger) (*envoylistener.FilterChain, error) {
+func GenerateEnvoyFilterChain(tcpProxy *envoytcpproxy.TcpProxy, listenerFilters []*envoylistener.Filter,
+	log logr.Logger) (*envoylistener.FilterChain, error) {
 	pbstTcpProxy, err := anypb.New(tcpProxy)
 	if err != nil {
 		log.Error(err, "could not marshall envoy tcp_proxy config")
@@ -293,9 +342,7 @@ func GenerateEnvoyFilterChain(tcpProxy *envoytcpproxy.TcpProxy, log logr.Logger)
 	}
 
 	filterChain := &envoylistener.FilterChain{
-		Filters: []*envoylistener.Filter{
-			brokerTcpProxyFilter,
-		},
+		Filters: append(listenerFilters, brokerTcpProxyFilter),
 	}
 	return filterChain, nil
 }
@@ -334,14 +381,20 @@ func GenerateEnvoyConfig(kc *v1beta1.KafkaCluster, elistener v1beta1.ExternalLis
 			tcpProxy := &envoytcpproxy.TcpProxy{
 				StatPrefix:         fmt.Sprintf("broker_tcp-%d", brokerId),
 				MaxConnectAttempts: &wrapperspb.UInt32Value{Value: 2},
-				IdleTimeout:        &durationpb.Duration{Seconds: 560},
+				IdleTimeout:        durationpb.New(ingressConfig.EnvoyConfig.GetListenerIdleTimeout()),
 				ClusterSpecifier: &envoytcpproxy.TcpProxy_Cluster{
 					Cluster: fmt.Sprintf("broker-%d", brokerId),
 				},
 			}
 
+			listenerFilters, err := generateEnvoyListenerFilters(ingressConfig.EnvoyConfig.ListenerConfig, tcpProxy.StatPrefix, log)
+			if err != nil {
+				log.Error(err, "Unable to generate broker envoy listener filters")
+				return ""
+			}
+
 			if elistener.TLSEnabled() {
-				filterChain, err = GenerateEnvoyTLSFilterChain(tcpProxy, func() string {
+				filterChain, err = GenerateEnvoyTLSFilterChain(tcpProxy, listenerFilters, func() string {
 					// Broker IDs are always within valid range for int32 conversion
 					if brokerId < 0 || brokerId > math.MaxInt32 {
 						// This should never happen as broker IDs are small positive integers
@@ -355,7 +408,7 @@ func GenerateEnvoyConfig(kc *v1beta1.KafkaCluster, elistener v1beta1.ExternalLis
 					return ""
 				}
 			} else {
-				filterChain, err = GenerateEnvoyFilterChain(tcpProxy, log)
+				filterChain, err = GenerateEnvoyFilterChain(tcpProxy, listenerFilters, log)
 				if err != nil {
 					log.Error(err, "Unable to generate broker envoy filter chain")
 					return ""
@@ -430,22 +483,28 @@ func GenerateEnvoyConfig(kc *v1beta1.KafkaCluster, elistener v1beta1.ExternalLis
 	// TCP_Proxy filter configuration
 	tcpProxy := &envoytcpproxy.TcpProxy{
 		StatPrefix:         envoyutils.AllBrokerEnvoyConfigName,
-		IdleTimeout:        &durationpb.Duration{Seconds: 560},
+		IdleTimeout:        durationpb.New(ingressConfig.EnvoyConfig.GetListenerIdleTimeout()),
 		MaxConnectAttempts: &wrapperspb.UInt32Value{Value: 2},
 		ClusterSpecifier: &envoytcpproxy.TcpProxy_Cluster{
 			Cluster: envoyutils.AllBrokerEnvoyConfigName,
 		},
 	}
 
+	listenerFilters, err := generateEnvoyListenerFilters(ingressConfig.EnvoyConfig.ListenerConfig, tcpProxy.StatPrefix, log)
+	if err != nil {
+		log.Error(err, "Unable to generate anycast envoy listener filters")
+		return ""
+	}
+
 	// Create TLS anycast broker listener
 	if elistener.TLSEnabled() {
-		filterChain, err = GenerateEnvoyTLSFilterChain(tcpProxy, ingressConfig.HostnameOverride, log)
+		filterChain, err = GenerateEnvoyTLSFilterChain(tcpProxy, listenerFilters, ingressConfig.HostnameOverride, log)
 		if err != nil {
 			log.Error(err, "Unable to generate anycast envoy tls filter chain")
 			return ""
 		}
 	} else {
-		filterChain, err = GenerateEnvoyFilterChain(tcpProxy, log)
+		filterChain, err = GenerateEnvoyFilterChain(tcpProxy, listenerFilters, log)
 		if err != nil {
 			log.Error(err, "Unable to generate anycast envoy filter chain")
 			return ""
@@ -464,6 +523,9 @@ func GenerateEnvoyConfig(kc *v1beta1.KafkaCluster, elistener v1beta1.ExternalLis
 	tlsListenerFilter := &tls_inspectorv3.TlsInspector{}
 	pbTlsListenerFilter, _ := anypb.New(tlsListenerFilter)
 
+	proxyProtocolListenerFilter := &proxy_protocolv3.ProxyProtocol{}
+	pbProxyProtocolListenerFilter, _ := anypb.New(proxyProtocolListenerFilter)
+
 	for _, p := range ports {
 		newListener := &envoylistener.Listener{
 			Address: &envoycore.Address{
@@ -480,15 +542,22 @@ func GenerateEnvoyConfig(kc *v1beta1.KafkaCluster, elistener v1beta1.ExternalLis
 			SocketOptions: getKeepAliveSocketOptions(),
 		}
 
+		// the PROXY protocol header precedes the TLS client hello, so its filter has to run first
+		if listenerConfig := ingressConfig.EnvoyConfig.ListenerConfig; listenerConfig != nil && listenerConfig.ProxyProtocol {
+			newListener.ListenerFilters = append(newListener.ListenerFilters, &envoylistener.ListenerFilter{
+				Name: wellknown.ProxyProtocol,
+				ConfigType: &envoylistener.ListenerFilter_TypedConfig{
+					TypedConfig: pbProxyProtocolListenerFilter,
+				},
+			})
+		}
 		if elistener.TLSEnabled() {
-			newListener.ListenerFilters = []*envoylistener.ListenerFilter{
-				{
-					Name: "tls_inspector",
-					ConfigType: &envoylistener.ListenerFilter_TypedConfig{
-						TypedConfig: pbTlsListenerFilter,
-					},
+			newListener.ListenerFilters = append(newListener.ListenerFilters, &envoylistener.ListenerFilter{
+				Name: "tls_inspector",
+				ConfigType: &envoylistener.ListenerFilter_TypedConfig{
+					TypedConfig: pbTlsListenerFilter,
 				},
-			}
+			})
 		}
 		listeners = append(listeners, newListener)
 	}
@@ -0,0 +1,112 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package envoy
+
+import (
+	"testing"
+	"time"
+
+	envoybootstrap "github.com/envoyproxy/go-control-plane/envoy/config/bootstrap/v3"
+	envoytcpproxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	envoyutils "github.com/banzaicloud/koperator/pkg/util/envoy"
+)
+
+func TestGenerateEnvoyConfigListenerConfig(t *testing.T) {
+	testCases := []struct {
+		testName                string
+		listenerConfig          *v1beta1.EnvoyListenerConfig
+		expectedListenerFilters []string
+		expectedNetworkFilters  []string
+		expectedIdleTimeout     time.Duration
+	}{
+		{
+			testName:               "default listeners",
+			expectedNetworkFilters: []string{wellknown.TCPProxy},
+			expectedIdleTimeout:    560 * time.Second,
+		},
+		{
+			testName: "connection limits, proxy protocol and idle timeout",
+			listenerConfig: &v1beta1.EnvoyListenerConfig{
+				MaxConnections: func(i int64) *int64 { return &i }(100),
+				ConnectionRateLimit: &v1beta1.EnvoyConnectionRateLimit{
+					MaxTokens:    10,
+					FillInterval: metav1.Duration{Duration: time.Second},
+				},
+				ProxyProtocol: true,
+				IdleTimeout:   &metav1.Duration{Duration: time.Minute},
+			},
+			expectedListenerFilters: []string{wellknown.ProxyProtocol},
+			expectedNetworkFilters: []string{
+				envoyutils.ConnectionLimitFilterName, envoyutils.LocalRateLimitFilterName, wellknown.TCPProxy,
+			},
+			expectedIdleTimeout: time.Minute,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Spec: v1beta1.KafkaClusterSpec{
+					Brokers: []v1beta1.Broker{{Id: 0, BrokerConfig: &v1beta1.BrokerConfig{}}},
+				},
+			}
+			extListener := v1beta1.ExternalListenerConfig{
+				CommonListenerSpec:   v1beta1.CommonListenerSpec{Name: "external", Type: v1beta1.SecurityProtocolPlaintext, ContainerPort: 9094},
+				ExternalStartingPort: 19090,
+			}
+			ingressConfig := v1beta1.IngressConfig{EnvoyConfig: &v1beta1.EnvoyConfig{ListenerConfig: test.listenerConfig}}
+
+			generated := GenerateEnvoyConfig(cluster, extListener, ingressConfig, "", "", logr.Discard())
+			require.NotEmpty(t, generated)
+			jsonConfig, err := yaml.YAMLToJSON([]byte(generated))
+			require.NoError(t, err)
+			bootstrap := &envoybootstrap.Bootstrap{}
+			require.NoError(t, protojson.Unmarshal(jsonConfig, bootstrap))
+
+			// the broker and the any cast listeners precede the health-check listener
+			listeners := bootstrap.GetStaticResources().GetListeners()
+			require.Len(t, listeners, 3)
+			for _, listener := range listeners[:2] {
+				var listenerFilters []string
+				for _, filter := range listener.GetListenerFilters() {
+					listenerFilters = append(listenerFilters, filter.GetName())
+				}
+				require.Equal(t, test.expectedListenerFilters, listenerFilters)
+
+				require.Len(t, listener.GetFilterChains(), 1)
+				filters := listener.GetFilterChains()[0].GetFilters()
+				var networkFilters []string
+				for _, filter := range filters {
+					networkFilters = append(networkFilters, filter.GetName())
+				}
+				require.Equal(t, test.expectedNetworkFilters, networkFilters)
+
+				tcpProxy := &envoytcpproxy.TcpProxy{}
+				require.NoError(t, filters[len(filters)-1].GetTypedConfig().UnmarshalTo(tcpProxy))
+				require.Equal(t, test.expectedIdleTimeout, tcpProxy.GetIdleTimeout().AsDuration())
+			}
+		})
+	}
+}
@@ -69,7 +69,7 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 
 	log.V(1).Info("Reconciling")
 	for _, eListener := range r.KafkaCluster.Spec.ListenersConfig.ExternalListeners {
-		if r.KafkaCluster.Spec.GetIngressController() == envoyutils.IngressControllerName && eListener.GetAccessMethod() == corev1.ServiceTypeLoadBalancer {
+		if r.KafkaCluster.Spec.GetIngressControllerForListener(eListener) == envoyutils.IngressControllerName && eListener.GetAccessMethod() == corev1.ServiceTypeLoadBalancer {
 			ingressConfigs, defaultControllerName, err := util.GetIngressConfigs(r.KafkaCluster.Spec, eListener)
 			if err != nil {
 				return err
@@ -97,7 +97,7 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 					}
 				}
 			}
-		} else if r.KafkaCluster.Spec.RemoveUnusedIngressResources {
+		} else if util.ShouldRemoveUnusedIngressResources(r.KafkaCluster, eListener.Name) {
 			// Cleaning up unused envoy resources when ingress controller is not envoy or externalListener access method is not LoadBalancer
 			deletionCounter := 0
 			ctx := context.Background()
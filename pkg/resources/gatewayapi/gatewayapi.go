@@ -0,0 +1,341 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gatewayapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/resources"
+	"github.com/banzaicloud/koperator/pkg/resources/templates"
+	"github.com/banzaicloud/koperator/pkg/util"
+	gatewayapiutils "github.com/banzaicloud/koperator/pkg/util/gatewayapi"
+)
+
+const (
+	componentName = "gatewayApiExternalAccess"
+)
+
+var (
+	serviceGVK  = corev1.SchemeGroupVersion.WithKind("Service")
+	gatewayGVK  = gatewayv1.SchemeGroupVersion.WithKind("Gateway")
+	tcpRouteGVK = gatewayv1alpha2.SchemeGroupVersion.WithKind("TCPRoute")
+	tlsRouteGVK = gatewayv1alpha2.SchemeGroupVersion.WithKind("TLSRoute")
+)
+
+// Reconciler implements the Component Reconciler
+type Reconciler struct {
+	resources.Reconciler
+}
+
+// New creates a new reconciler for Gateway API based external access
+func New(client client.Client, cluster *v1beta1.KafkaCluster) *Reconciler {
+	return &Reconciler{
+		Reconciler: resources.Reconciler{
+			Client:       client,
+			KafkaCluster: cluster,
+		},
+	}
+}
+
+// Reconcile implements the reconcile logic for Gateway API based external access
+func (r *Reconciler) Reconcile(log logr.Logger) error {
+	log = log.WithValues("component", componentName)
+	log.V(1).Info("Reconciling")
+
+	for _, eListener := range r.KafkaCluster.Spec.ListenersConfig.ExternalListeners {
+		if r.KafkaCluster.Spec.GetIngressControllerForListener(eListener) == gatewayapiutils.IngressControllerName &&
+			eListener.GetAccessMethod() == corev1.ServiceTypeLoadBalancer {
+			ingressConfigs, defaultControllerName, err := util.GetIngressConfigs(r.KafkaCluster.Spec, eListener)
+			if err != nil {
+				return err
+			}
+			var reconcileObjects []runtime.Object
+			// the routes of the route type which is not in use are left behind when the route type is changed
+			unusedRouteGVKs := map[schema.GroupVersionKind]struct{}{tcpRouteGVK: {}, tlsRouteGVK: {}}
+			for name, ingressConfig := range ingressConfigs {
+				if !util.IsIngressConfigInUse(name, defaultControllerName, r.KafkaCluster, log) {
+					continue
+				}
+				routeType := ingressConfig.GatewayAPIConfig.GetRouteType()
+				if routeType == v1beta1.GatewayAPIRouteTypeTLS {
+					delete(unusedRouteGVKs, tlsRouteGVK)
+				} else {
+					delete(unusedRouteGVKs, tcpRouteGVK)
+				}
+
+				gateway := r.gateway(eListener, ingressConfig, name)
+				reconcileObjects = append(reconcileObjects, gateway)
+
+				allBrokerService := r.allBrokerService(eListener, ingressConfig, name)
+				reconcileObjects = append(reconcileObjects, allBrokerService,
+					r.route(eListener, ingressConfig, gateway.Name, fmt.Sprintf(gatewayapiutils.AllBrokerRouteNameTemplate, gateway.Name),
+						gatewayapiutils.AllBrokerListenerName, ingressConfig.HostnameOverride, allBrokerService))
+
+				for _, broker := range r.KafkaCluster.Spec.Brokers {
+					brokerService := r.brokerService(broker.Id, eListener)
+					reconcileObjects = append(reconcileObjects, brokerService,
+						r.route(eListener, ingressConfig, gateway.Name, fmt.Sprintf(gatewayapiutils.BrokerRouteNameTemplate, gateway.Name, broker.Id),
+							fmt.Sprintf(gatewayapiutils.BrokerListenerNameTemplate, broker.Id),
+							ingressConfig.GatewayAPIConfig.GetBrokerHostname(broker.Id), brokerService))
+				}
+			}
+
+			for _, obj := range reconcileObjects {
+				if err = k8sutil.Reconcile(log, r.Client, obj, r.KafkaCluster); err != nil {
+					return err
+				}
+			}
+
+			gvks := make([]schema.GroupVersionKind, 0, len(unusedRouteGVKs))
+			for gvk := range unusedRouteGVKs {
+				gvks = append(gvks, gvk)
+			}
+			if err = r.removeResources(log, eListener.Name, gvks); err != nil {
+				return err
+			}
+		} else if util.ShouldRemoveUnusedIngressResources(r.KafkaCluster, eListener.Name) {
+			// Cleaning up unused Gateway API resources when ingress controller is not gatewayapi or externalListener access method is not LoadBalancer
+			if err := r.removeResources(log, eListener.Name, []schema.GroupVersionKind{serviceGVK, gatewayGVK, tcpRouteGVK, tlsRouteGVK}); err != nil {
+				return err
+			}
+		}
+	}
+
+	log.V(1).Info("Reconciled")
+
+	return nil
+}
+
+// removeResources removes the resources of the given kinds created for the external listener
+func (r *Reconciler) removeResources(log logr.Logger, eListenerName string, gvks []schema.GroupVersionKind) error {
+	deletionCounter := 0
+	ctx := context.Background()
+	var gatewayResources unstructured.UnstructuredList
+	for _, gvk := range gvks {
+		gatewayResources.SetGroupVersionKind(gvk)
+
+		if err := r.List(ctx, &gatewayResources, client.InNamespace(r.KafkaCluster.GetNamespace()),
+			client.MatchingLabels(labelsForGatewayAPIWithoutEListenerName(r.KafkaCluster.Name))); err != nil {
+			// the Gateway API CRDs are not installed, there is nothing to remove
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return errors.Wrap(err, "error when getting list of gateway api resources for deletion")
+		}
+
+		for _, removeObject := range gatewayResources.Items {
+			if !strings.Contains(removeObject.GetLabels()[util.ExternalListenerLabelNameKey], eListenerName) ||
+				util.ObjectManagedByClusterRegistry(&removeObject) ||
+				!removeObject.GetDeletionTimestamp().IsZero() {
+				continue
+			}
+			if err := r.Delete(ctx, &removeObject); client.IgnoreNotFound(err) != nil {
+				return errors.Wrap(err, "error when removing gateway api resources")
+			}
+			log.V(1).Info(fmt.Sprintf("Deleted gateway api '%s' resource '%s' for externalListener '%s'", gvk.Kind, removeObject.GetName(), eListenerName))
+			deletionCounter++
+		}
+	}
+	if deletionCounter > 0 {
+		log.Info(fmt.Sprintf("Removed '%d' resources for gateway api", deletionCounter))
+	}
+	return nil
+}
+
+// gateway generates the Gateway exposing the external listener. With TCPRoute every broker gets a dedicated listener
+// on its external port next to the anycast listener, with TLSRoute a single TLS passthrough listener serves all of them.
+func (r *Reconciler) gateway(extListener v1beta1.ExternalListenerConfig, ingressConfig v1beta1.IngressConfig,
+	ingressConfigName string) *gatewayv1.Gateway {
+	gatewayName := util.GenerateEnvoyResourceName(gatewayapiutils.GatewayName, gatewayapiutils.GatewayNameWithScope,
+		extListener, ingressConfig, ingressConfigName, r.KafkaCluster.GetName())
+
+	var listeners []gatewayv1.Listener
+	if ingressConfig.GatewayAPIConfig.GetRouteType() == v1beta1.GatewayAPIRouteTypeTLS {
+		passthrough := gatewayv1.TLSModePassthrough
+		listeners = append(listeners, gatewayv1.Listener{
+			Name:     gatewayapiutils.AllBrokerListenerName,
+			Port:     gatewayv1.PortNumber(extListener.GetAnyCastPort()),
+			Protocol: gatewayv1.TLSProtocolType,
+			TLS:      &gatewayv1.GatewayTLSConfig{Mode: &passthrough},
+			AllowedRoutes: &gatewayv1.AllowedRoutes{
+				Kinds: []gatewayv1.RouteGroupKind{{Kind: gatewayv1.Kind(tlsRouteGVK.Kind)}},
+			},
+		})
+	} else {
+		listeners = append(listeners, tcpListener(gatewayapiutils.AllBrokerListenerName, extListener.GetAnyCastPort()))
+		for _, broker := range r.KafkaCluster.Spec.Brokers {
+			listeners = append(listeners, tcpListener(fmt.Sprintf(gatewayapiutils.BrokerListenerNameTemplate, broker.Id),
+				extListener.GetBrokerPort(broker.Id)))
+		}
+	}
+
+	var infrastructure *gatewayv1.GatewayInfrastructure
+	if serviceAnnotations := ingressConfig.GetServiceAnnotations(); len(serviceAnnotations) > 0 {
+		infrastructure = &gatewayv1.GatewayInfrastructure{Annotations: make(map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue, len(serviceAnnotations))}
+		for k, v := range serviceAnnotations {
+			infrastructure.Annotations[gatewayv1.AnnotationKey(k)] = gatewayv1.AnnotationValue(v)
+		}
+	}
+
+	return &gatewayv1.Gateway{
+		ObjectMeta: templates.ObjectMetaWithAnnotations(
+			gatewayName,
+			apiutil.MergeLabels(
+				apiutil.LabelsForKafka(r.KafkaCluster.Name),
+				labelsForGatewayAPI(r.KafkaCluster.Name, extListener.Name)),
+			ingressConfig.GatewayAPIConfig.GetAnnotations(), r.KafkaCluster),
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: gatewayv1.ObjectName(ingressConfig.GatewayAPIConfig.GatewayClassName),
+			Listeners:        listeners,
+			Infrastructure:   infrastructure,
+		},
+	}
+}
+
+func tcpListener(name string, port int32) gatewayv1.Listener {
+	return gatewayv1.Listener{
+		Name:     gatewayv1.SectionName(name),
+		Port:     gatewayv1.PortNumber(port),
+		Protocol: gatewayv1.TCPProtocolType,
+		AllowedRoutes: &gatewayv1.AllowedRoutes{
+			Kinds: []gatewayv1.RouteGroupKind{{Kind: gatewayv1.Kind(tcpRouteGVK.Kind)}},
+		},
+	}
+}
+
+// route generates the TCPRoute or TLSRoute forwarding the traffic of the Gateway to the service. TCPRoutes are bound
+// to the listener of the Gateway with the given name, TLSRoutes are matched by the given SNI hostname instead.
+func (r *Reconciler) route(extListener v1beta1.ExternalListenerConfig, ingressConfig v1beta1.IngressConfig,
+	gatewayName, name, listenerName, hostname string, service *corev1.Service) runtime.Object {
+	objectMeta := templates.ObjectMeta(name,
+		apiutil.MergeLabels(
+			apiutil.LabelsForKafka(r.KafkaCluster.Name),
+			labelsForGatewayAPI(r.KafkaCluster.Name, extListener.Name)),
+		r.KafkaCluster)
+	port := gatewayv1.PortNumber(service.Spec.Ports[0].Port)
+	backendRefs := []gatewayv1.BackendRef{{
+		BackendObjectReference: gatewayv1.BackendObjectReference{
+			Name: gatewayv1.ObjectName(service.GetName()),
+			Port: &port,
+		},
+	}}
+
+	if ingressConfig.GatewayAPIConfig.GetRouteType() == v1beta1.GatewayAPIRouteTypeTLS {
+		var hostnames []gatewayv1.Hostname
+		if hostname != "" {
+			hostnames = append(hostnames, gatewayv1.Hostname(hostname))
+		}
+		return &gatewayv1alpha2.TLSRoute{
+			ObjectMeta: objectMeta,
+			Spec: gatewayv1alpha2.TLSRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: gatewayv1.ObjectName(gatewayName)}},
+				},
+				Hostnames: hostnames,
+				Rules:     []gatewayv1alpha2.TLSRouteRule{{BackendRefs: backendRefs}},
+			},
+		}
+	}
+
+	sectionName := gatewayv1.SectionName(listenerName)
+	return &gatewayv1alpha2.TCPRoute{
+		ObjectMeta: objectMeta,
+		Spec: gatewayv1alpha2.TCPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: gatewayv1.ObjectName(gatewayName), SectionName: &sectionName}},
+			},
+			Rules: []gatewayv1alpha2.TCPRouteRule{{BackendRefs: backendRefs}},
+		},
+	}
+}
+
+// generate the backend service of the route of a broker
+func (r *Reconciler) brokerService(id int32, extListener v1beta1.ExternalListenerConfig) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: templates.ObjectMeta(
+			fmt.Sprintf(gatewayapiutils.BrokerServiceNameTemplate, r.KafkaCluster.GetName(), id, strings.ReplaceAll(extListener.Name, "_", "-")),
+			apiutil.MergeLabels(
+				apiutil.LabelsForKafka(r.KafkaCluster.Name),
+				map[string]string{v1beta1.BrokerIdLabelKey: fmt.Sprintf("%d", id)},
+				labelsForGatewayAPI(r.KafkaCluster.Name, extListener.Name)),
+			r.KafkaCluster),
+		Spec: corev1.ServiceSpec{
+			Selector: apiutil.MergeLabels(apiutil.LabelsForKafka(r.KafkaCluster.Name),
+				map[string]string{v1beta1.BrokerIdLabelKey: fmt.Sprintf("%d", id)}),
+			Type: corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{{
+				Name:       fmt.Sprintf("broker-%d", id),
+				Port:       extListener.GetAnyCastPort(),
+				TargetPort: intstr.FromInt(int(extListener.ContainerPort)),
+				Protocol:   corev1.ProtocolTCP,
+			},
+			},
+		},
+	}
+}
+
+// generate the backend service of the anycast route
+func (r *Reconciler) allBrokerService(extListener v1beta1.ExternalListenerConfig,
+	ingressConfig v1beta1.IngressConfig, ingressConfigName string) *corev1.Service {
+	serviceName := util.GenerateEnvoyResourceName(gatewayapiutils.AllBrokerServiceName, gatewayapiutils.AllBrokerServiceNameWithScope,
+		extListener, ingressConfig, ingressConfigName, r.KafkaCluster.GetName())
+
+	return &corev1.Service{
+		ObjectMeta: templates.ObjectMeta(
+			serviceName,
+			apiutil.MergeLabels(
+				apiutil.LabelsForKafka(r.KafkaCluster.Name),
+				labelsForGatewayAPI(r.KafkaCluster.Name, extListener.Name)),
+			r.KafkaCluster),
+		Spec: corev1.ServiceSpec{
+			Selector: apiutil.LabelsForKafka(r.KafkaCluster.Name),
+			Type:     corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{{
+				Name:       gatewayapiutils.AllBrokerListenerName,
+				Port:       extListener.GetAnyCastPort(),
+				TargetPort: intstr.FromInt(int(extListener.ContainerPort)),
+				Protocol:   corev1.ProtocolTCP,
+			},
+			},
+		},
+	}
+}
+
+func labelsForGatewayAPI(crName, eLName string) map[string]string {
+	return apiutil.MergeLabels(labelsForGatewayAPIWithoutEListenerName(crName), map[string]string{util.ExternalListenerLabelNameKey: eLName})
+}
+
+func labelsForGatewayAPIWithoutEListenerName(crName string) map[string]string {
+	return map[string]string{v1beta1.AppLabelKey: "gatewayapi", v1beta1.KafkaCRLabelKey: crName}
+}
@@ -83,16 +83,23 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 	log = log.WithValues("component", componentName)
 	log.V(1).Info("Reconciling")
 
+	var istioRevision string
+	if r.KafkaCluster.Spec.IstioControlPlane != nil {
+		istioRevision = istioOperatorApi.NamespacedRevision(
+			strings.ReplaceAll(r.KafkaCluster.Spec.IstioControlPlane.Name, ".", "-"),
+			r.KafkaCluster.Spec.IstioControlPlane.Namespace)
+	}
+
+	if err := r.reconcileWaypoint(log, istioRevision); err != nil {
+		return err
+	}
+
 	for _, eListener := range r.KafkaCluster.Spec.ListenersConfig.ExternalListeners {
-		if r.KafkaCluster.Spec.GetIngressController() == istioingress.IngressControllerName && eListener.GetAccessMethod() == corev1.ServiceTypeLoadBalancer {
+		if r.KafkaCluster.Spec.GetIngressControllerForListener(eListener) == istioingress.IngressControllerName && eListener.GetAccessMethod() == corev1.ServiceTypeLoadBalancer {
 			if r.KafkaCluster.Spec.IstioControlPlane == nil {
 				log.Error(errors.NewPlain("reference to Istio Control Plane is missing"), "skip external listener reconciliation", "external listener", eListener.Name)
 				continue
 			}
-
-			istioRevision := istioOperatorApi.NamespacedRevision(
-				strings.ReplaceAll(r.KafkaCluster.Spec.IstioControlPlane.Name, ".", "-"),
-				r.KafkaCluster.Spec.IstioControlPlane.Namespace)
 			ingressConfigs, defaultControllerName, err := util.GetIngressConfigs(r.KafkaCluster.Spec, eListener)
 			if err != nil {
 				return err
@@ -113,7 +120,7 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 					}
 				}
 			}
-		} else if r.KafkaCluster.Spec.RemoveUnusedIngressResources {
+		} else if util.ShouldRemoveUnusedIngressResources(r.KafkaCluster, eListener.Name) {
 			// Cleaning up unused istio resources when ingress controller is not istioingress or externalListener access method is not LoadBalancer
 			deletionCounter := 0
 			ctx := context.Background()
@@ -0,0 +1,67 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istioingress
+
+import (
+	"context"
+	"fmt"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/banzaicloud/operator-tools/pkg/utils"
+
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/resources/templates"
+	istioingressutils "github.com/banzaicloud/koperator/pkg/util/istioingress"
+)
+
+// waypoint returns the waypoint proxy handling the traffic addressed to the broker pods enrolled into the ambient mesh,
+// the broker pods select it with the istio.io/use-waypoint label
+func (r *Reconciler) waypoint(istioRevision string) *gatewayv1.Gateway {
+	return &gatewayv1.Gateway{
+		ObjectMeta: templates.ObjectMeta(fmt.Sprintf(istioingressutils.WaypointNameTemplate, r.KafkaCluster.GetName()),
+			utils.MergeLabels(labelsForIstioIngressWithoutEListenerName(r.KafkaCluster.GetName(), istioRevision),
+				map[string]string{istioingressutils.WaypointForLabelKey: istioingressutils.WaypointForWorkload}),
+			r.KafkaCluster),
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: istioingressutils.WaypointGatewayClassName,
+			Listeners: []gatewayv1.Listener{{
+				Name:     istioingressutils.WaypointListenerName,
+				Port:     istioingressutils.WaypointPort,
+				Protocol: istioingressutils.WaypointProtocol,
+			}},
+		},
+	}
+}
+
+// reconcileWaypoint creates the waypoint proxy of the cluster in ambient mode and removes it otherwise
+func (r *Reconciler) reconcileWaypoint(log logr.Logger, istioRevision string) error {
+	waypoint := r.waypoint(istioRevision)
+	if r.KafkaCluster.Spec.IsIstioAmbientMode() {
+		return k8sutil.Reconcile(log, r.Client, waypoint, r.KafkaCluster)
+	}
+
+	err := r.Delete(context.Background(), waypoint)
+	// the Gateway API CRDs are not installed, there is no waypoint to remove
+	if client.IgnoreNotFound(err) == nil || apimeta.IsNoMatchError(err) {
+		return nil
+	}
+	return errors.WrapIfWithDetails(err, "error when removing the waypoint proxy", "name", waypoint.GetName())
+}
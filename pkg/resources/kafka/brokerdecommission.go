@@ -0,0 +1,236 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/banzaicloud/go-cruise-control/pkg/types"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/scale"
+)
+
+// reconcileBrokerDecommissions drives the decommissions of the brokers requested in the spec. The partition replicas
+// of a decommissioned broker are moved away by Cruise Control, then the cluster is verified to have no
+// under-replicated partitions before the broker is removed from the spec. The decommission completes once the pod
+// and the volumes of the broker have been deleted by the removal of the broker.
+func (r *Reconciler) reconcileBrokerDecommissions(ctx context.Context, log logr.Logger) error {
+	decommissions := maps.Clone(r.KafkaCluster.Status.BrokerDecommissions)
+	if decommissions == nil {
+		decommissions = make(map[string]v1beta1.BrokerDecommissionStatus)
+	}
+	changed := false
+	var removedBrokerIDs []string
+
+	var clusterState *types.KafkaClusterState
+	getClusterState := func() (*types.KafkaClusterState, error) {
+		if clusterState != nil {
+			return clusterState, nil
+		}
+		cc, err := r.CruiseControlScalerFactory(ctx, r.KafkaCluster)
+		if err != nil {
+			return nil, errorfactory.New(errorfactory.CruiseControlNotReady{}, err,
+				"failed to initialize Cruise Control Scaler", "cruise control url", scale.CruiseControlURLFromKafkaCluster(r.KafkaCluster))
+		}
+		if clusterState, err = cc.KafkaClusterState(ctx); err != nil {
+			return nil, errorfactory.New(errorfactory.CruiseControlNotReady{}, err,
+				"failed to get the state of the brokers from Cruise Control")
+		}
+		return clusterState, nil
+	}
+
+	for _, broker := range r.KafkaCluster.Spec.Brokers {
+		if !broker.Decommission {
+			continue
+		}
+		brokerID := strconv.Itoa(int(broker.Id))
+		status, ok := decommissions[brokerID]
+		// a broker added back after its decommission completed is decommissioned again
+		if !ok || status.Phase == v1beta1.BrokerDecommissionCompleted {
+			if r.deferToMaintenanceWindow(log, fmt.Sprintf("decommission of broker %s", brokerID)) {
+				continue
+			}
+			state, err := getClusterState()
+			if err != nil {
+				return err
+			}
+			replicas := state.KafkaBrokerState.ReplicaCountByBrokerID[brokerID]
+			now := metav1.Now()
+			status = v1beta1.BrokerDecommissionStatus{Phase: v1beta1.BrokerDecommissionDraining, StartTime: now,
+				InitialReplicas: replicas, RemainingReplicas: replicas, LastUpdateTime: now}
+			decommissions[brokerID] = status
+			changed = true
+			log.Info("broker decommission started", v1beta1.BrokerIdLabelKey, brokerID, "replicas", replicas)
+			k8sutil.RecordEvent(r.Recorder, r.KafkaCluster, corev1.EventTypeNormal, brokerDecommissionStartedEventReason,
+				"decommission of broker %s started, %d partition replicas to move", brokerID, replicas)
+		}
+
+		next := status
+		switch status.Phase {
+		case v1beta1.BrokerDecommissionDraining, v1beta1.BrokerDecommissionVerifying:
+			state, err := getClusterState()
+			if err != nil {
+				return err
+			}
+			if status.Phase == v1beta1.BrokerDecommissionDraining {
+				err = r.drainDecommissionedBroker(log, brokerID, state, &next)
+			} else {
+				verifyDecommissionedBroker(log, brokerID, state, &next)
+			}
+			if err != nil {
+				return err
+			}
+		case v1beta1.BrokerDecommissionRemoving:
+			// the removal of the broker from the spec failed after the verification
+			removedBrokerIDs = append(removedBrokerIDs, brokerID)
+		}
+
+		if next != status {
+			if next.Phase != status.Phase {
+				log.Info("broker decommission progressed", v1beta1.BrokerIdLabelKey, brokerID, "phase", next.Phase)
+			}
+			next.LastUpdateTime = metav1.Now()
+			decommissions[brokerID] = next
+			changed = true
+			if next.Phase == v1beta1.BrokerDecommissionRemoving {
+				removedBrokerIDs = append(removedBrokerIDs, brokerID)
+			}
+		}
+	}
+
+	// the removed brokers complete their decommission once their pod and volumes are gone
+	for _, brokerID := range slices.Sorted(maps.Keys(decommissions)) {
+		status := decommissions[brokerID]
+		if status.Phase != v1beta1.BrokerDecommissionRemoving || slices.Contains(removedBrokerIDs, brokerID) {
+			continue
+		}
+		removed, err := r.isBrokerRemoved(ctx, brokerID)
+		if err != nil {
+			return err
+		}
+		if !removed {
+			continue
+		}
+		now := metav1.Now()
+		status.Phase = v1beta1.BrokerDecommissionCompleted
+		status.CompletionTime = &now
+		status.Duration = &metav1.Duration{Duration: now.Sub(status.StartTime.Time).Round(time.Second)}
+		status.LastUpdateTime = now
+		decommissions[brokerID] = status
+		changed = true
+		log.Info("broker decommission completed", v1beta1.BrokerIdLabelKey, brokerID, "movedReplicas", status.MovedReplicas,
+			"duration", status.Duration.Duration)
+		k8sutil.RecordEvent(r.Recorder, r.KafkaCluster, corev1.EventTypeNormal, brokerDecommissionCompletedEventReason,
+			"broker %s has been decommissioned in %s, %d partition replicas moved", brokerID, status.Duration.Duration, status.MovedReplicas)
+	}
+
+	// the report is recorded before the broker is removed from the spec
+	if changed {
+		if err := k8sutil.UpdateCRStatus(r.Client, r.KafkaCluster, decommissions, log); err != nil {
+			return errorfactory.New(errorfactory.StatusUpdateError{}, err, "could not update the broker decommissions status")
+		}
+	}
+	if len(removedBrokerIDs) > 0 {
+		return r.removeDecommissionedBrokers(ctx, log, removedBrokerIDs)
+	}
+	return nil
+}
+
+// drainDecommissionedBroker drains the broker the same way as the brokers removed from the spec, and moves on to the
+// verification once no replicas are left on it while no graceful downscale of the broker is running.
+func (r *Reconciler) drainDecommissionedBroker(log logr.Logger, brokerID string, clusterState *types.KafkaClusterState,
+	status *v1beta1.BrokerDecommissionStatus) error {
+	remaining := clusterState.KafkaBrokerState.ReplicaCountByBrokerID[brokerID]
+	status.RemainingReplicas = remaining
+	status.MovedReplicas = max(status.InitialReplicas-remaining, 0)
+
+	drained, err := r.isBrokerDrained(log, brokerID, clusterState.KafkaBrokerState.ReplicaCountByBrokerID)
+	if err != nil {
+		return err
+	}
+	ccState := r.KafkaCluster.Status.BrokersState[brokerID].GracefulActionState.CruiseControlState
+	if drained && !ccState.IsRunningState() {
+		status.Phase = v1beta1.BrokerDecommissionVerifying
+	}
+	return nil
+}
+
+// verifyDecommissionedBroker moves on to the removal of the broker once the cluster has no under-replicated partitions.
+// The broker is drained again if partition replicas have been moved back onto it in the meantime, e.g. by a rebalance.
+func verifyDecommissionedBroker(log logr.Logger, brokerID string, clusterState *types.KafkaClusterState,
+	status *v1beta1.BrokerDecommissionStatus) {
+	remaining := clusterState.KafkaBrokerState.ReplicaCountByBrokerID[brokerID]
+	if remaining > 0 {
+		status.Phase = v1beta1.BrokerDecommissionDraining
+		status.RemainingReplicas = remaining
+		status.MovedReplicas = max(status.InitialReplicas-remaining, 0)
+		return
+	}
+	status.UnderReplicatedPartitions = int32(len(clusterState.KafkaPartitionState.UnderReplicatedPartitions))
+	if status.UnderReplicatedPartitions > 0 {
+		log.Info("waiting for the under-replicated partitions to catch up before removing the decommissioned broker",
+			v1beta1.BrokerIdLabelKey, brokerID, "underReplicatedPartitions", status.UnderReplicatedPartitions)
+		return
+	}
+	now := metav1.Now()
+	status.VerificationTime = &now
+	status.Phase = v1beta1.BrokerDecommissionRemoving
+}
+
+// removeDecommissionedBrokers removes the verified decommissioned brokers from the spec, their pods and volumes are
+// deleted by the removal of the brokers
+func (r *Reconciler) removeDecommissionedBrokers(ctx context.Context, log logr.Logger, brokerIDs []string) error {
+	typeMeta := r.KafkaCluster.TypeMeta
+	r.KafkaCluster.Spec.Brokers = slices.DeleteFunc(slices.Clone(r.KafkaCluster.Spec.Brokers), func(broker v1beta1.Broker) bool {
+		return slices.Contains(brokerIDs, strconv.Itoa(int(broker.Id)))
+	})
+	if err := r.Update(ctx, r.KafkaCluster); err != nil {
+		return errors.WrapIfWithDetails(err, "could not remove the decommissioned brokers from the spec", "ids", brokerIDs)
+	}
+	// update loses the typeMeta of the cluster that's used later when setting ownerrefs
+	r.KafkaCluster.TypeMeta = typeMeta
+	log.Info("decommissioned brokers removed from the spec", "ids", brokerIDs)
+	return nil
+}
+
+// isBrokerRemoved returns true if the pod and the persistent volume claims of the broker are gone
+func (r *Reconciler) isBrokerRemoved(ctx context.Context, brokerID string) (bool, error) {
+	matchingLabels := client.MatchingLabels(apiutil.MergeLabels(apiutil.LabelsForKafka(r.KafkaCluster.Name),
+		map[string]string{v1beta1.BrokerIdLabelKey: brokerID}))
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList, client.InNamespace(r.KafkaCluster.Namespace), matchingLabels); err != nil {
+		return false, errors.WrapIfWithDetails(err, "failed to list the pod of the broker", v1beta1.BrokerIdLabelKey, brokerID)
+	}
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := r.List(ctx, pvcList, client.InNamespace(r.KafkaCluster.Namespace), matchingLabels); err != nil {
+		return false, errors.WrapIfWithDetails(err, "failed to list the persistent volume claims of the broker", v1beta1.BrokerIdLabelKey, brokerID)
+	}
+	return len(podList.Items) == 0 && len(pvcList.Items) == 0, nil
+}
@@ -0,0 +1,233 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ccTypes "github.com/banzaicloud/go-cruise-control/pkg/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	controllerMocks "github.com/banzaicloud/koperator/controllers/tests/mocks"
+	"github.com/banzaicloud/koperator/pkg/util"
+)
+
+func TestReconcileBrokerDecommissions(t *testing.T) {
+	brokerLabels := apiutil.MergeLabels(apiutil.LabelsForKafka("kafka"), map[string]string{v1beta1.BrokerIdLabelKey: "1"})
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "kafka-1-abcde", Namespace: "kafka", Labels: brokerLabels}}
+	started := metav1.NewTime(time.Now().Add(-90 * time.Minute))
+	decommission := func(phase v1beta1.BrokerDecommissionPhase, remaining int32) *v1beta1.BrokerDecommissionStatus {
+		return &v1beta1.BrokerDecommissionStatus{Phase: phase, StartTime: started, InitialReplicas: 10,
+			RemainingReplicas: remaining, MovedReplicas: 10 - remaining}
+	}
+
+	testCases := []struct {
+		testName          string
+		removed           bool
+		ccState           v1beta1.CruiseControlState
+		decommission      *v1beta1.BrokerDecommissionStatus
+		objects           []client.Object
+		replicas          *int32
+		underReplicated   int
+		expectedPhase     v1beta1.BrokerDecommissionPhase
+		expectedRemaining int32
+		expectedMoved     int32
+		expectedURP       int32
+		expectedCCState   v1beta1.CruiseControlState
+		expectedBrokerIDs []int32
+		expectedVerified  bool
+		expectedCompleted bool
+		expectedInitial   int32
+	}{
+		{
+			testName:          "decommission starts draining the broker",
+			ccState:           v1beta1.GracefulUpscaleSucceeded,
+			replicas:          util.Int32Pointer(10),
+			expectedPhase:     v1beta1.BrokerDecommissionDraining,
+			expectedRemaining: 10,
+			expectedCCState:   v1beta1.GracefulDownscaleRequired,
+			expectedBrokerIDs: []int32{0, 1},
+			expectedInitial:   10,
+		},
+		{
+			testName:          "partition replicas are being moved away",
+			ccState:           v1beta1.GracefulDownscaleRunning,
+			decommission:      decommission(v1beta1.BrokerDecommissionDraining, 10),
+			replicas:          util.Int32Pointer(4),
+			expectedPhase:     v1beta1.BrokerDecommissionDraining,
+			expectedRemaining: 4,
+			expectedMoved:     6,
+			expectedCCState:   v1beta1.GracefulDownscaleRunning,
+			expectedBrokerIDs: []int32{0, 1},
+			expectedInitial:   10,
+		},
+		{
+			testName:          "graceful downscale is requested again with replicas left",
+			ccState:           v1beta1.GracefulDownscaleSucceeded,
+			decommission:      decommission(v1beta1.BrokerDecommissionDraining, 4),
+			replicas:          util.Int32Pointer(2),
+			expectedPhase:     v1beta1.BrokerDecommissionDraining,
+			expectedRemaining: 2,
+			expectedMoved:     8,
+			expectedCCState:   v1beta1.GracefulDownscaleRequired,
+			expectedBrokerIDs: []int32{0, 1},
+			expectedInitial:   10,
+		},
+		{
+			testName:          "drained broker is verified",
+			ccState:           v1beta1.GracefulDownscaleSucceeded,
+			decommission:      decommission(v1beta1.BrokerDecommissionDraining, 2),
+			replicas:          util.Int32Pointer(0),
+			expectedPhase:     v1beta1.BrokerDecommissionVerifying,
+			expectedMoved:     10,
+			expectedCCState:   v1beta1.GracefulDownscaleSucceeded,
+			expectedBrokerIDs: []int32{0, 1},
+			expectedInitial:   10,
+		},
+		{
+			testName:          "removal waits for the under-replicated partitions",
+			ccState:           v1beta1.GracefulDownscaleSucceeded,
+			decommission:      decommission(v1beta1.BrokerDecommissionVerifying, 0),
+			replicas:          util.Int32Pointer(0),
+			underReplicated:   2,
+			expectedPhase:     v1beta1.BrokerDecommissionVerifying,
+			expectedMoved:     10,
+			expectedURP:       2,
+			expectedCCState:   v1beta1.GracefulDownscaleSucceeded,
+			expectedBrokerIDs: []int32{0, 1},
+			expectedInitial:   10,
+		},
+		{
+			testName:          "replicas moved back onto the broker are drained again",
+			ccState:           v1beta1.GracefulDownscaleSucceeded,
+			decommission:      decommission(v1beta1.BrokerDecommissionVerifying, 0),
+			replicas:          util.Int32Pointer(3),
+			expectedPhase:     v1beta1.BrokerDecommissionDraining,
+			expectedRemaining: 3,
+			expectedMoved:     7,
+			expectedCCState:   v1beta1.GracefulDownscaleSucceeded,
+			expectedBrokerIDs: []int32{0, 1},
+			expectedInitial:   10,
+		},
+		{
+			testName:          "verified broker is removed from the spec",
+			ccState:           v1beta1.GracefulDownscaleSucceeded,
+			decommission:      decommission(v1beta1.BrokerDecommissionVerifying, 0),
+			replicas:          util.Int32Pointer(0),
+			expectedPhase:     v1beta1.BrokerDecommissionRemoving,
+			expectedMoved:     10,
+			expectedCCState:   v1beta1.GracefulDownscaleSucceeded,
+			expectedBrokerIDs: []int32{0},
+			expectedVerified:  true,
+			expectedInitial:   10,
+		},
+		{
+			testName:          "pod of the removed broker is still present",
+			removed:           true,
+			ccState:           v1beta1.GracefulDownscaleSucceeded,
+			decommission:      decommission(v1beta1.BrokerDecommissionRemoving, 0),
+			objects:           []client.Object{pod},
+			expectedPhase:     v1beta1.BrokerDecommissionRemoving,
+			expectedMoved:     10,
+			expectedCCState:   v1beta1.GracefulDownscaleSucceeded,
+			expectedBrokerIDs: []int32{0},
+			expectedInitial:   10,
+		},
+		{
+			testName:          "decommission completes once the broker is gone",
+			removed:           true,
+			decommission:      decommission(v1beta1.BrokerDecommissionRemoving, 0),
+			expectedPhase:     v1beta1.BrokerDecommissionCompleted,
+			expectedMoved:     10,
+			expectedBrokerIDs: []int32{0},
+			expectedCompleted: true,
+			expectedInitial:   10,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Spec:       v1beta1.KafkaClusterSpec{Brokers: []v1beta1.Broker{{Id: 0}}},
+			}
+			if !test.removed {
+				cluster.Spec.Brokers = append(cluster.Spec.Brokers, v1beta1.Broker{Id: 1, Decommission: true})
+			}
+			if test.ccState != "" {
+				cluster.Status.BrokersState = map[string]v1beta1.BrokerState{
+					"1": {GracefulActionState: v1beta1.GracefulActionState{CruiseControlState: test.ccState}},
+				}
+			}
+			if test.decommission != nil {
+				cluster.Status.BrokerDecommissions = map[string]v1beta1.BrokerDecommissionStatus{"1": *test.decommission}
+			}
+			scheme := runtime.NewScheme()
+			require.NoError(t, clientgoscheme.AddToScheme(scheme))
+			require.NoError(t, v1beta1.AddToScheme(scheme))
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(test.objects, cluster)...).
+				WithStatusSubresource(cluster).Build()
+
+			mockCtrl := gomock.NewController(t)
+			cc := controllerMocks.NewMockCruiseControlScaler(mockCtrl)
+			if test.replicas != nil {
+				cc.EXPECT().KafkaClusterState(gomock.Any()).Return(&ccTypes.KafkaClusterState{
+					KafkaBrokerState:    ccTypes.KafkaBrokerState{ReplicaCountByBrokerID: map[string]int32{"0": 20, "1": *test.replicas}},
+					KafkaPartitionState: ccTypes.KafkaPartitionState{UnderReplicatedPartitions: make([]ccTypes.PartitionState, test.underReplicated)},
+				}, nil)
+			}
+
+			r := New(c, nil, cluster, nil, nil)
+			r.CruiseControlScalerFactory = controllerMocks.NewMockScaleFactory(cc)
+			require.NoError(t, r.reconcileBrokerDecommissions(context.Background(), logf.Log))
+
+			updated := &v1beta1.KafkaCluster{}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(cluster), updated))
+			var brokerIDs []int32
+			for _, broker := range updated.Spec.Brokers {
+				brokerIDs = append(brokerIDs, broker.Id)
+			}
+			require.Equal(t, test.expectedBrokerIDs, brokerIDs)
+			require.Equal(t, test.expectedCCState, updated.Status.BrokersState["1"].GracefulActionState.CruiseControlState)
+
+			status, ok := updated.Status.BrokerDecommissions["1"]
+			require.True(t, ok)
+			require.Equal(t, test.expectedPhase, status.Phase)
+			require.Equal(t, test.expectedInitial, status.InitialReplicas)
+			require.Equal(t, test.expectedRemaining, status.RemainingReplicas)
+			require.Equal(t, test.expectedMoved, status.MovedReplicas)
+			require.Equal(t, test.expectedURP, status.UnderReplicatedPartitions)
+			require.Equal(t, test.expectedVerified, status.VerificationTime != nil)
+			require.Equal(t, test.expectedCompleted, status.CompletionTime != nil)
+			if test.expectedCompleted {
+				require.NotNil(t, status.Duration)
+				require.GreaterOrEqual(t, status.Duration.Duration, 90*time.Minute)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/scale"
+)
+
+// brokerReplicaCounts returns the number of partition replicas hosted by the brokers according to Cruise Control
+func brokerReplicaCounts(ctx context.Context, cc scale.CruiseControlScaler) (map[string]int32, error) {
+	state, err := cc.KafkaClusterState(ctx)
+	if err != nil {
+		return nil, errorfactory.New(errorfactory.CruiseControlNotReady{}, err,
+			"failed to get the partition replicas of the brokers from Cruise Control")
+	}
+	if state == nil || state.KafkaBrokerState.ReplicaCountByBrokerID == nil {
+		return map[string]int32{}, nil
+	}
+	return state.KafkaBrokerState.ReplicaCountByBrokerID, nil
+}
+
+// isBrokerDrained returns true if no partition replicas are left on the broker removed from the spec or decommissioned.
+// The remaining replicas are recorded in the status of the broker. When replicas are left on a broker whose graceful
+// downscale is considered done, e.g. the remove_broker operation completed with an ignored error or it was never
+// executed, the graceful downscale is requested again so the pod and the volumes of the broker are not deleted with
+// data on them.
+func (r *Reconciler) isBrokerDrained(log logr.Logger, brokerID string, replicaCounts map[string]int32) (bool, error) {
+	remaining := replicaCounts[brokerID]
+	brokerState, hasState := r.KafkaCluster.Status.BrokersState[brokerID]
+
+	if !hasState || brokerState.DrainState == nil || brokerState.DrainState.RemainingReplicas != remaining {
+		err := k8sutil.UpdateBrokerStatus(r.Client, []string{brokerID}, r.KafkaCluster,
+			v1beta1.BrokerDrainState{RemainingReplicas: remaining, LastUpdateTime: metav1.Now()}, log)
+		if err != nil {
+			return false, errors.WrapIfWithDetails(err, "could not update the drain state of the broker", v1beta1.BrokerIdLabelKey, brokerID)
+		}
+	}
+	if remaining == 0 {
+		return true, nil
+	}
+
+	log.Info("partition replicas are left on the broker, its deletion is blocked until they are moved away",
+		v1beta1.BrokerIdLabelKey, brokerID, "remainingReplicas", remaining)
+
+	ccState := brokerState.GracefulActionState.CruiseControlState
+	if ccState != v1beta1.GracefulDownscaleRequired && !ccState.IsRunningState() {
+		err := k8sutil.UpdateBrokerStatus(r.Client, []string{brokerID}, r.KafkaCluster,
+			v1beta1.GracefulActionState{CruiseControlState: v1beta1.GracefulDownscaleRequired}, log)
+		if err != nil {
+			return false, errors.WrapIfWithDetails(err, "could not request the graceful downscale of the broker", v1beta1.BrokerIdLabelKey, brokerID)
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,91 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func TestIsBrokerDrained(t *testing.T) {
+	testCases := []struct {
+		testName        string
+		ccState         v1beta1.CruiseControlState
+		replicaCounts   map[string]int32
+		expectedDrained bool
+		expectedCCState v1beta1.CruiseControlState
+	}{
+		{
+			testName:        "no replicas left after the graceful downscale",
+			ccState:         v1beta1.GracefulDownscaleSucceeded,
+			replicaCounts:   map[string]int32{"0": 10, "1": 0},
+			expectedDrained: true,
+			expectedCCState: v1beta1.GracefulDownscaleSucceeded,
+		},
+		{
+			testName:        "no replicas on a broker which was never rebalanced",
+			ccState:         v1beta1.GracefulUpscaleRequired,
+			replicaCounts:   map[string]int32{"0": 10},
+			expectedDrained: true,
+			expectedCCState: v1beta1.GracefulUpscaleRequired,
+		},
+		{
+			testName:        "replicas left after the graceful downscale",
+			ccState:         v1beta1.GracefulDownscaleSucceeded,
+			replicaCounts:   map[string]int32{"0": 10, "1": 3},
+			expectedCCState: v1beta1.GracefulDownscaleRequired,
+		},
+		{
+			testName:        "replicas left on a broker which was never rebalanced",
+			ccState:         v1beta1.GracefulUpscaleRequired,
+			replicaCounts:   map[string]int32{"0": 10, "1": 3},
+			expectedCCState: v1beta1.GracefulDownscaleRequired,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Status: v1beta1.KafkaClusterStatus{
+					BrokersState: map[string]v1beta1.BrokerState{
+						"1": {GracefulActionState: v1beta1.GracefulActionState{CruiseControlState: test.ccState}},
+					},
+				},
+			}
+			scheme := runtime.NewScheme()
+			require.NoError(t, v1beta1.AddToScheme(scheme))
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster).WithStatusSubresource(cluster).Build()
+
+			r := New(c, nil, cluster, nil, nil)
+			drained, err := r.isBrokerDrained(logf.Log, "1", test.replicaCounts)
+			require.NoError(t, err)
+			require.Equal(t, test.expectedDrained, drained)
+
+			brokerState := r.KafkaCluster.Status.BrokersState["1"]
+			require.NotNil(t, brokerState.DrainState)
+			require.Equal(t, test.replicaCounts["1"], brokerState.DrainState.RemainingReplicas)
+			require.Equal(t, test.expectedCCState, brokerState.GracefulActionState.CruiseControlState)
+		})
+	}
+}
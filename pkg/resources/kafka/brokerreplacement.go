@@ -0,0 +1,305 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/banzaicloud/go-cruise-control/pkg/types"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/resources/templates"
+	"github.com/banzaicloud/koperator/pkg/scale"
+)
+
+// reconcileBrokerReplacements drives the replacements of the brokers requested in the spec and returns the phase of
+// the replacements in progress by broker id. The brokers in the Deleting phase must not be recreated until their pod
+// and volumes are gone.
+func (r *Reconciler) reconcileBrokerReplacements(ctx context.Context, log logr.Logger) (map[string]v1beta1.BrokerReplacementPhase, error) {
+	replacements := make(map[string]v1beta1.BrokerReplacementPhase)
+
+	var cc scale.CruiseControlScaler
+	var clusterState *types.KafkaClusterState
+	for _, broker := range r.KafkaCluster.Spec.Brokers {
+		if broker.Replacement == nil {
+			continue
+		}
+		brokerID := strconv.Itoa(int(broker.Id))
+		brokerState, hasState := r.KafkaCluster.Status.BrokersState[brokerID]
+		state := brokerState.ReplacementState
+		if state != nil && state.ID == broker.Replacement.ID && state.Phase == v1beta1.BrokerReplacementSucceeded {
+			continue
+		}
+
+		if state == nil || state.ID != broker.Replacement.ID {
+			phase := v1beta1.BrokerReplacementDrainingLeadership
+			// there is nothing to replace if the broker has not been created yet
+			if !hasState {
+				phase = v1beta1.BrokerReplacementSucceeded
+			}
+			state = &v1beta1.BrokerReplacementState{ID: broker.Replacement.ID, Phase: phase, LastUpdateTime: metav1.Now()}
+			if err := r.updateBrokerReplacementState(brokerID, *state, log); err != nil {
+				return nil, err
+			}
+			if phase == v1beta1.BrokerReplacementSucceeded {
+				continue
+			}
+			log.Info("broker replacement started", v1beta1.BrokerIdLabelKey, brokerID, "replacement", state.ID)
+			k8sutil.RecordEvent(r.Recorder, r.KafkaCluster, corev1.EventTypeNormal, brokerReplacementStartedEventReason,
+				"replacement %s of broker %s started", state.ID, brokerID)
+		}
+
+		if state.Phase == v1beta1.BrokerReplacementDrainingLeadership || state.Phase == v1beta1.BrokerReplacementDeleting ||
+			state.Phase == v1beta1.BrokerReplacementResyncing {
+			if clusterState == nil {
+				var err error
+				if cc, err = r.CruiseControlScalerFactory(ctx, r.KafkaCluster); err != nil {
+					return nil, errorfactory.New(errorfactory.CruiseControlNotReady{}, err,
+						"failed to initialize Cruise Control Scaler", "cruise control url", scale.CruiseControlURLFromKafkaCluster(r.KafkaCluster))
+				}
+				if clusterState, err = cc.KafkaClusterState(ctx); err != nil {
+					return nil, errorfactory.New(errorfactory.CruiseControlNotReady{}, err,
+						"failed to get the state of the brokers from Cruise Control")
+				}
+			}
+		}
+
+		next := *state
+		var err error
+		switch state.Phase {
+		case v1beta1.BrokerReplacementDrainingLeadership:
+			err = r.drainBrokerLeadership(ctx, log, brokerID, clusterState, &next)
+		case v1beta1.BrokerReplacementDeleting:
+			err = r.deleteReplacedBroker(ctx, log, brokerID, broker.Replacement.KeepVolumes, clusterState, &next)
+		case v1beta1.BrokerReplacementResyncing:
+			err = r.checkReplacedBrokerInSync(ctx, brokerID, clusterState, &next)
+		default:
+			err = errors.NewWithDetails("unknown broker replacement phase", v1beta1.BrokerIdLabelKey, brokerID, "phase", state.Phase)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if !reflect.DeepEqual(next, *state) {
+			if next.Phase != state.Phase {
+				log.Info("broker replacement progressed", v1beta1.BrokerIdLabelKey, brokerID, "replacement", next.ID, "phase", next.Phase)
+			}
+			next.LastUpdateTime = metav1.Now()
+			if err = r.updateBrokerReplacementState(brokerID, next, log); err != nil {
+				return nil, err
+			}
+			if next.Phase == v1beta1.BrokerReplacementSucceeded {
+				k8sutil.RecordEvent(r.Recorder, r.KafkaCluster, corev1.EventTypeNormal, brokerReplacementSucceededEventReason,
+					"replacement %s of broker %s succeeded, its partition replicas are in sync", next.ID, brokerID)
+			}
+		}
+		if next.Phase != v1beta1.BrokerReplacementSucceeded {
+			replacements[brokerID] = next.Phase
+		}
+	}
+	return replacements, nil
+}
+
+// drainBrokerLeadership moves the leadership of the partitions away from the broker with a demote_broker
+// CruiseControlOperation and moves on to the deletion of the broker once it leads no partitions. The broker is demoted
+// again if the operation finished, or is gone, while the broker still leads partitions.
+func (r *Reconciler) drainBrokerLeadership(ctx context.Context, log logr.Logger, brokerID string,
+	clusterState *types.KafkaClusterState, state *v1beta1.BrokerReplacementState) error {
+	state.RemainingLeaders = clusterState.KafkaBrokerState.LeaderCountByBrokerID[brokerID]
+	if state.RemainingLeaders == 0 {
+		state.Phase = v1beta1.BrokerReplacementDeleting
+		state.DemoteOperationReference = nil
+		return nil
+	}
+
+	if ref := state.DemoteOperationReference; ref != nil {
+		operation := &v1alpha1.CruiseControlOperation{}
+		err := r.Get(ctx, client.ObjectKey{Namespace: r.KafkaCluster.Namespace, Name: ref.Name}, operation)
+		if client.IgnoreNotFound(err) != nil {
+			return errors.WrapIfWithDetails(err, "failed to get the demote_broker CruiseControlOperation", "name", ref.Name)
+		}
+		if err == nil && !operation.IsFinished() {
+			log.Info("waiting for the leadership of the partitions to be moved away from the broker",
+				v1beta1.BrokerIdLabelKey, brokerID, "remainingLeaders", state.RemainingLeaders, "cruiseControlOperation", ref.Name)
+			return nil
+		}
+	}
+
+	operation := &v1alpha1.CruiseControlOperation{
+		ObjectMeta: templates.ObjectMetaWithGeneratedName(
+			fmt.Sprintf("%s-%s-", r.KafkaCluster.Name, strings.ReplaceAll(string(v1alpha1.OperationDemoteBroker), "_", "")),
+			apiutil.LabelsForKafka(r.KafkaCluster.Name), r.KafkaCluster),
+		Spec: v1alpha1.CruiseControlOperationSpec{
+			ErrorPolicy:             v1alpha1.ErrorPolicyRetry,
+			TTLSecondsAfterFinished: r.KafkaCluster.Spec.CruiseControlConfig.CruiseControlOperationSpec.GetTTLSecondsAfterFinished(),
+		},
+	}
+	if err := r.Create(ctx, operation); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to create the demote_broker CruiseControlOperation", v1beta1.BrokerIdLabelKey, brokerID)
+	}
+	operation.Status.CurrentTask = &v1alpha1.CruiseControlTask{
+		Operation:  v1alpha1.OperationDemoteBroker,
+		Parameters: map[string]string{scale.ParamBrokerID: brokerID},
+	}
+	if err := r.Status().Update(ctx, operation); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to set the task of the CruiseControlOperation", "name", operation.Name)
+	}
+	log.Info("demoting the broker to move the leadership of its partitions away", v1beta1.BrokerIdLabelKey, brokerID,
+		"remainingLeaders", state.RemainingLeaders, "cruiseControlOperation", operation.Name)
+	state.DemoteOperationReference = &corev1.LocalObjectReference{Name: operation.Name}
+	return nil
+}
+
+// deleteReplacedBroker deletes the pod of the broker together with its volumes unless they are kept, and moves on to
+// the re-sync of the broker once they are gone. The deletion waits until no partition is offline or under-replicated
+// with the broker in its in-sync replicas, so the broker is not taken away while it holds a needed copy of the data.
+func (r *Reconciler) deleteReplacedBroker(ctx context.Context, log logr.Logger, brokerID string, keepVolumes bool,
+	clusterState *types.KafkaClusterState, state *v1beta1.BrokerReplacementState) error {
+	matchingLabels := client.MatchingLabels(apiutil.MergeLabels(apiutil.LabelsForKafka(r.KafkaCluster.Name),
+		map[string]string{v1beta1.BrokerIdLabelKey: brokerID}))
+
+	var objects []client.Object
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList, client.InNamespace(r.KafkaCluster.Namespace), matchingLabels); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to list the pod of the broker", v1beta1.BrokerIdLabelKey, brokerID)
+	}
+	for i := range podList.Items {
+		objects = append(objects, &podList.Items[i])
+	}
+	if !keepVolumes {
+		pvcList := &corev1.PersistentVolumeClaimList{}
+		if err := r.List(ctx, pvcList, client.InNamespace(r.KafkaCluster.Namespace), matchingLabels); err != nil {
+			return errors.WrapIfWithDetails(err, "failed to list the persistent volume claims of the broker", v1beta1.BrokerIdLabelKey, brokerID)
+		}
+		for i := range pvcList.Items {
+			objects = append(objects, &pvcList.Items[i])
+		}
+	}
+
+	if len(objects) == 0 {
+		state.Phase = v1beta1.BrokerReplacementResyncing
+		return nil
+	}
+	// the partitions are under-replicated once the deletion started, they are only checked before it
+	deleting := false
+	for _, obj := range objects {
+		deleting = deleting || obj.GetDeletionTimestamp() != nil
+	}
+	if !deleting {
+		if unsafe := partitionsNeedingBroker(clusterState, brokerID); unsafe > 0 {
+			log.Info("waiting for the offline and under-replicated partitions to recover before deleting the replaced broker",
+				v1beta1.BrokerIdLabelKey, brokerID, "partitions", unsafe)
+			return nil
+		}
+	}
+	for _, obj := range objects {
+		if obj.GetDeletionTimestamp() != nil {
+			continue
+		}
+		if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return errors.WrapIfWithDetails(err, "failed to delete the replaced broker", v1beta1.BrokerIdLabelKey, brokerID, "name", obj.GetName())
+		}
+		log.Info("deleted resource of the replaced broker", v1beta1.BrokerIdLabelKey, brokerID, "name", obj.GetName())
+	}
+	return nil
+}
+
+// checkReplacedBrokerInSync completes the replacement once the pod of the recreated broker is ready, all of its
+// partition replicas caught up with their leaders and none of the partitions it hosts is under-replicated. The broker
+// rebuilds its empty storage from the other brokers, so it is not considered healthy just because its pod is ready.
+func (r *Reconciler) checkReplacedBrokerInSync(ctx context.Context, brokerID string, clusterState *types.KafkaClusterState,
+	state *v1beta1.BrokerReplacementState) error {
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList, client.InNamespace(r.KafkaCluster.Namespace), client.MatchingLabels(apiutil.MergeLabels(
+		apiutil.LabelsForKafka(r.KafkaCluster.Name), map[string]string{v1beta1.BrokerIdLabelKey: brokerID}))); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to list the pod of the broker", v1beta1.BrokerIdLabelKey, brokerID)
+	}
+	if len(podList.Items) != 1 || !isPodReady(&podList.Items[0]) {
+		return nil
+	}
+
+	brokerState := clusterState.KafkaBrokerState
+	// the broker is not known by Cruise Control until it has joined the cluster
+	if _, ok := brokerState.ReplicaCountByBrokerID[brokerID]; !ok {
+		return nil
+	}
+	state.OutOfSyncReplicas = brokerState.OutOfSyncCountByBrokerID[brokerID] + brokerState.OfflineReplicaCountByBrokerID[brokerID]
+	state.UnderReplicatedPartitions = underReplicatedPartitionsOnBroker(clusterState, brokerID)
+	if state.OutOfSyncReplicas == 0 && state.UnderReplicatedPartitions == 0 {
+		state.Phase = v1beta1.BrokerReplacementSucceeded
+	}
+	return nil
+}
+
+// underReplicatedPartitionsOnBroker returns the number of under-replicated partitions with a replica on the broker
+func underReplicatedPartitionsOnBroker(clusterState *types.KafkaClusterState, brokerID string) int32 {
+	var count int32
+	for _, partition := range clusterState.KafkaPartitionState.UnderReplicatedPartitions {
+		for _, replica := range partition.Replicas {
+			if strconv.Itoa(int(replica)) == brokerID {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// partitionsNeedingBroker returns the number of the offline partitions and the under-replicated partitions with the
+// broker in their in-sync replicas, the latter lose one more in-sync replica when the broker is deleted
+func partitionsNeedingBroker(clusterState *types.KafkaClusterState, brokerID string) int32 {
+	count := int32(len(clusterState.KafkaPartitionState.Offline))
+	for _, partition := range clusterState.KafkaPartitionState.UnderReplicatedPartitions {
+		for _, replica := range partition.InSyncReplicas {
+			if strconv.Itoa(int(replica)) == brokerID {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+func (r *Reconciler) updateBrokerReplacementState(brokerID string, state v1beta1.BrokerReplacementState, log logr.Logger) error {
+	if err := k8sutil.UpdateBrokerStatus(r.Client, []string{brokerID}, r.KafkaCluster, state, log); err != nil {
+		return errors.WrapIfWithDetails(err, "could not update the replacement state of the broker", v1beta1.BrokerIdLabelKey, brokerID)
+	}
+	return nil
+}
+
+// replacementsInProgress returns the ids of the brokers whose replacement is in progress in ascending order
+func replacementsInProgress(replacements map[string]v1beta1.BrokerReplacementPhase) []string {
+	brokerIDs := make([]string, 0, len(replacements))
+	for brokerID := range replacements {
+		brokerIDs = append(brokerIDs, brokerID)
+	}
+	sort.Strings(brokerIDs)
+	return brokerIDs
+}
@@ -0,0 +1,340 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	ccTypes "github.com/banzaicloud/go-cruise-control/pkg/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	controllerMocks "github.com/banzaicloud/koperator/controllers/tests/mocks"
+	"github.com/banzaicloud/koperator/pkg/scale"
+)
+
+func TestReconcileBrokerReplacements(t *testing.T) {
+	brokerLabels := apiutil.MergeLabels(apiutil.LabelsForKafka("kafka"), map[string]string{v1beta1.BrokerIdLabelKey: "1"})
+	pod := func(ready bool) *corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "kafka-1-abcde", Namespace: "kafka", Labels: brokerLabels},
+			Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}},
+		}
+	}
+	pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "kafka-1-storage-0", Namespace: "kafka", Labels: brokerLabels}}
+	demoteRef := &corev1.LocalObjectReference{Name: "kafka-demotebroker-abcde"}
+	demoteOperation := func(state v1beta1.CruiseControlUserTaskState) *v1alpha1.CruiseControlOperation {
+		return &v1alpha1.CruiseControlOperation{
+			ObjectMeta: metav1.ObjectMeta{Name: demoteRef.Name, Namespace: "kafka", Labels: apiutil.LabelsForKafka("kafka")},
+			Spec:       v1alpha1.CruiseControlOperationSpec{ErrorPolicy: v1alpha1.ErrorPolicyRetry},
+			Status: v1alpha1.CruiseControlOperationStatus{
+				CurrentTask: &v1alpha1.CruiseControlTask{ID: "demote-task", Operation: v1alpha1.OperationDemoteBroker, State: state},
+			},
+		}
+	}
+
+	testCases := []struct {
+		testName             string
+		keepVolumes          bool
+		hasBrokerState       bool
+		replacementState     *v1beta1.BrokerReplacementState
+		objects              []client.Object
+		brokerState          *ccTypes.KafkaBrokerState
+		underReplicated      []ccTypes.PartitionState
+		offline              []ccTypes.PartitionState
+		expectDemote         bool
+		expectedState        v1beta1.BrokerReplacementState
+		expectedReplacements map[string]v1beta1.BrokerReplacementPhase
+		expectedRemaining    []string
+	}{
+		{
+			testName:             "broker not created yet",
+			expectedState:        v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementSucceeded},
+			expectedReplacements: map[string]v1beta1.BrokerReplacementPhase{},
+		},
+		{
+			testName:       "new replacement demotes the broker",
+			hasBrokerState: true,
+			brokerState:    &ccTypes.KafkaBrokerState{LeaderCountByBrokerID: map[string]int32{"1": 3}},
+			expectDemote:   true,
+			expectedState: v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementDrainingLeadership,
+				RemainingLeaders: 3},
+			expectedReplacements: map[string]v1beta1.BrokerReplacementPhase{"1": v1beta1.BrokerReplacementDrainingLeadership},
+		},
+		{
+			testName:         "new replacement id restarts a succeeded replacement",
+			hasBrokerState:   true,
+			replacementState: &v1beta1.BrokerReplacementState{ID: "r0", Phase: v1beta1.BrokerReplacementSucceeded},
+			brokerState:      &ccTypes.KafkaBrokerState{LeaderCountByBrokerID: map[string]int32{"1": 3}},
+			expectDemote:     true,
+			expectedState: v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementDrainingLeadership,
+				RemainingLeaders: 3},
+			expectedReplacements: map[string]v1beta1.BrokerReplacementPhase{"1": v1beta1.BrokerReplacementDrainingLeadership},
+		},
+		{
+			testName:       "demote operation still running",
+			hasBrokerState: true,
+			replacementState: &v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementDrainingLeadership,
+				DemoteOperationReference: demoteRef, RemainingLeaders: 3},
+			objects:     []client.Object{demoteOperation(v1beta1.CruiseControlTaskInExecution)},
+			brokerState: &ccTypes.KafkaBrokerState{LeaderCountByBrokerID: map[string]int32{"1": 1}},
+			expectedState: v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementDrainingLeadership,
+				DemoteOperationReference: demoteRef, RemainingLeaders: 1},
+			expectedReplacements: map[string]v1beta1.BrokerReplacementPhase{"1": v1beta1.BrokerReplacementDrainingLeadership},
+			expectedRemaining:    []string{"kafka-demotebroker-abcde"},
+		},
+		{
+			testName:       "failed demote operation is retried by the operation",
+			hasBrokerState: true,
+			replacementState: &v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementDrainingLeadership,
+				DemoteOperationReference: demoteRef, RemainingLeaders: 3},
+			objects:     []client.Object{demoteOperation(v1beta1.CruiseControlTaskCompletedWithError)},
+			brokerState: &ccTypes.KafkaBrokerState{LeaderCountByBrokerID: map[string]int32{"1": 3}},
+			expectedState: v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementDrainingLeadership,
+				DemoteOperationReference: demoteRef, RemainingLeaders: 3},
+			expectedReplacements: map[string]v1beta1.BrokerReplacementPhase{"1": v1beta1.BrokerReplacementDrainingLeadership},
+			expectedRemaining:    []string{"kafka-demotebroker-abcde"},
+		},
+		{
+			testName:       "broker is demoted again when the demote operation finished with leaders left",
+			hasBrokerState: true,
+			replacementState: &v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementDrainingLeadership,
+				DemoteOperationReference: demoteRef, RemainingLeaders: 3},
+			objects:      []client.Object{demoteOperation(v1beta1.CruiseControlTaskCompleted)},
+			brokerState:  &ccTypes.KafkaBrokerState{LeaderCountByBrokerID: map[string]int32{"1": 2}},
+			expectDemote: true,
+			expectedState: v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementDrainingLeadership,
+				RemainingLeaders: 2},
+			expectedReplacements: map[string]v1beta1.BrokerReplacementPhase{"1": v1beta1.BrokerReplacementDrainingLeadership},
+			expectedRemaining:    []string{"kafka-demotebroker-abcde"},
+		},
+		{
+			testName:       "broker is demoted again when the demote operation is gone",
+			hasBrokerState: true,
+			replacementState: &v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementDrainingLeadership,
+				DemoteOperationReference: demoteRef, RemainingLeaders: 3},
+			brokerState:  &ccTypes.KafkaBrokerState{LeaderCountByBrokerID: map[string]int32{"1": 3}},
+			expectDemote: true,
+			expectedState: v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementDrainingLeadership,
+				RemainingLeaders: 3},
+			expectedReplacements: map[string]v1beta1.BrokerReplacementPhase{"1": v1beta1.BrokerReplacementDrainingLeadership},
+		},
+		{
+			testName:       "broker leads no partitions",
+			hasBrokerState: true,
+			replacementState: &v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementDrainingLeadership,
+				DemoteOperationReference: demoteRef, RemainingLeaders: 1},
+			brokerState:          &ccTypes.KafkaBrokerState{LeaderCountByBrokerID: map[string]int32{"1": 0}},
+			expectedState:        v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementDeleting},
+			expectedReplacements: map[string]v1beta1.BrokerReplacementPhase{"1": v1beta1.BrokerReplacementDeleting},
+		},
+		{
+			testName:             "pod and volumes of the broker are deleted",
+			hasBrokerState:       true,
+			replacementState:     &v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementDeleting},
+			objects:              []client.Object{pod(true), pvc},
+			brokerState:          &ccTypes.KafkaBrokerState{},
+			expectedState:        v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementDeleting},
+			expectedReplacements: map[string]v1beta1.BrokerReplacementPhase{"1": v1beta1.BrokerReplacementDeleting},
+		},
+		{
+			testName:         "broker is deleted with the partitions under-replicated only by itself",
+			hasBrokerState:   true,
+			replacementState: &v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementDeleting},
+			objects:          []client.Object{pvc},
+			brokerState:      &ccTypes.KafkaBrokerState{},
+			underReplicated: []ccTypes.PartitionState{
+				{Topic: "orders", Partition: 0, Replicas: []int32{0, 1, 2}, InSyncReplicas: []int32{0, 2}, OutOfSyncReplicas: []int32{1}},
+			},
+			expectedState:        v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementDeleting},
+			expectedReplacements: map[string]v1beta1.BrokerReplacementPhase{"1": v1beta1.BrokerReplacementDeleting},
+		},
+		{
+			testName:         "broker is not deleted while it is an in-sync replica of under-replicated partitions",
+			hasBrokerState:   true,
+			replacementState: &v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementDeleting},
+			objects:          []client.Object{pod(true), pvc},
+			brokerState:      &ccTypes.KafkaBrokerState{},
+			underReplicated: []ccTypes.PartitionState{
+				{Topic: "orders", Partition: 0, Replicas: []int32{0, 1, 2}, InSyncReplicas: []int32{0, 1}, OutOfSyncReplicas: []int32{2}},
+			},
+			expectedState:        v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementDeleting},
+			expectedReplacements: map[string]v1beta1.BrokerReplacementPhase{"1": v1beta1.BrokerReplacementDeleting},
+			expectedRemaining:    []string{"kafka-1-abcde", "kafka-1-storage-0"},
+		},
+		{
+			testName:             "broker is not deleted while partitions are offline",
+			hasBrokerState:       true,
+			replacementState:     &v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementDeleting},
+			objects:              []client.Object{pod(true), pvc},
+			brokerState:          &ccTypes.KafkaBrokerState{},
+			offline:              []ccTypes.PartitionState{{Topic: "orders", Partition: 0, Replicas: []int32{1, 2}}},
+			expectedState:        v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementDeleting},
+			expectedReplacements: map[string]v1beta1.BrokerReplacementPhase{"1": v1beta1.BrokerReplacementDeleting},
+			expectedRemaining:    []string{"kafka-1-abcde", "kafka-1-storage-0"},
+		},
+		{
+			testName:             "volumes of the broker are kept",
+			keepVolumes:          true,
+			hasBrokerState:       true,
+			replacementState:     &v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementDeleting},
+			objects:              []client.Object{pvc},
+			brokerState:          &ccTypes.KafkaBrokerState{},
+			expectedState:        v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementResyncing},
+			expectedReplacements: map[string]v1beta1.BrokerReplacementPhase{"1": v1beta1.BrokerReplacementResyncing},
+			expectedRemaining:    []string{"kafka-1-storage-0"},
+		},
+		{
+			testName:         "recreated broker is not ready",
+			hasBrokerState:   true,
+			replacementState: &v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementResyncing},
+			objects:          []client.Object{pod(false)},
+			brokerState: &ccTypes.KafkaBrokerState{
+				ReplicaCountByBrokerID:   map[string]int32{"1": 10},
+				OutOfSyncCountByBrokerID: map[string]int32{"1": 10},
+			},
+			expectedState:        v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementResyncing},
+			expectedReplacements: map[string]v1beta1.BrokerReplacementPhase{"1": v1beta1.BrokerReplacementResyncing},
+			expectedRemaining:    []string{"kafka-1-abcde"},
+		},
+		{
+			testName:         "recreated broker re-syncs its replicas",
+			hasBrokerState:   true,
+			replacementState: &v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementResyncing},
+			objects:          []client.Object{pod(true)},
+			brokerState: &ccTypes.KafkaBrokerState{
+				ReplicaCountByBrokerID:   map[string]int32{"1": 10},
+				OutOfSyncCountByBrokerID: map[string]int32{"1": 4},
+			},
+			expectedState:        v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementResyncing, OutOfSyncReplicas: 4},
+			expectedReplacements: map[string]v1beta1.BrokerReplacementPhase{"1": v1beta1.BrokerReplacementResyncing},
+			expectedRemaining:    []string{"kafka-1-abcde"},
+		},
+		{
+			testName:         "recreated broker hosts under-replicated partitions",
+			hasBrokerState:   true,
+			replacementState: &v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementResyncing, OutOfSyncReplicas: 4},
+			objects:          []client.Object{pod(true)},
+			brokerState: &ccTypes.KafkaBrokerState{
+				ReplicaCountByBrokerID:   map[string]int32{"1": 10},
+				OutOfSyncCountByBrokerID: map[string]int32{"1": 0},
+			},
+			underReplicated: []ccTypes.PartitionState{
+				{Topic: "orders", Partition: 0, Replicas: []int32{0, 1, 2}},
+				{Topic: "orders", Partition: 1, Replicas: []int32{0, 2, 3}},
+			},
+			expectedState: v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementResyncing,
+				UnderReplicatedPartitions: 1},
+			expectedReplacements: map[string]v1beta1.BrokerReplacementPhase{"1": v1beta1.BrokerReplacementResyncing},
+			expectedRemaining:    []string{"kafka-1-abcde"},
+		},
+		{
+			testName:         "recreated broker is in sync",
+			hasBrokerState:   true,
+			replacementState: &v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementResyncing, OutOfSyncReplicas: 4},
+			objects:          []client.Object{pod(true)},
+			brokerState: &ccTypes.KafkaBrokerState{
+				ReplicaCountByBrokerID:   map[string]int32{"1": 10},
+				OutOfSyncCountByBrokerID: map[string]int32{"1": 0},
+			},
+			expectedState:        v1beta1.BrokerReplacementState{ID: "r1", Phase: v1beta1.BrokerReplacementSucceeded},
+			expectedReplacements: map[string]v1beta1.BrokerReplacementPhase{},
+			expectedRemaining:    []string{"kafka-1-abcde"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Spec: v1beta1.KafkaClusterSpec{
+					Brokers: []v1beta1.Broker{
+						{Id: 0},
+						{Id: 1, Replacement: &v1beta1.BrokerReplacement{ID: "r1", KeepVolumes: test.keepVolumes}},
+					},
+				},
+			}
+			if test.hasBrokerState {
+				cluster.Status.BrokersState = map[string]v1beta1.BrokerState{"1": {ReplacementState: test.replacementState}}
+			}
+			scheme := runtime.NewScheme()
+			require.NoError(t, clientgoscheme.AddToScheme(scheme))
+			require.NoError(t, v1beta1.AddToScheme(scheme))
+			require.NoError(t, v1alpha1.AddToScheme(scheme))
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(test.objects, cluster)...).
+				WithStatusSubresource(cluster, &v1alpha1.CruiseControlOperation{}).Build()
+
+			mockCtrl := gomock.NewController(t)
+			cc := controllerMocks.NewMockCruiseControlScaler(mockCtrl)
+			if test.brokerState != nil {
+				cc.EXPECT().KafkaClusterState(gomock.Any()).Return(&ccTypes.KafkaClusterState{
+					KafkaBrokerState:    *test.brokerState,
+					KafkaPartitionState: ccTypes.KafkaPartitionState{UnderReplicatedPartitions: test.underReplicated, Offline: test.offline},
+				}, nil)
+			}
+
+			r := New(c, nil, cluster, nil, nil)
+			r.CruiseControlScalerFactory = controllerMocks.NewMockScaleFactory(cc)
+			replacements, err := r.reconcileBrokerReplacements(context.Background(), logf.Log)
+			require.NoError(t, err)
+			require.Equal(t, test.expectedReplacements, replacements)
+
+			state := r.KafkaCluster.Status.BrokersState["1"].ReplacementState
+			require.NotNil(t, state)
+			state.LastUpdateTime = metav1.Time{}
+			if test.expectDemote {
+				// the broker is demoted through a new demote_broker CruiseControlOperation
+				require.NotNil(t, state.DemoteOperationReference)
+				operation := &v1alpha1.CruiseControlOperation{}
+				require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: state.DemoteOperationReference.Name, Namespace: "kafka"}, operation))
+				require.NotEqual(t, demoteRef.Name, operation.Name)
+				require.Equal(t, v1alpha1.OperationDemoteBroker, operation.CurrentTaskOperation())
+				require.Equal(t, map[string]string{scale.ParamBrokerID: "1"}, operation.CurrentTaskParameters())
+				require.Equal(t, "kafka", operation.GetClusterRef())
+				state.DemoteOperationReference = nil
+			}
+			require.Equal(t, test.expectedState, *state)
+
+			var remaining []string
+			for _, obj := range test.objects {
+				err := c.Get(context.Background(), types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}, obj)
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				require.NoError(t, err)
+				remaining = append(remaining, obj.GetName())
+			}
+			require.Equal(t, test.expectedRemaining, remaining)
+		})
+	}
+}
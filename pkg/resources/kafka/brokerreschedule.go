@@ -0,0 +1,189 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	banzaiv1beta1 "github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+)
+
+// rescheduleUnschedulableBroker reschedules the broker whose pod has been unschedulable for longer than the pending
+// timeout of the rescheduling policy because its local volumes are bound to an unavailable node. The persistent volume
+// claims of those volumes are deleted together with the pod, they are recreated on another node by the next
+// reconciliation. It returns true when the broker has been rescheduled.
+func (r *Reconciler) rescheduleUnschedulableBroker(log logr.Logger, pod *corev1.Pod) (bool, error) {
+	config := r.KafkaCluster.Spec.BrokerRescheduling
+	if config == nil || config.Policy != banzaiv1beta1.BrokerReschedulingPolicyRecreateVolumes {
+		return false, nil
+	}
+	unschedulableSince, unschedulable := podUnschedulableSince(pod)
+	if !unschedulable || time.Since(unschedulableSince) < config.GetPendingTimeout() {
+		return false, nil
+	}
+
+	brokerID := pod.Labels[banzaiv1beta1.BrokerIdLabelKey]
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := r.List(context.TODO(), pvcList, client.InNamespace(r.KafkaCluster.Namespace),
+		client.MatchingLabels(apiutil.MergeLabels(apiutil.LabelsForKafka(r.KafkaCluster.Name),
+			map[string]string{banzaiv1beta1.BrokerIdLabelKey: brokerID}))); err != nil {
+		return false, errors.WrapIfWithDetails(err, "failed to list the persistent volume claims of the broker",
+			banzaiv1beta1.BrokerIdLabelKey, brokerID)
+	}
+
+	var stranded []*corev1.PersistentVolumeClaim
+	unavailableNodes := make(map[string]struct{})
+	for i := range pvcList.Items {
+		pvc := &pvcList.Items[i]
+		nodeName, err := r.unavailableLocalVolumeNode(pvc)
+		if err != nil {
+			return false, err
+		}
+		if nodeName == "" {
+			continue
+		}
+		waitForFirstConsumer, err := r.hasWaitForFirstConsumerBinding(pvc)
+		if err != nil {
+			return false, err
+		}
+		if !waitForFirstConsumer {
+			log.Info("the volume of the unschedulable broker is bound to an unavailable node but its storage class does not bind volumes with the WaitForFirstConsumer mode, it is not recreated",
+				banzaiv1beta1.BrokerIdLabelKey, brokerID, "pvc", pvc.Name, "node", nodeName)
+			continue
+		}
+		stranded = append(stranded, pvc)
+		unavailableNodes[nodeName] = struct{}{}
+	}
+	if len(stranded) == 0 {
+		return false, nil
+	}
+
+	pvcNames := make([]string, 0, len(stranded))
+	for _, pvc := range stranded {
+		if err := r.Delete(context.TODO(), pvc); client.IgnoreNotFound(err) != nil {
+			return false, errorfactory.New(errorfactory.APIFailure{}, err, "deleting the persistent volume claim of the unschedulable broker failed",
+				banzaiv1beta1.BrokerIdLabelKey, brokerID, "pvc", pvc.Name)
+		}
+		pvcNames = append(pvcNames, pvc.Name)
+	}
+	if err := r.Delete(context.TODO(), pod); client.IgnoreNotFound(err) != nil {
+		return false, errorfactory.New(errorfactory.APIFailure{}, err, "deleting the pod of the unschedulable broker failed",
+			banzaiv1beta1.BrokerIdLabelKey, brokerID, "pod", pod.Name)
+	}
+
+	nodeNames := make([]string, 0, len(unavailableNodes))
+	for nodeName := range unavailableNodes {
+		nodeNames = append(nodeNames, nodeName)
+	}
+	sort.Strings(nodeNames)
+	log.Info("unschedulable broker rescheduled with new volumes", banzaiv1beta1.BrokerIdLabelKey, brokerID, "pod", pod.Name,
+		"pvcs", pvcNames, "nodes", nodeNames)
+	k8sutil.RecordEvent(r.Recorder, r.KafkaCluster, corev1.EventTypeWarning, brokerRescheduledEventReason,
+		"broker %s is rescheduled with new volumes as its pod %s could not be scheduled for %s, the volumes %s are bound to the unavailable nodes %s",
+		brokerID, pod.Name, config.GetPendingTimeout(), strings.Join(pvcNames, ", "), strings.Join(nodeNames, ", "))
+	return true, nil
+}
+
+// podUnschedulableSince returns when the pending pod has been found unschedulable by the scheduler
+func podUnschedulableSince(pod *corev1.Pod) (time.Time, bool) {
+	if pod.Status.Phase != corev1.PodPending || pod.DeletionTimestamp != nil {
+		return time.Time{}, false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse &&
+			condition.Reason == corev1.PodReasonUnschedulable {
+			return condition.LastTransitionTime.Time, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// unavailableLocalVolumeNode returns the node of the local persistent volume bound to the claim when the node was
+// removed, cordoned or is not ready
+func (r *Reconciler) unavailableLocalVolumeNode(pvc *corev1.PersistentVolumeClaim) (string, error) {
+	if pvc.Spec.VolumeName == "" {
+		return "", nil
+	}
+	pv := &corev1.PersistentVolume{}
+	if err := r.DirectClient.Get(context.TODO(), types.NamespacedName{Name: pvc.Spec.VolumeName}, pv); err != nil {
+		return "", errors.WrapIfWithDetails(client.IgnoreNotFound(err), "failed to get the persistent volume of the broker",
+			"pv", pvc.Spec.VolumeName)
+	}
+	hostname := localVolumeHostname(pv)
+	if hostname == "" {
+		return "", nil
+	}
+
+	nodeList := &corev1.NodeList{}
+	if err := r.List(context.TODO(), nodeList, client.MatchingLabels{corev1.LabelHostname: hostname}); err != nil {
+		return "", errors.WrapIfWithDetails(err, "failed to list the node of the persistent volume", "pv", pv.Name)
+	}
+	if len(nodeList.Items) == 0 {
+		return hostname, nil
+	}
+	node := &nodeList.Items[0]
+	if node.Spec.Unschedulable {
+		return node.Name, nil
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady && condition.Status != corev1.ConditionTrue {
+			return node.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// localVolumeHostname returns the hostname of the node the persistent volume is pinned to by its node affinity
+func localVolumeHostname(pv *corev1.PersistentVolume) string {
+	if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+		return ""
+	}
+	for _, term := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+		for _, expression := range term.MatchExpressions {
+			if expression.Key == corev1.LabelHostname && expression.Operator == corev1.NodeSelectorOpIn && len(expression.Values) == 1 {
+				return expression.Values[0]
+			}
+		}
+	}
+	return ""
+}
+
+// hasWaitForFirstConsumerBinding returns true when the storage class of the claim delays the binding of its volumes
+// until the pod using them is scheduled
+func (r *Reconciler) hasWaitForFirstConsumerBinding(pvc *corev1.PersistentVolumeClaim) (bool, error) {
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return false, nil
+	}
+	storageClass := &storagev1.StorageClass{}
+	if err := r.DirectClient.Get(context.TODO(), types.NamespacedName{Name: *pvc.Spec.StorageClassName}, storageClass); err != nil {
+		return false, errors.WrapIfWithDetails(client.IgnoreNotFound(err), "failed to get the storage class of the broker volume",
+			"storageClass", *pvc.Spec.StorageClassName)
+	}
+	return storageClass.VolumeBindingMode != nil && *storageClass.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer, nil
+}
@@ -0,0 +1,183 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/util"
+)
+
+func TestRescheduleUnschedulableBroker(t *testing.T) {
+	brokerLabels := map[string]string{"app": "kafka", "kafka_cr": "kafka", v1beta1.BrokerIdLabelKey: "0"}
+	pendingPod := func(unschedulableFor time.Duration) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "kafka-0-abcde", Namespace: "kafka", Labels: brokerLabels},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				Conditions: []corev1.PodCondition{{
+					Type:               corev1.PodScheduled,
+					Status:             corev1.ConditionFalse,
+					Reason:             corev1.PodReasonUnschedulable,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-unschedulableFor)),
+				}},
+			},
+		}
+	}
+	node := func(name string, unschedulable bool, ready corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{corev1.LabelHostname: name}},
+			Spec:       corev1.NodeSpec{Unschedulable: unschedulable},
+			Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}}},
+		}
+	}
+	localPV := func(hostname string) *corev1.PersistentVolume {
+		return &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "local-pv"},
+			Spec: corev1.PersistentVolumeSpec{NodeAffinity: &corev1.VolumeNodeAffinity{Required: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key: corev1.LabelHostname, Operator: corev1.NodeSelectorOpIn, Values: []string{hostname},
+				}}}},
+			}}},
+		}
+	}
+	storageClass := func(name string, mode storagev1.VolumeBindingMode) *storagev1.StorageClass {
+		return &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: name}, Provisioner: "local", VolumeBindingMode: &mode}
+	}
+	pvc := func(storageClassName string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "kafka-0-storage-0-abcde", Namespace: "kafka", Labels: brokerLabels},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "local-pv", StorageClassName: util.StringPointer(storageClassName)},
+		}
+	}
+	objects := func(extra ...client.Object) []client.Object {
+		return append([]client.Object{
+			storageClass("local", storagev1.VolumeBindingWaitForFirstConsumer),
+			storageClass("immediate", storagev1.VolumeBindingImmediate),
+		}, extra...)
+	}
+	recreateVolumes := &v1beta1.BrokerReschedulingConfig{Policy: v1beta1.BrokerReschedulingPolicyRecreateVolumes}
+
+	testCases := []struct {
+		testName     string
+		rescheduling *v1beta1.BrokerReschedulingConfig
+		pod          *corev1.Pod
+		objects      []client.Object
+		expected     bool
+	}{
+		{
+			testName:     "removed node",
+			rescheduling: recreateVolumes,
+			pod:          pendingPod(10 * time.Minute),
+			objects:      objects(localPV("removed"), pvc("local")),
+			expected:     true,
+		},
+		{
+			testName:     "cordoned node",
+			rescheduling: recreateVolumes,
+			pod:          pendingPod(10 * time.Minute),
+			objects:      objects(node("cordoned", true, corev1.ConditionTrue), localPV("cordoned"), pvc("local")),
+			expected:     true,
+		},
+		{
+			testName:     "not ready node",
+			rescheduling: recreateVolumes,
+			pod:          pendingPod(10 * time.Minute),
+			objects:      objects(node("failed", false, corev1.ConditionUnknown), localPV("failed"), pvc("local")),
+			expected:     true,
+		},
+		{
+			testName: "manual policy",
+			pod:      pendingPod(10 * time.Minute),
+			objects:  objects(localPV("removed"), pvc("local")),
+		},
+		{
+			testName:     "pending timeout not elapsed",
+			rescheduling: recreateVolumes,
+			pod:          pendingPod(time.Minute),
+			objects:      objects(localPV("removed"), pvc("local")),
+		},
+		{
+			testName: "custom pending timeout elapsed",
+			rescheduling: &v1beta1.BrokerReschedulingConfig{
+				Policy:                v1beta1.BrokerReschedulingPolicyRecreateVolumes,
+				PendingTimeoutSeconds: util.Int32Pointer(30),
+			},
+			pod:      pendingPod(time.Minute),
+			objects:  objects(localPV("removed"), pvc("local")),
+			expected: true,
+		},
+		{
+			testName:     "available node",
+			rescheduling: recreateVolumes,
+			pod:          pendingPod(10 * time.Minute),
+			objects:      objects(node("ready", false, corev1.ConditionTrue), localPV("ready"), pvc("local")),
+		},
+		{
+			testName:     "immediate volume binding",
+			rescheduling: recreateVolumes,
+			pod:          pendingPod(10 * time.Minute),
+			objects:      objects(localPV("removed"), pvc("immediate")),
+		},
+		{
+			testName:     "network attached volume",
+			rescheduling: recreateVolumes,
+			pod:          pendingPod(10 * time.Minute),
+			objects:      objects(&corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "local-pv"}}, pvc("local")),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithObjects(append(test.objects, test.pod)...).Build()
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Spec:       v1beta1.KafkaClusterSpec{BrokerRescheduling: test.rescheduling},
+			}
+			recorder := record.NewFakeRecorder(1)
+			r := New(c, c, cluster, nil, recorder)
+
+			rescheduled, err := r.rescheduleUnschedulableBroker(logf.Log, test.pod)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, rescheduled)
+
+			podErr := c.Get(context.Background(), client.ObjectKeyFromObject(test.pod), &corev1.Pod{})
+			pvcErr := c.Get(context.Background(), client.ObjectKey{Name: "kafka-0-storage-0-abcde", Namespace: "kafka"}, &corev1.PersistentVolumeClaim{})
+			if test.expected {
+				require.True(t, apierrors.IsNotFound(podErr))
+				require.True(t, apierrors.IsNotFound(pvcErr))
+				require.Len(t, recorder.Events, 1)
+			} else {
+				require.NoError(t, podErr)
+				require.NoError(t, pvcErr)
+				require.Empty(t, recorder.Events)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	certutil "github.com/banzaicloud/koperator/pkg/util/cert"
+	kafkautils "github.com/banzaicloud/koperator/pkg/util/kafka"
+)
+
+// reconcileCertificateReload makes the running brokers reload the keystores and truststores of their SSL listeners
+// whose served certificate differs from the one in the keystore secret of the listener, e.g. after a cert-manager
+// renewal. Kafka reloads the keystore and the truststore of a listener when their locations are set again through the
+// dynamic broker configuration and the files have been modified. The ids of the brokers still serving an old
+// certificate are returned, since the kubelet refreshes the mounted secrets with a delay the reload is retried until
+// the brokers serve the renewed certificates.
+func (r *Reconciler) reconcileCertificateReload(ctx context.Context, log logr.Logger) ([]string, error) {
+	if !r.KafkaCluster.Spec.ListenersConfig.HotReloadCertificates {
+		return nil, nil
+	}
+
+	var listeners []v1beta1.CommonListenerSpec
+	for _, iListener := range r.KafkaCluster.Spec.ListenersConfig.InternalListeners {
+		// the KRaft controllers are not configured through the dynamic broker configuration
+		if r.KafkaCluster.Spec.KRaftMode && iListener.UsedForControllerCommunication {
+			continue
+		}
+		listeners = append(listeners, iListener.CommonListenerSpec)
+	}
+	for _, eListener := range r.KafkaCluster.Spec.ListenersConfig.ExternalListeners {
+		listeners = append(listeners, eListener.CommonListenerSpec)
+	}
+
+	expected := make(map[string][]byte)
+	for _, listener := range listeners {
+		if listener.Type != v1beta1.SecurityProtocolSSL {
+			continue
+		}
+		secret, err := getListenerSSLCertSecret(r.Client, listener, r.KafkaCluster.Name, r.KafkaCluster.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		tlsCert, err := certutil.ParseKeyStoreToTLSCertificate(secret.Data[v1alpha1.TLSJKSKeyStore], secret.Data[v1alpha1.PasswordKey])
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "failed to decode certificate", "secretName", secret.Name)
+		}
+		expected[listener.Name] = tlsCert.Leaf.Raw
+	}
+	if len(expected) == 0 {
+		return nil, nil
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList, client.InNamespace(r.KafkaCluster.Namespace),
+		client.MatchingLabels(apiutil.LabelsForKafka(r.KafkaCluster.Name))); err != nil {
+		return nil, errors.WrapIf(err, "failed to list the broker pods")
+	}
+
+	reloading := make(map[string]struct{})
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		brokerID, ok := pod.Labels[v1beta1.BrokerIdLabelKey]
+		if !ok || pod.Status.PodIP == "" || !isPodReady(pod) {
+			continue
+		}
+		id, err := strconv.Atoi(brokerID)
+		if err != nil {
+			continue
+		}
+		reloadConfig := make(map[string]*string)
+		for _, listener := range listeners {
+			certificate, ok := expected[listener.Name]
+			if !ok {
+				continue
+			}
+			address := net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(listener.ContainerPort)))
+			served, err := r.ServedCertificate(ctx, address)
+			if err != nil {
+				// the listeners of the KRaft broker and controller nodes differ
+				log.V(1).Info("could not read the certificate served by the broker", v1beta1.BrokerIdLabelKey, brokerID,
+					"listener", listener.Name, "error", err.Error())
+				continue
+			}
+			if bytes.Equal(served.Raw, certificate) {
+				continue
+			}
+			for key, value := range listenerStoreLocations(listener.Name) {
+				reloadConfig[key] = &value
+			}
+		}
+		if len(reloadConfig) == 0 {
+			continue
+		}
+
+		log.Info("reloading the renewed certificates of the broker", v1beta1.BrokerIdLabelKey, brokerID)
+		if err := r.reloadBrokerCertificates(int32(id), reloadConfig); err != nil {
+			return nil, err
+		}
+		k8sutil.RecordEvent(r.Recorder, r.KafkaCluster, corev1.EventTypeNormal, certificatesReloadedEventReason,
+			"the renewed certificates of broker %s are being reloaded", brokerID)
+		reloading[brokerID] = struct{}{}
+	}
+
+	var brokerIDs []string
+	for brokerID := range reloading {
+		brokerIDs = append(brokerIDs, brokerID)
+	}
+	sort.Slice(brokerIDs, func(i, j int) bool {
+		idI, _ := strconv.Atoi(brokerIDs[i])
+		idJ, _ := strconv.Atoi(brokerIDs[j])
+		return idI < idJ
+	})
+	return brokerIDs, nil
+}
+
+func (r *Reconciler) reloadBrokerCertificates(brokerID int32, config map[string]*string) error {
+	kClient, closeClient, err := r.kafkaClientProvider.NewFromCluster(r.Client, r.KafkaCluster)
+	if err != nil {
+		return errorfactory.New(errorfactory.BrokersUnreachable{}, err, "could not connect to kafka brokers")
+	}
+	defer closeClient()
+
+	if err := kClient.SetPerBrokerConfig(brokerID, config); err != nil {
+		return errors.WrapIfWithDetails(err, "could not reload the certificates of the broker", v1beta1.BrokerIdLabelKey, brokerID)
+	}
+	return nil
+}
+
+// listenerStoreLocations returns the keystore and truststore locations of the SSL listener as set in the broker
+// configuration, setting them again makes the broker reload the stores
+func listenerStoreLocations(listenerName string) map[string]string {
+	namedKeystorePath := fmt.Sprintf(listenerServerKeyStorePathTemplate, serverKeystorePath, listenerName)
+	return map[string]string{
+		fmt.Sprintf("%s.%s.%s", kafkautils.KafkaConfigListenerName, listenerName, kafkautils.KafkaConfigSSLKeyStoreLocation):   namedKeystorePath + "/" + v1alpha1.TLSJKSKeyStore,
+		fmt.Sprintf("%s.%s.%s", kafkautils.KafkaConfigListenerName, listenerName, kafkautils.KafkaConfigSSLTrustStoreLocation): namedKeystorePath + "/" + v1alpha1.TLSJKSTrustStore,
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/kafkaclient"
+	"github.com/banzaicloud/koperator/pkg/resources/kafka/mocks"
+	certutil "github.com/banzaicloud/koperator/pkg/util/cert"
+)
+
+func TestReconcileCertificateReload(t *testing.T) {
+	generateCertificate := func() ([]byte, []byte) {
+		cert, key, _, err := certutil.GenerateTestCert()
+		require.NoError(t, err)
+		return cert, key
+	}
+	renewedCert, renewedKey := generateCertificate()
+	oldCert, _ := generateCertificate()
+	keyStore, password, err := certutil.GenerateJKSFromByte(renewedCert, renewedKey, renewedCert)
+	require.NoError(t, err)
+	renewed, err := certutil.DecodeCertificate(renewedCert)
+	require.NoError(t, err)
+	old, err := certutil.DecodeCertificate(oldCert)
+	require.NoError(t, err)
+
+	brokerPod := func(id, ip string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kafka-" + id + "-abcde",
+				Namespace: "kafka",
+				Labels:    apiutil.MergeLabels(apiutil.LabelsForKafka("kafka"), map[string]string{v1beta1.BrokerIdLabelKey: id}),
+			},
+			Status: corev1.PodStatus{
+				PodIP:      ip,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		testName          string
+		hotReload         bool
+		served            map[string]*x509.Certificate
+		expectedReloading []string
+	}{
+		{
+			testName: "hot reload disabled",
+			served:   map[string]*x509.Certificate{"10.0.0.1:29092": old, "10.0.0.2:29092": old},
+		},
+		{
+			testName:  "brokers serve the certificate of the secret",
+			hotReload: true,
+			served:    map[string]*x509.Certificate{"10.0.0.1:29092": renewed, "10.0.0.2:29092": renewed},
+		},
+		{
+			testName:          "broker serves an old certificate",
+			hotReload:         true,
+			served:            map[string]*x509.Certificate{"10.0.0.1:29092": renewed, "10.0.0.2:29092": old},
+			expectedReloading: []string{"1"},
+		},
+		{
+			testName:  "served certificate can not be read",
+			hotReload: true,
+			served:    map[string]*x509.Certificate{"10.0.0.1:29092": renewed},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Spec: v1beta1.KafkaClusterSpec{
+					ListenersConfig: v1beta1.ListenersConfig{
+						HotReloadCertificates: test.hotReload,
+						InternalListeners: []v1beta1.InternalListenerConfig{{
+							CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolSSL, ContainerPort: 29092},
+						}},
+					},
+				},
+			}
+			c := fake.NewClientBuilder().WithObjects(
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "kafka-server-certificate", Namespace: "kafka"},
+					Data: map[string][]byte{
+						v1alpha1.TLSJKSKeyStore:   keyStore,
+						v1alpha1.TLSJKSTrustStore: keyStore,
+						v1alpha1.PasswordKey:      password,
+					},
+				},
+				brokerPod("0", "10.0.0.1"),
+				brokerPod("1", "10.0.0.2"),
+			).Build()
+
+			kafkaClientProvider := new(kafkaclient.MockedProvider)
+			kafkaClient := mocks.NewMockKafkaClient(gomock.NewController(t))
+			kafkaClientProvider.On("NewFromCluster", mock.Anything, mock.Anything).Return(kafkaClient, func() {}, nil)
+			// only the broker serving the old certificate reloads its stores
+			if test.expectedReloading != nil {
+				keyStoreLocation := "/var/run/secrets/java.io/keystores/server/internal/keystore.jks"
+				trustStoreLocation := "/var/run/secrets/java.io/keystores/server/internal/truststore.jks"
+				kafkaClient.EXPECT().SetPerBrokerConfig(int32(1), map[string]*string{
+					"listener.name.internal.ssl.keystore.location":   &keyStoreLocation,
+					"listener.name.internal.ssl.truststore.location": &trustStoreLocation,
+				}).Return(nil)
+			}
+
+			r := New(c, nil, cluster, kafkaClientProvider, nil)
+			r.ServedCertificate = func(_ context.Context, address string) (*x509.Certificate, error) {
+				if served, ok := test.served[address]; ok {
+					return served, nil
+				}
+				return nil, errors.New("connection refused")
+			}
+
+			reloading, err := r.reconcileCertificateReload(context.Background(), logr.Discard())
+			require.NoError(t, err)
+			require.Equal(t, test.expectedReloading, reloading)
+		})
+	}
+}
@@ -19,7 +19,9 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"emperror.dev/errors"
@@ -34,6 +36,7 @@ import (
 	apiutil "github.com/banzaicloud/koperator/api/util"
 	"github.com/banzaicloud/koperator/api/v1alpha1"
 	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
 	"github.com/banzaicloud/koperator/pkg/resources/templates"
 	"github.com/banzaicloud/koperator/pkg/util"
 	kafkautils "github.com/banzaicloud/koperator/pkg/util/kafka"
@@ -62,14 +65,23 @@ func (r *Reconciler) getConfigProperties(bConfig *v1beta1.BrokerConfig, broker v
 	config.Merge(brokerConfig)
 	config.Merge(generalConfig)
 
+	// The keystore passwords managed by a password policy are resolved from the mounted secrets
+	if r.KafkaCluster.Spec.ListenersConfig.SSLSecrets.GetPasswordPolicy() != nil {
+		kafkautils.ConfigureKeystoreConfigProvider(config, log)
+	}
+
 	// Cruise Control metrics reporter configuration
 	r.configCCMetricsReporter(broker, bConfig, config, clientPass, log)
 
 	brokerReadOnlyConfig := getBrokerReadOnlyConfig(broker, r.KafkaCluster, log)
 
+	// SASL listeners configuration
+	configureSASLListeners(broker.Id, r.KafkaCluster.Spec.ListenersConfig, r.oauthBearerConfigs, config, brokerReadOnlyConfig, log)
+
 	// Kafka Broker configurations
 	if r.KafkaCluster.Spec.KRaftMode {
-		configureBrokerKRaftMode(bConfig, broker.Id, r.KafkaCluster, config, quorumVoters, serverPasses, extListenerStatuses, intListenerStatuses, log, brokerReadOnlyConfig)
+		configureBrokerKRaftMode(bConfig, broker.Id, r.KafkaCluster, config, quorumVoters, serverPasses, extListenerStatuses, intListenerStatuses, log,
+			getNodeMetadataMode(r.KafkaCluster, broker.Id, bConfig, brokerReadOnlyConfig))
 	} else {
 		configureBrokerZKMode(broker.Id, r.KafkaCluster, config, extListenerStatuses, intListenerStatuses, controllerIntListenerStatuses, log)
 	}
@@ -87,6 +99,13 @@ func (r *Reconciler) getConfigProperties(bConfig *v1beta1.BrokerConfig, broker v
 		log.Error(err, "could not get mountPaths from broker configmap", v1beta1.BrokerIdLabelKey, broker.Id)
 	}
 
+	// the log dirs released by the broker are not carried over from its current configuration
+	released := releasedLogDirs(r.KafkaCluster.Status.BrokersState[strconv.Itoa(int(broker.Id))])
+	mountPathsOld = slices.DeleteFunc(mountPathsOld, func(mountPath string) bool {
+		_, ok := released[mountPath]
+		return ok
+	})
+
 	mountPathsNew := generateStorageConfig(bConfig.StorageConfigs)
 	mountPathsMerged, isMountPathRemoved := mergeMountPaths(mountPathsOld, mountPathsNew)
 
@@ -102,8 +121,22 @@ func (r *Reconciler) getConfigProperties(bConfig *v1beta1.BrokerConfig, broker v
 		}
 	}
 
+	// Add principal builder configuration
+	configurePrincipalBuilder(r.KafkaCluster.Spec.PrincipalConfig, config, log)
+
+	// Add authorizer configuration
+	configureAuthorizer(r.KafkaCluster.Spec.AuthorizationConfig, config, log)
+
+	// Add tiered storage configuration, the controller-only nodes do not host log segments
+	if !bConfig.IsControllerOnlyNode() {
+		configureTieredStorage(r.KafkaCluster.Spec.TieredStorage, r.KafkaCluster.Spec.ListenersConfig, config, log)
+	}
+
+	// Add internal topics replication configuration
+	configureInternalTopicsReplication(r.KafkaCluster.Spec, config, brokerReadOnlyConfig, log)
+
 	// Add superuser configuration
-	su := strings.Join(generateSuperUsers(superUsers), ";")
+	su := strings.Join(mergeSuperUsers(generateSuperUsers(superUsers), r.KafkaCluster.Spec.AuthorizationConfig.GetSuperUsers()), ";")
 	if su != "" {
 		if err := config.Set(kafkautils.KafkaConfigSuperUsers, su); err != nil {
 			log.Error(err, fmt.Sprintf(kafkautils.BrokerConfigErrorMsgTemplate, kafkautils.KafkaConfigSuperUsers))
@@ -164,12 +197,12 @@ func (r *Reconciler) configCCMetricsReporter(broker v1beta1.Broker, bConfig *v1b
 
 func configureBrokerKRaftMode(bConfig *v1beta1.BrokerConfig, brokerID int32, kafkaCluster *v1beta1.KafkaCluster, config *properties.Properties,
 	quorumVoters []string, serverPasses map[string]string, extListenerStatuses, intListenerStatuses map[string]v1beta1.ListenerStatusList, log logr.Logger,
-	brokerReadOnlyConfig *properties.Properties) {
+	metadataMode nodeMetadataMode) {
 	controllerListenerName := generateControlPlaneListener(kafkaCluster.Spec.ListenersConfig.InternalListeners)
 
 	// when kRaft is enabled for the cluster, brokers can still be configured to use zookeeper for metadata.
 	// this is to support the zk to kRaft migration where both zookeeper and kRaft controllers are running in parallel.
-	if shouldUseKRaftModeForBroker(brokerReadOnlyConfig) {
+	if metadataMode.kRaftMode {
 		if err := config.Set(kafkautils.KafkaConfigNodeID, brokerID); err != nil {
 			log.Error(err, fmt.Sprintf(kafkautils.BrokerConfigErrorMsgTemplate, kafkautils.KafkaConfigNodeID))
 		}
@@ -177,6 +210,14 @@ func configureBrokerKRaftMode(bConfig *v1beta1.BrokerConfig, brokerID int32, kaf
 		if err := config.Set(kafkautils.KafkaConfigProcessRoles, bConfig.Roles); err != nil {
 			log.Error(err, fmt.Sprintf(kafkautils.BrokerConfigErrorMsgTemplate, kafkautils.KafkaConfigProcessRoles))
 		}
+
+		// the controllers migrating the metadata from zookeeper keep writing it to zookeeper until the migration is finalized
+		if metadataMode.zkMigration {
+			if err := config.Set(kafkautils.KafkaConfigZooKeeperConnect, zookeeperutils.PrepareConnectionAddress(
+				kafkaCluster.Spec.ZKAddresses, kafkaCluster.Spec.GetZkPath())); err != nil {
+				log.Error(err, fmt.Sprintf(kafkautils.BrokerConfigErrorMsgTemplate, kafkautils.KafkaConfigZooKeeperConnect))
+			}
+		}
 	} else { // use zk mode for broker.
 		// when in zk mode, "broker.id" and "zookeeper.connect" are configured so it will communicate with zookeeper
 		// control.plane.listener.name will not be set in zk mode.  There for it will default to the interbroker listener.
@@ -190,7 +231,13 @@ func configureBrokerKRaftMode(bConfig *v1beta1.BrokerConfig, brokerID int32, kaf
 		}
 	}
 
-	if shouldConfigureControllerQuorumForBroker(brokerReadOnlyConfig) {
+	if metadataMode.zkMigration {
+		if err := config.Set(kafkautils.KafkaConfigZooKeeperMetadataMigrationEnable, true); err != nil {
+			log.Error(err, fmt.Sprintf(kafkautils.BrokerConfigErrorMsgTemplate, kafkautils.KafkaConfigZooKeeperMetadataMigrationEnable))
+		}
+	}
+
+	if metadataMode.controllerQuorum {
 		if err := config.Set(kafkautils.KafkaConfigControllerQuorumVoters, quorumVoters); err != nil {
 			log.Error(err, fmt.Sprintf(kafkautils.BrokerConfigErrorMsgTemplate, kafkautils.KafkaConfigControllerQuorumVoters))
 		}
@@ -316,6 +363,64 @@ func mergeMountPaths(mountPathsOld, mountPathsNew []string) ([]string, bool) {
 	return mountPathsMerged, isMountPathRemoved
 }
 
+func configurePrincipalBuilder(principalConfig *v1beta1.PrincipalConfig, config *properties.Properties, log logr.Logger) {
+	if principalBuilderClass := principalConfig.GetPrincipalBuilderClass(); principalBuilderClass != "" {
+		if err := config.Set(kafkautils.KafkaConfigPrincipalBuilderClass, principalBuilderClass); err != nil {
+			log.Error(err, fmt.Sprintf(kafkautils.BrokerConfigErrorMsgTemplate, kafkautils.KafkaConfigPrincipalBuilderClass))
+		}
+	}
+	if rules := principalConfig.GetSSLPrincipalMappingRules(); len(rules) > 0 {
+		if err := config.Set(kafkautils.KafkaConfigSSLPrincipalMappingRules, strings.Join(rules, ",")); err != nil {
+			log.Error(err, fmt.Sprintf(kafkautils.BrokerConfigErrorMsgTemplate, kafkautils.KafkaConfigSSLPrincipalMappingRules))
+		}
+	}
+}
+
+func configureAuthorizer(authorizationConfig *v1beta1.AuthorizationConfig, config *properties.Properties, log logr.Logger) {
+	if authorizerClassName := authorizationConfig.GetAuthorizerClassName(); authorizerClassName != "" {
+		if err := config.Set(kafkautils.KafkaConfigAuthorizerClassName, authorizerClassName); err != nil {
+			log.Error(err, fmt.Sprintf(kafkautils.BrokerConfigErrorMsgTemplate, kafkautils.KafkaConfigAuthorizerClassName))
+		}
+	}
+	if allowEveryone, ok := authorizationConfig.GetAllowEveryoneIfNoACLFound(); ok {
+		if err := config.Set(kafkautils.KafkaConfigAllowEveryoneIfNoACLFound, allowEveryone); err != nil {
+			log.Error(err, fmt.Sprintf(kafkautils.BrokerConfigErrorMsgTemplate, kafkautils.KafkaConfigAllowEveryoneIfNoACLFound))
+		}
+	}
+}
+
+// configureInternalTopicsReplication sets the replication settings of the internal topics derived from the number of
+// brokers, the ones set in readOnlyConfig are left to the user. Single-node clusters always get them as the Kafka
+// defaults require three brokers.
+func configureInternalTopicsReplication(kafkaClusterSpec v1beta1.KafkaClusterSpec, config, brokerReadOnlyConfig *properties.Properties, log logr.Logger) {
+	if !kafkaClusterSpec.InternalTopicsConfig.IsAutoReplicationEnabled() && !kafkaClusterSpec.IsSingleNodeCombinedMode() {
+		return
+	}
+	brokerCount, err := kafkautils.BrokerNodeCount(kafkaClusterSpec)
+	if err != nil {
+		log.Error(err, "could not determine the number of brokers for the internal topics replication configuration")
+		return
+	}
+	for key, value := range kafkautils.InternalTopicsReplicationConfig(nil, brokerCount) {
+		if _, found := brokerReadOnlyConfig.Get(key); found {
+			continue
+		}
+		if err := config.Set(key, value); err != nil {
+			log.Error(err, fmt.Sprintf(kafkautils.BrokerConfigErrorMsgTemplate, key))
+		}
+	}
+}
+
+// mergeSuperUsers appends the super users not yet present in generated, keeping their order
+func mergeSuperUsers(generated []string, superUsers []string) []string {
+	for _, superUser := range superUsers {
+		if !apiutil.StringSliceContains(generated, superUser) {
+			generated = append(generated, superUser)
+		}
+	}
+	return generated
+}
+
 func generateSuperUsers(users []string) (suStrings []string) {
 	suStrings = make([]string, 0)
 	for _, x := range users {
@@ -345,6 +450,70 @@ func (r *Reconciler) configMap(broker v1beta1.Broker, brokerConfig *v1beta1.Brok
 	return brokerConf
 }
 
+// mergeExternalBrokerConfig merges the broker configuration of the ConfigMap referenced by the broker config beneath
+// the generated broker configuration and records the hash of the referenced content on the broker ConfigMap
+func (r *Reconciler) mergeExternalBrokerConfig(ctx context.Context, brokerConf *corev1.ConfigMap, brokerConfig *v1beta1.BrokerConfig, log logr.Logger) error {
+	if brokerConfig == nil || brokerConfig.ConfigMapRef == nil {
+		return nil
+	}
+	ref := brokerConfig.ConfigMapRef
+	optional := ref.Optional != nil && *ref.Optional
+
+	externalConfigMap := &corev1.ConfigMap{}
+	err := r.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: r.KafkaCluster.GetNamespace()}, externalConfigMap)
+	if err != nil {
+		if apierrors.IsNotFound(err) && optional {
+			log.V(1).Info("optional external broker configuration not found", "configMap", ref.Name)
+			return nil
+		}
+		return errorfactory.New(errorfactory.ResourceNotReady{}, err, "could not get external broker configuration", "configMap", ref.Name)
+	}
+	content, found := externalConfigMap.Data[ref.Key]
+	if !found {
+		if optional {
+			log.V(1).Info("optional external broker configuration key not found", "configMap", ref.Name, "key", ref.Key)
+			return nil
+		}
+		return errorfactory.New(errorfactory.ResourceNotReady{}, errors.New("key not found"),
+			"could not get external broker configuration", "configMap", ref.Name, "key", ref.Key)
+	}
+
+	merged, err := mergeExternalBrokerConfigProperties(content, brokerConf.Data[kafkautils.ConfigPropertyName])
+	if err != nil {
+		return errorfactory.New(errorfactory.FatalReconcileError{}, err, "could not merge external broker configuration", "configMap", ref.Name, "key", ref.Key)
+	}
+	brokerConf.Data[kafkautils.ConfigPropertyName] = merged
+
+	if brokerConf.Annotations == nil {
+		brokerConf.Annotations = make(map[string]string)
+	}
+	brokerConf.Annotations[externalBrokerConfigHashAnnotation] = util.GetMD5Hash(content)
+	return nil
+}
+
+// mergeExternalBrokerConfigProperties merges the generated broker configuration over the external one, the
+// generated properties take precedence except super.users whose values are combined
+func mergeExternalBrokerConfigProperties(external, generated string) (string, error) {
+	finalBrokerConfig, err := properties.NewFromString(external)
+	if err != nil {
+		return "", errors.WrapIf(err, "could not parse external broker configuration")
+	}
+	generatedConfig, err := properties.NewFromString(generated)
+	if err != nil {
+		return "", errors.WrapIf(err, "could not parse generated broker configuration")
+	}
+
+	if suMerged := mergeSuperUsersPropertyValue(finalBrokerConfig, generatedConfig); suMerged != "" {
+		if err := generatedConfig.Set(kafkautils.KafkaConfigSuperUsers, suMerged); err != nil {
+			return "", err
+		}
+	}
+	finalBrokerConfig.Merge(generatedConfig)
+	finalBrokerConfig.Sort()
+
+	return finalBrokerConfig.String(), nil
+}
+
 func generateAdvertisedListenerConfig(id int32, l v1beta1.ListenersConfig,
 	extListenerStatuses, intListenerStatuses, controllerIntListenerStatuses map[string]v1beta1.ListenerStatusList) []string {
 	externalListenerConfig := make([]string, 0, len(l.ExternalListeners))
@@ -480,7 +649,7 @@ func getListenerSpecificConfig(l *v1beta1.ListenersConfig, serverPasses map[stri
 		listenerConfig = append(listenerConfig, fmt.Sprintf("%s://:%d", upperedListenerName, eListener.ContainerPort))
 		// Add external listeners SSL configuration
 		if eListener.Type == v1beta1.SecurityProtocolSSL {
-			maps.Copy(externalListenerSSLConfig, generateListenerSSLConfig(eListener.Name, eListener.SSLClientAuth, serverPasses[eListener.Name]))
+			maps.Copy(externalListenerSSLConfig, generateListenerSSLConfig(eListener.Name, eListener.GetSSLClientAuth(), serverPasses[eListener.Name]))
 		}
 	}
 
@@ -499,7 +668,7 @@ func getListenerSpecificConfig(l *v1beta1.ListenersConfig, serverPasses map[stri
 
 		// Add internal listeners SSL configuration
 		if iListener.Type == v1beta1.SecurityProtocolSSL {
-			maps.Copy(internalListenerSSLConfig, generateListenerSSLConfig(iListener.Name, iListener.SSLClientAuth, serverPasses[iListener.Name]))
+			maps.Copy(internalListenerSSLConfig, generateListenerSSLConfig(iListener.Name, iListener.GetSSLClientAuth(), serverPasses[iListener.Name]))
 		}
 	}
 
@@ -521,13 +690,7 @@ func generateListenerSSLConfig(name string, sslClientAuth v1beta1.SSLClientAuthe
 		fmt.Sprintf("%s.%s.%s", kafkautils.KafkaConfigListenerName, name, kafkautils.KafkaConfigSSLTrustStoreType):     trustStoreType,
 		fmt.Sprintf("%s.%s.%s", kafkautils.KafkaConfigListenerName, name, kafkautils.KafkaConfigSSLTrustStorePassword): password,
 		fmt.Sprintf("%s.%s.%s", kafkautils.KafkaConfigListenerName, name, kafkautils.KafkaConfigSSLKeyStorePassword):   password,
-	}
-
-	// enable 2-way SSL authentication if SSL is enabled but this field is not provided in the listener config
-	if sslClientAuth == "" {
-		listenerSSLConfig[fmt.Sprintf("%s.%s.%s", kafkautils.KafkaConfigListenerName, name, kafkautils.KafkaConfigSSLClientAuth)] = string(v1beta1.SSLClientAuthRequired)
-	} else {
-		listenerSSLConfig[fmt.Sprintf("%s.%s.%s", kafkautils.KafkaConfigListenerName, name, kafkautils.KafkaConfigSSLClientAuth)] = string(sslClientAuth)
+		fmt.Sprintf("%s.%s.%s", kafkautils.KafkaConfigListenerName, name, kafkautils.KafkaConfigSSLClientAuth):         string(sslClientAuth),
 	}
 
 	return listenerSSLConfig
@@ -596,6 +759,26 @@ func (r Reconciler) generateBrokerConfig(broker v1beta1.Broker, brokerConfig *v1
 	finalBrokerConfig.Delete(kafkautils.MigrationBrokerControllerQuorumConfigEnabled)
 	finalBrokerConfig.Delete(kafkautils.MigrationBrokerKRaftMode)
 
+	// The upgrade of the Kafka version owns the inter broker protocol version of the brokers in ZooKeeper mode
+	if ibp, ok := interBrokerProtocolVersion(r.KafkaCluster); ok {
+		if err := finalBrokerConfig.Set(kafkautils.KafkaConfigInterBrokerProtocolVersion, ibp); err != nil {
+			log.Error(err, "setting inter broker protocol version in broker configuration resulted in an error")
+		}
+	}
+
+	// Kafka 4.x removed the inter broker protocol configurations, the protocol follows metadata.version in KRaft mode
+	if version, ok := kafkautils.BrokerKafkaVersion(brokerConfig, r.KafkaCluster.Spec); ok && kafkautils.IsKRaftOnlyVersion(version) {
+		for _, key := range kafkautils.KRaftOnlyRemovedConfigs {
+			if _, found := finalBrokerConfig.Get(key); found {
+				log.V(1).Info("dropping broker configuration removed in the Kafka version", "config", key, "version", version.String())
+				finalBrokerConfig.Delete(key)
+			}
+		}
+	}
+
+	// The secret and environment variable placeholders are resolved by config providers when the broker starts
+	kafkautils.ResolveConfigPlaceholders(finalBrokerConfig, log)
+
 	finalBrokerConfig.Sort()
 
 	return finalBrokerConfig.String()
@@ -16,6 +16,7 @@
 package kafka
 
 import (
+	"context"
 	"reflect"
 	"strings"
 	"testing"
@@ -25,12 +26,14 @@ import (
 	"go.uber.org/mock/gomock"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	kafkautils "github.com/banzaicloud/koperator/pkg/util/kafka"
 
 	"github.com/banzaicloud/koperator/api/v1beta1"
 	"github.com/banzaicloud/koperator/pkg/resources"
 	mocks "github.com/banzaicloud/koperator/pkg/resources/kafka/mocks"
+	"github.com/banzaicloud/koperator/pkg/util"
 	properties "github.com/banzaicloud/koperator/properties/pkg"
 )
 
@@ -141,6 +144,9 @@ func TestGenerateBrokerConfig(t *testing.T) { //nolint funlen
 		sslClientAuth             v1beta1.SSLClientAuthentication
 		expectedConfig            string
 		perBrokerStorageConfig    []v1beta1.StorageConfig
+		principalConfig           *v1beta1.PrincipalConfig
+		authorizationConfig       *v1beta1.AuthorizationConfig
+		passwordPolicy            *v1beta1.KeystorePasswordPolicy
 	}{
 		{
 			testName:                  "basicConfig",
@@ -603,6 +609,100 @@ listener.security.protocol.map=INTERNAL:SSL
 listeners=INTERNAL://:9092
 metric.reporters=com.linkedin.kafka.cruisecontrol.metricsreporter.CruiseControlMetricsReporter
 super.users=User:CN=kafka-headless.kafka.svc.cluster.local
+zookeeper.connect=example.zk:2181/`,
+		},
+		{
+			testName:                  "configWithSSL_with_passwordPolicy",
+			readOnlyConfig:            ``,
+			zkAddresses:               []string{"example.zk:2181"},
+			zkPath:                    ``,
+			kubernetesClusterDomain:   ``,
+			clusterWideConfig:         ``,
+			perBrokerConfig:           ``,
+			perBrokerReadOnlyConfig:   ``,
+			advertisedListenerAddress: `kafka-0.kafka.svc.cluster.local:9092`,
+			listenerType:              "ssl",
+			sslClientAuth:             "none",
+			passwordPolicy:            &v1beta1.KeystorePasswordPolicy{Generator: v1beta1.KeystorePasswordGeneratorRandom},
+			expectedConfig: `advertised.listeners=INTERNAL://kafka-0.kafka.svc.cluster.local:9092
+broker.id=0
+config.providers=koperator
+config.providers.koperator.class=org.apache.kafka.common.config.provider.DirectoryConfigProvider
+cruise.control.metrics.reporter.bootstrap.servers=kafka-all-broker.kafka.svc.cluster.local:9092
+cruise.control.metrics.reporter.kubernetes.mode=true
+cruise.control.metrics.reporter.security.protocol=SSL
+cruise.control.metrics.reporter.ssl.keystore.location=/var/run/secrets/java.io/keystores/client/keystore.jks
+cruise.control.metrics.reporter.ssl.keystore.password=${koperator:/var/run/secrets/java.io/keystores/client:password}
+cruise.control.metrics.reporter.ssl.truststore.location=/var/run/secrets/java.io/keystores/client/truststore.jks
+cruise.control.metrics.reporter.ssl.truststore.password=${koperator:/var/run/secrets/java.io/keystores/client:password}
+inter.broker.listener.name=INTERNAL
+listener.name.internal.ssl.client.auth=none
+listener.name.internal.ssl.keystore.location=/var/run/secrets/java.io/keystores/server/internal/keystore.jks
+listener.name.internal.ssl.keystore.password=${koperator:/var/run/secrets/java.io/keystores/server/internal:password}
+listener.name.internal.ssl.keystore.type=JKS
+listener.name.internal.ssl.truststore.location=/var/run/secrets/java.io/keystores/server/internal/truststore.jks
+listener.name.internal.ssl.truststore.password=${koperator:/var/run/secrets/java.io/keystores/server/internal:password}
+listener.name.internal.ssl.truststore.type=JKS
+listener.security.protocol.map=INTERNAL:SSL
+listeners=INTERNAL://:9092
+metric.reporters=com.linkedin.kafka.cruisecontrol.metricsreporter.CruiseControlMetricsReporter
+super.users=User:CN=kafka-headless.kafka.svc.cluster.local
+zookeeper.connect=example.zk:2181/`,
+		},
+		{
+			testName:                  "principalConfig",
+			readOnlyConfig:            ``,
+			zkAddresses:               []string{"example.zk:2181"},
+			zkPath:                    ``,
+			kubernetesClusterDomain:   ``,
+			clusterWideConfig:         ``,
+			perBrokerConfig:           ``,
+			perBrokerReadOnlyConfig:   ``,
+			advertisedListenerAddress: `kafka-0.kafka.svc.cluster.local:9092`,
+			listenerType:              "plaintext",
+			principalConfig: &v1beta1.PrincipalConfig{
+				PrincipalBuilderClass:    "com.example.CustomPrincipalBuilder",
+				SSLPrincipalMappingRules: []string{"RULE:^CN=([^,]+).*$/$1/L", "DEFAULT"},
+			},
+			expectedConfig: `advertised.listeners=INTERNAL://kafka-0.kafka.svc.cluster.local:9092
+broker.id=0
+cruise.control.metrics.reporter.bootstrap.servers=kafka-all-broker.kafka.svc.cluster.local:9092
+cruise.control.metrics.reporter.kubernetes.mode=true
+inter.broker.listener.name=INTERNAL
+listener.security.protocol.map=INTERNAL:PLAINTEXT
+listeners=INTERNAL://:9092
+metric.reporters=com.linkedin.kafka.cruisecontrol.metricsreporter.CruiseControlMetricsReporter
+principal.builder.class=com.example.CustomPrincipalBuilder
+ssl.principal.mapping.rules=RULE:^CN=([^,]+).*$/$1/L,DEFAULT
+zookeeper.connect=example.zk:2181/`,
+		},
+		{
+			testName:                  "authorizationConfig",
+			readOnlyConfig:            `super.users=User:CN=readonly-admin`,
+			zkAddresses:               []string{"example.zk:2181"},
+			zkPath:                    ``,
+			kubernetesClusterDomain:   ``,
+			clusterWideConfig:         ``,
+			perBrokerConfig:           ``,
+			perBrokerReadOnlyConfig:   ``,
+			advertisedListenerAddress: `kafka-0.kafka.svc.cluster.local:9092`,
+			listenerType:              "plaintext",
+			authorizationConfig: &v1beta1.AuthorizationConfig{
+				AuthorizerClassName:       "kafka.security.authorizer.AclAuthorizer",
+				SuperUsers:                []string{"User:CN=admin", "User:ANONYMOUS"},
+				AllowEveryoneIfNoACLFound: util.BoolPointer(false),
+			},
+			expectedConfig: `advertised.listeners=INTERNAL://kafka-0.kafka.svc.cluster.local:9092
+allow.everyone.if.no.acl.found=false
+authorizer.class.name=kafka.security.authorizer.AclAuthorizer
+broker.id=0
+cruise.control.metrics.reporter.bootstrap.servers=kafka-all-broker.kafka.svc.cluster.local:9092
+cruise.control.metrics.reporter.kubernetes.mode=true
+inter.broker.listener.name=INTERNAL
+listener.security.protocol.map=INTERNAL:PLAINTEXT
+listeners=INTERNAL://:9092
+metric.reporters=com.linkedin.kafka.cruisecontrol.metricsreporter.CruiseControlMetricsReporter
+super.users=User:CN=admin;User:ANONYMOUS;User:CN=readonly-admin
 zookeeper.connect=example.zk:2181/`,
 		},
 		{
@@ -665,10 +765,13 @@ zookeeper.connect=example.zk:2181/`,
 										},
 									},
 								},
+								SSLSecrets: &v1beta1.SSLSecrets{PasswordPolicy: test.passwordPolicy},
 							},
 							ReadOnlyConfig:          test.readOnlyConfig,
 							KubernetesClusterDomain: test.kubernetesClusterDomain,
 							ClusterWideConfig:       test.clusterWideConfig,
+							PrincipalConfig:         test.principalConfig,
+							AuthorizationConfig:     test.authorizationConfig,
 							Brokers: []v1beta1.Broker{{
 								Id:             0,
 								ReadOnlyConfig: test.perBrokerReadOnlyConfig,
@@ -702,6 +805,9 @@ zookeeper.connect=example.zk:2181/`,
 				clientPass = "keystore_clientpassword123"
 				superUsers = []string{"CN=kafka-headless.kafka.svc.cluster.local"}
 			}
+			if test.passwordPolicy != nil {
+				clientPass, serverPasses = keystorePasswordReferences(clientPass, serverPasses)
+			}
 
 			generatedConfig := r.generateBrokerConfig(r.KafkaCluster.Spec.Brokers[0], r.KafkaCluster.Spec.Brokers[0].BrokerConfig, nil, map[string]v1beta1.ListenerStatusList{},
 				map[string]v1beta1.ListenerStatusList{}, controllerListenerStatus, serverPasses, clientPass, superUsers, logr.Discard())
@@ -732,6 +838,8 @@ func TestGenerateBrokerConfigKRaftMode(t *testing.T) { //nolint funlen
 		controllerListenerStatus map[string]v1beta1.ListenerStatusList
 		zkAddresses              []string
 		zkPath                   string
+		kRaftMigration           *v1beta1.KRaftMigrationConfig
+		kRaftMigrationStatus     *v1beta1.KRaftMigrationStatus
 		expectedBrokerConfigs    []string
 	}{
 		{
@@ -1197,6 +1305,136 @@ log.dirs=/test-kafka-logs/kafka
 metric.reporters=com.linkedin.kafka.cruisecontrol.metricsreporter.CruiseControlMetricsReporter
 node.id=300
 process.roles=broker
+`},
+		},
+		{
+			testName: "a Kafka cluster migrating from ZooKeeper with one of the brokers flipped to KRaft mode",
+			brokers: []v1beta1.Broker{
+				{
+					Id: 0,
+					BrokerConfig: &v1beta1.BrokerConfig{
+						Roles: []string{"broker"},
+						StorageConfigs: []v1beta1.StorageConfig{
+							{
+								MountPath: "/test-kafka-logs",
+							},
+						},
+					},
+				},
+				{
+					Id: 1,
+					BrokerConfig: &v1beta1.BrokerConfig{
+						Roles: []string{"broker"},
+						StorageConfigs: []v1beta1.StorageConfig{
+							{
+								MountPath: "/test-kafka-logs",
+							},
+						},
+					},
+					// the migration flags are ignored while the migration is driven by spec.kRaftMigration
+					ReadOnlyConfig: "migration.broker.controllerQuorumConfigEnabled=false\nmigration.broker.kRaftMode=true",
+				},
+				{
+					Id: 50,
+					BrokerConfig: &v1beta1.BrokerConfig{
+						Roles: []string{"controller"},
+						StorageConfigs: []v1beta1.StorageConfig{
+							{
+								MountPath: "/test-kafka-logs",
+							},
+						},
+					},
+				},
+			},
+			listenersConfig: v1beta1.ListenersConfig{
+				InternalListeners: []v1beta1.InternalListenerConfig{
+					{
+						CommonListenerSpec: v1beta1.CommonListenerSpec{
+							Type:                            v1beta1.SecurityProtocol("PLAINTEXT"),
+							Name:                            "internal",
+							ContainerPort:                   9092,
+							UsedForInnerBrokerCommunication: true,
+						},
+					},
+					{
+						CommonListenerSpec: v1beta1.CommonListenerSpec{
+							Type:          v1beta1.SecurityProtocol("PLAINTEXT"),
+							Name:          "controller",
+							ContainerPort: 9093,
+						},
+						UsedForControllerCommunication: true,
+					},
+				},
+			},
+			internalListenerStatuses: map[string]v1beta1.ListenerStatusList{
+				"internal": {
+					{
+						Name:    "broker-0",
+						Address: "kafka-0.kafka.svc.cluster.local:9092",
+					},
+					{
+						Name:    "broker-1",
+						Address: "kafka-1.kafka.svc.cluster.local:9092",
+					},
+					{
+						Name:    "broker-50",
+						Address: "kafka-50.kafka.svc.cluster.local:9092",
+					},
+				},
+			},
+			controllerListenerStatus: map[string]v1beta1.ListenerStatusList{
+				"controller": {
+					{
+						Name:    "broker-50",
+						Address: "kafka-50.kafka.svc.cluster.local:9093",
+					},
+				},
+			},
+			zkAddresses:    []string{"example.zk:2181"},
+			zkPath:         "/kafka",
+			kRaftMigration: &v1beta1.KRaftMigrationConfig{Enabled: true},
+			kRaftMigrationStatus: &v1beta1.KRaftMigrationStatus{
+				Phase:           v1beta1.KRaftMigrationPhaseBrokersMigrating,
+				MigratedBrokers: []int32{0},
+			},
+			expectedBrokerConfigs: []string{
+				`advertised.listeners=INTERNAL://kafka-0.kafka.svc.cluster.local:9092
+controller.listener.names=CONTROLLER
+controller.quorum.voters=50@kafka-50.kafka.svc.cluster.local:9093
+cruise.control.metrics.reporter.bootstrap.servers=kafka-all-broker.kafka.svc.cluster.local:9092
+cruise.control.metrics.reporter.kubernetes.mode=true
+inter.broker.listener.name=INTERNAL
+listener.security.protocol.map=INTERNAL:PLAINTEXT,CONTROLLER:PLAINTEXT
+listeners=INTERNAL://:9092
+log.dirs=/test-kafka-logs/kafka
+metric.reporters=com.linkedin.kafka.cruisecontrol.metricsreporter.CruiseControlMetricsReporter
+node.id=0
+process.roles=broker
+`,
+				`advertised.listeners=INTERNAL://kafka-1.kafka.svc.cluster.local:9092
+broker.id=1
+controller.listener.names=CONTROLLER
+controller.quorum.voters=50@kafka-50.kafka.svc.cluster.local:9093
+cruise.control.metrics.reporter.bootstrap.servers=kafka-all-broker.kafka.svc.cluster.local:9092
+cruise.control.metrics.reporter.kubernetes.mode=true
+inter.broker.listener.name=INTERNAL
+listener.security.protocol.map=INTERNAL:PLAINTEXT,CONTROLLER:PLAINTEXT
+listeners=INTERNAL://:9092
+log.dirs=/test-kafka-logs/kafka
+metric.reporters=com.linkedin.kafka.cruisecontrol.metricsreporter.CruiseControlMetricsReporter
+zookeeper.connect=example.zk:2181/kafka
+zookeeper.metadata.migration.enable=true
+`,
+				`controller.listener.names=CONTROLLER
+controller.quorum.voters=50@kafka-50.kafka.svc.cluster.local:9093
+inter.broker.listener.name=INTERNAL
+listener.security.protocol.map=INTERNAL:PLAINTEXT,CONTROLLER:PLAINTEXT
+listeners=CONTROLLER://:9093
+log.dirs=/test-kafka-logs/kafka
+node.id=50
+process.roles=controller
+zookeeper.connect=example.zk:2181/kafka
+zookeeper.metadata.migration.enable=true
 `},
 		},
 	}
@@ -1223,6 +1461,10 @@ process.roles=broker
 							Brokers:         test.brokers,
 							ZKAddresses:     test.zkAddresses,
 							ZKPath:          test.zkPath,
+							KRaftMigration:  test.kRaftMigration,
+						},
+						Status: v1beta1.KafkaClusterStatus{
+							KRaftMigration: test.kRaftMigrationStatus,
 						},
 					},
 				},
@@ -1556,3 +1798,154 @@ process.roles=controller
 		})
 	}
 }
+
+func TestMergeExternalBrokerConfig(t *testing.T) {
+	externalConfigMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "external-config", Namespace: "kafka"},
+		Data: map[string]string{
+			"broker.properties": "num.io.threads=16\nlog.retention.hours=24\nsuper.users=User:admin",
+		},
+	}
+	generatedConfig := "broker.id=0\nlog.retention.hours=168\nsuper.users=User:CN=kafka-operator"
+
+	tests := []struct {
+		testName       string
+		configMapRef   *v1.ConfigMapKeySelector
+		expectedConfig string
+		expectedHash   string
+		expectedErr    bool
+	}{
+		{
+			testName:       "no reference",
+			expectedConfig: generatedConfig,
+		},
+		{
+			testName: "generated configuration takes precedence",
+			configMapRef: &v1.ConfigMapKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: "external-config"},
+				Key:                  "broker.properties",
+			},
+			expectedConfig: `broker.id=0
+log.retention.hours=168
+num.io.threads=16
+super.users=User:CN=kafka-operator;User:admin
+`,
+			expectedHash: util.GetMD5Hash(externalConfigMap.Data["broker.properties"]),
+		},
+		{
+			testName: "missing configmap",
+			configMapRef: &v1.ConfigMapKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: "missing-config"},
+				Key:                  "broker.properties",
+			},
+			expectedErr: true,
+		},
+		{
+			testName: "missing optional configmap",
+			configMapRef: &v1.ConfigMapKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: "missing-config"},
+				Key:                  "broker.properties",
+				Optional:             util.BoolPointer(true),
+			},
+			expectedConfig: generatedConfig,
+		},
+		{
+			testName: "missing key",
+			configMapRef: &v1.ConfigMapKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: "external-config"},
+				Key:                  "missing.properties",
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			r := Reconciler{
+				Reconciler: resources.Reconciler{
+					Client: fake.NewClientBuilder().WithObjects(externalConfigMap.DeepCopy()).Build(),
+					KafkaCluster: &v1beta1.KafkaCluster{
+						ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+					},
+				},
+			}
+			brokerConf := &v1.ConfigMap{Data: map[string]string{kafkautils.ConfigPropertyName: generatedConfig}}
+
+			err := r.mergeExternalBrokerConfig(context.Background(), brokerConf, &v1beta1.BrokerConfig{ConfigMapRef: test.configMapRef}, logr.Discard())
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expectedConfig, brokerConf.Data[kafkautils.ConfigPropertyName])
+			require.Equal(t, test.expectedHash, brokerConf.Annotations[externalBrokerConfigHashAnnotation])
+		})
+	}
+}
+
+func TestConfigureInternalTopicsReplication(t *testing.T) {
+	tests := []struct {
+		testName       string
+		brokerCount    int
+		kRaftCombined  bool
+		internalTopics *v1beta1.InternalTopicsConfig
+		readOnlyConfig string
+		expectedConfig string
+	}{
+		{
+			testName:       "auto replication disabled",
+			brokerCount:    3,
+			expectedConfig: "",
+		},
+		{
+			testName:       "two brokers",
+			brokerCount:    2,
+			internalTopics: &v1beta1.InternalTopicsConfig{AutoReplication: true},
+			expectedConfig: `offsets.topic.replication.factor=2
+transaction.state.log.min.isr=1
+transaction.state.log.replication.factor=2
+`,
+		},
+		{
+			testName:       "readOnlyConfig is left to the user",
+			brokerCount:    4,
+			internalTopics: &v1beta1.InternalTopicsConfig{AutoReplication: true},
+			readOnlyConfig: "offsets.topic.replication.factor=4",
+			expectedConfig: `transaction.state.log.min.isr=2
+transaction.state.log.replication.factor=3
+`,
+		},
+		{
+			testName:      "single-node cluster without auto replication",
+			brokerCount:   1,
+			kRaftCombined: true,
+			expectedConfig: `offsets.topic.replication.factor=1
+transaction.state.log.min.isr=1
+transaction.state.log.replication.factor=1
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			spec := v1beta1.KafkaClusterSpec{
+				InternalTopicsConfig: test.internalTopics,
+				BrokerConfigGroups:   map[string]v1beta1.BrokerConfig{"default": {}},
+			}
+			if test.kRaftCombined {
+				spec.KRaftMode = true
+				spec.BrokerConfigGroups["default"] = v1beta1.BrokerConfig{Roles: []string{"broker", "controller"}}
+			}
+			for id := 0; id < test.brokerCount; id++ {
+				spec.Brokers = append(spec.Brokers, v1beta1.Broker{Id: int32(id), BrokerConfigGroup: "default"})
+			}
+			readOnlyConfig, err := properties.NewFromString(test.readOnlyConfig)
+			require.NoError(t, err)
+
+			config := properties.NewProperties()
+			configureInternalTopicsReplication(spec, config, readOnlyConfig, logr.Discard())
+			config.Sort()
+			require.Equal(t, test.expectedConfig, config.String())
+		})
+	}
+}
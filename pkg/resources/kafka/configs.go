@@ -16,6 +16,7 @@
 package kafka
 
 import (
+	"slices"
 	"strconv"
 
 	"emperror.dev/errors"
@@ -45,8 +46,9 @@ func (r *Reconciler) reconcilePerBrokerDynamicConfig(brokerId int32, brokerConfi
 		return errors.WrapIf(err, "could not parse broker configuration")
 	}
 
-	currentPerBrokerConfigState := r.KafkaCluster.Status.BrokersState[strconv.Itoa(int(brokerId))].PerBrokerConfigurationState
-	if fullPerBrokerConfig.Len() == 0 && currentPerBrokerConfigState != v1beta1.PerBrokerConfigOutOfSync {
+	brokerState := r.KafkaCluster.Status.BrokersState[strconv.Itoa(int(brokerId))]
+	currentPerBrokerConfigState := brokerState.PerBrokerConfigurationState
+	if fullPerBrokerConfig.Len() == 0 && currentPerBrokerConfigState != v1beta1.PerBrokerConfigOutOfSync && len(brokerState.DynamicConfigKeys) == 0 {
 		return nil
 	}
 
@@ -60,6 +62,16 @@ func (r *Reconciler) reconcilePerBrokerDynamicConfig(brokerId int32, brokerConfi
 			fullPerBrokerConfig.Put(configProperty)
 		}
 	}
+	// the dynamic broker configs of the broker configuration are applied to the running broker as well
+	var dynamicConfigKeys v1beta1.DynamicConfigKeys
+	for _, dynamicConfig := range kafka.DynamicBrokerConfigs {
+		if configProperty, ok := configsFromConfigMap.Get(dynamicConfig); ok {
+			if _, found := fullPerBrokerConfig.Get(dynamicConfig); !found {
+				fullPerBrokerConfig.Put(configProperty)
+			}
+			dynamicConfigKeys = append(dynamicConfigKeys, dynamicConfig)
+		}
+	}
 
 	// query the current config
 	brokerConfigKeys := fullPerBrokerConfig.Keys()
@@ -68,7 +80,9 @@ func (r *Reconciler) reconcilePerBrokerDynamicConfig(brokerId int32, brokerConfi
 		return errors.WrapIfWithDetails(err, "could not describe broker config", v1beta1.BrokerIdLabelKey, brokerId)
 	}
 
-	if shouldUpdatePerBrokerConfig(response, fullPerBrokerConfig) {
+	// the broker configuration is altered as a whole, dynamic broker configs no longer in the broker configuration
+	// are removed from the running broker this way
+	if shouldUpdatePerBrokerConfig(response, fullPerBrokerConfig) || hasStaleDynamicConfigKeys(brokerState.DynamicConfigKeys, dynamicConfigKeys) {
 		if currentPerBrokerConfigState == v1beta1.PerBrokerConfigInSync {
 			log.V(1).Info("setting per broker config status to out of sync")
 			statusErr := k8sutil.UpdateBrokerStatus(r.Client, []string{strconv.Itoa(int(brokerId))}, r.KafkaCluster, v1beta1.PerBrokerConfigOutOfSync, log)
@@ -104,6 +118,13 @@ func (r *Reconciler) reconcilePerBrokerDynamicConfig(brokerId int32, brokerConfi
 			return errorfactory.New(errorfactory.PerBrokerConfigNotReady{}, errors.New("configuration is out of sync"), "per-broker configuration updated")
 		}
 
+		if !slices.Equal(brokerState.DynamicConfigKeys, dynamicConfigKeys) {
+			log.Info("dynamic broker configs applied to the running broker", v1beta1.BrokerIdLabelKey, brokerId, "keys", dynamicConfigKeys)
+			if err := k8sutil.UpdateBrokerStatus(r.Client, []string{strconv.Itoa(int(brokerId))}, r.KafkaCluster, dynamicConfigKeys, log); err != nil {
+				return errors.WrapIfWithDetails(err, "updating status for dynamic broker configuration keys failed", v1beta1.BrokerIdLabelKey, brokerId)
+			}
+		}
+
 		statusErr := k8sutil.UpdateBrokerStatus(r.Client, []string{strconv.Itoa(int(brokerId))}, r.KafkaCluster, v1beta1.PerBrokerConfigInSync, log)
 		if statusErr != nil {
 			return errors.WrapIfWithDetails(err, "updating status for per-broker configuration status failed", v1beta1.BrokerIdLabelKey, brokerId)
@@ -156,6 +177,17 @@ func (r *Reconciler) reconcileClusterWideDynamicConfig() error {
 	return nil
 }
 
+// hasStaleDynamicConfigKeys returns true if a dynamic broker config applied to the running broker is no longer in the
+// broker configuration
+func hasStaleDynamicConfigKeys(applied, desired v1beta1.DynamicConfigKeys) bool {
+	for _, key := range applied {
+		if !slices.Contains(desired, key) {
+			return true
+		}
+	}
+	return false
+}
+
 func shouldUpdatePerBrokerConfig(response []*sarama.ConfigEntry, brokerConfig *properties.Properties) bool {
 	if brokerConfig == nil {
 		return false
@@ -19,7 +19,19 @@ import (
 	"testing"
 
 	"github.com/IBM/sarama"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/kafkaclient"
+	"github.com/banzaicloud/koperator/pkg/resources/kafka/mocks"
+	"github.com/banzaicloud/koperator/pkg/util/kafka"
 	properties "github.com/banzaicloud/koperator/properties/pkg"
 )
 
@@ -96,3 +108,73 @@ config2=value3
 		}
 	}
 }
+
+func TestReconcilePerBrokerDynamicConfig(t *testing.T) {
+	cleanerThreads := "2"
+	testCases := []struct {
+		testName          string
+		brokerState       v1beta1.BrokerState
+		config            string
+		current           []*sarama.ConfigEntry
+		expectedAlter     map[string]*string
+		updated           []*sarama.ConfigEntry
+		expectedKeys      v1beta1.DynamicConfigKeys
+		expectedNoConnect bool
+	}{
+		{
+			testName:          "broker configuration without dynamic broker configs in sync",
+			brokerState:       v1beta1.BrokerState{PerBrokerConfigurationState: v1beta1.PerBrokerConfigInSync},
+			config:            "log.dirs=/kafka-logs/kafka",
+			expectedNoConnect: true,
+		},
+		{
+			testName:      "changed dynamic broker config is applied to the running broker",
+			brokerState:   v1beta1.BrokerState{PerBrokerConfigurationState: v1beta1.PerBrokerConfigOutOfSync},
+			config:        "log.dirs=/kafka-logs/kafka\nlog.cleaner.threads=2",
+			current:       []*sarama.ConfigEntry{{Name: "log.cleaner.threads", Value: "1"}},
+			expectedAlter: map[string]*string{"log.cleaner.threads": &cleanerThreads},
+			updated:       []*sarama.ConfigEntry{{Name: "log.cleaner.threads", Value: "2"}},
+			expectedKeys:  v1beta1.DynamicConfigKeys{"log.cleaner.threads"},
+		},
+		{
+			testName: "dynamic broker config removed from the broker configuration is removed from the running broker",
+			brokerState: v1beta1.BrokerState{
+				PerBrokerConfigurationState: v1beta1.PerBrokerConfigInSync,
+				DynamicConfigKeys:           v1beta1.DynamicConfigKeys{"log.cleaner.threads"},
+			},
+			config:        "log.dirs=/kafka-logs/kafka",
+			expectedAlter: map[string]*string{},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Status: v1beta1.KafkaClusterStatus{
+					BrokersState: map[string]v1beta1.BrokerState{"0": test.brokerState},
+				},
+			}
+			scheme := runtime.NewScheme()
+			require.NoError(t, v1beta1.AddToScheme(scheme))
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster).WithStatusSubresource(cluster).Build()
+
+			kafkaClientProvider := new(kafkaclient.MockedProvider)
+			kafkaClient := mocks.NewMockKafkaClient(gomock.NewController(t))
+			kafkaClientProvider.On("NewFromCluster", mock.Anything, mock.Anything).Return(kafkaClient, func() {}, nil)
+			if !test.expectedNoConnect {
+				gomock.InOrder(
+					kafkaClient.EXPECT().DescribePerBrokerConfig(int32(0), gomock.Any()).Return(test.current, nil),
+					kafkaClient.EXPECT().AlterPerBrokerConfig(int32(0), test.expectedAlter, true).Return(nil),
+					kafkaClient.EXPECT().AlterPerBrokerConfig(int32(0), test.expectedAlter, false).Return(nil),
+					kafkaClient.EXPECT().DescribePerBrokerConfig(int32(0), gomock.Any()).Return(test.updated, nil),
+				)
+			}
+
+			r := New(c, nil, cluster, kafkaClientProvider, nil)
+			configMap := &corev1.ConfigMap{Data: map[string]string{kafka.ConfigPropertyName: test.config}}
+			require.NoError(t, r.reconcilePerBrokerDynamicConfig(0, &v1beta1.BrokerConfig{}, configMap, logr.Discard()))
+			require.Equal(t, test.expectedKeys, cluster.Status.BrokersState["0"].DynamicConfigKeys)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	"github.com/banzaicloud/koperator/pkg/util"
+	kafkautils "github.com/banzaicloud/koperator/pkg/util/kafka"
+)
+
+const brokerConfigSecretVolumeNameTemplate = "config-secret-%s"
+
+// brokerConfigSecretNames returns the names of the secrets referenced by the read-only configuration of the broker
+// and by its tiered storage configuration
+func (r *Reconciler) brokerConfigSecretNames(id int32, log logr.Logger) []string {
+	for _, broker := range r.KafkaCluster.Spec.Brokers {
+		if broker.Id != id {
+			continue
+		}
+		config := getBrokerReadOnlyConfig(broker, r.KafkaCluster, log)
+		if brokerConfig, err := broker.GetBrokerConfig(r.KafkaCluster.Spec); err == nil && (brokerConfig == nil || !brokerConfig.IsControllerOnlyNode()) {
+			configureTieredStorage(r.KafkaCluster.Spec.TieredStorage, r.KafkaCluster.Spec.ListenersConfig, config, log)
+		}
+		return kafkautils.BrokerConfigSecretNames(config)
+	}
+	return nil
+}
+
+// checkBrokerConfigSecrets returns ResourceNotReady error if a secret referenced by the read-only configuration of a
+// broker does not exist, as the broker pod could not start without it
+func (r *Reconciler) checkBrokerConfigSecrets(ctx context.Context, log logr.Logger) error {
+	checked := make(map[string]struct{})
+	for _, broker := range r.KafkaCluster.Spec.Brokers {
+		for _, name := range r.brokerConfigSecretNames(broker.Id, log) {
+			if _, ok := checked[name]; ok {
+				continue
+			}
+			checked[name] = struct{}{}
+			err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: r.KafkaCluster.Namespace}, &corev1.Secret{})
+			if apierrors.IsNotFound(err) {
+				return errorfactory.New(errorfactory.ResourceNotReady{}, err,
+					"secret referenced by the broker configuration not found", "secret", name)
+			}
+			if err != nil {
+				return errors.WrapIfWithDetails(err, "failed to get secret referenced by the broker configuration", "secret", name)
+			}
+		}
+	}
+	return nil
+}
+
+// generateVolumesForBrokerConfigSecrets returns the volumes and volume mounts of the secrets referenced by the broker
+// configuration, the config provider reads the values from the files of the mounted secrets
+func generateVolumesForBrokerConfigSecrets(secretNames []string) ([]corev1.Volume, []corev1.VolumeMount) {
+	volumes := make([]corev1.Volume, 0, len(secretNames))
+	volumeMounts := make([]corev1.VolumeMount, 0, len(secretNames))
+	for _, name := range secretNames {
+		// the secret names may not be valid volume names
+		volumeName := fmt.Sprintf(brokerConfigSecretVolumeNameTemplate, util.GetMD5Hash(name)[:10])
+		volumes = append(volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  name,
+					DefaultMode: util.Int32Pointer(0644),
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: kafkautils.BrokerConfigSecretPath(name),
+			ReadOnly:  true,
+		})
+	}
+	return volumes, volumeMounts
+}
@@ -0,0 +1,83 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	"github.com/banzaicloud/koperator/pkg/resources"
+)
+
+func TestCheckBrokerConfigSecrets(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		readOnlyConfig string
+		expectedErr    bool
+	}{
+		{
+			testName:       "referenced secrets exist",
+			readOnlyConfig: "ssl.key.password=${secret:cluster-creds:key-pass}",
+		},
+		{
+			testName:       "referenced secret is missing",
+			readOnlyConfig: "ssl.key.password=${secret:missing-creds:key-pass}",
+			expectedErr:    true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Spec: v1beta1.KafkaClusterSpec{
+					ReadOnlyConfig: test.readOnlyConfig,
+					Brokers: []v1beta1.Broker{
+						{Id: 0},
+						{Id: 1, ReadOnlyConfig: "sasl.jaas.config=${secret:broker-creds:jaas}"},
+					},
+				},
+			}
+			c := fake.NewClientBuilder().WithObjects(
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "cluster-creds", Namespace: "kafka"}},
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "broker-creds", Namespace: "kafka"}},
+			).Build()
+			r := Reconciler{Reconciler: resources.Reconciler{Client: c, KafkaCluster: cluster}}
+
+			err := r.checkBrokerConfigSecrets(context.Background(), logr.Discard())
+			if test.expectedErr {
+				require.ErrorAs(t, err, &errorfactory.ResourceNotReady{})
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, []string{"cluster-creds"}, r.brokerConfigSecretNames(0, logr.Discard()))
+			volumes, volumeMounts := generateVolumesForBrokerConfigSecrets(r.brokerConfigSecretNames(1, logr.Discard()))
+			require.Len(t, volumes, 2)
+			require.Equal(t, "broker-creds", volumes[0].Secret.SecretName)
+			require.Equal(t, "/var/run/secrets/kafka/config/broker-creds", volumeMounts[0].MountPath)
+			require.Equal(t, volumes[1].Name, volumeMounts[1].Name)
+		})
+	}
+}
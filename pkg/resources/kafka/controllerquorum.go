@@ -0,0 +1,179 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	kafkautils "github.com/banzaicloud/koperator/pkg/util/kafka"
+	properties "github.com/banzaicloud/koperator/properties/pkg"
+)
+
+// The controller quorum is configured with a static voter set (controller.quorum.voters). Kafka does not support
+// changing a static voter set by rolling the nodes, the webhook rejects the changes of the controller nodes. The Kafka
+// client used by the operator does not support the DescribeQuorum, AddRaftVoter and RemoveRaftVoter APIs, the health
+// of the quorum is derived from the pods of the voters.
+
+// reconcileControllerQuorumStatus records the health of the KRaft controller quorum in the KafkaCluster status
+func (r *Reconciler) reconcileControllerQuorumStatus(ctx context.Context, log logr.Logger) error {
+	if !r.KafkaCluster.Spec.KRaftMode {
+		return nil
+	}
+
+	podList := &corev1.PodList{}
+	err := r.List(ctx, podList,
+		client.InNamespace(r.KafkaCluster.Namespace),
+		client.MatchingLabels(apiutil.LabelsForKafka(r.KafkaCluster.Name)),
+	)
+	if err != nil {
+		return errors.WrapIf(err, "failed to list broker pods that belong to Kafka cluster")
+	}
+
+	status, err := controllerQuorumStatus(r.KafkaCluster.Spec, podList.Items)
+	if err != nil {
+		return err
+	}
+	current := r.KafkaCluster.Status.ControllerQuorum
+	if current != nil && current.State == status.State {
+		if slices.Equal(current.Voters, status.Voters) && slices.Equal(current.ReadyVoters, status.ReadyVoters) {
+			return nil
+		}
+		status.LastTransitionTime = current.LastTransitionTime
+	} else {
+		status.LastTransitionTime = metav1.Now()
+	}
+
+	if status.State != v1beta1.ControllerQuorumHealthy {
+		log.Info("KRaft controller quorum is not healthy", "state", status.State, "voters", status.Voters, "readyVoters", status.ReadyVoters)
+	}
+	if err = k8sutil.UpdateCRStatus(r.Client, r.KafkaCluster, status, log); err != nil {
+		return errorfactory.New(errorfactory.StatusUpdateError{}, err, "could not update controller quorum status")
+	}
+	return nil
+}
+
+// controllerQuorumStatus returns the health of the controller quorum formed by the controller nodes of the spec
+func controllerQuorumStatus(kafkaClusterSpec v1beta1.KafkaClusterSpec, pods []corev1.Pod) (*v1beta1.ControllerQuorumStatus, error) {
+	voters, err := kafkaClusterSpec.GetControllerNodeIDs()
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not get the controller nodes")
+	}
+	slices.Sort(voters)
+
+	readyPods := make(map[string]struct{}, len(pods))
+	for _, pod := range pods {
+		if !k8sutil.IsMarkedForDeletion(pod.ObjectMeta) && isPodReady(&pod) {
+			readyPods[pod.Labels[v1beta1.BrokerIdLabelKey]] = struct{}{}
+		}
+	}
+
+	status := &v1beta1.ControllerQuorumStatus{Voters: voters}
+	for _, id := range voters {
+		if _, ok := readyPods[strconv.Itoa(int(id))]; ok {
+			status.ReadyVoters = append(status.ReadyVoters, id)
+		}
+	}
+
+	switch {
+	case len(status.ReadyVoters) == len(voters):
+		status.State = v1beta1.ControllerQuorumHealthy
+	case len(status.ReadyVoters) >= v1beta1.QuorumMajority(len(voters)):
+		status.State = v1beta1.ControllerQuorumDegraded
+	default:
+		status.State = v1beta1.ControllerQuorumUnavailable
+	}
+	return status, nil
+}
+
+// isControllerQuorumReadyForRemoval returns true if the controllers of the spec are ready and run with a voter set
+// which no longer holds the removed controllers, it guards the clusters whose controllers were removed without the
+// webhook. The remaining controllers are rolled while the removed ones still
+// vote, the removed ones are stopped only afterwards so the quorum keeps its majority during the change.
+func (r *Reconciler) isControllerQuorumReadyForRemoval(ctx context.Context, pods []corev1.Pod, removedIDs []string) (bool, error) {
+	status, err := controllerQuorumStatus(r.KafkaCluster.Spec, pods)
+	if err != nil {
+		return false, err
+	}
+	if status.State != v1beta1.ControllerQuorumHealthy {
+		return false, nil
+	}
+
+	for _, id := range status.Voters {
+		brokerID := strconv.Itoa(int(id))
+		if r.KafkaCluster.Status.BrokersState[brokerID].ConfigurationState != v1beta1.ConfigInSync {
+			return false, nil
+		}
+
+		configMap := &corev1.ConfigMap{}
+		err = r.Get(ctx, types.NamespacedName{
+			Name:      fmt.Sprintf(brokerConfigTemplate+"-%s", r.KafkaCluster.Name, brokerID),
+			Namespace: r.KafkaCluster.Namespace,
+		}, configMap)
+		if err != nil {
+			return false, errors.WrapIfWithDetails(err, "could not get the configuration of the controller", v1beta1.BrokerIdLabelKey, brokerID)
+		}
+		configuredVoters, err := configuredQuorumVoterIDs(configMap.Data[kafkautils.ConfigPropertyName])
+		if err != nil {
+			return false, errors.WrapIfWithDetails(err, "could not parse the configuration of the controller", v1beta1.BrokerIdLabelKey, brokerID)
+		}
+		for _, removedID := range removedIDs {
+			if slices.Contains(configuredVoters, removedID) {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// configuredQuorumVoterIDs returns the IDs of the voters in the controller.quorum.voters property of the given broker config
+func configuredQuorumVoterIDs(brokerConfig string) ([]string, error) {
+	config, err := properties.NewFromString(brokerConfig)
+	if err != nil {
+		return nil, err
+	}
+	voters, found := config.Get(kafkautils.KafkaConfigControllerQuorumVoters)
+	if !found {
+		return nil, nil
+	}
+	var ids []string
+	for _, voter := range strings.Split(voters.Value(), ",") {
+		if id, _, ok := strings.Cut(strings.TrimSpace(voter), "@"); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// isControllerPod returns true if the given Kafka pod runs with the controller process role
+func isControllerPod(pod *corev1.Pod) bool {
+	roles, found := pod.Labels[v1beta1.ProcessRolesKey]
+	return found && slices.Contains(strings.Split(roles, "_"), v1beta1.ControllerNodeProcessRole)
+}
@@ -0,0 +1,113 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func TestControllerQuorumStatus(t *testing.T) {
+	spec := v1beta1.KafkaClusterSpec{
+		BrokerConfigGroups: map[string]v1beta1.BrokerConfig{
+			"broker":     {Roles: []string{"broker"}},
+			"controller": {Roles: []string{"controller"}},
+		},
+		Brokers: []v1beta1.Broker{
+			{Id: 0, BrokerConfigGroup: "broker"},
+			{Id: 12, BrokerConfigGroup: "controller"},
+			{Id: 10, BrokerConfigGroup: "controller"},
+			{Id: 11, BrokerConfigGroup: "controller"},
+		},
+	}
+	pod := func(id string, ready bool) corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{v1beta1.BrokerIdLabelKey: id}},
+			Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}},
+		}
+	}
+
+	testCases := []struct {
+		testName            string
+		pods                []corev1.Pod
+		expectedState       v1beta1.ControllerQuorumState
+		expectedReadyVoters []int32
+	}{
+		{
+			testName:            "every voter ready",
+			pods:                []corev1.Pod{pod("0", false), pod("10", true), pod("11", true), pod("12", true)},
+			expectedState:       v1beta1.ControllerQuorumHealthy,
+			expectedReadyVoters: []int32{10, 11, 12},
+		},
+		{
+			testName:            "majority of the voters ready",
+			pods:                []corev1.Pod{pod("10", true), pod("11", false), pod("12", true)},
+			expectedState:       v1beta1.ControllerQuorumDegraded,
+			expectedReadyVoters: []int32{10, 12},
+		},
+		{
+			testName:            "minority of the voters ready",
+			pods:                []corev1.Pod{pod("0", true), pod("10", true)},
+			expectedState:       v1beta1.ControllerQuorumUnavailable,
+			expectedReadyVoters: []int32{10},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			status, err := controllerQuorumStatus(spec, test.pods)
+			require.NoError(t, err)
+			require.Equal(t, []int32{10, 11, 12}, status.Voters)
+			require.Equal(t, test.expectedState, status.State)
+			require.Equal(t, test.expectedReadyVoters, status.ReadyVoters)
+		})
+	}
+}
+
+func TestConfiguredQuorumVoterIDs(t *testing.T) {
+	testCases := []struct {
+		testName     string
+		brokerConfig string
+		expectedIDs  []string
+	}{
+		{
+			testName:     "quorum voters configured",
+			brokerConfig: "process.roles=controller\ncontroller.quorum.voters=10@kafka-10.kafka:29093,11@kafka-11.kafka:29093\n",
+			expectedIDs:  []string{"10", "11"},
+		},
+		{
+			testName:     "quorum voters not configured",
+			brokerConfig: "broker.id=0\n",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			ids, err := configuredQuorumVoterIDs(test.brokerConfig)
+			require.NoError(t, err)
+			require.Equal(t, test.expectedIDs, ids)
+		})
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/util"
+)
+
+// The access method of an external listener (its ingress controller and the type of its service) is changed in phases
+// so that clients can reach the brokers during the change:
+//   - Provisioning: the ingress reconcilers create the resources of the new access method while the brokers still
+//     advertise the addresses of the previous one whose resources are kept
+//   - Advertising: the new access method is reachable, the brokers are reconfigured to advertise its addresses
+//   - CleaningUp: every broker advertises the new addresses, the ingress reconcilers remove the resources of the
+//     previous access method
+
+// externalListenerDialTimeout is the timeout of the connection made to check the reachability of a new access method
+const externalListenerDialTimeout = 3 * time.Second
+
+// dialExternalListener checks that a TCP connection can be opened to the given address
+var dialExternalListener = func(address string) error {
+	conn, err := net.DialTimeout("tcp", address, externalListenerDialTimeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// reconcileExternalListenersAccess records the access method of the external listeners and moves the change of the
+// access method of a listener forward once the new access method is reachable
+func (r *Reconciler) reconcileExternalListenersAccess(log logr.Logger) error {
+	current := r.KafkaCluster.Status.ExternalListenersAccess
+	statuses := make(map[string]v1beta1.ExternalListenerAccessStatus, len(r.KafkaCluster.Spec.ListenersConfig.ExternalListeners))
+	for _, eListener := range r.KafkaCluster.Spec.ListenersConfig.ExternalListeners {
+		desired := r.KafkaCluster.Spec.GetAccess(eListener)
+		status, found := current[eListener.Name]
+		switch {
+		case !found:
+			// the access method of a new listener, or of a listener created before the access method was recorded,
+			// is adopted as it is
+			status = v1beta1.ExternalListenerAccessStatus{ExternalListenerAccess: desired}
+		case status.ExternalListenerAccess != desired:
+			status = externalListenerAccessChanged(status, desired)
+			if status.Transition != nil {
+				log.Info("external listener access method changed, provisioning the new access method",
+					"externalListenerName", eListener.Name, "previous", status.Transition.Previous, "new", desired)
+			}
+		case status.Transition.GetPhase() == v1beta1.ExternalListenerAccessProvisioning:
+			reachable, err := r.isExternalListenerAccessReachable(log, eListener)
+			if err != nil {
+				return err
+			}
+			if reachable {
+				log.Info("new access method of the external listener is reachable, advertising its addresses",
+					"externalListenerName", eListener.Name, "accessMethod", desired)
+				status = withExternalListenerAccessPhase(status, v1beta1.ExternalListenerAccessAdvertising)
+			}
+		case status.Transition.GetPhase() == v1beta1.ExternalListenerAccessCleaningUp:
+			// the ingress reconcilers run before this one have removed the resources of the previous access method
+			log.Info("access method of the external listener changed", "externalListenerName", eListener.Name,
+				"previous", status.Transition.Previous, "new", desired)
+			status.Transition = nil
+		}
+		statuses[eListener.Name] = status
+	}
+
+	if reflect.DeepEqual(statuses, current) || (len(statuses) == 0 && len(current) == 0) {
+		return nil
+	}
+	if err := k8sutil.UpdateCRStatus(r.Client, r.KafkaCluster, statuses, log); err != nil {
+		return errorfactory.New(errorfactory.StatusUpdateError{}, err, "could not update external listeners access status")
+	}
+	return nil
+}
+
+// externalListenerAccessChanged returns the status of an external listener whose access method is changed to the
+// desired one. The change starts from the access method advertised by the brokers, it is cancelled if the brokers
+// still advertise the desired one.
+func externalListenerAccessChanged(status v1beta1.ExternalListenerAccessStatus, desired v1beta1.ExternalListenerAccess) v1beta1.ExternalListenerAccessStatus {
+	advertised := status.ExternalListenerAccess
+	if status.Transition.GetPhase() == v1beta1.ExternalListenerAccessProvisioning {
+		advertised = status.Transition.Previous
+	}
+	if advertised == desired {
+		return v1beta1.ExternalListenerAccessStatus{ExternalListenerAccess: desired}
+	}
+	return v1beta1.ExternalListenerAccessStatus{
+		ExternalListenerAccess: desired,
+		Transition: &v1beta1.ExternalListenerAccessTransition{
+			Phase:              v1beta1.ExternalListenerAccessProvisioning,
+			Previous:           advertised,
+			LastTransitionTime: metav1.Now(),
+		},
+	}
+}
+
+// withExternalListenerAccessPhase returns a copy of the status with its transition moved to the given phase
+func withExternalListenerAccessPhase(status v1beta1.ExternalListenerAccessStatus, phase v1beta1.ExternalListenerAccessTransitionPhase) v1beta1.ExternalListenerAccessStatus {
+	transition := *status.Transition
+	transition.Phase = phase
+	transition.LastTransitionTime = metav1.Now()
+	status.Transition = &transition
+	return status
+}
+
+// finishExternalListenersAccessAdvertising moves the access method changes whose addresses are advertised by every
+// broker to the CleaningUp phase. It returns ResourceNotReady while a new access method is not reachable yet to
+// check it again.
+func (r *Reconciler) finishExternalListenersAccessAdvertising(log logr.Logger) error {
+	statuses := make(map[string]v1beta1.ExternalListenerAccessStatus, len(r.KafkaCluster.Status.ExternalListenersAccess))
+	provisioning := false
+	changed := false
+	for name, status := range r.KafkaCluster.Status.ExternalListenersAccess {
+		switch status.Transition.GetPhase() {
+		case v1beta1.ExternalListenerAccessProvisioning:
+			provisioning = true
+		case v1beta1.ExternalListenerAccessAdvertising:
+			if r.areBrokerConfigsInSync() {
+				log.Info("brokers advertise the new access method of the external listener, removing the previous one",
+					"externalListenerName", name, "previous", status.Transition.Previous)
+				status = withExternalListenerAccessPhase(status, v1beta1.ExternalListenerAccessCleaningUp)
+				changed = true
+			}
+		}
+		statuses[name] = status
+	}
+
+	if changed {
+		if err := k8sutil.UpdateCRStatus(r.Client, r.KafkaCluster, statuses, log); err != nil {
+			return errorfactory.New(errorfactory.StatusUpdateError{}, err, "could not update external listeners access status")
+		}
+	}
+	if provisioning {
+		return errorfactory.New(errorfactory.ResourceNotReady{}, errors.New("new access method of an external listener is not reachable yet"), "waiting")
+	}
+	return nil
+}
+
+// areBrokerConfigsInSync returns true if every broker of the spec runs with its generated configuration
+func (r *Reconciler) areBrokerConfigsInSync() bool {
+	if r.KafkaCluster.Status.State == v1beta1.KafkaClusterRollingUpgrading {
+		return false
+	}
+	for _, broker := range r.KafkaCluster.Spec.Brokers {
+		state, ok := r.KafkaCluster.Status.BrokersState[strconv.Itoa(int(broker.Id))]
+		if !ok || state.ConfigurationState != v1beta1.ConfigInSync ||
+			(state.PerBrokerConfigurationState != "" && state.PerBrokerConfigurationState != v1beta1.PerBrokerConfigInSync) {
+			return false
+		}
+	}
+	return true
+}
+
+// isExternalListenerAccessReachable returns true if the resources of the access method set in the spec for the
+// external listener serve traffic
+func (r *Reconciler) isExternalListenerAccessReachable(log logr.Logger, eListener v1beta1.ExternalListenerConfig) (bool, error) {
+	if eListener.GetAccessMethod() == corev1.ServiceTypeNodePort {
+		for _, broker := range r.KafkaCluster.Spec.Brokers {
+			if _, err := r.getK8sAssignedNodeport(log, eListener.Name, broker.Id); err != nil {
+				log.Info("nodeport service of the external listener is not ready yet", "externalListenerName", eListener.Name,
+					v1beta1.BrokerIdLabelKey, broker.Id, "reason", err.Error())
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+	if eListener.GetAccessMethod() == v1beta1.ServiceTypePerBrokerLoadBalancer {
+		for _, broker := range r.KafkaCluster.Spec.Brokers {
+			service, err := r.getPerBrokerLoadBalancerService(eListener.Name, broker.Id)
+			if err != nil {
+				log.Info("load balancer service of the broker is not created yet", "externalListenerName", eListener.Name,
+					v1beta1.BrokerIdLabelKey, broker.Id, "reason", err.Error())
+				return false, nil
+			}
+			address, err := getLoadBalancerIP(service)
+			if err != nil {
+				log.Info("load balancer of the broker has no address yet", "externalListenerName", eListener.Name,
+					v1beta1.BrokerIdLabelKey, broker.Id)
+				return false, nil
+			}
+			if err = dialExternalListener(net.JoinHostPort(address, fmt.Sprint(eListener.GetBrokerPort(broker.Id)))); err != nil {
+				log.Info("load balancer of the broker is not reachable yet", "externalListenerName", eListener.Name,
+					v1beta1.BrokerIdLabelKey, broker.Id, "reason", err.Error())
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+
+	ingressConfigs, defaultControllerName, err := util.GetIngressConfigs(r.KafkaCluster.Spec, eListener)
+	if err != nil {
+		return false, err
+	}
+	for iConfigName := range ingressConfigs {
+		if !util.IsIngressConfigInUse(iConfigName, defaultControllerName, r.KafkaCluster, log) {
+			continue
+		}
+		service, err := getServiceFromExternalListener(r.Client, r.KafkaCluster, eListener, iConfigName)
+		if err != nil {
+			log.Info("ingress service of the external listener is not created yet", "externalListenerName", eListener.Name,
+				"ingressConfig", iConfigName, "reason", err.Error())
+			return false, nil
+		}
+		if eListener.GetAccessMethod() != corev1.ServiceTypeLoadBalancer {
+			continue
+		}
+		address, err := getLoadBalancerIP(service)
+		if err != nil {
+			log.Info("load balancer of the external listener has no address yet", "externalListenerName", eListener.Name,
+				"ingressConfig", iConfigName)
+			return false, nil
+		}
+		port := allBrokerServicePort(service)
+		if port == 0 {
+			return false, errors.NewWithDetails("could not find port with name tcp-all-broker", "externalListenerName", eListener.Name)
+		}
+		if err = dialExternalListener(net.JoinHostPort(address, fmt.Sprint(port))); err != nil {
+			log.Info("load balancer of the external listener is not reachable yet", "externalListenerName", eListener.Name,
+				"ingressConfig", iConfigName, "reason", err.Error())
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// allBrokerServicePort returns the port of the ingress service which reaches any of the brokers
+func allBrokerServicePort(service *corev1.Service) int32 {
+	for _, port := range service.Spec.Ports {
+		if port.Name == "tcp-all-broker" {
+			return port.Port
+		}
+	}
+	return 0
+}
+
+// advertisedExternalListener returns the external listener with the access method whose addresses the brokers
+// advertise
+func advertisedExternalListener(cluster *v1beta1.KafkaCluster, eListener v1beta1.ExternalListenerConfig) v1beta1.ExternalListenerConfig {
+	access := cluster.Status.GetAdvertisedAccess(&cluster.Spec, eListener)
+	eListener.IngressController = access.IngressController
+	eListener.AccessMethod = access.AccessMethod
+	return eListener
+}
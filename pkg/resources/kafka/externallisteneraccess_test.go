@@ -0,0 +1,183 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func TestExternalListenerAccessChanged(t *testing.T) {
+	envoy := v1beta1.ExternalListenerAccess{IngressController: "envoy", AccessMethod: corev1.ServiceTypeLoadBalancer}
+	istio := v1beta1.ExternalListenerAccess{IngressController: "istioingress", AccessMethod: corev1.ServiceTypeLoadBalancer}
+	nodePort := v1beta1.ExternalListenerAccess{IngressController: "envoy", AccessMethod: corev1.ServiceTypeNodePort}
+
+	testCases := []struct {
+		testName         string
+		status           v1beta1.ExternalListenerAccessStatus
+		desired          v1beta1.ExternalListenerAccess
+		expectedPrevious *v1beta1.ExternalListenerAccess
+	}{
+		{
+			testName:         "access method changed",
+			status:           v1beta1.ExternalListenerAccessStatus{ExternalListenerAccess: envoy},
+			desired:          istio,
+			expectedPrevious: &envoy,
+		},
+		{
+			testName: "changed again while provisioning",
+			status: v1beta1.ExternalListenerAccessStatus{
+				ExternalListenerAccess: istio,
+				Transition:             &v1beta1.ExternalListenerAccessTransition{Phase: v1beta1.ExternalListenerAccessProvisioning, Previous: envoy},
+			},
+			desired:          nodePort,
+			expectedPrevious: &envoy,
+		},
+		{
+			testName: "reverted while provisioning",
+			status: v1beta1.ExternalListenerAccessStatus{
+				ExternalListenerAccess: istio,
+				Transition:             &v1beta1.ExternalListenerAccessTransition{Phase: v1beta1.ExternalListenerAccessProvisioning, Previous: envoy},
+			},
+			desired: envoy,
+		},
+		{
+			testName: "reverted while advertising",
+			status: v1beta1.ExternalListenerAccessStatus{
+				ExternalListenerAccess: istio,
+				Transition:             &v1beta1.ExternalListenerAccessTransition{Phase: v1beta1.ExternalListenerAccessAdvertising, Previous: envoy},
+			},
+			desired:          envoy,
+			expectedPrevious: &istio,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			status := externalListenerAccessChanged(test.status, test.desired)
+			require.Equal(t, test.desired, status.ExternalListenerAccess)
+			if test.expectedPrevious == nil {
+				require.Nil(t, status.Transition)
+				return
+			}
+			require.NotNil(t, status.Transition)
+			require.Equal(t, v1beta1.ExternalListenerAccessProvisioning, status.Transition.Phase)
+			require.Equal(t, *test.expectedPrevious, status.Transition.Previous)
+		})
+	}
+}
+
+func TestAdvertisedExternalListener(t *testing.T) {
+	eListener := v1beta1.ExternalListenerConfig{
+		CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "external"},
+		AccessMethod:       corev1.ServiceTypeLoadBalancer,
+		IngressController:  "istioingress",
+	}
+	envoy := v1beta1.ExternalListenerAccess{IngressController: "envoy", AccessMethod: corev1.ServiceTypeNodePort}
+
+	testCases := []struct {
+		testName       string
+		phase          v1beta1.ExternalListenerAccessTransitionPhase
+		expectedAccess v1beta1.ExternalListenerAccess
+	}{
+		{
+			testName:       "previous access method advertised while provisioning",
+			phase:          v1beta1.ExternalListenerAccessProvisioning,
+			expectedAccess: envoy,
+		},
+		{
+			testName:       "new access method advertised once reachable",
+			phase:          v1beta1.ExternalListenerAccessAdvertising,
+			expectedAccess: v1beta1.ExternalListenerAccess{IngressController: "istioingress", AccessMethod: corev1.ServiceTypeLoadBalancer},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			cluster := &v1beta1.KafkaCluster{
+				Status: v1beta1.KafkaClusterStatus{
+					ExternalListenersAccess: map[string]v1beta1.ExternalListenerAccessStatus{
+						"external": {
+							ExternalListenerAccess: v1beta1.ExternalListenerAccess{IngressController: "istioingress", AccessMethod: corev1.ServiceTypeLoadBalancer},
+							Transition:             &v1beta1.ExternalListenerAccessTransition{Phase: test.phase, Previous: envoy},
+						},
+					},
+				},
+			}
+			advertised := advertisedExternalListener(cluster, eListener)
+			require.Equal(t, test.expectedAccess, cluster.Spec.GetAccess(advertised))
+		})
+	}
+}
+
+func TestAreBrokerConfigsInSync(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		state          v1beta1.ClusterState
+		brokersState   map[string]v1beta1.BrokerState
+		expectedInSync bool
+	}{
+		{
+			testName: "every broker in sync",
+			brokersState: map[string]v1beta1.BrokerState{
+				"0": {ConfigurationState: v1beta1.ConfigInSync, PerBrokerConfigurationState: v1beta1.PerBrokerConfigInSync},
+				"1": {ConfigurationState: v1beta1.ConfigInSync},
+			},
+			expectedInSync: true,
+		},
+		{
+			testName: "per-broker config not applied",
+			brokersState: map[string]v1beta1.BrokerState{
+				"0": {ConfigurationState: v1beta1.ConfigInSync, PerBrokerConfigurationState: v1beta1.PerBrokerConfigOutOfSync},
+				"1": {ConfigurationState: v1beta1.ConfigInSync},
+			},
+		},
+		{
+			testName: "broker not restarted",
+			brokersState: map[string]v1beta1.BrokerState{
+				"0": {ConfigurationState: v1beta1.ConfigInSync},
+				"1": {ConfigurationState: v1beta1.ConfigOutOfSync},
+			},
+		},
+		{
+			testName:     "broker without state",
+			brokersState: map[string]v1beta1.BrokerState{"0": {ConfigurationState: v1beta1.ConfigInSync}},
+		},
+		{
+			testName: "rolling upgrade in progress",
+			state:    v1beta1.KafkaClusterRollingUpgrading,
+			brokersState: map[string]v1beta1.BrokerState{
+				"0": {ConfigurationState: v1beta1.ConfigInSync},
+				"1": {ConfigurationState: v1beta1.ConfigInSync},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			r := &Reconciler{}
+			r.KafkaCluster = &v1beta1.KafkaCluster{
+				Spec:   v1beta1.KafkaClusterSpec{Brokers: []v1beta1.Broker{{Id: 0}, {Id: 1}}},
+				Status: v1beta1.KafkaClusterStatus{State: test.state, BrokersState: test.brokersState},
+			}
+			require.Equal(t, test.expectedInSync, r.areBrokerConfigsInSync())
+		})
+	}
+}
@@ -61,11 +61,12 @@ func (r *Reconciler) headlessServiceTemplate(addressTemplate string, selectorLab
 	usedPorts = append(usedPorts,
 		generateServicePortForAdditionalPorts(r.KafkaCluster.Spec.AdditionalPorts)...)
 
-	// prometheus metrics port for servicemonitor
+	// prometheus metrics port for servicemonitor, the target is the named container port as the port of the
+	// JMX exporter can be overridden per broker config group
 	usedPorts = append(usedPorts, corev1.ServicePort{
 		Name:       "metrics",
 		Port:       MetricsPort,
-		TargetPort: intstr.FromInt(MetricsPort),
+		TargetPort: intstr.FromString("metrics"),
 		Protocol:   corev1.ProtocolTCP,
 	})
 
@@ -165,6 +166,10 @@ func (r *Reconciler) deleteNonHeadlessServices(ctx context.Context) error {
 	if isNodePortAccessMethodInUseAmongExternalListeners(r.KafkaCluster.Spec.ListenersConfig.ExternalListeners) {
 		filteredSvcsToDelete = nonNodePortServices(services)
 	}
+	// the same applies to the LoadBalancer services of the brokers exposed through their own load balancer
+	if isPerBrokerLoadBalancerAccessMethodInUseAmongExternalListeners(r.KafkaCluster.Spec.ListenersConfig.ExternalListeners) {
+		filteredSvcsToDelete = nonLoadBalancerServices(filteredSvcsToDelete)
+	}
 
 	for _, svc := range filteredSvcsToDelete.Items {
 		if !svc.GetDeletionTimestamp().IsZero() {
@@ -201,3 +206,27 @@ func nonNodePortServices(services corev1.ServiceList) corev1.ServiceList {
 
 	return nonNodePortSvc
 }
+
+// isPerBrokerLoadBalancerAccessMethodInUseAmongExternalListeners returns true when users specify any of the external
+// listeners to use PerBrokerLoadBalancer
+func isPerBrokerLoadBalancerAccessMethodInUseAmongExternalListeners(externalListeners []v1beta1.ExternalListenerConfig) bool {
+	for _, externalListener := range externalListeners {
+		if externalListener.GetAccessMethod() == v1beta1.ServiceTypePerBrokerLoadBalancer {
+			return true
+		}
+	}
+
+	return false
+}
+
+func nonLoadBalancerServices(services corev1.ServiceList) corev1.ServiceList {
+	var nonLoadBalancerSvc corev1.ServiceList
+
+	for _, svc := range services.Items {
+		if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			nonLoadBalancerSvc.Items = append(nonLoadBalancerSvc.Items, svc)
+		}
+	}
+
+	return nonLoadBalancerSvc
+}
@@ -0,0 +1,138 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"strconv"
+
+	"emperror.dev/errors"
+	"github.com/IBM/sarama"
+	"github.com/go-logr/logr"
+
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	"github.com/banzaicloud/koperator/pkg/kafkaclient"
+	"github.com/banzaicloud/koperator/pkg/util"
+	"github.com/banzaicloud/koperator/pkg/util/kafka"
+	properties "github.com/banzaicloud/koperator/properties/pkg"
+)
+
+// defaultMinInsyncReplicas is the Kafka default of the min.insync.replicas topic configuration
+const defaultMinInsyncReplicas = 1
+
+// reconcileInternalTopicsReplication raises the replication factor of the existing internal topics and the min ISR of
+// the transaction state topic to the configured values once the cluster has grown, they are never lowered
+func (r *Reconciler) reconcileInternalTopicsReplication(log logr.Logger) error {
+	if !r.KafkaCluster.Spec.InternalTopicsConfig.IsAutoReplicationEnabled() {
+		return nil
+	}
+
+	brokerCount, err := kafka.BrokerNodeCount(r.KafkaCluster.Spec)
+	if err != nil {
+		return errors.WrapIf(err, "could not determine the number of brokers")
+	}
+	readOnlyConfig, err := properties.NewFromString(r.KafkaCluster.Spec.ReadOnlyConfig)
+	if err != nil {
+		return errors.WrapIf(err, "could not parse readonly cluster configuration")
+	}
+	replicationConfig := kafka.InternalTopicsReplicationConfig(readOnlyConfig, brokerCount)
+
+	kClient, close, err := r.kafkaClientProvider.NewFromCluster(r.Client, r.KafkaCluster)
+	if err != nil {
+		return errorfactory.New(errorfactory.BrokersUnreachable{}, err, "could not connect to kafka brokers")
+	}
+	defer close()
+
+	topics, err := kClient.ListTopics()
+	if err != nil {
+		return errors.WrapIf(err, "could not list topics")
+	}
+
+	for _, topic := range []string{kafka.OffsetsTopicName, kafka.TransactionStateTopicName} {
+		// the internal topics are created on first use with the configured replication factor
+		detail, ok := topics[topic]
+		if !ok {
+			continue
+		}
+		replicationFactor := replicationConfig[kafka.InternalTopicReplicationFactorConfigs[topic]]
+		if err := raiseInternalTopicReplicationFactor(kClient, topic, detail, replicationFactor, log); err != nil {
+			return err
+		}
+	}
+
+	if detail, ok := topics[kafka.TransactionStateTopicName]; ok {
+		minISR := replicationConfig[kafka.KafkaConfigTransactionStateMinISR]
+		if err := raiseTransactionStateMinISR(kClient, detail, minISR, log); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func raiseInternalTopicReplicationFactor(kClient kafkaclient.KafkaClient, topic string, detail sarama.TopicDetail, replicationFactor int, log logr.Logger) error {
+	if int(detail.ReplicationFactor) >= replicationFactor {
+		return nil
+	}
+	if replicationFactor > kClient.NumBrokers() {
+		log.Info("not enough brokers are available to raise the replication factor of the internal topic",
+			"topic", topic, "replicationFactor", replicationFactor)
+		return nil
+	}
+
+	partitions := make([]int32, 0, detail.NumPartitions)
+	for id := int32(0); id < detail.NumPartitions; id++ {
+		partitions = append(partitions, id)
+	}
+	reassignments, err := kClient.ListPartitionReassignments(topic, partitions)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not list partition reassignments of internal topic", "topic", topic)
+	}
+	if len(reassignments) > 0 {
+		log.V(1).Info("partition reassignment of the internal topic is in progress", "topic", topic)
+		return nil
+	}
+
+	log.Info("raising the replication factor of the internal topic", "topic", topic,
+		"currentReplicationFactor", detail.ReplicationFactor, "replicationFactor", replicationFactor)
+	if err := kClient.ChangeReplicationFactor(topic, int32(replicationFactor)); err != nil {
+		return errors.WrapIfWithDetails(err, "could not raise the replication factor of internal topic", "topic", topic)
+	}
+	return nil
+}
+
+// raiseTransactionStateMinISR raises the min.insync.replicas of the transaction state topic which Kafka sets from
+// transaction.state.log.min.isr only when the topic is created
+func raiseTransactionStateMinISR(kClient kafkaclient.KafkaClient, detail sarama.TopicDetail, minISR int, log logr.Logger) error {
+	current := defaultMinInsyncReplicas
+	if value, ok := detail.ConfigEntries[kafka.TopicConfigMinInsyncReplicas]; ok && value != nil {
+		if parsed, err := strconv.Atoi(*value); err == nil {
+			current = parsed
+		}
+	}
+	// the min ISR is raised once the replicas are in place, otherwise writes would be rejected
+	if current >= minISR || minISR > int(detail.ReplicationFactor) {
+		return nil
+	}
+
+	log.Info("raising the min ISR of the internal topic", "topic", kafka.TransactionStateTopicName,
+		"currentMinISR", current, "minISR", minISR)
+	err := kClient.SetTopicConfig(kafka.TransactionStateTopicName, map[string]*string{
+		kafka.TopicConfigMinInsyncReplicas: util.StringPointer(strconv.Itoa(minISR)),
+	})
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not raise the min ISR of internal topic", "topic", kafka.TransactionStateTopicName)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mocks "github.com/banzaicloud/koperator/pkg/resources/kafka/mocks"
+	"github.com/banzaicloud/koperator/pkg/util"
+	kafkautils "github.com/banzaicloud/koperator/pkg/util/kafka"
+)
+
+func TestRaiseInternalTopicReplicationFactor(t *testing.T) {
+	testCases := []struct {
+		testName          string
+		currentRF         int16
+		replicationFactor int
+		numBrokers        int
+		reassignments     map[int32]*sarama.PartitionReplicaReassignmentsStatus
+		expectedChange    bool
+	}{
+		{
+			testName:          "replication factor reached",
+			currentRF:         3,
+			replicationFactor: 3,
+		},
+		{
+			testName:          "replication factor is never lowered",
+			currentRF:         3,
+			replicationFactor: 2,
+		},
+		{
+			testName:          "not enough brokers available",
+			currentRF:         1,
+			replicationFactor: 3,
+			numBrokers:        2,
+		},
+		{
+			testName:          "reassignment in progress",
+			currentRF:         1,
+			replicationFactor: 3,
+			numBrokers:        3,
+			reassignments: map[int32]*sarama.PartitionReplicaReassignmentsStatus{
+				0: {Replicas: []int32{0, 1, 2}, AddingReplicas: []int32{1, 2}},
+			},
+		},
+		{
+			testName:          "replication factor raised",
+			currentRF:         1,
+			replicationFactor: 3,
+			numBrokers:        3,
+			expectedChange:    true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			kClient := mocks.NewMockKafkaClient(mockCtrl)
+			if test.numBrokers > 0 {
+				kClient.EXPECT().NumBrokers().Return(test.numBrokers)
+			}
+			if test.numBrokers >= test.replicationFactor && int(test.currentRF) < test.replicationFactor {
+				kClient.EXPECT().ListPartitionReassignments(kafkautils.OffsetsTopicName, []int32{0, 1}).Return(test.reassignments, nil)
+			}
+			if test.expectedChange {
+				kClient.EXPECT().ChangeReplicationFactor(kafkautils.OffsetsTopicName, int32(test.replicationFactor)).Return(nil)
+			}
+
+			detail := sarama.TopicDetail{NumPartitions: 2, ReplicationFactor: test.currentRF}
+			err := raiseInternalTopicReplicationFactor(kClient, kafkautils.OffsetsTopicName, detail, test.replicationFactor, logr.Discard())
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRaiseTransactionStateMinISR(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		currentMinISR  *string
+		currentRF      int16
+		minISR         int
+		expectedChange bool
+	}{
+		{
+			testName:      "min ISR reached",
+			currentRF:     3,
+			currentMinISR: util.StringPointer("2"),
+			minISR:        2,
+		},
+		{
+			testName:  "replicas are not in place yet",
+			currentRF: 1,
+			minISR:    2,
+		},
+		{
+			testName:       "min ISR raised from the default",
+			currentRF:      3,
+			minISR:         2,
+			expectedChange: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			kClient := mocks.NewMockKafkaClient(mockCtrl)
+			if test.expectedChange {
+				kClient.EXPECT().SetTopicConfig(kafkautils.TransactionStateTopicName, map[string]*string{
+					kafkautils.TopicConfigMinInsyncReplicas: util.StringPointer("2"),
+				}).Return(nil)
+			}
+
+			detail := sarama.TopicDetail{
+				ReplicationFactor: test.currentRF,
+				ConfigEntries:     map[string]*string{},
+			}
+			if test.currentMinISR != nil {
+				detail.ConfigEntries[kafkautils.TopicConfigMinInsyncReplicas] = test.currentMinISR
+			}
+			require.NoError(t, raiseTransactionStateMinISR(kClient, detail, test.minISR, logr.Discard()))
+		})
+	}
+}
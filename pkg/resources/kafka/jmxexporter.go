@@ -0,0 +1,83 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	"github.com/banzaicloud/koperator/pkg/resources/kafkamonitoring"
+	"github.com/banzaicloud/koperator/pkg/util"
+)
+
+// jmxExporterConfigFileName is the name of the JMX exporter configuration file the java agent of the broker reads
+const jmxExporterConfigFileName = "config.yaml"
+
+// generateJmxExporterConfigVolumeSource returns the source of the JMX exporter configuration of the broker, the
+// ConfigMap referenced by the broker config or the one generated from the monitoring config of the cluster
+func generateJmxExporterConfigVolumeSource(brokerConfig *v1beta1.BrokerConfig, kafkaClusterName string) *corev1.ConfigMapVolumeSource {
+	ref := brokerConfig.GetJmxExporterRulesConfigMapRef()
+	if ref == nil {
+		return &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf(kafkamonitoring.BrokerJmxTemplate, kafkaClusterName)},
+			DefaultMode:          util.Int32Pointer(0644),
+		}
+	}
+	return &corev1.ConfigMapVolumeSource{
+		LocalObjectReference: ref.LocalObjectReference,
+		Items:                []corev1.KeyToPath{{Key: ref.Key, Path: jmxExporterConfigFileName}},
+		DefaultMode:          util.Int32Pointer(0644),
+		Optional:             ref.Optional,
+	}
+}
+
+// jmxExporterRulesHash returns the hash of the JMX exporter configuration referenced by the broker config, empty if
+// the broker uses the generated configuration or the optional reference does not resolve
+func (r *Reconciler) jmxExporterRulesHash(ctx context.Context, brokerConfig *v1beta1.BrokerConfig, log logr.Logger) (string, error) {
+	ref := brokerConfig.GetJmxExporterRulesConfigMapRef()
+	if ref == nil {
+		return "", nil
+	}
+	optional := ref.Optional != nil && *ref.Optional
+
+	rulesConfigMap := &corev1.ConfigMap{}
+	err := r.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: r.KafkaCluster.GetNamespace()}, rulesConfigMap)
+	if err != nil {
+		if apierrors.IsNotFound(err) && optional {
+			log.V(1).Info("optional JMX exporter configuration not found", "configMap", ref.Name)
+			return "", nil
+		}
+		return "", errorfactory.New(errorfactory.ResourceNotReady{}, err, "could not get JMX exporter configuration", "configMap", ref.Name)
+	}
+	content, found := rulesConfigMap.Data[ref.Key]
+	if !found {
+		if optional {
+			log.V(1).Info("optional JMX exporter configuration key not found", "configMap", ref.Name, "key", ref.Key)
+			return "", nil
+		}
+		return "", errorfactory.New(errorfactory.ResourceNotReady{}, errors.New("key not found"),
+			"could not get JMX exporter configuration", "configMap", ref.Name, "key", ref.Key)
+	}
+	return util.GetMD5Hash(content), nil
+}
@@ -0,0 +1,171 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/resources"
+	"github.com/banzaicloud/koperator/pkg/util"
+)
+
+func TestJmxExporterRulesHash(t *testing.T) {
+	rulesConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "jmx-rules", Namespace: "kafka"},
+		Data: map[string]string{
+			"config.yaml": "lowercaseOutputName: true\nrules:\n- pattern: \".*\"\n",
+		},
+	}
+
+	tests := []struct {
+		testName     string
+		jmxExporter  *v1beta1.BrokerJmxExporterConfig
+		expectedHash string
+		expectedErr  bool
+	}{
+		{
+			testName: "no override",
+		},
+		{
+			testName:    "no rules reference",
+			jmxExporter: &v1beta1.BrokerJmxExporterConfig{Port: util.Int32Pointer(9404)},
+		},
+		{
+			testName: "referenced rules",
+			jmxExporter: &v1beta1.BrokerJmxExporterConfig{
+				RulesConfigMapRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "jmx-rules"},
+					Key:                  "config.yaml",
+				},
+			},
+			expectedHash: util.GetMD5Hash(rulesConfigMap.Data["config.yaml"]),
+		},
+		{
+			testName: "missing configmap",
+			jmxExporter: &v1beta1.BrokerJmxExporterConfig{
+				RulesConfigMapRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "missing-rules"},
+					Key:                  "config.yaml",
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			testName: "missing optional configmap",
+			jmxExporter: &v1beta1.BrokerJmxExporterConfig{
+				RulesConfigMapRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "missing-rules"},
+					Key:                  "config.yaml",
+					Optional:             util.BoolPointer(true),
+				},
+			},
+		},
+		{
+			testName: "missing key",
+			jmxExporter: &v1beta1.BrokerJmxExporterConfig{
+				RulesConfigMapRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "jmx-rules"},
+					Key:                  "rules.yaml",
+				},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			r := Reconciler{
+				Reconciler: resources.Reconciler{
+					Client: fake.NewClientBuilder().WithObjects(rulesConfigMap.DeepCopy()).Build(),
+					KafkaCluster: &v1beta1.KafkaCluster{
+						ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+					},
+				},
+			}
+
+			hash, err := r.jmxExporterRulesHash(context.Background(), &v1beta1.BrokerConfig{JmxExporter: test.jmxExporter}, logr.Discard())
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expectedHash, hash)
+		})
+	}
+}
+
+func TestJmxExporterBrokerOverrides(t *testing.T) {
+	tests := []struct {
+		testName             string
+		jmxExporter          *v1beta1.BrokerJmxExporterConfig
+		expectedImage        string
+		expectedPort         int32
+		expectedConfigSource *corev1.ConfigMapVolumeSource
+	}{
+		{
+			testName:      "monitoring config of the cluster",
+			expectedImage: "jmx-exporter:cluster",
+			expectedPort:  9020,
+			expectedConfigSource: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "kafka-kafka-jmx-exporter"},
+				DefaultMode:          util.Int32Pointer(0644),
+			},
+		},
+		{
+			testName: "broker config group overrides",
+			jmxExporter: &v1beta1.BrokerJmxExporterConfig{
+				Image: "jmx-exporter:group",
+				Port:  util.Int32Pointer(9404),
+				RulesConfigMapRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "jmx-rules"},
+					Key:                  "kafka.yaml",
+				},
+			},
+			expectedImage: "jmx-exporter:group",
+			expectedPort:  9404,
+			expectedConfigSource: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "jmx-rules"},
+				Items:                []corev1.KeyToPath{{Key: "kafka.yaml", Path: "config.yaml"}},
+				DefaultMode:          util.Int32Pointer(0644),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			brokerConfig := &v1beta1.BrokerConfig{JmxExporter: test.jmxExporter}
+			clusterSpec := v1beta1.KafkaClusterSpec{MonitoringConfig: v1beta1.MonitoringConfig{JmxImage: "jmx-exporter:cluster"}}
+
+			var image string
+			for _, container := range getInitContainers(brokerConfig, clusterSpec) {
+				if container.Name == "jmx-exporter" {
+					image = container.Image
+				}
+			}
+			require.Equal(t, test.expectedImage, image)
+			require.Equal(t, test.expectedPort, brokerConfig.GetJmxExporterPort())
+			require.Equal(t, test.expectedConfigSource, generateJmxExporterConfigVolumeSource(brokerConfig, "kafka"))
+		})
+	}
+}
@@ -17,11 +17,14 @@ package kafka
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
+	"net/http"
 	"reflect"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"emperror.dev/errors"
 	ccTypes "github.com/banzaicloud/go-cruise-control/pkg/types"
@@ -29,11 +32,14 @@ import (
 	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	properties "github.com/banzaicloud/koperator/properties/pkg"
 
@@ -55,9 +61,33 @@ import (
 	certutil "github.com/banzaicloud/koperator/pkg/util/cert"
 	contourutils "github.com/banzaicloud/koperator/pkg/util/contour"
 	envoyutils "github.com/banzaicloud/koperator/pkg/util/envoy"
+	gatewayapiutils "github.com/banzaicloud/koperator/pkg/util/gatewayapi"
 	istioingressutils "github.com/banzaicloud/koperator/pkg/util/istioingress"
 	"github.com/banzaicloud/koperator/pkg/util/kafka"
+	"github.com/banzaicloud/koperator/pkg/util/maintenance"
+	nginxutils "github.com/banzaicloud/koperator/pkg/util/nginx"
 	pkicommon "github.com/banzaicloud/koperator/pkg/util/pki"
+	"github.com/banzaicloud/koperator/pkg/util/prometheus"
+	"github.com/banzaicloud/koperator/pkg/util/restarthooks"
+)
+
+// The reasons of the events emitted on the KafkaCluster
+const (
+	brokerAddedEventReason                 = "BrokerAdded"
+	brokerPodCreatedEventReason            = "BrokerPodCreated"
+	brokerPodNodeLostEventReason           = "BrokerPodNodeLost"
+	brokerRemovedEventReason               = "BrokerRemoved"
+	brokerRestartedEventReason             = "BrokerRestarted"
+	brokerRescheduledEventReason           = "BrokerRescheduled"
+	brokerDecommissionStartedEventReason   = "BrokerDecommissionStarted"
+	brokerDecommissionCompletedEventReason = "BrokerDecommissionCompleted"
+	brokerReplacementStartedEventReason    = "BrokerReplacementStarted"
+	brokerReplacementSucceededEventReason  = "BrokerReplacementSucceeded"
+	rollingUpgradeStartedEventReason       = "RollingUpgradeStarted"
+	certificatesReloadedEventReason        = "CertificatesReloaded"
+	versionUpgradeStartedEventReason       = "VersionUpgradeStarted"
+	protocolUpgradeStartedEventReason      = "ProtocolVersionUpgradeStarted"
+	versionUpgradeCompletedEventReason     = "VersionUpgradeCompleted"
 )
 
 // brokerReconcilePriority lower value represents higher priority for a broker to be reconciled
@@ -71,6 +101,13 @@ const (
 	brokerConfigMapVolumeMount = "broker-config"
 	kafkaDataVolumeMount       = "kafka-data"
 
+	// externalBrokerConfigHashAnnotation records the hash of the externally provided broker configuration
+	// merged into the broker ConfigMap
+	externalBrokerConfigHashAnnotation = "kafka.banzaicloud.io/external-config-hash"
+	// jmxExporterRulesHashAnnotation records the hash of the externally provided JMX exporter configuration of the
+	// broker on its pod, a change of the configuration rolls the brokers one by one
+	jmxExporterRulesHashAnnotation = "kafka.banzaicloud.io/jmx-exporter-rules-hash"
+
 	serverKeystorePath   = "/var/run/secrets/java.io/keystores/server"
 	clientKeystoreVolume = "client-ks-files"
 	clientKeystorePath   = "/var/run/secrets/java.io/keystores/client"
@@ -100,10 +137,18 @@ type Reconciler struct {
 	resources.Reconciler
 	kafkaClientProvider        kafkaclient.Provider
 	CruiseControlScalerFactory func(ctx context.Context, kafkaCluster *banzaiv1beta1.KafkaCluster) (scale.CruiseControlScaler, error)
+	ServedCertificate          func(ctx context.Context, address string) (*x509.Certificate, error)
+	// Recorder emits the events of the reconcile decisions on the KafkaCluster, e.g. the start of a rolling upgrade
+	Recorder record.EventRecorder
+	// oauthBearerConfigs holds the SASL/OAUTHBEARER configs of the listeners with their discovered endpoints
+	oauthBearerConfigs map[string]banzaiv1beta1.OAuthBearerConfig
+	// maintenanceWaiting holds the disruptive actions waiting for the next maintenance window of the cluster
+	maintenanceWaiting []string
 }
 
 // New creates a new reconciler for Kafka
-func New(client client.Client, directClient client.Reader, cluster *banzaiv1beta1.KafkaCluster, kafkaClientProvider kafkaclient.Provider) *Reconciler {
+func New(client client.Client, directClient client.Reader, cluster *banzaiv1beta1.KafkaCluster, kafkaClientProvider kafkaclient.Provider,
+	recorder record.EventRecorder) *Reconciler {
 	return &Reconciler{
 		Reconciler: resources.Reconciler{
 			Client:       client,
@@ -112,6 +157,8 @@ func New(client client.Client, directClient client.Reader, cluster *banzaiv1beta
 		},
 		kafkaClientProvider:        kafkaClientProvider,
 		CruiseControlScalerFactory: scale.ScaleFactoryFn(),
+		ServedCertificate:          certutil.GetServedCertificate,
+		Recorder:                   recorder,
 	}
 }
 
@@ -154,6 +201,9 @@ func getCreatedPvcForBroker(
 	}
 
 	var missing []string
+	// the PVCs of the volumes removed from the broker are left out, so the broker is restarted without them once
+	// their replicas have been moved off
+	var pvcs []corev1.PersistentVolumeClaim
 	for i := range storageConfigs {
 		if storageConfigs[i].PvcSpec == nil {
 			continue
@@ -163,6 +213,7 @@ func getCreatedPvcForBroker(
 		for j := range foundPvcList.Items {
 			if storageConfigs[i].MountPath == foundPvcList.Items[j].GetAnnotations()["mountPath"] {
 				found = true
+				pvcs = append(pvcs, foundPvcList.Items[j])
 				break
 			}
 		}
@@ -174,10 +225,10 @@ func getCreatedPvcForBroker(
 		return nil, errors.NewWithDetails("broker mount paths missing persistent volume claim",
 			banzaiv1beta1.BrokerIdLabelKey, brokerID, "mount paths", missing)
 	}
-	sort.Slice(foundPvcList.Items, func(i, j int) bool {
-		return foundPvcList.Items[i].Name < foundPvcList.Items[j].Name
+	sort.Slice(pvcs, func(i, j int) bool {
+		return pvcs[i].Name < pvcs[j].Name
 	})
-	return foundPvcList.Items, nil
+	return pvcs, nil
 }
 
 func getLoadBalancerIP(foundLBService *corev1.Service) (string, error) {
@@ -239,7 +290,13 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 	}
 
 	// Handle PDB for brokers
-	if r.KafkaCluster.Spec.DisruptionBudget.Create {
+	if r.KafkaCluster.Spec.DisruptionBudget.Create && r.KafkaCluster.Spec.IsSingleNodeCombinedMode() {
+		// the budget of the only node would block its eviction forever, the ones created before the cluster was
+		// scaled down to a single node are removed
+		if err := r.deleteSingleNodePodDisruptionBudgets(ctx, log); err != nil {
+			return err
+		}
+	} else if r.KafkaCluster.Spec.DisruptionBudget.Create {
 		o, err := r.podDisruptionBudgetBrokers(log)
 		if err != nil {
 			return errors.WrapIfWithDetails(err, "failed to compute podDisruptionBudget for brokers")
@@ -261,12 +318,34 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 		}
 	}
 
+	// the decommissioned brokers are removed from the spec once they are drained, their pods are then deleted below
+	if err := r.reconcileBrokerDecommissions(ctx, log); err != nil {
+		return errors.WrapIf(err, "failed to reconcile broker decommissions")
+	}
+
 	// Handle Pod delete
 	err := r.reconcileKafkaPodDelete(ctx, log)
 	if err != nil {
 		return errors.WrapIf(err, "failed to reconcile resource")
 	}
 
+	replacements, err := r.reconcileBrokerReplacements(ctx, log)
+	if err != nil {
+		return errors.WrapIf(err, "failed to reconcile broker replacements")
+	}
+
+	if err = r.reconcileStorageMigrations(ctx, log, replacements); err != nil {
+		return errors.WrapIf(err, "failed to reconcile storage migrations")
+	}
+
+	if err = r.reconcileControllerQuorumStatus(ctx, log); err != nil {
+		return err
+	}
+
+	if err = r.reconcileExternalListenersAccess(log); err != nil {
+		return err
+	}
+
 	extListenerStatuses, err := r.createExternalListenerStatuses(log)
 	if err != nil {
 		return errors.WrapIf(err, "could not update status for external listeners")
@@ -287,6 +366,10 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 			return err
 		}
 	}
+	// Setup the server certificates of the listeners with their own PKI
+	if err := pki.ReconcileListenerPKI(ctx, r.Client, r.KafkaCluster, extListenerStatuses); err != nil {
+		return err
+	}
 
 	// We need to grab names for servers and client in case user is enabling ACLs
 	// That way we can continue to manage topics and users
@@ -294,13 +377,34 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 	if err != nil {
 		return err
 	}
+	// The keystore passwords managed by a password policy are never rendered into the broker configurations, and the
+	// brokers are rolled when they are rotated
+	var keystorePasswordRevision string
+	if r.KafkaCluster.Spec.ListenersConfig.SSLSecrets.GetPasswordPolicy() != nil {
+		clientPass, serverPasses = keystorePasswordReferences(clientPass, serverPasses)
+		if keystorePasswordRevision, err = r.keystorePasswordRevision(ctx); err != nil {
+			return err
+		}
+	}
+
+	if r.oauthBearerConfigs, err = r.resolveOAuthBearerConfigs(ctx); err != nil {
+		return err
+	}
+	if err = r.checkBrokerConfigSecrets(ctx, log); err != nil {
+		return err
+	}
 
 	brokersVolumes := make(map[string][]*corev1.PersistentVolumeClaim, len(r.KafkaCluster.Spec.Brokers))
 	for _, broker := range r.KafkaCluster.Spec.Brokers {
+		// the volumes of the replaced broker are recreated once the old ones are gone
+		if replacements[strconv.Itoa(int(broker.Id))] == banzaiv1beta1.BrokerReplacementDeleting {
+			continue
+		}
 		brokerConfig, err := broker.GetBrokerConfig(r.KafkaCluster.Spec)
 		if err != nil {
 			return errors.WrapIf(err, "failed to reconcile resource")
 		}
+		brokerConfig = r.withStorageMigrations(broker.Id, brokerConfig)
 
 		var brokerVolumes []*corev1.PersistentVolumeClaim
 		for index, storage := range brokerConfig.StorageConfigs {
@@ -361,6 +465,19 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 		log.Error(err, "could not find controller broker")
 	}
 
+	if err = r.startVersionUpgrade(log); err != nil {
+		return err
+	}
+
+	// brokers leading fewer partitions are restarted first to minimize the leadership changes of a rolling upgrade
+	var partitionLeaderCounts map[int32]int
+	if isRollingRestartPending(r.KafkaCluster) {
+		partitionLeaderCounts, err = r.determinePartitionLeaderCounts()
+		if err != nil {
+			log.Error(err, "could not determine the partition leaders, brokers are reconciled in id order")
+		}
+	}
+
 	var quorumVoters []string
 	if r.KafkaCluster.Spec.KRaftMode {
 		// all broker nodes under the same Kafka cluster must use the same cluster UUID
@@ -373,6 +490,13 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 				}
 			}
 
+			// the KRaft controllers migrating the metadata from ZooKeeper must join the cluster with its existing ID
+			if r.KafkaCluster.Status.ClusterID == "" && r.KafkaCluster.Spec.KRaftMigration.IsEnabled() {
+				if r.KafkaCluster.Status.ClusterID, err = r.getZooKeeperClusterID(); err != nil {
+					return err
+				}
+			}
+
 			if r.KafkaCluster.Status.ClusterID == "" {
 				r.KafkaCluster.Status.ClusterID = generateRandomClusterID()
 			}
@@ -406,18 +530,29 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 		controllerID = -1
 	}
 
-	reorderedBrokers := reorderBrokers(runningBrokers, boundPersistentVolumeClaims, r.KafkaCluster.Spec.Brokers, r.KafkaCluster.Status.BrokersState, controllerID, log)
+	reorderedBrokers := reorderBrokers(runningBrokers, boundPersistentVolumeClaims, r.KafkaCluster.Spec.Brokers, r.KafkaCluster.Status.BrokersState, controllerID, partitionLeaderCounts, log)
 
 	allBrokerDynamicConfigSucceeded := true
 	for _, broker := range reorderedBrokers {
+		if replacements[strconv.Itoa(int(broker.Id))] == banzaiv1beta1.BrokerReplacementDeleting {
+			log.Info("waiting for the pod and the volumes of the replaced broker to be deleted", banzaiv1beta1.BrokerIdLabelKey, broker.Id)
+			continue
+		}
 		brokerConfig, err := broker.GetBrokerConfig(r.KafkaCluster.Spec)
 		if err != nil {
 			return errors.WrapIf(err, "failed to reconcile resource")
 		}
+		brokerConfig = r.withStorageMigrations(broker.Id, brokerConfig)
+		if err = r.checkKafkaVersion(broker, brokerConfig); err != nil {
+			return err
+		}
 
 		var configMap *corev1.ConfigMap
 		if r.KafkaCluster.Spec.RackAwareness == nil {
 			configMap = r.configMap(broker, brokerConfig, quorumVoters, extListenerStatuses, intListenerStatuses, controllerIntListenerStatuses, serverPasses, clientPass, superUsers, log)
+			if err := r.mergeExternalBrokerConfig(ctx, configMap, brokerConfig, log); err != nil {
+				return err
+			}
 			err := k8sutil.Reconcile(log, r.Client, configMap, r.KafkaCluster)
 			if err != nil {
 				return errors.WrapIfWithDetails(err, "failed to reconcile resource", "resource", configMap.GetObjectKind().GroupVersionKind())
@@ -425,6 +560,9 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 		} else if brokerState, ok := r.KafkaCluster.Status.BrokersState[strconv.Itoa(int(broker.Id))]; ok {
 			if brokerState.RackAwarenessState != "" {
 				configMap = r.configMap(broker, brokerConfig, quorumVoters, extListenerStatuses, intListenerStatuses, controllerIntListenerStatuses, serverPasses, clientPass, superUsers, log)
+				if err := r.mergeExternalBrokerConfig(ctx, configMap, brokerConfig, log); err != nil {
+					return err
+				}
 				err := k8sutil.Reconcile(log, r.Client, configMap, r.KafkaCluster)
 				if err != nil {
 					return errors.WrapIfWithDetails(err, "failed to reconcile resource", "resource", configMap.GetObjectKind().GroupVersionKind())
@@ -445,6 +583,18 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 			}
 		}
 		o := r.pod(broker.Id, brokerConfig, pvcs, log)
+		if keystorePasswordRevision != "" {
+			pod := o.(*corev1.Pod)
+			pod.Annotations = util.MergeAnnotations(pod.Annotations, map[string]string{pkicommon.KeystorePasswordRevisionAnnotation: keystorePasswordRevision})
+		}
+		jmxExporterRulesHash, err := r.jmxExporterRulesHash(ctx, brokerConfig, log)
+		if err != nil {
+			return err
+		}
+		if jmxExporterRulesHash != "" {
+			pod := o.(*corev1.Pod)
+			pod.Annotations = util.MergeAnnotations(pod.Annotations, map[string]string{jmxExporterRulesHashAnnotation: jmxExporterRulesHash})
+		}
 		err = r.reconcileKafkaPod(log, o.(*corev1.Pod), brokerConfig)
 		if err != nil {
 			return err
@@ -474,6 +624,10 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 		return err
 	}
 
+	if err = r.reconcileInternalTopicsReplication(log); err != nil {
+		return err
+	}
+
 	// in case HeadlessServiceEnabled is changed, delete the service that was created by the previous
 	// reconcile flow. The services must be deleted at the end of the reconcile flow after the new services
 	// were created and broker configurations reflecting the new services otherwise the Kafka brokers
@@ -498,11 +652,79 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 		}
 	}
 
+	if err = r.reconcileKRaftMigration(ctx, log); err != nil {
+		return err
+	}
+
+	if err = r.reconcileVersionUpgrade(ctx, log); err != nil {
+		return err
+	}
+
+	if err = r.finishExternalListenersAccessAdvertising(log); err != nil {
+		return err
+	}
+
+	if len(replacements) > 0 {
+		return errorfactory.New(errorfactory.ResourceNotReady{}, errors.New("broker replacement in progress"),
+			"waiting for the replacement of the brokers", "brokers", replacementsInProgress(replacements))
+	}
+
+	reloading, err := r.reconcileCertificateReload(ctx, log)
+	if err != nil {
+		return err
+	}
+	if len(reloading) > 0 {
+		return errorfactory.New(errorfactory.ResourceNotReady{}, errors.New("certificate reload in progress"),
+			"waiting for the brokers to serve the renewed certificates", "brokers", reloading)
+	}
+
 	log.V(1).Info("Reconciled")
 
 	return nil
 }
 
+// MaintenanceWaiting returns the disruptive actions deferred to the next maintenance window of the cluster
+func (r *Reconciler) MaintenanceWaiting() []string {
+	return r.maintenanceWaiting
+}
+
+// deferToMaintenanceWindow returns true when the disruptive action must wait for the next maintenance window of the
+// cluster, the action is then reported once every component of the cluster has been reconciled
+func (r *Reconciler) deferToMaintenanceWindow(log logr.Logger, action string) bool {
+	inWindow, _, err := maintenance.InWindow(r.KafkaCluster.Spec.MaintenanceWindows, time.Now())
+	if err != nil {
+		log.Error(err, "invalid maintenance windows, the disruptive actions wait until they are fixed")
+	}
+	if inWindow {
+		return false
+	}
+	log.Info("waiting for the next maintenance window", "action", action)
+	r.maintenanceWaiting = append(r.maintenanceWaiting, action)
+	return true
+}
+
+// deferBrokerRemovals returns the pods of the removed brokers whose removal can proceed, the removals which have not
+// started yet wait for the next maintenance window
+func (r *Reconciler) deferBrokerRemovals(log logr.Logger, pods []corev1.Pod) []corev1.Pod {
+	var notStarted []string
+	for _, pod := range pods {
+		brokerState := r.KafkaCluster.Status.BrokersState[pod.Labels[banzaiv1beta1.BrokerIdLabelKey]]
+		if pod.DeletionTimestamp == nil && !brokerState.GracefulActionState.CruiseControlState.IsDownscale() {
+			notStarted = append(notStarted, pod.Labels[banzaiv1beta1.BrokerIdLabelKey])
+		}
+	}
+	if len(notStarted) == 0 || !r.deferToMaintenanceWindow(log, fmt.Sprintf("removal of brokers %s", strings.Join(notStarted, ", "))) {
+		return pods
+	}
+	started := make([]corev1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if !slices.Contains(notStarted, pod.Labels[banzaiv1beta1.BrokerIdLabelKey]) {
+			started = append(started, pod)
+		}
+	}
+	return started
+}
+
 func (r *Reconciler) reconcileKafkaPodDelete(ctx context.Context, log logr.Logger) error {
 	podList := &corev1.PodList{}
 	err := r.List(context.TODO(), podList,
@@ -519,22 +741,26 @@ func (r *Reconciler) reconcileKafkaPodDelete(ctx context.Context, log logr.Logge
 	}
 
 	podsDeletedFromSpec := make([]corev1.Pod, 0, len(podList.Items))
-	brokerIDsDeletedFromSpec := make(map[string]bool)
 	for _, pod := range podList.Items {
 		id, ok := pod.Labels[banzaiv1beta1.BrokerIdLabelKey]
 		if !ok {
 			continue
 		}
 		if _, ok = brokerIDsFromSpec[id]; !ok {
-			brokerIDsDeletedFromSpec[id] = true
 			podsDeletedFromSpec = append(podsDeletedFromSpec, pod)
 		}
 	}
+	podsDeletedFromSpec = r.deferBrokerRemovals(log, podsDeletedFromSpec)
+	brokerIDsDeletedFromSpec := make(map[string]bool, len(podsDeletedFromSpec))
+	for _, pod := range podsDeletedFromSpec {
+		brokerIDsDeletedFromSpec[pod.Labels[banzaiv1beta1.BrokerIdLabelKey]] = true
+	}
 
 	if len(podsDeletedFromSpec) > 0 {
+		var cc scale.CruiseControlScaler
 		if !arePodsAlreadyDeleted(podsDeletedFromSpec, log) {
 			// FIXME: we should reuse the context of the Kafka Controller
-			cc, err := r.CruiseControlScalerFactory(context.TODO(), r.KafkaCluster)
+			cc, err = r.CruiseControlScalerFactory(context.TODO(), r.KafkaCluster)
 			if err != nil {
 				return errorfactory.New(errorfactory.CruiseControlNotReady{}, err,
 					"failed to initialize Cruise Control Scaler", "cruise control url", scale.CruiseControlURLFromKafkaCluster(r.KafkaCluster))
@@ -590,6 +816,21 @@ func (r *Reconciler) reconcileKafkaPodDelete(ctx context.Context, log logr.Logge
 			}
 		}
 
+		removedControllerIDs := make([]string, 0)
+		for _, broker := range podsDeletedFromSpec {
+			if isControllerPod(&broker) {
+				removedControllerIDs = append(removedControllerIDs, broker.Labels[banzaiv1beta1.BrokerIdLabelKey])
+			}
+		}
+		controllerQuorumReady := true
+		if len(removedControllerIDs) > 0 {
+			controllerQuorumReady, err = r.isControllerQuorumReadyForRemoval(ctx, podList.Items, removedControllerIDs)
+			if err != nil {
+				return err
+			}
+		}
+
+		var replicaCounts map[string]int32
 		for _, broker := range podsDeletedFromSpec {
 			broker := broker
 			if broker.DeletionTimestamp != nil {
@@ -597,6 +838,13 @@ func (r *Reconciler) reconcileKafkaPodDelete(ctx context.Context, log logr.Logge
 				continue
 			}
 
+			// the removed controllers keep voting until the remaining ones run with the new voter set
+			if isControllerPod(&broker) && !controllerQuorumReady {
+				log.Info("waiting for the remaining controllers to be rolled with the new controller quorum voters",
+					banzaiv1beta1.BrokerIdLabelKey, broker.Labels[banzaiv1beta1.BrokerIdLabelKey])
+				continue
+			}
+
 			processRoles, found := broker.GetLabels()[banzaiv1beta1.ProcessRolesKey]
 			// only applicable in KRaft: if this Kafka pod is not a controller-only node, there is no corresponding CC
 			// therefore we can just skip the broker state check and delete the pod safely
@@ -609,6 +857,21 @@ func (r *Reconciler) reconcileKafkaPodDelete(ctx context.Context, log logr.Logge
 					}
 					continue
 				}
+
+				// the graceful downscale may be considered done while replicas are still left on the broker
+				if replicaCounts == nil {
+					replicaCounts, err = brokerReplicaCounts(ctx, cc)
+					if err != nil {
+						return err
+					}
+				}
+				drained, err := r.isBrokerDrained(log, broker.Labels[banzaiv1beta1.BrokerIdLabelKey], replicaCounts)
+				if err != nil {
+					return err
+				}
+				if !drained {
+					continue
+				}
 			}
 
 			err = r.Delete(context.TODO(), &broker)
@@ -616,6 +879,8 @@ func (r *Reconciler) reconcileKafkaPodDelete(ctx context.Context, log logr.Logge
 				return errors.WrapIfWithDetails(err, "could not delete broker", "id", broker.Labels[banzaiv1beta1.BrokerIdLabelKey])
 			}
 			log.Info("broker pod deleted", banzaiv1beta1.BrokerIdLabelKey, broker.Labels[banzaiv1beta1.BrokerIdLabelKey], "pod", broker.GetName())
+			k8sutil.RecordEvent(r.Recorder, r.KafkaCluster, corev1.EventTypeNormal, brokerRemovedEventReason,
+				"broker %s has been removed from the cluster", broker.Labels[banzaiv1beta1.BrokerIdLabelKey])
 			configMapName := fmt.Sprintf(brokerConfigTemplate+"-%s", r.KafkaCluster.Name, broker.Labels[banzaiv1beta1.BrokerIdLabelKey])
 			err = r.Delete(context.TODO(), &corev1.ConfigMap{ObjectMeta: templates.ObjectMeta(configMapName, apiutil.LabelsForKafka(r.KafkaCluster.Name), r.KafkaCluster)})
 			if err != nil {
@@ -708,11 +973,7 @@ func (r *Reconciler) getClientPasswordKeyAndUser() (string, string, error) {
 }
 
 func getListenerSSLCertSecret(client client.Reader, commonSpec banzaiv1beta1.CommonListenerSpec, clusterName string, clusterNamespace string) (*corev1.Secret, error) {
-	// Use default SSL cert secret
-	secretNamespacedName := types.NamespacedName{Name: fmt.Sprintf(pkicommon.BrokerServerCertTemplate, clusterName), Namespace: clusterNamespace}
-	if commonSpec.GetServerSSLCertSecretName() != "" {
-		secretNamespacedName = types.NamespacedName{Name: commonSpec.GetServerSSLCertSecretName(), Namespace: clusterNamespace}
-	}
+	secretNamespacedName := types.NamespacedName{Name: pkicommon.ListenerServerCertSecretName(clusterName, commonSpec), Namespace: clusterNamespace}
 	serverSecret := &corev1.Secret{}
 	if err := client.Get(context.TODO(), secretNamespacedName, serverSecret); err != nil {
 		if apierrors.IsNotFound(err) && commonSpec.GetServerSSLCertSecretName() == "" {
@@ -744,7 +1005,7 @@ func (r *Reconciler) getServerPasswordKeysAndUsers() (map[string]string, []strin
 		if iListener.Type == banzaiv1beta1.SecurityProtocolSSL {
 			// This implementation logic gets the generated ssl secret only once even
 			// if multiple listener use the generated one, because they share the same.
-			if globKeyPass == "" || iListener.GetServerSSLCertSecretName() != "" {
+			if globKeyPass == "" || iListener.HasOwnServerCertificate() {
 				// get the appropriate secret: the generated as default or the custom if its specified
 				serverSecret, err = getListenerSSLCertSecret(r.Client, iListener.CommonListenerSpec, r.KafkaCluster.Name, r.KafkaCluster.Namespace)
 				if err != nil {
@@ -755,7 +1016,7 @@ func (r *Reconciler) getServerPasswordKeysAndUsers() (map[string]string, []strin
 			}
 
 			// Set the globKeyPass if there is no custom server cert present
-			if r.KafkaCluster.Spec.ListenersConfig.SSLSecrets != nil && iListener.GetServerSSLCertSecretName() == "" {
+			if r.KafkaCluster.Spec.ListenersConfig.SSLSecrets != nil && !iListener.HasOwnServerCertificate() {
 				if globKeyPass == "" {
 					globKeyPass = string(serverSecret.Data[v1alpha1.PasswordKey])
 				}
@@ -776,7 +1037,7 @@ func (r *Reconciler) getServerPasswordKeysAndUsers() (map[string]string, []strin
 	// Same as at the internalListeners except we dont need to collect Common Names from certificates.
 	for _, eListener := range r.KafkaCluster.Spec.ListenersConfig.ExternalListeners {
 		if eListener.Type == banzaiv1beta1.SecurityProtocolSSL {
-			if globKeyPass == "" || eListener.GetServerSSLCertSecretName() != "" {
+			if globKeyPass == "" || eListener.HasOwnServerCertificate() {
 				serverSecret, err = getListenerSSLCertSecret(r.Client, eListener.CommonListenerSpec, r.KafkaCluster.Name, r.KafkaCluster.Namespace)
 				if err != nil {
 					return nil, nil, err
@@ -784,7 +1045,7 @@ func (r *Reconciler) getServerPasswordKeysAndUsers() (map[string]string, []strin
 				pair[eListener.Name] = string(serverSecret.Data[v1alpha1.PasswordKey])
 			}
 
-			if r.KafkaCluster.Spec.ListenersConfig.SSLSecrets != nil && eListener.GetServerSSLCertSecretName() == "" {
+			if r.KafkaCluster.Spec.ListenersConfig.SSLSecrets != nil && !eListener.HasOwnServerCertificate() {
 				if globKeyPass == "" {
 					globKeyPass = string(serverSecret.Data[v1alpha1.PasswordKey])
 				}
@@ -806,6 +1067,12 @@ func (r *Reconciler) getPasswordKeysAndSuperUsers() (clientPass string, serverPa
 	if superUser != "" {
 		superUsers = append(superUsers, superUser)
 	}
+	// The super users have to be specified the same way as the brokers resolve the principals of the certificates
+	for i, su := range superUsers {
+		if superUsers[i], err = kafka.GetPrincipalNameForDistinguishedName(r.KafkaCluster, su); err != nil {
+			return "", nil, nil, errors.WrapIfWithDetails(err, "failed to map super user certificate to principal name", "distinguishedName", su)
+		}
+	}
 	return clientPass, serverPasses, superUsers, nil
 }
 
@@ -834,9 +1101,16 @@ func (r *Reconciler) reconcileKafkaPod(log logr.Logger, desiredPod *corev1.Pod,
 			return errors.WrapIf(err, "could not apply last state to annotation")
 		}
 
-		if err := r.Create(context.TODO(), desiredPod); err != nil {
+		if err := r.Create(context.TODO(), desiredPod, client.FieldOwner(brokerPodFieldManager)); err != nil {
 			return errorfactory.New(errorfactory.APIFailure{}, err, "creating resource failed", "kind", desiredType)
 		}
+		if _, ok := r.KafkaCluster.Status.BrokersState[desiredPod.Labels[banzaiv1beta1.BrokerIdLabelKey]]; ok {
+			k8sutil.RecordEvent(r.Recorder, r.KafkaCluster, corev1.EventTypeNormal, brokerPodCreatedEventReason,
+				"the pod %s of broker %s has been created", desiredPod.Name, desiredPod.Labels[banzaiv1beta1.BrokerIdLabelKey])
+		} else {
+			k8sutil.RecordEvent(r.Recorder, r.KafkaCluster, corev1.EventTypeNormal, brokerAddedEventReason,
+				"broker %s has been added to the cluster", desiredPod.Labels[banzaiv1beta1.BrokerIdLabelKey])
+		}
 		// Update status what externalListener configs are in use
 		var externalConfigNames banzaiv1beta1.ExternalListenerConfigNames
 		if len(bConfig.BrokerIngressMapping) > 0 {
@@ -892,6 +1166,20 @@ func (r *Reconciler) reconcileKafkaPod(log logr.Logger, desiredPod *corev1.Pod,
 	case len(podList.Items) == 1:
 		currentPod = podList.Items[0].DeepCopy()
 		brokerId := currentPod.Labels[banzaiv1beta1.BrokerIdLabelKey]
+		lostNodeReason, err := r.brokerPodLostNode(currentPod)
+		if err != nil {
+			return errorfactory.New(errorfactory.APIFailure{}, err, "checking the node of the broker pod failed", "kind", desiredType)
+		}
+		if lostNodeReason != "" {
+			return r.forceDeleteBrokerPod(log, currentPod, lostNodeReason)
+		}
+		rescheduled, err := r.rescheduleUnschedulableBroker(log, currentPod)
+		if err != nil {
+			return err
+		}
+		if rescheduled {
+			return nil
+		}
 		if _, ok := r.KafkaCluster.Status.BrokersState[brokerId]; ok {
 			if currentPod.Spec.NodeName == "" {
 				log.Info(fmt.Sprintf("pod for brokerId %s does not scheduled to node yet", brokerId))
@@ -936,6 +1224,47 @@ func (r *Reconciler) updateStatusWithDockerImageAndVersion(brokerId int32, broke
 	return nil
 }
 
+// checkKafkaVersion refuses to roll out a Kafka version the broker can not run or be upgraded to: Kafka 4.x
+// requires KRaft mode and a broker running Kafka 3.3 or later
+func (r *Reconciler) checkKafkaVersion(broker banzaiv1beta1.Broker, brokerConfig *banzaiv1beta1.BrokerConfig) error {
+	version, ok := kafka.BrokerKafkaVersion(brokerConfig, r.KafkaCluster.Spec)
+	if !ok || !kafka.IsKRaftOnlyVersion(version) {
+		return nil
+	}
+	if !r.KafkaCluster.Spec.KRaftMode {
+		return errorfactory.New(errorfactory.FatalReconcileError{}, errors.New("ZooKeeper mode is not supported"),
+			"Kafka version requires KRaft mode", "version", version.String(), banzaiv1beta1.BrokerIdLabelKey, broker.Id)
+	}
+	currentVersion := r.KafkaCluster.Status.BrokersState[strconv.Itoa(int(broker.Id))].Version
+	if err := kafka.CheckKRaftOnlyUpgrade(currentVersion, version); err != nil {
+		return errorfactory.New(errorfactory.FatalReconcileError{}, err, "unsupported Kafka upgrade path", banzaiv1beta1.BrokerIdLabelKey, broker.Id)
+	}
+	return nil
+}
+
+// sharesReplicasWithPods returns true if the broker of the given pod hosts a replica of a partition together with the
+// broker of any of the other pods
+func sharesReplicasWithPods(kClient kafkaclient.KafkaClient, pod *corev1.Pod, otherPods []corev1.Pod) (bool, error) {
+	brokerID, err := strconv.ParseInt(pod.Labels[banzaiv1beta1.BrokerIdLabelKey], 10, 32)
+	if err != nil {
+		return false, errors.WrapIfWithDetails(err, "could not parse broker id", "pod", pod.Name)
+	}
+	replicaPeers, err := kClient.ReplicaPeers(int32(brokerID))
+	if err != nil {
+		return false, err
+	}
+	for _, otherPod := range otherPods {
+		otherBrokerID, err := strconv.ParseInt(otherPod.Labels[banzaiv1beta1.BrokerIdLabelKey], 10, 32)
+		if err != nil {
+			return false, errors.WrapIfWithDetails(err, "could not parse broker id", "pod", otherPod.Name)
+		}
+		if slices.Contains(replicaPeers, int32(otherBrokerID)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 //gocyclo:ignore
 func (r *Reconciler) handleRollingUpgrade(log logr.Logger, desiredPod, currentPod *corev1.Pod, desiredType reflect.Type) error {
 	// Since toleration does not support patchStrategy:"merge,retainKeys",
@@ -960,13 +1289,15 @@ func (r *Reconciler) handleRollingUpgrade(log logr.Logger, desiredPod, currentPo
 	case r.isPodTainted(log, currentPod):
 		log.Info("pod has tainted labels, deleting it", "pod", currentPod)
 	case patchResult.IsEmpty():
-		if !k8sutil.IsPodContainsTerminatedContainer(currentPod) &&
-			r.KafkaCluster.Status.BrokersState[currentPod.Labels[banzaiv1beta1.BrokerIdLabelKey]].ConfigurationState == banzaiv1beta1.ConfigInSync &&
-			!k8sutil.IsPodContainsEvictedContainer(currentPod) &&
-			!k8sutil.IsPodContainsShutdownContainer(currentPod) {
+		if r.isBrokerPodRunningDesiredConfig(currentPod) {
 			log.V(1).Info("resource is in sync")
 			return nil
 		}
+	case isMetadataOnlyPatch(patchResult.Patch) && r.isBrokerPodRunningDesiredConfig(currentPod):
+		if err := patch.DefaultAnnotator.SetLastAppliedAnnotation(desiredPod); err != nil {
+			return errors.WrapIf(err, "could not apply last state to annotation")
+		}
+		return r.applyBrokerPodMetadata(log, desiredPod, currentPod)
 	default:
 		log.V(1).Info("kafka pod resource diffs",
 			"patch", string(patchResult.Patch),
@@ -979,14 +1310,30 @@ func (r *Reconciler) handleRollingUpgrade(log logr.Logger, desiredPod, currentPo
 		return errors.WrapIf(err, "could not apply last state to annotation")
 	}
 
+	// a plan records every broker to restart instead of restarting them one by one
+	if plan, planning := k8sutil.PlanFromClient(r.Client); planning {
+		plan.RecordBrokerRestart(currentPod.Labels[banzaiv1beta1.BrokerIdLabelKey])
+		return nil
+	}
+
 	if !k8sutil.IsPodContainsTerminatedContainer(currentPod) {
 		if r.KafkaCluster.Status.State != banzaiv1beta1.KafkaClusterRollingUpgrading {
+			// a rolling upgrade only starts in a maintenance window, once started it is completed
+			if r.deferToMaintenanceWindow(log, fmt.Sprintf("rolling restart of broker %s", currentPod.Labels[banzaiv1beta1.BrokerIdLabelKey])) {
+				return nil
+			}
 			if err := k8sutil.UpdateCRStatus(r.Client, r.KafkaCluster, banzaiv1beta1.KafkaClusterRollingUpgrading, log); err != nil {
 				return errorfactory.New(errorfactory.StatusUpdateError{}, err, "setting state to rolling upgrade failed")
 			}
+			k8sutil.RecordEvent(r.Recorder, r.KafkaCluster, corev1.EventTypeNormal, rollingUpgradeStartedEventReason,
+				"rolling upgrade started to apply the changes of broker %s", currentPod.Labels[banzaiv1beta1.BrokerIdLabelKey])
 		}
 
 		if r.KafkaCluster.Status.State == banzaiv1beta1.KafkaClusterRollingUpgrading {
+			if err := r.checkRollingUpgradePaused(log); err != nil {
+				return err
+			}
+
 			// Check if any kafka pod is in terminating or pending state
 			podList := &corev1.PodList{}
 			matchingLabels := client.MatchingLabels(apiutil.LabelsForKafka(r.KafkaCluster.Name))
@@ -1004,7 +1351,8 @@ func (r *Reconciler) handleRollingUpgrade(log logr.Logger, desiredPod, currentPo
 			if len(terminatingOrPendingPods) >= r.KafkaCluster.Spec.RollingUpgradeConfig.ConcurrentBrokerRestartCountPerRack {
 				return errorfactory.New(errorfactory.ReconcileRollingUpgrade{}, errors.New(strconv.Itoa(r.KafkaCluster.Spec.RollingUpgradeConfig.ConcurrentBrokerRestartCountPerRack)+" pod(s) is still terminating or creating"), "rolling upgrade in progress")
 			}
-			if r.KafkaCluster.Spec.RollingUpgradeConfig.ConcurrentBrokerRestartCountPerRack > 1 && len(terminatingOrPendingPods) > 0 {
+			if r.KafkaCluster.Spec.RollingUpgradeConfig.ConcurrentBrokerRestartCountPerRack > 1 && len(terminatingOrPendingPods) > 0 &&
+				!r.KafkaCluster.Spec.RollingUpgradeConfig.VerifyNoSharedReplicas {
 				err = r.checkCCRackAwareDistributionGoal()
 				if err != nil {
 					return err
@@ -1023,6 +1371,16 @@ func (r *Reconciler) handleRollingUpgrade(log logr.Logger, desiredPod, currentPo
 				return errorfactory.New(errorfactory.BrokersUnreachable{}, err, "could not connect to kafka brokers")
 			}
 			defer close()
+			if r.KafkaCluster.Spec.RollingUpgradeConfig.ConcurrentBrokerRestartCountPerRack > 1 && len(terminatingOrPendingPods) > 0 &&
+				r.KafkaCluster.Spec.RollingUpgradeConfig.VerifyNoSharedReplicas {
+				sharing, err := sharesReplicasWithPods(kClient, currentPod, terminatingOrPendingPods)
+				if err != nil {
+					return errors.WrapIf(err, "could not check replicas shared with restarting brokers")
+				}
+				if sharing {
+					return errorfactory.New(errorfactory.ReconcileRollingUpgrade{}, errors.New("broker shares partition replicas with a terminating or creating broker"), "rolling upgrade in progress")
+				}
+			}
 			allOfflineReplicas, err := kClient.AllOfflineReplicas()
 			if err != nil {
 				return errors.WrapIf(err, "health check failed")
@@ -1045,16 +1403,40 @@ func (r *Reconciler) handleRollingUpgrade(log logr.Logger, desiredPod, currentPo
 				impactedReplicas[brokerID] = struct{}{}
 			}
 			errorCount += len(impactedReplicas)
+			// The replicas of the brokers being restarted may not be reported as out of sync by the cluster metadata
+			// yet, consult Prometheus before adding another broker to the concurrent restart batch
+			if len(terminatingOrPendingPods) > 0 && r.KafkaCluster.Spec.RollingUpgradeConfig.PrometheusReplicationCheck != nil {
+				failureCount, err := r.prometheusReplicationFailureCount(log)
+				if err != nil {
+					return err
+				}
+				errorCount += failureCount
+			}
 			if errorCount >= r.KafkaCluster.Spec.RollingUpgradeConfig.FailureThreshold {
 				return errorfactory.New(errorfactory.ReconcileRollingUpgrade{}, errors.New("cluster is not healthy"), "rolling upgrade in progress")
 			}
 
+			// The previously restarted broker has returned and caught up, move the leadership of its partitions back
+			// to it before restarting the next one
+			if len(terminatingOrPendingPods) == 0 && len(impactedReplicas) == 0 {
+				elected, err := kClient.ElectPreferredLeaders()
+				if err != nil {
+					log.Error(err, "preferred leader election failed")
+				} else if elected > 0 {
+					log.Info("preferred leaders elected", "partitions", elected)
+				}
+			}
+
 			// If multiple concurrent restarts and broker failures allowed, restart only brokers from the same AZ
 			if r.KafkaCluster.Spec.RollingUpgradeConfig.ConcurrentBrokerRestartCountPerRack > 1 && r.KafkaCluster.Spec.RollingUpgradeConfig.FailureThreshold > 1 {
 				if r.existsFailedBrokerFromAnotherRack(currentPodAz, impactedReplicas, kafkaBrokerAvailabilityZoneMap) {
 					return errorfactory.New(errorfactory.ReconcileRollingUpgrade{}, errors.New("broker is not healthy from another AZ"), "rolling upgrade in progress")
 				}
 			}
+
+			if err := r.runRestartHooks(log, currentPod, terminatingOrPendingPods); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -1063,6 +1445,19 @@ func (r *Reconciler) handleRollingUpgrade(log logr.Logger, desiredPod, currentPo
 		return errorfactory.New(errorfactory.APIFailure{}, err, "deleting resource failed", "kind", desiredType)
 	}
 
+	if r.KafkaCluster.Spec.RollingUpgradeConfig.PauseAfterCanary && !k8sutil.IsPodContainsTerminatedContainer(currentPod) {
+		if err := r.recordRollingUpgradeRestart(log, currentPod.Labels[banzaiv1beta1.BrokerIdLabelKey]); err != nil {
+			return err
+		}
+	}
+
+	if len(r.KafkaCluster.Spec.RollingUpgradeConfig.GetPostRestartHooks()) > 0 {
+		if err := k8sutil.UpdateBrokerStatus(r.Client, []string{currentPod.Labels[banzaiv1beta1.BrokerIdLabelKey]}, r.KafkaCluster,
+			banzaiv1beta1.PostRestartHooksPending, log); err != nil {
+			return errorfactory.New(errorfactory.StatusUpdateError{}, err, "could not update restart hooks state")
+		}
+	}
+
 	// Print terminated container's statuses
 	if k8sutil.IsPodContainsTerminatedContainer(currentPod) {
 		for _, containerState := range currentPod.Status.ContainerStatuses {
@@ -1073,9 +1468,105 @@ func (r *Reconciler) handleRollingUpgrade(log logr.Logger, desiredPod, currentPo
 		}
 	}
 	log.Info("broker pod deleted", "pod", currentPod.GetName(), banzaiv1beta1.BrokerIdLabelKey, currentPod.Labels[banzaiv1beta1.BrokerIdLabelKey])
+	k8sutil.RecordEvent(r.Recorder, r.KafkaCluster, corev1.EventTypeNormal, brokerRestartedEventReason,
+		"the pod %s of broker %s has been deleted to be recreated with the desired configuration", currentPod.GetName(),
+		currentPod.Labels[banzaiv1beta1.BrokerIdLabelKey])
 	return nil
 }
 
+// checkRollingUpgradePaused returns an error while the rolling upgrade is paused after the restart of the canary broker.
+// The rolling upgrade is resumed once the KafkaCluster is annotated with ResumeRollingUpgradeAnnotationKey, the
+// annotation is removed first so that it does not resume the pause of the next rolling upgrade.
+func (r *Reconciler) checkRollingUpgradePaused(log logr.Logger) error {
+	progress := r.KafkaCluster.Status.RollingUpgrade.Progress
+	// unsetting pauseAfterCanary resumes the rolling upgrade as well
+	if progress == nil || !progress.Paused || !r.KafkaCluster.Spec.RollingUpgradeConfig.PauseAfterCanary {
+		return nil
+	}
+	if _, ok := r.KafkaCluster.GetAnnotations()[banzaiv1beta1.ResumeRollingUpgradeAnnotationKey]; !ok {
+		return errorfactory.New(errorfactory.ReconcileRollingUpgrade{}, errors.New("rolling upgrade is paused after the restart of the canary broker"),
+			"rolling upgrade paused", "canaryBrokerId", progress.CanaryBrokerID)
+	}
+
+	clusterPatch := client.MergeFrom(r.KafkaCluster.DeepCopy())
+	delete(r.KafkaCluster.Annotations, banzaiv1beta1.ResumeRollingUpgradeAnnotationKey)
+	if err := r.Patch(context.TODO(), r.KafkaCluster, clusterPatch); err != nil {
+		return errorfactory.New(errorfactory.APIFailure{}, err, "could not remove the annotation resuming the rolling upgrade")
+	}
+	resumed := progress.DeepCopy()
+	resumed.Paused = false
+	if err := k8sutil.UpdateCRStatus(r.Client, r.KafkaCluster, resumed, log); err != nil {
+		return errorfactory.New(errorfactory.StatusUpdateError{}, err, "could not resume the rolling upgrade")
+	}
+	log.Info("rolling upgrade resumed after the restart of the canary broker", "canaryBrokerId", resumed.CanaryBrokerID)
+	return nil
+}
+
+// recordRollingUpgradeRestart records the restart of the broker in the progress of the rolling upgrade, the rolling
+// upgrade is paused after the restart of the first (canary) broker
+func (r *Reconciler) recordRollingUpgradeRestart(log logr.Logger, brokerID string) error {
+	progress := &banzaiv1beta1.RollingUpgradeProgress{}
+	if r.KafkaCluster.Status.RollingUpgrade.Progress != nil {
+		progress = r.KafkaCluster.Status.RollingUpgrade.Progress.DeepCopy()
+	}
+	if !slices.Contains(progress.RestartedBrokers, brokerID) {
+		progress.RestartedBrokers = append(progress.RestartedBrokers, brokerID)
+	}
+	if progress.CanaryBrokerID == "" {
+		progress.CanaryBrokerID = brokerID
+		progress.Paused = true
+		log.Info("canary broker restarted, the rolling upgrade is paused until it is resumed", banzaiv1beta1.BrokerIdLabelKey, brokerID,
+			"annotation", banzaiv1beta1.ResumeRollingUpgradeAnnotationKey)
+	}
+	if err := k8sutil.UpdateCRStatus(r.Client, r.KafkaCluster, progress, log); err != nil {
+		return errorfactory.New(errorfactory.StatusUpdateError{}, err, "could not update rolling upgrade progress")
+	}
+	return nil
+}
+
+// runRestartHooks runs the pending post-restart hooks of the previously restarted brokers which are back, then the
+// pre-restart hooks of the broker of the pod about to be restarted
+func (r *Reconciler) runRestartHooks(log logr.Logger, currentPod *corev1.Pod, terminatingOrPendingPods []corev1.Pod) error {
+	if r.KafkaCluster.Spec.RollingUpgradeConfig.Hooks == nil {
+		return nil
+	}
+	runner := restarthooks.NewRunner(r.Client, r.DirectClient)
+	restarting := make(map[string]struct{}, len(terminatingOrPendingPods))
+	for _, pod := range terminatingOrPendingPods {
+		restarting[pod.Labels[banzaiv1beta1.BrokerIdLabelKey]] = struct{}{}
+	}
+	err := runner.RunPendingPostRestart(context.TODO(), log, r.KafkaCluster, func(brokerID string) bool {
+		_, ok := restarting[brokerID]
+		return ok
+	})
+	if err != nil {
+		return err
+	}
+	return runner.Run(context.TODO(), log, r.KafkaCluster, restarthooks.PreRestart, currentPod.Labels[banzaiv1beta1.BrokerIdLabelKey])
+}
+
+// prometheusReplicationFailureCount returns the number of series reported by the Prometheus replication check. The
+// check fails closed: the rolling upgrade does not proceed while Prometheus cannot be queried.
+func (r *Reconciler) prometheusReplicationFailureCount(log logr.Logger) (int, error) {
+	check := r.KafkaCluster.Spec.RollingUpgradeConfig.PrometheusReplicationCheck
+	query := strings.NewReplacer(
+		"$(CLUSTER_NAME)", r.KafkaCluster.Name,
+		"$(NAMESPACE)", r.KafkaCluster.Namespace,
+	).Replace(check.GetQuery())
+	result, err := prometheus.Query(context.TODO(), http.DefaultClient, check.PrometheusURL, query, check.GetTimeout())
+	if err != nil {
+		return 0, errorfactory.New(errorfactory.ReconcileRollingUpgrade{}, err, "could not query Prometheus for under-replicated partitions")
+	}
+	count, err := result.SeriesCount()
+	if err != nil {
+		return 0, errorfactory.New(errorfactory.ReconcileRollingUpgrade{}, err, "could not query Prometheus for under-replicated partitions")
+	}
+	if count > 0 {
+		log.Info("Prometheus reports under-replicated or offline partitions", "series", count)
+	}
+	return count, nil
+}
+
 func (r *Reconciler) checkCCRackAwareDistributionGoal() error {
 	cruiseControlURL := scale.CruiseControlURLFromKafkaCluster(r.KafkaCluster)
 	cc, err := r.CruiseControlScalerFactory(context.TODO(), r.KafkaCluster)
@@ -1122,6 +1613,15 @@ func (r *Reconciler) existsTerminatingPodFromAnotherAz(currentPodAz string, term
 	return false
 }
 
+// isBrokerPodRunningDesiredConfig returns true when the containers of the broker pod are running with the desired
+// configuration of the broker
+func (r *Reconciler) isBrokerPodRunningDesiredConfig(pod *corev1.Pod) bool {
+	return !k8sutil.IsPodContainsTerminatedContainer(pod) &&
+		r.KafkaCluster.Status.BrokersState[pod.Labels[banzaiv1beta1.BrokerIdLabelKey]].ConfigurationState == banzaiv1beta1.ConfigInSync &&
+		!k8sutil.IsPodContainsEvictedContainer(pod) &&
+		!k8sutil.IsPodContainsShutdownContainer(pod)
+}
+
 // Checks for match between pod labels and TaintedBrokersSelector
 func (r *Reconciler) isPodTainted(log logr.Logger, pod *corev1.Pod) bool {
 	selector, err := metav1.LabelSelectorAsSelector(r.KafkaCluster.Spec.TaintedBrokersSelector)
@@ -1316,11 +1816,24 @@ func handleDiskRemoval(ctx context.Context, pvcList *corev1.PersistentVolumeClai
 			ccVolumeState := volumeStateStatus.CruiseControlVolumeState
 			switch {
 			case ccVolumeState.IsDiskRemovalSucceeded():
+				// the broker is rolled without the volume first, so Kafka does not fail over the log dir of the PVC
+				// deleted under the running broker
+				mounted, err := r.isPvcMountedByBroker(ctx, brokerId, pvc.Name)
+				if err != nil {
+					return false, err
+				}
+				if mounted {
+					if ccVolumeState != banzaiv1beta1.GracefulDiskRemovalRestartPending {
+						brokerVolumesState[mountPathToRemove] = banzaiv1beta1.VolumeState{CruiseControlVolumeState: banzaiv1beta1.GracefulDiskRemovalRestartPending}
+					}
+					log.Info("Disk removal succeeded, waiting for the broker to be restarted without the volume", "brokerId", brokerId, "mountPath", mountPathToRemove)
+					continue
+				}
 				if err := r.Delete(ctx, &pvc); err != nil {
 					return false, errorfactory.New(errorfactory.APIFailure{}, err, "deleting resource failed", "kind", desiredType)
 				}
 				log.Info("resource deleted")
-				err := k8sutil.DeleteVolumeStatus(r.Client, brokerId, mountPathToRemove, r.KafkaCluster, log)
+				err = k8sutil.DeleteVolumeStatus(r.Client, brokerId, mountPathToRemove, r.KafkaCluster, log)
 				if err != nil {
 					return false, errors.WrapIfWithDetails(err, "could not delete volume status for broker volume", "brokerId", brokerId, "mountPath", mountPathToRemove)
 				}
@@ -1340,6 +1853,25 @@ func handleDiskRemoval(ctx context.Context, pvcList *corev1.PersistentVolumeClai
 	return waitForDiskRemovalToFinish, nil
 }
 
+// isPvcMountedByBroker returns true if a pod of the broker mounts the PVC
+func (r *Reconciler) isPvcMountedByBroker(ctx context.Context, brokerId string, pvcName string) (bool, error) {
+	pods := &corev1.PodList{}
+	err := r.List(ctx, pods, client.InNamespace(r.KafkaCluster.GetNamespace()), client.MatchingLabels(
+		apiutil.MergeLabels(apiutil.LabelsForKafka(r.KafkaCluster.Name), map[string]string{banzaiv1beta1.BrokerIdLabelKey: brokerId}),
+	))
+	if err != nil {
+		return false, errorfactory.New(errorfactory.APIFailure{}, err, "listing broker pods failed", banzaiv1beta1.BrokerIdLabelKey, brokerId)
+	}
+	for _, pod := range pods.Items {
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == pvcName {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
 // GetBrokersWithPendingOrRunningCCTask returns list of brokers that are either waiting for CC
 // to start executing a broker task (add broker, remove broker, etc) or CC already running a task for it.
 func GetBrokersWithPendingOrRunningCCTask(kafkaCluster *banzaiv1beta1.KafkaCluster) []int32 {
@@ -1411,12 +1943,32 @@ func (r *Reconciler) getBrokerHost(log logr.Logger, defaultHost string, broker b
 		// TODO understand why this is not needed. Tests are failing when this is added
 		// portNumber = eListener.ContainerPort
 	case corev1.ServiceTypeLoadBalancer:
-		if eListener.TLSEnabled() {
+		if r.KafkaCluster.Spec.GetIngressControllerForListener(eListener) == gatewayapiutils.IngressControllerName {
+			// with TLSRoute the brokers are routed by SNI hostname on the anycast port of the Gateway
+			if iConfig.GatewayAPIConfig.GetRouteType() == banzaiv1beta1.GatewayAPIRouteTypeTLS {
+				brokerHost = iConfig.GatewayAPIConfig.GetBrokerHostname(broker.Id)
+				if brokerHost == "" {
+					return "", errors.New("brokerHostnameTemplate is not set in the gateway api config")
+				}
+				portNumber = eListener.GetAnyCastPort()
+			}
+		} else if eListener.TLSEnabled() {
 			brokerHost = iConfig.EnvoyConfig.GetBrokerHostname(broker.Id)
 			if brokerHost == "" {
 				return "", errors.New("brokerHostnameTemplate is not set in the ingress service settings")
 			}
 		}
+	case banzaiv1beta1.ServiceTypePerBrokerLoadBalancer:
+		brokerHost = eListener.PerBrokerLoadBalancerConfig.GetBrokerHostname(broker.Id)
+		if brokerHost == "" {
+			service, err := r.getPerBrokerLoadBalancerService(eListener.Name, broker.Id)
+			if err != nil {
+				return "", err
+			}
+			if brokerHost, err = getLoadBalancerIP(service); err != nil {
+				return "", err
+			}
+		}
 	case corev1.ServiceTypeExternalName:
 		return ":", errors.New("unsupported external listener access method")
 	}
@@ -1426,8 +1978,10 @@ func (r *Reconciler) getBrokerHost(log logr.Logger, defaultHost string, broker b
 func (r *Reconciler) createExternalListenerStatuses(log logr.Logger) (map[string]banzaiv1beta1.ListenerStatusList, error) {
 	extListenerStatuses := make(map[string]banzaiv1beta1.ListenerStatusList, len(r.KafkaCluster.Spec.ListenersConfig.ExternalListeners))
 	for _, eListener := range r.KafkaCluster.Spec.ListenersConfig.ExternalListeners {
+		// the brokers advertise the addresses of the previous access method until the new one is reachable
+		eListener = advertisedExternalListener(r.KafkaCluster, eListener)
 		// in case if external listener uses loadbalancer type of service and istioControlPlane is not specified than we skip this listener from status update. In this way this external listener will not be in the configmap.
-		if eListener.GetAccessMethod() == corev1.ServiceTypeLoadBalancer && r.KafkaCluster.Spec.GetIngressController() == istioingressutils.IngressControllerName && r.KafkaCluster.Spec.IstioControlPlane == nil {
+		if eListener.GetAccessMethod() == corev1.ServiceTypeLoadBalancer && r.KafkaCluster.Spec.GetIngressControllerForListener(eListener) == istioingressutils.IngressControllerName && r.KafkaCluster.Spec.IstioControlPlane == nil {
 			continue
 		}
 		var host string
@@ -1437,6 +1991,10 @@ func (r *Reconciler) createExternalListenerStatuses(log logr.Logger) (map[string
 		if err != nil {
 			return nil, err
 		}
+		// the Gateway API implementation owns the load balancer of the Gateway, its address is taken from the Gateway
+		isGatewayAPI := r.KafkaCluster.Spec.GetIngressControllerForListener(eListener) == gatewayapiutils.IngressControllerName
+		// the brokers are exposed through the service of an existing ingress-nginx controller
+		isNginx := r.KafkaCluster.Spec.GetIngressControllerForListener(eListener) == nginxutils.IngressControllerName
 		listenerStatusList := make(banzaiv1beta1.ListenerStatusList, 0, len(r.KafkaCluster.Spec.Brokers)+1)
 		for iConfigName, iConfig := range ingressConfigs {
 			if !util.IsIngressConfigInUse(iConfigName, defaultControllerName, r.KafkaCluster, log) {
@@ -1444,8 +2002,26 @@ func (r *Reconciler) createExternalListenerStatuses(log logr.Logger) (map[string
 			}
 			if iConfig.HostnameOverride != "" {
 				host = iConfig.HostnameOverride
+			} else if eListener.GetAccessMethod() == corev1.ServiceTypeLoadBalancer && isGatewayAPI {
+				host, err = getGatewayAddress(r.Client, r.KafkaCluster, eListener, iConfigName, iConfig)
+				if err != nil {
+					return nil, errors.WrapIfWithDetails(err, "could not extract address from Gateway", "externalListenerName", eListener.Name)
+				}
+			} else if eListener.GetAccessMethod() == corev1.ServiceTypeLoadBalancer && isNginx {
+				foundLBService = &corev1.Service{}
+				err = r.Client.Get(context.TODO(), types.NamespacedName{
+					Name:      iConfig.NginxIngressConfig.GetControllerServiceName(),
+					Namespace: iConfig.NginxIngressConfig.GetControllerNamespace(),
+				}, foundLBService)
+				if err != nil {
+					return nil, errors.WrapIfWithDetails(err, "could not get ingress-nginx controller service", "externalListenerName", eListener.Name)
+				}
+				host, err = getLoadBalancerIP(foundLBService)
+				if err != nil {
+					return nil, errors.WrapIfWithDetails(err, "could not extract IP from ingress-nginx controller service", "externalListenerName", eListener.Name)
+				}
 			} else if eListener.GetAccessMethod() == corev1.ServiceTypeLoadBalancer {
-				foundLBService, err = getServiceFromExternalListener(r.Client, r.KafkaCluster, eListener.Name, iConfigName)
+				foundLBService, err = getServiceFromExternalListener(r.Client, r.KafkaCluster, eListener, iConfigName)
 				if err != nil {
 					return nil, errors.WrapIfWithDetails(err, "could not get service corresponding to the external listener", "externalListenerName", eListener.Name)
 				}
@@ -1457,18 +2033,22 @@ func (r *Reconciler) createExternalListenerStatuses(log logr.Logger) (map[string
 			}
 
 			// optionally add all brokers service to the top of the list
-			if eListener.GetAccessMethod() != corev1.ServiceTypeNodePort {
-				if foundLBService == nil {
-					foundLBService, err = getServiceFromExternalListener(r.Client, r.KafkaCluster, eListener.Name, iConfigName)
+			if eListener.GetAccessMethod() != corev1.ServiceTypeNodePort && eListener.GetAccessMethod() != banzaiv1beta1.ServiceTypePerBrokerLoadBalancer {
+				var allBrokerPort int32 = 0
+				if isGatewayAPI || isNginx {
+					allBrokerPort = eListener.GetAnyCastPort()
+				} else if foundLBService == nil {
+					foundLBService, err = getServiceFromExternalListener(r.Client, r.KafkaCluster, eListener, iConfigName)
 					if err != nil {
 						return nil, errors.WrapIfWithDetails(err, "could not get service corresponding to the external listener", "externalListenerName", eListener.Name)
 					}
 				}
-				var allBrokerPort int32 = 0
-				for _, port := range foundLBService.Spec.Ports {
-					if port.Name == "tcp-all-broker" {
-						allBrokerPort = port.Port
-						break
+				if foundLBService != nil {
+					for _, port := range foundLBService.Spec.Ports {
+						if port.Name == "tcp-all-broker" {
+							allBrokerPort = port.Port
+							break
+						}
 					}
 				}
 				if allBrokerPort == 0 {
@@ -1544,6 +2124,21 @@ func (r *Reconciler) getK8sAssignedNodeport(log logr.Logger, eListenerName strin
 	return nodePort, nil
 }
 
+// getPerBrokerLoadBalancerService returns the LoadBalancer Service of the broker for the external listener
+func (r *Reconciler) getPerBrokerLoadBalancerService(eListenerName string, brokerId int32) (*corev1.Service, error) {
+	service := &corev1.Service{}
+	serviceName := fmt.Sprintf(kafka.PerBrokerLoadBalancerServiceTemplate, r.KafkaCluster.GetName(), brokerId, eListenerName)
+	err := r.Get(context.TODO(), types.NamespacedName{Name: serviceName, Namespace: r.KafkaCluster.GetNamespace()}, service)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errorfactory.New(errorfactory.ResourceNotReady{}, err, "LoadBalancer service of the broker is not created yet",
+				"serviceName", serviceName)
+		}
+		return nil, errors.WrapIfWithDetails(err, "could not get LoadBalancer service of the broker", "serviceName", serviceName)
+	}
+	return service, nil
+}
+
 func (r *Reconciler) getK8sAssignedNodeAddress(brokerId int32, nodeAddressType string) (string, error) {
 	podList := &corev1.PodList{}
 	if err := r.List(context.TODO(), podList,
@@ -1600,6 +2195,35 @@ func (r *Reconciler) determineControllerId() (int32, error) {
 	return controllerID, nil
 }
 
+// determinePartitionLeaderCounts returns the number of partitions led by the brokers of the current cluster
+func (r *Reconciler) determinePartitionLeaderCounts() (map[int32]int, error) {
+	kClient, close, err := r.kafkaClientProvider.NewFromCluster(r.Client, r.KafkaCluster)
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not create Kafka client, thus could not determine partition leaders")
+	}
+	defer close()
+
+	return kClient.PartitionLeaderCounts()
+}
+
+// isRollingRestartPending returns true if the brokers of the cluster are being restarted one by one or are known to
+// need a restart, which is when the reconcile order of the brokers matters
+func isRollingRestartPending(cluster *banzaiv1beta1.KafkaCluster) bool {
+	if cluster.Status.State == banzaiv1beta1.KafkaClusterRollingUpgrading {
+		return true
+	}
+	if upgrade := cluster.Status.VersionUpgrade; upgrade != nil &&
+		(upgrade.Phase == banzaiv1beta1.KafkaVersionUpgradePhaseBinariesUpgrading || upgrade.Phase == banzaiv1beta1.KafkaVersionUpgradePhaseProtocolUpgrading) {
+		return true
+	}
+	for _, brokerState := range cluster.Status.BrokersState {
+		if brokerState.ConfigurationState == banzaiv1beta1.ConfigOutOfSync {
+			return true
+		}
+	}
+	return false
+}
+
 func getPodsInTerminatingOrPendingState(items []corev1.Pod) []corev1.Pod {
 	var pods []corev1.Pod
 	for _, pod := range items {
@@ -1622,32 +2246,32 @@ func (r *Reconciler) getBrokerAz(pod *corev1.Pod, kafkaBrokerAvailabilityZoneMap
 }
 
 func getServiceFromExternalListener(client client.Client, cluster *banzaiv1beta1.KafkaCluster,
-	eListenerName string, ingressConfigName string) (*corev1.Service, error) {
+	eListener banzaiv1beta1.ExternalListenerConfig, ingressConfigName string) (*corev1.Service, error) {
 	foundLBService := &corev1.Service{}
 	var iControllerServiceName string
-	switch cluster.Spec.GetIngressController() {
+	switch cluster.Spec.GetIngressControllerForListener(eListener) {
 	case istioingressutils.IngressControllerName:
 		if ingressConfigName == util.IngressConfigGlobalName {
-			iControllerServiceName = fmt.Sprintf(istioingressutils.MeshGatewayNameTemplate, eListenerName, cluster.GetName())
+			iControllerServiceName = fmt.Sprintf(istioingressutils.MeshGatewayNameTemplate, eListener.Name, cluster.GetName())
 			iControllerServiceName = strings.ReplaceAll(iControllerServiceName, "_", "-")
 		} else {
-			iControllerServiceName = fmt.Sprintf(istioingressutils.MeshGatewayNameTemplateWithScope, eListenerName, ingressConfigName, cluster.GetName())
+			iControllerServiceName = fmt.Sprintf(istioingressutils.MeshGatewayNameTemplateWithScope, eListener.Name, ingressConfigName, cluster.GetName())
 			iControllerServiceName = strings.ReplaceAll(iControllerServiceName, "_", "-")
 		}
 	case envoyutils.IngressControllerName:
 		if ingressConfigName == util.IngressConfigGlobalName {
-			iControllerServiceName = fmt.Sprintf(envoyutils.EnvoyServiceName, eListenerName, cluster.GetName())
+			iControllerServiceName = fmt.Sprintf(envoyutils.EnvoyServiceName, eListener.Name, cluster.GetName())
 			iControllerServiceName = strings.ReplaceAll(iControllerServiceName, "_", "-")
 		} else {
-			iControllerServiceName = fmt.Sprintf(envoyutils.EnvoyServiceNameWithScope, eListenerName, ingressConfigName, cluster.GetName())
+			iControllerServiceName = fmt.Sprintf(envoyutils.EnvoyServiceNameWithScope, eListener.Name, ingressConfigName, cluster.GetName())
 			iControllerServiceName = strings.ReplaceAll(iControllerServiceName, "_", "-")
 		}
 	case contourutils.IngressControllerName:
 		if ingressConfigName == util.IngressConfigGlobalName {
-			iControllerServiceName = fmt.Sprintf(contourutils.ContourServiceName, eListenerName, cluster.GetName())
+			iControllerServiceName = fmt.Sprintf(contourutils.ContourServiceName, eListener.Name, cluster.GetName())
 			iControllerServiceName = strings.ReplaceAll(iControllerServiceName, "_", "-")
 		} else {
-			iControllerServiceName = fmt.Sprintf(contourutils.ContourServiceNameWithScope, eListenerName, ingressConfigName, cluster.GetName())
+			iControllerServiceName = fmt.Sprintf(contourutils.ContourServiceNameWithScope, eListener.Name, ingressConfigName, cluster.GetName())
 			iControllerServiceName = strings.ReplaceAll(iControllerServiceName, "_", "-")
 		}
 	}
@@ -1659,13 +2283,36 @@ func getServiceFromExternalListener(client client.Client, cluster *banzaiv1beta1
 	return foundLBService, nil
 }
 
+// getGatewayAddress returns the address assigned to the Gateway of the external listener by the Gateway API implementation
+func getGatewayAddress(client client.Client, cluster *banzaiv1beta1.KafkaCluster, eListener banzaiv1beta1.ExternalListenerConfig,
+	ingressConfigName string, ingressConfig banzaiv1beta1.IngressConfig) (string, error) {
+	gatewayName := util.GenerateEnvoyResourceName(gatewayapiutils.GatewayName, gatewayapiutils.GatewayNameWithScope,
+		eListener, ingressConfig, ingressConfigName, cluster.GetName())
+	gateway := &gatewayv1.Gateway{}
+	err := client.Get(context.TODO(), types.NamespacedName{Name: gatewayName, Namespace: cluster.GetNamespace()}, gateway)
+	if err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return "", errorfactory.New(errorfactory.ResourceNotReady{}, err, "gateway is not created yet", "gatewayName", gatewayName)
+		}
+		return "", errors.WrapIfWithDetails(err, "could not get Gateway", "gatewayName", gatewayName)
+	}
+	for _, address := range gateway.Status.Addresses {
+		if address.Value != "" {
+			return address.Value, nil
+		}
+	}
+	return "", errorfactory.New(errorfactory.LoadBalancerIPNotReady{}, errors.New("gateway address has not been assigned yet - waiting"), "trying", "gatewayName", gatewayName)
+}
+
 // reorderBrokers returns the KafkaCluster brokers list reordered for reconciliation such that:
 //   - the controller broker is reconciled last
+//   - the running brokers leading fewer partitions are reconciled first, so that a rolling upgrade moves the
+//     leadership of as few partitions as possible until the preferred leaders are elected again
 //   - prioritize missing broker pods where downscale operation has not been finished yet to give bigger chance to be scheduled and downscale operation to be continued
 //   - prioritize upscale in order to allow upscaling the cluster even when there is a stuck RU
 //   - prioritize missing broker pods to be able for escaping from offline partitions, not all replicas in sync which
 //     could stall RU flow
-func reorderBrokers(runningBrokers, boundPersistentVolumeClaims map[string]struct{}, desiredBrokers []banzaiv1beta1.Broker, brokersState map[string]banzaiv1beta1.BrokerState, controllerBrokerID int32, log logr.Logger) []banzaiv1beta1.Broker {
+func reorderBrokers(runningBrokers, boundPersistentVolumeClaims map[string]struct{}, desiredBrokers []banzaiv1beta1.Broker, brokersState map[string]banzaiv1beta1.BrokerState, controllerBrokerID int32, partitionLeaderCounts map[int32]int, log logr.Logger) []banzaiv1beta1.Broker {
 	brokersReconcilePriority := make(map[string]brokerReconcilePriority, len(desiredBrokers))
 	missingBrokerDownScaleRunning := make(map[string]struct{})
 	// logic for handling that case when a broker pod is removed before downscale operation completed
@@ -1714,6 +2361,10 @@ func reorderBrokers(runningBrokers, boundPersistentVolumeClaims map[string]struc
 		brokerID1 := fmt.Sprintf("%d", reorderedBrokers[i].Id)
 		brokerID2 := fmt.Sprintf("%d", reorderedBrokers[j].Id)
 
+		if brokersReconcilePriority[brokerID1] == nonControllerBrokerReconcilePriority &&
+			brokersReconcilePriority[brokerID2] == nonControllerBrokerReconcilePriority {
+			return partitionLeaderCounts[reorderedBrokers[i].Id] < partitionLeaderCounts[reorderedBrokers[j].Id]
+		}
 		return brokersReconcilePriority[brokerID1] < brokersReconcilePriority[brokerID2]
 	})
 
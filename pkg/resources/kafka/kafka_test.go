@@ -17,6 +17,9 @@ package kafka
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 	"time"
@@ -27,8 +30,12 @@ import (
 	"github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,6 +44,7 @@ import (
 	"github.com/banzaicloud/koperator/api/v1alpha1"
 	"github.com/banzaicloud/koperator/api/v1beta1"
 	controllerMocks "github.com/banzaicloud/koperator/controllers/tests/mocks"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
 	"github.com/banzaicloud/koperator/pkg/kafkaclient"
 	"github.com/banzaicloud/koperator/pkg/resources"
 	"github.com/banzaicloud/koperator/pkg/resources/kafka/mocks"
@@ -47,6 +55,7 @@ type PvcTestCase struct {
 	testName            string
 	brokersDesiredPvcs  map[string][]*corev1.PersistentVolumeClaim
 	existingPvcs        []*corev1.PersistentVolumeClaim
+	existingPods        []corev1.Pod
 	kafkaClusterSpec    v1beta1.KafkaClusterSpec
 	kafkaClusterStatus  v1beta1.KafkaClusterStatus
 	expectedError       bool
@@ -347,6 +356,7 @@ func TestReorderBrokers(t *testing.T) {
 		desiredBrokers           []v1beta1.Broker
 		brokersState             map[string]v1beta1.BrokerState
 		controllerBrokerID       int32
+		partitionLeaderCounts    map[int32]int
 		expectedReorderedBrokers []v1beta1.Broker
 	}{
 		{
@@ -401,6 +411,37 @@ func TestReorderBrokers(t *testing.T) {
 				{Id: 1}, // controller broker should be last
 			},
 		},
+		{
+			testName: "brokers leading fewer partitions first with controller broker",
+			brokerPods: corev1.PodList{
+				Items: []corev1.Pod{
+					{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{v1beta1.BrokerIdLabelKey: "0"}}},
+					{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{v1beta1.BrokerIdLabelKey: "1"}}},
+					{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{v1beta1.BrokerIdLabelKey: "2"}}},
+					{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{v1beta1.BrokerIdLabelKey: "3"}}},
+				},
+			},
+			desiredBrokers: []v1beta1.Broker{
+				{Id: 0},
+				{Id: 1},
+				{Id: 2},
+				{Id: 3},
+			},
+			brokersState: map[string]v1beta1.BrokerState{
+				"0": {ConfigurationState: v1beta1.ConfigOutOfSync},
+				"1": {ConfigurationState: v1beta1.ConfigOutOfSync},
+				"2": {ConfigurationState: v1beta1.ConfigOutOfSync},
+				"3": {ConfigurationState: v1beta1.ConfigOutOfSync},
+			},
+			controllerBrokerID:    1,
+			partitionLeaderCounts: map[int32]int{0: 12, 1: 2, 2: 3, 3: 3},
+			expectedReorderedBrokers: []v1beta1.Broker{
+				{Id: 2},
+				{Id: 3},
+				{Id: 0}, // broker leading the most partitions should be the last of the non-controller brokers
+				{Id: 1}, // controller broker should be last
+			},
+		},
 		{
 			testName: "some missing broker pods",
 			brokerPods: corev1.PodList{
@@ -584,7 +625,7 @@ func TestReorderBrokers(t *testing.T) {
 				}
 			}
 
-			reorderedBrokers := reorderBrokers(runningBrokers, boundPersistentVolumeClaims, test.desiredBrokers, test.brokersState, test.controllerBrokerID, logr.Discard())
+			reorderedBrokers := reorderBrokers(runningBrokers, boundPersistentVolumeClaims, test.desiredBrokers, test.brokersState, test.controllerBrokerID, test.partitionLeaderCounts, logr.Discard())
 
 			g.Expect(reorderedBrokers).To(gomega.Equal(test.expectedReorderedBrokers))
 		})
@@ -1109,6 +1150,66 @@ func TestReconcileKafkaPvcDiskRemoval(t *testing.T) {
 			expectedDeletePvc:   true,
 			expectedVolumeState: nil,
 		},
+		{
+			testName: "If disk removal successful and the broker still mounts the volume, wait for the broker restart",
+			brokersDesiredPvcs: map[string][]*corev1.PersistentVolumeClaim{
+				"0": {
+					createPvc("test-pvc-1", "0", "/path/to/mount1"),
+				},
+			},
+			existingPvcs: []*corev1.PersistentVolumeClaim{
+				createPvc("test-pvc-1", "0", "/path/to/mount1"),
+				createPvc("test-pvc-2", "0", "/path/to/mount2"),
+			},
+			existingPods: []corev1.Pod{createPodWithPvcs("kafka-0", "test-pvc-1", "test-pvc-2")},
+			kafkaClusterStatus: v1beta1.KafkaClusterStatus{
+				BrokersState: map[string]v1beta1.BrokerState{
+					"0": {
+						GracefulActionState: v1beta1.GracefulActionState{
+							VolumeStates: map[string]v1beta1.VolumeState{
+								"/path/to/mount2": {
+									CruiseControlVolumeState: v1beta1.GracefulDiskRemovalSucceeded,
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedError:     false,
+			expectedDeletePvc: false,
+			expectedVolumeState: map[string]v1beta1.CruiseControlVolumeState{
+				"/path/to/mount2": v1beta1.GracefulDiskRemovalRestartPending,
+			},
+		},
+		{
+			testName: "If the broker restarted without the removed volume, delete pvc and volume state",
+			brokersDesiredPvcs: map[string][]*corev1.PersistentVolumeClaim{
+				"0": {
+					createPvc("test-pvc-1", "0", "/path/to/mount1"),
+				},
+			},
+			existingPvcs: []*corev1.PersistentVolumeClaim{
+				createPvc("test-pvc-1", "0", "/path/to/mount1"),
+				createPvc("test-pvc-2", "0", "/path/to/mount2"),
+			},
+			existingPods: []corev1.Pod{createPodWithPvcs("kafka-0", "test-pvc-1")},
+			kafkaClusterStatus: v1beta1.KafkaClusterStatus{
+				BrokersState: map[string]v1beta1.BrokerState{
+					"0": {
+						GracefulActionState: v1beta1.GracefulActionState{
+							VolumeStates: map[string]v1beta1.VolumeState{
+								"/path/to/mount2": {
+									CruiseControlVolumeState: v1beta1.GracefulDiskRemovalRestartPending,
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedError:       false,
+			expectedDeletePvc:   true,
+			expectedVolumeState: nil,
+		},
 		{
 			testName: "If disk removal failed, and it is readded, mark the disk as rebalancing",
 			brokersDesiredPvcs: map[string][]*corev1.PersistentVolumeClaim{
@@ -1272,6 +1373,14 @@ func execPvcTest(t *testing.T, testCases []PvcTestCase) {
 
 				list.Items = pvcItems
 			}).Return(nil).AnyTimes()
+			mockClient.EXPECT().List(
+				context.TODO(),
+				gomock.AssignableToTypeOf(&corev1.PodList{}),
+				client.InNamespace("kafka"),
+				gomock.Any(),
+			).Do(func(ctx context.Context, list *corev1.PodList, opts ...client.ListOption) {
+				list.Items = test.existingPods
+			}).Return(nil).AnyTimes()
 
 			// Mock the client.Delete call
 			if test.expectedDeletePvc {
@@ -1336,6 +1445,19 @@ func createPvc(name, brokerId, mountPath string) *corev1.PersistentVolumeClaim {
 	}
 }
 
+func createPodWithPvcs(name string, pvcNames ...string) corev1.Pod {
+	pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	for _, pvcName := range pvcNames {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: pvcName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvcName},
+			},
+		})
+	}
+	return pod
+}
+
 // nolint funlen
 func TestReconcileConcurrentBrokerRestartsAllowed(t *testing.T) {
 	t.Parallel()
@@ -1347,6 +1469,7 @@ func TestReconcileConcurrentBrokerRestartsAllowed(t *testing.T) {
 		pods               []corev1.Pod
 		allOfflineReplicas []int32
 		outOfSyncReplicas  []int32
+		replicaPeers       []int32
 		ccStatus           *scale.StatusTaskResult
 		errorExpected      bool
 	}{
@@ -1851,6 +1974,74 @@ func TestReconcileConcurrentBrokerRestartsAllowed(t *testing.T) {
 			},
 			errorExpected: true,
 		},
+		{
+			testName: "Pod is deleted if it shares no replicas with the restarting pods in the same AZ, without checking Cruise Control goals",
+			kafkaCluster: v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "kafka",
+					Namespace: "kafka",
+				},
+				Spec: v1beta1.KafkaClusterSpec{
+					Brokers: []v1beta1.Broker{
+						{Id: 101, ReadOnlyConfig: "broker.rack=az1"},
+						{Id: 102, ReadOnlyConfig: "broker.rack=az1"},
+						{Id: 103, ReadOnlyConfig: "broker.rack=az1"},
+						{Id: 201, ReadOnlyConfig: "broker.rack=az2"},
+						{Id: 301, ReadOnlyConfig: "broker.rack=az3"}},
+					RollingUpgradeConfig: v1beta1.RollingUpgradeConfig{
+						FailureThreshold:                    3,
+						ConcurrentBrokerRestartCountPerRack: 3,
+						VerifyNoSharedReplicas:              true,
+					},
+				},
+				Status: v1beta1.KafkaClusterStatus{State: v1beta1.KafkaClusterRollingUpgrading},
+			},
+			desiredPod: &corev1.Pod{},
+			currentPod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "kafka-103", Labels: map[string]string{"brokerId": "103"}}},
+			pods: []corev1.Pod{
+				{ObjectMeta: metav1.ObjectMeta{Name: "kafka-101", Labels: map[string]string{"brokerId": "101"}, DeletionTimestamp: &metav1.Time{Time: time.Now()}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "kafka-102", Labels: map[string]string{"brokerId": "102"}, DeletionTimestamp: &metav1.Time{Time: time.Now()}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "kafka-103", Labels: map[string]string{"brokerId": "103"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "kafka-201", Labels: map[string]string{"brokerId": "201"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "kafka-301", Labels: map[string]string{"brokerId": "301"}}},
+			},
+			replicaPeers:       []int32{201, 301},
+			allOfflineReplicas: []int32{},
+			outOfSyncReplicas:  []int32{101, 102},
+			errorExpected:      false,
+		},
+		{
+			testName: "Pod is not deleted if it shares replicas with a restarting pod in the same AZ",
+			kafkaCluster: v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "kafka",
+					Namespace: "kafka",
+				},
+				Spec: v1beta1.KafkaClusterSpec{
+					Brokers: []v1beta1.Broker{
+						{Id: 101, ReadOnlyConfig: "broker.rack=az1"},
+						{Id: 102, ReadOnlyConfig: "broker.rack=az1"},
+						{Id: 201, ReadOnlyConfig: "broker.rack=az2"},
+						{Id: 301, ReadOnlyConfig: "broker.rack=az3"}},
+					RollingUpgradeConfig: v1beta1.RollingUpgradeConfig{
+						FailureThreshold:                    2,
+						ConcurrentBrokerRestartCountPerRack: 2,
+						VerifyNoSharedReplicas:              true,
+					},
+				},
+				Status: v1beta1.KafkaClusterStatus{State: v1beta1.KafkaClusterRollingUpgrading},
+			},
+			desiredPod: &corev1.Pod{},
+			currentPod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "kafka-102", Labels: map[string]string{"brokerId": "102"}}},
+			pods: []corev1.Pod{
+				{ObjectMeta: metav1.ObjectMeta{Name: "kafka-101", Labels: map[string]string{"brokerId": "101"}, DeletionTimestamp: &metav1.Time{Time: time.Now()}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "kafka-102", Labels: map[string]string{"brokerId": "102"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "kafka-201", Labels: map[string]string{"brokerId": "201"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "kafka-301", Labels: map[string]string{"brokerId": "301"}}},
+			},
+			replicaPeers:  []int32{101, 201},
+			errorExpected: true,
+		},
 	}
 
 	mockCtrl := gomock.NewController(t)
@@ -1860,7 +2051,7 @@ func TestReconcileConcurrentBrokerRestartsAllowed(t *testing.T) {
 		mockKafkaClientProvider := new(kafkaclient.MockedProvider)
 
 		t.Run(test.testName, func(t *testing.T) {
-			r := New(mockClient, nil, &test.kafkaCluster, mockKafkaClientProvider)
+			r := New(mockClient, nil, &test.kafkaCluster, mockKafkaClientProvider, nil)
 
 			// Mock client
 			mockClient.EXPECT().List(
@@ -1877,12 +2068,16 @@ func TestReconcileConcurrentBrokerRestartsAllowed(t *testing.T) {
 
 			// Mock kafka client
 			mockedKafkaClient := mocks.NewMockKafkaClient(mockCtrl)
+			if test.replicaPeers != nil {
+				mockedKafkaClient.EXPECT().ReplicaPeers(gomock.Any()).Return(test.replicaPeers, nil)
+			}
 			if test.allOfflineReplicas != nil {
 				mockedKafkaClient.EXPECT().AllOfflineReplicas().Return(test.allOfflineReplicas, nil)
 			}
 			if test.outOfSyncReplicas != nil {
 				mockedKafkaClient.EXPECT().OutOfSyncReplicas().Return(test.outOfSyncReplicas, nil)
 			}
+			mockedKafkaClient.EXPECT().ElectPreferredLeaders().Return(0, nil).AnyTimes()
 			mockKafkaClientProvider.On("NewFromCluster", mockClient, &test.kafkaCluster).Return(mockedKafkaClient, func() {}, nil)
 
 			// Mock Cruise Control client
@@ -1905,6 +2100,218 @@ func TestReconcileConcurrentBrokerRestartsAllowed(t *testing.T) {
 	}
 }
 
+func TestRollingUpgradePrometheusReplicationCheck(t *testing.T) {
+	t.Parallel()
+
+	prometheusServer := func(response string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("query") != `ALERTS{alertstate="firing",alertname=~"KafkaUnderReplicatedPartitions"}` {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"status":"error","error":"unexpected query"}`))
+				return
+			}
+			_, _ = w.Write([]byte(response))
+		}))
+	}
+
+	testCases := []struct {
+		testName         string
+		prometheusResult string
+		restartingPod    bool
+		errorExpected    bool
+	}{
+		{
+			testName:         "Pod is deleted if Prometheus reports no under-replicated partitions",
+			prometheusResult: `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+			restartingPod:    true,
+		},
+		{
+			testName:         "Pod is not deleted if Prometheus reports under-replicated partitions of a restarting broker",
+			prometheusResult: `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"alertname":"KafkaUnderReplicatedPartitions"},"value":[1700000000,"1"]}]}}`,
+			restartingPod:    true,
+			errorExpected:    true,
+		},
+		{
+			testName:         "Pod is not deleted if Prometheus cannot be queried",
+			prometheusResult: `{"status":"error","error":"query timed out"}`,
+			restartingPod:    true,
+			errorExpected:    true,
+		},
+		{
+			testName:         "Prometheus is not consulted if no broker is restarting",
+			prometheusResult: `{"status":"error","error":"query timed out"}`,
+		},
+	}
+
+	mockCtrl := gomock.NewController(t)
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			server := prometheusServer(test.prometheusResult)
+			defer server.Close()
+
+			kafkaCluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Spec: v1beta1.KafkaClusterSpec{
+					Brokers: []v1beta1.Broker{
+						{Id: 101, ReadOnlyConfig: "broker.rack=az1"},
+						{Id: 102, ReadOnlyConfig: "broker.rack=az1"},
+						{Id: 201, ReadOnlyConfig: "broker.rack=az2"}},
+					RollingUpgradeConfig: v1beta1.RollingUpgradeConfig{
+						FailureThreshold:                    1,
+						ConcurrentBrokerRestartCountPerRack: 2,
+						VerifyNoSharedReplicas:              true,
+						PrometheusReplicationCheck: &v1beta1.PrometheusReplicationCheck{
+							PrometheusURL: server.URL,
+							Alerts:        []string{"KafkaUnderReplicatedPartitions"},
+						},
+					},
+				},
+				Status: v1beta1.KafkaClusterStatus{State: v1beta1.KafkaClusterRollingUpgrading},
+			}
+			currentPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "kafka-102", Labels: map[string]string{"brokerId": "102"}}}
+			restartingPod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "kafka-101", Labels: map[string]string{"brokerId": "101"}}}
+			if test.restartingPod {
+				restartingPod.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			}
+			pods := []corev1.Pod{restartingPod, *currentPod,
+				{ObjectMeta: metav1.ObjectMeta{Name: "kafka-201", Labels: map[string]string{"brokerId": "201"}}}}
+
+			mockClient := mocks.NewMockClient(mockCtrl)
+			mockClient.EXPECT().List(
+				context.TODO(),
+				gomock.AssignableToTypeOf(&corev1.PodList{}),
+				client.InNamespace("kafka"),
+				gomock.Any(),
+			).Do(func(ctx context.Context, list *corev1.PodList, opts ...client.ListOption) {
+				list.Items = pods
+			}).Return(nil)
+			if !test.errorExpected {
+				mockClient.EXPECT().Delete(context.TODO(), currentPod).Return(nil)
+			}
+
+			mockedKafkaClient := mocks.NewMockKafkaClient(mockCtrl)
+			mockedKafkaClient.EXPECT().ReplicaPeers(gomock.Any()).Return([]int32{201}, nil).AnyTimes()
+			mockedKafkaClient.EXPECT().AllOfflineReplicas().Return(nil, nil)
+			mockedKafkaClient.EXPECT().OutOfSyncReplicas().Return(nil, nil)
+			mockedKafkaClient.EXPECT().ElectPreferredLeaders().Return(0, nil).AnyTimes()
+			mockKafkaClientProvider := new(kafkaclient.MockedProvider)
+			mockKafkaClientProvider.On("NewFromCluster", mockClient, kafkaCluster).Return(mockedKafkaClient, func() {}, nil)
+
+			r := New(mockClient, nil, kafkaCluster, mockKafkaClientProvider, nil)
+			err := r.handleRollingUpgrade(logf.Log, &corev1.Pod{}, currentPod, reflect.TypeOf(currentPod))
+			if test.errorExpected {
+				assert.True(t, errors.As(err, &errorfactory.ReconcileRollingUpgrade{}))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRollingUpgradePauseAfterCanary(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := v1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		testName           string
+		pauseAfterCanary   bool
+		annotations        map[string]string
+		progress           *v1beta1.RollingUpgradeProgress
+		expectedErr        bool
+		expectedProgress   *v1beta1.RollingUpgradeProgress
+		expectedAnnotation bool
+	}{
+		{
+			testName:         "the rolling upgrade is paused after the restart of the canary broker",
+			pauseAfterCanary: true,
+			expectedProgress: &v1beta1.RollingUpgradeProgress{CanaryBrokerID: "1", Paused: true, RestartedBrokers: []string{"1"}},
+		},
+		{
+			testName:         "the broker is not restarted while the rolling upgrade is paused",
+			pauseAfterCanary: true,
+			progress:         &v1beta1.RollingUpgradeProgress{CanaryBrokerID: "0", Paused: true, RestartedBrokers: []string{"0"}},
+			expectedErr:      true,
+			expectedProgress: &v1beta1.RollingUpgradeProgress{CanaryBrokerID: "0", Paused: true, RestartedBrokers: []string{"0"}},
+		},
+		{
+			testName:         "the annotation resumes the rolling upgrade and is removed",
+			pauseAfterCanary: true,
+			annotations:      map[string]string{v1beta1.ResumeRollingUpgradeAnnotationKey: "true"},
+			progress:         &v1beta1.RollingUpgradeProgress{CanaryBrokerID: "0", Paused: true, RestartedBrokers: []string{"0"}},
+			expectedProgress: &v1beta1.RollingUpgradeProgress{CanaryBrokerID: "0", RestartedBrokers: []string{"0", "1"}},
+		},
+		{
+			testName:         "unsetting pauseAfterCanary resumes the rolling upgrade",
+			progress:         &v1beta1.RollingUpgradeProgress{CanaryBrokerID: "0", Paused: true, RestartedBrokers: []string{"0"}},
+			expectedProgress: &v1beta1.RollingUpgradeProgress{CanaryBrokerID: "0", Paused: true, RestartedBrokers: []string{"0"}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka", Annotations: test.annotations},
+				Spec: v1beta1.KafkaClusterSpec{
+					Brokers: []v1beta1.Broker{{Id: 0}, {Id: 1}},
+					RollingUpgradeConfig: v1beta1.RollingUpgradeConfig{
+						FailureThreshold:                    1,
+						ConcurrentBrokerRestartCountPerRack: 1,
+						PauseAfterCanary:                    test.pauseAfterCanary,
+					},
+				},
+				Status: v1beta1.KafkaClusterStatus{
+					State:          v1beta1.KafkaClusterRollingUpgrading,
+					RollingUpgrade: v1beta1.RollingUpgradeStatus{Progress: test.progress},
+				},
+			}
+			pod := func(brokerID string) *corev1.Pod {
+				return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+					Name:      "kafka-" + brokerID,
+					Namespace: "kafka",
+					Labels:    map[string]string{"app": "kafka", "kafka_cr": "kafka", v1beta1.BrokerIdLabelKey: brokerID},
+				}}
+			}
+			currentPod := pod("1")
+			c := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(cluster, pod("0"), currentPod).WithStatusSubresource(cluster).Build()
+
+			mockCtrl := gomock.NewController(t)
+			mockedKafkaClient := mocks.NewMockKafkaClient(mockCtrl)
+			mockedKafkaClient.EXPECT().AllOfflineReplicas().Return(nil, nil).AnyTimes()
+			mockedKafkaClient.EXPECT().OutOfSyncReplicas().Return(nil, nil).AnyTimes()
+			mockedKafkaClient.EXPECT().ElectPreferredLeaders().Return(0, nil).AnyTimes()
+			mockKafkaClientProvider := new(kafkaclient.MockedProvider)
+			mockKafkaClientProvider.On("NewFromCluster", c, cluster).Return(mockedKafkaClient, func() {}, nil)
+
+			r := New(c, c, cluster, mockKafkaClientProvider, nil)
+			err := r.handleRollingUpgrade(logf.Log, pod("1"), currentPod, reflect.TypeOf(currentPod))
+			if test.expectedErr {
+				assert.True(t, errors.As(err, &errorfactory.ReconcileRollingUpgrade{}))
+			} else {
+				assert.NoError(t, err)
+			}
+
+			stored := &v1beta1.KafkaCluster{}
+			assert.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(cluster), stored))
+			assert.Equal(t, test.expectedProgress, stored.Status.RollingUpgrade.Progress)
+			_, annotated := stored.GetAnnotations()[v1beta1.ResumeRollingUpgradeAnnotationKey]
+			assert.Equal(t, test.expectedAnnotation, annotated)
+			podErr := c.Get(context.Background(), client.ObjectKeyFromObject(currentPod), &corev1.Pod{})
+			assert.Equal(t, test.expectedErr, podErr == nil, "the pod of the broker is only kept when the rolling upgrade is paused")
+		})
+	}
+}
+
 func TestGetBrokerAzMap(t *testing.T) {
 	t.Parallel()
 	testCases := []struct {
@@ -1986,3 +2393,462 @@ func TestGetBrokerAzMap(t *testing.T) {
 		})
 	}
 }
+
+func TestIsRollingRestartPending(t *testing.T) {
+	testCases := []struct {
+		testName        string
+		status          v1beta1.KafkaClusterStatus
+		expectedPending bool
+	}{
+		{
+			testName: "brokers are running their desired config",
+			status: v1beta1.KafkaClusterStatus{
+				State:        v1beta1.KafkaClusterRunning,
+				BrokersState: map[string]v1beta1.BrokerState{"0": {ConfigurationState: v1beta1.ConfigInSync}},
+			},
+		},
+		{
+			testName:        "rolling upgrade is in progress",
+			status:          v1beta1.KafkaClusterStatus{State: v1beta1.KafkaClusterRollingUpgrading},
+			expectedPending: true,
+		},
+		{
+			testName: "broker config is out of sync",
+			status: v1beta1.KafkaClusterStatus{
+				State: v1beta1.KafkaClusterRunning,
+				BrokersState: map[string]v1beta1.BrokerState{
+					"0": {ConfigurationState: v1beta1.ConfigInSync},
+					"1": {ConfigurationState: v1beta1.ConfigOutOfSync},
+				},
+			},
+			expectedPending: true,
+		},
+		{
+			testName: "binaries of a version upgrade are rolled out",
+			status: v1beta1.KafkaClusterStatus{
+				State:          v1beta1.KafkaClusterRunning,
+				VersionUpgrade: &v1beta1.KafkaVersionUpgradeStatus{Phase: v1beta1.KafkaVersionUpgradePhaseBinariesUpgrading},
+			},
+			expectedPending: true,
+		},
+		{
+			testName: "version upgrade waits for the protocol bump",
+			status: v1beta1.KafkaClusterStatus{
+				State:          v1beta1.KafkaClusterRunning,
+				VersionUpgrade: &v1beta1.KafkaVersionUpgradeStatus{Phase: v1beta1.KafkaVersionUpgradePhaseProtocolPending},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			assert.Equal(t, test.expectedPending, isRollingRestartPending(&v1beta1.KafkaCluster{Status: test.status}))
+		})
+	}
+}
+
+func TestCheckKafkaVersion(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		kraftMode      bool
+		image          string
+		currentVersion string
+		errorExpected  bool
+	}{
+		{
+			testName: "Kafka 3.x in ZooKeeper mode",
+			image:    "ghcr.io/adobe/koperator/kafka:2.13-3.9.1",
+		},
+		{
+			testName:      "Kafka 4.x in ZooKeeper mode",
+			image:         "ghcr.io/adobe/koperator/kafka:2.13-4.0.0",
+			errorExpected: true,
+		},
+		{
+			testName:       "upgrade to Kafka 4.x from KRaft on Kafka 3.9",
+			kraftMode:      true,
+			image:          "ghcr.io/adobe/koperator/kafka:2.13-4.0.0",
+			currentVersion: "3.9.1",
+		},
+		{
+			testName:       "upgrade to Kafka 4.x from Kafka 3.2",
+			kraftMode:      true,
+			image:          "ghcr.io/adobe/koperator/kafka:2.13-4.0.0",
+			currentVersion: "3.2.3",
+			errorExpected:  true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			r := Reconciler{
+				Reconciler: resources.Reconciler{
+					KafkaCluster: &v1beta1.KafkaCluster{
+						Spec: v1beta1.KafkaClusterSpec{KRaftMode: test.kraftMode},
+						Status: v1beta1.KafkaClusterStatus{
+							BrokersState: map[string]v1beta1.BrokerState{"0": {Version: test.currentVersion}},
+						},
+					},
+				},
+			}
+			err := r.checkKafkaVersion(v1beta1.Broker{Id: 0}, &v1beta1.BrokerConfig{Image: test.image})
+			if test.errorExpected {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCreateExternalListenerStatusesGatewayAPI(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		gatewayAPIConfig v1beta1.GatewayAPIConfig
+		gatewayAddresses []gatewayv1.GatewayStatusAddress
+		expectedStatuses v1beta1.ListenerStatusList
+		expectedErr      bool
+	}{
+		{
+			testName:         "TCPRoute",
+			gatewayAPIConfig: v1beta1.GatewayAPIConfig{GatewayClassName: "eg"},
+			gatewayAddresses: []gatewayv1.GatewayStatusAddress{{Value: "10.0.0.1"}},
+			expectedStatuses: v1beta1.ListenerStatusList{
+				{Name: "any-broker", Address: "10.0.0.1:29092"},
+				{Name: "broker-0", Address: "10.0.0.1:19090"},
+				{Name: "broker-1", Address: "10.0.0.1:19091"},
+			},
+		},
+		{
+			testName: "TLSRoute",
+			gatewayAPIConfig: v1beta1.GatewayAPIConfig{
+				GatewayClassName:       "eg",
+				RouteType:              v1beta1.GatewayAPIRouteTypeTLS,
+				BrokerHostnameTemplate: "broker-%id.kafka.example.com",
+			},
+			gatewayAddresses: []gatewayv1.GatewayStatusAddress{{Value: "kafka.example.com"}},
+			expectedStatuses: v1beta1.ListenerStatusList{
+				{Name: "any-broker", Address: "kafka.example.com:29092"},
+				{Name: "broker-0", Address: "broker-0.kafka.example.com:29092"},
+				{Name: "broker-1", Address: "broker-1.kafka.example.com:29092"},
+			},
+		},
+		{
+			testName:         "gateway address not assigned yet",
+			gatewayAPIConfig: v1beta1.GatewayAPIConfig{GatewayClassName: "eg"},
+			expectedErr:      true,
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, gatewayv1.Install(scheme))
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			anyCastPort := int32(29092)
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Spec: v1beta1.KafkaClusterSpec{
+					IngressController: "gatewayapi",
+					GatewayAPIConfig:  test.gatewayAPIConfig,
+					Brokers:           []v1beta1.Broker{{Id: 0, BrokerConfig: &v1beta1.BrokerConfig{}}, {Id: 1, BrokerConfig: &v1beta1.BrokerConfig{}}},
+					ListenersConfig: v1beta1.ListenersConfig{
+						ExternalListeners: []v1beta1.ExternalListenerConfig{{
+							CommonListenerSpec: v1beta1.CommonListenerSpec{
+								Name:          "external",
+								Type:          v1beta1.SecurityProtocolSSL,
+								ContainerPort: 9094,
+							},
+							ExternalStartingPort: 19090,
+							AnyCastPort:          &anyCastPort,
+						}},
+					},
+				},
+			}
+			gateway := &gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "gateway-external-kafka", Namespace: "kafka"},
+				Status:     gatewayv1.GatewayStatus{Addresses: test.gatewayAddresses},
+			}
+			r := Reconciler{
+				Reconciler: resources.Reconciler{
+					Client:       fake.NewClientBuilder().WithScheme(scheme).WithObjects(gateway).Build(),
+					KafkaCluster: cluster,
+				},
+			}
+
+			statuses, err := r.createExternalListenerStatuses(logr.Discard())
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedStatuses, statuses["external"])
+		})
+	}
+}
+
+func TestCreateExternalListenerStatusesPerBrokerLoadBalancer(t *testing.T) {
+	testCases := []struct {
+		testName               string
+		brokerHostnameTemplate string
+		loadBalancerIngress    []corev1.LoadBalancerIngress
+		expectedStatuses       v1beta1.ListenerStatusList
+		expectedErr            bool
+	}{
+		{
+			testName:            "load balancer addresses",
+			loadBalancerIngress: []corev1.LoadBalancerIngress{{IP: "10.0.0.1"}},
+			expectedStatuses: v1beta1.ListenerStatusList{
+				{Name: "broker-0", Address: "10.0.0.1:19090"},
+				{Name: "broker-1", Address: "10.0.0.1:19091"},
+			},
+		},
+		{
+			testName:               "broker hostnames",
+			brokerHostnameTemplate: "broker-%id.kafka.example.com",
+			expectedStatuses: v1beta1.ListenerStatusList{
+				{Name: "broker-0", Address: "broker-0.kafka.example.com:19090"},
+				{Name: "broker-1", Address: "broker-1.kafka.example.com:19091"},
+			},
+		},
+		{
+			testName:    "load balancer address not assigned yet",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Spec: v1beta1.KafkaClusterSpec{
+					Brokers: []v1beta1.Broker{{Id: 0, BrokerConfig: &v1beta1.BrokerConfig{}}, {Id: 1, BrokerConfig: &v1beta1.BrokerConfig{}}},
+					ListenersConfig: v1beta1.ListenersConfig{
+						ExternalListeners: []v1beta1.ExternalListenerConfig{{
+							CommonListenerSpec: v1beta1.CommonListenerSpec{
+								Name:          "external",
+								Type:          v1beta1.SecurityProtocolSSL,
+								ContainerPort: 9094,
+							},
+							ExternalStartingPort: 19090,
+							AccessMethod:         v1beta1.ServiceTypePerBrokerLoadBalancer,
+							PerBrokerLoadBalancerConfig: &v1beta1.PerBrokerLoadBalancerConfig{
+								BrokerHostnameTemplate: test.brokerHostnameTemplate,
+							},
+						}},
+					},
+				},
+			}
+			var services []client.Object
+			for _, id := range []int{0, 1} {
+				services = append(services, &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("kafka-%d-external-lb", id), Namespace: "kafka"},
+					Status:     corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: test.loadBalancerIngress}},
+				})
+			}
+			r := Reconciler{
+				Reconciler: resources.Reconciler{
+					Client:       fake.NewClientBuilder().WithObjects(services...).Build(),
+					KafkaCluster: cluster,
+				},
+			}
+
+			statuses, err := r.createExternalListenerStatuses(logr.Discard())
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedStatuses, statuses["external"])
+		})
+	}
+}
+
+func TestGetCreatedPvcForBroker(t *testing.T) {
+	pvc := func(name, mountPath string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "kafka",
+			Labels:      map[string]string{"app": "kafka", "kafka_cr": "kafka", v1beta1.BrokerIdLabelKey: "0"},
+			Annotations: map[string]string{"mountPath": mountPath},
+		}}
+	}
+	storageConfig := func(mountPath string) v1beta1.StorageConfig {
+		return v1beta1.StorageConfig{MountPath: mountPath, PvcSpec: &corev1.PersistentVolumeClaimSpec{}}
+	}
+	c := fake.NewClientBuilder().WithObjects(pvc("kafka-0-storage-1", "/kafka-logs-1"), pvc("kafka-0-storage-0", "/kafka-logs-0"),
+		pvc("kafka-0-storage-2", "/kafka-logs-removed")).Build()
+
+	testCases := []struct {
+		testName         string
+		storageConfigs   []v1beta1.StorageConfig
+		expectedPvcNames []string
+		expectedError    bool
+	}{
+		{
+			testName:         "the PVCs of the removed volumes are left out",
+			storageConfigs:   []v1beta1.StorageConfig{storageConfig("/kafka-logs-1"), storageConfig("/kafka-logs-0")},
+			expectedPvcNames: []string{"kafka-0-storage-0", "kafka-0-storage-1"},
+		},
+		{
+			testName:       "missing PVC",
+			storageConfigs: []v1beta1.StorageConfig{storageConfig("/kafka-logs-0"), storageConfig("/kafka-logs-new")},
+			expectedError:  true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			pvcs, err := getCreatedPvcForBroker(context.Background(), c, 0, test.storageConfigs, "kafka", "kafka")
+			if test.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			var pvcNames []string
+			for _, pvc := range pvcs {
+				pvcNames = append(pvcNames, pvc.Name)
+			}
+			assert.Equal(t, test.expectedPvcNames, pvcNames)
+		})
+	}
+}
+
+func TestMaintenanceWindowDefersRollingUpgrade(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := v1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		testName        string
+		state           v1beta1.ClusterState
+		expectedWaiting []string
+	}{
+		{
+			testName:        "the rolling upgrade does not start outside of the maintenance windows",
+			state:           v1beta1.KafkaClusterRunning,
+			expectedWaiting: []string{"rolling restart of broker 1"},
+		},
+		{
+			testName: "the started rolling upgrade is completed outside of the maintenance windows",
+			state:    v1beta1.KafkaClusterRollingUpgrading,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Spec: v1beta1.KafkaClusterSpec{
+					Brokers: []v1beta1.Broker{{Id: 0}, {Id: 1}},
+					RollingUpgradeConfig: v1beta1.RollingUpgradeConfig{
+						FailureThreshold:                    1,
+						ConcurrentBrokerRestartCountPerRack: 1,
+					},
+					// the window never starts
+					MaintenanceWindows: []v1beta1.MaintenanceWindow{{Schedule: "0 0 30 2 *", Duration: metav1.Duration{Duration: time.Hour}}},
+				},
+				Status: v1beta1.KafkaClusterStatus{State: test.state},
+			}
+			pod := func(brokerID string) *corev1.Pod {
+				return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+					Name:      "kafka-" + brokerID,
+					Namespace: "kafka",
+					Labels:    map[string]string{"app": "kafka", "kafka_cr": "kafka", v1beta1.BrokerIdLabelKey: brokerID},
+				}}
+			}
+			currentPod := pod("1")
+			c := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(cluster, pod("0"), currentPod).WithStatusSubresource(cluster).Build()
+
+			mockCtrl := gomock.NewController(t)
+			mockedKafkaClient := mocks.NewMockKafkaClient(mockCtrl)
+			mockedKafkaClient.EXPECT().AllOfflineReplicas().Return(nil, nil).AnyTimes()
+			mockedKafkaClient.EXPECT().OutOfSyncReplicas().Return(nil, nil).AnyTimes()
+			mockedKafkaClient.EXPECT().ElectPreferredLeaders().Return(0, nil).AnyTimes()
+			mockKafkaClientProvider := new(kafkaclient.MockedProvider)
+			mockKafkaClientProvider.On("NewFromCluster", c, cluster).Return(mockedKafkaClient, func() {}, nil)
+
+			r := New(c, c, cluster, mockKafkaClientProvider, nil)
+			// the changes of the pod restart the broker
+			desiredPod := pod("1")
+			desiredPod.Spec.PriorityClassName = "kafka"
+			assert.NoError(t, r.handleRollingUpgrade(logf.Log, desiredPod, currentPod, reflect.TypeOf(currentPod)))
+			assert.Equal(t, test.expectedWaiting, r.maintenanceWaiting)
+
+			stored := &v1beta1.KafkaCluster{}
+			assert.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(cluster), stored))
+			assert.Equal(t, test.state, stored.Status.State)
+			podErr := c.Get(context.Background(), client.ObjectKeyFromObject(currentPod), &corev1.Pod{})
+			assert.Equal(t, test.expectedWaiting != nil, podErr == nil, "the pod of the broker is only kept while the restart waits")
+		})
+	}
+}
+
+func TestDeferBrokerRemovals(t *testing.T) {
+	t.Parallel()
+
+	pod := func(brokerID string, deleting bool) corev1.Pod {
+		pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:   "kafka-" + brokerID,
+			Labels: map[string]string{v1beta1.BrokerIdLabelKey: brokerID},
+		}}
+		if deleting {
+			pod.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		}
+		return pod
+	}
+	pods := []corev1.Pod{pod("1", false), pod("2", false), pod("3", true)}
+	brokersState := map[string]v1beta1.BrokerState{
+		"2": {GracefulActionState: v1beta1.GracefulActionState{CruiseControlState: v1beta1.GracefulDownscaleRunning}},
+	}
+
+	testCases := []struct {
+		testName        string
+		windows         []v1beta1.MaintenanceWindow
+		expectedPods    []string
+		expectedWaiting []string
+	}{
+		{
+			testName:     "no maintenance window",
+			expectedPods: []string{"kafka-1", "kafka-2", "kafka-3"},
+		},
+		{
+			testName:     "in a maintenance window",
+			windows:      []v1beta1.MaintenanceWindow{{Schedule: "* * * * *", Duration: metav1.Duration{Duration: time.Hour}}},
+			expectedPods: []string{"kafka-1", "kafka-2", "kafka-3"},
+		},
+		{
+			testName:        "the removals not started yet wait outside of the maintenance windows",
+			windows:         []v1beta1.MaintenanceWindow{{Schedule: "0 0 30 2 *", Duration: metav1.Duration{Duration: time.Hour}}},
+			expectedPods:    []string{"kafka-2", "kafka-3"},
+			expectedWaiting: []string{"removal of brokers 1"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			t.Parallel()
+
+			r := Reconciler{Reconciler: resources.Reconciler{KafkaCluster: &v1beta1.KafkaCluster{
+				Spec:   v1beta1.KafkaClusterSpec{MaintenanceWindows: test.windows},
+				Status: v1beta1.KafkaClusterStatus{BrokersState: brokersState},
+			}}}
+			var podNames []string
+			for _, pod := range r.deferBrokerRemovals(logf.Log, pods) {
+				podNames = append(podNames, pod.Name)
+			}
+			assert.Equal(t, test.expectedPods, podNames)
+			assert.Equal(t, test.expectedWaiting, r.maintenanceWaiting)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/util"
+	kafkautils "github.com/banzaicloud/koperator/pkg/util/kafka"
+)
+
+const (
+	kerberosMechanism                = "GSSAPI"
+	kerberosLoginModule              = "com.sun.security.auth.module.Krb5LoginModule"
+	kerberosKeytabVolumeNameTemplate = "listener-%s-keytab"
+	kerberosKeytabPath               = "/var/run/secrets/kerberos"
+	kerberosKeytabFile               = "kafka.keytab"
+	krb5ConfigVolumeName             = "krb5-config"
+	krb5ConfigPath                   = "/etc/krb5.conf"
+)
+
+func generateListenerKerberosConfig(brokerID int32, name string, kerberos v1beta1.KerberosConfig) map[string]string {
+	jaasOptions := []string{
+		kerberosLoginModule, "required",
+		"useKeyTab=true",
+		"storeKey=true",
+		fmt.Sprintf("keyTab=%q", fmt.Sprintf("%s/%s/%s", kerberosKeytabPath, name, kerberosKeytabFile)),
+		fmt.Sprintf("principal=%q", kerberos.GetPrincipal(brokerID)),
+	}
+	return map[string]string{
+		fmt.Sprintf("%s.%s.%s", kafkautils.KafkaConfigListenerName, name, kafkautils.KafkaConfigSASLKerberosServiceName): kerberos.GetServiceName(),
+		fmt.Sprintf(saslMechanismConfigKeyTemplate, kafkautils.KafkaConfigListenerName, name, strings.ToLower(kerberosMechanism),
+			kafkautils.KafkaConfigSASLJAASConfig): strings.Join(jaasOptions, " ") + ";",
+	}
+}
+
+// kerberosListeners returns the listeners authenticating the clients with SASL/GSSAPI
+func kerberosListeners(l v1beta1.ListenersConfig) []v1beta1.CommonListenerSpec {
+	var listeners []v1beta1.CommonListenerSpec
+	for _, iListener := range l.InternalListeners {
+		if iListener.Kerberos != nil && iListener.Type.IsSasl() {
+			listeners = append(listeners, iListener.CommonListenerSpec)
+		}
+	}
+	for _, eListener := range l.ExternalListeners {
+		if eListener.Kerberos != nil && eListener.Type.IsSasl() {
+			listeners = append(listeners, eListener.CommonListenerSpec)
+		}
+	}
+	return listeners
+}
+
+// generateVolumesForKerberos returns the volumes of the keytabs of the Kerberos listeners and of the Kerberos
+// configuration
+func generateVolumesForKerberos(l v1beta1.ListenersConfig) (ret []corev1.Volume) {
+	listeners := kerberosListeners(l)
+	for _, listener := range listeners {
+		keytabRef := listener.Kerberos.KeytabSecretRef
+		ret = append(ret, corev1.Volume{
+			Name: fmt.Sprintf(kerberosKeytabVolumeNameTemplate, listener.Name),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  keytabRef.Name,
+					Items:       []corev1.KeyToPath{{Key: keytabRef.Key, Path: kerberosKeytabFile}},
+					DefaultMode: util.Int32Pointer(0644),
+				},
+			},
+		})
+	}
+	if len(listeners) > 0 && l.Krb5ConfigMapRef != nil {
+		ret = append(ret, corev1.Volume{
+			Name: krb5ConfigVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: l.Krb5ConfigMapRef.LocalObjectReference,
+					Items:                []corev1.KeyToPath{{Key: l.Krb5ConfigMapRef.Key, Path: "krb5.conf"}},
+					DefaultMode:          util.Int32Pointer(0644),
+				},
+			},
+		})
+	}
+	return ret
+}
+
+func generateVolumeMountsForKerberos(l v1beta1.ListenersConfig) (ret []corev1.VolumeMount) {
+	listeners := kerberosListeners(l)
+	for _, listener := range listeners {
+		ret = append(ret, corev1.VolumeMount{
+			Name:      fmt.Sprintf(kerberosKeytabVolumeNameTemplate, listener.Name),
+			MountPath: fmt.Sprintf("%s/%s", kerberosKeytabPath, listener.Name),
+			ReadOnly:  true,
+		})
+	}
+	if len(listeners) > 0 && l.Krb5ConfigMapRef != nil {
+		ret = append(ret, corev1.VolumeMount{
+			Name:      krb5ConfigVolumeName,
+			MountPath: krb5ConfigPath,
+			SubPath:   "krb5.conf",
+			ReadOnly:  true,
+		})
+	}
+	return ret
+}
@@ -0,0 +1,64 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"emperror.dev/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	kafkautils "github.com/banzaicloud/koperator/pkg/util/kafka"
+	pkicommon "github.com/banzaicloud/koperator/pkg/util/pki"
+)
+
+// keystorePasswordReferences replaces the keystore passwords of the listeners and the client with config provider
+// references to the password files in the mounted secrets
+func keystorePasswordReferences(clientPass string, serverPasses map[string]string) (string, map[string]string) {
+	references := make(map[string]string, len(serverPasses))
+	for listenerName := range serverPasses {
+		references[listenerName] = kafkautils.KeystorePasswordReference(fmt.Sprintf(listenerServerKeyStorePathTemplate, serverKeystorePath, listenerName))
+	}
+	if clientPass != "" {
+		clientPass = kafkautils.KeystorePasswordReference(clientKeystorePath)
+	}
+	return clientPass, references
+}
+
+// keystorePasswordRevision returns the rotation times of the keystore passwords of the broker and the controller users
+func (r *Reconciler) keystorePasswordRevision(ctx context.Context) (string, error) {
+	var revisions []string
+	for _, name := range []string{
+		fmt.Sprintf(pkicommon.BrokerServerCertTemplate, r.KafkaCluster.Name),
+		fmt.Sprintf(pkicommon.BrokerControllerTemplate, r.KafkaCluster.Name),
+	} {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: r.KafkaCluster.Namespace}, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return "", errors.WrapIfWithDetails(err, "failed to get keystore secret", "secret", name)
+		}
+		if rotatedAt, ok := secret.Annotations[pkicommon.KeystorePasswordRotatedAtAnnotation]; ok {
+			revisions = append(revisions, rotatedAt)
+		}
+	}
+	return strings.Join(revisions, ","), nil
+}
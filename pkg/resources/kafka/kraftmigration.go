@@ -0,0 +1,258 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	properties "github.com/banzaicloud/koperator/properties/pkg"
+)
+
+// nodeMetadataMode describes how a node of a KRaft enabled cluster manages the cluster metadata
+type nodeMetadataMode struct {
+	// kRaftMode is false for the brokers still running in ZooKeeper mode
+	kRaftMode bool
+	// controllerQuorum is true when the node is configured with the controller quorum
+	controllerQuorum bool
+	// zkMigration is true when the node runs with the ZooKeeper migration enabled
+	zkMigration bool
+}
+
+// getNodeMetadataMode returns the metadata mode of the given node. While the ZooKeeper to KRaft migration is enabled the
+// mode follows the phase of the migration, otherwise it is derived from the migration flags of the broker read-only config.
+func getNodeMetadataMode(kafkaCluster *v1beta1.KafkaCluster, brokerID int32, bConfig *v1beta1.BrokerConfig,
+	brokerReadOnlyConfig *properties.Properties) nodeMetadataMode {
+	if !kafkaCluster.Spec.KRaftMigration.IsEnabled() {
+		return nodeMetadataMode{
+			kRaftMode:        shouldUseKRaftModeForBroker(brokerReadOnlyConfig),
+			controllerQuorum: shouldConfigureControllerQuorumForBroker(brokerReadOnlyConfig),
+		}
+	}
+
+	migration := kafkaCluster.Status.KRaftMigration
+	phase := migration.GetPhase()
+	if bConfig.IsControllerOnlyNode() {
+		return nodeMetadataMode{
+			kRaftMode:        true,
+			controllerQuorum: true,
+			zkMigration:      phase != v1beta1.KRaftMigrationPhaseFinalizing && phase != v1beta1.KRaftMigrationPhaseCompleted,
+		}
+	}
+
+	switch phase {
+	case v1beta1.KRaftMigrationPhaseFinalizing, v1beta1.KRaftMigrationPhaseCompleted:
+		return nodeMetadataMode{kRaftMode: true, controllerQuorum: true}
+	case v1beta1.KRaftMigrationPhaseBrokersMigrating:
+		if migration.IsBrokerMigrated(brokerID) {
+			return nodeMetadataMode{kRaftMode: true, controllerQuorum: true}
+		}
+		return nodeMetadataMode{controllerQuorum: true, zkMigration: true}
+	case v1beta1.KRaftMigrationPhaseMetadataMigrating:
+		return nodeMetadataMode{controllerQuorum: true, zkMigration: true}
+	default:
+		// the brokers keep running in ZooKeeper mode until the controller quorum is provisioned
+		return nodeMetadataMode{}
+	}
+}
+
+// reconcileKRaftMigration moves the ZooKeeper to KRaft migration to its next phase once the nodes are rolled with the
+// configuration of the current one. It runs at the end of the reconcile flow, the status update of a new phase triggers
+// the reconciliation which rolls out its configuration.
+func (r *Reconciler) reconcileKRaftMigration(ctx context.Context, log logr.Logger) error {
+	if !r.KafkaCluster.Spec.KRaftMigration.IsEnabled() {
+		return nil
+	}
+
+	migration := r.KafkaCluster.Status.KRaftMigration
+	phase := migration.GetPhase()
+	if phase == v1beta1.KRaftMigrationPhaseCompleted {
+		return nil
+	}
+	log = log.WithValues("kRaftMigrationPhase", phase)
+
+	if phase == "" {
+		log.Info("starting the ZooKeeper to KRaft migration")
+		return r.updateKRaftMigrationStatus(v1beta1.KRaftMigrationPhaseControllersProvisioning, nil, log)
+	}
+
+	settled, err := r.isClusterSettled(ctx)
+	if err != nil {
+		return err
+	}
+	if !settled {
+		log.V(1).Info("waiting for the nodes to be rolled with the configuration of the migration phase")
+		return nil
+	}
+
+	var nextPhase v1beta1.KRaftMigrationPhase
+	switch phase {
+	case v1beta1.KRaftMigrationPhaseControllersProvisioning:
+		nextPhase = v1beta1.KRaftMigrationPhaseMetadataMigrating
+	case v1beta1.KRaftMigrationPhaseMetadataMigrating:
+		migrated, err := r.isKRaftMetadataMigrated()
+		if err != nil {
+			return err
+		}
+		if !migrated {
+			return errorfactory.New(errorfactory.ResourceNotReady{}, errors.New("active controller is not a KRaft controller"),
+				"waiting for the KRaft controller to migrate the metadata from ZooKeeper")
+		}
+		nextPhase = v1beta1.KRaftMigrationPhaseBrokersMigrating
+	case v1beta1.KRaftMigrationPhaseBrokersMigrating:
+		brokerID, found, err := nextBrokerToMigrate(r.KafkaCluster.Spec, migration)
+		if err != nil {
+			return err
+		}
+		if found {
+			// the brokers are flipped one by one, the next one is flipped once the previous one has been rolled
+			log.Info("migrating broker to KRaft mode", v1beta1.BrokerIdLabelKey, brokerID)
+			return r.updateKRaftMigrationStatus(phase, append(slices.Clone(migration.MigratedBrokers), brokerID), log)
+		}
+		nextPhase = v1beta1.KRaftMigrationPhaseFinalizing
+	case v1beta1.KRaftMigrationPhaseFinalizing:
+		nextPhase = v1beta1.KRaftMigrationPhaseCompleted
+	default:
+		return errors.NewWithDetails("unknown ZooKeeper to KRaft migration phase", "phase", phase)
+	}
+
+	log.Info("ZooKeeper to KRaft migration phase completed", "nextPhase", nextPhase)
+	return r.updateKRaftMigrationStatus(nextPhase, nil, log)
+}
+
+// getZooKeeperClusterID returns the ID of the cluster running in ZooKeeper mode
+func (r *Reconciler) getZooKeeperClusterID() (string, error) {
+	kClient, close, err := r.kafkaClientProvider.NewFromCluster(r.Client, r.KafkaCluster)
+	if err != nil {
+		return "", errorfactory.New(errorfactory.BrokersUnreachable{}, err, "could not connect to kafka brokers")
+	}
+	defer close()
+
+	clusterID, err := kClient.ClusterID()
+	if err != nil {
+		return "", errors.WrapIf(err, "could not get the ID of the ZooKeeper based cluster")
+	}
+	return clusterID, nil
+}
+
+// isClusterSettled returns true if every node runs with its generated configuration and its pod is ready
+func (r *Reconciler) isClusterSettled(ctx context.Context) (bool, error) {
+	for _, broker := range r.KafkaCluster.Spec.Brokers {
+		state, ok := r.KafkaCluster.Status.BrokersState[strconv.Itoa(int(broker.Id))]
+		if !ok || state.ConfigurationState != v1beta1.ConfigInSync {
+			return false, nil
+		}
+	}
+
+	podList := &corev1.PodList{}
+	err := r.List(ctx, podList,
+		client.InNamespace(r.KafkaCluster.Namespace),
+		client.MatchingLabels(apiutil.LabelsForKafka(r.KafkaCluster.Name)),
+	)
+	if err != nil {
+		return false, errors.WrapIf(err, "failed to list broker pods that belong to Kafka cluster")
+	}
+	if len(podList.Items) < len(r.KafkaCluster.Spec.Brokers) {
+		return false, nil
+	}
+	for _, pod := range podList.Items {
+		if k8sutil.IsMarkedForDeletion(pod.ObjectMeta) || !isPodReady(&pod) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// isKRaftMetadataMigrated returns true if the brokers still running in ZooKeeper mode report a KRaft controller as the
+// active controller. The KRaft controller propagates itself to the ZooKeeper brokers only after it has migrated the
+// metadata from ZooKeeper and entered the dual-write mode.
+func (r *Reconciler) isKRaftMetadataMigrated() (bool, error) {
+	kClient, close, err := r.kafkaClientProvider.NewFromCluster(r.Client, r.KafkaCluster)
+	if err != nil {
+		return false, errorfactory.New(errorfactory.BrokersUnreachable{}, err, "could not connect to kafka brokers")
+	}
+	defer close()
+
+	_, controllerID, err := kClient.DescribeCluster()
+	if err != nil {
+		return false, errors.WrapIf(err, "could not describe kafka cluster")
+	}
+	return isKRaftController(r.KafkaCluster.Spec, controllerID)
+}
+
+func isKRaftController(kafkaClusterSpec v1beta1.KafkaClusterSpec, nodeID int32) (bool, error) {
+	for _, broker := range kafkaClusterSpec.Brokers {
+		if broker.Id != nodeID {
+			continue
+		}
+		brokerConfig, err := broker.GetBrokerConfig(kafkaClusterSpec)
+		if err != nil {
+			return false, errors.WrapIfWithDetails(err, "could not get broker config", v1beta1.BrokerIdLabelKey, broker.Id)
+		}
+		return brokerConfig.IsControllerOnlyNode(), nil
+	}
+	return false, nil
+}
+
+// nextBrokerToMigrate returns the first broker, in the order of the spec, which still runs in ZooKeeper mode
+func nextBrokerToMigrate(kafkaClusterSpec v1beta1.KafkaClusterSpec, migration *v1beta1.KRaftMigrationStatus) (int32, bool, error) {
+	for _, broker := range kafkaClusterSpec.Brokers {
+		brokerConfig, err := broker.GetBrokerConfig(kafkaClusterSpec)
+		if err != nil {
+			return 0, false, errors.WrapIfWithDetails(err, "could not get broker config", v1beta1.BrokerIdLabelKey, broker.Id)
+		}
+		if brokerConfig.IsControllerOnlyNode() || migration.IsBrokerMigrated(broker.Id) {
+			continue
+		}
+		return broker.Id, true, nil
+	}
+	return 0, false, nil
+}
+
+func (r *Reconciler) updateKRaftMigrationStatus(phase v1beta1.KRaftMigrationPhase, migratedBrokers []int32, log logr.Logger) error {
+	status := &v1beta1.KRaftMigrationStatus{
+		Phase:              phase,
+		MigratedBrokers:    migratedBrokers,
+		LastTransitionTime: metav1.Now(),
+	}
+	if current := r.KafkaCluster.Status.KRaftMigration; current != nil && current.Phase == phase {
+		status.LastTransitionTime = current.LastTransitionTime
+	}
+	if err := k8sutil.UpdateCRStatus(r.Client, r.KafkaCluster, status, log); err != nil {
+		return errorfactory.New(errorfactory.StatusUpdateError{}, err, "could not update ZooKeeper to KRaft migration status")
+	}
+	return nil
+}
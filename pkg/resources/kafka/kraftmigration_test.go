@@ -0,0 +1,168 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	properties "github.com/banzaicloud/koperator/properties/pkg"
+)
+
+func TestGetNodeMetadataMode(t *testing.T) {
+	broker := &v1beta1.BrokerConfig{Roles: []string{"broker"}}
+	controller := &v1beta1.BrokerConfig{Roles: []string{"controller"}}
+
+	testCases := []struct {
+		testName       string
+		kRaftMigration *v1beta1.KRaftMigrationConfig
+		migration      *v1beta1.KRaftMigrationStatus
+		readOnlyConfig string
+		brokerID       int32
+		brokerConfig   *v1beta1.BrokerConfig
+		expectedMode   nodeMetadataMode
+	}{
+		{
+			testName:     "migration disabled",
+			brokerConfig: broker,
+			expectedMode: nodeMetadataMode{kRaftMode: true, controllerQuorum: true},
+		},
+		{
+			testName:       "migration disabled with migration flags",
+			readOnlyConfig: "migration.broker.controllerQuorumConfigEnabled=true\nmigration.broker.kRaftMode=false",
+			brokerConfig:   broker,
+			expectedMode:   nodeMetadataMode{controllerQuorum: true},
+		},
+		{
+			testName:       "broker while the controllers are provisioned",
+			kRaftMigration: &v1beta1.KRaftMigrationConfig{Enabled: true},
+			migration:      &v1beta1.KRaftMigrationStatus{Phase: v1beta1.KRaftMigrationPhaseControllersProvisioning},
+			readOnlyConfig: "migration.broker.kRaftMode=true",
+			brokerConfig:   broker,
+			expectedMode:   nodeMetadataMode{},
+		},
+		{
+			testName:       "controller while the controllers are provisioned",
+			kRaftMigration: &v1beta1.KRaftMigrationConfig{Enabled: true},
+			migration:      &v1beta1.KRaftMigrationStatus{Phase: v1beta1.KRaftMigrationPhaseControllersProvisioning},
+			brokerConfig:   controller,
+			expectedMode:   nodeMetadataMode{kRaftMode: true, controllerQuorum: true, zkMigration: true},
+		},
+		{
+			testName:       "broker while the metadata is migrated",
+			kRaftMigration: &v1beta1.KRaftMigrationConfig{Enabled: true},
+			migration:      &v1beta1.KRaftMigrationStatus{Phase: v1beta1.KRaftMigrationPhaseMetadataMigrating},
+			brokerConfig:   broker,
+			expectedMode:   nodeMetadataMode{controllerQuorum: true, zkMigration: true},
+		},
+		{
+			testName:       "broker not migrated yet",
+			kRaftMigration: &v1beta1.KRaftMigrationConfig{Enabled: true},
+			migration:      &v1beta1.KRaftMigrationStatus{Phase: v1beta1.KRaftMigrationPhaseBrokersMigrating, MigratedBrokers: []int32{1}},
+			brokerID:       2,
+			brokerConfig:   broker,
+			expectedMode:   nodeMetadataMode{controllerQuorum: true, zkMigration: true},
+		},
+		{
+			testName:       "migrated broker",
+			kRaftMigration: &v1beta1.KRaftMigrationConfig{Enabled: true},
+			migration:      &v1beta1.KRaftMigrationStatus{Phase: v1beta1.KRaftMigrationPhaseBrokersMigrating, MigratedBrokers: []int32{1}},
+			brokerID:       1,
+			brokerConfig:   broker,
+			expectedMode:   nodeMetadataMode{kRaftMode: true, controllerQuorum: true},
+		},
+		{
+			testName:       "controller while the migration is finalized",
+			kRaftMigration: &v1beta1.KRaftMigrationConfig{Enabled: true},
+			migration:      &v1beta1.KRaftMigrationStatus{Phase: v1beta1.KRaftMigrationPhaseFinalizing},
+			brokerConfig:   controller,
+			expectedMode:   nodeMetadataMode{kRaftMode: true, controllerQuorum: true},
+		},
+		{
+			testName:       "broker after the migration",
+			kRaftMigration: &v1beta1.KRaftMigrationConfig{Enabled: true},
+			migration:      &v1beta1.KRaftMigrationStatus{Phase: v1beta1.KRaftMigrationPhaseCompleted},
+			brokerConfig:   broker,
+			expectedMode:   nodeMetadataMode{kRaftMode: true, controllerQuorum: true},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			kafkaCluster := &v1beta1.KafkaCluster{
+				Spec:   v1beta1.KafkaClusterSpec{KRaftMode: true, KRaftMigration: test.kRaftMigration},
+				Status: v1beta1.KafkaClusterStatus{KRaftMigration: test.migration},
+			}
+			readOnlyConfig, err := properties.NewFromString(test.readOnlyConfig)
+			require.NoError(t, err)
+
+			mode := getNodeMetadataMode(kafkaCluster, test.brokerID, test.brokerConfig, readOnlyConfig)
+			require.Equal(t, test.expectedMode, mode)
+		})
+	}
+}
+
+func TestNextBrokerToMigrate(t *testing.T) {
+	spec := v1beta1.KafkaClusterSpec{
+		BrokerConfigGroups: map[string]v1beta1.BrokerConfig{
+			"broker":     {Roles: []string{"broker"}},
+			"controller": {Roles: []string{"controller"}},
+		},
+		Brokers: []v1beta1.Broker{
+			{Id: 10, BrokerConfigGroup: "controller"},
+			{Id: 2, BrokerConfigGroup: "broker"},
+			{Id: 0, BrokerConfigGroup: "broker"},
+		},
+	}
+
+	testCases := []struct {
+		testName         string
+		migratedBrokers  []int32
+		expectedBrokerID int32
+		expectedFound    bool
+	}{
+		{
+			testName:         "first broker in the spec order",
+			expectedBrokerID: 2,
+			expectedFound:    true,
+		},
+		{
+			testName:         "migrated brokers are skipped",
+			migratedBrokers:  []int32{2},
+			expectedBrokerID: 0,
+			expectedFound:    true,
+		},
+		{
+			testName:        "every broker migrated",
+			migratedBrokers: []int32{2, 0},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			migration := &v1beta1.KRaftMigrationStatus{
+				Phase:           v1beta1.KRaftMigrationPhaseBrokersMigrating,
+				MigratedBrokers: test.migratedBrokers,
+			}
+			brokerID, found, err := nextBrokerToMigrate(spec, migration)
+			require.NoError(t, err)
+			require.Equal(t, test.expectedFound, found)
+			require.Equal(t, test.expectedBrokerID, brokerID)
+		})
+	}
+}
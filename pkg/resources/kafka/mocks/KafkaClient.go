@@ -27,6 +27,7 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
 	sarama "github.com/IBM/sarama"
 	v1alpha1 "github.com/banzaicloud/koperator/api/v1alpha1"
@@ -115,6 +116,20 @@ func (mr *MockKafkaClientMockRecorder) Brokers() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Brokers", reflect.TypeOf((*MockKafkaClient)(nil).Brokers))
 }
 
+// ChangeReplicationFactor mocks base method.
+func (m *MockKafkaClient) ChangeReplicationFactor(arg0 string, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeReplicationFactor", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeReplicationFactor indicates an expected call of ChangeReplicationFactor.
+func (mr *MockKafkaClientMockRecorder) ChangeReplicationFactor(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeReplicationFactor", reflect.TypeOf((*MockKafkaClient)(nil).ChangeReplicationFactor), arg0, arg1)
+}
+
 // Close mocks base method.
 func (m *MockKafkaClient) Close() error {
 	m.ctrl.T.Helper()
@@ -129,6 +144,35 @@ func (mr *MockKafkaClientMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockKafkaClient)(nil).Close))
 }
 
+// ClusterID mocks base method.
+func (m *MockKafkaClient) ClusterID() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClusterID")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClusterID indicates an expected call of ClusterID.
+func (mr *MockKafkaClientMockRecorder) ClusterID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterID", reflect.TypeOf((*MockKafkaClient)(nil).ClusterID))
+}
+
+// CreateACLBindings mocks base method.
+func (m *MockKafkaClient) CreateACLBindings(arg0 []kafkaclient.ACLBinding) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateACLBindings", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateACLBindings indicates an expected call of CreateACLBindings.
+func (mr *MockKafkaClientMockRecorder) CreateACLBindings(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateACLBindings", reflect.TypeOf((*MockKafkaClient)(nil).CreateACLBindings), arg0)
+}
+
 // CreateTopic mocks base method.
 func (m *MockKafkaClient) CreateTopic(arg0 *kafkaclient.CreateTopicOptions) error {
 	m.ctrl.T.Helper()
@@ -143,6 +187,21 @@ func (mr *MockKafkaClientMockRecorder) CreateTopic(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTopic", reflect.TypeOf((*MockKafkaClient)(nil).CreateTopic), arg0)
 }
 
+// CreateTopics mocks base method.
+func (m *MockKafkaClient) CreateTopics(arg0 []*kafkaclient.CreateTopicOptions) (map[string]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTopics", arg0)
+	ret0, _ := ret[0].(map[string]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTopics indicates an expected call of CreateTopics.
+func (mr *MockKafkaClientMockRecorder) CreateTopics(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTopics", reflect.TypeOf((*MockKafkaClient)(nil).CreateTopics), arg0)
+}
+
 // CreateUserACLs mocks base method.
 func (m *MockKafkaClient) CreateUserACLs(arg0 v1alpha1.KafkaAccessType, arg1 v1alpha1.KafkaPatternType, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
@@ -157,6 +216,34 @@ func (mr *MockKafkaClientMockRecorder) CreateUserACLs(arg0, arg1, arg2, arg3 any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserACLs", reflect.TypeOf((*MockKafkaClient)(nil).CreateUserACLs), arg0, arg1, arg2, arg3)
 }
 
+// DeleteACLBindings mocks base method.
+func (m *MockKafkaClient) DeleteACLBindings(arg0 []kafkaclient.ACLBinding) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteACLBindings", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteACLBindings indicates an expected call of DeleteACLBindings.
+func (mr *MockKafkaClientMockRecorder) DeleteACLBindings(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteACLBindings", reflect.TypeOf((*MockKafkaClient)(nil).DeleteACLBindings), arg0)
+}
+
+// DeletePerBrokerConfig mocks base method.
+func (m *MockKafkaClient) DeletePerBrokerConfig(arg0 int32, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePerBrokerConfig", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePerBrokerConfig indicates an expected call of DeletePerBrokerConfig.
+func (mr *MockKafkaClientMockRecorder) DeletePerBrokerConfig(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePerBrokerConfig", reflect.TypeOf((*MockKafkaClient)(nil).DeletePerBrokerConfig), arg0, arg1)
+}
+
 // DeleteTopic mocks base method.
 func (m *MockKafkaClient) DeleteTopic(arg0 string, arg1 bool) error {
 	m.ctrl.T.Helper()
@@ -171,6 +258,20 @@ func (mr *MockKafkaClientMockRecorder) DeleteTopic(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTopic", reflect.TypeOf((*MockKafkaClient)(nil).DeleteTopic), arg0, arg1)
 }
 
+// DeleteTopicConfig mocks base method.
+func (m *MockKafkaClient) DeleteTopicConfig(arg0 string, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTopicConfig", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTopicConfig indicates an expected call of DeleteTopicConfig.
+func (mr *MockKafkaClientMockRecorder) DeleteTopicConfig(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTopicConfig", reflect.TypeOf((*MockKafkaClient)(nil).DeleteTopicConfig), arg0, arg1)
+}
+
 // DeleteUserACLs mocks base method.
 func (m *MockKafkaClient) DeleteUserACLs(arg0 string, arg1 v1alpha1.KafkaPatternType) error {
 	m.ctrl.T.Helper()
@@ -185,6 +286,20 @@ func (mr *MockKafkaClientMockRecorder) DeleteUserACLs(arg0, arg1 any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserACLs", reflect.TypeOf((*MockKafkaClient)(nil).DeleteUserACLs), arg0, arg1)
 }
 
+// DeleteUserScramCredential mocks base method.
+func (m *MockKafkaClient) DeleteUserScramCredential(arg0 string, arg1 v1alpha1.UserAuthenticationType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserScramCredential", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserScramCredential indicates an expected call of DeleteUserScramCredential.
+func (mr *MockKafkaClientMockRecorder) DeleteUserScramCredential(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserScramCredential", reflect.TypeOf((*MockKafkaClient)(nil).DeleteUserScramCredential), arg0, arg1)
+}
+
 // DescribeCluster mocks base method.
 func (m *MockKafkaClient) DescribeCluster() ([]*sarama.Broker, int32, error) {
 	m.ctrl.T.Helper()
@@ -216,6 +331,36 @@ func (mr *MockKafkaClientMockRecorder) DescribeClusterWideConfig() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeClusterWideConfig", reflect.TypeOf((*MockKafkaClient)(nil).DescribeClusterWideConfig))
 }
 
+// DescribeConsumerGroup mocks base method.
+func (m *MockKafkaClient) DescribeConsumerGroup(arg0 string) (*kafkaclient.ConsumerGroupDescription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeConsumerGroup", arg0)
+	ret0, _ := ret[0].(*kafkaclient.ConsumerGroupDescription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeConsumerGroup indicates an expected call of DescribeConsumerGroup.
+func (mr *MockKafkaClientMockRecorder) DescribeConsumerGroup(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeConsumerGroup", reflect.TypeOf((*MockKafkaClient)(nil).DescribeConsumerGroup), arg0)
+}
+
+// DescribeLogDirs mocks base method.
+func (m *MockKafkaClient) DescribeLogDirs(arg0 []int32) (map[int32][]sarama.DescribeLogDirsResponseDirMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeLogDirs", arg0)
+	ret0, _ := ret[0].(map[int32][]sarama.DescribeLogDirsResponseDirMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeLogDirs indicates an expected call of DescribeLogDirs.
+func (mr *MockKafkaClientMockRecorder) DescribeLogDirs(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeLogDirs", reflect.TypeOf((*MockKafkaClient)(nil).DescribeLogDirs), arg0)
+}
+
 // DescribePerBrokerConfig mocks base method.
 func (m *MockKafkaClient) DescribePerBrokerConfig(arg0 int32, arg1 []string) ([]*sarama.ConfigEntry, error) {
 	m.ctrl.T.Helper()
@@ -246,6 +391,21 @@ func (mr *MockKafkaClientMockRecorder) DescribeTopic(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTopic", reflect.TypeOf((*MockKafkaClient)(nil).DescribeTopic), arg0)
 }
 
+// ElectPreferredLeaders mocks base method.
+func (m *MockKafkaClient) ElectPreferredLeaders() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ElectPreferredLeaders")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ElectPreferredLeaders indicates an expected call of ElectPreferredLeaders.
+func (mr *MockKafkaClientMockRecorder) ElectPreferredLeaders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ElectPreferredLeaders", reflect.TypeOf((*MockKafkaClient)(nil).ElectPreferredLeaders))
+}
+
 // EnsurePartitionCount mocks base method.
 func (m *MockKafkaClient) EnsurePartitionCount(arg0 string, arg1 int32) (bool, error) {
 	m.ctrl.T.Helper()
@@ -275,6 +435,21 @@ func (mr *MockKafkaClientMockRecorder) EnsureTopicConfig(arg0, arg1 any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureTopicConfig", reflect.TypeOf((*MockKafkaClient)(nil).EnsureTopicConfig), arg0, arg1)
 }
 
+// EnsureTopicConfigs mocks base method.
+func (m *MockKafkaClient) EnsureTopicConfigs(arg0 map[string]map[string]*string) (map[string]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureTopicConfigs", arg0)
+	ret0, _ := ret[0].(map[string]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnsureTopicConfigs indicates an expected call of EnsureTopicConfigs.
+func (mr *MockKafkaClientMockRecorder) EnsureTopicConfigs(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureTopicConfigs", reflect.TypeOf((*MockKafkaClient)(nil).EnsureTopicConfigs), arg0)
+}
+
 // GetTopic mocks base method.
 func (m *MockKafkaClient) GetTopic(arg0 string) (*sarama.TopicDetail, error) {
 	m.ctrl.T.Helper()
@@ -290,6 +465,51 @@ func (mr *MockKafkaClientMockRecorder) GetTopic(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopic", reflect.TypeOf((*MockKafkaClient)(nil).GetTopic), arg0)
 }
 
+// LeadershipImbalance mocks base method.
+func (m *MockKafkaClient) LeadershipImbalance() (kafkaclient.LeadershipImbalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeadershipImbalance")
+	ret0, _ := ret[0].(kafkaclient.LeadershipImbalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LeadershipImbalance indicates an expected call of LeadershipImbalance.
+func (mr *MockKafkaClientMockRecorder) LeadershipImbalance() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeadershipImbalance", reflect.TypeOf((*MockKafkaClient)(nil).LeadershipImbalance))
+}
+
+// ListACLBindings mocks base method.
+func (m *MockKafkaClient) ListACLBindings(arg0 string) ([]kafkaclient.ACLBinding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListACLBindings", arg0)
+	ret0, _ := ret[0].([]kafkaclient.ACLBinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListACLBindings indicates an expected call of ListACLBindings.
+func (mr *MockKafkaClientMockRecorder) ListACLBindings(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListACLBindings", reflect.TypeOf((*MockKafkaClient)(nil).ListACLBindings), arg0)
+}
+
+// ListPartitionReassignments mocks base method.
+func (m *MockKafkaClient) ListPartitionReassignments(arg0 string, arg1 []int32) (map[int32]*sarama.PartitionReplicaReassignmentsStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPartitionReassignments", arg0, arg1)
+	ret0, _ := ret[0].(map[int32]*sarama.PartitionReplicaReassignmentsStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPartitionReassignments indicates an expected call of ListPartitionReassignments.
+func (mr *MockKafkaClientMockRecorder) ListPartitionReassignments(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPartitionReassignments", reflect.TypeOf((*MockKafkaClient)(nil).ListPartitionReassignments), arg0, arg1)
+}
+
 // ListTopics mocks base method.
 func (m *MockKafkaClient) ListTopics() (map[string]sarama.TopicDetail, error) {
 	m.ctrl.T.Helper()
@@ -320,6 +540,21 @@ func (mr *MockKafkaClientMockRecorder) ListUserACLs() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserACLs", reflect.TypeOf((*MockKafkaClient)(nil).ListUserACLs))
 }
 
+// ListUserScramCredentials mocks base method.
+func (m *MockKafkaClient) ListUserScramCredentials() (map[string][]v1alpha1.UserAuthenticationType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserScramCredentials")
+	ret0, _ := ret[0].(map[string][]v1alpha1.UserAuthenticationType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserScramCredentials indicates an expected call of ListUserScramCredentials.
+func (mr *MockKafkaClientMockRecorder) ListUserScramCredentials() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserScramCredentials", reflect.TypeOf((*MockKafkaClient)(nil).ListUserScramCredentials))
+}
+
 // NumBrokers mocks base method.
 func (m *MockKafkaClient) NumBrokers() int {
 	m.ctrl.T.Helper()
@@ -363,6 +598,93 @@ func (mr *MockKafkaClientMockRecorder) OutOfSyncReplicas() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutOfSyncReplicas", reflect.TypeOf((*MockKafkaClient)(nil).OutOfSyncReplicas))
 }
 
+// PartitionLeaderCounts mocks base method.
+func (m *MockKafkaClient) PartitionLeaderCounts() (map[int32]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PartitionLeaderCounts")
+	ret0, _ := ret[0].(map[int32]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PartitionLeaderCounts indicates an expected call of PartitionLeaderCounts.
+func (mr *MockKafkaClientMockRecorder) PartitionLeaderCounts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PartitionLeaderCounts", reflect.TypeOf((*MockKafkaClient)(nil).PartitionLeaderCounts))
+}
+
+// ProduceConsumeSmokeTest mocks base method.
+func (m *MockKafkaClient) ProduceConsumeSmokeTest(arg0 string, arg1 []int32, arg2 time.Duration) (map[int32]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProduceConsumeSmokeTest", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[int32]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProduceConsumeSmokeTest indicates an expected call of ProduceConsumeSmokeTest.
+func (mr *MockKafkaClientMockRecorder) ProduceConsumeSmokeTest(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProduceConsumeSmokeTest", reflect.TypeOf((*MockKafkaClient)(nil).ProduceConsumeSmokeTest), arg0, arg1, arg2)
+}
+
+// ReassignPartitions mocks base method.
+func (m *MockKafkaClient) ReassignPartitions(arg0 string, arg1 map[int32][]int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignPartitions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReassignPartitions indicates an expected call of ReassignPartitions.
+func (mr *MockKafkaClientMockRecorder) ReassignPartitions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignPartitions", reflect.TypeOf((*MockKafkaClient)(nil).ReassignPartitions), arg0, arg1)
+}
+
+// ReplicaPeers mocks base method.
+func (m *MockKafkaClient) ReplicaPeers(arg0 int32) ([]int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplicaPeers", arg0)
+	ret0, _ := ret[0].([]int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplicaPeers indicates an expected call of ReplicaPeers.
+func (mr *MockKafkaClientMockRecorder) ReplicaPeers(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplicaPeers", reflect.TypeOf((*MockKafkaClient)(nil).ReplicaPeers), arg0)
+}
+
+// SetPerBrokerConfig mocks base method.
+func (m *MockKafkaClient) SetPerBrokerConfig(arg0 int32, arg1 map[string]*string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPerBrokerConfig", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPerBrokerConfig indicates an expected call of SetPerBrokerConfig.
+func (mr *MockKafkaClientMockRecorder) SetPerBrokerConfig(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPerBrokerConfig", reflect.TypeOf((*MockKafkaClient)(nil).SetPerBrokerConfig), arg0, arg1)
+}
+
+// SetTopicConfig mocks base method.
+func (m *MockKafkaClient) SetTopicConfig(arg0 string, arg1 map[string]*string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTopicConfig", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTopicConfig indicates an expected call of SetTopicConfig.
+func (mr *MockKafkaClientMockRecorder) SetTopicConfig(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTopicConfig", reflect.TypeOf((*MockKafkaClient)(nil).SetTopicConfig), arg0, arg1)
+}
+
 // TopicMetaToStatus mocks base method.
 func (m *MockKafkaClient) TopicMetaToStatus(meta *sarama.TopicMetadata) *v1alpha1.KafkaTopicStatus {
 	m.ctrl.T.Helper()
@@ -376,3 +698,32 @@ func (mr *MockKafkaClientMockRecorder) TopicMetaToStatus(meta any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopicMetaToStatus", reflect.TypeOf((*MockKafkaClient)(nil).TopicMetaToStatus), meta)
 }
+
+// UpsertUserScramCredential mocks base method.
+func (m *MockKafkaClient) UpsertUserScramCredential(arg0 string, arg1 v1alpha1.UserAuthenticationType, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserScramCredential", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertUserScramCredential indicates an expected call of UpsertUserScramCredential.
+func (mr *MockKafkaClientMockRecorder) UpsertUserScramCredential(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserScramCredential", reflect.TypeOf((*MockKafkaClient)(nil).UpsertUserScramCredential), arg0, arg1, arg2)
+}
+
+// UserScramCredentialExists mocks base method.
+func (m *MockKafkaClient) UserScramCredentialExists(arg0 string, arg1 v1alpha1.UserAuthenticationType) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserScramCredentialExists", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserScramCredentialExists indicates an expected call of UserScramCredentialExists.
+func (mr *MockKafkaClientMockRecorder) UserScramCredentialExists(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserScramCredentialExists", reflect.TypeOf((*MockKafkaClient)(nil).UserScramCredentialExists), arg0, arg1)
+}
@@ -0,0 +1,171 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"emperror.dev/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	"github.com/banzaicloud/koperator/pkg/util"
+	kafkautils "github.com/banzaicloud/koperator/pkg/util/kafka"
+	"github.com/banzaicloud/koperator/pkg/util/oidc"
+)
+
+const (
+	oauthBearerMechanism                = "OAUTHBEARER"
+	oauthBearerLoginModule              = "org.apache.kafka.common.security.oauthbearer.OAuthBearerLoginModule"
+	oauthBearerValidatorCallbackHandler = "org.apache.kafka.common.security.oauthbearer.OAuthBearerValidatorCallbackHandler"
+	oauthBearerCAVolumeNameTemplate     = "listener-%s-oauthbearer-ca"
+	oauthBearerCAPath                   = "/var/run/secrets/oauthbearer"
+	oauthBearerCAFile                   = "ca.crt"
+)
+
+// oauthBearerListeners returns the listeners authenticating the clients with SASL/OAUTHBEARER
+func oauthBearerListeners(l v1beta1.ListenersConfig) []v1beta1.CommonListenerSpec {
+	var listeners []v1beta1.CommonListenerSpec
+	for _, iListener := range l.InternalListeners {
+		if iListener.OAuthBearer != nil && iListener.Type.IsSasl() {
+			listeners = append(listeners, iListener.CommonListenerSpec)
+		}
+	}
+	for _, eListener := range l.ExternalListeners {
+		if eListener.OAuthBearer != nil && eListener.Type.IsSasl() {
+			listeners = append(listeners, eListener.CommonListenerSpec)
+		}
+	}
+	return listeners
+}
+
+// resolveOAuthBearerConfigs returns the SASL/OAUTHBEARER configs of the listeners by name with the endpoints which are
+// not set explicitly discovered from the OpenID Connect provider of the issuer
+func (r *Reconciler) resolveOAuthBearerConfigs(ctx context.Context) (map[string]v1beta1.OAuthBearerConfig, error) {
+	configs := make(map[string]v1beta1.OAuthBearerConfig)
+	for _, listener := range oauthBearerListeners(r.KafkaCluster.Spec.ListenersConfig) {
+		config := *listener.OAuthBearer
+		if config.IssuerURL != "" && (config.JWKSEndpointURL == "" || config.TokenEndpointURL == "") {
+			metadata, err := r.discoverOAuthBearerProvider(ctx, config)
+			if err != nil {
+				return nil, errorfactory.New(errorfactory.ResourceNotReady{}, err,
+					"could not discover the OpenID Connect provider of the listener", "listener", listener.Name)
+			}
+			if config.JWKSEndpointURL == "" {
+				config.JWKSEndpointURL = metadata.JWKSURI
+			}
+			if config.TokenEndpointURL == "" {
+				config.TokenEndpointURL = metadata.TokenEndpoint
+			}
+		}
+		configs[listener.Name] = config
+	}
+	return configs, nil
+}
+
+func (r *Reconciler) discoverOAuthBearerProvider(ctx context.Context, config v1beta1.OAuthBearerConfig) (*oidc.ProviderMetadata, error) {
+	var caBundle []byte
+	if config.TrustedCASecretRef != nil {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, client.ObjectKey{Name: config.TrustedCASecretRef.Name, Namespace: r.KafkaCluster.Namespace}, secret); err != nil {
+			return nil, errors.WrapIfWithDetails(err, "could not get the CA certificates of the OpenID Connect provider",
+				"secret", config.TrustedCASecretRef.Name)
+		}
+		caBundle = secret.Data[config.TrustedCASecretRef.Key]
+	}
+	httpClient, err := oidc.NewHTTPClient(caBundle)
+	if err != nil {
+		return nil, err
+	}
+	return oidc.Discover(ctx, httpClient, config.IssuerURL)
+}
+
+func generateListenerOAuthBearerConfig(name string, oauthBearer v1beta1.OAuthBearerConfig) map[string]string {
+	listenerConfigKey := func(key string) string {
+		return fmt.Sprintf("%s.%s.%s", kafkautils.KafkaConfigListenerName, name, key)
+	}
+	mechanismConfigKey := func(key string) string {
+		return fmt.Sprintf(saslMechanismConfigKeyTemplate, kafkautils.KafkaConfigListenerName, name, strings.ToLower(oauthBearerMechanism), key)
+	}
+
+	jaasOptions := []string{oauthBearerLoginModule, "required"}
+	if oauthBearer.Scope != "" {
+		jaasOptions = append(jaasOptions, fmt.Sprintf("scope=%q", oauthBearer.Scope))
+	}
+	if oauthBearer.TrustedCASecretRef != nil {
+		jaasOptions = append(jaasOptions,
+			fmt.Sprintf("ssl.truststore.location=%q", fmt.Sprintf("%s/%s/%s", oauthBearerCAPath, name, oauthBearerCAFile)),
+			fmt.Sprintf("ssl.truststore.type=%q", "PEM"))
+	}
+
+	listenerConfig := map[string]string{
+		mechanismConfigKey(kafkautils.KafkaConfigSASLServerCallbackHandlerClass): oauthBearerValidatorCallbackHandler,
+		mechanismConfigKey(kafkautils.KafkaConfigSASLJAASConfig):                 strings.Join(jaasOptions, " ") + ";",
+	}
+	if oauthBearer.JWKSEndpointURL != "" {
+		listenerConfig[listenerConfigKey(kafkautils.KafkaConfigSASLOAuthBearerJWKSEndpointURL)] = oauthBearer.JWKSEndpointURL
+	}
+	if oauthBearer.TokenEndpointURL != "" {
+		listenerConfig[listenerConfigKey(kafkautils.KafkaConfigSASLOAuthBearerTokenEndpointURL)] = oauthBearer.TokenEndpointURL
+	}
+	if len(oauthBearer.ExpectedAudience) > 0 {
+		listenerConfig[listenerConfigKey(kafkautils.KafkaConfigSASLOAuthBearerExpectedAudience)] = strings.Join(oauthBearer.ExpectedAudience, ",")
+	}
+	if oauthBearer.IssuerURL != "" {
+		listenerConfig[listenerConfigKey(kafkautils.KafkaConfigSASLOAuthBearerExpectedIssuer)] = oauthBearer.IssuerURL
+	}
+	return listenerConfig
+}
+
+// generateVolumesForOAuthBearerCAs returns the volumes of the CA certificates of the OpenID Connect providers of the
+// SASL/OAUTHBEARER listeners
+func generateVolumesForOAuthBearerCAs(l v1beta1.ListenersConfig) (ret []corev1.Volume) {
+	for _, listener := range oauthBearerListeners(l) {
+		caRef := listener.OAuthBearer.TrustedCASecretRef
+		if caRef == nil {
+			continue
+		}
+		ret = append(ret, corev1.Volume{
+			Name: fmt.Sprintf(oauthBearerCAVolumeNameTemplate, listener.Name),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  caRef.Name,
+					Items:       []corev1.KeyToPath{{Key: caRef.Key, Path: oauthBearerCAFile}},
+					DefaultMode: util.Int32Pointer(0644),
+				},
+			},
+		})
+	}
+	return ret
+}
+
+func generateVolumeMountsForOAuthBearerCAs(l v1beta1.ListenersConfig) (ret []corev1.VolumeMount) {
+	for _, listener := range oauthBearerListeners(l) {
+		if listener.OAuthBearer.TrustedCASecretRef == nil {
+			continue
+		}
+		ret = append(ret, corev1.VolumeMount{
+			Name:      fmt.Sprintf(oauthBearerCAVolumeNameTemplate, listener.Name),
+			MountPath: fmt.Sprintf("%s/%s", oauthBearerCAPath, listener.Name),
+			ReadOnly:  true,
+		})
+	}
+	return ret
+}
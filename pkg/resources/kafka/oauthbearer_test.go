@@ -0,0 +1,68 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/resources"
+	"github.com/banzaicloud/koperator/pkg/util/oidc"
+)
+
+func TestResolveOAuthBearerConfigs(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewEncoder(w).Encode(oidc.ProviderMetadata{
+			Issuer:        server.URL,
+			TokenEndpoint: server.URL + "/token",
+			JWKSURI:       server.URL + "/keys",
+		}))
+	}))
+	defer server.Close()
+
+	cluster := &v1beta1.KafkaCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+		Spec: v1beta1.KafkaClusterSpec{
+			ListenersConfig: v1beta1.ListenersConfig{
+				InternalListeners: []v1beta1.InternalListenerConfig{{CommonListenerSpec: v1beta1.CommonListenerSpec{
+					Name: "internal", Type: v1beta1.SecurityProtocolSaslPlaintext,
+					OAuthBearer: &v1beta1.OAuthBearerConfig{IssuerURL: server.URL, JWKSEndpointURL: "https://keys.example.com"},
+				}}},
+				ExternalListeners: []v1beta1.ExternalListenerConfig{{CommonListenerSpec: v1beta1.CommonListenerSpec{
+					Name: "external", Type: v1beta1.SecurityProtocolSaslSSL,
+					OAuthBearer: &v1beta1.OAuthBearerConfig{IssuerURL: server.URL},
+				}}},
+			},
+		},
+	}
+	r := Reconciler{Reconciler: resources.Reconciler{Client: fake.NewClientBuilder().Build(), KafkaCluster: cluster}}
+
+	configs, err := r.resolveOAuthBearerConfigs(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]v1beta1.OAuthBearerConfig{
+		"internal": {IssuerURL: server.URL, JWKSEndpointURL: "https://keys.example.com", TokenEndpointURL: server.URL + "/token"},
+		"external": {IssuerURL: server.URL, JWKSEndpointURL: server.URL + "/keys", TokenEndpointURL: server.URL + "/token"},
+	}, configs)
+}
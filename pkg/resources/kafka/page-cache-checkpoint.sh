@@ -0,0 +1,25 @@
+# Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+# Copyright 2025 Adobe. All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+#
+# Usage: page-cache-checkpoint.sh <max segments per storage> <storage mount path>...
+# Records the most recently modified log segments of the given storages so page-cache-warmup.sh can read them back.
+MAXSEGMENTS=$1
+shift
+for MOUNTPATH in "$@"; do
+  LOGDIR="$MOUNTPATH/kafka"
+  [[ -d "$LOGDIR" ]] || continue
+  find "$LOGDIR" -type f -name '*.log' -printf '%T@ %p\n' 2>/dev/null \
+    | sort -rn | head -n "$MAXSEGMENTS" | cut -d' ' -f2- > "$MOUNTPATH/.page-cache-checkpoint"
+done
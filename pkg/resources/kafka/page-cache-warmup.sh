@@ -0,0 +1,33 @@
+# Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+# Copyright 2025 Adobe. All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+#
+# Usage: page-cache-warmup.sh <read index files: true|false> <storage mount path>...
+# Reads the index files and the checkpointed log segments of the given storages into the page cache.
+READ_INDEX_FILES=$1
+shift
+for MOUNTPATH in "$@"; do
+  LOGDIR="$MOUNTPATH/kafka"
+  if [[ "$READ_INDEX_FILES" == "true" && -d "$LOGDIR" ]]; then
+    find "$LOGDIR" -type f \( -name '*.index' -o -name '*.timeindex' \) -exec cat {} + > /dev/null 2>&1
+  fi
+  CHECKPOINT="$MOUNTPATH/.page-cache-checkpoint"
+  if [[ -f "$CHECKPOINT" ]]; then
+    while read -r SEGMENT; do
+      [[ -f "$SEGMENT" ]] && cat "$SEGMENT" > /dev/null 2>&1
+    done < "$CHECKPOINT"
+    rm -f "$CHECKPOINT"
+  fi
+done
+exit 0
@@ -18,6 +18,7 @@ package kafka
 import (
 	_ "embed"
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -31,10 +32,10 @@ import (
 	"github.com/banzaicloud/koperator/api/assets"
 	apiutil "github.com/banzaicloud/koperator/api/util"
 	"github.com/banzaicloud/koperator/api/v1beta1"
-	"github.com/banzaicloud/koperator/pkg/k8sutil"
 	"github.com/banzaicloud/koperator/pkg/resources/kafkamonitoring"
 	"github.com/banzaicloud/koperator/pkg/resources/templates"
 	"github.com/banzaicloud/koperator/pkg/util"
+	istioingressutils "github.com/banzaicloud/koperator/pkg/util/istioingress"
 	kafkautils "github.com/banzaicloud/koperator/pkg/util/kafka"
 	pkicommon "github.com/banzaicloud/koperator/pkg/util/pki"
 )
@@ -42,12 +43,31 @@ import (
 var (
 	//go:embed wait-for-envoy-sidecar.sh
 	envoySidecarScript string
+	//go:embed page-cache-warmup.sh
+	pageCacheWarmupScript string
+	//go:embed page-cache-checkpoint.sh
+	pageCacheCheckpointScript string
 )
 
+const kafkaPreStopScript = `
+if [[ -n "$ENVOY_SIDECAR_STATUS" ]]; then
+  HEALTHYSTATUSCODE="200"
+  SC=$(curl -s -o /dev/null -w "%{http_code}" http://localhost:15000/ready)
+  if [[ "$SC" == "$HEALTHYSTATUSCODE" ]]; then
+    kill -s TERM $(pidof java)
+  else
+    kill -s KILL $(pidof java)
+  fi
+else
+  kill -s TERM $(pidof java)
+fi`
+
 func (r *Reconciler) pod(id int32, brokerConfig *v1beta1.BrokerConfig, pvcs []corev1.PersistentVolumeClaim, log logr.Logger) runtime.Object {
 	const kafkaContainerName = "kafka"
 
 	dataVolume, dataVolumeMount := generateDataVolumeAndVolumeMount(pvcs, brokerConfig.StorageConfigs)
+	configSecretVolumes, configSecretVolumeMounts := generateVolumesForBrokerConfigSecrets(r.brokerConfigSecretNames(id, log))
+	tieredStorageVolumes, tieredStorageVolumeMounts := generateVolumesForTieredStoragePlugin(brokerConfig, r.KafkaCluster.Spec)
 
 	// TODO remove this bash envoy sidecar checker script once sidecar precedence becomes available to Kubernetes(baluchicken)
 	command := []string{"bash", "-c", envoySidecarScript}
@@ -59,26 +79,9 @@ func (r *Reconciler) pod(id int32, brokerConfig *v1beta1.BrokerConfig, pvcs []co
 	}
 
 	kafkaContainer := corev1.Container{
-		Name:  kafkaContainerName,
-		Image: util.GetBrokerImage(brokerConfig, r.KafkaCluster.Spec.GetClusterImage()),
-		Lifecycle: &corev1.Lifecycle{
-			PreStop: &corev1.LifecycleHandler{
-				Exec: &corev1.ExecAction{
-					Command: []string{"bash", "-c", `
-if [[ -n "$ENVOY_SIDECAR_STATUS" ]]; then
-  HEALTHYSTATUSCODE="200"
-  SC=$(curl -s -o /dev/null -w "%{http_code}" http://localhost:15000/ready)
-  if [[ "$SC" == "$HEALTHYSTATUSCODE" ]]; then
-    kill -s TERM $(pidof java)
-  else
-    kill -s KILL $(pidof java)
-  fi
-else
-  kill -s TERM $(pidof java)
-fi`},
-				},
-			},
-		},
+		Name:            kafkaContainerName,
+		Image:           util.GetBrokerImage(brokerConfig, r.KafkaCluster.Spec.GetClusterImage()),
+		Lifecycle:       generateKafkaContainerLifecycle(brokerConfig, dataVolumeMount),
 		SecurityContext: brokerConfig.SecurityContext,
 		Env: generateEnvConfig(brokerConfig, []corev1.EnvVar{
 			{
@@ -87,7 +90,7 @@ fi`},
 			},
 			{
 				Name:  "KAFKA_OPTS",
-				Value: "-javaagent:/opt/jmx-exporter/jmx_prometheus.jar=9020:/etc/jmx-exporter/config.yaml",
+				Value: fmt.Sprintf("-javaagent:/opt/jmx-exporter/jmx_prometheus.jar=%d:/etc/jmx-exporter/config.yaml", brokerConfig.GetJmxExporterPort()),
 			},
 			{
 				Name: "ENVOY_SIDECAR_STATUS",
@@ -100,8 +103,8 @@ fi`},
 		}),
 
 		Command:      command,
-		Ports:        r.generateKafkaContainerPorts(log),
-		VolumeMounts: getVolumeMounts(brokerConfig.VolumeMounts, dataVolumeMount, r.KafkaCluster.Spec, r.KafkaCluster.Name),
+		Ports:        r.generateKafkaContainerPorts(brokerConfig, log),
+		VolumeMounts: getVolumeMounts(slices.Concat(brokerConfig.VolumeMounts, configSecretVolumeMounts, tieredStorageVolumeMounts), dataVolumeMount, r.KafkaCluster.Spec, r.KafkaCluster.Name),
 		Resources:    *brokerConfig.GetResources(),
 	}
 
@@ -138,10 +141,15 @@ fi`},
 		}
 	}
 
+	podLabels := brokerConfig.GetBrokerLabels(r.KafkaCluster.Name, id, r.KafkaCluster.Spec.KRaftMode)
+	if r.KafkaCluster.Spec.IsIstioAmbientMode() {
+		podLabels = apiutil.MergeLabels(podLabels, istioingressutils.AmbientPodLabels(r.KafkaCluster.Name))
+	}
+
 	pod := &corev1.Pod{
 		ObjectMeta: templates.ObjectMetaWithGeneratedNameAndAnnotations(
 			podname,
-			brokerConfig.GetBrokerLabels(r.KafkaCluster.Name, id, r.KafkaCluster.Spec.KRaftMode),
+			podLabels,
 			brokerConfig.GetBrokerAnnotations(),
 			r.KafkaCluster,
 		),
@@ -150,7 +158,7 @@ fi`},
 			InitContainers:                getInitContainers(brokerConfig, r.KafkaCluster.Spec),
 			Affinity:                      getAffinity(brokerConfig, r.KafkaCluster),
 			Containers:                    append([]corev1.Container{kafkaContainer}, brokerConfig.Containers...),
-			Volumes:                       getVolumes(brokerConfig.Volumes, dataVolume, r.KafkaCluster.Spec, r.KafkaCluster.Name, id),
+			Volumes:                       getVolumes(slices.Concat(brokerConfig.Volumes, configSecretVolumes, tieredStorageVolumes), dataVolume, r.KafkaCluster.Spec, r.KafkaCluster.Name, id, brokerConfig),
 			RestartPolicy:                 corev1.RestartPolicyNever,
 			TerminationGracePeriodSeconds: util.Int64Pointer(brokerConfig.GetTerminationGracePeriod()),
 			ImagePullSecrets:              brokerConfig.GetImagePullSecrets(),
@@ -158,8 +166,13 @@ fi`},
 			Tolerations:                   brokerConfig.GetTolerations(),
 			NodeSelector:                  brokerConfig.GetNodeSelector(),
 			PriorityClassName:             brokerConfig.GetPriorityClassName(),
+			TopologySpreadConstraints:     getTopologySpreadConstraints(brokerConfig, r.KafkaCluster, id),
 		},
 	}
+	// the readiness of the brokers is gated on the broker readiness expression evaluated by Koperator
+	if r.KafkaCluster.Spec.BrokerReadiness != nil && !brokerConfig.IsControllerOnlyNode() {
+		pod.Spec.ReadinessGates = []corev1.PodReadinessGate{{ConditionType: v1beta1.BrokerReadyConditionType}}
+	}
 	if r.KafkaCluster.Spec.HeadlessServiceEnabled {
 		pod.Spec.Hostname = fmt.Sprintf("%s-%d", r.KafkaCluster.Name, id)
 
@@ -210,7 +223,7 @@ func addClusterIdEnv(r *Reconciler, pod *corev1.Pod, i int) {
 	)
 }
 
-func (r *Reconciler) generateKafkaContainerPorts(log logr.Logger) []corev1.ContainerPort {
+func (r *Reconciler) generateKafkaContainerPorts(brokerConfig *v1beta1.BrokerConfig, log logr.Logger) []corev1.ContainerPort {
 	var kafkaContainerPorts []corev1.ContainerPort
 
 	for _, eListener := range r.KafkaCluster.Spec.ListenersConfig.ExternalListeners {
@@ -252,7 +265,7 @@ func (r *Reconciler) generateKafkaContainerPorts(log logr.Logger) []corev1.Conta
 
 	// container port for metrics
 	kafkaContainerPorts = append(kafkaContainerPorts, corev1.ContainerPort{
-		ContainerPort: 9020,
+		ContainerPort: brokerConfig.GetJmxExporterPort(),
 		Protocol:      corev1.ProtocolTCP,
 		Name:          "metrics",
 	})
@@ -273,22 +286,26 @@ func getInitContainers(brokerConfig *v1beta1.BrokerConfig, kafkaClusterSpec v1be
 				Name:      "extensions",
 				MountPath: "/opt/kafka/libs/extensions",
 			}},
-			Resources: k8sutil.GetDefaultInitContainerResourceRequirements(),
+			Resources: *brokerConfig.GetMetricsReporterInitContainerResources(),
 		},
 		{
 			Name:    "jmx-exporter",
-			Image:   kafkaClusterSpec.MonitoringConfig.GetImage(),
-			Command: []string{"cp", kafkaClusterSpec.MonitoringConfig.GetPathToJar(), "/opt/jmx-exporter/jmx_prometheus.jar"},
+			Image:   brokerConfig.GetJmxExporterImage(&kafkaClusterSpec.MonitoringConfig),
+			Command: []string{"cp", brokerConfig.GetJmxExporterPathToJar(&kafkaClusterSpec.MonitoringConfig), "/opt/jmx-exporter/jmx_prometheus.jar"},
 			VolumeMounts: []corev1.VolumeMount{
 				{
 					Name:      jmxVolumeName,
 					MountPath: jmxVolumePath,
 				},
 			},
-			Resources: k8sutil.GetDefaultInitContainerResourceRequirements(),
+			Resources: *brokerConfig.GetJmxExporterInitContainerResources(),
 		},
 	}...)
 
+	if hasTieredStoragePlugin(brokerConfig, kafkaClusterSpec) {
+		initContainers = append(initContainers, generateTieredStoragePluginInitContainer(kafkaClusterSpec.TieredStorage))
+	}
+
 	sort.Slice(initContainers, func(i, j int) bool {
 		return initContainers[i].Name < initContainers[j].Name
 	})
@@ -308,6 +325,8 @@ func getVolumeMounts(brokerConfigVolumeMounts, dataVolumeMount []corev1.VolumeMo
 	}
 
 	volumeMounts = append(volumeMounts, generateVolumeMountForListenerCerts(kafkaClusterSpec.ListenersConfig)...)
+	volumeMounts = append(volumeMounts, generateVolumeMountsForOAuthBearerCAs(kafkaClusterSpec.ListenersConfig)...)
+	volumeMounts = append(volumeMounts, generateVolumeMountsForKerberos(kafkaClusterSpec.ListenersConfig)...)
 	volumeMounts = append(volumeMounts, []corev1.VolumeMount{
 		{
 			Name:      brokerConfigMapVolumeMount,
@@ -338,7 +357,8 @@ func getVolumeMounts(brokerConfigVolumeMounts, dataVolumeMount []corev1.VolumeMo
 	return volumeMounts
 }
 
-func getVolumes(brokerConfigVolumes, dataVolume []corev1.Volume, kafkaClusterSpec v1beta1.KafkaClusterSpec, kafkaClusterName string, id int32) []corev1.Volume {
+func getVolumes(brokerConfigVolumes, dataVolume []corev1.Volume, kafkaClusterSpec v1beta1.KafkaClusterSpec, kafkaClusterName string, id int32,
+	brokerConfig *v1beta1.BrokerConfig) []corev1.Volume {
 	volumes := make([]corev1.Volume, 0, len(brokerConfigVolumes))
 	// clone the brokerConfig volumes
 	volumes = append(volumes, brokerConfigVolumes...)
@@ -349,6 +369,8 @@ func getVolumes(brokerConfigVolumes, dataVolume []corev1.Volume, kafkaClusterSpe
 	}
 
 	volumes = append(volumes, generateVolumesForListenerCerts(kafkaClusterSpec.ListenersConfig, kafkaClusterName)...)
+	volumes = append(volumes, generateVolumesForOAuthBearerCAs(kafkaClusterSpec.ListenersConfig)...)
+	volumes = append(volumes, generateVolumesForKerberos(kafkaClusterSpec.ListenersConfig)...)
 	volumes = append(volumes, []corev1.Volume{
 		{
 			Name: "exitfile",
@@ -374,10 +396,7 @@ func getVolumes(brokerConfigVolumes, dataVolume []corev1.Volume, kafkaClusterSpe
 		{
 			Name: fmt.Sprintf(kafkamonitoring.BrokerJmxTemplate, kafkaClusterName),
 			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf(kafkamonitoring.BrokerJmxTemplate, kafkaClusterName)},
-					DefaultMode:          util.Int32Pointer(0644),
-				},
+				ConfigMap: generateJmxExporterConfigVolumeSource(brokerConfig, kafkaClusterName),
 			},
 		},
 		{
@@ -397,11 +416,59 @@ func getVolumes(brokerConfigVolumes, dataVolume []corev1.Volume, kafkaClusterSpe
 
 // getAffinity returns a default `v1.Affinity` which is generated regarding the `OneBrokerPerNode` value
 // or if there is any user Affinity definition provided by the user the latter will be used ignoring the value of `OneBrokerPerNode`
+// When the placement policy of the broker lists zones, the node affinity restricting the pod to them is added unless
+// the user defined node affinity
 func getAffinity(bc *v1beta1.BrokerConfig, cluster *v1beta1.KafkaCluster) *corev1.Affinity {
-	if bc.Affinity == nil {
-		return &corev1.Affinity{PodAntiAffinity: generatePodAntiAffinity(cluster.Name, cluster.Spec.OneBrokerPerNode)}
+	affinity := bc.Affinity
+	if affinity == nil {
+		affinity = &corev1.Affinity{PodAntiAffinity: generatePodAntiAffinity(cluster.Name, cluster.Spec.OneBrokerPerNode)}
+	}
+	if bc.PlacementPolicy != nil && len(bc.PlacementPolicy.Zones) > 0 && affinity.NodeAffinity == nil {
+		affinity = affinity.DeepCopy()
+		affinity.NodeAffinity = generateZoneNodeAffinity(bc.PlacementPolicy)
+	}
+	return affinity
+}
+
+func generateZoneNodeAffinity(policy *v1beta1.BrokerPlacementPolicy) *corev1.NodeAffinity {
+	return &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{
+					MatchExpressions: []corev1.NodeSelectorRequirement{
+						{
+							Key:      policy.GetTopologyKey(),
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   policy.Zones,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// getTopologySpreadConstraints returns the topology spread constraints generated from the placement policy of the
+// broker. The pods are spread together with the other pods of the cluster having the same process roles, so the
+// brokers and the KRaft controllers are distributed evenly across the failure domains independently of each other.
+func getTopologySpreadConstraints(bc *v1beta1.BrokerConfig, cluster *v1beta1.KafkaCluster, id int32) []corev1.TopologySpreadConstraint {
+	if bc.PlacementPolicy == nil {
+		return nil
+	}
+	podLabels := bc.GetBrokerLabels(cluster.Name, id, cluster.Spec.KRaftMode)
+	return []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           bc.PlacementPolicy.GetMaxSkew(),
+			TopologyKey:       bc.PlacementPolicy.GetTopologyKey(),
+			WhenUnsatisfiable: bc.PlacementPolicy.GetWhenUnsatisfiable(),
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: apiutil.MergeLabels(apiutil.LabelsForKafka(cluster.Name), map[string]string{
+					v1beta1.IsControllerNodeKey: podLabels[v1beta1.IsControllerNodeKey],
+					v1beta1.IsBrokerNodeKey:     podLabels[v1beta1.IsBrokerNodeKey],
+				}),
+			},
+		},
 	}
-	return bc.Affinity
 }
 
 func generatePodAntiAffinity(clusterName string, hardRuleEnabled bool) *corev1.PodAntiAffinity {
@@ -486,16 +553,11 @@ func generateDataVolumeAndVolumeMount(pvcs []corev1.PersistentVolumeClaim, stora
 }
 
 func generateVolumeForListenersCertsFromCommonSpec(commonSpec v1beta1.CommonListenerSpec, clusterName string) corev1.Volume {
-	// Use default one if custom has not specified
-	secretName := fmt.Sprintf(pkicommon.BrokerServerCertTemplate, clusterName)
-	if commonSpec.GetServerSSLCertSecretName() != "" {
-		secretName = commonSpec.GetServerSSLCertSecretName()
-	}
 	return corev1.Volume{
 		Name: fmt.Sprintf(listenerSSLCertVolumeNameTemplate, commonSpec.Name),
 		VolumeSource: corev1.VolumeSource{
 			Secret: &corev1.SecretVolumeSource{
-				SecretName:  secretName,
+				SecretName:  pkicommon.ListenerServerCertSecretName(clusterName, commonSpec),
 				DefaultMode: util.Int32Pointer(0644),
 			},
 		},
@@ -652,3 +714,42 @@ func findControllerListenerPort(kc *v1beta1.KafkaCluster) (int32, error) {
 	// If no controller listener is found, return an error
 	return 0, fmt.Errorf("no controller listener found")
 }
+
+// generateKafkaContainerLifecycle returns the lifecycle hooks of the kafka container. The preStop hook always stops
+// the broker, optionally after checkpointing the active log segments, while the postStart hook is only set when
+// a custom handler or page cache warmup is configured for the broker.
+func generateKafkaContainerLifecycle(brokerConfig *v1beta1.BrokerConfig, dataVolumeMount []corev1.VolumeMount) *corev1.Lifecycle {
+	mountPaths := make([]string, 0, len(dataVolumeMount))
+	for _, vm := range dataVolumeMount {
+		mountPaths = append(mountPaths, vm.MountPath)
+	}
+
+	preStopCommand := []string{"bash", "-c", kafkaPreStopScript}
+	var postStart *corev1.LifecycleHandler
+
+	if warmup := brokerConfig.GetPageCacheWarmup(); warmup != nil {
+		if warmup.PreserveActiveSegments {
+			preStopCommand = append([]string{"bash", "-c", pageCacheCheckpointScript + "\n" + kafkaPreStopScript,
+				"page-cache-checkpoint", strconv.Itoa(int(warmup.GetMaxSegmentsPerStorage()))}, mountPaths...)
+		}
+		postStart = &corev1.LifecycleHandler{
+			Exec: &corev1.ExecAction{
+				Command: append([]string{"bash", "-c", pageCacheWarmupScript,
+					"page-cache-warmup", strconv.FormatBool(warmup.GetIndexFiles())}, mountPaths...),
+			},
+		}
+	}
+
+	if hook := brokerConfig.GetPostStartHook(); hook != nil {
+		postStart = hook.DeepCopy()
+	}
+
+	return &corev1.Lifecycle{
+		PostStart: postStart,
+		PreStop: &corev1.LifecycleHandler{
+			Exec: &corev1.ExecAction{
+				Command: preStopCommand,
+			},
+		},
+	}
+}
@@ -185,3 +185,177 @@ func Test_generateEnvConfig(t *testing.T) {
 		t.Error("Expected:", expected, "Got:", result)
 	}
 }
+
+func Test_generateKafkaContainerLifecycle(t *testing.T) {
+	dataVolumeMount := []corev1.VolumeMount{
+		{Name: "kafka-data-0", MountPath: "/kafka-logs"},
+		{Name: "kafka-data-1", MountPath: "/kafka-logs2"},
+	}
+	customPostStart := &corev1.LifecycleHandler{
+		Exec: &corev1.ExecAction{Command: []string{"vmtouch", "-t", "/kafka-logs"}},
+	}
+	indexFiles := false
+	maxSegments := int32(10)
+
+	tests := []struct {
+		testName          string
+		lifecycle         *v1beta1.BrokerLifecycle
+		expectedPostStart *corev1.LifecycleHandler
+		expectedPreStop   []string
+	}{
+		{
+			testName:        "no lifecycle config",
+			expectedPreStop: []string{"bash", "-c", kafkaPreStopScript},
+		},
+		{
+			testName:          "custom postStart hook",
+			lifecycle:         &v1beta1.BrokerLifecycle{PostStart: customPostStart},
+			expectedPostStart: customPostStart,
+			expectedPreStop:   []string{"bash", "-c", kafkaPreStopScript},
+		},
+		{
+			testName:  "page cache warmup with defaults",
+			lifecycle: &v1beta1.BrokerLifecycle{PageCacheWarmup: &v1beta1.PageCacheWarmup{}},
+			expectedPostStart: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{
+					Command: []string{"bash", "-c", pageCacheWarmupScript, "page-cache-warmup", "true", "/kafka-logs", "/kafka-logs2"},
+				},
+			},
+			expectedPreStop: []string{"bash", "-c", kafkaPreStopScript},
+		},
+		{
+			testName: "page cache warmup preserving active segments",
+			lifecycle: &v1beta1.BrokerLifecycle{PageCacheWarmup: &v1beta1.PageCacheWarmup{
+				IndexFiles:             &indexFiles,
+				PreserveActiveSegments: true,
+				MaxSegmentsPerStorage:  &maxSegments,
+			}},
+			expectedPostStart: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{
+					Command: []string{"bash", "-c", pageCacheWarmupScript, "page-cache-warmup", "false", "/kafka-logs", "/kafka-logs2"},
+				},
+			},
+			expectedPreStop: []string{"bash", "-c", pageCacheCheckpointScript + "\n" + kafkaPreStopScript,
+				"page-cache-checkpoint", "10", "/kafka-logs", "/kafka-logs2"},
+		},
+		{
+			testName: "custom postStart hook takes precedence over page cache warmup",
+			lifecycle: &v1beta1.BrokerLifecycle{
+				PostStart:       customPostStart,
+				PageCacheWarmup: &v1beta1.PageCacheWarmup{PreserveActiveSegments: true},
+			},
+			expectedPostStart: customPostStart,
+			expectedPreStop: []string{"bash", "-c", pageCacheCheckpointScript + "\n" + kafkaPreStopScript,
+				"page-cache-checkpoint", "50", "/kafka-logs", "/kafka-logs2"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			lifecycle := generateKafkaContainerLifecycle(&v1beta1.BrokerConfig{Lifecycle: test.lifecycle}, dataVolumeMount)
+			assert.DeepEqual(t, lifecycle.PostStart, test.expectedPostStart)
+			assert.DeepEqual(t, lifecycle.PreStop.Exec.Command, test.expectedPreStop)
+		})
+	}
+}
+
+func Test_getTopologySpreadConstraints(t *testing.T) {
+	maxSkew := int32(2)
+	cluster := &v1beta1.KafkaCluster{ObjectMeta: metav1.ObjectMeta{Name: "kafka"}}
+
+	tests := []struct {
+		testName            string
+		kRaftMode           bool
+		brokerConfig        *v1beta1.BrokerConfig
+		expectedConstraints []corev1.TopologySpreadConstraint
+		expectedAffinity    *corev1.NodeAffinity
+	}{
+		{
+			testName:     "no placement policy",
+			brokerConfig: &v1beta1.BrokerConfig{},
+		},
+		{
+			testName:     "placement policy with defaults",
+			brokerConfig: &v1beta1.BrokerConfig{PlacementPolicy: &v1beta1.BrokerPlacementPolicy{}},
+			expectedConstraints: []corev1.TopologySpreadConstraint{
+				{
+					MaxSkew:           1,
+					TopologyKey:       corev1.LabelTopologyZone,
+					WhenUnsatisfiable: corev1.DoNotSchedule,
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							v1beta1.AppLabelKey: "kafka", v1beta1.KafkaCRLabelKey: "kafka",
+							v1beta1.IsControllerNodeKey: "false", v1beta1.IsBrokerNodeKey: "true",
+						},
+					},
+				},
+			},
+		},
+		{
+			testName:  "KRaft controllers spread across the listed zones",
+			kRaftMode: true,
+			brokerConfig: &v1beta1.BrokerConfig{
+				Roles: []string{"controller"},
+				PlacementPolicy: &v1beta1.BrokerPlacementPolicy{
+					TopologyKey:       "failure-domain",
+					MaxSkew:           &maxSkew,
+					WhenUnsatisfiable: corev1.ScheduleAnyway,
+					Zones:             []string{"a", "b", "c"},
+				},
+			},
+			expectedConstraints: []corev1.TopologySpreadConstraint{
+				{
+					MaxSkew:           2,
+					TopologyKey:       "failure-domain",
+					WhenUnsatisfiable: corev1.ScheduleAnyway,
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							v1beta1.AppLabelKey: "kafka", v1beta1.KafkaCRLabelKey: "kafka",
+							v1beta1.IsControllerNodeKey: "true", v1beta1.IsBrokerNodeKey: "false",
+						},
+					},
+				},
+			},
+			expectedAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{
+						{
+							MatchExpressions: []corev1.NodeSelectorRequirement{
+								{Key: "failure-domain", Operator: corev1.NodeSelectorOpIn, Values: []string{"a", "b", "c"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			testName: "user defined node affinity takes precedence over the zones",
+			brokerConfig: &v1beta1.BrokerConfig{
+				Affinity:        &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{}},
+				PlacementPolicy: &v1beta1.BrokerPlacementPolicy{MaxSkew: &maxSkew, Zones: []string{"a"}},
+			},
+			expectedConstraints: []corev1.TopologySpreadConstraint{
+				{
+					MaxSkew:           2,
+					TopologyKey:       corev1.LabelTopologyZone,
+					WhenUnsatisfiable: corev1.DoNotSchedule,
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							v1beta1.AppLabelKey: "kafka", v1beta1.KafkaCRLabelKey: "kafka",
+							v1beta1.IsControllerNodeKey: "false", v1beta1.IsBrokerNodeKey: "true",
+						},
+					},
+				},
+			},
+			expectedAffinity: &corev1.NodeAffinity{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			cluster.Spec.KRaftMode = test.kRaftMode
+			assert.DeepEqual(t, getTopologySpreadConstraints(test.brokerConfig, cluster, 0), test.expectedConstraints)
+			assert.DeepEqual(t, getAffinity(test.brokerConfig, cluster).NodeAffinity, test.expectedAffinity)
+		})
+	}
+}
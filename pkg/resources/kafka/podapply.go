@@ -0,0 +1,135 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	banzaiv1beta1 "github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+)
+
+// brokerPodFieldManager is the field manager owning the fields of the broker pods set by the operator, the fields set
+// by other controllers (e.g. the labels and annotations of admission webhooks) are left to them
+const brokerPodFieldManager = "koperator"
+
+// restartingMetadataPrefixes are the prefixes of the labels and annotations read by admission webhooks when the pod is
+// created, e.g. sidecar injection, their change is only effective after the restart of the broker
+var restartingMetadataPrefixes = []string{
+	"sidecar.istio.io/",
+	"proxy.istio.io/",
+	"istio.io/rev",
+	"linkerd.io/",
+	"config.linkerd.io/",
+}
+
+// isMetadataOnlyPatch returns true when the patch between the current and the desired broker pod only adds or changes
+// labels and annotations which the running pod picks up without a restart. Removals are not applied in place as the
+// labels and annotations set at the creation of the pods are not owned through server-side apply.
+func isMetadataOnlyPatch(podPatch []byte) bool {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(podPatch, &fields); err != nil || len(fields) != 1 || fields["metadata"] == nil {
+		return false
+	}
+	var metadata map[string]map[string]*string
+	if err := json.Unmarshal(fields["metadata"], &metadata); err != nil {
+		return false
+	}
+	for field, values := range metadata {
+		if field != "labels" && field != "annotations" || values == nil {
+			return false
+		}
+		for key, value := range values {
+			if value == nil || hasRestartingMetadataPrefix(key) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func hasRestartingMetadataPrefix(key string) bool {
+	for _, prefix := range restartingMetadataPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyBrokerPodMetadata applies the labels and annotations of the desired pod to the running broker pod through
+// server-side apply instead of restarting the broker
+func (r *Reconciler) applyBrokerPodMetadata(log logr.Logger, desiredPod, currentPod *corev1.Pod) error {
+	podApply := corev1ac.Pod(currentPod.Name, currentPod.Namespace).
+		WithLabels(desiredPod.Labels).
+		WithAnnotations(desiredPod.Annotations)
+	if err := r.Apply(context.TODO(), podApply, client.FieldOwner(brokerPodFieldManager), client.ForceOwnership); err != nil {
+		return errorfactory.New(errorfactory.APIFailure{}, err, "applying the labels and annotations of the broker pod failed",
+			"pod", currentPod.Name)
+	}
+	log.Info("labels and annotations of the broker pod applied without restart", "pod", currentPod.Name,
+		banzaiv1beta1.BrokerIdLabelKey, currentPod.Labels[banzaiv1beta1.BrokerIdLabelKey])
+	return nil
+}
+
+// brokerPodLostNode returns the reason why the node of the broker pod is considered lost when the force deletion of the
+// pods of lost nodes is enabled: the node was removed or it was fenced by the out-of-service taint. The kubelet of a lost
+// node never confirms the removal of the pod, so the broker would not be recreated until the node returns. An unreachable
+// node is not lost on its own, its broker may still be running and a second broker with the same id must not be started.
+func (r *Reconciler) brokerPodLostNode(pod *corev1.Pod) (string, error) {
+	rescheduling := r.KafkaCluster.Spec.BrokerRescheduling
+	if rescheduling == nil || !rescheduling.ForceDeleteLostNodePods || pod.Spec.NodeName == "" {
+		return "", nil
+	}
+	node := &corev1.Node{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: pod.Spec.NodeName}, node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "the node has been removed", nil
+		}
+		return "", errors.WrapIfWithDetails(err, "could not get the node of the broker pod", "node", pod.Spec.NodeName)
+	}
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == corev1.TaintNodeOutOfService {
+			return "the node is out of service", nil
+		}
+	}
+	return "", nil
+}
+
+// forceDeleteBrokerPod deletes the pod of the broker from a lost node without waiting for its kubelet, so that the pod
+// is recreated on another node
+func (r *Reconciler) forceDeleteBrokerPod(log logr.Logger, pod *corev1.Pod, reason string) error {
+	if err := r.Delete(context.TODO(), pod, client.GracePeriodSeconds(0)); client.IgnoreNotFound(err) != nil {
+		return errorfactory.New(errorfactory.APIFailure{}, err, "force deleting the broker pod failed", "pod", pod.Name)
+	}
+	brokerID := pod.Labels[banzaiv1beta1.BrokerIdLabelKey]
+	log.Info("broker pod force deleted from its lost node", "pod", pod.Name, banzaiv1beta1.BrokerIdLabelKey, brokerID,
+		"node", pod.Spec.NodeName, "reason", reason)
+	k8sutil.RecordEvent(r.Recorder, r.KafkaCluster, corev1.EventTypeWarning, brokerPodNodeLostEventReason,
+		"the pod %s of broker %s on node %s has been force deleted to be recreated elsewhere as %s", pod.Name, brokerID, pod.Spec.NodeName, reason)
+	return nil
+}
@@ -0,0 +1,185 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/banzaicloud/k8s-objectmatcher/patch"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func TestIsMetadataOnlyPatch(t *testing.T) {
+	testCases := []struct {
+		testName string
+		patch    string
+		expected bool
+	}{
+		{
+			testName: "changed labels and annotations",
+			patch:    `{"metadata":{"labels":{"team":"streaming"},"annotations":{"prometheus.io/scrape":"true"}}}`,
+			expected: true,
+		},
+		{
+			testName: "removed label",
+			patch:    `{"metadata":{"labels":{"team":null}}}`,
+		},
+		{
+			testName: "removed annotations",
+			patch:    `{"metadata":{"annotations":null}}`,
+		},
+		{
+			testName: "sidecar injection annotation",
+			patch:    `{"metadata":{"annotations":{"sidecar.istio.io/inject":"false"}}}`,
+		},
+		{
+			testName: "owner references",
+			patch:    `{"metadata":{"ownerReferences":[]}}`,
+		},
+		{
+			testName: "labels and spec",
+			patch:    `{"metadata":{"labels":{"team":"streaming"}},"spec":{"containers":[{"name":"kafka","image":"kafka:4.0.0"}]}}`,
+		},
+		{
+			testName: "invalid patch",
+			patch:    `[]`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			require.Equal(t, test.expected, isMetadataOnlyPatch([]byte(test.patch)))
+		})
+	}
+}
+
+func TestBrokerPodLostNode(t *testing.T) {
+	node := func(name string, ready corev1.ConditionStatus, taints ...corev1.Taint) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       corev1.NodeSpec{Taints: taints},
+			Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}}},
+		}
+	}
+	pod := func(nodeName string, terminating bool) *corev1.Pod {
+		p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "kafka-0-abcde", Namespace: "kafka"}, Spec: corev1.PodSpec{NodeName: nodeName}}
+		if terminating {
+			p.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		}
+		return p
+	}
+	outOfService := corev1.Taint{Key: corev1.TaintNodeOutOfService, Value: "nodeshutdown", Effect: corev1.TaintEffectNoExecute}
+
+	testCases := []struct {
+		testName     string
+		rescheduling *v1beta1.BrokerReschedulingConfig
+		pod          *corev1.Pod
+		expected     string
+	}{
+		{testName: "unscheduled pod", rescheduling: &v1beta1.BrokerReschedulingConfig{ForceDeleteLostNodePods: true}, pod: pod("", false)},
+		{testName: "ready node", rescheduling: &v1beta1.BrokerReschedulingConfig{ForceDeleteLostNodePods: true}, pod: pod("ready", true)},
+		{
+			testName:     "removed node",
+			rescheduling: &v1beta1.BrokerReschedulingConfig{ForceDeleteLostNodePods: true},
+			pod:          pod("removed", false),
+			expected:     "the node has been removed",
+		},
+		{testName: "removed node without force deletion", pod: pod("removed", false)},
+		{
+			testName:     "removed node with force deletion disabled",
+			rescheduling: &v1beta1.BrokerReschedulingConfig{Policy: v1beta1.BrokerReschedulingPolicyRecreateVolumes},
+			pod:          pod("removed", false),
+		},
+		{
+			testName:     "terminating pod on an unreachable node",
+			rescheduling: &v1beta1.BrokerReschedulingConfig{ForceDeleteLostNodePods: true},
+			pod:          pod("unreachable", true),
+		},
+		{
+			testName:     "pod on an out of service node",
+			rescheduling: &v1beta1.BrokerReschedulingConfig{ForceDeleteLostNodePods: true},
+			pod:          pod("fenced", false),
+			expected:     "the node is out of service",
+		},
+		{testName: "pod on an out of service node without force deletion", pod: pod("fenced", true)},
+	}
+
+	c := fake.NewClientBuilder().WithObjects(
+		node("ready", corev1.ConditionTrue),
+		node("unreachable", corev1.ConditionUnknown),
+		node("fenced", corev1.ConditionUnknown, outOfService),
+	).Build()
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			r := New(c, c, &v1beta1.KafkaCluster{Spec: v1beta1.KafkaClusterSpec{BrokerRescheduling: test.rescheduling}}, nil, nil)
+			reason, err := r.brokerPodLostNode(test.pod)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, reason)
+		})
+	}
+}
+
+func TestHandleRollingUpgradeAppliesMetadataInPlace(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	cluster := &v1beta1.KafkaCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+		Status: v1beta1.KafkaClusterStatus{
+			BrokersState: map[string]v1beta1.BrokerState{"0": {ConfigurationState: v1beta1.ConfigInSync}},
+		},
+	}
+	desiredPod := func(labels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kafka", GenerateName: "kafka-0-", Labels: labels},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "kafka", Image: "kafka:3.9.0"}}},
+		}
+	}
+	currentPod := desiredPod(map[string]string{v1beta1.BrokerIdLabelKey: "0"})
+	require.NoError(t, patch.DefaultAnnotator.SetLastAppliedAnnotation(currentPod))
+	currentPod.Name = "kafka-0-abcde"
+	// a label set by another controller
+	currentPod.Labels["topology.example.com/zone"] = "a"
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, currentPod).Build()
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(currentPod), currentPod))
+
+	r := New(c, c, cluster, nil, nil)
+	desired := desiredPod(map[string]string{v1beta1.BrokerIdLabelKey: "0", "team": "streaming"})
+	require.NoError(t, r.handleRollingUpgrade(logf.Log, desired, currentPod, reflect.TypeOf(desired)))
+
+	updated := &corev1.Pod{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(currentPod), updated), "the pod must not be restarted")
+	require.Equal(t, map[string]string{v1beta1.BrokerIdLabelKey: "0", "team": "streaming", "topology.example.com/zone": "a"}, updated.Labels)
+
+	// the applied labels are recorded, the next reconciliation finds the pod in sync
+	result, err := patch.DefaultPatchMaker.Calculate(updated, desiredPod(map[string]string{v1beta1.BrokerIdLabelKey: "0", "team": "streaming"}))
+	require.NoError(t, err)
+	require.True(t, result.IsEmpty())
+}
@@ -16,6 +16,7 @@
 package kafka
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math"
@@ -23,10 +24,12 @@ import (
 	"strings"
 
 	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
 	"github.com/banzaicloud/koperator/pkg/resources/templates"
 	"github.com/banzaicloud/koperator/pkg/util"
 
@@ -70,6 +73,22 @@ func (r *Reconciler) podDisruptionBudgetControllers(log logr.Logger) (runtime.Ob
 		minAvailable)
 }
 
+// deleteSingleNodePodDisruptionBudgets deletes the pod disruption budgets of the brokers and the controllers of a
+// single-node cluster
+func (r *Reconciler) deleteSingleNodePodDisruptionBudgets(ctx context.Context, log logr.Logger) error {
+	for _, name := range []string{fmt.Sprintf("%s-pdb", r.KafkaCluster.Name), fmt.Sprintf("%s-controller-pdb", r.KafkaCluster.Name)} {
+		pdb := &policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: r.KafkaCluster.Namespace}}
+		if err := r.Client.Delete(ctx, pdb); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return errorfactory.New(errorfactory.APIFailure{}, err, "could not delete pod disruption budget of single-node cluster", "name", name)
+		}
+		log.Info("pod disruption budget of single-node cluster deleted", "name", name)
+	}
+	return nil
+}
+
 func (r *Reconciler) podDisruptionBudget(name string, podSelectorLabels map[string]string, minAvailable intstr.IntOrString) (runtime.Object, error) {
 	return &policyv1.PodDisruptionBudget{
 		TypeMeta: metav1.TypeMeta{
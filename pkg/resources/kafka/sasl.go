@@ -0,0 +1,99 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	kafkautils "github.com/banzaicloud/koperator/pkg/util/kafka"
+	properties "github.com/banzaicloud/koperator/properties/pkg"
+)
+
+// saslMechanismConfigKeyTemplate is the template of the configuration keys of a SASL mechanism of a listener
+const saslMechanismConfigKeyTemplate = "%s.%s.%s.%s"
+
+// configureSASLListeners enables the SASL mechanisms configured on the SASL listeners: SASL/OAUTHBEARER validating
+// the tokens of an OpenID Connect provider and SASL/GSSAPI with the Kerberos principals of the brokers. The mechanisms
+// enabled on the listeners through the read-only configuration are kept.
+func configureSASLListeners(brokerID int32, l v1beta1.ListenersConfig, resolvedOAuthBearer map[string]v1beta1.OAuthBearerConfig,
+	config, brokerReadOnlyConfig *properties.Properties, log logr.Logger) {
+	listeners := make([]v1beta1.CommonListenerSpec, 0, len(l.InternalListeners)+len(l.ExternalListeners))
+	for _, iListener := range l.InternalListeners {
+		listeners = append(listeners, iListener.CommonListenerSpec)
+	}
+	for _, eListener := range l.ExternalListeners {
+		listeners = append(listeners, eListener.CommonListenerSpec)
+	}
+
+	for i, listener := range listeners {
+		if !listener.Type.IsSasl() {
+			continue
+		}
+		var mechanisms []string
+		listenerConfig := make(map[string]string)
+		if listener.OAuthBearer != nil {
+			oauthBearer, ok := resolvedOAuthBearer[listener.Name]
+			if !ok {
+				oauthBearer = *listener.OAuthBearer
+			}
+			mechanisms = append(mechanisms, oauthBearerMechanism)
+			maps.Copy(listenerConfig, generateListenerOAuthBearerConfig(listener.Name, oauthBearer))
+		}
+		if listener.Kerberos != nil {
+			mechanisms = append(mechanisms, kerberosMechanism)
+			maps.Copy(listenerConfig, generateListenerKerberosConfig(brokerID, listener.Name, *listener.Kerberos))
+
+			// the brokers authenticate with their own principals when they connect to each other on the listener
+			if listener.UsedForInnerBrokerCommunication {
+				setIfNotConfigured(listenerConfig, brokerReadOnlyConfig, kafkautils.KafkaConfigSASLMechanismInterBrokerProtocol, kerberosMechanism)
+			}
+			if i < len(l.InternalListeners) && l.InternalListeners[i].UsedForControllerCommunication {
+				setIfNotConfigured(listenerConfig, brokerReadOnlyConfig, kafkautils.KafkaConfigSASLMechanismControllerProtocol, kerberosMechanism)
+			}
+		}
+		if len(mechanisms) == 0 {
+			continue
+		}
+
+		enabledMechanismsKey := fmt.Sprintf("%s.%s.%s", kafkautils.KafkaConfigListenerName, listener.Name, kafkautils.KafkaConfigSASLEnabledMechanisms)
+		if enabled, found := brokerReadOnlyConfig.Get(enabledMechanismsKey); found {
+			for _, mechanism := range strings.Split(enabled.Value(), ",") {
+				if mechanism = strings.TrimSpace(mechanism); mechanism != "" && !slices.Contains(mechanisms, mechanism) {
+					mechanisms = append(mechanisms, mechanism)
+				}
+			}
+		}
+		listenerConfig[enabledMechanismsKey] = strings.Join(mechanisms, ",")
+
+		for k, v := range listenerConfig {
+			if err := config.Set(k, v); err != nil {
+				log.Error(err, fmt.Sprintf(kafkautils.BrokerConfigErrorMsgTemplate, k))
+			}
+		}
+	}
+}
+
+func setIfNotConfigured(listenerConfig map[string]string, brokerReadOnlyConfig *properties.Properties, key, value string) {
+	if _, found := brokerReadOnlyConfig.Get(key); !found {
+		listenerConfig[key] = value
+	}
+}
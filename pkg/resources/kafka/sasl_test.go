@@ -0,0 +1,127 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	properties "github.com/banzaicloud/koperator/properties/pkg"
+)
+
+func TestConfigureSASLListeners(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		listener       v1beta1.InternalListenerConfig
+		resolved       map[string]v1beta1.OAuthBearerConfig
+		readOnlyConfig string
+		expectedConfig string
+	}{
+		{
+			testName: "non SASL listener",
+			listener: v1beta1.InternalListenerConfig{CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolSSL,
+				OAuthBearer: &v1beta1.OAuthBearerConfig{JWKSEndpointURL: "https://idp.example.com/keys"}}},
+		},
+		{
+			testName: "explicit endpoints with truststore",
+			listener: v1beta1.InternalListenerConfig{CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolSaslSSL,
+				OAuthBearer: &v1beta1.OAuthBearerConfig{
+					JWKSEndpointURL:    "https://idp.example.com/keys",
+					TokenEndpointURL:   "https://idp.example.com/token",
+					ExpectedAudience:   []string{"kafka", "admin"},
+					Scope:              "kafka",
+					TrustedCASecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "idp-ca"}, Key: "ca.pem"},
+				}}},
+			expectedConfig: `listener.name.internal.oauthbearer.sasl.jaas.config=org.apache.kafka.common.security.oauthbearer.OAuthBearerLoginModule required scope="kafka" ssl.truststore.location="/var/run/secrets/oauthbearer/internal/ca.crt" ssl.truststore.type="PEM";
+listener.name.internal.oauthbearer.sasl.server.callback.handler.class=org.apache.kafka.common.security.oauthbearer.OAuthBearerValidatorCallbackHandler
+listener.name.internal.sasl.enabled.mechanisms=OAUTHBEARER
+listener.name.internal.sasl.oauthbearer.expected.audience=kafka,admin
+listener.name.internal.sasl.oauthbearer.jwks.endpoint.url=https://idp.example.com/keys
+listener.name.internal.sasl.oauthbearer.token.endpoint.url=https://idp.example.com/token
+`,
+		},
+		{
+			testName: "discovered endpoints and mechanisms enabled through the read-only config",
+			listener: v1beta1.InternalListenerConfig{CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolSaslPlaintext,
+				OAuthBearer: &v1beta1.OAuthBearerConfig{IssuerURL: "https://idp.example.com"}}},
+			resolved: map[string]v1beta1.OAuthBearerConfig{"internal": {
+				IssuerURL:        "https://idp.example.com",
+				JWKSEndpointURL:  "https://idp.example.com/discovered/keys",
+				TokenEndpointURL: "https://idp.example.com/discovered/token",
+			}},
+			readOnlyConfig: "listener.name.internal.sasl.enabled.mechanisms=SCRAM-SHA-512,OAUTHBEARER",
+			expectedConfig: `listener.name.internal.oauthbearer.sasl.jaas.config=org.apache.kafka.common.security.oauthbearer.OAuthBearerLoginModule required;
+listener.name.internal.oauthbearer.sasl.server.callback.handler.class=org.apache.kafka.common.security.oauthbearer.OAuthBearerValidatorCallbackHandler
+listener.name.internal.sasl.enabled.mechanisms=OAUTHBEARER,SCRAM-SHA-512
+listener.name.internal.sasl.oauthbearer.expected.issuer=https://idp.example.com
+listener.name.internal.sasl.oauthbearer.jwks.endpoint.url=https://idp.example.com/discovered/keys
+listener.name.internal.sasl.oauthbearer.token.endpoint.url=https://idp.example.com/discovered/token
+`,
+		},
+		{
+			testName: "kerberos listener used for inter broker and controller communication",
+			listener: v1beta1.InternalListenerConfig{
+				CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolSaslSSL,
+					UsedForInnerBrokerCommunication: true,
+					Kerberos: &v1beta1.KerberosConfig{
+						PrincipalTemplate: "kafka/kafka-%id.kafka-headless.kafka.svc.cluster.local@EXAMPLE.COM",
+						KeytabSecretRef:   corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "kafka-keytab"}, Key: "keytab"},
+					}},
+				UsedForControllerCommunication: true,
+			},
+			readOnlyConfig: "sasl.mechanism.controller.protocol=PLAIN",
+			expectedConfig: `listener.name.internal.gssapi.sasl.jaas.config=com.sun.security.auth.module.Krb5LoginModule required useKeyTab=true storeKey=true keyTab="/var/run/secrets/kerberos/internal/kafka.keytab" principal="kafka/kafka-2.kafka-headless.kafka.svc.cluster.local@EXAMPLE.COM";
+listener.name.internal.sasl.enabled.mechanisms=GSSAPI
+listener.name.internal.sasl.kerberos.service.name=kafka
+sasl.mechanism.inter.broker.protocol=GSSAPI
+`,
+		},
+		{
+			testName: "oauthbearer and kerberos on the same listener",
+			listener: v1beta1.InternalListenerConfig{CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolSaslPlaintext,
+				OAuthBearer: &v1beta1.OAuthBearerConfig{JWKSEndpointURL: "https://idp.example.com/keys"},
+				Kerberos: &v1beta1.KerberosConfig{
+					ServiceName:       "broker",
+					PrincipalTemplate: "broker/kafka.example.com@EXAMPLE.COM",
+					KeytabSecretRef:   corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "kafka-keytab"}, Key: "keytab"},
+				}}},
+			expectedConfig: `listener.name.internal.gssapi.sasl.jaas.config=com.sun.security.auth.module.Krb5LoginModule required useKeyTab=true storeKey=true keyTab="/var/run/secrets/kerberos/internal/kafka.keytab" principal="broker/kafka.example.com@EXAMPLE.COM";
+listener.name.internal.oauthbearer.sasl.jaas.config=org.apache.kafka.common.security.oauthbearer.OAuthBearerLoginModule required;
+listener.name.internal.oauthbearer.sasl.server.callback.handler.class=org.apache.kafka.common.security.oauthbearer.OAuthBearerValidatorCallbackHandler
+listener.name.internal.sasl.enabled.mechanisms=OAUTHBEARER,GSSAPI
+listener.name.internal.sasl.kerberos.service.name=broker
+listener.name.internal.sasl.oauthbearer.jwks.endpoint.url=https://idp.example.com/keys
+`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			readOnlyConfig, err := properties.NewFromString(test.readOnlyConfig)
+			require.NoError(t, err)
+			config := properties.NewProperties()
+			configureSASLListeners(2, v1beta1.ListenersConfig{
+				InternalListeners: []v1beta1.InternalListenerConfig{test.listener},
+			}, test.resolved, config, readOnlyConfig, logr.Discard())
+			config.Sort()
+			require.Equal(t, test.expectedConfig, config.String())
+		})
+	}
+}
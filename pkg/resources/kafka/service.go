@@ -27,7 +27,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 )
 
-func (r *Reconciler) service(id int32, _ *v1beta1.BrokerConfig) runtime.Object {
+func (r *Reconciler) service(id int32, brokerConfig *v1beta1.BrokerConfig) runtime.Object {
 	var usedPorts []corev1.ServicePort
 	// Append internal listener ports
 	usedPorts = append(usedPorts,
@@ -42,7 +42,7 @@ func (r *Reconciler) service(id int32, _ *v1beta1.BrokerConfig) runtime.Object {
 	usedPorts = append(usedPorts, corev1.ServicePort{
 		Name:       "metrics",
 		Port:       MetricsPort,
-		TargetPort: intstr.FromInt(MetricsPort),
+		TargetPort: intstr.FromInt(int(brokerConfig.GetJmxExporterPort())),
 		Protocol:   corev1.ProtocolTCP,
 	})
 
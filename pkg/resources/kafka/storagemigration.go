@@ -0,0 +1,262 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"strconv"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/util"
+	kafkautils "github.com/banzaicloud/koperator/pkg/util/kafka"
+)
+
+// reconcileStorageMigrations moves the volumes of the brokers to the StorageClass set in their storage config when it
+// differs from the StorageClass of the existing volume. A migration goes through the following phases:
+//   - AddingVolume: a volume of the new StorageClass is attached to the broker next to the old one, and Cruise Control
+//     rebalances the replicas onto it by the graceful disk rebalance
+//   - DrainingVolume: the old volume is removed by the graceful disk removal, which moves its replicas off, restarts the
+//     broker without it and deletes it
+//   - SwappingMounts: the new volume is mounted at the mount path of the old one by a rolling restart of the broker
+func (r *Reconciler) reconcileStorageMigrations(ctx context.Context, log logr.Logger, replacements map[string]v1beta1.BrokerReplacementPhase) error {
+	for _, broker := range r.KafkaCluster.Spec.Brokers {
+		brokerID := strconv.Itoa(int(broker.Id))
+		brokerState, ok := r.KafkaCluster.Status.BrokersState[brokerID]
+		// there is nothing to migrate before the broker is created and while it is replaced
+		if !ok || replacements[brokerID] == v1beta1.BrokerReplacementDeleting {
+			continue
+		}
+		brokerConfig, err := broker.GetBrokerConfig(r.KafkaCluster.Spec)
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "failed to get the config of the broker", v1beta1.BrokerIdLabelKey, brokerID)
+		}
+		// the controllers have a single volume, which can not be drained
+		if brokerConfig.IsControllerNode() {
+			continue
+		}
+
+		pvcList := &corev1.PersistentVolumeClaimList{}
+		if err := r.List(ctx, pvcList, client.InNamespace(r.KafkaCluster.Namespace), client.MatchingLabels(apiutil.MergeLabels(
+			apiutil.LabelsForKafka(r.KafkaCluster.Name), map[string]string{v1beta1.BrokerIdLabelKey: brokerID}))); err != nil {
+			return errors.WrapIfWithDetails(err, "failed to list the persistent volume claims of the broker", v1beta1.BrokerIdLabelKey, brokerID)
+		}
+		pvcs := make(map[string]*corev1.PersistentVolumeClaim, len(pvcList.Items))
+		for i := range pvcList.Items {
+			pvcs[pvcList.Items[i].Annotations["mountPath"]] = &pvcList.Items[i]
+		}
+
+		migrations := make(v1beta1.StorageMigrationStates, len(brokerState.StorageMigrations))
+		for mountPath, migration := range brokerState.StorageMigrations {
+			migrations[mountPath] = migration
+		}
+		// the migrations of the volumes removed from the spec are dropped, the new volume is removed with them
+		changed := false
+		for mountPath := range migrations {
+			if !hasStorageConfig(brokerConfig.StorageConfigs, mountPath) {
+				delete(migrations, mountPath)
+				changed = true
+			}
+		}
+
+		for _, storage := range brokerConfig.StorageConfigs {
+			migration, inProgress := migrations[storage.MountPath]
+			if !inProgress {
+				if storage.PvcSpec == nil || storage.PvcSpec.StorageClassName == nil {
+					continue
+				}
+				pvc, found := pvcs[storage.MountPath]
+				if !found || (pvc.Spec.StorageClassName != nil && *pvc.Spec.StorageClassName == *storage.PvcSpec.StorageClassName) {
+					continue
+				}
+				migrations[storage.MountPath] = v1beta1.StorageMigrationState{
+					Phase:            v1beta1.StorageMigrationAddingVolume,
+					StorageClassName: *storage.PvcSpec.StorageClassName,
+					LastUpdateTime:   metav1.Now(),
+				}
+				changed = true
+				log.Info("storage migration started", v1beta1.BrokerIdLabelKey, brokerID, "mountPath", storage.MountPath,
+					"storageClassName", *storage.PvcSpec.StorageClassName)
+				continue
+			}
+
+			next := migration
+			switch migration.Phase {
+			case v1beta1.StorageMigrationAddingVolume:
+				newVolumeState := r.KafkaCluster.Status.BrokersState[brokerID].GracefulActionState.VolumeStates[kafkautils.StorageMigrationMountPath(storage.MountPath)]
+				if newVolumeState.CruiseControlVolumeState.IsDiskRebalanceSucceeded() {
+					next.Phase = v1beta1.StorageMigrationDrainingVolume
+				}
+			case v1beta1.StorageMigrationDrainingVolume:
+				// the graceful disk removal deletes the old volume once the broker has been restarted without it
+				if _, found := pvcs[storage.MountPath]; !found {
+					next.Phase = v1beta1.StorageMigrationSwappingMounts
+					next.LastUpdateTime = metav1.Now()
+					migrations[storage.MountPath] = next
+					// the phase is saved before the new volume is moved, otherwise a failed status update would leave
+					// the migration draining a volume which is not there anymore
+					if err := k8sutil.UpdateBrokerStatus(r.Client, []string{brokerID}, r.KafkaCluster, migrations, log); err != nil {
+						return errors.WrapIfWithDetails(err, "could not update the storage migrations of the broker", v1beta1.BrokerIdLabelKey, brokerID)
+					}
+					log.Info("storage migration progressed", v1beta1.BrokerIdLabelKey, brokerID, "mountPath", storage.MountPath, "phase", next.Phase)
+					if err := r.swapStorageMigrationMount(ctx, log, brokerID, storage.MountPath, pvcs); err != nil {
+						return err
+					}
+					continue
+				}
+			case v1beta1.StorageMigrationSwappingMounts:
+				// the new volume is moved again if the swap was interrupted after the phase had been saved
+				if err := r.swapStorageMigrationMount(ctx, log, brokerID, storage.MountPath, pvcs); err != nil {
+					return err
+				}
+				pvc, found := pvcs[storage.MountPath]
+				if !found {
+					return errors.NewWithDetails("the new volume of the storage migration is missing",
+						v1beta1.BrokerIdLabelKey, brokerID, "mountPath", storage.MountPath)
+				}
+				mounted, err := r.isPvcMountedByBrokerAt(ctx, brokerID, pvc.Name, storage.MountPath)
+				if err != nil {
+					return err
+				}
+				if mounted {
+					delete(migrations, storage.MountPath)
+					changed = true
+					log.Info("storage migration finished", v1beta1.BrokerIdLabelKey, brokerID, "mountPath", storage.MountPath,
+						"storageClassName", migration.StorageClassName)
+					continue
+				}
+			default:
+				return errors.NewWithDetails("unknown storage migration phase", v1beta1.BrokerIdLabelKey, brokerID,
+					"mountPath", storage.MountPath, "phase", migration.Phase)
+			}
+			if next.Phase != migration.Phase {
+				next.LastUpdateTime = metav1.Now()
+				migrations[storage.MountPath] = next
+				changed = true
+				log.Info("storage migration progressed", v1beta1.BrokerIdLabelKey, brokerID, "mountPath", storage.MountPath, "phase", next.Phase)
+			}
+		}
+
+		if changed {
+			if err := k8sutil.UpdateBrokerStatus(r.Client, []string{brokerID}, r.KafkaCluster, migrations, log); err != nil {
+				return errors.WrapIfWithDetails(err, "could not update the storage migrations of the broker", v1beta1.BrokerIdLabelKey, brokerID)
+			}
+		}
+	}
+	return nil
+}
+
+// swapStorageMigrationMount moves the new volume of the storage migration to the mount path of the removed old volume.
+// The replicas have been rebalanced onto the new volume already, so it takes over the volume state of the old one.
+// It does nothing when the new volume has been moved already.
+func (r *Reconciler) swapStorageMigrationMount(ctx context.Context, log logr.Logger, brokerID, mountPath string,
+	pvcs map[string]*corev1.PersistentVolumeClaim) error {
+	newMountPath := kafkautils.StorageMigrationMountPath(mountPath)
+	_, newPvcFound := pvcs[newMountPath]
+	_, newVolumeStateFound := r.KafkaCluster.Status.BrokersState[brokerID].GracefulActionState.VolumeStates[newMountPath]
+	if !newPvcFound && !newVolumeStateFound {
+		return nil
+	}
+	if pvc, found := pvcs[newMountPath]; found {
+		pvc.Annotations["mountPath"] = mountPath
+		if err := r.Update(ctx, pvc); err != nil {
+			return errors.WrapIfWithDetails(err, "could not move the new volume of the storage migration to its mount path",
+				v1beta1.BrokerIdLabelKey, brokerID, "mountPath", mountPath)
+		}
+		delete(pvcs, newMountPath)
+		pvcs[mountPath] = pvc
+		log.Info("new volume of the storage migration moved to the mount path of the old one", v1beta1.BrokerIdLabelKey, brokerID,
+			"mountPath", mountPath, "name", pvc.Name)
+	}
+
+	if err := k8sutil.DeleteVolumeStatus(r.Client, brokerID, newMountPath, r.KafkaCluster, log); err != nil {
+		return errors.WrapIfWithDetails(err, "could not delete volume status for broker volume", v1beta1.BrokerIdLabelKey, brokerID, "mountPath", newMountPath)
+	}
+	volumeStates := map[string]v1beta1.VolumeState{mountPath: {CruiseControlVolumeState: v1beta1.GracefulDiskRebalanceSucceeded}}
+	if err := k8sutil.UpdateBrokerStatus(r.Client, []string{brokerID}, r.KafkaCluster, volumeStates, log); err != nil {
+		return errors.WrapIfWithDetails(err, "could not update volume status for broker volume", v1beta1.BrokerIdLabelKey, brokerID, "mountPath", mountPath)
+	}
+	return nil
+}
+
+// isPvcMountedByBrokerAt returns true if a pod of the broker mounts the PVC at the given mount path
+func (r *Reconciler) isPvcMountedByBrokerAt(ctx context.Context, brokerID, pvcName, mountPath string) (bool, error) {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(r.KafkaCluster.Namespace), client.MatchingLabels(apiutil.MergeLabels(
+		apiutil.LabelsForKafka(r.KafkaCluster.Name), map[string]string{v1beta1.BrokerIdLabelKey: brokerID}))); err != nil {
+		return false, errors.WrapIfWithDetails(err, "failed to list the pod of the broker", v1beta1.BrokerIdLabelKey, brokerID)
+	}
+	for _, pod := range pods.Items {
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim == nil || volume.PersistentVolumeClaim.ClaimName != pvcName {
+				continue
+			}
+			for _, container := range pod.Spec.Containers {
+				for _, volumeMount := range container.VolumeMounts {
+					if volumeMount.Name == volume.Name && volumeMount.MountPath == mountPath {
+						return true, nil
+					}
+				}
+			}
+		}
+	}
+	return false, nil
+}
+
+// withStorageMigrations returns the config of the broker with the volumes of its storage migrations in progress
+func (r *Reconciler) withStorageMigrations(brokerID int32, brokerConfig *v1beta1.BrokerConfig) *v1beta1.BrokerConfig {
+	migrations := r.KafkaCluster.Status.BrokersState[strconv.Itoa(int(brokerID))].StorageMigrations
+	if len(migrations) == 0 {
+		return brokerConfig
+	}
+	config := *brokerConfig
+	config.StorageConfigs = kafkautils.StorageConfigsWithMigrations(brokerConfig.StorageConfigs, migrations)
+	return &config
+}
+
+// releasedLogDirs returns the log dirs the broker does not use anymore, which are left out of its configuration even
+// though they are in its current one: the log dirs of the volumes whose replicas have been moved off for their removal
+// and the log dirs of the new volumes of the storage migrations mounted at the mount path of the old volume
+func releasedLogDirs(brokerState v1beta1.BrokerState) map[string]struct{} {
+	logDirs := make(map[string]struct{})
+	for mountPath, volumeState := range brokerState.GracefulActionState.VolumeStates {
+		if volumeState.CruiseControlVolumeState.IsDiskRemovalSucceeded() {
+			logDirs[util.StorageConfigKafkaMountPath(mountPath)] = struct{}{}
+		}
+	}
+	for mountPath, migration := range brokerState.StorageMigrations {
+		if migration.Phase == v1beta1.StorageMigrationSwappingMounts {
+			logDirs[util.StorageConfigKafkaMountPath(kafkautils.StorageMigrationMountPath(mountPath))] = struct{}{}
+		}
+	}
+	return logDirs
+}
+
+func hasStorageConfig(storageConfigs []v1beta1.StorageConfig, mountPath string) bool {
+	for _, storage := range storageConfigs {
+		if storage.MountPath == mountPath {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,215 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func TestReconcileStorageMigrations(t *testing.T) {
+	brokerLabels := apiutil.MergeLabels(apiutil.LabelsForKafka("kafka"), map[string]string{v1beta1.BrokerIdLabelKey: "1"})
+	pvc := func(name, mountPath, storageClassName string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kafka", Labels: brokerLabels,
+				Annotations: map[string]string{"mountPath": mountPath}},
+			Spec: corev1.PersistentVolumeClaimSpec{StorageClassName: &storageClassName},
+		}
+	}
+	pod := func(pvcName, mountPath string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "kafka-1-abcde", Namespace: "kafka", Labels: brokerLabels},
+			Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{{Name: "kafka-data-0", VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvcName}}}},
+				Containers: []corev1.Container{{Name: "kafka", VolumeMounts: []corev1.VolumeMount{{Name: "kafka-data-0", MountPath: mountPath}}}},
+			},
+		}
+	}
+	volumeState := func(state v1beta1.CruiseControlVolumeState) v1beta1.VolumeState {
+		return v1beta1.VolumeState{CruiseControlVolumeState: state}
+	}
+	migration := func(phase v1beta1.StorageMigrationPhase) v1beta1.StorageMigrationStates {
+		return v1beta1.StorageMigrationStates{"/kafka-logs": {Phase: phase, StorageClassName: "gp3"}}
+	}
+
+	testCases := []struct {
+		testName             string
+		migrations           v1beta1.StorageMigrationStates
+		volumeStates         map[string]v1beta1.VolumeState
+		objects              []client.Object
+		expectedMigrations   v1beta1.StorageMigrationStates
+		expectedVolumeStates map[string]v1beta1.VolumeState
+		expectedMountPaths   map[string]string
+	}{
+		{
+			testName:           "volume of the desired storage class",
+			objects:            []client.Object{pvc("kafka-1-storage-0", "/kafka-logs", "gp3")},
+			expectedMountPaths: map[string]string{"kafka-1-storage-0": "/kafka-logs"},
+		},
+		{
+			testName:           "changed storage class starts the migration",
+			objects:            []client.Object{pvc("kafka-1-storage-0", "/kafka-logs", "gp2")},
+			expectedMigrations: migration(v1beta1.StorageMigrationAddingVolume),
+			expectedMountPaths: map[string]string{"kafka-1-storage-0": "/kafka-logs"},
+		},
+		{
+			testName:     "replicas are rebalanced onto the new volume",
+			migrations:   migration(v1beta1.StorageMigrationAddingVolume),
+			volumeStates: map[string]v1beta1.VolumeState{"/kafka-logs-migration": volumeState(v1beta1.GracefulDiskRebalanceRunning)},
+			objects: []client.Object{pvc("kafka-1-storage-0", "/kafka-logs", "gp2"),
+				pvc("kafka-1-storage-1", "/kafka-logs-migration", "gp3")},
+			expectedMigrations:   migration(v1beta1.StorageMigrationAddingVolume),
+			expectedVolumeStates: map[string]v1beta1.VolumeState{"/kafka-logs-migration": volumeState(v1beta1.GracefulDiskRebalanceRunning)},
+			expectedMountPaths:   map[string]string{"kafka-1-storage-0": "/kafka-logs", "kafka-1-storage-1": "/kafka-logs-migration"},
+		},
+		{
+			testName:     "old volume is drained once the replicas are rebalanced",
+			migrations:   migration(v1beta1.StorageMigrationAddingVolume),
+			volumeStates: map[string]v1beta1.VolumeState{"/kafka-logs-migration": volumeState(v1beta1.GracefulDiskRebalanceSucceeded)},
+			objects: []client.Object{pvc("kafka-1-storage-0", "/kafka-logs", "gp2"),
+				pvc("kafka-1-storage-1", "/kafka-logs-migration", "gp3")},
+			expectedMigrations:   migration(v1beta1.StorageMigrationDrainingVolume),
+			expectedVolumeStates: map[string]v1beta1.VolumeState{"/kafka-logs-migration": volumeState(v1beta1.GracefulDiskRebalanceSucceeded)},
+			expectedMountPaths:   map[string]string{"kafka-1-storage-0": "/kafka-logs", "kafka-1-storage-1": "/kafka-logs-migration"},
+		},
+		{
+			testName:   "old volume is being removed",
+			migrations: migration(v1beta1.StorageMigrationDrainingVolume),
+			volumeStates: map[string]v1beta1.VolumeState{
+				"/kafka-logs":           volumeState(v1beta1.GracefulDiskRemovalRunning),
+				"/kafka-logs-migration": volumeState(v1beta1.GracefulDiskRebalanceSucceeded),
+			},
+			objects: []client.Object{pvc("kafka-1-storage-0", "/kafka-logs", "gp2"),
+				pvc("kafka-1-storage-1", "/kafka-logs-migration", "gp3")},
+			expectedMigrations: migration(v1beta1.StorageMigrationDrainingVolume),
+			expectedVolumeStates: map[string]v1beta1.VolumeState{
+				"/kafka-logs":           volumeState(v1beta1.GracefulDiskRemovalRunning),
+				"/kafka-logs-migration": volumeState(v1beta1.GracefulDiskRebalanceSucceeded),
+			},
+			expectedMountPaths: map[string]string{"kafka-1-storage-0": "/kafka-logs", "kafka-1-storage-1": "/kafka-logs-migration"},
+		},
+		{
+			testName:             "new volume is moved to the mount path of the removed one",
+			migrations:           migration(v1beta1.StorageMigrationDrainingVolume),
+			volumeStates:         map[string]v1beta1.VolumeState{"/kafka-logs-migration": volumeState(v1beta1.GracefulDiskRebalanceSucceeded)},
+			objects:              []client.Object{pvc("kafka-1-storage-1", "/kafka-logs-migration", "gp3")},
+			expectedMigrations:   migration(v1beta1.StorageMigrationSwappingMounts),
+			expectedVolumeStates: map[string]v1beta1.VolumeState{"/kafka-logs": volumeState(v1beta1.GracefulDiskRebalanceSucceeded)},
+			expectedMountPaths:   map[string]string{"kafka-1-storage-1": "/kafka-logs"},
+		},
+		{
+			testName:             "interrupted move of the new volume is resumed",
+			migrations:           migration(v1beta1.StorageMigrationSwappingMounts),
+			volumeStates:         map[string]v1beta1.VolumeState{"/kafka-logs-migration": volumeState(v1beta1.GracefulDiskRebalanceSucceeded)},
+			objects:              []client.Object{pvc("kafka-1-storage-1", "/kafka-logs-migration", "gp3")},
+			expectedMigrations:   migration(v1beta1.StorageMigrationSwappingMounts),
+			expectedVolumeStates: map[string]v1beta1.VolumeState{"/kafka-logs": volumeState(v1beta1.GracefulDiskRebalanceSucceeded)},
+			expectedMountPaths:   map[string]string{"kafka-1-storage-1": "/kafka-logs"},
+		},
+		{
+			testName:             "broker is not restarted with the swapped mounts yet",
+			migrations:           migration(v1beta1.StorageMigrationSwappingMounts),
+			volumeStates:         map[string]v1beta1.VolumeState{"/kafka-logs": volumeState(v1beta1.GracefulDiskRebalanceSucceeded)},
+			objects:              []client.Object{pvc("kafka-1-storage-1", "/kafka-logs", "gp3"), pod("kafka-1-storage-1", "/kafka-logs-migration")},
+			expectedMigrations:   migration(v1beta1.StorageMigrationSwappingMounts),
+			expectedVolumeStates: map[string]v1beta1.VolumeState{"/kafka-logs": volumeState(v1beta1.GracefulDiskRebalanceSucceeded)},
+			expectedMountPaths:   map[string]string{"kafka-1-storage-1": "/kafka-logs"},
+		},
+		{
+			testName:             "migration finishes once the broker mounts the new volume at the mount path",
+			migrations:           migration(v1beta1.StorageMigrationSwappingMounts),
+			volumeStates:         map[string]v1beta1.VolumeState{"/kafka-logs": volumeState(v1beta1.GracefulDiskRebalanceSucceeded)},
+			objects:              []client.Object{pvc("kafka-1-storage-1", "/kafka-logs", "gp3"), pod("kafka-1-storage-1", "/kafka-logs")},
+			expectedVolumeStates: map[string]v1beta1.VolumeState{"/kafka-logs": volumeState(v1beta1.GracefulDiskRebalanceSucceeded)},
+			expectedMountPaths:   map[string]string{"kafka-1-storage-1": "/kafka-logs"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			gp3 := "gp3"
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Spec: v1beta1.KafkaClusterSpec{
+					Brokers: []v1beta1.Broker{{Id: 1, BrokerConfig: &v1beta1.BrokerConfig{StorageConfigs: []v1beta1.StorageConfig{
+						{MountPath: "/kafka-logs", PvcSpec: &corev1.PersistentVolumeClaimSpec{StorageClassName: &gp3}},
+					}}}},
+				},
+				Status: v1beta1.KafkaClusterStatus{BrokersState: map[string]v1beta1.BrokerState{"1": {
+					GracefulActionState: v1beta1.GracefulActionState{VolumeStates: test.volumeStates},
+					StorageMigrations:   test.migrations,
+				}}},
+			}
+			scheme := runtime.NewScheme()
+			require.NoError(t, clientgoscheme.AddToScheme(scheme))
+			require.NoError(t, v1beta1.AddToScheme(scheme))
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(test.objects, cluster)...).
+				WithStatusSubresource(cluster).Build()
+
+			r := New(c, nil, cluster, nil, nil)
+			require.NoError(t, r.reconcileStorageMigrations(context.Background(), logf.Log, nil))
+
+			brokerState := r.KafkaCluster.Status.BrokersState["1"]
+			for mountPath, state := range brokerState.StorageMigrations {
+				state.LastUpdateTime = metav1.Time{}
+				brokerState.StorageMigrations[mountPath] = state
+			}
+			require.Equal(t, test.expectedMigrations, brokerState.StorageMigrations)
+			require.Equal(t, test.expectedVolumeStates, brokerState.GracefulActionState.VolumeStates)
+
+			mountPaths := make(map[string]string)
+			for _, obj := range test.objects {
+				if pvc, ok := obj.(*corev1.PersistentVolumeClaim); ok {
+					require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace}, pvc))
+					mountPaths[pvc.Name] = pvc.Annotations["mountPath"]
+				}
+			}
+			require.Equal(t, test.expectedMountPaths, mountPaths)
+		})
+	}
+}
+
+func TestReleasedLogDirs(t *testing.T) {
+	brokerState := v1beta1.BrokerState{
+		GracefulActionState: v1beta1.GracefulActionState{VolumeStates: map[string]v1beta1.VolumeState{
+			"/kafka-logs":  {CruiseControlVolumeState: v1beta1.GracefulDiskRemovalRestartPending},
+			"/kafka-logs2": {CruiseControlVolumeState: v1beta1.GracefulDiskRemovalRunning},
+			"/kafka-logs3": {CruiseControlVolumeState: v1beta1.GracefulDiskRebalanceSucceeded},
+		}},
+		StorageMigrations: v1beta1.StorageMigrationStates{
+			"/kafka-logs4": {Phase: v1beta1.StorageMigrationSwappingMounts},
+			"/kafka-logs5": {Phase: v1beta1.StorageMigrationDrainingVolume},
+		},
+	}
+	require.Equal(t, map[string]struct{}{
+		"/kafka-logs/kafka":            {},
+		"/kafka-logs4-migration/kafka": {},
+	}, releasedLogDirs(brokerState))
+}
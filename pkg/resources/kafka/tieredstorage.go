@@ -0,0 +1,88 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	kafkautils "github.com/banzaicloud/koperator/pkg/util/kafka"
+	properties "github.com/banzaicloud/koperator/properties/pkg"
+)
+
+const (
+	tieredStoragePluginContainerName = "tiered-storage-plugin"
+	tieredStoragePluginVolumeName    = "tiered-storage-plugin"
+)
+
+func configureTieredStorage(tieredStorage *v1beta1.TieredStorageConfig, l v1beta1.ListenersConfig, config *properties.Properties, log logr.Logger) {
+	if tieredStorage == nil {
+		return
+	}
+	// the RemoteLogMetadataManager connects to the brokers through the listener used for inter broker communication
+	var listenerName string
+	for _, iListener := range l.InternalListeners {
+		if iListener.UsedForInnerBrokerCommunication {
+			listenerName = strings.ToUpper(iListener.Name)
+			break
+		}
+	}
+	for key, value := range kafkautils.TieredStorageConfigs(tieredStorage, listenerName) {
+		if err := config.Set(key, value); err != nil {
+			log.Error(err, fmt.Sprintf(kafkautils.BrokerConfigErrorMsgTemplate, key))
+		}
+	}
+}
+
+// hasTieredStoragePlugin returns true if the jars of the RemoteStorageManager plugin are copied into the pod of the
+// broker, the controller-only nodes do not host log segments
+func hasTieredStoragePlugin(brokerConfig *v1beta1.BrokerConfig, kafkaClusterSpec v1beta1.KafkaClusterSpec) bool {
+	return kafkaClusterSpec.TieredStorage != nil && kafkaClusterSpec.TieredStorage.PluginImage != "" && !brokerConfig.IsControllerOnlyNode()
+}
+
+// generateTieredStoragePluginInitContainer returns the init container copying the jars of the RemoteStorageManager
+// plugin from the plugin image into the pod of the broker
+func generateTieredStoragePluginInitContainer(tieredStorage *v1beta1.TieredStorageConfig) corev1.Container {
+	return corev1.Container{
+		Name:    tieredStoragePluginContainerName,
+		Image:   tieredStorage.PluginImage,
+		Command: []string{"/bin/sh", "-cex", fmt.Sprintf("cp -rv %s/. %s/", tieredStorage.GetPluginPath(), kafkautils.TieredStoragePluginPath)},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      tieredStoragePluginVolumeName,
+			MountPath: kafkautils.TieredStoragePluginPath,
+		}},
+		Resources: *tieredStorage.GetPluginInitContainerResources(),
+	}
+}
+
+// generateVolumesForTieredStoragePlugin returns the volume and the volume mount of the jars of the RemoteStorageManager
+// plugin
+func generateVolumesForTieredStoragePlugin(brokerConfig *v1beta1.BrokerConfig, kafkaClusterSpec v1beta1.KafkaClusterSpec) ([]corev1.Volume, []corev1.VolumeMount) {
+	if !hasTieredStoragePlugin(brokerConfig, kafkaClusterSpec) {
+		return nil, nil
+	}
+	return []corev1.Volume{{
+		Name:         tieredStoragePluginVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}}, []corev1.VolumeMount{{
+		Name:      tieredStoragePluginVolumeName,
+		MountPath: kafkautils.TieredStoragePluginPath,
+	}}
+}
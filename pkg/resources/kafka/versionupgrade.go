@@ -0,0 +1,334 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/IBM/sarama"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/resources/templates"
+	"github.com/banzaicloud/koperator/pkg/util"
+	clientutil "github.com/banzaicloud/koperator/pkg/util/client"
+)
+
+const (
+	metadataVersionJobSuffix        = "-metadata-version"
+	maxJobNameLength                = 63
+	metadataVersionJobAppLabelValue = "kafka-metadata-version"
+	metadataVersionJobContainerName = "kafka-features"
+	kafkaFeaturesScript             = "/opt/kafka/bin/kafka-features.sh"
+	metadataVersionClientConfig     = "/tmp/client.properties"
+	keystorePasswordEnvVar          = "KEYSTORE_PASSWORD"
+)
+
+// startVersionUpgrade records the start of the upgrade of the Kafka version before the broker configurations are
+// generated, so that the brokers rolled with the images of the target version keep the protocol of the previous one.
+// An upgrade whose protocol has not been bumped yet is rolled back once the version upgrade is removed from the spec.
+func (r *Reconciler) startVersionUpgrade(log logr.Logger) error {
+	upgrade := r.KafkaCluster.Spec.VersionUpgrade
+	status := r.KafkaCluster.Status.VersionUpgrade
+	if upgrade == nil {
+		if status != nil && !status.IsProtocolBumped() {
+			log.Info("Kafka version upgrade rolled back before the protocol was bumped", "targetVersion", status.TargetVersion)
+			return r.updateVersionUpgradeStatus(nil, log)
+		}
+		return nil
+	}
+	if status != nil && status.TargetVersion == upgrade.TargetVersion {
+		return nil
+	}
+
+	newStatus := &v1beta1.KafkaVersionUpgradeStatus{
+		Phase:         v1beta1.KafkaVersionUpgradePhaseBinariesUpgrading,
+		TargetVersion: upgrade.TargetVersion,
+	}
+	// the brokers of a new cluster start with the target version, there is no previous protocol to keep
+	if len(r.KafkaCluster.Status.BrokersState) > 0 {
+		previousVersion, ok := lowestRunningKafkaVersion(r.KafkaCluster)
+		if !ok {
+			return errorfactory.New(errorfactory.ResourceNotReady{}, errors.New("Kafka version of the brokers is unknown"),
+				"waiting for the brokers to report their Kafka version before upgrading it")
+		}
+		newStatus.PreviousProtocolVersion = v1beta1.ProtocolVersionOf(previousVersion.String())
+		k8sutil.RecordEvent(r.Recorder, r.KafkaCluster, corev1.EventTypeNormal, versionUpgradeStartedEventReason,
+			"Upgrading Kafka from %s to %s", previousVersion.String(), upgrade.TargetVersion)
+	}
+	log.Info("starting the Kafka version upgrade", "targetVersion", upgrade.TargetVersion,
+		"previousProtocolVersion", newStatus.PreviousProtocolVersion)
+	return r.updateVersionUpgradeStatus(newStatus, log)
+}
+
+// reconcileVersionUpgrade moves the upgrade of the Kafka version to its next phase once the nodes are rolled with the
+// configuration of the current one. It runs at the end of the reconcile flow, the status update of a new phase
+// triggers the reconciliation which rolls out its configuration.
+func (r *Reconciler) reconcileVersionUpgrade(ctx context.Context, log logr.Logger) error {
+	upgrade := r.KafkaCluster.Spec.VersionUpgrade
+	status := r.KafkaCluster.Status.VersionUpgrade
+	if upgrade == nil || status == nil || status.TargetVersion != upgrade.TargetVersion ||
+		status.Phase == v1beta1.KafkaVersionUpgradePhaseCompleted {
+		return nil
+	}
+	log = log.WithValues("versionUpgradePhase", status.Phase, "targetVersion", status.TargetVersion)
+
+	settled, err := r.isClusterSettled(ctx)
+	if err != nil {
+		return err
+	}
+	if !settled {
+		log.V(1).Info("waiting for the nodes to be rolled with the configuration of the version upgrade phase")
+		return nil
+	}
+
+	var nextPhase v1beta1.KafkaVersionUpgradePhase
+	switch status.Phase {
+	case v1beta1.KafkaVersionUpgradePhaseBinariesUpgrading:
+		upgraded, err := allBrokersRunKafkaVersion(r.KafkaCluster, status.TargetVersion)
+		if err != nil {
+			return err
+		}
+		if !upgraded {
+			log.V(1).Info("waiting for every broker to run the target Kafka version")
+			return nil
+		}
+		nextPhase = v1beta1.KafkaVersionUpgradePhaseProtocolPending
+	case v1beta1.KafkaVersionUpgradePhaseProtocolPending:
+		if upgrade.HoldProtocolVersion {
+			log.V(1).Info("the protocol version is held at the previous Kafka version")
+			return nil
+		}
+		k8sutil.RecordEvent(r.Recorder, r.KafkaCluster, corev1.EventTypeNormal, protocolUpgradeStartedEventReason,
+			"Bumping the protocol version of the brokers to Kafka %s, the upgrade can not be rolled back anymore", status.TargetVersion)
+		nextPhase = v1beta1.KafkaVersionUpgradePhaseProtocolUpgrading
+	case v1beta1.KafkaVersionUpgradePhaseProtocolUpgrading:
+		// the inter broker protocol version of the brokers in ZooKeeper mode is rolled out by their configuration, the
+		// metadata version of a KRaft cluster is finalized by the kafka-features tool
+		if r.KafkaCluster.Spec.KRaftMode {
+			if err := r.upgradeMetadataVersion(ctx, upgrade.GetMetadataVersion(), log); err != nil {
+				return err
+			}
+		}
+		k8sutil.RecordEvent(r.Recorder, r.KafkaCluster, corev1.EventTypeNormal, versionUpgradeCompletedEventReason,
+			"Upgraded Kafka to %s", status.TargetVersion)
+		nextPhase = v1beta1.KafkaVersionUpgradePhaseCompleted
+	default:
+		return errors.NewWithDetails("unknown Kafka version upgrade phase", "phase", status.Phase)
+	}
+
+	log.Info("Kafka version upgrade phase completed", "nextPhase", nextPhase)
+	return r.updateVersionUpgradeStatus(&v1beta1.KafkaVersionUpgradeStatus{
+		Phase:                   nextPhase,
+		TargetVersion:           status.TargetVersion,
+		PreviousProtocolVersion: status.PreviousProtocolVersion,
+	}, log)
+}
+
+// interBrokerProtocolVersion returns the inter broker protocol version the brokers of a ZooKeeper based cluster run
+// during and after the upgrade of the Kafka version: the version they ran before until the protocol is bumped
+func interBrokerProtocolVersion(kafkaCluster *v1beta1.KafkaCluster) (string, bool) {
+	upgrade := kafkaCluster.Spec.VersionUpgrade
+	status := kafkaCluster.Status.VersionUpgrade
+	if upgrade == nil || kafkaCluster.Spec.KRaftMode || status == nil || status.TargetVersion != upgrade.TargetVersion {
+		return "", false
+	}
+	if status.IsProtocolBumped() {
+		return upgrade.GetInterBrokerProtocolVersion(), true
+	}
+	return status.PreviousProtocolVersion, status.PreviousProtocolVersion != ""
+}
+
+// upgradeMetadataVersion runs the kafka-features tool upgrading the metadata version of the KRaft cluster in a Job
+// and returns nil once it completed successfully. A failed Job is deleted so that it is run again on the next reconcile.
+func (r *Reconciler) upgradeMetadataVersion(ctx context.Context, metadataVersion string, log logr.Logger) error {
+	name := metadataVersionJobName(r.KafkaCluster.Name)
+	job := &batchv1.Job{}
+	err := r.DirectClient.Get(ctx, client.ObjectKey{Name: name, Namespace: r.KafkaCluster.Namespace}, job)
+	switch {
+	case apierrors.IsNotFound(err):
+		job, err = r.metadataVersionJob(name, metadataVersion)
+		if err != nil {
+			return err
+		}
+		if err := r.Client.Create(ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+			return errorfactory.New(errorfactory.APIFailure{}, err, "could not create metadata version upgrade job", "job", name)
+		}
+		log.Info("upgrading the metadata version of the cluster", "metadataVersion", metadataVersion, "job", name)
+		return errorfactory.New(errorfactory.ResourceNotReady{}, errors.New("metadata version upgrade job created"),
+			"waiting for the metadata version to be upgraded", "job", name)
+	case err != nil:
+		return errorfactory.New(errorfactory.APIFailure{}, err, "could not get metadata version upgrade job", "job", name)
+	case job.GetDeletionTimestamp() != nil:
+		return errorfactory.New(errorfactory.ResourceNotReady{}, errors.New("metadata version upgrade job is being deleted"),
+			"waiting for the metadata version upgrade job of the previous run to be deleted", "job", name)
+	}
+
+	if job.Status.Succeeded > 0 {
+		return r.deleteMetadataVersionJob(ctx, job)
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			if err := r.deleteMetadataVersionJob(ctx, job); err != nil {
+				return err
+			}
+			return errorfactory.New(errorfactory.ResourceNotReady{}, errors.New(condition.Message),
+				"metadata version upgrade job failed, it is run again", "job", name)
+		}
+	}
+	return errorfactory.New(errorfactory.ResourceNotReady{}, errors.New("metadata version upgrade job is running"),
+		"waiting for the metadata version to be upgraded", "job", name)
+}
+
+func (r *Reconciler) deleteMetadataVersionJob(ctx context.Context, job *batchv1.Job) error {
+	err := r.Client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if client.IgnoreNotFound(err) != nil {
+		return errorfactory.New(errorfactory.APIFailure{}, err, "could not delete metadata version upgrade job", "job", job.Name)
+	}
+	return nil
+}
+
+// metadataVersionJob returns the Job running the kafka-features tool of the image of the brokers against the cluster,
+// it authenticates with the client certificate of the operator when the cluster is accessed over SSL
+func (r *Reconciler) metadataVersionJob(name, metadataVersion string) (*batchv1.Job, error) {
+	if len(r.KafkaCluster.Spec.Brokers) == 0 {
+		return nil, errors.New("the cluster has no brokers to take the image of the metadata version upgrade job from")
+	}
+	brokerConfig, err := r.KafkaCluster.Spec.Brokers[0].GetBrokerConfig(r.KafkaCluster.Spec)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not get broker config", v1beta1.BrokerIdLabelKey, r.KafkaCluster.Spec.Brokers[0].Id)
+	}
+	image := r.KafkaCluster.Spec.GetClusterImage()
+	if brokerConfig.Image != "" {
+		image = brokerConfig.Image
+	}
+
+	command := fmt.Sprintf("%s --bootstrap-server %s", kafkaFeaturesScript, clientutil.GenerateKafkaAddress(r.KafkaCluster))
+	container := corev1.Container{
+		Name:  metadataVersionJobContainerName,
+		Image: image,
+	}
+	var volumes []corev1.Volume
+	if clientutil.UseSSL(r.KafkaCluster) {
+		clientConfig := []string{
+			"security.protocol=SSL",
+			fmt.Sprintf("ssl.keystore.location=%s/%s", clientKeystorePath, v1alpha1.TLSJKSKeyStore),
+			fmt.Sprintf("ssl.keystore.password=$%s", keystorePasswordEnvVar),
+			fmt.Sprintf("ssl.truststore.location=%s/%s", clientKeystorePath, v1alpha1.TLSJKSTrustStore),
+			fmt.Sprintf("ssl.truststore.password=$%s", keystorePasswordEnvVar),
+		}
+		command = fmt.Sprintf("printf '%%s\\n' %s > %s && %s --command-config %s", `"`+strings.Join(clientConfig, `" "`)+`"`,
+			metadataVersionClientConfig, command, metadataVersionClientConfig)
+
+		volume := generateVolumeForClientSSLCert(r.KafkaCluster.Spec, r.KafkaCluster.Name)
+		volumes = append(volumes, volume)
+		container.VolumeMounts = []corev1.VolumeMount{generateVolumeMountForClientSSLCerts()}
+		container.Env = []corev1.EnvVar{{
+			Name: keystorePasswordEnvVar,
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: volume.Secret.SecretName},
+				Key:                  v1alpha1.PasswordKey,
+			}},
+		}}
+	}
+	container.Command = []string{"/bin/bash", "-c", fmt.Sprintf("%s upgrade --metadata %s", command, metadataVersion)}
+
+	labels := map[string]string{
+		v1beta1.AppLabelKey:     metadataVersionJobAppLabelValue,
+		v1beta1.KafkaCRLabelKey: r.KafkaCluster.Name,
+	}
+	return &batchv1.Job{
+		ObjectMeta: templates.ObjectMeta(name, labels, r.KafkaCluster),
+		Spec: batchv1.JobSpec{
+			BackoffLimit: util.Int32Pointer(3),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy:    corev1.RestartPolicyNever,
+					ImagePullSecrets: brokerConfig.GetImagePullSecrets(),
+					Containers:       []corev1.Container{container},
+					Volumes:          volumes,
+				},
+			},
+		},
+	}, nil
+}
+
+// metadataVersionJobName returns the name of the metadata version upgrade Job, the cluster name is shortened to fit
+// in a label value
+func metadataVersionJobName(clusterName string) string {
+	if len(clusterName)+len(metadataVersionJobSuffix) > maxJobNameLength {
+		clusterName = strings.TrimSuffix(clusterName[:maxJobNameLength-len(metadataVersionJobSuffix)], "-")
+	}
+	return clusterName + metadataVersionJobSuffix
+}
+
+// lowestRunningKafkaVersion returns the lowest Kafka version reported by the nodes of the cluster
+func lowestRunningKafkaVersion(kafkaCluster *v1beta1.KafkaCluster) (sarama.KafkaVersion, bool) {
+	var lowest sarama.KafkaVersion
+	found := false
+	for _, broker := range kafkaCluster.Spec.Brokers {
+		version, err := sarama.ParseKafkaVersion(kafkaCluster.Status.BrokersState[strconv.Itoa(int(broker.Id))].Version)
+		if err != nil {
+			continue
+		}
+		if !found || !version.IsAtLeast(lowest) {
+			lowest, found = version, true
+		}
+	}
+	return lowest, found
+}
+
+// allBrokersRunKafkaVersion returns true if every node of the cluster reports at least the given Kafka version
+func allBrokersRunKafkaVersion(kafkaCluster *v1beta1.KafkaCluster, kafkaVersion string) (bool, error) {
+	target, err := sarama.ParseKafkaVersion(kafkaVersion)
+	if err != nil {
+		return false, errors.WrapIfWithDetails(err, "could not parse the target Kafka version", "version", kafkaVersion)
+	}
+	for _, broker := range kafkaCluster.Spec.Brokers {
+		version, err := sarama.ParseKafkaVersion(kafkaCluster.Status.BrokersState[strconv.Itoa(int(broker.Id))].Version)
+		if err != nil || !version.IsAtLeast(target) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (r *Reconciler) updateVersionUpgradeStatus(status *v1beta1.KafkaVersionUpgradeStatus, log logr.Logger) error {
+	if status != nil {
+		status.LastTransitionTime = metav1.Now()
+		if current := r.KafkaCluster.Status.VersionUpgrade; current != nil && current.Phase == status.Phase &&
+			current.TargetVersion == status.TargetVersion {
+			status.LastTransitionTime = current.LastTransitionTime
+		}
+	}
+	if err := k8sutil.UpdateCRStatus(r.Client, r.KafkaCluster, status, log); err != nil {
+		return errorfactory.New(errorfactory.StatusUpdateError{}, err, "could not update Kafka version upgrade status")
+	}
+	return nil
+}
@@ -0,0 +1,365 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+)
+
+func TestInterBrokerProtocolVersion(t *testing.T) {
+	testCases := []struct {
+		testName    string
+		kRaftMode   bool
+		upgrade     *v1beta1.KafkaVersionUpgradeConfig
+		status      *v1beta1.KafkaVersionUpgradeStatus
+		expectedIBP string
+	}{
+		{
+			testName: "no version upgrade",
+		},
+		{
+			testName: "binaries upgrading",
+			upgrade:  &v1beta1.KafkaVersionUpgradeConfig{TargetVersion: "3.9.1"},
+			status: &v1beta1.KafkaVersionUpgradeStatus{Phase: v1beta1.KafkaVersionUpgradePhaseBinariesUpgrading,
+				TargetVersion: "3.9.1", PreviousProtocolVersion: "3.7"},
+			expectedIBP: "3.7",
+		},
+		{
+			testName: "protocol upgrading",
+			upgrade:  &v1beta1.KafkaVersionUpgradeConfig{TargetVersion: "3.9.1"},
+			status: &v1beta1.KafkaVersionUpgradeStatus{Phase: v1beta1.KafkaVersionUpgradePhaseProtocolUpgrading,
+				TargetVersion: "3.9.1", PreviousProtocolVersion: "3.7"},
+			expectedIBP: "3.9",
+		},
+		{
+			testName: "completed with an explicit protocol version",
+			upgrade:  &v1beta1.KafkaVersionUpgradeConfig{TargetVersion: "3.9.1", InterBrokerProtocolVersion: "3.9-IV0"},
+			status: &v1beta1.KafkaVersionUpgradeStatus{Phase: v1beta1.KafkaVersionUpgradePhaseCompleted,
+				TargetVersion: "3.9.1", PreviousProtocolVersion: "3.7"},
+			expectedIBP: "3.9-IV0",
+		},
+		{
+			testName: "new target version not started yet",
+			upgrade:  &v1beta1.KafkaVersionUpgradeConfig{TargetVersion: "3.9.1"},
+			status: &v1beta1.KafkaVersionUpgradeStatus{Phase: v1beta1.KafkaVersionUpgradePhaseCompleted,
+				TargetVersion: "3.8.1", PreviousProtocolVersion: "3.7"},
+		},
+		{
+			testName:  "KRaft mode",
+			kRaftMode: true,
+			upgrade:   &v1beta1.KafkaVersionUpgradeConfig{TargetVersion: "3.9.1"},
+			status: &v1beta1.KafkaVersionUpgradeStatus{Phase: v1beta1.KafkaVersionUpgradePhaseBinariesUpgrading,
+				TargetVersion: "3.9.1", PreviousProtocolVersion: "3.7"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			ibp, ok := interBrokerProtocolVersion(&v1beta1.KafkaCluster{
+				Spec:   v1beta1.KafkaClusterSpec{KRaftMode: test.kRaftMode, VersionUpgrade: test.upgrade},
+				Status: v1beta1.KafkaClusterStatus{VersionUpgrade: test.status},
+			})
+			require.Equal(t, test.expectedIBP != "", ok)
+			require.Equal(t, test.expectedIBP, ibp)
+		})
+	}
+}
+
+func TestStartVersionUpgrade(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		upgrade        *v1beta1.KafkaVersionUpgradeConfig
+		status         *v1beta1.KafkaVersionUpgradeStatus
+		brokerVersions map[string]string
+		expectedStatus *v1beta1.KafkaVersionUpgradeStatus
+		expectedErr    bool
+	}{
+		{
+			testName:       "upgrade started from the lowest running version",
+			upgrade:        &v1beta1.KafkaVersionUpgradeConfig{TargetVersion: "3.9.1"},
+			brokerVersions: map[string]string{"0": "3.8.0", "1": "3.7.2"},
+			expectedStatus: &v1beta1.KafkaVersionUpgradeStatus{Phase: v1beta1.KafkaVersionUpgradePhaseBinariesUpgrading,
+				TargetVersion: "3.9.1", PreviousProtocolVersion: "3.7"},
+		},
+		{
+			testName: "new cluster",
+			upgrade:  &v1beta1.KafkaVersionUpgradeConfig{TargetVersion: "3.9.1"},
+			expectedStatus: &v1beta1.KafkaVersionUpgradeStatus{Phase: v1beta1.KafkaVersionUpgradePhaseBinariesUpgrading,
+				TargetVersion: "3.9.1"},
+		},
+		{
+			testName:       "versions of the brokers unknown",
+			upgrade:        &v1beta1.KafkaVersionUpgradeConfig{TargetVersion: "3.9.1"},
+			brokerVersions: map[string]string{"0": "", "1": ""},
+			expectedErr:    true,
+		},
+		{
+			testName: "rolled back before the protocol bump",
+			status: &v1beta1.KafkaVersionUpgradeStatus{Phase: v1beta1.KafkaVersionUpgradePhaseProtocolPending,
+				TargetVersion: "3.9.1", PreviousProtocolVersion: "3.7"},
+			brokerVersions: map[string]string{"0": "3.9.1", "1": "3.9.1"},
+		},
+		{
+			testName: "upgrade completed and removed from the spec",
+			status: &v1beta1.KafkaVersionUpgradeStatus{Phase: v1beta1.KafkaVersionUpgradePhaseCompleted,
+				TargetVersion: "3.9.1", PreviousProtocolVersion: "3.7"},
+			brokerVersions: map[string]string{"0": "3.9.1", "1": "3.9.1"},
+			expectedStatus: &v1beta1.KafkaVersionUpgradeStatus{Phase: v1beta1.KafkaVersionUpgradePhaseCompleted,
+				TargetVersion: "3.9.1", PreviousProtocolVersion: "3.7"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Spec: v1beta1.KafkaClusterSpec{
+					Brokers:        []v1beta1.Broker{{Id: 0}, {Id: 1}},
+					VersionUpgrade: test.upgrade,
+				},
+				Status: v1beta1.KafkaClusterStatus{VersionUpgrade: test.status},
+			}
+			for brokerID, version := range test.brokerVersions {
+				if cluster.Status.BrokersState == nil {
+					cluster.Status.BrokersState = make(map[string]v1beta1.BrokerState)
+				}
+				cluster.Status.BrokersState[brokerID] = v1beta1.BrokerState{Version: version}
+			}
+			c := fake.NewClientBuilder().WithScheme(versionUpgradeTestScheme(t)).WithObjects(cluster).
+				WithStatusSubresource(cluster).Build()
+
+			r := New(c, c, cluster, nil, nil)
+			err := r.startVersionUpgrade(logf.Log)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			status := r.KafkaCluster.Status.VersionUpgrade
+			if status != nil {
+				status.LastTransitionTime = metav1.Time{}
+			}
+			require.Equal(t, test.expectedStatus, status)
+		})
+	}
+}
+
+func TestReconcileVersionUpgrade(t *testing.T) {
+	brokerPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka-0-abcde", Namespace: "kafka", Labels: apiutil.LabelsForKafka("kafka")},
+		Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}},
+	}
+	metadataVersionJob := func(succeeded int32) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "kafka-metadata-version", Namespace: "kafka"},
+			Status:     batchv1.JobStatus{Succeeded: succeeded},
+		}
+	}
+
+	testCases := []struct {
+		testName           string
+		kRaftMode          bool
+		holdProtocol       bool
+		phase              v1beta1.KafkaVersionUpgradePhase
+		brokerVersion      string
+		configurationState v1beta1.ConfigurationState
+		objects            []client.Object
+		expectedPhase      v1beta1.KafkaVersionUpgradePhase
+		expectedNotReady   bool
+		expectJob          bool
+		expectedJobCommand string
+	}{
+		{
+			testName:           "binaries upgraded",
+			phase:              v1beta1.KafkaVersionUpgradePhaseBinariesUpgrading,
+			brokerVersion:      "3.9.1",
+			configurationState: v1beta1.ConfigInSync,
+			objects:            []client.Object{brokerPod},
+			expectedPhase:      v1beta1.KafkaVersionUpgradePhaseProtocolPending,
+		},
+		{
+			testName:           "broker still running the previous version",
+			phase:              v1beta1.KafkaVersionUpgradePhaseBinariesUpgrading,
+			brokerVersion:      "3.7.2",
+			configurationState: v1beta1.ConfigInSync,
+			objects:            []client.Object{brokerPod},
+			expectedPhase:      v1beta1.KafkaVersionUpgradePhaseBinariesUpgrading,
+		},
+		{
+			testName:           "broker being rolled",
+			phase:              v1beta1.KafkaVersionUpgradePhaseBinariesUpgrading,
+			brokerVersion:      "3.9.1",
+			configurationState: v1beta1.ConfigOutOfSync,
+			objects:            []client.Object{brokerPod},
+			expectedPhase:      v1beta1.KafkaVersionUpgradePhaseBinariesUpgrading,
+		},
+		{
+			testName:           "protocol held",
+			holdProtocol:       true,
+			phase:              v1beta1.KafkaVersionUpgradePhaseProtocolPending,
+			brokerVersion:      "3.9.1",
+			configurationState: v1beta1.ConfigInSync,
+			objects:            []client.Object{brokerPod},
+			expectedPhase:      v1beta1.KafkaVersionUpgradePhaseProtocolPending,
+		},
+		{
+			testName:           "protocol bumped",
+			phase:              v1beta1.KafkaVersionUpgradePhaseProtocolPending,
+			brokerVersion:      "3.9.1",
+			configurationState: v1beta1.ConfigInSync,
+			objects:            []client.Object{brokerPod},
+			expectedPhase:      v1beta1.KafkaVersionUpgradePhaseProtocolUpgrading,
+		},
+		{
+			testName:           "inter broker protocol version rolled out",
+			phase:              v1beta1.KafkaVersionUpgradePhaseProtocolUpgrading,
+			brokerVersion:      "3.9.1",
+			configurationState: v1beta1.ConfigInSync,
+			objects:            []client.Object{brokerPod},
+			expectedPhase:      v1beta1.KafkaVersionUpgradePhaseCompleted,
+		},
+		{
+			testName:           "metadata version upgrade job created",
+			kRaftMode:          true,
+			phase:              v1beta1.KafkaVersionUpgradePhaseProtocolUpgrading,
+			brokerVersion:      "3.9.1",
+			configurationState: v1beta1.ConfigInSync,
+			objects:            []client.Object{brokerPod},
+			expectedPhase:      v1beta1.KafkaVersionUpgradePhaseProtocolUpgrading,
+			expectedNotReady:   true,
+			expectJob:          true,
+			expectedJobCommand: "/opt/kafka/bin/kafka-features.sh --bootstrap-server kafka-all-broker.kafka.svc.cluster.local:29092 upgrade --metadata 3.9",
+		},
+		{
+			testName:           "metadata version upgrade job running",
+			kRaftMode:          true,
+			phase:              v1beta1.KafkaVersionUpgradePhaseProtocolUpgrading,
+			brokerVersion:      "3.9.1",
+			configurationState: v1beta1.ConfigInSync,
+			objects:            []client.Object{brokerPod, metadataVersionJob(0)},
+			expectedPhase:      v1beta1.KafkaVersionUpgradePhaseProtocolUpgrading,
+			expectedNotReady:   true,
+			expectJob:          true,
+		},
+		{
+			testName:           "metadata version upgraded",
+			kRaftMode:          true,
+			phase:              v1beta1.KafkaVersionUpgradePhaseProtocolUpgrading,
+			brokerVersion:      "3.9.1",
+			configurationState: v1beta1.ConfigInSync,
+			objects:            []client.Object{brokerPod, metadataVersionJob(1)},
+			expectedPhase:      v1beta1.KafkaVersionUpgradePhaseCompleted,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Spec: v1beta1.KafkaClusterSpec{
+					KRaftMode:          test.kRaftMode,
+					ClusterImage:       "ghcr.io/adobe/koperator/kafka:2.13-3.9.1",
+					BrokerConfigGroups: map[string]v1beta1.BrokerConfig{"default": {}},
+					Brokers:            []v1beta1.Broker{{Id: 0, BrokerConfigGroup: "default"}},
+					ListenersConfig: v1beta1.ListenersConfig{InternalListeners: []v1beta1.InternalListenerConfig{{
+						CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolPlaintext,
+							ContainerPort: 29092, UsedForInnerBrokerCommunication: true},
+					}}},
+					VersionUpgrade: &v1beta1.KafkaVersionUpgradeConfig{TargetVersion: "3.9.1", HoldProtocolVersion: test.holdProtocol},
+				},
+				Status: v1beta1.KafkaClusterStatus{
+					BrokersState: map[string]v1beta1.BrokerState{"0": {
+						Version: test.brokerVersion, ConfigurationState: test.configurationState}},
+					VersionUpgrade: &v1beta1.KafkaVersionUpgradeStatus{
+						Phase: test.phase, TargetVersion: "3.9.1", PreviousProtocolVersion: "3.7"},
+				},
+			}
+			c := fake.NewClientBuilder().WithScheme(versionUpgradeTestScheme(t)).WithObjects(append(test.objects, cluster)...).
+				WithStatusSubresource(cluster).Build()
+
+			r := New(c, c, cluster, nil, nil)
+			err := r.reconcileVersionUpgrade(context.Background(), logf.Log)
+			if test.expectedNotReady {
+				require.ErrorAs(t, err, &errorfactory.ResourceNotReady{})
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, test.expectedPhase, r.KafkaCluster.Status.VersionUpgrade.Phase)
+
+			job := &batchv1.Job{}
+			err = c.Get(context.Background(), types.NamespacedName{Name: "kafka-metadata-version", Namespace: "kafka"}, job)
+			if !test.expectJob {
+				require.True(t, apierrors.IsNotFound(err))
+				return
+			}
+			require.NoError(t, err)
+			if test.expectedJobCommand != "" {
+				require.Equal(t, "ghcr.io/adobe/koperator/kafka:2.13-3.9.1", job.Spec.Template.Spec.Containers[0].Image)
+				require.Equal(t, []string{"/bin/bash", "-c", test.expectedJobCommand}, job.Spec.Template.Spec.Containers[0].Command)
+			}
+		})
+	}
+}
+
+func TestMetadataVersionJobSSL(t *testing.T) {
+	cluster := &v1beta1.KafkaCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+		Spec: v1beta1.KafkaClusterSpec{
+			ClusterImage:       "ghcr.io/adobe/koperator/kafka:2.13-3.9.1",
+			BrokerConfigGroups: map[string]v1beta1.BrokerConfig{"default": {}},
+			Brokers:            []v1beta1.Broker{{Id: 0, BrokerConfigGroup: "default"}},
+			ListenersConfig: v1beta1.ListenersConfig{InternalListeners: []v1beta1.InternalListenerConfig{{
+				CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolSSL,
+					ContainerPort: 29092, UsedForInnerBrokerCommunication: true},
+			}}},
+		},
+	}
+	r := New(nil, nil, cluster, nil, nil)
+
+	job, err := r.metadataVersionJob("kafka-metadata-version", "3.9-IV0")
+	require.NoError(t, err)
+	container := job.Spec.Template.Spec.Containers[0]
+	require.Equal(t, []corev1.VolumeMount{{Name: clientKeystoreVolume, MountPath: clientKeystorePath}}, container.VolumeMounts)
+	require.Equal(t, "kafka-controller", container.Env[0].ValueFrom.SecretKeyRef.Name)
+	require.Contains(t, container.Command[2], "security.protocol=SSL")
+	require.Contains(t, container.Command[2], "--command-config /tmp/client.properties upgrade --metadata 3.9-IV0")
+	require.Equal(t, "kafka-controller", job.Spec.Template.Spec.Volumes[0].Secret.SecretName)
+}
+
+func versionUpgradeTestScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	return scheme
+}
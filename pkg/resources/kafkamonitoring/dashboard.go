@@ -0,0 +1,200 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkamonitoring
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"emperror.dev/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/pkg/resources/templates"
+)
+
+const (
+	// GrafanaDashboardTemplate holds the template of the name of the ConfigMap with the Grafana dashboard of the cluster
+	GrafanaDashboardTemplate = "%s-kafka-grafana-dashboard"
+
+	dashboardPanelWidth  = 12
+	dashboardPanelHeight = 8
+)
+
+// dashboardPanel is a time series panel of the dashboard with one query
+type dashboardPanel struct {
+	title  string
+	unit   string
+	expr   string
+	legend string
+}
+
+// grafanaDashboard generates the ConfigMap with the Grafana dashboard of the cluster, its broker variable lists the
+// ids of the brokers of the cluster
+func (r *Reconciler) grafanaDashboard() (runtime.Object, error) {
+	config := r.KafkaCluster.Spec.MonitoringConfig.GrafanaDashboard
+	dashboard, err := json.MarshalIndent(r.dashboard(), "", "  ")
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not marshal the Grafana dashboard")
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: templates.ObjectMeta(fmt.Sprintf(GrafanaDashboardTemplate, r.KafkaCluster.Name),
+			apiutil.MergeLabels(labelsForMonitoring(r.KafkaCluster.Name), config.GetLabels()), r.KafkaCluster),
+		Data: map[string]string{fmt.Sprintf("%s-%s-kafka.json", r.KafkaCluster.Namespace, r.KafkaCluster.Name): string(dashboard)},
+	}, nil
+}
+
+func (r *Reconciler) dashboard() map[string]interface{} {
+	selector := fmt.Sprintf(`kafka_cr="%s",namespace="%s",brokerId=~"$broker"`, r.KafkaCluster.Name, r.KafkaCluster.Namespace)
+	volumeSelector := fmt.Sprintf(`namespace="%s",persistentvolumeclaim=~"%s-(${broker:regex})-storage-.*"`,
+		r.KafkaCluster.Namespace, r.KafkaCluster.Name)
+
+	panels := []dashboardPanel{
+		{
+			title:  "Under-replicated partitions",
+			unit:   "short",
+			expr:   fmt.Sprintf("sum by (brokerId) (kafka_server_replicamanager_underreplicatedpartitions{%s})", selector),
+			legend: "broker {{brokerId}}",
+		},
+		{
+			title:  "Offline partitions",
+			unit:   "short",
+			expr:   fmt.Sprintf("sum by (brokerId) (kafka_controller_kafkacontroller_offlinepartitionscount{%s})", selector),
+			legend: "broker {{brokerId}}",
+		},
+		{
+			title:  "Bytes in",
+			unit:   "Bps",
+			expr:   fmt.Sprintf("sum by (brokerId) (rate(kafka_server_brokertopicmetrics_bytesin_total{%s}[5m]))", selector),
+			legend: "broker {{brokerId}}",
+		},
+		{
+			title:  "Bytes out",
+			unit:   "Bps",
+			expr:   fmt.Sprintf("sum by (brokerId) (rate(kafka_server_brokertopicmetrics_bytesout_total{%s}[5m]))", selector),
+			legend: "broker {{brokerId}}",
+		},
+		{
+			title:  "Messages in",
+			unit:   "short",
+			expr:   fmt.Sprintf("sum by (brokerId) (rate(kafka_server_brokertopicmetrics_messagesin_total{%s}[5m]))", selector),
+			legend: "broker {{brokerId}}",
+		},
+		{
+			title:  "Partitions",
+			unit:   "short",
+			expr:   fmt.Sprintf("sum by (brokerId) (kafka_server_replicamanager_partitioncount{%s})", selector),
+			legend: "broker {{brokerId}}",
+		},
+		{
+			title: "Disk usage",
+			unit:  "percent",
+			expr: fmt.Sprintf("100 * (1 - kubelet_volume_stats_available_bytes{%s} / kubelet_volume_stats_capacity_bytes{%s})",
+				volumeSelector, volumeSelector),
+			legend: "{{persistentvolumeclaim}}",
+		},
+	}
+
+	dashboardPanels := make([]interface{}, 0, len(panels))
+	for i, panel := range panels {
+		dashboardPanels = append(dashboardPanels, map[string]interface{}{
+			"id":         i + 1,
+			"type":       "timeseries",
+			"title":      panel.title,
+			"datasource": map[string]interface{}{"type": "prometheus", "uid": "${datasource}"},
+			"gridPos": map[string]interface{}{
+				"h": dashboardPanelHeight,
+				"w": dashboardPanelWidth,
+				"x": (i % 2) * dashboardPanelWidth,
+				"y": (i / 2) * dashboardPanelHeight,
+			},
+			"fieldConfig": map[string]interface{}{
+				"defaults":  map[string]interface{}{"unit": panel.unit},
+				"overrides": []interface{}{},
+			},
+			"targets": []interface{}{
+				map[string]interface{}{"refId": "A", "expr": panel.expr, "legendFormat": panel.legend},
+			},
+		})
+	}
+
+	brokerIDs := r.dashboardBrokerIDs()
+	brokerOptions := make([]interface{}, 0, len(brokerIDs))
+	for _, brokerID := range brokerIDs {
+		brokerOptions = append(brokerOptions, map[string]interface{}{"text": brokerID, "value": brokerID, "selected": false})
+	}
+
+	return map[string]interface{}{
+		"uid":           dashboardUID(r.KafkaCluster.Namespace, r.KafkaCluster.Name),
+		"title":         fmt.Sprintf("Kafka cluster %s/%s", r.KafkaCluster.Namespace, r.KafkaCluster.Name),
+		"tags":          []interface{}{"kafka", "koperator"},
+		"editable":      false,
+		"schemaVersion": 39,
+		"refresh":       "1m",
+		"time":          map[string]interface{}{"from": "now-6h", "to": "now"},
+		"panels":        dashboardPanels,
+		"templating": map[string]interface{}{
+			"list": []interface{}{
+				map[string]interface{}{
+					"name":  "datasource",
+					"label": "Data source",
+					"type":  "datasource",
+					"query": "prometheus",
+				},
+				map[string]interface{}{
+					"name":       "broker",
+					"label":      "Broker",
+					"type":       "custom",
+					"query":      strings.Join(brokerIDs, ","),
+					"options":    brokerOptions,
+					"multi":      true,
+					"includeAll": true,
+					"current":    map[string]interface{}{"text": []interface{}{"All"}, "value": []interface{}{"$__all"}},
+				},
+			},
+		},
+	}
+}
+
+// dashboardBrokerIDs returns the sorted ids of the brokers of the cluster, the KRaft controller-only nodes excluded
+func (r *Reconciler) dashboardBrokerIDs() []string {
+	var ids []int
+	for _, broker := range r.KafkaCluster.Spec.Brokers {
+		brokerConfig, err := broker.GetBrokerConfig(r.KafkaCluster.Spec)
+		if err == nil && brokerConfig != nil && r.KafkaCluster.Spec.KRaftMode && brokerConfig.IsControllerOnlyNode() {
+			continue
+		}
+		ids = append(ids, int(broker.Id))
+	}
+	sort.Ints(ids)
+	brokerIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		brokerIDs = append(brokerIDs, strconv.Itoa(id))
+	}
+	return brokerIDs
+}
+
+// dashboardUID returns the uid of the dashboard of the cluster, stable and within the 40 characters Grafana allows
+func dashboardUID(namespace, name string) string {
+	hash := sha256.Sum256([]byte(namespace + "/" + name))
+	return "kafka-" + hex.EncodeToString(hash[:])[:16]
+}
@@ -15,7 +15,16 @@
 package kafkamonitoring
 
 import (
+	"context"
+	"fmt"
+
+	"emperror.dev/errors"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/banzaicloud/koperator/api/v1beta1"
@@ -56,11 +65,62 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 		return err
 	}
 
+	if r.KafkaCluster.Spec.MonitoringConfig.IsPrometheusRulesEnabled() {
+		rule, err := r.prometheusRule()
+		if err != nil {
+			return err
+		}
+		if err := k8sutil.Reconcile(log, r.Client, rule, r.KafkaCluster); err != nil {
+			return err
+		}
+	} else {
+		rule := &unstructured.Unstructured{}
+		rule.SetGroupVersionKind(prometheusRuleGVK)
+		if err := r.removeResource(rule, fmt.Sprintf(PrometheusRuleTemplate, r.KafkaCluster.Name)); err != nil {
+			return err
+		}
+	}
+
+	if r.KafkaCluster.Spec.MonitoringConfig.IsGrafanaDashboardEnabled() {
+		dashboard, err := r.grafanaDashboard()
+		if err != nil {
+			return err
+		}
+		if err := k8sutil.Reconcile(log, r.Client, dashboard, r.KafkaCluster); err != nil {
+			return err
+		}
+	} else if err := r.removeResource(&corev1.ConfigMap{}, fmt.Sprintf(GrafanaDashboardTemplate, r.KafkaCluster.Name)); err != nil {
+		return err
+	}
+
 	log.V(1).Info("Reconciled")
 
 	return nil
 }
 
+// removeResource removes the generated monitoring resource of the cluster when it is disabled, if any
+func (r *Reconciler) removeResource(obj client.Object, name string) error {
+	ctx := context.Background()
+	if err := r.Get(ctx, client.ObjectKey{Namespace: r.KafkaCluster.Namespace, Name: name}, obj); err != nil {
+		// the Prometheus Operator CRDs are not installed, there is nothing to remove
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return errors.WrapIfWithDetails(err, "could not get the monitoring resource", "name", name)
+	}
+	if !metav1.IsControlledBy(obj, r.KafkaCluster) {
+		return nil
+	}
+	if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+		return errors.WrapIfWithDetails(err, "could not remove the monitoring resource", "name", name)
+	}
+	return nil
+}
+
+func labelsForMonitoring(name string) map[string]string {
+	return map[string]string{v1beta1.AppLabelKey: "kafka-monitoring", v1beta1.KafkaCRLabelKey: name}
+}
+
 func labelsForJmx(name string) map[string]string {
 	return map[string]string{v1beta1.AppLabelKey: "kafka-jmx", v1beta1.KafkaCRLabelKey: name}
 }
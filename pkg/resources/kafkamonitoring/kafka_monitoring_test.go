@@ -0,0 +1,145 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkamonitoring
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/util"
+)
+
+func TestReconcileMonitoringResources(t *testing.T) {
+	testCases := []struct {
+		testName             string
+		prometheusRules      *v1beta1.PrometheusRulesConfig
+		grafanaDashboard     *v1beta1.GrafanaDashboardConfig
+		expectedRules        []string
+		expectedDiskAlert    string
+		expectedAnomalyAlert string
+		expectedLabels       map[string]string
+		expectedBrokerIDs    string
+	}{
+		{
+			testName: "monitoring resources disabled",
+		},
+		{
+			testName:         "monitoring resources enabled",
+			prometheusRules:  &v1beta1.PrometheusRulesConfig{Enabled: true, Labels: map[string]string{"release": "prometheus"}, DiskUsageThresholdPercent: util.Int32Pointer(90)},
+			grafanaDashboard: &v1beta1.GrafanaDashboardConfig{Enabled: true},
+			expectedRules: []string{"KafkaUnderReplicatedPartitions", "KafkaOfflinePartitions", "KafkaBrokerDiskNearingCapacity",
+				"KafkaCruiseControlAnomaly"},
+			expectedDiskAlert: `100 * (1 - kubelet_volume_stats_available_bytes{namespace="kafka",persistentvolumeclaim=~"kafka-[0-9]+-storage-.*"}` +
+				` / kubelet_volume_stats_capacity_bytes{namespace="kafka",persistentvolumeclaim=~"kafka-[0-9]+-storage-.*"}) > 90`,
+			expectedAnomalyAlert: `sum by (namespace, kafka_cr, type) (koperator_cruisecontrol_anomalies{kafka_cr="kafka",namespace="kafka",status!="FIX_STARTED"}) > 0`,
+			expectedLabels:       map[string]string{"grafana_dashboard": "1"},
+			expectedBrokerIDs:    "0,1,2",
+		},
+		{
+			testName:          "grafana dashboard with custom labels",
+			grafanaDashboard:  &v1beta1.GrafanaDashboardConfig{Enabled: true, Labels: map[string]string{"dashboards": "kafka"}},
+			expectedLabels:    map[string]string{"dashboards": "kafka"},
+			expectedBrokerIDs: "0,1,2",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka", UID: "uid"},
+				Spec: v1beta1.KafkaClusterSpec{
+					Brokers: []v1beta1.Broker{{Id: 2}, {Id: 0}, {Id: 1}},
+					MonitoringConfig: v1beta1.MonitoringConfig{
+						PrometheusRules:  test.prometheusRules,
+						GrafanaDashboard: test.grafanaDashboard,
+					},
+				},
+			}
+			scheme := runtime.NewScheme()
+			require.NoError(t, corev1.AddToScheme(scheme))
+			require.NoError(t, v1beta1.AddToScheme(scheme))
+			scheme.AddKnownTypeWithName(prometheusRuleGVK, &unstructured.Unstructured{})
+			c := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			require.NoError(t, New(c, cluster).Reconcile(logr.Discard()))
+
+			rule := &unstructured.Unstructured{}
+			rule.SetGroupVersionKind(prometheusRuleGVK)
+			err := c.Get(context.Background(), types.NamespacedName{Namespace: "kafka", Name: "kafka-kafka-alerts"}, rule)
+			if test.expectedRules == nil {
+				assert.True(t, apierrors.IsNotFound(err))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "prometheus", rule.GetLabels()["release"])
+				groups, _, _ := unstructured.NestedSlice(rule.Object, "spec", "groups")
+				require.Len(t, groups, 1)
+				var alerts []string
+				for _, r := range groups[0].(map[string]interface{})["rules"].([]interface{}) {
+					alert := r.(map[string]interface{})
+					alerts = append(alerts, alert["alert"].(string))
+					if alert["alert"] == "KafkaBrokerDiskNearingCapacity" {
+						assert.Equal(t, test.expectedDiskAlert, alert["expr"])
+					}
+					if alert["alert"] == "KafkaCruiseControlAnomaly" {
+						assert.Equal(t, test.expectedAnomalyAlert, alert["expr"])
+					}
+				}
+				assert.Equal(t, test.expectedRules, alerts)
+			}
+
+			configMap := &corev1.ConfigMap{}
+			err = c.Get(context.Background(), types.NamespacedName{Namespace: "kafka", Name: "kafka-kafka-grafana-dashboard"}, configMap)
+			if test.expectedLabels == nil {
+				assert.True(t, apierrors.IsNotFound(err))
+				return
+			}
+			require.NoError(t, err)
+			for key, value := range test.expectedLabels {
+				assert.Equal(t, value, configMap.Labels[key])
+			}
+			var dashboard struct {
+				Templating struct {
+					List []struct {
+						Name  string `json:"name"`
+						Query string `json:"query"`
+					} `json:"list"`
+				} `json:"templating"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(configMap.Data["kafka-kafka-kafka.json"]), &dashboard))
+			require.Len(t, dashboard.Templating.List, 2)
+			assert.Equal(t, test.expectedBrokerIDs, dashboard.Templating.List[1].Query)
+
+			// the generated resources are removed once disabled
+			cluster.Spec.MonitoringConfig = v1beta1.MonitoringConfig{}
+			require.NoError(t, New(c, cluster).Reconcile(logr.Discard()))
+			err = c.Get(context.Background(), types.NamespacedName{Namespace: "kafka", Name: "kafka-kafka-grafana-dashboard"}, configMap)
+			assert.True(t, apierrors.IsNotFound(err))
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkamonitoring
+
+import (
+	"fmt"
+
+	"emperror.dev/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/pkg/resources/templates"
+)
+
+const (
+	// PrometheusRuleTemplate holds the template of the name of the PrometheusRule with the alerts of the cluster
+	PrometheusRuleTemplate = "%s-kafka-alerts"
+
+	alertSeverityLabel = "severity"
+	alertSeverityWarn  = "warning"
+	alertSeverityCrit  = "critical"
+)
+
+var prometheusRuleGVK = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PrometheusRule"}
+
+// prometheusRuleSpec is the spec of the PrometheusRule, the Prometheus Operator API is not a dependency of the operator
+type prometheusRuleSpec struct {
+	Groups []prometheusRuleGroup `json:"groups"`
+}
+
+type prometheusRuleGroup struct {
+	Name  string           `json:"name"`
+	Rules []prometheusRule `json:"rules"`
+}
+
+type prometheusRule struct {
+	Alert       string            `json:"alert"`
+	Expr        string            `json:"expr"`
+	For         string            `json:"for,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// prometheusRule generates the PrometheusRule with the alerts of the under-replicated and offline partitions, the
+// broker volumes nearing capacity and the Cruise Control anomalies of the cluster
+func (r *Reconciler) prometheusRule() (runtime.Object, error) {
+	config := r.KafkaCluster.Spec.MonitoringConfig.PrometheusRules
+	selector := fmt.Sprintf(`kafka_cr="%s",namespace="%s"`, r.KafkaCluster.Name, r.KafkaCluster.Namespace)
+	volumeSelector := fmt.Sprintf(`namespace="%s",persistentvolumeclaim=~"%s-[0-9]+-storage-.*"`, r.KafkaCluster.Namespace, r.KafkaCluster.Name)
+
+	spec := &prometheusRuleSpec{
+		Groups: []prometheusRuleGroup{
+			{
+				Name: fmt.Sprintf("%s.%s.kafka", r.KafkaCluster.Namespace, r.KafkaCluster.Name),
+				Rules: []prometheusRule{
+					{
+						Alert:  "KafkaUnderReplicatedPartitions",
+						Expr:   fmt.Sprintf("sum by (namespace, kafka_cr, brokerId) (kafka_server_replicamanager_underreplicatedpartitions{%s}) > 0", selector),
+						For:    "5m",
+						Labels: map[string]string{alertSeverityLabel: alertSeverityWarn},
+						Annotations: map[string]string{
+							"summary":     "Kafka broker has under-replicated partitions",
+							"description": "Broker {{ $labels.brokerId }} of the Kafka cluster {{ $labels.namespace }}/{{ $labels.kafka_cr }} has {{ $value }} under-replicated partitions",
+						},
+					},
+					{
+						Alert:  "KafkaOfflinePartitions",
+						Expr:   fmt.Sprintf("sum by (namespace, kafka_cr) (kafka_controller_kafkacontroller_offlinepartitionscount{%s}) > 0", selector),
+						For:    "1m",
+						Labels: map[string]string{alertSeverityLabel: alertSeverityCrit},
+						Annotations: map[string]string{
+							"summary":     "Kafka cluster has offline partitions",
+							"description": "The Kafka cluster {{ $labels.namespace }}/{{ $labels.kafka_cr }} has {{ $value }} partitions without an active leader",
+						},
+					},
+					{
+						Alert: "KafkaBrokerDiskNearingCapacity",
+						Expr: fmt.Sprintf("100 * (1 - kubelet_volume_stats_available_bytes{%s} / kubelet_volume_stats_capacity_bytes{%s}) > %d",
+							volumeSelector, volumeSelector, config.GetDiskUsageThresholdPercent()),
+						For:    "5m",
+						Labels: map[string]string{alertSeverityLabel: alertSeverityWarn},
+						Annotations: map[string]string{
+							"summary":     "Kafka broker volume is nearing capacity",
+							"description": "The volume {{ $labels.persistentvolumeclaim }} of the Kafka cluster " + r.KafkaCluster.Name + " is {{ $value | humanize }}% full",
+						},
+					},
+					{
+						Alert:  "KafkaCruiseControlAnomaly",
+						Expr:   fmt.Sprintf(`sum by (namespace, kafka_cr, type) (koperator_cruisecontrol_anomalies{%s,status!="FIX_STARTED"}) > 0`, selector),
+						For:    "15m",
+						Labels: map[string]string{alertSeverityLabel: alertSeverityWarn},
+						Annotations: map[string]string{
+							"summary":     "Cruise Control detected an anomaly of the Kafka cluster",
+							"description": "Cruise Control detected a {{ $labels.type }} anomaly of the Kafka cluster {{ $labels.namespace }}/{{ $labels.kafka_cr }} whose fix has not been started for 15 minutes",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	specObject, err := runtime.DefaultUnstructuredConverter.ToUnstructured(spec)
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not convert the PrometheusRule spec")
+	}
+	rule := &unstructured.Unstructured{Object: map[string]interface{}{"spec": specObject}}
+	rule.SetGroupVersionKind(prometheusRuleGVK)
+	objectMeta := templates.ObjectMeta(fmt.Sprintf(PrometheusRuleTemplate, r.KafkaCluster.Name),
+		apiutil.MergeLabels(labelsForMonitoring(r.KafkaCluster.Name), config.Labels), r.KafkaCluster)
+	rule.SetName(objectMeta.Name)
+	rule.SetNamespace(objectMeta.Namespace)
+	rule.SetLabels(objectMeta.Labels)
+	rule.SetOwnerReferences(objectMeta.OwnerReferences)
+	return rule, nil
+}
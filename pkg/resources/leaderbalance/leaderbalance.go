@@ -0,0 +1,175 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leaderbalance
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/kafkaclient"
+	"github.com/banzaicloud/koperator/pkg/resources"
+)
+
+const (
+	componentName = "leaderBalance"
+
+	// preferredLeadersElectedEventReason is the reason of the events reporting the election of the preferred leaders
+	preferredLeadersElectedEventReason = "PreferredLeadersElected"
+)
+
+// Reconciler implements the Component Reconciler
+type Reconciler struct {
+	resources.Reconciler
+	kafkaClientProvider kafkaclient.Provider
+	recorder            record.EventRecorder
+}
+
+// New creates a new reconciler for the leadership balance of the partitions
+func New(client client.Client, cluster *v1beta1.KafkaCluster, kafkaClientProvider kafkaclient.Provider, recorder record.EventRecorder) *Reconciler {
+	return &Reconciler{
+		Reconciler: resources.Reconciler{
+			Client:       client,
+			KafkaCluster: cluster,
+		},
+		kafkaClientProvider: kafkaClientProvider,
+		recorder:            recorder,
+	}
+}
+
+// Reconcile elects the preferred leaders of the partitions once the share of the partitions led by another broker
+// exceeds the imbalance threshold of the cluster, e.g. after a rolling restart or the recovery of the cluster, and
+// reports the leadership imbalance in the status of the cluster. The election waits for the running rolling upgrade
+// and Cruise Control operations to finish.
+func (r *Reconciler) Reconcile(log logr.Logger) error {
+	log = log.WithValues("component", componentName)
+
+	log.V(1).Info("Reconciling")
+	if r.KafkaCluster.Spec.LeaderBalance == nil {
+		if r.KafkaCluster.Status.LeaderBalance != nil {
+			return k8sutil.UpdateCRStatus(r.Client, r.KafkaCluster, (*v1beta1.LeaderBalanceStatus)(nil), log)
+		}
+		return nil
+	}
+
+	kClient, closeClient, err := r.kafkaClientProvider.NewFromCluster(r.Client, r.KafkaCluster)
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+
+	imbalance, err := kClient.LeadershipImbalance()
+	if err != nil {
+		return err
+	}
+
+	status := &v1beta1.LeaderBalanceStatus{}
+	if previous := r.KafkaCluster.Status.LeaderBalance; previous != nil {
+		status.LastElectionTime = previous.LastElectionTime
+		status.LastElectedPartitions = previous.LastElectedPartitions
+	}
+
+	threshold := int(r.KafkaCluster.Spec.LeaderBalance.GetImbalanceThresholdPercentage())
+	if imbalance.NonPreferredLeaderPartitions > 0 && imbalance.NonPreferredLeaderPartitions*100 > threshold*imbalance.Partitions {
+		blocker, err := r.electionBlocker()
+		if err != nil {
+			return err
+		}
+		if blocker != "" {
+			log.Info("election of the preferred leaders is deferred", "reason", blocker,
+				"nonPreferredLeaderPartitions", imbalance.NonPreferredLeaderPartitions, "totalPartitions", imbalance.Partitions)
+			return r.updateStatus(log, status, imbalance)
+		}
+		elected, err := kClient.ElectPreferredLeaders()
+		if err != nil {
+			return err
+		}
+		if elected > 0 {
+			log.Info("preferred leaders elected", "partitions", elected,
+				"nonPreferredLeaderPartitions", imbalance.NonPreferredLeaderPartitions, "totalPartitions", imbalance.Partitions)
+			k8sutil.RecordEvent(r.recorder, r.KafkaCluster, corev1.EventTypeNormal, preferredLeadersElectedEventReason,
+				"the preferred leaders of %d partitions were elected, %d of %d partitions were led by another broker",
+				elected, imbalance.NonPreferredLeaderPartitions, imbalance.Partitions)
+			now := metav1.Now()
+			status.LastElectionTime = &now
+			status.LastElectedPartitions = int32(elected)
+
+			if imbalance, err = kClient.LeadershipImbalance(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return r.updateStatus(log, status, imbalance)
+}
+
+// electionBlocker returns the reason the preferred leaders must not be elected, or an empty string when they can be.
+// The leaderships are moved by the brokers restarted during a rolling upgrade and by the reassignments of a running
+// Cruise Control operation, the preferred leaders are elected once those are done.
+func (r *Reconciler) electionBlocker() (string, error) {
+	if r.KafkaCluster.Status.State == v1beta1.KafkaClusterRollingUpgrading {
+		return "rolling upgrade in progress", nil
+	}
+	ccOperations := &v1alpha1.CruiseControlOperationList{}
+	err := r.List(context.TODO(), ccOperations, client.InNamespace(r.KafkaCluster.Namespace),
+		client.MatchingLabels(apiutil.LabelsForKafka(r.KafkaCluster.Name)))
+	if err != nil {
+		return "", errors.WrapIf(err, "could not list the Cruise Control operations of the cluster")
+	}
+	for i := range ccOperations.Items {
+		if ccOperations.Items[i].IsCurrentTaskRunning() {
+			return fmt.Sprintf("Cruise Control operation %s is running", ccOperations.Items[i].Name), nil
+		}
+	}
+	return "", nil
+}
+
+// updateStatus reports the leadership imbalance in the status of the cluster
+func (r *Reconciler) updateStatus(log logr.Logger, status *v1beta1.LeaderBalanceStatus, imbalance kafkaclient.LeadershipImbalance) error {
+	status.Partitions = int32(imbalance.Partitions)
+	status.NonPreferredLeaderPartitions = int32(imbalance.NonPreferredLeaderPartitions)
+	if imbalance.Partitions > 0 {
+		status.ImbalancePercentage = int32(imbalance.NonPreferredLeaderPartitions * 100 / imbalance.Partitions)
+	}
+	if len(imbalance.LeaderCounts) > 0 {
+		status.LeaderCounts = make(map[string]int32, len(imbalance.LeaderCounts))
+		for brokerID, count := range imbalance.LeaderCounts {
+			status.LeaderCounts[strconv.Itoa(int(brokerID))] = int32(count)
+		}
+	}
+
+	if !reflect.DeepEqual(status, r.KafkaCluster.Status.LeaderBalance) {
+		if err := k8sutil.UpdateCRStatus(r.Client, r.KafkaCluster, status, log); err != nil {
+			return err
+		}
+	}
+
+	log.V(1).Info("Reconciled")
+
+	return nil
+}
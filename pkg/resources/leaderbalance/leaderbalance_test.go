@@ -0,0 +1,175 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leaderbalance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/kafkaclient"
+	"github.com/banzaicloud/koperator/pkg/resources/kafka/mocks"
+	"github.com/banzaicloud/koperator/pkg/util"
+)
+
+func ccOperation(name string, state v1beta1.CruiseControlUserTaskState) *v1alpha1.CruiseControlOperation {
+	return &v1alpha1.CruiseControlOperation{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kafka", Labels: apiutil.LabelsForKafka("kafka")},
+		Status: v1alpha1.CruiseControlOperationStatus{
+			CurrentTask: &v1alpha1.CruiseControlTask{ID: "1", Operation: v1alpha1.OperationRebalance, State: state},
+		},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	skewed := kafkaclient.LeadershipImbalance{Partitions: 10, NonPreferredLeaderPartitions: 4, LeaderCounts: map[int32]int{0: 7, 1: 3}}
+	balanced := kafkaclient.LeadershipImbalance{Partitions: 10, NonPreferredLeaderPartitions: 0, LeaderCounts: map[int32]int{0: 5, 1: 5}}
+
+	testCases := []struct {
+		testName       string
+		config         *v1beta1.LeaderBalanceConfig
+		previousStatus *v1beta1.LeaderBalanceStatus
+		state          v1beta1.ClusterState
+		objects        []client.Object
+		imbalances     []kafkaclient.LeadershipImbalance
+		elected        int
+		expectedStatus *v1beta1.LeaderBalanceStatus
+	}{
+		{
+			testName:       "not configured",
+			previousStatus: &v1beta1.LeaderBalanceStatus{Partitions: 10},
+		},
+		{
+			testName:   "balanced",
+			config:     &v1beta1.LeaderBalanceConfig{},
+			imbalances: []kafkaclient.LeadershipImbalance{balanced},
+			expectedStatus: &v1beta1.LeaderBalanceStatus{
+				Partitions:   10,
+				LeaderCounts: map[string]int32{"0": 5, "1": 5},
+			},
+		},
+		{
+			testName:   "imbalance below the threshold",
+			config:     &v1beta1.LeaderBalanceConfig{ImbalanceThresholdPercentage: util.Int32Pointer(50)},
+			imbalances: []kafkaclient.LeadershipImbalance{skewed},
+			expectedStatus: &v1beta1.LeaderBalanceStatus{
+				Partitions:                   10,
+				NonPreferredLeaderPartitions: 4,
+				ImbalancePercentage:          40,
+				LeaderCounts:                 map[string]int32{"0": 7, "1": 3},
+			},
+		},
+		{
+			testName:   "imbalance above the threshold",
+			config:     &v1beta1.LeaderBalanceConfig{},
+			imbalances: []kafkaclient.LeadershipImbalance{skewed, balanced},
+			elected:    4,
+			expectedStatus: &v1beta1.LeaderBalanceStatus{
+				Partitions:            10,
+				LeaderCounts:          map[string]int32{"0": 5, "1": 5},
+				LastElectedPartitions: 4,
+			},
+		},
+		{
+			testName:   "election deferred during a rolling upgrade",
+			config:     &v1beta1.LeaderBalanceConfig{},
+			state:      v1beta1.KafkaClusterRollingUpgrading,
+			imbalances: []kafkaclient.LeadershipImbalance{skewed},
+			expectedStatus: &v1beta1.LeaderBalanceStatus{
+				Partitions:                   10,
+				NonPreferredLeaderPartitions: 4,
+				ImbalancePercentage:          40,
+				LeaderCounts:                 map[string]int32{"0": 7, "1": 3},
+			},
+		},
+		{
+			testName:   "election deferred while a Cruise Control operation is running",
+			config:     &v1beta1.LeaderBalanceConfig{},
+			objects:    []client.Object{ccOperation("rebalance", v1beta1.CruiseControlTaskInExecution)},
+			imbalances: []kafkaclient.LeadershipImbalance{skewed},
+			expectedStatus: &v1beta1.LeaderBalanceStatus{
+				Partitions:                   10,
+				NonPreferredLeaderPartitions: 4,
+				ImbalancePercentage:          40,
+				LeaderCounts:                 map[string]int32{"0": 7, "1": 3},
+			},
+		},
+		{
+			testName:   "finished Cruise Control operation does not defer the election",
+			config:     &v1beta1.LeaderBalanceConfig{},
+			objects:    []client.Object{ccOperation("rebalance", v1beta1.CruiseControlTaskCompleted)},
+			imbalances: []kafkaclient.LeadershipImbalance{skewed, balanced},
+			elected:    4,
+			expectedStatus: &v1beta1.LeaderBalanceStatus{
+				Partitions:            10,
+				LeaderCounts:          map[string]int32{"0": 5, "1": 5},
+				LastElectedPartitions: 4,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, v1beta1.AddToScheme(scheme))
+			require.NoError(t, v1alpha1.AddToScheme(scheme))
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Spec:       v1beta1.KafkaClusterSpec{LeaderBalance: test.config},
+				Status:     v1beta1.KafkaClusterStatus{State: test.state, LeaderBalance: test.previousStatus},
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(cluster).
+				WithObjects(append(test.objects, cluster)...).Build()
+
+			kafkaClientProvider := new(kafkaclient.MockedProvider)
+			kafkaClient := mocks.NewMockKafkaClient(gomock.NewController(t))
+			kafkaClientProvider.On("NewFromCluster", mock.Anything, mock.Anything).Return(kafkaClient, func() {}, nil)
+			// the imbalance is described again after the election of the preferred leaders
+			for i, imbalance := range test.imbalances {
+				if i > 0 {
+					kafkaClient.EXPECT().ElectPreferredLeaders().Return(test.elected, nil)
+				}
+				kafkaClient.EXPECT().LeadershipImbalance().Return(imbalance, nil)
+			}
+			recorder := record.NewFakeRecorder(1)
+
+			require.NoError(t, New(c, cluster, kafkaClientProvider, recorder).Reconcile(logr.Discard()))
+
+			updated := &v1beta1.KafkaCluster{}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(cluster), updated))
+			status := updated.Status.LeaderBalance
+			if test.elected > 0 {
+				require.NotNil(t, status.LastElectionTime)
+				status.LastElectionTime = nil
+				require.Contains(t, <-recorder.Events, preferredLeadersElectedEventReason)
+			}
+			require.Equal(t, test.expectedStatus, status)
+			require.Empty(t, recorder.Events)
+		})
+	}
+}
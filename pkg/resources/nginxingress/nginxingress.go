@@ -0,0 +1,447 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nginxingress
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/resources"
+	"github.com/banzaicloud/koperator/pkg/resources/templates"
+	"github.com/banzaicloud/koperator/pkg/util"
+	nginxutils "github.com/banzaicloud/koperator/pkg/util/nginx"
+)
+
+const (
+	componentName = "nginxIngressExternalAccess"
+	appLabelValue = "nginxingress"
+)
+
+// Reconciler implements the Component Reconciler
+type Reconciler struct {
+	resources.Reconciler
+	// directClient reads the resources of the ingress-nginx controller which may live in a namespace not watched by the operator
+	directClient client.Reader
+}
+
+// New creates a new reconciler for ingress-nginx based external access
+func New(client client.Client, directClient client.Reader, cluster *v1beta1.KafkaCluster) *Reconciler {
+	return &Reconciler{
+		Reconciler: resources.Reconciler{
+			Client:       client,
+			KafkaCluster: cluster,
+		},
+		directClient: directClient,
+	}
+}
+
+// Reconcile implements the reconcile logic for ingress-nginx based external access. The brokers are exposed as TCP
+// services of the ingress-nginx controller: a ClusterIP Service is created for every broker and for the anycast port,
+// they are registered in the TCP services ConfigMap of the controller and their ports are added to its Service.
+func (r *Reconciler) Reconcile(log logr.Logger) error {
+	log = log.WithValues("component", componentName)
+	log.V(1).Info("Reconciling")
+	ctx := context.Background()
+
+	for _, eListener := range r.KafkaCluster.Spec.ListenersConfig.ExternalListeners {
+		if r.KafkaCluster.Spec.GetIngressControllerForListener(eListener) == nginxutils.IngressControllerName &&
+			eListener.GetAccessMethod() == corev1.ServiceTypeLoadBalancer {
+			ingressConfigs, defaultControllerName, err := util.GetIngressConfigs(r.KafkaCluster.Spec, eListener)
+			if err != nil {
+				return err
+			}
+			var reconcileObjects []runtime.Object
+			controllers := make(map[string]v1beta1.NginxIngressConfig)
+			tcpServices := make(map[string]map[string]string)
+			for name, ingressConfig := range ingressConfigs {
+				if !util.IsIngressConfigInUse(name, defaultControllerName, r.KafkaCluster, log) {
+					continue
+				}
+				key := controllerKey(*ingressConfig.NginxIngressConfig)
+				controllers[key] = *ingressConfig.NginxIngressConfig
+				if tcpServices[key] == nil {
+					tcpServices[key] = make(map[string]string)
+				}
+
+				allBrokerService := r.allBrokerService(eListener, ingressConfig, name)
+				reconcileObjects = append(reconcileObjects, allBrokerService)
+				tcpServices[key][strconv.Itoa(int(eListener.GetAnyCastPort()))] = tcpServiceEntry(allBrokerService)
+
+				for _, broker := range r.KafkaCluster.Spec.Brokers {
+					brokerService := r.brokerService(broker.Id, eListener)
+					reconcileObjects = append(reconcileObjects, brokerService)
+					tcpServices[key][strconv.Itoa(int(eListener.GetBrokerPort(broker.Id)))] = tcpServiceEntry(brokerService)
+				}
+			}
+
+			for _, obj := range reconcileObjects {
+				if err = k8sutil.Reconcile(log, r.Client, obj, r.KafkaCluster); err != nil {
+					return err
+				}
+			}
+			for key, controller := range controllers {
+				if err = r.syncTCPServices(ctx, log, controller, eListener.Name, tcpServices[key]); err != nil {
+					return err
+				}
+			}
+		} else if util.ShouldRemoveUnusedIngressResources(r.KafkaCluster, eListener.Name) {
+			// Cleaning up unused nginx ingress resources when ingress controller is not nginx or externalListener access method is not LoadBalancer
+			if err := r.removeListener(ctx, log, eListener.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	log.V(1).Info("Reconciled")
+
+	return nil
+}
+
+// Finalize removes the TCP services of the cluster from the ingress-nginx controllers, those are not garbage
+// collected together with the KafkaCluster since they live in the namespace of the controller. The controllers are
+// only touched when the cluster has Services registered in them.
+func (r *Reconciler) Finalize(ctx context.Context, log logr.Logger) error {
+	var services corev1.ServiceList
+	if err := r.List(ctx, &services, client.InNamespace(r.KafkaCluster.GetNamespace()),
+		client.MatchingLabels(labelsForNginxIngressWithoutEListenerName(r.KafkaCluster.Name))); err != nil {
+		return errors.Wrap(err, "error when getting list of nginx ingress resources")
+	}
+	for _, controller := range r.registeredControllers(services.Items) {
+		if err := r.syncTCPServices(ctx, log, controller, "", nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeListener removes the TCP services of the external listener from the ingress-nginx controllers and deletes
+// their Services afterwards, so the ownership of the TCP services can be still determined if the removal fails
+func (r *Reconciler) removeListener(ctx context.Context, log logr.Logger, eListenerName string) error {
+	var services corev1.ServiceList
+	if err := r.List(ctx, &services, client.InNamespace(r.KafkaCluster.GetNamespace()),
+		client.MatchingLabels(labelsForNginxIngress(r.KafkaCluster.Name, eListenerName))); err != nil {
+		return errors.Wrap(err, "error when getting list of nginx ingress resources for deletion")
+	}
+	if len(services.Items) == 0 {
+		return nil
+	}
+
+	for _, controller := range r.registeredControllers(services.Items) {
+		if err := r.syncTCPServices(ctx, log, controller, eListenerName, nil); err != nil {
+			return err
+		}
+	}
+
+	deletionCounter := 0
+	for _, service := range services.Items {
+		if util.ObjectManagedByClusterRegistry(&service) || !service.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if err := r.Delete(ctx, &service); client.IgnoreNotFound(err) != nil {
+			return errors.Wrap(err, "error when removing nginx ingress resources")
+		}
+		log.V(1).Info(fmt.Sprintf("Deleted nginx ingress Service resource '%s' for externalListener '%s'", service.GetName(), eListenerName))
+		deletionCounter++
+	}
+	if deletionCounter > 0 {
+		log.Info(fmt.Sprintf("Removed '%d' resources for nginx ingress", deletionCounter))
+	}
+	return nil
+}
+
+// registeredControllers returns the ingress-nginx controllers the TCP services of the given Services are registered
+// in, as recorded on the anycast port Services. The controllers configured for the cluster are returned for the
+// Services created before the controllers were recorded.
+func (r *Reconciler) registeredControllers(services []corev1.Service) map[string]v1beta1.NginxIngressConfig {
+	controllers := make(map[string]v1beta1.NginxIngressConfig)
+	if len(services) == 0 {
+		return controllers
+	}
+	for _, service := range services {
+		namespace, names, _ := strings.Cut(service.Annotations[nginxutils.ControllerAnnotationKey], "/")
+		configMapName, serviceName, ok := strings.Cut(names, "/")
+		if !ok {
+			continue
+		}
+		controller := v1beta1.NginxIngressConfig{
+			ControllerNamespace:      namespace,
+			ControllerServiceName:    serviceName,
+			TCPServicesConfigMapName: configMapName,
+		}
+		controllers[controllerKey(controller)] = controller
+	}
+	if len(controllers) > 0 {
+		return controllers
+	}
+
+	controllers[controllerKey(r.KafkaCluster.Spec.NginxIngressConfig)] = r.KafkaCluster.Spec.NginxIngressConfig
+	for _, eListener := range r.KafkaCluster.Spec.ListenersConfig.ExternalListeners {
+		if eListener.Config == nil {
+			continue
+		}
+		for _, ingressConfig := range eListener.Config.IngressConfig {
+			if ingressConfig.NginxIngressConfig != nil {
+				controllers[controllerKey(*ingressConfig.NginxIngressConfig)] = *ingressConfig.NginxIngressConfig
+			}
+		}
+	}
+	return controllers
+}
+
+// syncTCPServices sets the desired TCP services of the external listener in the TCP services ConfigMap of the
+// ingress-nginx controller, removes the ones of the listener which are not desired anymore and aligns the ports of
+// the controller Service. All the TCP services of the cluster are considered when eListenerName is empty.
+// The TCP services pointing to Services not created by the operator are never modified.
+func (r *Reconciler) syncTCPServices(ctx context.Context, log logr.Logger, controller v1beta1.NginxIngressConfig,
+	eListenerName string, desired map[string]string) error {
+	configMap := &corev1.ConfigMap{}
+	configMapName := types.NamespacedName{Namespace: controller.GetControllerNamespace(), Name: controller.GetTCPServicesConfigMapName()}
+	err := r.directClient.Get(ctx, configMapName, configMap)
+	switch {
+	case apierrors.IsNotFound(err):
+		if len(desired) == 0 {
+			return nil
+		}
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: configMapName.Name, Namespace: configMapName.Namespace},
+			Data:       desired,
+		}
+		if err = r.Create(ctx, configMap); err != nil {
+			return errorfactory.New(errorfactory.APIFailure{}, err, "creating nginx tcp services configmap failed", "configMap", configMapName)
+		}
+		log.Info("nginx tcp services configmap created", "configMap", configMapName)
+		return r.syncControllerServicePorts(ctx, log, controller, configMap.Data)
+	case err != nil:
+		return errorfactory.New(errorfactory.APIFailure{}, err, "getting nginx tcp services configmap failed", "configMap", configMapName)
+	}
+
+	services, err := r.nginxIngressServices(ctx)
+	if err != nil {
+		return err
+	}
+	data := make(map[string]string, len(configMap.Data)+len(desired))
+	for port, entry := range configMap.Data {
+		if owner, ok := services[tcpServiceName(entry)]; ok && owner.cluster == r.KafkaCluster.Name &&
+			owner.namespace == r.KafkaCluster.Namespace && (eListenerName == "" || owner.listener == eListenerName) {
+			continue
+		}
+		data[port] = entry
+	}
+	for port, entry := range desired {
+		if current, ok := data[port]; ok && current != entry {
+			return errors.NewWithDetails("port of the ingress-nginx controller is already used by another TCP service",
+				"port", port, "tcpService", current, "configMap", configMapName)
+		}
+		data[port] = entry
+	}
+
+	if !reflect.DeepEqual(data, configMap.Data) && (len(data) > 0 || len(configMap.Data) > 0) {
+		configMap.Data = data
+		if err = r.Update(ctx, configMap); err != nil {
+			return errorfactory.New(errorfactory.APIFailure{}, err, "updating nginx tcp services configmap failed", "configMap", configMapName)
+		}
+		log.Info("nginx tcp services configmap updated", "configMap", configMapName)
+	}
+
+	return r.syncControllerServicePorts(ctx, log, controller, data)
+}
+
+// syncControllerServicePorts aligns the ports of the ingress-nginx controller Service added by the operator with the
+// TCP services of the Kafka clusters registered in the ConfigMap
+func (r *Reconciler) syncControllerServicePorts(ctx context.Context, log logr.Logger, controller v1beta1.NginxIngressConfig,
+	tcpServices map[string]string) error {
+	service := &corev1.Service{}
+	serviceName := types.NamespacedName{Namespace: controller.GetControllerNamespace(), Name: controller.GetControllerServiceName()}
+	if err := r.directClient.Get(ctx, serviceName, service); err != nil {
+		if apierrors.IsNotFound(err) {
+			return errorfactory.New(errorfactory.ResourceNotReady{}, err, "ingress-nginx controller service not found", "service", serviceName)
+		}
+		return errorfactory.New(errorfactory.APIFailure{}, err, "getting ingress-nginx controller service failed", "service", serviceName)
+	}
+
+	services, err := r.nginxIngressServices(ctx)
+	if err != nil {
+		return err
+	}
+	var kafkaPorts []int32
+	for port, entry := range tcpServices {
+		if _, ok := services[tcpServiceName(entry)]; !ok {
+			continue
+		}
+		portNumber, err := strconv.ParseInt(port, 10, 32)
+		if err != nil {
+			continue
+		}
+		kafkaPorts = append(kafkaPorts, int32(portNumber))
+	}
+	sort.Slice(kafkaPorts, func(i, j int) bool { return kafkaPorts[i] < kafkaPorts[j] })
+
+	ports := make([]corev1.ServicePort, 0, len(service.Spec.Ports)+len(kafkaPorts))
+	currentKafkaPorts := make(map[string]corev1.ServicePort)
+	for _, port := range service.Spec.Ports {
+		if strings.HasPrefix(port.Name, nginxutils.ControllerServicePortNamePrefix) {
+			currentKafkaPorts[port.Name] = port
+			continue
+		}
+		ports = append(ports, port)
+	}
+	for _, portNumber := range kafkaPorts {
+		name := fmt.Sprintf(nginxutils.ControllerServicePortNameTemplate, portNumber)
+		port := corev1.ServicePort{
+			Name:       name,
+			Port:       portNumber,
+			TargetPort: intstr.FromInt(int(portNumber)),
+			Protocol:   corev1.ProtocolTCP,
+		}
+		// keep the node port allocated for the port
+		if current, ok := currentKafkaPorts[name]; ok {
+			port.NodePort = current.NodePort
+		}
+		ports = append(ports, port)
+	}
+
+	if reflect.DeepEqual(ports, service.Spec.Ports) {
+		return nil
+	}
+	service.Spec.Ports = ports
+	if err = r.Update(ctx, service); err != nil {
+		return errorfactory.New(errorfactory.APIFailure{}, err, "updating ingress-nginx controller service failed", "service", serviceName)
+	}
+	log.Info("ingress-nginx controller service ports updated", "service", serviceName)
+	return nil
+}
+
+// nginxIngressService identifies the cluster and the external listener a Service created by the operator belongs to
+type nginxIngressService struct {
+	namespace string
+	cluster   string
+	listener  string
+}
+
+// nginxIngressServices returns the Services created by the operator for the ingress-nginx controllers of all the
+// Kafka clusters, keyed by the namespace/name used in the TCP services ConfigMap
+func (r *Reconciler) nginxIngressServices(ctx context.Context) (map[string]nginxIngressService, error) {
+	var services corev1.ServiceList
+	if err := r.directClient.List(ctx, &services, client.MatchingLabels{v1beta1.AppLabelKey: appLabelValue}); err != nil {
+		return nil, errorfactory.New(errorfactory.APIFailure{}, err, "listing nginx ingress services failed")
+	}
+	result := make(map[string]nginxIngressService, len(services.Items))
+	for _, service := range services.Items {
+		result[fmt.Sprintf("%s/%s", service.Namespace, service.Name)] = nginxIngressService{
+			namespace: service.Namespace,
+			cluster:   service.Labels[v1beta1.KafkaCRLabelKey],
+			listener:  service.Labels[util.ExternalListenerLabelNameKey],
+		}
+	}
+	return result, nil
+}
+
+// controllerKey identifies the ingress-nginx controller of the config
+func controllerKey(controller v1beta1.NginxIngressConfig) string {
+	return strings.Join([]string{controller.GetControllerNamespace(), controller.GetTCPServicesConfigMapName(),
+		controller.GetControllerServiceName()}, "/")
+}
+
+// tcpServiceEntry returns the entry of the TCP services ConfigMap of the ingress-nginx controller for the Service
+// in the <namespace>/<service name>:<service port> format
+func tcpServiceEntry(service *corev1.Service) string {
+	return fmt.Sprintf("%s/%s:%d", service.Namespace, service.Name, service.Spec.Ports[0].Port)
+}
+
+// tcpServiceName returns the <namespace>/<service name> part of the entry of the TCP services ConfigMap
+func tcpServiceName(entry string) string {
+	name, _, _ := strings.Cut(entry, ":")
+	return name
+}
+
+// generate service for broker
+func (r *Reconciler) brokerService(id int32, extListener v1beta1.ExternalListenerConfig) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: templates.ObjectMeta(
+			fmt.Sprintf(nginxutils.BrokerServiceNameTemplate, r.KafkaCluster.GetName(), id, strings.ReplaceAll(extListener.Name, "_", "-")),
+			apiutil.MergeLabels(
+				apiutil.LabelsForKafka(r.KafkaCluster.Name),
+				map[string]string{v1beta1.BrokerIdLabelKey: fmt.Sprintf("%d", id)},
+				labelsForNginxIngress(r.KafkaCluster.Name, extListener.Name)),
+			r.KafkaCluster),
+		Spec: corev1.ServiceSpec{
+			Selector: apiutil.MergeLabels(apiutil.LabelsForKafka(r.KafkaCluster.Name),
+				map[string]string{v1beta1.BrokerIdLabelKey: fmt.Sprintf("%d", id)}),
+			Type: corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{{
+				Name:       fmt.Sprintf("broker-%d", id),
+				Port:       extListener.GetAnyCastPort(),
+				TargetPort: intstr.FromInt(int(extListener.ContainerPort)),
+				Protocol:   corev1.ProtocolTCP,
+			},
+			},
+		},
+	}
+}
+
+// generate service for anycast port
+func (r *Reconciler) allBrokerService(extListener v1beta1.ExternalListenerConfig,
+	ingressConfig v1beta1.IngressConfig, ingressConfigName string) *corev1.Service {
+	serviceName := util.GenerateEnvoyResourceName(nginxutils.AllBrokerServiceName, nginxutils.AllBrokerServiceNameWithScope,
+		extListener, ingressConfig, ingressConfigName, r.KafkaCluster.GetName())
+
+	return &corev1.Service{
+		ObjectMeta: templates.ObjectMetaWithAnnotations(
+			serviceName,
+			apiutil.MergeLabels(
+				apiutil.LabelsForKafka(r.KafkaCluster.Name),
+				labelsForNginxIngress(r.KafkaCluster.Name, extListener.Name)),
+			map[string]string{nginxutils.ControllerAnnotationKey: controllerKey(*ingressConfig.NginxIngressConfig)},
+			r.KafkaCluster),
+		Spec: corev1.ServiceSpec{
+			Selector: apiutil.LabelsForKafka(r.KafkaCluster.Name),
+			Type:     corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{{
+				Name:       "tcp-all-broker",
+				Port:       extListener.GetAnyCastPort(),
+				TargetPort: intstr.FromInt(int(extListener.ContainerPort)),
+				Protocol:   corev1.ProtocolTCP,
+			},
+			},
+		},
+	}
+}
+
+func labelsForNginxIngress(crName, eLName string) map[string]string {
+	return apiutil.MergeLabels(labelsForNginxIngressWithoutEListenerName(crName), map[string]string{util.ExternalListenerLabelNameKey: eLName})
+}
+
+func labelsForNginxIngressWithoutEListenerName(crName string) map[string]string {
+	return map[string]string{v1beta1.AppLabelKey: appLabelValue, v1beta1.KafkaCRLabelKey: crName}
+}
@@ -0,0 +1,225 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nginxingress
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	nginxutils "github.com/banzaicloud/koperator/pkg/util/nginx"
+)
+
+func TestSyncTCPServices(t *testing.T) {
+	ownService := func(name, listener string) *corev1.Service {
+		return &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "kafka",
+			Labels:    labelsForNginxIngress("kafka", listener),
+		}}
+	}
+
+	testCases := []struct {
+		testName          string
+		eListenerName     string
+		desired           map[string]string
+		expectedData      map[string]string
+		expectedPortNames []string
+		expectedErr       bool
+	}{
+		{
+			testName:      "desired tcp services replace the stale ones of the listener",
+			eListenerName: "external",
+			desired:       map[string]string{"19090": "kafka/nginx-svc-kafka-0-external:29092"},
+			expectedData: map[string]string{
+				"9000":  "other/foreign:9000",
+				"19190": "kafka/nginx-svc-kafka-0-internal:29092",
+				"19090": "kafka/nginx-svc-kafka-0-external:29092",
+			},
+			expectedPortNames: []string{"http", "kafka-19090", "kafka-19190"},
+		},
+		{
+			testName:          "all tcp services of the cluster are removed on finalize",
+			expectedData:      map[string]string{"9000": "other/foreign:9000"},
+			expectedPortNames: []string{"http"},
+		},
+		{
+			testName:      "port used by a foreign tcp service",
+			eListenerName: "external",
+			desired:       map[string]string{"9000": "kafka/nginx-svc-kafka-0-external:29092"},
+			expectedErr:   true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithObjects(
+				ownService("nginx-svc-kafka-0-external", "external"),
+				ownService("nginx-svc-kafka-9-external", "external"),
+				ownService("nginx-svc-kafka-0-internal", "internal"),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "tcp-services", Namespace: "ingress-nginx"},
+					Data: map[string]string{
+						"9000":  "other/foreign:9000",
+						"19099": "kafka/nginx-svc-kafka-9-external:29092",
+						"19190": "kafka/nginx-svc-kafka-0-internal:29092",
+					},
+				},
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: "ingress-nginx-controller", Namespace: "ingress-nginx"},
+					Spec: corev1.ServiceSpec{
+						Type: corev1.ServiceTypeLoadBalancer,
+						Ports: []corev1.ServicePort{
+							{Name: "http", Port: 80, TargetPort: intstr.FromString("http")},
+							{Name: "kafka-19099", Port: 19099, TargetPort: intstr.FromInt(19099), NodePort: 30099},
+						},
+					},
+				},
+			).Build()
+			cluster := &v1beta1.KafkaCluster{ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"}}
+			r := New(c, c, cluster)
+
+			err := r.syncTCPServices(context.Background(), logr.Discard(), cluster.Spec.NginxIngressConfig, test.eListenerName, test.desired)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			configMap := &corev1.ConfigMap{}
+			require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "tcp-services", Namespace: "ingress-nginx"}, configMap))
+			require.Equal(t, test.expectedData, configMap.Data)
+
+			service := &corev1.Service{}
+			require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "ingress-nginx-controller", Namespace: "ingress-nginx"}, service))
+			var portNames []string
+			for _, port := range service.Spec.Ports {
+				portNames = append(portNames, port.Name)
+			}
+			require.Equal(t, test.expectedPortNames, portNames)
+		})
+	}
+}
+
+func TestFinalize(t *testing.T) {
+	controllerObjects := func(namespace string) []client.Object {
+		return []client.Object{
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "tcp-services", Namespace: namespace},
+				Data: map[string]string{
+					"9000":  "other/foreign:9000",
+					"19090": "kafka/nginx-svc-kafka-0-external:29092",
+				},
+			},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "ingress-nginx-controller", Namespace: namespace},
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeLoadBalancer,
+					Ports: []corev1.ServicePort{
+						{Name: "http", Port: 80, TargetPort: intstr.FromString("http")},
+						{Name: "kafka-19090", Port: 19090, TargetPort: intstr.FromInt(19090)},
+						{Name: "kafka-29090", Port: 29090, TargetPort: intstr.FromInt(29090)},
+					},
+				},
+			},
+		}
+	}
+	anyCastService := func(annotations map[string]string) *corev1.Service {
+		return &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+			Name:        "nginx-svc-kafka-external",
+			Namespace:   "kafka",
+			Labels:      labelsForNginxIngress("kafka", "external"),
+			Annotations: annotations,
+		}}
+	}
+
+	testCases := []struct {
+		testName          string
+		services          []client.Object
+		expectedNamespace string
+		expectedData      map[string]string
+		expectedPortNames []string
+	}{
+		{
+			testName:          "cluster without ingress-nginx services",
+			expectedNamespace: "ingress-nginx",
+			expectedData: map[string]string{
+				"9000":  "other/foreign:9000",
+				"19090": "kafka/nginx-svc-kafka-0-external:29092",
+			},
+			expectedPortNames: []string{"http", "kafka-19090", "kafka-29090"},
+		},
+		{
+			testName: "tcp services removed from the recorded controller",
+			services: []client.Object{
+				anyCastService(map[string]string{nginxutils.ControllerAnnotationKey: "custom-nginx/tcp-services/ingress-nginx-controller"}),
+				&corev1.Service{ObjectMeta: metav1.ObjectMeta{
+					Name:      "nginx-svc-kafka-0-external",
+					Namespace: "kafka",
+					Labels:    labelsForNginxIngress("kafka", "external"),
+				}},
+			},
+			expectedNamespace: "custom-nginx",
+			expectedData:      map[string]string{"9000": "other/foreign:9000"},
+			expectedPortNames: []string{"http"},
+		},
+		{
+			testName: "tcp services removed from the configured controller without a recorded one",
+			services: []client.Object{
+				anyCastService(nil),
+				&corev1.Service{ObjectMeta: metav1.ObjectMeta{
+					Name:      "nginx-svc-kafka-0-external",
+					Namespace: "kafka",
+					Labels:    labelsForNginxIngress("kafka", "external"),
+				}},
+			},
+			expectedNamespace: "ingress-nginx",
+			expectedData:      map[string]string{"9000": "other/foreign:9000"},
+			expectedPortNames: []string{"http"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			objects := append(controllerObjects("ingress-nginx"), controllerObjects("custom-nginx")...)
+			c := fake.NewClientBuilder().WithObjects(append(objects, test.services...)...).Build()
+			cluster := &v1beta1.KafkaCluster{ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"}}
+
+			require.NoError(t, New(c, c, cluster).Finalize(context.Background(), logr.Discard()))
+
+			configMap := &corev1.ConfigMap{}
+			require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "tcp-services", Namespace: test.expectedNamespace}, configMap))
+			require.Equal(t, test.expectedData, configMap.Data)
+
+			service := &corev1.Service{}
+			require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "ingress-nginx-controller", Namespace: test.expectedNamespace}, service))
+			var portNames []string
+			for _, port := range service.Spec.Ports {
+				portNames = append(portNames, port.Name)
+			}
+			require.Equal(t, test.expectedPortNames, portNames)
+		})
+	}
+}
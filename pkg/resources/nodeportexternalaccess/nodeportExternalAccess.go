@@ -26,6 +26,7 @@ import (
 	"github.com/banzaicloud/koperator/api/v1beta1"
 	"github.com/banzaicloud/koperator/pkg/k8sutil"
 	"github.com/banzaicloud/koperator/pkg/resources"
+	"github.com/banzaicloud/koperator/pkg/util"
 )
 
 const (
@@ -67,7 +68,7 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 					if err != nil {
 						return err
 					}
-				} else if r.KafkaCluster.Spec.RemoveUnusedIngressResources {
+				} else if util.ShouldRemoveUnusedIngressResources(r.KafkaCluster, eListener.Name) {
 					// Cleaning up unused nodeport services
 					removeService := service.(client.Object)
 					if err := r.Delete(context.Background(), removeService); client.IgnoreNotFound(err) != nil {
@@ -0,0 +1,100 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package perbrokerloadbalancer
+
+import (
+	"context"
+	"fmt"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/resources"
+	"github.com/banzaicloud/koperator/pkg/util"
+)
+
+const (
+	componentName = "perBrokerLoadBalancerExternalAccess"
+)
+
+// Reconciler implements the Component Reconciler
+type Reconciler struct {
+	resources.Reconciler
+}
+
+// New creates a new reconciler for per broker LoadBalancer based external access
+func New(client client.Client, cluster *v1beta1.KafkaCluster) *Reconciler {
+	return &Reconciler{
+		Reconciler: resources.Reconciler{
+			Client:       client,
+			KafkaCluster: cluster,
+		},
+	}
+}
+
+// Reconcile implements the reconcile logic for per broker LoadBalancer based external access
+func (r *Reconciler) Reconcile(log logr.Logger) error {
+	log = log.WithValues("component", componentName)
+
+	log.V(1).Info("Reconciling")
+	// while the access method of a listener is changed its Services are kept until they can be removed
+	desiredServices := make(map[string]struct{})
+	for _, eListener := range r.KafkaCluster.Spec.ListenersConfig.ExternalListeners {
+		accessMethod := eListener.GetAccessMethod()
+		if accessMethod != v1beta1.ServiceTypePerBrokerLoadBalancer && util.ShouldRemoveUnusedIngressResources(r.KafkaCluster, eListener.Name) {
+			continue
+		}
+		for _, broker := range r.KafkaCluster.Spec.Brokers {
+			service := r.service(broker.Id, eListener)
+			desiredServices[service.GetName()] = struct{}{}
+
+			if accessMethod == v1beta1.ServiceTypePerBrokerLoadBalancer {
+				if err := k8sutil.Reconcile(log, r.Client, service, r.KafkaCluster); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// Cleaning up the per broker LoadBalancer services of the removed brokers and external listeners and of the
+	// external listeners using an other access method
+	services := &corev1.ServiceList{}
+	if err := r.List(context.Background(), services, client.InNamespace(r.KafkaCluster.GetNamespace()),
+		client.MatchingLabels(apiutil.LabelsForKafka(r.KafkaCluster.Name)), client.HasLabels{util.ExternalListenerLabelNameKey}); err != nil {
+		return errors.Wrap(err, "error when getting list of per broker LoadBalancer services for deletion")
+	}
+	for i := range services.Items {
+		service := &services.Items[i]
+		if _, ok := desiredServices[service.GetName()]; ok || util.ObjectManagedByClusterRegistry(service) ||
+			!service.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if err := r.Delete(context.Background(), service); client.IgnoreNotFound(err) != nil {
+			return errors.Wrap(err, "error when removing unused per broker LoadBalancer services")
+		}
+		log.V(1).Info(fmt.Sprintf("Deleted LoadBalancer service '%s' for external listener '%s'", service.GetName(),
+			service.GetLabels()[util.ExternalListenerLabelNameKey]))
+	}
+
+	log.V(1).Info("Reconciled")
+
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package perbrokerloadbalancer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func TestReconcile(t *testing.T) {
+	service := func(name, eListenerName string) *corev1.Service {
+		return &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kafka",
+			Labels: labelsForPerBrokerLoadBalancer("kafka", eListenerName)}}
+	}
+	listener := func(name string, accessMethod corev1.ServiceType) v1beta1.ExternalListenerConfig {
+		return v1beta1.ExternalListenerConfig{
+			CommonListenerSpec:   v1beta1.CommonListenerSpec{Name: name, ContainerPort: 9094},
+			ExternalStartingPort: 19090,
+			AccessMethod:         accessMethod,
+		}
+	}
+	testCases := []struct {
+		testName                     string
+		externalListeners            []v1beta1.ExternalListenerConfig
+		removeUnusedIngressResources bool
+		objects                      []client.Object
+		expectedServices             []string
+	}{
+		{
+			testName:          "services of the brokers are created",
+			externalListeners: []v1beta1.ExternalListenerConfig{listener("external", v1beta1.ServiceTypePerBrokerLoadBalancer)},
+			expectedServices:  []string{"kafka-0-external-lb", "kafka-1-external-lb"},
+		},
+		{
+			testName:          "services of the removed brokers are deleted",
+			externalListeners: []v1beta1.ExternalListenerConfig{listener("external", v1beta1.ServiceTypePerBrokerLoadBalancer)},
+			objects:           []client.Object{service("kafka-2-external-lb", "external")},
+			expectedServices:  []string{"kafka-0-external-lb", "kafka-1-external-lb"},
+		},
+		{
+			testName:          "services of the removed external listeners are deleted",
+			externalListeners: []v1beta1.ExternalListenerConfig{listener("external", v1beta1.ServiceTypePerBrokerLoadBalancer)},
+			objects:           []client.Object{service("kafka-0-removed-lb", "removed"), service("kafka-1-removed-lb", "removed")},
+			expectedServices:  []string{"kafka-0-external-lb", "kafka-1-external-lb"},
+		},
+		{
+			testName:          "services of the external listeners using an other access method are kept",
+			externalListeners: []v1beta1.ExternalListenerConfig{listener("external", corev1.ServiceTypeNodePort)},
+			objects:           []client.Object{service("kafka-0-external-lb", "external"), service("kafka-1-external-lb", "external")},
+			expectedServices:  []string{"kafka-0-external-lb", "kafka-1-external-lb"},
+		},
+		{
+			testName:                     "unused services of the external listeners using an other access method are deleted",
+			externalListeners:            []v1beta1.ExternalListenerConfig{listener("external", corev1.ServiceTypeNodePort)},
+			removeUnusedIngressResources: true,
+			objects:                      []client.Object{service("kafka-0-external-lb", "external"), service("kafka-1-external-lb", "external")},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Spec: v1beta1.KafkaClusterSpec{
+					Brokers:                      []v1beta1.Broker{{Id: 0}, {Id: 1}},
+					ListenersConfig:              v1beta1.ListenersConfig{ExternalListeners: test.externalListeners},
+					RemoveUnusedIngressResources: test.removeUnusedIngressResources,
+				},
+			}
+			scheme := runtime.NewScheme()
+			require.NoError(t, clientgoscheme.AddToScheme(scheme))
+			require.NoError(t, v1beta1.AddToScheme(scheme))
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(test.objects, cluster)...).Build()
+
+			require.NoError(t, New(c, cluster).Reconcile(logf.Log))
+
+			services := &corev1.ServiceList{}
+			require.NoError(t, c.List(context.Background(), services))
+			var names []string
+			for _, service := range services.Items {
+				names = append(names, service.Name)
+			}
+			require.Equal(t, test.expectedServices, names)
+		})
+	}
+}
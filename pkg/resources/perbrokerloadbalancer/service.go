@@ -0,0 +1,63 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package perbrokerloadbalancer
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/resources/templates"
+	"github.com/banzaicloud/koperator/pkg/util"
+	"github.com/banzaicloud/koperator/pkg/util/kafka"
+)
+
+// service returns the LoadBalancer Service of the broker for the external listener, the broker is reached on the
+// port externalStartingPort + broker id (or on the anyCastPort if externalStartingPort is -1) of its load balancer
+func (r *Reconciler) service(id int32, extListener v1beta1.ExternalListenerConfig) *corev1.Service {
+	annotations := extListener.GetServiceAnnotations()
+	if hostname := extListener.PerBrokerLoadBalancerConfig.GetBrokerHostname(id); hostname != "" {
+		annotations = util.MergeAnnotations(annotations, map[string]string{v1beta1.ExternalDNSHostnameAnnotationKey: hostname})
+	}
+	return &corev1.Service{
+		ObjectMeta: templates.ObjectMetaWithAnnotations(
+			fmt.Sprintf(kafka.PerBrokerLoadBalancerServiceTemplate, r.KafkaCluster.GetName(), id, extListener.Name),
+			apiutil.MergeLabels(labelsForPerBrokerLoadBalancer(r.KafkaCluster.Name, extListener.Name),
+				map[string]string{v1beta1.BrokerIdLabelKey: fmt.Sprintf("%d", id)}),
+			annotations, r.KafkaCluster),
+		Spec: corev1.ServiceSpec{
+			Selector: apiutil.MergeLabels(apiutil.LabelsForKafka(r.KafkaCluster.Name),
+				map[string]string{v1beta1.BrokerIdLabelKey: fmt.Sprintf("%d", id)}),
+			Type: corev1.ServiceTypeLoadBalancer,
+			Ports: []corev1.ServicePort{{
+				Name:       fmt.Sprintf("broker-%d", id),
+				Port:       extListener.GetBrokerPort(id),
+				TargetPort: intstr.FromInt(int(extListener.ContainerPort)),
+				Protocol:   corev1.ProtocolTCP,
+			},
+			},
+			ExternalTrafficPolicy: extListener.ExternalTrafficPolicy,
+		},
+	}
+}
+
+// labelsForPerBrokerLoadBalancer returns the labels of the LoadBalancer Services of the external listener
+func labelsForPerBrokerLoadBalancer(crName, eListenerName string) map[string]string {
+	return apiutil.MergeLabels(apiutil.LabelsForKafka(crName), map[string]string{util.ExternalListenerLabelNameKey: eListenerName})
+}
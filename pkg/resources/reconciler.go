@@ -40,6 +40,12 @@ type ComponentReconciler interface {
 	Reconcile(log logr.Logger) error
 }
 
+// MaintenanceWindowWaiter describes the component reconcilers deferring disruptive actions to the maintenance windows
+// of the cluster
+type MaintenanceWindowWaiter interface {
+	MaintenanceWaiting() []string
+}
+
 // Resource simple function without parameter
 type Resource func() runtime.Object
 
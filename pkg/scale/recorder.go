@@ -0,0 +1,278 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scale
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"emperror.dev/errors"
+	"github.com/banzaicloud/go-cruise-control/pkg/client"
+	"github.com/banzaicloud/go-cruise-control/pkg/types"
+	"github.com/go-logr/logr"
+)
+
+const (
+	// replayServerURL is the Cruise Control URL of the scalers replaying fixtures, the host of the recorded
+	// interactions is not matched
+	replayServerURL = "http://cruise-control/kafkacruisecontrol/"
+
+	redactedValue = "REDACTED"
+)
+
+var (
+	// sensitiveQueryParamRegex matches the query parameters whose values are redacted from the recorded interactions
+	sensitiveQueryParamRegex = regexp.MustCompile(`(?i)(password|token|secret|credential)`)
+	// recordedResponseHeaders are the response headers kept in the recorded interactions
+	recordedResponseHeaders = []string{
+		"Content-Type", types.UserTaskIDHTTPHeader, types.CruiseControlVersionHTTPHeader, types.DateHTTPHeader,
+	}
+	fixtureFileNameRegex = regexp.MustCompile(`[^a-zA-Z0-9.-]+`)
+
+	recordOptions RecordOptions
+)
+
+// RecordOptions defines where the HTTP interactions of the scalers with Cruise Control are recorded
+type RecordOptions struct {
+	// Log writes the interactions into the log of the scalers
+	Log bool
+	// FixtureDir is the directory the interactions are appended to, into one fixture file per Cruise Control server
+	// which can be replayed with NewFixtureReplayScaler. Interactions are not written to fixture files when empty.
+	FixtureDir string
+}
+
+// Enabled returns true if the interactions are recorded
+func (o RecordOptions) Enabled() bool {
+	return o.Log || o.FixtureDir != ""
+}
+
+// SetRecordOptions sets where the scalers created afterwards record their interactions with Cruise Control
+func SetRecordOptions(options RecordOptions) {
+	recordOptions = options
+}
+
+// Interaction is an HTTP interaction with Cruise Control, sanitized from credentials
+type Interaction struct {
+	Method          string            `json:"method"`
+	Path            string            `json:"path"`
+	Query           string            `json:"query,omitempty"`
+	StatusCode      int               `json:"statusCode"`
+	ResponseHeaders map[string]string `json:"responseHeaders,omitempty"`
+	ResponseBody    string            `json:"responseBody,omitempty"`
+}
+
+func (i Interaction) key() string {
+	return fmt.Sprintf("%s %s?%s", i.Method, i.Path, i.Query)
+}
+
+func sanitizedQuery(query url.Values) string {
+	sanitized := make(url.Values, len(query))
+	for key, values := range query {
+		if sensitiveQueryParamRegex.MatchString(key) {
+			sanitized[key] = []string{redactedValue}
+			continue
+		}
+		sanitized[key] = values
+	}
+	// Encode sorts the parameters by key, the same request always has the same query
+	return sanitized.Encode()
+}
+
+// recordingTransport records the interactions passing through it, the request headers holding the credentials of
+// the client are never recorded
+type recordingTransport struct {
+	transport   http.RoundTripper
+	log         logr.Logger
+	options     RecordOptions
+	fixturePath string
+	mu          sync.Mutex
+}
+
+func newRecordingTransport(transport http.RoundTripper, log logr.Logger, serverURL string, options RecordOptions) *recordingTransport {
+	t := &recordingTransport{
+		transport: transport,
+		log:       log.WithName("recorder"),
+		options:   options,
+	}
+	if options.FixtureDir != "" {
+		host := serverURL
+		if u, err := url.Parse(serverURL); err == nil && u.Host != "" {
+			host = u.Host
+		}
+		t.fixturePath = filepath.Join(options.FixtureDir, fixtureFileNameRegex.ReplaceAllString(host, "_")+".jsonl")
+	}
+	return t
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not read Cruise Control response")
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	interaction := Interaction{
+		Method:       req.Method,
+		Path:         req.URL.Path,
+		Query:        sanitizedQuery(req.URL.Query()),
+		StatusCode:   resp.StatusCode,
+		ResponseBody: string(body),
+	}
+	for _, header := range recordedResponseHeaders {
+		if value := resp.Header.Get(header); value != "" {
+			if interaction.ResponseHeaders == nil {
+				interaction.ResponseHeaders = make(map[string]string)
+			}
+			interaction.ResponseHeaders[header] = value
+		}
+	}
+	t.record(interaction)
+
+	return resp, nil
+}
+
+// record writes the interaction, failing to record it does not fail the request
+func (t *recordingTransport) record(interaction Interaction) {
+	if t.options.Log {
+		t.log.Info("Cruise Control interaction", "method", interaction.Method, "path", interaction.Path,
+			"query", interaction.Query, "status", interaction.StatusCode, "response", interaction.ResponseBody)
+	}
+	if t.fixturePath == "" {
+		return
+	}
+
+	var line bytes.Buffer
+	encoder := json.NewEncoder(&line)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(interaction); err != nil {
+		t.log.Error(err, "could not marshal Cruise Control interaction")
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	f, err := os.OpenFile(t.fixturePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.log.Error(err, "could not open Cruise Control fixture file", "path", t.fixturePath)
+		return
+	}
+	defer func() { _ = f.Close() }()
+	if _, err := f.Write(line.Bytes()); err != nil {
+		t.log.Error(err, "could not write Cruise Control fixture file", "path", t.fixturePath)
+	}
+}
+
+// LoadFixtures reads the interactions of a fixture file written by the recording of the scalers
+func LoadFixtures(path string) ([]Interaction, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not open Cruise Control fixture file", "path", path)
+	}
+	defer func() { _ = f.Close() }()
+
+	var interactions []Interaction
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var interaction Interaction
+		if err := json.Unmarshal([]byte(line), &interaction); err != nil {
+			return nil, errors.WrapIfWithDetails(err, "could not parse Cruise Control fixture", "path", path, "line", len(interactions)+1)
+		}
+		interactions = append(interactions, interaction)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not read Cruise Control fixture file", "path", path)
+	}
+	return interactions, nil
+}
+
+// replayTransport answers the requests with the recorded interactions matching their method, path and query. The
+// interactions of the same request are replayed in the recorded order, the last one is repeated once the others have
+// been replayed.
+type replayTransport struct {
+	interactions map[string][]Interaction
+	mu           sync.Mutex
+}
+
+func newReplayTransport(interactions []Interaction) *replayTransport {
+	t := &replayTransport{interactions: make(map[string][]Interaction)}
+	for _, interaction := range interactions {
+		t.interactions[interaction.key()] = append(t.interactions[interaction.key()], interaction)
+	}
+	return t
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := Interaction{Method: req.Method, Path: req.URL.Path, Query: sanitizedQuery(req.URL.Query())}.key()
+
+	t.mu.Lock()
+	recorded := t.interactions[key]
+	if len(recorded) == 0 {
+		t.mu.Unlock()
+		return nil, errors.Errorf("no Cruise Control fixture for request %s", key)
+	}
+	interaction := recorded[0]
+	if len(recorded) > 1 {
+		t.interactions[key] = recorded[1:]
+	}
+	t.mu.Unlock()
+
+	header := make(http.Header)
+	for name, value := range interaction.ResponseHeaders {
+		header.Set(name, value)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.StatusCode, http.StatusText(interaction.StatusCode)),
+		StatusCode:    interaction.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(interaction.ResponseBody)),
+		ContentLength: int64(len(interaction.ResponseBody)),
+		Request:       req,
+	}, nil
+}
+
+// NewFixtureReplayScaler returns a scaler answering its requests with the given recorded interactions instead of a
+// live Cruise Control, e.g. to reproduce a bug report in tests
+func NewFixtureReplayScaler(ctx context.Context, interactions []Interaction) (CruiseControlScaler, error) {
+	return newScaler(ctx, &client.Config{
+		ServerURL:  replayServerURL,
+		UserAgent:  "koperator",
+		HTTPClient: &http.Client{Transport: newReplayTransport(interactions)},
+	})
+}
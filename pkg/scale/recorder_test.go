@@ -0,0 +1,142 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scale
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/banzaicloud/go-cruise-control/pkg/client"
+	"github.com/banzaicloud/go-cruise-control/pkg/types"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+const userTasksResponse = `{"userTasks":[{"UserTaskId":"d4dcd9a8-2e3e-4b4f-a4e5-3c4b8f0a1c2d",` +
+	`"RequestURL":"POST /kafkacruisecontrol/add_broker?brokerid=3","ClientIdentity":"127.0.0.1",` +
+	`"StartMs":"1700000000000","Status":"InExecution","originalResponse":""}],"version":1}`
+
+func TestSanitizedQuery(t *testing.T) {
+	testCases := []struct {
+		testName string
+		query    url.Values
+		want     string
+	}{
+		{
+			testName: "parameters are sorted",
+			query:    url.Values{"json": {"true"}, "brokerid": {"1,2"}},
+			want:     "brokerid=1%2C2&json=true",
+		},
+		{
+			testName: "credentials are redacted",
+			query:    url.Values{"json": {"true"}, "password": {"secret"}, "access_token": {"abc"}},
+			want:     "access_token=REDACTED&json=true&password=REDACTED",
+		},
+		{
+			testName: "empty query",
+			query:    url.Values{},
+			want:     "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			require.Equal(t, tc.want, sanitizedQuery(tc.query))
+		})
+	}
+}
+
+func TestRecordAndReplayInteractions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/kafkacruisecontrol/user_tasks", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(types.UserTaskIDHTTPHeader, "d4dcd9a8-2e3e-4b4f-a4e5-3c4b8f0a1c2d")
+		_, _ = w.Write([]byte(userTasksResponse))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	serverURL := server.URL + "/kafkacruisecontrol/"
+	fixtureDir := t.TempDir()
+	recorded, err := newScaler(ctx, &client.Config{
+		ServerURL: serverURL,
+		UserAgent: "koperator",
+		HTTPClient: &http.Client{
+			Transport: newRecordingTransport(http.DefaultTransport, logr.Discard(), serverURL, RecordOptions{FixtureDir: fixtureDir}),
+		},
+	})
+	require.NoError(t, err)
+
+	want, err := recorded.StatusTask(ctx, "d4dcd9a8-2e3e-4b4f-a4e5-3c4b8f0a1c2d")
+	require.NoError(t, err)
+	require.Equal(t, v1beta1.CruiseControlTaskInExecution, want.TaskResult.State)
+
+	fixtures, err := filepath.Glob(filepath.Join(fixtureDir, "*.jsonl"))
+	require.NoError(t, err)
+	require.Len(t, fixtures, 1)
+	interactions, err := LoadFixtures(fixtures[0])
+	require.NoError(t, err)
+	require.Len(t, interactions, 1)
+
+	replayed, err := NewFixtureReplayScaler(ctx, interactions)
+	require.NoError(t, err)
+	// the last interaction of a request is repeated
+	for i := 0; i < 2; i++ {
+		got, err := replayed.StatusTask(ctx, "d4dcd9a8-2e3e-4b4f-a4e5-3c4b8f0a1c2d")
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	}
+
+	_, err = replayed.StatusTask(ctx, "unknown")
+	require.Error(t, err)
+}
+
+func TestReplayFixture(t *testing.T) {
+	interactions, err := LoadFixtures("testdata/user_tasks.jsonl")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	scaler, err := NewFixtureReplayScaler(ctx, interactions)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		testName string
+		want     v1beta1.CruiseControlUserTaskState
+	}{
+		{
+			testName: "task in execution",
+			want:     v1beta1.CruiseControlTaskInExecution,
+		},
+		{
+			testName: "task completed",
+			want:     v1beta1.CruiseControlTaskCompleted,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			got, err := scaler.StatusTask(ctx, "d4dcd9a8-2e3e-4b4f-a4e5-3c4b8f0a1c2d")
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got.TaskResult.State)
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"net/http"
 	"strconv"
 	"strings"
 
@@ -31,17 +32,27 @@ import (
 
 	"github.com/banzaicloud/koperator/api/v1alpha1"
 	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/util/tracing"
 )
 
 const (
 	// Constants for the Cruise Control operations parameters
 	// Check for more details: https://github.com/linkedin/cruise-control/wiki/REST-APIs
-	ParamBrokerID           = "brokerid"
-	ParamExcludeDemoted     = "exclude_recently_demoted_brokers"
-	ParamExcludeRemoved     = "exclude_recently_removed_brokers"
-	ParamDestbrokerIDs      = "destination_broker_ids"
-	ParamRebalanceDisk      = "rebalance_disk"
-	ParamBrokerIDAndLogDirs = "brokerid_and_logdirs"
+	ParamBrokerID                              = "brokerid"
+	ParamExcludeDemoted                        = "exclude_recently_demoted_brokers"
+	ParamExcludeRemoved                        = "exclude_recently_removed_brokers"
+	ParamDestbrokerIDs                         = "destination_broker_ids"
+	ParamRebalanceDisk                         = "rebalance_disk"
+	ParamBrokerIDAndLogDirs                    = "brokerid_and_logdirs"
+	ParamTopic                                 = "topic"
+	ParamReplicationFactor                     = "replication_factor"
+	ParamExcludedTopics                        = "excluded_topics"
+	ParamSkipUrpDemotion                       = "skip_urp_demotion"
+	ParamExcludeFollowerDemotion               = "exclude_follower_demotion"
+	ParamReplicaMovementStrategies             = "replica_movement_strategies"
+	ParamConcurrentPartitionMovementsPerBroker = "concurrent_partition_movements_per_broker"
+	ParamConcurrentLeaderMovements             = "concurrent_leader_movements"
+	ParamGoals                                 = "goals"
 	// Cruise Control API returns NullPointerException when a broker storage capacity calculations are missing
 	// from the Cruise Control configurations
 	nullPointerExceptionErrString = "NullPointerException"
@@ -62,14 +73,36 @@ var (
 		ParamExcludeRemoved: {},
 	}
 	rebalanceSupportedParams = map[string]struct{}{
-		ParamDestbrokerIDs:  {},
-		ParamRebalanceDisk:  {},
-		ParamExcludeDemoted: {},
-		ParamExcludeRemoved: {},
+		ParamDestbrokerIDs:                         {},
+		ParamRebalanceDisk:                         {},
+		ParamExcludeDemoted:                        {},
+		ParamExcludeRemoved:                        {},
+		ParamExcludedTopics:                        {},
+		ParamReplicaMovementStrategies:             {},
+		ParamConcurrentPartitionMovementsPerBroker: {},
+		ParamConcurrentLeaderMovements:             {},
+		ParamGoals:                                 {},
 	}
 	removeDisksSupportedParams = map[string]struct{}{
 		ParamBrokerIDAndLogDirs: {},
 	}
+	topicConfigurationSupportedParams = map[string]struct{}{
+		ParamTopic:             {},
+		ParamReplicationFactor: {},
+		ParamExcludeDemoted:    {},
+		ParamExcludeRemoved:    {},
+	}
+	demoteBrokerSupportedParams = map[string]struct{}{
+		ParamBrokerID:                {},
+		ParamExcludeDemoted:          {},
+		ParamSkipUrpDemotion:         {},
+		ParamExcludeFollowerDemotion: {},
+	}
+	fixOfflineReplicasSupportedParams = map[string]struct{}{
+		ParamExcludeDemoted: {},
+		ParamExcludeRemoved: {},
+		ParamExcludedTopics: {},
+	}
 )
 
 func ScaleFactoryFn() func(ctx context.Context, kafkaCluster *v1beta1.KafkaCluster) (CruiseControlScaler, error) {
@@ -89,6 +122,19 @@ func createNewDefaultCruiseControlScaler(ctx context.Context, serverURL string)
 		ServerURL: serverURL,
 		UserAgent: "koperator",
 	}
+	transport := http.DefaultTransport
+	if recordOptions.Enabled() {
+		transport = newRecordingTransport(transport, log, serverURL, recordOptions)
+	}
+	cfg.HTTPClient = &http.Client{
+		Transport: tracing.WrapTransport("cruise-control", transport),
+	}
+
+	return newScaler(ctx, cfg)
+}
+
+func newScaler(ctx context.Context, cfg *client.Config) (CruiseControlScaler, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("Scaler")
 
 	cruisecontrol, err := client.NewClient(cfg)
 	if err != nil {
@@ -482,6 +528,156 @@ func (cc *cruiseControlScaler) AddBrokers(ctx context.Context, brokerIDs ...stri
 	}, nil
 }
 
+// DemoteBrokers requests Cruise Control to move the leadership of the partitions away from the provided brokers, the
+// partition replicas stay on the brokers.
+func (cc *cruiseControlScaler) DemoteBrokers(ctx context.Context, brokerIDs ...string) (*Result, error) {
+	if len(brokerIDs) == 0 {
+		return nil, errors.New("no broker id(s) provided for demote brokers request")
+	}
+
+	brokersToDemote, err := brokerIDsFromStringSlice(brokerIDs)
+	if err != nil {
+		cc.log.Error(err, "failed to cast broker IDs from string slice")
+		return nil, err
+	}
+
+	demoteBrokerReq := api.DemoteBrokerRequestWithDefaults()
+	demoteBrokerReq.BrokerIDs = brokersToDemote
+	demoteBrokerResp, err := cc.client.DemoteBroker(ctx, demoteBrokerReq)
+	if err != nil {
+		return &Result{
+			TaskID:             demoteBrokerResp.TaskID,
+			StartedAt:          demoteBrokerResp.Date,
+			ResponseStatusCode: demoteBrokerResp.StatusCode,
+			RequestURL:         demoteBrokerResp.RequestURL,
+			State:              v1beta1.CruiseControlTaskCompletedWithError,
+			Err:                err,
+		}, err
+	}
+
+	return &Result{
+		TaskID:             demoteBrokerResp.TaskID,
+		StartedAt:          demoteBrokerResp.Date,
+		ResponseStatusCode: demoteBrokerResp.StatusCode,
+		RequestURL:         demoteBrokerResp.RequestURL,
+		State:              v1beta1.CruiseControlTaskActive,
+	}, nil
+}
+
+// DemoteBrokersWithParams requests Cruise Control to move the leadership of the partitions off the brokers of the
+// brokerid parameter, e.g. before maintenance. The operation properties can be added with the use of the params argument.
+func (cc *cruiseControlScaler) DemoteBrokersWithParams(ctx context.Context, params map[string]string) (*Result, error) {
+	demoteBrokerReq := api.DemoteBrokerRequestWithDefaults()
+	for param, pvalue := range params {
+		if _, ok := demoteBrokerSupportedParams[param]; ok {
+			switch param {
+			case ParamBrokerID:
+				ret, err := parseBrokerIDtoSlice(pvalue)
+				if err != nil {
+					return nil, err
+				}
+				demoteBrokerReq.BrokerIDs = ret
+			case ParamExcludeDemoted:
+				ret, err := strconv.ParseBool(pvalue)
+				if err != nil {
+					return nil, err
+				}
+				demoteBrokerReq.ExcludeRecentlyDemotedBrokers = ret
+			case ParamSkipUrpDemotion:
+				ret, err := strconv.ParseBool(pvalue)
+				if err != nil {
+					return nil, err
+				}
+				demoteBrokerReq.SkipUrpDemotion = ret
+			case ParamExcludeFollowerDemotion:
+				ret, err := strconv.ParseBool(pvalue)
+				if err != nil {
+					return nil, err
+				}
+				demoteBrokerReq.ExcludeFollowerDemotion = ret
+			default:
+				return nil, fmt.Errorf("unsupported %s parameter: %s, supported parameters: %s", v1alpha1.OperationDemoteBroker, param, demoteBrokerSupportedParams)
+			}
+		}
+	}
+
+	if len(demoteBrokerReq.BrokerIDs) == 0 {
+		return nil, errors.NewWithDetails("broker id(s) must be specified",
+			"operation", v1alpha1.OperationDemoteBroker, "parameters", params)
+	}
+
+	demoteBrokerResp, err := cc.client.DemoteBroker(ctx, demoteBrokerReq)
+	if err != nil {
+		return &Result{
+			TaskID:             demoteBrokerResp.TaskID,
+			StartedAt:          demoteBrokerResp.Date,
+			ResponseStatusCode: demoteBrokerResp.StatusCode,
+			RequestURL:         demoteBrokerResp.RequestURL,
+			State:              v1beta1.CruiseControlTaskCompletedWithError,
+			Err:                err,
+		}, err
+	}
+
+	return &Result{
+		TaskID:             demoteBrokerResp.TaskID,
+		StartedAt:          demoteBrokerResp.Date,
+		ResponseStatusCode: demoteBrokerResp.StatusCode,
+		RequestURL:         demoteBrokerResp.RequestURL,
+		Result:             demoteBrokerResp.Result,
+		State:              v1beta1.CruiseControlTaskActive,
+	}, nil
+}
+
+// FixOfflineReplicasWithParams requests Cruise Control to move the offline replicas of the cluster, e.g. the ones on
+// failed disks, to healthy brokers. The operation properties can be added with the use of the params argument.
+func (cc *cruiseControlScaler) FixOfflineReplicasWithParams(ctx context.Context, params map[string]string) (*Result, error) {
+	fixOfflineReplicasReq := api.FixOfflineReplicasRequestWithDefaults()
+	fixOfflineReplicasReq.UseReadyDefaultGoals = true
+	for param, pvalue := range params {
+		if _, ok := fixOfflineReplicasSupportedParams[param]; ok {
+			switch param {
+			case ParamExcludeDemoted:
+				ret, err := strconv.ParseBool(pvalue)
+				if err != nil {
+					return nil, err
+				}
+				fixOfflineReplicasReq.ExcludeRecentlyDemotedBrokers = ret
+			case ParamExcludeRemoved:
+				ret, err := strconv.ParseBool(pvalue)
+				if err != nil {
+					return nil, err
+				}
+				fixOfflineReplicasReq.ExcludeRecentlyRemovedBrokers = ret
+			case ParamExcludedTopics:
+				fixOfflineReplicasReq.ExcludedTopics = pvalue
+			default:
+				return nil, fmt.Errorf("unsupported %s parameter: %s, supported parameters: %s", v1alpha1.OperationFixOfflineReplicas, param, fixOfflineReplicasSupportedParams)
+			}
+		}
+	}
+
+	fixOfflineReplicasResp, err := cc.client.FixOfflineReplicas(ctx, fixOfflineReplicasReq)
+	if err != nil {
+		return &Result{
+			TaskID:             fixOfflineReplicasResp.TaskID,
+			StartedAt:          fixOfflineReplicasResp.Date,
+			ResponseStatusCode: fixOfflineReplicasResp.StatusCode,
+			RequestURL:         fixOfflineReplicasResp.RequestURL,
+			State:              v1beta1.CruiseControlTaskCompletedWithError,
+			Err:                err,
+		}, err
+	}
+
+	return &Result{
+		TaskID:             fixOfflineReplicasResp.TaskID,
+		StartedAt:          fixOfflineReplicasResp.Date,
+		ResponseStatusCode: fixOfflineReplicasResp.StatusCode,
+		RequestURL:         fixOfflineReplicasResp.RequestURL,
+		Result:             fixOfflineReplicasResp.Result,
+		State:              v1beta1.CruiseControlTaskActive,
+	}, nil
+}
+
 // RemoveBrokers requests Cruise Control to move partition replicase off from the provided brokers.
 // The broker list and operation properties can be added with the use of the params argument.
 func (cc *cruiseControlScaler) RemoveBrokers(ctx context.Context, brokerIDs ...string) (*Result, error) {
@@ -587,6 +783,34 @@ func (cc *cruiseControlScaler) RebalanceWithParams(ctx context.Context, params m
 					return nil, err
 				}
 				rebalanceReq.ExcludeRecentlyRemovedBrokers = ret
+			case ParamExcludedTopics:
+				rebalanceReq.ExcludedTopics = pvalue
+			case ParamReplicaMovementStrategies:
+				ret, err := parseReplicaMovementStrategies(pvalue)
+				if err != nil {
+					return nil, err
+				}
+				rebalanceReq.ReplicaMovementStrategies = ret
+			case ParamConcurrentPartitionMovementsPerBroker:
+				ret, err := strconv.ParseInt(pvalue, 10, 32)
+				if err != nil {
+					return nil, err
+				}
+				rebalanceReq.ConcurrentPartitionMovementsPerBroker = int32(ret)
+			case ParamConcurrentLeaderMovements:
+				ret, err := strconv.ParseInt(pvalue, 10, 32)
+				if err != nil {
+					return nil, err
+				}
+				rebalanceReq.ConcurrentLeaderMovements = int32(ret)
+			case ParamGoals:
+				ret, err := ParseGoals(pvalue)
+				if err != nil {
+					return nil, err
+				}
+				// the goals given explicitly are used instead of the ready default goals
+				rebalanceReq.Goals = ret
+				rebalanceReq.UseReadyDefaultGoals = false
 			default:
 				return nil, fmt.Errorf("unsupported %s parameter: %s, supported parameters: %s", v1alpha1.OperationRebalance, param, rebalanceSupportedParams)
 			}
@@ -615,6 +839,38 @@ func (cc *cruiseControlScaler) RebalanceWithParams(ctx context.Context, params m
 	}, nil
 }
 
+// parseReplicaMovementStrategies parses the comma separated list of Cruise Control replica movement strategy names
+func parseReplicaMovementStrategies(strategies string) ([]types.ReplicaMovementStrategy, error) {
+	var ret []types.ReplicaMovementStrategy
+	for _, name := range strings.Split(strategies, ",") {
+		var strategy types.ReplicaMovementStrategy
+		if err := strategy.UnmarshalText([]byte(strings.TrimSpace(name))); err != nil {
+			return nil, err
+		}
+		if strategy == types.ReplicaMovementStrategyUndefined {
+			return nil, fmt.Errorf("unsupported replica movement strategy: %s", name)
+		}
+		ret = append(ret, strategy)
+	}
+	return ret, nil
+}
+
+// ParseGoals parses the comma separated list of Cruise Control goal names
+func ParseGoals(goals string) ([]types.Goal, error) {
+	var ret []types.Goal
+	for _, name := range strings.Split(goals, ",") {
+		var goal types.Goal
+		if err := goal.UnmarshalText([]byte(strings.TrimSpace(name))); err != nil {
+			return nil, err
+		}
+		if goal == types.UndefinedGoal {
+			return nil, fmt.Errorf("unsupported goal: %s", name)
+		}
+		ret = append(ret, goal)
+	}
+	return ret, nil
+}
+
 func (cc *cruiseControlScaler) RemoveDisksWithParams(ctx context.Context, params map[string]string) (*Result, error) {
 	removeReq := &api.RemoveDisksRequest{}
 
@@ -661,6 +917,66 @@ func (cc *cruiseControlScaler) RemoveDisksWithParams(ctx context.Context, params
 	}, nil
 }
 
+func (cc *cruiseControlScaler) TopicConfigurationWithParams(ctx context.Context, params map[string]string) (*Result, error) {
+	topicConfigurationReq := api.TopicConfigurationRequestWithDefaults()
+	topicConfigurationReq.UseReadyDefaultGoals = true
+
+	for param, pvalue := range params {
+		if _, ok := topicConfigurationSupportedParams[param]; ok {
+			switch param {
+			case ParamTopic:
+				topicConfigurationReq.Topic = pvalue
+			case ParamReplicationFactor:
+				ret, err := strconv.ParseInt(pvalue, 10, 32)
+				if err != nil {
+					return nil, err
+				}
+				topicConfigurationReq.ReplicationFactor = int32(ret)
+			case ParamExcludeDemoted:
+				ret, err := strconv.ParseBool(pvalue)
+				if err != nil {
+					return nil, err
+				}
+				topicConfigurationReq.ExcludeRecentlyDemotedBrokers = ret
+			case ParamExcludeRemoved:
+				ret, err := strconv.ParseBool(pvalue)
+				if err != nil {
+					return nil, err
+				}
+				topicConfigurationReq.ExcludeRecentlyRemovedBrokers = ret
+			default:
+				return nil, fmt.Errorf("unsupported %s parameter: %s, supported parameters: %s", v1alpha1.OperationTopicConfiguration, param, topicConfigurationSupportedParams)
+			}
+		}
+	}
+
+	if topicConfigurationReq.Topic == "" || topicConfigurationReq.ReplicationFactor < 1 {
+		return nil, errors.NewWithDetails("topic and a positive replication factor must be specified",
+			"operation", v1alpha1.OperationTopicConfiguration, "parameters", params)
+	}
+
+	topicConfigurationResp, err := cc.client.TopicConfiguration(ctx, topicConfigurationReq)
+	if err != nil {
+		return &Result{
+			TaskID:             topicConfigurationResp.TaskID,
+			StartedAt:          topicConfigurationResp.Date,
+			ResponseStatusCode: topicConfigurationResp.StatusCode,
+			RequestURL:         topicConfigurationResp.RequestURL,
+			State:              v1beta1.CruiseControlTaskCompletedWithError,
+			Err:                err,
+		}, err
+	}
+
+	return &Result{
+		TaskID:             topicConfigurationResp.TaskID,
+		StartedAt:          topicConfigurationResp.Date,
+		ResponseStatusCode: topicConfigurationResp.StatusCode,
+		RequestURL:         topicConfigurationResp.RequestURL,
+		Result:             topicConfigurationResp.Result,
+		State:              v1beta1.CruiseControlTaskActive,
+	}, nil
+}
+
 func parseBrokerIDsAndLogDirsToMap(brokerIDsAndLogDirs string) (map[int32][]string, error) {
 	// brokerIDsAndLogDirs format: brokerID1-logDir1,brokerID2-logDir2,brokerID1-logDir3
 	brokerIDLogDirMap := make(map[int32][]string)
@@ -792,6 +1108,22 @@ func (cc *cruiseControlScaler) KafkaClusterState(ctx context.Context) (*types.Ka
 	return clusterStateResp.Result, nil
 }
 
+// AnomalyDetectorState returns the state of the Cruise Control anomaly detector with the recently detected anomalies
+func (cc *cruiseControlScaler) AnomalyDetectorState(ctx context.Context) (*types.AnomalyDetectorState, error) {
+	req := &api.StateRequest{
+		Substates: []types.Substate{types.SubstateAnomalyDetector},
+		Verbose:   true,
+	}
+	resp, err := cc.client.State(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Result == nil {
+		return nil, fmt.Errorf("could not get the anomaly detector state, the request is still in progress as user task %s", resp.TaskID)
+	}
+	return &resp.Result.AnomalyDetectorState, nil
+}
+
 // PartitionLeadersReplicasByBroker returns the number of partition replicas for every broker in the Kafka cluster.
 func (cc *cruiseControlScaler) PartitionLeadersReplicasByBroker(ctx context.Context) (brokerIDReplicaCounts map[string]int32, brokerIDLeaderCounts map[string]int32, err error) {
 	clusterStateReq := api.KafkaClusterStateRequestWithDefaults()
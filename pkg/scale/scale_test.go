@@ -16,8 +16,10 @@
 package scale
 
 import (
+	"context"
 	"testing"
 
+	"github.com/banzaicloud/go-cruise-control/pkg/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -69,3 +71,107 @@ func TestParseBrokerIDsAndLogDirToMap(t *testing.T) {
 		})
 	}
 }
+
+func TestDemoteBrokersWithParamsValidation(t *testing.T) {
+	testCases := []struct {
+		testName string
+		params   map[string]string
+	}{
+		{
+			testName: "missing broker ids",
+			params:   map[string]string{ParamSkipUrpDemotion: "false"},
+		},
+		{
+			testName: "invalid broker id",
+			params:   map[string]string{ParamBrokerID: "1,abc"},
+		},
+		{
+			testName: "invalid flag",
+			params:   map[string]string{ParamBrokerID: "1", ParamExcludeFollowerDemotion: "maybe"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			// the request is rejected before Cruise Control is called
+			_, err := (&cruiseControlScaler{}).DemoteBrokersWithParams(context.Background(), tc.params)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestParseReplicaMovementStrategies(t *testing.T) {
+	testCases := []struct {
+		testName           string
+		strategies         string
+		expectedStrategies []types.ReplicaMovementStrategy
+		expectedErr        bool
+	}{
+		{
+			testName:           "single strategy",
+			strategies:         "PrioritizeLargeReplicaMovementStrategy",
+			expectedStrategies: []types.ReplicaMovementStrategy{types.ReplicaMovementStrategyPrioritizeLarge},
+		},
+		{
+			testName:   "strategies in order",
+			strategies: "PostponeUrpReplicaMovementStrategy, PrioritizeSmallReplicaMovementStrategy",
+			expectedStrategies: []types.ReplicaMovementStrategy{
+				types.ReplicaMovementStrategyPostponeURP, types.ReplicaMovementStrategyPrioritizeSmall,
+			},
+		},
+		{
+			testName:    "unknown strategy",
+			strategies:  "PrioritizeLargeReplicaMovementStrategy,BaseReplicaMovementStrategy",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			strategies, err := parseReplicaMovementStrategies(tc.strategies)
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedStrategies, strategies)
+		})
+	}
+}
+
+func TestParseGoals(t *testing.T) {
+	testCases := []struct {
+		testName      string
+		goals         string
+		expectedGoals []types.Goal
+		expectedErr   bool
+	}{
+		{
+			testName:      "single goal",
+			goals:         "RackAwareGoal",
+			expectedGoals: []types.Goal{types.RackAwareGoal},
+		},
+		{
+			testName:      "goals in order",
+			goals:         "ReplicaCapacityGoal, DiskUsageDistributionGoal",
+			expectedGoals: []types.Goal{types.ReplicaCapacityGoal, types.DiskUsageDistributionGoal},
+		},
+		{
+			testName:    "unknown goal",
+			goals:       "RackAwareGoal,NoSuchGoal",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			goals, err := ParseGoals(tc.goals)
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedGoals, goals)
+		})
+	}
+}
@@ -36,10 +36,15 @@ type CruiseControlScaler interface {
 	RebalanceWithParams(ctx context.Context, params map[string]string) (*Result, error)
 	StopExecution(ctx context.Context) (*Result, error)
 	RemoveBrokers(ctx context.Context, brokerIDs ...string) (*Result, error)
+	DemoteBrokers(ctx context.Context, brokerIDs ...string) (*Result, error)
+	DemoteBrokersWithParams(ctx context.Context, params map[string]string) (*Result, error)
+	FixOfflineReplicasWithParams(ctx context.Context, params map[string]string) (*Result, error)
 	RemoveDisksWithParams(ctx context.Context, params map[string]string) (*Result, error)
+	TopicConfigurationWithParams(ctx context.Context, params map[string]string) (*Result, error)
 	RebalanceDisks(ctx context.Context, brokerIDs ...string) (*Result, error)
 	BrokersWithState(ctx context.Context, states ...KafkaBrokerState) ([]string, error)
 	KafkaClusterState(ctx context.Context) (*types.KafkaClusterState, error)
+	AnomalyDetectorState(ctx context.Context) (*types.AnomalyDetectorState, error)
 	PartitionReplicasByBroker(ctx context.Context) (map[string]int32, error)
 	BrokerWithLeastPartitionReplicas(ctx context.Context) (string, error)
 	LogDirsByBroker(ctx context.Context) (map[string]map[LogDirState][]string, error)
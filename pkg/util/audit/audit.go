@@ -0,0 +1,219 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	properties "github.com/banzaicloud/koperator/properties/pkg"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/util/kafka"
+)
+
+// The names of the best-practice rules
+const (
+	RuleReplicationFactor = "replication-factor"
+	RuleMinInsyncReplicas = "min-insync-replicas"
+	RuleRackSpread        = "rack-spread"
+	RuleDisruptionBudget  = "disruption-budget"
+	RuleResources         = "resources"
+	RuleTLS               = "tls"
+)
+
+const (
+	defaultReplicationFactorKey = "default.replication.factor"
+	minInsyncReplicasKey        = "min.insync.replicas"
+	brokerRackKey               = "broker.rack"
+	// the defaults of Kafka when the configs are not set
+	kafkaDefaultReplicationFactor = 1
+	kafkaDefaultMinInsyncReplicas = 1
+)
+
+// rule is a best-practice rule, its check returns the violations of the rule by the cluster
+type rule struct {
+	name     string
+	severity v1beta1.ConformanceAuditSeverity
+	check    func(cluster *v1beta1.KafkaCluster, topics []v1alpha1.KafkaTopic, brokerCount int) []string
+}
+
+// rules is the built-in best-practice ruleset the clusters are audited against
+var rules = []rule{
+	{name: RuleReplicationFactor, severity: v1beta1.ConformanceAuditSeverityCritical, check: checkReplicationFactor},
+	{name: RuleMinInsyncReplicas, severity: v1beta1.ConformanceAuditSeverityCritical, check: checkMinInsyncReplicas},
+	{name: RuleRackSpread, severity: v1beta1.ConformanceAuditSeverityWarning, check: checkRackSpread},
+	{name: RuleDisruptionBudget, severity: v1beta1.ConformanceAuditSeverityWarning, check: checkDisruptionBudget},
+	{name: RuleResources, severity: v1beta1.ConformanceAuditSeverityWarning, check: checkResources},
+	{name: RuleTLS, severity: v1beta1.ConformanceAuditSeverityCritical, check: checkTLS},
+}
+
+// severityWeights are the weights of the rules in the score by their severity
+var severityWeights = map[v1beta1.ConformanceAuditSeverity]int{
+	v1beta1.ConformanceAuditSeverityCritical: 3,
+	v1beta1.ConformanceAuditSeverityWarning:  1,
+}
+
+// Evaluate audits the cluster and its KafkaTopics against the best-practice ruleset and returns the weighted
+// percentage of the rules the cluster conforms to with the violations of the rules
+func Evaluate(cluster *v1beta1.KafkaCluster, topics []v1alpha1.KafkaTopic) (int32, []v1beta1.ConformanceAuditFinding, error) {
+	brokerCount, err := kafka.BrokerNodeCount(cluster.Spec)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var findings []v1beta1.ConformanceAuditFinding
+	total, passed := 0, 0
+	for _, r := range rules {
+		weight := severityWeights[r.severity]
+		total += weight
+		violations := r.check(cluster, topics, brokerCount)
+		if len(violations) == 0 {
+			passed += weight
+			continue
+		}
+		for _, violation := range violations {
+			findings = append(findings, v1beta1.ConformanceAuditFinding{Rule: r.name, Severity: r.severity, Message: violation})
+		}
+	}
+	return int32(passed * 100 / total), findings, nil
+}
+
+// checkReplicationFactor checks that the default replication factor and the replication factor of the topics allow
+// losing a broker without losing data
+func checkReplicationFactor(cluster *v1beta1.KafkaCluster, topics []v1alpha1.KafkaTopic, brokerCount int) []string {
+	recommended, _ := kafka.InternalTopicsReplicationDefaults(brokerCount)
+	var violations []string
+	if rf := clusterIntConfig(cluster, defaultReplicationFactorKey, kafkaDefaultReplicationFactor); rf < recommended {
+		violations = append(violations, fmt.Sprintf("%s is %d, at least %d is recommended for %d brokers",
+			defaultReplicationFactorKey, rf, recommended, brokerCount))
+	}
+	var underReplicated []string
+	for _, topic := range topics {
+		if topic.Spec.ReplicationFactor != -1 && int(topic.Spec.ReplicationFactor) < recommended {
+			underReplicated = append(underReplicated, topic.GetNamespace()+"/"+topic.GetName())
+		}
+	}
+	if len(underReplicated) > 0 {
+		sort.Strings(underReplicated)
+		violations = append(violations, fmt.Sprintf("the replication factor of the KafkaTopics %s is lower than %d",
+			strings.Join(underReplicated, ", "), recommended))
+	}
+	return violations
+}
+
+// checkMinInsyncReplicas checks that the acknowledged writes are replicated to more than one broker
+func checkMinInsyncReplicas(cluster *v1beta1.KafkaCluster, _ []v1alpha1.KafkaTopic, brokerCount int) []string {
+	_, recommended := kafka.InternalTopicsReplicationDefaults(brokerCount)
+	if minISR := clusterIntConfig(cluster, minInsyncReplicasKey, kafkaDefaultMinInsyncReplicas); minISR < recommended {
+		return []string{fmt.Sprintf("%s is %d, at least %d is recommended for %d brokers",
+			minInsyncReplicasKey, minISR, recommended, brokerCount)}
+	}
+	return nil
+}
+
+// checkRackSpread checks that the brokers are spread across racks, either by rack awareness or by the broker.rack
+// read-only config of the brokers
+func checkRackSpread(cluster *v1beta1.KafkaCluster, _ []v1alpha1.KafkaTopic, brokerCount int) []string {
+	if brokerCount < 2 || cluster.Spec.RackAwareness != nil {
+		return nil
+	}
+	racks := make(map[string]struct{})
+	for _, broker := range cluster.Spec.Brokers {
+		if rack, found := brokerReadOnlyConfig(cluster, broker).Get(brokerRackKey); found && rack.Value() != "" {
+			racks[rack.Value()] = struct{}{}
+		}
+	}
+	if len(racks) < 2 {
+		return []string{"the brokers are not spread across racks, enable rackAwareness or set broker.rack for the brokers"}
+	}
+	return nil
+}
+
+// checkDisruptionBudget checks that voluntary disruptions can not take down more brokers than the cluster tolerates
+func checkDisruptionBudget(cluster *v1beta1.KafkaCluster, _ []v1alpha1.KafkaTopic, brokerCount int) []string {
+	if brokerCount < 2 || cluster.Spec.DisruptionBudget.Create {
+		return nil
+	}
+	return []string{"no PodDisruptionBudget is created for the brokers, set disruptionBudget.create"}
+}
+
+// checkResources checks that the memory requirements of the brokers are set instead of relying on the defaults
+func checkResources(cluster *v1beta1.KafkaCluster, _ []v1alpha1.KafkaTopic, _ int) []string {
+	var brokerIDs []string
+	for _, broker := range cluster.Spec.Brokers {
+		brokerConfig, err := broker.GetBrokerConfig(cluster.Spec)
+		if err != nil || brokerConfig.Resources == nil || brokerConfig.Resources.Requests.Memory().IsZero() ||
+			brokerConfig.Resources.Limits.Memory().IsZero() {
+			brokerIDs = append(brokerIDs, strconv.Itoa(int(broker.Id)))
+		}
+	}
+	if len(brokerIDs) > 0 {
+		return []string{fmt.Sprintf("the memory requests and limits of the brokers %s are not set", strings.Join(brokerIDs, ", "))}
+	}
+	return nil
+}
+
+// checkTLS checks that the traffic of every listener is encrypted
+func checkTLS(cluster *v1beta1.KafkaCluster, _ []v1alpha1.KafkaTopic, _ int) []string {
+	var listeners []string
+	for _, listener := range cluster.Spec.ListenersConfig.InternalListeners {
+		if !listener.Type.IsSSL() {
+			listeners = append(listeners, listener.Name)
+		}
+	}
+	for _, listener := range cluster.Spec.ListenersConfig.ExternalListeners {
+		if !listener.Type.IsSSL() && !listener.TLSEnabled() {
+			listeners = append(listeners, listener.Name)
+		}
+	}
+	if len(listeners) > 0 {
+		return []string{fmt.Sprintf("the listeners %s do not use TLS", strings.Join(listeners, ", "))}
+	}
+	return nil
+}
+
+// clusterIntConfig returns the integer value of the cluster-wide read-only config, the given default if not set
+func clusterIntConfig(cluster *v1beta1.KafkaCluster, key string, defaultValue int) int {
+	config, err := properties.NewFromString(cluster.Spec.ReadOnlyConfig)
+	if err != nil {
+		return defaultValue
+	}
+	property, found := config.Get(key)
+	if !found {
+		return defaultValue
+	}
+	value, err := property.Int()
+	if err != nil {
+		return defaultValue
+	}
+	return int(value)
+}
+
+// brokerReadOnlyConfig returns the read-only config of the broker merged over the cluster-wide one
+func brokerReadOnlyConfig(cluster *v1beta1.KafkaCluster, broker v1beta1.Broker) *properties.Properties {
+	config, err := properties.NewFromString(cluster.Spec.ReadOnlyConfig)
+	if err != nil {
+		config = properties.NewProperties()
+	}
+	if brokerConfig, err := properties.NewFromString(broker.ReadOnlyConfig); err == nil {
+		config.Merge(brokerConfig)
+	}
+	return config
+}
@@ -0,0 +1,146 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func TestEvaluate(t *testing.T) {
+	resources := &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+		Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+	}
+	conformingSpec := func() v1beta1.KafkaClusterSpec {
+		return v1beta1.KafkaClusterSpec{
+			ReadOnlyConfig:     "default.replication.factor=3\nmin.insync.replicas=2",
+			BrokerConfigGroups: map[string]v1beta1.BrokerConfig{"default": {Resources: resources}},
+			Brokers: []v1beta1.Broker{
+				{Id: 0, BrokerConfigGroup: "default", ReadOnlyConfig: "broker.rack=az1"},
+				{Id: 1, BrokerConfigGroup: "default", ReadOnlyConfig: "broker.rack=az2"},
+				{Id: 2, BrokerConfigGroup: "default", ReadOnlyConfig: "broker.rack=az3"},
+			},
+			DisruptionBudget: v1beta1.DisruptionBudget{Create: true, Budget: "1"},
+			ListenersConfig: v1beta1.ListenersConfig{
+				InternalListeners: []v1beta1.InternalListenerConfig{
+					{CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolSSL}},
+				},
+			},
+		}
+	}
+	topic := func(name string, replicationFactor int32) v1alpha1.KafkaTopic {
+		return v1alpha1.KafkaTopic{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kafka"},
+			Spec:       v1alpha1.KafkaTopicSpec{Name: name, ReplicationFactor: replicationFactor},
+		}
+	}
+
+	testCases := []struct {
+		testName      string
+		spec          func(spec *v1beta1.KafkaClusterSpec)
+		topics        []v1alpha1.KafkaTopic
+		expectedScore int32
+		expectedRules []string
+	}{
+		{
+			testName:      "conforming cluster",
+			topics:        []v1alpha1.KafkaTopic{topic("orders", 3), topic("defaults", -1)},
+			expectedScore: 100,
+		},
+		{
+			testName:      "under-replicated topic",
+			topics:        []v1alpha1.KafkaTopic{topic("orders", 1)},
+			expectedScore: 75,
+			expectedRules: []string{RuleReplicationFactor},
+		},
+		{
+			testName: "Kafka defaults",
+			spec: func(spec *v1beta1.KafkaClusterSpec) {
+				spec.ReadOnlyConfig = ""
+			},
+			expectedScore: 50,
+			expectedRules: []string{RuleReplicationFactor, RuleMinInsyncReplicas},
+		},
+		{
+			testName: "brokers in a single rack without disruption budget",
+			spec: func(spec *v1beta1.KafkaClusterSpec) {
+				for i := range spec.Brokers {
+					spec.Brokers[i].ReadOnlyConfig = "broker.rack=az1"
+				}
+				spec.DisruptionBudget.Create = false
+			},
+			expectedScore: 83,
+			expectedRules: []string{RuleRackSpread, RuleDisruptionBudget},
+		},
+		{
+			testName: "rack awareness",
+			spec: func(spec *v1beta1.KafkaClusterSpec) {
+				for i := range spec.Brokers {
+					spec.Brokers[i].ReadOnlyConfig = ""
+				}
+				spec.RackAwareness = &v1beta1.RackAwareness{Labels: []string{"topology.kubernetes.io/zone"}}
+			},
+			expectedScore: 100,
+		},
+		{
+			testName: "default resources and plaintext listener",
+			spec: func(spec *v1beta1.KafkaClusterSpec) {
+				spec.BrokerConfigGroups = map[string]v1beta1.BrokerConfig{"default": {}}
+				spec.ListenersConfig.InternalListeners[0].Type = v1beta1.SecurityProtocolPlaintext
+			},
+			expectedScore: 66,
+			expectedRules: []string{RuleResources, RuleTLS},
+		},
+		{
+			testName: "single-node cluster",
+			spec: func(spec *v1beta1.KafkaClusterSpec) {
+				spec.ReadOnlyConfig = ""
+				spec.Brokers = spec.Brokers[:1]
+				spec.DisruptionBudget.Create = false
+			},
+			expectedScore: 100,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Spec:       conformingSpec(),
+			}
+			if test.spec != nil {
+				test.spec(&cluster.Spec)
+			}
+
+			score, findings, err := Evaluate(cluster, test.topics)
+			require.NoError(t, err)
+			require.Equal(t, test.expectedScore, score)
+			var rules []string
+			for _, finding := range findings {
+				rules = append(rules, finding.Rule)
+			}
+			require.Equal(t, test.expectedRules, rules)
+		})
+	}
+}
@@ -17,6 +17,7 @@ package cert
 
 import (
 	"bytes"
+	"context"
 	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
@@ -160,6 +161,32 @@ func EnsureSecretPassJKS(secret *corev1.Secret) (injected *corev1.Secret, err er
 	return
 }
 
+// ReencryptJKS stores the entries of a JKS keystore or truststore with a new password, the private key entries are
+// protected by the new password as well
+func ReencryptJKS(store, oldPassword, newPassword []byte) ([]byte, error) {
+	jksStore := jks.New()
+	if err := jksStore.Load(bytes.NewReader(store), oldPassword); err != nil {
+		return nil, errors.WrapIf(err, "couldn't load JKS with the current password")
+	}
+	for _, alias := range jksStore.Aliases() {
+		if !jksStore.IsPrivateKeyEntry(alias) {
+			continue
+		}
+		entry, err := jksStore.GetPrivateKeyEntry(alias, oldPassword)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "couldn't get private key entry from JKS", "alias", alias)
+		}
+		if err = jksStore.SetPrivateKeyEntry(alias, entry, newPassword); err != nil {
+			return nil, errors.WrapIfWithDetails(err, "couldn't set private key entry of JKS", "alias", alias)
+		}
+	}
+	var out bytes.Buffer
+	if err := jksStore.Store(&out, newPassword); err != nil {
+		return nil, errors.WrapIf(err, "couldn't store JKS with the new password")
+	}
+	return out.Bytes(), nil
+}
+
 func GenerateJKSFromByte(certByte []byte, privateKey []byte, caCert []byte) (out, passw []byte, err error) {
 	c, err := DecodeCertificate(certByte)
 	if err != nil {
@@ -231,6 +258,29 @@ func GenerateJKS(certs []*x509.Certificate, privateKey []byte) (out, passw []byt
 	return outBuf.Bytes(), password, err
 }
 
+// GenerateTrustStoreJKS creates a JKS truststore protected by the given password holding the given CA certificates
+// as trusted certificate entries
+func GenerateTrustStoreJKS(caCerts []*x509.Certificate, password []byte) ([]byte, error) {
+	jksTrustStore := jks.New()
+	for i, caCert := range caCerts {
+		caIn := jks.TrustedCertificateEntry{
+			CreationTime: time.Now(),
+			Certificate: jks.Certificate{
+				Type:    "X.509",
+				Content: caCert.Raw,
+			},
+		}
+		if err := jksTrustStore.SetTrustedCertificateEntry(fmt.Sprintf("trusted_ca_%d", i), caIn); err != nil {
+			return nil, err
+		}
+	}
+	var out bytes.Buffer
+	if err := jksTrustStore.Store(&out, password); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
 // GenerateTestCert is used from unit tests for generating certificates
 func GenerateTestCert() (cert, key []byte, expectedDn string, err error) {
 	priv, serialNumber, err := generatePrivateKey()
@@ -243,6 +293,8 @@ func GenerateTestCert() (cert, key []byte, expectedDn string, err error) {
 		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
 		BasicConstraintsValid: true,
 		IsCA:                  true,
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
 		Subject: pkix.Name{
 			CommonName:   "test-cn",
 			Organization: []string{"test-ou"},
@@ -428,3 +480,34 @@ func ParseKeyStoreToTLSCertificate(keystore, password []byte) (tls.Certificate,
 
 	return x509ClientCert, nil
 }
+
+// GetServedCertificate returns the leaf certificate presented by the TLS server listening on the given address. The
+// certificate is only read and not verified, and the handshake is allowed to fail afterwards, e.g. when the server
+// requires a client certificate.
+func GetServedCertificate(ctx context.Context, address string) (*x509.Certificate, error) {
+	var served []byte
+	dialer := &tls.Dialer{
+		Config: &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			//nolint:gosec // the presented certificate is compared with the expected one instead of being trusted
+			InsecureSkipVerify: true,
+			VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+				if len(rawCerts) > 0 {
+					served = rawCerts[0]
+				}
+				return nil
+			},
+		},
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if conn != nil {
+		_ = conn.Close()
+	}
+	if served == nil {
+		if err == nil {
+			err = errors.New("no certificate presented")
+		}
+		return nil, errors.WrapIfWithDetails(err, "could not read the served certificate", "address", address)
+	}
+	return x509.ParseCertificate(served)
+}
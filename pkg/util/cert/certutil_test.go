@@ -16,11 +16,13 @@ package cert
 
 import (
 	"bytes"
+	"context"
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/pem"
@@ -160,6 +162,33 @@ func TestGenerateJKS(t *testing.T) {
 	}
 }
 
+func TestReencryptJKS(t *testing.T) {
+	cert, key, _, err := GenerateTestCert()
+	if err != nil {
+		t.Error("Failed to generate test certificate")
+	}
+	keyStoreBytes, password, err := GenerateJKSFromByte(cert, key, cert)
+	if err != nil {
+		t.Error("Expected to generate JKS, got error:", err)
+	}
+
+	newPassword := []byte("new-password")
+	reencrypted, err := ReencryptJKS(keyStoreBytes, password, newPassword)
+	if err != nil {
+		t.Error("Expected to re-encrypt JKS, got error:", err)
+	}
+	if _, err = ParseKeyStoreToTLSCertificate(reencrypted, newPassword); err != nil {
+		t.Error("Expected to parse re-encrypted JKS with the new password, got error:", err)
+	}
+	if err = keystore.New().Load(bytes.NewReader(reencrypted), password); err == nil {
+		t.Error("Expected to fail loading re-encrypted JKS with the old password, got nil error")
+	}
+
+	if _, err = ReencryptJKS(keyStoreBytes, []byte("wrong-password"), newPassword); err == nil {
+		t.Error("Expected to fail re-encrypting JKS with a wrong password, got nil error")
+	}
+}
+
 func TestEnsureJKSPassoword(t *testing.T) {
 	cert, key, _, err := GenerateTestCert()
 	if err != nil {
@@ -229,3 +258,46 @@ func TestParseKeyStoreToTLSCertificate(t *testing.T) {
 		}
 	}
 }
+
+func TestGetServedCertificate(t *testing.T) {
+	cert, key, _, err := GenerateTestCert()
+	if err != nil {
+		t.Fatal("Failed to generate test certificate", err)
+	}
+	serverCert, err := tls.X509KeyPair(cert, key)
+	if err != nil {
+		t.Fatal("Failed to load test certificate", err)
+	}
+	// the server requires a client certificate which the client does not present
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAnyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	})
+	if err != nil {
+		t.Fatal("Failed to listen", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.(*tls.Conn).Handshake()
+			_ = conn.Close()
+		}
+	}()
+
+	served, err := GetServedCertificate(context.Background(), listener.Addr().String())
+	if err != nil {
+		t.Fatal("Expected to read the served certificate, got error:", err)
+	}
+	if !bytes.Equal(served.Raw, serverCert.Certificate[0]) {
+		t.Error("Served certificate differs from the certificate of the server")
+	}
+
+	if _, err := GetServedCertificate(context.Background(), "127.0.0.1:1"); err == nil {
+		t.Error("Expected error when nothing listens on the address")
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// maxLookahead bounds the search of the next activation, schedules never activating (e.g. on February 30) return the
+// zero time once exceeded
+const maxLookahead = 5 * 366 * 24 * time.Hour
+
+// field describes the allowed values of a schedule field
+type field struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 7 is accepted for Sunday as well and folded onto 0
+	dowField = field{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// descriptors are the shorthands of the common schedules
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Schedule is a parsed cron expression
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar record the day fields starting with an asterisk, when both day fields are restricted a day
+	// matching either of them activates the schedule
+	domStar, dowStar bool
+}
+
+// Parse parses a standard five field cron expression (minute, hour, day of month, month and day of week) supporting
+// lists, ranges, steps, month and day names and the @yearly, @monthly, @weekly, @daily and @hourly shorthands
+func Parse(expression string) (*Schedule, error) {
+	expression = strings.TrimSpace(expression)
+	if descriptor, ok := descriptors[strings.ToLower(expression)]; ok {
+		expression = descriptor
+	}
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, errors.Errorf("expected 5 fields in cron expression %q, found %d", expression, len(fields))
+	}
+
+	schedule := &Schedule{
+		domStar: strings.HasPrefix(fields[2], "*"),
+		dowStar: strings.HasPrefix(fields[4], "*"),
+	}
+	var err error
+	for i, target := range []struct {
+		field field
+		bits  *uint64
+	}{
+		{minuteField, &schedule.minute},
+		{hourField, &schedule.hour},
+		{domField, &schedule.dom},
+		{monthField, &schedule.month},
+		{dowField, &schedule.dow},
+	} {
+		if *target.bits, err = target.field.parse(fields[i]); err != nil {
+			return nil, errors.WrapIff(err, "invalid cron expression %q", expression)
+		}
+	}
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow = schedule.dow&^(1<<7) | 1
+	}
+	return schedule, nil
+}
+
+// parse returns the bit set of the values listed by the field expression
+func (f field) parse(expression string) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(expression, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepExpr); err != nil || step <= 0 {
+				return 0, errors.Errorf("invalid step %q in %s field", stepExpr, f.name)
+			}
+		}
+
+		var low, high int
+		switch {
+		case rangeExpr == "*":
+			low, high = f.min, f.max
+		case strings.Contains(rangeExpr, "-"):
+			lowExpr, highExpr, _ := strings.Cut(rangeExpr, "-")
+			var err error
+			if low, err = f.value(lowExpr); err != nil {
+				return 0, err
+			}
+			if high, err = f.value(highExpr); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, errors.Errorf("invalid range %q in %s field", rangeExpr, f.name)
+			}
+		default:
+			var err error
+			if low, err = f.value(rangeExpr); err != nil {
+				return 0, err
+			}
+			high = low
+			// a step after a single value runs to the end of the range, e.g. 5/15 in the minute field
+			if hasStep {
+				high = f.max
+			}
+		}
+
+		for value := low; value <= high; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+// value parses a single value of the field given as a number or as a name
+func (f field) value(expression string) (int, error) {
+	if value, ok := f.names[strings.ToLower(expression)]; ok {
+		return value, nil
+	}
+	value, err := strconv.Atoi(expression)
+	if err != nil {
+		return 0, errors.Errorf("invalid value %q in %s field", expression, f.name)
+	}
+	if value < f.min || value > f.max {
+		return 0, errors.Errorf("value %d out of the range %d-%d of the %s field", value, f.min, f.max, f.name)
+	}
+	return value, nil
+}
+
+// Next returns the first activation of the schedule strictly after the given time in its location, the zero time
+// when the schedule never activates
+func (s *Schedule) Next(t time.Time) time.Time {
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxLookahead)
+	for next.Before(limit) {
+		switch {
+		case !has(s.month, int(next.Month())):
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, next.Location())
+		case !s.dayMatches(next):
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, next.Location())
+		case !has(s.hour, next.Hour()):
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, next.Location())
+		case !has(s.minute, next.Minute()):
+			next = next.Add(time.Minute)
+		default:
+			return next
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches returns whether the day of the given time matches the day of month and day of week fields
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatches := has(s.dom, t.Day())
+	dowMatches := has(s.dow, int(t.Weekday()))
+	if s.domStar || s.dowStar {
+		return domMatches && dowMatches
+	}
+	return domMatches || dowMatches
+}
+
+func has(bits uint64, value int) bool {
+	return bits&(1<<uint(value)) != 0
+}
@@ -0,0 +1,130 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		testName   string
+		expression string
+		expectErr  bool
+	}{
+		{testName: "every minute", expression: "* * * * *"},
+		{testName: "lists ranges and steps", expression: "0,30 1-5/2 */10 * mon-fri"},
+		{testName: "names", expression: "0 2 * JAN,Jul sun"},
+		{testName: "sunday as 7", expression: "0 2 * * 7"},
+		{testName: "descriptor", expression: "@daily"},
+		{testName: "too few fields", expression: "0 2 * *", expectErr: true},
+		{testName: "too many fields", expression: "0 0 2 * * *", expectErr: true},
+		{testName: "minute out of range", expression: "60 * * * *", expectErr: true},
+		{testName: "day of month out of range", expression: "0 0 0 * *", expectErr: true},
+		{testName: "inverted range", expression: "0 5-1 * * *", expectErr: true},
+		{testName: "zero step", expression: "*/0 * * * *", expectErr: true},
+		{testName: "unknown name", expression: "0 0 * foo *", expectErr: true},
+		{testName: "empty", expression: "", expectErr: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			_, err := Parse(test.expression)
+			if test.expectErr != (err != nil) {
+				t.Errorf("expected error: %v, got: %v", test.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	// Wednesday
+	from := time.Date(2025, time.January, 15, 10, 17, 30, 0, time.UTC)
+
+	testCases := []struct {
+		testName   string
+		expression string
+		expected   time.Time
+	}{
+		{
+			testName:   "every minute",
+			expression: "* * * * *",
+			expected:   time.Date(2025, time.January, 15, 10, 18, 0, 0, time.UTC),
+		},
+		{
+			testName:   "later the same day",
+			expression: "30 22 * * *",
+			expected:   time.Date(2025, time.January, 15, 22, 30, 0, 0, time.UTC),
+		},
+		{
+			testName:   "next day",
+			expression: "0 2 * * *",
+			expected:   time.Date(2025, time.January, 16, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			testName:   "step",
+			expression: "*/20 * * * *",
+			expected:   time.Date(2025, time.January, 15, 10, 20, 0, 0, time.UTC),
+		},
+		{
+			testName:   "weekend",
+			expression: "0 3 * * sat,sun",
+			expected:   time.Date(2025, time.January, 18, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			testName:   "sunday as 7",
+			expression: "0 3 * * 7",
+			expected:   time.Date(2025, time.January, 19, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			testName:   "next month",
+			expression: "0 0 1 * *",
+			expected:   time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			testName:   "next year",
+			expression: "@yearly",
+			expected:   time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			testName:   "day of month or day of week when both are restricted",
+			expression: "0 0 17 * mon",
+			expected:   time.Date(2025, time.January, 17, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			testName:   "leap day",
+			expression: "0 0 29 2 *",
+			expected:   time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			testName:   "never",
+			expression: "0 0 30 2 *",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			schedule, err := Parse(test.expression)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if next := schedule.Next(from); !next.Equal(test.expected) {
+				t.Errorf("expected: %v, got: %v", test.expected, next)
+			}
+		})
+	}
+}
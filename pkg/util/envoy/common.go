@@ -14,6 +14,8 @@
 
 package envoy
 
+import "time"
+
 const (
 	// EnvoyServiceName name for loadbalancer service
 	EnvoyServiceName = "envoy-loadbalancer-%s-%s"
@@ -33,3 +35,12 @@ const (
 	AllBrokerEnvoyConfigName          = "all-brokers"
 	HealthCheckPath                   = "/healthcheck"
 )
+
+const (
+	// ConnectionLimitFilterName is the name of the network filter limiting the concurrent connections
+	ConnectionLimitFilterName = "envoy.filters.network.connection_limit"
+	// LocalRateLimitFilterName is the name of the network filter limiting the rate of new connections
+	LocalRateLimitFilterName = "envoy.filters.network.local_ratelimit"
+	// MinRateLimitFillInterval is the shortest fill interval of the token buckets accepted by Envoy
+	MinRateLimitFillInterval = 50 * time.Millisecond
+)
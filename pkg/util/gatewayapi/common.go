@@ -0,0 +1,39 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gatewayapi
+
+const (
+	// GatewayName name for the Gateway
+	GatewayName = "gateway-%s-%s"
+	// GatewayNameWithScope name for the Gateway with ingress config scope
+	GatewayNameWithScope = "gateway-%s-%s-%s"
+	// AllBrokerServiceName name for the service of the anycast route
+	AllBrokerServiceName = "gateway-svc-%s-%s"
+	// AllBrokerServiceNameWithScope name for the service of the anycast route with ingress config scope
+	AllBrokerServiceNameWithScope = "gateway-svc-%s-%s-%s"
+	// BrokerServiceNameTemplate name for the service of a broker route
+	BrokerServiceNameTemplate = "gateway-svc-%s-%d-%s"
+	// BrokerRouteNameTemplate name for the route of a broker, derived from the name of the Gateway
+	BrokerRouteNameTemplate = "%s-broker-%d"
+	// AllBrokerRouteNameTemplate name for the anycast route, derived from the name of the Gateway
+	AllBrokerRouteNameTemplate = "%s-all-broker"
+	// AllBrokerListenerName name of the Gateway listener and port of the anycast route
+	AllBrokerListenerName = "tcp-all-broker"
+	// BrokerListenerNameTemplate name of the Gateway listener of a broker
+	BrokerListenerNameTemplate = "broker-%d"
+	// IngressControllerName name for the Gateway API ingress controller
+	IngressControllerName = "gatewayapi"
+)
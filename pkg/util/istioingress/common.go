@@ -14,6 +14,8 @@
 
 package istioingress
 
+import "fmt"
+
 const (
 	// IngressControllerName name for istioingress ingress service
 	IngressControllerName = "istioingress"
@@ -21,4 +23,36 @@ const (
 	MeshGatewayNameTemplate = "meshgateway-%s-%s"
 	// MeshGatewayNameTemplateWithScope name for istioingress gateway service with scope
 	MeshGatewayNameTemplateWithScope = "meshgateway-%s-%s-%s"
+	// DataplaneModeLabelKey enrolls the labeled pods into the ambient mesh
+	DataplaneModeLabelKey = "istio.io/dataplane-mode"
+	// DataplaneModeAmbient is the value of DataplaneModeLabelKey enrolling pods into the ambient mesh
+	DataplaneModeAmbient = "ambient"
+	// SidecarInjectLabelKey controls the sidecar injection into the labeled pods
+	SidecarInjectLabelKey = "sidecar.istio.io/inject"
+	// UseWaypointLabelKey selects the waypoint proxy handling the traffic of the labeled pods
+	UseWaypointLabelKey = "istio.io/use-waypoint"
+	// WaypointForLabelKey defines the kind of traffic handled by a waypoint proxy
+	WaypointForLabelKey = "istio.io/waypoint-for"
+	// WaypointForWorkload is the value of WaypointForLabelKey for waypoints handling the traffic addressed to pods
+	WaypointForWorkload = "workload"
+	// WaypointGatewayClassName is the GatewayClass Istio provisions waypoint proxies for
+	WaypointGatewayClassName = "istio-waypoint"
+	// WaypointNameTemplate name for the waypoint proxy of the cluster
+	WaypointNameTemplate = "%s-waypoint"
+	// WaypointListenerName name of the HBONE listener of the waypoint proxy
+	WaypointListenerName = "mesh"
+	// WaypointProtocol is the protocol of the tunneled traffic the waypoint proxy receives from ztunnel
+	WaypointProtocol = "HBONE"
+	// WaypointPort is the port of the HBONE listener of the waypoint proxy
+	WaypointPort = 15008
 )
+
+// AmbientPodLabels returns the labels enrolling the broker pods of the given cluster into the ambient mesh behind the
+// waypoint proxy of the cluster, the sidecar injection is disabled as the pods must not be part of both data planes
+func AmbientPodLabels(clusterName string) map[string]string {
+	return map[string]string{
+		DataplaneModeLabelKey: DataplaneModeAmbient,
+		SidecarInjectLabelKey: "false",
+		UseWaypointLabelKey:   fmt.Sprintf(WaypointNameTemplate, clusterName),
+	}
+}
@@ -41,6 +41,48 @@ var PerBrokerConfigs = []string{
 	KafkaConfigListenerSecurityProtocolMap,
 }
 
+// DynamicBrokerConfigs are the broker configurations Kafka can update on a running broker, changing them in the broker
+// configuration applies the new value through the dynamic broker configuration of the broker instead of a rolling
+// restart. Removing them from the broker configuration still requires a rolling restart for the brokers to fall back
+// to their default value.
+var DynamicBrokerConfigs = []string{
+	"background.threads",
+	"compression.type",
+	"log.cleaner.backoff.ms",
+	"log.cleaner.dedupe.buffer.size",
+	"log.cleaner.delete.retention.ms",
+	"log.cleaner.io.buffer.load.factor",
+	"log.cleaner.io.buffer.size",
+	"log.cleaner.io.max.bytes.per.second",
+	"log.cleaner.max.compaction.lag.ms",
+	"log.cleaner.min.cleanable.ratio",
+	"log.cleaner.min.compaction.lag.ms",
+	"log.cleaner.threads",
+	"log.cleanup.policy",
+	"log.flush.interval.messages",
+	"log.flush.interval.ms",
+	"log.index.interval.bytes",
+	"log.index.size.max.bytes",
+	"log.preallocate",
+	"log.retention.bytes",
+	"log.retention.ms",
+	"log.roll.jitter.ms",
+	"log.roll.ms",
+	"log.segment.bytes",
+	"log.segment.delete.delay.ms",
+	"max.connection.creation.rate",
+	"max.connections",
+	"max.connections.per.ip",
+	"max.connections.per.ip.overrides",
+	"message.max.bytes",
+	"min.insync.replicas",
+	"num.io.threads",
+	"num.network.threads",
+	"num.recovery.threads.per.data.dir",
+	"num.replica.fetchers",
+	"unclean.leader.election.enable",
+}
+
 // commonACLString is the raw representation of an ACL allowing Describe on a Topic
 var commonACLString = "User:%s,Topic,%s,%s,Describe,Allow,*"
 
@@ -112,6 +154,13 @@ func ShouldRefreshOnlyPerBrokerConfigs(currentConfigs, desiredConfigs *propertie
 		delete(configDiff, perBrokerConfig)
 	}
 
+	// added or changed dynamic broker configs are applied to the running brokers, removed ones need a restart
+	for _, dynamicConfig := range DynamicBrokerConfigs {
+		if diff, ok := configDiff[dynamicConfig]; ok && diff[1].Value() != "" {
+			delete(configDiff, dynamicConfig)
+		}
+	}
+
 	return len(configDiff) == 0
 }
 
@@ -90,6 +90,26 @@ ssl.client.auth=modified_value_4
 			DesiredConfigs: "listener.security.protocol.map=listener1:protocol1,listener2:protocol3",
 			Result:         false,
 		},
+		{
+			Description: "dynamic broker configs added or changed",
+			CurrentConfigs: `unmodified_config_1=unmodified_value_1
+log.cleaner.threads=1
+`,
+			DesiredConfigs: `unmodified_config_1=unmodified_value_1
+log.cleaner.threads=2
+message.max.bytes=2097152
+`,
+			Result: true,
+		},
+		{
+			Description: "dynamic broker config removed",
+			CurrentConfigs: `unmodified_config_1=unmodified_value_1
+log.cleaner.threads=2
+`,
+			DesiredConfigs: `unmodified_config_1=unmodified_value_1
+`,
+			Result: false,
+		},
 		{
 			Description:    "security protocol map added as config",
 			CurrentConfigs: "",
@@ -0,0 +1,178 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	properties "github.com/banzaicloud/koperator/properties/pkg"
+)
+
+// Feature is an operator feature which requires specific Kafka or Cruise Control versions
+type Feature string
+
+const (
+	// FeatureKRaft runs the cluster without ZooKeeper
+	FeatureKRaft Feature = "KRaft"
+	// FeatureTieredStorage offloads log segments to remote storage
+	FeatureTieredStorage Feature = "tiered storage"
+
+	// KafkaConfigRemoteLogStorageSystemEnable enables tiered storage on the brokers
+	KafkaConfigRemoteLogStorageSystemEnable = "remote.log.storage.system.enable"
+)
+
+// CruiseControlCompatibility holds the Kafka versions whose metrics a range of Cruise Control versions can parse
+type CruiseControlCompatibility struct {
+	CruiseControl string
+	Kafka         string
+}
+
+// FeatureCompatibility holds the Kafka and Cruise Control versions an operator feature requires, an empty constraint
+// allows any version
+type FeatureCompatibility struct {
+	Feature       Feature
+	Kafka         string
+	CruiseControl string
+}
+
+var (
+	// CruiseControlCompatibilityMatrix lists the Kafka versions supported by the Cruise Control versions, Cruise Control
+	// versions not listed are incompatible with every Kafka version
+	CruiseControlCompatibilityMatrix = []CruiseControlCompatibility{
+		{CruiseControl: ">= 2.0.0, < 2.5.0", Kafka: ">= 2.0.0, < 3.0.0"},
+		{CruiseControl: ">= 2.5.0, < 3.0.0", Kafka: ">= 2.5.0, < 4.0.0"},
+		{CruiseControl: ">= 3.0.0", Kafka: ">= 3.0.0"},
+	}
+
+	// FeatureCompatibilityMatrix lists the versions required by the operator features
+	FeatureCompatibilityMatrix = []FeatureCompatibility{
+		{Feature: FeatureKRaft, Kafka: ">= 3.3.0", CruiseControl: ">= 3.0.0"},
+		{Feature: FeatureTieredStorage, Kafka: ">= 3.6.0"},
+	}
+)
+
+// CruiseControlVersionFromImage returns the Cruise Control version held by the tag of the given image, e.g. 3.0.3 for
+// adobe/cruise-control:3.0.3-adbe-20250804. It returns false if the tag does not hold a version.
+func CruiseControlVersionFromImage(image string) (string, bool) {
+	tag, ok := imageTag(image)
+	if !ok {
+		return "", false
+	}
+	// the build metadata follows the Cruise Control version in the tag, the first match is the version
+	version := imageTagVersionRegex.FindString(tag)
+	return version, version != ""
+}
+
+// EnabledFeatures returns the operator features enabled by the given cluster spec which have version requirements
+func EnabledFeatures(kafkaClusterSpec v1beta1.KafkaClusterSpec) []Feature {
+	var features []Feature
+	if kafkaClusterSpec.KRaftMode {
+		features = append(features, FeatureKRaft)
+	}
+	if kafkaClusterSpec.TieredStorage != nil {
+		features = append(features, FeatureTieredStorage)
+	} else if config, err := properties.NewFromString(kafkaClusterSpec.ReadOnlyConfig); err == nil {
+		if enabled, found := config.Get(KafkaConfigRemoteLogStorageSystemEnable); found && enabled.Value() == "true" {
+			features = append(features, FeatureTieredStorage)
+		}
+	}
+	return features
+}
+
+// CheckCompatibility returns the incompatibilities of the given Kafka and Cruise Control versions with each other and
+// with the given features according to the compatibility matrix. Empty or unparsable versions are not checked.
+func CheckCompatibility(kafkaVersion, cruiseControlVersion string, features []Feature) []string {
+	kafka, _ := semver.NewVersion(kafkaVersion)
+	cruiseControl, _ := semver.NewVersion(cruiseControlVersion)
+
+	var incompatibilities []string
+	if kafka != nil && cruiseControl != nil {
+		compatible := false
+		for _, entry := range CruiseControlCompatibilityMatrix {
+			if satisfies(cruiseControl, entry.CruiseControl) && satisfies(kafka, entry.Kafka) {
+				compatible = true
+				break
+			}
+		}
+		if !compatible {
+			incompatibilities = append(incompatibilities,
+				fmt.Sprintf("Cruise Control %s does not support Kafka %s", cruiseControl, kafka))
+		}
+	}
+	for _, feature := range features {
+		for _, entry := range FeatureCompatibilityMatrix {
+			if entry.Feature != feature {
+				continue
+			}
+			if kafka != nil && !satisfies(kafka, entry.Kafka) {
+				incompatibilities = append(incompatibilities,
+					fmt.Sprintf("%s requires Kafka %s, found %s", feature, entry.Kafka, kafka))
+			}
+			if cruiseControl != nil && !satisfies(cruiseControl, entry.CruiseControl) {
+				incompatibilities = append(incompatibilities,
+					fmt.Sprintf("%s requires Cruise Control %s, found %s", feature, entry.CruiseControl, cruiseControl))
+			}
+		}
+	}
+	return incompatibilities
+}
+
+// DetectedVersions returns the Kafka versions reported by the brokers and the Cruise Control version of the image of
+// the given cluster, together with their incompatibilities
+func DetectedVersions(cluster *v1beta1.KafkaCluster) *v1beta1.DetectedVersionsStatus {
+	status := &v1beta1.DetectedVersionsStatus{}
+	status.CruiseControl, _ = CruiseControlVersionFromImage(cluster.Spec.CruiseControlConfig.GetCCImage())
+
+	kafkaVersions := make(map[string]struct{})
+	for _, brokerState := range cluster.Status.BrokersState {
+		if brokerState.Version != "" {
+			kafkaVersions[brokerState.Version] = struct{}{}
+		}
+	}
+	for version := range kafkaVersions {
+		status.Kafka = append(status.Kafka, version)
+	}
+	sort.Slice(status.Kafka, func(i, j int) bool {
+		vi, errI := semver.NewVersion(status.Kafka[i])
+		vj, errJ := semver.NewVersion(status.Kafka[j])
+		if errI != nil || errJ != nil {
+			return status.Kafka[i] < status.Kafka[j]
+		}
+		return vi.LessThan(vj)
+	})
+
+	features := EnabledFeatures(cluster.Spec)
+	for _, version := range status.Kafka {
+		status.Incompatibilities = append(status.Incompatibilities, CheckCompatibility(version, status.CruiseControl, features)...)
+	}
+	return status
+}
+
+// satisfies returns true if the version satisfies the constraint, an empty constraint is satisfied by any version
+func satisfies(version *semver.Version, constraint string) bool {
+	if constraint == "" {
+		return true
+	}
+	constraints, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return false
+	}
+	return constraints.Check(version)
+}
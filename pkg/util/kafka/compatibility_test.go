@@ -0,0 +1,126 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func TestCruiseControlVersionFromImage(t *testing.T) {
+	testCases := []struct {
+		testName        string
+		image           string
+		expectedVersion string
+		expectedFound   bool
+	}{
+		{
+			testName:        "version with build metadata",
+			image:           "adobe/cruise-control:3.0.3-adbe-20250804",
+			expectedVersion: "3.0.3",
+			expectedFound:   true,
+		},
+		{
+			testName:        "version tag",
+			image:           "ghcr.io/banzaicloud/cruise-control:2.5.138",
+			expectedVersion: "2.5.138",
+			expectedFound:   true,
+		},
+		{
+			testName: "tag without version",
+			image:    "adobe/cruise-control:latest",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			version, found := CruiseControlVersionFromImage(test.image)
+			require.Equal(t, test.expectedFound, found)
+			require.Equal(t, test.expectedVersion, version)
+		})
+	}
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	testCases := []struct {
+		testName                  string
+		kafkaVersion              string
+		cruiseControlVersion      string
+		features                  []Feature
+		expectedIncompatibilities []string
+	}{
+		{
+			testName:             "compatible versions",
+			kafkaVersion:         "3.9.1",
+			cruiseControlVersion: "3.0.3",
+			features:             []Feature{FeatureKRaft, FeatureTieredStorage},
+		},
+		{
+			testName:                  "Cruise Control does not support newer Kafka",
+			kafkaVersion:              "4.0.0",
+			cruiseControlVersion:      "2.5.138",
+			expectedIncompatibilities: []string{"Cruise Control 2.5.138 does not support Kafka 4.0.0"},
+		},
+		{
+			testName:             "features require newer versions",
+			kafkaVersion:         "3.2.3",
+			cruiseControlVersion: "2.5.138",
+			features:             []Feature{FeatureKRaft, FeatureTieredStorage},
+			expectedIncompatibilities: []string{
+				"KRaft requires Kafka >= 3.3.0, found 3.2.3",
+				"KRaft requires Cruise Control >= 3.0.0, found 2.5.138",
+				"tiered storage requires Kafka >= 3.6.0, found 3.2.3",
+			},
+		},
+		{
+			testName:                  "unknown Cruise Control version",
+			kafkaVersion:              "3.2.3",
+			features:                  []Feature{FeatureKRaft},
+			expectedIncompatibilities: []string{"KRaft requires Kafka >= 3.3.0, found 3.2.3"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			require.Equal(t, test.expectedIncompatibilities, CheckCompatibility(test.kafkaVersion, test.cruiseControlVersion, test.features))
+		})
+	}
+}
+
+func TestDetectedVersions(t *testing.T) {
+	cluster := &v1beta1.KafkaCluster{
+		Spec: v1beta1.KafkaClusterSpec{
+			CruiseControlConfig: v1beta1.CruiseControlConfig{Image: "adobe/cruise-control:2.5.138"},
+		},
+		Status: v1beta1.KafkaClusterStatus{
+			BrokersState: map[string]v1beta1.BrokerState{
+				"0": {Version: "4.0.0"},
+				"1": {Version: "3.10.0"},
+				"2": {Version: "3.9.1"},
+				"3": {},
+			},
+		},
+	}
+
+	require.Equal(t, &v1beta1.DetectedVersionsStatus{
+		Kafka:             []string{"3.9.1", "3.10.0", "4.0.0"},
+		CruiseControl:     "2.5.138",
+		Incompatibilities: []string{"Cruise Control 2.5.138 does not support Kafka 4.0.0"},
+	}, DetectedVersions(cluster))
+}
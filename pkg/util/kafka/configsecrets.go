@@ -0,0 +1,121 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	properties "github.com/banzaicloud/koperator/properties/pkg"
+)
+
+const (
+	// DirectoryConfigProviderClass is the class of the config provider which resolves the values from the files of a
+	// directory
+	DirectoryConfigProviderClass = "org.apache.kafka.common.config.provider.DirectoryConfigProvider"
+	// EnvVarConfigProviderClass is the class of the config provider which resolves the values from the environment
+	// variables of the broker
+	EnvVarConfigProviderClass = "org.apache.kafka.common.config.provider.EnvVarConfigProvider"
+
+	// SecretConfigProviderName is the name of the config provider resolving the ${secret:<secret name>:<key>}
+	// placeholders of the broker configuration from the mounted secrets
+	SecretConfigProviderName = "secret"
+	// EnvConfigProviderName is the name of the config provider resolving the ${env:<variable>} placeholders of the
+	// broker configuration from the environment variables of the broker
+	EnvConfigProviderName = "env"
+
+	// BrokerConfigSecretsPath is the directory the secrets referenced by the broker configuration are mounted under
+	BrokerConfigSecretsPath = "/var/run/secrets/kafka/config"
+)
+
+var (
+	secretPlaceholderRegex = regexp.MustCompile(`\$\{` + SecretConfigProviderName + `:([a-z0-9]([-a-z0-9.]*[a-z0-9])?):([-._a-zA-Z0-9]+)\}`)
+	envPlaceholderRegex    = regexp.MustCompile(`\$\{` + EnvConfigProviderName + `:[A-Za-z_][A-Za-z0-9_]*\}`)
+)
+
+// BrokerConfigSecretPath returns the directory the given secret referenced by the broker configuration is mounted to
+func BrokerConfigSecretPath(secretName string) string {
+	return fmt.Sprintf("%s/%s", BrokerConfigSecretsPath, secretName)
+}
+
+// BrokerConfigSecretNames returns the sorted names of the secrets referenced by the ${secret:<secret name>:<key>}
+// placeholders of the given broker configuration
+func BrokerConfigSecretNames(config *properties.Properties) []string {
+	var names []string
+	for _, key := range config.Keys() {
+		property, _ := config.Get(key)
+		for _, match := range secretPlaceholderRegex.FindAllStringSubmatch(property.Value(), -1) {
+			if !slices.Contains(names, match[1]) {
+				names = append(names, match[1])
+			}
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ResolveConfigPlaceholders prepares the secret and environment variable placeholders of the broker configuration to
+// be resolved by config providers when the broker starts, so the credentials are never rendered into the broker
+// ConfigMap. The ${secret:<secret name>:<key>} placeholders are rewritten to reference the directory the secret is
+// mounted to. The config providers are only registered when their placeholders are used.
+func ResolveConfigPlaceholders(config *properties.Properties, log logr.Logger) {
+	var secretUsed, envUsed bool
+	for _, key := range config.Keys() {
+		property, _ := config.Get(key)
+		value := property.Value()
+		envUsed = envUsed || envPlaceholderRegex.MatchString(value)
+		if !secretPlaceholderRegex.MatchString(value) {
+			continue
+		}
+		secretUsed = true
+		resolved := secretPlaceholderRegex.ReplaceAllString(value,
+			fmt.Sprintf("$${%s:%s/$1:$3}", SecretConfigProviderName, BrokerConfigSecretsPath))
+		if err := config.SetWithComment(key, resolved, property.Comment()); err != nil {
+			log.Error(err, fmt.Sprintf(BrokerConfigErrorMsgTemplate, key))
+		}
+	}
+	if secretUsed {
+		AddConfigProvider(config, SecretConfigProviderName, DirectoryConfigProviderClass, log)
+	}
+	if envUsed {
+		AddConfigProvider(config, EnvConfigProviderName, EnvVarConfigProviderClass, log)
+	}
+}
+
+// AddConfigProvider registers the config provider with the given name and class, the config providers already
+// registered in the configuration are kept
+func AddConfigProvider(config *properties.Properties, name, class string, log logr.Logger) {
+	var providers []string
+	if registered, found := config.Get(KafkaConfigProviders); found {
+		for _, provider := range strings.Split(registered.Value(), ",") {
+			if provider = strings.TrimSpace(provider); provider != "" && provider != name {
+				providers = append(providers, provider)
+			}
+		}
+	}
+	providers = append(providers, name)
+	if err := config.Set(KafkaConfigProviders, strings.Join(providers, ",")); err != nil {
+		log.Error(err, fmt.Sprintf(BrokerConfigErrorMsgTemplate, KafkaConfigProviders))
+	}
+	providerClassConfig := fmt.Sprintf(KafkaConfigProviderClassTemplate, name)
+	if err := config.Set(providerClassConfig, class); err != nil {
+		log.Error(err, fmt.Sprintf(BrokerConfigErrorMsgTemplate, providerClassConfig))
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+
+	properties "github.com/banzaicloud/koperator/properties/pkg"
+)
+
+func TestResolveConfigPlaceholders(t *testing.T) {
+	testCases := []struct {
+		testName            string
+		config              string
+		expectedConfig      string
+		expectedSecretNames []string
+	}{
+		{
+			testName:       "configuration without placeholders",
+			config:         "auto.create.topics.enable=false",
+			expectedConfig: "auto.create.topics.enable=false\n",
+		},
+		{
+			testName: "secret placeholders",
+			config: `listener.name.external.scram-sha-512.sasl.jaas.config=org.apache.kafka.common.security.scram.ScramLoginModule required username="${secret:broker-creds:username}" password="${secret:broker-creds:password}";
+ssl.keystore.password=${secret:keystore.pass:pass}`,
+			expectedConfig: `config.providers=secret
+config.providers.secret.class=org.apache.kafka.common.config.provider.DirectoryConfigProvider
+listener.name.external.scram-sha-512.sasl.jaas.config=org.apache.kafka.common.security.scram.ScramLoginModule required username="${secret:/var/run/secrets/kafka/config/broker-creds:username}" password="${secret:/var/run/secrets/kafka/config/broker-creds:password}";
+ssl.keystore.password=${secret:/var/run/secrets/kafka/config/keystore.pass:pass}
+`,
+			expectedSecretNames: []string{"broker-creds", "keystore.pass"},
+		},
+		{
+			testName: "environment variable placeholder with registered config provider",
+			config: `config.providers=koperator
+config.providers.koperator.class=org.apache.kafka.common.config.provider.DirectoryConfigProvider
+sasl.oauthbearer.client.secret=${env:OAUTH_CLIENT_SECRET}`,
+			expectedConfig: `config.providers=koperator,env
+config.providers.env.class=org.apache.kafka.common.config.provider.EnvVarConfigProvider
+config.providers.koperator.class=org.apache.kafka.common.config.provider.DirectoryConfigProvider
+sasl.oauthbearer.client.secret=${env:OAUTH_CLIENT_SECRET}
+`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			config, err := properties.NewFromString(test.config)
+			require.NoError(t, err)
+			require.Equal(t, test.expectedSecretNames, BrokerConfigSecretNames(config))
+
+			ResolveConfigPlaceholders(config, logr.Discard())
+			config.Sort()
+			require.Equal(t, test.expectedConfig, config.String())
+		})
+	}
+}
@@ -20,7 +20,9 @@ const ConfigPropertyName = "broker-config"
 
 // used for Kafka configurations
 const (
-	KafkaConfigSuperUsers = "super.users"
+	KafkaConfigSuperUsers                = "super.users"
+	KafkaConfigAuthorizerClassName       = "authorizer.class.name"
+	KafkaConfigAllowEveryoneIfNoACLFound = "allow.everyone.if.no.acl.found"
 
 	KafkaConfigBoostrapServers  = "bootstrap.servers"
 	KafkaConfigZooKeeperConnect = "zookeeper.connect"
@@ -50,12 +52,58 @@ const (
 	KafkaConfigSSLKeystoreType       = "ssl.keystore.type"
 	KafkaConfigSSLKeyStoreLocation   = "ssl.keystore.location"
 	KafkaConfigSSLKeyStorePassword   = "ssl.keystore.password"
+
+	KafkaConfigSASLEnabledMechanisms          = "sasl.enabled.mechanisms"
+	KafkaConfigSASLJAASConfig                 = "sasl.jaas.config"
+	KafkaConfigSASLServerCallbackHandlerClass = "sasl.server.callback.handler.class"
+	KafkaConfigSASLKerberosServiceName        = "sasl.kerberos.service.name"
+
+	KafkaConfigSASLMechanismInterBrokerProtocol = "sasl.mechanism.inter.broker.protocol"
+	KafkaConfigSASLMechanismControllerProtocol  = "sasl.mechanism.controller.protocol"
+
+	KafkaConfigSASLOAuthBearerJWKSEndpointURL  = "sasl.oauthbearer.jwks.endpoint.url"
+	KafkaConfigSASLOAuthBearerTokenEndpointURL = "sasl.oauthbearer.token.endpoint.url"
+	KafkaConfigSASLOAuthBearerExpectedAudience = "sasl.oauthbearer.expected.audience"
+	KafkaConfigSASLOAuthBearerExpectedIssuer   = "sasl.oauthbearer.expected.issuer"
+
+	KafkaConfigProviders = "config.providers"
+	// KafkaConfigProviderClassTemplate is the configuration key of the class of a config provider
+	KafkaConfigProviderClassTemplate = "config.providers.%s.class"
+
+	KafkaConfigPrincipalBuilderClass    = "principal.builder.class"
+	KafkaConfigSSLPrincipalMappingRules = "ssl.principal.mapping.rules"
+
+	KafkaConfigInterBrokerProtocolVersion = "inter.broker.protocol.version"
+	KafkaConfigLogMessageFormatVersion    = "log.message.format.version"
+
+	KafkaConfigOffsetsTopicReplicationFactor     = "offsets.topic.replication.factor"
+	KafkaConfigTransactionStateReplicationFactor = "transaction.state.log.replication.factor"
+	KafkaConfigTransactionStateMinISR            = "transaction.state.log.min.isr"
+)
+
+// used for the Kafka internal topics
+const (
+	OffsetsTopicName          = "__consumer_offsets"
+	TransactionStateTopicName = "__transaction_state"
+
+	TopicConfigMinInsyncReplicas = "min.insync.replicas"
+)
+
+// used for throttling the replication traffic of partition reassignments
+const (
+	TopicConfigLeaderReplicationThrottledReplicas   = "leader.replication.throttled.replicas"
+	TopicConfigFollowerReplicationThrottledReplicas = "follower.replication.throttled.replicas"
+
+	KafkaConfigLeaderReplicationThrottledRate   = "leader.replication.throttled.rate"
+	KafkaConfigFollowerReplicationThrottledRate = "follower.replication.throttled.rate"
 )
 
 // used for zk to kraft migration
 const (
 	MigrationBrokerControllerQuorumConfigEnabled = "migration.broker.controllerQuorumConfigEnabled"
 	MigrationBrokerKRaftMode                     = "migration.broker.kRaftMode"
+
+	KafkaConfigZooKeeperMetadataMigrationEnable = "zookeeper.metadata.migration.enable"
 )
 
 // used for Cruise Control configurations
@@ -65,6 +113,7 @@ const (
 	CruiseControlConfigMetricsReporterK8sMode            = "cruise.control.metrics.reporter.kubernetes.mode"
 	CruiseControlConfigTopicConfigProviderClass          = "topic.config.provider.class"
 	CruiseControlConfigKafkaBrokerFailureDetectionEnable = "kafka.broker.failure.detection.enable"
+	CruiseControlConfigSampleStoreTopicReplicationFactor = "sample.store.topic.replication.factor"
 
 	CruiseControlConfigMetricsReportersVal                  = "com.linkedin.kafka.cruisecontrol.metricsreporter.CruiseControlMetricsReporter"
 	CruiseControlConfigTopicConfigProviderClassVal          = "com.linkedin.kafka.cruisecontrol.config.KafkaAdminTopicConfigProvider"
@@ -0,0 +1,120 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	properties "github.com/banzaicloud/koperator/properties/pkg"
+)
+
+// maxInternalTopicReplicationFactor is the replication factor of the internal topics once the cluster is large enough
+const maxInternalTopicReplicationFactor = 3
+
+// InternalTopicReplicationFactorConfigs maps the internal topics to the broker configuration of their replication factor
+var InternalTopicReplicationFactorConfigs = map[string]string{
+	OffsetsTopicName:          KafkaConfigOffsetsTopicReplicationFactor,
+	TransactionStateTopicName: KafkaConfigTransactionStateReplicationFactor,
+}
+
+// BrokerNodeCount returns the number of nodes hosting partitions, controller-only nodes are not counted
+func BrokerNodeCount(kafkaClusterSpec v1beta1.KafkaClusterSpec) (int, error) {
+	count := 0
+	for _, broker := range kafkaClusterSpec.Brokers {
+		brokerConfig, err := broker.GetBrokerConfig(kafkaClusterSpec)
+		if err != nil {
+			return 0, err
+		}
+		if !brokerConfig.IsControllerOnlyNode() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// InternalTopicsReplicationDefaults returns the replication factor and the min ISR of the internal topics which are
+// safe for the given number of brokers
+func InternalTopicsReplicationDefaults(brokerCount int) (replicationFactor int, minISR int) {
+	replicationFactor = min(max(brokerCount, 1), maxInternalTopicReplicationFactor)
+	minISR = max(replicationFactor-1, 1)
+	return replicationFactor, minISR
+}
+
+// InternalTopicsReplicationConfig returns the replication related broker configurations of the internal topics,
+// the values set in the given configuration take precedence over the defaults derived from the number of brokers
+func InternalTopicsReplicationConfig(config *properties.Properties, brokerCount int) map[string]int {
+	replicationFactor, minISR := InternalTopicsReplicationDefaults(brokerCount)
+	replicationConfig := map[string]int{
+		KafkaConfigOffsetsTopicReplicationFactor:     replicationFactor,
+		KafkaConfigTransactionStateReplicationFactor: replicationFactor,
+		KafkaConfigTransactionStateMinISR:            minISR,
+	}
+	for key := range replicationConfig {
+		if value, ok := getIntProperty(config, key); ok {
+			replicationConfig[key] = value
+		}
+	}
+	return replicationConfig
+}
+
+// InternalTopicsReplicationWarnings returns the warnings about the replication settings of the internal topics in
+// the given configuration which are unsafe for the given number of brokers
+func InternalTopicsReplicationWarnings(config *properties.Properties, brokerCount int) []string {
+	var warnings []string
+	recommended, _ := InternalTopicsReplicationDefaults(brokerCount)
+	for _, key := range []string{KafkaConfigOffsetsTopicReplicationFactor, KafkaConfigTransactionStateReplicationFactor} {
+		value, ok := getIntProperty(config, key)
+		if !ok {
+			continue
+		}
+		switch {
+		case value > brokerCount:
+			warnings = append(warnings, fmt.Sprintf("%s=%d exceeds the number of brokers (%d), the topic cannot be created", key, value, brokerCount))
+		case value < recommended:
+			warnings = append(warnings, fmt.Sprintf("%s=%d is lower than %d recommended for %d brokers", key, value, recommended, brokerCount))
+		}
+	}
+
+	replicationConfig := InternalTopicsReplicationConfig(config, brokerCount)
+	replicationFactor := replicationConfig[KafkaConfigTransactionStateReplicationFactor]
+	if minISR, ok := getIntProperty(config, KafkaConfigTransactionStateMinISR); ok {
+		switch {
+		case minISR > replicationFactor:
+			warnings = append(warnings, fmt.Sprintf("%s=%d exceeds the replication factor (%d), transactions cannot be committed",
+				KafkaConfigTransactionStateMinISR, minISR, replicationFactor))
+		case minISR == replicationFactor && replicationFactor > 1:
+			warnings = append(warnings, fmt.Sprintf("%s=%d equals the replication factor, transactions are unavailable while any of the replicas is down",
+				KafkaConfigTransactionStateMinISR, minISR))
+		}
+	}
+	return warnings
+}
+
+func getIntProperty(config *properties.Properties, key string) (int, bool) {
+	if config == nil {
+		return 0, false
+	}
+	property, found := config.Get(key)
+	if !found {
+		return 0, false
+	}
+	value, err := property.Int()
+	if err != nil {
+		return 0, false
+	}
+	return int(value), true
+}
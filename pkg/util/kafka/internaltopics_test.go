@@ -0,0 +1,164 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	properties "github.com/banzaicloud/koperator/properties/pkg"
+)
+
+func TestBrokerNodeCount(t *testing.T) {
+	spec := v1beta1.KafkaClusterSpec{
+		BrokerConfigGroups: map[string]v1beta1.BrokerConfig{
+			"broker":     {Roles: []string{"broker"}},
+			"controller": {Roles: []string{"controller"}},
+			"combined":   {Roles: []string{"broker", "controller"}},
+		},
+		Brokers: []v1beta1.Broker{
+			{Id: 0, BrokerConfigGroup: "broker"},
+			{Id: 1, BrokerConfigGroup: "controller"},
+			{Id: 2, BrokerConfigGroup: "combined"},
+			{Id: 3, BrokerConfig: &v1beta1.BrokerConfig{}},
+		},
+	}
+	count, err := BrokerNodeCount(spec)
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+}
+
+func TestInternalTopicsReplicationDefaults(t *testing.T) {
+	testCases := []struct {
+		testName                  string
+		brokerCount               int
+		expectedReplicationFactor int
+		expectedMinISR            int
+	}{
+		{
+			testName:                  "no brokers",
+			brokerCount:               0,
+			expectedReplicationFactor: 1,
+			expectedMinISR:            1,
+		},
+		{
+			testName:                  "single broker",
+			brokerCount:               1,
+			expectedReplicationFactor: 1,
+			expectedMinISR:            1,
+		},
+		{
+			testName:                  "two brokers",
+			brokerCount:               2,
+			expectedReplicationFactor: 2,
+			expectedMinISR:            1,
+		},
+		{
+			testName:                  "three brokers",
+			brokerCount:               3,
+			expectedReplicationFactor: 3,
+			expectedMinISR:            2,
+		},
+		{
+			testName:                  "replication factor is capped",
+			brokerCount:               12,
+			expectedReplicationFactor: 3,
+			expectedMinISR:            2,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			replicationFactor, minISR := InternalTopicsReplicationDefaults(test.brokerCount)
+			require.Equal(t, test.expectedReplicationFactor, replicationFactor)
+			require.Equal(t, test.expectedMinISR, minISR)
+		})
+	}
+}
+
+func TestInternalTopicsReplicationConfig(t *testing.T) {
+	config, err := properties.NewFromString("transaction.state.log.min.isr=1")
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]int{
+		KafkaConfigOffsetsTopicReplicationFactor:     3,
+		KafkaConfigTransactionStateReplicationFactor: 3,
+		KafkaConfigTransactionStateMinISR:            1,
+	}, InternalTopicsReplicationConfig(config, 5))
+}
+
+func TestInternalTopicsReplicationWarnings(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		config           string
+		brokerCount      int
+		expectedWarnings []string
+	}{
+		{
+			testName:    "defaults",
+			config:      "",
+			brokerCount: 3,
+		},
+		{
+			testName:    "safe overrides",
+			config:      "offsets.topic.replication.factor=4\ntransaction.state.log.replication.factor=3\ntransaction.state.log.min.isr=2",
+			brokerCount: 6,
+		},
+		{
+			testName:    "replication factor exceeds the brokers",
+			config:      "offsets.topic.replication.factor=3",
+			brokerCount: 2,
+			expectedWarnings: []string{
+				"offsets.topic.replication.factor=3 exceeds the number of brokers (2), the topic cannot be created",
+			},
+		},
+		{
+			testName:    "replication factor lower than recommended",
+			config:      "offsets.topic.replication.factor=1\ntransaction.state.log.replication.factor=2",
+			brokerCount: 5,
+			expectedWarnings: []string{
+				"offsets.topic.replication.factor=1 is lower than 3 recommended for 5 brokers",
+				"transaction.state.log.replication.factor=2 is lower than 3 recommended for 5 brokers",
+			},
+		},
+		{
+			testName:    "min ISR equals the replication factor",
+			config:      "transaction.state.log.min.isr=3",
+			brokerCount: 3,
+			expectedWarnings: []string{
+				"transaction.state.log.min.isr=3 equals the replication factor, transactions are unavailable while any of the replicas is down",
+			},
+		},
+		{
+			testName:    "min ISR exceeds the replication factor",
+			config:      "transaction.state.log.replication.factor=3\ntransaction.state.log.min.isr=4",
+			brokerCount: 3,
+			expectedWarnings: []string{
+				"transaction.state.log.min.isr=4 exceeds the replication factor (3), transactions cannot be committed",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			config, err := properties.NewFromString(test.config)
+			require.NoError(t, err)
+			require.Equal(t, test.expectedWarnings, InternalTopicsReplicationWarnings(config, test.brokerCount))
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	properties "github.com/banzaicloud/koperator/properties/pkg"
+)
+
+const (
+	// KeystoreConfigProviderName is the name of the config provider which resolves the keystore passwords from the
+	// mounted secrets, so they are never rendered into configurations
+	KeystoreConfigProviderName = "koperator"
+	// KeystoreConfigProviderClass is the class of the config provider which resolves the keystore passwords
+	KeystoreConfigProviderClass = DirectoryConfigProviderClass
+)
+
+// KeystorePasswordReference returns the config provider reference of the password file in the given directory
+func KeystorePasswordReference(directory string) string {
+	return fmt.Sprintf("${%s:%s:%s}", KeystoreConfigProviderName, directory, v1alpha1.PasswordKey)
+}
+
+// ConfigureKeystoreConfigProvider registers the config provider resolving the keystore password references
+func ConfigureKeystoreConfigProvider(config *properties.Properties, log logr.Logger) {
+	AddConfigProvider(config, KeystoreConfigProviderName, KeystoreConfigProviderClass, log)
+}
@@ -0,0 +1,133 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+const sslPrincipalMappingDefaultRule = "DEFAULT"
+
+var (
+	// sslPrincipalMappingRuleParser follows the rule format accepted by Kafka's SslPrincipalMapper
+	sslPrincipalMappingRuleParser = regexp.MustCompile(`^\s*(?:(DEFAULT)|RULE:((?:\\.|[^\\/])*)/((?:\\.|[^\\/])*)/([LU]?))\s*$`)
+	// javaGroupReference matches the $n group references of a Java regular expression replacement
+	javaGroupReference = regexp.MustCompile(`\$(\d+)`)
+)
+
+// sslPrincipalMappingRule is a parsed ssl.principal.mapping.rules entry
+type sslPrincipalMappingRule struct {
+	isDefault   bool
+	pattern     *regexp.Regexp
+	fullMatch   *regexp.Regexp
+	replacement string
+	toLowerCase bool
+	toUpperCase bool
+}
+
+// apply returns the mapped principal name and true when the rule matches the distinguished name
+func (r sslPrincipalMappingRule) apply(dn string) (string, bool) {
+	if r.isDefault {
+		return dn, true
+	}
+	if !r.fullMatch.MatchString(dn) {
+		return "", false
+	}
+	result := r.pattern.ReplaceAllString(dn, r.replacement)
+	switch {
+	case r.toLowerCase:
+		result = strings.ToLower(result)
+	case r.toUpperCase:
+		result = strings.ToUpper(result)
+	}
+	return result, true
+}
+
+// SSLPrincipalMapper maps the distinguished name of SSL client certificates to principal names
+// the same way as the Kafka brokers do when ssl.principal.mapping.rules is configured
+type SSLPrincipalMapper struct {
+	rules []sslPrincipalMappingRule
+}
+
+// NewSSLPrincipalMapper parses the given SSL principal mapping rules. When no rules are given the
+// returned mapper behaves as the "DEFAULT" rule and returns the distinguished name as is.
+func NewSSLPrincipalMapper(rules []string) (*SSLPrincipalMapper, error) {
+	if len(rules) == 0 {
+		rules = []string{sslPrincipalMappingDefaultRule}
+	}
+	mapper := &SSLPrincipalMapper{}
+	for _, rule := range rules {
+		parsedRule, err := parseSSLPrincipalMappingRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		mapper.rules = append(mapper.rules, parsedRule)
+	}
+	return mapper, nil
+}
+
+func parseSSLPrincipalMappingRule(rule string) (sslPrincipalMappingRule, error) {
+	groups := sslPrincipalMappingRuleParser.FindStringSubmatch(rule)
+	if groups == nil {
+		return sslPrincipalMappingRule{}, errors.NewWithDetails("SSL principal mapping rule must be DEFAULT or have the RULE:pattern/replacement/[LU] format", "rule", rule)
+	}
+	if groups[1] != "" {
+		return sslPrincipalMappingRule{isDefault: true}, nil
+	}
+	pattern, err := regexp.Compile(groups[2])
+	if err != nil {
+		return sslPrincipalMappingRule{}, errors.WrapIfWithDetails(err, "invalid pattern in SSL principal mapping rule", "rule", rule)
+	}
+	fullMatch, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", groups[2]))
+	if err != nil {
+		return sslPrincipalMappingRule{}, errors.WrapIfWithDetails(err, "invalid pattern in SSL principal mapping rule", "rule", rule)
+	}
+	return sslPrincipalMappingRule{
+		pattern:   pattern,
+		fullMatch: fullMatch,
+		// Java replacements may reference groups as $1 followed by any character while Go needs ${1}
+		replacement: javaGroupReference.ReplaceAllString(groups[3], "$${$1}"),
+		toLowerCase: groups[4] == "L",
+		toUpperCase: groups[4] == "U",
+	}, nil
+}
+
+// GetName returns the principal name of the given distinguished name using the first rule that matches it.
+// An error is returned when none of the rules match, in this case Kafka brokers reject the client.
+func (m *SSLPrincipalMapper) GetName(dn string) (string, error) {
+	for _, rule := range m.rules {
+		if name, ok := rule.apply(dn); ok {
+			return name, nil
+		}
+	}
+	return "", errors.NewWithDetails("no SSL principal mapping rule applies to the distinguished name", "distinguishedName", dn)
+}
+
+// GetPrincipalNameForDistinguishedName returns the principal name that the brokers of the given cluster
+// resolve for an SSL client certificate with the given distinguished name
+func GetPrincipalNameForDistinguishedName(cluster *v1beta1.KafkaCluster, dn string) (string, error) {
+	mapper, err := NewSSLPrincipalMapper(cluster.Spec.PrincipalConfig.GetSSLPrincipalMappingRules())
+	if err != nil {
+		return "", err
+	}
+	return mapper.GetName(dn)
+}
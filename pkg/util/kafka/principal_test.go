@@ -0,0 +1,110 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSLPrincipalMapper(t *testing.T) {
+	testCases := []struct {
+		testName      string
+		rules         []string
+		dn            string
+		expectedName  string
+		expectedError bool
+	}{
+		{
+			testName:     "no rules returns the distinguished name",
+			dn:           "CN=kafka-user,OU=ServiceUsers,O=Org",
+			expectedName: "CN=kafka-user,OU=ServiceUsers,O=Org",
+		},
+		{
+			testName:     "default rule returns the distinguished name",
+			rules:        []string{"DEFAULT"},
+			dn:           "CN=kafka-user,OU=ServiceUsers,O=Org",
+			expectedName: "CN=kafka-user,OU=ServiceUsers,O=Org",
+		},
+		{
+			testName:     "first matching rule is used",
+			rules:        []string{"RULE:^CN=(.*?),OU=Admins.*$/admin-$1/", "RULE:^CN=(.*?),OU=ServiceUsers.*$/$1/", "DEFAULT"},
+			dn:           "CN=kafka-user,OU=ServiceUsers,O=Org",
+			expectedName: "kafka-user",
+		},
+		{
+			testName:     "group reference followed by a word character",
+			rules:        []string{"RULE:^CN=(.*?),OU=(.*?),O=(.*?)$/$1@$2x/"},
+			dn:           "CN=kafka-user,OU=ServiceUsers,O=Org",
+			expectedName: "kafka-user@ServiceUsersx",
+		},
+		{
+			testName:     "lower case flag",
+			rules:        []string{"RULE:^CN=([^,]+).*$/$1/L"},
+			dn:           "CN=Kafka-User,OU=ServiceUsers",
+			expectedName: "kafka-user",
+		},
+		{
+			testName:     "upper case flag",
+			rules:        []string{"RULE:^CN=([^,]+).*$/$1/U"},
+			dn:           "CN=Kafka-User,OU=ServiceUsers",
+			expectedName: "KAFKA-USER",
+		},
+		{
+			testName:     "escaped slash in the pattern",
+			rules:        []string{`RULE:^CN=([^,]+),OU=a\/b$/$1/`},
+			dn:           "CN=kafka-user,OU=a/b",
+			expectedName: "kafka-user",
+		},
+		{
+			testName:      "no rule applies",
+			rules:         []string{"RULE:^CN=(.*?),OU=Admins$/$1/"},
+			dn:            "CN=kafka-user,OU=ServiceUsers",
+			expectedError: true,
+		},
+		{
+			testName:      "invalid rule format",
+			rules:         []string{"RULE:^CN=(.*?)$/$1"},
+			dn:            "CN=kafka-user",
+			expectedError: true,
+		},
+		{
+			testName:      "invalid rule pattern",
+			rules:         []string{"RULE:^CN=(.*?$/$1/"},
+			dn:            "CN=kafka-user",
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		test := testCase
+		t.Run(test.testName, func(t *testing.T) {
+			mapper, err := NewSSLPrincipalMapper(test.rules)
+			if err == nil {
+				var name string
+				name, err = mapper.GetName(test.dn)
+				if !test.expectedError {
+					require.NoError(t, err)
+					require.Equal(t, test.expectedName, name)
+				}
+			}
+			if test.expectedError {
+				require.Error(t, err)
+			}
+		})
+	}
+}
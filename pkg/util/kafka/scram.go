@@ -0,0 +1,48 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+)
+
+const scramJAASConfigTemplate = `org.apache.kafka.common.security.scram.ScramLoginModule required username="%s" password="%s";`
+
+// jaasValueEscaper escapes the characters which would terminate a quoted JAAS option value
+var jaasValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// SCRAMSASLMechanism returns the value of the sasl.mechanism client property for the given SCRAM authentication type
+func SCRAMSASLMechanism(authType v1alpha1.UserAuthenticationType) string {
+	return strings.ToUpper(string(authType))
+}
+
+// SCRAMJAASConfig returns the value of the sasl.jaas.config client property for the given SCRAM credentials
+func SCRAMJAASConfig(username, password string) string {
+	return fmt.Sprintf(scramJAASConfigTemplate, jaasValueEscaper.Replace(username), jaasValueEscaper.Replace(password))
+}
+
+// SCRAMUserSecretData returns the data of the KafkaUser secret exposing the SCRAM credentials to the clients
+func SCRAMUserSecretData(authType v1alpha1.UserAuthenticationType, username string, password []byte) map[string][]byte {
+	return map[string][]byte{
+		v1alpha1.SCRAMUsernameKey:  []byte(username),
+		v1alpha1.SCRAMPasswordKey:  password,
+		v1alpha1.SASLMechanismKey:  []byte(SCRAMSASLMechanism(authType)),
+		v1alpha1.SASLJAASConfigKey: []byte(SCRAMJAASConfig(username, string(password))),
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+)
+
+func TestSCRAMUserSecretData(t *testing.T) {
+	testCases := []struct {
+		testName          string
+		authType          v1alpha1.UserAuthenticationType
+		username          string
+		password          string
+		expectedMechanism string
+		expectedJAAS      string
+	}{
+		{
+			testName:          "scram-sha-512",
+			authType:          v1alpha1.UserAuthenticationTypeSCRAMSHA512,
+			username:          "test-user",
+			password:          "secret",
+			expectedMechanism: "SCRAM-SHA-512",
+			expectedJAAS:      `org.apache.kafka.common.security.scram.ScramLoginModule required username="test-user" password="secret";`,
+		},
+		{
+			testName:          "scram-sha-256 with characters to escape",
+			authType:          v1alpha1.UserAuthenticationTypeSCRAMSHA256,
+			username:          "test-user",
+			password:          `se"cr\et`,
+			expectedMechanism: "SCRAM-SHA-256",
+			expectedJAAS:      `org.apache.kafka.common.security.scram.ScramLoginModule required username="test-user" password="se\"cr\\et";`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			data := SCRAMUserSecretData(testCase.authType, testCase.username, []byte(testCase.password))
+			require.Equal(t, testCase.username, string(data[v1alpha1.SCRAMUsernameKey]))
+			require.Equal(t, testCase.password, string(data[v1alpha1.SCRAMPasswordKey]))
+			require.Equal(t, testCase.expectedMechanism, string(data[v1alpha1.SASLMechanismKey]))
+			require.Equal(t, testCase.expectedJAAS, string(data[v1alpha1.SASLJAASConfigKey]))
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+// storageMigrationMountPathSuffix is appended to the mount path of a volume moved to a new StorageClass to get the
+// mount path of the new volume while both volumes are attached to the broker
+const storageMigrationMountPathSuffix = "-migration"
+
+// StorageMigrationMountPath returns the mount path the new volume of the migration of the volume at the given mount
+// path is attached at until it replaces the old volume
+func StorageMigrationMountPath(mountPath string) string {
+	return mountPath + storageMigrationMountPathSuffix
+}
+
+// StorageConfigsWithMigrations returns the storage configs of a broker with the volumes of the storage migrations in
+// progress. The new volume is added next to the old one until the replicas are rebalanced onto it, then it takes the
+// place of the old one while the old one is drained and removed.
+func StorageConfigsWithMigrations(storageConfigs []v1beta1.StorageConfig, migrations v1beta1.StorageMigrationStates) []v1beta1.StorageConfig {
+	if len(migrations) == 0 {
+		return storageConfigs
+	}
+	result := make([]v1beta1.StorageConfig, 0, len(storageConfigs)+len(migrations))
+	for _, storage := range storageConfigs {
+		migration, ok := migrations[storage.MountPath]
+		if !ok {
+			result = append(result, storage)
+			continue
+		}
+		newVolume := storage
+		newVolume.MountPath = StorageMigrationMountPath(storage.MountPath)
+		switch migration.Phase {
+		case v1beta1.StorageMigrationAddingVolume:
+			result = append(result, storage, newVolume)
+		case v1beta1.StorageMigrationDrainingVolume:
+			result = append(result, newVolume)
+		default:
+			result = append(result, storage)
+		}
+	}
+	return result
+}
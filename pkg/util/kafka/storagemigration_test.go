@@ -0,0 +1,73 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func TestStorageConfigsWithMigrations(t *testing.T) {
+	gp3 := "gp3"
+	pvcSpec := &corev1.PersistentVolumeClaimSpec{StorageClassName: &gp3}
+	storageConfigs := []v1beta1.StorageConfig{
+		{MountPath: "/kafka-logs", PvcSpec: pvcSpec},
+		{MountPath: "/kafka-logs2", PvcSpec: pvcSpec},
+	}
+
+	testCases := []struct {
+		testName   string
+		migrations v1beta1.StorageMigrationStates
+		expected   []v1beta1.StorageConfig
+	}{
+		{
+			testName: "no migrations",
+			expected: storageConfigs,
+		},
+		{
+			testName:   "new volume is added next to the old one",
+			migrations: v1beta1.StorageMigrationStates{"/kafka-logs": {Phase: v1beta1.StorageMigrationAddingVolume}},
+			expected: []v1beta1.StorageConfig{
+				{MountPath: "/kafka-logs", PvcSpec: pvcSpec},
+				{MountPath: "/kafka-logs-migration", PvcSpec: pvcSpec},
+				{MountPath: "/kafka-logs2", PvcSpec: pvcSpec},
+			},
+		},
+		{
+			testName:   "new volume replaces the drained one",
+			migrations: v1beta1.StorageMigrationStates{"/kafka-logs2": {Phase: v1beta1.StorageMigrationDrainingVolume}},
+			expected: []v1beta1.StorageConfig{
+				{MountPath: "/kafka-logs", PvcSpec: pvcSpec},
+				{MountPath: "/kafka-logs2-migration", PvcSpec: pvcSpec},
+			},
+		},
+		{
+			testName:   "new volume is mounted at the mount path of the old one",
+			migrations: v1beta1.StorageMigrationStates{"/kafka-logs": {Phase: v1beta1.StorageMigrationSwappingMounts}},
+			expected:   storageConfigs,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			require.Equal(t, test.expected, StorageConfigsWithMigrations(storageConfigs, test.migrations))
+		})
+	}
+}
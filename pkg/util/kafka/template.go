@@ -24,6 +24,10 @@ const (
 	HeadlessControllerServiceTemplate = "%s-controller-headless"
 	// NodePortServiceTemplate template for Kafka nodeport service
 	NodePortServiceTemplate = "%s-%d-%s"
+	// PerBrokerLoadBalancerServiceTemplate template for the LoadBalancer service of a Kafka broker
+	PerBrokerLoadBalancerServiceTemplate = "%s-%d-%s-lb"
+	// DiskPlacementPlanConfigMapTemplate template for the ConfigMap holding the disk placement reassignment plan
+	DiskPlacementPlanConfigMapTemplate = "%s-disk-placement-plan"
 
 	BrokerConfigErrorMsgTemplate = "setting '%s' in broker configuration resulted in an error"
 )
@@ -0,0 +1,112 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+const (
+	KafkaConfigRemoteLogStorageManagerClassName     = "remote.log.storage.manager.class.name"
+	KafkaConfigRemoteLogStorageManagerClassPath     = "remote.log.storage.manager.class.path"
+	KafkaConfigRemoteLogMetadataManagerListenerName = "remote.log.metadata.manager.listener.name"
+	KafkaConfigRemoteStorageManagerConfigPrefix     = "rsm.config."
+	KafkaConfigRemoteLogMetadataManagerConfigPrefix = "rlmm.config."
+
+	// TopicConfigRemoteStorageEnable enables the tiered storage of a topic
+	TopicConfigRemoteStorageEnable = "remote.storage.enable"
+	// TopicConfigCleanupPolicy is the cleanup policy of a topic, the compacted topics can not use tiered storage
+	TopicConfigCleanupPolicy = "cleanup.policy"
+
+	// TieredStoragePluginPath is the directory the jars of the RemoteStorageManager plugin are copied to in the broker pods
+	TieredStoragePluginPath = "/opt/kafka/libs/tiered-storage"
+
+	// the configurations of the S3 and GCS storage backends of the Aiven tiered storage plugin
+	tieredStorageBackendClass          = "storage.backend.class"
+	tieredStorageS3BackendClass        = "io.aiven.kafka.tieredstorage.storage.s3.S3Storage"
+	tieredStorageS3BucketName          = "storage.s3.bucket.name"
+	tieredStorageS3Region              = "storage.s3.region"
+	tieredStorageS3EndpointURL         = "storage.s3.endpoint.url"
+	tieredStorageS3AccessKeyID         = "storage.aws.access.key.id"
+	tieredStorageS3SecretAccessKey     = "storage.aws.secret.access.key"
+	tieredStorageGCSBackendClass       = "io.aiven.kafka.tieredstorage.storage.gcs.GcsStorage"
+	tieredStorageGCSBucketName         = "storage.gcs.bucket.name"
+	tieredStorageGCSCredentialsJSON    = "storage.gcs.credentials.json"
+	tieredStorageGCSCredentialsDefault = "storage.gcs.credentials.default"
+)
+
+// TieredStorageConfigs returns the broker configuration of the given tiered storage. The RemoteLogMetadataManager
+// connects to the brokers through the given listener. The credentials of the storage backends are referenced with
+// ${secret:<secret name>:<key>} placeholders, which are resolved by the config provider when the broker starts.
+func TieredStorageConfigs(tieredStorage *v1beta1.TieredStorageConfig, listenerName string) map[string]string {
+	configs := map[string]string{
+		KafkaConfigRemoteLogStorageSystemEnable:     "true",
+		KafkaConfigRemoteLogStorageManagerClassName: tieredStorage.RemoteStorageManagerClassName,
+	}
+	if classPath := tieredStorage.RemoteStorageManagerClassPath; classPath != "" {
+		configs[KafkaConfigRemoteLogStorageManagerClassPath] = classPath
+	} else if tieredStorage.PluginImage != "" {
+		configs[KafkaConfigRemoteLogStorageManagerClassPath] = TieredStoragePluginPath + "/*"
+	}
+	if listenerName != "" {
+		configs[KafkaConfigRemoteLogMetadataManagerListenerName] = listenerName
+	}
+
+	rsmConfigs := make(map[string]string)
+	if s3 := tieredStorage.S3; s3 != nil {
+		rsmConfigs[tieredStorageBackendClass] = tieredStorageS3BackendClass
+		rsmConfigs[tieredStorageS3BucketName] = s3.Bucket
+		rsmConfigs[tieredStorageS3Region] = s3.Region
+		if s3.Endpoint != "" {
+			rsmConfigs[tieredStorageS3EndpointURL] = s3.Endpoint
+		}
+		if s3.AccessKeyIDSecretRef != nil {
+			rsmConfigs[tieredStorageS3AccessKeyID] = secretPlaceholder(s3.AccessKeyIDSecretRef)
+		}
+		if s3.SecretAccessKeySecretRef != nil {
+			rsmConfigs[tieredStorageS3SecretAccessKey] = secretPlaceholder(s3.SecretAccessKeySecretRef)
+		}
+	}
+	if gcs := tieredStorage.GCS; gcs != nil {
+		rsmConfigs[tieredStorageBackendClass] = tieredStorageGCSBackendClass
+		rsmConfigs[tieredStorageGCSBucketName] = gcs.Bucket
+		if gcs.CredentialsSecretRef != nil {
+			rsmConfigs[tieredStorageGCSCredentialsJSON] = secretPlaceholder(gcs.CredentialsSecretRef)
+		} else {
+			rsmConfigs[tieredStorageGCSCredentialsDefault] = "true"
+		}
+	}
+	// the configuration of the plugin given explicitly takes precedence over the one of the storage backend
+	for key, value := range tieredStorage.RemoteStorageManagerConfig {
+		rsmConfigs[key] = value
+	}
+	for key, value := range rsmConfigs {
+		configs[KafkaConfigRemoteStorageManagerConfigPrefix+key] = value
+	}
+	for key, value := range tieredStorage.RemoteLogMetadataManagerConfig {
+		configs[KafkaConfigRemoteLogMetadataManagerConfigPrefix+key] = value
+	}
+	return configs
+}
+
+// secretPlaceholder returns the placeholder of the broker configuration referencing the given key of a secret
+func secretPlaceholder(selector *corev1.SecretKeySelector) string {
+	return fmt.Sprintf("${%s:%s:%s}", SecretConfigProviderName, selector.Name, selector.Key)
+}
@@ -0,0 +1,128 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func TestTieredStorageConfigs(t *testing.T) {
+	secretKey := func(name, key string) *corev1.SecretKeySelector {
+		return &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: key}
+	}
+	const rsmClass = "io.aiven.kafka.tieredstorage.RemoteStorageManager"
+
+	testCases := []struct {
+		testName      string
+		tieredStorage v1beta1.TieredStorageConfig
+		listenerName  string
+		expected      map[string]string
+	}{
+		{
+			testName:      "remote storage manager class only",
+			tieredStorage: v1beta1.TieredStorageConfig{RemoteStorageManagerClassName: rsmClass},
+			expected: map[string]string{
+				"remote.log.storage.system.enable":      "true",
+				"remote.log.storage.manager.class.name": rsmClass,
+			},
+		},
+		{
+			testName: "S3 with access keys and plugin image",
+			tieredStorage: v1beta1.TieredStorageConfig{
+				RemoteStorageManagerClassName: rsmClass,
+				PluginImage:                   "aiven/tiered-storage:1.0.0",
+				S3: &v1beta1.TieredStorageS3Config{
+					Bucket:                   "kafka-segments",
+					Region:                   "eu-west-1",
+					Endpoint:                 "http://minio:9000",
+					AccessKeyIDSecretRef:     secretKey("s3-credentials", "access-key-id"),
+					SecretAccessKeySecretRef: secretKey("s3-credentials", "secret-access-key"),
+				},
+				RemoteStorageManagerConfig:     map[string]string{"chunk.size": "4194304"},
+				RemoteLogMetadataManagerConfig: map[string]string{"remote.log.metadata.topic.replication.factor": "3"},
+			},
+			listenerName: "INTERNAL",
+			expected: map[string]string{
+				"remote.log.storage.system.enable":                         "true",
+				"remote.log.storage.manager.class.name":                    rsmClass,
+				"remote.log.storage.manager.class.path":                    "/opt/kafka/libs/tiered-storage/*",
+				"remote.log.metadata.manager.listener.name":                "INTERNAL",
+				"rsm.config.storage.backend.class":                         "io.aiven.kafka.tieredstorage.storage.s3.S3Storage",
+				"rsm.config.storage.s3.bucket.name":                        "kafka-segments",
+				"rsm.config.storage.s3.region":                             "eu-west-1",
+				"rsm.config.storage.s3.endpoint.url":                       "http://minio:9000",
+				"rsm.config.storage.aws.access.key.id":                     "${secret:s3-credentials:access-key-id}",
+				"rsm.config.storage.aws.secret.access.key":                 "${secret:s3-credentials:secret-access-key}",
+				"rsm.config.chunk.size":                                    "4194304",
+				"rlmm.config.remote.log.metadata.topic.replication.factor": "3",
+			},
+		},
+		{
+			testName: "GCS with default credentials and explicit class path",
+			tieredStorage: v1beta1.TieredStorageConfig{
+				RemoteStorageManagerClassName: rsmClass,
+				RemoteStorageManagerClassPath: "/opt/plugins/*",
+				PluginImage:                   "aiven/tiered-storage:1.0.0",
+				GCS:                           &v1beta1.TieredStorageGCSConfig{Bucket: "kafka-segments"},
+			},
+			expected: map[string]string{
+				"remote.log.storage.system.enable":           "true",
+				"remote.log.storage.manager.class.name":      rsmClass,
+				"remote.log.storage.manager.class.path":      "/opt/plugins/*",
+				"rsm.config.storage.backend.class":           "io.aiven.kafka.tieredstorage.storage.gcs.GcsStorage",
+				"rsm.config.storage.gcs.bucket.name":         "kafka-segments",
+				"rsm.config.storage.gcs.credentials.default": "true",
+			},
+		},
+		{
+			testName: "GCS credentials and overridden plugin config",
+			tieredStorage: v1beta1.TieredStorageConfig{
+				RemoteStorageManagerClassName: rsmClass,
+				GCS: &v1beta1.TieredStorageGCSConfig{Bucket: "kafka-segments",
+					CredentialsSecretRef: secretKey("gcs-credentials", "credentials.json")},
+				RemoteStorageManagerConfig: map[string]string{"storage.gcs.bucket.name": "other-bucket"},
+			},
+			expected: map[string]string{
+				"remote.log.storage.system.enable":        "true",
+				"remote.log.storage.manager.class.name":   rsmClass,
+				"rsm.config.storage.backend.class":        "io.aiven.kafka.tieredstorage.storage.gcs.GcsStorage",
+				"rsm.config.storage.gcs.bucket.name":      "other-bucket",
+				"rsm.config.storage.gcs.credentials.json": "${secret:gcs-credentials:credentials.json}",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			require.Equal(t, test.expected, TieredStorageConfigs(&test.tieredStorage, test.listenerName))
+		})
+	}
+}
+
+func TestEnabledFeaturesTieredStorage(t *testing.T) {
+	require.Equal(t, []Feature{FeatureTieredStorage}, EnabledFeatures(v1beta1.KafkaClusterSpec{
+		TieredStorage: &v1beta1.TieredStorageConfig{RemoteStorageManagerClassName: "RemoteStorageManager"},
+	}))
+	require.Equal(t, []Feature{FeatureTieredStorage}, EnabledFeatures(v1beta1.KafkaClusterSpec{
+		ReadOnlyConfig: "remote.log.storage.system.enable=true",
+	}))
+	require.Empty(t, EnabledFeatures(v1beta1.KafkaClusterSpec{}))
+}
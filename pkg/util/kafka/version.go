@@ -0,0 +1,103 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/IBM/sarama"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+var (
+	// KRaftOnlyMinVersion is the first Kafka version which removed ZooKeeper support entirely
+	KRaftOnlyMinVersion = sarama.V4_0_0_0
+	// KRaftOnlyUpgradeMinVersion is the oldest Kafka version a KRaft cluster can be upgraded to Kafka 4.x from
+	KRaftOnlyUpgradeMinVersion = sarama.V3_3_0_0
+
+	// KRaftOnlyRemovedConfigs are the broker configurations removed together with ZooKeeper, the inter broker
+	// protocol is driven by metadata.version instead
+	KRaftOnlyRemovedConfigs = []string{
+		KafkaConfigInterBrokerProtocolVersion,
+		KafkaConfigLogMessageFormatVersion,
+	}
+
+	imageTagVersionRegex = regexp.MustCompile(`\d+\.\d+\.\d+`)
+)
+
+// KafkaVersionFromImage returns the Kafka version held by the tag of the given image, e.g. 3.9.1 for
+// ghcr.io/adobe/koperator/kafka:2.13-3.9.1. It returns false if the tag does not hold a Kafka version.
+func KafkaVersionFromImage(image string) (sarama.KafkaVersion, bool) {
+	tag, ok := imageTag(image)
+	if !ok {
+		return sarama.KafkaVersion{}, false
+	}
+	// the Scala version precedes the Kafka version in the tag, the last match is the Kafka version
+	matches := imageTagVersionRegex.FindAllString(tag, -1)
+	if len(matches) == 0 {
+		return sarama.KafkaVersion{}, false
+	}
+	version, err := sarama.ParseKafkaVersion(matches[len(matches)-1])
+	if err != nil {
+		return sarama.KafkaVersion{}, false
+	}
+	return version, true
+}
+
+// imageTag returns the tag of the given image, the digest of the image is ignored
+func imageTag(image string) (string, bool) {
+	image, _, _ = strings.Cut(image, "@")
+	tagIndex := strings.LastIndex(image, ":")
+	if tagIndex < 0 || tagIndex < strings.LastIndex(image, "/") {
+		return "", false
+	}
+	return image[tagIndex+1:], true
+}
+
+// BrokerKafkaVersion returns the Kafka version of the image the given broker config runs
+func BrokerKafkaVersion(brokerConfig *v1beta1.BrokerConfig, kafkaClusterSpec v1beta1.KafkaClusterSpec) (sarama.KafkaVersion, bool) {
+	image := kafkaClusterSpec.GetClusterImage()
+	if brokerConfig != nil && brokerConfig.Image != "" {
+		image = brokerConfig.Image
+	}
+	return KafkaVersionFromImage(image)
+}
+
+// IsKRaftOnlyVersion returns true if the given Kafka version can only run in KRaft mode
+func IsKRaftOnlyVersion(version sarama.KafkaVersion) bool {
+	return version.IsAtLeast(KRaftOnlyMinVersion)
+}
+
+// CheckKRaftOnlyUpgrade returns an error if a broker running the current version can not be upgraded to the desired
+// one. Upgrading to Kafka 4.x requires the cluster to run KRaft with at least Kafka 3.3, unknown current versions are
+// not checked.
+func CheckKRaftOnlyUpgrade(currentVersion string, desiredVersion sarama.KafkaVersion) error {
+	if !IsKRaftOnlyVersion(desiredVersion) || currentVersion == "" {
+		return nil
+	}
+	current, err := sarama.ParseKafkaVersion(currentVersion)
+	if err != nil {
+		return nil
+	}
+	if !current.IsAtLeast(KRaftOnlyUpgradeMinVersion) {
+		return fmt.Errorf("upgrading from Kafka %s to %s is not supported, upgrade to Kafka %s or later first",
+			current, desiredVersion, KRaftOnlyUpgradeMinVersion)
+	}
+	return nil
+}
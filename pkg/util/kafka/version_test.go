@@ -0,0 +1,111 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKafkaVersionFromImage(t *testing.T) {
+	testCases := []struct {
+		testName        string
+		image           string
+		expectedVersion string
+		expectedFound   bool
+	}{
+		{
+			testName:        "scala and kafka version tag",
+			image:           "ghcr.io/adobe/koperator/kafka:2.13-3.9.1",
+			expectedVersion: "3.9.1",
+			expectedFound:   true,
+		},
+		{
+			testName:        "kafka version tag",
+			image:           "apache/kafka:4.0.0",
+			expectedVersion: "4.0.0",
+			expectedFound:   true,
+		},
+		{
+			testName:        "registry with port and digest",
+			image:           "registry.local:5000/kafka:2.13-4.1.0@sha256:0123456789abcdef",
+			expectedVersion: "4.1.0",
+			expectedFound:   true,
+		},
+		{
+			testName: "registry with port without tag",
+			image:    "registry.local:5000/kafka",
+		},
+		{
+			testName: "tag without version",
+			image:    "ghcr.io/adobe/koperator/kafka:latest",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			version, found := KafkaVersionFromImage(test.image)
+			require.Equal(t, test.expectedFound, found)
+			if test.expectedFound {
+				require.Equal(t, test.expectedVersion, version.String())
+			}
+		})
+	}
+}
+
+func TestCheckKRaftOnlyUpgrade(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		currentVersion string
+		desiredVersion sarama.KafkaVersion
+		expectedErr    bool
+	}{
+		{
+			testName:       "upgrade within 3.x",
+			currentVersion: "3.1.0",
+			desiredVersion: sarama.V3_9_0_0,
+		},
+		{
+			testName:       "upgrade from a supported version",
+			currentVersion: "3.9.1",
+			desiredVersion: sarama.V4_0_0_0,
+		},
+		{
+			testName:       "upgrade from an unsupported version",
+			currentVersion: "3.2.3",
+			desiredVersion: sarama.V4_0_0_0,
+			expectedErr:    true,
+		},
+		{
+			testName:       "unknown current version",
+			currentVersion: "",
+			desiredVersion: sarama.V4_0_0_0,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			err := CheckKRaftOnlyUpgrade(test.currentVersion, test.desiredVersion)
+			if test.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keystore
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+const (
+	passwordLength = 32
+	passwordChars  = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+	kmsRequestTimeout = 10 * time.Second
+)
+
+// Password is a keystore password generated by a PasswordGenerator
+type Password struct {
+	// Value is the password the keystores are encrypted with
+	Value []byte
+	// Wrapped is the password encrypted by the KMS key, it is empty unless the password is generated by a KMS
+	Wrapped string
+}
+
+// PasswordGenerator generates keystore passwords
+type PasswordGenerator interface {
+	// Generate returns a new password
+	Generate(ctx context.Context) (Password, error)
+}
+
+// NewPasswordGenerator returns the generator of the keystore passwords of the cluster according to the given policy
+func NewPasswordGenerator(c client.Reader, namespace string, policy *v1beta1.KeystorePasswordPolicy) (PasswordGenerator, error) {
+	if policy == nil {
+		return &randomGenerator{}, nil
+	}
+	switch policy.GetGenerator() {
+	case v1beta1.KeystorePasswordGeneratorRandom:
+		return &randomGenerator{}, nil
+	case v1beta1.KeystorePasswordGeneratorKMSWrapped:
+		if policy.KMS == nil {
+			return nil, errors.New("kms configuration is required by the kmsWrapped keystore password generator")
+		}
+		return &kmsWrappedGenerator{
+			client:     c,
+			namespace:  namespace,
+			config:     *policy.KMS,
+			httpClient: &http.Client{Timeout: kmsRequestTimeout},
+		}, nil
+	case v1beta1.KeystorePasswordGeneratorExternal:
+		if policy.ExternalSecretRef == nil {
+			return nil, errors.New("externalSecretRef is required by the external keystore password generator")
+		}
+		return &externalGenerator{client: c, namespace: namespace, ref: *policy.ExternalSecretRef}, nil
+	default:
+		return nil, errors.NewWithDetails("unknown keystore password generator", "generator", policy.Generator)
+	}
+}
+
+// randomGenerator generates passwords with a cryptographically secure random generator
+type randomGenerator struct{}
+
+func (g *randomGenerator) Generate(_ context.Context) (Password, error) {
+	var b strings.Builder
+	for i := 0; i < passwordLength; i++ {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(passwordChars))))
+		if err != nil {
+			return Password{}, errors.WrapIf(err, "could not generate random password")
+		}
+		b.WriteByte(passwordChars[n.Int64()])
+	}
+	return Password{Value: []byte(b.String())}, nil
+}
+
+// kmsWrappedGenerator generates passwords as data keys of a KMS exposing a Vault transit compatible API
+type kmsWrappedGenerator struct {
+	client     client.Reader
+	namespace  string
+	config     v1beta1.KeystorePasswordKMSConfig
+	httpClient *http.Client
+}
+
+type kmsDataKeyResponse struct {
+	Data struct {
+		Plaintext  string `json:"plaintext"`
+		Ciphertext string `json:"ciphertext"`
+	} `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+func (g *kmsWrappedGenerator) Generate(ctx context.Context) (Password, error) {
+	token, err := secretKeyValue(ctx, g.client, g.namespace, g.config.TokenSecretRef)
+	if err != nil {
+		return Password{}, errors.WrapIf(err, "could not get the KMS token")
+	}
+
+	url := fmt.Sprintf("%s/v1/%s/datakey/plaintext/%s", strings.TrimSuffix(g.config.Address, "/"),
+		strings.Trim(g.config.GetMountPath(), "/"), g.config.KeyName)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBufferString(`{"bits":256}`))
+	if err != nil {
+		return Password{}, errors.WrapIf(err, "could not create KMS data key request")
+	}
+	req.Header.Set("X-Vault-Token", strings.TrimSpace(string(token)))
+	req.Header.Set("Content-Type", "application/json")
+
+	rsp, err := g.httpClient.Do(req)
+	if err != nil {
+		return Password{}, errors.WrapIf(err, "could not generate data key with the KMS")
+	}
+	defer rsp.Body.Close()
+
+	var dataKey kmsDataKeyResponse
+	if err = json.NewDecoder(rsp.Body).Decode(&dataKey); err != nil {
+		return Password{}, errors.WrapIfWithDetails(err, "could not decode KMS data key response", "status", rsp.Status)
+	}
+	if rsp.StatusCode != http.StatusOK {
+		return Password{}, errors.NewWithDetails("KMS data key request failed", "status", rsp.Status, "errors", strings.Join(dataKey.Errors, "; "))
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(dataKey.Data.Plaintext)
+	if err != nil || len(plaintext) == 0 || dataKey.Data.Ciphertext == "" {
+		return Password{}, errors.New("KMS returned an invalid data key")
+	}
+	return Password{
+		Value:   []byte(base64.RawURLEncoding.EncodeToString(plaintext)),
+		Wrapped: dataKey.Data.Ciphertext,
+	}, nil
+}
+
+// externalGenerator takes the password from a Secret maintained by an external secret provider
+type externalGenerator struct {
+	client    client.Reader
+	namespace string
+	ref       corev1.SecretKeySelector
+}
+
+func (g *externalGenerator) Generate(ctx context.Context) (Password, error) {
+	value, err := secretKeyValue(ctx, g.client, g.namespace, g.ref)
+	if err != nil {
+		return Password{}, errors.WrapIf(err, "could not get the external keystore password")
+	}
+	return Password{Value: value}, nil
+}
+
+func secretKeyValue(ctx context.Context, c client.Reader, namespace string, ref corev1.SecretKeySelector) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, secret); err != nil {
+		return nil, err
+	}
+	value := secret.Data[ref.Key]
+	if len(value) == 0 {
+		return nil, errors.NewWithDetails("key of the secret is empty", "secret", ref.Name, "key", ref.Key)
+	}
+	return value, nil
+}
@@ -0,0 +1,148 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keystore
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func TestGenerate(t *testing.T) {
+	dataKey := []byte("0123456789abcdef0123456789abcdef")
+	kms := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/transit/datakey/plaintext/kafka" || r.Header.Get("X-Vault-Token") != "s.token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"plaintext":"` + base64.StdEncoding.EncodeToString(dataKey) + `","ciphertext":"vault:v1:wrapped"}}`))
+	}))
+	defer kms.Close()
+
+	secrets := []corev1.Secret{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "kms-token", Namespace: "kafka"},
+			Data:       map[string][]byte{"token": []byte("s.token\n")},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "keystore-password", Namespace: "kafka"},
+			Data:       map[string][]byte{"password": []byte("external-password")},
+		},
+	}
+
+	testCases := []struct {
+		testName        string
+		policy          *v1beta1.KeystorePasswordPolicy
+		expectedValue   string
+		expectedWrapped string
+		expectedErr     bool
+	}{
+		{
+			testName: "kms wrapped",
+			policy: &v1beta1.KeystorePasswordPolicy{
+				Generator: v1beta1.KeystorePasswordGeneratorKMSWrapped,
+				KMS: &v1beta1.KeystorePasswordKMSConfig{
+					Address: kms.URL,
+					KeyName: "kafka",
+					TokenSecretRef: corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "kms-token"},
+						Key:                  "token",
+					},
+				},
+			},
+			expectedValue:   base64.RawURLEncoding.EncodeToString(dataKey),
+			expectedWrapped: "vault:v1:wrapped",
+		},
+		{
+			testName: "kms rejects the request",
+			policy: &v1beta1.KeystorePasswordPolicy{
+				Generator: v1beta1.KeystorePasswordGeneratorKMSWrapped,
+				KMS: &v1beta1.KeystorePasswordKMSConfig{
+					Address: kms.URL,
+					KeyName: "other",
+					TokenSecretRef: corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "kms-token"},
+						Key:                  "token",
+					},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			testName: "external",
+			policy: &v1beta1.KeystorePasswordPolicy{
+				Generator: v1beta1.KeystorePasswordGeneratorExternal,
+				ExternalSecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "keystore-password"},
+					Key:                  "password",
+				},
+			},
+			expectedValue: "external-password",
+		},
+		{
+			testName: "external secret key missing",
+			policy: &v1beta1.KeystorePasswordPolicy{
+				Generator: v1beta1.KeystorePasswordGeneratorExternal,
+				ExternalSecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "keystore-password"},
+					Key:                  "missing",
+				},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithLists(&corev1.SecretList{Items: secrets}).Build()
+			generator, err := NewPasswordGenerator(c, "kafka", test.policy)
+			require.NoError(t, err)
+
+			password, err := generator.Generate(context.Background())
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expectedValue, string(password.Value))
+			require.Equal(t, test.expectedWrapped, password.Wrapped)
+		})
+	}
+}
+
+func TestGenerateRandom(t *testing.T) {
+	generator, err := NewPasswordGenerator(nil, "kafka", nil)
+	require.NoError(t, err)
+
+	first, err := generator.Generate(context.Background())
+	require.NoError(t, err)
+	second, err := generator.Generate(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, first.Value, passwordLength)
+	require.NotEqual(t, first.Value, second.Value)
+	require.Empty(t, first.Wrapped)
+}
@@ -0,0 +1,83 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"time"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/util/cron"
+)
+
+// Window is a parsed maintenance window
+type Window struct {
+	schedule *cron.Schedule
+	duration time.Duration
+	location *time.Location
+}
+
+// Parse parses the schedule and the time zone of the maintenance window
+func Parse(window v1beta1.MaintenanceWindow) (*Window, error) {
+	schedule, err := cron.Parse(window.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	if window.Duration.Duration <= 0 {
+		return nil, errors.Errorf("the duration of the maintenance window must be positive, got %s", window.Duration.Duration)
+	}
+	location, err := time.LoadLocation(window.TimeZone)
+	if err != nil {
+		return nil, errors.WrapIff(err, "unknown time zone %q", window.TimeZone)
+	}
+	return &Window{schedule: schedule, duration: window.Duration.Duration, location: location}, nil
+}
+
+// Contains returns whether the given time is in the window, and the start of the next window otherwise
+func (w *Window) Contains(t time.Time) (bool, time.Time) {
+	// the first start after the given time minus the duration is either the start of the window the time is in or the
+	// start of the next window
+	start := w.schedule.Next(t.In(w.location).Add(-w.duration))
+	if !start.IsZero() && !start.After(t) {
+		return true, time.Time{}
+	}
+	return false, start
+}
+
+// InWindow returns whether the given time is in one of the maintenance windows, it is always true when no window is
+// defined. Outside of the windows the start of the next window is returned as well, the zero time when none of the
+// windows ever starts again.
+func InWindow(windows []v1beta1.MaintenanceWindow, t time.Time) (bool, time.Time, error) {
+	if len(windows) == 0 {
+		return true, time.Time{}, nil
+	}
+	var next time.Time
+	for _, window := range windows {
+		parsed, err := Parse(window)
+		if err != nil {
+			return false, time.Time{}, err
+		}
+		in, start := parsed.Contains(t)
+		if in {
+			return true, time.Time{}, nil
+		}
+		if !start.IsZero() && (next.IsZero() || start.Before(next)) {
+			next = start
+		}
+	}
+	return false, next, nil
+}
@@ -0,0 +1,113 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func TestInWindow(t *testing.T) {
+	weeknights := v1beta1.MaintenanceWindow{Schedule: "0 22 * * mon-fri", Duration: metav1.Duration{Duration: 4 * time.Hour}}
+	berlinSundays := v1beta1.MaintenanceWindow{Schedule: "0 3 * * sun", Duration: metav1.Duration{Duration: 2 * time.Hour}, TimeZone: "Europe/Berlin"}
+
+	testCases := []struct {
+		testName     string
+		windows      []v1beta1.MaintenanceWindow
+		now          time.Time
+		expectedIn   bool
+		expectedNext time.Time
+		expectErr    bool
+	}{
+		{
+			testName:   "no window",
+			now:        time.Date(2025, time.June, 4, 12, 0, 0, 0, time.UTC),
+			expectedIn: true,
+		},
+		{
+			testName:   "in the window",
+			windows:    []v1beta1.MaintenanceWindow{weeknights},
+			now:        time.Date(2025, time.June, 4, 23, 30, 0, 0, time.UTC),
+			expectedIn: true,
+		},
+		{
+			testName:   "in the window past midnight",
+			windows:    []v1beta1.MaintenanceWindow{weeknights},
+			now:        time.Date(2025, time.June, 5, 1, 59, 0, 0, time.UTC),
+			expectedIn: true,
+		},
+		{
+			testName:     "at the end of the window",
+			windows:      []v1beta1.MaintenanceWindow{weeknights},
+			now:          time.Date(2025, time.June, 5, 2, 0, 0, 0, time.UTC),
+			expectedNext: time.Date(2025, time.June, 5, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			testName:     "before the window",
+			windows:      []v1beta1.MaintenanceWindow{weeknights},
+			now:          time.Date(2025, time.June, 4, 12, 0, 0, 0, time.UTC),
+			expectedNext: time.Date(2025, time.June, 4, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			testName:     "weekend",
+			windows:      []v1beta1.MaintenanceWindow{weeknights},
+			now:          time.Date(2025, time.June, 7, 12, 0, 0, 0, time.UTC),
+			expectedNext: time.Date(2025, time.June, 9, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			testName:   "in the window of the time zone",
+			windows:    []v1beta1.MaintenanceWindow{weeknights, berlinSundays},
+			now:        time.Date(2025, time.June, 8, 1, 30, 0, 0, time.UTC),
+			expectedIn: true,
+		},
+		{
+			testName:     "earliest next window",
+			windows:      []v1beta1.MaintenanceWindow{weeknights, berlinSundays},
+			now:          time.Date(2025, time.June, 7, 12, 0, 0, 0, time.UTC),
+			expectedNext: time.Date(2025, time.June, 8, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			testName:  "unknown time zone",
+			windows:   []v1beta1.MaintenanceWindow{{Schedule: "@daily", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Mars/Olympus"}},
+			now:       time.Date(2025, time.June, 7, 12, 0, 0, 0, time.UTC),
+			expectErr: true,
+		},
+		{
+			testName:  "empty window",
+			windows:   []v1beta1.MaintenanceWindow{{Schedule: "@daily"}},
+			now:       time.Date(2025, time.June, 7, 12, 0, 0, 0, time.UTC),
+			expectErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			in, next, err := InWindow(test.windows, test.now)
+			if test.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expectedIn, in)
+			require.True(t, test.expectedNext.Equal(next), "expected next window at %s, got %s", test.expectedNext, next)
+		})
+	}
+}
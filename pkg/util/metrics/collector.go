@@ -0,0 +1,205 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	certutil "github.com/banzaicloud/koperator/pkg/util/cert"
+	pkicommon "github.com/banzaicloud/koperator/pkg/util/pki"
+)
+
+const (
+	collectTimeout = 10 * time.Second
+	// pendingOperationState is the state of the operations whose task has not been executed yet
+	pendingOperationState = "Pending"
+)
+
+var (
+	cruiseControlOperationsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "cruisecontrol_operations"),
+		"Number of CruiseControlOperations of the cluster by the state of their current task",
+		[]string{namespaceLabel, clusterLabel, stateLabel}, nil)
+	brokersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "brokers"),
+		"Number of brokers of the cluster by their configuration and Cruise Control state",
+		[]string{namespaceLabel, clusterLabel, configStateLabel, gracefulStateLabel}, nil)
+	rollingUpgradeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "rolling_upgrade_in_progress"),
+		"Whether a rolling upgrade of the cluster is in progress",
+		[]string{namespaceLabel, clusterLabel}, nil)
+	cruiseControlAnomaliesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "cruisecontrol_anomalies"),
+		"Number of the anomalies recently detected by Cruise Control by their type and status",
+		[]string{namespaceLabel, clusterLabel, typeLabel, statusLabel}, nil)
+	certificateExpiryDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "listener_certificate_expiry_timestamp_seconds"),
+		"Expiry of the server certificate of the SSL listener of the cluster as a Unix timestamp",
+		[]string{namespaceLabel, clusterLabel, listenerLabel}, nil)
+)
+
+// ClusterCollector collects the metrics of the state of the KafkaClusters and their CruiseControlOperations from the
+// cache of the operator at scrape time, so no series of deleted resources remain
+type ClusterCollector struct {
+	Client client.Reader
+	Log    logr.Logger
+}
+
+// RegisterClusterCollector registers the collector of the state of the clusters on the metrics registry of the
+// controller-runtime
+func RegisterClusterCollector(reader client.Reader, log logr.Logger) error {
+	return metrics.Registry.Register(&ClusterCollector{Client: reader, Log: log})
+}
+
+// Describe implements prometheus.Collector
+func (c *ClusterCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- cruiseControlOperationsDesc
+	ch <- brokersDesc
+	ch <- rollingUpgradeDesc
+	ch <- cruiseControlAnomaliesDesc
+	ch <- certificateExpiryDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *ClusterCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	clusters := &v1beta1.KafkaClusterList{}
+	if err := c.Client.List(ctx, clusters); err != nil {
+		c.Log.Error(err, "could not list Kafka clusters")
+		return
+	}
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		collectBrokers(ch, cluster)
+		collectCruiseControlAnomalies(ch, cluster)
+		c.collectCertificateExpiry(ctx, ch, cluster)
+	}
+
+	operations := &v1alpha1.CruiseControlOperationList{}
+	if err := c.Client.List(ctx, operations); err != nil {
+		c.Log.Error(err, "could not list CruiseControlOperations")
+		return
+	}
+	collectCruiseControlOperations(ch, operations.Items)
+}
+
+// collectBrokers collects the number of brokers by state and whether the cluster is rolling upgrading
+func collectBrokers(ch chan<- prometheus.Metric, cluster *v1beta1.KafkaCluster) {
+	type brokerState struct {
+		configuration v1beta1.ConfigurationState
+		cruiseControl v1beta1.CruiseControlState
+	}
+	counts := make(map[brokerState]int)
+	for _, state := range cluster.Status.BrokersState {
+		counts[brokerState{configuration: state.ConfigurationState, cruiseControl: state.GracefulActionState.CruiseControlState}]++
+	}
+	for state, count := range counts {
+		ch <- prometheus.MustNewConstMetric(brokersDesc, prometheus.GaugeValue, float64(count),
+			cluster.Namespace, cluster.Name, string(state.configuration), string(state.cruiseControl))
+	}
+
+	rollingUpgrade := 0.0
+	if cluster.Status.State == v1beta1.KafkaClusterRollingUpgrading {
+		rollingUpgrade = 1
+	}
+	ch <- prometheus.MustNewConstMetric(rollingUpgradeDesc, prometheus.GaugeValue, rollingUpgrade, cluster.Namespace, cluster.Name)
+}
+
+// collectCruiseControlAnomalies collects the number of the anomalies of the cluster recently detected by Cruise Control
+func collectCruiseControlAnomalies(ch chan<- prometheus.Metric, cluster *v1beta1.KafkaCluster) {
+	if cluster.Status.CruiseControlAnomalies == nil {
+		return
+	}
+	type anomalyKey struct {
+		anomalyType, status string
+	}
+	counts := make(map[anomalyKey]int)
+	for _, anomaly := range cluster.Status.CruiseControlAnomalies.Anomalies {
+		counts[anomalyKey{anomalyType: string(anomaly.Type), status: anomaly.Status}]++
+	}
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(cruiseControlAnomaliesDesc, prometheus.GaugeValue, float64(count),
+			cluster.Namespace, cluster.Name, key.anomalyType, key.status)
+	}
+}
+
+// collectCertificateExpiry collects the expiry of the server certificates of the SSL listeners of the cluster, the
+// listeners whose certificate is not issued yet are skipped
+func (c *ClusterCollector) collectCertificateExpiry(ctx context.Context, ch chan<- prometheus.Metric, cluster *v1beta1.KafkaCluster) {
+	var listeners []v1beta1.CommonListenerSpec
+	for _, iListener := range cluster.Spec.ListenersConfig.InternalListeners {
+		listeners = append(listeners, iListener.CommonListenerSpec)
+	}
+	for _, eListener := range cluster.Spec.ListenersConfig.ExternalListeners {
+		listeners = append(listeners, eListener.CommonListenerSpec)
+	}
+
+	expiries := make(map[string]time.Time)
+	for _, listener := range listeners {
+		if listener.Type != v1beta1.SecurityProtocolSSL {
+			continue
+		}
+		secretName := pkicommon.ListenerServerCertSecretName(cluster.Name, listener)
+		expiry, ok := expiries[secretName]
+		if !ok {
+			secret := &corev1.Secret{}
+			if err := c.Client.Get(ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: secretName}, secret); err != nil {
+				continue
+			}
+			tlsCert, err := certutil.ParseKeyStoreToTLSCertificate(secret.Data[v1alpha1.TLSJKSKeyStore], secret.Data[v1alpha1.PasswordKey])
+			if err != nil || tlsCert.Leaf == nil {
+				continue
+			}
+			expiry = tlsCert.Leaf.NotAfter
+			expiries[secretName] = expiry
+		}
+		ch <- prometheus.MustNewConstMetric(certificateExpiryDesc, prometheus.GaugeValue, float64(expiry.Unix()),
+			cluster.Namespace, cluster.Name, listener.Name)
+	}
+}
+
+// collectCruiseControlOperations collects the number of CruiseControlOperations by cluster and the state of their
+// current task
+func collectCruiseControlOperations(ch chan<- prometheus.Metric, operations []v1alpha1.CruiseControlOperation) {
+	type operationKey struct {
+		namespace, cluster, state string
+	}
+	counts := make(map[operationKey]int)
+	for i := range operations {
+		operation := &operations[i]
+		state := string(operation.CurrentTaskState())
+		if state == "" {
+			state = pendingOperationState
+		}
+		counts[operationKey{namespace: operation.Namespace, cluster: operation.GetClusterRef(), state: state}]++
+	}
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(cruiseControlOperationsDesc, prometheus.GaugeValue, float64(count),
+			key.namespace, key.cluster, key.state)
+	}
+}
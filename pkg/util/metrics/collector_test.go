@@ -0,0 +1,134 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func TestClusterCollector(t *testing.T) {
+	brokerState := func(configurationState v1beta1.ConfigurationState, ccState v1beta1.CruiseControlState) v1beta1.BrokerState {
+		return v1beta1.BrokerState{
+			ConfigurationState:  configurationState,
+			GracefulActionState: v1beta1.GracefulActionState{CruiseControlState: ccState},
+		}
+	}
+	operation := func(name string, state v1beta1.CruiseControlUserTaskState) client.Object {
+		operation := &v1alpha1.CruiseControlOperation{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kafka", Labels: map[string]string{v1beta1.KafkaCRLabelKey: "kafka"}},
+		}
+		if state != "" {
+			operation.Status.CurrentTask = &v1alpha1.CruiseControlTask{State: state}
+		}
+		return operation
+	}
+
+	testCases := []struct {
+		testName        string
+		clusterState    v1beta1.ClusterState
+		brokersState    map[string]v1beta1.BrokerState
+		anomalies       *v1beta1.CruiseControlAnomaliesStatus
+		operations      []client.Object
+		expectedMetrics string
+	}{
+		{
+			testName:     "running cluster",
+			clusterState: v1beta1.KafkaClusterRunning,
+			brokersState: map[string]v1beta1.BrokerState{
+				"0": brokerState(v1beta1.ConfigInSync, v1beta1.GracefulUpscaleSucceeded),
+				"1": brokerState(v1beta1.ConfigInSync, v1beta1.GracefulUpscaleSucceeded),
+				"2": brokerState(v1beta1.ConfigOutOfSync, v1beta1.GracefulUpscaleRunning),
+			},
+			operations: []client.Object{
+				operation("op-1", v1beta1.CruiseControlTaskCompleted),
+				operation("op-2", v1beta1.CruiseControlTaskCompleted),
+				operation("op-3", ""),
+			},
+			expectedMetrics: `
+# HELP koperator_brokers Number of brokers of the cluster by their configuration and Cruise Control state
+# TYPE koperator_brokers gauge
+koperator_brokers{configuration_state="ConfigInSync",cruise_control_state="GracefulUpscaleSucceeded",kafka_cr="kafka",namespace="kafka"} 2
+koperator_brokers{configuration_state="ConfigOutOfSync",cruise_control_state="GracefulUpscaleRunning",kafka_cr="kafka",namespace="kafka"} 1
+# HELP koperator_cruisecontrol_operations Number of CruiseControlOperations of the cluster by the state of their current task
+# TYPE koperator_cruisecontrol_operations gauge
+koperator_cruisecontrol_operations{kafka_cr="kafka",namespace="kafka",state="Completed"} 2
+koperator_cruisecontrol_operations{kafka_cr="kafka",namespace="kafka",state="Pending"} 1
+# HELP koperator_rolling_upgrade_in_progress Whether a rolling upgrade of the cluster is in progress
+# TYPE koperator_rolling_upgrade_in_progress gauge
+koperator_rolling_upgrade_in_progress{kafka_cr="kafka",namespace="kafka"} 0
+`,
+		},
+		{
+			testName:     "rolling upgrading cluster",
+			clusterState: v1beta1.KafkaClusterRollingUpgrading,
+			brokersState: map[string]v1beta1.BrokerState{
+				"0": brokerState(v1beta1.ConfigOutOfSync, v1beta1.GracefulUpscaleSucceeded),
+			},
+			anomalies: &v1beta1.CruiseControlAnomaliesStatus{
+				Anomalies: []v1beta1.CruiseControlAnomaly{
+					{ID: "1", Type: v1beta1.CruiseControlAnomalyGoalViolation, Status: "DETECTED"},
+					{ID: "2", Type: v1beta1.CruiseControlAnomalyGoalViolation, Status: "FIX_STARTED"},
+					{ID: "3", Type: v1beta1.CruiseControlAnomalyBrokerFailure, Status: "FIX_STARTED"},
+				},
+			},
+			expectedMetrics: `
+# HELP koperator_brokers Number of brokers of the cluster by their configuration and Cruise Control state
+# TYPE koperator_brokers gauge
+koperator_brokers{configuration_state="ConfigOutOfSync",cruise_control_state="GracefulUpscaleSucceeded",kafka_cr="kafka",namespace="kafka"} 1
+# HELP koperator_cruisecontrol_anomalies Number of the anomalies recently detected by Cruise Control by their type and status
+# TYPE koperator_cruisecontrol_anomalies gauge
+koperator_cruisecontrol_anomalies{kafka_cr="kafka",namespace="kafka",status="DETECTED",type="GoalViolation"} 1
+koperator_cruisecontrol_anomalies{kafka_cr="kafka",namespace="kafka",status="FIX_STARTED",type="BrokerFailure"} 1
+koperator_cruisecontrol_anomalies{kafka_cr="kafka",namespace="kafka",status="FIX_STARTED",type="GoalViolation"} 1
+# HELP koperator_rolling_upgrade_in_progress Whether a rolling upgrade of the cluster is in progress
+# TYPE koperator_rolling_upgrade_in_progress gauge
+koperator_rolling_upgrade_in_progress{kafka_cr="kafka",namespace="kafka"} 1
+`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			cluster := &v1beta1.KafkaCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+				Status: v1beta1.KafkaClusterStatus{
+					State:                  test.clusterState,
+					BrokersState:           test.brokersState,
+					CruiseControlAnomalies: test.anomalies,
+				},
+			}
+			scheme := runtime.NewScheme()
+			require.NoError(t, v1beta1.AddToScheme(scheme))
+			require.NoError(t, v1alpha1.AddToScheme(scheme))
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster).WithObjects(test.operations...).Build()
+
+			collector := &ClusterCollector{Client: c, Log: logr.Discard()}
+			require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(test.expectedMetrics)))
+		})
+	}
+}
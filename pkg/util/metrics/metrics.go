@@ -0,0 +1,62 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	metricsNamespace = "koperator"
+
+	controllerLabel    = "controller"
+	namespaceLabel     = "namespace"
+	nameLabel          = "name"
+	clusterLabel       = "kafka_cr"
+	stateLabel         = "state"
+	typeLabel          = "type"
+	statusLabel        = "status"
+	listenerLabel      = "listener"
+	configStateLabel   = "configuration_state"
+	gracefulStateLabel = "cruise_control_state"
+)
+
+// reconcileDuration is the duration of the reconciles of the custom resources by controller
+var reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: metricsNamespace,
+	Name:      "reconcile_duration_seconds",
+	Help:      "Duration of the reconciles of the custom resources by controller",
+	Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+}, []string{controllerLabel, namespaceLabel, nameLabel})
+
+func init() {
+	metrics.Registry.MustRegister(reconcileDuration)
+}
+
+// ObserveReconcileDuration records the duration of the reconcile of the custom resource started at the given time,
+// meant to be deferred once the custom resource is fetched
+func ObserveReconcileDuration(controller string, request reconcile.Request, start time.Time) {
+	reconcileDuration.WithLabelValues(controller, request.Namespace, request.Name).Observe(time.Since(start).Seconds())
+}
+
+// DeleteReconcileDuration removes the reconcile duration of the deleted custom resource
+func DeleteReconcileDuration(controller string, request reconcile.Request) {
+	reconcileDuration.DeleteLabelValues(controller, request.Namespace, request.Name)
+}
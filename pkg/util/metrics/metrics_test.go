@@ -0,0 +1,37 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestDeleteReconcileDuration(t *testing.T) {
+	kafka := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "kafka", Name: "kafka"}}
+	other := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "kafka", Name: "other"}}
+	ObserveReconcileDuration("KafkaCluster", kafka, time.Now())
+	ObserveReconcileDuration("KafkaCluster", other, time.Now())
+	require.Equal(t, 2, testutil.CollectAndCount(reconcileDuration))
+
+	DeleteReconcileDuration("KafkaCluster", kafka)
+	require.Equal(t, 1, testutil.CollectAndCount(reconcileDuration))
+}
@@ -0,0 +1,34 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nginx
+
+const (
+	// AllBrokerServiceName name for the service of the anycast port
+	AllBrokerServiceName = "nginx-svc-%s-%s"
+	// AllBrokerServiceNameWithScope name for the service of the anycast port with ingress config scope
+	AllBrokerServiceNameWithScope = "nginx-svc-%s-%s-%s"
+	// BrokerServiceNameTemplate name for the service of a broker
+	BrokerServiceNameTemplate = "nginx-svc-%s-%d-%s"
+	// ControllerServicePortNameTemplate name of the ports added to the Service of the ingress-nginx controller
+	ControllerServicePortNameTemplate = "kafka-%d"
+	// ControllerServicePortNamePrefix prefix of the names of the ports added to the Service of the ingress-nginx controller
+	ControllerServicePortNamePrefix = "kafka-"
+	// IngressControllerName name for the nginx ingress controller
+	IngressControllerName = "nginx"
+	// ControllerAnnotationKey annotation of the anycast port Services holding the ingress-nginx controller their TCP
+	// services are registered in, in the <namespace>/<tcp services configmap>/<service> format
+	ControllerAnnotationKey = "kafka.banzaicloud.io/nginx-ingress-controller"
+)
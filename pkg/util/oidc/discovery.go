@@ -0,0 +1,90 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+const (
+	// DiscoveryPath is the path of the OpenID Connect discovery document relative to the issuer URL
+	DiscoveryPath = "/.well-known/openid-configuration"
+
+	discoveryRequestTimeout = 10 * time.Second
+)
+
+// ProviderMetadata holds the endpoints an OpenID Connect provider publishes in its discovery document
+type ProviderMetadata struct {
+	Issuer        string `json:"issuer"`
+	TokenEndpoint string `json:"token_endpoint"`
+	JWKSURI       string `json:"jwks_uri"`
+}
+
+// NewHTTPClient returns an HTTP client for the endpoints of an OpenID Connect provider which trusts the given PEM
+// encoded CA certificates, or the system CAs if none is given
+func NewHTTPClient(caBundle []byte) (*http.Client, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(caBundle) > 0 {
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(caBundle) {
+			return nil, errors.New("CA bundle of the OpenID Connect provider holds no PEM encoded certificate")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Timeout: discoveryRequestTimeout, Transport: transport}, nil
+}
+
+// Discover fetches the discovery document of the OpenID Connect provider of the given issuer. The issuer of the
+// document has to match the given one as required by the OpenID Connect Discovery specification.
+func Discover(ctx context.Context, httpClient *http.Client, issuerURL string) (*ProviderMetadata, error) {
+	discoveryURL := strings.TrimSuffix(issuerURL, "/") + DiscoveryPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not create discovery request", "url", discoveryURL)
+	}
+	req.Header.Set("Accept", "application/json")
+	rsp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not fetch discovery document", "url", discoveryURL)
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return nil, errors.NewWithDetails("unexpected discovery response status", "url", discoveryURL, "status", rsp.Status)
+	}
+
+	metadata := &ProviderMetadata{}
+	if err := json.NewDecoder(rsp.Body).Decode(metadata); err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not decode discovery document", "url", discoveryURL)
+	}
+	if strings.TrimSuffix(metadata.Issuer, "/") != strings.TrimSuffix(issuerURL, "/") {
+		return nil, errors.NewWithDetails("issuer of the discovery document does not match",
+			"expected", issuerURL, "issuer", metadata.Issuer)
+	}
+	if metadata.JWKSURI == "" {
+		return nil, errors.NewWithDetails("discovery document holds no JWKS endpoint", "url", discoveryURL)
+	}
+	return metadata, nil
+}
@@ -0,0 +1,96 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscover(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		issuer           string
+		jwksURI          string
+		status           int
+		expectedMetadata bool
+	}{
+		{
+			testName:         "endpoints of the issuer are discovered",
+			jwksURI:          "/keys",
+			status:           http.StatusOK,
+			expectedMetadata: true,
+		},
+		{
+			testName: "issuer of the document does not match",
+			issuer:   "https://other.example.com",
+			jwksURI:  "/keys",
+			status:   http.StatusOK,
+		},
+		{
+			testName: "document without JWKS endpoint",
+			status:   http.StatusOK,
+		},
+		{
+			testName: "discovery document not found",
+			status:   http.StatusNotFound,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, DiscoveryPath, r.URL.Path)
+				w.WriteHeader(test.status)
+				issuer := test.issuer
+				if issuer == "" {
+					issuer = server.URL
+				}
+				metadata := ProviderMetadata{Issuer: issuer, TokenEndpoint: server.URL + "/token"}
+				if test.jwksURI != "" {
+					metadata.JWKSURI = server.URL + test.jwksURI
+				}
+				require.NoError(t, json.NewEncoder(w).Encode(metadata))
+			}))
+			defer server.Close()
+
+			httpClient, err := NewHTTPClient(nil)
+			require.NoError(t, err)
+			metadata, err := Discover(context.Background(), httpClient, server.URL+"/")
+			if !test.expectedMetadata {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, &ProviderMetadata{
+				Issuer:        server.URL,
+				TokenEndpoint: server.URL + "/token",
+				JWKSURI:       server.URL + "/keys",
+			}, metadata)
+		})
+	}
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	_, err := NewHTTPClient([]byte("not a certificate"))
+	require.Error(t, err)
+}
@@ -0,0 +1,205 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ownership
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"emperror.dev/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+)
+
+// Scope holds the topic name prefixes the KafkaTopic, KafkaUser and KafkaACL resources of a namespace may touch
+type Scope struct {
+	// Namespace the scope belongs to
+	Namespace string
+	// Team owning the namespace, empty if the namespace is not owned by any team
+	Team string
+	// TopicPrefixes owned by the team of the namespace, collected from every namespace of the team
+	TopicPrefixes []string
+	// ClaimedTopicPrefixes owned by the other teams
+	ClaimedTopicPrefixes []string
+}
+
+// ScopeOf returns the ownership scope of the given namespace based on the team and topic prefixes annotations of
+// the namespaces of the cluster
+func ScopeOf(ctx context.Context, c client.Reader, namespace string) (Scope, error) {
+	scope := Scope{Namespace: namespace}
+
+	namespaces := &corev1.NamespaceList{}
+	if err := c.List(ctx, namespaces); err != nil {
+		return scope, errors.WrapIfWithDetails(err, "failed to list namespaces", "namespace", namespace)
+	}
+	for _, ns := range namespaces.Items {
+		if ns.GetName() == namespace {
+			scope.Team = ns.GetAnnotations()[v1alpha1.NamespaceTeamAnnotationKey]
+		}
+	}
+
+	owned := make(map[string]struct{})
+	claimed := make(map[string]struct{})
+	for _, ns := range namespaces.Items {
+		team := ns.GetAnnotations()[v1alpha1.NamespaceTeamAnnotationKey]
+		if team == "" {
+			continue
+		}
+		for _, prefix := range topicPrefixes(ns) {
+			if team == scope.Team {
+				owned[prefix] = struct{}{}
+			} else {
+				claimed[prefix] = struct{}{}
+			}
+		}
+	}
+	scope.TopicPrefixes = sortedKeys(owned)
+	scope.ClaimedTopicPrefixes = sortedKeys(claimed)
+	return scope, nil
+}
+
+// CheckOwner returns an error if the team label of the object does not match the team owning the namespace
+func (s Scope) CheckOwner(obj metav1.Object) error {
+	team := obj.GetLabels()[v1alpha1.TeamLabelKey]
+	if team == s.Team {
+		return nil
+	}
+	if s.Team == "" {
+		return errors.NewWithDetails("namespace is not owned by any team", "namespace", s.Namespace, "team", team)
+	}
+	return errors.NewWithDetails(fmt.Sprintf("resource must carry the '%s: %s' label of the team owning the namespace", v1alpha1.TeamLabelKey, s.Team),
+		"namespace", s.Namespace, "team", team)
+}
+
+// CheckTopic returns an error if the topic name pattern touches topics which are not owned by the team of the
+// namespace. The namespaces owned by a team may only touch the topics starting with the topic prefixes of the
+// team, the namespaces not owned by any team may touch every topic but the ones claimed by the teams.
+func (s Scope) CheckTopic(name string, patternType v1alpha1.KafkaPatternType) error {
+	if patternType == "" {
+		patternType = v1alpha1.KafkaPatternTypeDefault
+	}
+
+	if s.Team != "" {
+		if patternType == v1alpha1.KafkaPatternTypeAny || patternType == v1alpha1.KafkaPatternTypeMatch || name == "*" {
+			return errors.NewWithDetails("topic pattern touches topics not owned by the team",
+				"namespace", s.Namespace, "team", s.Team, "topic", name, "patternType", patternType)
+		}
+		for _, prefix := range s.TopicPrefixes {
+			if strings.HasPrefix(name, prefix) {
+				return nil
+			}
+		}
+		return errors.NewWithDetails("topic is not owned by the team", "namespace", s.Namespace, "team", s.Team,
+			"topic", name, "topicPrefixes", s.TopicPrefixes)
+	}
+
+	for _, prefix := range s.ClaimedTopicPrefixes {
+		if overlaps(name, patternType, prefix) {
+			return errors.NewWithDetails("topic is owned by a team", "namespace", s.Namespace, "topic", name,
+				"patternType", patternType, "topicPrefix", prefix)
+		}
+	}
+	return nil
+}
+
+// CheckKafkaTopic returns an error if the KafkaTopic is not owned by the team of its namespace
+func CheckKafkaTopic(ctx context.Context, c client.Reader, topic *v1alpha1.KafkaTopic) error {
+	scope, err := ScopeOf(ctx, c, topic.GetNamespace())
+	if err != nil {
+		return err
+	}
+	if err := scope.CheckOwner(topic); err != nil {
+		return err
+	}
+	return scope.CheckTopic(topic.Spec.Name, v1alpha1.KafkaPatternTypeLiteral)
+}
+
+// CheckKafkaUser returns an error if the KafkaUser is not owned by the team of its namespace or its topic grants
+// touch topics of other teams
+func CheckKafkaUser(ctx context.Context, c client.Reader, user *v1alpha1.KafkaUser) error {
+	scope, err := ScopeOf(ctx, c, user.GetNamespace())
+	if err != nil {
+		return err
+	}
+	if err := scope.CheckOwner(user); err != nil {
+		return err
+	}
+	for _, grant := range user.Spec.TopicGrants {
+		if err := scope.CheckTopic(grant.TopicName, grant.PatternType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckKafkaACL returns an error if the KafkaACL is not owned by the team of its namespace or its topic rules touch
+// topics of other teams
+func CheckKafkaACL(ctx context.Context, c client.Reader, acl *v1alpha1.KafkaACL) error {
+	scope, err := ScopeOf(ctx, c, acl.GetNamespace())
+	if err != nil {
+		return err
+	}
+	if err := scope.CheckOwner(acl); err != nil {
+		return err
+	}
+	for i := range acl.Spec.ACLs {
+		rule := &acl.Spec.ACLs[i]
+		if !strings.EqualFold(string(rule.ResourceType), "topic") {
+			continue
+		}
+		if err := scope.CheckTopic(rule.ResourceName, rule.GetPatternType()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// overlaps returns true if the topic name pattern may touch a topic starting with the given prefix
+func overlaps(name string, patternType v1alpha1.KafkaPatternType, prefix string) bool {
+	switch {
+	case patternType == v1alpha1.KafkaPatternTypeAny || patternType == v1alpha1.KafkaPatternTypeMatch || name == "*":
+		return true
+	case patternType == v1alpha1.KafkaPatternTypePrefixed:
+		return strings.HasPrefix(name, prefix) || strings.HasPrefix(prefix, name)
+	default:
+		return strings.HasPrefix(name, prefix)
+	}
+}
+
+// topicPrefixes returns the topic prefixes listed in the topic prefixes annotation of the namespace
+func topicPrefixes(ns corev1.Namespace) []string {
+	var prefixes []string
+	for _, prefix := range strings.Split(ns.GetAnnotations()[v1alpha1.NamespaceTopicPrefixesAnnotationKey], ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	var keys []string
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
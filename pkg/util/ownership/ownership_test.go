@@ -0,0 +1,212 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ownership
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+)
+
+func newNamespace(name, team, topicPrefixes string) *corev1.Namespace {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if team != "" {
+		ns.SetAnnotations(map[string]string{
+			v1alpha1.NamespaceTeamAnnotationKey:          team,
+			v1alpha1.NamespaceTopicPrefixesAnnotationKey: topicPrefixes,
+		})
+	}
+	return ns
+}
+
+func TestScopeOf(t *testing.T) {
+	c := fake.NewClientBuilder().WithObjects(
+		newNamespace("payments", "payments", "payments., billing."),
+		newNamespace("payments-staging", "payments", "payments-staging."),
+		newNamespace("shipping", "shipping", "shipping."),
+		newNamespace("default", "", ""),
+	).Build()
+
+	testCases := []struct {
+		testName      string
+		namespace     string
+		expectedScope Scope
+	}{
+		{
+			testName:  "namespace owned by a team",
+			namespace: "payments",
+			expectedScope: Scope{
+				Namespace:            "payments",
+				Team:                 "payments",
+				TopicPrefixes:        []string{"billing.", "payments-staging.", "payments."},
+				ClaimedTopicPrefixes: []string{"shipping."},
+			},
+		},
+		{
+			testName:  "namespace not owned by any team",
+			namespace: "default",
+			expectedScope: Scope{
+				Namespace:            "default",
+				ClaimedTopicPrefixes: []string{"billing.", "payments-staging.", "payments.", "shipping."},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			scope, err := ScopeOf(context.Background(), c, test.namespace)
+			require.NoError(t, err)
+			require.Equal(t, test.expectedScope, scope)
+		})
+	}
+}
+
+func TestCheckTopic(t *testing.T) {
+	teamScope := Scope{Namespace: "payments", Team: "payments", TopicPrefixes: []string{"payments."}, ClaimedTopicPrefixes: []string{"shipping."}}
+	unownedScope := Scope{Namespace: "default", ClaimedTopicPrefixes: []string{"payments.", "shipping."}}
+
+	testCases := []struct {
+		testName    string
+		scope       Scope
+		topicName   string
+		patternType v1alpha1.KafkaPatternType
+		expectedErr bool
+	}{
+		{
+			testName:  "topic of the team",
+			scope:     teamScope,
+			topicName: "payments.orders",
+		},
+		{
+			testName:    "prefixed pattern of the team",
+			scope:       teamScope,
+			topicName:   "payments.orders",
+			patternType: v1alpha1.KafkaPatternTypePrefixed,
+		},
+		{
+			testName:    "topic of another team",
+			scope:       teamScope,
+			topicName:   "shipping.orders",
+			expectedErr: true,
+		},
+		{
+			testName:    "wildcard topic in a namespace owned by a team",
+			scope:       teamScope,
+			topicName:   "*",
+			expectedErr: true,
+		},
+		{
+			testName:    "any pattern in a namespace owned by a team",
+			scope:       teamScope,
+			topicName:   "payments.orders",
+			patternType: v1alpha1.KafkaPatternTypeAny,
+			expectedErr: true,
+		},
+		{
+			testName:  "unowned topic in a namespace not owned by any team",
+			scope:     unownedScope,
+			topicName: "orders",
+		},
+		{
+			testName:    "topic of a team in a namespace not owned by any team",
+			scope:       unownedScope,
+			topicName:   "payments.orders",
+			expectedErr: true,
+		},
+		{
+			testName:    "prefixed pattern covering the topics of a team",
+			scope:       unownedScope,
+			topicName:   "pay",
+			patternType: v1alpha1.KafkaPatternTypePrefixed,
+			expectedErr: true,
+		},
+		{
+			testName:    "wildcard topic in a namespace not owned by any team",
+			scope:       unownedScope,
+			topicName:   "*",
+			expectedErr: true,
+		},
+		{
+			testName:  "wildcard topic without teams",
+			scope:     Scope{Namespace: "default"},
+			topicName: "*",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			err := test.scope.CheckTopic(test.topicName, test.patternType)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCheckOwner(t *testing.T) {
+	testCases := []struct {
+		testName    string
+		scope       Scope
+		labels      map[string]string
+		expectedErr bool
+	}{
+		{
+			testName: "label of the team owning the namespace",
+			scope:    Scope{Namespace: "payments", Team: "payments"},
+			labels:   map[string]string{v1alpha1.TeamLabelKey: "payments"},
+		},
+		{
+			testName:    "missing team label",
+			scope:       Scope{Namespace: "payments", Team: "payments"},
+			expectedErr: true,
+		},
+		{
+			testName:    "label of another team",
+			scope:       Scope{Namespace: "payments", Team: "payments"},
+			labels:      map[string]string{v1alpha1.TeamLabelKey: "shipping"},
+			expectedErr: true,
+		},
+		{
+			testName: "namespace not owned by any team",
+			scope:    Scope{Namespace: "default"},
+		},
+		{
+			testName:    "team label in a namespace not owned by any team",
+			scope:       Scope{Namespace: "default"},
+			labels:      map[string]string{v1alpha1.TeamLabelKey: "payments"},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			err := test.scope.CheckOwner(&v1alpha1.KafkaUser{ObjectMeta: metav1.ObjectMeta{Labels: test.labels}})
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/banzaicloud/koperator/api/v1alpha1"
 	"github.com/banzaicloud/koperator/api/v1beta1"
 	"github.com/banzaicloud/koperator/pkg/resources/templates"
+	"github.com/banzaicloud/koperator/pkg/util"
 	certutil "github.com/banzaicloud/koperator/pkg/util/cert"
 	"github.com/banzaicloud/koperator/pkg/util/kafka"
 )
@@ -59,6 +60,12 @@ const (
 	// KafkaUserAnnotationName used in case of PKIbackend is k8s-csr to find the appropriate kafkauser in case of
 	// signing request event
 	KafkaUserAnnotationName = "banzaicloud.io/owner"
+	// KeystorePasswordRotatedAtAnnotation holds the time when the JKS password of a secret was last rotated
+	KeystorePasswordRotatedAtAnnotation = "kafka.banzaicloud.io/keystore-password-rotated-at"
+	// KeystorePasswordWrappedAnnotation holds the JKS password of a secret encrypted by the KMS key
+	KeystorePasswordWrappedAnnotation = "kafka.banzaicloud.io/keystore-password-wrapped"
+	// KeystorePasswordRevisionAnnotation is set on the pods using the keystores so they are rolled when the password is rotated
+	KeystorePasswordRevisionAnnotation = "kafka.banzaicloud.io/keystore-password-revision"
 	// MaxCNLen specifies the number of chars that the longest common name can have
 	MaxCNLen = 64
 )
@@ -187,7 +194,9 @@ func LabelsForKafkaPKI(name, namespace string) map[string]string {
 	return map[string]string{v1beta1.AppLabelKey: "kafka", "kafka_issuer": fmt.Sprintf(BrokerClusterIssuerTemplate, namespace, name)}
 }
 
-// BrokerUserForCluster returns a KafkaUser CR for the broker certificates in a KafkaCluster
+// BrokerUserForCluster returns a KafkaUser CR for the broker certificates in a KafkaCluster, valid for the internal DNS
+// names of the cluster, the advertised addresses of the external listeners and the wildcard DNS names derived from
+// their broker hostname templates
 func BrokerUserForCluster(cluster *v1beta1.KafkaCluster, extListenerStatuses map[string]v1beta1.ListenerStatusList) *v1alpha1.KafkaUser {
 	additionalHosts := make([]string, 0, len(extListenerStatuses))
 	for _, listenerStatus := range extListenerStatuses {
@@ -196,6 +205,9 @@ func BrokerUserForCluster(cluster *v1beta1.KafkaCluster, extListenerStatuses map
 			additionalHosts = append(additionalHosts, host)
 		}
 	}
+	for _, eListener := range cluster.Spec.ListenersConfig.ExternalListeners {
+		additionalHosts = append(additionalHosts, externalWildcardDNSNames(cluster, eListener)...)
+	}
 	additionalHosts = sortAndDedupe(additionalHosts)
 	return &v1alpha1.KafkaUser{
 		ObjectMeta: templates.ObjectMeta(EnsureValidCommonNameLen(GetCommonName(cluster)), LabelsForKafkaPKI(cluster.Name, cluster.Namespace), cluster),
@@ -211,6 +223,57 @@ func BrokerUserForCluster(cluster *v1beta1.KafkaCluster, extListenerStatuses map
 	}
 }
 
+// externalWildcardDNSNames returns the wildcard DNS names matching the hostnames the brokers are advertised on through
+// the external listener, derived from its broker hostname templates, so that the server certificate is already valid
+// for the brokers added to the cluster while it is being renewed
+func externalWildcardDNSNames(cluster *v1beta1.KafkaCluster, eListener v1beta1.ExternalListenerConfig) []string {
+	var hostnameTemplates []string
+	switch eListener.GetAccessMethod() {
+	case v1beta1.ServiceTypePerBrokerLoadBalancer:
+		if eListener.PerBrokerLoadBalancerConfig != nil {
+			hostnameTemplates = append(hostnameTemplates, eListener.PerBrokerLoadBalancerConfig.BrokerHostnameTemplate)
+		}
+	default:
+		ingressConfigs, _, err := util.GetIngressConfigs(cluster.Spec, eListener)
+		if err != nil {
+			return nil
+		}
+		for _, iConfig := range ingressConfigs {
+			switch {
+			case eListener.GetAccessMethod() == corev1.ServiceTypeNodePort:
+				// the brokers are advertised on <cluster>-<id>-<listener>.<namespace><hostnameOverride>
+				if iConfig.HostnameOverride != "" {
+					hostnameTemplates = append(hostnameTemplates, fmt.Sprintf("%%id.%s%s", cluster.Namespace, iConfig.HostnameOverride))
+				}
+			case iConfig.EnvoyConfig != nil:
+				hostnameTemplates = append(hostnameTemplates, iConfig.EnvoyConfig.BrokerHostnameTemplate)
+			case iConfig.ContourIngressConfig != nil:
+				hostnameTemplates = append(hostnameTemplates, iConfig.ContourIngressConfig.BrokerFQDNTemplate)
+			case iConfig.GatewayAPIConfig != nil:
+				hostnameTemplates = append(hostnameTemplates, iConfig.GatewayAPIConfig.BrokerHostnameTemplate)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(hostnameTemplates))
+	for _, template := range hostnameTemplates {
+		if name := wildcardDNSName(template); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// wildcardDNSName returns the wildcard DNS name matching the hostnames generated from the broker hostname template,
+// empty when the broker id is not in the first label of the template as a wildcard only covers the first label
+func wildcardDNSName(template string) string {
+	label, domain, found := strings.Cut(template, ".")
+	if !found || domain == "" || !strings.Contains(label, "%id") || strings.Contains(domain, "%id") {
+		return ""
+	}
+	return "*." + domain
+}
+
 func sortAndDedupe(hosts []string) []string {
 	sort.Strings(hosts)
 
@@ -0,0 +1,209 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pki
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	"emperror.dev/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	apiutil "github.com/banzaicloud/koperator/api/util"
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/resources/templates"
+	certutil "github.com/banzaicloud/koperator/pkg/util/cert"
+)
+
+const (
+	// ListenerServerCertTemplate is the template used for the server certificate resources of the listeners with
+	// their own PKI
+	ListenerServerCertTemplate = "%s-%s-server-certificate"
+	// ListenerLabelKey holds the name of the listener on the server certificate resources of the listeners with their
+	// own PKI
+	ListenerLabelKey = "kafka_listener"
+	// ListenerServerCertSourceAnnotation holds the hash of the pre-provisioned certificate chain the keystore and
+	// truststore of a listener were generated from
+	ListenerServerCertSourceAnnotation = "kafka.banzaicloud.io/server-certificate-source"
+)
+
+// ListenerServerCertSecretName returns the name of the secret holding the keystore and truststore of the listener
+func ListenerServerCertSecretName(clusterName string, commonSpec v1beta1.CommonListenerSpec) string {
+	switch {
+	case commonSpec.GetServerSSLCertSecretName() != "":
+		return commonSpec.GetServerSSLCertSecretName()
+	case commonSpec.ServerCertificate != nil:
+		return fmt.Sprintf(ListenerServerCertTemplate, clusterName, commonSpec.Name)
+	default:
+		return fmt.Sprintf(BrokerServerCertTemplate, clusterName)
+	}
+}
+
+// LabelsForListenerPKI returns kubernetes labels for the server certificate resources of a listener with its own PKI
+func LabelsForListenerPKI(name, namespace, listenerName string) map[string]string {
+	return apiutil.MergeLabels(LabelsForKafkaPKI(name, namespace), map[string]string{ListenerLabelKey: listenerName})
+}
+
+// ListenerUserForCluster returns a KafkaUser CR for the server certificate of a listener signed by the issuer of the
+// listener, the certificate is valid for the internal DNS names of the cluster, the hosts of the listener and the
+// wildcard DNS names derived from its broker hostname templates
+func ListenerUserForCluster(cluster *v1beta1.KafkaCluster, commonSpec v1beta1.CommonListenerSpec,
+	extListenerStatuses map[string]v1beta1.ListenerStatusList) *v1alpha1.KafkaUser {
+	additionalHosts := make([]string, 0, len(extListenerStatuses[commonSpec.Name]))
+	for _, status := range extListenerStatuses[commonSpec.Name] {
+		additionalHosts = append(additionalHosts, strings.Split(status.Address, ":")[0])
+	}
+	for _, eListener := range cluster.Spec.ListenersConfig.ExternalListeners {
+		if eListener.Name == commonSpec.Name {
+			additionalHosts = append(additionalHosts, externalWildcardDNSNames(cluster, eListener)...)
+		}
+	}
+	name := fmt.Sprintf(ListenerServerCertTemplate, cluster.Name, commonSpec.Name)
+	return &v1alpha1.KafkaUser{
+		ObjectMeta: templates.ObjectMeta(EnsureValidCommonNameLen(name),
+			LabelsForListenerPKI(cluster.Name, cluster.Namespace, commonSpec.Name), cluster),
+		Spec: v1alpha1.KafkaUserSpec{
+			SecretName: name,
+			DNSNames:   append(GetInternalDNSNames(cluster), sortAndDedupe(additionalHosts)...),
+			IncludeJKS: true,
+			ClusterRef: v1alpha1.ClusterReference{
+				Name:      cluster.Name,
+				Namespace: cluster.Namespace,
+			},
+			PKIBackendSpec: &v1alpha1.PKIBackendSpec{
+				IssuerRef:  commonSpec.ServerCertificate.IssuerRef,
+				PKIBackend: string(v1beta1.PKIBackendCertManager),
+			},
+		},
+	}
+}
+
+// ValidateServerCertChain checks that the private key belongs to the leaf certificate of the PEM encoded chain and
+// that the chain is valid and trusted by the CA certificates. It returns the certificates of the chain and the CA
+// certificates.
+func ValidateServerCertChain(certPEM, keyPEM, caPEM []byte) (chain, caCerts []*x509.Certificate, err error) {
+	if _, err = tls.X509KeyPair(certPEM, keyPEM); err != nil {
+		return nil, nil, errors.WrapIf(err, "private key does not match the certificate")
+	}
+	chainContainers, err := certutil.ParseCertificates(certPEM)
+	if err != nil {
+		return nil, nil, errors.WrapIf(err, "could not parse the certificate chain")
+	}
+	caContainers, err := certutil.ParseCertificates(caPEM)
+	if err != nil {
+		return nil, nil, errors.WrapIf(err, "could not parse the CA certificates")
+	}
+	chain, caCerts = certutil.GetCertBundle(chainContainers), certutil.GetCertBundle(caContainers)
+	if len(caCerts) == 0 {
+		return nil, nil, errors.New("no CA certificate found")
+	}
+
+	roots, intermediates := x509.NewCertPool(), x509.NewCertPool()
+	for _, caCert := range caCerts {
+		roots.AddCert(caCert)
+	}
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err = chain[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return nil, nil, errors.WrapIf(err, "certificate chain is not trusted by the CA certificates")
+	}
+	return chain, caCerts, nil
+}
+
+// serverOnlyCertChain checks that the private key belongs to the leaf certificate of the PEM encoded chain of a
+// listener not authenticating its clients. The chain is not verified as no CA certificate is provided, the top
+// certificate of the chain is returned as the CA certificate of the truststore which is never used to verify a client.
+func serverOnlyCertChain(certPEM, keyPEM []byte) (chain, caCerts []*x509.Certificate, err error) {
+	if _, err = tls.X509KeyPair(certPEM, keyPEM); err != nil {
+		return nil, nil, errors.WrapIf(err, "private key does not match the certificate")
+	}
+	chainContainers, err := certutil.ParseCertificates(certPEM)
+	if err != nil {
+		return nil, nil, errors.WrapIf(err, "could not parse the certificate chain")
+	}
+	chain = certutil.GetCertBundle(chainContainers)
+	return chain, chain[len(chain)-1:], nil
+}
+
+// ListenerKeystoreSecret returns the secret holding the keystore and truststore of the listener generated from the
+// pre-provisioned certificate chain in the given TLS secret. The CA certificates are optional for the listeners with
+// the "none" client authentication.
+func ListenerKeystoreSecret(cluster *v1beta1.KafkaCluster, commonSpec v1beta1.CommonListenerSpec, tlsSecret *corev1.Secret) (*corev1.Secret, error) {
+	var chain, caCerts []*x509.Certificate
+	var err error
+	if len(tlsSecret.Data[v1alpha1.CoreCACertKey]) == 0 && commonSpec.GetSSLClientAuth() == v1beta1.SSLClientAuthNone {
+		chain, caCerts, err = serverOnlyCertChain(tlsSecret.Data[corev1.TLSCertKey], tlsSecret.Data[corev1.TLSPrivateKeyKey])
+	} else {
+		chain, caCerts, err = ValidateServerCertChain(tlsSecret.Data[corev1.TLSCertKey], tlsSecret.Data[corev1.TLSPrivateKeyKey],
+			tlsSecret.Data[v1alpha1.CoreCACertKey])
+	}
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "invalid server certificate", "secret", tlsSecret.Name, "listener", commonSpec.Name)
+	}
+	keyStoreCerts := chain
+	for _, caCert := range caCerts {
+		if !containsCertificate(keyStoreCerts, caCert) {
+			keyStoreCerts = append(keyStoreCerts, caCert)
+		}
+	}
+	keyStore, password, err := certutil.GenerateJKS(keyStoreCerts, tlsSecret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not generate keystore", "listener", commonSpec.Name)
+	}
+	trustStore, err := certutil.GenerateTrustStoreJKS(caCerts, password)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not generate truststore", "listener", commonSpec.Name)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: templates.ObjectMeta(fmt.Sprintf(ListenerServerCertTemplate, cluster.Name, commonSpec.Name),
+			LabelsForListenerPKI(cluster.Name, cluster.Namespace, commonSpec.Name), cluster),
+		Data: map[string][]byte{
+			v1alpha1.TLSJKSKeyStore:   keyStore,
+			v1alpha1.TLSJKSTrustStore: trustStore,
+			v1alpha1.PasswordKey:      password,
+		},
+	}
+	secret.Annotations = map[string]string{ListenerServerCertSourceAnnotation: ListenerServerCertSource(tlsSecret)}
+	return secret, nil
+}
+
+// ListenerServerCertSource returns the hash of the certificate chain, private key and CA certificates in the TLS secret
+func ListenerServerCertSource(tlsSecret *corev1.Secret) string {
+	hash := sha256.New()
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey, v1alpha1.CoreCACertKey} {
+		hash.Write(tlsSecret.Data[key])
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil))
+}
+
+func containsCertificate(certs []*x509.Certificate, cert *x509.Certificate) bool {
+	for _, c := range certs {
+		if c.Equal(cert) {
+			return true
+		}
+	}
+	return false
+}
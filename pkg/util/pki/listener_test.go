@@ -0,0 +1,173 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pki
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	certutil "github.com/banzaicloud/koperator/pkg/util/cert"
+)
+
+// testCertificate returns the PEM encoded certificate and key of a certificate signed by the given parent, the
+// certificate is self-signed if parent is nil
+func testCertificate(t *testing.T, commonName string, isCA bool, parent *x509.Certificate, parentKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey, []byte, []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(raw)
+	require.NoError(t, err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: raw})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return cert, key, certPEM, keyPEM
+}
+
+func TestValidateServerCertChain(t *testing.T) {
+	rootCA, rootKey, rootPEM, _ := testCertificate(t, "root-ca", true, nil, nil)
+	intermediateCA, intermediateKey, intermediatePEM, _ := testCertificate(t, "intermediate-ca", true, rootCA, rootKey)
+	_, _, leafPEM, leafKeyPEM := testCertificate(t, "kafka", false, intermediateCA, intermediateKey)
+	_, _, otherPEM, otherKeyPEM := testCertificate(t, "other-ca", true, nil, nil)
+
+	testCases := []struct {
+		testName         string
+		certPEM          []byte
+		keyPEM           []byte
+		caPEM            []byte
+		expectedChainLen int
+		expectedErr      bool
+	}{
+		{
+			testName:         "chain with intermediate CA",
+			certPEM:          append(append([]byte{}, leafPEM...), intermediatePEM...),
+			keyPEM:           leafKeyPEM,
+			caPEM:            rootPEM,
+			expectedChainLen: 2,
+		},
+		{
+			testName:    "missing intermediate CA",
+			certPEM:     leafPEM,
+			keyPEM:      leafKeyPEM,
+			caPEM:       rootPEM,
+			expectedErr: true,
+		},
+		{
+			testName:    "chain not trusted by the CA",
+			certPEM:     append(append([]byte{}, leafPEM...), intermediatePEM...),
+			keyPEM:      leafKeyPEM,
+			caPEM:       otherPEM,
+			expectedErr: true,
+		},
+		{
+			testName:    "private key of another certificate",
+			certPEM:     append(append([]byte{}, leafPEM...), intermediatePEM...),
+			keyPEM:      otherKeyPEM,
+			caPEM:       rootPEM,
+			expectedErr: true,
+		},
+		{
+			testName:    "missing CA",
+			certPEM:     append(append([]byte{}, leafPEM...), intermediatePEM...),
+			keyPEM:      leafKeyPEM,
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			chain, caCerts, err := ValidateServerCertChain(test.certPEM, test.keyPEM, test.caPEM)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, chain, test.expectedChainLen)
+			require.Equal(t, "kafka", chain[0].Subject.CommonName)
+			require.Equal(t, []*x509.Certificate{rootCA}, caCerts)
+		})
+	}
+}
+
+func TestListenerKeystoreSecret(t *testing.T) {
+	rootCA, rootKey, rootPEM, _ := testCertificate(t, "root-ca", true, nil, nil)
+	_, _, leafPEM, leafKeyPEM := testCertificate(t, "kafka", false, rootCA, rootKey)
+	tlsSecret := &corev1.Secret{Data: map[string][]byte{
+		corev1.TLSCertKey:       leafPEM,
+		corev1.TLSPrivateKeyKey: leafKeyPEM,
+		v1alpha1.CoreCACertKey:  rootPEM,
+	}}
+	listener := v1beta1.CommonListenerSpec{Name: "external", Type: v1beta1.SecurityProtocolSSL,
+		ServerCertificate: &v1beta1.ListenerServerCertificate{TLSSecretName: "external-tls"}}
+
+	secret, err := ListenerKeystoreSecret(testCluster(t), listener, tlsSecret)
+	require.NoError(t, err)
+	require.Equal(t, "test-cluster-external-server-certificate", secret.Name)
+	require.Equal(t, ListenerServerCertSecretName("test-cluster", listener), secret.Name)
+	require.Equal(t, "external", secret.Labels[ListenerLabelKey])
+	require.Equal(t, ListenerServerCertSource(tlsSecret), secret.Annotations[ListenerServerCertSourceAnnotation])
+	require.NoError(t, certutil.CheckSSLCertSecret(secret))
+
+	tlsCert, err := certutil.ParseKeyStoreToTLSCertificate(secret.Data[v1alpha1.TLSJKSKeyStore], secret.Data[v1alpha1.PasswordKey])
+	require.NoError(t, err)
+	require.Equal(t, "kafka", tlsCert.Leaf.Subject.CommonName)
+	caCerts, err := certutil.ParseTrustStoreToCaChain(secret.Data[v1alpha1.TLSJKSTrustStore], secret.Data[v1alpha1.PasswordKey])
+	require.NoError(t, err)
+	require.Equal(t, []*x509.Certificate{rootCA}, caCerts)
+
+	_, _, otherPEM, _ := testCertificate(t, "other-ca", true, nil, nil)
+	tlsSecret.Data[v1alpha1.CoreCACertKey] = otherPEM
+	_, err = ListenerKeystoreSecret(testCluster(t), listener, tlsSecret)
+	require.Error(t, err)
+
+	// the CA certificates are required unless the listener does not authenticate its clients
+	delete(tlsSecret.Data, v1alpha1.CoreCACertKey)
+	_, err = ListenerKeystoreSecret(testCluster(t), listener, tlsSecret)
+	require.Error(t, err)
+
+	listener.SSLClientAuth = v1beta1.SSLClientAuthNone
+	secret, err = ListenerKeystoreSecret(testCluster(t), listener, tlsSecret)
+	require.NoError(t, err)
+	require.NoError(t, certutil.CheckSSLCertSecret(secret))
+	caCerts, err = certutil.ParseTrustStoreToCaChain(secret.Data[v1alpha1.TLSJKSTrustStore], secret.Data[v1alpha1.PasswordKey])
+	require.NoError(t, err)
+	require.Len(t, caCerts, 1)
+	require.Equal(t, "kafka", caCerts[0].Subject.CommonName)
+}
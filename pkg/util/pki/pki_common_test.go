@@ -19,6 +19,8 @@ import (
 	"reflect"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
+
 	"github.com/banzaicloud/koperator/api/v1alpha1"
 	"github.com/banzaicloud/koperator/api/v1beta1"
 	"github.com/banzaicloud/koperator/pkg/resources/templates"
@@ -154,6 +156,60 @@ func TestBrokerUserForCluster(t *testing.T) {
 	}
 }
 
+func TestBrokerUserForClusterExternalWildcards(t *testing.T) {
+	testCases := []struct {
+		testName          string
+		eListener         v1beta1.ExternalListenerConfig
+		envoyConfig       v1beta1.EnvoyConfig
+		expectedWildcards []string
+	}{
+		{
+			testName:          "envoy broker hostname template",
+			eListener:         v1beta1.ExternalListenerConfig{ExternalStartingPort: -1},
+			envoyConfig:       v1beta1.EnvoyConfig{BrokerHostnameTemplate: "broker-%id.kafka.example.com"},
+			expectedWildcards: []string{"*.kafka.example.com"},
+		},
+		{
+			testName:    "broker id not in the first label",
+			eListener:   v1beta1.ExternalListenerConfig{ExternalStartingPort: -1},
+			envoyConfig: v1beta1.EnvoyConfig{BrokerHostnameTemplate: "kafka.broker-%id.example.com"},
+		},
+		{
+			testName: "per broker load balancer hostname template",
+			eListener: v1beta1.ExternalListenerConfig{
+				AccessMethod:                v1beta1.ServiceTypePerBrokerLoadBalancer,
+				PerBrokerLoadBalancerConfig: &v1beta1.PerBrokerLoadBalancerConfig{BrokerHostnameTemplate: "b%id.kafka.example.com"},
+			},
+			expectedWildcards: []string{"*.kafka.example.com"},
+		},
+		{
+			testName: "node port hostname override",
+			eListener: v1beta1.ExternalListenerConfig{
+				AccessMethod:           corev1.ServiceTypeNodePort,
+				IngressServiceSettings: v1beta1.IngressServiceSettings{HostnameOverride: ".example.com"},
+			},
+			expectedWildcards: []string{"*.test-namespace.example.com"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			cluster := testCluster(t)
+			cluster.Spec.EnvoyConfig = test.envoyConfig
+			test.eListener.Name = "external"
+			cluster.Spec.ListenersConfig.ExternalListeners = []v1beta1.ExternalListenerConfig{test.eListener}
+			user := BrokerUserForCluster(cluster, map[string]v1beta1.ListenerStatusList{
+				"external": {{Name: "broker-0", Address: "broker-0.kafka.example.com:9094"}},
+			})
+
+			expected := append(GetInternalDNSNames(cluster), sortAndDedupe(append(test.expectedWildcards, "broker-0.kafka.example.com"))...)
+			if !reflect.DeepEqual(user.Spec.DNSNames, expected) {
+				t.Errorf("Expected %v\nGot %v", expected, user.Spec.DNSNames)
+			}
+		})
+	}
+}
+
 func TestControllerUserForCluster(t *testing.T) {
 	cluster := testCluster(t)
 	user := ControllerUserForCluster(cluster)
@@ -0,0 +1,110 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+const (
+	queryAPIPath = "/api/v1/query"
+
+	// ResultTypeVector is the result type of the instant queries returning a series per label set
+	ResultTypeVector = "vector"
+	// ResultTypeMatrix is the result type of the range vector queries
+	ResultTypeMatrix = "matrix"
+	// ResultTypeScalar is the result type of the queries returning a single number
+	ResultTypeScalar = "scalar"
+)
+
+// QueryResult is the result of an instant query
+type QueryResult struct {
+	ResultType string          `json:"resultType"`
+	Result     json.RawMessage `json:"result"`
+}
+
+// queryResponse is the response of the instant query API of Prometheus
+type queryResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	Data   QueryResult `json:"data"`
+}
+
+// Query runs the PromQL instant query against the Prometheus API at the given base URL
+func Query(ctx context.Context, httpClient *http.Client, prometheusURL, query string, timeout time.Duration) (*QueryResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	queryURL := strings.TrimSuffix(prometheusURL, "/") + queryAPIPath + "?" + url.Values{"query": {query}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, queryURL, nil)
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not create Prometheus query request")
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not query Prometheus")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var queryResp queryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&queryResp); err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not parse Prometheus response", "statusCode", resp.StatusCode)
+	}
+	if queryResp.Status != "success" {
+		return nil, errors.NewWithDetails("Prometheus query failed", "error", queryResp.Error)
+	}
+	return &queryResp.Data, nil
+}
+
+// SeriesCount returns the number of series of a vector or matrix result
+func (r *QueryResult) SeriesCount() (int, error) {
+	if r.ResultType != ResultTypeVector && r.ResultType != ResultTypeMatrix {
+		return 0, errors.NewWithDetails("unsupported Prometheus result type", "resultType", r.ResultType)
+	}
+	var series []json.RawMessage
+	if err := json.Unmarshal(r.Result, &series); err != nil {
+		return 0, errors.WrapIfWithDetails(err, "could not parse Prometheus result", "resultType", r.ResultType)
+	}
+	return len(series), nil
+}
+
+// Scalar returns the value of a scalar result
+func (r *QueryResult) Scalar() (float64, error) {
+	if r.ResultType != ResultTypeScalar {
+		return 0, errors.NewWithDetails("unsupported Prometheus result type", "resultType", r.ResultType)
+	}
+	var sample []interface{}
+	if err := json.Unmarshal(r.Result, &sample); err != nil || len(sample) != 2 {
+		return 0, errors.New("could not parse Prometheus scalar result")
+	}
+	value, ok := sample[1].(string)
+	if !ok {
+		return 0, errors.New("could not parse Prometheus scalar result")
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, errors.WrapIf(err, "could not parse Prometheus scalar result")
+	}
+	return v, nil
+}
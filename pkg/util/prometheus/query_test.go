@@ -0,0 +1,89 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery(t *testing.T) {
+	testCases := []struct {
+		testName            string
+		response            string
+		expectedErr         bool
+		expectedSeriesCount int
+		expectedScalar      float64
+	}{
+		{
+			testName:            "vector",
+			response:            `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"3"]}]}}`,
+			expectedSeriesCount: 1,
+		},
+		{
+			testName:            "empty vector",
+			response:            `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+			expectedSeriesCount: 0,
+		},
+		{
+			testName:       "scalar",
+			response:       `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"2.5"]}}`,
+			expectedScalar: 2.5,
+		},
+		{
+			testName:    "failed query",
+			response:    `{"status":"error","error":"parse error"}`,
+			expectedErr: true,
+		},
+		{
+			testName:    "invalid response",
+			response:    `not json`,
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/api/v1/query", r.URL.Path)
+				require.Equal(t, "up", r.URL.Query().Get("query"))
+				_, _ = w.Write([]byte(test.response))
+			}))
+			defer server.Close()
+
+			result, err := Query(context.Background(), server.Client(), server.URL+"/", "up", time.Second)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if result.ResultType == ResultTypeScalar {
+				value, err := result.Scalar()
+				require.NoError(t, err)
+				require.Equal(t, test.expectedScalar, value)
+				return
+			}
+			count, err := result.SeriesCount()
+			require.NoError(t, err)
+			require.Equal(t, test.expectedSeriesCount, count)
+		})
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package readiness
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"emperror.dev/errors"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+)
+
+var (
+	comparisonRegex = regexp.MustCompile(`^([a-zA-Z_:][a-zA-Z0-9_:]*)\s*(?:\{([^}]*)\})?\s*(==|!=|<=|>=|<|>)\s*(\S+)$`)
+	labelRegex      = regexp.MustCompile(`^([a-zA-Z_][a-zA-Z0-9_]*)\s*=\s*"([^"]*)"$`)
+)
+
+// Expression is a broker readiness expression: comparisons of broker metrics which must all hold
+type Expression struct {
+	comparisons []comparison
+}
+
+type comparison struct {
+	text   string
+	metric string
+	labels map[string]string
+	op     string
+	value  float64
+}
+
+// Parse parses a broker readiness expression of comparisons joined with &&
+func Parse(expression string) (*Expression, error) {
+	expr := &Expression{}
+	for _, text := range strings.Split(expression, "&&") {
+		text = strings.TrimSpace(text)
+		match := comparisonRegex.FindStringSubmatch(text)
+		if match == nil {
+			return nil, errors.NewWithDetails("invalid comparison, expected `metric_name{label=\"value\"} <op> <number>`", "comparison", text)
+		}
+		value, err := strconv.ParseFloat(match[4], 64)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "invalid number", "comparison", text)
+		}
+		labels, err := parseLabels(match[2])
+		if err != nil {
+			return nil, errors.WithDetails(err, "comparison", text)
+		}
+		expr.comparisons = append(expr.comparisons, comparison{
+			text:   text,
+			metric: match[1],
+			labels: labels,
+			op:     match[3],
+			value:  value,
+		})
+	}
+	return expr, nil
+}
+
+func parseLabels(selector string) (map[string]string, error) {
+	if strings.TrimSpace(selector) == "" {
+		return nil, nil
+	}
+	labels := make(map[string]string)
+	for _, label := range strings.Split(selector, ",") {
+		match := labelRegex.FindStringSubmatch(strings.TrimSpace(label))
+		if match == nil {
+			return nil, errors.NewWithDetails("invalid label matcher, expected `label=\"value\"`", "label", label)
+		}
+		labels[match[1]] = match[2]
+	}
+	return labels, nil
+}
+
+// ParseMetrics parses metrics in the Prometheus text format
+func ParseMetrics(in io.Reader) (map[string]*dto.MetricFamily, error) {
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	return parser.TextToMetricFamilies(in)
+}
+
+// Evaluate returns true if every comparison of the expression holds for the given metrics, otherwise it returns
+// false and the reason
+func (e *Expression) Evaluate(metrics map[string]*dto.MetricFamily) (bool, string) {
+	for _, c := range e.comparisons {
+		family, ok := metrics[c.metric]
+		if !ok {
+			return false, fmt.Sprintf("metric %s not found", c.metric)
+		}
+		matched := false
+		for _, metric := range family.GetMetric() {
+			if !matchesLabels(metric, c.labels) {
+				continue
+			}
+			matched = true
+			value := metricValue(metric)
+			if !compare(value, c.op, c.value) {
+				return false, fmt.Sprintf("%s does not hold, value is %v", c.text, value)
+			}
+		}
+		if !matched {
+			return false, fmt.Sprintf("metric %s has no series matching the labels of %s", c.metric, c.text)
+		}
+	}
+	return true, ""
+}
+
+func matchesLabels(metric *dto.Metric, labels map[string]string) bool {
+	found := 0
+	for _, pair := range metric.GetLabel() {
+		if value, ok := labels[pair.GetName()]; ok {
+			if value != pair.GetValue() {
+				return false
+			}
+			found++
+		}
+	}
+	return found == len(labels)
+}
+
+func metricValue(metric *dto.Metric) float64 {
+	switch {
+	case metric.GetGauge() != nil:
+		return metric.GetGauge().GetValue()
+	case metric.GetCounter() != nil:
+		return metric.GetCounter().GetValue()
+	default:
+		return metric.GetUntyped().GetValue()
+	}
+}
+
+func compare(value float64, op string, threshold float64) bool {
+	switch op {
+	case "==":
+		return value == threshold
+	case "!=":
+		return value != threshold
+	case "<":
+		return value < threshold
+	case "<=":
+		return value <= threshold
+	case ">":
+		return value > threshold
+	case ">=":
+		return value >= threshold
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package readiness
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const brokerMetrics = `# TYPE kafka_server_replicamanager_underreplicatedpartitions gauge
+kafka_server_replicamanager_underreplicatedpartitions 0.0
+# TYPE kafka_server_kafkaserver_brokerstate gauge
+kafka_server_kafkaserver_brokerstate 3.0
+# TYPE kafka_network_requestmetrics_requests_total counter
+kafka_network_requestmetrics_requests_total{request="Produce",version="9"} 120.0
+kafka_network_requestmetrics_requests_total{request="Fetch",version="13"} 80.0
+`
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		testName    string
+		expression  string
+		expectedErr bool
+	}{
+		{
+			testName:   "single comparison",
+			expression: "kafka_server_kafkaserver_brokerstate == 3",
+		},
+		{
+			testName:   "comparisons with label selector",
+			expression: `kafka_server_replicamanager_underreplicatedpartitions == 0 && kafka_network_requestmetrics_requests_total{request="Fetch", version="13"} >= 1`,
+		},
+		{
+			testName:    "missing operator",
+			expression:  "kafka_server_kafkaserver_brokerstate 3",
+			expectedErr: true,
+		},
+		{
+			testName:    "invalid number",
+			expression:  "kafka_server_kafkaserver_brokerstate == running",
+			expectedErr: true,
+		},
+		{
+			testName:    "invalid label matcher",
+			expression:  "kafka_network_requestmetrics_requests_total{request=Fetch} > 0",
+			expectedErr: true,
+		},
+		{
+			testName:    "dangling conjunction",
+			expression:  "kafka_server_kafkaserver_brokerstate == 3 &&",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			_, err := Parse(test.expression)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	metrics, err := ParseMetrics(strings.NewReader(brokerMetrics))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		testName      string
+		expression    string
+		expectedReady bool
+	}{
+		{
+			testName:      "every comparison holds",
+			expression:    "kafka_server_replicamanager_underreplicatedpartitions == 0 && kafka_server_kafkaserver_brokerstate == 3",
+			expectedReady: true,
+		},
+		{
+			testName:   "comparison does not hold",
+			expression: "kafka_server_replicamanager_underreplicatedpartitions == 0 && kafka_server_kafkaserver_brokerstate != 3",
+		},
+		{
+			testName:      "every series matching the selector holds",
+			expression:    "kafka_network_requestmetrics_requests_total > 50",
+			expectedReady: true,
+		},
+		{
+			testName:   "one of the series does not hold",
+			expression: "kafka_network_requestmetrics_requests_total > 100",
+		},
+		{
+			testName:      "series selected by labels",
+			expression:    `kafka_network_requestmetrics_requests_total{request="Produce"} > 100`,
+			expectedReady: true,
+		},
+		{
+			testName:   "no series matching the selector",
+			expression: `kafka_network_requestmetrics_requests_total{request="Metadata"} > 0`,
+		},
+		{
+			testName:   "missing metric",
+			expression: "kafka_controller_kafkacontroller_activecontrollercount == 1",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			expression, err := Parse(test.expression)
+			require.NoError(t, err)
+			ready, message := expression.Evaluate(metrics)
+			require.Equal(t, test.expectedReady, ready)
+			require.Equal(t, test.expectedReady, message == "")
+		})
+	}
+}
@@ -0,0 +1,317 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restarthooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+	"github.com/banzaicloud/koperator/pkg/k8sutil"
+	"github.com/banzaicloud/koperator/pkg/resources/templates"
+	"github.com/banzaicloud/koperator/pkg/util"
+	"github.com/banzaicloud/koperator/pkg/util/prometheus"
+)
+
+// Phase is the phase of the broker restart a hook is run at
+type Phase string
+
+const (
+	// PreRestart hooks are run before the broker is restarted
+	PreRestart Phase = "pre-restart"
+	// PostRestart hooks are run after the broker was restarted
+	PostRestart Phase = "post-restart"
+
+	jobAppLabelValue  = "kafka-restart-hook"
+	jobContainerName  = "hook"
+	maxJobNameLength  = 63
+	clusterNameEnvVar = "CLUSTER_NAME"
+	namespaceEnvVar   = "NAMESPACE"
+	brokerIDEnvVar    = "BROKER_ID"
+)
+
+// Runner runs the restart hooks of the brokers of a cluster
+type Runner struct {
+	// Client creates and deletes the Jobs of the job hooks
+	Client client.Client
+	// Reader reads the Jobs of the job hooks
+	Reader client.Reader
+	// HTTPClient sends the requests of the HTTP and PromQL hooks
+	HTTPClient *http.Client
+}
+
+// NewRunner returns a Runner sending the requests of the hooks with the default HTTP client
+func NewRunner(c client.Client, reader client.Reader) *Runner {
+	return &Runner{
+		Client:     c,
+		Reader:     reader,
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+// Run runs the hooks of the phase for the broker in order. It returns a ReconcileRollingUpgrade error when a hook
+// has not passed (yet), the hooks are run again on the next reconcile.
+func (r *Runner) Run(ctx context.Context, log logr.Logger, cluster *v1beta1.KafkaCluster, phase Phase, brokerID string) error {
+	hooks := cluster.Spec.RollingUpgradeConfig.GetPreRestartHooks()
+	if phase == PostRestart {
+		hooks = cluster.Spec.RollingUpgradeConfig.GetPostRestartHooks()
+	}
+	for _, hook := range hooks {
+		passed, reason, err := r.run(ctx, cluster, phase, brokerID, hook)
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "could not run restart hook", "phase", phase, "hook", hook.Name, v1beta1.BrokerIdLabelKey, brokerID)
+		}
+		if !passed {
+			return errorfactory.New(errorfactory.ReconcileRollingUpgrade{}, errors.New(reason), "restart hook has not passed",
+				"phase", phase, "hook", hook.Name, v1beta1.BrokerIdLabelKey, brokerID)
+		}
+		log.Info("restart hook passed", "phase", phase, "hook", hook.Name, v1beta1.BrokerIdLabelKey, brokerID)
+	}
+	return nil
+}
+
+// RunPendingPostRestart runs the post-restart hooks of the brokers restarted by the rolling upgrade which have not
+// passed them yet, except the brokers for which skip returns true, and records the brokers passing them in their status
+func (r *Runner) RunPendingPostRestart(ctx context.Context, log logr.Logger, cluster *v1beta1.KafkaCluster, skip func(brokerID string) bool) error {
+	var brokerIDs []string
+	for brokerID, brokerState := range cluster.Status.BrokersState {
+		if brokerState.RestartHooksState == v1beta1.PostRestartHooksPending && (skip == nil || !skip(brokerID)) {
+			brokerIDs = append(brokerIDs, brokerID)
+		}
+	}
+	sort.Strings(brokerIDs)
+
+	for _, brokerID := range brokerIDs {
+		if err := r.Run(ctx, log, cluster, PostRestart, brokerID); err != nil {
+			return err
+		}
+		if err := k8sutil.UpdateBrokerStatus(r.Client, []string{brokerID}, cluster, v1beta1.PostRestartHooksPassed, log); err != nil {
+			return errorfactory.New(errorfactory.StatusUpdateError{}, err, "could not update restart hooks state", v1beta1.BrokerIdLabelKey, brokerID)
+		}
+	}
+	return nil
+}
+
+// run runs the hook and returns whether it passed, and the reason if not
+func (r *Runner) run(ctx context.Context, cluster *v1beta1.KafkaCluster, phase Phase, brokerID string, hook v1beta1.RollingUpgradeHook) (bool, string, error) {
+	expand := placeholderReplacer(cluster, brokerID).Replace
+	switch {
+	case hook.HTTP != nil:
+		passed, reason := r.runHTTP(ctx, expand(hook.HTTP.URL), hook)
+		return passed, reason, nil
+	case hook.PromQL != nil:
+		passed, reason := r.runPromQL(ctx, hook.PromQL.PrometheusURL, expand(hook.PromQL.Query), hook)
+		return passed, reason, nil
+	case hook.Job != nil:
+		return r.runJob(ctx, cluster, phase, brokerID, hook, expand)
+	default:
+		return false, "", errors.New("restart hook has no http, promQL or job check")
+	}
+}
+
+func (r *Runner) runHTTP(ctx context.Context, hookURL string, hook v1beta1.RollingUpgradeHook) (bool, string) {
+	resp, err := r.get(ctx, hookURL, hook)
+	if err != nil {
+		return false, err.Error()
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return false, fmt.Sprintf("unexpected status code %d", resp.StatusCode)
+	}
+	return true, ""
+}
+
+func (r *Runner) runPromQL(ctx context.Context, prometheusURL, query string, hook v1beta1.RollingUpgradeHook) (bool, string) {
+	result, err := prometheus.Query(ctx, r.HTTPClient, prometheusURL, query, hook.GetTimeout())
+	if err != nil {
+		return false, err.Error()
+	}
+	return promQLResultPassed(result.ResultType, result.Result)
+}
+
+// promQLResultPassed returns true if the query result is a non-empty vector or matrix, or a non-zero scalar
+func promQLResultPassed(resultType string, result json.RawMessage) (bool, string) {
+	queryResult := &prometheus.QueryResult{ResultType: resultType, Result: result}
+	switch resultType {
+	case prometheus.ResultTypeVector, prometheus.ResultTypeMatrix:
+		count, err := queryResult.SeriesCount()
+		if err != nil {
+			return false, err.Error()
+		}
+		if count == 0 {
+			return false, "query returned an empty result"
+		}
+		return true, ""
+	case prometheus.ResultTypeScalar:
+		value, err := queryResult.Scalar()
+		if err != nil {
+			return false, err.Error()
+		}
+		if value == 0 {
+			return false, "query returned 0"
+		}
+		return true, ""
+	default:
+		return false, fmt.Sprintf("unsupported Prometheus result type %q", resultType)
+	}
+}
+
+func (r *Runner) get(ctx context.Context, getURL string, hook v1beta1.RollingUpgradeHook) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, hook.GetTimeout())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp, err := r.HTTPClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose cancels the context of the request once its response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
+// runJob creates the Job of the hook unless it exists, and returns true once it completed successfully. Completed
+// Jobs are deleted so that the hook runs again on the next restart.
+func (r *Runner) runJob(ctx context.Context, cluster *v1beta1.KafkaCluster, phase Phase, brokerID string,
+	hook v1beta1.RollingUpgradeHook, expand func(string) string) (bool, string, error) {
+	name := jobName(cluster.Name, brokerID, phase, hook.Name)
+	job := &batchv1.Job{}
+	err := r.Reader.Get(ctx, client.ObjectKey{Name: name, Namespace: cluster.Namespace}, job)
+	switch {
+	case apierrors.IsNotFound(err):
+		job = newJob(name, cluster, phase, brokerID, hook, expand)
+		if err := r.Client.Create(ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+			return false, "", errors.WrapIfWithDetails(err, "could not create restart hook job", "job", name)
+		}
+		return false, fmt.Sprintf("job %s created", name), nil
+	case err != nil:
+		return false, "", errors.WrapIfWithDetails(err, "could not get restart hook job", "job", name)
+	case job.GetDeletionTimestamp() != nil:
+		return false, fmt.Sprintf("job %s of the previous run is being deleted", name), nil
+	}
+
+	if job.Status.Succeeded > 0 {
+		return true, "", r.deleteJob(ctx, job)
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			// the job is run again on the next reconcile
+			return false, fmt.Sprintf("job %s failed: %s", name, condition.Message), r.deleteJob(ctx, job)
+		}
+	}
+	return false, fmt.Sprintf("job %s is running", name), nil
+}
+
+func (r *Runner) deleteJob(ctx context.Context, job *batchv1.Job) error {
+	err := r.Client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if client.IgnoreNotFound(err) != nil {
+		return errors.WrapIfWithDetails(err, "could not delete restart hook job", "job", job.Name)
+	}
+	return nil
+}
+
+func newJob(name string, cluster *v1beta1.KafkaCluster, phase Phase, brokerID string, hook v1beta1.RollingUpgradeHook,
+	expand func(string) string) *batchv1.Job {
+	labels := map[string]string{
+		v1beta1.AppLabelKey:      jobAppLabelValue,
+		v1beta1.KafkaCRLabelKey:  cluster.Name,
+		v1beta1.BrokerIdLabelKey: brokerID,
+	}
+	env := append([]corev1.EnvVar{
+		{Name: clusterNameEnvVar, Value: cluster.Name},
+		{Name: namespaceEnvVar, Value: cluster.Namespace},
+		{Name: brokerIDEnvVar, Value: brokerID},
+	}, hook.Job.Env...)
+
+	return &batchv1.Job{
+		ObjectMeta: templates.ObjectMeta(name, labels, cluster),
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          util.Int32Pointer(0),
+			ActiveDeadlineSeconds: util.Int64Pointer(int64(hook.GetTimeout().Seconds())),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: hook.Job.ServiceAccountName,
+					Containers: []corev1.Container{{
+						Name:    jobContainerName,
+						Image:   hook.Job.Image,
+						Command: expandAll(hook.Job.Command, expand),
+						Args:    expandAll(hook.Job.Args, expand),
+						Env:     env,
+					}},
+				},
+			},
+		},
+	}
+}
+
+// jobName returns the name of the Job of the hook, the cluster name is shortened to fit in a label value
+func jobName(clusterName, brokerID string, phase Phase, hookName string) string {
+	suffix := fmt.Sprintf("-%s-%s-%s", brokerID, phase, hookName)
+	if len(clusterName)+len(suffix) > maxJobNameLength {
+		clusterName = strings.TrimSuffix(clusterName[:maxJobNameLength-len(suffix)], "-")
+	}
+	return clusterName + suffix
+}
+
+func placeholderReplacer(cluster *v1beta1.KafkaCluster, brokerID string) *strings.Replacer {
+	return strings.NewReplacer(
+		fmt.Sprintf("$(%s)", clusterNameEnvVar), cluster.Name,
+		fmt.Sprintf("$(%s)", namespaceEnvVar), cluster.Namespace,
+		fmt.Sprintf("$(%s)", brokerIDEnvVar), brokerID,
+	)
+}
+
+func expandAll(values []string, expand func(string) string) []string {
+	if values == nil {
+		return nil
+	}
+	expanded := make([]string, 0, len(values))
+	for _, value := range values {
+		expanded = append(expanded, expand(value))
+	}
+	return expanded
+}
@@ -0,0 +1,249 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restarthooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/errorfactory"
+)
+
+func testCluster(hooks *v1beta1.RollingUpgradeHooks) *v1beta1.KafkaCluster {
+	return &v1beta1.KafkaCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka"},
+		Spec:       v1beta1.KafkaClusterSpec{RollingUpgradeConfig: v1beta1.RollingUpgradeConfig{Hooks: hooks}},
+	}
+}
+
+func TestRunHTTPAndPromQLHooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ready/kafka/1":
+			w.WriteHeader(http.StatusOK)
+		case "/api/v1/query":
+			result := `[]`
+			if r.URL.Query().Get("query") == `kafka_consumergroup_lag{broker="1"} < 100` {
+				result = `[{"metric":{},"value":[1700000000,"42"]}]`
+			}
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":` + result + `}}`))
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		testName       string
+		hook           v1beta1.RollingUpgradeHook
+		expectedPassed bool
+	}{
+		{
+			testName:       "http hook passes with placeholders replaced",
+			hook:           v1beta1.RollingUpgradeHook{Name: "ready", HTTP: &v1beta1.HTTPRestartHook{URL: server.URL + "/ready/$(CLUSTER_NAME)/$(BROKER_ID)"}},
+			expectedPassed: true,
+		},
+		{
+			testName: "http hook fails on unexpected status code",
+			hook:     v1beta1.RollingUpgradeHook{Name: "ready", HTTP: &v1beta1.HTTPRestartHook{URL: server.URL + "/unavailable"}},
+		},
+		{
+			testName: "promql hook passes on non-empty vector",
+			hook: v1beta1.RollingUpgradeHook{Name: "lag", PromQL: &v1beta1.PromQLRestartHook{
+				PrometheusURL: server.URL + "/", Query: `kafka_consumergroup_lag{broker="$(BROKER_ID)"} < 100`}},
+			expectedPassed: true,
+		},
+		{
+			testName: "promql hook fails on empty vector",
+			hook: v1beta1.RollingUpgradeHook{Name: "lag", PromQL: &v1beta1.PromQLRestartHook{
+				PrometheusURL: server.URL, Query: `kafka_consumergroup_lag{broker="$(BROKER_ID)"} < 10`}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			cluster := testCluster(&v1beta1.RollingUpgradeHooks{PreRestart: []v1beta1.RollingUpgradeHook{test.hook}})
+			runner := NewRunner(nil, nil)
+
+			err := runner.Run(context.Background(), logr.Discard(), cluster, PreRestart, "1")
+			if test.expectedPassed {
+				require.NoError(t, err)
+				return
+			}
+			require.True(t, errors.As(err, &errorfactory.ReconcileRollingUpgrade{}))
+		})
+	}
+}
+
+func TestPromQLResultPassed(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		resultType     string
+		result         string
+		expectedPassed bool
+	}{
+		{
+			testName:       "non-empty matrix",
+			resultType:     "matrix",
+			result:         `[{"metric":{},"values":[[1700000000,"1"]]}]`,
+			expectedPassed: true,
+		},
+		{
+			testName:       "non-zero scalar",
+			resultType:     "scalar",
+			result:         `[1700000000,"1"]`,
+			expectedPassed: true,
+		},
+		{
+			testName:   "zero scalar",
+			resultType: "scalar",
+			result:     `[1700000000,"0"]`,
+		},
+		{
+			testName:   "string result",
+			resultType: "string",
+			result:     `[1700000000,"1"]`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			passed, _ := promQLResultPassed(test.resultType, json.RawMessage(test.result))
+			require.Equal(t, test.expectedPassed, passed)
+		})
+	}
+}
+
+func TestRunJobHook(t *testing.T) {
+	cluster := testCluster(&v1beta1.RollingUpgradeHooks{PostRestart: []v1beta1.RollingUpgradeHook{{
+		Name: "check",
+		Job:  &v1beta1.JobRestartHook{Image: "busybox", Command: []string{"check", "--broker=$(BROKER_ID)"}},
+	}}})
+	c := fake.NewClientBuilder().Build()
+	runner := NewRunner(c, c)
+	ctx := context.Background()
+	key := client.ObjectKey{Name: "kafka-2-post-restart-check", Namespace: "kafka"}
+
+	// the job is created on the first run
+	err := runner.Run(ctx, logr.Discard(), cluster, PostRestart, "2")
+	require.True(t, errors.As(err, &errorfactory.ReconcileRollingUpgrade{}))
+	job := &batchv1.Job{}
+	require.NoError(t, c.Get(ctx, key, job))
+	require.Equal(t, []string{"check", "--broker=2"}, job.Spec.Template.Spec.Containers[0].Command)
+	require.Equal(t, int64(300), *job.Spec.ActiveDeadlineSeconds)
+
+	// the failed job is deleted to run it again
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "exit code 1"}}
+	require.NoError(t, c.Status().Update(ctx, job))
+	err = runner.Run(ctx, logr.Discard(), cluster, PostRestart, "2")
+	require.ErrorContains(t, err, "exit code 1")
+	require.True(t, apierrors.IsNotFound(c.Get(ctx, key, &batchv1.Job{})))
+
+	// the succeeded job passes and is deleted
+	err = runner.Run(ctx, logr.Discard(), cluster, PostRestart, "2")
+	require.True(t, errors.As(err, &errorfactory.ReconcileRollingUpgrade{}))
+	require.NoError(t, c.Get(ctx, key, job))
+	job.Status.Succeeded = 1
+	require.NoError(t, c.Status().Update(ctx, job))
+	require.NoError(t, runner.Run(ctx, logr.Discard(), cluster, PostRestart, "2"))
+	require.True(t, apierrors.IsNotFound(c.Get(ctx, key, &batchv1.Job{})))
+}
+
+func TestRunPendingPostRestart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("broker") == "2" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	testCases := []struct {
+		testName        string
+		skippedBrokerID string
+		expectedStates  map[string]v1beta1.RestartHooksState
+		expectedErr     bool
+	}{
+		{
+			testName: "brokers passing the hooks are recorded until one fails",
+			expectedStates: map[string]v1beta1.RestartHooksState{
+				"0": v1beta1.PostRestartHooksPassed,
+				"1": v1beta1.PostRestartHooksPassed,
+				"2": v1beta1.PostRestartHooksPending,
+			},
+			expectedErr: true,
+		},
+		{
+			testName:        "skipped brokers stay pending",
+			skippedBrokerID: "2",
+			expectedStates: map[string]v1beta1.RestartHooksState{
+				"0": v1beta1.PostRestartHooksPassed,
+				"1": v1beta1.PostRestartHooksPassed,
+				"2": v1beta1.PostRestartHooksPending,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			cluster := testCluster(&v1beta1.RollingUpgradeHooks{PostRestart: []v1beta1.RollingUpgradeHook{{
+				Name: "ready", HTTP: &v1beta1.HTTPRestartHook{URL: server.URL + "/?broker=$(BROKER_ID)"},
+			}}})
+			cluster.Status.BrokersState = map[string]v1beta1.BrokerState{
+				"0": {RestartHooksState: v1beta1.PostRestartHooksPassed},
+				"1": {RestartHooksState: v1beta1.PostRestartHooksPending},
+				"2": {RestartHooksState: v1beta1.PostRestartHooksPending},
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster).WithStatusSubresource(cluster).Build()
+
+			err := NewRunner(c, c).RunPendingPostRestart(context.Background(), logr.Discard(), cluster, func(brokerID string) bool {
+				return brokerID == test.skippedBrokerID
+			})
+			if test.expectedErr {
+				require.True(t, errors.As(err, &errorfactory.ReconcileRollingUpgrade{}))
+			} else {
+				require.NoError(t, err)
+			}
+
+			stored := &v1beta1.KafkaCluster{}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(cluster), stored))
+			states := make(map[string]v1beta1.RestartHooksState)
+			for brokerID, brokerState := range stored.Status.BrokersState {
+				states[brokerID] = brokerState.RestartHooksState
+			}
+			require.Equal(t, test.expectedStates, states)
+		})
+	}
+}
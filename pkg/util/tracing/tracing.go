@@ -0,0 +1,163 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"emperror.dev/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	instrumentationName = "github.com/banzaicloud/koperator"
+	serviceName         = "koperator"
+
+	// The attributes of the spans
+	controllerAttribute = "koperator.controller"
+	namespaceAttribute  = "k8s.namespace.name"
+	nameAttribute       = "koperator.resource.name"
+	componentAttribute  = "koperator.component"
+	clusterAttribute    = "koperator.kafka_cr"
+)
+
+// Options configures the export of the spans of the operator
+type Options struct {
+	// Endpoint is the host:port of the OTLP/HTTP receiver the spans are exported to, tracing is disabled when empty
+	Endpoint string
+	// Insecure exports the spans over plain HTTP instead of HTTPS
+	Insecure bool
+	// SampleRatio is the ratio of the traces sampled, the sampling decision of the parent span is respected
+	SampleRatio float64
+}
+
+// Setup installs the global tracer provider exporting the spans to the configured OTLP endpoint and returns the
+// function flushing the pending spans on shutdown. Without an endpoint the spans are not recorded at all.
+func Setup(ctx context.Context, options Options) (func(context.Context) error, error) {
+	if options.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporterOptions := []otlptracehttp.Option{otlptracehttp.WithEndpoint(options.Endpoint)}
+	if options.Insecure {
+		exporterOptions = append(exporterOptions, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, exporterOptions...)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not create the OTLP trace exporter", "endpoint", options.Endpoint)
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, errors.WrapIf(err, "could not create the resource of the operator")
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(options.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// Start starts a span of the operator as the child of the span of the context
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End records the error of the operation of the span, if any, and ends the span
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// StartReconcile starts the span of the reconcile of the custom resource of the request by the controller
+func StartReconcile(ctx context.Context, controller string, request reconcile.Request) (context.Context, trace.Span) {
+	return Start(ctx, "Reconcile "+controller,
+		attribute.String(controllerAttribute, controller),
+		attribute.String(namespaceAttribute, request.Namespace),
+		attribute.String(nameAttribute, request.Name),
+	)
+}
+
+// ClusterAttributes returns the attributes identifying the KafkaCluster the operation of a span targets
+func ClusterAttributes(namespace, name string) []attribute.KeyValue {
+	return []attribute.KeyValue{attribute.String(namespaceAttribute, namespace), attribute.String(clusterAttribute, name)}
+}
+
+// Run runs fn in a child span of the span of the context, the error of fn is recorded on the span
+func Run(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	ctx, span := Start(ctx, name)
+	err := fn(ctx)
+	End(span, err)
+	return err
+}
+
+// WrapTransport returns a transport tracing the requests sent to the component in client spans, the trace context is
+// propagated in the headers of the requests
+func WrapTransport(component string, transport http.RoundTripper) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &tracingTransport{component: component, transport: transport}
+}
+
+type tracingTransport struct {
+	component string
+	transport http.RoundTripper
+}
+
+// RoundTrip sends the request in a client span named after the component and the method of the request
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := otel.Tracer(instrumentationName).Start(req.Context(), t.component+" "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String(componentAttribute, t.component),
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.URLPath(req.URL.Path),
+			semconv.ServerAddress(req.URL.Hostname()),
+		),
+	)
+	defer span.End()
+
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, nil
+}
@@ -0,0 +1,110 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestWrapTransport(t *testing.T) {
+	tests := []struct {
+		testName       string
+		statusCode     int
+		expectedStatus codes.Code
+	}{
+		{
+			testName:       "successful request",
+			statusCode:     http.StatusOK,
+			expectedStatus: codes.Unset,
+		},
+		{
+			testName:       "server error",
+			statusCode:     http.StatusInternalServerError,
+			expectedStatus: codes.Error,
+		},
+	}
+
+	defer func(provider trace.TracerProvider, propagator propagation.TextMapPropagator) {
+		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagator)
+	}(otel.GetTracerProvider(), otel.GetTextMapPropagator())
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			var traceparent string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				traceparent = r.Header.Get("traceparent")
+				w.WriteHeader(test.statusCode)
+			}))
+			defer server.Close()
+
+			ctx, parent := Start(context.Background(), "parent")
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/kafkacruisecontrol/state", nil)
+			require.NoError(t, err)
+			resp, err := (&http.Client{Transport: WrapTransport("cruise-control", nil)}).Do(req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			parent.End()
+
+			spans := recorder.Ended()
+			require.GreaterOrEqual(t, len(spans), 2)
+			span := spans[len(spans)-2]
+			require.Equal(t, "cruise-control GET", span.Name())
+			require.Equal(t, parent.SpanContext().TraceID(), span.SpanContext().TraceID())
+			require.Equal(t, test.expectedStatus, span.Status().Code)
+			require.Contains(t, traceparent, span.SpanContext().SpanID().String())
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	defer func(provider trace.TracerProvider) {
+		otel.SetTracerProvider(provider)
+	}(otel.GetTracerProvider())
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	ctx, reconcileSpan := StartReconcile(context.Background(), "KafkaCluster",
+		reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "kafka", Name: "kafka"}})
+	err := Run(ctx, "kafka", func(context.Context) error { return errors.New("brokers not ready") })
+	require.Error(t, err)
+	End(reconcileSpan, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, "kafka", spans[0].Name())
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Equal(t, reconcileSpan.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	require.Equal(t, "Reconcile KafkaCluster", spans[1].Name())
+	require.Equal(t, codes.Unset, spans[1].Status().Code)
+}
@@ -59,7 +59,9 @@ import (
 	"github.com/banzaicloud/koperator/pkg/util/cert"
 	"github.com/banzaicloud/koperator/pkg/util/contour"
 	envoyutils "github.com/banzaicloud/koperator/pkg/util/envoy"
+	"github.com/banzaicloud/koperator/pkg/util/gatewayapi"
 	"github.com/banzaicloud/koperator/pkg/util/istioingress"
+	"github.com/banzaicloud/koperator/pkg/util/nginx"
 	properties "github.com/banzaicloud/koperator/properties/pkg"
 )
 
@@ -230,6 +232,16 @@ func FilterControllerOnlyNodes(brokerIDs []string, spec v1beta1.KafkaClusterSpec
 	return filteredIDs, nil
 }
 
+// ShouldRemoveUnusedIngressResources returns true if the ingress resources not used by the given external listener
+// can be removed. While the access method of the listener is changed the resources of the previous access method are
+// kept until every broker advertises the new one, they are removed afterwards.
+func ShouldRemoveUnusedIngressResources(cluster *v1beta1.KafkaCluster, eListenerName string) bool {
+	if status, ok := cluster.Status.ExternalListenersAccess[eListenerName]; ok && status.Transition != nil {
+		return status.Transition.Phase == v1beta1.ExternalListenerAccessCleaningUp
+	}
+	return cluster.Spec.RemoveUnusedIngressResources
+}
+
 // IsIngressConfigInUse returns true if the provided ingressConfigName is bound to the given broker
 func IsIngressConfigInUse(iConfigName, defaultConfigName string, cluster *v1beta1.KafkaCluster, log logr.Logger) bool {
 	// Check if the global default is in use
@@ -290,7 +302,7 @@ func GetIngressConfigs(kafkaClusterSpec v1beta1.KafkaClusterSpec,
 	var ingressConfigs map[string]v1beta1.IngressConfig
 	var defaultIngressConfigName string
 	// Merge specific external listener configuration with the global one if none specified
-	switch kafkaClusterSpec.GetIngressController() {
+	switch kafkaClusterSpec.GetIngressControllerForListener(eListenerConfig) {
 	case envoyutils.IngressControllerName:
 		if eListenerConfig.Config != nil {
 			defaultIngressConfigName = eListenerConfig.Config.DefaultIngressConfig
@@ -375,8 +387,64 @@ func GetIngressConfigs(kafkaClusterSpec v1beta1.KafkaClusterSpec,
 				},
 			}
 		}
+	case gatewayapi.IngressControllerName:
+		if eListenerConfig.Config != nil {
+			defaultIngressConfigName = eListenerConfig.Config.DefaultIngressConfig
+			ingressConfigs = make(map[string]v1beta1.IngressConfig, len(eListenerConfig.Config.IngressConfig))
+			for k, iConf := range eListenerConfig.Config.IngressConfig {
+				if iConf.GatewayAPIConfig != nil {
+					err := mergo.Merge(iConf.GatewayAPIConfig, kafkaClusterSpec.GatewayAPIConfig)
+					if err != nil {
+						return nil, "", errors.WrapWithDetails(err,
+							"could not merge global gateway api config with local one", "gatewayAPIConfig", k)
+					}
+					err = mergo.Merge(&iConf.IngressServiceSettings, eListenerConfig.IngressServiceSettings)
+					if err != nil {
+						return nil, "", errors.WrapWithDetails(err,
+							"could not merge global loadbalancer config with local one",
+							"externalListenerName", eListenerConfig.Name)
+					}
+					ingressConfigs[k] = iConf
+				}
+			}
+		} else {
+			ingressConfigs = map[string]v1beta1.IngressConfig{
+				IngressConfigGlobalName: {
+					IngressServiceSettings: eListenerConfig.IngressServiceSettings,
+					GatewayAPIConfig:       &kafkaClusterSpec.GatewayAPIConfig,
+				},
+			}
+		}
+	case nginx.IngressControllerName:
+		if eListenerConfig.Config != nil {
+			defaultIngressConfigName = eListenerConfig.Config.DefaultIngressConfig
+			ingressConfigs = make(map[string]v1beta1.IngressConfig, len(eListenerConfig.Config.IngressConfig))
+			for k, iConf := range eListenerConfig.Config.IngressConfig {
+				if iConf.NginxIngressConfig != nil {
+					err := mergo.Merge(iConf.NginxIngressConfig, kafkaClusterSpec.NginxIngressConfig)
+					if err != nil {
+						return nil, "", errors.WrapWithDetails(err,
+							"could not merge global nginx ingress config with local one", "nginxIngressConfig", k)
+					}
+					err = mergo.Merge(&iConf.IngressServiceSettings, eListenerConfig.IngressServiceSettings)
+					if err != nil {
+						return nil, "", errors.WrapWithDetails(err,
+							"could not merge global loadbalancer config with local one",
+							"externalListenerName", eListenerConfig.Name)
+					}
+					ingressConfigs[k] = iConf
+				}
+			}
+		} else {
+			ingressConfigs = map[string]v1beta1.IngressConfig{
+				IngressConfigGlobalName: {
+					IngressServiceSettings: eListenerConfig.IngressServiceSettings,
+					NginxIngressConfig:     &kafkaClusterSpec.NginxIngressConfig,
+				},
+			}
+		}
 	default:
-		return nil, "", errors.NewWithDetails("not supported ingress type", "name", kafkaClusterSpec.GetIngressController())
+		return nil, "", errors.NewWithDetails("not supported ingress type", "name", kafkaClusterSpec.GetIngressControllerForListener(eListenerConfig))
 	}
 	return ingressConfigs, defaultIngressConfigName, nil
 }
@@ -473,6 +473,25 @@ func TestGetIngressConfigs(t *testing.T) {
 				},
 			},
 		},
+		// ExternalListener overrides the cluster wide ingress controller with IstioIngress
+		{
+			v1beta1.KafkaClusterSpec{
+				EnvoyConfig:        defaultKafkaClusterWithEnvoy.EnvoyConfig,
+				IstioIngressConfig: defaultKafkaClusterWithIstioIngress.IstioIngressConfig,
+			},
+			v1beta1.ExternalListenerConfig{
+				CommonListenerSpec: v1beta1.CommonListenerSpec{
+					Type:          "plaintext",
+					Name:          "external",
+					ContainerPort: 9094,
+				},
+				ExternalStartingPort: 19090,
+				IngressController:    istioingress.IngressControllerName,
+			},
+			map[string]v1beta1.IngressConfig{
+				IngressConfigGlobalName: {IstioIngressConfig: &defaultKafkaClusterWithIstioIngress.IstioIngressConfig},
+			},
+		},
 	}
 	for _, testCase := range testCases {
 		ingressConfigs, _, err := GetIngressConfigs(testCase.globalConfig, testCase.externalListenerSpecifiedConfigs)
@@ -0,0 +1,112 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	banzaicloudv1alpha1 "github.com/banzaicloud/koperator/api/v1alpha1"
+)
+
+var supportedReplicaMovementStrategies = []banzaicloudv1alpha1.ReplicaMovementStrategy{
+	banzaicloudv1alpha1.ReplicaMovementStrategyPrioritizeLarge,
+	banzaicloudv1alpha1.ReplicaMovementStrategyPrioritizeSmall,
+	banzaicloudv1alpha1.ReplicaMovementStrategyPostponeURP,
+	banzaicloudv1alpha1.ReplicaMovementStrategyPrioritizeMinIsrWithOffline,
+}
+
+type CruiseControlOperationValidator struct {
+	Log logr.Logger
+}
+
+func (s CruiseControlOperationValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (warnings admission.Warnings, err error) {
+	return s.validate(obj)
+}
+
+func (s CruiseControlOperationValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (warnings admission.Warnings, err error) {
+	return s.validate(newObj)
+}
+
+func (s CruiseControlOperationValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (warnings admission.Warnings, err error) {
+	return nil, nil
+}
+
+func (s CruiseControlOperationValidator) validate(obj runtime.Object) (warnings admission.Warnings, err error) {
+	ccOperation := obj.(*banzaicloudv1alpha1.CruiseControlOperation)
+	log := s.Log.WithValues("name", ccOperation.GetName(), "namespace", ccOperation.GetNamespace())
+
+	fieldErrs := checkRebalanceOptions(ccOperation)
+	if len(fieldErrs) == 0 {
+		return nil, nil
+	}
+	log.Info("rejected", "invalid field(s)", fieldErrs.ToAggregate().Error())
+	return nil, apierrors.NewInvalid(
+		ccOperation.GetObjectKind().GroupVersionKind().GroupKind(),
+		ccOperation.Name, fieldErrs)
+}
+
+// checkRebalanceOptions validates the rebalance options which are passed to Cruise Control as rebalance parameters
+func checkRebalanceOptions(ccOperation *banzaicloudv1alpha1.CruiseControlOperation) field.ErrorList {
+	options := ccOperation.Spec.Rebalance
+	if options == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	path := field.NewPath("spec").Child("rebalance")
+
+	// the operation is only known once the current task is set in the status
+	if operation := ccOperation.CurrentTaskOperation(); operation != "" && operation != banzaicloudv1alpha1.OperationRebalance {
+		allErrs = append(allErrs, field.Invalid(path, options,
+			fmt.Sprintf("%s: the rebalance options are not supported by the %s operation", invalidRebalanceOptionsErrMsg, operation)))
+	}
+
+	if _, err := regexp.Compile(options.ExcludedTopics); err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("excludedTopics"), options.ExcludedTopics,
+			fmt.Sprintf("%s: %s", invalidRebalanceOptionsErrMsg, err)))
+	}
+
+	seen := make(map[banzaicloudv1alpha1.ReplicaMovementStrategy]struct{}, len(options.ReplicaMovementStrategies))
+	for i, strategy := range options.ReplicaMovementStrategies {
+		strategyPath := path.Child("replicaMovementStrategies").Index(i)
+		if _, ok := seen[strategy]; ok {
+			allErrs = append(allErrs, field.Duplicate(strategyPath, strategy))
+			continue
+		}
+		seen[strategy] = struct{}{}
+		if !slices.Contains(supportedReplicaMovementStrategies, strategy) {
+			allErrs = append(allErrs, field.NotSupported(strategyPath, strategy, supportedReplicaMovementStrategies))
+		}
+	}
+
+	if options.ConcurrentPartitionMovementsPerBroker != nil && *options.ConcurrentPartitionMovementsPerBroker < 1 {
+		allErrs = append(allErrs, field.Invalid(path.Child("concurrentPartitionMovementsPerBroker"), *options.ConcurrentPartitionMovementsPerBroker,
+			fmt.Sprintf("%s: must be at least 1", invalidRebalanceOptionsErrMsg)))
+	}
+	if options.ConcurrentLeaderMovements != nil && *options.ConcurrentLeaderMovements < 1 {
+		allErrs = append(allErrs, field.Invalid(path.Child("concurrentLeaderMovements"), *options.ConcurrentLeaderMovements,
+			fmt.Sprintf("%s: must be at least 1", invalidRebalanceOptionsErrMsg)))
+	}
+	return allErrs
+}
@@ -0,0 +1,94 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/koperator/api/v1alpha1"
+	"github.com/banzaicloud/koperator/pkg/util"
+)
+
+func TestCheckRebalanceOptions(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		operation        v1alpha1.CruiseControlTaskOperation
+		rebalance        *v1alpha1.RebalanceOptions
+		expectedErrPaths []string
+	}{
+		{
+			testName:  "no rebalance options",
+			operation: v1alpha1.OperationAddBroker,
+		},
+		{
+			testName: "valid rebalance options before the task is set",
+			rebalance: &v1alpha1.RebalanceOptions{
+				ExcludedTopics:                        "(orders|payments)-.*",
+				ReplicaMovementStrategies:             []v1alpha1.ReplicaMovementStrategy{v1alpha1.ReplicaMovementStrategyPostponeURP},
+				ConcurrentPartitionMovementsPerBroker: util.Int32Pointer(5),
+				ConcurrentLeaderMovements:             util.Int32Pointer(100),
+			},
+		},
+		{
+			testName:         "rebalance options of another operation",
+			operation:        v1alpha1.OperationRemoveBroker,
+			rebalance:        &v1alpha1.RebalanceOptions{ExcludedTopics: "orders"},
+			expectedErrPaths: []string{"spec.rebalance"},
+		},
+		{
+			testName:         "invalid topic regex",
+			operation:        v1alpha1.OperationRebalance,
+			rebalance:        &v1alpha1.RebalanceOptions{ExcludedTopics: "orders-(.*"},
+			expectedErrPaths: []string{"spec.rebalance.excludedTopics"},
+		},
+		{
+			testName:  "duplicated and unknown strategies",
+			operation: v1alpha1.OperationRebalance,
+			rebalance: &v1alpha1.RebalanceOptions{ReplicaMovementStrategies: []v1alpha1.ReplicaMovementStrategy{
+				v1alpha1.ReplicaMovementStrategyPrioritizeLarge, v1alpha1.ReplicaMovementStrategyPrioritizeLarge, "BaseReplicaMovementStrategy",
+			}},
+			expectedErrPaths: []string{"spec.rebalance.replicaMovementStrategies[1]", "spec.rebalance.replicaMovementStrategies[2]"},
+		},
+		{
+			testName:  "out of range concurrency",
+			operation: v1alpha1.OperationRebalance,
+			rebalance: &v1alpha1.RebalanceOptions{
+				ConcurrentPartitionMovementsPerBroker: util.Int32Pointer(0),
+				ConcurrentLeaderMovements:             util.Int32Pointer(-1),
+			},
+			expectedErrPaths: []string{"spec.rebalance.concurrentPartitionMovementsPerBroker", "spec.rebalance.concurrentLeaderMovements"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			ccOperation := &v1alpha1.CruiseControlOperation{
+				Spec: v1alpha1.CruiseControlOperationSpec{Rebalance: test.rebalance},
+			}
+			if test.operation != "" {
+				ccOperation.Status.CurrentTask = &v1alpha1.CruiseControlTask{Operation: test.operation}
+			}
+			errs := checkRebalanceOptions(ccOperation)
+			var errPaths []string
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.Equal(t, test.expectedErrPaths, errPaths)
+		})
+	}
+}
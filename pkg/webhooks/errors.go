@@ -26,9 +26,49 @@ const (
 	invalidReplicationFactorErrMsg                 = "replication factor is larger than the number of nodes in the kafka cluster"
 	outOfRangeReplicationFactorErrMsg              = "replication factor must be larger than 0 (or set it to be -1 to use the broker's default)"
 	outOfRangePartitionsErrMsg                     = "number of partitions must be larger than 0 (or set it to be -1 to use the broker's default)"
+	reassignmentInProgressErrMsg                   = "replication factor can not be changed while a replica reassignment of the topic is in progress"
 	unsupportedRemovingStorageMsg                  = "removing storage from a broker is not supported"
 	invalidExternalListenerStartingPortErrMsg      = "invalid external listener starting port number"
 	invalidContainerPortForIngressControllerErrMsg = "invalid trarget port number for ingress controller deployment"
+	invalidSSLPrincipalMappingRuleErrMsg           = "invalid SSL principal mapping rule"
+	conflictingAuthorizationConfigErrMsg           = "readOnlyConfig property conflicts with spec.authorizationConfig"
+	kraftRequiredErrMsg                            = "Kafka 4.x and later versions require KRaft mode"
+	unsupportedKafkaUpgradeErrMsg                  = "unsupported Kafka upgrade path"
+	kafkaDowngradeAfterProtocolBumpErrMsg          = "the Kafka version can not be downgraded once the protocol version has been bumped"
+	invalidKRaftMigrationErrMsg                    = "invalid ZooKeeper to KRaft migration"
+	kraftMigrationInProgressErrMsg                 = "the ZooKeeper to KRaft migration can not be disabled while it is in progress"
+	controllerQuorumChangeErrMsg                   = "the voters of the static KRaft controller quorum can not be changed"
+	invalidBrokerReadinessExpressionErrMsg         = "invalid broker readiness expression"
+	missingKeystorePasswordSourceErrMsg            = "the keystore password generator requires its source to be configured"
+	invalidGatewayAPIConfigErrMsg                  = "invalid gateway api configuration"
+	invalidNginxIngressConfigErrMsg                = "invalid nginx ingress configuration"
+	incompatibleVersionsErrMsg                     = "incompatible Kafka and Cruise Control versions"
+	invalidPerBrokerLoadBalancerConfigErrMsg       = "invalid per broker load balancer configuration"
+	notOwnedByTeamErrMsg                           = "not owned by the team of the namespace"
+	invalidDiskPlacementHintErrMsg                 = "invalid disk placement hint"
+	invalidIstioMeshModeErrMsg                     = "invalid istio mesh mode"
+	invalidEnvoyListenerConfigErrMsg               = "invalid envoy listener configuration"
+	limitRangeViolationErrMsg                      = "violates a LimitRange of the namespace"
+	invalidListenerServerCertificateErrMsg         = "invalid listener server certificate"
+	missingVaultConfigErrMsg                       = "the vault PKI backend requires the vault configuration"
+	vaultCertificatesWithoutHotReloadErrMsg        = "the vault PKI backend requires the hot reload of the certificates, the brokers would serve the certificates issued by Vault until they expire"
+	invalidListenerOAuthBearerErrMsg               = "invalid listener SASL/OAUTHBEARER configuration"
+	invalidListenerKerberosErrMsg                  = "invalid listener Kerberos configuration"
+	invalidListenerSSLClientAuthErrMsg             = "invalid listener SSL client authentication"
+	invalidRollingUpgradeHookErrMsg                = "invalid rolling upgrade hook"
+	invalidSingleNodeClusterErrMsg                 = "invalid single-node cluster configuration"
+	invalidPrometheusReplicationCheckErrMsg        = "invalid rolling upgrade Prometheus replication check"
+	invalidRebalanceOptionsErrMsg                  = "invalid rebalance options"
+	invalidCruiseControlSelfHealingErrMsg          = "invalid cruise control self-healing configuration"
+	invalidCruiseControlRefErrMsg                  = "invalid cruise control reference"
+	invalidRebalanceScheduleErrMsg                 = "invalid rebalance schedule"
+	invalidMaintenanceWindowErrMsg                 = "invalid maintenance window"
+	invalidBrokerDecommissionErrMsg                = "invalid broker decommission"
+	invalidTieredStorageErrMsg                     = "invalid tiered storage configuration"
+	invalidTopicRemoteStorageErrMsg                = "invalid topic remote storage configuration"
+	missingKRaftControllerErrMsg                   = "KRaft mode requires at least one controller node"
+	invalidStorageMountPathErrMsg                  = "invalid storage mount path"
+	invalidDisruptionBudgetErrMsg                  = "invalid disruption budget"
 
 	// errorDuringValidationMsg is added to infrastructure errors (e.g. failed to connect), but not to field validation errors
 	errorDuringValidationMsg = "error during validation"
@@ -0,0 +1,129 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	banzaicloudv1beta1 "github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+const (
+	defaultInternalListenerName   = "internal"
+	defaultInternalListenerPort   = 29092
+	defaultControllerListenerName = "controller"
+	defaultControllerListenerPort = 29093
+	defaultFailureThreshold       = 1
+)
+
+// KafkaClusterDefaulter fills in the defaults of the KafkaClusters when they are created, so that a minimal manifest
+// with the brokers and their storage results in a usable cluster. The defaults are persisted in the spec, so the
+// running configuration of the cluster is not changed by a later operator version with different defaults.
+type KafkaClusterDefaulter struct {
+	Log logr.Logger
+}
+
+func (d KafkaClusterDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	kafkaCluster, ok := obj.(*banzaicloudv1beta1.KafkaCluster)
+	if !ok {
+		return fmt.Errorf("expected a KafkaCluster but got a %T", obj)
+	}
+	d.Log.V(1).Info("defaulting", "name", kafkaCluster.GetName(), "namespace", kafkaCluster.GetNamespace())
+
+	defaultKafkaClusterSpec(&kafkaCluster.Spec)
+	return nil
+}
+
+// defaultKafkaClusterSpec sets the fields of the spec left empty to the values the operator would use for them
+func defaultKafkaClusterSpec(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) {
+	kafkaClusterSpec.ClusterImage = kafkaClusterSpec.GetClusterImage()
+
+	defaultListeners(kafkaClusterSpec)
+
+	monitoringConfig := &kafkaClusterSpec.MonitoringConfig
+	monitoringConfig.JmxImage = monitoringConfig.GetImage()
+	monitoringConfig.PathToJar = monitoringConfig.GetPathToJar()
+
+	cruiseControlConfig := &kafkaClusterSpec.CruiseControlConfig
+	cruiseControlConfig.Image = cruiseControlConfig.GetCCImage()
+	taskSpec := &cruiseControlConfig.CruiseControlTaskSpec
+	taskSpec.RetryDurationMinutes = int(taskSpec.GetDurationMinutes())
+
+	if kafkaClusterSpec.RollingUpgradeConfig.FailureThreshold == 0 {
+		kafkaClusterSpec.RollingUpgradeConfig.FailureThreshold = defaultFailureThreshold
+	}
+}
+
+// defaultListeners adds a plaintext internal listener for the communication of the brokers, and in KRaft mode one
+// for the controllers, when the spec has none. The listeners without a name are named after their port.
+func defaultListeners(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) {
+	listenersConfig := &kafkaClusterSpec.ListenersConfig
+	if len(listenersConfig.InternalListeners) == 0 {
+		listenersConfig.InternalListeners = append(listenersConfig.InternalListeners, banzaicloudv1beta1.InternalListenerConfig{
+			CommonListenerSpec: banzaicloudv1beta1.CommonListenerSpec{
+				Type:                            banzaicloudv1beta1.SecurityProtocolPlaintext,
+				Name:                            defaultInternalListenerName,
+				ContainerPort:                   defaultInternalListenerPort,
+				UsedForInnerBrokerCommunication: true,
+			},
+		})
+	}
+	if kafkaClusterSpec.KRaftMode && !hasControllerListener(listenersConfig.InternalListeners) {
+		listenersConfig.InternalListeners = append(listenersConfig.InternalListeners, banzaicloudv1beta1.InternalListenerConfig{
+			CommonListenerSpec: banzaicloudv1beta1.CommonListenerSpec{
+				Type:          banzaicloudv1beta1.SecurityProtocolPlaintext,
+				Name:          defaultControllerListenerName,
+				ContainerPort: defaultControllerListenerPort,
+			},
+			UsedForControllerCommunication: true,
+		})
+	}
+
+	for i := range listenersConfig.InternalListeners {
+		listener := &listenersConfig.InternalListeners[i]
+		if listener.Name == "" {
+			prefix := defaultInternalListenerName
+			if listener.UsedForControllerCommunication {
+				prefix = defaultControllerListenerName
+			}
+			listener.Name = defaultListenerName(prefix, listener.CommonListenerSpec)
+		}
+	}
+	for i := range listenersConfig.ExternalListeners {
+		listener := &listenersConfig.ExternalListeners[i]
+		if listener.Name == "" {
+			listener.Name = defaultListenerName("external", listener.CommonListenerSpec)
+		}
+	}
+}
+
+// defaultListenerName returns the name of a listener from its kind and port, e.g. internal-29092
+func defaultListenerName(prefix string, listener banzaicloudv1beta1.CommonListenerSpec) string {
+	return fmt.Sprintf("%s-%d", prefix, listener.ContainerPort)
+}
+
+func hasControllerListener(listeners []banzaicloudv1beta1.InternalListenerConfig) bool {
+	for _, listener := range listeners {
+		if listener.UsedForControllerCommunication {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+// Copyright © 2025 Cisco Systems, Inc. and/or its affiliates
+// Copyright 2025 Adobe. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/koperator/api/v1beta1"
+)
+
+func TestKafkaClusterDefaulter(t *testing.T) {
+	testCases := []struct {
+		testName                  string
+		spec                      v1beta1.KafkaClusterSpec
+		expectedInternalListeners []v1beta1.InternalListenerConfig
+		expectedExternalNames     []string
+	}{
+		{
+			testName: "ZooKeeper cluster without listeners",
+			expectedInternalListeners: []v1beta1.InternalListenerConfig{
+				{CommonListenerSpec: v1beta1.CommonListenerSpec{Type: v1beta1.SecurityProtocolPlaintext, Name: "internal", ContainerPort: 29092, UsedForInnerBrokerCommunication: true}},
+			},
+		},
+		{
+			testName: "KRaft cluster without listeners",
+			spec:     v1beta1.KafkaClusterSpec{KRaftMode: true},
+			expectedInternalListeners: []v1beta1.InternalListenerConfig{
+				{CommonListenerSpec: v1beta1.CommonListenerSpec{Type: v1beta1.SecurityProtocolPlaintext, Name: "internal", ContainerPort: 29092, UsedForInnerBrokerCommunication: true}},
+				{CommonListenerSpec: v1beta1.CommonListenerSpec{Type: v1beta1.SecurityProtocolPlaintext, Name: "controller", ContainerPort: 29093}, UsedForControllerCommunication: true},
+			},
+		},
+		{
+			testName: "listeners without names",
+			spec: v1beta1.KafkaClusterSpec{
+				KRaftMode: true,
+				ListenersConfig: v1beta1.ListenersConfig{
+					InternalListeners: []v1beta1.InternalListenerConfig{
+						{CommonListenerSpec: v1beta1.CommonListenerSpec{Type: v1beta1.SecurityProtocolSSL, ContainerPort: 9092, UsedForInnerBrokerCommunication: true}},
+						{CommonListenerSpec: v1beta1.CommonListenerSpec{Type: v1beta1.SecurityProtocolSSL, Name: "kraft", ContainerPort: 9093}, UsedForControllerCommunication: true},
+					},
+					ExternalListeners: []v1beta1.ExternalListenerConfig{
+						{CommonListenerSpec: v1beta1.CommonListenerSpec{Type: v1beta1.SecurityProtocolSSL, ContainerPort: 9094}},
+					},
+				},
+			},
+			expectedInternalListeners: []v1beta1.InternalListenerConfig{
+				{CommonListenerSpec: v1beta1.CommonListenerSpec{Type: v1beta1.SecurityProtocolSSL, Name: "internal-9092", ContainerPort: 9092, UsedForInnerBrokerCommunication: true}},
+				{CommonListenerSpec: v1beta1.CommonListenerSpec{Type: v1beta1.SecurityProtocolSSL, Name: "kraft", ContainerPort: 9093}, UsedForControllerCommunication: true},
+			},
+			expectedExternalNames: []string{"external-9094"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		test := testCase
+		t.Run(test.testName, func(t *testing.T) {
+			kafkaCluster := &v1beta1.KafkaCluster{Spec: test.spec}
+			require.NoError(t, KafkaClusterDefaulter{Log: logr.Discard()}.Default(context.Background(), kafkaCluster))
+
+			spec := kafkaCluster.Spec
+			require.Equal(t, test.expectedInternalListeners, spec.ListenersConfig.InternalListeners)
+			var externalNames []string
+			for _, listener := range spec.ListenersConfig.ExternalListeners {
+				externalNames = append(externalNames, listener.Name)
+			}
+			require.Equal(t, test.expectedExternalNames, externalNames)
+
+			require.Equal(t, v1beta1.DefaultKafkaImage, spec.ClusterImage)
+			require.NotEmpty(t, spec.MonitoringConfig.JmxImage)
+			require.NotEmpty(t, spec.MonitoringConfig.PathToJar)
+			require.Equal(t, v1beta1.DefaultCruiseControlImage, spec.CruiseControlConfig.Image)
+			require.Equal(t, 5, spec.CruiseControlConfig.CruiseControlTaskSpec.RetryDurationMinutes)
+			require.Equal(t, 1, spec.RollingUpgradeConfig.FailureThreshold)
+		})
+	}
+}
+
+func TestKafkaClusterDefaulterKeepsSetFields(t *testing.T) {
+	kafkaCluster := &v1beta1.KafkaCluster{Spec: v1beta1.KafkaClusterSpec{
+		ClusterImage:         "example.com/kafka:custom",
+		MonitoringConfig:     v1beta1.MonitoringConfig{JmxImage: "example.com/jmx:custom", PathToJar: "/custom.jar"},
+		CruiseControlConfig:  v1beta1.CruiseControlConfig{Image: "example.com/cc:custom", CruiseControlTaskSpec: v1beta1.CruiseControlTaskSpec{RetryDurationMinutes: 10}},
+		RollingUpgradeConfig: v1beta1.RollingUpgradeConfig{FailureThreshold: 3},
+	}}
+	expected := kafkaCluster.Spec.DeepCopy()
+	expected.ListenersConfig.InternalListeners = []v1beta1.InternalListenerConfig{
+		{CommonListenerSpec: v1beta1.CommonListenerSpec{Type: v1beta1.SecurityProtocolPlaintext, Name: "internal", ContainerPort: 29092, UsedForInnerBrokerCommunication: true}},
+	}
+
+	require.NoError(t, KafkaClusterDefaulter{Log: logr.Discard()}.Default(context.Background(), kafkaCluster))
+	require.Equal(t, *expected, kafkaCluster.Spec)
+}
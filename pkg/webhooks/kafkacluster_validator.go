@@ -18,6 +18,10 @@ package webhooks
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 
@@ -27,14 +31,28 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	"emperror.dev/errors"
+	"github.com/IBM/sarama"
 	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	banzaicloudv1beta1 "github.com/banzaicloud/koperator/api/v1beta1"
+	"github.com/banzaicloud/koperator/pkg/scale"
 	"github.com/banzaicloud/koperator/pkg/util"
+	"github.com/banzaicloud/koperator/pkg/util/cron"
+	envoyutils "github.com/banzaicloud/koperator/pkg/util/envoy"
+	gatewayapiutils "github.com/banzaicloud/koperator/pkg/util/gatewayapi"
+	istioingressutils "github.com/banzaicloud/koperator/pkg/util/istioingress"
+	kafkautil "github.com/banzaicloud/koperator/pkg/util/kafka"
+	"github.com/banzaicloud/koperator/pkg/util/maintenance"
+	nginxutils "github.com/banzaicloud/koperator/pkg/util/nginx"
+	"github.com/banzaicloud/koperator/pkg/util/readiness"
+	properties "github.com/banzaicloud/koperator/properties/pkg"
 )
 
 type KafkaClusterValidator struct {
-	Log logr.Logger
+	Client client.Client
+	Log    logr.Logger
 }
 
 func (s KafkaClusterValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (warnings admission.Warnings, err error) {
@@ -47,12 +65,78 @@ func (s KafkaClusterValidator) ValidateUpdate(ctx context.Context, oldObj, newOb
 		allErrs = append(allErrs, listenerErrs...)
 	}
 
+	allErrs = append(allErrs, checkPrincipalConfig(&kafkaClusterNew.Spec)...)
+
+	allErrs = append(allErrs, checkAuthorizationConfig(&kafkaClusterNew.Spec)...)
+
+	allErrs = append(allErrs, checkBrokerReadiness(&kafkaClusterNew.Spec)...)
+
+	allErrs = append(allErrs, checkKeystorePasswordPolicy(&kafkaClusterNew.Spec)...)
+
+	allErrs = append(allErrs, checkVaultConfig(&kafkaClusterNew.Spec)...)
+
+	allErrs = append(allErrs, checkDiskPlacementHints(&kafkaClusterNew.Spec)...)
+
+	allErrs = append(allErrs, checkRollingUpgradeHooks(&kafkaClusterNew.Spec)...)
+
+	allErrs = append(allErrs, checkPrometheusReplicationCheck(&kafkaClusterNew.Spec)...)
+
+	allErrs = append(allErrs, checkCruiseControlSelfHealing(&kafkaClusterNew.Spec)...)
+
+	allErrs = append(allErrs, checkCruiseControlRef(&kafkaClusterNew.Spec)...)
+
+	allErrs = append(allErrs, checkRebalanceSchedule(&kafkaClusterNew.Spec)...)
+
+	allErrs = append(allErrs, checkMaintenanceWindows(&kafkaClusterNew.Spec)...)
+
+	allErrs = append(allErrs, checkBrokerDecommissions(&kafkaClusterNew.Spec)...)
+
+	allErrs = append(allErrs, checkTieredStorage(&kafkaClusterNew.Spec)...)
+
+	allErrs = append(allErrs, checkUniqueBrokerIDs(&kafkaClusterNew.Spec)...)
+
+	allErrs = append(allErrs, checkKRaftControllers(&kafkaClusterNew.Spec)...)
+
+	allErrs = append(allErrs, checkStorageMountPaths(&kafkaClusterNew.Spec)...)
+
+	allErrs = append(allErrs, checkDisruptionBudget(&kafkaClusterNew.Spec)...)
+
+	// the versions the brokers run are recorded in the status of the stored cluster
+	kafkaClusterOld := oldObj.(*banzaicloudv1beta1.KafkaCluster)
+	allErrs = append(allErrs, checkKafkaVersion(&kafkaClusterNew.Spec, &kafkaClusterOld.Status)...)
+
+	allErrs = append(allErrs, checkKRaftMigration(&kafkaClusterNew.Spec, &kafkaClusterOld.Status)...)
+
+	allErrs = append(allErrs, checkVersionUpgrade(&kafkaClusterNew.Spec, &kafkaClusterOld.Status)...)
+
+	allErrs = append(allErrs, checkControllerQuorum(&kafkaClusterNew.Spec, &kafkaClusterOld.Spec)...)
+
+	allErrs = append(allErrs, checkListenerSSLClientAuth(&kafkaClusterNew.Spec, &kafkaClusterOld.Spec)...)
+
+	compatibilityErrs, compatibilityWarnings := checkVersionCompatibility(&kafkaClusterNew.Spec)
+	allErrs = append(allErrs, compatibilityErrs...)
+
+	singleNodeErrs, singleNodeWarnings := checkSingleNodeCluster(&kafkaClusterNew.Spec)
+	allErrs = append(allErrs, singleNodeErrs...)
+
+	limitRangeErrs, err := s.checkLimitRanges(ctx, kafkaClusterNew)
+	if err != nil {
+		log.Error(err, errorDuringValidationMsg)
+		return nil, apierrors.NewInternalError(errors.WithMessage(err, errorDuringValidationMsg))
+	}
+	allErrs = append(allErrs, limitRangeErrs...)
+
+	warnings = internalTopicsReplicationWarnings(&kafkaClusterNew.Spec)
+	warnings = append(warnings, kraftOnlyRemovedConfigWarnings(&kafkaClusterNew.Spec)...)
+	warnings = append(warnings, compatibilityWarnings...)
+	warnings = append(warnings, singleNodeWarnings...)
+
 	if len(allErrs) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
 	log.Info("rejected", "invalid field(s)", allErrs.ToAggregate().Error())
-	return nil, apierrors.NewInvalid(
+	return warnings, apierrors.NewInvalid(
 		kafkaClusterNew.GroupVersionKind().GroupKind(),
 		kafkaClusterNew.Name, allErrs)
 }
@@ -67,12 +151,72 @@ func (s KafkaClusterValidator) ValidateCreate(ctx context.Context, obj runtime.O
 		allErrs = append(allErrs, listenerErrs...)
 	}
 
+	allErrs = append(allErrs, checkPrincipalConfig(&kafkaCluster.Spec)...)
+
+	allErrs = append(allErrs, checkAuthorizationConfig(&kafkaCluster.Spec)...)
+
+	allErrs = append(allErrs, checkBrokerReadiness(&kafkaCluster.Spec)...)
+
+	allErrs = append(allErrs, checkKeystorePasswordPolicy(&kafkaCluster.Spec)...)
+
+	allErrs = append(allErrs, checkVaultConfig(&kafkaCluster.Spec)...)
+
+	allErrs = append(allErrs, checkDiskPlacementHints(&kafkaCluster.Spec)...)
+
+	allErrs = append(allErrs, checkRollingUpgradeHooks(&kafkaCluster.Spec)...)
+
+	allErrs = append(allErrs, checkPrometheusReplicationCheck(&kafkaCluster.Spec)...)
+
+	allErrs = append(allErrs, checkCruiseControlSelfHealing(&kafkaCluster.Spec)...)
+
+	allErrs = append(allErrs, checkCruiseControlRef(&kafkaCluster.Spec)...)
+
+	allErrs = append(allErrs, checkRebalanceSchedule(&kafkaCluster.Spec)...)
+
+	allErrs = append(allErrs, checkMaintenanceWindows(&kafkaCluster.Spec)...)
+
+	allErrs = append(allErrs, checkBrokerDecommissions(&kafkaCluster.Spec)...)
+
+	allErrs = append(allErrs, checkTieredStorage(&kafkaCluster.Spec)...)
+
+	allErrs = append(allErrs, checkUniqueBrokerIDs(&kafkaCluster.Spec)...)
+
+	allErrs = append(allErrs, checkKRaftControllers(&kafkaCluster.Spec)...)
+
+	allErrs = append(allErrs, checkStorageMountPaths(&kafkaCluster.Spec)...)
+
+	allErrs = append(allErrs, checkDisruptionBudget(&kafkaCluster.Spec)...)
+
+	allErrs = append(allErrs, checkKafkaVersion(&kafkaCluster.Spec, nil)...)
+
+	allErrs = append(allErrs, checkKRaftMigration(&kafkaCluster.Spec, nil)...)
+
+	allErrs = append(allErrs, checkListenerSSLClientAuth(&kafkaCluster.Spec, nil)...)
+
+	compatibilityErrs, compatibilityWarnings := checkVersionCompatibility(&kafkaCluster.Spec)
+	allErrs = append(allErrs, compatibilityErrs...)
+
+	singleNodeErrs, singleNodeWarnings := checkSingleNodeCluster(&kafkaCluster.Spec)
+	allErrs = append(allErrs, singleNodeErrs...)
+
+	limitRangeErrs, err := s.checkLimitRanges(ctx, kafkaCluster)
+	if err != nil {
+		log.Error(err, errorDuringValidationMsg)
+		return nil, apierrors.NewInternalError(errors.WithMessage(err, errorDuringValidationMsg))
+	}
+	allErrs = append(allErrs, limitRangeErrs...)
+
+	warnings = internalTopicsReplicationWarnings(&kafkaCluster.Spec)
+	warnings = append(warnings, kraftOnlyRemovedConfigWarnings(&kafkaCluster.Spec)...)
+	warnings = append(warnings, compatibilityWarnings...)
+	warnings = append(warnings, singleNodeWarnings...)
+
 	if len(allErrs) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
 	log.Info("rejected", "invalid field(s)", allErrs.ToAggregate().Error())
-	return nil, apierrors.NewInvalid(
+	return warnings, apierrors.NewInvalid(
 		kafkaCluster.GroupVersionKind().GroupKind(),
 		kafkaCluster.Name, allErrs)
 }
@@ -81,6 +225,855 @@ func (s KafkaClusterValidator) ValidateDelete(ctx context.Context, obj runtime.O
 	return nil, nil
 }
 
+// checkLimitRanges validates the resources of the containers of the broker pods against the LimitRanges of the
+// namespace of the cluster, which would otherwise only reject the pods when the operator creates them
+func (s KafkaClusterValidator) checkLimitRanges(ctx context.Context, kafkaCluster *banzaicloudv1beta1.KafkaCluster) (field.ErrorList, error) {
+	limitRanges := &corev1.LimitRangeList{}
+	if err := s.Client.List(ctx, limitRanges, client.InNamespace(kafkaCluster.GetNamespace())); err != nil {
+		return nil, errors.Wrap(err, cantConnectAPIServerMsg)
+	}
+	return checkBrokerResourcesAgainstLimitRanges(&kafkaCluster.Spec, limitRanges.Items), nil
+}
+
+// containerResources holds the resources of a container of the broker pods and the path of the field they are set by
+type containerResources struct {
+	name      string
+	path      *field.Path
+	resources corev1.ResourceRequirements
+}
+
+// brokerContainerResources returns the resources of the containers of the pod of the broker. The resources are
+// reported at the broker config group of the broker unless the broker overrides them, so the brokers sharing a group
+// report the same path.
+func brokerContainerResources(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec, brokerIndex int) []containerResources {
+	broker := kafkaClusterSpec.Brokers[brokerIndex]
+	brokerConfig, err := broker.GetBrokerConfig(*kafkaClusterSpec)
+	if err != nil {
+		// a missing broker config group is reported by the reconciliation
+		return nil
+	}
+	if brokerConfig == nil {
+		brokerConfig = &banzaicloudv1beta1.BrokerConfig{}
+	}
+	override := broker.BrokerConfig
+	if override == nil {
+		override = &banzaicloudv1beta1.BrokerConfig{}
+	}
+	brokerPath := field.NewPath("spec").Child("brokers").Index(brokerIndex).Child("brokerConfig")
+	sourcePath := func(setOnBroker bool) *field.Path {
+		if setOnBroker || broker.BrokerConfigGroup == "" {
+			return brokerPath
+		}
+		return field.NewPath("spec").Child("brokerConfigGroups").Key(broker.BrokerConfigGroup)
+	}
+
+	containers := []containerResources{
+		{
+			name:      "kafka",
+			path:      sourcePath(override.Resources != nil).Child("resourceRequirements"),
+			resources: *brokerConfig.GetResources(),
+		},
+		{
+			name:      "cruise-control-reporter",
+			path:      sourcePath(override.MetricsReporterInitContainerResources != nil).Child("metricsReporterInitContainerResources"),
+			resources: *brokerConfig.GetMetricsReporterInitContainerResources(),
+		},
+		{
+			name:      "jmx-exporter",
+			path:      sourcePath(override.JmxExporterInitContainerResources != nil).Child("jmxExporterInitContainerResources"),
+			resources: *brokerConfig.GetJmxExporterInitContainerResources(),
+		},
+	}
+	// the containers of the broker precede the ones of its group in the merged broker config
+	for _, additional := range []struct {
+		fieldName     string
+		containers    []corev1.Container
+		overrideCount int
+	}{
+		{fieldName: "initContainers", containers: brokerConfig.InitContainers, overrideCount: len(override.InitContainers)},
+		{fieldName: "containers", containers: brokerConfig.Containers, overrideCount: len(override.Containers)},
+	} {
+		for i, container := range additional.containers {
+			index := i
+			if i >= additional.overrideCount {
+				index = i - additional.overrideCount
+			}
+			containers = append(containers, containerResources{
+				name:      container.Name,
+				path:      sourcePath(i < additional.overrideCount).Child(additional.fieldName).Index(index).Child("resources"),
+				resources: container.Resources,
+			})
+		}
+	}
+	return containers
+}
+
+// checkBrokerResourcesAgainstLimitRanges validates the resources of the containers of the broker pods against the
+// Container limits of the given LimitRanges. The defaults of the LimitRanges apply to the resources the containers do
+// not set, as they do on pod admission.
+func checkBrokerResourcesAgainstLimitRanges(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec, limitRanges []corev1.LimitRange) field.ErrorList {
+	if len(limitRanges) == 0 {
+		return nil
+	}
+	var allErrs field.ErrorList
+	reported := make(map[string]struct{})
+	for i := range kafkaClusterSpec.Brokers {
+		for _, container := range brokerContainerResources(kafkaClusterSpec, i) {
+			for _, limitRange := range limitRanges {
+				for _, item := range limitRange.Spec.Limits {
+					if item.Type != corev1.LimitTypeContainer {
+						continue
+					}
+					for _, err := range checkContainerLimitRangeItem(container, limitRange.GetName(), item) {
+						// brokers of the same group report the same errors
+						if _, ok := reported[err.Error()]; ok {
+							continue
+						}
+						reported[err.Error()] = struct{}{}
+						allErrs = append(allErrs, err)
+					}
+				}
+			}
+		}
+	}
+	return allErrs
+}
+
+func checkContainerLimitRangeItem(container containerResources, limitRangeName string, item corev1.LimitRangeItem) field.ErrorList {
+	resourceNames := make(map[corev1.ResourceName]struct{})
+	for _, limits := range []corev1.ResourceList{item.Max, item.Min, item.MaxLimitRequestRatio} {
+		for name := range limits {
+			resourceNames[name] = struct{}{}
+		}
+	}
+	sortedNames := make([]string, 0, len(resourceNames))
+	for name := range resourceNames {
+		sortedNames = append(sortedNames, string(name))
+	}
+	slices.Sort(sortedNames)
+
+	var allErrs field.ErrorList
+	for _, n := range sortedNames {
+		name := corev1.ResourceName(n)
+		limit, hasLimit := container.resources.Limits[name]
+		if !hasLimit {
+			limit, hasLimit = item.Default[name]
+		}
+		// the requests default to the limits set on the container before the defaults of the LimitRange
+		request, hasRequest := container.resources.Requests[name]
+		if !hasRequest {
+			request, hasRequest = container.resources.Limits[name]
+		}
+		if !hasRequest {
+			request, hasRequest = item.DefaultRequest[name]
+		}
+		if !hasRequest {
+			request, hasRequest = item.Default[name]
+		}
+		limitPath := container.path.Child("limits").Key(n)
+		requestPath := container.path.Child("requests").Key(n)
+
+		if maxQuantity, ok := item.Max[name]; ok {
+			switch {
+			case !hasLimit:
+				allErrs = append(allErrs, field.Required(limitPath, fmt.Sprintf(
+					"%s: the %s container must set a %s limit, the LimitRange %s allows at most %s and has no default",
+					limitRangeViolationErrMsg, container.name, n, limitRangeName, maxQuantity.String())))
+			case limit.Cmp(maxQuantity) > 0:
+				allErrs = append(allErrs, field.Invalid(limitPath, limit.String(), fmt.Sprintf(
+					"%s: the %s limit of the %s container exceeds the maximum %s of the LimitRange %s, lower the limit or raise the maximum",
+					limitRangeViolationErrMsg, n, container.name, maxQuantity.String(), limitRangeName)))
+			}
+			if hasRequest && request.Cmp(maxQuantity) > 0 {
+				allErrs = append(allErrs, field.Invalid(requestPath, request.String(), fmt.Sprintf(
+					"%s: the %s request of the %s container exceeds the maximum %s of the LimitRange %s, lower the request or raise the maximum",
+					limitRangeViolationErrMsg, n, container.name, maxQuantity.String(), limitRangeName)))
+			}
+		}
+		if minQuantity, ok := item.Min[name]; ok {
+			switch {
+			case !hasRequest:
+				allErrs = append(allErrs, field.Required(requestPath, fmt.Sprintf(
+					"%s: the %s container must set a %s request, the LimitRange %s requires at least %s and has no default",
+					limitRangeViolationErrMsg, container.name, n, limitRangeName, minQuantity.String())))
+			case request.Cmp(minQuantity) < 0:
+				allErrs = append(allErrs, field.Invalid(requestPath, request.String(), fmt.Sprintf(
+					"%s: the %s request of the %s container is below the minimum %s of the LimitRange %s, raise the request or lower the minimum",
+					limitRangeViolationErrMsg, n, container.name, minQuantity.String(), limitRangeName)))
+			}
+		}
+		if maxRatio, ok := item.MaxLimitRequestRatio[name]; ok && hasLimit && hasRequest && !request.IsZero() {
+			ratio := float64(limit.MilliValue()) / float64(request.MilliValue())
+			if ratio > maxRatio.AsApproximateFloat64() {
+				allErrs = append(allErrs, field.Invalid(limitPath, limit.String(), fmt.Sprintf(
+					"%s: the %s limit of the %s container is %.2f times its request %s, more than the maximum limit to request ratio %s of the LimitRange %s",
+					limitRangeViolationErrMsg, n, container.name, ratio, request.String(), maxRatio.String(), limitRangeName)))
+			}
+		}
+	}
+	return allErrs
+}
+
+// checkPrincipalConfig validates that the SSL principal mapping rules can be parsed the same way as the brokers do
+func checkPrincipalConfig(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, rule := range kafkaClusterSpec.PrincipalConfig.GetSSLPrincipalMappingRules() {
+		if _, err := kafkautil.NewSSLPrincipalMapper([]string{rule}); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("principalConfig").Child("sslPrincipalMappingRules").Index(i),
+				rule, invalidSSLPrincipalMappingRuleErrMsg+": "+err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// checkBrokerReadiness validates that the broker readiness expression can be parsed
+func checkBrokerReadiness(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	if kafkaClusterSpec.BrokerReadiness == nil {
+		return nil
+	}
+	if _, err := readiness.Parse(kafkaClusterSpec.BrokerReadiness.Expression); err != nil {
+		return field.ErrorList{field.Invalid(field.NewPath("spec").Child("brokerReadiness").Child("expression"),
+			kafkaClusterSpec.BrokerReadiness.Expression, invalidBrokerReadinessExpressionErrMsg+": "+err.Error())}
+	}
+	return nil
+}
+
+// checkDiskPlacementHints validates that the topic patterns of the disk placement hints are valid regular expressions
+// and their disk classes are defined by the storage configs of the brokers
+func checkDiskPlacementHints(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	if len(kafkaClusterSpec.DiskPlacementHints) == 0 {
+		return nil
+	}
+	diskClasses := make(map[string]struct{})
+	for i := range kafkaClusterSpec.Brokers {
+		brokerConfig, err := kafkaClusterSpec.Brokers[i].GetBrokerConfig(*kafkaClusterSpec)
+		if err != nil {
+			continue
+		}
+		for _, storage := range brokerConfig.StorageConfigs {
+			if storage.DiskClass != "" {
+				diskClasses[storage.DiskClass] = struct{}{}
+			}
+		}
+	}
+
+	var allErrs field.ErrorList
+	path := field.NewPath("spec").Child("diskPlacementHints")
+	for i, hint := range kafkaClusterSpec.DiskPlacementHints {
+		if _, err := regexp.Compile(hint.TopicPattern); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Index(i).Child("topicPattern"), hint.TopicPattern,
+				invalidDiskPlacementHintErrMsg+": "+err.Error()))
+		}
+		if _, found := diskClasses[hint.DiskClass]; !found {
+			allErrs = append(allErrs, field.Invalid(path.Index(i).Child("diskClass"), hint.DiskClass,
+				invalidDiskPlacementHintErrMsg+": no broker storage config has this disk class"))
+		}
+	}
+	return allErrs
+}
+
+// checkRollingUpgradeHooks validates that each rolling upgrade hook has exactly one check and a unique name within its phase
+func checkRollingUpgradeHooks(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	hooks := kafkaClusterSpec.RollingUpgradeConfig.Hooks
+	if hooks == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	path := field.NewPath("spec").Child("rollingUpgradeConfig").Child("hooks")
+	for _, phase := range []struct {
+		name  string
+		hooks []banzaicloudv1beta1.RollingUpgradeHook
+	}{{"preRestart", hooks.PreRestart}, {"postRestart", hooks.PostRestart}} {
+		names := make(map[string]struct{}, len(phase.hooks))
+		for i, hook := range phase.hooks {
+			hookPath := path.Child(phase.name).Index(i)
+			if _, found := names[hook.Name]; found {
+				allErrs = append(allErrs, field.Duplicate(hookPath.Child("name"), hook.Name))
+			}
+			names[hook.Name] = struct{}{}
+
+			checks := 0
+			for _, set := range []bool{hook.HTTP != nil, hook.PromQL != nil, hook.Job != nil} {
+				if set {
+					checks++
+				}
+			}
+			if checks != 1 {
+				allErrs = append(allErrs, field.Invalid(hookPath, hook.Name,
+					invalidRollingUpgradeHookErrMsg+": exactly one of http, promQL and job must be set"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// checkPrometheusReplicationCheck validates that the Prometheus replication check of rolling upgrades has an URL and
+// at most one of query and alerts
+func checkPrometheusReplicationCheck(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	check := kafkaClusterSpec.RollingUpgradeConfig.PrometheusReplicationCheck
+	if check == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	path := field.NewPath("spec").Child("rollingUpgradeConfig").Child("prometheusReplicationCheck")
+	if check.PrometheusURL == "" {
+		allErrs = append(allErrs, field.Required(path.Child("prometheusURL"), invalidPrometheusReplicationCheckErrMsg))
+	}
+	if check.Query != "" && len(check.Alerts) > 0 {
+		allErrs = append(allErrs, field.Invalid(path, check.Query,
+			invalidPrometheusReplicationCheckErrMsg+": at most one of query and alerts can be set"))
+	}
+	return allErrs
+}
+
+// cruiseControlSelfHealingConfigs are the Cruise Control configs enabling its own self-healing of the anomaly types
+var cruiseControlSelfHealingConfigs = map[banzaicloudv1beta1.CruiseControlAnomalyType]string{
+	banzaicloudv1beta1.CruiseControlAnomalyGoalViolation: "self.healing.goal.violation.enabled",
+	banzaicloudv1beta1.CruiseControlAnomalyBrokerFailure: "self.healing.broker.failure.enabled",
+	banzaicloudv1beta1.CruiseControlAnomalyDiskFailure:   "self.healing.disk.failure.enabled",
+}
+
+// checkCruiseControlSelfHealing validates that the anomalies remediated by the operator are not self-healed by
+// Cruise Control as well
+func checkCruiseControlSelfHealing(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	selfHealing := kafkaClusterSpec.CruiseControlConfig.SelfHealing
+	if selfHealing == nil || len(selfHealing.Remediations) == 0 {
+		return nil
+	}
+	var allErrs field.ErrorList
+	path := field.NewPath("spec").Child("cruiseControlConfig").Child("selfHealing").Child("remediations")
+	config, err := properties.NewFromString(kafkaClusterSpec.CruiseControlConfig.Config)
+	if err != nil {
+		config = properties.NewProperties()
+	}
+	enabled := false
+	if property, found := config.Get("self.healing.enabled"); found {
+		enabled, _ = property.Bool()
+	}
+	for i, anomalyType := range selfHealing.Remediations {
+		if slices.Contains(selfHealing.Remediations[:i], anomalyType) {
+			allErrs = append(allErrs, field.Duplicate(path.Index(i), anomalyType))
+			continue
+		}
+		typeEnabled := enabled
+		if property, found := config.Get(cruiseControlSelfHealingConfigs[anomalyType]); found {
+			typeEnabled, _ = property.Bool()
+		}
+		if typeEnabled {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), anomalyType,
+				fmt.Sprintf("%s: the self-healing of Cruise Control is enabled for %s anomalies", invalidCruiseControlSelfHealingErrMsg, anomalyType)))
+		}
+	}
+	return allErrs
+}
+
+// checkCruiseControlRef validates that Cruise Control is not deployed by a CruiseControl when an external Cruise
+// Control is used
+func checkCruiseControlRef(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	ref := kafkaClusterSpec.CruiseControlConfig.CruiseControlRef
+	if ref == nil {
+		return nil
+	}
+	path := field.NewPath("spec").Child("cruiseControlConfig").Child("cruiseControlRef")
+	if ref.Name == "" {
+		return field.ErrorList{field.Required(path.Child("name"), invalidCruiseControlRefErrMsg)}
+	}
+	if kafkaClusterSpec.CruiseControlConfig.CruiseControlEndpoint != "" {
+		return field.ErrorList{field.Forbidden(path,
+			fmt.Sprintf("%s: cruiseControlEndpoint and cruiseControlRef are mutually exclusive", invalidCruiseControlRefErrMsg))}
+	}
+	return nil
+}
+
+// checkRebalanceSchedule validates the cron expression and the goals of the scheduled rebalances
+func checkRebalanceSchedule(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	rebalanceSchedule := kafkaClusterSpec.CruiseControlConfig.RebalanceSchedule
+	if rebalanceSchedule == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	path := field.NewPath("spec").Child("cruiseControlConfig").Child("rebalanceSchedule")
+	if _, err := cron.Parse(rebalanceSchedule.Schedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("schedule"), rebalanceSchedule.Schedule,
+			fmt.Sprintf("%s: %s", invalidRebalanceScheduleErrMsg, err)))
+	}
+	for i, goal := range rebalanceSchedule.Goals {
+		if slices.Contains(rebalanceSchedule.Goals[:i], goal) {
+			allErrs = append(allErrs, field.Duplicate(path.Child("goals").Index(i), goal))
+			continue
+		}
+		if _, err := scale.ParseGoals(goal); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("goals").Index(i), goal,
+				fmt.Sprintf("%s: %s", invalidRebalanceScheduleErrMsg, err)))
+		}
+	}
+	return allErrs
+}
+
+// checkMaintenanceWindows validates the schedules, the durations and the time zones of the maintenance windows
+func checkMaintenanceWindows(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	path := field.NewPath("spec").Child("maintenanceWindows")
+	for i, window := range kafkaClusterSpec.MaintenanceWindows {
+		if _, err := maintenance.Parse(window); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), window, fmt.Sprintf("%s: %s", invalidMaintenanceWindowErrMsg, err)))
+		}
+	}
+	return allErrs
+}
+
+// checkBrokerDecommissions validates that the decommissioned brokers can be drained by Cruise Control: a broker can not
+// be replaced while it is decommissioned, and the controller-only nodes hosting no partition replicas are removed from
+// the brokers list directly
+func checkBrokerDecommissions(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, broker := range kafkaClusterSpec.Brokers {
+		if !broker.Decommission {
+			continue
+		}
+		path := field.NewPath("spec").Child("brokers").Index(i).Child("decommission")
+		if broker.Replacement != nil {
+			allErrs = append(allErrs, field.Invalid(path, broker.Decommission,
+				fmt.Sprintf("%s: the broker can not be replaced while it is decommissioned", invalidBrokerDecommissionErrMsg)))
+		}
+		if !kafkaClusterSpec.KRaftMode {
+			continue
+		}
+		brokerConfig, err := broker.GetBrokerConfig(*kafkaClusterSpec)
+		if err == nil && brokerConfig.IsControllerOnlyNode() {
+			allErrs = append(allErrs, field.Invalid(path, broker.Decommission,
+				fmt.Sprintf("%s: controller-only nodes host no partition replicas, remove them from the brokers list instead", invalidBrokerDecommissionErrMsg)))
+		}
+	}
+	return allErrs
+}
+
+// checkTieredStorage validates that a single storage backend is configured for the tiered storage plugin and that
+// the access keys of S3 are set together
+func checkTieredStorage(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	tieredStorage := kafkaClusterSpec.TieredStorage
+	if tieredStorage == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	path := field.NewPath("spec").Child("tieredStorage")
+	if tieredStorage.S3 != nil && tieredStorage.GCS != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("gcs"),
+			fmt.Sprintf("%s: s3 and gcs are mutually exclusive", invalidTieredStorageErrMsg)))
+	}
+	if s3 := tieredStorage.S3; s3 != nil && (s3.AccessKeyIDSecretRef == nil) != (s3.SecretAccessKeySecretRef == nil) {
+		allErrs = append(allErrs, field.Required(path.Child("s3"),
+			fmt.Sprintf("%s: accessKeyIdSecretRef and secretAccessKeySecretRef must be set together", invalidTieredStorageErrMsg)))
+	}
+	return allErrs
+}
+
+// checkKeystorePasswordPolicy validates that the source of the keystore passwords is configured for the generator
+func checkKeystorePasswordPolicy(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	policy := kafkaClusterSpec.ListenersConfig.SSLSecrets.GetPasswordPolicy()
+	if policy == nil {
+		return nil
+	}
+	path := field.NewPath("spec").Child("listenersConfig").Child("sslSecrets").Child("passwordPolicy")
+	switch policy.GetGenerator() {
+	case banzaicloudv1beta1.KeystorePasswordGeneratorKMSWrapped:
+		if policy.KMS == nil {
+			return field.ErrorList{field.Required(path.Child("kms"), missingKeystorePasswordSourceErrMsg)}
+		}
+	case banzaicloudv1beta1.KeystorePasswordGeneratorExternal:
+		if policy.ExternalSecretRef == nil {
+			return field.ErrorList{field.Required(path.Child("externalSecretRef"), missingKeystorePasswordSourceErrMsg)}
+		}
+	}
+	return nil
+}
+
+// checkVaultConfig validates that the vault configuration is set when the vault PKI backend is selected, and that the
+// brokers hot reload their certificates: the short-lived certificates issued by Vault are reissued well before they
+// expire, the brokers would keep serving the old ones until they are restarted otherwise
+func checkVaultConfig(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	sslSecrets := kafkaClusterSpec.ListenersConfig.SSLSecrets
+	if sslSecrets == nil || sslSecrets.PKIBackend != banzaicloudv1beta1.PKIBackendVault {
+		return nil
+	}
+	var allErrs field.ErrorList
+	if kafkaClusterSpec.VaultConfig == nil {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("vaultConfig"), missingVaultConfigErrMsg))
+	}
+	if !kafkaClusterSpec.ListenersConfig.HotReloadCertificates {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("listenersConfig").Child("hotReloadCertificates"),
+			vaultCertificatesWithoutHotReloadErrMsg))
+	}
+	return allErrs
+}
+
+// checkAuthorizationConfig validates that the readOnlyConfig does not set the authorizer properties to values
+// different from spec.authorizationConfig, those would be silently overridden by the generated broker configuration
+func checkAuthorizationConfig(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	authorizationConfig := kafkaClusterSpec.AuthorizationConfig
+	if authorizationConfig == nil {
+		return nil
+	}
+	desired := make(map[string]string)
+	if authorizerClassName := authorizationConfig.GetAuthorizerClassName(); authorizerClassName != "" {
+		desired[kafkautil.KafkaConfigAuthorizerClassName] = authorizerClassName
+	}
+	if allowEveryone, ok := authorizationConfig.GetAllowEveryoneIfNoACLFound(); ok {
+		desired[kafkautil.KafkaConfigAllowEveryoneIfNoACLFound] = strconv.FormatBool(allowEveryone)
+	}
+
+	var allErrs field.ErrorList
+	checkReadOnlyConfig := func(path *field.Path, readOnlyConfig string) {
+		config, err := properties.NewFromString(readOnlyConfig)
+		if err != nil {
+			// unparsable configurations are reported by the broker config generation
+			return
+		}
+		for _, key := range []string{kafkautil.KafkaConfigAuthorizerClassName, kafkautil.KafkaConfigAllowEveryoneIfNoACLFound} {
+			desiredValue, ok := desired[key]
+			if !ok {
+				continue
+			}
+			if property, found := config.Get(key); found && strings.TrimSpace(property.Value()) != desiredValue {
+				allErrs = append(allErrs, field.Invalid(path, key+"="+property.Value(), conflictingAuthorizationConfigErrMsg))
+			}
+		}
+	}
+	checkReadOnlyConfig(field.NewPath("spec").Child("readOnlyConfig"), kafkaClusterSpec.ReadOnlyConfig)
+	for i, broker := range kafkaClusterSpec.Brokers {
+		checkReadOnlyConfig(field.NewPath("spec").Child("brokers").Index(i).Child("readOnlyConfig"), broker.ReadOnlyConfig)
+	}
+	return allErrs
+}
+
+// checkKafkaVersion validates that the brokers running Kafka 4.x, which removed ZooKeeper entirely, are in KRaft mode
+// and are upgraded from a supported version. The versions the brokers currently run are taken from the given status.
+func checkKafkaVersion(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec, status *banzaicloudv1beta1.KafkaClusterStatus) field.ErrorList {
+	var allErrs field.ErrorList
+	kraftRequired := false
+	for i, broker := range kafkaClusterSpec.Brokers {
+		brokerConfig, err := broker.GetBrokerConfig(*kafkaClusterSpec)
+		if err != nil {
+			continue
+		}
+		version, ok := kafkautil.BrokerKafkaVersion(brokerConfig, *kafkaClusterSpec)
+		if !ok || !kafkautil.IsKRaftOnlyVersion(version) {
+			continue
+		}
+		kraftRequired = true
+		if status == nil {
+			continue
+		}
+		if err := kafkautil.CheckKRaftOnlyUpgrade(status.BrokersState[strconv.Itoa(int(broker.Id))].Version, version); err != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("brokers").Index(i),
+				unsupportedKafkaUpgradeErrMsg+": "+err.Error()))
+		}
+	}
+	if kraftRequired && !kafkaClusterSpec.KRaftMode {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("kRaft"), kafkaClusterSpec.KRaftMode, kraftRequiredErrMsg))
+	}
+	return allErrs
+}
+
+// checkVersionUpgrade validates that the brokers are not downgraded below the target version of an upgrade of the
+// Kafka version whose protocol version is being or has been bumped, as the previous version can not run the protocol
+func checkVersionUpgrade(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec, status *banzaicloudv1beta1.KafkaClusterStatus) field.ErrorList {
+	var allErrs field.ErrorList
+	if !status.VersionUpgrade.IsProtocolBumped() {
+		return nil
+	}
+	bumpedVersion, err := sarama.ParseKafkaVersion(status.VersionUpgrade.TargetVersion)
+	if err != nil {
+		return nil
+	}
+	if upgrade := kafkaClusterSpec.VersionUpgrade; upgrade != nil {
+		if targetVersion, err := sarama.ParseKafkaVersion(upgrade.TargetVersion); err == nil && !targetVersion.IsAtLeast(bumpedVersion) {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("versionUpgrade").Child("targetVersion"),
+				fmt.Sprintf("%s: the protocol version of Kafka %s is in use", kafkaDowngradeAfterProtocolBumpErrMsg, bumpedVersion)))
+		}
+	}
+	for i, broker := range kafkaClusterSpec.Brokers {
+		brokerConfig, err := broker.GetBrokerConfig(*kafkaClusterSpec)
+		if err != nil {
+			continue
+		}
+		version, ok := kafkautil.BrokerKafkaVersion(brokerConfig, *kafkaClusterSpec)
+		if ok && !version.IsAtLeast(bumpedVersion) {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("brokers").Index(i),
+				fmt.Sprintf("%s: the image runs Kafka %s while the protocol version of Kafka %s is in use",
+					kafkaDowngradeAfterProtocolBumpErrMsg, version, bumpedVersion)))
+		}
+	}
+	return allErrs
+}
+
+// checkVersionCompatibility validates the Kafka versions of the brokers against the Cruise Control version and the
+// enabled features according to the compatibility matrix, versions which can not be detected from the image tags are
+// reported as warnings
+func checkVersionCompatibility(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) (field.ErrorList, admission.Warnings) {
+	var allErrs field.ErrorList
+	var warnings admission.Warnings
+	ccImage := kafkaClusterSpec.CruiseControlConfig.GetCCImage()
+	ccVersion, ok := kafkautil.CruiseControlVersionFromImage(ccImage)
+	if !ok {
+		warnings = append(warnings, fmt.Sprintf("%s: the Cruise Control version can not be detected from image %s, its compatibility is not checked",
+			field.NewPath("spec").Child("cruiseControlConfig").Child("image"), ccImage))
+	}
+	features := kafkautil.EnabledFeatures(*kafkaClusterSpec)
+
+	checkedImages := make(map[string]struct{})
+	for i, broker := range kafkaClusterSpec.Brokers {
+		brokerConfig, err := broker.GetBrokerConfig(*kafkaClusterSpec)
+		if err != nil {
+			continue
+		}
+		image, path := kafkaClusterSpec.GetClusterImage(), field.NewPath("spec").Child("clusterImage")
+		if brokerConfig.Image != "" {
+			image, path = brokerConfig.Image, field.NewPath("spec").Child("brokers").Index(i).Child("brokerConfig").Child("image")
+		}
+		if _, checked := checkedImages[image]; checked {
+			continue
+		}
+		checkedImages[image] = struct{}{}
+
+		version, ok := kafkautil.KafkaVersionFromImage(image)
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("%s: the Kafka version can not be detected from image %s, its compatibility is not checked", path, image))
+			continue
+		}
+		for _, incompatibility := range kafkautil.CheckCompatibility(version.String(), ccVersion, features) {
+			allErrs = append(allErrs, field.Invalid(path, image, incompatibleVersionsErrMsg+": "+incompatibility))
+		}
+	}
+	return allErrs, warnings
+}
+
+// checkSingleNodeCluster validates that single-node KRaft clusters do not require more than one broker, and warns
+// about the settings which are ignored for them
+func checkSingleNodeCluster(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) (field.ErrorList, admission.Warnings) {
+	if !kafkaClusterSpec.IsSingleNodeCombinedMode() {
+		return nil, nil
+	}
+	var allErrs field.ErrorList
+	var warnings admission.Warnings
+	if topicConfig := kafkaClusterSpec.CruiseControlConfig.TopicConfig; topicConfig != nil && topicConfig.ReplicationFactor > 1 &&
+		kafkaClusterSpec.CruiseControlConfig.CruiseControlEndpoint == "" {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("cruiseControlConfig").Child("topicConfig").Child("replicationFactor"),
+			topicConfig.ReplicationFactor, invalidSingleNodeClusterErrMsg+": the replication factor exceeds the number of brokers, "+
+				"the Cruise Control metrics topic is created with a single replica when topicConfig is not set"))
+	}
+	if kafkaClusterSpec.DisruptionBudget.Create {
+		warnings = append(warnings, fmt.Sprintf("%s: pod disruption budgets are not created for single-node clusters, they would block the eviction of the node",
+			field.NewPath("spec").Child("disruptionBudget").Child("create")))
+	}
+	return allErrs, warnings
+}
+
+// checkKRaftMigration validates that the ZooKeeper to KRaft migration has a controller quorum to migrate to and that
+// a migration in progress, as recorded in the given status, is not disabled
+func checkKRaftMigration(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec, status *banzaicloudv1beta1.KafkaClusterStatus) field.ErrorList {
+	var allErrs field.ErrorList
+	path := field.NewPath("spec").Child("kRaftMigration")
+	if !kafkaClusterSpec.KRaftMigration.IsEnabled() {
+		if status != nil && status.KRaftMigration.IsInProgress() {
+			allErrs = append(allErrs, field.Forbidden(path.Child("enabled"), kraftMigrationInProgressErrMsg))
+		}
+		return allErrs
+	}
+
+	if !kafkaClusterSpec.KRaftMode {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("kRaft"), kafkaClusterSpec.KRaftMode,
+			invalidKRaftMigrationErrMsg+": the controller quorum requires KRaft mode"))
+	}
+	if status == nil || status.KRaftMigration.GetPhase() != banzaicloudv1beta1.KRaftMigrationPhaseCompleted {
+		if len(kafkaClusterSpec.ZKAddresses) == 0 {
+			allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("zkAddresses"),
+				invalidKRaftMigrationErrMsg+": the metadata is migrated from ZooKeeper"))
+		}
+	}
+
+	hasController := false
+	for i, broker := range kafkaClusterSpec.Brokers {
+		brokerConfig, err := broker.GetBrokerConfig(*kafkaClusterSpec)
+		if err != nil {
+			continue
+		}
+		switch {
+		case brokerConfig.IsControllerOnlyNode():
+			hasController = true
+		case brokerConfig.IsControllerNode():
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("brokers").Index(i),
+				invalidKRaftMigrationErrMsg+": combined broker and controller nodes can not be migrated from ZooKeeper"))
+		}
+	}
+	if !hasController {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("brokers"),
+			invalidKRaftMigrationErrMsg+": at least one controller node is required"))
+	}
+	return allErrs
+}
+
+// checkControllerQuorum validates that the controller nodes of a KRaft cluster are not changed. The controller quorum
+// is configured with the static controller.quorum.voters set, which Kafka does not support changing by rolling the
+// nodes: the controllers with different voter sets could elect two leaders. Changing the controllers requires the
+// dynamic quorum membership of KIP-853, which the operator does not manage.
+func checkControllerQuorum(kafkaClusterSpec, currentKafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	if !kafkaClusterSpec.KRaftMode || !currentKafkaClusterSpec.KRaftMode {
+		return nil
+	}
+	currentVoters, err := currentKafkaClusterSpec.GetControllerNodeIDs()
+	if err != nil || len(currentVoters) == 0 {
+		return nil
+	}
+	voters, err := kafkaClusterSpec.GetControllerNodeIDs()
+	if err != nil {
+		return nil
+	}
+
+	slices.Sort(currentVoters)
+	slices.Sort(voters)
+	if !slices.Equal(currentVoters, voters) {
+		return field.ErrorList{field.Forbidden(field.NewPath("spec").Child("brokers"),
+			fmt.Sprintf("%s: the controller nodes %v can not be changed to %v", controllerQuorumChangeErrMsg, currentVoters, voters))}
+	}
+	return nil
+}
+
+// checkUniqueBrokerIDs validates that the ids of the brokers are unique, the broker pods, services and PVCs are
+// named after them
+func checkUniqueBrokerIDs(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	ids := make(map[int32]struct{}, len(kafkaClusterSpec.Brokers))
+	for i, broker := range kafkaClusterSpec.Brokers {
+		if _, found := ids[broker.Id]; found {
+			allErrs = append(allErrs, field.Duplicate(field.NewPath("spec").Child("brokers").Index(i).Child("id"), broker.Id))
+		}
+		ids[broker.Id] = struct{}{}
+	}
+	return allErrs
+}
+
+// checkKRaftControllers validates that a KRaft cluster has at least one controller node, the brokers can not start
+// without a controller quorum. Clusters migrating from ZooKeeper are validated by checkKRaftMigration.
+func checkKRaftControllers(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	if !kafkaClusterSpec.KRaftMode || kafkaClusterSpec.KRaftMigration.IsEnabled() {
+		return nil
+	}
+	controllers, err := kafkaClusterSpec.GetControllerNodeIDs()
+	if err != nil || len(controllers) > 0 {
+		return nil
+	}
+	return field.ErrorList{field.Required(field.NewPath("spec").Child("brokers"), missingKRaftControllerErrMsg)}
+}
+
+// checkStorageMountPaths validates that the storage configs of the broker config groups and of the brokers are mounted
+// on distinct paths, and that the extra volume mounts of the brokers do not use the mount paths of the storages
+func checkStorageMountPaths(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	checkDuplicates := func(path *field.Path, storageConfigs []banzaicloudv1beta1.StorageConfig) {
+		mountPaths := make(map[string]struct{}, len(storageConfigs))
+		for i, storage := range storageConfigs {
+			if _, found := mountPaths[storage.MountPath]; found {
+				allErrs = append(allErrs, field.Duplicate(path.Index(i).Child("mountPath"), storage.MountPath))
+			}
+			mountPaths[storage.MountPath] = struct{}{}
+		}
+	}
+
+	groupNames := make([]string, 0, len(kafkaClusterSpec.BrokerConfigGroups))
+	for name := range kafkaClusterSpec.BrokerConfigGroups {
+		groupNames = append(groupNames, name)
+	}
+	slices.Sort(groupNames)
+	for _, name := range groupNames {
+		checkDuplicates(field.NewPath("spec").Child("brokerConfigGroups").Key(name).Child("storageConfigs"),
+			kafkaClusterSpec.BrokerConfigGroups[name].StorageConfigs)
+	}
+
+	for i, broker := range kafkaClusterSpec.Brokers {
+		path := field.NewPath("spec").Child("brokers").Index(i)
+		if broker.BrokerConfig != nil {
+			checkDuplicates(path.Child("brokerConfig").Child("storageConfigs"), broker.BrokerConfig.StorageConfigs)
+		}
+		brokerConfig, err := broker.GetBrokerConfig(*kafkaClusterSpec)
+		if err != nil || brokerConfig == nil {
+			continue
+		}
+		for _, storage := range brokerConfig.StorageConfigs {
+			for _, volumeMount := range brokerConfig.VolumeMounts {
+				if volumeMount.MountPath == storage.MountPath {
+					allErrs = append(allErrs, field.Invalid(path, storage.MountPath,
+						fmt.Sprintf("%s: the volume mount %s uses the mount path of a storage", invalidStorageMountPathErrMsg, volumeMount.Name)))
+				}
+			}
+		}
+	}
+	return allErrs
+}
+
+// checkDisruptionBudget validates that the budget of the pod disruption budget of the brokers is either a number or a
+// percentage, the pod disruption budget can not be created otherwise
+func checkDisruptionBudget(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	disruptionBudget := kafkaClusterSpec.DisruptionBudget
+	path := field.NewPath("spec").Child("disruptionBudget").Child("budget")
+	if disruptionBudget.Budget == "" {
+		if disruptionBudget.Create {
+			return field.ErrorList{field.Required(path, invalidDisruptionBudgetErrMsg+": the budget is required to create the pod disruption budget")}
+		}
+		return nil
+	}
+	if percentage, isPercentage := strings.CutSuffix(disruptionBudget.Budget, "%"); isPercentage {
+		if value, err := strconv.Atoi(percentage); err != nil || value < 0 || value > 100 {
+			return field.ErrorList{field.Invalid(path, disruptionBudget.Budget, invalidDisruptionBudgetErrMsg+": the percentage must be between 0% and 100%")}
+		}
+		return nil
+	}
+	if value, err := strconv.Atoi(disruptionBudget.Budget); err != nil || value < 0 {
+		return field.ErrorList{field.Invalid(path, disruptionBudget.Budget, invalidDisruptionBudgetErrMsg+": the budget must be a non-negative number or a percentage")}
+	}
+	return nil
+}
+
+// kraftOnlyRemovedConfigWarnings warns about the readOnlyConfig properties removed in Kafka 4.x, they are dropped from
+// the configuration of the brokers running Kafka 4.x
+func kraftOnlyRemovedConfigWarnings(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) admission.Warnings {
+	version, ok := kafkautil.KafkaVersionFromImage(kafkaClusterSpec.GetClusterImage())
+	if !ok || !kafkautil.IsKRaftOnlyVersion(version) {
+		return nil
+	}
+	config, err := properties.NewFromString(kafkaClusterSpec.ReadOnlyConfig)
+	if err != nil {
+		return nil
+	}
+	var warnings admission.Warnings
+	for _, key := range kafkautil.KRaftOnlyRemovedConfigs {
+		if _, found := config.Get(key); found {
+			warnings = append(warnings, fmt.Sprintf("%s: %s is not supported by Kafka %s and is ignored",
+				field.NewPath("spec").Child("readOnlyConfig"), key, version))
+		}
+	}
+	return warnings
+}
+
+// internalTopicsReplicationWarnings warns about replication settings of the internal topics in readOnlyConfig
+// which are unsafe for the number of brokers of the cluster
+func internalTopicsReplicationWarnings(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) admission.Warnings {
+	config, err := properties.NewFromString(kafkaClusterSpec.ReadOnlyConfig)
+	if err != nil {
+		// unparsable configurations are reported by the broker config generation
+		return nil
+	}
+	brokerCount, err := kafkautil.BrokerNodeCount(*kafkaClusterSpec)
+	if err != nil {
+		return nil
+	}
+	var warnings admission.Warnings
+	for _, warning := range kafkautil.InternalTopicsReplicationWarnings(config, brokerCount) {
+		warnings = append(warnings, field.NewPath("spec").Child("readOnlyConfig").String()+": "+warning)
+	}
+	return warnings
+}
+
 // checkListeners validates the spec.listenersConfig object
 func checkInternalAndExternalListeners(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
 	var allErrs field.ErrorList
@@ -93,7 +1086,144 @@ func checkInternalAndExternalListeners(kafkaClusterSpec *banzaicloudv1beta1.Kafk
 }
 
 func checkInternalListeners(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
-	return checkUniqueListenerContainerPort(kafkaClusterSpec.ListenersConfig)
+	var allErrs field.ErrorList
+
+	allErrs = append(allErrs, checkUniqueListenerContainerPort(kafkaClusterSpec.ListenersConfig)...)
+
+	allErrs = append(allErrs, checkListenerServerCertificates(kafkaClusterSpec)...)
+
+	allErrs = append(allErrs, checkListenerOAuthBearer(kafkaClusterSpec)...)
+
+	allErrs = append(allErrs, checkListenerKerberos(kafkaClusterSpec)...)
+
+	return allErrs
+}
+
+// checkListenerServerCertificates validates the server certificates of the listeners with their own PKI: only SSL
+// listeners have server certificates and exactly one source of the certificate has to be set
+func checkListenerServerCertificates(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	checkServerCertificate := func(path *field.Path, listener banzaicloudv1beta1.CommonListenerSpec) {
+		if listener.ServerCertificate == nil {
+			return
+		}
+		if listener.Type != banzaicloudv1beta1.SecurityProtocolSSL {
+			allErrs = append(allErrs, field.Invalid(path.Child("type"), listener.Type,
+				invalidListenerServerCertificateErrMsg+": server certificate can only be set for ssl listeners"))
+		}
+		if (listener.ServerCertificate.IssuerRef == nil) == (listener.ServerCertificate.TLSSecretName == "") {
+			allErrs = append(allErrs, field.Invalid(path.Child("serverCertificate"), listener.ServerCertificate,
+				invalidListenerServerCertificateErrMsg+": exactly one of issuerRef and tlsSecretName must be set"))
+		}
+	}
+
+	path := field.NewPath("spec").Child("listenersConfig")
+	for i, intListener := range kafkaClusterSpec.ListenersConfig.InternalListeners {
+		checkServerCertificate(path.Child("internalListeners").Index(i), intListener.CommonListenerSpec)
+	}
+	for i, extListener := range kafkaClusterSpec.ListenersConfig.ExternalListeners {
+		checkServerCertificate(path.Child("externalListeners").Index(i), extListener.CommonListenerSpec)
+	}
+	return allErrs
+}
+
+// checkListenerOAuthBearer validates the SASL/OAUTHBEARER configuration of the listeners: it can only be set for SASL
+// listeners which are not used for the communication between the brokers, as the brokers have no client credentials
+// of the OpenID Connect provider, and the JWKS endpoint has to be set or discovered from the issuer
+func checkListenerOAuthBearer(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	checkOAuthBearer := func(path *field.Path, listener banzaicloudv1beta1.CommonListenerSpec) {
+		if listener.OAuthBearer == nil {
+			return
+		}
+		if !listener.Type.IsSasl() {
+			allErrs = append(allErrs, field.Invalid(path.Child("type"), listener.Type,
+				invalidListenerOAuthBearerErrMsg+": SASL/OAUTHBEARER can only be set for sasl_ssl and sasl_plaintext listeners"))
+		}
+		if listener.UsedForInnerBrokerCommunication {
+			allErrs = append(allErrs, field.Forbidden(path.Child("usedForInnerBrokerCommunication"),
+				invalidListenerOAuthBearerErrMsg+": SASL/OAUTHBEARER listeners can not be used for inter broker communication"))
+		}
+		if listener.OAuthBearer.JWKSEndpointURL == "" && listener.OAuthBearer.IssuerURL == "" {
+			allErrs = append(allErrs, field.Required(path.Child("oauthBearer").Child("jwksEndpointURL"),
+				invalidListenerOAuthBearerErrMsg+": the JWKS endpoint or the issuer to discover it from must be set"))
+		}
+	}
+
+	path := field.NewPath("spec").Child("listenersConfig")
+	for i, intListener := range kafkaClusterSpec.ListenersConfig.InternalListeners {
+		checkOAuthBearer(path.Child("internalListeners").Index(i), intListener.CommonListenerSpec)
+	}
+	for i, extListener := range kafkaClusterSpec.ListenersConfig.ExternalListeners {
+		checkOAuthBearer(path.Child("externalListeners").Index(i), extListener.CommonListenerSpec)
+	}
+	return allErrs
+}
+
+// checkListenerKerberos validates the Kerberos configuration of the listeners: it can only be set for SASL listeners
+// and the principal of the brokers and the keytab holding its keys have to be set
+func checkListenerKerberos(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	checkKerberos := func(path *field.Path, listener banzaicloudv1beta1.CommonListenerSpec) {
+		if listener.Kerberos == nil {
+			return
+		}
+		if !listener.Type.IsSasl() {
+			allErrs = append(allErrs, field.Invalid(path.Child("type"), listener.Type,
+				invalidListenerKerberosErrMsg+": SASL/GSSAPI can only be set for sasl_ssl and sasl_plaintext listeners"))
+		}
+		if listener.Kerberos.PrincipalTemplate == "" {
+			allErrs = append(allErrs, field.Required(path.Child("kerberos").Child("principalTemplate"), invalidListenerKerberosErrMsg))
+		}
+		if listener.Kerberos.KeytabSecretRef.Name == "" || listener.Kerberos.KeytabSecretRef.Key == "" {
+			allErrs = append(allErrs, field.Required(path.Child("kerberos").Child("keytabSecretRef"), invalidListenerKerberosErrMsg))
+		}
+	}
+
+	path := field.NewPath("spec").Child("listenersConfig")
+	for i, intListener := range kafkaClusterSpec.ListenersConfig.InternalListeners {
+		checkKerberos(path.Child("internalListeners").Index(i), intListener.CommonListenerSpec)
+	}
+	for i, extListener := range kafkaClusterSpec.ListenersConfig.ExternalListeners {
+		checkKerberos(path.Child("externalListeners").Index(i), extListener.CommonListenerSpec)
+	}
+	return allErrs
+}
+
+// checkListenerSSLClientAuth validates the client authentication mode of the listeners: it can only be set for ssl
+// listeners, as it is not applied to any other listener. It is still accepted unchanged on a non-ssl listener of the
+// stored cluster, so the clusters created before this check can be updated.
+func checkListenerSSLClientAuth(kafkaClusterSpec, oldKafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	oldListeners := make(map[string]banzaicloudv1beta1.CommonListenerSpec)
+	if oldKafkaClusterSpec != nil {
+		for _, intListener := range oldKafkaClusterSpec.ListenersConfig.InternalListeners {
+			oldListeners[intListener.Name] = intListener.CommonListenerSpec
+		}
+		for _, extListener := range oldKafkaClusterSpec.ListenersConfig.ExternalListeners {
+			oldListeners[extListener.Name] = extListener.CommonListenerSpec
+		}
+	}
+
+	var allErrs field.ErrorList
+	checkSSLClientAuth := func(path *field.Path, listener banzaicloudv1beta1.CommonListenerSpec) {
+		if listener.SSLClientAuth == "" || listener.Type == banzaicloudv1beta1.SecurityProtocolSSL {
+			return
+		}
+		if old, ok := oldListeners[listener.Name]; ok && old.Type == listener.Type && old.SSLClientAuth == listener.SSLClientAuth {
+			return
+		}
+		allErrs = append(allErrs, field.Invalid(path.Child("sslClientAuth"), listener.SSLClientAuth,
+			invalidListenerSSLClientAuthErrMsg+": client authentication can only be set for ssl listeners"))
+	}
+
+	path := field.NewPath("spec").Child("listenersConfig")
+	for i, intListener := range kafkaClusterSpec.ListenersConfig.InternalListeners {
+		checkSSLClientAuth(path.Child("internalListeners").Index(i), intListener.CommonListenerSpec)
+	}
+	for i, extListener := range kafkaClusterSpec.ListenersConfig.ExternalListeners {
+		checkSSLClientAuth(path.Child("externalListeners").Index(i), extListener.CommonListenerSpec)
+	}
+	return allErrs
 }
 
 func checkExternalListeners(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
@@ -103,9 +1233,165 @@ func checkExternalListeners(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpe
 
 	allErrs = append(allErrs, checkTargetPortsCollisionForEnvoy(kafkaClusterSpec)...)
 
+	allErrs = append(allErrs, checkGatewayAPIConfig(kafkaClusterSpec)...)
+
+	allErrs = append(allErrs, checkNginxIngressConfig(kafkaClusterSpec)...)
+
+	allErrs = append(allErrs, checkPerBrokerLoadBalancerConfig(kafkaClusterSpec)...)
+
+	allErrs = append(allErrs, checkIstioMeshMode(kafkaClusterSpec)...)
+
+	allErrs = append(allErrs, checkEnvoyListenerConfig(kafkaClusterSpec)...)
+
+	return allErrs
+}
+
+// checkGatewayAPIConfig validates the external listeners exposed through the Gateway API: the Gateway is provisioned
+// by the Gateway API implementation, so only the LoadBalancer access method is supported, and TLSRoute requires the
+// broker hostnames and a TLS listener since the traffic is routed by SNI without being terminated on the Gateway
+func checkGatewayAPIConfig(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, extListener := range kafkaClusterSpec.ListenersConfig.ExternalListeners {
+		if kafkaClusterSpec.GetIngressControllerForListener(extListener) != gatewayapiutils.IngressControllerName {
+			continue
+		}
+		path := field.NewPath("spec").Child("listenersConfig").Child("externalListeners").Index(i)
+		if extListener.GetAccessMethod() != corev1.ServiceTypeLoadBalancer {
+			allErrs = append(allErrs, field.NotSupported(path.Child("accessMethod"), extListener.GetAccessMethod(),
+				[]string{string(corev1.ServiceTypeLoadBalancer)}))
+			continue
+		}
+		ingressConfigs, _, err := util.GetIngressConfigs(*kafkaClusterSpec, extListener)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("config"), extListener.Config, invalidGatewayAPIConfigErrMsg+": "+err.Error()))
+			continue
+		}
+		for name, ingressConfig := range ingressConfigs {
+			configPath := field.NewPath("spec").Child("gatewayAPIConfig")
+			if name != util.IngressConfigGlobalName {
+				configPath = path.Child("config").Child("ingressConfig").Key(name).Child("gatewayAPIConfig")
+			}
+			gatewayAPIConfig := ingressConfig.GatewayAPIConfig
+			if gatewayAPIConfig.GatewayClassName == "" {
+				allErrs = append(allErrs, field.Required(configPath.Child("gatewayClassName"), invalidGatewayAPIConfigErrMsg))
+			}
+			if gatewayAPIConfig.GetRouteType() != banzaicloudv1beta1.GatewayAPIRouteTypeTLS {
+				continue
+			}
+			if !strings.Contains(gatewayAPIConfig.BrokerHostnameTemplate, "%id") {
+				allErrs = append(allErrs, field.Invalid(configPath.Child("brokerHostnameTemplate"), gatewayAPIConfig.BrokerHostnameTemplate,
+					invalidGatewayAPIConfigErrMsg+": TLSRoute requires a broker hostname template containing %id"))
+			}
+		}
+		if usesTLSRoute(ingressConfigs) && !extListener.Type.IsSSL() {
+			allErrs = append(allErrs, field.Invalid(path.Child("type"), extListener.Type,
+				invalidGatewayAPIConfigErrMsg+": TLSRoute requires an ssl or sasl_ssl external listener"))
+		}
+	}
+	return allErrs
+}
+
+// checkNginxIngressConfig validates the external listeners exposed through ingress-nginx: the brokers are published
+// as TCP services on the LoadBalancer service of the controller, hence each broker needs a dedicated port
+func checkNginxIngressConfig(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, extListener := range kafkaClusterSpec.ListenersConfig.ExternalListeners {
+		if kafkaClusterSpec.GetIngressControllerForListener(extListener) != nginxutils.IngressControllerName {
+			continue
+		}
+		path := field.NewPath("spec").Child("listenersConfig").Child("externalListeners").Index(i)
+		if extListener.GetAccessMethod() != corev1.ServiceTypeLoadBalancer {
+			allErrs = append(allErrs, field.NotSupported(path.Child("accessMethod"), extListener.GetAccessMethod(),
+				[]string{string(corev1.ServiceTypeLoadBalancer)}))
+		}
+		if extListener.TLSEnabled() {
+			allErrs = append(allErrs, field.Invalid(path.Child("externalStartingPort"), extListener.ExternalStartingPort,
+				invalidNginxIngressConfigErrMsg+": ingress-nginx requires a dedicated port per broker"))
+		}
+	}
 	return allErrs
 }
 
+// checkIstioMeshMode validates that the external listeners exposed through Istio use the mesh mode of the brokers,
+// a broker pod is either enrolled into the ambient mesh or gets a sidecar but cannot be part of both data planes
+func checkIstioMeshMode(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	meshMode := kafkaClusterSpec.IstioIngressConfig.GetMeshMode()
+	for i, extListener := range kafkaClusterSpec.ListenersConfig.ExternalListeners {
+		if kafkaClusterSpec.GetIngressControllerForListener(extListener) != istioingressutils.IngressControllerName || extListener.Config == nil {
+			continue
+		}
+		path := field.NewPath("spec").Child("listenersConfig").Child("externalListeners").Index(i).Child("config").Child("ingressConfig")
+		for name, ingressConfig := range extListener.Config.IngressConfig {
+			if ingressConfig.IstioIngressConfig == nil || ingressConfig.IstioIngressConfig.MeshMode == "" {
+				continue
+			}
+			if ingressConfig.IstioIngressConfig.GetMeshMode() != meshMode {
+				allErrs = append(allErrs, field.Invalid(path.Key(name).Child("istioIngressConfig").Child("meshMode"),
+					ingressConfig.IstioIngressConfig.MeshMode,
+					fmt.Sprintf("%s: the brokers run in %s mode set by spec.istioIngressConfig.meshMode", invalidIstioMeshModeErrMsg, meshMode)))
+			}
+		}
+	}
+	return allErrs
+}
+
+// checkEnvoyListenerConfig validates the listener settings of the Envoy configs, Envoy rejects connection rate limit
+// token buckets refilled more often than every 50ms
+func checkEnvoyListenerConfig(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	checkListenerConfig := func(path *field.Path, listenerConfig *banzaicloudv1beta1.EnvoyListenerConfig) {
+		if listenerConfig == nil || listenerConfig.ConnectionRateLimit == nil {
+			return
+		}
+		if fillInterval := listenerConfig.ConnectionRateLimit.FillInterval; fillInterval.Duration < envoyutils.MinRateLimitFillInterval {
+			allErrs = append(allErrs, field.Invalid(path.Child("connectionRateLimit").Child("fillInterval"), fillInterval.Duration.String(),
+				fmt.Sprintf("%s: the fill interval must be at least %s", invalidEnvoyListenerConfigErrMsg, envoyutils.MinRateLimitFillInterval)))
+		}
+	}
+
+	checkListenerConfig(field.NewPath("spec").Child("envoyConfig").Child("listenerConfig"), kafkaClusterSpec.EnvoyConfig.ListenerConfig)
+	for i, extListener := range kafkaClusterSpec.ListenersConfig.ExternalListeners {
+		if extListener.Config == nil {
+			continue
+		}
+		path := field.NewPath("spec").Child("listenersConfig").Child("externalListeners").Index(i).Child("config").Child("ingressConfig")
+		for name, ingressConfig := range extListener.Config.IngressConfig {
+			if ingressConfig.EnvoyConfig != nil {
+				checkListenerConfig(path.Key(name).Child("envoyConfig").Child("listenerConfig"), ingressConfig.EnvoyConfig.ListenerConfig)
+			}
+		}
+	}
+	return allErrs
+}
+
+// checkPerBrokerLoadBalancerConfig validates that the brokers exposed through their own load balancer are advertised
+// on distinct hostnames
+func checkPerBrokerLoadBalancerConfig(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, extListener := range kafkaClusterSpec.ListenersConfig.ExternalListeners {
+		if extListener.GetAccessMethod() != banzaicloudv1beta1.ServiceTypePerBrokerLoadBalancer || extListener.PerBrokerLoadBalancerConfig == nil {
+			continue
+		}
+		template := extListener.PerBrokerLoadBalancerConfig.BrokerHostnameTemplate
+		if template != "" && !strings.Contains(template, "%id") {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("listenersConfig").Child("externalListeners").Index(i).
+				Child("perBrokerLoadBalancerConfig").Child("brokerHostnameTemplate"), template,
+				invalidPerBrokerLoadBalancerConfigErrMsg+": the broker hostname template must contain %id"))
+		}
+	}
+	return allErrs
+}
+
+func usesTLSRoute(ingressConfigs map[string]banzaicloudv1beta1.IngressConfig) bool {
+	for _, ingressConfig := range ingressConfigs {
+		if ingressConfig.GatewayAPIConfig.GetRouteType() == banzaicloudv1beta1.GatewayAPIRouteTypeTLS {
+			return true
+		}
+	}
+	return false
+}
+
 // checkUniqueListenerContainerPort checks for duplicate containerPort numbers across both internal and external listeners
 // which would subsequently generate a "Duplicate value" error when creating a Service which accumulates all these ports.
 // The first time a port number is found will not be reported as duplicate; only subsequent instances using that port are.
@@ -153,7 +1439,7 @@ func checkExternalListenerStartingPort(kafkaClusterSpec *banzaicloudv1beta1.Kafk
 				collidingPortsBrokerIDs = append(collidingPortsBrokerIDs, broker.Id)
 			}
 
-			if kafkaClusterSpec.GetIngressController() == "envoy" {
+			if kafkaClusterSpec.GetIngressControllerForListener(extListener) == "envoy" {
 				if externalPort == kafkaClusterSpec.EnvoyConfig.GetEnvoyAdminPort() || externalPort == kafkaClusterSpec.EnvoyConfig.GetEnvoyHealthCheckPort() {
 					collidingPortsBrokerIDs = append(collidingPortsBrokerIDs, broker.Id)
 				}
@@ -177,9 +1463,22 @@ func checkExternalListenerStartingPort(kafkaClusterSpec *banzaicloudv1beta1.Kafk
 	return allErrs
 }
 
+// isEnvoyIngressControllerInUse returns true if envoy is the cluster wide ingress controller or any of the external listeners uses it
+func isEnvoyIngressControllerInUse(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) bool {
+	if kafkaClusterSpec.GetIngressController() == "envoy" {
+		return true
+	}
+	for _, extListener := range kafkaClusterSpec.ListenersConfig.ExternalListeners {
+		if kafkaClusterSpec.GetIngressControllerForListener(extListener) == "envoy" {
+			return true
+		}
+	}
+	return false
+}
+
 // checkTargetPortsCollisionForEnvoy checks if the IngressControllerTargetPort collides with the other container ports for envoy deployment
 func checkTargetPortsCollisionForEnvoy(kafkaClusterSpec *banzaicloudv1beta1.KafkaClusterSpec) field.ErrorList {
-	if kafkaClusterSpec.GetIngressController() != "envoy" {
+	if !isEnvoyIngressControllerInUse(kafkaClusterSpec) {
 		return nil
 	}
 
@@ -200,6 +1499,10 @@ func checkTargetPortsCollisionForEnvoy(kafkaClusterSpec *banzaicloudv1beta1.Kafk
 			if extListener.GetAccessMethod() != corev1.ServiceTypeLoadBalancer {
 				continue
 			}
+			// listeners exposed through other ingress controllers are not served by the envoy deployment
+			if kafkaClusterSpec.GetIngressControllerForListener(extListener) != "envoy" {
+				continue
+			}
 
 			if extListener.GetIngressControllerTargetPort() == ap {
 				errmsg := invalidContainerPortForIngressControllerErrMsg + ": " + fmt.Sprintf(
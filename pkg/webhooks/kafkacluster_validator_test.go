@@ -18,9 +18,13 @@ package webhooks
 import (
 	"fmt"
 	"testing"
+	"time"
 
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/banzaicloud/koperator/pkg/util"
@@ -376,6 +380,47 @@ func TestCheckTargetPortsCollisionForEnvoy(t *testing.T) {
 					invalidContainerPortForIngressControllerErrMsg+": ExternalListener 'test-external2' uses an ingress controller target port number that collides with the envoy's admin port"),
 			),
 		},
+		{
+			testName: "valid config: colliding ingress controller target port on an external listener which uses a different ingress controller",
+			kafkaClusterSpec: v1beta1.KafkaClusterSpec{
+				ListenersConfig: v1beta1.ListenersConfig{
+					ExternalListeners: []v1beta1.ExternalListenerConfig{
+						{
+							CommonListenerSpec:          v1beta1.CommonListenerSpec{Name: "test-external1"},
+							IngressController:           "istioingress",
+							IngressControllerTargetPort: util.Int32Pointer(8081),
+						},
+						{
+							CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "test-external2"},
+						},
+					},
+				},
+			},
+			expected: nil,
+		},
+		{
+			testName: "invalid config: envoy is only used by an external listener and its target port collides with the envoy admin port",
+			kafkaClusterSpec: v1beta1.KafkaClusterSpec{
+				IngressController: "istioingress",
+				ListenersConfig: v1beta1.ListenersConfig{
+					ExternalListeners: []v1beta1.ExternalListenerConfig{
+						{
+							CommonListenerSpec:          v1beta1.CommonListenerSpec{Name: "test-external1"},
+							IngressControllerTargetPort: util.Int32Pointer(8081),
+						},
+						{
+							CommonListenerSpec:          v1beta1.CommonListenerSpec{Name: "test-external2"},
+							IngressController:           "envoy",
+							IngressControllerTargetPort: util.Int32Pointer(8081),
+						},
+					},
+				},
+			},
+			expected: append(field.ErrorList{},
+				field.Invalid(field.NewPath("spec").Child("listenersConfig").Child("externalListeners").Index(1).Child("ingressControllerTargetPort"), int32(8081),
+					invalidContainerPortForIngressControllerErrMsg+": ExternalListener 'test-external2' uses an ingress controller target port number that collides with the envoy's admin port"),
+			),
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -385,3 +430,1843 @@ func TestCheckTargetPortsCollisionForEnvoy(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckPrincipalConfig(t *testing.T) {
+	testCases := []struct {
+		testName        string
+		principalConfig *v1beta1.PrincipalConfig
+		expectedErrPath []string
+	}{
+		{
+			testName: "no principal config",
+		},
+		{
+			testName: "valid rules",
+			principalConfig: &v1beta1.PrincipalConfig{
+				SSLPrincipalMappingRules: []string{"RULE:^CN=(.*?),OU=ServiceUsers.*$/$1/L", "DEFAULT"},
+			},
+		},
+		{
+			testName: "invalid rules",
+			principalConfig: &v1beta1.PrincipalConfig{
+				SSLPrincipalMappingRules: []string{"DEFAULT", "RULE:^CN=(.*?)$", "RULE:^CN=(.*?$/$1/"},
+			},
+			expectedErrPath: []string{
+				"spec.principalConfig.sslPrincipalMappingRules[1]",
+				"spec.principalConfig.sslPrincipalMappingRules[2]",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		test := testCase
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkPrincipalConfig(&v1beta1.KafkaClusterSpec{PrincipalConfig: test.principalConfig})
+			require.Len(t, errs, len(test.expectedErrPath))
+			for i, err := range errs {
+				require.Equal(t, test.expectedErrPath[i], err.Field)
+				require.Contains(t, err.Detail, invalidSSLPrincipalMappingRuleErrMsg)
+			}
+		})
+	}
+}
+
+func TestCheckAuthorizationConfig(t *testing.T) {
+	testCases := []struct {
+		testName             string
+		authorizationConfig  *v1beta1.AuthorizationConfig
+		readOnlyConfig       string
+		brokerReadOnlyConfig string
+		expectedErrPath      []string
+	}{
+		{
+			testName:       "no authorization config",
+			readOnlyConfig: "authorizer.class.name=kafka.security.authorizer.AclAuthorizer",
+		},
+		{
+			testName: "matching readOnlyConfig",
+			authorizationConfig: &v1beta1.AuthorizationConfig{
+				AuthorizerClassName:       "kafka.security.authorizer.AclAuthorizer",
+				AllowEveryoneIfNoACLFound: util.BoolPointer(false),
+			},
+			readOnlyConfig: "authorizer.class.name=kafka.security.authorizer.AclAuthorizer\nallow.everyone.if.no.acl.found=false\nsuper.users=User:CN=admin",
+		},
+		{
+			testName: "conflicting readOnlyConfig",
+			authorizationConfig: &v1beta1.AuthorizationConfig{
+				AuthorizerClassName:       "org.apache.kafka.metadata.authorizer.StandardAuthorizer",
+				AllowEveryoneIfNoACLFound: util.BoolPointer(false),
+			},
+			readOnlyConfig:       "authorizer.class.name=kafka.security.authorizer.AclAuthorizer",
+			brokerReadOnlyConfig: "allow.everyone.if.no.acl.found=true",
+			expectedErrPath: []string{
+				"spec.readOnlyConfig",
+				"spec.brokers[0].readOnlyConfig",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		test := testCase
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkAuthorizationConfig(&v1beta1.KafkaClusterSpec{
+				AuthorizationConfig: test.authorizationConfig,
+				ReadOnlyConfig:      test.readOnlyConfig,
+				Brokers:             []v1beta1.Broker{{Id: 0, ReadOnlyConfig: test.brokerReadOnlyConfig}},
+			})
+			require.Len(t, errs, len(test.expectedErrPath))
+			for i, err := range errs {
+				require.Equal(t, test.expectedErrPath[i], err.Field)
+				require.Contains(t, err.Detail, conflictingAuthorizationConfigErrMsg)
+			}
+		})
+	}
+}
+
+func TestInternalTopicsReplicationWarnings(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		readOnlyConfig   string
+		expectedWarnings int
+	}{
+		{
+			testName:       "no overrides",
+			readOnlyConfig: "auto.create.topics.enable=false",
+		},
+		{
+			testName:         "replication factor exceeds the brokers",
+			readOnlyConfig:   "offsets.topic.replication.factor=5",
+			expectedWarnings: 1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		test := testCase
+		t.Run(test.testName, func(t *testing.T) {
+			warnings := internalTopicsReplicationWarnings(&v1beta1.KafkaClusterSpec{
+				ReadOnlyConfig:     test.readOnlyConfig,
+				BrokerConfigGroups: map[string]v1beta1.BrokerConfig{"default": {}},
+				Brokers: []v1beta1.Broker{
+					{Id: 0, BrokerConfigGroup: "default"},
+					{Id: 1, BrokerConfigGroup: "default"},
+					{Id: 2, BrokerConfigGroup: "default"},
+				},
+			})
+			require.Len(t, warnings, test.expectedWarnings)
+			for _, warning := range warnings {
+				require.Contains(t, warning, "spec.readOnlyConfig: ")
+			}
+		})
+	}
+}
+
+func TestCheckKafkaVersion(t *testing.T) {
+	testCases := []struct {
+		testName        string
+		kraftMode       bool
+		clusterImage    string
+		status          *v1beta1.KafkaClusterStatus
+		expectedErrPath []string
+	}{
+		{
+			testName:     "Kafka 3.x in ZooKeeper mode",
+			clusterImage: "ghcr.io/adobe/koperator/kafka:2.13-3.9.1",
+		},
+		{
+			testName:     "Kafka 4.x in KRaft mode",
+			kraftMode:    true,
+			clusterImage: "ghcr.io/adobe/koperator/kafka:2.13-4.0.0",
+		},
+		{
+			testName:        "Kafka 4.x in ZooKeeper mode",
+			clusterImage:    "ghcr.io/adobe/koperator/kafka:2.13-4.0.0",
+			expectedErrPath: []string{"spec.kRaft"},
+		},
+		{
+			testName:     "upgrade to Kafka 4.x from an unsupported version",
+			kraftMode:    true,
+			clusterImage: "ghcr.io/adobe/koperator/kafka:2.13-4.0.0",
+			status: &v1beta1.KafkaClusterStatus{
+				BrokersState: map[string]v1beta1.BrokerState{"0": {Version: "3.2.3"}},
+			},
+			expectedErrPath: []string{"spec.brokers[0]"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		test := testCase
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkKafkaVersion(&v1beta1.KafkaClusterSpec{
+				KRaftMode:          test.kraftMode,
+				ClusterImage:       test.clusterImage,
+				BrokerConfigGroups: map[string]v1beta1.BrokerConfig{"default": {}},
+				Brokers:            []v1beta1.Broker{{Id: 0, BrokerConfigGroup: "default"}},
+			}, test.status)
+			errPaths := make([]string, 0, len(errs))
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.ElementsMatch(t, test.expectedErrPath, errPaths)
+		})
+	}
+}
+
+func TestCheckVersionUpgrade(t *testing.T) {
+	bumpedStatus := &v1beta1.KafkaClusterStatus{VersionUpgrade: &v1beta1.KafkaVersionUpgradeStatus{
+		Phase: v1beta1.KafkaVersionUpgradePhaseCompleted, TargetVersion: "3.9.1", PreviousProtocolVersion: "3.7"}}
+	testCases := []struct {
+		testName        string
+		clusterImage    string
+		upgrade         *v1beta1.KafkaVersionUpgradeConfig
+		status          *v1beta1.KafkaClusterStatus
+		expectedErrPath []string
+	}{
+		{
+			testName:     "no version upgrade",
+			clusterImage: "ghcr.io/adobe/koperator/kafka:2.13-3.7.2",
+			status:       &v1beta1.KafkaClusterStatus{},
+		},
+		{
+			testName:     "rolled back before the protocol bump",
+			clusterImage: "ghcr.io/adobe/koperator/kafka:2.13-3.7.2",
+			upgrade:      &v1beta1.KafkaVersionUpgradeConfig{TargetVersion: "3.9.1"},
+			status: &v1beta1.KafkaClusterStatus{VersionUpgrade: &v1beta1.KafkaVersionUpgradeStatus{
+				Phase: v1beta1.KafkaVersionUpgradePhaseProtocolPending, TargetVersion: "3.9.1", PreviousProtocolVersion: "3.7"}},
+		},
+		{
+			testName:     "upgraded after the protocol bump",
+			clusterImage: "ghcr.io/adobe/koperator/kafka:2.13-4.0.0",
+			upgrade:      &v1beta1.KafkaVersionUpgradeConfig{TargetVersion: "4.0.0"},
+			status:       bumpedStatus,
+		},
+		{
+			testName:        "downgraded after the protocol bump",
+			clusterImage:    "ghcr.io/adobe/koperator/kafka:2.13-3.7.2",
+			upgrade:         &v1beta1.KafkaVersionUpgradeConfig{TargetVersion: "3.7.2"},
+			status:          bumpedStatus,
+			expectedErrPath: []string{"spec.versionUpgrade.targetVersion", "spec.brokers[0]"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkVersionUpgrade(&v1beta1.KafkaClusterSpec{
+				ClusterImage:       test.clusterImage,
+				BrokerConfigGroups: map[string]v1beta1.BrokerConfig{"default": {}},
+				Brokers:            []v1beta1.Broker{{Id: 0, BrokerConfigGroup: "default"}},
+				VersionUpgrade:     test.upgrade,
+			}, test.status)
+			errPaths := make([]string, 0, len(errs))
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.ElementsMatch(t, test.expectedErrPath, errPaths)
+		})
+	}
+}
+
+func TestCheckKRaftMigration(t *testing.T) {
+	brokerConfigGroups := map[string]v1beta1.BrokerConfig{
+		"broker":     {Roles: []string{"broker"}},
+		"controller": {Roles: []string{"controller"}},
+		"combined":   {Roles: []string{"broker", "controller"}},
+	}
+	migratingBrokers := []v1beta1.Broker{
+		{Id: 0, BrokerConfigGroup: "broker"},
+		{Id: 10, BrokerConfigGroup: "controller"},
+	}
+
+	testCases := []struct {
+		testName        string
+		kraftMode       bool
+		kRaftMigration  *v1beta1.KRaftMigrationConfig
+		zkAddresses     []string
+		brokers         []v1beta1.Broker
+		status          *v1beta1.KafkaClusterStatus
+		expectedErrPath []string
+	}{
+		{
+			testName: "migration disabled",
+			brokers:  []v1beta1.Broker{{Id: 0, BrokerConfigGroup: "broker"}},
+		},
+		{
+			testName:       "valid migration",
+			kraftMode:      true,
+			kRaftMigration: &v1beta1.KRaftMigrationConfig{Enabled: true},
+			zkAddresses:    []string{"example.zk:2181"},
+			brokers:        migratingBrokers,
+		},
+		{
+			testName:        "migration without KRaft mode and ZooKeeper",
+			kRaftMigration:  &v1beta1.KRaftMigrationConfig{Enabled: true},
+			brokers:         migratingBrokers,
+			expectedErrPath: []string{"spec.kRaft", "spec.zkAddresses"},
+		},
+		{
+			testName:       "ZooKeeper is no longer needed once the migration completed",
+			kraftMode:      true,
+			kRaftMigration: &v1beta1.KRaftMigrationConfig{Enabled: true},
+			brokers:        migratingBrokers,
+			status: &v1beta1.KafkaClusterStatus{
+				KRaftMigration: &v1beta1.KRaftMigrationStatus{Phase: v1beta1.KRaftMigrationPhaseCompleted},
+			},
+		},
+		{
+			testName:       "migration with combined nodes and without controllers",
+			kraftMode:      true,
+			kRaftMigration: &v1beta1.KRaftMigrationConfig{Enabled: true},
+			zkAddresses:    []string{"example.zk:2181"},
+			brokers: []v1beta1.Broker{
+				{Id: 0, BrokerConfigGroup: "broker"},
+				{Id: 1, BrokerConfigGroup: "combined"},
+			},
+			expectedErrPath: []string{"spec.brokers[1]", "spec.brokers"},
+		},
+		{
+			testName:    "migration in progress disabled",
+			kraftMode:   true,
+			zkAddresses: []string{"example.zk:2181"},
+			brokers:     migratingBrokers,
+			status: &v1beta1.KafkaClusterStatus{
+				KRaftMigration: &v1beta1.KRaftMigrationStatus{Phase: v1beta1.KRaftMigrationPhaseBrokersMigrating},
+			},
+			expectedErrPath: []string{"spec.kRaftMigration.enabled"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		test := testCase
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkKRaftMigration(&v1beta1.KafkaClusterSpec{
+				KRaftMode:          test.kraftMode,
+				KRaftMigration:     test.kRaftMigration,
+				ZKAddresses:        test.zkAddresses,
+				BrokerConfigGroups: brokerConfigGroups,
+				Brokers:            test.brokers,
+			}, test.status)
+			errPaths := make([]string, 0, len(errs))
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.ElementsMatch(t, test.expectedErrPath, errPaths)
+		})
+	}
+}
+
+func TestCheckControllerQuorum(t *testing.T) {
+	brokerConfigGroups := map[string]v1beta1.BrokerConfig{
+		"broker":     {Roles: []string{"broker"}},
+		"controller": {Roles: []string{"controller"}},
+	}
+	brokers := func(controllerIDs ...int32) []v1beta1.Broker {
+		result := []v1beta1.Broker{{Id: 0, BrokerConfigGroup: "broker"}}
+		for _, id := range controllerIDs {
+			result = append(result, v1beta1.Broker{Id: id, BrokerConfigGroup: "controller"})
+		}
+		return result
+	}
+
+	testCases := []struct {
+		testName        string
+		kraftMode       bool
+		currentBrokers  []v1beta1.Broker
+		brokers         []v1beta1.Broker
+		expectedErrPath []string
+	}{
+		{
+			testName:       "ZooKeeper mode",
+			currentBrokers: brokers(10, 11, 12),
+			brokers:        brokers(10),
+		},
+		{
+			testName:       "controllers kept",
+			kraftMode:      true,
+			currentBrokers: brokers(10, 11, 12),
+			brokers:        append(brokers(12, 10, 11), v1beta1.Broker{Id: 1, BrokerConfigGroup: "broker"}),
+		},
+		{
+			testName:        "controller added",
+			kraftMode:       true,
+			currentBrokers:  brokers(10, 11, 12),
+			brokers:         brokers(10, 11, 12, 13),
+			expectedErrPath: []string{"spec.brokers"},
+		},
+		{
+			testName:        "controller removed",
+			kraftMode:       true,
+			currentBrokers:  brokers(10, 11, 12),
+			brokers:         brokers(10, 11),
+			expectedErrPath: []string{"spec.brokers"},
+		},
+		{
+			testName:        "majority of the controllers removed",
+			kraftMode:       true,
+			currentBrokers:  brokers(10, 11, 12),
+			brokers:         brokers(10),
+			expectedErrPath: []string{"spec.brokers"},
+		},
+		{
+			testName:        "controllers replaced at once",
+			kraftMode:       true,
+			currentBrokers:  brokers(10, 11, 12),
+			brokers:         brokers(20, 21, 22),
+			expectedErrPath: []string{"spec.brokers"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		test := testCase
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkControllerQuorum(
+				&v1beta1.KafkaClusterSpec{KRaftMode: test.kraftMode, BrokerConfigGroups: brokerConfigGroups, Brokers: test.brokers},
+				&v1beta1.KafkaClusterSpec{KRaftMode: test.kraftMode, BrokerConfigGroups: brokerConfigGroups, Brokers: test.currentBrokers})
+			errPaths := make([]string, 0, len(errs))
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.ElementsMatch(t, test.expectedErrPath, errPaths)
+		})
+	}
+}
+
+func TestCheckKeystorePasswordPolicy(t *testing.T) {
+	testCases := []struct {
+		testName        string
+		policy          *v1beta1.KeystorePasswordPolicy
+		expectedErrPath string
+	}{
+		{
+			testName: "no password policy",
+		},
+		{
+			testName: "random generator",
+			policy:   &v1beta1.KeystorePasswordPolicy{Generator: v1beta1.KeystorePasswordGeneratorRandom},
+		},
+		{
+			testName:        "kms wrapped generator without kms",
+			policy:          &v1beta1.KeystorePasswordPolicy{Generator: v1beta1.KeystorePasswordGeneratorKMSWrapped},
+			expectedErrPath: "spec.listenersConfig.sslSecrets.passwordPolicy.kms",
+		},
+		{
+			testName:        "external generator without secret reference",
+			policy:          &v1beta1.KeystorePasswordPolicy{Generator: v1beta1.KeystorePasswordGeneratorExternal},
+			expectedErrPath: "spec.listenersConfig.sslSecrets.passwordPolicy.externalSecretRef",
+		},
+		{
+			testName: "external generator with secret reference",
+			policy: &v1beta1.KeystorePasswordPolicy{
+				Generator:         v1beta1.KeystorePasswordGeneratorExternal,
+				ExternalSecretRef: &corev1.SecretKeySelector{Key: "password"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		test := testCase
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkKeystorePasswordPolicy(&v1beta1.KafkaClusterSpec{
+				ListenersConfig: v1beta1.ListenersConfig{SSLSecrets: &v1beta1.SSLSecrets{PasswordPolicy: test.policy}},
+			})
+			if test.expectedErrPath == "" {
+				require.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			require.Equal(t, test.expectedErrPath, errs[0].Field)
+			require.Contains(t, errs[0].Detail, missingKeystorePasswordSourceErrMsg)
+		})
+	}
+}
+
+func TestCheckVaultConfig(t *testing.T) {
+	vaultConfig := &v1beta1.VaultConfig{
+		Address: "https://vault.vault.svc:8200",
+		Auth:    v1beta1.VaultAuthConfig{Role: "koperator"},
+		Role:    "kafka",
+	}
+
+	testCases := []struct {
+		testName              string
+		sslSecrets            *v1beta1.SSLSecrets
+		vaultConfig           *v1beta1.VaultConfig
+		hotReloadCertificates bool
+		expectedErrPaths      []string
+	}{
+		{
+			testName: "no ssl secrets",
+		},
+		{
+			testName:   "cert-manager PKI backend",
+			sslSecrets: &v1beta1.SSLSecrets{PKIBackend: v1beta1.PKIBackendCertManager},
+		},
+		{
+			testName:              "vault PKI backend without vault configuration",
+			sslSecrets:            &v1beta1.SSLSecrets{PKIBackend: v1beta1.PKIBackendVault},
+			hotReloadCertificates: true,
+			expectedErrPaths:      []string{"spec.vaultConfig"},
+		},
+		{
+			testName:              "vault PKI backend with vault configuration",
+			sslSecrets:            &v1beta1.SSLSecrets{PKIBackend: v1beta1.PKIBackendVault},
+			vaultConfig:           vaultConfig,
+			hotReloadCertificates: true,
+		},
+		{
+			testName:         "vault PKI backend without certificate hot reload",
+			sslSecrets:       &v1beta1.SSLSecrets{PKIBackend: v1beta1.PKIBackendVault},
+			vaultConfig:      vaultConfig,
+			expectedErrPaths: []string{"spec.listenersConfig.hotReloadCertificates"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		test := testCase
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkVaultConfig(&v1beta1.KafkaClusterSpec{
+				ListenersConfig: v1beta1.ListenersConfig{SSLSecrets: test.sslSecrets, HotReloadCertificates: test.hotReloadCertificates},
+				VaultConfig:     test.vaultConfig,
+			})
+			errPaths := make([]string, 0, len(errs))
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.ElementsMatch(t, test.expectedErrPaths, errPaths)
+		})
+	}
+}
+
+func TestCheckDiskPlacementHints(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		hints            []v1beta1.DiskPlacementHint
+		expectedErrPaths []string
+	}{
+		{
+			testName: "no hints",
+		},
+		{
+			testName: "hints of disk classes of the brokers",
+			hints: []v1beta1.DiskPlacementHint{
+				{TopicPattern: "orders-.*", DiskClass: "nvme"},
+				{TopicPattern: "archive-.*", DiskClass: "hdd"},
+			},
+		},
+		{
+			testName: "invalid topic pattern and unknown disk class",
+			hints: []v1beta1.DiskPlacementHint{
+				{TopicPattern: "orders-(", DiskClass: "nvme"},
+				{TopicPattern: "archive-.*", DiskClass: "tape"},
+			},
+			expectedErrPaths: []string{"spec.diskPlacementHints[0].topicPattern", "spec.diskPlacementHints[1].diskClass"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		test := testCase
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkDiskPlacementHints(&v1beta1.KafkaClusterSpec{
+				BrokerConfigGroups: map[string]v1beta1.BrokerConfig{
+					"default": {StorageConfigs: []v1beta1.StorageConfig{{MountPath: "/kafka-logs-nvme", DiskClass: "nvme"}}},
+				},
+				Brokers: []v1beta1.Broker{{
+					Id:                0,
+					BrokerConfigGroup: "default",
+					BrokerConfig:      &v1beta1.BrokerConfig{StorageConfigs: []v1beta1.StorageConfig{{MountPath: "/kafka-logs-hdd", DiskClass: "hdd"}}},
+				}},
+				DiskPlacementHints: test.hints,
+			})
+			var errPaths []string
+			for _, err := range errs {
+				require.Contains(t, err.Detail, invalidDiskPlacementHintErrMsg)
+				errPaths = append(errPaths, err.Field)
+			}
+			require.Equal(t, test.expectedErrPaths, errPaths)
+		})
+	}
+}
+
+func TestCheckGatewayAPIConfig(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		listenerType     v1beta1.SecurityProtocol
+		accessMethod     corev1.ServiceType
+		gatewayAPIConfig v1beta1.GatewayAPIConfig
+		expectedErrPaths []string
+	}{
+		{
+			testName:         "TCPRoute",
+			listenerType:     v1beta1.SecurityProtocolPlaintext,
+			gatewayAPIConfig: v1beta1.GatewayAPIConfig{GatewayClassName: "eg"},
+		},
+		{
+			testName:         "missing gateway class",
+			listenerType:     v1beta1.SecurityProtocolPlaintext,
+			expectedErrPaths: []string{"spec.gatewayAPIConfig.gatewayClassName"},
+		},
+		{
+			testName:         "unsupported access method",
+			listenerType:     v1beta1.SecurityProtocolPlaintext,
+			accessMethod:     corev1.ServiceTypeNodePort,
+			gatewayAPIConfig: v1beta1.GatewayAPIConfig{GatewayClassName: "eg"},
+			expectedErrPaths: []string{"spec.listenersConfig.externalListeners[0].accessMethod"},
+		},
+		{
+			testName:     "TLSRoute",
+			listenerType: v1beta1.SecurityProtocolSSL,
+			gatewayAPIConfig: v1beta1.GatewayAPIConfig{
+				GatewayClassName:       "eg",
+				RouteType:              v1beta1.GatewayAPIRouteTypeTLS,
+				BrokerHostnameTemplate: "broker-%id.kafka.example.com",
+			},
+		},
+		{
+			testName:     "TLSRoute without broker hostname template on plaintext listener",
+			listenerType: v1beta1.SecurityProtocolPlaintext,
+			gatewayAPIConfig: v1beta1.GatewayAPIConfig{
+				GatewayClassName: "eg",
+				RouteType:        v1beta1.GatewayAPIRouteTypeTLS,
+			},
+			expectedErrPaths: []string{
+				"spec.gatewayAPIConfig.brokerHostnameTemplate",
+				"spec.listenersConfig.externalListeners[0].type",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		test := testCase
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkGatewayAPIConfig(&v1beta1.KafkaClusterSpec{
+				IngressController: "gatewayapi",
+				GatewayAPIConfig:  test.gatewayAPIConfig,
+				ListenersConfig: v1beta1.ListenersConfig{
+					ExternalListeners: []v1beta1.ExternalListenerConfig{{
+						CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "external", Type: test.listenerType, ContainerPort: 9094},
+						AccessMethod:       test.accessMethod,
+					}},
+				},
+			})
+			var errPaths []string
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.Equal(t, test.expectedErrPaths, errPaths)
+		})
+	}
+}
+
+func TestCheckNginxIngressConfig(t *testing.T) {
+	testCases := []struct {
+		testName             string
+		accessMethod         corev1.ServiceType
+		externalStartingPort int32
+		expectedErrPaths     []string
+	}{
+		{
+			testName:             "valid configuration",
+			accessMethod:         corev1.ServiceTypeLoadBalancer,
+			externalStartingPort: 19090,
+		},
+		{
+			testName:             "node port access method",
+			accessMethod:         corev1.ServiceTypeNodePort,
+			externalStartingPort: 19090,
+			expectedErrPaths:     []string{"spec.listenersConfig.externalListeners[0].accessMethod"},
+		},
+		{
+			testName:             "without dedicated broker ports",
+			accessMethod:         corev1.ServiceTypeLoadBalancer,
+			externalStartingPort: -1,
+			expectedErrPaths:     []string{"spec.listenersConfig.externalListeners[0].externalStartingPort"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		test := testCase
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkNginxIngressConfig(&v1beta1.KafkaClusterSpec{
+				IngressController: "nginx",
+				ListenersConfig: v1beta1.ListenersConfig{
+					ExternalListeners: []v1beta1.ExternalListenerConfig{{
+						CommonListenerSpec:   v1beta1.CommonListenerSpec{Name: "external", Type: v1beta1.SecurityProtocolPlaintext, ContainerPort: 9094},
+						ExternalStartingPort: test.externalStartingPort,
+						AccessMethod:         test.accessMethod,
+					}},
+				},
+			})
+			var errPaths []string
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.Equal(t, test.expectedErrPaths, errPaths)
+		})
+	}
+}
+
+func TestCheckIstioMeshMode(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		clusterMeshMode  v1beta1.IstioMeshMode
+		listenerMeshMode v1beta1.IstioMeshMode
+		expectedErrPaths []string
+	}{
+		{
+			testName:        "listener inherits the mesh mode of the brokers",
+			clusterMeshMode: v1beta1.IstioMeshModeAmbient,
+		},
+		{
+			testName:         "listener sets the mesh mode of the brokers",
+			clusterMeshMode:  v1beta1.IstioMeshModeAmbient,
+			listenerMeshMode: v1beta1.IstioMeshModeAmbient,
+		},
+		{
+			testName:         "listener in ambient mode with sidecar brokers",
+			listenerMeshMode: v1beta1.IstioMeshModeAmbient,
+			expectedErrPaths: []string{"spec.listenersConfig.externalListeners[0].config.ingressConfig[az1].istioIngressConfig.meshMode"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		test := testCase
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkIstioMeshMode(&v1beta1.KafkaClusterSpec{
+				IngressController:  "istioingress",
+				IstioIngressConfig: v1beta1.IstioIngressConfig{MeshMode: test.clusterMeshMode},
+				ListenersConfig: v1beta1.ListenersConfig{
+					ExternalListeners: []v1beta1.ExternalListenerConfig{{
+						CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "external", Type: v1beta1.SecurityProtocolPlaintext, ContainerPort: 9094},
+						Config: &v1beta1.Config{
+							DefaultIngressConfig: "az1",
+							IngressConfig: map[string]v1beta1.IngressConfig{
+								"az1": {IstioIngressConfig: &v1beta1.IstioIngressConfig{MeshMode: test.listenerMeshMode}},
+							},
+						},
+					}},
+				},
+			})
+			var errPaths []string
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.Equal(t, test.expectedErrPaths, errPaths)
+		})
+	}
+}
+
+func TestCheckEnvoyListenerConfig(t *testing.T) {
+	testCases := []struct {
+		testName             string
+		clusterFillInterval  time.Duration
+		listenerFillInterval time.Duration
+		expectedErrPaths     []string
+	}{
+		{
+			testName:             "valid fill intervals",
+			clusterFillInterval:  time.Second,
+			listenerFillInterval: 50 * time.Millisecond,
+		},
+		{
+			testName:             "fill intervals shorter than 50ms",
+			clusterFillInterval:  10 * time.Millisecond,
+			listenerFillInterval: time.Millisecond,
+			expectedErrPaths: []string{
+				"spec.envoyConfig.listenerConfig.connectionRateLimit.fillInterval",
+				"spec.listenersConfig.externalListeners[0].config.ingressConfig[az1].envoyConfig.listenerConfig.connectionRateLimit.fillInterval",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		test := testCase
+		t.Run(test.testName, func(t *testing.T) {
+			listenerConfig := func(fillInterval time.Duration) *v1beta1.EnvoyListenerConfig {
+				return &v1beta1.EnvoyListenerConfig{
+					ConnectionRateLimit: &v1beta1.EnvoyConnectionRateLimit{MaxTokens: 1, FillInterval: metav1.Duration{Duration: fillInterval}},
+				}
+			}
+			errs := checkEnvoyListenerConfig(&v1beta1.KafkaClusterSpec{
+				EnvoyConfig: v1beta1.EnvoyConfig{ListenerConfig: listenerConfig(test.clusterFillInterval)},
+				ListenersConfig: v1beta1.ListenersConfig{
+					ExternalListeners: []v1beta1.ExternalListenerConfig{{
+						CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "external", Type: v1beta1.SecurityProtocolPlaintext, ContainerPort: 9094},
+						Config: &v1beta1.Config{
+							DefaultIngressConfig: "az1",
+							IngressConfig: map[string]v1beta1.IngressConfig{
+								"az1": {EnvoyConfig: &v1beta1.EnvoyConfig{ListenerConfig: listenerConfig(test.listenerFillInterval)}},
+							},
+						},
+					}},
+				},
+			})
+			var errPaths []string
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.Equal(t, test.expectedErrPaths, errPaths)
+		})
+	}
+}
+
+func TestCheckListenerServerCertificates(t *testing.T) {
+	issuerRef := &cmmeta.ObjectReference{Name: "listener-issuer", Kind: "Issuer"}
+	testCases := []struct {
+		testName         string
+		internalListener v1beta1.CommonListenerSpec
+		externalListener v1beta1.CommonListenerSpec
+		expectedErrPaths []string
+	}{
+		{
+			testName: "valid server certificates",
+			internalListener: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolSSL,
+				ServerCertificate: &v1beta1.ListenerServerCertificate{IssuerRef: issuerRef}},
+			externalListener: v1beta1.CommonListenerSpec{Name: "external", Type: v1beta1.SecurityProtocolSSL,
+				ServerCertificate: &v1beta1.ListenerServerCertificate{TLSSecretName: "external-tls"}},
+		},
+		{
+			testName: "server certificate of a plaintext listener",
+			internalListener: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolPlaintext,
+				ServerCertificate: &v1beta1.ListenerServerCertificate{IssuerRef: issuerRef}},
+			externalListener: v1beta1.CommonListenerSpec{Name: "external", Type: v1beta1.SecurityProtocolSSL},
+			expectedErrPaths: []string{"spec.listenersConfig.internalListeners[0].type"},
+		},
+		{
+			testName: "none or both sources of the server certificate",
+			internalListener: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolSSL,
+				ServerCertificate: &v1beta1.ListenerServerCertificate{}},
+			externalListener: v1beta1.CommonListenerSpec{Name: "external", Type: v1beta1.SecurityProtocolSSL,
+				ServerCertificate: &v1beta1.ListenerServerCertificate{IssuerRef: issuerRef, TLSSecretName: "external-tls"}},
+			expectedErrPaths: []string{
+				"spec.listenersConfig.internalListeners[0].serverCertificate",
+				"spec.listenersConfig.externalListeners[0].serverCertificate",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		test := testCase
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkListenerServerCertificates(&v1beta1.KafkaClusterSpec{
+				ListenersConfig: v1beta1.ListenersConfig{
+					InternalListeners: []v1beta1.InternalListenerConfig{{CommonListenerSpec: test.internalListener}},
+					ExternalListeners: []v1beta1.ExternalListenerConfig{{CommonListenerSpec: test.externalListener}},
+				},
+			})
+			var errPaths []string
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.Equal(t, test.expectedErrPaths, errPaths)
+		})
+	}
+}
+
+func TestCheckListenerOAuthBearer(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		internalListener v1beta1.CommonListenerSpec
+		externalListener v1beta1.CommonListenerSpec
+		expectedErrPaths []string
+	}{
+		{
+			testName: "valid SASL/OAUTHBEARER listeners",
+			internalListener: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolSaslPlaintext,
+				OAuthBearer: &v1beta1.OAuthBearerConfig{JWKSEndpointURL: "https://idp.example.com/keys"}},
+			externalListener: v1beta1.CommonListenerSpec{Name: "external", Type: v1beta1.SecurityProtocolSaslSSL,
+				OAuthBearer: &v1beta1.OAuthBearerConfig{IssuerURL: "https://idp.example.com"}},
+		},
+		{
+			testName: "SASL/OAUTHBEARER on an ssl listener used for inter broker communication",
+			internalListener: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolSSL,
+				UsedForInnerBrokerCommunication: true,
+				OAuthBearer:                     &v1beta1.OAuthBearerConfig{IssuerURL: "https://idp.example.com"}},
+			externalListener: v1beta1.CommonListenerSpec{Name: "external", Type: v1beta1.SecurityProtocolSaslSSL},
+			expectedErrPaths: []string{
+				"spec.listenersConfig.internalListeners[0].type",
+				"spec.listenersConfig.internalListeners[0].usedForInnerBrokerCommunication",
+			},
+		},
+		{
+			testName:         "neither JWKS endpoint nor issuer",
+			internalListener: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolPlaintext},
+			externalListener: v1beta1.CommonListenerSpec{Name: "external", Type: v1beta1.SecurityProtocolSaslSSL,
+				OAuthBearer: &v1beta1.OAuthBearerConfig{ExpectedAudience: []string{"kafka"}}},
+			expectedErrPaths: []string{"spec.listenersConfig.externalListeners[0].oauthBearer.jwksEndpointURL"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkListenerOAuthBearer(&v1beta1.KafkaClusterSpec{
+				ListenersConfig: v1beta1.ListenersConfig{
+					InternalListeners: []v1beta1.InternalListenerConfig{{CommonListenerSpec: test.internalListener}},
+					ExternalListeners: []v1beta1.ExternalListenerConfig{{CommonListenerSpec: test.externalListener}},
+				},
+			})
+			var errPaths []string
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.Equal(t, test.expectedErrPaths, errPaths)
+		})
+	}
+}
+
+func TestCheckListenerKerberos(t *testing.T) {
+	kerberos := &v1beta1.KerberosConfig{
+		PrincipalTemplate: "kafka/kafka-%id.kafka-headless.kafka.svc.cluster.local@EXAMPLE.COM",
+		KeytabSecretRef:   corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "kafka-keytab"}, Key: "keytab"},
+	}
+	testCases := []struct {
+		testName         string
+		internalListener v1beta1.CommonListenerSpec
+		externalListener v1beta1.CommonListenerSpec
+		expectedErrPaths []string
+	}{
+		{
+			testName:         "valid kerberos listeners",
+			internalListener: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolSaslPlaintext, Kerberos: kerberos},
+			externalListener: v1beta1.CommonListenerSpec{Name: "external", Type: v1beta1.SecurityProtocolSaslSSL, Kerberos: kerberos},
+		},
+		{
+			testName:         "kerberos on an ssl listener",
+			internalListener: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolSSL, Kerberos: kerberos},
+			externalListener: v1beta1.CommonListenerSpec{Name: "external", Type: v1beta1.SecurityProtocolSaslSSL},
+			expectedErrPaths: []string{"spec.listenersConfig.internalListeners[0].type"},
+		},
+		{
+			testName:         "missing principal and keytab",
+			internalListener: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolPlaintext},
+			externalListener: v1beta1.CommonListenerSpec{Name: "external", Type: v1beta1.SecurityProtocolSaslSSL,
+				Kerberos: &v1beta1.KerberosConfig{}},
+			expectedErrPaths: []string{
+				"spec.listenersConfig.externalListeners[0].kerberos.principalTemplate",
+				"spec.listenersConfig.externalListeners[0].kerberos.keytabSecretRef",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkListenerKerberos(&v1beta1.KafkaClusterSpec{
+				ListenersConfig: v1beta1.ListenersConfig{
+					InternalListeners: []v1beta1.InternalListenerConfig{{CommonListenerSpec: test.internalListener}},
+					ExternalListeners: []v1beta1.ExternalListenerConfig{{CommonListenerSpec: test.externalListener}},
+				},
+			})
+			var errPaths []string
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.Equal(t, test.expectedErrPaths, errPaths)
+		})
+	}
+}
+
+func TestCheckListenerSSLClientAuth(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		internalListener v1beta1.CommonListenerSpec
+		externalListener v1beta1.CommonListenerSpec
+		oldListener      *v1beta1.CommonListenerSpec
+		expectedErrPaths []string
+	}{
+		{
+			testName: "client authentication of ssl listeners",
+			internalListener: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolSSL,
+				SSLClientAuth: v1beta1.SSLClientAuthRequested},
+			externalListener: v1beta1.CommonListenerSpec{Name: "external", Type: v1beta1.SecurityProtocolSSL,
+				SSLClientAuth: v1beta1.SSLClientAuthNone},
+		},
+		{
+			testName:         "no client authentication",
+			internalListener: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolPlaintext},
+			externalListener: v1beta1.CommonListenerSpec{Name: "external", Type: v1beta1.SecurityProtocolSaslSSL},
+		},
+		{
+			testName: "client authentication of non ssl listeners",
+			internalListener: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolPlaintext,
+				SSLClientAuth: v1beta1.SSLClientAuthRequired},
+			externalListener: v1beta1.CommonListenerSpec{Name: "external", Type: v1beta1.SecurityProtocolSaslSSL,
+				SSLClientAuth: v1beta1.SSLClientAuthNone},
+			expectedErrPaths: []string{
+				"spec.listenersConfig.internalListeners[0].sslClientAuth",
+				"spec.listenersConfig.externalListeners[0].sslClientAuth",
+			},
+		},
+		{
+			testName: "unchanged client authentication of non ssl listener",
+			internalListener: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolPlaintext,
+				SSLClientAuth: v1beta1.SSLClientAuthRequired},
+			oldListener: &v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolPlaintext,
+				SSLClientAuth: v1beta1.SSLClientAuthRequired},
+		},
+		{
+			testName: "changed client authentication of non ssl listener",
+			internalListener: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolPlaintext,
+				SSLClientAuth: v1beta1.SSLClientAuthRequired},
+			oldListener: &v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolPlaintext,
+				SSLClientAuth: v1beta1.SSLClientAuthRequested},
+			expectedErrPaths: []string{"spec.listenersConfig.internalListeners[0].sslClientAuth"},
+		},
+		{
+			testName: "client authentication kept on listener changed from ssl",
+			internalListener: v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolPlaintext,
+				SSLClientAuth: v1beta1.SSLClientAuthRequired},
+			oldListener: &v1beta1.CommonListenerSpec{Name: "internal", Type: v1beta1.SecurityProtocolSSL,
+				SSLClientAuth: v1beta1.SSLClientAuthRequired},
+			expectedErrPaths: []string{"spec.listenersConfig.internalListeners[0].sslClientAuth"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			var oldSpec *v1beta1.KafkaClusterSpec
+			if test.oldListener != nil {
+				oldSpec = &v1beta1.KafkaClusterSpec{ListenersConfig: v1beta1.ListenersConfig{
+					InternalListeners: []v1beta1.InternalListenerConfig{{CommonListenerSpec: *test.oldListener}},
+				}}
+			}
+			errs := checkListenerSSLClientAuth(&v1beta1.KafkaClusterSpec{
+				ListenersConfig: v1beta1.ListenersConfig{
+					InternalListeners: []v1beta1.InternalListenerConfig{{CommonListenerSpec: test.internalListener}},
+					ExternalListeners: []v1beta1.ExternalListenerConfig{{CommonListenerSpec: test.externalListener}},
+				},
+			}, oldSpec)
+			var errPaths []string
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.Equal(t, test.expectedErrPaths, errPaths)
+		})
+	}
+}
+
+func TestCheckRollingUpgradeHooks(t *testing.T) {
+	httpHook := v1beta1.RollingUpgradeHook{Name: "lag", HTTP: &v1beta1.HTTPRestartHook{URL: "http://lag-checker/ready"}}
+	testCases := []struct {
+		testName         string
+		hooks            *v1beta1.RollingUpgradeHooks
+		expectedErrPaths []string
+	}{
+		{
+			testName: "no hooks",
+		},
+		{
+			testName: "valid hooks",
+			hooks: &v1beta1.RollingUpgradeHooks{
+				PreRestart: []v1beta1.RollingUpgradeHook{httpHook},
+				PostRestart: []v1beta1.RollingUpgradeHook{httpHook, {Name: "slo", PromQL: &v1beta1.PromQLRestartHook{
+					PrometheusURL: "http://prometheus:9090", Query: "up == 1"}}},
+			},
+		},
+		{
+			testName: "duplicate name and missing check",
+			hooks: &v1beta1.RollingUpgradeHooks{
+				PreRestart:  []v1beta1.RollingUpgradeHook{httpHook, httpHook},
+				PostRestart: []v1beta1.RollingUpgradeHook{{Name: "empty"}},
+			},
+			expectedErrPaths: []string{
+				"spec.rollingUpgradeConfig.hooks.preRestart[1].name",
+				"spec.rollingUpgradeConfig.hooks.postRestart[0]",
+			},
+		},
+		{
+			testName: "multiple checks",
+			hooks: &v1beta1.RollingUpgradeHooks{
+				PreRestart: []v1beta1.RollingUpgradeHook{{Name: "both", HTTP: httpHook.HTTP, Job: &v1beta1.JobRestartHook{Image: "busybox"}}},
+			},
+			expectedErrPaths: []string{"spec.rollingUpgradeConfig.hooks.preRestart[0]"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkRollingUpgradeHooks(&v1beta1.KafkaClusterSpec{
+				RollingUpgradeConfig: v1beta1.RollingUpgradeConfig{Hooks: test.hooks},
+			})
+			var errPaths []string
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.Equal(t, test.expectedErrPaths, errPaths)
+		})
+	}
+}
+
+func TestCheckPrometheusReplicationCheck(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		check            *v1beta1.PrometheusReplicationCheck
+		expectedErrPaths []string
+	}{
+		{
+			testName: "no check",
+		},
+		{
+			testName: "default query",
+			check:    &v1beta1.PrometheusReplicationCheck{PrometheusURL: "http://prometheus:9090"},
+		},
+		{
+			testName: "alerts",
+			check: &v1beta1.PrometheusReplicationCheck{PrometheusURL: "http://prometheus:9090",
+				Alerts: []string{"KafkaUnderReplicatedPartitions"}},
+		},
+		{
+			testName:         "missing URL",
+			check:            &v1beta1.PrometheusReplicationCheck{Query: "kafka_under_replicated > 0"},
+			expectedErrPaths: []string{"spec.rollingUpgradeConfig.prometheusReplicationCheck.prometheusURL"},
+		},
+		{
+			testName: "query and alerts",
+			check: &v1beta1.PrometheusReplicationCheck{PrometheusURL: "http://prometheus:9090",
+				Query: "kafka_under_replicated > 0", Alerts: []string{"KafkaUnderReplicatedPartitions"}},
+			expectedErrPaths: []string{"spec.rollingUpgradeConfig.prometheusReplicationCheck"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkPrometheusReplicationCheck(&v1beta1.KafkaClusterSpec{
+				RollingUpgradeConfig: v1beta1.RollingUpgradeConfig{PrometheusReplicationCheck: test.check},
+			})
+			var errPaths []string
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.Equal(t, test.expectedErrPaths, errPaths)
+		})
+	}
+}
+
+func TestCheckBrokerResourcesAgainstLimitRanges(t *testing.T) {
+	limitRange := corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: "limits"},
+		Spec: corev1.LimitRangeSpec{
+			Limits: []corev1.LimitRangeItem{
+				{
+					Type:                 corev1.LimitTypeContainer,
+					Max:                  corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+					Min:                  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m")},
+					MaxLimitRequestRatio: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				},
+				{
+					Type: corev1.LimitTypePod,
+					Max:  corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Mi")},
+				},
+			},
+		},
+	}
+	resources := func(request, limit string) *corev1.ResourceRequirements {
+		return &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(request), corev1.ResourceMemory: resource.MustParse("1Gi")},
+			Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(limit), corev1.ResourceMemory: resource.MustParse("2Gi")},
+		}
+	}
+	initContainerResources := resources("200m", "200m")
+
+	testCases := []struct {
+		testName         string
+		limitRanges      []corev1.LimitRange
+		groupConfig      v1beta1.BrokerConfig
+		brokerConfig     *v1beta1.BrokerConfig
+		expectedErrPaths []string
+	}{
+		{
+			testName: "no LimitRange in the namespace",
+		},
+		{
+			testName:    "resources within the limits",
+			limitRanges: []corev1.LimitRange{limitRange},
+			groupConfig: v1beta1.BrokerConfig{
+				Resources:                             resources("1", "2"),
+				MetricsReporterInitContainerResources: initContainerResources,
+				JmxExporterInitContainerResources:     initContainerResources,
+			},
+		},
+		{
+			testName:    "default init container resources are below the minimum",
+			limitRanges: []corev1.LimitRange{limitRange},
+			groupConfig: v1beta1.BrokerConfig{Resources: resources("1", "2")},
+			expectedErrPaths: []string{
+				"spec.brokerConfigGroups[default].metricsReporterInitContainerResources.requests[cpu]",
+				"spec.brokerConfigGroups[default].jmxExporterInitContainerResources.requests[cpu]",
+			},
+		},
+		{
+			testName:    "broker overrides the resources of its group",
+			limitRanges: []corev1.LimitRange{limitRange},
+			groupConfig: v1beta1.BrokerConfig{
+				Resources:                             resources("1", "2"),
+				MetricsReporterInitContainerResources: initContainerResources,
+				JmxExporterInitContainerResources:     initContainerResources,
+			},
+			brokerConfig: &v1beta1.BrokerConfig{
+				Resources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+					Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3"), corev1.ResourceMemory: resource.MustParse("8Gi")},
+				},
+				InitContainers: []corev1.Container{{Name: "custom"}},
+			},
+			expectedErrPaths: []string{
+				"spec.brokers[0].brokerConfig.resourceRequirements.limits[cpu]",
+				"spec.brokers[0].brokerConfig.resourceRequirements.limits[memory]",
+				"spec.brokers[0].brokerConfig.initContainers[0].resources.requests[cpu]",
+				"spec.brokers[0].brokerConfig.initContainers[0].resources.limits[memory]",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		test := testCase
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkBrokerResourcesAgainstLimitRanges(&v1beta1.KafkaClusterSpec{
+				BrokerConfigGroups: map[string]v1beta1.BrokerConfig{"default": test.groupConfig},
+				Brokers: []v1beta1.Broker{
+					{Id: 0, BrokerConfigGroup: "default", BrokerConfig: test.brokerConfig},
+					{Id: 1, BrokerConfigGroup: "default"},
+				},
+			}, test.limitRanges)
+			var errPaths []string
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.Equal(t, test.expectedErrPaths, errPaths)
+		})
+	}
+}
+
+func TestCheckVersionCompatibility(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		clusterImage     string
+		ccImage          string
+		brokerImage      string
+		kraft            bool
+		expectedErrPaths []string
+		expectedWarnings int
+	}{
+		{
+			testName:     "compatible versions",
+			clusterImage: "ghcr.io/adobe/koperator/kafka:2.13-3.9.1",
+			ccImage:      "adobe/cruise-control:3.0.3-adbe-20250804",
+			kraft:        true,
+		},
+		{
+			testName:         "Cruise Control does not support the broker image",
+			clusterImage:     "ghcr.io/adobe/koperator/kafka:2.13-3.9.1",
+			ccImage:          "adobe/cruise-control:2.5.138",
+			brokerImage:      "ghcr.io/adobe/koperator/kafka:2.13-4.0.0",
+			expectedErrPaths: []string{"spec.brokers[1].brokerConfig.image"},
+		},
+		{
+			testName:         "KRaft requires a newer Cruise Control",
+			clusterImage:     "ghcr.io/adobe/koperator/kafka:2.13-3.9.1",
+			ccImage:          "adobe/cruise-control:2.5.138",
+			kraft:            true,
+			expectedErrPaths: []string{"spec.clusterImage"},
+		},
+		{
+			testName:         "versions can not be detected",
+			clusterImage:     "ghcr.io/adobe/koperator/kafka:latest",
+			ccImage:          "adobe/cruise-control:latest",
+			expectedWarnings: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		test := testCase
+		t.Run(test.testName, func(t *testing.T) {
+			errs, warnings := checkVersionCompatibility(&v1beta1.KafkaClusterSpec{
+				KRaftMode:           test.kraft,
+				ClusterImage:        test.clusterImage,
+				CruiseControlConfig: v1beta1.CruiseControlConfig{Image: test.ccImage},
+				Brokers: []v1beta1.Broker{
+					{Id: 0, BrokerConfig: &v1beta1.BrokerConfig{}},
+					{Id: 1, BrokerConfig: &v1beta1.BrokerConfig{Image: test.brokerImage}},
+				},
+			})
+			var errPaths []string
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.Equal(t, test.expectedErrPaths, errPaths)
+			require.Len(t, warnings, test.expectedWarnings)
+		})
+	}
+}
+
+func TestCheckSingleNodeCluster(t *testing.T) {
+	combined := &v1beta1.BrokerConfig{Roles: []string{"broker", "controller"}}
+	testCases := []struct {
+		testName         string
+		spec             v1beta1.KafkaClusterSpec
+		expectedErrPaths []string
+		expectedWarnings int
+	}{
+		{
+			testName: "single-node cluster with defaults",
+			spec:     v1beta1.KafkaClusterSpec{KRaftMode: true, Brokers: []v1beta1.Broker{{Id: 0, BrokerConfig: combined}}},
+		},
+		{
+			testName: "single-node cluster with replicated Cruise Control topic and disruption budget",
+			spec: v1beta1.KafkaClusterSpec{
+				KRaftMode:           true,
+				Brokers:             []v1beta1.Broker{{Id: 0, BrokerConfig: combined}},
+				CruiseControlConfig: v1beta1.CruiseControlConfig{TopicConfig: &v1beta1.TopicConfig{Partitions: 12, ReplicationFactor: 2}},
+				DisruptionBudget:    v1beta1.DisruptionBudget{Create: true},
+			},
+			expectedErrPaths: []string{"spec.cruiseControlConfig.topicConfig.replicationFactor"},
+			expectedWarnings: 1,
+		},
+		{
+			testName: "single-node cluster with external Cruise Control",
+			spec: v1beta1.KafkaClusterSpec{
+				KRaftMode: true,
+				Brokers:   []v1beta1.Broker{{Id: 0, BrokerConfig: combined}},
+				CruiseControlConfig: v1beta1.CruiseControlConfig{
+					CruiseControlEndpoint: "cruise-control:8090",
+					TopicConfig:           &v1beta1.TopicConfig{Partitions: 12, ReplicationFactor: 2},
+				},
+			},
+		},
+		{
+			testName: "multi-node cluster",
+			spec: v1beta1.KafkaClusterSpec{
+				KRaftMode:           true,
+				Brokers:             []v1beta1.Broker{{Id: 0, BrokerConfig: combined}, {Id: 1, BrokerConfig: combined}},
+				CruiseControlConfig: v1beta1.CruiseControlConfig{TopicConfig: &v1beta1.TopicConfig{Partitions: 12, ReplicationFactor: 2}},
+				DisruptionBudget:    v1beta1.DisruptionBudget{Create: true},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		test := testCase
+		t.Run(test.testName, func(t *testing.T) {
+			errs, warnings := checkSingleNodeCluster(&test.spec)
+			var errPaths []string
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.Equal(t, test.expectedErrPaths, errPaths)
+			require.Len(t, warnings, test.expectedWarnings)
+		})
+	}
+}
+
+func TestCheckPerBrokerLoadBalancerConfig(t *testing.T) {
+	testCases := []struct {
+		testName               string
+		brokerHostnameTemplate string
+		expectedErrPaths       []string
+	}{
+		{
+			testName:               "broker hostname template with broker id",
+			brokerHostnameTemplate: "broker-%id.kafka.example.com",
+		},
+		{
+			testName: "without broker hostname template",
+		},
+		{
+			testName:               "broker hostname template without broker id",
+			brokerHostnameTemplate: "kafka.example.com",
+			expectedErrPaths:       []string{"spec.listenersConfig.externalListeners[0].perBrokerLoadBalancerConfig.brokerHostnameTemplate"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		test := testCase
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkPerBrokerLoadBalancerConfig(&v1beta1.KafkaClusterSpec{
+				ListenersConfig: v1beta1.ListenersConfig{
+					ExternalListeners: []v1beta1.ExternalListenerConfig{{
+						CommonListenerSpec: v1beta1.CommonListenerSpec{Name: "external", Type: v1beta1.SecurityProtocolPlaintext, ContainerPort: 9094},
+						AccessMethod:       v1beta1.ServiceTypePerBrokerLoadBalancer,
+						PerBrokerLoadBalancerConfig: &v1beta1.PerBrokerLoadBalancerConfig{
+							BrokerHostnameTemplate: test.brokerHostnameTemplate,
+						},
+					}},
+				},
+			})
+			var errPaths []string
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.Equal(t, test.expectedErrPaths, errPaths)
+		})
+	}
+}
+
+func TestCheckCruiseControlSelfHealing(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		config           string
+		remediations     []v1beta1.CruiseControlAnomalyType
+		expectedErrPaths []string
+	}{
+		{
+			testName: "anomalies reported only",
+			config:   "self.healing.enabled=true",
+		},
+		{
+			testName:     "self-healing of Cruise Control disabled",
+			remediations: []v1beta1.CruiseControlAnomalyType{v1beta1.CruiseControlAnomalyGoalViolation, v1beta1.CruiseControlAnomalyBrokerFailure},
+		},
+		{
+			testName:     "self-healing of Cruise Control enabled for other anomaly types",
+			config:       "self.healing.enabled=true\nself.healing.broker.failure.enabled=false",
+			remediations: []v1beta1.CruiseControlAnomalyType{v1beta1.CruiseControlAnomalyBrokerFailure},
+		},
+		{
+			testName:         "self-healing of Cruise Control enabled",
+			config:           "self.healing.enabled=true",
+			remediations:     []v1beta1.CruiseControlAnomalyType{v1beta1.CruiseControlAnomalyDiskFailure},
+			expectedErrPaths: []string{"spec.cruiseControlConfig.selfHealing.remediations[0]"},
+		},
+		{
+			testName:         "self-healing of Cruise Control enabled for the anomaly type",
+			config:           "self.healing.goal.violation.enabled=true",
+			remediations:     []v1beta1.CruiseControlAnomalyType{v1beta1.CruiseControlAnomalyBrokerFailure, v1beta1.CruiseControlAnomalyGoalViolation},
+			expectedErrPaths: []string{"spec.cruiseControlConfig.selfHealing.remediations[1]"},
+		},
+		{
+			testName:         "duplicated remediation",
+			remediations:     []v1beta1.CruiseControlAnomalyType{v1beta1.CruiseControlAnomalyDiskFailure, v1beta1.CruiseControlAnomalyDiskFailure},
+			expectedErrPaths: []string{"spec.cruiseControlConfig.selfHealing.remediations[1]"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkCruiseControlSelfHealing(&v1beta1.KafkaClusterSpec{
+				CruiseControlConfig: v1beta1.CruiseControlConfig{
+					Config:      test.config,
+					SelfHealing: &v1beta1.CruiseControlSelfHealing{Remediations: test.remediations},
+				},
+			})
+			var errPaths []string
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.Equal(t, test.expectedErrPaths, errPaths)
+		})
+	}
+}
+
+func TestCheckCruiseControlRef(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		endpoint         string
+		ref              *corev1.LocalObjectReference
+		expectedErrPaths []string
+	}{
+		{
+			testName: "deployed by the cluster",
+		},
+		{
+			testName: "deployed by a CruiseControl",
+			ref:      &corev1.LocalObjectReference{Name: "cruisecontrol"},
+		},
+		{
+			testName:         "CruiseControl name missing",
+			ref:              &corev1.LocalObjectReference{},
+			expectedErrPaths: []string{"spec.cruiseControlConfig.cruiseControlRef.name"},
+		},
+		{
+			testName:         "external Cruise Control referenced",
+			endpoint:         "cruisecontrol.kafka.svc:8090",
+			ref:              &corev1.LocalObjectReference{Name: "cruisecontrol"},
+			expectedErrPaths: []string{"spec.cruiseControlConfig.cruiseControlRef"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkCruiseControlRef(&v1beta1.KafkaClusterSpec{
+				CruiseControlConfig: v1beta1.CruiseControlConfig{
+					CruiseControlEndpoint: test.endpoint,
+					CruiseControlRef:      test.ref,
+				},
+			})
+			var errPaths []string
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.Equal(t, test.expectedErrPaths, errPaths)
+		})
+	}
+}
+
+func TestCheckRebalanceSchedule(t *testing.T) {
+	testCases := []struct {
+		testName          string
+		rebalanceSchedule *v1beta1.RebalanceSchedule
+		expectedErrPaths  []string
+	}{
+		{
+			testName: "no rebalance schedule",
+		},
+		{
+			testName:          "valid schedule and goals",
+			rebalanceSchedule: &v1beta1.RebalanceSchedule{Schedule: "0 2 * * sat,sun", Goals: []string{"RackAwareGoal", "DiskUsageDistributionGoal"}},
+		},
+		{
+			testName:          "invalid schedule",
+			rebalanceSchedule: &v1beta1.RebalanceSchedule{Schedule: "0 25 * * *"},
+			expectedErrPaths:  []string{"spec.cruiseControlConfig.rebalanceSchedule.schedule"},
+		},
+		{
+			testName:          "unknown and duplicate goals",
+			rebalanceSchedule: &v1beta1.RebalanceSchedule{Schedule: "@daily", Goals: []string{"RackAwareGoal", "NoSuchGoal", "RackAwareGoal"}},
+			expectedErrPaths: []string{
+				"spec.cruiseControlConfig.rebalanceSchedule.goals[1]",
+				"spec.cruiseControlConfig.rebalanceSchedule.goals[2]",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkRebalanceSchedule(&v1beta1.KafkaClusterSpec{
+				CruiseControlConfig: v1beta1.CruiseControlConfig{RebalanceSchedule: test.rebalanceSchedule},
+			})
+			var errPaths []string
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.Equal(t, test.expectedErrPaths, errPaths)
+		})
+	}
+}
+
+func TestCheckMaintenanceWindows(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		windows          []v1beta1.MaintenanceWindow
+		expectedErrPaths []string
+	}{
+		{
+			testName: "no maintenance window",
+		},
+		{
+			testName: "valid windows",
+			windows: []v1beta1.MaintenanceWindow{
+				{Schedule: "0 22 * * mon-fri", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+				{Schedule: "@weekly", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Europe/Berlin"},
+			},
+		},
+		{
+			testName: "invalid windows",
+			windows: []v1beta1.MaintenanceWindow{
+				{Schedule: "0 22 * *", Duration: metav1.Duration{Duration: time.Hour}},
+				{Schedule: "@daily"},
+				{Schedule: "@daily", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Mars/Olympus"},
+			},
+			expectedErrPaths: []string{"spec.maintenanceWindows[0]", "spec.maintenanceWindows[1]", "spec.maintenanceWindows[2]"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkMaintenanceWindows(&v1beta1.KafkaClusterSpec{MaintenanceWindows: test.windows})
+			var errPaths []string
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.Equal(t, test.expectedErrPaths, errPaths)
+		})
+	}
+}
+
+func TestCheckBrokerDecommissions(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		spec             v1beta1.KafkaClusterSpec
+		expectedErrPaths []string
+	}{
+		{
+			testName: "no decommission",
+			spec:     v1beta1.KafkaClusterSpec{Brokers: []v1beta1.Broker{{Id: 0}, {Id: 1}}},
+		},
+		{
+			testName: "decommissioned broker",
+			spec:     v1beta1.KafkaClusterSpec{Brokers: []v1beta1.Broker{{Id: 0}, {Id: 1, Decommission: true}}},
+		},
+		{
+			testName: "decommissioned broker being replaced",
+			spec: v1beta1.KafkaClusterSpec{Brokers: []v1beta1.Broker{
+				{Id: 0}, {Id: 1, Decommission: true, Replacement: &v1beta1.BrokerReplacement{ID: "node-failure"}},
+			}},
+			expectedErrPaths: []string{"spec.brokers[1].decommission"},
+		},
+		{
+			testName: "decommissioned controller-only node",
+			spec: v1beta1.KafkaClusterSpec{KRaftMode: true, Brokers: []v1beta1.Broker{
+				{Id: 0, BrokerConfig: &v1beta1.BrokerConfig{Roles: []string{"broker"}}, Decommission: true},
+				{Id: 1, BrokerConfig: &v1beta1.BrokerConfig{Roles: []string{"controller"}}, Decommission: true},
+			}},
+			expectedErrPaths: []string{"spec.brokers[1].decommission"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkBrokerDecommissions(&test.spec)
+			var errPaths []string
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.Equal(t, test.expectedErrPaths, errPaths)
+		})
+	}
+}
+
+func TestCheckTieredStorage(t *testing.T) {
+	secretKey := &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "s3-credentials"}, Key: "key"}
+	testCases := []struct {
+		testName         string
+		tieredStorage    *v1beta1.TieredStorageConfig
+		expectedErrPaths []string
+	}{
+		{
+			testName: "tiered storage disabled",
+		},
+		{
+			testName: "S3 with access keys",
+			tieredStorage: &v1beta1.TieredStorageConfig{
+				S3: &v1beta1.TieredStorageS3Config{Bucket: "segments", AccessKeyIDSecretRef: secretKey, SecretAccessKeySecretRef: secretKey},
+			},
+		},
+		{
+			testName: "S3 and GCS both set",
+			tieredStorage: &v1beta1.TieredStorageConfig{
+				S3:  &v1beta1.TieredStorageS3Config{Bucket: "segments"},
+				GCS: &v1beta1.TieredStorageGCSConfig{Bucket: "segments"},
+			},
+			expectedErrPaths: []string{"spec.tieredStorage.gcs"},
+		},
+		{
+			testName: "S3 secret access key missing",
+			tieredStorage: &v1beta1.TieredStorageConfig{
+				S3: &v1beta1.TieredStorageS3Config{Bucket: "segments", AccessKeyIDSecretRef: secretKey},
+			},
+			expectedErrPaths: []string{"spec.tieredStorage.s3"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkTieredStorage(&v1beta1.KafkaClusterSpec{TieredStorage: test.tieredStorage})
+			var errPaths []string
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.Equal(t, test.expectedErrPaths, errPaths)
+		})
+	}
+}
+
+func TestCheckUniqueBrokerIDs(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		brokers          []v1beta1.Broker
+		expectedErrPaths []string
+	}{
+		{
+			testName: "unique broker ids",
+			brokers:  []v1beta1.Broker{{Id: 0}, {Id: 1}, {Id: 2}},
+		},
+		{
+			testName:         "duplicate broker ids",
+			brokers:          []v1beta1.Broker{{Id: 0}, {Id: 1}, {Id: 0}, {Id: 1}},
+			expectedErrPaths: []string{"spec.brokers[2].id", "spec.brokers[3].id"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		test := testCase
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkUniqueBrokerIDs(&v1beta1.KafkaClusterSpec{Brokers: test.brokers})
+			var errPaths []string
+			for _, err := range errs {
+				require.Equal(t, field.ErrorTypeDuplicate, err.Type)
+				errPaths = append(errPaths, err.Field)
+			}
+			require.Equal(t, test.expectedErrPaths, errPaths)
+		})
+	}
+}
+
+func TestCheckKRaftControllers(t *testing.T) {
+	brokerConfigGroups := map[string]v1beta1.BrokerConfig{
+		"broker":     {Roles: []string{"broker"}},
+		"controller": {Roles: []string{"controller"}},
+	}
+
+	testCases := []struct {
+		testName       string
+		kraftMode      bool
+		kRaftMigration *v1beta1.KRaftMigrationConfig
+		brokers        []v1beta1.Broker
+		expectedErr    bool
+	}{
+		{
+			testName: "ZooKeeper mode",
+			brokers:  []v1beta1.Broker{{Id: 0, BrokerConfigGroup: "broker"}},
+		},
+		{
+			testName:  "KRaft mode with controllers",
+			kraftMode: true,
+			brokers:   []v1beta1.Broker{{Id: 0, BrokerConfigGroup: "broker"}, {Id: 10, BrokerConfigGroup: "controller"}},
+		},
+		{
+			testName:    "KRaft mode without controllers",
+			kraftMode:   true,
+			brokers:     []v1beta1.Broker{{Id: 0, BrokerConfigGroup: "broker"}, {Id: 1, BrokerConfigGroup: "broker"}},
+			expectedErr: true,
+		},
+		{
+			testName:       "migration from ZooKeeper is validated separately",
+			kraftMode:      true,
+			kRaftMigration: &v1beta1.KRaftMigrationConfig{Enabled: true},
+			brokers:        []v1beta1.Broker{{Id: 0, BrokerConfigGroup: "broker"}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		test := testCase
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkKRaftControllers(&v1beta1.KafkaClusterSpec{
+				KRaftMode:          test.kraftMode,
+				KRaftMigration:     test.kRaftMigration,
+				BrokerConfigGroups: brokerConfigGroups,
+				Brokers:            test.brokers,
+			})
+			if !test.expectedErr {
+				require.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			require.Equal(t, "spec.brokers", errs[0].Field)
+			require.Contains(t, errs[0].Detail, missingKRaftControllerErrMsg)
+		})
+	}
+}
+
+func TestCheckStorageMountPaths(t *testing.T) {
+	testCases := []struct {
+		testName           string
+		brokerConfigGroups map[string]v1beta1.BrokerConfig
+		brokers            []v1beta1.Broker
+		expectedErrPaths   []string
+	}{
+		{
+			testName: "distinct mount paths",
+			brokerConfigGroups: map[string]v1beta1.BrokerConfig{
+				"default": {StorageConfigs: []v1beta1.StorageConfig{{MountPath: "/kafka-logs"}}},
+			},
+			brokers: []v1beta1.Broker{{
+				Id:                0,
+				BrokerConfigGroup: "default",
+				BrokerConfig: &v1beta1.BrokerConfig{
+					StorageConfigs: []v1beta1.StorageConfig{{MountPath: "/kafka-logs-2"}},
+					VolumeMounts:   []corev1.VolumeMount{{Name: "extra", MountPath: "/extra"}},
+				},
+			}},
+		},
+		{
+			testName: "duplicate mount paths of a broker config group and of a broker",
+			brokerConfigGroups: map[string]v1beta1.BrokerConfig{
+				"default": {StorageConfigs: []v1beta1.StorageConfig{{MountPath: "/kafka-logs"}, {MountPath: "/kafka-logs"}}},
+			},
+			brokers: []v1beta1.Broker{{
+				Id: 0,
+				BrokerConfig: &v1beta1.BrokerConfig{
+					StorageConfigs: []v1beta1.StorageConfig{{MountPath: "/kafka-logs"}, {MountPath: "/kafka-logs-2"}, {MountPath: "/kafka-logs"}},
+				},
+			}},
+			expectedErrPaths: []string{
+				"spec.brokerConfigGroups[default].storageConfigs[1].mountPath",
+				"spec.brokers[0].brokerConfig.storageConfigs[2].mountPath",
+			},
+		},
+		{
+			testName: "volume mount on the mount path of a storage",
+			brokerConfigGroups: map[string]v1beta1.BrokerConfig{
+				"default": {StorageConfigs: []v1beta1.StorageConfig{{MountPath: "/kafka-logs"}}},
+			},
+			brokers: []v1beta1.Broker{{
+				Id:                0,
+				BrokerConfigGroup: "default",
+				BrokerConfig: &v1beta1.BrokerConfig{
+					VolumeMounts: []corev1.VolumeMount{{Name: "extra", MountPath: "/kafka-logs"}},
+				},
+			}},
+			expectedErrPaths: []string{"spec.brokers[0]"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		test := testCase
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkStorageMountPaths(&v1beta1.KafkaClusterSpec{
+				BrokerConfigGroups: test.brokerConfigGroups,
+				Brokers:            test.brokers,
+			})
+			var errPaths []string
+			for _, err := range errs {
+				errPaths = append(errPaths, err.Field)
+			}
+			require.Equal(t, test.expectedErrPaths, errPaths)
+		})
+	}
+}
+
+func TestCheckDisruptionBudget(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		disruptionBudget v1beta1.DisruptionBudget
+		expectedErr      bool
+	}{
+		{
+			testName: "disruption budget not created",
+		},
+		{
+			testName:         "static budget",
+			disruptionBudget: v1beta1.DisruptionBudget{Create: true, Budget: "1"},
+		},
+		{
+			testName:         "percentage budget",
+			disruptionBudget: v1beta1.DisruptionBudget{Create: true, Budget: "20%"},
+		},
+		{
+			testName:         "missing budget",
+			disruptionBudget: v1beta1.DisruptionBudget{Create: true},
+			expectedErr:      true,
+		},
+		{
+			testName:         "percentage above 100%",
+			disruptionBudget: v1beta1.DisruptionBudget{Create: true, Budget: "150%"},
+			expectedErr:      true,
+		},
+		{
+			testName:         "invalid budget",
+			disruptionBudget: v1beta1.DisruptionBudget{Budget: "one"},
+			expectedErr:      true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		test := testCase
+		t.Run(test.testName, func(t *testing.T) {
+			errs := checkDisruptionBudget(&v1beta1.KafkaClusterSpec{DisruptionBudget: test.disruptionBudget})
+			if !test.expectedErr {
+				require.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			require.Equal(t, "spec.disruptionBudget.budget", errs[0].Field)
+			require.Contains(t, errs[0].Detail, invalidDisruptionBudgetErrMsg)
+		})
+	}
+}
@@ -18,6 +18,7 @@ package webhooks
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -39,6 +40,8 @@ import (
 	"github.com/banzaicloud/koperator/pkg/k8sutil"
 	"github.com/banzaicloud/koperator/pkg/kafkaclient"
 	"github.com/banzaicloud/koperator/pkg/util"
+	kafkautil "github.com/banzaicloud/koperator/pkg/util/kafka"
+	"github.com/banzaicloud/koperator/pkg/util/ownership"
 )
 
 const (
@@ -130,6 +133,14 @@ func (s *KafkaTopicValidator) validateKafkaTopic(ctx context.Context, log logr.L
 		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("clusterRef").Child("name"), clusterName, logMsg))
 	}
 
+	fieldErrList, err := s.checkOwnership(ctx, topic)
+	if err != nil {
+		return nil, err
+	}
+	allErrs = append(allErrs, fieldErrList...)
+
+	allErrs = append(allErrs, checkRemoteStorage(topic, cluster)...)
+
 	fieldErr, err := s.checkExistingKafkaTopicCRs(ctx, clusterNamespace, topic)
 	if err != nil {
 		return nil, err
@@ -138,7 +149,7 @@ func (s *KafkaTopicValidator) validateKafkaTopic(ctx context.Context, log logr.L
 		allErrs = append(allErrs, fieldErr)
 	}
 
-	fieldErrList, err := s.checkKafka(ctx, topic, cluster)
+	fieldErrList, err = s.checkKafka(ctx, topic, cluster)
 	if err != nil {
 		return nil, err
 	}
@@ -148,6 +159,54 @@ func (s *KafkaTopicValidator) validateKafkaTopic(ctx context.Context, log logr.L
 	return allErrs, nil
 }
 
+// checkRemoteStorage checks that the tiered storage is only enabled for the topic when it is enabled on the brokers,
+// and that it is not enabled for a compacted topic as Kafka does not support it
+func checkRemoteStorage(topic *banzaicloudv1alpha1.KafkaTopic, cluster *banzaicloudv1beta1.KafkaCluster) field.ErrorList {
+	value, ok := topic.Spec.Config[kafkautil.TopicConfigRemoteStorageEnable]
+	if !ok {
+		return nil
+	}
+	path := field.NewPath("spec").Child("config").Key(kafkautil.TopicConfigRemoteStorageEnable)
+	value = strings.TrimSpace(value)
+	if !strings.EqualFold(value, "true") && !strings.EqualFold(value, "false") {
+		return field.ErrorList{field.Invalid(path, value, invalidTopicRemoteStorageErrMsg+": the value must be true or false")}
+	}
+	if strings.EqualFold(value, "false") {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	if !slices.Contains(kafkautil.EnabledFeatures(cluster.Spec), kafkautil.FeatureTieredStorage) {
+		allErrs = append(allErrs, field.Invalid(path, value,
+			invalidTopicRemoteStorageErrMsg+": the tiered storage is not enabled on the kafka cluster"))
+	}
+	if strings.Contains(topic.Spec.Config[kafkautil.TopicConfigCleanupPolicy], "compact") {
+		allErrs = append(allErrs, field.Invalid(path, value,
+			invalidTopicRemoteStorageErrMsg+": the tiered storage can not be enabled for compacted topics"))
+	}
+	return allErrs
+}
+
+// checkOwnership checks that the topic carries the team label of the namespace and its name starts with one of the
+// topic prefixes owned by the team
+func (s *KafkaTopicValidator) checkOwnership(ctx context.Context, topic *banzaicloudv1alpha1.KafkaTopic) (field.ErrorList, error) {
+	scope, err := ownership.ScopeOf(ctx, s.Client, topic.GetNamespace())
+	if err != nil {
+		return nil, errors.Wrap(err, cantConnectAPIServerMsg)
+	}
+
+	var allErrs field.ErrorList
+	if err := scope.CheckOwner(topic); err != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("metadata").Child("labels").Key(banzaicloudv1alpha1.TeamLabelKey),
+			fmt.Sprintf("%s: %s", notOwnedByTeamErrMsg, err)))
+	}
+	if err := scope.CheckTopic(topic.Spec.Name, banzaicloudv1alpha1.KafkaPatternTypeLiteral); err != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("name"),
+			fmt.Sprintf("%s: %s", notOwnedByTeamErrMsg, err)))
+	}
+	return allErrs, nil
+}
+
 // checkKafka creates a Kafka admin client and connects to the Kafka brokers to check
 // whether the referred topic exists, and what are its properties
 func (s *KafkaTopicValidator) checkKafka(ctx context.Context, topic *banzaicloudv1alpha1.KafkaTopic,
@@ -170,9 +229,12 @@ func (s *KafkaTopicValidator) checkKafka(ctx context.Context, topic *banzaicloud
 	if existing != nil {
 		// Check if this is the correct CR for this topic
 		topicCR := &banzaicloudv1alpha1.KafkaTopic{}
+		// the topics of a cluster adopting the existing topics reconcile only the explicitly set spec fields, so their
+		// spec may differ from the existing topic
+		adopting := cluster.Spec.AdoptExistingTopics
 		if err := s.Client.Get(ctx, types.NamespacedName{Name: topic.Name, Namespace: topic.Namespace}, topicCR); err != nil {
 			// Checking that the validation request is update
-			if apierrors.IsNotFound(err) {
+			if apierrors.IsNotFound(err) && !adopting {
 				if manager, ok := topic.GetAnnotations()[TopicManagedByAnnotationKey]; !ok || strings.ToLower(manager) != TopicManagedByKoperatorAnnotationValue {
 					allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("name"), topic.Spec.Name,
 						fmt.Sprintf(`topic "%s" already exists on kafka cluster and it is not managed by Koperator,
@@ -198,21 +260,29 @@ func (s *KafkaTopicValidator) checkKafka(ctx context.Context, topic *banzaicloud
 				if len(allErrs) > 0 {
 					return allErrs, nil
 				}
-			} else {
+			} else if !apierrors.IsNotFound(err) {
 				return nil, errors.WrapIff(err, cantConnectAPIServerMsg)
 			}
+		} else {
+			adopting = topicCR.Status.Adoption != nil || (adopting && topicCR.Status.State != banzaicloudv1alpha1.TopicStateCreated)
 		}
 
-		// make sure the user isn't trying to decrease partition count
-		if existing.NumPartitions > topic.Spec.Partitions {
+		// make sure the user isn't trying to decrease partition count, the partition count of adopted topics is
+		// only ever increased
+		if existing.NumPartitions > topic.Spec.Partitions && !adopting {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("partitions"), topic.Spec.Partitions,
 				fmt.Sprintf("kafka does not support decreasing partition count on an existing topic (from %v to %v)", existing.NumPartitions, topic.Spec.Partitions)))
 		}
 
-		// check if the user is trying to change the replication factor
-		if existing.ReplicationFactor != int16(topic.Spec.ReplicationFactor) {
-			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("replicationFactor"), topic.Spec.ReplicationFactor,
-				fmt.Sprintf("kafka does not support changing the replication factor on an existing topic (from %v to %v)", existing.ReplicationFactor, topic.Spec.ReplicationFactor)))
+		// the replication factor of an existing topic is changed by reassigning its replicas
+		if topic.Spec.ReplicationFactor > 0 && existing.ReplicationFactor != int16(topic.Spec.ReplicationFactor) {
+			if int(topic.Spec.ReplicationFactor) > broker.NumBrokers() {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("replicationFactor"), topic.Spec.ReplicationFactor,
+					fmt.Sprintf("%s (available brokers: %v)", invalidReplicationFactorErrMsg, broker.NumBrokers())))
+			} else if reassignment := topicCR.Status.Reassignment; reassignment.IsInProgress() && reassignment.TargetReplicationFactor != topic.Spec.ReplicationFactor {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("replicationFactor"), topic.Spec.ReplicationFactor,
+					fmt.Sprintf("%s (target replication factor: %v)", reassignmentInProgressErrMsg, reassignment.TargetReplicationFactor)))
+			}
 		}
 
 		// the topic does not exist check if requesting a replication factor larger than the broker size
@@ -20,6 +20,7 @@ import (
 	"strings"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -69,6 +70,7 @@ func newMockTopic() *v1alpha1.KafkaTopic {
 
 func newMockClients(cluster *v1beta1.KafkaCluster) (runtimeClient.WithWatch, kafkaclient.KafkaClient, func(client runtimeClient.Client, cluster *v1beta1.KafkaCluster) (kafkaclient.KafkaClient, func(), error)) {
 	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
 	_ = v1beta1.AddToScheme(scheme)
 	_ = v1alpha1.AddToScheme(scheme)
 
@@ -227,6 +229,40 @@ func TestCheckKafkaTopicExist(t *testing.T) {
 	}
 }
 
+func TestCheckKafkaTopicAdopt(t *testing.T) {
+	cluster := newMockCluster()
+	cluster.Spec.AdoptExistingTopics = true
+	client, kafkaClient, returnMockedKafkaClient := newMockClients(cluster)
+
+	kafkaTopicValidator := KafkaTopicValidator{
+		Client:              client,
+		NewKafkaFromCluster: returnMockedKafkaClient,
+	}
+
+	err := kafkaClient.CreateTopic(&kafkaclient.CreateTopicOptions{Name: "test-topic", ReplicationFactor: 1, Partitions: 2, Config: util.MapStringStringPointer(map[string]string{"testConfKey": "testConfVal"})})
+	if err != nil {
+		t.Error("creation of topic should have been successful")
+	}
+
+	// the existing topic is adopted with a spec differing from it and without the managedBy annotation
+	topic := &v1alpha1.KafkaTopic{
+		Spec: v1alpha1.KafkaTopicSpec{
+			Name:              "test-topic",
+			Partitions:        1,
+			ReplicationFactor: -1,
+			Config:            map[string]string{"retention.ms": "3600000"},
+			ClusterRef:        v1alpha1.ClusterReference{},
+		},
+	}
+	fieldErrorList, err := kafkaTopicValidator.checkKafka(context.Background(), topic, cluster)
+	if err != nil {
+		t.Errorf("err should be nil, got: %s", err)
+	}
+	if len(fieldErrorList) != 0 {
+		t.Errorf("Expected adoption of the existing topic to be allowed, got: %s", fieldErrorList.ToAggregate().Error())
+	}
+}
+
 func TestValidateTopic(t *testing.T) {
 	topic := newMockTopic()
 	cluster := newMockCluster()
@@ -347,7 +383,7 @@ func TestValidateTopic(t *testing.T) {
 		t.Error("Expected not allowed for reason: kafka does not support decreasing partition count")
 	}
 
-	// replication factor change attempt
+	// replication factor increase beyond the number of brokers
 	topic.Spec.Partitions = 2
 	topic.Spec.ReplicationFactor = 2
 	fieldErrorList, err = kafkaTopicValidator.validateKafkaTopic(context.Background(), logr.Discard(), topic)
@@ -355,8 +391,150 @@ func TestValidateTopic(t *testing.T) {
 		t.Errorf("err should be nil, got: %s", err)
 	}
 	if len(fieldErrorList) != 1 {
-		t.Error("Expected not allowed due to replication factor change, got allowed")
-	} else if !strings.Contains(fieldErrorList.ToAggregate().Error(), "kafka does not support changing the replication factor") {
-		t.Error("Expected not allowed for reason: kafka does not support changing the replication factor")
+		t.Error("Expected not allowed due to replication factor larger than num brokers, got allowed")
+	} else if !strings.Contains(fieldErrorList.ToAggregate().Error(), invalidReplicationFactorErrMsg) {
+		t.Errorf("Expected not allowed for reason: %s", invalidReplicationFactorErrMsg)
+	}
+}
+
+func TestCheckOwnership(t *testing.T) {
+	client, _, _ := newMockClients(newMockCluster())
+	for _, ns := range []*corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "payments", Annotations: map[string]string{
+			v1alpha1.NamespaceTeamAnnotationKey:          "payments",
+			v1alpha1.NamespaceTopicPrefixesAnnotationKey: "payments.",
+		}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-namespace"}},
+	} {
+		if err := client.Create(context.Background(), ns); err != nil {
+			t.Fatal("Expected no error, got:", err)
+		}
+	}
+
+	kafkaTopicValidator := KafkaTopicValidator{Client: client}
+
+	testCases := []struct {
+		testName           string
+		namespace          string
+		teamLabel          string
+		topicName          string
+		expectedFieldPaths []string
+	}{
+		{
+			testName:  "topic of the team",
+			namespace: "payments",
+			teamLabel: "payments",
+			topicName: "payments.orders",
+		},
+		{
+			testName:           "topic without the team label",
+			namespace:          "payments",
+			topicName:          "payments.orders",
+			expectedFieldPaths: []string{"metadata.labels[kafka.banzaicloud.io/team]"},
+		},
+		{
+			testName:           "topic outside of the topic prefixes of the team",
+			namespace:          "payments",
+			teamLabel:          "payments",
+			topicName:          "shipping.orders",
+			expectedFieldPaths: []string{"spec.name"},
+		},
+		{
+			testName:           "topic of a team in a namespace not owned by any team",
+			namespace:          "test-namespace",
+			teamLabel:          "payments",
+			topicName:          "payments.orders",
+			expectedFieldPaths: []string{"metadata.labels[kafka.banzaicloud.io/team]", "spec.name"},
+		},
+		{
+			testName:  "unowned topic in a namespace not owned by any team",
+			namespace: "test-namespace",
+			topicName: "test-topic",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			topic := newMockTopic()
+			topic.SetNamespace(test.namespace)
+			topic.Spec.Name = test.topicName
+			if test.teamLabel != "" {
+				topic.SetLabels(map[string]string{v1alpha1.TeamLabelKey: test.teamLabel})
+			}
+
+			fieldErrs, err := kafkaTopicValidator.checkOwnership(context.Background(), topic)
+			if err != nil {
+				t.Fatal("Expected no error, got:", err)
+			}
+			var fieldPaths []string
+			for _, fieldErr := range fieldErrs {
+				if !strings.Contains(fieldErr.Error(), notOwnedByTeamErrMsg) {
+					t.Errorf("Expected not allowed for reason: %s", notOwnedByTeamErrMsg)
+				}
+				fieldPaths = append(fieldPaths, fieldErr.Field)
+			}
+			if strings.Join(fieldPaths, ",") != strings.Join(test.expectedFieldPaths, ",") {
+				t.Errorf("Expected invalid fields %v, got %v", test.expectedFieldPaths, fieldPaths)
+			}
+		})
+	}
+}
+
+func TestCheckRemoteStorage(t *testing.T) {
+	testCases := []struct {
+		testName           string
+		tieredStorage      *v1beta1.TieredStorageConfig
+		config             map[string]string
+		expectedFieldPaths []string
+	}{
+		{
+			testName: "remote storage not configured",
+		},
+		{
+			testName:      "remote storage enabled on a tiered storage cluster",
+			tieredStorage: &v1beta1.TieredStorageConfig{},
+			config:        map[string]string{"remote.storage.enable": "true"},
+		},
+		{
+			testName: "remote storage disabled",
+			config:   map[string]string{"remote.storage.enable": "false"},
+		},
+		{
+			testName:           "invalid remote storage value",
+			tieredStorage:      &v1beta1.TieredStorageConfig{},
+			config:             map[string]string{"remote.storage.enable": "yes"},
+			expectedFieldPaths: []string{"spec.config[remote.storage.enable]"},
+		},
+		{
+			testName:           "remote storage enabled without tiered storage",
+			config:             map[string]string{"remote.storage.enable": "true"},
+			expectedFieldPaths: []string{"spec.config[remote.storage.enable]"},
+		},
+		{
+			testName:           "remote storage enabled for a compacted topic",
+			tieredStorage:      &v1beta1.TieredStorageConfig{},
+			config:             map[string]string{"remote.storage.enable": "true", "cleanup.policy": "compact,delete"},
+			expectedFieldPaths: []string{"spec.config[remote.storage.enable]"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.testName, func(t *testing.T) {
+			topic := newMockTopic()
+			topic.Spec.Config = test.config
+			cluster := newMockCluster()
+			cluster.Spec.TieredStorage = test.tieredStorage
+
+			var fieldPaths []string
+			for _, fieldErr := range checkRemoteStorage(topic, cluster) {
+				if !strings.Contains(fieldErr.Error(), invalidTopicRemoteStorageErrMsg) {
+					t.Errorf("Expected invalid for reason: %s", invalidTopicRemoteStorageErrMsg)
+				}
+				fieldPaths = append(fieldPaths, fieldErr.Field)
+			}
+			if strings.Join(fieldPaths, ",") != strings.Join(test.expectedFieldPaths, ",") {
+				t.Errorf("Expected invalid fields %v, got %v", test.expectedFieldPaths, fieldPaths)
+			}
+		})
 	}
 }